@@ -0,0 +1,332 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/golobby/container/v3"
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/readpref"
+
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	email_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/email/entities"
+	iam_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/iam/entities"
+	iam_in "github.com/psavelis/team-pro/replay-api/pkg/domain/iam/ports/in"
+	iam_out "github.com/psavelis/team-pro/replay-api/pkg/domain/iam/ports/out"
+	"github.com/psavelis/team-pro/replay-api/pkg/infra/blob/s3"
+	db "github.com/psavelis/team-pro/replay-api/pkg/infra/db/mongodb"
+	"github.com/psavelis/team-pro/replay-api/pkg/infra/events/rabbitmq"
+	ioc "github.com/psavelis/team-pro/replay-api/pkg/infra/ioc"
+)
+
+const bootstrapUsage = `usage:
+  cli bootstrap -admin-email <email> [-admin-name <name>] [-skip-indexes] [-timeout <duration>]
+
+provisions a self-hosted deployment (safe to run again: existing indexes, users and roles are kept):
+  - verifies connectivity to mongodb, rabbitmq and the replay storage backend
+  - creates the indexes of the collections
+  - onboards the admin user (signing in through a magic link to the email) with the "` + bootstrapAdminRole + `" role
+
+the tenant and client application are built in (not provisioned), they're listed in the report.`
+
+// bootstrapAdminRole grants every permission to the admin user.
+const bootstrapAdminRole = "Administrator"
+
+type bootstrapStep struct {
+	Name   string
+	Status string // ok, skipped or failed
+	Detail string
+}
+
+type bootstrapReport struct {
+	steps  []bootstrapStep
+	failed bool
+}
+
+func (r *bootstrapReport) ok(name, detail string) {
+	r.steps = append(r.steps, bootstrapStep{Name: name, Status: "ok", Detail: detail})
+}
+
+func (r *bootstrapReport) skip(name, detail string) {
+	r.steps = append(r.steps, bootstrapStep{Name: name, Status: "skipped", Detail: detail})
+}
+
+func (r *bootstrapReport) fail(name string, err error) {
+	r.steps = append(r.steps, bootstrapStep{Name: name, Status: "failed", Detail: err.Error()})
+	r.failed = true
+}
+
+func (r *bootstrapReport) print() {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "STEP\tSTATUS\tDETAIL")
+
+	for _, step := range r.steps {
+		fmt.Fprintf(w, "%s\t%s\t%s\n", step.Name, step.Status, step.Detail)
+	}
+
+	w.Flush()
+}
+
+func bootstrapCommand(ctx context.Context, args []string) error {
+	flags := flag.NewFlagSet("bootstrap", flag.ContinueOnError)
+	flags.Usage = func() {
+		fmt.Fprintln(os.Stderr, bootstrapUsage)
+		fmt.Fprintln(os.Stderr, "")
+		flags.PrintDefaults()
+	}
+
+	adminEmail := flags.String("admin-email", "", "email of the admin user (required)")
+	adminName := flags.String("admin-name", "", "name of the admin user (default: the local part of the email)")
+	skipIndexes := flags.Bool("skip-indexes", false, "don't create the indexes of the collections")
+	timeout := flags.Duration("timeout", 10*time.Second, "timeout of each connectivity check")
+
+	err := flags.Parse(args)
+	if err != nil {
+		return err
+	}
+
+	email, err := email_entities.NormalizeEmail(*adminEmail)
+	if err != nil {
+		flags.Usage()
+		return fmt.Errorf("-admin-email: %w", err)
+	}
+
+	name := strings.TrimSpace(*adminName)
+	if name == "" {
+		name = email[:strings.LastIndex(email, "@")]
+	}
+
+	builder := ioc.NewContainerBuilder()
+
+	c := builder.WithEnvFile().With(ioc.InjectMongoDB).WithInboundPorts().Build()
+
+	defer builder.Close(c)
+
+	var config common.Config
+	err = c.Resolve(&config)
+	if err != nil {
+		return err
+	}
+
+	// the admin is onboarded as a new user of the application (as on a first sign in)
+	ctx = common.WithRequestScope(ctx, common.RequestScope{
+		ResourceOwner: common.ResourceOwner{
+			TenantID: common.TeamPROTenantID,
+			ClientID: common.TeamPROAppClientID,
+			GroupID:  uuid.New(),
+			UserID:   uuid.New(),
+		},
+	})
+
+	report := &bootstrapReport{}
+
+	report.ok("tenant", common.TeamPROTenantID.String())
+	report.ok("client", fmt.Sprintf("%s (server: %s)", common.TeamPROAppClientID, common.ServerClientID))
+
+	mongoReady := bootstrapMongoDB(ctx, c, config, *timeout, report)
+
+	bootstrapRabbitMQ(config, report)
+
+	// only the async api POC publishes to kafka, the services exchange events through rabbitmq
+	report.skip("kafka", "not used by this deployment (events go through rabbitmq)")
+
+	bootstrapReplayStorage(ctx, c, config, *timeout, mongoReady, report)
+
+	switch {
+	case !mongoReady:
+		report.skip("indexes", "mongodb unreachable")
+	case *skipIndexes:
+		report.skip("indexes", "-skip-indexes")
+	default:
+		bootstrapIndexes(ctx, c, config, report)
+	}
+
+	report.skip("ledger", "no ledger in this deployment (no system accounts to initialize)")
+
+	if mongoReady {
+		bootstrapAdmin(ctx, c, name, email, report)
+	} else {
+		report.skip("admin", "mongodb unreachable")
+	}
+
+	report.print()
+
+	if report.failed {
+		return fmt.Errorf("bootstrap failed, see the report above (it's safe to run again)")
+	}
+
+	fmt.Printf("\nbootstrap completed: sign in as %s through a magic link\n", email)
+
+	return nil
+}
+
+func bootstrapMongoDB(ctx context.Context, c container.Container, config common.Config, timeout time.Duration, report *bootstrapReport) bool {
+	var client *mongo.Client
+	err := c.Resolve(&client)
+	if err != nil {
+		report.fail("mongodb", err)
+		return false
+	}
+
+	pingCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	err = client.Ping(pingCtx, readpref.Primary())
+	if err != nil {
+		report.fail("mongodb", err)
+		return false
+	}
+
+	report.ok("mongodb", "database "+config.MongoDB.DBName)
+
+	return true
+}
+
+func bootstrapRabbitMQ(config common.Config, report *bootstrapReport) {
+	if config.RabbitMQ.URL == "" {
+		report.skip("rabbitmq", "RABBITMQ_URL unset (replays are processed in-request)")
+		return
+	}
+
+	err := rabbitmq.Ping(config.RabbitMQ.URL)
+	if err != nil {
+		report.fail("rabbitmq", err)
+		return
+	}
+
+	report.ok("rabbitmq", "connected")
+}
+
+func bootstrapReplayStorage(ctx context.Context, c container.Container, config common.Config, timeout time.Duration, mongoReady bool, report *bootstrapReport) {
+	if config.ReplayStorage.Backend != s3.BackendName {
+		if !mongoReady {
+			report.skip("replay storage", "gridfs (mongodb unreachable)")
+			return
+		}
+
+		report.ok("replay storage", "gridfs (mongodb)")
+		return
+	}
+
+	var adapter *s3.S3Adapter
+	err := c.Resolve(&adapter)
+	if err != nil {
+		report.fail("replay storage", err)
+		return
+	}
+
+	pingCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	err = adapter.Ping(pingCtx)
+	if err != nil {
+		report.fail("replay storage", fmt.Errorf("s3 bucket %s: %w", adapter.Bucket, err))
+		return
+	}
+
+	report.ok("replay storage", "s3 bucket "+adapter.Bucket)
+}
+
+func bootstrapIndexes(ctx context.Context, c container.Container, config common.Config, report *bootstrapReport) {
+	var client *mongo.Client
+	err := c.Resolve(&client)
+	if err != nil {
+		report.fail("indexes", err)
+		return
+	}
+
+	results, err := db.RunIndexMigrations(ctx, client, config.MongoDB.DBName, db.DefaultIndexMigrations)
+	if err != nil {
+		report.fail("indexes", err)
+		return
+	}
+
+	for _, result := range results {
+		report.ok("indexes", fmt.Sprintf("%s: %s", result.Collection, strings.Join(result.Indexes, ", ")))
+	}
+}
+
+func bootstrapAdmin(ctx context.Context, c container.Container, name, email string, report *bootstrapReport) {
+	var onboard iam_in.OnboardOpenIDUserCommandHandler
+	err := c.Resolve(&onboard)
+	if err != nil {
+		report.fail("admin", err)
+		return
+	}
+
+	// an existing profile of the email is reused
+	profile, _, err := onboard.Exec(ctx, iam_in.OnboardOpenIDUserCommand{
+		Name:           name,
+		Source:         iam_entities.RIDSource_Email,
+		Key:            email,
+		ProfileDetails: map[string]interface{}{"email": email, "email_verified": true},
+	})
+
+	if err != nil {
+		report.fail("admin", err)
+		return
+	}
+
+	userID := profile.ResourceOwner.UserID
+	report.ok("admin", fmt.Sprintf("%s (user %s)", email, userID))
+
+	role, err := bootstrapAdminRoleOf(ctx, c)
+	if err != nil {
+		report.fail("admin role", err)
+		return
+	}
+
+	var assign iam_in.AssignRoleCommandHandler
+	err = c.Resolve(&assign)
+	if err != nil {
+		report.fail("admin role", err)
+		return
+	}
+
+	_, err = assign.Exec(ctx, iam_in.RoleAssignmentCommand{RoleID: role.ID, UserID: userID})
+	if err != nil {
+		report.fail("admin role", err)
+		return
+	}
+
+	report.ok("admin role", fmt.Sprintf("%s (role %s)", role.Name, role.ID))
+}
+
+// bootstrapAdminRoleOf returns the admin role of the tenant, creating it on the first run.
+func bootstrapAdminRoleOf(ctx context.Context, c container.Container) (*iam_entities.Role, error) {
+	var roleReader iam_out.RoleReader
+	err := c.Resolve(&roleReader)
+	if err != nil {
+		return nil, err
+	}
+
+	roles, err := roleReader.Search(ctx, common.NewSearchByValues(ctx, []common.SearchableValue{
+		{Field: "Name", Values: []interface{}{bootstrapAdminRole}},
+	}, common.NewSearchResultOptions(0, 1), common.ClientApplicationAudienceIDKey))
+
+	if err != nil {
+		return nil, err
+	}
+
+	if len(roles) > 0 {
+		return &roles[0], nil
+	}
+
+	var createRole iam_in.CreateRoleCommandHandler
+	err = c.Resolve(&createRole)
+	if err != nil {
+		return nil, err
+	}
+
+	return createRole.Exec(ctx, iam_in.CreateRoleCommand{
+		Name:        bootstrapAdminRole,
+		Description: "Every permission, granted to the admin user by the bootstrap command",
+		Permissions: []iam_entities.Permission{iam_entities.PermissionAll},
+	})
+}
@@ -0,0 +1,254 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/mongo"
+
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	db "github.com/psavelis/team-pro/replay-api/pkg/infra/db/mongodb"
+	ioc "github.com/psavelis/team-pro/replay-api/pkg/infra/ioc"
+)
+
+const integrityUsage = `usage:
+  cli integrity scan   [-rule <name>] [-tenant <id>] [-limit <n>]
+  cli integrity repair  -rule <name> -action relink|tombstone|delete [-target <id>] [-tenant <id>] [-limit <n>] [-apply]
+
+repair runs in dry-run mode (prints the planned changes) unless -apply is given.
+tombstoned documents are moved to the "` + db.IntegrityTombstonesCollection + `" collection.
+
+rules:`
+
+func integrityCommand(ctx context.Context, args []string) error {
+	if len(args) < 1 {
+		integrityHelp()
+		return fmt.Errorf("missing integrity subcommand")
+	}
+
+	switch args[0] {
+	case "scan":
+		return integrityScan(ctx, args[1:])
+	case "repair":
+		return integrityRepair(ctx, args[1:])
+	default:
+		integrityHelp()
+		return fmt.Errorf("unknown integrity subcommand %q", args[0])
+	}
+}
+
+func integrityHelp() {
+	fmt.Fprintln(os.Stderr, integrityUsage)
+	for _, rule := range db.DefaultReferenceRules {
+		fmt.Fprintf(os.Stderr, "  %-18s %s.%s -> %s.%s\n", rule.Name, rule.Collection, rule.LocalField, rule.ForeignCollection, rule.ForeignField)
+	}
+}
+
+func newIntegrityRepository() (*db.IntegrityRepository, func(), error) {
+	builder := ioc.NewContainerBuilder()
+
+	c := builder.WithEnvFile().With(ioc.InjectMongoDB).Build()
+
+	var config common.Config
+	err := c.Resolve(&config)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var client *mongo.Client
+	err = c.Resolve(&client)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return db.NewIntegrityRepository(client, config.MongoDB.DBName), func() { builder.Close(c) }, nil
+}
+
+func parseTenant(tenant string) (uuid.UUID, error) {
+	if tenant == "" {
+		return uuid.Nil, nil
+	}
+
+	return uuid.Parse(tenant)
+}
+
+func integrityScan(ctx context.Context, args []string) error {
+	flags := flag.NewFlagSet("integrity scan", flag.ContinueOnError)
+	ruleName := flags.String("rule", "", "only scan the given rule (default: all)")
+	tenant := flags.String("tenant", "", "only scan documents of the given tenant_id")
+	limit := flags.Int64("limit", 100, "max dangling references listed per rule (0 = unlimited)")
+
+	err := flags.Parse(args)
+	if err != nil {
+		return err
+	}
+
+	tenantID, err := parseTenant(*tenant)
+	if err != nil {
+		return fmt.Errorf("invalid tenant: %w", err)
+	}
+
+	rules := db.DefaultReferenceRules
+	if *ruleName != "" {
+		rule, err := db.GetReferenceRule(*ruleName)
+		if err != nil {
+			return err
+		}
+
+		rules = []db.ReferenceRule{rule}
+	}
+
+	repo, closeFn, err := newIntegrityRepository()
+	if err != nil {
+		return err
+	}
+
+	defer closeFn()
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "RULE\tCOLLECTION\tDOCUMENT\tMISSING REFERENCE\tTENANT")
+
+	total := 0
+	for _, rule := range rules {
+		dangling, err := repo.FindDangling(ctx, rule, tenantID, *limit)
+		if err != nil {
+			return err
+		}
+
+		for _, d := range dangling {
+			fmt.Fprintf(w, "%s\t%s\t%v\t%s.%s=%v\t%s\n", rule.Name, rule.Collection, d.DocumentID, rule.ForeignCollection, rule.ForeignField, d.LocalValue, d.TenantID)
+		}
+
+		total += len(dangling)
+	}
+
+	w.Flush()
+
+	fmt.Printf("\n%d dangling reference(s) found\n", total)
+
+	return nil
+}
+
+func integrityRepair(ctx context.Context, args []string) error {
+	flags := flag.NewFlagSet("integrity repair", flag.ContinueOnError)
+	ruleName := flags.String("rule", "", "rule to repair (required)")
+	action := flags.String("action", "", "repair action: relink, tombstone or delete (required)")
+	target := flags.String("target", "", "relink target id (required for relink)")
+	tenant := flags.String("tenant", "", "only repair documents of the given tenant_id")
+	limit := flags.Int64("limit", 100, "max documents repaired (0 = unlimited)")
+	apply := flags.Bool("apply", false, "apply the changes (default: dry-run)")
+
+	err := flags.Parse(args)
+	if err != nil {
+		return err
+	}
+
+	rule, err := db.GetReferenceRule(*ruleName)
+	if err != nil {
+		return err
+	}
+
+	tenantID, err := parseTenant(*tenant)
+	if err != nil {
+		return fmt.Errorf("invalid tenant: %w", err)
+	}
+
+	var targetID uuid.UUID
+	switch *action {
+	case "relink":
+		targetID, err = uuid.Parse(*target)
+		if err != nil {
+			return fmt.Errorf("relink requires a valid -target: %w", err)
+		}
+	case "tombstone", "delete":
+	default:
+		return fmt.Errorf("invalid action %q (expected relink, tombstone or delete)", *action)
+	}
+
+	repo, closeFn, err := newIntegrityRepository()
+	if err != nil {
+		return err
+	}
+
+	defer closeFn()
+
+	dangling, err := repo.FindDangling(ctx, rule, tenantID, *limit)
+	if err != nil {
+		return err
+	}
+
+	// a reference is only relinked to a target of the same tenant and client
+	if *action == "relink" && len(dangling) > 0 {
+		owner, err := repo.GetRelinkTarget(ctx, rule, targetID)
+		if err != nil {
+			return err
+		}
+
+		if owner == nil {
+			return fmt.Errorf("relink target %s not found in %s.%s", targetID, rule.ForeignCollection, rule.ForeignField)
+		}
+
+		dangling = sameOwner(dangling, *owner)
+	}
+
+	if len(dangling) == 0 {
+		fmt.Printf("no dangling references for rule %s\n", rule.Name)
+		return nil
+	}
+
+	ids := make([]interface{}, len(dangling))
+	for i, d := range dangling {
+		ids[i] = d.DocumentID
+
+		switch *action {
+		case "relink":
+			fmt.Printf("relink    %s %v: %s %v -> %s\n", rule.Collection, d.DocumentID, rule.LocalField, d.LocalValue, targetID)
+		case "tombstone":
+			fmt.Printf("tombstone %s %v (%s %v)\n", rule.Collection, d.DocumentID, rule.LocalField, d.LocalValue)
+		case "delete":
+			fmt.Printf("delete    %s %v (%s %v)\n", rule.Collection, d.DocumentID, rule.LocalField, d.LocalValue)
+		}
+	}
+
+	if !*apply {
+		fmt.Printf("\ndry-run: %d document(s) would be affected. re-run with -apply to %s them.\n", len(ids), *action)
+		return nil
+	}
+
+	var affected int64
+	switch *action {
+	case "relink":
+		affected, err = repo.Relink(ctx, rule, ids, targetID)
+	case "tombstone":
+		affected, err = repo.Tombstone(ctx, rule, ids)
+	case "delete":
+		affected, err = repo.Delete(ctx, rule, ids)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("\n%d document(s) affected (%s)\n", affected, *action)
+
+	return nil
+}
+
+// sameOwner keeps the dangling references of the tenant and client of the owner, printing the ones left out.
+func sameOwner(dangling []db.DanglingReference, owner common.ResourceOwner) []db.DanglingReference {
+	kept := make([]db.DanglingReference, 0, len(dangling))
+	for _, d := range dangling {
+		if d.TenantID != owner.TenantID || d.ClientID != owner.ClientID {
+			fmt.Printf("skip      %v: tenant %s / client %s differs from the relink target\n", d.DocumentID, d.TenantID, d.ClientID)
+			continue
+		}
+
+		kept = append(kept, d)
+	}
+
+	return kept
+}
@@ -0,0 +1,322 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/golobby/container/v3"
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"go.mongodb.org/mongo-driver/mongo"
+
+	"github.com/psavelis/team-pro/replay-api/cmd/rest-api/routing"
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	iam_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/iam/entities"
+	iam_out "github.com/psavelis/team-pro/replay-api/pkg/domain/iam/ports/out"
+	db "github.com/psavelis/team-pro/replay-api/pkg/infra/db/mongodb"
+	ioc "github.com/psavelis/team-pro/replay-api/pkg/infra/ioc"
+)
+
+const isolationUsage = `usage:
+  cli isolation [-base-url <url>] [-timeout <duration>] [-keep]
+
+verifies the tenant isolation of the read endpoints:
+  - seeds two throwaway tenants, each with a canary document in every tenant scoped collection and an API key
+  - requests every GET route as each tenant (ids of the path are the ids of the canaries of the other tenant)
+  - fails when a response has a document of the other tenant (its tenant id)
+  - deletes the documents of both tenants (unless -keep)
+
+without -base-url the routes are served in-process (ie: CI, with the mongodb of the environment). against a deployment (ie:
+staging), the environment must point to the mongodb of that deployment.`
+
+// isolationSkippedRoutes never answer a plain GET with a document.
+var isolationSkippedRoutes = map[string]string{
+	routing.CI:                       "static file",
+	routing.ReplayProgress:           "websocket",
+	routing.MatchmakingSessionStream: "server-sent events",
+}
+
+// isolationResponseLimit caps the response body scanned for leaks (the canaries are listed first).
+const isolationResponseLimit = 4 << 20
+
+var routeVariable = regexp.MustCompile(`\{([^}:]+)(:[^}]*)?\}`)
+
+type isolationTenant struct {
+	Name     string
+	Canaries *db.TenantCanaries
+	APIKey   string
+}
+
+type isolationResult struct {
+	Route  string
+	Tenant string
+	Status string
+	Result string // ok, leak or skipped
+}
+
+func isolationCommand(ctx context.Context, args []string) error {
+	flags := flag.NewFlagSet("isolation", flag.ContinueOnError)
+	flags.Usage = func() {
+		fmt.Fprintln(os.Stderr, isolationUsage)
+		fmt.Fprintln(os.Stderr, "")
+		flags.PrintDefaults()
+	}
+
+	baseURL := flags.String("base-url", "", "url of the deployment to verify (default: the routes served in-process)")
+	timeout := flags.Duration("timeout", 10*time.Second, "timeout of each request")
+	keep := flags.Bool("keep", false, "keep the documents of the tenants (ie: to reproduce a leak)")
+
+	err := flags.Parse(args)
+	if err != nil {
+		return err
+	}
+
+	builder := ioc.NewContainerBuilder()
+
+	c := builder.WithEnvFile().With(ioc.InjectMongoDB).WithInboundPorts().Build()
+
+	defer builder.Close(c)
+
+	var config common.Config
+	err = c.Resolve(&config)
+	if err != nil {
+		return err
+	}
+
+	var client *mongo.Client
+	err = c.Resolve(&client)
+	if err != nil {
+		return err
+	}
+
+	// building the router initializes the repositories of every route (registering their collections)
+	router, ok := routing.NewRouter(ctx, c).(*mux.Router)
+	if !ok {
+		return fmt.Errorf("unable to list the routes of the api")
+	}
+
+	collections := db.TenantScopedCollections(ctx, db.DefaultEntityMetadataRegistry)
+
+	tenants := make([]*isolationTenant, 0, 2)
+
+	defer func() {
+		if *keep {
+			return
+		}
+
+		for _, tenant := range tenants {
+			deleted, err := db.DeleteTenantDocuments(ctx, client, config.MongoDB.DBName, collections, tenant.Canaries.ResourceOwner.TenantID)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "unable to delete the documents of tenant %s: %v\n", tenant.Name, err)
+				continue
+			}
+
+			fmt.Printf("deleted %d document(s) of tenant %s\n", deleted, tenant.Name)
+		}
+	}()
+
+	for _, name := range []string{"A", "B"} {
+		tenant, err := seedIsolationTenant(ctx, c, client, config.MongoDB.DBName, collections, name)
+		if tenant != nil {
+			tenants = append(tenants, tenant)
+		}
+
+		if err != nil {
+			return fmt.Errorf("unable to seed tenant %s: %w", name, err)
+		}
+
+		fmt.Printf("tenant %s: %s (%d canaries, skipped: %s)\n", name, tenant.Canaries.ResourceOwner.TenantID, len(tenant.Canaries.Collections), strings.Join(tenant.Canaries.Skipped, ", "))
+	}
+
+	if *baseURL == "" {
+		server := httptest.NewServer(router)
+		defer server.Close()
+
+		*baseURL = server.URL
+	}
+
+	routes, err := isolationRoutes(router)
+	if err != nil {
+		return err
+	}
+
+	httpClient := &http.Client{Timeout: *timeout}
+
+	results := make([]isolationResult, 0, len(routes)*len(tenants))
+	leaks := 0
+
+	for _, route := range routes {
+		if reason, skipped := isolationSkippedRoutes[route]; skipped {
+			results = append(results, isolationResult{Route: route, Tenant: "*", Result: "skipped (" + reason + ")"})
+			continue
+		}
+
+		for i, tenant := range tenants {
+			other := tenants[(i+1)%len(tenants)]
+
+			result := checkIsolation(ctx, httpClient, strings.TrimSuffix(*baseURL, "/"), route, tenant, other)
+			if result.Result == "leak" {
+				leaks++
+			}
+
+			results = append(results, result)
+		}
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "ROUTE\tTENANT\tSTATUS\tRESULT")
+
+	for _, result := range results {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", result.Route, result.Tenant, result.Status, result.Result)
+	}
+
+	w.Flush()
+
+	fmt.Printf("\n%d route(s) checked, %d leak(s) found\n", len(routes), leaks)
+
+	if leaks > 0 {
+		return fmt.Errorf("%d cross-tenant leak(s) found", leaks)
+	}
+
+	return nil
+}
+
+// seedIsolationTenant seeds the canaries of a new tenant, and issues the API key its requests are sent with.
+func seedIsolationTenant(ctx context.Context, c container.Container, client *mongo.Client, dbName string, collections []string, name string) (*isolationTenant, error) {
+	owner := common.ResourceOwner{TenantID: uuid.New(), ClientID: uuid.New(), GroupID: uuid.New(), UserID: uuid.New()}
+	now := time.Now().UTC()
+
+	canaries, err := db.SeedTenantCanaries(ctx, client, dbName, collections, owner, now)
+	if canaries == nil {
+		return nil, err
+	}
+
+	tenant := &isolationTenant{Name: name, Canaries: canaries}
+	if err != nil {
+		return tenant, err
+	}
+
+	var keyWriter iam_out.APIKeyWriter
+	err = c.Resolve(&keyWriter)
+	if err != nil {
+		return tenant, err
+	}
+
+	expiresAt := now.Add(time.Hour)
+	key, err := iam_entities.NewAPIKey("tenant isolation check", []iam_entities.Permission{iam_entities.PermissionAll}, iam_entities.APIKeyTierPartner, &expiresAt, owner, now)
+	if err != nil {
+		return tenant, err
+	}
+
+	_, err = keyWriter.Create(common.WithRequestScope(ctx, common.RequestScope{ResourceOwner: owner}), key)
+	if err != nil {
+		return tenant, err
+	}
+
+	tenant.APIKey = key.Key
+
+	return tenant, nil
+}
+
+// isolationRoutes lists the path templates of the GET routes.
+func isolationRoutes(router *mux.Router) ([]string, error) {
+	seen := make(map[string]bool)
+
+	err := router.Walk(func(route *mux.Route, _ *mux.Router, _ []*mux.Route) error {
+		methods, err := route.GetMethods()
+		if err != nil {
+			return nil // no method matcher
+		}
+
+		template, err := route.GetPathTemplate()
+		if err != nil {
+			return nil
+		}
+
+		for _, method := range methods {
+			if method == http.MethodGet {
+				seen[template] = true
+			}
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	routes := make([]string, 0, len(seen))
+	for route := range seen {
+		routes = append(routes, route)
+	}
+
+	sort.Strings(routes)
+
+	return routes, nil
+}
+
+// isolationPath fills the variables of the route: the game, the ids with the id of the canaries of the other tenant, and the others (ie:
+// search queries) with a placeholder.
+func isolationPath(route string, other *isolationTenant) string {
+	return routeVariable.ReplaceAllStringFunc(route, func(variable string) string {
+		name := routeVariable.FindStringSubmatch(variable)[1]
+
+		switch {
+		case name == "game_id":
+			return string(common.CS2_GAME_ID)
+		case strings.HasSuffix(name, "_id"):
+			return other.Canaries.ID.String()
+		default:
+			return "isolation"
+		}
+	})
+}
+
+// checkIsolation requests the route as the tenant. The ids of the canaries of the other tenant are in the path (and may be echoed in the
+// response), so a leak is the tenant id of the other tenant (never sent) in the response.
+func checkIsolation(ctx context.Context, httpClient *http.Client, baseURL string, route string, tenant, other *isolationTenant) isolationResult {
+	result := isolationResult{Route: route, Tenant: tenant.Name}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, baseURL+isolationPath(route, other), nil)
+	if err != nil {
+		result.Result = "skipped (" + err.Error() + ")"
+		return result
+	}
+
+	req.Header.Set(string(common.APIKeyParamKey), tenant.APIKey)
+
+	res, err := httpClient.Do(req)
+	if err != nil {
+		result.Result = "skipped (" + err.Error() + ")"
+		return result
+	}
+
+	defer res.Body.Close()
+
+	result.Status = fmt.Sprint(res.StatusCode)
+
+	body, err := io.ReadAll(io.LimitReader(res.Body, isolationResponseLimit))
+	if err != nil {
+		result.Result = "skipped (" + err.Error() + ")"
+		return result
+	}
+
+	result.Result = "ok"
+
+	if strings.Contains(string(body), other.Canaries.ResourceOwner.TenantID.String()) {
+		result.Result = "leak"
+	}
+
+	return result
+}
@@ -0,0 +1,96 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+)
+
+const requestTimeout = 30 * time.Second
+
+// error bodies printed at most
+const errorBodyLimit = 4 << 10
+
+type apiClient struct {
+	profile *profile
+	http    *http.Client
+}
+
+func newAPIClient(profile *profile) *apiClient {
+	return &apiClient{profile: profile, http: &http.Client{Timeout: requestTimeout}}
+}
+
+// do sends the request as the profile, decoding a successful response into out (when given). Every request carries a request id,
+// reported on failures to find the request in the logs of the api.
+func (c *apiClient) do(ctx context.Context, method string, path string, query url.Values, body interface{}, out interface{}) error {
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+
+		reader = bytes.NewReader(data)
+	}
+
+	target := c.profile.BaseURL + path
+	if len(query) > 0 {
+		target += "?" + query.Encode()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, target, reader)
+	if err != nil {
+		return err
+	}
+
+	requestID := uuid.New().String()
+
+	req.Header.Set(string(common.RequestIDParamKey), requestID)
+	req.Header.Set("Accept", "application/json")
+
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	if c.profile.APIKey != "" {
+		req.Header.Set(string(common.APIKeyParamKey), c.profile.APIKey)
+	} else {
+		req.Header.Set(string(common.ResourceOwnerIDParamKey), c.profile.RID)
+	}
+
+	res, err := c.http.Do(req)
+	if err != nil {
+		return fmt.Errorf("%s %s: %w (request id %s)", method, path, err, requestID)
+	}
+
+	defer res.Body.Close()
+
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		data, _ := io.ReadAll(io.LimitReader(res.Body, errorBodyLimit))
+		return fmt.Errorf("%s %s: %s: %s (request id %s)", method, path, res.Status, strings.TrimSpace(string(data)), requestID)
+	}
+
+	if out == nil {
+		return nil
+	}
+
+	return json.NewDecoder(res.Body).Decode(out)
+}
+
+// printJSON writes the response to stdout as indented json (ie: for jq).
+func printJSON(v interface{}) error {
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+
+	return enc.Encode(v)
+}
@@ -0,0 +1,160 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/psavelis/team-pro/replay-api/cmd/rest-api/routing"
+)
+
+// drain calls made at most by queue drain (each cancels a window of the oldest waiting tickets)
+const maxDrainCalls = 100
+
+const replayUsage = `usage:
+  rctl replay reprocess <replay_file_id>
+
+requeues a Failed, Quarantined or stuck Pending replay file for the replay workers (requires replays:manage). completed
+replay files and the ones being processed are refused.`
+
+const queueUsage = `usage:
+  rctl queue drain <pool_id>
+
+cancels the waiting tickets of the matchmaking pool (requires matchmaking:manage), ie: while its matcher is broken. the pool
+stays open: players can queue again.`
+
+const jobsUsage = `usage:
+  rctl jobs list
+  rctl jobs runs <job> [-limit <n>]
+  rctl jobs trigger <job>
+  rctl jobs pause <job>
+  rctl jobs resume <job>
+
+requires jobs:manage. triggers and pauses are applied by the schedulers within their next poll.`
+
+func replayCommand(ctx context.Context, client *apiClient, args []string) error {
+	if len(args) != 2 || args[0] != "reprocess" {
+		fmt.Fprintln(os.Stderr, replayUsage)
+		return fmt.Errorf("invalid replay arguments")
+	}
+
+	replayFileID, err := uuid.Parse(args[1])
+	if err != nil {
+		return fmt.Errorf("invalid replay_file_id %q", args[1])
+	}
+
+	var replayFile json.RawMessage
+
+	err = client.do(ctx, http.MethodPost, path(routing.AdminReplayReprocess, "replay_file_id", replayFileID.String()), nil, nil, &replayFile)
+	if err != nil {
+		return err
+	}
+
+	return printJSON(replayFile)
+}
+
+func queueCommand(ctx context.Context, client *apiClient, args []string) error {
+	if len(args) != 2 || args[0] != "drain" {
+		fmt.Fprintln(os.Stderr, queueUsage)
+		return fmt.Errorf("invalid queue arguments")
+	}
+
+	poolID, err := uuid.Parse(args[1])
+	if err != nil {
+		return fmt.Errorf("invalid pool_id %q", args[1])
+	}
+
+	drained := 0
+	for i := 0; i < maxDrainCalls; i++ {
+		var res struct {
+			Cancelled int `json:"cancelled"`
+		}
+
+		err = client.do(ctx, http.MethodPost, path(routing.MatchmakingQueueDrain, "pool_id", poolID.String()), nil, nil, &res)
+		if err != nil {
+			return fmt.Errorf("drained %d ticket(s) before failing: %w", drained, err)
+		}
+
+		drained += res.Cancelled
+
+		if res.Cancelled == 0 {
+			break
+		}
+	}
+
+	fmt.Printf("pool %s: %d waiting ticket(s) cancelled\n", poolID, drained)
+
+	return nil
+}
+
+func jobsCommand(ctx context.Context, client *apiClient, args []string) error {
+	if len(args) < 1 {
+		fmt.Fprintln(os.Stderr, jobsUsage)
+		return fmt.Errorf("missing jobs subcommand")
+	}
+
+	if args[0] == "list" {
+		var jobs json.RawMessage
+
+		err := client.do(ctx, http.MethodGet, routing.AdminJobs, nil, nil, &jobs)
+		if err != nil {
+			return err
+		}
+
+		return printJSON(jobs)
+	}
+
+	if len(args) < 2 {
+		fmt.Fprintln(os.Stderr, jobsUsage)
+		return fmt.Errorf("missing job name")
+	}
+
+	name := args[1]
+
+	var method, route string
+	var query url.Values
+
+	switch args[0] {
+	case "runs":
+		flags := flag.NewFlagSet("jobs runs", flag.ContinueOnError)
+		limit := flags.Uint("limit", 20, "runs returned at most (most recent first)")
+
+		err := flags.Parse(args[2:])
+		if err != nil {
+			return err
+		}
+
+		method, route = http.MethodGet, routing.AdminJobRuns
+		query = url.Values{"limit": []string{strconv.FormatUint(uint64(*limit), 10)}}
+	case "trigger":
+		method, route = http.MethodPost, routing.AdminJobRuns
+	case "pause":
+		method, route = http.MethodPost, routing.AdminJobPause
+	case "resume":
+		method, route = http.MethodDelete, routing.AdminJobPause
+	default:
+		fmt.Fprintln(os.Stderr, jobsUsage)
+		return fmt.Errorf("unknown jobs subcommand %q", args[0])
+	}
+
+	var res json.RawMessage
+
+	err := client.do(ctx, method, path(route, "job_name", name), query, nil, &res)
+	if err != nil {
+		return err
+	}
+
+	return printJSON(res)
+}
+
+// path fills the variable of the route.
+func path(route string, name string, value string) string {
+	return strings.Replace(route, "{"+name+"}", url.PathEscape(value), 1)
+}
@@ -0,0 +1,85 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+)
+
+type command func(ctx context.Context, client *apiClient, args []string) error
+
+var commands = map[string]command{
+	"replay": replayCommand,
+	"queue":  queueCommand,
+	"jobs":   jobsCommand,
+}
+
+const usageText = `usage: rctl [-profile <name>] <command> [arguments]
+
+operator client of the replay api (REST), authenticated by the profile (-profile, or $RCTL_PROFILE, or "default").
+
+commands:
+  profile   manage the profiles: the base url and credentials of each deployment
+  replay    requeue a failed, quarantined or stuck replay file for the replay workers
+  queue     cancel the waiting tickets of a matchmaking pool
+  jobs      list, trigger, pause and resume the background jobs`
+
+func usage(flags *flag.FlagSet) {
+	fmt.Fprintln(os.Stderr, usageText)
+	fmt.Fprintln(os.Stderr, "")
+	flags.PrintDefaults()
+}
+
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	flags := flag.NewFlagSet("rctl", flag.ContinueOnError)
+	flags.Usage = func() { usage(flags) }
+
+	profileName := flags.String("profile", "", "profile to authenticate with (default: $RCTL_PROFILE, or \"default\")")
+
+	err := flags.Parse(os.Args[1:])
+	if err != nil {
+		os.Exit(2)
+	}
+
+	args := flags.Args()
+	if len(args) < 1 {
+		usage(flags)
+		os.Exit(2)
+	}
+
+	// profiles are managed without authenticating
+	if args[0] == "profile" {
+		err = profileCommand(args[1:])
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "error:", err)
+			os.Exit(1)
+		}
+
+		return
+	}
+
+	cmd, ok := commands[args[0]]
+	if !ok {
+		fmt.Fprintf(os.Stderr, "unknown command %q\n\n", args[0])
+		usage(flags)
+		os.Exit(2)
+	}
+
+	profile, err := loadProfile(*profileName)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "error:", err)
+		os.Exit(1)
+	}
+
+	err = cmd(ctx, newAPIClient(profile), args[1:])
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "error:", err)
+		os.Exit(1)
+	}
+}
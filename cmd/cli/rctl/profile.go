@@ -0,0 +1,247 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io/fs"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/tabwriter"
+)
+
+const defaultProfileName = "default"
+
+const profileUsage = `usage:
+  rctl profile set <name> -base-url <url> (-api-key <key> | -rid <rid>)
+  rctl profile list
+  rctl profile delete <name>
+
+profiles are kept in $RCTL_CONFIG (default: <user config dir>/rctl/profiles.json), readable by the current user only.
+a profile authenticates either by an api key (X-API-Key, granted the permissions of the operations) or by the session of an
+operator (X-Resource-Owner-ID, the user granted the permissions by their roles).`
+
+// profile is a deployment of the api and the credentials used against it.
+type profile struct {
+	Name    string `json:"-"`
+	BaseURL string `json:"base_url"`
+	APIKey  string `json:"api_key,omitempty"`
+	RID     string `json:"rid,omitempty"`
+}
+
+func profilesPath() (string, error) {
+	if path := os.Getenv("RCTL_CONFIG"); path != "" {
+		return path, nil
+	}
+
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(dir, "rctl", "profiles.json"), nil
+}
+
+func readProfiles() (map[string]profile, error) {
+	path, err := profilesPath()
+	if err != nil {
+		return nil, err
+	}
+
+	profiles := make(map[string]profile)
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return profiles, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	err = json.Unmarshal(data, &profiles)
+	if err != nil {
+		return nil, fmt.Errorf("invalid profiles file %s: %w", path, err)
+	}
+
+	return profiles, nil
+}
+
+func writeProfiles(profiles map[string]profile) error {
+	path, err := profilesPath()
+	if err != nil {
+		return err
+	}
+
+	err = os.MkdirAll(filepath.Dir(path), 0o700)
+	if err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(profiles, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	// credentials: readable by the current user only
+	return os.WriteFile(path, data, 0o600)
+}
+
+// loadProfile returns the named profile, or the one of $RCTL_PROFILE, or the default one.
+func loadProfile(name string) (*profile, error) {
+	if name == "" {
+		name = os.Getenv("RCTL_PROFILE")
+	}
+
+	if name == "" {
+		name = defaultProfileName
+	}
+
+	profiles, err := readProfiles()
+	if err != nil {
+		return nil, err
+	}
+
+	p, ok := profiles[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown profile %q (see: rctl profile set)", name)
+	}
+
+	p.Name = name
+
+	return &p, nil
+}
+
+func profileCommand(args []string) error {
+	if len(args) < 1 {
+		fmt.Fprintln(os.Stderr, profileUsage)
+		return fmt.Errorf("missing profile subcommand")
+	}
+
+	switch args[0] {
+	case "set":
+		return profileSet(args[1:])
+	case "list":
+		return profileList()
+	case "delete":
+		return profileDelete(args[1:])
+	default:
+		fmt.Fprintln(os.Stderr, profileUsage)
+		return fmt.Errorf("unknown profile subcommand %q", args[0])
+	}
+}
+
+func profileSet(args []string) error {
+	if len(args) < 1 || strings.HasPrefix(args[0], "-") {
+		fmt.Fprintln(os.Stderr, profileUsage)
+		return fmt.Errorf("missing profile name")
+	}
+
+	name := args[0]
+
+	flags := flag.NewFlagSet("profile set", flag.ContinueOnError)
+	baseURL := flags.String("base-url", "", "url of the api (ie: https://api.example.com)")
+	apiKey := flags.String("api-key", "", "api key to authenticate with")
+	rid := flags.String("rid", "", "session (rid) of the operator to authenticate with")
+
+	err := flags.Parse(args[1:])
+	if err != nil {
+		return err
+	}
+
+	u, err := url.Parse(*baseURL)
+	if err != nil || u.Scheme == "" || u.Host == "" {
+		return fmt.Errorf("invalid -base-url %q", *baseURL)
+	}
+
+	// the api rejects requests carrying both
+	if (*apiKey == "") == (*rid == "") {
+		return fmt.Errorf("either -api-key or -rid is required")
+	}
+
+	profiles, err := readProfiles()
+	if err != nil {
+		return err
+	}
+
+	profiles[name] = profile{BaseURL: strings.TrimRight(*baseURL, "/"), APIKey: *apiKey, RID: *rid}
+
+	err = writeProfiles(profiles)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("profile %s saved\n", name)
+
+	return nil
+}
+
+func profileList() error {
+	profiles, err := readProfiles()
+	if err != nil {
+		return err
+	}
+
+	names := make([]string, 0, len(profiles))
+	for name := range profiles {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	tw := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "PROFILE\tBASE URL\tAUTH")
+
+	for _, name := range names {
+		p := profiles[name]
+
+		auth := "api key " + mask(p.APIKey)
+		if p.RID != "" {
+			auth = "rid " + mask(p.RID)
+		}
+
+		fmt.Fprintf(tw, "%s\t%s\t%s\n", name, p.BaseURL, auth)
+	}
+
+	return tw.Flush()
+}
+
+func profileDelete(args []string) error {
+	if len(args) != 1 {
+		fmt.Fprintln(os.Stderr, profileUsage)
+		return fmt.Errorf("missing profile name")
+	}
+
+	profiles, err := readProfiles()
+	if err != nil {
+		return err
+	}
+
+	if _, ok := profiles[args[0]]; !ok {
+		return fmt.Errorf("unknown profile %q", args[0])
+	}
+
+	delete(profiles, args[0])
+
+	err = writeProfiles(profiles)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("profile %s deleted\n", args[0])
+
+	return nil
+}
+
+// mask keeps the last 4 characters of a credential.
+func mask(credential string) string {
+	if len(credential) <= 4 {
+		return "****"
+	}
+
+	return "****" + credential[len(credential)-4:]
+}
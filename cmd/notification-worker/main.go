@@ -0,0 +1,150 @@
+package main
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+
+	"github.com/google/uuid"
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	matchmaking_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/matchmaking/entities"
+	notification_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/notification/entities"
+	notification_in "github.com/psavelis/team-pro/replay-api/pkg/domain/notification/ports/in"
+	squad_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/squad/entities"
+	"github.com/psavelis/team-pro/replay-api/pkg/infra/chaos"
+	"github.com/psavelis/team-pro/replay-api/pkg/infra/events/rabbitmq"
+	ioc "github.com/psavelis/team-pro/replay-api/pkg/infra/ioc"
+	"github.com/psavelis/team-pro/replay-api/pkg/infra/logging"
+)
+
+// sending is I/O bound (the push services): a lobby alerts all its players sequentially, lobbies are alerted concurrently
+const defaultConcurrency = 8
+
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	logger := slog.New(logging.NewContextHandler(slog.NewJSONHandler(os.Stdout, nil)))
+
+	slog.SetDefault(logger)
+
+	builder := ioc.NewContainerBuilder()
+
+	c := builder.WithEnvFile().With(ioc.InjectMongoDB).WithInboundPorts().Build()
+
+	defer builder.Close(c)
+
+	var config common.Config
+	err := c.Resolve(&config)
+	if err != nil {
+		slog.ErrorContext(ctx, "unable to resolve common.Config", "err", err)
+		panic(err)
+	}
+
+	if config.RabbitMQ.URL == "" {
+		slog.ErrorContext(ctx, "RABBITMQ_URL is required by the notification worker")
+		os.Exit(1)
+	}
+
+	var notifyMatchFound notification_in.NotifyMatchFoundCommandHandler
+	err = c.Resolve(&notifyMatchFound)
+	if err != nil {
+		slog.ErrorContext(ctx, "unable to resolve notification_in.NotifyMatchFoundCommandHandler", "err", err)
+		panic(err)
+	}
+
+	var dispatchNotification notification_in.DispatchNotificationCommandHandler
+	err = c.Resolve(&dispatchNotification)
+	if err != nil {
+		slog.ErrorContext(ctx, "unable to resolve notification_in.DispatchNotificationCommandHandler", "err", err)
+		panic(err)
+	}
+
+	consumer := rabbitmq.NewLobbyCreatedConsumer(config.RabbitMQ.URL, defaultConcurrency, func(handleCtx context.Context, event matchmaking_entities.LobbyCreated) error {
+		// notified on behalf of the pool owner (the client application of the players)
+		handleCtx = common.WithResourceOwner(handleCtx, event.ResourceOwner)
+
+		userIDs := make([]uuid.UUID, 0, len(event.Players))
+		for _, player := range event.Players {
+			userIDs = append(userIDs, player.UserID)
+		}
+
+		_, err := notifyMatchFound.Exec(handleCtx, notification_entities.MatchFound{
+			LobbyID:            event.LobbyID,
+			GameID:             event.GameID,
+			UserIDs:            userIDs,
+			ReadyCheckDeadline: event.ReadyCheckDeadline,
+			ResourceOwner:      event.ResourceOwner,
+			CreatedAt:          event.CreatedAt,
+		})
+
+		return err
+	})
+
+	squadConsumer := rabbitmq.NewSquadJoinRequestConsumer(config.RabbitMQ.URL, defaultConcurrency, func(handleCtx context.Context, event squad_entities.JoinRequestUpdated) error {
+		handleCtx = common.WithResourceOwner(handleCtx, event.ResourceOwner)
+
+		_, err := dispatchNotification.Exec(handleCtx, joinRequestNotification(event))
+
+		return err
+	})
+
+	if config.Chaos.Targeted(chaos.TargetRabbitMQ) {
+		var injector *chaos.Injector
+		err = c.Resolve(&injector)
+		if err != nil {
+			slog.ErrorContext(ctx, "unable to resolve chaos.Injector", "err", err)
+			panic(err)
+		}
+
+		consumer.Dial = chaos.NewDialer(chaos.TargetRabbitMQ, injector).Dial
+		squadConsumer.Dial = consumer.Dial
+	}
+
+	slog.InfoContext(ctx, "Starting notification worker", "concurrency", defaultConcurrency)
+
+	var wg sync.WaitGroup
+	for _, run := range []func(context.Context){consumer.Run, squadConsumer.Run} {
+		wg.Add(1)
+
+		go func(run func(context.Context)) {
+			defer wg.Done()
+			run(ctx)
+		}(run)
+	}
+
+	wg.Wait()
+}
+
+// joinRequestNotification notifies the other party of a join request: who answers it while pending, who asked once answered.
+func joinRequestNotification(event squad_entities.JoinRequestUpdated) notification_entities.NotificationEvent {
+	t := notification_entities.NotificationSquadApplication
+
+	switch {
+	case event.Status == squad_entities.JoinRequestStatusAccepted:
+		t = notification_entities.NotificationSquadJoinAccepted
+	case event.Status == squad_entities.JoinRequestStatusDeclined:
+		t = notification_entities.NotificationSquadJoinDeclined
+	case event.Kind == squad_entities.JoinRequestKindInvite:
+		t = notification_entities.NotificationSquadInvite
+	}
+
+	return notification_entities.NotificationEvent{
+		Type:    t,
+		UserIDs: []uuid.UUID{event.RecipientID},
+		Data: map[string]string{
+			"join_request_id": event.JoinRequestID.String(),
+			"squad_id":        event.SquadID.String(),
+			"squad_name":      event.SquadName,
+			"user_id":         event.UserID.String(),
+			"user_name":       event.UserName,
+			"kind":            string(event.Kind),
+		},
+		Reference:     event.JoinRequestID.String(),
+		ResourceOwner: event.ResourceOwner,
+		CreatedAt:     event.UpdatedAt,
+	}
+}
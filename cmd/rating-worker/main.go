@@ -0,0 +1,95 @@
+package main
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"os/signal"
+	"syscall"
+
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	matchmaking_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/matchmaking/entities"
+	rating_in "github.com/psavelis/team-pro/replay-api/pkg/domain/rating/ports/in"
+	"github.com/psavelis/team-pro/replay-api/pkg/infra/badges"
+	"github.com/psavelis/team-pro/replay-api/pkg/infra/chaos"
+	"github.com/psavelis/team-pro/replay-api/pkg/infra/events/rabbitmq"
+	ioc "github.com/psavelis/team-pro/replay-api/pkg/infra/ioc"
+	"github.com/psavelis/team-pro/replay-api/pkg/infra/logging"
+)
+
+// players are rated match by match, a player of concurrent matches may be rated from a stale rating (the last write wins)
+const defaultConcurrency = 4
+
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	logger := slog.New(logging.NewContextHandler(slog.NewJSONHandler(os.Stdout, nil)))
+
+	slog.SetDefault(logger)
+
+	builder := ioc.NewContainerBuilder()
+
+	c := builder.WithEnvFile().With(ioc.InjectMongoDB).WithInboundPorts().Build()
+
+	defer builder.Close(c)
+
+	var config common.Config
+	err := c.Resolve(&config)
+	if err != nil {
+		slog.ErrorContext(ctx, "unable to resolve common.Config", "err", err)
+		panic(err)
+	}
+
+	if config.RabbitMQ.URL == "" {
+		slog.ErrorContext(ctx, "RABBITMQ_URL is required by the rating worker")
+		os.Exit(1)
+	}
+
+	var rateMatch rating_in.RateMatchCommand
+	err = c.Resolve(&rateMatch)
+	if err != nil {
+		slog.ErrorContext(ctx, "unable to resolve rating_in.RateMatchCommand", "err", err)
+		panic(err)
+	}
+
+	var badgeEvaluator *badges.MatchBadgeEvaluator
+	err = c.Resolve(&badgeEvaluator)
+	if err != nil {
+		slog.ErrorContext(ctx, "unable to resolve badges.MatchBadgeEvaluator", "err", err)
+		panic(err)
+	}
+
+	consumer := rabbitmq.NewMatchCompletedConsumer(config.RabbitMQ.URL, defaultConcurrency, func(handleCtx context.Context, event matchmaking_entities.MatchCompleted) error {
+		// rated on behalf of the pool owner (the client application of the players)
+		handleCtx = common.WithResourceOwner(handleCtx, event.ResourceOwner)
+
+		err := rateMatch.Exec(handleCtx, event)
+		if err != nil {
+			return err
+		}
+
+		// not failing the delivery: a redelivered match would be rated twice
+		err = badgeEvaluator.EvaluateMatchBadges(handleCtx, event)
+		if err != nil {
+			slog.WarnContext(handleCtx, "unable to evaluate match badges", "matchID", event.MatchID, "err", err)
+		}
+
+		return nil
+	})
+
+	if config.Chaos.Targeted(chaos.TargetRabbitMQ) {
+		var injector *chaos.Injector
+		err = c.Resolve(&injector)
+		if err != nil {
+			slog.ErrorContext(ctx, "unable to resolve chaos.Injector", "err", err)
+			panic(err)
+		}
+
+		consumer.Dial = chaos.NewDialer(chaos.TargetRabbitMQ, injector).Dial
+	}
+
+	slog.InfoContext(ctx, "Starting rating worker", "concurrency", defaultConcurrency)
+
+	consumer.Run(ctx)
+}
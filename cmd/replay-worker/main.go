@@ -0,0 +1,131 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"os"
+	"os/signal"
+	"syscall"
+
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	"github.com/psavelis/team-pro/replay-api/pkg/domain/replay"
+	replay_entity "github.com/psavelis/team-pro/replay-api/pkg/domain/replay/entities"
+	replay_in "github.com/psavelis/team-pro/replay-api/pkg/domain/replay/ports/in"
+	replay_use_cases "github.com/psavelis/team-pro/replay-api/pkg/domain/replay/use_cases"
+	"github.com/psavelis/team-pro/replay-api/pkg/infra/chaos"
+	"github.com/psavelis/team-pro/replay-api/pkg/infra/events/rabbitmq"
+	ioc "github.com/psavelis/team-pro/replay-api/pkg/infra/ioc"
+	"github.com/psavelis/team-pro/replay-api/pkg/infra/logging"
+	"github.com/psavelis/team-pro/replay-api/pkg/infra/sandbox"
+)
+
+// parsing is CPU and memory bound: scale with replicas rather than a high per-worker concurrency
+const defaultConcurrency = 2
+
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	logger := slog.New(logging.NewContextHandler(slog.NewJSONHandler(os.Stdout, nil)))
+
+	slog.SetDefault(logger)
+
+	// the parser process of the sandbox (see sandbox.ReplayParserSandbox)
+	if len(os.Args) > 1 && os.Args[1] == sandbox.ParseCommand {
+		code := parse(ctx)
+		stop()
+		os.Exit(code)
+	}
+
+	builder := ioc.NewContainerBuilder()
+
+	c := builder.WithEnvFile().With(ioc.InjectMongoDB).WithInboundPorts().Build()
+
+	defer builder.Close(c)
+
+	var config common.Config
+	err := c.Resolve(&config)
+	if err != nil {
+		slog.ErrorContext(ctx, "unable to resolve common.Config", "err", err)
+		panic(err)
+	}
+
+	if config.RabbitMQ.URL == "" {
+		slog.ErrorContext(ctx, "RABBITMQ_URL is required by the replay worker")
+		os.Exit(1)
+	}
+
+	var processUploadedReplayFile replay_in.ProcessUploadedReplayFileCommand
+	err = c.Resolve(&processUploadedReplayFile)
+	if err != nil {
+		slog.ErrorContext(ctx, "unable to resolve replay_in.ProcessUploadedReplayFileCommand", "err", err)
+		panic(err)
+	}
+
+	var recordReplayParseCrash replay_in.RecordReplayParseCrashCommand
+	err = c.Resolve(&recordReplayParseCrash)
+	if err != nil {
+		slog.ErrorContext(ctx, "unable to resolve replay_in.RecordReplayParseCrashCommand", "err", err)
+		panic(err)
+	}
+
+	var parserSandbox *sandbox.ReplayParserSandbox
+	if config.ReplayParser.Sandbox {
+		parserSandbox, err = sandbox.NewReplayParserSandbox(config.ReplayParser)
+		if err != nil {
+			slog.ErrorContext(ctx, "unable to create the replay parser sandbox", "err", err)
+			panic(err)
+		}
+	}
+
+	concurrency := config.RabbitMQ.ReplayWorkerConcurrency
+	if concurrency < 1 {
+		concurrency = defaultConcurrency
+	}
+
+	consumer := rabbitmq.NewReplayUploadedConsumer(config.RabbitMQ.URL, concurrency, replay_use_cases.MaxReplayProcessingAttempts, func(handleCtx context.Context, event replay_entity.ReplayFileUploaded) error {
+		// processed on behalf of the uploader
+		handleCtx = common.WithResourceOwner(handleCtx, event.ResourceOwner)
+
+		if parserSandbox == nil {
+			_, err := processUploadedReplayFile.Exec(handleCtx, event)
+
+			return err
+		}
+
+		err := parserSandbox.Process(handleCtx, event)
+
+		var crash *replay.ReplayParserCrashError
+		if !errors.As(err, &crash) {
+			return err
+		}
+
+		replayFile, recordErr := recordReplayParseCrash.Exec(handleCtx, event.ReplayFileID, crash.Failure)
+		if recordErr != nil {
+			return err
+		}
+
+		// acked: quarantined replay files aren't retried
+		if replayFile.Status == replay_entity.ReplayFileStatusQuarantined {
+			return nil
+		}
+
+		return err
+	})
+
+	if config.Chaos.Targeted(chaos.TargetRabbitMQ) {
+		var injector *chaos.Injector
+		err = c.Resolve(&injector)
+		if err != nil {
+			slog.ErrorContext(ctx, "unable to resolve chaos.Injector", "err", err)
+			panic(err)
+		}
+
+		consumer.Dial = chaos.NewDialer(chaos.TargetRabbitMQ, injector).Dial
+	}
+
+	slog.InfoContext(ctx, "Starting replay worker", "concurrency", concurrency, "sandbox", parserSandbox != nil)
+
+	consumer.Run(ctx)
+}
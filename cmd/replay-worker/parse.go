@@ -0,0 +1,58 @@
+package main
+
+import (
+	"context"
+	"log/slog"
+	"os"
+
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	replay_in "github.com/psavelis/team-pro/replay-api/pkg/domain/replay/ports/in"
+	ioc "github.com/psavelis/team-pro/replay-api/pkg/infra/ioc"
+	"github.com/psavelis/team-pro/replay-api/pkg/infra/sandbox"
+)
+
+// parse is the parser process of the sandbox: it processes the replay file read from stdin within the parser limits, returning the
+// exit code (a panic or a limit kills it, for the worker to record the crash).
+func parse(ctx context.Context) int {
+	builder := ioc.NewContainerBuilder()
+
+	c := builder.WithEnvFile().With(ioc.InjectMongoDB).WithInboundPorts().Build()
+
+	defer builder.Close(c)
+
+	var config common.Config
+	err := c.Resolve(&config)
+	if err != nil {
+		slog.ErrorContext(ctx, "unable to resolve common.Config", "err", err)
+		return sandbox.ExitCodeFailed
+	}
+
+	err = sandbox.NewLimits(config.ReplayParser).Apply()
+	if err != nil {
+		slog.ErrorContext(ctx, "unable to apply the replay parser limits", "err", err)
+		return sandbox.ExitCodeFailed
+	}
+
+	ctx, event, err := sandbox.ReadParseRequest(ctx, os.Stdin)
+	if err != nil {
+		slog.ErrorContext(ctx, "unable to read the replay file to parse", "err", err)
+		return sandbox.ExitCodeFailed
+	}
+
+	var processUploadedReplayFile replay_in.ProcessUploadedReplayFileCommand
+	err = c.Resolve(&processUploadedReplayFile)
+	if err != nil {
+		slog.ErrorContext(ctx, "unable to resolve replay_in.ProcessUploadedReplayFileCommand", "err", err)
+		return sandbox.ExitCodeFailed
+	}
+
+	// processed on behalf of the uploader
+	ctx = common.WithResourceOwner(ctx, event.ResourceOwner)
+
+	_, err = processUploadedReplayFile.Exec(ctx, event)
+	if err != nil {
+		return sandbox.ExitCodeFailed
+	}
+
+	return 0
+}
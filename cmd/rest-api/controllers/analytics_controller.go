@@ -0,0 +1,99 @@
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/golobby/container/v3"
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	analytics_in "github.com/psavelis/team-pro/replay-api/pkg/domain/analytics/ports/in"
+)
+
+const analyticsDateLayout = "2006-01-02"
+
+type AnalyticsController struct {
+	EngagementReader analytics_in.EngagementReader
+}
+
+func NewAnalyticsController(container *container.Container) *AnalyticsController {
+	var engagementReader analytics_in.EngagementReader
+	err := container.Resolve(&engagementReader)
+
+	if err != nil {
+		slog.Error("Cannot resolve analytics_in.EngagementReader for new AnalyticsController", "err", err)
+		panic(err)
+	}
+
+	return &AnalyticsController{EngagementReader: engagementReader}
+}
+
+// GetEngagement returns the engagement snapshots between `from` and `to` (YYYY-MM-DD, defaults to the last 30 days). Internal use (dashboards).
+func (c *AnalyticsController) GetEngagement(apiContext context.Context) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		to := time.Now().UTC()
+		from := to.AddDate(0, 0, -30)
+
+		var err error
+
+		if v := r.URL.Query().Get("from"); v != "" {
+			from, err = time.Parse(analyticsDateLayout, v)
+			if err != nil {
+				slog.ErrorContext(r.Context(), "invalid engagement `from` parameter", "err", err, "from", v)
+				http.Error(w, "Bad Request", http.StatusBadRequest)
+				return
+			}
+		}
+
+		if v := r.URL.Query().Get("to"); v != "" {
+			to, err = time.Parse(analyticsDateLayout, v)
+			if err != nil {
+				slog.ErrorContext(r.Context(), "invalid engagement `to` parameter", "err", err, "to", v)
+				http.Error(w, "Bad Request", http.StatusBadRequest)
+				return
+			}
+		}
+
+		if to.Before(from) {
+			slog.ErrorContext(r.Context(), "invalid engagement date range", "from", from, "to", to)
+			http.Error(w, "Bad Request", http.StatusBadRequest)
+			return
+		}
+
+		params := []common.SearchAggregation{
+			{
+				Params: []common.SearchParameter{
+					{
+						DateParams: []common.SearchableDateRange{
+							{
+								Field: "Day",
+								Min:   &from,
+								Max:   &to,
+							},
+						},
+					},
+				},
+			},
+		}
+
+		s, err := c.EngagementReader.Compile(r.Context(), params, common.NewSearchResultOptions(0, 200))
+		if err != nil {
+			slog.ErrorContext(r.Context(), "error compiling engagement search", "err", err)
+			http.Error(w, "Bad Request", http.StatusBadRequest)
+			return
+		}
+
+		snapshots, err := c.EngagementReader.Search(r.Context(), *s)
+		if err != nil {
+			slog.ErrorContext(r.Context(), "error searching engagement snapshots", "err", err)
+			http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(snapshots)
+	}
+}
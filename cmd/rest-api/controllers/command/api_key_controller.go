@@ -0,0 +1,127 @@
+package cmd_controllers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/golobby/container/v3"
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/psavelis/team-pro/replay-api/pkg/domain/iam"
+	iam_in "github.com/psavelis/team-pro/replay-api/pkg/domain/iam/ports/in"
+)
+
+type APIKeyController struct {
+	IssueAPIKeyCommandHandler  iam_in.IssueAPIKeyCommandHandler
+	RotateAPIKeyCommandHandler iam_in.RotateAPIKeyCommandHandler
+	RevokeAPIKeyCommandHandler iam_in.RevokeAPIKeyCommandHandler
+}
+
+func NewAPIKeyController(container *container.Container) *APIKeyController {
+	var issueAPIKeyCommandHandler iam_in.IssueAPIKeyCommandHandler
+	err := container.Resolve(&issueAPIKeyCommandHandler)
+	if err != nil {
+		slog.Error("Cannot resolve iam_in.IssueAPIKeyCommandHandler for new APIKeyController", "err", err)
+		panic(err)
+	}
+
+	var rotateAPIKeyCommandHandler iam_in.RotateAPIKeyCommandHandler
+	err = container.Resolve(&rotateAPIKeyCommandHandler)
+	if err != nil {
+		slog.Error("Cannot resolve iam_in.RotateAPIKeyCommandHandler for new APIKeyController", "err", err)
+		panic(err)
+	}
+
+	var revokeAPIKeyCommandHandler iam_in.RevokeAPIKeyCommandHandler
+	err = container.Resolve(&revokeAPIKeyCommandHandler)
+	if err != nil {
+		slog.Error("Cannot resolve iam_in.RevokeAPIKeyCommandHandler for new APIKeyController", "err", err)
+		panic(err)
+	}
+
+	return &APIKeyController{
+		IssueAPIKeyCommandHandler:  issueAPIKeyCommandHandler,
+		RotateAPIKeyCommandHandler: rotateAPIKeyCommandHandler,
+		RevokeAPIKeyCommandHandler: revokeAPIKeyCommandHandler,
+	}
+}
+
+// IssueHandler answers the new key in plain text: it is the only time it is shown (only its hash is stored).
+func (ctlr *APIKeyController) IssueHandler(apiContext context.Context) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var cmd iam_in.IssueAPIKeyCommand
+		err := json.NewDecoder(r.Body).Decode(&cmd)
+		if err != nil {
+			http.Error(w, "Bad Request", http.StatusBadRequest)
+			return
+		}
+
+		key, err := ctlr.IssueAPIKeyCommandHandler.Exec(r.Context(), cmd)
+		if err != nil {
+			writeAPIKeyError(w, err)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(key)
+	}
+}
+
+func (ctlr *APIKeyController) RotateHandler(apiContext context.Context) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		keyID, err := uuid.Parse(mux.Vars(r)["api_key_id"])
+		if err != nil {
+			http.Error(w, "Bad Request", http.StatusBadRequest)
+			return
+		}
+
+		key, err := ctlr.RotateAPIKeyCommandHandler.Exec(r.Context(), keyID)
+		if err != nil {
+			writeAPIKeyError(w, err)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(key)
+	}
+}
+
+func (ctlr *APIKeyController) RevokeHandler(apiContext context.Context) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		keyID, err := uuid.Parse(mux.Vars(r)["api_key_id"])
+		if err != nil {
+			http.Error(w, "Bad Request", http.StatusBadRequest)
+			return
+		}
+
+		_, err = ctlr.RevokeAPIKeyCommandHandler.Exec(r.Context(), keyID)
+		if err != nil {
+			writeAPIKeyError(w, err)
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func writeAPIKeyError(w http.ResponseWriter, err error) {
+	var invalidKeyErr *iam.InvalidAPIKeyError
+	var notFoundErr *iam.APIKeyNotFoundError
+	var deniedErr *iam.PermissionDeniedError
+
+	switch {
+	case errors.As(err, &invalidKeyErr):
+		http.Error(w, invalidKeyErr.Message, http.StatusBadRequest)
+	case errors.As(err, &notFoundErr):
+		http.Error(w, notFoundErr.Message, http.StatusNotFound)
+	case errors.As(err, &deniedErr):
+		http.Error(w, deniedErr.Message, http.StatusForbidden)
+	default:
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+	}
+}
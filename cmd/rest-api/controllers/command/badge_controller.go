@@ -0,0 +1,68 @@
+package cmd_controllers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/golobby/container/v3"
+	"github.com/psavelis/team-pro/replay-api/pkg/domain/badge"
+	badge_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/badge/entities"
+	badge_in "github.com/psavelis/team-pro/replay-api/pkg/domain/badge/ports/in"
+)
+
+type BadgeController struct {
+	CreateBadgeCommand badge_in.CreateBadgeCommand
+}
+
+func NewBadgeController(container *container.Container) *BadgeController {
+	var createBadgeCommand badge_in.CreateBadgeCommand
+	err := container.Resolve(&createBadgeCommand)
+	if err != nil {
+		slog.Error("Cannot resolve badge_in.CreateBadgeCommand for new BadgeController", "err", err)
+		panic(err)
+	}
+
+	return &BadgeController{
+		CreateBadgeCommand: createBadgeCommand,
+	}
+}
+
+// CreateHandler defines a badge of a game, awarded by the replay processing or the rating worker (as per its trigger).
+func (ctlr *BadgeController) CreateHandler(apiContext context.Context) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var b badge_entities.Badge
+		err := json.NewDecoder(r.Body).Decode(&b)
+		if err != nil {
+			slog.ErrorContext(r.Context(), "invalid badge request", "err", err)
+			http.Error(w, "Bad Request", http.StatusBadRequest)
+			return
+		}
+
+		created, err := ctlr.CreateBadgeCommand.Exec(r.Context(), b)
+		if err != nil {
+			writeBadgeError(w, err)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(created)
+	}
+}
+
+func writeBadgeError(w http.ResponseWriter, err error) {
+	var invalidErr *badge.InvalidBadgeError
+	var keyTakenErr *badge.BadgeKeyTakenError
+
+	switch {
+	case errors.As(err, &invalidErr):
+		http.Error(w, invalidErr.Message, http.StatusBadRequest)
+	case errors.As(err, &keyTakenErr):
+		http.Error(w, keyTakenErr.Message, http.StatusConflict)
+	default:
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,150 @@
+package cmd_controllers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/golobby/container/v3"
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	"github.com/psavelis/team-pro/replay-api/pkg/domain/consent"
+	consent_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/consent/entities"
+	consent_in "github.com/psavelis/team-pro/replay-api/pkg/domain/consent/ports/in"
+)
+
+type ConsentController struct {
+	PublishPolicyVersionCommand consent_in.PublishPolicyVersionCommand
+	AcceptPolicyCommandHandler  consent_in.AcceptPolicyCommandHandler
+	GetConsentStatusQuery       consent_in.GetConsentStatusQuery
+}
+
+type AcceptPolicyRequest struct {
+	Version int `json:"version"`
+}
+
+func NewConsentController(container *container.Container) *ConsentController {
+	var publishPolicyVersionCommand consent_in.PublishPolicyVersionCommand
+	err := container.Resolve(&publishPolicyVersionCommand)
+	if err != nil {
+		slog.Error("Cannot resolve consent_in.PublishPolicyVersionCommand for new ConsentController", "err", err)
+		panic(err)
+	}
+
+	var acceptPolicyCommandHandler consent_in.AcceptPolicyCommandHandler
+	err = container.Resolve(&acceptPolicyCommandHandler)
+	if err != nil {
+		slog.Error("Cannot resolve consent_in.AcceptPolicyCommandHandler for new ConsentController", "err", err)
+		panic(err)
+	}
+
+	var getConsentStatusQuery consent_in.GetConsentStatusQuery
+	err = container.Resolve(&getConsentStatusQuery)
+	if err != nil {
+		slog.Error("Cannot resolve consent_in.GetConsentStatusQuery for new ConsentController", "err", err)
+		panic(err)
+	}
+
+	return &ConsentController{
+		PublishPolicyVersionCommand: publishPolicyVersionCommand,
+		AcceptPolicyCommandHandler:  acceptPolicyCommandHandler,
+		GetConsentStatusQuery:       getConsentStatusQuery,
+	}
+}
+
+// StatusHandler returns the consent of the user in context to the current version of every policy (the ones pending re-acceptance).
+func (ctlr *ConsentController) StatusHandler(apiContext context.Context) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID := common.GetResourceOwner(r.Context()).UserID
+		if userID == uuid.Nil {
+			http.Error(w, "Unauthorized", http.StatusUnauthorized)
+			return
+		}
+
+		status, err := ctlr.GetConsentStatusQuery.Exec(r.Context(), userID)
+		if err != nil {
+			writeConsentError(w, err)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Cache-Control", "no-store")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(status)
+	}
+}
+
+// AcceptHandler records the acceptance of the current version of a policy by the user in context, with the IP and user agent of the request.
+func (ctlr *ConsentController) AcceptHandler(apiContext context.Context) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req AcceptPolicyRequest
+		err := json.NewDecoder(r.Body).Decode(&req)
+		if err != nil || req.Version <= 0 {
+			slog.ErrorContext(r.Context(), "invalid policy acceptance request", "err", err)
+			http.Error(w, "Bad Request", http.StatusBadRequest)
+			return
+		}
+
+		// as resolved by the ResourceContextMiddleware: the addresses of X-Forwarded-For sent by the client are ignored
+		scope, _ := common.GetRequestScope(r.Context())
+
+		record, err := ctlr.AcceptPolicyCommandHandler.Exec(r.Context(), consent_in.AcceptPolicyCommand{
+			Kind:      consent_entities.PolicyKind(mux.Vars(r)["kind"]),
+			Version:   req.Version,
+			IPAddress: scope.ClientIP,
+			UserAgent: r.UserAgent(),
+		})
+
+		if err != nil {
+			writeConsentError(w, err)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(record)
+	}
+}
+
+// PublishHandler publishes a new version of a policy.
+func (ctlr *ConsentController) PublishHandler(apiContext context.Context) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var policy consent_entities.PolicyVersion
+		err := json.NewDecoder(r.Body).Decode(&policy)
+		if err != nil {
+			slog.ErrorContext(r.Context(), "invalid policy version request", "err", err)
+			http.Error(w, "Bad Request", http.StatusBadRequest)
+			return
+		}
+
+		created, err := ctlr.PublishPolicyVersionCommand.Exec(r.Context(), policy)
+		if err != nil {
+			writeConsentError(w, err)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(created)
+	}
+}
+
+func writeConsentError(w http.ResponseWriter, err error) {
+	var invalidPolicyErr *consent.InvalidPolicyError
+	var policyNotFoundErr *consent.PolicyNotFoundError
+	var outdatedPolicyErr *consent.OutdatedPolicyError
+
+	switch {
+	case errors.As(err, &invalidPolicyErr):
+		http.Error(w, invalidPolicyErr.Message, http.StatusBadRequest)
+	case errors.As(err, &policyNotFoundErr):
+		http.Error(w, policyNotFoundErr.Message, http.StatusNotFound)
+	case errors.As(err, &outdatedPolicyErr):
+		http.Error(w, outdatedPolicyErr.Message, http.StatusConflict)
+	default:
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+	}
+}
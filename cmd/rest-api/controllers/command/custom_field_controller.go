@@ -0,0 +1,117 @@
+package cmd_controllers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/golobby/container/v3"
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/psavelis/team-pro/replay-api/pkg/domain/customfield"
+	customfield_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/customfield/entities"
+	customfield_in "github.com/psavelis/team-pro/replay-api/pkg/domain/customfield/ports/in"
+)
+
+type CustomFieldController struct {
+	DefineCustomFieldCommand      customfield_in.DefineCustomFieldCommand
+	SetCustomFieldsCommandHandler customfield_in.SetCustomFieldsCommandHandler
+}
+
+func NewCustomFieldController(container *container.Container) *CustomFieldController {
+	var defineCustomFieldCommand customfield_in.DefineCustomFieldCommand
+	err := container.Resolve(&defineCustomFieldCommand)
+	if err != nil {
+		slog.Error("Cannot resolve customfield_in.DefineCustomFieldCommand for new CustomFieldController", "err", err)
+		panic(err)
+	}
+
+	var setCustomFieldsCommandHandler customfield_in.SetCustomFieldsCommandHandler
+	err = container.Resolve(&setCustomFieldsCommandHandler)
+	if err != nil {
+		slog.Error("Cannot resolve customfield_in.SetCustomFieldsCommandHandler for new CustomFieldController", "err", err)
+		panic(err)
+	}
+
+	return &CustomFieldController{
+		DefineCustomFieldCommand:      defineCustomFieldCommand,
+		SetCustomFieldsCommandHandler: setCustomFieldsCommandHandler,
+	}
+}
+
+// DefineHandler defines (or updates) the custom field of the entity type and key of the path.
+func (ctlr *CustomFieldController) DefineHandler(apiContext context.Context) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var d customfield_entities.CustomFieldDefinition
+		err := json.NewDecoder(r.Body).Decode(&d)
+		if err != nil {
+			slog.ErrorContext(r.Context(), "invalid custom field definition request", "err", err)
+			http.Error(w, "Bad Request", http.StatusBadRequest)
+			return
+		}
+
+		d.EntityType = customfield_entities.CustomFieldEntityType(mux.Vars(r)["entity_type"])
+		d.Key = mux.Vars(r)["key"]
+
+		defined, err := ctlr.DefineCustomFieldCommand.Exec(r.Context(), d)
+		if err != nil {
+			writeCustomFieldError(w, err)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(defined)
+	}
+}
+
+// SetHandler replaces the custom fields of the entity of the type, identified by the path variable given (ie: "squad_id").
+func (ctlr *CustomFieldController) SetHandler(apiContext context.Context, entityType customfield_entities.CustomFieldEntityType, idVar string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		entityID, err := uuid.Parse(mux.Vars(r)[idVar])
+		if err != nil {
+			http.Error(w, "Bad Request", http.StatusBadRequest)
+			return
+		}
+
+		var cmd customfield_in.SetCustomFieldsCommand
+		err = json.NewDecoder(r.Body).Decode(&cmd)
+		if err != nil {
+			slog.ErrorContext(r.Context(), "invalid custom fields request", "err", err)
+			http.Error(w, "Bad Request", http.StatusBadRequest)
+			return
+		}
+
+		cmd.EntityType = entityType
+		cmd.EntityID = entityID
+
+		fields, err := ctlr.SetCustomFieldsCommandHandler.Exec(r.Context(), cmd)
+		if err != nil {
+			writeCustomFieldError(w, err)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(map[string]interface{}{"custom_fields": fields})
+	}
+}
+
+func writeCustomFieldError(w http.ResponseWriter, err error) {
+	var invalidDefinitionErr *customfield.InvalidCustomFieldDefinitionError
+	var invalidFieldsErr *customfield.InvalidCustomFieldsError
+	var notFoundErr *customfield.CustomFieldsEntityNotFoundError
+
+	switch {
+	case errors.As(err, &invalidDefinitionErr):
+		http.Error(w, invalidDefinitionErr.Message, http.StatusBadRequest)
+	case errors.As(err, &invalidFieldsErr):
+		http.Error(w, invalidFieldsErr.Message, http.StatusBadRequest)
+	case errors.As(err, &notFoundErr):
+		http.Error(w, notFoundErr.Message, http.StatusNotFound)
+	default:
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,97 @@
+package cmd_controllers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/golobby/container/v3"
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/psavelis/team-pro/replay-api/pkg/domain/notification"
+	notification_in "github.com/psavelis/team-pro/replay-api/pkg/domain/notification/ports/in"
+)
+
+type DeviceController struct {
+	RegisterDeviceTokenCommandHandler   notification_in.RegisterDeviceTokenCommandHandler
+	UnregisterDeviceTokenCommandHandler notification_in.UnregisterDeviceTokenCommandHandler
+}
+
+func NewDeviceController(container *container.Container) *DeviceController {
+	var registerDeviceTokenCommandHandler notification_in.RegisterDeviceTokenCommandHandler
+	err := container.Resolve(&registerDeviceTokenCommandHandler)
+	if err != nil {
+		slog.Error("Cannot resolve notification_in.RegisterDeviceTokenCommandHandler for new DeviceController", "err", err)
+		panic(err)
+	}
+
+	var unregisterDeviceTokenCommandHandler notification_in.UnregisterDeviceTokenCommandHandler
+	err = container.Resolve(&unregisterDeviceTokenCommandHandler)
+	if err != nil {
+		slog.Error("Cannot resolve notification_in.UnregisterDeviceTokenCommandHandler for new DeviceController", "err", err)
+		panic(err)
+	}
+
+	return &DeviceController{
+		RegisterDeviceTokenCommandHandler:   registerDeviceTokenCommandHandler,
+		UnregisterDeviceTokenCommandHandler: unregisterDeviceTokenCommandHandler,
+	}
+}
+
+// RegisterHandler registers the push token of the app install of the user in context (ie: on login, and whenever the token is refreshed).
+func (ctlr *DeviceController) RegisterHandler(apiContext context.Context) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var cmd notification_in.RegisterDeviceTokenCommand
+		err := json.NewDecoder(r.Body).Decode(&cmd)
+		if err != nil {
+			slog.ErrorContext(r.Context(), "invalid device token request", "err", err)
+			http.Error(w, "Bad Request", http.StatusBadRequest)
+			return
+		}
+
+		device, err := ctlr.RegisterDeviceTokenCommandHandler.Exec(r.Context(), cmd)
+		if err != nil {
+			writeDeviceError(w, err)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(device)
+	}
+}
+
+// UnregisterHandler stops the notifications to a device of the user in context (ie: on logout).
+func (ctlr *DeviceController) UnregisterHandler(apiContext context.Context) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		deviceTokenID, err := uuid.Parse(mux.Vars(r)["device_token_id"])
+		if err != nil {
+			http.Error(w, "Bad Request", http.StatusBadRequest)
+			return
+		}
+
+		err = ctlr.UnregisterDeviceTokenCommandHandler.Exec(r.Context(), deviceTokenID)
+		if err != nil {
+			writeDeviceError(w, err)
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func writeDeviceError(w http.ResponseWriter, err error) {
+	var invalidTokenErr *notification.InvalidDeviceTokenError
+	var notFoundErr *notification.DeviceTokenNotFoundError
+
+	switch {
+	case errors.As(err, &invalidTokenErr):
+		http.Error(w, invalidTokenErr.Message, http.StatusBadRequest)
+	case errors.As(err, &notFoundErr):
+		http.Error(w, notFoundErr.Message, http.StatusNotFound)
+	default:
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,115 @@
+package cmd_controllers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/golobby/container/v3"
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	"github.com/psavelis/team-pro/replay-api/pkg/domain/email"
+	email_in "github.com/psavelis/team-pro/replay-api/pkg/domain/email/ports/in"
+)
+
+type EmailController struct {
+	RequestMagicLinkCommandHandler email_in.RequestMagicLinkCommandHandler
+	VerifyMagicLinkCommandHandler  email_in.VerifyMagicLinkCommandHandler
+}
+
+type VerifyMagicLinkRequest struct {
+	Token string `json:"token"`
+}
+
+func NewEmailController(container *container.Container) *EmailController {
+	var requestMagicLinkCommandHandler email_in.RequestMagicLinkCommandHandler
+	err := container.Resolve(&requestMagicLinkCommandHandler)
+	if err != nil {
+		slog.Error("Cannot resolve email_in.RequestMagicLinkCommandHandler for new EmailController", "err", err)
+		panic(err)
+	}
+
+	var verifyMagicLinkCommandHandler email_in.VerifyMagicLinkCommandHandler
+	err = container.Resolve(&verifyMagicLinkCommandHandler)
+	if err != nil {
+		slog.Error("Cannot resolve email_in.VerifyMagicLinkCommandHandler for new EmailController", "err", err)
+		panic(err)
+	}
+
+	return &EmailController{
+		RequestMagicLinkCommandHandler: requestMagicLinkCommandHandler,
+		VerifyMagicLinkCommandHandler:  verifyMagicLinkCommandHandler,
+	}
+}
+
+// RequestMagicLinkHandler emails a sign in link to the address (signing up its owner on the first login). The response doesn't tell
+// whether the address is already onboarded.
+func (ctlr *EmailController) RequestMagicLinkHandler(apiContext context.Context) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var cmd email_in.RequestMagicLinkCommand
+		err := json.NewDecoder(r.Body).Decode(&cmd)
+		if err != nil {
+			slog.ErrorContext(r.Context(), "invalid magic link request", "err", err)
+			http.Error(w, "Bad Request", http.StatusBadRequest)
+			return
+		}
+
+		// as resolved by the ResourceContextMiddleware: the addresses of X-Forwarded-For sent by the client are ignored
+		scope, _ := common.GetRequestScope(r.Context())
+		cmd.IPAddress = scope.ClientIP
+
+		err = ctlr.RequestMagicLinkCommandHandler.Exec(r.Context(), cmd)
+		if err != nil {
+			writeEmailError(w, err)
+			return
+		}
+
+		w.WriteHeader(http.StatusAccepted)
+	}
+}
+
+// VerifyMagicLinkHandler logs in with the token of a magic link, returning the profile and its RID (X-Resource-Owner-ID header).
+func (ctlr *EmailController) VerifyMagicLinkHandler(apiContext context.Context) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req VerifyMagicLinkRequest
+		err := json.NewDecoder(r.Body).Decode(&req)
+		if err != nil || req.Token == "" {
+			slog.ErrorContext(r.Context(), "invalid magic link verification request", "err", err)
+			http.Error(w, "Bad Request", http.StatusBadRequest)
+			return
+		}
+
+		profile, ridToken, err := ctlr.VerifyMagicLinkCommandHandler.Exec(r.Context(), req.Token)
+		if err != nil {
+			writeEmailError(w, err)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Cache-Control", "no-store")
+		w.Header().Set("X-Resource-Owner-ID", ridToken.GetID().String())
+		w.Header().Set("X-Refresh-Token", ridToken.RefreshKey)
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(profile)
+	}
+}
+
+func writeEmailError(w http.ResponseWriter, err error) {
+	var invalidEmailErr *email.InvalidEmailError
+	var rateLimitedErr *email.EmailRateLimitedError
+	var invalidTokenErr *email.InvalidLoginTokenError
+
+	switch {
+	case errors.As(err, &invalidEmailErr):
+		http.Error(w, invalidEmailErr.Message, http.StatusBadRequest)
+	case errors.As(err, &rateLimitedErr):
+		w.Header().Set("Retry-After", strconv.Itoa(int(rateLimitedErr.RetryAfter.Seconds())))
+		http.Error(w, rateLimitedErr.Message, http.StatusTooManyRequests)
+	case errors.As(err, &invalidTokenErr):
+		http.Error(w, invalidTokenErr.Message, http.StatusUnauthorized)
+	default:
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,60 @@
+package cmd_controllers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/golobby/container/v3"
+	"github.com/psavelis/team-pro/replay-api/pkg/domain/export"
+	export_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/export/entities"
+	export_in "github.com/psavelis/team-pro/replay-api/pkg/domain/export/ports/in"
+)
+
+type ExportController struct {
+	CreateExportConfigCommand export_in.CreateExportConfigCommand
+}
+
+func NewExportController(container *container.Container) *ExportController {
+	var createExportConfigCommand export_in.CreateExportConfigCommand
+	err := container.Resolve(&createExportConfigCommand)
+	if err != nil {
+		slog.Error("Cannot resolve export_in.CreateExportConfigCommand for new ExportController", "err", err)
+		panic(err)
+	}
+
+	return &ExportController{
+		CreateExportConfigCommand: createExportConfigCommand,
+	}
+}
+
+// CreateHandler creates a nightly export of the client application, returning the external ID its destination role has to require.
+func (ctlr *ExportController) CreateHandler(apiContext context.Context) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var config export_entities.ExportConfig
+		err := json.NewDecoder(r.Body).Decode(&config)
+		if err != nil {
+			slog.ErrorContext(r.Context(), "invalid export config request", "err", err)
+			http.Error(w, "Bad Request", http.StatusBadRequest)
+			return
+		}
+
+		created, err := ctlr.CreateExportConfigCommand.Exec(r.Context(), config)
+		if err != nil {
+			var invalidConfigErr *export.InvalidExportConfigError
+			if errors.As(err, &invalidConfigErr) {
+				http.Error(w, invalidConfigErr.Message, http.StatusBadRequest)
+				return
+			}
+
+			http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(created)
+	}
+}
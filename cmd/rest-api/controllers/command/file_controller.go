@@ -3,12 +3,19 @@ package cmd_controllers
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"log/slog"
 	"net/http"
 
 	"github.com/golobby/container/v3"
+	"github.com/gorilla/mux"
 	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	"github.com/psavelis/team-pro/replay-api/pkg/domain/faceit"
+	faceit_in "github.com/psavelis/team-pro/replay-api/pkg/domain/faceit/ports/in"
 	replay_in "github.com/psavelis/team-pro/replay-api/pkg/domain/replay/ports/in"
+	"github.com/psavelis/team-pro/replay-api/pkg/domain/steam"
+	steam_in "github.com/psavelis/team-pro/replay-api/pkg/domain/steam/ports/in"
+	"github.com/psavelis/team-pro/replay-api/pkg/infra/clients"
 )
 
 type FileController struct {
@@ -31,6 +38,17 @@ func (ctlr *FileController) UploadHandler(apiContext context.Context) http.Handl
 
 		slog.InfoContext(reqContext, "Receiving file", string(common.GameIDParamKey), r.FormValue("game_id"))
 
+		// the default visibility of the tenant (or group) applies unless another is requested
+		visibility := common.Visibility(r.FormValue("visibility"))
+		if visibility != "" {
+			if !visibility.Known() {
+				http.Error(w, "unknown visibility '"+string(visibility)+"'", http.StatusBadRequest)
+				return
+			}
+
+			reqContext = context.WithValue(reqContext, common.VisibilityParamKey, visibility)
+		}
+
 		file, _, err := r.FormFile("file")
 		if err != nil {
 			slog.ErrorContext(reqContext, "Failed to get file", "err", err)
@@ -39,6 +57,43 @@ func (ctlr *FileController) UploadHandler(apiContext context.Context) http.Handl
 		}
 		defer file.Close()
 
+		var config common.Config
+		err = ctlr.container.Resolve(&config)
+		if err != nil {
+			slog.ErrorContext(reqContext, "Failed to resolve config", "err", err)
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+
+		// with a broker the replay is processed by the replay workers: the replay file (Pending) is returned right away
+		if config.RabbitMQ.URL != "" {
+			var submitReplayFileCommand replay_in.SubmitReplayFileCommand
+			err = ctlr.container.Resolve(&submitReplayFileCommand)
+			if err != nil {
+				slog.ErrorContext(reqContext, "Failed to resolve submitReplayFileCommand", "err", err)
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+
+			replayFile, err := submitReplayFileCommand.Exec(reqContext, file)
+			if err != nil {
+				slog.ErrorContext(reqContext, "Failed to submit file", "err", err)
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+
+			w.Header().Set("Content-Type", "application/json")
+			w.Header().Set("Location", r.URL.Path+"/"+replayFile.ID.String())
+			w.WriteHeader(http.StatusAccepted)
+
+			err = json.NewEncoder(w).Encode(replayFile)
+			if err != nil {
+				slog.ErrorContext(reqContext, "Failed to encode response", "err", err, "replayFileID", replayFile.ID)
+			}
+
+			return
+		}
+
 		var uploadAndProcessReplayFileCommand replay_in.UploadAndProcessReplayFileCommand
 		err = ctlr.container.Resolve(&uploadAndProcessReplayFileCommand)
 		if err != nil {
@@ -67,6 +122,127 @@ func (ctlr *FileController) UploadHandler(apiContext context.Context) http.Handl
 	}
 }
 
+type ImportShareCodeRequest struct {
+	ShareCode string `json:"share_code"`
+}
+
+// ImportShareCodeHandler imports a CS2 matchmaking match from its share code (CSGO-xxxxx-...) instead of an uploaded file: the demo is
+// downloaded from Valve and processed in-request, like an upload without a broker.
+func (ctlr *FileController) ImportShareCodeHandler(apiContext context.Context) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		reqContext := context.WithValue(r.Context(), common.GameIDParamKey, mux.Vars(r)["game_id"])
+
+		var req ImportShareCodeRequest
+		err := json.NewDecoder(r.Body).Decode(&req)
+		if err != nil || req.ShareCode == "" {
+			slog.ErrorContext(reqContext, "invalid share code import request", "err", err)
+			http.Error(w, "Bad Request", http.StatusBadRequest)
+			return
+		}
+
+		var importSteamMatchCommand steam_in.ImportSteamMatchCommand
+		err = ctlr.container.Resolve(&importSteamMatchCommand)
+		if err != nil {
+			slog.ErrorContext(reqContext, "Failed to resolve importSteamMatchCommand", "err", err)
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+
+		match, err := importSteamMatchCommand.Exec(reqContext, req.ShareCode)
+		if err != nil {
+			var invalidShareCodeErr *steam.InvalidShareCodeError
+			var notFoundErr *steam.SteamMatchNotFoundError
+
+			switch {
+			case errors.As(err, &invalidShareCodeErr):
+				http.Error(w, invalidShareCodeErr.Message, http.StatusBadRequest)
+			case errors.As(err, &notFoundErr):
+				http.Error(w, notFoundErr.Message, http.StatusNotFound)
+			case errors.Is(err, clients.ErrSteamGCDisabled):
+				http.Error(w, err.Error(), http.StatusServiceUnavailable)
+			default:
+				slog.ErrorContext(reqContext, "Failed to import share code", "err", err, "shareCode", req.ShareCode)
+				w.WriteHeader(http.StatusInternalServerError)
+			}
+
+			return
+		}
+
+		match.Events = nil
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Location", "/games/"+mux.Vars(r)["game_id"]+"/match/"+match.ID.String())
+		w.WriteHeader(http.StatusCreated)
+
+		err = json.NewEncoder(w).Encode(match)
+		if err != nil {
+			slog.ErrorContext(reqContext, "Failed to encode response", "err", err, "matchID", match.ID)
+		}
+	}
+}
+
+type ImportFaceitMatchRequest struct {
+	Match string `json:"match"` // room URL or match ID
+}
+
+// ImportFaceitMatchHandler imports a FACEIT match from its room URL or match ID: the demo is downloaded through the FACEIT API and
+// processed in-request, and the match linked to the FACEIT accounts of its players.
+func (ctlr *FileController) ImportFaceitMatchHandler(apiContext context.Context) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		reqContext := context.WithValue(r.Context(), common.GameIDParamKey, mux.Vars(r)["game_id"])
+
+		var req ImportFaceitMatchRequest
+		err := json.NewDecoder(r.Body).Decode(&req)
+		if err != nil || req.Match == "" {
+			slog.ErrorContext(reqContext, "invalid faceit import request", "err", err)
+			http.Error(w, "Bad Request", http.StatusBadRequest)
+			return
+		}
+
+		var importFaceitMatchCommand faceit_in.ImportFaceitMatchCommand
+		err = ctlr.container.Resolve(&importFaceitMatchCommand)
+		if err != nil {
+			slog.ErrorContext(reqContext, "Failed to resolve importFaceitMatchCommand", "err", err)
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+
+		match, err := importFaceitMatchCommand.Exec(reqContext, req.Match)
+		if err != nil {
+			var invalidMatchErr *faceit.InvalidFaceitMatchError
+			var notFoundErr *faceit.FaceitMatchNotFoundError
+			var notFinishedErr *faceit.FaceitMatchNotFinishedError
+
+			switch {
+			case errors.As(err, &invalidMatchErr):
+				http.Error(w, invalidMatchErr.Message, http.StatusBadRequest)
+			case errors.As(err, &notFoundErr):
+				http.Error(w, notFoundErr.Message, http.StatusNotFound)
+			case errors.As(err, &notFinishedErr):
+				http.Error(w, notFinishedErr.Message, http.StatusConflict)
+			case errors.Is(err, clients.ErrFaceitDisabled):
+				http.Error(w, err.Error(), http.StatusServiceUnavailable)
+			default:
+				slog.ErrorContext(reqContext, "Failed to import faceit match", "err", err, "match", req.Match)
+				w.WriteHeader(http.StatusInternalServerError)
+			}
+
+			return
+		}
+
+		match.Events = nil
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Location", "/games/"+mux.Vars(r)["game_id"]+"/match/"+match.ID.String())
+		w.WriteHeader(http.StatusCreated)
+
+		err = json.NewEncoder(w).Encode(match)
+		if err != nil {
+			slog.ErrorContext(reqContext, "Failed to encode response", "err", err, "matchID", match.ID)
+		}
+	}
+}
+
 // func (ctlr *FileController) ReplayMetadataFilterHandler(apiContext context.Context) http.HandlerFunc {
 // 	return func(w http.ResponseWriter, r *http.Request) {
 // 		w.Header().Set("Access-Control-Allow-Origin", "localhost:3000")
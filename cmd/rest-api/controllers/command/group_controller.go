@@ -0,0 +1,156 @@
+package cmd_controllers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/golobby/container/v3"
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/psavelis/team-pro/replay-api/pkg/domain/iam"
+	iam_in "github.com/psavelis/team-pro/replay-api/pkg/domain/iam/ports/in"
+)
+
+type GroupController struct {
+	CreateGroupCommandHandler    iam_in.CreateGroupCommandHandler
+	SetGroupParentCommandHandler iam_in.SetGroupParentCommandHandler
+	SetGroupMemberCommandHandler iam_in.SetGroupMemberCommandHandler
+}
+
+func NewGroupController(container *container.Container) *GroupController {
+	var createGroupCommandHandler iam_in.CreateGroupCommandHandler
+	err := container.Resolve(&createGroupCommandHandler)
+	if err != nil {
+		slog.Error("Cannot resolve iam_in.CreateGroupCommandHandler for new GroupController", "err", err)
+		panic(err)
+	}
+
+	var setGroupParentCommandHandler iam_in.SetGroupParentCommandHandler
+	err = container.Resolve(&setGroupParentCommandHandler)
+	if err != nil {
+		slog.Error("Cannot resolve iam_in.SetGroupParentCommandHandler for new GroupController", "err", err)
+		panic(err)
+	}
+
+	var setGroupMemberCommandHandler iam_in.SetGroupMemberCommandHandler
+	err = container.Resolve(&setGroupMemberCommandHandler)
+	if err != nil {
+		slog.Error("Cannot resolve iam_in.SetGroupMemberCommandHandler for new GroupController", "err", err)
+		panic(err)
+	}
+
+	return &GroupController{
+		CreateGroupCommandHandler:    createGroupCommandHandler,
+		SetGroupParentCommandHandler: setGroupParentCommandHandler,
+		SetGroupMemberCommandHandler: setGroupMemberCommandHandler,
+	}
+}
+
+// CreateHandler creates a group (a subgroup with a parent_group_id) owned by the user in context.
+func (ctlr *GroupController) CreateHandler(apiContext context.Context) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var cmd iam_in.CreateGroupCommand
+		err := json.NewDecoder(r.Body).Decode(&cmd)
+		if err != nil {
+			http.Error(w, "Bad Request", http.StatusBadRequest)
+			return
+		}
+
+		group, err := ctlr.CreateGroupCommandHandler.Exec(r.Context(), cmd)
+		if err != nil {
+			writeGroupError(w, err)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(group)
+	}
+}
+
+// SetParentHandler moves a group (with its subgroups) under the parent_group_id, or to the root of the hierarchy when null.
+func (ctlr *GroupController) SetParentHandler(apiContext context.Context) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		groupID, err := uuid.Parse(mux.Vars(r)["group_id"])
+		if err != nil {
+			http.Error(w, "Bad Request", http.StatusBadRequest)
+			return
+		}
+
+		var cmd iam_in.SetGroupParentCommand
+		err = json.NewDecoder(r.Body).Decode(&cmd)
+		if err != nil {
+			http.Error(w, "Bad Request", http.StatusBadRequest)
+			return
+		}
+
+		cmd.GroupID = groupID
+
+		group, err := ctlr.SetGroupParentCommandHandler.Exec(r.Context(), cmd)
+		if err != nil {
+			writeGroupError(w, err)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(group)
+	}
+}
+
+// SetMemberHandler adds a user to the group, or changes the type of their membership.
+func (ctlr *GroupController) SetMemberHandler(apiContext context.Context) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		groupID, err := uuid.Parse(mux.Vars(r)["group_id"])
+		if err != nil {
+			http.Error(w, "Bad Request", http.StatusBadRequest)
+			return
+		}
+
+		userID, err := uuid.Parse(mux.Vars(r)["user_id"])
+		if err != nil {
+			http.Error(w, "Bad Request", http.StatusBadRequest)
+			return
+		}
+
+		var cmd iam_in.SetGroupMemberCommand
+		err = json.NewDecoder(r.Body).Decode(&cmd)
+		if err != nil {
+			http.Error(w, "Bad Request", http.StatusBadRequest)
+			return
+		}
+
+		cmd.GroupID = groupID
+		cmd.UserID = userID
+
+		membership, err := ctlr.SetGroupMemberCommandHandler.Exec(r.Context(), cmd)
+		if err != nil {
+			writeGroupError(w, err)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(membership)
+	}
+}
+
+func writeGroupError(w http.ResponseWriter, err error) {
+	var notFoundErr *iam.GroupNotFoundError
+	var invalidErr *iam.InvalidGroupError
+	var deniedErr *iam.GroupAccessDeniedError
+
+	switch {
+	case errors.As(err, &notFoundErr):
+		http.Error(w, notFoundErr.Message, http.StatusNotFound)
+	case errors.As(err, &invalidErr):
+		http.Error(w, invalidErr.Message, http.StatusBadRequest)
+	case errors.As(err, &deniedErr):
+		http.Error(w, deniedErr.Message, http.StatusForbidden)
+	default:
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,321 @@
+package cmd_controllers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/golobby/container/v3"
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/psavelis/team-pro/replay-api/pkg/domain/matchmaking"
+	matchmaking_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/matchmaking/entities"
+	matchmaking_in "github.com/psavelis/team-pro/replay-api/pkg/domain/matchmaking/ports/in"
+)
+
+type LobbyController struct {
+	GetLobbyQuery            matchmaking_in.GetLobbyQuery
+	RespondReadyCheckCommand matchmaking_in.RespondReadyCheckCommand
+	StartCaptainDraftCommand matchmaking_in.StartCaptainDraftCommand
+	PickDraftPlayerCommand   matchmaking_in.PickDraftPlayerCommand
+	LinkLobbyMatchCommand    matchmaking_in.LinkLobbyMatchCommand
+	AbandonLobbyCommand      matchmaking_in.AbandonLobbyCommand
+	ReportLobbyResultCommand matchmaking_in.ReportLobbyResultCommand
+}
+
+type ReadyCheckRequest struct {
+	Accept *bool `json:"accept"`
+}
+
+type PickDraftPlayerRequest struct {
+	UserID uuid.UUID `json:"user_id"`
+}
+
+type LinkLobbyMatchRequest struct {
+	MatchID uuid.UUID `json:"match_id"`
+}
+
+type AbandonLobbyRequest struct {
+	UserID uuid.UUID `json:"user_id"`
+}
+
+type ReportLobbyResultRequest struct {
+	Teams []matchmaking_entities.LobbyTeamResult `json:"teams"`
+}
+
+func NewLobbyController(container *container.Container) *LobbyController {
+	var getLobbyQuery matchmaking_in.GetLobbyQuery
+	err := container.Resolve(&getLobbyQuery)
+	if err != nil {
+		slog.Error("Cannot resolve matchmaking_in.GetLobbyQuery for new LobbyController", "err", err)
+		panic(err)
+	}
+
+	var respondReadyCheckCommand matchmaking_in.RespondReadyCheckCommand
+	err = container.Resolve(&respondReadyCheckCommand)
+	if err != nil {
+		slog.Error("Cannot resolve matchmaking_in.RespondReadyCheckCommand for new LobbyController", "err", err)
+		panic(err)
+	}
+
+	var startCaptainDraftCommand matchmaking_in.StartCaptainDraftCommand
+	err = container.Resolve(&startCaptainDraftCommand)
+	if err != nil {
+		slog.Error("Cannot resolve matchmaking_in.StartCaptainDraftCommand for new LobbyController", "err", err)
+		panic(err)
+	}
+
+	var pickDraftPlayerCommand matchmaking_in.PickDraftPlayerCommand
+	err = container.Resolve(&pickDraftPlayerCommand)
+	if err != nil {
+		slog.Error("Cannot resolve matchmaking_in.PickDraftPlayerCommand for new LobbyController", "err", err)
+		panic(err)
+	}
+
+	var linkLobbyMatchCommand matchmaking_in.LinkLobbyMatchCommand
+	err = container.Resolve(&linkLobbyMatchCommand)
+	if err != nil {
+		slog.Error("Cannot resolve matchmaking_in.LinkLobbyMatchCommand for new LobbyController", "err", err)
+		panic(err)
+	}
+
+	var abandonLobbyCommand matchmaking_in.AbandonLobbyCommand
+	err = container.Resolve(&abandonLobbyCommand)
+	if err != nil {
+		slog.Error("Cannot resolve matchmaking_in.AbandonLobbyCommand for new LobbyController", "err", err)
+		panic(err)
+	}
+
+	var reportLobbyResultCommand matchmaking_in.ReportLobbyResultCommand
+	err = container.Resolve(&reportLobbyResultCommand)
+	if err != nil {
+		slog.Error("Cannot resolve matchmaking_in.ReportLobbyResultCommand for new LobbyController", "err", err)
+		panic(err)
+	}
+
+	return &LobbyController{
+		GetLobbyQuery:            getLobbyQuery,
+		RespondReadyCheckCommand: respondReadyCheckCommand,
+		StartCaptainDraftCommand: startCaptainDraftCommand,
+		PickDraftPlayerCommand:   pickDraftPlayerCommand,
+		LinkLobbyMatchCommand:    linkLobbyMatchCommand,
+		AbandonLobbyCommand:      abandonLobbyCommand,
+		ReportLobbyResultCommand: reportLobbyResultCommand,
+	}
+}
+
+// GetLobbyHandler returns the lobby (including the draft state and pick deadline) to its players. While drafting, clients follow its
+// changes on the draft stream instead.
+func (ctlr *LobbyController) GetLobbyHandler(apiContext context.Context) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		lobbyID, ok := parseLobbyID(w, r)
+		if !ok {
+			return
+		}
+
+		lobby, err := ctlr.GetLobbyQuery.Exec(r.Context(), lobbyID)
+		if err != nil {
+			writeLobbyError(w, err)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(lobby)
+	}
+}
+
+// ReadyCheckHandler accepts or declines the match on behalf of the player in context. Declining cancels the lobby (the other players
+// are queued again) and puts the player in a queue cooldown.
+func (ctlr *LobbyController) ReadyCheckHandler(apiContext context.Context) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		lobbyID, ok := parseLobbyID(w, r)
+		if !ok {
+			return
+		}
+
+		var req ReadyCheckRequest
+		err := json.NewDecoder(r.Body).Decode(&req)
+		if err != nil || req.Accept == nil {
+			slog.ErrorContext(r.Context(), "invalid ready check request", "err", err)
+			http.Error(w, "Bad Request", http.StatusBadRequest)
+			return
+		}
+
+		lobby, err := ctlr.RespondReadyCheckCommand.Exec(r.Context(), lobbyID, *req.Accept)
+		if err != nil {
+			writeLobbyError(w, err)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(lobby)
+	}
+}
+
+func (ctlr *LobbyController) StartDraftHandler(apiContext context.Context) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		lobbyID, ok := parseLobbyID(w, r)
+		if !ok {
+			return
+		}
+
+		lobby, err := ctlr.StartCaptainDraftCommand.Exec(r.Context(), lobbyID)
+		if err != nil {
+			writeLobbyError(w, err)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(lobby)
+	}
+}
+
+func (ctlr *LobbyController) PickHandler(apiContext context.Context) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		lobbyID, ok := parseLobbyID(w, r)
+		if !ok {
+			return
+		}
+
+		var req PickDraftPlayerRequest
+		err := json.NewDecoder(r.Body).Decode(&req)
+		if err != nil || req.UserID == uuid.Nil {
+			slog.ErrorContext(r.Context(), "invalid draft pick request", "err", err)
+			http.Error(w, "Bad Request", http.StatusBadRequest)
+			return
+		}
+
+		lobby, err := ctlr.PickDraftPlayerCommand.Exec(r.Context(), lobbyID, req.UserID)
+		if err != nil {
+			writeLobbyError(w, err)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(lobby)
+	}
+}
+
+func (ctlr *LobbyController) LinkMatchHandler(apiContext context.Context) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		lobbyID, ok := parseLobbyID(w, r)
+		if !ok {
+			return
+		}
+
+		var req LinkLobbyMatchRequest
+		err := json.NewDecoder(r.Body).Decode(&req)
+		if err != nil || req.MatchID == uuid.Nil {
+			slog.ErrorContext(r.Context(), "invalid lobby match request", "err", err)
+			http.Error(w, "Bad Request", http.StatusBadRequest)
+			return
+		}
+
+		lobby, err := ctlr.LinkLobbyMatchCommand.Exec(r.Context(), lobbyID, req.MatchID)
+		if err != nil {
+			writeLobbyError(w, err)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(lobby)
+	}
+}
+
+// AbandonHandler reports a player leaving a ready or ongoing match: the player itself, or the leader on their behalf (ie: disconnected).
+func (ctlr *LobbyController) AbandonHandler(apiContext context.Context) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		lobbyID, ok := parseLobbyID(w, r)
+		if !ok {
+			return
+		}
+
+		var req AbandonLobbyRequest
+		err := json.NewDecoder(r.Body).Decode(&req)
+		if err != nil || req.UserID == uuid.Nil {
+			slog.ErrorContext(r.Context(), "invalid lobby abandon request", "err", err)
+			http.Error(w, "Bad Request", http.StatusBadRequest)
+			return
+		}
+
+		lobby, err := ctlr.AbandonLobbyCommand.Exec(r.Context(), lobbyID, req.UserID)
+		if err != nil {
+			writeLobbyError(w, err)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(lobby)
+	}
+}
+
+// ReportResultHandler completes the ongoing match of the lobby with the final score of each team, reported by the leader.
+// The players (and the squads they played as) are rated from it.
+func (ctlr *LobbyController) ReportResultHandler(apiContext context.Context) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		lobbyID, ok := parseLobbyID(w, r)
+		if !ok {
+			return
+		}
+
+		var req ReportLobbyResultRequest
+		err := json.NewDecoder(r.Body).Decode(&req)
+		if err != nil || len(req.Teams) == 0 {
+			slog.ErrorContext(r.Context(), "invalid lobby result request", "err", err)
+			http.Error(w, "Bad Request", http.StatusBadRequest)
+			return
+		}
+
+		lobby, err := ctlr.ReportLobbyResultCommand.Exec(r.Context(), lobbyID, req.Teams)
+		if err != nil {
+			writeLobbyError(w, err)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(lobby)
+	}
+}
+
+func parseLobbyID(w http.ResponseWriter, r *http.Request) (uuid.UUID, bool) {
+	lobbyID, err := uuid.Parse(mux.Vars(r)["lobby_id"])
+	if err != nil {
+		slog.ErrorContext(r.Context(), "invalid lobby_id", "err", err, "lobby_id", mux.Vars(r)["lobby_id"])
+		http.Error(w, "Bad Request", http.StatusBadRequest)
+		return uuid.Nil, false
+	}
+
+	return lobbyID, true
+}
+
+func writeLobbyError(w http.ResponseWriter, err error) {
+	var notFoundErr *matchmaking.LobbyNotFoundError
+	var matchNotFoundErr *matchmaking.MatchNotFoundError
+	var matchLinkedErr *matchmaking.MatchLinkedError
+	var forbiddenErr *matchmaking.LobbyForbiddenError
+	var stateErr *matchmaking.LobbyStateError
+
+	switch {
+	case errors.As(err, &notFoundErr):
+		http.Error(w, notFoundErr.Message, http.StatusNotFound)
+	case errors.As(err, &matchNotFoundErr):
+		http.Error(w, matchNotFoundErr.Message, http.StatusNotFound)
+	case errors.As(err, &matchLinkedErr):
+		http.Error(w, matchLinkedErr.Message, http.StatusConflict)
+	case errors.As(err, &forbiddenErr):
+		http.Error(w, forbiddenErr.Message, http.StatusForbidden)
+	case errors.As(err, &stateErr):
+		http.Error(w, stateErr.Message, http.StatusConflict)
+	default:
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+	}
+}
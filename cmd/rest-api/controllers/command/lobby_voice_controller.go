@@ -0,0 +1,93 @@
+package cmd_controllers
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+
+	"github.com/golobby/container/v3"
+	"github.com/google/uuid"
+	matchmaking_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/matchmaking/entities"
+	matchmaking_in "github.com/psavelis/team-pro/replay-api/pkg/domain/matchmaking/ports/in"
+)
+
+type LobbyVoiceController struct {
+	JoinLobbyVoiceCommand     matchmaking_in.JoinLobbyVoiceCommand
+	ModerateLobbyVoiceCommand matchmaking_in.ModerateLobbyVoiceCommand
+}
+
+type ModerateLobbyVoiceRequest struct {
+	UserID uuid.UUID                                  `json:"user_id"`
+	Action matchmaking_entities.VoiceModerationAction `json:"action"`
+}
+
+func NewLobbyVoiceController(container *container.Container) *LobbyVoiceController {
+	var joinLobbyVoiceCommand matchmaking_in.JoinLobbyVoiceCommand
+	err := container.Resolve(&joinLobbyVoiceCommand)
+	if err != nil {
+		slog.Error("Cannot resolve matchmaking_in.JoinLobbyVoiceCommand for new LobbyVoiceController", "err", err)
+		panic(err)
+	}
+
+	var moderateLobbyVoiceCommand matchmaking_in.ModerateLobbyVoiceCommand
+	err = container.Resolve(&moderateLobbyVoiceCommand)
+	if err != nil {
+		slog.Error("Cannot resolve matchmaking_in.ModerateLobbyVoiceCommand for new LobbyVoiceController", "err", err)
+		panic(err)
+	}
+
+	return &LobbyVoiceController{
+		JoinLobbyVoiceCommand:     joinLobbyVoiceCommand,
+		ModerateLobbyVoiceCommand: moderateLobbyVoiceCommand,
+	}
+}
+
+// JoinHandler returns a voice channel invite (provider URL + short lived token) for the lobby player in context.
+func (ctlr *LobbyVoiceController) JoinHandler(apiContext context.Context) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		lobbyID, ok := parseLobbyID(w, r)
+		if !ok {
+			return
+		}
+
+		invite, err := ctlr.JoinLobbyVoiceCommand.Exec(r.Context(), lobbyID)
+		if err != nil {
+			writeLobbyError(w, err)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Cache-Control", "no-store")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(invite)
+	}
+}
+
+// ModerateHandler mutes, unmutes or kicks a player from the lobby voice channel (lobby leader only).
+func (ctlr *LobbyVoiceController) ModerateHandler(apiContext context.Context) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		lobbyID, ok := parseLobbyID(w, r)
+		if !ok {
+			return
+		}
+
+		var req ModerateLobbyVoiceRequest
+		err := json.NewDecoder(r.Body).Decode(&req)
+		if err != nil || req.UserID == uuid.Nil || req.Action == "" {
+			slog.ErrorContext(r.Context(), "invalid lobby voice moderation request", "err", err)
+			http.Error(w, "Bad Request", http.StatusBadRequest)
+			return
+		}
+
+		lobby, err := ctlr.ModerateLobbyVoiceCommand.Exec(r.Context(), lobbyID, req.UserID, req.Action)
+		if err != nil {
+			writeLobbyError(w, err)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(lobby.Voice)
+	}
+}
@@ -0,0 +1,324 @@
+package cmd_controllers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/golobby/container/v3"
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/psavelis/team-pro/replay-api/pkg/domain/matchmaking"
+	matchmaking_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/matchmaking/entities"
+	matchmaking_in "github.com/psavelis/team-pro/replay-api/pkg/domain/matchmaking/ports/in"
+)
+
+type MatchmakingController struct {
+	CreateMatchmakingPoolCommand matchmaking_in.CreateMatchmakingPoolCommand
+	UpdatePoolStrategyCommand    matchmaking_in.UpdatePoolStrategyCommand
+	UpdatePoolScheduleCommand    matchmaking_in.UpdatePoolScheduleCommand
+	DrainQueueCommand            matchmaking_in.DrainQueueCommand
+	CreateEconomyConfigCommand   matchmaking_in.CreateEconomyConfigCommand
+	EnqueuePlayerCommandHandler  matchmaking_in.EnqueuePlayerCommandHandler
+	LeaveQueueCommand            matchmaking_in.LeaveQueueCommand
+	GetQueueStatusQuery          matchmaking_in.GetQueueStatusQuery
+}
+
+type UpdatePoolStrategyRequest struct {
+	Strategy         string   `json:"strategy"`
+	ShadowStrategies []string `json:"shadow_strategies"`
+}
+
+type drainQueueResponse struct {
+	Cancelled int `json:"cancelled"`
+}
+
+func NewMatchmakingController(container *container.Container) *MatchmakingController {
+	var createMatchmakingPoolCommand matchmaking_in.CreateMatchmakingPoolCommand
+	err := container.Resolve(&createMatchmakingPoolCommand)
+	if err != nil {
+		slog.Error("Cannot resolve matchmaking_in.CreateMatchmakingPoolCommand for new MatchmakingController", "err", err)
+		panic(err)
+	}
+
+	var updatePoolStrategyCommand matchmaking_in.UpdatePoolStrategyCommand
+	err = container.Resolve(&updatePoolStrategyCommand)
+	if err != nil {
+		slog.Error("Cannot resolve matchmaking_in.UpdatePoolStrategyCommand for new MatchmakingController", "err", err)
+		panic(err)
+	}
+
+	var updatePoolScheduleCommand matchmaking_in.UpdatePoolScheduleCommand
+	err = container.Resolve(&updatePoolScheduleCommand)
+	if err != nil {
+		slog.Error("Cannot resolve matchmaking_in.UpdatePoolScheduleCommand for new MatchmakingController", "err", err)
+		panic(err)
+	}
+
+	var drainQueueCommand matchmaking_in.DrainQueueCommand
+	err = container.Resolve(&drainQueueCommand)
+	if err != nil {
+		slog.Error("Cannot resolve matchmaking_in.DrainQueueCommand for new MatchmakingController", "err", err)
+		panic(err)
+	}
+
+	var createEconomyConfigCommand matchmaking_in.CreateEconomyConfigCommand
+	err = container.Resolve(&createEconomyConfigCommand)
+	if err != nil {
+		slog.Error("Cannot resolve matchmaking_in.CreateEconomyConfigCommand for new MatchmakingController", "err", err)
+		panic(err)
+	}
+
+	var enqueuePlayerCommandHandler matchmaking_in.EnqueuePlayerCommandHandler
+	err = container.Resolve(&enqueuePlayerCommandHandler)
+	if err != nil {
+		slog.Error("Cannot resolve matchmaking_in.EnqueuePlayerCommandHandler for new MatchmakingController", "err", err)
+		panic(err)
+	}
+
+	var leaveQueueCommand matchmaking_in.LeaveQueueCommand
+	err = container.Resolve(&leaveQueueCommand)
+	if err != nil {
+		slog.Error("Cannot resolve matchmaking_in.LeaveQueueCommand for new MatchmakingController", "err", err)
+		panic(err)
+	}
+
+	var getQueueStatusQuery matchmaking_in.GetQueueStatusQuery
+	err = container.Resolve(&getQueueStatusQuery)
+	if err != nil {
+		slog.Error("Cannot resolve matchmaking_in.GetQueueStatusQuery for new MatchmakingController", "err", err)
+		panic(err)
+	}
+
+	return &MatchmakingController{
+		CreateMatchmakingPoolCommand: createMatchmakingPoolCommand,
+		UpdatePoolStrategyCommand:    updatePoolStrategyCommand,
+		UpdatePoolScheduleCommand:    updatePoolScheduleCommand,
+		DrainQueueCommand:            drainQueueCommand,
+		CreateEconomyConfigCommand:   createEconomyConfigCommand,
+		EnqueuePlayerCommandHandler:  enqueuePlayerCommandHandler,
+		LeaveQueueCommand:            leaveQueueCommand,
+		GetQueueStatusQuery:          getQueueStatusQuery,
+	}
+}
+
+func (ctlr *MatchmakingController) CreatePoolHandler(apiContext context.Context) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var pool matchmaking_entities.MatchmakingPool
+		err := json.NewDecoder(r.Body).Decode(&pool)
+		if err != nil {
+			slog.ErrorContext(r.Context(), "invalid matchmaking pool request", "err", err)
+			http.Error(w, "Bad Request", http.StatusBadRequest)
+			return
+		}
+
+		created, err := ctlr.CreateMatchmakingPoolCommand.Exec(r.Context(), pool)
+		if err != nil {
+			writeMatchmakingError(w, err)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(created)
+	}
+}
+
+// CreateEconomyConfigHandler publishes a new version of the economy config of a game mode.
+func (ctlr *MatchmakingController) CreateEconomyConfigHandler(apiContext context.Context) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var config matchmaking_entities.EconomyConfig
+		err := json.NewDecoder(r.Body).Decode(&config)
+		if err != nil {
+			slog.ErrorContext(r.Context(), "invalid economy config request", "err", err)
+			http.Error(w, "Bad Request", http.StatusBadRequest)
+			return
+		}
+
+		created, err := ctlr.CreateEconomyConfigCommand.Exec(r.Context(), config)
+		if err != nil {
+			writeMatchmakingError(w, err)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(created)
+	}
+}
+
+func (ctlr *MatchmakingController) UpdateStrategyHandler(apiContext context.Context) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		poolID, ok := parseUUIDVar(w, r, "pool_id")
+		if !ok {
+			return
+		}
+
+		var req UpdatePoolStrategyRequest
+		err := json.NewDecoder(r.Body).Decode(&req)
+		if err != nil || req.Strategy == "" {
+			slog.ErrorContext(r.Context(), "invalid pool strategy request", "err", err)
+			http.Error(w, "Bad Request", http.StatusBadRequest)
+			return
+		}
+
+		pool, err := ctlr.UpdatePoolStrategyCommand.Exec(r.Context(), poolID, req.Strategy, req.ShadowStrategies)
+		if err != nil {
+			writeMatchmakingError(w, err)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(pool)
+	}
+}
+
+// UpdateScheduleHandler sets the playlist windows of the pool. A null body removes the schedule (always open).
+func (ctlr *MatchmakingController) UpdateScheduleHandler(apiContext context.Context) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		poolID, ok := parseUUIDVar(w, r, "pool_id")
+		if !ok {
+			return
+		}
+
+		var schedule *matchmaking_entities.PoolSchedule
+		err := json.NewDecoder(r.Body).Decode(&schedule)
+		if err != nil {
+			slog.ErrorContext(r.Context(), "invalid pool schedule request", "err", err)
+			http.Error(w, "Bad Request", http.StatusBadRequest)
+			return
+		}
+
+		pool, err := ctlr.UpdatePoolScheduleCommand.Exec(r.Context(), poolID, schedule)
+		if err != nil {
+			writeMatchmakingError(w, err)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(pool)
+	}
+}
+
+// DrainQueueHandler cancels the waiting tickets of the pool, returning how many were cancelled (ie: while its matcher is broken).
+func (ctlr *MatchmakingController) DrainQueueHandler(apiContext context.Context) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		poolID, ok := parseUUIDVar(w, r, "pool_id")
+		if !ok {
+			return
+		}
+
+		drained, err := ctlr.DrainQueueCommand.Exec(r.Context(), poolID)
+		if err != nil {
+			writeMatchmakingError(w, err)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(drainQueueResponse{Cancelled: drained})
+	}
+}
+
+func (ctlr *MatchmakingController) EnqueueHandler(apiContext context.Context) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		poolID, ok := parseUUIDVar(w, r, "pool_id")
+		if !ok {
+			return
+		}
+
+		var cmd matchmaking_in.EnqueuePlayerCommand
+		err := json.NewDecoder(r.Body).Decode(&cmd)
+		if err != nil || cmd.PlayerID == uuid.Nil {
+			slog.ErrorContext(r.Context(), "invalid enqueue request", "err", err)
+			http.Error(w, "Bad Request", http.StatusBadRequest)
+			return
+		}
+
+		cmd.PoolID = poolID
+
+		ticket, err := ctlr.EnqueuePlayerCommandHandler.Exec(r.Context(), cmd)
+		if err != nil {
+			writeMatchmakingError(w, err)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(ticket)
+	}
+}
+
+func (ctlr *MatchmakingController) LeaveQueueHandler(apiContext context.Context) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ticketID, ok := parseUUIDVar(w, r, "ticket_id")
+		if !ok {
+			return
+		}
+
+		ticket, err := ctlr.LeaveQueueCommand.Exec(r.Context(), ticketID)
+		if err != nil {
+			writeMatchmakingError(w, err)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(ticket)
+	}
+}
+
+// QueueStatusHandler returns the position and estimated wait of the ticket. Clients poll it while queued.
+func (ctlr *MatchmakingController) QueueStatusHandler(apiContext context.Context) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ticketID, ok := parseUUIDVar(w, r, "ticket_id")
+		if !ok {
+			return
+		}
+
+		status, err := ctlr.GetQueueStatusQuery.Exec(r.Context(), ticketID)
+		if err != nil {
+			writeMatchmakingError(w, err)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(status)
+	}
+}
+
+func parseUUIDVar(w http.ResponseWriter, r *http.Request, name string) (uuid.UUID, bool) {
+	id, err := uuid.Parse(mux.Vars(r)[name])
+	if err != nil {
+		slog.ErrorContext(r.Context(), "invalid "+name, "err", err, name, mux.Vars(r)[name])
+		http.Error(w, "Bad Request", http.StatusBadRequest)
+		return uuid.Nil, false
+	}
+
+	return id, true
+}
+
+func writeMatchmakingError(w http.ResponseWriter, err error) {
+	var poolNotFoundErr *matchmaking.PoolNotFoundError
+	var invalidPoolErr *matchmaking.InvalidPoolError
+	var invalidEconomyErr *matchmaking.InvalidEconomyConfigError
+	var queueStateErr *matchmaking.QueueStateError
+
+	switch {
+	case errors.As(err, &poolNotFoundErr):
+		http.Error(w, poolNotFoundErr.Message, http.StatusNotFound)
+	case errors.As(err, &invalidPoolErr):
+		http.Error(w, invalidPoolErr.Message, http.StatusBadRequest)
+	case errors.As(err, &invalidEconomyErr):
+		http.Error(w, invalidEconomyErr.Message, http.StatusBadRequest)
+	case errors.As(err, &queueStateErr):
+		http.Error(w, queueStateErr.Message, http.StatusConflict)
+	default:
+		writeLobbyError(w, err)
+	}
+}
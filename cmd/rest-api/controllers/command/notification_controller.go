@@ -0,0 +1,123 @@
+package cmd_controllers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/golobby/container/v3"
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/psavelis/team-pro/replay-api/pkg/domain/notification"
+	notification_in "github.com/psavelis/team-pro/replay-api/pkg/domain/notification/ports/in"
+)
+
+type NotificationController struct {
+	MarkNotificationReadCommandHandler          notification_in.MarkNotificationReadCommandHandler
+	NotificationPreferencesQuery                notification_in.NotificationPreferencesQuery
+	UpdateNotificationPreferencesCommandHandler notification_in.UpdateNotificationPreferencesCommandHandler
+}
+
+func NewNotificationController(container *container.Container) *NotificationController {
+	var markNotificationReadCommandHandler notification_in.MarkNotificationReadCommandHandler
+	err := container.Resolve(&markNotificationReadCommandHandler)
+	if err != nil {
+		slog.Error("Cannot resolve notification_in.MarkNotificationReadCommandHandler for new NotificationController", "err", err)
+		panic(err)
+	}
+
+	var notificationPreferencesQuery notification_in.NotificationPreferencesQuery
+	err = container.Resolve(&notificationPreferencesQuery)
+	if err != nil {
+		slog.Error("Cannot resolve notification_in.NotificationPreferencesQuery for new NotificationController", "err", err)
+		panic(err)
+	}
+
+	var updateNotificationPreferencesCommandHandler notification_in.UpdateNotificationPreferencesCommandHandler
+	err = container.Resolve(&updateNotificationPreferencesCommandHandler)
+	if err != nil {
+		slog.Error("Cannot resolve notification_in.UpdateNotificationPreferencesCommandHandler for new NotificationController", "err", err)
+		panic(err)
+	}
+
+	return &NotificationController{
+		MarkNotificationReadCommandHandler:          markNotificationReadCommandHandler,
+		NotificationPreferencesQuery:                notificationPreferencesQuery,
+		UpdateNotificationPreferencesCommandHandler: updateNotificationPreferencesCommandHandler,
+	}
+}
+
+// MarkReadHandler marks a notification of the inbox of the user in context as read.
+func (ctlr *NotificationController) MarkReadHandler(apiContext context.Context) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		notificationID, err := uuid.Parse(mux.Vars(r)["notification_id"])
+		if err != nil {
+			http.Error(w, "Bad Request", http.StatusBadRequest)
+			return
+		}
+
+		n, err := ctlr.MarkNotificationReadCommandHandler.Exec(r.Context(), notificationID)
+		if err != nil {
+			writeNotificationError(w, err)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(n)
+	}
+}
+
+// GetPreferencesHandler returns the channels of the user in context for each type of notification.
+func (ctlr *NotificationController) GetPreferencesHandler(apiContext context.Context) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		preferences, err := ctlr.NotificationPreferencesQuery.Exec(r.Context())
+		if err != nil {
+			writeNotificationError(w, err)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(preferences)
+	}
+}
+
+// UpdatePreferencesHandler replaces the preferences of the user in context.
+func (ctlr *NotificationController) UpdatePreferencesHandler(apiContext context.Context) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var cmd notification_in.UpdateNotificationPreferencesCommand
+		err := json.NewDecoder(r.Body).Decode(&cmd)
+		if err != nil {
+			slog.ErrorContext(r.Context(), "invalid notification preferences request", "err", err)
+			http.Error(w, "Bad Request", http.StatusBadRequest)
+			return
+		}
+
+		preferences, err := ctlr.UpdateNotificationPreferencesCommandHandler.Exec(r.Context(), cmd)
+		if err != nil {
+			writeNotificationError(w, err)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(preferences)
+	}
+}
+
+func writeNotificationError(w http.ResponseWriter, err error) {
+	var invalidPreferencesErr *notification.InvalidNotificationPreferencesError
+	var notFoundErr *notification.NotificationNotFoundError
+
+	switch {
+	case errors.As(err, &invalidPreferencesErr):
+		http.Error(w, invalidPreferencesErr.Message, http.StatusBadRequest)
+	case errors.As(err, &notFoundErr):
+		http.Error(w, notFoundErr.Message, http.StatusNotFound)
+	default:
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+	}
+}
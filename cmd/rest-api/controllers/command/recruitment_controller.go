@@ -0,0 +1,172 @@
+package cmd_controllers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/golobby/container/v3"
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	"github.com/psavelis/team-pro/replay-api/pkg/domain/squad"
+	squad_in "github.com/psavelis/team-pro/replay-api/pkg/domain/squad/ports/in"
+)
+
+type RecruitmentController struct {
+	ListFreeAgentCommandHandler     squad_in.ListFreeAgentCommandHandler
+	UnlistFreeAgentCommandHandler   squad_in.UnlistFreeAgentCommandHandler
+	PostSquadOpeningCommandHandler  squad_in.PostSquadOpeningCommandHandler
+	CloseSquadOpeningCommandHandler squad_in.CloseSquadOpeningCommandHandler
+}
+
+func NewRecruitmentController(container *container.Container) *RecruitmentController {
+	var listFreeAgentCommandHandler squad_in.ListFreeAgentCommandHandler
+	err := container.Resolve(&listFreeAgentCommandHandler)
+	if err != nil {
+		slog.Error("Cannot resolve squad_in.ListFreeAgentCommandHandler for new RecruitmentController", "err", err)
+		panic(err)
+	}
+
+	var unlistFreeAgentCommandHandler squad_in.UnlistFreeAgentCommandHandler
+	err = container.Resolve(&unlistFreeAgentCommandHandler)
+	if err != nil {
+		slog.Error("Cannot resolve squad_in.UnlistFreeAgentCommandHandler for new RecruitmentController", "err", err)
+		panic(err)
+	}
+
+	var postSquadOpeningCommandHandler squad_in.PostSquadOpeningCommandHandler
+	err = container.Resolve(&postSquadOpeningCommandHandler)
+	if err != nil {
+		slog.Error("Cannot resolve squad_in.PostSquadOpeningCommandHandler for new RecruitmentController", "err", err)
+		panic(err)
+	}
+
+	var closeSquadOpeningCommandHandler squad_in.CloseSquadOpeningCommandHandler
+	err = container.Resolve(&closeSquadOpeningCommandHandler)
+	if err != nil {
+		slog.Error("Cannot resolve squad_in.CloseSquadOpeningCommandHandler for new RecruitmentController", "err", err)
+		panic(err)
+	}
+
+	return &RecruitmentController{
+		ListFreeAgentCommandHandler:     listFreeAgentCommandHandler,
+		UnlistFreeAgentCommandHandler:   unlistFreeAgentCommandHandler,
+		PostSquadOpeningCommandHandler:  postSquadOpeningCommandHandler,
+		CloseSquadOpeningCommandHandler: closeSquadOpeningCommandHandler,
+	}
+}
+
+// ListFreeAgentHandler lists the user in context as looking for a team in the game (updating its listing when there's one).
+func (ctlr *RecruitmentController) ListFreeAgentHandler(apiContext context.Context) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var cmd squad_in.ListFreeAgentCommand
+		err := json.NewDecoder(r.Body).Decode(&cmd)
+		if err != nil {
+			http.Error(w, "Bad Request", http.StatusBadRequest)
+			return
+		}
+
+		cmd.GameID = common.GameIDKey(mux.Vars(r)["game_id"])
+
+		freeAgent, err := ctlr.ListFreeAgentCommandHandler.Exec(r.Context(), cmd)
+		if err != nil {
+			writeRecruitmentError(w, err)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(freeAgent)
+	}
+}
+
+func (ctlr *RecruitmentController) UnlistFreeAgentHandler(apiContext context.Context) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		freeAgentID, err := uuid.Parse(mux.Vars(r)["free_agent_id"])
+		if err != nil {
+			http.Error(w, "Bad Request", http.StatusBadRequest)
+			return
+		}
+
+		_, err = ctlr.UnlistFreeAgentCommandHandler.Exec(r.Context(), squad_in.UnlistFreeAgentCommand{FreeAgentID: freeAgentID})
+		if err != nil {
+			writeRecruitmentError(w, err)
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// PostOpeningHandler posts an opening of the squad, on behalf of its owner.
+func (ctlr *RecruitmentController) PostOpeningHandler(apiContext context.Context) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		squadID, err := uuid.Parse(mux.Vars(r)["squad_id"])
+		if err != nil {
+			http.Error(w, "Bad Request", http.StatusBadRequest)
+			return
+		}
+
+		var cmd squad_in.PostSquadOpeningCommand
+		err = json.NewDecoder(r.Body).Decode(&cmd)
+		if err != nil {
+			http.Error(w, "Bad Request", http.StatusBadRequest)
+			return
+		}
+
+		cmd.SquadID = squadID
+
+		opening, err := ctlr.PostSquadOpeningCommandHandler.Exec(r.Context(), cmd)
+		if err != nil {
+			writeRecruitmentError(w, err)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(opening)
+	}
+}
+
+func (ctlr *RecruitmentController) CloseOpeningHandler(apiContext context.Context) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+
+		squadID, err := uuid.Parse(vars["squad_id"])
+		if err != nil {
+			http.Error(w, "Bad Request", http.StatusBadRequest)
+			return
+		}
+
+		openingID, err := uuid.Parse(vars["opening_id"])
+		if err != nil {
+			http.Error(w, "Bad Request", http.StatusBadRequest)
+			return
+		}
+
+		_, err = ctlr.CloseSquadOpeningCommandHandler.Exec(r.Context(), squad_in.CloseSquadOpeningCommand{SquadID: squadID, OpeningID: openingID})
+		if err != nil {
+			writeRecruitmentError(w, err)
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func writeRecruitmentError(w http.ResponseWriter, err error) {
+	var recruitmentNotFoundErr *squad.RecruitmentNotFoundError
+	var invalidErr *squad.InvalidRecruitmentError
+
+	switch {
+	case errors.As(err, &recruitmentNotFoundErr):
+		http.Error(w, recruitmentNotFoundErr.Message, http.StatusNotFound)
+	case errors.As(err, &invalidErr):
+		http.Error(w, invalidErr.Message, http.StatusBadRequest)
+	default:
+		writeSquadMembershipError(w, err)
+	}
+}
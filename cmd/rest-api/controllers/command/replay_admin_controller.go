@@ -0,0 +1,73 @@
+package cmd_controllers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/golobby/container/v3"
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	"github.com/psavelis/team-pro/replay-api/pkg/domain/replay"
+	replay_in "github.com/psavelis/team-pro/replay-api/pkg/domain/replay/ports/in"
+)
+
+type ReplayAdminController struct {
+	container *container.Container
+}
+
+func NewReplayAdminController(container *container.Container) *ReplayAdminController {
+	return &ReplayAdminController{container: container}
+}
+
+// ReprocessHandler queues a Failed, Quarantined or stuck Pending replay file for the replay workers again. Replays are only queued
+// with a broker (RABBITMQ_URL), the command is resolved per request.
+func (ctlr *ReplayAdminController) ReprocessHandler(apiContext context.Context) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		replayFileID, ok := parseUUIDVar(w, r, "replay_file_id")
+		if !ok {
+			return
+		}
+
+		var config common.Config
+		err := ctlr.container.Resolve(&config)
+		if err != nil || config.RabbitMQ.URL == "" {
+			slog.ErrorContext(r.Context(), "replay reprocessing requires a broker", "err", err)
+			http.Error(w, "Service Unavailable", http.StatusServiceUnavailable)
+			return
+		}
+
+		var reprocessReplayFileCommand replay_in.ReprocessReplayFileCommand
+		err = ctlr.container.Resolve(&reprocessReplayFileCommand)
+		if err != nil {
+			slog.ErrorContext(r.Context(), "unable to resolve replay_in.ReprocessReplayFileCommand", "err", err)
+			http.Error(w, "Service Unavailable", http.StatusServiceUnavailable)
+			return
+		}
+
+		replayFile, err := reprocessReplayFileCommand.Exec(r.Context(), replayFileID)
+		if err != nil {
+			writeReplayAdminError(w, err)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusAccepted)
+		json.NewEncoder(w).Encode(replayFile)
+	}
+}
+
+func writeReplayAdminError(w http.ResponseWriter, err error) {
+	var notFoundErr *replay.ReplayFileNotFoundError
+	var stateErr *replay.ReplayFileStateError
+
+	switch {
+	case errors.As(err, &notFoundErr):
+		http.Error(w, notFoundErr.Message, http.StatusNotFound)
+	case errors.As(err, &stateErr):
+		http.Error(w, stateErr.Message, http.StatusConflict)
+	default:
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+	}
+}
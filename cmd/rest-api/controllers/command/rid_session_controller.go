@@ -0,0 +1,129 @@
+package cmd_controllers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/golobby/container/v3"
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/psavelis/team-pro/replay-api/pkg/domain/iam"
+	iam_in "github.com/psavelis/team-pro/replay-api/pkg/domain/iam/ports/in"
+)
+
+type RIDSessionController struct {
+	RefreshRIDTokenCommand  iam_in.RefreshRIDTokenCommand
+	ListRIDSessionsQuery    iam_in.ListRIDSessionsQuery
+	RevokeRIDSessionCommand iam_in.RevokeRIDSessionCommand
+}
+
+func NewRIDSessionController(container *container.Container) *RIDSessionController {
+	var refreshRIDTokenCommand iam_in.RefreshRIDTokenCommand
+	err := container.Resolve(&refreshRIDTokenCommand)
+	if err != nil {
+		slog.Error("Cannot resolve iam_in.RefreshRIDTokenCommand for new RIDSessionController", "err", err)
+		panic(err)
+	}
+
+	var listRIDSessionsQuery iam_in.ListRIDSessionsQuery
+	err = container.Resolve(&listRIDSessionsQuery)
+	if err != nil {
+		slog.Error("Cannot resolve iam_in.ListRIDSessionsQuery for new RIDSessionController", "err", err)
+		panic(err)
+	}
+
+	var revokeRIDSessionCommand iam_in.RevokeRIDSessionCommand
+	err = container.Resolve(&revokeRIDSessionCommand)
+	if err != nil {
+		slog.Error("Cannot resolve iam_in.RevokeRIDSessionCommand for new RIDSessionController", "err", err)
+		panic(err)
+	}
+
+	return &RIDSessionController{
+		RefreshRIDTokenCommand:  refreshRIDTokenCommand,
+		ListRIDSessionsQuery:    listRIDSessionsQuery,
+		RevokeRIDSessionCommand: revokeRIDSessionCommand,
+	}
+}
+
+type refreshRIDTokenRequest struct {
+	RefreshToken string `json:"refresh_token"`
+}
+
+// RefreshHandler rotates the RID of a refresh token, returning the new RID (X-Resource-Owner-ID header) and refresh token
+// (X-Refresh-Token header). Refresh tokens are single use.
+func (ctlr *RIDSessionController) RefreshHandler(apiContext context.Context) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req refreshRIDTokenRequest
+		err := json.NewDecoder(r.Body).Decode(&req)
+		if err != nil {
+			http.Error(w, "Bad Request", http.StatusBadRequest)
+			return
+		}
+
+		ridToken, err := ctlr.RefreshRIDTokenCommand.Exec(r.Context(), req.RefreshToken)
+		if err != nil {
+			writeRIDSessionError(w, err)
+			return
+		}
+
+		w.Header().Set("Cache-Control", "no-store")
+		w.Header().Set("X-Resource-Owner-ID", ridToken.GetID().String())
+		w.Header().Set("X-Refresh-Token", ridToken.RefreshKey)
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// ListHandler lists the active sessions (signed in devices) of the user in context.
+func (ctlr *RIDSessionController) ListHandler(apiContext context.Context) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		sessions, err := ctlr.ListRIDSessionsQuery.Exec(r.Context())
+		if err != nil {
+			writeRIDSessionError(w, err)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(sessions)
+	}
+}
+
+// RevokeHandler signs a session of the user in context out.
+func (ctlr *RIDSessionController) RevokeHandler(apiContext context.Context) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		sessionID, err := uuid.Parse(mux.Vars(r)["session_id"])
+		if err != nil {
+			http.Error(w, "Bad Request", http.StatusBadRequest)
+			return
+		}
+
+		_, err = ctlr.RevokeRIDSessionCommand.Exec(r.Context(), sessionID)
+		if err != nil {
+			writeRIDSessionError(w, err)
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func writeRIDSessionError(w http.ResponseWriter, err error) {
+	var invalidRefreshKeyErr *iam.InvalidRefreshKeyError
+	var reusedErr *iam.RefreshKeyReusedError
+	var notFoundErr *iam.RIDSessionNotFoundError
+
+	switch {
+	case errors.As(err, &invalidRefreshKeyErr):
+		http.Error(w, invalidRefreshKeyErr.Message, http.StatusUnauthorized)
+	case errors.As(err, &reusedErr):
+		http.Error(w, reusedErr.Message, http.StatusUnauthorized)
+	case errors.As(err, &notFoundErr):
+		http.Error(w, notFoundErr.Message, http.StatusNotFound)
+	default:
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,142 @@
+package cmd_controllers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/golobby/container/v3"
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/psavelis/team-pro/replay-api/pkg/domain/iam"
+	iam_in "github.com/psavelis/team-pro/replay-api/pkg/domain/iam/ports/in"
+)
+
+type RoleController struct {
+	CreateRoleCommandHandler   iam_in.CreateRoleCommandHandler
+	AssignRoleCommandHandler   iam_in.AssignRoleCommandHandler
+	UnassignRoleCommandHandler iam_in.UnassignRoleCommandHandler
+}
+
+func NewRoleController(container *container.Container) *RoleController {
+	var createRoleCommandHandler iam_in.CreateRoleCommandHandler
+	err := container.Resolve(&createRoleCommandHandler)
+	if err != nil {
+		slog.Error("Cannot resolve iam_in.CreateRoleCommandHandler for new RoleController", "err", err)
+		panic(err)
+	}
+
+	var assignRoleCommandHandler iam_in.AssignRoleCommandHandler
+	err = container.Resolve(&assignRoleCommandHandler)
+	if err != nil {
+		slog.Error("Cannot resolve iam_in.AssignRoleCommandHandler for new RoleController", "err", err)
+		panic(err)
+	}
+
+	var unassignRoleCommandHandler iam_in.UnassignRoleCommandHandler
+	err = container.Resolve(&unassignRoleCommandHandler)
+	if err != nil {
+		slog.Error("Cannot resolve iam_in.UnassignRoleCommandHandler for new RoleController", "err", err)
+		panic(err)
+	}
+
+	return &RoleController{
+		CreateRoleCommandHandler:   createRoleCommandHandler,
+		AssignRoleCommandHandler:   assignRoleCommandHandler,
+		UnassignRoleCommandHandler: unassignRoleCommandHandler,
+	}
+}
+
+func (ctlr *RoleController) CreateHandler(apiContext context.Context) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var cmd iam_in.CreateRoleCommand
+		err := json.NewDecoder(r.Body).Decode(&cmd)
+		if err != nil {
+			http.Error(w, "Bad Request", http.StatusBadRequest)
+			return
+		}
+
+		role, err := ctlr.CreateRoleCommandHandler.Exec(r.Context(), cmd)
+		if err != nil {
+			writeRoleError(w, err)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(role)
+	}
+}
+
+type assignRoleRequest struct {
+	UserID uuid.UUID `json:"user_id"`
+}
+
+func (ctlr *RoleController) AssignHandler(apiContext context.Context) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		roleID, err := uuid.Parse(mux.Vars(r)["role_id"])
+		if err != nil {
+			http.Error(w, "Bad Request", http.StatusBadRequest)
+			return
+		}
+
+		var req assignRoleRequest
+		err = json.NewDecoder(r.Body).Decode(&req)
+		if err != nil || req.UserID == uuid.Nil {
+			http.Error(w, "Bad Request", http.StatusBadRequest)
+			return
+		}
+
+		assignment, err := ctlr.AssignRoleCommandHandler.Exec(r.Context(), iam_in.RoleAssignmentCommand{RoleID: roleID, UserID: req.UserID})
+		if err != nil {
+			writeRoleError(w, err)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(assignment)
+	}
+}
+
+func (ctlr *RoleController) UnassignHandler(apiContext context.Context) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+
+		roleID, err := uuid.Parse(vars["role_id"])
+		if err != nil {
+			http.Error(w, "Bad Request", http.StatusBadRequest)
+			return
+		}
+
+		userID, err := uuid.Parse(vars["user_id"])
+		if err != nil {
+			http.Error(w, "Bad Request", http.StatusBadRequest)
+			return
+		}
+
+		_, err = ctlr.UnassignRoleCommandHandler.Exec(r.Context(), iam_in.RoleAssignmentCommand{RoleID: roleID, UserID: userID})
+		if err != nil {
+			writeRoleError(w, err)
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func writeRoleError(w http.ResponseWriter, err error) {
+	var invalidRoleErr *iam.InvalidRoleError
+	var notFoundErr *iam.RoleNotFoundError
+
+	switch {
+	case errors.As(err, &invalidRoleErr):
+		http.Error(w, invalidRoleErr.Message, http.StatusBadRequest)
+	case errors.As(err, &notFoundErr):
+		http.Error(w, notFoundErr.Message, http.StatusNotFound)
+	default:
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+	}
+}
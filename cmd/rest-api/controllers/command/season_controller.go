@@ -0,0 +1,71 @@
+package cmd_controllers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/golobby/container/v3"
+	"github.com/psavelis/team-pro/replay-api/pkg/domain/season"
+	season_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/season/entities"
+	season_in "github.com/psavelis/team-pro/replay-api/pkg/domain/season/ports/in"
+)
+
+type SeasonController struct {
+	ScheduleSeasonCommand season_in.ScheduleSeasonCommand
+}
+
+func NewSeasonController(container *container.Container) *SeasonController {
+	var scheduleSeasonCommand season_in.ScheduleSeasonCommand
+	err := container.Resolve(&scheduleSeasonCommand)
+	if err != nil {
+		slog.Error("Cannot resolve season_in.ScheduleSeasonCommand for new SeasonController", "err", err)
+		panic(err)
+	}
+
+	return &SeasonController{
+		ScheduleSeasonCommand: scheduleSeasonCommand,
+	}
+}
+
+// ScheduleHandler schedules a season of a game, started and ended on time by the seasons job of the scheduler.
+func (ctlr *SeasonController) ScheduleHandler(apiContext context.Context) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var s season_entities.Season
+		err := json.NewDecoder(r.Body).Decode(&s)
+		if err != nil {
+			slog.ErrorContext(r.Context(), "invalid season request", "err", err)
+			http.Error(w, "Bad Request", http.StatusBadRequest)
+			return
+		}
+
+		created, err := ctlr.ScheduleSeasonCommand.Exec(r.Context(), s)
+		if err != nil {
+			writeSeasonError(w, err)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(created)
+	}
+}
+
+func writeSeasonError(w http.ResponseWriter, err error) {
+	var notFoundErr *season.SeasonNotFoundError
+	var invalidErr *season.InvalidSeasonError
+	var overlapErr *season.SeasonOverlapError
+
+	switch {
+	case errors.As(err, &notFoundErr):
+		http.Error(w, notFoundErr.Message, http.StatusNotFound)
+	case errors.As(err, &invalidErr):
+		http.Error(w, invalidErr.Message, http.StatusBadRequest)
+	case errors.As(err, &overlapErr):
+		http.Error(w, overlapErr.Message, http.StatusConflict)
+	default:
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,302 @@
+package cmd_controllers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+
+	"github.com/golobby/container/v3"
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/psavelis/team-pro/replay-api/pkg/domain/squad"
+	squad_in "github.com/psavelis/team-pro/replay-api/pkg/domain/squad/ports/in"
+)
+
+type SquadMembershipController struct {
+	InviteToSquadCommandHandler        squad_in.InviteToSquadCommandHandler
+	ApplyToSquadCommandHandler         squad_in.ApplyToSquadCommandHandler
+	RespondToJoinRequestCommandHandler squad_in.RespondToJoinRequestCommandHandler
+	SetMemberRoleCommandHandler        squad_in.SetMemberRoleCommandHandler
+	TransferOwnershipCommandHandler    squad_in.TransferSquadOwnershipCommandHandler
+	RemoveMemberCommandHandler         squad_in.RemoveSquadMemberCommandHandler
+}
+
+func NewSquadMembershipController(container *container.Container) *SquadMembershipController {
+	var inviteToSquadCommandHandler squad_in.InviteToSquadCommandHandler
+	err := container.Resolve(&inviteToSquadCommandHandler)
+	if err != nil {
+		slog.Error("Cannot resolve squad_in.InviteToSquadCommandHandler for new SquadMembershipController", "err", err)
+		panic(err)
+	}
+
+	var applyToSquadCommandHandler squad_in.ApplyToSquadCommandHandler
+	err = container.Resolve(&applyToSquadCommandHandler)
+	if err != nil {
+		slog.Error("Cannot resolve squad_in.ApplyToSquadCommandHandler for new SquadMembershipController", "err", err)
+		panic(err)
+	}
+
+	var respondToJoinRequestCommandHandler squad_in.RespondToJoinRequestCommandHandler
+	err = container.Resolve(&respondToJoinRequestCommandHandler)
+	if err != nil {
+		slog.Error("Cannot resolve squad_in.RespondToJoinRequestCommandHandler for new SquadMembershipController", "err", err)
+		panic(err)
+	}
+
+	var setMemberRoleCommandHandler squad_in.SetMemberRoleCommandHandler
+	err = container.Resolve(&setMemberRoleCommandHandler)
+	if err != nil {
+		slog.Error("Cannot resolve squad_in.SetMemberRoleCommandHandler for new SquadMembershipController", "err", err)
+		panic(err)
+	}
+
+	var transferOwnershipCommandHandler squad_in.TransferSquadOwnershipCommandHandler
+	err = container.Resolve(&transferOwnershipCommandHandler)
+	if err != nil {
+		slog.Error("Cannot resolve squad_in.TransferSquadOwnershipCommandHandler for new SquadMembershipController", "err", err)
+		panic(err)
+	}
+
+	var removeMemberCommandHandler squad_in.RemoveSquadMemberCommandHandler
+	err = container.Resolve(&removeMemberCommandHandler)
+	if err != nil {
+		slog.Error("Cannot resolve squad_in.RemoveSquadMemberCommandHandler for new SquadMembershipController", "err", err)
+		panic(err)
+	}
+
+	return &SquadMembershipController{
+		InviteToSquadCommandHandler:        inviteToSquadCommandHandler,
+		ApplyToSquadCommandHandler:         applyToSquadCommandHandler,
+		RespondToJoinRequestCommandHandler: respondToJoinRequestCommandHandler,
+		SetMemberRoleCommandHandler:        setMemberRoleCommandHandler,
+		TransferOwnershipCommandHandler:    transferOwnershipCommandHandler,
+		RemoveMemberCommandHandler:         removeMemberCommandHandler,
+	}
+}
+
+// InviteHandler invites a user to the squad, on behalf of its owner.
+func (ctlr *SquadMembershipController) InviteHandler(apiContext context.Context) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		squadID, err := uuid.Parse(mux.Vars(r)["squad_id"])
+		if err != nil {
+			http.Error(w, "Bad Request", http.StatusBadRequest)
+			return
+		}
+
+		var cmd squad_in.InviteToSquadCommand
+		err = json.NewDecoder(r.Body).Decode(&cmd)
+		if err != nil || cmd.UserID == uuid.Nil {
+			http.Error(w, "Bad Request", http.StatusBadRequest)
+			return
+		}
+
+		cmd.SquadID = squadID
+
+		request, err := ctlr.InviteToSquadCommandHandler.Exec(r.Context(), cmd)
+		if err != nil {
+			writeSquadMembershipError(w, err)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(request)
+	}
+}
+
+// ApplyHandler applies to the squad on behalf of the user in context (the message is optional).
+func (ctlr *SquadMembershipController) ApplyHandler(apiContext context.Context) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		squadID, err := uuid.Parse(mux.Vars(r)["squad_id"])
+		if err != nil {
+			http.Error(w, "Bad Request", http.StatusBadRequest)
+			return
+		}
+
+		var cmd squad_in.ApplyToSquadCommand
+		err = json.NewDecoder(r.Body).Decode(&cmd)
+		if err != nil && !errors.Is(err, io.EOF) {
+			http.Error(w, "Bad Request", http.StatusBadRequest)
+			return
+		}
+
+		cmd.SquadID = squadID
+
+		request, err := ctlr.ApplyToSquadCommandHandler.Exec(r.Context(), cmd)
+		if err != nil {
+			writeSquadMembershipError(w, err)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(request)
+	}
+}
+
+// AcceptHandler accepts an invite (as the invited user) or an application (as the squad owner), adding the user to the squad.
+func (ctlr *SquadMembershipController) AcceptHandler(apiContext context.Context) http.HandlerFunc {
+	return ctlr.respondHandler(true)
+}
+
+// DeclineHandler declines an invite (as the invited user) or an application (as the squad owner).
+func (ctlr *SquadMembershipController) DeclineHandler(apiContext context.Context) http.HandlerFunc {
+	return ctlr.respondHandler(false)
+}
+
+func (ctlr *SquadMembershipController) respondHandler(accept bool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		squadID, err := uuid.Parse(mux.Vars(r)["squad_id"])
+		if err != nil {
+			http.Error(w, "Bad Request", http.StatusBadRequest)
+			return
+		}
+
+		joinRequestID, err := uuid.Parse(mux.Vars(r)["join_request_id"])
+		if err != nil {
+			http.Error(w, "Bad Request", http.StatusBadRequest)
+			return
+		}
+
+		request, err := ctlr.RespondToJoinRequestCommandHandler.Exec(r.Context(), squad_in.RespondToJoinRequestCommand{
+			SquadID:       squadID,
+			JoinRequestID: joinRequestID,
+			Accept:        accept,
+		})
+
+		if err != nil {
+			writeSquadMembershipError(w, err)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(request)
+	}
+}
+
+// SetMemberRoleHandler promotes or demotes a member, on behalf of the squad owner (ie: {"role": "captain"}, an empty role is a player).
+func (ctlr *SquadMembershipController) SetMemberRoleHandler(apiContext context.Context) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		squadID, err := uuid.Parse(mux.Vars(r)["squad_id"])
+		if err != nil {
+			http.Error(w, "Bad Request", http.StatusBadRequest)
+			return
+		}
+
+		userID, err := uuid.Parse(mux.Vars(r)["user_id"])
+		if err != nil {
+			http.Error(w, "Bad Request", http.StatusBadRequest)
+			return
+		}
+
+		var cmd squad_in.SetMemberRoleCommand
+		err = json.NewDecoder(r.Body).Decode(&cmd)
+		if err != nil {
+			http.Error(w, "Bad Request", http.StatusBadRequest)
+			return
+		}
+
+		cmd.SquadID = squadID
+		cmd.UserID = userID
+
+		s, err := ctlr.SetMemberRoleCommandHandler.Exec(r.Context(), cmd)
+		if err != nil {
+			writeSquadMembershipError(w, err)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(s)
+	}
+}
+
+// TransferOwnershipHandler hands the squad to one of its members, on behalf of the squad owner.
+func (ctlr *SquadMembershipController) TransferOwnershipHandler(apiContext context.Context) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		squadID, err := uuid.Parse(mux.Vars(r)["squad_id"])
+		if err != nil {
+			http.Error(w, "Bad Request", http.StatusBadRequest)
+			return
+		}
+
+		var cmd squad_in.TransferSquadOwnershipCommand
+		err = json.NewDecoder(r.Body).Decode(&cmd)
+		if err != nil || cmd.UserID == uuid.Nil {
+			http.Error(w, "Bad Request", http.StatusBadRequest)
+			return
+		}
+
+		cmd.SquadID = squadID
+
+		s, err := ctlr.TransferOwnershipCommandHandler.Exec(r.Context(), cmd)
+		if err != nil {
+			writeSquadMembershipError(w, err)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(s)
+	}
+}
+
+// RemoveMemberHandler removes a member on behalf of the squad owner, or the member in context leaves the squad.
+func (ctlr *SquadMembershipController) RemoveMemberHandler(apiContext context.Context) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		squadID, err := uuid.Parse(mux.Vars(r)["squad_id"])
+		if err != nil {
+			http.Error(w, "Bad Request", http.StatusBadRequest)
+			return
+		}
+
+		userID, err := uuid.Parse(mux.Vars(r)["user_id"])
+		if err != nil {
+			http.Error(w, "Bad Request", http.StatusBadRequest)
+			return
+		}
+
+		s, err := ctlr.RemoveMemberCommandHandler.Exec(r.Context(), squad_in.RemoveSquadMemberCommand{SquadID: squadID, UserID: userID})
+		if err != nil {
+			writeSquadMembershipError(w, err)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(s)
+	}
+}
+
+func writeSquadMembershipError(w http.ResponseWriter, err error) {
+	var squadNotFoundErr *squad.SquadNotFoundError
+	var userNotFoundErr *squad.UserNotFoundError
+	var requestNotFoundErr *squad.JoinRequestNotFoundError
+	var memberNotFoundErr *squad.MemberNotFoundError
+	var invalidRoleErr *squad.InvalidMemberRoleError
+	var forbiddenErr *squad.SquadForbiddenError
+	var stateErr *squad.MembershipStateError
+
+	switch {
+	case errors.As(err, &squadNotFoundErr):
+		http.Error(w, squadNotFoundErr.Message, http.StatusNotFound)
+	case errors.As(err, &userNotFoundErr):
+		http.Error(w, userNotFoundErr.Message, http.StatusNotFound)
+	case errors.As(err, &requestNotFoundErr):
+		http.Error(w, requestNotFoundErr.Message, http.StatusNotFound)
+	case errors.As(err, &memberNotFoundErr):
+		http.Error(w, memberNotFoundErr.Message, http.StatusNotFound)
+	case errors.As(err, &invalidRoleErr):
+		http.Error(w, invalidRoleErr.Message, http.StatusBadRequest)
+	case errors.As(err, &forbiddenErr):
+		http.Error(w, forbiddenErr.Message, http.StatusForbidden)
+	case errors.As(err, &stateErr):
+		http.Error(w, stateErr.Message, http.StatusConflict)
+	default:
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,58 @@
+package cmd_controllers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/golobby/container/v3"
+	"github.com/psavelis/team-pro/replay-api/pkg/domain/iam"
+	iam_in "github.com/psavelis/team-pro/replay-api/pkg/domain/iam/ports/in"
+)
+
+type VisibilityPolicyController struct {
+	SetVisibilityPolicyCommandHandler iam_in.SetVisibilityPolicyCommandHandler
+}
+
+func NewVisibilityPolicyController(container *container.Container) *VisibilityPolicyController {
+	var setVisibilityPolicyCommandHandler iam_in.SetVisibilityPolicyCommandHandler
+	err := container.Resolve(&setVisibilityPolicyCommandHandler)
+	if err != nil {
+		slog.Error("Cannot resolve iam_in.SetVisibilityPolicyCommandHandler for new VisibilityPolicyController", "err", err)
+		panic(err)
+	}
+
+	return &VisibilityPolicyController{
+		SetVisibilityPolicyCommandHandler: setVisibilityPolicyCommandHandler,
+	}
+}
+
+// SetHandler replaces the default visibility of the tenant (or of a group, with a group_id).
+func (ctlr *VisibilityPolicyController) SetHandler(apiContext context.Context) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var cmd iam_in.SetVisibilityPolicyCommand
+		err := json.NewDecoder(r.Body).Decode(&cmd)
+		if err != nil {
+			http.Error(w, "Bad Request", http.StatusBadRequest)
+			return
+		}
+
+		policy, err := ctlr.SetVisibilityPolicyCommandHandler.Exec(r.Context(), cmd)
+		if err != nil {
+			var invalidPolicyErr *iam.InvalidVisibilityPolicyError
+			if errors.As(err, &invalidPolicyErr) {
+				http.Error(w, invalidPolicyErr.Message, http.StatusBadRequest)
+				return
+			}
+
+			http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(policy)
+	}
+}
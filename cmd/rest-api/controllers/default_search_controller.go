@@ -3,6 +3,7 @@ package controllers
 import (
 	"encoding/base64"
 	"encoding/json"
+	"fmt"
 	"log/slog"
 	"net/http"
 
@@ -49,6 +50,16 @@ func (c *DefaultSearchController[T]) DefaultSearchHandler(w http.ResponseWriter,
 		return
 	}
 
+	// the sort query parameter takes precedence over the sort of the search source
+	if sortParam := r.URL.Query().Get("sort"); sortParam != "" {
+		s.ResultOptions.Sort, err = common.ParseSortOptions(sortParam)
+		if err != nil {
+			slog.Error("(DefaultSearchHandler) Error parsing sort parameter", "error", err)
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+	}
+
 	compiledSearch, err := c.Compile(r.Context(), s.SearchParams, s.ResultOptions)
 	if err != nil {
 		slog.Error("(DefaultSearchHandler) Error validating search request", "error", err)
@@ -69,7 +80,48 @@ func (c *DefaultSearchController[T]) DefaultSearchHandler(w http.ResponseWriter,
 		return
 	}
 
+	page := common.SearchResultPage[T]{Results: results}
+
+	if compiledSearch.ResultOptions.Cursor != nil && common.HasNextPage(compiledSearch.ResultOptions, len(results)) {
+		paginated, ok := c.Searchable.(common.CursorPaginated)
+		if !ok {
+			slog.Error("(DefaultSearchHandler) Cursor pagination requested but not supported", "service", fmt.Sprintf("%T", c.Searchable))
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		page.NextCursor, err = paginated.NextCursor(r.Context(), *compiledSearch, results[len(results)-1])
+		if err != nil {
+			slog.Error("(DefaultSearchHandler) Error building next cursor", "error", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+	}
+
+	if len(compiledSearch.ResultOptions.Facets) > 0 {
+		facetable, ok := c.Searchable.(common.Facetable)
+		if !ok {
+			slog.Error("(DefaultSearchHandler) Facets requested but not supported", "service", fmt.Sprintf("%T", c.Searchable))
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		page.Facets, err = facetable.Facets(r.Context(), *compiledSearch)
+		if err != nil {
+			slog.Error("(DefaultSearchHandler) Error computing facets", "error", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+	}
+
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
-	json.NewEncoder(w).Encode(results)
+
+	// plain result list, unless facets or cursor pagination were requested
+	if len(compiledSearch.ResultOptions.Facets) == 0 && compiledSearch.ResultOptions.Cursor == nil {
+		json.NewEncoder(w).Encode(results)
+		return
+	}
+
+	json.NewEncoder(w).Encode(page)
 }
@@ -0,0 +1,133 @@
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/golobby/container/v3"
+	"github.com/psavelis/team-pro/replay-api/pkg/domain/discord"
+	discord_entity "github.com/psavelis/team-pro/replay-api/pkg/domain/discord/entities"
+	discord_in "github.com/psavelis/team-pro/replay-api/pkg/domain/discord/ports/in"
+)
+
+type DiscordController struct {
+	OnboardDiscordUserCommand discord_in.OnboardDiscordUserCommand
+	LinkDiscordAccountCommand discord_in.LinkDiscordAccountCommand
+}
+
+func NewDiscordController(container *container.Container) *DiscordController {
+	var onboardDiscordUserCommand discord_in.OnboardDiscordUserCommand
+	err := container.Resolve(&onboardDiscordUserCommand)
+
+	if err != nil {
+		slog.Error("Cannot resolve discord_in.OnboardDiscordUserCommand for new DiscordController", "err", err)
+		panic(err)
+	}
+
+	var linkDiscordAccountCommand discord_in.LinkDiscordAccountCommand
+	err = container.Resolve(&linkDiscordAccountCommand)
+
+	if err != nil {
+		slog.Error("Cannot resolve discord_in.LinkDiscordAccountCommand for new DiscordController", "err", err)
+		panic(err)
+	}
+
+	return &DiscordController{OnboardDiscordUserCommand: onboardDiscordUserCommand, LinkDiscordAccountCommand: linkDiscordAccountCommand}
+}
+
+func (c *DiscordController) OnboardDiscordUser(apiContext context.Context) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Access-Control-Allow-Origin", "localhost:3000") // TODO: >>> config
+		w.Header().Set("Access-Control-Allow-Methods", "POST")
+		w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization")
+
+		if r.Body == nil {
+			slog.ErrorContext(r.Context(), "no request body", "request.Body", r.Body)
+			http.Error(w, "Bad Request", http.StatusBadRequest)
+			return
+		}
+
+		decoder := json.NewDecoder(r.Body)
+		var discordUserParams discord_entity.DiscordUser
+		err := decoder.Decode(&discordUserParams)
+
+		if err != nil {
+			slog.ErrorContext(r.Context(), "error decoding discord user from request", "err", err, "request.body", r.Body)
+			http.Error(w, "Bad Request", http.StatusBadRequest)
+			return
+		}
+
+		err = c.OnboardDiscordUserCommand.Validate(r.Context(), &discordUserParams)
+
+		if err != nil {
+			slog.ErrorContext(r.Context(), "error validating discord user", "err", err, "discordUserParams", discordUserParams)
+			http.Error(w, "Bad Request", http.StatusBadRequest)
+			return
+		}
+
+		discordUser, ridToken, err := c.OnboardDiscordUserCommand.Exec(r.Context(), &discordUserParams)
+
+		if err != nil {
+			slog.ErrorContext(r.Context(), "error onboarding discord user", "err", err, "discordUserParams.DiscordID", discordUserParams.DiscordID, "discordUserParams.VHash", discordUserParams.VHash)
+			http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+			return
+		}
+
+		if ridToken == nil {
+			slog.ErrorContext(r.Context(), "error onboarding discord user", "err", "controller: ridToken is nil", "discordUserParams.DiscordID", discordUserParams.DiscordID, "discordUserParams.VHash", discordUserParams.VHash)
+			http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("X-Resource-Owner-ID", ridToken.GetID().String())
+		w.Header().Set("X-Refresh-Token", ridToken.RefreshKey)
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(discordUser)
+	}
+}
+
+// LinkDiscordAccount links the Discord account authorized by the signed in user to their account.
+func (c *DiscordController) LinkDiscordAccount(apiContext context.Context) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var discordUserParams discord_entity.DiscordUser
+		err := json.NewDecoder(r.Body).Decode(&discordUserParams)
+		if err != nil {
+			slog.ErrorContext(r.Context(), "error decoding discord user from request", "err", err)
+			http.Error(w, "Bad Request", http.StatusBadRequest)
+			return
+		}
+
+		discordUser, err := c.LinkDiscordAccountCommand.Exec(r.Context(), &discordUserParams)
+		if err != nil {
+			writeDiscordLinkError(w, err)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(discordUser)
+	}
+}
+
+func writeDiscordLinkError(w http.ResponseWriter, err error) {
+	var forbiddenErr *discord.DiscordLinkForbiddenError
+	var alreadyLinkedErr *discord.DiscordAccountAlreadyLinkedError
+	var discordIDRequiredErr *discord.DiscordIDRequiredError
+	var vHashRequiredErr *discord.VHashRequiredError
+	var invalidVHashErr *discord.InvalidVHashError
+
+	switch {
+	case errors.As(err, &forbiddenErr):
+		http.Error(w, forbiddenErr.Message, http.StatusForbidden)
+	case errors.As(err, &alreadyLinkedErr):
+		http.Error(w, alreadyLinkedErr.Message, http.StatusConflict)
+	case errors.As(err, &discordIDRequiredErr), errors.As(err, &vHashRequiredErr), errors.As(err, &invalidVHashErr):
+		http.Error(w, "Bad Request", http.StatusBadRequest)
+	default:
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+	}
+}
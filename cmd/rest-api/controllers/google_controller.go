@@ -73,9 +73,10 @@ func (c *GoogleController) OnboardGoogleUser(apiContext context.Context) http.Ha
 			return
 		}
 
-		w.WriteHeader(http.StatusCreated)
 		w.Header().Set("Content-Type", "application/json")
 		w.Header().Set("X-Resource-Owner-ID", ridToken.GetID().String())
+		w.Header().Set("X-Refresh-Token", ridToken.RefreshKey)
+		w.WriteHeader(http.StatusCreated)
 		json.NewEncoder(w).Encode(googleUser)
 	}
 }
@@ -0,0 +1,137 @@
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/golobby/container/v3"
+	"github.com/gorilla/mux"
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	"github.com/psavelis/team-pro/replay-api/pkg/infra/scheduler"
+)
+
+// runs returned at most per request (the history is kept for a week)
+const maxJobRunsLimit = 500
+
+type JobsController struct {
+	JobStore scheduler.JobStore
+}
+
+func NewJobsController(container *container.Container) *JobsController {
+	var jobStore scheduler.JobStore
+	err := container.Resolve(&jobStore)
+
+	if err != nil {
+		slog.Error("Cannot resolve scheduler.JobStore for new JobsController", "err", err)
+		panic(err)
+	}
+
+	return &JobsController{JobStore: jobStore}
+}
+
+type jobResponse struct {
+	scheduler.JobState
+	Overdue bool `json:"overdue"`
+}
+
+// GetJobs returns the background jobs registered by the schedulers: their interval, controls and last run, and whether they're
+// overdue (missed their schedule).
+func (c *JobsController) GetJobs(apiContext context.Context) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		states, err := c.JobStore.Jobs(r.Context())
+		if err != nil {
+			slog.ErrorContext(r.Context(), "error listing jobs", "err", err)
+			http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+			return
+		}
+
+		now := time.Now().UTC()
+
+		jobs := make([]jobResponse, 0, len(states))
+		for _, state := range states {
+			jobs = append(jobs, jobResponse{JobState: state, Overdue: state.Overdue(now)})
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(jobs)
+	}
+}
+
+// GetJobRuns returns the last runs of a job (most recent first), up to `limit`.
+func (c *JobsController) GetJobRuns(apiContext context.Context) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		limit, err := parseUintQueryParam(r, "limit", common.DefaultPageSize)
+		if err != nil || limit == 0 || limit > maxJobRunsLimit {
+			slog.ErrorContext(r.Context(), "invalid job runs `limit` parameter", "err", err, "limit", r.URL.Query().Get("limit"))
+			http.Error(w, "Bad Request", http.StatusBadRequest)
+			return
+		}
+
+		name := mux.Vars(r)["job_name"]
+
+		runs, err := c.JobStore.Runs(r.Context(), name, int(limit))
+		if err != nil {
+			slog.ErrorContext(r.Context(), "error listing job runs", "job", name, "err", err)
+			http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(runs)
+	}
+}
+
+// TriggerJob requests a run of the job, started by a scheduler within its next poll of the job controls (paused jobs included).
+func (c *JobsController) TriggerJob(apiContext context.Context) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		name := mux.Vars(r)["job_name"]
+
+		state, err := c.JobStore.RequestTrigger(r.Context(), name)
+
+		writeJobState(w, r, name, state, err, http.StatusAccepted)
+	}
+}
+
+// PauseJob skips the scheduled runs of the job (on every scheduler) until it's resumed.
+func (c *JobsController) PauseJob(apiContext context.Context) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		name := mux.Vars(r)["job_name"]
+
+		state, err := c.JobStore.SetPaused(r.Context(), name, true)
+
+		writeJobState(w, r, name, state, err, http.StatusOK)
+	}
+}
+
+func (c *JobsController) ResumeJob(apiContext context.Context) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		name := mux.Vars(r)["job_name"]
+
+		state, err := c.JobStore.SetPaused(r.Context(), name, false)
+
+		writeJobState(w, r, name, state, err, http.StatusOK)
+	}
+}
+
+func writeJobState(w http.ResponseWriter, r *http.Request, name string, state *scheduler.JobState, err error, status int) {
+	if errors.Is(err, scheduler.ErrJobNotFound) {
+		http.Error(w, "Not Found", http.StatusNotFound)
+		return
+	}
+
+	if err != nil {
+		slog.ErrorContext(r.Context(), "error updating job controls", "job", name, "err", err)
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(jobResponse{JobState: *state, Overdue: state.Overdue(time.Now().UTC())})
+}
@@ -0,0 +1,36 @@
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+
+	"github.com/golobby/container/v3"
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+)
+
+type MetaController struct {
+	EntityMetadataReader common.EntityMetadataReader
+}
+
+func NewMetaController(container *container.Container) *MetaController {
+	var entityMetadataReader common.EntityMetadataReader
+	err := container.Resolve(&entityMetadataReader)
+
+	if err != nil {
+		slog.Error("Cannot resolve common.EntityMetadataReader for new MetaController", "err", err)
+		panic(err)
+	}
+
+	return &MetaController{EntityMetadataReader: entityMetadataReader}
+}
+
+// GetEntities describes the registered entities, their fields (queryable or not, with bson mappings) and the supported search operators.
+func (c *MetaController) GetEntities(apiContext context.Context) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(c.EntityMetadataReader.ListEntityMetadata(r.Context()))
+	}
+}
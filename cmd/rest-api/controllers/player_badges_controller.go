@@ -0,0 +1,57 @@
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+
+	"github.com/golobby/container/v3"
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	badge_in "github.com/psavelis/team-pro/replay-api/pkg/domain/badge/ports/in"
+)
+
+type PlayerBadgesController struct {
+	PlayerBadgesQuery badge_in.PlayerBadgesQuery
+}
+
+func NewPlayerBadgesController(container *container.Container) *PlayerBadgesController {
+	var playerBadgesQuery badge_in.PlayerBadgesQuery
+	err := container.Resolve(&playerBadgesQuery)
+
+	if err != nil {
+		slog.Error("Cannot resolve badge_in.PlayerBadgesQuery for new PlayerBadgesController", "err", err)
+		panic(err)
+	}
+
+	return &PlayerBadgesController{PlayerBadgesQuery: playerBadgesQuery}
+}
+
+// GetPlayerBadges returns the badges of a player (most recent first), optionally of a single `game_id`.
+func (c *PlayerBadgesController) GetPlayerBadges(apiContext context.Context) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		playerID, err := uuid.Parse(mux.Vars(r)["player_id"])
+		if err != nil {
+			slog.ErrorContext(r.Context(), "invalid player_id", "err", err, "player_id", mux.Vars(r)["player_id"])
+			http.Error(w, "Bad Request", http.StatusBadRequest)
+			return
+		}
+
+		badges, err := c.PlayerBadgesQuery.Exec(r.Context(), badge_in.PlayerBadgesQueryParams{
+			PlayerID: playerID,
+			GameID:   common.GameIDKey(r.URL.Query().Get("game_id")),
+		})
+
+		if err != nil {
+			slog.ErrorContext(r.Context(), "error getting player badges", "err", err, "player_id", playerID)
+			http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(badges)
+	}
+}
@@ -0,0 +1,76 @@
+package controllers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/golobby/container/v3"
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	"github.com/psavelis/team-pro/replay-api/pkg/domain/replay"
+	replay_in "github.com/psavelis/team-pro/replay-api/pkg/domain/replay/ports/in"
+)
+
+type PlayerCardController struct {
+	PlayerCardQuery replay_in.PlayerCardQuery
+	CacheTTL        time.Duration
+}
+
+func NewPlayerCardController(container *container.Container) *PlayerCardController {
+	var playerCardQuery replay_in.PlayerCardQuery
+	err := container.Resolve(&playerCardQuery)
+	if err != nil {
+		slog.Error("Cannot resolve replay_in.PlayerCardQuery for new PlayerCardController", "err", err)
+		panic(err)
+	}
+
+	var config common.Config
+	err = container.Resolve(&config)
+	if err != nil {
+		slog.Error("Cannot resolve common.Config for new PlayerCardController", "err", err)
+		panic(err)
+	}
+
+	return &PlayerCardController{PlayerCardQuery: playerCardQuery, CacheTTL: config.PlayerCard.CacheDuration()}
+}
+
+// GetPlayerCard returns the card of a player as a PNG image, the og:image/twitter:image of the pages of their profile. Cards are cached
+// by the API and by the clients (crawlers of Discord and Twitter) for the cache TTL, so they may be behind the stats by as much.
+func (c *PlayerCardController) GetPlayerCard(apiContext context.Context) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		playerID, err := uuid.Parse(mux.Vars(r)["player_id"])
+		if err != nil {
+			slog.ErrorContext(r.Context(), "invalid player_id", "err", err, "player_id", mux.Vars(r)["player_id"])
+			http.Error(w, "Bad Request", http.StatusBadRequest)
+			return
+		}
+
+		png, err := c.PlayerCardQuery.Exec(r.Context(), playerID)
+		if err != nil {
+			var notFoundErr *replay.PlayerNotFoundError
+			if errors.As(err, &notFoundErr) {
+				http.Error(w, notFoundErr.Message, http.StatusNotFound)
+				return
+			}
+
+			slog.ErrorContext(r.Context(), "error rendering player card", "err", err, "player_id", playerID)
+			http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "image/png")
+		w.Header().Set("Content-Length", strconv.Itoa(len(png)))
+		w.Header().Set("Cache-Control", fmt.Sprintf("public, max-age=%d", int(c.CacheTTL.Seconds())))
+		w.WriteHeader(http.StatusOK)
+
+		if r.Method != http.MethodHead {
+			w.Write(png)
+		}
+	}
+}
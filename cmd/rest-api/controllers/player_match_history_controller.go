@@ -0,0 +1,134 @@
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/golobby/container/v3"
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	replay_in "github.com/psavelis/team-pro/replay-api/pkg/domain/replay/ports/in"
+)
+
+type PlayerMatchHistoryController struct {
+	HistoryReader replay_in.PlayerMatchHistoryReader
+}
+
+func NewPlayerMatchHistoryController(container *container.Container) *PlayerMatchHistoryController {
+	var historyReader replay_in.PlayerMatchHistoryReader
+	err := container.Resolve(&historyReader)
+
+	if err != nil {
+		slog.Error("Cannot resolve replay_in.PlayerMatchHistoryReader for new PlayerMatchHistoryController", "err", err)
+		panic(err)
+	}
+
+	return &PlayerMatchHistoryController{HistoryReader: historyReader}
+}
+
+// GetPlayerMatches returns the match history of a player (most recent first, unless `sort` is given), paginated by `skip` and `limit`, and
+// optionally restricted to the matches of a `platform`, `server_region` and `tickrate` (see common.ReplayTags).
+func (c *PlayerMatchHistoryController) GetPlayerMatches(apiContext context.Context) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		playerID, err := uuid.Parse(mux.Vars(r)["player_id"])
+		if err != nil {
+			slog.ErrorContext(r.Context(), "invalid player_id", "err", err, "player_id", mux.Vars(r)["player_id"])
+			http.Error(w, "Bad Request", http.StatusBadRequest)
+			return
+		}
+
+		skip, err := parseUintQueryParam(r, "skip", 0)
+		if err != nil {
+			slog.ErrorContext(r.Context(), "invalid player matches `skip` parameter", "err", err)
+			http.Error(w, "Bad Request", http.StatusBadRequest)
+			return
+		}
+
+		limit, err := parseUintQueryParam(r, "limit", common.DefaultPageSize)
+		if err != nil {
+			slog.ErrorContext(r.Context(), "invalid player matches `limit` parameter", "err", err)
+			http.Error(w, "Bad Request", http.StatusBadRequest)
+			return
+		}
+
+		sort, err := common.ParseSortOptions(r.URL.Query().Get("sort"))
+		if err != nil {
+			slog.ErrorContext(r.Context(), "invalid player matches `sort` parameter", "err", err)
+			http.Error(w, "Bad Request", http.StatusBadRequest)
+			return
+		}
+
+		tags, err := parseReplayTagsFilter(r)
+		if err != nil {
+			slog.ErrorContext(r.Context(), "invalid player matches tags parameters", "err", err)
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		params := []common.SearchAggregation{
+			{
+				Params: []common.SearchParameter{
+					{
+						ValueParams: append([]common.SearchableValue{
+							{
+								Field:  "PlayerID",
+								Values: []interface{}{playerID},
+							},
+						}, tags.SearchableValues("Tags")...),
+					},
+				},
+			},
+		}
+
+		resultOptions := common.NewSearchResultOptions(skip, limit)
+		resultOptions.Sort = sort
+
+		s, err := c.HistoryReader.Compile(r.Context(), params, resultOptions)
+		if err != nil {
+			slog.ErrorContext(r.Context(), "error compiling player matches search", "err", err)
+			http.Error(w, "Bad Request", http.StatusBadRequest)
+			return
+		}
+
+		// most recent first, unless sorted otherwise
+		if len(s.SortOptions) == 0 {
+			s.SortOptions = []common.SearchSortOption{{Field: "PlayedAt", Direction: common.DescendingIDKey}}
+		}
+
+		entries, err := c.HistoryReader.Search(r.Context(), *s)
+		if err != nil {
+			slog.ErrorContext(r.Context(), "error searching player match history", "err", err, "player_id", playerID)
+			http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(entries)
+	}
+}
+
+func parseUintQueryParam(r *http.Request, name string, defaultValue uint) (uint, error) {
+	v := r.URL.Query().Get(name)
+	if v == "" {
+		return defaultValue, nil
+	}
+
+	n, err := strconv.ParseUint(v, 10, 32)
+	if err != nil {
+		return 0, err
+	}
+
+	return uint(n), nil
+}
+
+// parseReplayTagsFilter reads the `platform`, `server_region` and `tickrate` the matches are filtered by.
+func parseReplayTagsFilter(r *http.Request) (common.ReplayTagsFilter, error) {
+	q := r.URL.Query()
+
+	return common.NewReplayTagsFilter(q.Get("platform"), q.Get("server_region"), q.Get("tickrate"))
+}
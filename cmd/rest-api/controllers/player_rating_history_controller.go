@@ -0,0 +1,119 @@
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+
+	"github.com/golobby/container/v3"
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	rating_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/rating/entities"
+	rating_in "github.com/psavelis/team-pro/replay-api/pkg/domain/rating/ports/in"
+)
+
+type PlayerRatingHistoryController struct {
+	HistoryReader rating_in.RatingHistoryReader
+}
+
+func NewPlayerRatingHistoryController(container *container.Container) *PlayerRatingHistoryController {
+	var historyReader rating_in.RatingHistoryReader
+	err := container.Resolve(&historyReader)
+
+	if err != nil {
+		slog.Error("Cannot resolve rating_in.RatingHistoryReader for new PlayerRatingHistoryController", "err", err)
+		panic(err)
+	}
+
+	return &PlayerRatingHistoryController{HistoryReader: historyReader}
+}
+
+// GetPlayerRatingHistory returns the rating of a player after each of their rated matches (most recent first, unless `sort` is given),
+// optionally of a single `game_id`, paginated by `skip` and `limit`.
+func (c *PlayerRatingHistoryController) GetPlayerRatingHistory(apiContext context.Context) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		playerID, err := uuid.Parse(mux.Vars(r)["player_id"])
+		if err != nil {
+			slog.ErrorContext(r.Context(), "invalid player_id", "err", err, "player_id", mux.Vars(r)["player_id"])
+			http.Error(w, "Bad Request", http.StatusBadRequest)
+			return
+		}
+
+		skip, err := parseUintQueryParam(r, "skip", 0)
+		if err != nil {
+			slog.ErrorContext(r.Context(), "invalid rating history `skip` parameter", "err", err)
+			http.Error(w, "Bad Request", http.StatusBadRequest)
+			return
+		}
+
+		limit, err := parseUintQueryParam(r, "limit", common.DefaultPageSize)
+		if err != nil {
+			slog.ErrorContext(r.Context(), "invalid rating history `limit` parameter", "err", err)
+			http.Error(w, "Bad Request", http.StatusBadRequest)
+			return
+		}
+
+		sort, err := common.ParseSortOptions(r.URL.Query().Get("sort"))
+		if err != nil {
+			slog.ErrorContext(r.Context(), "invalid rating history `sort` parameter", "err", err)
+			http.Error(w, "Bad Request", http.StatusBadRequest)
+			return
+		}
+
+		valueParams := []common.SearchableValue{
+			{
+				Field:  "Subject",
+				Values: []interface{}{rating_entities.RatingSubjectPlayer},
+			},
+			{
+				Field:  "SubjectID",
+				Values: []interface{}{playerID},
+			},
+		}
+
+		if gameID := r.URL.Query().Get("game_id"); gameID != "" {
+			valueParams = append(valueParams, common.SearchableValue{
+				Field:  "GameID",
+				Values: []interface{}{common.GameIDKey(gameID)},
+			})
+		}
+
+		params := []common.SearchAggregation{
+			{
+				Params: []common.SearchParameter{
+					{
+						ValueParams: valueParams,
+					},
+				},
+			},
+		}
+
+		resultOptions := common.NewSearchResultOptions(skip, limit)
+		resultOptions.Sort = sort
+
+		s, err := c.HistoryReader.Compile(r.Context(), params, resultOptions)
+		if err != nil {
+			slog.ErrorContext(r.Context(), "error compiling rating history search", "err", err)
+			http.Error(w, "Bad Request", http.StatusBadRequest)
+			return
+		}
+
+		// most recent first, unless sorted otherwise
+		if len(s.SortOptions) == 0 {
+			s.SortOptions = []common.SearchSortOption{{Field: "PlayedAt", Direction: common.DescendingIDKey}}
+		}
+
+		entries, err := c.HistoryReader.Search(r.Context(), *s)
+		if err != nil {
+			slog.ErrorContext(r.Context(), "error searching player rating history", "err", err, "player_id", playerID)
+			http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(entries)
+	}
+}
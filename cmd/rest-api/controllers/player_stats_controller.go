@@ -0,0 +1,108 @@
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/golobby/container/v3"
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/psavelis/team-pro/replay-api/pkg/domain/replay"
+	replay_in "github.com/psavelis/team-pro/replay-api/pkg/domain/replay/ports/in"
+)
+
+type PlayerStatsController struct {
+	PlayerStatsQuery replay_in.PlayerStatsQuery
+}
+
+func NewPlayerStatsController(container *container.Container) *PlayerStatsController {
+	var playerStatsQuery replay_in.PlayerStatsQuery
+	err := container.Resolve(&playerStatsQuery)
+
+	if err != nil {
+		slog.Error("Cannot resolve replay_in.PlayerStatsQuery for new PlayerStatsController", "err", err)
+		panic(err)
+	}
+
+	return &PlayerStatsController{PlayerStatsQuery: playerStatsQuery}
+}
+
+// GetPlayerStats returns the stats of a player (overall and by map), optionally restricted to a `map`, to the replays of the days
+// between `from` and `to` (YYYY-MM-DD, both inclusive) and to the ones of a `platform`, `server_region` and `tickrate`.
+func (c *PlayerStatsController) GetPlayerStats(apiContext context.Context) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		playerID, err := uuid.Parse(mux.Vars(r)["player_id"])
+		if err != nil {
+			slog.ErrorContext(r.Context(), "invalid player_id", "err", err, "player_id", mux.Vars(r)["player_id"])
+			http.Error(w, "Bad Request", http.StatusBadRequest)
+			return
+		}
+
+		from, err := parseDayQueryParam(r, "from")
+		if err != nil {
+			slog.ErrorContext(r.Context(), "invalid player stats `from` parameter", "err", err)
+			http.Error(w, "Bad Request", http.StatusBadRequest)
+			return
+		}
+
+		to, err := parseDayQueryParam(r, "to")
+		if err != nil {
+			slog.ErrorContext(r.Context(), "invalid player stats `to` parameter", "err", err)
+			http.Error(w, "Bad Request", http.StatusBadRequest)
+			return
+		}
+
+		if from != nil && to != nil && to.Before(*from) {
+			http.Error(w, "`to` must not be before `from`", http.StatusBadRequest)
+			return
+		}
+
+		tags, err := parseReplayTagsFilter(r)
+		if err != nil {
+			slog.ErrorContext(r.Context(), "invalid player stats tags parameters", "err", err)
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		stats, err := c.PlayerStatsQuery.Exec(r.Context(), replay_in.PlayerStatsQueryParams{
+			PlayerID: playerID,
+			MapName:  r.URL.Query().Get("map"),
+			From:     from,
+			To:       to,
+			Tags:     tags,
+		})
+
+		if err != nil {
+			var notFoundErr *replay.PlayerNotFoundError
+			if errors.As(err, &notFoundErr) {
+				http.Error(w, notFoundErr.Message, http.StatusNotFound)
+				return
+			}
+
+			http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(stats)
+	}
+}
+
+func parseDayQueryParam(r *http.Request, name string) (*time.Time, error) {
+	v := r.URL.Query().Get(name)
+	if v == "" {
+		return nil, nil
+	}
+
+	day, err := time.Parse(time.DateOnly, v)
+	if err != nil {
+		return nil, err
+	}
+
+	return &day, nil
+}
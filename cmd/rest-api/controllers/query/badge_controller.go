@@ -0,0 +1,26 @@
+package query_controllers
+
+import (
+	"github.com/golobby/container/v3"
+	controllers "github.com/psavelis/team-pro/replay-api/cmd/rest-api/controllers"
+	badge_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/badge/entities"
+	badge_in "github.com/psavelis/team-pro/replay-api/pkg/domain/badge/ports/in"
+)
+
+type BadgeQueryController struct {
+	controllers.DefaultSearchController[badge_entities.Badge]
+}
+
+func NewBadgeQueryController(c container.Container) *BadgeQueryController {
+	var queryService badge_in.BadgeReader
+
+	err := c.Resolve(&queryService)
+
+	if err != nil {
+		panic(err)
+	}
+
+	baseController := controllers.NewDefaultSearchController(queryService)
+
+	return &BadgeQueryController{*baseController}
+}
@@ -0,0 +1,26 @@
+package query_controllers
+
+import (
+	"github.com/golobby/container/v3"
+	controllers "github.com/psavelis/team-pro/replay-api/cmd/rest-api/controllers"
+	customfield_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/customfield/entities"
+	customfield_in "github.com/psavelis/team-pro/replay-api/pkg/domain/customfield/ports/in"
+)
+
+type CustomFieldQueryController struct {
+	controllers.DefaultSearchController[customfield_entities.CustomFieldDefinition]
+}
+
+func NewCustomFieldQueryController(c container.Container) *CustomFieldQueryController {
+	var queryService customfield_in.CustomFieldDefinitionReader
+
+	err := c.Resolve(&queryService)
+
+	if err != nil {
+		panic(err)
+	}
+
+	baseController := controllers.NewDefaultSearchController(queryService)
+
+	return &CustomFieldQueryController{*baseController}
+}
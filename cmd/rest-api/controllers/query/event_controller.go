@@ -1,14 +1,25 @@
 package query_controllers
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strings"
+
 	"github.com/golobby/container/v3"
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
 	controllers "github.com/psavelis/team-pro/replay-api/cmd/rest-api/controllers"
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
 	replay_entity "github.com/psavelis/team-pro/replay-api/pkg/domain/replay/entities"
 	replay_in "github.com/psavelis/team-pro/replay-api/pkg/domain/replay/ports/in"
 )
 
 type EventQueryController struct {
 	controllers.DefaultSearchController[replay_entity.GameEvent]
+	EventReader replay_in.EventReader
 }
 
 func NewEventQueryController(c container.Container) *EventQueryController {
@@ -22,5 +33,70 @@ func NewEventQueryController(c container.Container) *EventQueryController {
 
 	baseController := controllers.NewDefaultSearchController(queryService)
 
-	return &EventQueryController{*baseController}
+	return &EventQueryController{*baseController, queryService}
+}
+
+// MatchStatsHandler rolls up the events of a match: the `metrics` (ie: "count,avg:Time", count when not informed) of its events
+// grouped by the `group_by` fields (comma separated, Type when not informed; Taxonomy.Category or Taxonomy.Type across games).
+func (c *EventQueryController) MatchStatsHandler(apiContext context.Context) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		matchID, err := uuid.Parse(mux.Vars(r)["match_id"])
+		if err != nil {
+			slog.ErrorContext(r.Context(), "invalid match_id", "err", err, "match_id", mux.Vars(r)["match_id"])
+			http.Error(w, "Bad Request", http.StatusBadRequest)
+			return
+		}
+
+		groupBy := []string{"Type"}
+		if v := r.URL.Query().Get("group_by"); v != "" {
+			groupBy = strings.Split(v, ",")
+		}
+
+		metrics := []common.AggregateMetric{{Name: string(common.CountAggregateOperator), Operator: common.CountAggregateOperator}}
+		if v := r.URL.Query().Get("metrics"); v != "" {
+			metrics, err = common.ParseAggregateMetrics(v)
+			if err != nil {
+				slog.ErrorContext(r.Context(), "invalid match stats `metrics` parameter", "err", err)
+				http.Error(w, "Bad Request", http.StatusBadRequest)
+				return
+			}
+		}
+
+		params := []common.SearchAggregation{
+			{
+				Params: []common.SearchParameter{
+					{
+						ValueParams: []common.SearchableValue{
+							{Field: "GameID", Values: []interface{}{mux.Vars(r)["game_id"]}},
+							{Field: "MatchID", Values: []interface{}{matchID}},
+						},
+					},
+				},
+			},
+		}
+
+		s, err := c.EventReader.Compile(r.Context(), params, common.NewSearchResultOptions(0, 0))
+		if err != nil {
+			slog.ErrorContext(r.Context(), "error compiling match stats search", "err", err)
+			http.Error(w, "Bad Request", http.StatusBadRequest)
+			return
+		}
+
+		groups, err := c.EventReader.Aggregate(r.Context(), *s, groupBy, metrics)
+		if err != nil {
+			if errors.Is(err, common.ErrInvalidAggregate) {
+				slog.ErrorContext(r.Context(), "invalid match stats request", "err", err)
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+
+			slog.ErrorContext(r.Context(), "error aggregating match events", "err", err, "match_id", matchID)
+			http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(groups)
+	}
 }
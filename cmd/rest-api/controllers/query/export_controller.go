@@ -0,0 +1,96 @@
+package query_controllers
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/golobby/container/v3"
+	controllers "github.com/psavelis/team-pro/replay-api/cmd/rest-api/controllers"
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	export_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/export/entities"
+	export_in "github.com/psavelis/team-pro/replay-api/pkg/domain/export/ports/in"
+)
+
+// ExportConfigResponse is an export config as listed: its destination (the bucket, the role assumed and its external ID) is only
+// returned on creation.
+type ExportConfigResponse struct {
+	export_entities.ExportConfig
+	Destination *export_entities.ExportDestination `json:"destination,omitempty"` // never set, shadows the destination of the config
+}
+
+// ExportConfigSearch lists the export configs of the service without their destinations.
+type ExportConfigSearch struct {
+	export_in.ExportConfigReader
+}
+
+func (s ExportConfigSearch) Search(ctx context.Context, search common.Search) ([]ExportConfigResponse, error) {
+	configs, err := s.ExportConfigReader.Search(ctx, search)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]ExportConfigResponse, 0, len(configs))
+	for _, config := range configs {
+		res = append(res, ExportConfigResponse{ExportConfig: config})
+	}
+
+	return res, nil
+}
+
+func (s ExportConfigSearch) NextCursor(ctx context.Context, search common.Search, last interface{}) (string, error) {
+	paginated, ok := s.ExportConfigReader.(common.CursorPaginated)
+	if !ok {
+		return "", fmt.Errorf("export config search %T does not support cursor pagination", s.ExportConfigReader)
+	}
+
+	if response, ok := last.(ExportConfigResponse); ok {
+		last = response.ExportConfig
+	}
+
+	return paginated.NextCursor(ctx, search, last)
+}
+
+func (s ExportConfigSearch) Facets(ctx context.Context, search common.Search) (common.Facets, error) {
+	facetable, ok := s.ExportConfigReader.(common.Facetable)
+	if !ok {
+		return nil, fmt.Errorf("export config search %T does not support facets", s.ExportConfigReader)
+	}
+
+	return facetable.Facets(ctx, search)
+}
+
+type ExportConfigQueryController struct {
+	controllers.DefaultSearchController[ExportConfigResponse]
+}
+
+func NewExportConfigQueryController(c container.Container) *ExportConfigQueryController {
+	var queryService export_in.ExportConfigReader
+
+	err := c.Resolve(&queryService)
+
+	if err != nil {
+		panic(err)
+	}
+
+	baseController := controllers.NewDefaultSearchController[ExportConfigResponse](ExportConfigSearch{queryService})
+
+	return &ExportConfigQueryController{*baseController}
+}
+
+type ExportReceiptQueryController struct {
+	controllers.DefaultSearchController[export_entities.ExportReceipt]
+}
+
+func NewExportReceiptQueryController(c container.Container) *ExportReceiptQueryController {
+	var queryService export_in.ExportReceiptReader
+
+	err := c.Resolve(&queryService)
+
+	if err != nil {
+		panic(err)
+	}
+
+	baseController := controllers.NewDefaultSearchController(queryService)
+
+	return &ExportReceiptQueryController{*baseController}
+}
@@ -0,0 +1,66 @@
+package query_controllers_test
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+	query_controllers "github.com/psavelis/team-pro/replay-api/cmd/rest-api/controllers/query"
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	export_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/export/entities"
+	"github.com/stretchr/testify/assert"
+)
+
+type mockExportConfigReader struct {
+	configs []export_entities.ExportConfig
+}
+
+func (m *mockExportConfigReader) Search(ctx context.Context, s common.Search) ([]export_entities.ExportConfig, error) {
+	return m.configs, nil
+}
+
+func (m *mockExportConfigReader) Compile(ctx context.Context, searchParams []common.SearchAggregation, resultOptions common.SearchResultOptions) (*common.Search, error) {
+	return &common.Search{SearchParams: searchParams, ResultOptions: resultOptions}, nil
+}
+
+func TestExportConfigQueryController_OmitsDestination(t *testing.T) {
+	config := export_entities.ExportConfig{
+		ID:     uuid.New(),
+		Name:   "nightly",
+		Format: export_entities.ExportFormatJSON,
+		Destination: export_entities.ExportDestination{
+			Bucket:     "tenant-exports",
+			Region:     "us-east-1",
+			RoleARN:    "arn:aws:iam::123456789012:role/exports",
+			ExternalID: uuid.NewString(),
+		},
+	}
+
+	controller := query_controllers.ExportConfigQueryController{}
+	controller.Searchable = query_controllers.ExportConfigSearch{ExportConfigReader: &mockExportConfigReader{configs: []export_entities.ExportConfig{config}}}
+
+	search, _ := json.Marshal(common.Search{SearchParams: []common.SearchAggregation{}})
+
+	r := httptest.NewRequest(http.MethodGet, "/exports", nil)
+	r.Header.Set("x-search", base64.StdEncoding.EncodeToString(search))
+	w := httptest.NewRecorder()
+
+	controller.DefaultSearchHandler(w, r)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var res []map[string]interface{}
+	if !assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &res)) || !assert.Len(t, res, 1) {
+		return
+	}
+
+	assert.Equal(t, config.ID.String(), res[0]["id"])
+	assert.Equal(t, "nightly", res[0]["name"])
+	assert.NotContains(t, res[0], "destination")
+	assert.NotContains(t, w.Body.String(), config.Destination.ExternalID)
+	assert.NotContains(t, w.Body.String(), config.Destination.RoleARN)
+}
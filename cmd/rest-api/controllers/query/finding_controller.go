@@ -0,0 +1,26 @@
+package query_controllers
+
+import (
+	"github.com/golobby/container/v3"
+	controllers "github.com/psavelis/team-pro/replay-api/cmd/rest-api/controllers"
+	quality_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/quality/entities"
+	quality_in "github.com/psavelis/team-pro/replay-api/pkg/domain/quality/ports/in"
+)
+
+type FindingQueryController struct {
+	controllers.DefaultSearchController[quality_entities.Finding]
+}
+
+func NewFindingQueryController(c container.Container) *FindingQueryController {
+	var queryService quality_in.FindingReader
+
+	err := c.Resolve(&queryService)
+
+	if err != nil {
+		panic(err)
+	}
+
+	baseController := controllers.NewDefaultSearchController(queryService)
+
+	return &FindingQueryController{*baseController}
+}
@@ -0,0 +1,107 @@
+package query_controllers
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+
+	"github.com/golobby/container/v3"
+	replay_in "github.com/psavelis/team-pro/replay-api/pkg/domain/replay/ports/in"
+	squad_in "github.com/psavelis/team-pro/replay-api/pkg/domain/squad/ports/in"
+	"github.com/psavelis/team-pro/replay-api/pkg/infra/graphql"
+)
+
+// max size of a GraphQL request body (query and variables)
+const MaxGraphQLRequestBytes = 64 << 10
+
+type GraphQLController struct {
+	Schema *graphql.Schema
+}
+
+func NewGraphQLController(c *container.Container) *GraphQLController {
+	var matchReader replay_in.MatchReader
+	err := c.Resolve(&matchReader)
+	if err != nil {
+		slog.Error("Cannot resolve replay_in.MatchReader for NewGraphQLController", "err", err)
+		panic(err)
+	}
+
+	var playerReader replay_in.PlayerReader
+	err = c.Resolve(&playerReader)
+	if err != nil {
+		slog.Error("Cannot resolve replay_in.PlayerReader for NewGraphQLController", "err", err)
+		panic(err)
+	}
+
+	var historyReader replay_in.PlayerMatchHistoryReader
+	err = c.Resolve(&historyReader)
+	if err != nil {
+		slog.Error("Cannot resolve replay_in.PlayerMatchHistoryReader for NewGraphQLController", "err", err)
+		panic(err)
+	}
+
+	var squadReader squad_in.SquadSearchableReader
+	err = c.Resolve(&squadReader)
+	if err != nil {
+		slog.Error("Cannot resolve squad_in.SquadSearchableReader for NewGraphQLController", "err", err)
+		panic(err)
+	}
+
+	schema, err := NewReadModelSchema(matchReader, playerReader, historyReader, squadReader)
+	if err != nil {
+		slog.Error("Cannot build the read model GraphQL schema", "err", err)
+		panic(err)
+	}
+
+	return &GraphQLController{Schema: schema}
+}
+
+// QueryHandler executes GraphQL queries, sent as a JSON body (POST) or as the `query`, `operationName` and `variables` query
+// parameters (GET). Requests that can't be executed (ie: syntax errors, unknown fields) are answered with 400.
+func (ctrl *GraphQLController) QueryHandler(apiContext context.Context) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req graphql.Request
+
+		if r.Method == http.MethodGet {
+			req.Query = r.URL.Query().Get("query")
+			req.OperationName = r.URL.Query().Get("operationName")
+
+			if variables := r.URL.Query().Get("variables"); variables != "" {
+				err := json.Unmarshal([]byte(variables), &req.Variables)
+				if err != nil {
+					slog.ErrorContext(r.Context(), "invalid graphql variables", "err", err)
+					http.Error(w, "Bad Request", http.StatusBadRequest)
+					return
+				}
+			}
+		} else {
+			err := json.NewDecoder(http.MaxBytesReader(w, r.Body, MaxGraphQLRequestBytes)).Decode(&req)
+			if err != nil {
+				slog.ErrorContext(r.Context(), "invalid graphql request", "err", err)
+				http.Error(w, "Bad Request", http.StatusBadRequest)
+				return
+			}
+		}
+
+		if req.Query == "" {
+			http.Error(w, "Bad Request", http.StatusBadRequest)
+			return
+		}
+
+		res := ctrl.Schema.Execute(r.Context(), req)
+
+		status := http.StatusOK
+		if res.Data == nil {
+			status = http.StatusBadRequest
+		}
+
+		for _, gqlErr := range res.Errors {
+			slog.WarnContext(r.Context(), "graphql error", "operationName", req.OperationName, "message", gqlErr.Message, "path", gqlErr.Path)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		json.NewEncoder(w).Encode(res)
+	}
+}
@@ -0,0 +1,304 @@
+package query_controllers
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	replay_entity "github.com/psavelis/team-pro/replay-api/pkg/domain/replay/entities"
+	replay_in "github.com/psavelis/team-pro/replay-api/pkg/domain/replay/ports/in"
+	squad_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/squad/entities"
+	squad_in "github.com/psavelis/team-pro/replay-api/pkg/domain/squad/ports/in"
+	"github.com/psavelis/team-pro/replay-api/pkg/infra/graphql"
+)
+
+const MaxGraphQLPageSize = 100
+
+// readModelResolvers backs the GraphQL schema with the query services of the REST API (same queryable fields, same visibility).
+type readModelResolvers struct {
+	MatchReader   replay_in.MatchReader
+	PlayerReader  replay_in.PlayerReader
+	HistoryReader replay_in.PlayerMatchHistoryReader
+	SquadReader   squad_in.SquadSearchableReader
+}
+
+// NewReadModelSchema returns the GraphQL schema of the read models:
+//
+//	type Query {
+//	  match(id: ID): Match
+//	  matches(gameId: String, skip: Int, limit: Int): [Match]
+//	  player(id: ID): Player
+//	  players(gameId: String, skip: Int, limit: Int): [Player]
+//	  squad(id: ID): Squad
+//	  squads(gameId: String, skip: Int, limit: Int): [Squad]
+//	}
+//
+// Player.matches is loaded once per distinct player and PlayerMatch.match with a single search for every match of the level, so a
+// list of players with their recent matches costs a query per player (not per match).
+func NewReadModelSchema(matchReader replay_in.MatchReader, playerReader replay_in.PlayerReader, historyReader replay_in.PlayerMatchHistoryReader, squadReader squad_in.SquadSearchableReader) (*graphql.Schema, error) {
+	r := &readModelResolvers{
+		MatchReader:   matchReader,
+		PlayerReader:  playerReader,
+		HistoryReader: historyReader,
+		SquadReader:   squadReader,
+	}
+
+	match := &graphql.Object{Name: "Match"}
+	team := &graphql.Object{Name: "MatchTeam"}
+	player := &graphql.Object{Name: "Player"}
+	rankBadge := &graphql.Object{Name: "RankBadge"}
+	playerMatch := &graphql.Object{Name: "PlayerMatch"}
+	squad := &graphql.Object{Name: "Squad"}
+
+	match.Fields = map[string]*graphql.FieldDefinition{
+		"id":           property(graphql.ID, func(m replay_entity.Match) any { return m.ID }),
+		"gameId":       property(graphql.String, func(m replay_entity.Match) any { return m.GameID }),
+		"regionId":     property(graphql.String, func(m replay_entity.Match) any { return m.RegionID }),
+		"replayFileId": property(graphql.ID, func(m replay_entity.Match) any { return m.ReplayFileID }),
+		"lobbyId":      property(graphql.ID, func(m replay_entity.Match) any { return m.LobbyID }),
+		"createdAt":    property(graphql.String, func(m replay_entity.Match) any { return m.CreatedAt }),
+		"teams":        property(graphql.NewList(team), func(m replay_entity.Match) any { return m.Scoreboard.TeamScoreboards }),
+		"mvp": property(player, func(m replay_entity.Match) any {
+			if m.Scoreboard.MatchMVP == nil {
+				return nil
+			}
+
+			return *m.Scoreboard.MatchMVP
+		}),
+	}
+
+	team.Fields = map[string]*graphql.FieldDefinition{
+		"id":      property(graphql.ID, func(t replay_entity.TeamScoreboard) any { return t.Team.ID }),
+		"name":    property(graphql.String, func(t replay_entity.TeamScoreboard) any { return t.Team.Name }),
+		"score":   property(graphql.Int, func(t replay_entity.TeamScoreboard) any { return t.TeamScore }),
+		"players": property(graphql.NewList(player), func(t replay_entity.TeamScoreboard) any { return t.Players }),
+	}
+
+	player.Fields = map[string]*graphql.FieldDefinition{
+		"id":         property(graphql.ID, func(p replay_entity.Player) any { return uuid.UUID(p.ID) }),
+		"gameId":     property(graphql.String, func(p replay_entity.Player) any { return p.GameID }),
+		"networkId":  property(graphql.String, func(p replay_entity.Player) any { return p.NetworkID }),
+		"name":       property(graphql.String, func(p replay_entity.Player) any { return p.Name }),
+		"clanName":   property(graphql.String, func(p replay_entity.Player) any { return p.ClanName }),
+		"avatarUri":  property(graphql.String, func(p replay_entity.Player) any { return p.AvatarURI }),
+		"rankBadges": property(graphql.NewList(rankBadge), func(p replay_entity.Player) any { return p.RankBadges }),
+		"matches": {
+			Type:    graphql.NewList(playerMatch),
+			Args:    map[string]*graphql.Scalar{"skip": graphql.Int, "limit": graphql.Int},
+			Resolve: r.resolvePlayerMatches,
+		},
+	}
+
+	rankBadge.Fields = map[string]*graphql.FieldDefinition{
+		"source": property(graphql.String, func(b replay_entity.RankBadge) any { return b.Source }),
+		"label":  property(graphql.String, func(b replay_entity.RankBadge) any { return b.Label }),
+		"tier":   property(graphql.Int, func(b replay_entity.RankBadge) any { return b.Tier }),
+	}
+
+	playerMatch.Fields = map[string]*graphql.FieldDefinition{
+		"id":            property(graphql.ID, func(h replay_entity.PlayerMatchHistory) any { return h.ID }),
+		"outcome":       property(graphql.String, func(h replay_entity.PlayerMatchHistory) any { return h.Outcome }),
+		"teamName":      property(graphql.String, func(h replay_entity.PlayerMatchHistory) any { return h.TeamName }),
+		"teamScore":     property(graphql.Int, func(h replay_entity.PlayerMatchHistory) any { return h.TeamScore }),
+		"opponentScore": property(graphql.Int, func(h replay_entity.PlayerMatchHistory) any { return h.OpponentScore }),
+		"playedAt":      property(graphql.String, func(h replay_entity.PlayerMatchHistory) any { return h.PlayedAt }),
+		"match": {
+			Type:    match,
+			Resolve: r.resolveHistoryMatches,
+		},
+	}
+
+	squad.Fields = map[string]*graphql.FieldDefinition{
+		"id":          property(graphql.ID, func(s squad_entities.Squad) any { return s.ID }),
+		"gameId":      property(graphql.String, func(s squad_entities.Squad) any { return s.GameID }),
+		"name":        property(graphql.String, func(s squad_entities.Squad) any { return s.Name }),
+		"symbol":      property(graphql.String, func(s squad_entities.Squad) any { return s.Symbol }),
+		"description": property(graphql.String, func(s squad_entities.Squad) any { return s.Description }),
+		"logoUri":     property(graphql.String, func(s squad_entities.Squad) any { return s.LogoURI }),
+	}
+
+	idArgs := map[string]*graphql.Scalar{"id": graphql.ID}
+	listArgs := map[string]*graphql.Scalar{"gameId": graphql.String, "skip": graphql.Int, "limit": graphql.Int}
+
+	query := &graphql.Object{
+		Name: "Query",
+		Fields: map[string]*graphql.FieldDefinition{
+			"match":   {Type: match, Args: idArgs, Resolve: rootByID(r.MatchReader, func(id uuid.UUID) any { return id })},
+			"matches": {Type: graphql.NewList(match), Args: listArgs, Resolve: rootList(r.MatchReader, []common.SearchSortOption{{Field: "CreatedAt", Direction: common.DescendingIDKey}})},
+			"player":  {Type: player, Args: idArgs, Resolve: rootByID(r.PlayerReader, func(id uuid.UUID) any { return common.PlayerIDType(id) })},
+			"players": {Type: graphql.NewList(player), Args: listArgs, Resolve: rootList(r.PlayerReader, nil)},
+			"squad":   {Type: squad, Args: idArgs, Resolve: rootByID(r.SquadReader, func(id uuid.UUID) any { return id })},
+			"squads":  {Type: graphql.NewList(squad), Args: listArgs, Resolve: rootList(r.SquadReader, nil)},
+		},
+	}
+
+	return graphql.NewSchema(query)
+}
+
+// property resolves a field read from the source entity.
+func property[T any](t graphql.Type, get func(source T) any) *graphql.FieldDefinition {
+	return &graphql.FieldDefinition{
+		Type: t,
+		Resolve: graphql.ResolveEach(func(source any, _ map[string]any) (any, error) {
+			return get(source.(T)), nil
+		}),
+	}
+}
+
+// rootByID resolves a root field fetching a single entity by its id (null when not found, or not visible to the user).
+func rootByID[T any](reader common.Searchable[T], id func(uuid.UUID) any) graphql.BatchResolveFunc {
+	return func(ctx context.Context, sources []any, args map[string]any) ([]any, error) {
+		value, _ := args["id"].(string)
+
+		parsed, err := uuid.Parse(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid id %q", value)
+		}
+
+		results, err := search(ctx, reader, []common.SearchableValue{{Field: "ID", Values: []interface{}{id(parsed)}}}, 0, 1, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(results) == 0 {
+			return []any{nil}, nil
+		}
+
+		return []any{results[0]}, nil
+	}
+}
+
+// rootList resolves a root field listing the entities of a game (or of every game), paginated by `skip` and `limit`.
+func rootList[T any](reader common.Searchable[T], sort []common.SearchSortOption) graphql.BatchResolveFunc {
+	return func(ctx context.Context, sources []any, args map[string]any) ([]any, error) {
+		skip, limit, err := pageArgs(args)
+		if err != nil {
+			return nil, err
+		}
+
+		var values []common.SearchableValue
+		if gameID, ok := args["gameId"].(string); ok {
+			values = append(values, common.SearchableValue{Field: "GameID", Values: []interface{}{gameID}})
+		}
+
+		results, err := search(ctx, reader, values, skip, limit, sort)
+		if err != nil {
+			return nil, err
+		}
+
+		return []any{results}, nil
+	}
+}
+
+// resolvePlayerMatches loads the recent matches of each distinct player once, even when the player is repeated in the level (ie: the
+// players of every team of a list of matches).
+func (r *readModelResolvers) resolvePlayerMatches(ctx context.Context, sources []any, args map[string]any) ([]any, error) {
+	skip, limit, err := pageArgs(args)
+	if err != nil {
+		return nil, err
+	}
+
+	histories := make(map[uuid.UUID][]replay_entity.PlayerMatchHistory)
+	values := make([]any, len(sources))
+
+	for i, source := range sources {
+		playerID := uuid.UUID(source.(replay_entity.Player).ID)
+
+		entries, ok := histories[playerID]
+		if !ok {
+			entries, err = search(ctx, r.HistoryReader, []common.SearchableValue{{Field: "PlayerID", Values: []interface{}{playerID}}}, skip, limit, []common.SearchSortOption{{Field: "PlayedAt", Direction: common.DescendingIDKey}})
+			if err != nil {
+				return nil, err
+			}
+
+			histories[playerID] = entries
+		}
+
+		values[i] = entries
+	}
+
+	return values, nil
+}
+
+// resolveHistoryMatches loads the matches of every history entry of the level with a single search.
+func (r *readModelResolvers) resolveHistoryMatches(ctx context.Context, sources []any, args map[string]any) ([]any, error) {
+	seen := make(map[uuid.UUID]bool)
+	ids := make([]interface{}, 0, len(sources))
+
+	for _, source := range sources {
+		matchID := source.(replay_entity.PlayerMatchHistory).MatchID
+		if !seen[matchID] {
+			seen[matchID] = true
+			ids = append(ids, matchID)
+		}
+	}
+
+	matches, err := search(ctx, r.MatchReader, []common.SearchableValue{{Field: "ID", Operator: common.InOperator, Values: ids}}, 0, uint(len(ids)), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	byID := make(map[uuid.UUID]replay_entity.Match, len(matches))
+	for _, m := range matches {
+		byID[m.ID] = m
+	}
+
+	values := make([]any, len(sources))
+	for i, source := range sources {
+		if m, ok := byID[source.(replay_entity.PlayerMatchHistory).MatchID]; ok {
+			values[i] = m
+		}
+	}
+
+	return values, nil
+}
+
+// search compiles the search with the reader, validating the fields as the REST search does.
+func search[T any](ctx context.Context, reader common.Searchable[T], values []common.SearchableValue, skip uint, limit uint, sort []common.SearchSortOption) ([]T, error) {
+	params := []common.SearchAggregation{
+		{
+			Params: []common.SearchParameter{
+				{
+					ValueParams: values,
+				},
+			},
+		},
+	}
+
+	s, err := reader.Compile(ctx, params, common.NewSearchResultOptions(skip, limit))
+	if err != nil {
+		return nil, err
+	}
+
+	s.SortOptions = sort
+
+	results, err := reader.Search(ctx, *s)
+	if err != nil {
+		return nil, err
+	}
+
+	if results == nil {
+		results = []T{} // an empty list, not null
+	}
+
+	return results, nil
+}
+
+func pageArgs(args map[string]any) (uint, uint, error) {
+	skip, _ := args["skip"].(int)
+	if skip < 0 {
+		return 0, 0, fmt.Errorf("skip must not be negative")
+	}
+
+	limit, ok := args["limit"].(int)
+	if !ok {
+		limit = int(common.DefaultPageSize)
+	}
+
+	if limit <= 0 || limit > MaxGraphQLPageSize {
+		return 0, 0, fmt.Errorf("limit must be between 1 and %d", MaxGraphQLPageSize)
+	}
+
+	return uint(skip), uint(limit), nil
+}
@@ -0,0 +1,166 @@
+package query_controllers_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	query_controllers "github.com/psavelis/team-pro/replay-api/cmd/rest-api/controllers/query"
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	replay_entity "github.com/psavelis/team-pro/replay-api/pkg/domain/replay/entities"
+	squad_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/squad/entities"
+	"github.com/psavelis/team-pro/replay-api/pkg/infra/graphql"
+	"github.com/stretchr/testify/assert"
+)
+
+// mockReader filters its items by the value params of the search (any of the values of every field).
+type mockReader[T any] struct {
+	items    []T
+	fields   map[string]func(T) any
+	searches []common.Search
+}
+
+func (m *mockReader[T]) Compile(ctx context.Context, params []common.SearchAggregation, resultOptions common.SearchResultOptions) (*common.Search, error) {
+	s := common.NewSearchByAggregation(ctx, params, resultOptions, common.UserAudienceIDKey)
+	return &s, nil
+}
+
+func (m *mockReader[T]) Search(ctx context.Context, s common.Search) ([]T, error) {
+	m.searches = append(m.searches, s)
+
+	results := []T{}
+	for _, item := range m.items {
+		if m.matches(item, s.SearchParams[0].Params[0].ValueParams) {
+			results = append(results, item)
+		}
+	}
+
+	return results, nil
+}
+
+func (m *mockReader[T]) matches(item T, values []common.SearchableValue) bool {
+	for _, v := range values {
+		found := false
+		for _, want := range v.Values {
+			if fmt.Sprint(m.fields[v.Field](item)) == fmt.Sprint(want) {
+				found = true
+			}
+		}
+
+		if !found {
+			return false
+		}
+	}
+
+	return true
+}
+
+func userContext() context.Context {
+	return common.WithResourceOwner(context.Background(), common.ResourceOwner{TenantID: uuid.New(), UserID: uuid.New()})
+}
+
+func TestReadModelSchema(t *testing.T) {
+	alice := replay_entity.Player{ID: common.PlayerIDType(uuid.New()), GameID: common.CS2_GAME_ID, Name: "alice"}
+	bob := replay_entity.Player{ID: common.PlayerIDType(uuid.New()), GameID: common.CS2_GAME_ID, Name: "bob"}
+
+	match := replay_entity.Match{
+		ID:     uuid.New(),
+		GameID: common.CS2_GAME_ID,
+		Scoreboard: replay_entity.Scoreboard{
+			TeamScoreboards: []replay_entity.TeamScoreboard{
+				{Team: replay_entity.Team{Name: "CT"}, TeamScore: 13, Players: []replay_entity.Player{alice}},
+				{Team: replay_entity.Team{Name: "T"}, TeamScore: 9, Players: []replay_entity.Player{bob}},
+			},
+		},
+	}
+
+	other := replay_entity.Match{ID: uuid.New(), GameID: common.CS2_GAME_ID}
+
+	history := func(p replay_entity.Player, m replay_entity.Match, outcome replay_entity.MatchOutcome) replay_entity.PlayerMatchHistory {
+		return replay_entity.PlayerMatchHistory{ID: uuid.New(), PlayerID: uuid.UUID(p.ID), MatchID: m.ID, Outcome: outcome, PlayedAt: time.Now()}
+	}
+
+	matches := &mockReader[replay_entity.Match]{
+		items:  []replay_entity.Match{match, other},
+		fields: map[string]func(replay_entity.Match) any{"ID": func(m replay_entity.Match) any { return m.ID }, "GameID": func(m replay_entity.Match) any { return m.GameID }},
+	}
+
+	players := &mockReader[replay_entity.Player]{
+		items:  []replay_entity.Player{alice, bob},
+		fields: map[string]func(replay_entity.Player) any{"ID": func(p replay_entity.Player) any { return uuid.UUID(p.ID) }, "GameID": func(p replay_entity.Player) any { return p.GameID }},
+	}
+
+	histories := &mockReader[replay_entity.PlayerMatchHistory]{
+		items: []replay_entity.PlayerMatchHistory{
+			history(alice, match, replay_entity.MatchOutcomeWin),
+			history(alice, other, replay_entity.MatchOutcomeLoss),
+			history(bob, match, replay_entity.MatchOutcomeLoss),
+		},
+		fields: map[string]func(replay_entity.PlayerMatchHistory) any{"PlayerID": func(h replay_entity.PlayerMatchHistory) any { return h.PlayerID }},
+	}
+
+	squads := &mockReader[squad_entities.Squad]{}
+
+	schema, err := query_controllers.NewReadModelSchema(matches, players, histories, squads)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	res := schema.Execute(userContext(), graphql.Request{
+		Query: `query($id: ID) {
+			match(id: $id) { teams { name score players { name matches { outcome match { id } } } } }
+			squads(gameId: "cs2") { id }
+		}`,
+		Variables: map[string]any{"id": match.ID.String()},
+	})
+
+	if !assert.Empty(t, res.Errors) {
+		return
+	}
+
+	b, _ := json.Marshal(res.Data)
+	assert.JSONEq(t, fmt.Sprintf(`{
+		"match": {"teams": [
+			{"name": "CT", "score": 13, "players": [{"name": "alice", "matches": [{"outcome": "win", "match": {"id": %[1]q}}, {"outcome": "loss", "match": {"id": %[2]q}}]}]},
+			{"name": "T", "score": 9, "players": [{"name": "bob", "matches": [{"outcome": "loss", "match": {"id": %[1]q}}]}]}
+		]},
+		"squads": []
+	}`, match.ID, other.ID), string(b))
+
+	// a history search per player, a single search for the matches of every history entry
+	assert.Len(t, histories.searches, 2)
+	if assert.Len(t, matches.searches, 2) {
+		loaded := matches.searches[1].SearchParams[0].Params[0].ValueParams[0]
+		assert.Equal(t, common.InOperator, loaded.Operator)
+		assert.ElementsMatch(t, []interface{}{match.ID, other.ID}, loaded.Values)
+	}
+}
+
+func TestReadModelSchema_PlayerByID(t *testing.T) {
+	alice := replay_entity.Player{ID: common.PlayerIDType(uuid.New()), Name: "alice"}
+
+	players := &mockReader[replay_entity.Player]{
+		items:  []replay_entity.Player{alice},
+		fields: map[string]func(replay_entity.Player) any{"ID": func(p replay_entity.Player) any { return p.ID }},
+	}
+
+	schema, err := query_controllers.NewReadModelSchema(&mockReader[replay_entity.Match]{}, players, &mockReader[replay_entity.PlayerMatchHistory]{}, &mockReader[squad_entities.Squad]{})
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	res := schema.Execute(userContext(), graphql.Request{Query: fmt.Sprintf(`{ found: player(id: %q) { id name } missing: player(id: %q) { id } }`, uuid.UUID(alice.ID), uuid.New())})
+	assert.Empty(t, res.Errors)
+
+	b, _ := json.Marshal(res.Data)
+	assert.JSONEq(t, fmt.Sprintf(`{"found": {"id": %q, "name": "alice"}, "missing": null}`, uuid.UUID(alice.ID)), string(b))
+
+	res = schema.Execute(userContext(), graphql.Request{Query: `{ player(id: "not-an-id") { id } players(limit: 500) { id } }`})
+	if assert.Len(t, res.Errors, 2) {
+		assert.Equal(t, `invalid id "not-an-id"`, res.Errors[0].Message)
+		assert.Equal(t, "limit must be between 1 and 100", res.Errors[1].Message)
+	}
+}
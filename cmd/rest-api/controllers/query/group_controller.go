@@ -0,0 +1,173 @@
+package query_controllers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/golobby/container/v3"
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/psavelis/team-pro/replay-api/pkg/domain/iam"
+	iam_in "github.com/psavelis/team-pro/replay-api/pkg/domain/iam/ports/in"
+	replay_in "github.com/psavelis/team-pro/replay-api/pkg/domain/replay/ports/in"
+)
+
+// GroupQueryController reads the hierarchy of the groups (ie: org → teams → rosters) of the user in context, and rolls up their matches.
+type GroupQueryController struct {
+	GroupHierarchy iam_in.GroupHierarchyReader
+	GroupMatches   replay_in.GroupMatchesQuery
+}
+
+func NewGroupQueryController(container *container.Container) *GroupQueryController {
+	var groupHierarchy iam_in.GroupHierarchyReader
+	err := container.Resolve(&groupHierarchy)
+	if err != nil {
+		slog.Error("Cannot resolve iam_in.GroupHierarchyReader for new GroupQueryController", "err", err)
+		panic(err)
+	}
+
+	var groupMatches replay_in.GroupMatchesQuery
+	err = container.Resolve(&groupMatches)
+	if err != nil {
+		slog.Error("Cannot resolve replay_in.GroupMatchesQuery for new GroupQueryController", "err", err)
+		panic(err)
+	}
+
+	return &GroupQueryController{
+		GroupHierarchy: groupHierarchy,
+		GroupMatches:   groupMatches,
+	}
+}
+
+// UserGroupsHandler lists the groups of the user in context, with the subgroups of the ones they're a member of.
+func (ctrl *GroupQueryController) UserGroupsHandler(apiContext context.Context) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		groups, err := ctrl.GroupHierarchy.UserGroups(r.Context())
+		if err != nil {
+			writeGroupError(w, err)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(groups)
+	}
+}
+
+// SubgroupsHandler lists the groups nested under a group, parents first.
+func (ctrl *GroupQueryController) SubgroupsHandler(apiContext context.Context) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		groupID, err := uuid.Parse(mux.Vars(r)["group_id"])
+		if err != nil {
+			http.Error(w, "Bad Request", http.StatusBadRequest)
+			return
+		}
+
+		subgroups, err := ctrl.GroupHierarchy.Subgroups(r.Context(), groupID)
+		if err != nil {
+			writeGroupError(w, err)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(subgroups)
+	}
+}
+
+// MembersHandler lists the memberships of a group and of its subgroups.
+func (ctrl *GroupQueryController) MembersHandler(apiContext context.Context) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		groupID, err := uuid.Parse(mux.Vars(r)["group_id"])
+		if err != nil {
+			http.Error(w, "Bad Request", http.StatusBadRequest)
+			return
+		}
+
+		members, err := ctrl.GroupHierarchy.Members(r.Context(), groupID)
+		if err != nil {
+			writeGroupError(w, err)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(members)
+	}
+}
+
+// MemberHandler returns the effective membership of a user in a group (inherited from a parent group when stronger).
+func (ctrl *GroupQueryController) MemberHandler(apiContext context.Context) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		groupID, err := uuid.Parse(mux.Vars(r)["group_id"])
+		if err != nil {
+			http.Error(w, "Bad Request", http.StatusBadRequest)
+			return
+		}
+
+		userID, err := uuid.Parse(mux.Vars(r)["user_id"])
+		if err != nil {
+			http.Error(w, "Bad Request", http.StatusBadRequest)
+			return
+		}
+
+		membership, err := ctrl.GroupHierarchy.EffectiveMembership(r.Context(), groupID, userID)
+		if err != nil {
+			writeGroupError(w, err)
+			return
+		}
+
+		if membership == nil {
+			http.Error(w, "Not Found", http.StatusNotFound)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(membership)
+	}
+}
+
+// MatchesHandler rolls up the matches of a group and of its subgroups, most recent first (up to `limit`).
+func (ctrl *GroupQueryController) MatchesHandler(apiContext context.Context) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		groupID, err := uuid.Parse(mux.Vars(r)["group_id"])
+		if err != nil {
+			http.Error(w, "Bad Request", http.StatusBadRequest)
+			return
+		}
+
+		limit, ok := intQueryParam(r, "limit")
+		if !ok || limit < 0 {
+			http.Error(w, "`limit` must be a positive number", http.StatusBadRequest)
+			return
+		}
+
+		matches, err := ctrl.GroupMatches.Exec(r.Context(), replay_in.GroupMatchesQueryParams{GroupID: groupID, Limit: uint(limit)})
+		if err != nil {
+			writeGroupError(w, err)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(matches)
+	}
+}
+
+func writeGroupError(w http.ResponseWriter, err error) {
+	var notFoundErr *iam.GroupNotFoundError
+	var deniedErr *iam.GroupAccessDeniedError
+
+	switch {
+	case errors.As(err, &notFoundErr):
+		http.Error(w, notFoundErr.Message, http.StatusNotFound)
+	case errors.As(err, &deniedErr):
+		http.Error(w, deniedErr.Message, http.StatusForbidden)
+	default:
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,175 @@
+package query_controllers
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/golobby/container/v3"
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	gorilla "github.com/gorilla/websocket"
+	"github.com/psavelis/team-pro/replay-api/pkg/domain/matchmaking"
+	matchmaking_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/matchmaking/entities"
+	matchmaking_in "github.com/psavelis/team-pro/replay-api/pkg/domain/matchmaking/ports/in"
+	"github.com/psavelis/team-pro/replay-api/pkg/infra/websocket"
+)
+
+const (
+	lobbyDraftWriteTimeout = 10 * time.Second
+	lobbyDraftPongTimeout  = 60 * time.Second
+	lobbyDraftPingInterval = lobbyDraftPongTimeout * 9 / 10
+
+	// picks are made on other instances, and expired turns are auto-picked by the scheduler: the lobby is polled, and sent when it
+	// changes
+	lobbyDraftPollInterval = time.Second
+
+	LobbyDraftChannel = "lobby.draft"
+)
+
+type LobbyDraftQueryController struct {
+	GetLobbyQuery matchmaking_in.GetLobbyQuery
+	Upgrader      *websocket.Upgrader
+}
+
+func NewLobbyDraftQueryController(container *container.Container) *LobbyDraftQueryController {
+	var getLobbyQuery matchmaking_in.GetLobbyQuery
+	err := container.Resolve(&getLobbyQuery)
+	if err != nil {
+		slog.Error("Cannot resolve matchmaking_in.GetLobbyQuery for new LobbyDraftQueryController", "err", err)
+		panic(err)
+	}
+
+	var upgrader *websocket.Upgrader
+	err = container.Resolve(&upgrader)
+	if err != nil {
+		slog.Error("Cannot resolve websocket.Upgrader for new LobbyDraftQueryController", "err", err)
+		panic(err)
+	}
+
+	return &LobbyDraftQueryController{
+		GetLobbyQuery: getLobbyQuery,
+		Upgrader:      upgrader,
+	}
+}
+
+// StreamHandler streams the lobby to one of its players over a WebSocket: the current lobby first, then every change of it (ie: the
+// draft started, a captain picked, a turn timer ran out and its player was auto-picked, the draft completed) until the client
+// disconnects. The lobby carries the draft state and the deadline of the current turn. Reconnecting clients resume from the lobby sent
+// on connect, so nothing missed while disconnected is lost.
+func (ctrl *LobbyDraftQueryController) StreamHandler(apiContext context.Context) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx, cancelCtx := context.WithCancel(r.Context())
+		defer cancelCtx()
+
+		lobbyID, err := uuid.Parse(mux.Vars(r)["lobby_id"])
+		if err != nil {
+			slog.ErrorContext(ctx, "invalid lobby_id", "err", err, "lobby_id", mux.Vars(r)["lobby_id"])
+			http.Error(w, "Bad Request", http.StatusBadRequest)
+			return
+		}
+
+		// read before upgrading: the clients not in the lobby are answered with a plain 403 (404 when it doesn't exist)
+		lobby, err := ctrl.GetLobbyQuery.Exec(ctx, lobbyID)
+		if err != nil {
+			writeLobbyDraftError(w, err)
+			return
+		}
+
+		conn, err := ctrl.Upgrader.Upgrade(w, r, LobbyDraftChannel)
+		if err != nil {
+			// the upgrader already answered the request
+			slog.ErrorContext(ctx, "unable to upgrade lobby draft connection", "lobbyID", lobbyID, "err", err)
+			return
+		}
+
+		defer conn.Close()
+
+		// the client isn't expected to send anything, reading handles the pongs and detects the disconnection
+		conn.SetReadDeadline(time.Now().Add(lobbyDraftPongTimeout))
+		conn.SetPongHandler(func(string) error {
+			return conn.SetReadDeadline(time.Now().Add(lobbyDraftPongTimeout))
+		})
+
+		go func() {
+			defer cancelCtx()
+
+			for {
+				if _, _, err := conn.NextReader(); err != nil {
+					return
+				}
+			}
+		}()
+
+		if !writeLobbyDraft(conn, lobby) {
+			return
+		}
+
+		ping := time.NewTicker(lobbyDraftPingInterval)
+		defer ping.Stop()
+
+		poll := time.NewTicker(lobbyDraftPollInterval)
+		defer poll.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-apiContext.Done():
+				conn.WriteControl(gorilla.CloseMessage, gorilla.FormatCloseMessage(gorilla.CloseGoingAway, ""), time.Now().Add(lobbyDraftWriteTimeout))
+				return
+			case <-ping.C:
+				err = conn.WriteControl(gorilla.PingMessage, nil, time.Now().Add(lobbyDraftWriteTimeout))
+				if err != nil {
+					return
+				}
+			case <-poll.C:
+				current, err := ctrl.GetLobbyQuery.Exec(ctx, lobbyID)
+				if err != nil {
+					var forbiddenErr *matchmaking.LobbyForbiddenError
+					if errors.As(err, &forbiddenErr) {
+						// no longer in the lobby (ie: abandoned it)
+						conn.WriteControl(gorilla.CloseMessage, gorilla.FormatCloseMessage(gorilla.ClosePolicyViolation, forbiddenErr.Message), time.Now().Add(lobbyDraftWriteTimeout))
+						return
+					}
+
+					// transient (ie: database unavailable), retried on the next poll
+					slog.WarnContext(ctx, "unable to refresh lobby", "lobbyID", lobbyID, "err", err)
+					continue
+				}
+
+				if current.UpdatedAt.Equal(lobby.UpdatedAt) {
+					continue
+				}
+
+				lobby = current
+
+				if !writeLobbyDraft(conn, lobby) {
+					return
+				}
+			}
+		}
+	}
+}
+
+func writeLobbyDraft(conn *websocket.Conn, lobby *matchmaking_entities.Lobby) bool {
+	err := conn.WriteMessage(lobby, lobbyDraftWriteTimeout)
+	if err != nil {
+		slog.Warn("unable to write lobby draft", "lobbyID", lobby.ID, "err", err)
+		return false
+	}
+
+	return true
+}
+
+func writeLobbyDraftError(w http.ResponseWriter, err error) {
+	var notFoundErr *matchmaking.LobbyNotFoundError
+	if errors.As(err, &notFoundErr) {
+		http.Error(w, notFoundErr.Message, http.StatusNotFound)
+		return
+	}
+
+	writeMatchmakingSessionError(w, err)
+}
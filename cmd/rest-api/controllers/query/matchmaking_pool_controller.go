@@ -0,0 +1,26 @@
+package query_controllers
+
+import (
+	"github.com/golobby/container/v3"
+	controllers "github.com/psavelis/team-pro/replay-api/cmd/rest-api/controllers"
+	matchmaking_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/matchmaking/entities"
+	matchmaking_in "github.com/psavelis/team-pro/replay-api/pkg/domain/matchmaking/ports/in"
+)
+
+type MatchmakingPoolQueryController struct {
+	controllers.DefaultSearchController[matchmaking_entities.MatchmakingPool]
+}
+
+func NewMatchmakingPoolQueryController(c container.Container) *MatchmakingPoolQueryController {
+	var queryService matchmaking_in.MatchmakingPoolReader
+
+	err := c.Resolve(&queryService)
+
+	if err != nil {
+		panic(err)
+	}
+
+	baseController := controllers.NewDefaultSearchController(queryService)
+
+	return &MatchmakingPoolQueryController{*baseController}
+}
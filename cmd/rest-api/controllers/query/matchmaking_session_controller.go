@@ -0,0 +1,174 @@
+package query_controllers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/golobby/container/v3"
+	gorilla "github.com/gorilla/websocket"
+	"github.com/psavelis/team-pro/replay-api/pkg/domain/matchmaking"
+	matchmaking_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/matchmaking/entities"
+	matchmaking_in "github.com/psavelis/team-pro/replay-api/pkg/domain/matchmaking/ports/in"
+	"github.com/psavelis/team-pro/replay-api/pkg/infra/websocket"
+)
+
+const (
+	matchmakingSessionWriteTimeout = 10 * time.Second
+	matchmakingSessionPongTimeout  = 60 * time.Second
+	matchmakingSessionPingInterval = matchmakingSessionPongTimeout * 9 / 10
+
+	// tickets and lobbies are updated by other instances (and by the scheduler): the session is polled, and sent when it changes
+	matchmakingSessionPollInterval = 2 * time.Second
+
+	MatchmakingSessionChannel = "matchmaking.session"
+)
+
+type MatchmakingSessionQueryController struct {
+	GetMatchmakingSessionQuery matchmaking_in.GetMatchmakingSessionQuery
+	Upgrader                   *websocket.Upgrader
+}
+
+func NewMatchmakingSessionQueryController(container *container.Container) *MatchmakingSessionQueryController {
+	var getMatchmakingSessionQuery matchmaking_in.GetMatchmakingSessionQuery
+	err := container.Resolve(&getMatchmakingSessionQuery)
+	if err != nil {
+		slog.Error("Cannot resolve matchmaking_in.GetMatchmakingSessionQuery for new MatchmakingSessionQueryController", "err", err)
+		panic(err)
+	}
+
+	var upgrader *websocket.Upgrader
+	err = container.Resolve(&upgrader)
+	if err != nil {
+		slog.Error("Cannot resolve websocket.Upgrader for new MatchmakingSessionQueryController", "err", err)
+		panic(err)
+	}
+
+	return &MatchmakingSessionQueryController{
+		GetMatchmakingSessionQuery: getMatchmakingSessionQuery,
+		Upgrader:                   upgrader,
+	}
+}
+
+// SessionHandler returns the matchmaking session of the user (queue position, pending ready check and lobby), for the clients
+// restoring it once reconnected.
+func (ctrl *MatchmakingSessionQueryController) SessionHandler(apiContext context.Context) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		session, err := ctrl.GetMatchmakingSessionQuery.Exec(r.Context())
+		if err != nil {
+			writeMatchmakingSessionError(w, err)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(session)
+	}
+}
+
+// StreamHandler streams the matchmaking session of the user over a WebSocket: the current session first, then every change of its
+// version (ie: matched, ready check accepted by the other players, lobby in match) until the client disconnects. Reconnecting clients
+// resume from the session sent on connect, so nothing missed while disconnected is lost.
+func (ctrl *MatchmakingSessionQueryController) StreamHandler(apiContext context.Context) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx, cancelCtx := context.WithCancel(r.Context())
+		defer cancelCtx()
+
+		// restored before upgrading: the clients without a user are answered with a plain 403
+		session, err := ctrl.GetMatchmakingSessionQuery.Exec(ctx)
+		if err != nil {
+			writeMatchmakingSessionError(w, err)
+			return
+		}
+
+		conn, err := ctrl.Upgrader.Upgrade(w, r, MatchmakingSessionChannel)
+		if err != nil {
+			// the upgrader already answered the request
+			slog.ErrorContext(ctx, "unable to upgrade matchmaking session connection", "userID", session.UserID, "err", err)
+			return
+		}
+
+		defer conn.Close()
+
+		// the client isn't expected to send anything, reading handles the pongs and detects the disconnection
+		conn.SetReadDeadline(time.Now().Add(matchmakingSessionPongTimeout))
+		conn.SetPongHandler(func(string) error {
+			return conn.SetReadDeadline(time.Now().Add(matchmakingSessionPongTimeout))
+		})
+
+		go func() {
+			defer cancelCtx()
+
+			for {
+				if _, _, err := conn.NextReader(); err != nil {
+					return
+				}
+			}
+		}()
+
+		if !writeMatchmakingSession(conn, session) {
+			return
+		}
+
+		ping := time.NewTicker(matchmakingSessionPingInterval)
+		defer ping.Stop()
+
+		poll := time.NewTicker(matchmakingSessionPollInterval)
+		defer poll.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-apiContext.Done():
+				conn.WriteControl(gorilla.CloseMessage, gorilla.FormatCloseMessage(gorilla.CloseGoingAway, ""), time.Now().Add(matchmakingSessionWriteTimeout))
+				return
+			case <-ping.C:
+				err = conn.WriteControl(gorilla.PingMessage, nil, time.Now().Add(matchmakingSessionWriteTimeout))
+				if err != nil {
+					return
+				}
+			case <-poll.C:
+				current, err := ctrl.GetMatchmakingSessionQuery.Exec(ctx)
+				if err != nil {
+					// transient (ie: database unavailable), retried on the next poll
+					slog.WarnContext(ctx, "unable to refresh matchmaking session", "userID", session.UserID, "err", err)
+					continue
+				}
+
+				if current.Version == session.Version {
+					continue
+				}
+
+				session = current
+
+				if !writeMatchmakingSession(conn, session) {
+					return
+				}
+			}
+		}
+	}
+}
+
+func writeMatchmakingSession(conn *websocket.Conn, session *matchmaking_entities.MatchmakingSession) bool {
+	err := conn.WriteMessage(session, matchmakingSessionWriteTimeout)
+	if err != nil {
+		slog.Warn("unable to write matchmaking session", "userID", session.UserID, "err", err)
+		return false
+	}
+
+	return true
+}
+
+func writeMatchmakingSessionError(w http.ResponseWriter, err error) {
+	var forbiddenErr *matchmaking.LobbyForbiddenError
+	if errors.As(err, &forbiddenErr) {
+		http.Error(w, forbiddenErr.Message, http.StatusForbidden)
+		return
+	}
+
+	http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+}
@@ -18,10 +18,7 @@ import (
 )
 
 func GetDefaultTestContext(reqContext context.Context, tenantID, clientID, groupID, userID uuid.UUID) context.Context {
-	reqContext = context.WithValue(reqContext, common.TenantIDKey, tenantID)
-	reqContext = context.WithValue(reqContext, common.ClientIDKey, clientID)
-	reqContext = context.WithValue(reqContext, common.GroupIDKey, groupID)
-	reqContext = context.WithValue(reqContext, common.UserIDKey, userID)
+	reqContext = common.WithResourceOwner(reqContext, common.ResourceOwner{TenantID: tenantID, ClientID: clientID, GroupID: groupID, UserID: userID})
 	return reqContext
 }
 
@@ -35,10 +32,7 @@ func TestReplaySearchHandler(t *testing.T) {
 
 	setContextWithValues := func(tenantID, clientID, groupID, userID uuid.UUID) context.Context {
 		newCtx := context.TODO()
-		newCtx = context.WithValue(newCtx, common.TenantIDKey, tenantID)
-		newCtx = context.WithValue(newCtx, common.ClientIDKey, clientID)
-		newCtx = context.WithValue(newCtx, common.GroupIDKey, groupID)
-		newCtx = context.WithValue(newCtx, common.UserIDKey, userID)
+		newCtx = common.WithResourceOwner(newCtx, common.ResourceOwner{TenantID: tenantID, ClientID: clientID, GroupID: groupID, UserID: userID})
 		return newCtx
 	}
 
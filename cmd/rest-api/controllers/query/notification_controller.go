@@ -0,0 +1,26 @@
+package query_controllers
+
+import (
+	"github.com/golobby/container/v3"
+	controllers "github.com/psavelis/team-pro/replay-api/cmd/rest-api/controllers"
+	notification_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/notification/entities"
+	notification_in "github.com/psavelis/team-pro/replay-api/pkg/domain/notification/ports/in"
+)
+
+type NotificationQueryController struct {
+	controllers.DefaultSearchController[notification_entities.Notification]
+}
+
+func NewNotificationQueryController(c container.Container) *NotificationQueryController {
+	var queryService notification_in.NotificationReader
+
+	err := c.Resolve(&queryService)
+
+	if err != nil {
+		panic(err)
+	}
+
+	baseController := controllers.NewDefaultSearchController(queryService)
+
+	return &NotificationQueryController{*baseController}
+}
@@ -0,0 +1,194 @@
+package query_controllers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/golobby/container/v3"
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	"github.com/psavelis/team-pro/replay-api/pkg/domain/public"
+	public_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/public/entities"
+	public_in "github.com/psavelis/team-pro/replay-api/pkg/domain/public/ports/in"
+)
+
+// PublicStatsQueryController serves the public API of third-party stat sites: every response carries the attribution the sites must
+// display along with the stats.
+type PublicStatsQueryController struct {
+	PublicMatchesQuery public_in.PublicMatchesQuery
+	PublicMatchQuery   public_in.PublicMatchQuery
+	LeaderboardQuery   public_in.LeaderboardQuery
+	Attribution        public_entities.Attribution
+}
+
+func NewPublicStatsQueryController(container *container.Container) *PublicStatsQueryController {
+	var publicMatchesQuery public_in.PublicMatchesQuery
+	err := container.Resolve(&publicMatchesQuery)
+	if err != nil {
+		slog.Error("Cannot resolve public_in.PublicMatchesQuery for new PublicStatsQueryController", "err", err)
+		panic(err)
+	}
+
+	var publicMatchQuery public_in.PublicMatchQuery
+	err = container.Resolve(&publicMatchQuery)
+	if err != nil {
+		slog.Error("Cannot resolve public_in.PublicMatchQuery for new PublicStatsQueryController", "err", err)
+		panic(err)
+	}
+
+	var leaderboardQuery public_in.LeaderboardQuery
+	err = container.Resolve(&leaderboardQuery)
+	if err != nil {
+		slog.Error("Cannot resolve public_in.LeaderboardQuery for new PublicStatsQueryController", "err", err)
+		panic(err)
+	}
+
+	var config common.Config
+	err = container.Resolve(&config)
+	if err != nil {
+		slog.Error("Cannot resolve common.Config for new PublicStatsQueryController", "err", err)
+		panic(err)
+	}
+
+	return &PublicStatsQueryController{
+		PublicMatchesQuery: publicMatchesQuery,
+		PublicMatchQuery:   publicMatchQuery,
+		LeaderboardQuery:   leaderboardQuery,
+		Attribution:        public_entities.NewAttribution(config.PublicAPI.AttributionText, config.PublicAPI.AttributionURL),
+	}
+}
+
+// MatchesHandler lists the latest public matches of a game, `limit` at a time, paging with `before` (RFC 3339, the played_at of the
+// last match of the previous page).
+func (ctrl *PublicStatsQueryController) MatchesHandler(apiContext context.Context) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		params := public_in.PublicMatchesQueryParams{GameID: common.GameIDKey(mux.Vars(r)["game_id"])}
+
+		if before := r.URL.Query().Get("before"); before != "" {
+			t, err := time.Parse(time.RFC3339, before)
+			if err != nil {
+				http.Error(w, "`before` must be an RFC 3339 timestamp", http.StatusBadRequest)
+				return
+			}
+
+			params.Before = &t
+		}
+
+		limit, ok := intQueryParam(r, "limit")
+		if !ok {
+			http.Error(w, "`limit` must be a number", http.StatusBadRequest)
+			return
+		}
+
+		params.Limit = limit
+
+		matches, err := ctrl.PublicMatchesQuery.Exec(r.Context(), params)
+		if err != nil {
+			http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+			return
+		}
+
+		writeAttributed(w, matches, ctrl.Attribution)
+	}
+}
+
+// MatchHandler returns a public match with the stats of its players.
+func (ctrl *PublicStatsQueryController) MatchHandler(apiContext context.Context) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+
+		matchID, err := uuid.Parse(vars["match_id"])
+		if err != nil {
+			http.Error(w, "Bad Request", http.StatusBadRequest)
+			return
+		}
+
+		match, err := ctrl.PublicMatchQuery.Exec(r.Context(), common.GameIDKey(vars["game_id"]), matchID)
+
+		var notFoundErr *public.PublicMatchNotFoundError
+		switch {
+		case errors.As(err, &notFoundErr):
+			http.Error(w, notFoundErr.Message, http.StatusNotFound)
+			return
+		case err != nil:
+			http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+			return
+		}
+
+		writeAttributed(w, match, ctrl.Attribution)
+	}
+}
+
+// LeaderboardHandler ranks the players of the public matches of a game over the last `days` (up to today), optionally the matches of
+// a single `season_id`.
+func (ctrl *PublicStatsQueryController) LeaderboardHandler(apiContext context.Context) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		days, ok := intQueryParam(r, "days")
+		if !ok {
+			http.Error(w, "`days` must be a number", http.StatusBadRequest)
+			return
+		}
+
+		limit, ok := intQueryParam(r, "limit")
+		if !ok {
+			http.Error(w, "`limit` must be a number", http.StatusBadRequest)
+			return
+		}
+
+		var seasonID *uuid.UUID
+		if value := r.URL.Query().Get("season_id"); value != "" {
+			id, err := uuid.Parse(value)
+			if err != nil {
+				http.Error(w, "`season_id` must be a UUID", http.StatusBadRequest)
+				return
+			}
+
+			seasonID = &id
+		}
+
+		leaderboard, err := ctrl.LeaderboardQuery.Exec(r.Context(), public_in.LeaderboardQueryParams{
+			GameID:   common.GameIDKey(mux.Vars(r)["game_id"]),
+			Days:     days,
+			SeasonID: seasonID,
+			Limit:    limit,
+		})
+
+		if err != nil {
+			http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+			return
+		}
+
+		writeAttributed(w, leaderboard, ctrl.Attribution)
+	}
+}
+
+// intQueryParam reads an optional number from the query string (0 when missing), false when it isn't a number.
+func intQueryParam(r *http.Request, name string) (int, bool) {
+	value := r.URL.Query().Get(name)
+	if value == "" {
+		return 0, true
+	}
+
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, false
+	}
+
+	return n, true
+}
+
+func writeAttributed[T any](w http.ResponseWriter, data T, attribution public_entities.Attribution) {
+	if attribution.URL != "" {
+		w.Header().Set("Link", "<"+attribution.URL+`>; rel="attribution"`)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(public_entities.Attributed[T]{Data: data, Attribution: attribution})
+}
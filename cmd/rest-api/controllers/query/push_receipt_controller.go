@@ -0,0 +1,26 @@
+package query_controllers
+
+import (
+	"github.com/golobby/container/v3"
+	controllers "github.com/psavelis/team-pro/replay-api/cmd/rest-api/controllers"
+	notification_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/notification/entities"
+	notification_in "github.com/psavelis/team-pro/replay-api/pkg/domain/notification/ports/in"
+)
+
+type PushReceiptQueryController struct {
+	controllers.DefaultSearchController[notification_entities.PushReceipt]
+}
+
+func NewPushReceiptQueryController(c container.Container) *PushReceiptQueryController {
+	var queryService notification_in.PushReceiptReader
+
+	err := c.Resolve(&queryService)
+
+	if err != nil {
+		panic(err)
+	}
+
+	baseController := controllers.NewDefaultSearchController(queryService)
+
+	return &PushReceiptQueryController{*baseController}
+}
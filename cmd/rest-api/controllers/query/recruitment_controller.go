@@ -0,0 +1,156 @@
+package query_controllers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/golobby/container/v3"
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	"github.com/psavelis/team-pro/replay-api/pkg/domain/squad"
+	squad_in "github.com/psavelis/team-pro/replay-api/pkg/domain/squad/ports/in"
+)
+
+// RecruitmentQueryController matches the free agents (LFT) and the squad openings of the tenant.
+type RecruitmentQueryController struct {
+	RecruitmentSearch squad_in.RecruitmentSearch
+}
+
+func NewRecruitmentQueryController(container *container.Container) *RecruitmentQueryController {
+	var recruitmentSearch squad_in.RecruitmentSearch
+	err := container.Resolve(&recruitmentSearch)
+	if err != nil {
+		slog.Error("Cannot resolve squad_in.RecruitmentSearch for new RecruitmentQueryController", "err", err)
+		panic(err)
+	}
+
+	return &RecruitmentQueryController{
+		RecruitmentSearch: recruitmentSearch,
+	}
+}
+
+// SearchHandler searches the open free agents and openings of a game, filtered by `role` and `region` (repeated or comma separated),
+// `min_mmr` and `max_mmr`. `side` (free_agents or openings) searches a single side.
+func (ctrl *RecruitmentQueryController) SearchHandler(apiContext context.Context) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		filter := squad_in.RecruitmentFilter{
+			GameID:  common.GameIDKey(mux.Vars(r)["game_id"]),
+			Side:    squad_in.RecruitmentSide(r.URL.Query().Get("side")),
+			Roles:   listQueryParam(r, "role"),
+			Regions: listQueryParam(r, "region"),
+		}
+
+		if filter.Side != "" && filter.Side != squad_in.RecruitmentSideFreeAgents && filter.Side != squad_in.RecruitmentSideOpenings {
+			http.Error(w, "`side` must be free_agents or openings", http.StatusBadRequest)
+			return
+		}
+
+		var ok bool
+		filter.MinMMR, ok = intQueryParam(r, "min_mmr")
+		if !ok {
+			http.Error(w, "`min_mmr` must be a number", http.StatusBadRequest)
+			return
+		}
+
+		filter.MaxMMR, ok = intQueryParam(r, "max_mmr")
+		if !ok {
+			http.Error(w, "`max_mmr` must be a number", http.StatusBadRequest)
+			return
+		}
+
+		limit, ok := intQueryParam(r, "limit")
+		if !ok || limit < 0 {
+			http.Error(w, "`limit` must be a positive number", http.StatusBadRequest)
+			return
+		}
+
+		filter.Limit = uint(limit)
+
+		result, err := ctrl.RecruitmentSearch.Search(r.Context(), filter)
+		if err != nil {
+			writeRecruitmentError(w, err)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(result)
+	}
+}
+
+// MatchOpeningHandler lists the free agents fitting an opening.
+func (ctrl *RecruitmentQueryController) MatchOpeningHandler(apiContext context.Context) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		openingID, err := uuid.Parse(mux.Vars(r)["opening_id"])
+		if err != nil {
+			http.Error(w, "Bad Request", http.StatusBadRequest)
+			return
+		}
+
+		freeAgents, err := ctrl.RecruitmentSearch.MatchOpening(r.Context(), openingID)
+		if err != nil {
+			writeRecruitmentError(w, err)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(freeAgents)
+	}
+}
+
+// MatchFreeAgentHandler lists the openings fitting a free agent.
+func (ctrl *RecruitmentQueryController) MatchFreeAgentHandler(apiContext context.Context) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		freeAgentID, err := uuid.Parse(mux.Vars(r)["free_agent_id"])
+		if err != nil {
+			http.Error(w, "Bad Request", http.StatusBadRequest)
+			return
+		}
+
+		openings, err := ctrl.RecruitmentSearch.MatchFreeAgent(r.Context(), freeAgentID)
+		if err != nil {
+			writeRecruitmentError(w, err)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(openings)
+	}
+}
+
+// listQueryParam reads the values of a repeated (or comma separated) query parameter.
+func listQueryParam(r *http.Request, name string) []string {
+	values := make([]string, 0)
+
+	for _, param := range r.URL.Query()[name] {
+		for _, value := range strings.Split(param, ",") {
+			value = strings.TrimSpace(value)
+			if value != "" {
+				values = append(values, value)
+			}
+		}
+	}
+
+	return values
+}
+
+func writeRecruitmentError(w http.ResponseWriter, err error) {
+	var notFoundErr *squad.RecruitmentNotFoundError
+	var invalidErr *squad.InvalidRecruitmentError
+
+	switch {
+	case errors.As(err, &notFoundErr):
+		http.Error(w, notFoundErr.Message, http.StatusNotFound)
+	case errors.As(err, &invalidErr):
+		http.Error(w, invalidErr.Message, http.StatusBadRequest)
+	default:
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,70 @@
+package query_controllers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+
+	"github.com/golobby/container/v3"
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	"github.com/psavelis/team-pro/replay-api/pkg/domain/replay"
+	replay_in "github.com/psavelis/team-pro/replay-api/pkg/domain/replay/ports/in"
+)
+
+type ReplayDownloadQueryController struct {
+	ContentQuery replay_in.ReplayFileContentQuery
+}
+
+func NewReplayDownloadQueryController(container *container.Container) *ReplayDownloadQueryController {
+	var contentQuery replay_in.ReplayFileContentQuery
+	err := container.Resolve(&contentQuery)
+	if err != nil {
+		slog.Error("Cannot resolve replay_in.ReplayFileContentQuery for new ReplayDownloadQueryController", "err", err)
+		panic(err)
+	}
+
+	return &ReplayDownloadQueryController{
+		ContentQuery: contentQuery,
+	}
+}
+
+// DownloadHandler streams the content of a replay file from the store. Range requests (and If-Range) are supported: the demo player
+// seeks within the file without downloading it first, and only the ranges requested are read from the store.
+func (ctrl *ReplayDownloadQueryController) DownloadHandler(apiContext context.Context) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+
+		replayFileID, err := uuid.Parse(vars["replay_file_id"])
+		if err != nil {
+			slog.ErrorContext(r.Context(), "invalid replay_file_id", "err", err, "replay_file_id", vars["replay_file_id"])
+			http.Error(w, "Bad Request", http.StatusBadRequest)
+			return
+		}
+
+		replayFile, content, err := ctrl.ContentQuery.Open(r.Context(), common.GameIDKey(vars["game_id"]), replayFileID)
+		if err != nil {
+			var notFoundErr *replay.ReplayFileNotFoundError
+			if errors.As(err, &notFoundErr) {
+				http.Error(w, notFoundErr.Message, http.StatusNotFound)
+				return
+			}
+
+			http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+			return
+		}
+
+		defer content.Close()
+
+		// the content of a replay file never changes: its id is a strong validator (for If-Range and caches)
+		w.Header().Set("Content-Type", "application/octet-stream")
+		w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="%s.dem"`, replayFileID))
+		w.Header().Set("ETag", fmt.Sprintf(`"%s"`, replayFileID))
+		w.Header().Set("Cache-Control", "private, max-age=86400")
+
+		http.ServeContent(w, r, replayFileID.String()+".dem", replayFile.CreatedAt, content)
+	}
+}
@@ -0,0 +1,64 @@
+package query_controllers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/golobby/container/v3"
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	"github.com/psavelis/team-pro/replay-api/pkg/domain/replay"
+	replay_in "github.com/psavelis/team-pro/replay-api/pkg/domain/replay/ports/in"
+)
+
+type ReplayEconomyQueryController struct {
+	EconomyQuery replay_in.ReplayEconomyQuery
+}
+
+func NewReplayEconomyQueryController(container *container.Container) *ReplayEconomyQueryController {
+	var economyQuery replay_in.ReplayEconomyQuery
+	err := container.Resolve(&economyQuery)
+	if err != nil {
+		slog.Error("Cannot resolve replay_in.ReplayEconomyQuery for new ReplayEconomyQueryController", "err", err)
+		panic(err)
+	}
+
+	return &ReplayEconomyQueryController{
+		EconomyQuery: economyQuery,
+	}
+}
+
+// EconomyHandler returns the economy simulation of the rounds of a replay file: the buy of each side, the money each alternative buy
+// would have left for the next round and the one recommended.
+func (ctrl *ReplayEconomyQueryController) EconomyHandler(apiContext context.Context) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+
+		replayFileID, err := uuid.Parse(vars["replay_file_id"])
+		if err != nil {
+			slog.ErrorContext(r.Context(), "invalid replay_file_id", "err", err, "replay_file_id", vars["replay_file_id"])
+			http.Error(w, "Bad Request", http.StatusBadRequest)
+			return
+		}
+
+		economy, err := ctrl.EconomyQuery.Exec(r.Context(), common.GameIDKey(vars["game_id"]), replayFileID)
+		if err != nil {
+			var notFoundErr *replay.ReplayFileNotFoundError
+			if errors.As(err, &notFoundErr) {
+				http.Error(w, notFoundErr.Message, http.StatusNotFound)
+				return
+			}
+
+			http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(economy)
+	}
+}
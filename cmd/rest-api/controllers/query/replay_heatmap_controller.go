@@ -0,0 +1,122 @@
+package query_controllers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"slices"
+	"strconv"
+
+	"github.com/golobby/container/v3"
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	"github.com/psavelis/team-pro/replay-api/pkg/domain/replay"
+	replay_entity "github.com/psavelis/team-pro/replay-api/pkg/domain/replay/entities"
+	replay_in "github.com/psavelis/team-pro/replay-api/pkg/domain/replay/ports/in"
+)
+
+// heatmap types by query parameter
+var heatmapTypes = map[string]replay_entity.PositionSampleType{
+	"positions": replay_entity.PositionSampleTypePosition,
+	"deaths":    replay_entity.PositionSampleTypeDeath,
+	"grenades":  replay_entity.PositionSampleTypeGrenade,
+}
+
+type ReplayHeatmapQueryController struct {
+	HeatmapQuery replay_in.ReplayHeatmapQuery
+}
+
+func NewReplayHeatmapQueryController(container *container.Container) *ReplayHeatmapQueryController {
+	var heatmapQuery replay_in.ReplayHeatmapQuery
+	err := container.Resolve(&heatmapQuery)
+	if err != nil {
+		slog.Error("Cannot resolve replay_in.ReplayHeatmapQuery for new ReplayHeatmapQueryController", "err", err)
+		panic(err)
+	}
+
+	return &ReplayHeatmapQueryController{
+		HeatmapQuery: heatmapQuery,
+	}
+}
+
+// HeatmapHandler returns the heatmap of a replay file: `type` positions (default), deaths or grenades, of a `side` (CT or T, both when
+// not informed), binned in cells of `cell` game units.
+func (ctrl *ReplayHeatmapQueryController) HeatmapHandler(apiContext context.Context) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+
+		replayFileID, err := uuid.Parse(vars["replay_file_id"])
+		if err != nil {
+			slog.ErrorContext(r.Context(), "invalid replay_file_id", "err", err, "replay_file_id", vars["replay_file_id"])
+			http.Error(w, "Bad Request", http.StatusBadRequest)
+			return
+		}
+
+		params, err := parseHeatmapParams(r)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		params.GameID = common.GameIDKey(vars["game_id"])
+		params.ReplayFileID = replayFileID
+
+		heatmap, err := ctrl.HeatmapQuery.Exec(r.Context(), params)
+		if err != nil {
+			var notFoundErr *replay.ReplayFileNotFoundError
+			if errors.As(err, &notFoundErr) {
+				http.Error(w, notFoundErr.Message, http.StatusNotFound)
+				return
+			}
+
+			http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(heatmap)
+	}
+}
+
+func parseHeatmapParams(r *http.Request) (replay_in.ReplayHeatmapQueryParams, error) {
+	query := r.URL.Query()
+
+	params := replay_in.ReplayHeatmapQueryParams{
+		Type:     replay_entity.PositionSampleTypePosition,
+		CellSize: replay_entity.DefaultHeatmapCellSize,
+	}
+
+	if v := query.Get("type"); v != "" {
+		sampleType, ok := heatmapTypes[v]
+		if !ok {
+			return params, fmt.Errorf("invalid heatmap type '%s' (expected positions, deaths or grenades)", v)
+		}
+
+		params.Type = sampleType
+	}
+
+	if v := query.Get("side"); v != "" {
+		side := replay_entity.RoundSide(v)
+		if !slices.Contains([]replay_entity.RoundSide{replay_entity.RoundSideCT, replay_entity.RoundSideT}, side) {
+			return params, fmt.Errorf("invalid heatmap side '%s' (expected CT or T)", v)
+		}
+
+		params.Side = side
+	}
+
+	if v := query.Get("cell"); v != "" {
+		cellSize, err := strconv.Atoi(v)
+		if err != nil || cellSize < replay_entity.MinHeatmapCellSize || cellSize > replay_entity.MaxHeatmapCellSize {
+			return params, fmt.Errorf("invalid heatmap cell '%s' (expected %d to %d units)", v, replay_entity.MinHeatmapCellSize, replay_entity.MaxHeatmapCellSize)
+		}
+
+		params.CellSize = cellSize
+	}
+
+	return params, nil
+}
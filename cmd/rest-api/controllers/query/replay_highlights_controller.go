@@ -0,0 +1,90 @@
+package query_controllers
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+
+	"github.com/golobby/container/v3"
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	replay_in "github.com/psavelis/team-pro/replay-api/pkg/domain/replay/ports/in"
+)
+
+type ReplayHighlightsQueryController struct {
+	HighlightsReader replay_in.ReplayHighlightsReader
+}
+
+func NewReplayHighlightsQueryController(container *container.Container) *ReplayHighlightsQueryController {
+	var highlightsReader replay_in.ReplayHighlightsReader
+	err := container.Resolve(&highlightsReader)
+	if err != nil {
+		slog.Error("Cannot resolve replay_in.ReplayHighlightsReader for new ReplayHighlightsQueryController", "err", err)
+		panic(err)
+	}
+
+	return &ReplayHighlightsQueryController{
+		HighlightsReader: highlightsReader,
+	}
+}
+
+// HighlightsHandler returns the highlights of a replay file (the latest ones, when it was processed more than once), by their tick
+// ranges for the demo playback.
+func (ctrl *ReplayHighlightsQueryController) HighlightsHandler(apiContext context.Context) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+
+		replayFileID, err := uuid.Parse(vars["replay_file_id"])
+		if err != nil {
+			slog.ErrorContext(r.Context(), "invalid replay_file_id", "err", err, "replay_file_id", vars["replay_file_id"])
+			http.Error(w, "Bad Request", http.StatusBadRequest)
+			return
+		}
+
+		params := []common.SearchAggregation{
+			{
+				Params: []common.SearchParameter{
+					{
+						ValueParams: []common.SearchableValue{
+							{
+								Field:  "ReplayFileID",
+								Values: []interface{}{replayFileID},
+							},
+							{
+								Field:  "GameID",
+								Values: []interface{}{vars["game_id"]},
+							},
+						},
+					},
+				},
+			},
+		}
+
+		s, err := ctrl.HighlightsReader.Compile(r.Context(), params, common.NewSearchResultOptions(0, 1))
+		if err != nil {
+			slog.ErrorContext(r.Context(), "error compiling replay highlights search", "err", err)
+			http.Error(w, "Bad Request", http.StatusBadRequest)
+			return
+		}
+
+		s.SortOptions = []common.SearchSortOption{{Field: "CreatedAt", Direction: common.DescendingIDKey}}
+
+		highlights, err := ctrl.HighlightsReader.Search(r.Context(), *s)
+		if err != nil {
+			slog.ErrorContext(r.Context(), "error searching replay highlights", "err", err, "replay_file_id", replayFileID)
+			http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+			return
+		}
+
+		if len(highlights) == 0 {
+			http.Error(w, "Not Found", http.StatusNotFound)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(highlights[0])
+	}
+}
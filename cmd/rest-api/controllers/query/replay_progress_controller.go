@@ -0,0 +1,186 @@
+package query_controllers
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/golobby/container/v3"
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	gorilla "github.com/gorilla/websocket"
+	"github.com/psavelis/team-pro/replay-api/pkg/domain/events"
+	"github.com/psavelis/team-pro/replay-api/pkg/domain/replay"
+	replay_entity "github.com/psavelis/team-pro/replay-api/pkg/domain/replay/entities"
+	replay_in "github.com/psavelis/team-pro/replay-api/pkg/domain/replay/ports/in"
+	"github.com/psavelis/team-pro/replay-api/pkg/infra/websocket"
+)
+
+const (
+	replayProgressWriteTimeout = 10 * time.Second
+	replayProgressPongTimeout  = 60 * time.Second
+	replayProgressPingInterval = replayProgressPongTimeout * 9 / 10
+
+	// the clients follow the replay.progress events
+	ReplayProgressChannel = string(events.ReplayProcessingProgress)
+)
+
+type ReplayProgressQueryController struct {
+	ProgressSubscriber replay_in.ReplayProcessingProgressSubscriber
+	Upgrader           *websocket.Upgrader
+}
+
+func NewReplayProgressQueryController(container *container.Container) *ReplayProgressQueryController {
+	var progressSubscriber replay_in.ReplayProcessingProgressSubscriber
+	err := container.Resolve(&progressSubscriber)
+	if err != nil {
+		slog.Error("Cannot resolve replay_in.ReplayProcessingProgressSubscriber for new ReplayProgressQueryController", "err", err)
+		panic(err)
+	}
+
+	var upgrader *websocket.Upgrader
+	err = container.Resolve(&upgrader)
+	if err != nil {
+		slog.Error("Cannot resolve websocket.Upgrader for new ReplayProgressQueryController", "err", err)
+		panic(err)
+	}
+
+	return &ReplayProgressQueryController{
+		ProgressSubscriber: progressSubscriber,
+		Upgrader:           upgrader,
+	}
+}
+
+// ProgressHandler streams the processing progress of a replay file over a WebSocket: the current progress first, then every
+// update until the replay file is completed or failed (or the client disconnects). Clients offering the msgpack subprotocol receive
+// MessagePack binary messages, and the larger messages are compressed when the client supports permessage-deflate. Reconnecting
+// clients pass the event_id of the last update received (last_event_id) to receive the updates they missed, after the current
+// progress (which has no event_id).
+func (ctrl *ReplayProgressQueryController) ProgressHandler(apiContext context.Context) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		replayFileID, err := uuid.Parse(mux.Vars(r)["replay_file_id"])
+		if err != nil {
+			slog.ErrorContext(r.Context(), "invalid replay_file_id", "err", err, "replay_file_id", mux.Vars(r)["replay_file_id"])
+			http.Error(w, "Bad Request", http.StatusBadRequest)
+			return
+		}
+
+		// browsers can't set headers on websocket requests: the last event id received is also accepted as a query param
+		lastEventID := r.URL.Query().Get("last_event_id")
+		if lastEventID == "" {
+			lastEventID = r.Header.Get("Last-Event-ID")
+		}
+
+		var resumeFrom uint64
+		if lastEventID != "" {
+			resumeFrom, err = strconv.ParseUint(lastEventID, 10, 64)
+			if err != nil {
+				slog.ErrorContext(r.Context(), "invalid last_event_id", "err", err, "last_event_id", lastEventID)
+				http.Error(w, "Bad Request", http.StatusBadRequest)
+				return
+			}
+		}
+
+		ctx, cancelCtx := context.WithCancel(r.Context())
+		defer cancelCtx()
+
+		// subscribed before upgrading: an unknown replay file is answered with a plain 404
+		current, updates, cancel, err := ctrl.ProgressSubscriber.Subscribe(ctx, replayFileID, resumeFrom)
+		if err != nil {
+			var notFoundErr *replay.ReplayFileNotFoundError
+			if errors.As(err, &notFoundErr) {
+				http.Error(w, notFoundErr.Message, http.StatusNotFound)
+				return
+			}
+
+			http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+			return
+		}
+
+		defer cancel()
+
+		conn, err := ctrl.Upgrader.Upgrade(w, r, ReplayProgressChannel)
+		if err != nil {
+			// the upgrader already answered the request
+			slog.ErrorContext(ctx, "unable to upgrade replay progress connection", "replayFileID", replayFileID, "err", err)
+			return
+		}
+
+		defer conn.Close()
+
+		// the client isn't expected to send anything, reading handles the pongs and detects the disconnection
+		conn.SetReadDeadline(time.Now().Add(replayProgressPongTimeout))
+		conn.SetPongHandler(func(string) error {
+			return conn.SetReadDeadline(time.Now().Add(replayProgressPongTimeout))
+		})
+
+		go func() {
+			defer cancelCtx()
+
+			for {
+				if _, _, err := conn.NextReader(); err != nil {
+					return
+				}
+			}
+		}()
+
+		if !writeReplayProgress(conn, *current) || isReplayProcessingFinished(*current) {
+			closeReplayProgress(conn)
+			return
+		}
+
+		ping := time.NewTicker(replayProgressPingInterval)
+		defer ping.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-apiContext.Done():
+				closeReplayProgress(conn)
+				return
+			case <-ping.C:
+				err = conn.WriteControl(gorilla.PingMessage, nil, time.Now().Add(replayProgressWriteTimeout))
+				if err != nil {
+					return
+				}
+			case progress, ok := <-updates:
+				if !ok {
+					closeReplayProgress(conn)
+					return
+				}
+
+				if !writeReplayProgress(conn, progress) || isReplayProcessingFinished(progress) {
+					closeReplayProgress(conn)
+					return
+				}
+			}
+		}
+	}
+}
+
+func writeReplayProgress(conn *websocket.Conn, progress replay_entity.ReplayProcessingProgress) bool {
+	err := conn.WriteMessage(progress, replayProgressWriteTimeout)
+	if err != nil {
+		slog.Warn("unable to write replay progress", "replayFileID", progress.ReplayFileID, "err", err)
+		return false
+	}
+
+	return true
+}
+
+func closeReplayProgress(conn *websocket.Conn) {
+	conn.WriteControl(gorilla.CloseMessage, gorilla.FormatCloseMessage(gorilla.CloseNormalClosure, ""), time.Now().Add(replayProgressWriteTimeout))
+}
+
+func isReplayProcessingFinished(progress replay_entity.ReplayProcessingProgress) bool {
+	switch progress.Status {
+	case replay_entity.ReplayFileStatusCompleted, replay_entity.ReplayFileStatusFailed, replay_entity.ReplayFileStatusQuarantined:
+		return true
+	}
+
+	return false
+}
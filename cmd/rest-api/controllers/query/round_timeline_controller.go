@@ -0,0 +1,89 @@
+package query_controllers
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+
+	"github.com/golobby/container/v3"
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	replay_in "github.com/psavelis/team-pro/replay-api/pkg/domain/replay/ports/in"
+)
+
+type RoundTimelineQueryController struct {
+	TimelineReader replay_in.RoundTimelineReader
+}
+
+func NewRoundTimelineQueryController(container *container.Container) *RoundTimelineQueryController {
+	var timelineReader replay_in.RoundTimelineReader
+	err := container.Resolve(&timelineReader)
+	if err != nil {
+		slog.Error("Cannot resolve replay_in.RoundTimelineReader for new RoundTimelineQueryController", "err", err)
+		panic(err)
+	}
+
+	return &RoundTimelineQueryController{
+		TimelineReader: timelineReader,
+	}
+}
+
+// RoundsHandler returns the round timeline of a replay file (the latest one, when it was processed more than once).
+func (ctrl *RoundTimelineQueryController) RoundsHandler(apiContext context.Context) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+
+		replayFileID, err := uuid.Parse(vars["replay_file_id"])
+		if err != nil {
+			slog.ErrorContext(r.Context(), "invalid replay_file_id", "err", err, "replay_file_id", vars["replay_file_id"])
+			http.Error(w, "Bad Request", http.StatusBadRequest)
+			return
+		}
+
+		params := []common.SearchAggregation{
+			{
+				Params: []common.SearchParameter{
+					{
+						ValueParams: []common.SearchableValue{
+							{
+								Field:  "ReplayFileID",
+								Values: []interface{}{replayFileID},
+							},
+							{
+								Field:  "GameID",
+								Values: []interface{}{vars["game_id"]},
+							},
+						},
+					},
+				},
+			},
+		}
+
+		s, err := ctrl.TimelineReader.Compile(r.Context(), params, common.NewSearchResultOptions(0, 1))
+		if err != nil {
+			slog.ErrorContext(r.Context(), "error compiling round timeline search", "err", err)
+			http.Error(w, "Bad Request", http.StatusBadRequest)
+			return
+		}
+
+		s.SortOptions = []common.SearchSortOption{{Field: "CreatedAt", Direction: common.DescendingIDKey}}
+
+		timelines, err := ctrl.TimelineReader.Search(r.Context(), *s)
+		if err != nil {
+			slog.ErrorContext(r.Context(), "error searching round timeline", "err", err, "replay_file_id", replayFileID)
+			http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+			return
+		}
+
+		if len(timelines) == 0 {
+			http.Error(w, "Not Found", http.StatusNotFound)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(timelines[0])
+	}
+}
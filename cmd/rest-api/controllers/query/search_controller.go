@@ -2,6 +2,7 @@ package query_controllers
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log/slog"
 	"net/http"
@@ -9,6 +10,8 @@ import (
 
 	"github.com/golobby/container/v3"
 	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	badge_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/badge/entities"
+	badge_in "github.com/psavelis/team-pro/replay-api/pkg/domain/badge/ports/in"
 	iam_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/iam/entities"
 	iam_in "github.com/psavelis/team-pro/replay-api/pkg/domain/iam/ports/in"
 	replay_entity "github.com/psavelis/team-pro/replay-api/pkg/domain/replay/entities"
@@ -33,7 +36,6 @@ func NewSearchMux(c *container.Container) *SearchableResourceMultiplexer {
 		Handlers: make(map[common.ResourceType]interface{}),
 	}
 
-	// smux.Handlers[common.ResourceTypeBadge] = NewBadgeSearchController(c)
 	// smux.Handlers[common.ResourceTypeRound] = NewMatchSearchController(c)
 	smux.Handlers[common.ResourceTypeReplayFile] = NewReplayFileSearchController(c)
 	smux.Handlers[common.ResourceTypeMatch] = NewMatchSearchController(c)
@@ -41,6 +43,7 @@ func NewSearchMux(c *container.Container) *SearchableResourceMultiplexer {
 
 	smux.Handlers[common.ResourceTypeGameEvent] = NewEventSearchController(c)
 	smux.Handlers[common.ResourceTypeProfile] = NewProfileSearchController(c)
+	smux.Handlers[common.ResourceTypeBadge] = NewBadgeSearchController(c)
 	// smux.Handlers[common.ResourceTypeTeam] = NewTeamSearchController(c)
 	smux.ResourceTypes = make([]common.ResourceType, len(smux.Handlers))
 
@@ -162,16 +165,16 @@ func NewEventSearchController(c *container.Container) *SearchController[replay_e
 	}
 }
 
-func NewBadgeSearchController(c *container.Container) *SearchController[replay_entity.Badge] {
-	var s replay_in.BadgeReader
+func NewBadgeSearchController(c *container.Container) *SearchController[badge_entities.PlayerBadge] {
+	var s badge_in.PlayerBadgeReader
 	err := c.Resolve(&s)
 
 	if err != nil {
-		slog.Error("Cannot resolve replay_in.BadgeReader for NewBadgeSearchController", "err", err)
+		slog.Error("Cannot resolve badge_in.PlayerBadgeReader for NewBadgeSearchController", "err", err)
 		panic(err)
 	}
 
-	return &SearchController[replay_entity.Badge]{
+	return &SearchController[badge_entities.PlayerBadge]{
 		Searchable: s,
 	}
 }
@@ -253,7 +256,18 @@ func GetSearchParams(r *http.Request) (*common.Search, error) {
 		}
 	}
 
+	sort, err := common.ParseSortOptions(queryParams.Get("sort"))
+	if err != nil {
+		return nil, err
+	}
+
+	s.SortOptions = sort
+
 	for key, values := range queryParams {
+		if key == "sort" {
+			continue
+		}
+
 		value := common.SearchableValue{
 			Field:    key,
 			Values:   make([]interface{}, len(values)),
@@ -299,6 +313,12 @@ func (c *SearchController[T]) HandleSearchRequest(w http.ResponseWriter, r *http
 			return
 		}
 
+		if errors.Is(err, common.ErrInvalidSearch) {
+			slog.ErrorContext(r.Context(), "BadRequest: invalid search", "request", r, "error", err)
+			http.Error(w, "BadRequest", http.StatusBadRequest)
+			return
+		}
+
 		slog.ErrorContext(r.Context(), "UnprocessableEntity", "request", r, "error", err)
 		http.Error(w, "UnprocessableEntity", http.StatusUnprocessableEntity)
 		return
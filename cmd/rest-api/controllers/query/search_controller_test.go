@@ -124,10 +124,7 @@ func TestVectorGetResourceStringFromPath(t *testing.T) {
 			t.Errorf("Test Case %s failed: expected %s, but received %s. (Path: %s)", tc.Name, tc.ExpectedResource, res, tc.Path)
 		}
 
-		ctx := context.WithValue(context.TODO(), common.TenantIDKey, common.TeamPROTenantID)
-		ctx = context.WithValue(ctx, common.ClientIDKey, common.TeamPROAppClientID)
-		ctx = context.WithValue(ctx, common.GroupIDKey, uuid.New())
-		ctx = context.WithValue(ctx, common.UserIDKey, uuid.New())
+		ctx := common.WithResourceOwner(context.TODO(), common.ResourceOwner{TenantID: common.TeamPROTenantID, ClientID: common.TeamPROAppClientID, GroupID: uuid.New(), UserID: uuid.New()})
 
 		req, _ := http.NewRequestWithContext(ctx, "GET", tc.Path, &io.PipeReader{})
 
@@ -0,0 +1,26 @@
+package query_controllers
+
+import (
+	"github.com/golobby/container/v3"
+	controllers "github.com/psavelis/team-pro/replay-api/cmd/rest-api/controllers"
+	season_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/season/entities"
+	season_in "github.com/psavelis/team-pro/replay-api/pkg/domain/season/ports/in"
+)
+
+type SeasonQueryController struct {
+	controllers.DefaultSearchController[season_entities.Season]
+}
+
+func NewSeasonQueryController(c container.Container) *SeasonQueryController {
+	var queryService season_in.SeasonReader
+
+	err := c.Resolve(&queryService)
+
+	if err != nil {
+		panic(err)
+	}
+
+	baseController := controllers.NewDefaultSearchController(queryService)
+
+	return &SeasonQueryController{*baseController}
+}
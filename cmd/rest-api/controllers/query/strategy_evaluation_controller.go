@@ -0,0 +1,26 @@
+package query_controllers
+
+import (
+	"github.com/golobby/container/v3"
+	controllers "github.com/psavelis/team-pro/replay-api/cmd/rest-api/controllers"
+	matchmaking_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/matchmaking/entities"
+	matchmaking_in "github.com/psavelis/team-pro/replay-api/pkg/domain/matchmaking/ports/in"
+)
+
+type StrategyEvaluationQueryController struct {
+	controllers.DefaultSearchController[matchmaking_entities.StrategyEvaluation]
+}
+
+func NewStrategyEvaluationQueryController(c container.Container) *StrategyEvaluationQueryController {
+	var queryService matchmaking_in.StrategyEvaluationReader
+
+	err := c.Resolve(&queryService)
+
+	if err != nil {
+		panic(err)
+	}
+
+	baseController := controllers.NewDefaultSearchController(queryService)
+
+	return &StrategyEvaluationQueryController{*baseController}
+}
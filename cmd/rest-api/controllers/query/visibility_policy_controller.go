@@ -0,0 +1,44 @@
+package query_controllers
+
+import (
+	"github.com/golobby/container/v3"
+	controllers "github.com/psavelis/team-pro/replay-api/cmd/rest-api/controllers"
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	iam_in "github.com/psavelis/team-pro/replay-api/pkg/domain/iam/ports/in"
+)
+
+type VisibilityPolicyQueryController struct {
+	controllers.DefaultSearchController[common.VisibilityPolicy]
+}
+
+func NewVisibilityPolicyQueryController(c container.Container) *VisibilityPolicyQueryController {
+	var queryService iam_in.VisibilityPolicyReader
+
+	err := c.Resolve(&queryService)
+
+	if err != nil {
+		panic(err)
+	}
+
+	baseController := controllers.NewDefaultSearchController(queryService)
+
+	return &VisibilityPolicyQueryController{*baseController}
+}
+
+type VisibilityOverrideQueryController struct {
+	controllers.DefaultSearchController[common.VisibilityOverride]
+}
+
+func NewVisibilityOverrideQueryController(c container.Container) *VisibilityOverrideQueryController {
+	var queryService iam_in.VisibilityOverrideReader
+
+	err := c.Resolve(&queryService)
+
+	if err != nil {
+		panic(err)
+	}
+
+	baseController := controllers.NewDefaultSearchController(queryService)
+
+	return &VisibilityOverrideQueryController{*baseController}
+}
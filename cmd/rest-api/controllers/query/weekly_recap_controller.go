@@ -0,0 +1,52 @@
+package query_controllers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/golobby/container/v3"
+	"github.com/psavelis/team-pro/replay-api/pkg/domain/recap"
+	recap_in "github.com/psavelis/team-pro/replay-api/pkg/domain/recap/ports/in"
+)
+
+type WeeklyRecapQueryController struct {
+	LatestPlayerRecapQuery recap_in.LatestPlayerRecapQuery
+}
+
+func NewWeeklyRecapQueryController(container *container.Container) *WeeklyRecapQueryController {
+	var latestPlayerRecapQuery recap_in.LatestPlayerRecapQuery
+	err := container.Resolve(&latestPlayerRecapQuery)
+	if err != nil {
+		slog.Error("Cannot resolve recap_in.LatestPlayerRecapQuery for new WeeklyRecapQueryController", "err", err)
+		panic(err)
+	}
+
+	return &WeeklyRecapQueryController{
+		LatestPlayerRecapQuery: latestPlayerRecapQuery,
+	}
+}
+
+// LatestHandler returns the latest weekly recap of the user (the one their recap alert links to).
+func (ctrl *WeeklyRecapQueryController) LatestHandler(apiContext context.Context) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		weeklyRecap, err := ctrl.LatestPlayerRecapQuery.Exec(r.Context())
+
+		var notFoundErr *recap.WeeklyRecapNotFoundError
+		switch {
+		case errors.As(err, &notFoundErr):
+			http.Error(w, notFoundErr.Message, http.StatusNotFound)
+			return
+		case err != nil:
+			slog.ErrorContext(r.Context(), "unable to get the latest weekly recap", "err", err)
+			http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(weeklyRecap)
+	}
+}
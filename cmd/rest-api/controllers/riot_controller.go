@@ -0,0 +1,80 @@
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+
+	"github.com/golobby/container/v3"
+	riot_entity "github.com/psavelis/team-pro/replay-api/pkg/domain/riot/entities"
+	riot_in "github.com/psavelis/team-pro/replay-api/pkg/domain/riot/ports/in"
+)
+
+type RiotController struct {
+	OnboardRiotUserCommand riot_in.OnboardRiotUserCommand
+}
+
+func NewRiotController(container *container.Container) *RiotController {
+	var onboardRiotUserCommand riot_in.OnboardRiotUserCommand
+	err := container.Resolve(&onboardRiotUserCommand)
+
+	if err != nil {
+		slog.Error("Cannot resolve riot_in.OnboardRiotUserCommand for new RiotController", "err", err)
+		panic(err)
+	}
+
+	return &RiotController{OnboardRiotUserCommand: onboardRiotUserCommand}
+}
+
+func (c *RiotController) OnboardRiotUser(apiContext context.Context) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Access-Control-Allow-Origin", "localhost:3000") // TODO: >>> config
+		w.Header().Set("Access-Control-Allow-Methods", "POST")
+		w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization")
+
+		if r.Body == nil {
+			slog.ErrorContext(r.Context(), "no request body", "request.Body", r.Body)
+			http.Error(w, "Bad Request", http.StatusBadRequest)
+			return
+		}
+
+		decoder := json.NewDecoder(r.Body)
+		var riotUserParams riot_entity.RiotUser
+		err := decoder.Decode(&riotUserParams)
+
+		if err != nil {
+			slog.ErrorContext(r.Context(), "error decoding riot user from request", "err", err, "request.body", r.Body)
+			http.Error(w, "Bad Request", http.StatusBadRequest)
+			return
+		}
+
+		err = c.OnboardRiotUserCommand.Validate(r.Context(), &riotUserParams)
+
+		if err != nil {
+			slog.ErrorContext(r.Context(), "error validating riot user", "err", err, "riotUserParams", riotUserParams)
+			http.Error(w, "Bad Request", http.StatusBadRequest)
+			return
+		}
+
+		riotUser, ridToken, err := c.OnboardRiotUserCommand.Exec(r.Context(), &riotUserParams)
+
+		if err != nil {
+			slog.ErrorContext(r.Context(), "error onboarding riot user", "err", err, "riotUserParams.PUUID", riotUserParams.PUUID, "riotUserParams.VHash", riotUserParams.VHash)
+			http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+			return
+		}
+
+		if ridToken == nil {
+			slog.ErrorContext(r.Context(), "error onboarding riot user", "err", "controller: ridToken is nil", "riotUserParams.PUUID", riotUserParams.PUUID, "riotUserParams.VHash", riotUserParams.VHash)
+			http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("X-Resource-Owner-ID", ridToken.GetID().String())
+		w.Header().Set("X-Refresh-Token", ridToken.RefreshKey)
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(riotUser)
+	}
+}
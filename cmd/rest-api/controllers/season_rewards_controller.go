@@ -0,0 +1,114 @@
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+
+	"github.com/golobby/container/v3"
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	season_in "github.com/psavelis/team-pro/replay-api/pkg/domain/season/ports/in"
+)
+
+type SeasonRewardsController struct {
+	RewardReader season_in.SeasonRewardReader
+}
+
+func NewSeasonRewardsController(container *container.Container) *SeasonRewardsController {
+	var rewardReader season_in.SeasonRewardReader
+	err := container.Resolve(&rewardReader)
+
+	if err != nil {
+		slog.Error("Cannot resolve season_in.SeasonRewardReader for new SeasonRewardsController", "err", err)
+		panic(err)
+	}
+
+	return &SeasonRewardsController{RewardReader: rewardReader}
+}
+
+// GetSeasonRewards returns the rewards of the players of an ended season (best rated first, unless `sort` is given), optionally of a
+// single `tier`, paginated by `skip` and `limit`.
+func (c *SeasonRewardsController) GetSeasonRewards(apiContext context.Context) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		seasonID, err := uuid.Parse(mux.Vars(r)["season_id"])
+		if err != nil {
+			slog.ErrorContext(r.Context(), "invalid season_id", "err", err, "season_id", mux.Vars(r)["season_id"])
+			http.Error(w, "Bad Request", http.StatusBadRequest)
+			return
+		}
+
+		skip, err := parseUintQueryParam(r, "skip", 0)
+		if err != nil {
+			slog.ErrorContext(r.Context(), "invalid season rewards `skip` parameter", "err", err)
+			http.Error(w, "Bad Request", http.StatusBadRequest)
+			return
+		}
+
+		limit, err := parseUintQueryParam(r, "limit", common.DefaultPageSize)
+		if err != nil {
+			slog.ErrorContext(r.Context(), "invalid season rewards `limit` parameter", "err", err)
+			http.Error(w, "Bad Request", http.StatusBadRequest)
+			return
+		}
+
+		sort, err := common.ParseSortOptions(r.URL.Query().Get("sort"))
+		if err != nil {
+			slog.ErrorContext(r.Context(), "invalid season rewards `sort` parameter", "err", err)
+			http.Error(w, "Bad Request", http.StatusBadRequest)
+			return
+		}
+
+		valueParams := []common.SearchableValue{
+			{
+				Field:  "SeasonID",
+				Values: []interface{}{seasonID},
+			},
+		}
+
+		if tier := r.URL.Query().Get("tier"); tier != "" {
+			valueParams = append(valueParams, common.SearchableValue{
+				Field:  "Tier",
+				Values: []interface{}{tier},
+			})
+		}
+
+		params := []common.SearchAggregation{
+			{
+				Params: []common.SearchParameter{
+					{
+						ValueParams: valueParams,
+					},
+				},
+			},
+		}
+
+		resultOptions := common.NewSearchResultOptions(skip, limit)
+		resultOptions.Sort = sort
+
+		s, err := c.RewardReader.Compile(r.Context(), params, resultOptions)
+		if err != nil {
+			slog.ErrorContext(r.Context(), "error compiling season rewards search", "err", err)
+			http.Error(w, "Bad Request", http.StatusBadRequest)
+			return
+		}
+
+		// best rated first, unless sorted otherwise
+		if len(s.SortOptions) == 0 {
+			s.SortOptions = []common.SearchSortOption{{Field: "Rating", Direction: common.DescendingIDKey}}
+		}
+
+		rewards, err := c.RewardReader.Search(r.Context(), *s)
+		if err != nil {
+			slog.ErrorContext(r.Context(), "error searching season rewards", "err", err, "season_id", seasonID)
+			http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(rewards)
+	}
+}
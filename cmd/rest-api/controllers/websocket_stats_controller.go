@@ -0,0 +1,56 @@
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+
+	"github.com/golobby/container/v3"
+	"github.com/psavelis/team-pro/replay-api/pkg/infra/websocket"
+)
+
+type WebSocketStatsController struct {
+	Upgrader          *websocket.Upgrader
+	ReplayProgressHub *websocket.ReplayProgressHub
+}
+
+func NewWebSocketStatsController(container *container.Container) *WebSocketStatsController {
+	var upgrader *websocket.Upgrader
+	err := container.Resolve(&upgrader)
+
+	if err != nil {
+		slog.Error("Cannot resolve websocket.Upgrader for new WebSocketStatsController", "err", err)
+		panic(err)
+	}
+
+	var replayProgressHub *websocket.ReplayProgressHub
+	err = container.Resolve(&replayProgressHub)
+
+	if err != nil {
+		slog.Error("Cannot resolve websocket.ReplayProgressHub for new WebSocketStatsController", "err", err)
+		panic(err)
+	}
+
+	return &WebSocketStatsController{Upgrader: upgrader, ReplayProgressHub: replayProgressHub}
+}
+
+// GetPayloadStats returns the payload sizes of the messages written to the websocket clients of this process, per channel: as JSON,
+// as framed for the clients (JSON or MessagePack) and on the wire (compressed when permessage-deflate was negotiated).
+func (c *WebSocketStatsController) GetPayloadStats(apiContext context.Context) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(c.Upgrader.Stats.Snapshot())
+	}
+}
+
+// GetSubscriberStats returns the send queue of every websocket connection of this process (the most lagging first): the updates
+// queued and not yet written, and the ones skipped for being too slow.
+func (c *WebSocketStatsController) GetSubscriberStats(apiContext context.Context) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(c.ReplayProgressHub.Hub.Stats())
+	}
+}
@@ -5,17 +5,23 @@ import (
 	"log/slog"
 	"net/http"
 	"os"
+	_ "time/tzdata" // pool schedules use IANA timezones, the runtime image has no zoneinfo
 
 	//	"golang.org/x/oauth2/jwt"
 
 	"github.com/psavelis/team-pro/replay-api/cmd/rest-api/routing"
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	"github.com/psavelis/team-pro/replay-api/pkg/infra/chaos"
+	"github.com/psavelis/team-pro/replay-api/pkg/infra/events/rabbitmq"
 	ioc "github.com/psavelis/team-pro/replay-api/pkg/infra/ioc"
+	"github.com/psavelis/team-pro/replay-api/pkg/infra/logging"
+	"github.com/psavelis/team-pro/replay-api/pkg/infra/websocket"
 )
 
 func main() {
 	ctx := context.Background()
 
-	logger := slog.New(slog.NewJSONHandler(os.Stdout, nil))
+	logger := slog.New(logging.NewContextHandler(slog.NewJSONHandler(os.Stdout, nil)))
 
 	slog.SetDefault(logger)
 
@@ -25,6 +31,38 @@ func main() {
 
 	defer builder.Close(c)
 
+	var config common.Config
+	err := c.Resolve(&config)
+	if err != nil {
+		slog.ErrorContext(ctx, "unable to resolve common.Config", "err", err)
+		panic(err)
+	}
+
+	// replay files processed by the replay workers report their progress through the broker
+	if config.RabbitMQ.URL != "" {
+		var hub *websocket.ReplayProgressHub
+		err = c.Resolve(&hub)
+		if err != nil {
+			slog.ErrorContext(ctx, "unable to resolve websocket.ReplayProgressHub", "err", err)
+			panic(err)
+		}
+
+		relay := rabbitmq.NewReplayProgressRelay(config.RabbitMQ.URL, hub.PublishProgress)
+
+		if config.Chaos.Targeted(chaos.TargetRabbitMQ) {
+			var injector *chaos.Injector
+			err = c.Resolve(&injector)
+			if err != nil {
+				slog.ErrorContext(ctx, "unable to resolve chaos.Injector", "err", err)
+				panic(err)
+			}
+
+			relay.Dial = chaos.NewDialer(chaos.TargetRabbitMQ, injector).Dial
+		}
+
+		go relay.Run(ctx)
+	}
+
 	router := routing.NewRouter(ctx, c)
 
 	slog.InfoContext(ctx, "Starting server on port 4991")
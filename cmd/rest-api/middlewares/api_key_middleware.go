@@ -0,0 +1,78 @@
+package middlewares
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/golobby/container/v3"
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	"github.com/psavelis/team-pro/replay-api/pkg/domain/iam"
+	iam_in "github.com/psavelis/team-pro/replay-api/pkg/domain/iam/ports/in"
+)
+
+// APIKeyMiddleware authenticates the server-to-server callers (game server plugins, bots) by their X-API-Key, acting
+// on behalf of the client that issued the key (runs after the ResourceContextMiddleware).
+type APIKeyMiddleware struct {
+	AuthenticateAPIKey iam_in.AuthenticateAPIKeyCommand
+}
+
+func NewAPIKeyMiddleware(container *container.Container) *APIKeyMiddleware {
+	var authenticateAPIKey iam_in.AuthenticateAPIKeyCommand
+	err := container.Resolve(&authenticateAPIKey)
+	if err != nil {
+		slog.Error("Cannot resolve iam_in.AuthenticateAPIKeyCommand for new APIKeyMiddleware", "err", err)
+		panic(err)
+	}
+
+	return &APIKeyMiddleware{
+		AuthenticateAPIKey: authenticateAPIKey,
+	}
+}
+
+func (m *APIKeyMiddleware) Handler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		key := r.Header.Get(string(common.APIKeyParamKey))
+		if key == "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		// a request is either from an end user or from a server, never both
+		if r.Header.Get(string(common.ResourceOwnerIDParamKey)) != "" {
+			http.Error(w, "X-API-Key and X-Resource-Owner-ID are mutually exclusive", http.StatusBadRequest)
+			return
+		}
+
+		ctx := r.Context()
+
+		apiKey, err := m.AuthenticateAPIKey.Exec(ctx, key)
+
+		var unauthenticatedErr *iam.UnauthenticatedAPIKeyError
+		switch {
+		case errors.As(err, &unauthenticatedErr):
+			http.Error(w, unauthenticatedErr.Message, http.StatusUnauthorized)
+			return
+		case err != nil:
+			slog.ErrorContext(ctx, "unable to authenticate api key", "err", err)
+			http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+			return
+		}
+
+		scope, _ := common.GetRequestScope(ctx)
+
+		scope.ResourceOwner = common.ResourceOwner{
+			TenantID: apiKey.ResourceOwner.TenantID,
+			ClientID: apiKey.ResourceOwner.ClientID,
+		}
+		scope.Authenticated = true
+		scope.APIKeyID = apiKey.ID
+		scope.APIKeyTier = string(apiKey.Tier)
+		scope.APIKeyPermissions = make([]string, 0, len(apiKey.Permissions))
+		for _, p := range apiKey.Permissions {
+			scope.APIKeyPermissions = append(scope.APIKeyPermissions, string(p))
+		}
+
+		next.ServeHTTP(w, r.WithContext(common.WithRequestScope(ctx, scope)))
+	})
+}
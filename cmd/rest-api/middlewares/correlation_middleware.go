@@ -0,0 +1,82 @@
+package middlewares
+
+import (
+	"bufio"
+	"net"
+	"net/http"
+	"regexp"
+	"strings"
+
+	"github.com/google/uuid"
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+)
+
+// request IDs set by the callers (ie: a gateway, another service) are kept when they're safe to log
+var requestIDPattern = regexp.MustCompile(`^[A-Za-z0-9._:-]{1,128}$`)
+
+// CorrelationMiddleware sets the correlation ID of the request (the X-Request-ID of the caller, or a new one) in its scope, where it's
+// logged with every record and published with every event. It's answered in the X-Request-ID header of every response, and appended to
+// the body of the plain text error responses (http.Error), so it can be quoted to support.
+type CorrelationMiddleware struct{}
+
+func NewCorrelationMiddleware() *CorrelationMiddleware {
+	return &CorrelationMiddleware{}
+}
+
+func (m *CorrelationMiddleware) Handler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestID := r.Header.Get(string(common.RequestIDParamKey))
+		if !requestIDPattern.MatchString(requestID) {
+			requestID = uuid.NewString()
+		}
+
+		w.Header().Set(string(common.RequestIDParamKey), requestID)
+
+		next.ServeHTTP(&correlatedResponseWriter{ResponseWriter: w, requestID: requestID}, r.WithContext(common.WithRequestID(r.Context(), requestID)))
+	})
+}
+
+type correlatedResponseWriter struct {
+	http.ResponseWriter
+	requestID string
+	status    int
+	appended  bool
+}
+
+func (w *correlatedResponseWriter) WriteHeader(status int) {
+	if w.status == 0 {
+		w.status = status
+	}
+
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *correlatedResponseWriter) Write(b []byte) (int, error) {
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+
+	n, err := w.ResponseWriter.Write(b)
+	if err != nil || w.appended || w.status < http.StatusBadRequest || !strings.HasPrefix(w.Header().Get("Content-Type"), "text/plain") {
+		return n, err
+	}
+
+	// http.Error writes the message (and its line break) at once
+	w.appended = true
+	_, err = w.ResponseWriter.Write([]byte("request_id: " + w.requestID + "\n"))
+
+	return n, err
+}
+
+// Flush and Hijack keep the streamed responses and the websockets working through the wrapper.
+func (w *correlatedResponseWriter) Flush() {
+	http.NewResponseController(w.ResponseWriter).Flush()
+}
+
+func (w *correlatedResponseWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	return http.NewResponseController(w.ResponseWriter).Hijack()
+}
+
+func (w *correlatedResponseWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
@@ -0,0 +1,50 @@
+package middlewares
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/golobby/container/v3"
+	"github.com/psavelis/team-pro/replay-api/pkg/domain/iam"
+	iam_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/iam/entities"
+	iam_in "github.com/psavelis/team-pro/replay-api/pkg/domain/iam/ports/in"
+)
+
+// PermissionMiddleware guards routes by the permissions granted to the user in context by their roles (runs after the
+// ResourceContextMiddleware).
+type PermissionMiddleware struct {
+	Authorize iam_in.AuthorizeQuery
+}
+
+func NewPermissionMiddleware(container *container.Container) *PermissionMiddleware {
+	var authorize iam_in.AuthorizeQuery
+	err := container.Resolve(&authorize)
+	if err != nil {
+		slog.Error("Cannot resolve iam_in.AuthorizeQuery for new PermissionMiddleware", "err", err)
+		panic(err)
+	}
+
+	return &PermissionMiddleware{
+		Authorize: authorize,
+	}
+}
+
+// Require serves the route to users holding every permission, answering 403 Forbidden to the others.
+func (m *PermissionMiddleware) Require(next http.HandlerFunc, permissions ...iam_entities.Permission) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		err := m.Authorize.Exec(r.Context(), permissions...)
+
+		var deniedErr *iam.PermissionDeniedError
+		switch {
+		case err == nil:
+			next(w, r)
+		case errors.As(err, &deniedErr):
+			slog.WarnContext(r.Context(), "permission denied", "permission", deniedErr.Permission, "path", r.URL.Path)
+			http.Error(w, deniedErr.Message, http.StatusForbidden)
+		default:
+			slog.ErrorContext(r.Context(), "unable to authorize request", "path", r.URL.Path, "err", err)
+			http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		}
+	}
+}
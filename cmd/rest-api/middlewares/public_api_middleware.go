@@ -0,0 +1,132 @@
+package middlewares
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/golobby/container/v3"
+	"github.com/google/uuid"
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	"github.com/psavelis/team-pro/replay-api/pkg/domain/iam"
+	iam_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/iam/entities"
+	iam_in "github.com/psavelis/team-pro/replay-api/pkg/domain/iam/ports/in"
+)
+
+// PublicAPIRateWindow is the window the requests of each key are counted in (the rate limits of the tiers are per minute).
+const PublicAPIRateWindow = time.Minute
+
+// PublicAPIMiddleware guards the public API: callers authenticate with an API key granted public:read, and are rate limited by the tier
+// of their key (runs after the APIKeyMiddleware). Requests are counted by each instance of the API.
+type PublicAPIMiddleware struct {
+	Authorize iam_in.AuthorizeQuery
+	CacheTTL  time.Duration
+
+	mu      sync.Mutex
+	windows map[uuid.UUID]*rateWindow
+	swept   time.Time
+}
+
+type rateWindow struct {
+	start    time.Time
+	requests int
+}
+
+func NewPublicAPIMiddleware(container *container.Container) *PublicAPIMiddleware {
+	var authorize iam_in.AuthorizeQuery
+	err := container.Resolve(&authorize)
+	if err != nil {
+		slog.Error("Cannot resolve iam_in.AuthorizeQuery for new PublicAPIMiddleware", "err", err)
+		panic(err)
+	}
+
+	var config common.Config
+	err = container.Resolve(&config)
+	if err != nil {
+		slog.Error("Cannot resolve common.Config for new PublicAPIMiddleware", "err", err)
+		panic(err)
+	}
+
+	return &PublicAPIMiddleware{
+		Authorize: authorize,
+		CacheTTL:  config.PublicAPI.CacheDuration(),
+		windows:   make(map[uuid.UUID]*rateWindow),
+	}
+}
+
+// Require serves the route to API keys granted public:read within the rate limit of their tier, answering 401 Unauthorized without a
+// key, 403 Forbidden to the other keys and 429 Too Many Requests over the limit.
+func (m *PublicAPIMiddleware) Require(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		scope, _ := common.GetRequestScope(ctx)
+		if scope.APIKeyID == uuid.Nil {
+			http.Error(w, fmt.Sprintf("%s required", common.APIKeyParamKey), http.StatusUnauthorized)
+			return
+		}
+
+		err := m.Authorize.Exec(ctx, iam_entities.PermissionPublicRead)
+
+		var deniedErr *iam.PermissionDeniedError
+		switch {
+		case errors.As(err, &deniedErr):
+			http.Error(w, deniedErr.Message, http.StatusForbidden)
+			return
+		case err != nil:
+			slog.ErrorContext(ctx, "unable to authorize public api request", "path", r.URL.Path, "err", err)
+			http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+			return
+		}
+
+		limit := iam_entities.APIKeyTier(scope.APIKeyTier).RateLimit()
+		remaining, reset := m.take(scope.APIKeyID, limit, time.Now())
+
+		w.Header().Set("X-RateLimit-Limit", strconv.Itoa(limit))
+		w.Header().Set("X-RateLimit-Remaining", strconv.Itoa(max(remaining, 0)))
+		w.Header().Set("X-RateLimit-Reset", strconv.FormatInt(reset.Unix(), 10))
+
+		if remaining < 0 {
+			slog.WarnContext(ctx, "public api rate limited", "keyID", scope.APIKeyID, "tier", scope.APIKeyTier, "limit", limit)
+			w.Header().Set("Retry-After", strconv.Itoa(int(time.Until(reset).Seconds())+1))
+			http.Error(w, "Too Many Requests", http.StatusTooManyRequests)
+			return
+		}
+
+		// public stats are the same for every caller: shared caches (ie: CDNs) may keep them as long as the API does
+		w.Header().Set("Cache-Control", fmt.Sprintf("public, max-age=%d", int(m.CacheTTL.Seconds())))
+
+		next(w, r)
+	}
+}
+
+// take counts a request of the key in the current window, returning the requests left (negative when over the limit) and when the
+// window resets. The expired windows are evicted once per window, so keys no longer calling don't pile up.
+func (m *PublicAPIMiddleware) take(keyID uuid.UUID, limit int, now time.Time) (int, time.Time) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if !now.Before(m.swept.Add(PublicAPIRateWindow)) {
+		for id, window := range m.windows {
+			if !now.Before(window.start.Add(PublicAPIRateWindow)) {
+				delete(m.windows, id)
+			}
+		}
+
+		m.swept = now
+	}
+
+	window, ok := m.windows[keyID]
+	if !ok || !now.Before(window.start.Add(PublicAPIRateWindow)) {
+		window = &rateWindow{start: now}
+		m.windows[keyID] = window
+	}
+
+	window.requests++
+
+	return limit - window.requests, window.start.Add(PublicAPIRateWindow)
+}
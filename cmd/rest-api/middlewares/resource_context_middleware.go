@@ -1,9 +1,10 @@
 package middlewares
 
 import (
-	"context"
 	"log/slog"
+	"net"
 	"net/http"
+	"strings"
 
 	"github.com/golobby/container/v3"
 	"github.com/google/uuid"
@@ -30,12 +31,26 @@ func NewResourceContextMiddleware(container *container.Container) *ResourceConte
 
 func (m *ResourceContextMiddleware) Handler(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		ctx := context.WithValue(r.Context(), common.TenantIDKey, common.TeamPROTenantID)
-		ctx = context.WithValue(ctx, common.ClientIDKey, common.TeamPROAppClientID)
-		ctx = context.WithValue(ctx, common.GroupIDKey, uuid.New())
-		ctx = context.WithValue(ctx, common.UserIDKey, uuid.New())
+		// correlated by the CorrelationMiddleware
+		scope, _ := common.GetRequestScope(r.Context())
+		requestID := scope.RequestID
+		if requestID == "" {
+			requestID = uuid.NewString()
+		}
+
+		ctx := common.WithRequestScope(r.Context(), common.RequestScope{
+			ResourceOwner: common.ResourceOwner{
+				TenantID: common.TeamPROTenantID,
+				ClientID: common.TeamPROAppClientID,
+				GroupID:  uuid.New(),
+				UserID:   uuid.New(),
+			},
+			RequestID: requestID,
+			UserAgent: r.UserAgent(),
+			ClientIP:  clientIP(r),
+		})
 
-		rid := r.Header.Get("X-Resource-Owner-ID")
+		rid := r.Header.Get(string(common.ResourceOwnerIDParamKey))
 		if rid == "" {
 			next.ServeHTTP(w, r.WithContext(ctx))
 			return
@@ -45,15 +60,41 @@ func (m *ResourceContextMiddleware) Handler(next http.Handler) http.Handler {
 		if err != nil {
 			slog.ErrorContext(ctx, "unable to verify rid", "X-Resource-Owner-ID", rid)
 			http.Error(w, "unknown", http.StatusUnauthorized)
+			return
 		}
 
 		if !reso.IsUser() {
 			slog.WarnContext(ctx, "non end user resource owner", "reso", reso)
 		}
 
-		ctx = context.WithValue(ctx, common.GroupIDKey, reso.GroupID)
-		ctx = context.WithValue(ctx, common.UserIDKey, reso.UserID)
+		ctx = common.WithAuthenticated(common.WithResourceOwner(ctx, common.ResourceOwner{GroupID: reso.GroupID, UserID: reso.UserID}))
 
 		next.ServeHTTP(w, r.WithContext(ctx))
 	})
 }
+
+// clientIP is the peer address of the request. When the peer is a proxy on the private network (ie: the load balancer), it's the last
+// address of X-Forwarded-For instead: the one appended by the proxy, the ones before it are sent by the client (and can be spoofed).
+func clientIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+
+	peer := net.ParseIP(host)
+	if peer == nil || !(peer.IsLoopback() || peer.IsPrivate()) {
+		return host
+	}
+
+	forwarded := r.Header.Values("X-Forwarded-For")
+	if len(forwarded) == 0 {
+		return host
+	}
+
+	hops := strings.Split(forwarded[len(forwarded)-1], ",")
+	if last := strings.TrimSpace(hops[len(hops)-1]); net.ParseIP(last) != nil {
+		return last
+	}
+
+	return host
+}
@@ -11,43 +11,236 @@ import (
 	cmd_controllers "github.com/psavelis/team-pro/replay-api/cmd/rest-api/controllers/command"
 	query_controllers "github.com/psavelis/team-pro/replay-api/cmd/rest-api/controllers/query"
 	"github.com/psavelis/team-pro/replay-api/cmd/rest-api/middlewares"
+	customfield_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/customfield/entities"
+	iam_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/iam/entities"
 )
 
 const (
 	Health string = "/health"
 	CI     string = "/coverage"
 
-	Match         string = "/games/{game_id}/match"
-	MatchDetail   string = "/games/{game_id}/match/{match_id}"
-	MatchEvent    string = "/games/{game_id}/match/{match_id}/events"
-	GameEvents    string = "/games/{game_id}/events"
-	Replay        string = "/games/{game_id}/replays"
-	ReplayDetail  string = "/games/{game_id}/replay/{replay_file_id}"
-	Onboard       string = "/onboarding"
-	OnboardSteam  string = "/onboarding/steam"
-	OnboardGoogle string = "/onboarding/google"
+	Match          string = "/games/{game_id}/match"
+	MatchDetail    string = "/games/{game_id}/match/{match_id}"
+	MatchEvent     string = "/games/{game_id}/match/{match_id}/events"
+	GameEvents     string = "/games/{game_id}/events"
+	MatchStats     string = "/games/{game_id}/match/{match_id}/stats"
+	Replay         string = "/games/{game_id}/replays"
+	ReplayImport   string = "/games/{game_id}/replays/share_codes"
+	FaceitImport   string = "/games/{game_id}/replays/faceit"
+	ReplayDetail   string = "/games/{game_id}/replay/{replay_file_id}"
+	Onboard        string = "/onboarding"
+	OnboardSteam   string = "/onboarding/steam"
+	OnboardGoogle  string = "/onboarding/google"
+	OnboardRiot    string = "/onboarding/riot"
+	OnboardDiscord string = "/onboarding/discord"
+	DiscordLink    string = "/onboarding/discord/link"
+	OnboardEmail   string = "/onboarding/email"
+	EmailLogin     string = "/onboarding/email/verify"
+	RIDRefresh     string = "/onboarding/refresh"
+
+	Sessions      string = "/sessions"
+	SessionDetail string = "/sessions/{session_id}"
+
+	PlayerMatches       string = "/players/{player_id}/matches"
+	PlayerStats         string = "/players/{player_id}/stats"
+	PlayerRatingHistory string = "/players/{player_id}/rating-history"
+	PlayerBadges        string = "/players/{player_id}/badges"
+	PlayerCard          string = "/players/{player_id}/card.png"
+
+	PlayerLatestRecap string = "/players/me/recaps/latest"
+
+	SquadInvites            string = "/squads/{squad_id}/invites"
+	SquadApplications       string = "/squads/{squad_id}/applications"
+	SquadJoinRequestAccept  string = "/squads/{squad_id}/join-requests/{join_request_id}/accept"
+	SquadJoinRequestDecline string = "/squads/{squad_id}/join-requests/{join_request_id}/decline"
+	SquadMember             string = "/squads/{squad_id}/members/{user_id}"
+	SquadMemberRole         string = "/squads/{squad_id}/members/{user_id}/role"
+	SquadOwner              string = "/squads/{squad_id}/owner"
+	SquadOpenings           string = "/squads/{squad_id}/openings"
+	SquadOpening            string = "/squads/{squad_id}/openings/{opening_id}"
+	Recruitment             string = "/games/{game_id}/recruitment"
+	RecruitmentFreeAgents   string = "/games/{game_id}/recruitment/free-agents"
+	RecruitmentFreeAgent    string = "/recruitment/free-agents/{free_agent_id}"
+	RecruitmentFreeAgentFit string = "/recruitment/free-agents/{free_agent_id}/matches"
+	RecruitmentOpeningFit   string = "/recruitment/openings/{opening_id}/matches"
+
+	Groups         string = "/groups"
+	GroupParent    string = "/groups/{group_id}/parent"
+	GroupSubgroups string = "/groups/{group_id}/subgroups"
+	GroupMembers   string = "/groups/{group_id}/members"
+	GroupMember    string = "/groups/{group_id}/members/{user_id}"
+	GroupMatches   string = "/groups/{group_id}/matches"
+
+	ReplayProgress   string = "/games/{game_id}/replays/{replay_file_id}/progress"
+	ReplayRounds     string = "/games/{game_id}/replays/{replay_file_id}/rounds"
+	ReplayHeatmap    string = "/games/{game_id}/replays/{replay_file_id}/heatmap"
+	ReplayEconomy    string = "/games/{game_id}/replays/{replay_file_id}/economy"
+	ReplayHighlights string = "/games/{game_id}/replays/{replay_file_id}/highlights"
+	ReplaySummary    string = "/games/{game_id}/replays/{replay_file_id}/summary"
+	ReplayDownload   string = "/games/{game_id}/replays/{replay_file_id}/download"
+
+	LobbyDetail          string = "/lobbies/{lobby_id}"
+	LobbyReadyCheck      string = "/lobbies/{lobby_id}/ready_check"
+	LobbyDraft           string = "/lobbies/{lobby_id}/draft"
+	LobbyDraftPicks      string = "/lobbies/{lobby_id}/draft/picks"
+	LobbyDraftStream     string = "/lobbies/{lobby_id}/draft/stream"
+	LobbyMatch           string = "/lobbies/{lobby_id}/match"
+	LobbyAbandons        string = "/lobbies/{lobby_id}/abandons"
+	LobbyVoice           string = "/lobbies/{lobby_id}/voice"
+	LobbyVoiceModeration string = "/lobbies/{lobby_id}/voice/moderation"
+	LobbyResult          string = "/lobbies/{lobby_id}/result"
+
+	MatchmakingPools     string = "/matchmaking/pools"
+	MatchmakingPoolQueue string = "/matchmaking/pools/{pool_id}/queue"
+	MatchmakingTicket    string = "/matchmaking/queue/{ticket_id}"
+
+	MatchmakingSession       string = "/matchmaking/me/session"
+	MatchmakingSessionStream string = "/matchmaking/me/session/stream"
+
+	Seasons       string = "/seasons"
+	SeasonRewards string = "/seasons/{season_id}/rewards"
+
+	Badges string = "/badges"
+
+	CustomFields       string = "/custom-fields"
+	SquadCustomFields  string = "/squads/{squad_id}/custom-fields"
+	PlayerCustomFields string = "/players/{player_id}/custom-fields"
+
+	Consent       string = "/consent"
+	ConsentPolicy string = "/consent/{kind}"
+
+	Exports        string = "/exports"
+	ExportReceipts string = "/exports/receipts"
+
+	Devices      string = "/devices"
+	DeviceDetail string = "/devices/{device_token_id}"
+
+	Notifications           string = "/notifications"
+	NotificationRead        string = "/notifications/{notification_id}/read"
+	NotificationPreferences string = "/notifications/preferences"
 
 	Search string = "/search/{query:.*}"
+
+	GraphQL string = "/graphql"
+
+	// internal
+	AnalyticsEngagement      string = "/analytics/engagement"
+	DataQualityFindings      string = "/quality/findings"
+	MetaEntities             string = "/meta/entities"
+	MatchmakingStrategy      string = "/matchmaking/pools/{pool_id}/strategy"
+	MatchmakingSchedule      string = "/matchmaking/pools/{pool_id}/schedule"
+	MatchmakingQueueDrain    string = "/matchmaking/pools/{pool_id}/queue/drain"
+	MatchmakingEvals         string = "/matchmaking/evaluations"
+	MatchmakingEconomy       string = "/matchmaking/economy"
+	Policies                 string = "/policies"
+	PushReceipts             string = "/notifications/receipts"
+	WebSocketStats           string = "/websocket/stats"
+	WebSocketLag             string = "/websocket/subscribers"
+	CustomField              string = "/custom-fields/{entity_type}/{key}"
+	AdminJobs                string = "/admin/jobs"
+	AdminJobRuns             string = "/admin/jobs/{job_name}/runs"
+	AdminJobPause            string = "/admin/jobs/{job_name}/pause"
+	AdminRoles               string = "/admin/roles"
+	AdminRoleAssignees       string = "/admin/roles/{role_id}/assignments"
+	AdminRoleAssignee        string = "/admin/roles/{role_id}/assignments/{user_id}"
+	AdminAPIKeys             string = "/admin/api-keys"
+	AdminAPIKey              string = "/admin/api-keys/{api_key_id}"
+	AdminAPIKeyRotate        string = "/admin/api-keys/{api_key_id}/rotate"
+	AdminVisibilityPolicies  string = "/admin/visibility-policies"
+	AdminVisibilityOverrides string = "/admin/visibility-overrides"
+	AdminReplayReprocess     string = "/admin/replays/{replay_file_id}/reprocess"
+	PublicMatches            string = "/public/v1/games/{game_id}/matches"
+	PublicMatch              string = "/public/v1/games/{game_id}/matches/{match_id}"
+	PublicLeaderboard        string = "/public/v1/games/{game_id}/leaderboard"
 )
 
 func NewRouter(ctx context.Context, container container.Container) http.Handler {
 	// middleware
+	correlationMiddleware := middlewares.NewCorrelationMiddleware()
 	resourceContextMiddleware := middlewares.NewResourceContextMiddleware(&container)
+	apiKeyMiddleware := middlewares.NewAPIKeyMiddleware(&container)
+	permissionMiddleware := middlewares.NewPermissionMiddleware(&container)
+	publicAPIMiddleware := middlewares.NewPublicAPIMiddleware(&container)
 
 	// metadataController := controllers.NewMetadataController(container)
 	fileController := cmd_controllers.NewFileController(container)
 	healthController := controllers.NewHealthController(container)
 	steamController := controllers.NewSteamController(&container)
 	googleController := controllers.NewGoogleController(&container)
+	riotController := controllers.NewRiotController(&container)
+	discordController := controllers.NewDiscordController(&container)
 	matchController := query_controllers.NewMatchQueryController(container)
 	eventController := query_controllers.NewEventQueryController(container)
+	analyticsController := controllers.NewAnalyticsController(&container)
+	findingController := query_controllers.NewFindingQueryController(container)
+	metaController := controllers.NewMetaController(&container)
+	playerMatchHistoryController := controllers.NewPlayerMatchHistoryController(&container)
+	playerStatsController := controllers.NewPlayerStatsController(&container)
+	playerRatingHistoryController := controllers.NewPlayerRatingHistoryController(&container)
+	playerBadgesController := controllers.NewPlayerBadgesController(&container)
+	playerCardController := controllers.NewPlayerCardController(&container)
+	weeklyRecapController := query_controllers.NewWeeklyRecapQueryController(&container)
+	squadMembershipController := cmd_controllers.NewSquadMembershipController(&container)
+	recruitmentController := cmd_controllers.NewRecruitmentController(&container)
+	recruitmentQueryController := query_controllers.NewRecruitmentQueryController(&container)
+	groupController := cmd_controllers.NewGroupController(&container)
+	groupQueryController := query_controllers.NewGroupQueryController(&container)
+	lobbyController := cmd_controllers.NewLobbyController(&container)
+	replayAdminController := cmd_controllers.NewReplayAdminController(&container)
+	lobbyVoiceController := cmd_controllers.NewLobbyVoiceController(&container)
+	matchmakingController := cmd_controllers.NewMatchmakingController(&container)
+	matchmakingPoolController := query_controllers.NewMatchmakingPoolQueryController(container)
+	seasonController := cmd_controllers.NewSeasonController(&container)
+	seasonQueryController := query_controllers.NewSeasonQueryController(container)
+	seasonRewardsController := controllers.NewSeasonRewardsController(&container)
+	badgeController := cmd_controllers.NewBadgeController(&container)
+	badgeQueryController := query_controllers.NewBadgeQueryController(container)
+	customFieldController := cmd_controllers.NewCustomFieldController(&container)
+	customFieldQueryController := query_controllers.NewCustomFieldQueryController(container)
+	strategyEvaluationController := query_controllers.NewStrategyEvaluationQueryController(container)
+	matchmakingSessionController := query_controllers.NewMatchmakingSessionQueryController(&container)
+	lobbyDraftController := query_controllers.NewLobbyDraftQueryController(&container)
+	replayProgressController := query_controllers.NewReplayProgressQueryController(&container)
+	roundTimelineController := query_controllers.NewRoundTimelineQueryController(&container)
+	replayHeatmapController := query_controllers.NewReplayHeatmapQueryController(&container)
+	replayEconomyController := query_controllers.NewReplayEconomyQueryController(&container)
+	replayHighlightsController := query_controllers.NewReplayHighlightsQueryController(&container)
+	matchSummaryController := query_controllers.NewMatchSummaryQueryController(&container)
+	replayDownloadController := query_controllers.NewReplayDownloadQueryController(&container)
+	graphQLController := query_controllers.NewGraphQLController(&container)
+	consentController := cmd_controllers.NewConsentController(&container)
+	emailController := cmd_controllers.NewEmailController(&container)
+	ridSessionController := cmd_controllers.NewRIDSessionController(&container)
+	exportController := cmd_controllers.NewExportController(&container)
+	exportConfigController := query_controllers.NewExportConfigQueryController(container)
+	exportReceiptController := query_controllers.NewExportReceiptQueryController(container)
+	deviceController := cmd_controllers.NewDeviceController(&container)
+	pushReceiptController := query_controllers.NewPushReceiptQueryController(container)
+	notificationController := cmd_controllers.NewNotificationController(&container)
+	notificationQueryController := query_controllers.NewNotificationQueryController(container)
+	webSocketStatsController := controllers.NewWebSocketStatsController(&container)
+	jobsController := controllers.NewJobsController(&container)
+	roleController := cmd_controllers.NewRoleController(&container)
+	apiKeyController := cmd_controllers.NewAPIKeyController(&container)
+	visibilityPolicyController := cmd_controllers.NewVisibilityPolicyController(&container)
+	visibilityPolicyQueryController := query_controllers.NewVisibilityPolicyQueryController(container)
+	visibilityOverrideQueryController := query_controllers.NewVisibilityOverrideQueryController(container)
+	publicStatsController := query_controllers.NewPublicStatsQueryController(&container)
 
 	// search controllers
 	searchMux := query_controllers.NewSearchMux(&container)
 
 	r := mux.NewRouter()
+	r.Use(correlationMiddleware.Handler)
 	r.Use(mux.CORSMethodMiddleware(r))
 	r.Use(resourceContextMiddleware.Handler)
+	r.Use(apiKeyMiddleware.Handler)
+
+	// the requests not matching any route are correlated too
+	r.NotFoundHandler = correlationMiddleware.Handler(http.NotFoundHandler())
+	r.MethodNotAllowedHandler = correlationMiddleware.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+	}))
 
 	// r.Use(middlewares.NewLoggerMiddleware().Handler)
 	// r.Use(middlewares.NewRecoveryMiddleware().Handler)
@@ -59,6 +252,9 @@ func NewRouter(ctx context.Context, container container.Container) http.Handler
 	// search mux
 	r.HandleFunc(Search, searchMux.Dispatch).Methods("GET")
 
+	// read models (matches, players and squads) as a graph
+	r.HandleFunc(GraphQL, graphQLController.QueryHandler(ctx)).Methods("GET", "POST")
+
 	// health
 	r.HandleFunc(Health, healthController.HealthCheck(ctx)).Methods("GET")
 
@@ -72,6 +268,22 @@ func NewRouter(ctx context.Context, container container.Container) http.Handler
 
 	r.HandleFunc(OnboardGoogle, googleController.OnboardGoogleUser(ctx)).Methods("POST")
 
+	// onboarding/riot (Riot Sign-On)
+	r.HandleFunc(OnboardRiot, riotController.OnboardRiotUser(ctx)).Methods("POST")
+
+	// onboarding/discord (OAuth callback, and linking to the signed in user)
+	r.HandleFunc(OnboardDiscord, discordController.OnboardDiscordUser(ctx)).Methods("POST")
+	r.HandleFunc(DiscordLink, discordController.LinkDiscordAccount(ctx)).Methods("POST")
+
+	// onboarding/email (magic links)
+	r.HandleFunc(OnboardEmail, emailController.RequestMagicLinkHandler(ctx)).Methods("POST")
+	r.HandleFunc(EmailLogin, emailController.VerifyMagicLinkHandler(ctx)).Methods("POST")
+
+	// Sessions API (RID refresh, and the signed in devices of the user)
+	r.HandleFunc(RIDRefresh, ridSessionController.RefreshHandler(ctx)).Methods("POST")
+	r.HandleFunc(Sessions, ridSessionController.ListHandler(ctx)).Methods("GET")
+	r.HandleFunc(SessionDetail, ridSessionController.RevokeHandler(ctx)).Methods("DELETE")
+
 	// Matches API
 	// r.HandleFunc(MatchEvent, metadataController.GetEventsByGameIDAndMatchID(ctx)).Methods("GET") // DEPRECATED
 
@@ -80,12 +292,177 @@ func NewRouter(ctx context.Context, container container.Container) http.Handler
 
 	// Replay API
 	r.HandleFunc(Replay, fileController.UploadHandler(ctx)).Methods("POST")
+	r.HandleFunc(ReplayImport, fileController.ImportShareCodeHandler(ctx)).Methods("POST")
+	r.HandleFunc(FaceitImport, fileController.ImportFaceitMatchHandler(ctx)).Methods("POST")
 	r.HandleFunc(Replay, OptionsHandler).Methods("OPTIONS") // TODO: remover
+
+	// upgraded to a websocket
+	r.HandleFunc(ReplayProgress, replayProgressController.ProgressHandler(ctx)).Methods("GET")
+	r.HandleFunc(ReplayRounds, roundTimelineController.RoundsHandler(ctx)).Methods("GET")
+	r.HandleFunc(ReplayHeatmap, replayHeatmapController.HeatmapHandler(ctx)).Methods("GET")
+	r.HandleFunc(ReplayEconomy, replayEconomyController.EconomyHandler(ctx)).Methods("GET")
+	r.HandleFunc(ReplayHighlights, replayHighlightsController.HighlightsHandler(ctx)).Methods("GET")
+	r.HandleFunc(ReplaySummary, matchSummaryController.SummaryHandler(ctx)).Methods("GET")
+	r.HandleFunc(ReplayDownload, replayDownloadController.DownloadHandler(ctx)).Methods("GET", "HEAD")
 	// r.HandleFunc(Replay, metadataController.ReplaySearchHandler(ctx)).Methods("GET")
 	r.HandleFunc(Match, matchController.DefaultSearchHandler).Methods("GET")
 
+	// Players API
+	r.HandleFunc(PlayerMatches, playerMatchHistoryController.GetPlayerMatches(ctx)).Methods("GET")
+	r.HandleFunc(PlayerStats, playerStatsController.GetPlayerStats(ctx)).Methods("GET")
+	r.HandleFunc(PlayerRatingHistory, playerRatingHistoryController.GetPlayerRatingHistory(ctx)).Methods("GET")
+	r.HandleFunc(PlayerBadges, playerBadgesController.GetPlayerBadges(ctx)).Methods("GET")
+	r.HandleFunc(PlayerCard, playerCardController.GetPlayerCard(ctx)).Methods("GET", "HEAD")
+	r.HandleFunc(PlayerLatestRecap, weeklyRecapController.LatestHandler(ctx)).Methods("GET")
+
+	// Squads API (invites and applications, answered by the invited user or the squad owner)
+	r.HandleFunc(SquadInvites, squadMembershipController.InviteHandler(ctx)).Methods("POST")
+	r.HandleFunc(SquadApplications, squadMembershipController.ApplyHandler(ctx)).Methods("POST")
+	r.HandleFunc(SquadJoinRequestAccept, squadMembershipController.AcceptHandler(ctx)).Methods("POST")
+	r.HandleFunc(SquadJoinRequestDecline, squadMembershipController.DeclineHandler(ctx)).Methods("POST")
+	r.HandleFunc(SquadMemberRole, squadMembershipController.SetMemberRoleHandler(ctx)).Methods("PUT")
+	r.HandleFunc(SquadMember, squadMembershipController.RemoveMemberHandler(ctx)).Methods("DELETE")
+	r.HandleFunc(SquadOwner, squadMembershipController.TransferOwnershipHandler(ctx)).Methods("PUT")
+
+	// Recruitment API (players looking for a team and the openings of the squads)
+	r.HandleFunc(Recruitment, recruitmentQueryController.SearchHandler(ctx)).Methods("GET")
+	r.HandleFunc(RecruitmentFreeAgents, recruitmentController.ListFreeAgentHandler(ctx)).Methods("PUT")
+	r.HandleFunc(RecruitmentFreeAgent, recruitmentController.UnlistFreeAgentHandler(ctx)).Methods("DELETE")
+	r.HandleFunc(RecruitmentFreeAgentFit, recruitmentQueryController.MatchFreeAgentHandler(ctx)).Methods("GET")
+	r.HandleFunc(RecruitmentOpeningFit, recruitmentQueryController.MatchOpeningHandler(ctx)).Methods("GET")
+	r.HandleFunc(SquadOpenings, recruitmentController.PostOpeningHandler(ctx)).Methods("POST")
+	r.HandleFunc(SquadOpening, recruitmentController.CloseOpeningHandler(ctx)).Methods("DELETE")
+
+	// Groups API (org → teams → rosters: the members of a group are members of its subgroups)
+	r.HandleFunc(Groups, groupQueryController.UserGroupsHandler(ctx)).Methods("GET")
+	r.HandleFunc(Groups, groupController.CreateHandler(ctx)).Methods("POST")
+	r.HandleFunc(GroupParent, groupController.SetParentHandler(ctx)).Methods("PUT")
+	r.HandleFunc(GroupSubgroups, groupQueryController.SubgroupsHandler(ctx)).Methods("GET")
+	r.HandleFunc(GroupMembers, groupQueryController.MembersHandler(ctx)).Methods("GET")
+	r.HandleFunc(GroupMember, groupQueryController.MemberHandler(ctx)).Methods("GET")
+	r.HandleFunc(GroupMember, groupController.SetMemberHandler(ctx)).Methods("PUT")
+	r.HandleFunc(GroupMatches, groupQueryController.MatchesHandler(ctx)).Methods("GET")
+
+	// Lobbies API
+	r.HandleFunc(LobbyDetail, lobbyController.GetLobbyHandler(ctx)).Methods("GET")
+	r.HandleFunc(LobbyReadyCheck, lobbyController.ReadyCheckHandler(ctx)).Methods("POST")
+	r.HandleFunc(LobbyDraft, lobbyController.StartDraftHandler(ctx)).Methods("POST")
+	r.HandleFunc(LobbyDraftPicks, lobbyController.PickHandler(ctx)).Methods("POST")
+	r.HandleFunc(LobbyDraftStream, lobbyDraftController.StreamHandler(ctx)).Methods("GET")
+	r.HandleFunc(LobbyMatch, lobbyController.LinkMatchHandler(ctx)).Methods("PUT")
+	r.HandleFunc(LobbyAbandons, lobbyController.AbandonHandler(ctx)).Methods("POST")
+	r.HandleFunc(LobbyResult, lobbyController.ReportResultHandler(ctx)).Methods("POST")
+	r.HandleFunc(LobbyVoice, lobbyVoiceController.JoinHandler(ctx)).Methods("GET")
+	r.HandleFunc(LobbyVoiceModeration, lobbyVoiceController.ModerateHandler(ctx)).Methods("POST")
+
+	// Matchmaking API
+	r.HandleFunc(MatchmakingPools, matchmakingPoolController.DefaultSearchHandler).Methods("GET")
+	r.HandleFunc(MatchmakingPoolQueue, matchmakingController.EnqueueHandler(ctx)).Methods("POST")
+	r.HandleFunc(MatchmakingTicket, matchmakingController.QueueStatusHandler(ctx)).Methods("GET")
+	r.HandleFunc(MatchmakingTicket, matchmakingController.LeaveQueueHandler(ctx)).Methods("DELETE")
+	r.HandleFunc(MatchmakingSession, matchmakingSessionController.SessionHandler(ctx)).Methods("GET")
+	r.HandleFunc(MatchmakingSessionStream, matchmakingSessionController.StreamHandler(ctx)).Methods("GET")
+
+	// Seasons API
+	r.HandleFunc(Seasons, seasonQueryController.DefaultSearchHandler).Methods("GET")
+	r.HandleFunc(SeasonRewards, seasonRewardsController.GetSeasonRewards(ctx)).Methods("GET")
+
+	// Badges API (definitions, the badges awarded are searched at /search/badges)
+	r.HandleFunc(Badges, badgeQueryController.DefaultSearchHandler).Methods("GET")
+
+	// Custom Fields API (the fields the client application defined for its squads and players, searched as "CustomFields.<key>")
+	r.HandleFunc(CustomFields, customFieldQueryController.DefaultSearchHandler).Methods("GET")
+
+	// Consent API
+	r.HandleFunc(Consent, consentController.StatusHandler(ctx)).Methods("GET")
+	r.HandleFunc(ConsentPolicy, consentController.AcceptHandler(ctx)).Methods("POST")
+
+	// Exports API (nightly exports to tenant buckets, with their delivery receipts)
+	r.HandleFunc(Exports, permissionMiddleware.Require(exportController.CreateHandler(ctx), iam_entities.PermissionExportsManage)).Methods("POST")
+	r.HandleFunc(Exports, permissionMiddleware.Require(exportConfigController.DefaultSearchHandler, iam_entities.PermissionExportsManage)).Methods("GET")
+	r.HandleFunc(ExportReceipts, permissionMiddleware.Require(exportReceiptController.DefaultSearchHandler, iam_entities.PermissionExportsManage)).Methods("GET")
+
+	// Devices API (push tokens of the app installs, for match found alerts)
+	r.HandleFunc(Devices, deviceController.RegisterHandler(ctx)).Methods("POST")
+	r.HandleFunc(DeviceDetail, deviceController.UnregisterHandler(ctx)).Methods("DELETE")
+
+	// Notifications API (the inbox of the user, and the channels they are notified through)
+	r.HandleFunc(Notifications, notificationQueryController.DefaultSearchHandler).Methods("GET")
+	r.HandleFunc(NotificationRead, notificationController.MarkReadHandler(ctx)).Methods("PUT")
+	r.HandleFunc(NotificationPreferences, notificationController.GetPreferencesHandler(ctx)).Methods("GET")
+	r.HandleFunc(NotificationPreferences, notificationController.UpdatePreferencesHandler(ctx)).Methods("PUT")
+
 	// Game Events API
 	r.HandleFunc(GameEvents, eventController.DefaultSearchHandler).Methods("GET")
+	r.HandleFunc(MatchStats, eventController.MatchStatsHandler(ctx)).Methods("GET")
+
+	// Public API (read-only, third-party stat sites authenticated by API keys, rate limited by the tier of their key)
+	r.HandleFunc(PublicMatches, publicAPIMiddleware.Require(publicStatsController.MatchesHandler(ctx))).Methods("GET")
+	r.HandleFunc(PublicMatch, publicAPIMiddleware.Require(publicStatsController.MatchHandler(ctx))).Methods("GET")
+	r.HandleFunc(PublicLeaderboard, publicAPIMiddleware.Require(publicStatsController.LeaderboardHandler(ctx))).Methods("GET")
+
+	// Analytics API (internal)
+	r.HandleFunc(AnalyticsEngagement, permissionMiddleware.Require(analyticsController.GetEngagement(ctx), iam_entities.PermissionAnalyticsRead)).Methods("GET")
+
+	// Data Quality API (internal, on-call triage)
+	r.HandleFunc(DataQualityFindings, permissionMiddleware.Require(findingController.DefaultSearchHandler, iam_entities.PermissionQualityRead)).Methods("GET")
+
+	// Meta API (internal, admin UI)
+	r.HandleFunc(MetaEntities, permissionMiddleware.Require(metaController.GetEntities(ctx), iam_entities.PermissionMetaRead)).Methods("GET")
+
+	// Matchmaking API (internal, pool administration)
+	r.HandleFunc(MatchmakingPools, permissionMiddleware.Require(matchmakingController.CreatePoolHandler(ctx), iam_entities.PermissionMatchmakingManage)).Methods("POST")
+	r.HandleFunc(MatchmakingStrategy, permissionMiddleware.Require(matchmakingController.UpdateStrategyHandler(ctx), iam_entities.PermissionMatchmakingManage)).Methods("PUT")
+	r.HandleFunc(MatchmakingSchedule, permissionMiddleware.Require(matchmakingController.UpdateScheduleHandler(ctx), iam_entities.PermissionMatchmakingManage)).Methods("PUT")
+	r.HandleFunc(MatchmakingQueueDrain, permissionMiddleware.Require(matchmakingController.DrainQueueHandler(ctx), iam_entities.PermissionMatchmakingManage)).Methods("POST")
+	r.HandleFunc(MatchmakingEvals, permissionMiddleware.Require(strategyEvaluationController.DefaultSearchHandler, iam_entities.PermissionMatchmakingManage)).Methods("GET")
+	r.HandleFunc(MatchmakingEconomy, permissionMiddleware.Require(matchmakingController.CreateEconomyConfigHandler(ctx), iam_entities.PermissionMatchmakingManage)).Methods("POST")
+
+	// Seasons API (internal, organizers)
+	r.HandleFunc(Seasons, permissionMiddleware.Require(seasonController.ScheduleHandler(ctx), iam_entities.PermissionSeasonsManage)).Methods("POST")
+
+	// Badges API (internal, organizers)
+	r.HandleFunc(Badges, permissionMiddleware.Require(badgeController.CreateHandler(ctx), iam_entities.PermissionBadgesManage)).Methods("POST")
+
+	// Custom Fields API (internal, tenant administration)
+	r.HandleFunc(CustomField, permissionMiddleware.Require(customFieldController.DefineHandler(ctx), iam_entities.PermissionCustomFieldsManage)).Methods("PUT")
+	r.HandleFunc(SquadCustomFields, permissionMiddleware.Require(customFieldController.SetHandler(ctx, customfield_entities.CustomFieldEntitySquad, "squad_id"), iam_entities.PermissionCustomFieldsManage)).Methods("PUT")
+	r.HandleFunc(PlayerCustomFields, permissionMiddleware.Require(customFieldController.SetHandler(ctx, customfield_entities.CustomFieldEntityPlayer, "player_id"), iam_entities.PermissionCustomFieldsManage)).Methods("PUT")
+
+	// Consent API (internal, policy publishing)
+	r.HandleFunc(Policies, permissionMiddleware.Require(consentController.PublishHandler(ctx), iam_entities.PermissionConsentPublish)).Methods("POST")
+
+	// Notifications API (internal, delivery receipts for the notification analytics)
+	r.HandleFunc(PushReceipts, permissionMiddleware.Require(pushReceiptController.DefaultSearchHandler, iam_entities.PermissionNotificationsRead)).Methods("GET")
+
+	// WebSocket API (internal, payload sizes per channel, framing and compression, and the lag of the connections)
+	r.HandleFunc(WebSocketStats, permissionMiddleware.Require(webSocketStatsController.GetPayloadStats(ctx), iam_entities.PermissionWebSocketRead)).Methods("GET")
+	r.HandleFunc(WebSocketLag, permissionMiddleware.Require(webSocketStatsController.GetSubscriberStats(ctx), iam_entities.PermissionWebSocketRead)).Methods("GET")
+
+	// Jobs API (internal, run history of the background jobs, manual triggers and pauses)
+	r.HandleFunc(AdminJobs, permissionMiddleware.Require(jobsController.GetJobs(ctx), iam_entities.PermissionJobsManage)).Methods("GET")
+	r.HandleFunc(AdminJobRuns, permissionMiddleware.Require(jobsController.GetJobRuns(ctx), iam_entities.PermissionJobsManage)).Methods("GET")
+	r.HandleFunc(AdminJobRuns, permissionMiddleware.Require(jobsController.TriggerJob(ctx), iam_entities.PermissionJobsManage)).Methods("POST")
+	r.HandleFunc(AdminJobPause, permissionMiddleware.Require(jobsController.PauseJob(ctx), iam_entities.PermissionJobsManage)).Methods("POST")
+	r.HandleFunc(AdminJobPause, permissionMiddleware.Require(jobsController.ResumeJob(ctx), iam_entities.PermissionJobsManage)).Methods("DELETE")
+
+	// Roles API (internal, role administration)
+	r.HandleFunc(AdminRoles, permissionMiddleware.Require(roleController.CreateHandler(ctx), iam_entities.PermissionRolesManage)).Methods("POST")
+	r.HandleFunc(AdminRoleAssignees, permissionMiddleware.Require(roleController.AssignHandler(ctx), iam_entities.PermissionRolesManage)).Methods("POST")
+	r.HandleFunc(AdminRoleAssignee, permissionMiddleware.Require(roleController.UnassignHandler(ctx), iam_entities.PermissionRolesManage)).Methods("DELETE")
+
+	// API Keys API (internal, server-to-server credentials of game server plugins and bots)
+	r.HandleFunc(AdminAPIKeys, permissionMiddleware.Require(apiKeyController.IssueHandler(ctx), iam_entities.PermissionAPIKeysManage)).Methods("POST")
+	r.HandleFunc(AdminAPIKeyRotate, permissionMiddleware.Require(apiKeyController.RotateHandler(ctx), iam_entities.PermissionAPIKeysManage)).Methods("POST")
+	r.HandleFunc(AdminAPIKey, permissionMiddleware.Require(apiKeyController.RevokeHandler(ctx), iam_entities.PermissionAPIKeysManage)).Methods("DELETE")
+
+	// Visibility Policies API (internal, default visibility of the entities created in a tenant or group, and the overrides of it)
+	r.HandleFunc(AdminVisibilityPolicies, permissionMiddleware.Require(visibilityPolicyQueryController.DefaultSearchHandler, iam_entities.PermissionVisibilityManage)).Methods("GET")
+	r.HandleFunc(AdminVisibilityPolicies, permissionMiddleware.Require(visibilityPolicyController.SetHandler(ctx), iam_entities.PermissionVisibilityManage)).Methods("PUT")
+	r.HandleFunc(AdminVisibilityOverrides, permissionMiddleware.Require(visibilityOverrideQueryController.DefaultSearchHandler, iam_entities.PermissionVisibilityManage)).Methods("GET")
+
+	// Replays API (internal, requeueing replay files for the replay workers)
+	r.HandleFunc(AdminReplayReprocess, permissionMiddleware.Require(replayAdminController.ReprocessHandler(ctx), iam_entities.PermissionReplaysManage)).Methods("POST")
 
 	// r.HandleFunc(ReplayDetail, fileController.ReplayDetailHandler(ctx)).Methods("GET")
 	// r.HandleFunc(("/games/{game_id}/replay/{replay_file_id}"), fileController.ProcessReplayFile(ctx)).Methods("PUT")
@@ -111,13 +488,6 @@ func NewRouter(ctx context.Context, container container.Container) http.Handler
 	// r.HandleFunc("/games/{game_id}/user/{user_id}", userController.UpdateUser(ctx)).Methods("PUT")
 	// r.HandleFunc("/games/{game_id}/user/{user_id}", userController.DeleteUser(ctx)).Methods("DELETE")
 
-	// Badges API
-	// r.HandleFunc("/games/{game_id}/badges", badgeController.GetBadgesByGameID(ctx)).Methods("GET")
-	// r.HandleFunc("/games/{game_id}/badge_types", badgeController.GetBadgeTypes(ctx)).Methods("GET")
-	// r.HandleFunc("/games/{game_id}/badges/{badge_id}", badgeController.GetBadgeByID(ctx)).Methods("GET")
-	// r.HandleFunc("/games/{game_id}/badges/{badge_id}", badgeController.UpdateBadge(ctx)).Methods("PUT")
-	// r.HandleFunc("/games/{game_id}/badges/{badge_id}", badgeController.DeleteBadge(ctx)).Methods("DELETE")
-
 	// Stats API
 	// r.HandleFunc("/games/{game_id}/stats", statsController.GetStatsByGameID(ctx)).Methods("GET")
 
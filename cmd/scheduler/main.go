@@ -0,0 +1,361 @@
+package main
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+	_ "time/tzdata" // matcher evaluates pool schedule timezones
+
+	"github.com/google/uuid"
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	analytics_in "github.com/psavelis/team-pro/replay-api/pkg/domain/analytics/ports/in"
+	export_in "github.com/psavelis/team-pro/replay-api/pkg/domain/export/ports/in"
+	matchmaking_in "github.com/psavelis/team-pro/replay-api/pkg/domain/matchmaking/ports/in"
+	notification_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/notification/entities"
+	notification_in "github.com/psavelis/team-pro/replay-api/pkg/domain/notification/ports/in"
+	quality_in "github.com/psavelis/team-pro/replay-api/pkg/domain/quality/ports/in"
+	recap_in "github.com/psavelis/team-pro/replay-api/pkg/domain/recap/ports/in"
+	replay_in "github.com/psavelis/team-pro/replay-api/pkg/domain/replay/ports/in"
+	season_in "github.com/psavelis/team-pro/replay-api/pkg/domain/season/ports/in"
+	ioc "github.com/psavelis/team-pro/replay-api/pkg/infra/ioc"
+	"github.com/psavelis/team-pro/replay-api/pkg/infra/logging"
+	"github.com/psavelis/team-pro/replay-api/pkg/infra/scheduler"
+)
+
+// standby schedulers take over the matcher within a TTL (plus a heartbeat) of the leader going silent
+const matcherLeaseTTL = 15 * time.Second
+
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	logger := slog.New(logging.NewContextHandler(slog.NewJSONHandler(os.Stdout, nil)))
+
+	slog.SetDefault(logger)
+
+	builder := ioc.NewContainerBuilder()
+
+	c := builder.WithEnvFile().With(ioc.InjectMongoDB).WithInboundPorts().Build()
+
+	defer builder.Close(c)
+
+	// jobs run on behalf of the server application (client level)
+	ctx = common.WithRequestScope(ctx, common.RequestScope{
+		ResourceOwner: common.ResourceOwner{TenantID: common.TeamPROTenantID, ClientID: common.ServerClientID},
+	})
+
+	var computeEngagement analytics_in.ComputeEngagementSnapshotCommand
+	err := c.Resolve(&computeEngagement)
+	if err != nil {
+		slog.ErrorContext(ctx, "unable to resolve analytics_in.ComputeEngagementSnapshotCommand", "err", err)
+		panic(err)
+	}
+
+	var runDataQualityChecks quality_in.RunDataQualityChecksCommand
+	err = c.Resolve(&runDataQualityChecks)
+	if err != nil {
+		slog.ErrorContext(ctx, "unable to resolve quality_in.RunDataQualityChecksCommand", "err", err)
+		panic(err)
+	}
+
+	var projectPlayerMatchHistory replay_in.ProjectPlayerMatchHistoryCommand
+	err = c.Resolve(&projectPlayerMatchHistory)
+	if err != nil {
+		slog.ErrorContext(ctx, "unable to resolve replay_in.ProjectPlayerMatchHistoryCommand", "err", err)
+		panic(err)
+	}
+
+	var syncPlayerProfiles replay_in.SyncPlayerProfilesCommand
+	err = c.Resolve(&syncPlayerProfiles)
+	if err != nil {
+		slog.ErrorContext(ctx, "unable to resolve replay_in.SyncPlayerProfilesCommand", "err", err)
+		panic(err)
+	}
+
+	var transitionSeasons season_in.TransitionSeasonsCommand
+	err = c.Resolve(&transitionSeasons)
+	if err != nil {
+		slog.ErrorContext(ctx, "unable to resolve season_in.TransitionSeasonsCommand", "err", err)
+		panic(err)
+	}
+
+	var config common.Config
+	err = c.Resolve(&config)
+	if err != nil {
+		slog.ErrorContext(ctx, "unable to resolve common.Config", "err", err)
+		panic(err)
+	}
+
+	var syncLobbyVoiceChannels matchmaking_in.SyncLobbyVoiceChannelsCommand
+	err = c.Resolve(&syncLobbyVoiceChannels)
+	if err != nil {
+		slog.ErrorContext(ctx, "unable to resolve matchmaking_in.SyncLobbyVoiceChannelsCommand", "err", err)
+		panic(err)
+	}
+
+	var expireDraftPicks matchmaking_in.ExpireDraftPicksCommand
+	err = c.Resolve(&expireDraftPicks)
+	if err != nil {
+		slog.ErrorContext(ctx, "unable to resolve matchmaking_in.ExpireDraftPicksCommand", "err", err)
+		panic(err)
+	}
+
+	var expireReadyChecks matchmaking_in.ExpireReadyChecksCommand
+	err = c.Resolve(&expireReadyChecks)
+	if err != nil {
+		slog.ErrorContext(ctx, "unable to resolve matchmaking_in.ExpireReadyChecksCommand", "err", err)
+		panic(err)
+	}
+
+	var expireStaleLobbies matchmaking_in.ExpireStaleLobbiesCommand
+	err = c.Resolve(&expireStaleLobbies)
+	if err != nil {
+		slog.ErrorContext(ctx, "unable to resolve matchmaking_in.ExpireStaleLobbiesCommand", "err", err)
+		panic(err)
+	}
+
+	var runMatchmaking matchmaking_in.RunMatchmakingCommand
+	err = c.Resolve(&runMatchmaking)
+	if err != nil {
+		slog.ErrorContext(ctx, "unable to resolve matchmaking_in.RunMatchmakingCommand", "err", err)
+		panic(err)
+	}
+
+	var recoverMatchmakingQueues matchmaking_in.RecoverMatchmakingQueuesCommand
+	err = c.Resolve(&recoverMatchmakingQueues)
+	if err != nil {
+		slog.ErrorContext(ctx, "unable to resolve matchmaking_in.RecoverMatchmakingQueuesCommand", "err", err)
+		panic(err)
+	}
+
+	var runScheduledExports export_in.RunScheduledExportsCommand
+	err = c.Resolve(&runScheduledExports)
+	if err != nil {
+		slog.ErrorContext(ctx, "unable to resolve export_in.RunScheduledExportsCommand", "err", err)
+		panic(err)
+	}
+
+	var generateWeeklyRecaps recap_in.GenerateWeeklyRecapsCommand
+	err = c.Resolve(&generateWeeklyRecaps)
+	if err != nil {
+		slog.ErrorContext(ctx, "unable to resolve recap_in.GenerateWeeklyRecapsCommand", "err", err)
+		panic(err)
+	}
+
+	var notifyWeeklyRecap notification_in.NotifyWeeklyRecapCommandHandler
+	err = c.Resolve(&notifyWeeklyRecap)
+	if err != nil {
+		slog.ErrorContext(ctx, "unable to resolve notification_in.NotifyWeeklyRecapCommandHandler", "err", err)
+		panic(err)
+	}
+
+	var leaseLock scheduler.LeaseLock
+	err = c.Resolve(&leaseLock)
+	if err != nil {
+		slog.ErrorContext(ctx, "unable to resolve scheduler.LeaseLock", "err", err)
+		panic(err)
+	}
+
+	var jobStore scheduler.JobStore
+	err = c.Resolve(&jobStore)
+	if err != nil {
+		slog.ErrorContext(ctx, "unable to resolve scheduler.JobStore", "err", err)
+		panic(err)
+	}
+
+	var missedScheduleNotifier scheduler.MissedScheduleNotifier
+	err = c.Resolve(&missedScheduleNotifier)
+	if err != nil {
+		slog.ErrorContext(ctx, "unable to resolve scheduler.MissedScheduleNotifier", "err", err)
+		panic(err)
+	}
+
+	// a single replica runs the matcher, the others stand by and take over when its lease expires
+	hostname, _ := os.Hostname()
+	holder := hostname + "-" + uuid.NewString()
+	matcherElection := scheduler.NewLeaderElection(leaseLock, "matchmaking.matcher", holder, matcherLeaseTTL)
+
+	go matcherElection.Run(ctx, func(electionCtx context.Context) error {
+		recovered, err := recoverMatchmakingQueues.Exec(electionCtx)
+
+		slog.InfoContext(electionCtx, "matchmaking queues recovered", "tickets", recovered)
+
+		return err
+	})
+
+	// runs are recorded in the job history, paused and triggered through the jobs API
+	s := scheduler.NewScheduler().WithStore(jobStore, holder)
+
+	s.Every(time.Hour, "analytics.engagement", func(jobCtx context.Context) error {
+		now := time.Now().UTC()
+
+		// yesterday is recomputed so late uploads are reflected in the closed day
+		for _, day := range []time.Time{now.Add(-24 * time.Hour), now} {
+			_, err := computeEngagement.Exec(jobCtx, day)
+			if err != nil {
+				return err
+			}
+
+			scheduler.Processed(jobCtx, 1)
+		}
+
+		return nil
+	})
+
+	s.Every(15*time.Minute, "quality.checks", func(jobCtx context.Context) error {
+		results, err := runDataQualityChecks.Exec(jobCtx)
+
+		scheduler.Processed(jobCtx, len(results))
+
+		slog.InfoContext(jobCtx, "data quality checks completed", "results", results)
+
+		return err
+	})
+
+	s.Every(5*time.Minute, "replay.player_match_history", func(jobCtx context.Context) error {
+		// overlapping window: projecting a match twice is idempotent, missing one is not
+		written, err := projectPlayerMatchHistory.Exec(jobCtx, time.Now().UTC().Add(-15*time.Minute))
+
+		scheduler.Processed(jobCtx, written)
+
+		slog.InfoContext(jobCtx, "player match history projected", "entries", written)
+
+		return err
+	})
+
+	// a batch of the players last synced over a day ago (external APIs are rate limited)
+	s.Every(15*time.Minute, "players.profile_sync", func(jobCtx context.Context) error {
+		synced, err := syncPlayerProfiles.Exec(jobCtx, time.Now().UTC().Add(-24*time.Hour))
+
+		scheduler.Processed(jobCtx, synced)
+
+		slog.InfoContext(jobCtx, "player profiles synced", "players", synced)
+
+		return err
+	})
+
+	// seasons start and end within a minute of their schedule (rewards are granted as the season ends)
+	s.Every(time.Minute, "seasons.transitions", func(jobCtx context.Context) error {
+		transitioned, err := transitionSeasons.Exec(jobCtx)
+		scheduler.Processed(jobCtx, transitioned)
+
+		if transitioned > 0 {
+			slog.InfoContext(jobCtx, "seasons transitioned", "seasons", transitioned)
+		}
+
+		return err
+	})
+
+	// exports are claimed by a single replica, each runs once a night (at its hour_utc)
+	s.Every(10*time.Minute, "exports.nightly", func(jobCtx context.Context) error {
+		delivered, err := runScheduledExports.Exec(jobCtx, time.Now().UTC())
+		scheduler.Processed(jobCtx, delivered)
+
+		if delivered > 0 {
+			slog.InfoContext(jobCtx, "scheduled exports delivered", "datasets", delivered)
+		}
+
+		return err
+	})
+
+	// recaps of the closed week: later runs only recap the subjects of the matches projected late (each recap is generated once)
+	s.Every(6*time.Hour, "recaps.weekly", func(jobCtx context.Context) error {
+		recaps, err := generateWeeklyRecaps.Exec(jobCtx, time.Now().UTC())
+
+		for _, recap := range recaps {
+			// notified on behalf of the owner of the recap
+			notifyCtx := common.WithResourceOwner(jobCtx, recap.ResourceOwner)
+
+			_, notifyErr := notifyWeeklyRecap.Exec(notifyCtx, notification_entities.WeeklyRecapReady{
+				RecapID:       recap.ID,
+				Subject:       string(recap.Subject),
+				Name:          recap.Name,
+				WeekStart:     recap.WeekStart,
+				MatchesPlayed: recap.MatchesPlayed,
+				UserIDs:       recap.UserIDs,
+				ResourceOwner: recap.ResourceOwner,
+				CreatedAt:     recap.CreatedAt,
+			})
+
+			if notifyErr != nil {
+				slog.WarnContext(jobCtx, "unable to notify weekly recap", "recapID", recap.ID, "err", notifyErr)
+			}
+		}
+
+		scheduler.Processed(jobCtx, len(recaps))
+
+		if len(recaps) > 0 {
+			slog.InfoContext(jobCtx, "weekly recaps generated", "recaps", len(recaps))
+		}
+
+		return err
+	})
+
+	s.Every(5*time.Second, "matchmaking.matcher", matcherElection.Guard(func(jobCtx context.Context) error {
+		lobbies, err := runMatchmaking.Exec(jobCtx)
+		scheduler.Processed(jobCtx, lobbies)
+
+		if lobbies > 0 {
+			slog.InfoContext(jobCtx, "matchmaking lobbies formed", "lobbies", lobbies)
+		}
+
+		return err
+	}))
+
+	// expired ready checks are also settled when a player responds, this requeues the players of abandoned ones
+	s.Every(5*time.Second, "matchmaking.ready_checks", func(jobCtx context.Context) error {
+		cancelled, err := expireReadyChecks.Exec(jobCtx)
+		scheduler.Processed(jobCtx, cancelled)
+
+		if cancelled > 0 {
+			slog.InfoContext(jobCtx, "expired ready checks cancelled", "lobbies", cancelled)
+		}
+
+		return err
+	})
+
+	// pick timers are also enforced when a captain picks, this only keeps idle drafts moving
+	s.Every(10*time.Second, "matchmaking.draft_timers", func(jobCtx context.Context) error {
+		picks, err := expireDraftPicks.Exec(jobCtx)
+		scheduler.Processed(jobCtx, picks)
+
+		if picks > 0 {
+			slog.InfoContext(jobCtx, "expired draft picks auto-picked", "picks", picks)
+		}
+
+		return err
+	})
+
+	// pools without lobby and queue timeouts are skipped
+	s.Every(time.Minute, "matchmaking.janitor", func(jobCtx context.Context) error {
+		cancelled, expired, err := expireStaleLobbies.Exec(jobCtx)
+		scheduler.Processed(jobCtx, cancelled+expired)
+
+		if cancelled > 0 || expired > 0 {
+			slog.InfoContext(jobCtx, "stale lobbies and queue tickets expired", "lobbies", cancelled, "tickets", expired)
+		}
+
+		return err
+	})
+
+	if config.Voice.Provider != "" {
+		s.Every(time.Minute, "matchmaking.voice", func(jobCtx context.Context) error {
+			provisioned, tornDown, err := syncLobbyVoiceChannels.Exec(jobCtx)
+			scheduler.Processed(jobCtx, provisioned+tornDown)
+
+			slog.InfoContext(jobCtx, "lobby voice channels synced", "provisioned", provisioned, "torn_down", tornDown)
+
+			return err
+		})
+	}
+
+	// alerts (once per miss) on the jobs above not started for twice their interval
+	s.Every(time.Minute, "scheduler.watchdog", s.Watchdog(missedScheduleNotifier))
+
+	slog.InfoContext(ctx, "Starting scheduler")
+
+	s.Start(ctx)
+}
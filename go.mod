@@ -3,10 +3,12 @@ module github.com/psavelis/team-pro/replay-api
 go 1.22.2
 
 require (
+	github.com/aws/aws-sdk-go v1.55.8
 	github.com/golang/geo v0.0.0-20230421003525-6adc56603217
 	github.com/golobby/container/v3 v3.3.2
 	github.com/google/uuid v1.6.0
 	github.com/gorilla/mux v1.8.1
+	github.com/gorilla/websocket v1.5.3
 	github.com/joho/godotenv v1.5.1
 	github.com/markus-wa/demoinfocs-golang/v4 v4.1.3
 	github.com/markus-wa/godispatch v1.4.1
@@ -18,6 +20,7 @@ require (
 require (
 	github.com/davecgh/go-spew v1.1.1 // indirect
 	github.com/golang/snappy v0.0.4 // indirect
+	github.com/jmespath/go-jmespath v0.4.0 // indirect
 	github.com/klauspost/compress v1.17.8 // indirect
 	github.com/kr/pretty v0.3.0 // indirect
 	github.com/markus-wa/go-unassert v0.1.3 // indirect
@@ -2,6 +2,7 @@ package builders
 
 import (
 	"log/slog"
+	"sort"
 	"strconv"
 
 	dem "github.com/markus-wa/demoinfocs-golang/v4/pkg/demoinfocs"
@@ -123,6 +124,11 @@ func (builder *CS2MatchStatsBuilder) GetTeamEconomyStats(r *state.CS2RoundContex
 func (builder *CS2MatchStatsBuilder) GetPlayerStatsWithRound(r *state.CS2RoundContext) []*cs_entity.CSPlayerStats {
 	participants := builder.Parser.GameState().Participants().All()
 
+	// participants are kept in a map by the parser: sorted for a stable output
+	sort.Slice(participants, func(i, j int) bool {
+		return participants[i].UserID < participants[j].UserID
+	})
+
 	playerStats := make([]*cs_entity.CSPlayerStats, len(participants))
 
 	for i, player := range participants {
@@ -144,7 +150,12 @@ func (builder *CS2MatchStatsBuilder) GetClutchStats(r *state.CS2RoundContext) *c
 			OpponentsStats:  make([]*cs_entity.CSPlayerStats, len(currentClutch.GetOpponents())),
 		}
 
-		for i, opponent := range r.GetClutch().GetOpponents() {
+		opponents := append([]cs2.Player(nil), currentClutch.GetOpponents()...)
+		sort.Slice(opponents, func(i, j int) bool {
+			return opponents[i].UserID < opponents[j].UserID
+		})
+
+		for i, opponent := range opponents {
 			clutchStats.OpponentsStats[i] = builder.StatsFromPlayerWithRound(r.RoundNumber, &opponent)
 		}
 	} else {
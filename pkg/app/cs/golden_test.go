@@ -0,0 +1,309 @@
+package cs2_test
+
+import (
+	"bufio"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	cs2 "github.com/psavelis/team-pro/replay-api/pkg/app/cs"
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	e "github.com/psavelis/team-pro/replay-api/pkg/domain/replay/entities"
+)
+
+// go test ./pkg/app/cs -run TestCS2ReplayAdapter_Golden -update (or make bless-cs2-goldens) rewrites the goldens after an intended
+// parser change, the golden diff is then reviewed along with the change
+var update = flag.Bool("update", false, "bless the golden event outputs of the reference demos")
+
+const (
+	corpusDir = "../../../test/sample_replays/cs2"
+	goldenDir = corpusDir + "/golden"
+
+	// differences reported per demo before giving up
+	maxReportedDiffs = 20
+)
+
+// version 4 (random) uuids are generated on every parse, they're replaced by their order of appearance. Derived ids are kept.
+var randomUUIDPattern = regexp.MustCompile(`[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}`)
+
+var goldenMatchID = uuid.MustParse("00000000-0000-0000-0000-00000000c52d")
+
+type goldenEvent struct {
+	Type     common.EventIDKey                 `json:"type"`
+	Taxonomy *common.NormalizedEvent           `json:"taxonomy"`
+	TickID   common.TickIDType                 `json:"tick_id"`
+	GameTime time.Duration                     `json:"event_time"`
+	Payload  interface{}                       `json:"payload"`
+	Stats    map[common.StatType][]interface{} `json:"stats"`
+}
+
+func TestCS2ReplayAdapter_Golden(t *testing.T) {
+	demos, err := filepath.Glob(filepath.Join(corpusDir, "*.dem"))
+	if err != nil {
+		t.Fatalf("Failed to list reference demos: %v", err)
+	}
+
+	if len(demos) == 0 {
+		t.Skip("no reference demos in " + corpusDir)
+	}
+
+	for _, demo := range demos {
+		name := strings.TrimSuffix(filepath.Base(demo), ".dem")
+		goldenPath := filepath.Join(goldenDir, name+".events.jsonl.gz")
+
+		t.Run(name, func(t *testing.T) {
+			actual := parseGoldenEvents(t, demo)
+
+			if *update {
+				writeGolden(t, goldenPath, actual)
+				t.Logf("blessed %d events in %s", len(actual), goldenPath)
+				return
+			}
+
+			expected, err := readGolden(goldenPath)
+			if os.IsNotExist(err) {
+				t.Fatalf("No golden for %s, bless it with -update", demo)
+			}
+
+			if err != nil {
+				t.Fatalf("Failed to read golden %s: %v", goldenPath, err)
+			}
+
+			diffs := diffGoldenEvents(expected, actual)
+			if len(diffs) > 0 {
+				t.Errorf("Parser output of %s differs from %s (rerun with -update if intended):\n%s", demo, goldenPath, strings.Join(diffs, "\n"))
+			}
+		})
+	}
+}
+
+// parseGoldenEvents parses the demo with a fixed match and owner and returns its events, one normalized JSON document per event.
+func parseGoldenEvents(t *testing.T, demo string) []string {
+	file, err := os.Open(demo)
+	if err != nil {
+		t.Fatalf("Failed to open demo file: %v", err)
+	}
+
+	defer file.Close()
+
+	ctx := common.WithResourceOwner(context.Background(), common.ResourceOwner{
+		TenantID: common.TeamPROTenantID,
+		ClientID: common.TeamPROAppClientID,
+		UserID:   uuid.MustParse("00000000-0000-0000-0000-0000000005e5"),
+	})
+
+	eventsChan := make(chan *e.GameEvent)
+	done := make(chan struct{})
+
+	events := make([]string, 0)
+	normalized := make(map[string]string)
+	marshalErrs := make([]error, 0)
+
+	go func() {
+		defer close(done)
+
+		for ge := range eventsChan {
+			doc, err := json.Marshal(goldenEvent{
+				Type:     ge.Type,
+				Taxonomy: ge.Taxonomy,
+				TickID:   ge.TickID,
+				GameTime: ge.GameTime,
+				Payload:  ge.Payload,
+				Stats:    ge.Stats,
+			})
+
+			if err != nil {
+				marshalErrs = append(marshalErrs, fmt.Errorf("%s at tick %v: %w", ge.Type, ge.TickID, err))
+				continue
+			}
+
+			events = append(events, randomUUIDPattern.ReplaceAllStringFunc(string(doc), func(id string) string {
+				if _, ok := normalized[id]; !ok {
+					normalized[id] = fmt.Sprintf("uuid-%d", len(normalized)+1)
+				}
+
+				return normalized[id]
+			}))
+		}
+	}()
+
+	err = cs2.NewCS2ReplayAdapter().Parse(ctx, goldenMatchID, file, eventsChan)
+
+	close(eventsChan)
+	<-done
+
+	if err != nil {
+		t.Fatalf("Parse returned an error: %v", err)
+	}
+
+	for _, err := range marshalErrs {
+		t.Errorf("Failed to encode event: %v", err)
+	}
+
+	return events
+}
+
+func readGolden(path string) ([]string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+
+	defer file.Close()
+
+	gz, err := gzip.NewReader(file)
+	if err != nil {
+		return nil, err
+	}
+
+	defer gz.Close()
+
+	events := make([]string, 0)
+
+	scanner := bufio.NewScanner(gz)
+	scanner.Buffer(make([]byte, 0, 1024*1024), 64*1024*1024)
+
+	for scanner.Scan() {
+		events = append(events, scanner.Text())
+	}
+
+	return events, scanner.Err()
+}
+
+func writeGolden(t *testing.T, path string, events []string) {
+	err := os.MkdirAll(filepath.Dir(path), 0755)
+	if err != nil {
+		t.Fatalf("Failed to create golden dir: %v", err)
+	}
+
+	file, err := os.Create(path)
+	if err != nil {
+		t.Fatalf("Failed to create golden %s: %v", path, err)
+	}
+
+	defer file.Close()
+
+	gz, _ := gzip.NewWriterLevel(file, gzip.BestCompression)
+
+	for _, event := range events {
+		_, err = gz.Write([]byte(event + "\n"))
+		if err != nil {
+			t.Fatalf("Failed to write golden %s: %v", path, err)
+		}
+	}
+
+	err = gz.Close()
+	if err != nil {
+		t.Fatalf("Failed to write golden %s: %v", path, err)
+	}
+}
+
+// diffGoldenEvents compares the events in order, reporting the JSON paths that changed (ie: "event 12 (ClutchEnd): payload.RoundsStats[0]...").
+func diffGoldenEvents(expected, actual []string) []string {
+	diffs := make([]string, 0)
+
+	if len(expected) != len(actual) {
+		diffs = append(diffs, fmt.Sprintf("event count: expected %d, got %d", len(expected), len(actual)))
+	}
+
+	for i := 0; i < len(expected) || i < len(actual); i++ {
+		if len(diffs) >= maxReportedDiffs {
+			return append(diffs, "...")
+		}
+
+		switch {
+		case i >= len(actual):
+			diffs = append(diffs, fmt.Sprintf("event %d: missing %s", i, truncate(expected[i])))
+			continue
+		case i >= len(expected):
+			diffs = append(diffs, fmt.Sprintf("event %d: unexpected %s", i, truncate(actual[i])))
+			continue
+		case expected[i] == actual[i]:
+			continue
+		}
+
+		var want, got map[string]interface{}
+		json.Unmarshal([]byte(expected[i]), &want)
+		json.Unmarshal([]byte(actual[i]), &got)
+
+		for _, d := range diffJSON("", want, got) {
+			diffs = append(diffs, fmt.Sprintf("event %d (%v): %s", i, want["type"], d))
+			if len(diffs) >= maxReportedDiffs {
+				break
+			}
+		}
+	}
+
+	return diffs
+}
+
+func diffJSON(path string, want, got interface{}) []string {
+	switch w := want.(type) {
+	case map[string]interface{}:
+		g, ok := got.(map[string]interface{})
+		if !ok {
+			break
+		}
+
+		keys := make([]string, 0, len(w)+len(g))
+		for k := range w {
+			keys = append(keys, k)
+		}
+
+		for k := range g {
+			if _, ok := w[k]; !ok {
+				keys = append(keys, k)
+			}
+		}
+
+		sort.Strings(keys)
+
+		diffs := make([]string, 0)
+		for _, k := range keys {
+			diffs = append(diffs, diffJSON(strings.TrimPrefix(path+"."+k, "."), w[k], g[k])...)
+		}
+
+		return diffs
+	case []interface{}:
+		g, ok := got.([]interface{})
+		if !ok || len(w) != len(g) {
+			break
+		}
+
+		diffs := make([]string, 0)
+		for i := range w {
+			diffs = append(diffs, diffJSON(fmt.Sprintf("%s[%d]", path, i), w[i], g[i])...)
+		}
+
+		return diffs
+	}
+
+	if reflect.DeepEqual(want, got) {
+		return nil
+	}
+
+	wantJSON, _ := json.Marshal(want)
+	gotJSON, _ := json.Marshal(got)
+
+	return []string{fmt.Sprintf("%s: expected %s, got %s", path, truncate(string(wantJSON)), truncate(string(gotJSON)))}
+}
+
+func truncate(s string) string {
+	const max = 200
+	if len(s) <= max {
+		return s
+	}
+
+	return s[:max] + "..."
+}
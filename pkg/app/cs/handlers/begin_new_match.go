@@ -21,7 +21,7 @@ func BeginNewMatch(p dem.Parser, matchContext *state.CS2MatchContext, out chan *
 
 		matchContext = matchContext.WithRound(0, gs)
 
-		matchContext.SetHeader(cs_entity.CSReplayFileHeader{
+		header := cs_entity.CSReplayFileHeader{
 			Filestamp:       h.Filestamp,
 			Protocol:        h.Protocol,
 			NetworkProtocol: h.NetworkProtocol,
@@ -31,11 +31,19 @@ func BeginNewMatch(p dem.Parser, matchContext *state.CS2MatchContext, out chan *
 			Length:          h.PlaybackTime,
 			Ticks:           h.PlaybackTicks,
 			Frames:          h.PlaybackFrames,
-		})
+			TickRate:        p.TickRate(),
+			LAN:             gs.Rules().ConVars()["sv_lan"] == "1",
+		}
+
+		header.Tags = cs_entity.DetectReplayTags(header)
+
+		matchContext.SetHeader(header)
 
 		b := builders.NewCSMatchStatsBuilder(p, matchContext).WithRoundsStats(matchContext.RoundContexts)
 
+		// the header (and its tags) is read from the match start, see replay_entity.ReplayTagsOf
 		payload := b.Build()
+		payload.Header = &matchContext.Header
 
 		currentTick := common.TickIDType(gs.IngameTick())
 
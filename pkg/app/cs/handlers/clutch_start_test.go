@@ -233,12 +233,7 @@ func TestClutchStart(t *testing.T) {
 }
 
 func getUserContext() context.Context {
-	tentantContext := context.WithValue(context.Background(), common.TenantIDKey, "test-tenant")
-	clientContext := context.WithValue(tentantContext, common.ClientIDKey, "test-client")
-	groupContext := context.WithValue(clientContext, common.GroupIDKey, "test-group")
-	userContext := context.WithValue(groupContext, common.UserIDKey, "test-user")
-
-	return userContext
+	return common.WithResourceOwner(context.Background(), common.ResourceOwner{TenantID: uuid.New(), ClientID: uuid.New(), GroupID: uuid.New(), UserID: uuid.New()})
 }
 
 func getDefaultTestCaseArgs() testMatrixArgs {
@@ -0,0 +1,102 @@
+package handlers
+
+import (
+	"fmt"
+	"sort"
+
+	dem "github.com/markus-wa/demoinfocs-golang/v4/pkg/demoinfocs"
+	infocs "github.com/markus-wa/demoinfocs-golang/v4/pkg/demoinfocs/common"
+	evt "github.com/markus-wa/demoinfocs-golang/v4/pkg/demoinfocs/events"
+	state "github.com/psavelis/team-pro/replay-api/pkg/app/cs/state"
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	replay_entity "github.com/psavelis/team-pro/replay-api/pkg/domain/replay/entities"
+)
+
+// PlayerScoreboard emits the scoreboard of the match at the end of each round. It's counted from the kills and damage events (not
+// read from the in-game scoreboard, which lags behind the round end): enemies only, warmup excluded, and restarted on MatchStart.
+func PlayerScoreboard(p dem.Parser, matchContext *state.CS2MatchContext, out chan *replay_entity.GameEvent) func(e evt.RoundEnd) {
+	players := make(map[uint64]*replay_entity.PlayerScoreboardEntry)
+
+	player := func(pl *infocs.Player) *replay_entity.PlayerScoreboardEntry {
+		// bots aren't kept (they all share the SteamID64 0)
+		if pl.IsBot {
+			return &replay_entity.PlayerScoreboardEntry{}
+		}
+
+		if players[pl.SteamID64] == nil {
+			players[pl.SteamID64] = &replay_entity.PlayerScoreboardEntry{NetworkPlayerID: fmt.Sprintf("%d", pl.SteamID64)}
+		}
+
+		entry := players[pl.SteamID64]
+		entry.Name = pl.Name
+
+		return entry
+	}
+
+	p.RegisterEventHandler(func(e evt.MatchStart) {
+		players = make(map[uint64]*replay_entity.PlayerScoreboardEntry)
+	})
+
+	p.RegisterEventHandler(func(e evt.Kill) {
+		if p.GameState().IsWarmupPeriod() || e.Victim == nil {
+			return
+		}
+
+		player(e.Victim).Deaths++
+
+		if e.Killer != nil && e.Killer.Team != e.Victim.Team {
+			killer := player(e.Killer)
+			killer.Kills++
+
+			if e.IsHeadshot {
+				killer.Headshots++
+			}
+		}
+
+		if e.Assister != nil && e.Assister.Team != e.Victim.Team {
+			player(e.Assister).Assists++
+		}
+	})
+
+	p.RegisterEventHandler(func(e evt.PlayerHurt) {
+		if p.GameState().IsWarmupPeriod() || e.Attacker == nil || e.Player == nil || e.Attacker.Team == e.Player.Team {
+			return
+		}
+
+		player(e.Attacker).Damage += e.HealthDamageTaken
+	})
+
+	return func(event evt.RoundEnd) {
+		gs := p.GameState()
+
+		// the round played is already counted on its end (see RoundOutcome)
+		roundNumber := gs.TotalRoundsPlayed()
+		if roundNumber <= 0 {
+			return
+		}
+
+		for _, teamState := range []*infocs.TeamState{gs.TeamCounterTerrorists(), gs.TeamTerrorists()} {
+			for _, member := range teamState.Members() {
+				if member.IsConnected {
+					player(member).RoundsPlayed++
+				}
+			}
+		}
+
+		payload := replay_entity.PlayerScoreboardPayload{
+			RoundNumber: roundNumber,
+			MapName:     p.Header().MapName,
+			Players:     make([]replay_entity.PlayerScoreboardEntry, 0, len(players)),
+		}
+
+		for _, entry := range players {
+			payload.Players = append(payload.Players, *entry)
+		}
+
+		sort.Slice(payload.Players, func(i, j int) bool {
+			return payload.Players[i].NetworkPlayerID < payload.Players[j].NetworkPlayerID
+		})
+
+		out <- newRoundTimelineEvent(p, matchContext, common.Event_PlayerScoreboardID, payload)
+	}
+}
@@ -0,0 +1,131 @@
+package handlers
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/golang/geo/r3"
+	dem "github.com/markus-wa/demoinfocs-golang/v4/pkg/demoinfocs"
+	infocs "github.com/markus-wa/demoinfocs-golang/v4/pkg/demoinfocs/common"
+	evt "github.com/markus-wa/demoinfocs-golang/v4/pkg/demoinfocs/events"
+	state "github.com/psavelis/team-pro/replay-api/pkg/app/cs/state"
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	replay_entity "github.com/psavelis/team-pro/replay-api/pkg/domain/replay/entities"
+)
+
+const (
+	// PositionSampleInterval is the interval between position samples, in seconds of game time.
+	PositionSampleInterval = 1
+	// defaultTickRate is used when the demo doesn't inform its tick rate
+	defaultTickRate = 64
+)
+
+var grenadeNames = map[infocs.EquipmentType]string{
+	infocs.EqHE:         "he",
+	infocs.EqFlash:      "flash",
+	infocs.EqSmoke:      "smoke",
+	infocs.EqMolotov:    "fire",
+	infocs.EqIncendiary: "fire",
+}
+
+// PositionSamples emits the position samples of each round at its end: the alive players every PositionSampleInterval (freeze time
+// excluded), the deaths and the grenade detonations. Samples of the warmup are dropped.
+func PositionSamples(p dem.Parser, matchContext *state.CS2MatchContext, out chan *replay_entity.GameEvent) func(e evt.RoundEnd) {
+	samples := make([]replay_entity.PositionSample, 0)
+	lastSampleTick := 0
+
+	sample := func(sampleType replay_entity.PositionSampleType, player *infocs.Player, position r3.Vector) replay_entity.PositionSample {
+		s := replay_entity.PositionSample{
+			Type:   sampleType,
+			TickID: common.TickIDType(p.GameState().IngameTick()),
+			X:      int(math.Round(position.X)),
+			Y:      int(math.Round(position.Y)),
+			Z:      int(math.Round(position.Z)),
+		}
+
+		if player != nil {
+			s.Side = roundSide(player.Team)
+
+			if !player.IsBot {
+				s.NetworkPlayerID = fmt.Sprintf("%d", player.SteamID64)
+			}
+		}
+
+		return s
+	}
+
+	live := func() bool {
+		gs := p.GameState()
+		return !gs.IsWarmupPeriod() && !gs.IsFreezetimePeriod()
+	}
+
+	p.RegisterEventHandler(func(e evt.FrameDone) {
+		tick := p.GameState().IngameTick()
+
+		tickRate := p.TickRate()
+		if tickRate <= 0 {
+			tickRate = defaultTickRate
+		}
+
+		if !live() || tick-lastSampleTick < int(tickRate*PositionSampleInterval) {
+			return
+		}
+
+		lastSampleTick = tick
+
+		// participants are kept in a map: sorted, so samples come out in a stable order
+		players := p.GameState().Participants().Playing()
+		sort.Slice(players, func(i, j int) bool {
+			return players[i].EntityID < players[j].EntityID
+		})
+
+		for _, player := range players {
+			if player.IsAlive() {
+				samples = append(samples, sample(replay_entity.PositionSampleTypePosition, player, player.Position()))
+			}
+		}
+	})
+
+	p.RegisterEventHandler(func(e evt.Kill) {
+		if live() && e.Victim != nil {
+			samples = append(samples, sample(replay_entity.PositionSampleTypeDeath, e.Victim, e.Victim.Position()))
+		}
+	})
+
+	detonation := func(e evt.GrenadeEvent) {
+		name, ok := grenadeNames[e.GrenadeType]
+		if !ok || !live() {
+			return
+		}
+
+		s := sample(replay_entity.PositionSampleTypeGrenade, e.Thrower, e.Position)
+		s.Grenade = name
+
+		samples = append(samples, s)
+	}
+
+	p.RegisterEventHandler(func(e evt.HeExplode) { detonation(e.GrenadeEvent) })
+	p.RegisterEventHandler(func(e evt.FlashExplode) { detonation(e.GrenadeEvent) })
+	p.RegisterEventHandler(func(e evt.SmokeStart) { detonation(e.GrenadeEvent) })
+	p.RegisterEventHandler(func(e evt.FireGrenadeStart) { detonation(e.GrenadeEvent) })
+
+	return func(event evt.RoundEnd) {
+		roundSamples := samples
+		samples = make([]replay_entity.PositionSample, 0)
+
+		// the round played is already counted on its end (see RoundOutcome)
+		roundNumber := p.GameState().TotalRoundsPlayed()
+		if roundNumber <= 0 || len(roundSamples) == 0 {
+			return
+		}
+
+		payload := replay_entity.PositionSamplesPayload{
+			RoundNumber: roundNumber,
+			MapName:     p.Header().MapName,
+			Samples:     roundSamples,
+		}
+
+		out <- newRoundTimelineEvent(p, matchContext, common.Event_PositionSamplesID, payload)
+	}
+}
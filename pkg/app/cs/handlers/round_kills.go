@@ -0,0 +1,106 @@
+package handlers
+
+import (
+	"fmt"
+	"sort"
+
+	dem "github.com/markus-wa/demoinfocs-golang/v4/pkg/demoinfocs"
+	infocs "github.com/markus-wa/demoinfocs-golang/v4/pkg/demoinfocs/common"
+	evt "github.com/markus-wa/demoinfocs-golang/v4/pkg/demoinfocs/events"
+	state "github.com/psavelis/team-pro/replay-api/pkg/app/cs/state"
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	replay_entity "github.com/psavelis/team-pro/replay-api/pkg/domain/replay/entities"
+)
+
+// RoundKills emits the players and the kills of each round at its end, for the highlights of the replay (see HighlightDetectionService).
+// Kills of the warmup are dropped, and the ones of a round restarted by MatchStart.
+func RoundKills(p dem.Parser, matchContext *state.CS2MatchContext, out chan *replay_entity.GameEvent) func(e evt.RoundEnd) {
+	kills := make([]replay_entity.RoundKill, 0)
+
+	networkPlayerID := func(pl *infocs.Player) string {
+		// bots all share the SteamID64 0
+		if pl == nil || pl.IsBot {
+			return ""
+		}
+
+		return fmt.Sprintf("%d", pl.SteamID64)
+	}
+
+	p.RegisterEventHandler(func(e evt.MatchStart) {
+		kills = make([]replay_entity.RoundKill, 0)
+	})
+
+	p.RegisterEventHandler(func(e evt.Kill) {
+		if p.GameState().IsWarmupPeriod() || e.Victim == nil {
+			return
+		}
+
+		kill := replay_entity.RoundKill{
+			TickID:                common.TickIDType(p.GameState().IngameTick()),
+			VictimNetworkPlayerID: networkPlayerID(e.Victim),
+			VictimName:            e.Victim.Name,
+			VictimSide:            roundSide(e.Victim.Team),
+			Headshot:              e.IsHeadshot,
+		}
+
+		if e.Killer != nil {
+			kill.KillerNetworkPlayerID = networkPlayerID(e.Killer)
+			kill.KillerName = e.Killer.Name
+			kill.KillerSide = roundSide(e.Killer.Team)
+		}
+
+		if e.Weapon != nil {
+			kill.Weapon = e.Weapon.String()
+		}
+
+		kills = append(kills, kill)
+	})
+
+	return func(event evt.RoundEnd) {
+		roundKills := kills
+		kills = make([]replay_entity.RoundKill, 0)
+
+		gs := p.GameState()
+
+		// the round played is already counted on its end (see RoundOutcome)
+		roundNumber := gs.TotalRoundsPlayed()
+		if roundNumber <= 0 {
+			return
+		}
+
+		payload := replay_entity.RoundKillsPayload{
+			RoundNumber: roundNumber,
+			Players:     make([]replay_entity.RoundKillsPlayer, 0),
+			Kills:       roundKills,
+		}
+
+		for _, teamState := range []*infocs.TeamState{gs.TeamCounterTerrorists(), gs.TeamTerrorists()} {
+			for _, member := range teamState.Members() {
+				if !member.IsConnected {
+					continue
+				}
+
+				payload.Players = append(payload.Players, replay_entity.RoundKillsPlayer{
+					NetworkPlayerID: networkPlayerID(member),
+					Name:            member.Name,
+					Side:            roundSide(teamState.Team()),
+				})
+			}
+		}
+
+		// members are kept in a map
+		sort.Slice(payload.Players, func(i, j int) bool {
+			if payload.Players[i].Side != payload.Players[j].Side {
+				return payload.Players[i].Side < payload.Players[j].Side
+			}
+
+			if payload.Players[i].NetworkPlayerID != payload.Players[j].NetworkPlayerID {
+				return payload.Players[i].NetworkPlayerID < payload.Players[j].NetworkPlayerID
+			}
+
+			return payload.Players[i].Name < payload.Players[j].Name
+		})
+
+		out <- newRoundTimelineEvent(p, matchContext, common.Event_RoundKillsID, payload)
+	}
+}
@@ -0,0 +1,156 @@
+package handlers
+
+import (
+	"fmt"
+
+	dem "github.com/markus-wa/demoinfocs-golang/v4/pkg/demoinfocs"
+	infocs "github.com/markus-wa/demoinfocs-golang/v4/pkg/demoinfocs/common"
+	evt "github.com/markus-wa/demoinfocs-golang/v4/pkg/demoinfocs/events"
+	state "github.com/psavelis/team-pro/replay-api/pkg/app/cs/state"
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	replay_entity "github.com/psavelis/team-pro/replay-api/pkg/domain/replay/entities"
+)
+
+// round timeline events carry their round number only: they never open a round context (see RoundBegin)
+var roundEndReasons = map[evt.RoundEndReason]string{
+	evt.RoundEndReasonTargetBombed:        "target_bombed",
+	evt.RoundEndReasonBombDefused:         "bomb_defused",
+	evt.RoundEndReasonCTWin:               "ct_win",
+	evt.RoundEndReasonTerroristsWin:       "t_win",
+	evt.RoundEndReasonDraw:                "draw",
+	evt.RoundEndReasonTargetSaved:         "target_saved",
+	evt.RoundEndReasonTerroristsSurrender: "t_surrender",
+	evt.RoundEndReasonCTSurrender:         "ct_surrender",
+}
+
+// RoundBegin is the RoundStart of the timeline (RoundStart opens the round context, and is kept unregistered: the context has to be
+// opened after the buy for its economy stats).
+func RoundBegin(p dem.Parser, matchContext *state.CS2MatchContext, out chan *replay_entity.GameEvent) func(e evt.RoundStart) {
+	return func(event evt.RoundStart) {
+		payload := replay_entity.RoundStartPayload{
+			RoundNumber: p.GameState().TotalRoundsPlayed() + 1,
+		}
+
+		out <- newRoundTimelineEvent(p, matchContext, common.Event_RoundStartID, payload)
+	}
+}
+
+func RoundOutcome(p dem.Parser, matchContext *state.CS2MatchContext, out chan *replay_entity.GameEvent) func(e evt.RoundEnd) {
+	return func(event evt.RoundEnd) {
+		gs := p.GameState()
+
+		reason, ok := roundEndReasons[event.Reason]
+		if !ok {
+			reason = fmt.Sprintf("other_%d", event.Reason)
+		}
+
+		// the round played is already counted on its end (see ClutchEnd)
+		payload := replay_entity.RoundEndPayload{
+			RoundNumber: gs.TotalRoundsPlayed(),
+			Winner:      roundSide(event.Winner),
+			Reason:      reason,
+		}
+
+		out <- newRoundTimelineEvent(p, matchContext, common.Event_RoundEndID, payload)
+	}
+}
+
+// RoundEconomy emits the buy of each side once the freeze time (buy time) is over.
+func RoundEconomy(p dem.Parser, matchContext *state.CS2MatchContext, out chan *replay_entity.GameEvent) func(e evt.RoundFreezetimeEnd) {
+	return func(event evt.RoundFreezetimeEnd) {
+		gs := p.GameState()
+
+		payload := replay_entity.RoundEconomyPayload{
+			RoundNumber: gs.TotalRoundsPlayed() + 1,
+			Teams:       make([]replay_entity.TeamRoundEconomy, 0, 2),
+		}
+
+		for _, teamState := range []*infocs.TeamState{gs.TeamCounterTerrorists(), gs.TeamTerrorists()} {
+			members := make([]*infocs.Player, 0)
+			for _, player := range teamState.Members() {
+				if player.IsConnected {
+					members = append(members, player)
+				}
+			}
+
+			if len(members) == 0 {
+				continue
+			}
+
+			economy := replay_entity.TeamRoundEconomy{
+				Side:    roundSide(teamState.Team()),
+				BuyType: state.DetermineBuyType(members),
+				Players: len(members),
+			}
+
+			for _, player := range members {
+				economy.EquipmentValue += player.EquipmentValueCurrent()
+				economy.Money += player.Money()
+				economy.Spent += player.MoneySpentThisRound()
+			}
+
+			payload.Teams = append(payload.Teams, economy)
+		}
+
+		out <- newRoundTimelineEvent(p, matchContext, common.Event_Economy, payload)
+	}
+}
+
+func BombPlanted(p dem.Parser, matchContext *state.CS2MatchContext, out chan *replay_entity.GameEvent) func(e evt.BombPlanted) {
+	return func(event evt.BombPlanted) {
+		out <- newRoundTimelineEvent(p, matchContext, common.Event_BombPlantedID, bombPayload(p, event.BombEvent))
+	}
+}
+
+func BombDefused(p dem.Parser, matchContext *state.CS2MatchContext, out chan *replay_entity.GameEvent) func(e evt.BombDefused) {
+	return func(event evt.BombDefused) {
+		out <- newRoundTimelineEvent(p, matchContext, common.Event_BombDefusedID, bombPayload(p, event.BombEvent))
+	}
+}
+
+func BombExploded(p dem.Parser, matchContext *state.CS2MatchContext, out chan *replay_entity.GameEvent) func(e evt.BombExplode) {
+	return func(event evt.BombExplode) {
+		out <- newRoundTimelineEvent(p, matchContext, common.Event_BombExplodedID, bombPayload(p, event.BombEvent))
+	}
+}
+
+func bombPayload(p dem.Parser, event evt.BombEvent) replay_entity.BombPayload {
+	payload := replay_entity.BombPayload{
+		RoundNumber: p.GameState().TotalRoundsPlayed() + 1,
+	}
+
+	if event.Site != evt.BomsiteUnknown {
+		payload.Site = string(rune(event.Site))
+	}
+
+	if event.Player != nil {
+		payload.NetworkPlayerID = fmt.Sprintf("%d", event.Player.SteamID64)
+		payload.PlayerName = event.Player.Name
+	}
+
+	return payload
+}
+
+func newRoundTimelineEvent[T any](p dem.Parser, matchContext *state.CS2MatchContext, eventType common.EventIDKey, payload T) *replay_entity.GameEvent {
+	return replay_entity.NewGameEvent(
+		matchContext.MatchID,
+		common.TickIDType(p.GameState().IngameTick()),
+		p.CurrentTime(),
+		eventType,
+		payload,
+		nil,
+		nil,
+		matchContext.ResourceOwner,
+	)
+}
+
+func roundSide(team infocs.Team) replay_entity.RoundSide {
+	switch team {
+	case infocs.TeamCounterTerrorists:
+		return replay_entity.RoundSideCT
+	case infocs.TeamTerrorists:
+		return replay_entity.RoundSideT
+	default:
+		return ""
+	}
+}
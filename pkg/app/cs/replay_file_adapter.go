@@ -7,8 +7,10 @@ import (
 
 	"github.com/google/uuid"
 	dem "github.com/markus-wa/demoinfocs-golang/v4/pkg/demoinfocs"
+	evt "github.com/markus-wa/demoinfocs-golang/v4/pkg/demoinfocs/events"
 	handlers "github.com/psavelis/team-pro/replay-api/pkg/app/cs/handlers"
 	state "github.com/psavelis/team-pro/replay-api/pkg/app/cs/state"
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
 	e "github.com/psavelis/team-pro/replay-api/pkg/domain/replay/entities"
 )
 
@@ -24,9 +26,40 @@ func registerParsers(p dem.Parser, matchContext *state.CS2MatchContext, eventsCh
 	// p.RegisterEventHandler(handlers.WeaponFire(p, matchContext, eventsChan))
 	// p.RegisterEventHandler(handlers.HitEvent(p, matchContext, eventsChan))
 	p.RegisterEventHandler(handlers.RoundMVP(p, matchContext, eventsChan))
-	p.RegisterEventHandler(handlers.ClutchStart(p, matchContext, eventsChan))
-	p.RegisterEventHandler(handlers.ClutchProgress(p, matchContext, eventsChan))
-	p.RegisterEventHandler(handlers.ClutchEnd(p, matchContext, eventsChan))
+
+	// the dispatcher doesn't keep the registration order of handlers of the same event: kills are handled by a single handler so a
+	// clutch always starts before its progress is counted
+	clutchStart := handlers.ClutchStart(p, matchContext, eventsChan)
+	clutchProgress := handlers.ClutchProgress(p, matchContext, eventsChan)
+	p.RegisterEventHandler(func(event evt.Kill) {
+		clutchStart(event)
+		clutchProgress(event)
+	})
+
+	// round timeline
+	p.RegisterEventHandler(handlers.RoundBegin(p, matchContext, eventsChan))
+	p.RegisterEventHandler(handlers.RoundEconomy(p, matchContext, eventsChan))
+	p.RegisterEventHandler(handlers.BombPlanted(p, matchContext, eventsChan))
+	p.RegisterEventHandler(handlers.BombDefused(p, matchContext, eventsChan))
+	p.RegisterEventHandler(handlers.BombExploded(p, matchContext, eventsChan))
+
+	// same for the round end: clutch, round timeline, player stats, heatmaps and highlights, in this order
+	clutchEnd := handlers.ClutchEnd(p, matchContext, eventsChan)
+	roundOutcome := handlers.RoundOutcome(p, matchContext, eventsChan)
+	playerScoreboard := handlers.PlayerScoreboard(p, matchContext, eventsChan)
+	positionSamples := handlers.PositionSamples(p, matchContext, eventsChan)
+	roundKills := handlers.RoundKills(p, matchContext, eventsChan)
+	p.RegisterEventHandler(func(event evt.RoundEnd) {
+		err := clutchEnd(event)
+		if err != nil {
+			slog.Error("unable to handle clutch end", "err", err)
+		}
+
+		roundOutcome(event)
+		playerScoreboard(event)
+		positionSamples(event)
+		roundKills(event)
+	})
 	// p.RegisterEventHandler(handlers.EconomyEvent(p, matchContext, eventsChan))
 	// p.RegisterEventHandler(handlers.GenericGameEvent(p, matchContext, eventsChan))
 }
@@ -37,10 +70,25 @@ func (c *CS2ReplayAdapter) Parse(ctx context.Context, matchID uuid.UUID, content
 	slog.Info("Parsing demo file at %s", "CS2ReplayAdapter.GetEvents", matchID)
 	defer parser.Close()
 
-	registerParsers(parser, matchContext, eventsChan)
+	// the handlers emit the CS events, normalized on their way out
+	csEvents := make(chan *e.GameEvent)
+	normalized := make(chan struct{})
+
+	go func() {
+		defer close(normalized)
+
+		for event := range csEvents {
+			eventsChan <- normalize(event)
+		}
+	}()
+
+	registerParsers(parser, matchContext, csEvents)
 
 	err := parser.ParseToEnd()
 
+	close(csEvents)
+	<-normalized
+
 	if err != nil {
 		slog.ErrorContext(ctx, "Failed to parse demo: %v", "err", err)
 		return err
@@ -48,3 +96,14 @@ func (c *CS2ReplayAdapter) Parse(ctx context.Context, matchID uuid.UUID, content
 
 	return nil
 }
+
+// normalize tags the event with its normalized type (see common.CSEventTaxonomy).
+func normalize(event *e.GameEvent) *e.GameEvent {
+	if event.GameID == "" {
+		event.GameID = common.CS2_GAME_ID
+	}
+
+	event.Taxonomy = common.CS2.Taxonomy.Normalize(event.Type)
+
+	return event
+}
@@ -63,9 +63,7 @@ func TestCS2ReplayAdapter_GetEvents(t *testing.T) {
 		}
 	}()
 
-	ctx := context.WithValue(context.Background(), common.TenantIDKey, common.TeamPROTenantID)
-	ctx = context.WithValue(ctx, common.ClientIDKey, common.TeamPROAppClientID)
-	ctx = context.WithValue(ctx, common.UserIDKey, uuid.New())
+	ctx := common.WithResourceOwner(context.Background(), common.ResourceOwner{TenantID: common.TeamPROTenantID, ClientID: common.TeamPROAppClientID, UserID: uuid.New()})
 
 	match := &e.Match{
 		ID:            uuid.New(),
@@ -0,0 +1,135 @@
+package common
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrInvalidAggregate is wrapped by the errors of aggregations rejected by validation (ie: grouping by a field not queryable).
+var ErrInvalidAggregate = errors.New("invalid aggregation")
+
+const (
+	// MaxAggregateGroupBy caps the fields a single aggregation may group by.
+	MaxAggregateGroupBy = 3
+	// MaxAggregateMetrics caps the metrics computed per group.
+	MaxAggregateMetrics = 10
+)
+
+type AggregateOperator string
+
+const (
+	CountAggregateOperator AggregateOperator = "count" // documents of the group (no field)
+	SumAggregateOperator   AggregateOperator = "sum"
+	AvgAggregateOperator   AggregateOperator = "avg"
+	MinAggregateOperator   AggregateOperator = "min"
+	MaxAggregateOperator   AggregateOperator = "max"
+)
+
+// AggregateOperators lists every operator supported by AggregateMetric.
+var AggregateOperators = []AggregateOperator{
+	CountAggregateOperator,
+	SumAggregateOperator,
+	AvgAggregateOperator,
+	MinAggregateOperator,
+	MaxAggregateOperator,
+}
+
+// AggregateMetric is a value computed over the documents of each group, returned under Name.
+type AggregateMetric struct {
+	Name     string            `json:"name"`
+	Operator AggregateOperator `json:"op"`
+	Field    string            `json:"field,omitempty"` // queryable field, not informed for count
+}
+
+// AggregateGroup is a group of the documents matching a search: its value of each group by field, and its metrics.
+type AggregateGroup struct {
+	Key     map[string]interface{} `json:"key"`
+	Metrics map[string]interface{} `json:"metrics"`
+}
+
+// Aggregatable is implemented by readers able to compute metrics over the search matches grouped by field (in the store, without
+// loading the matches). Groups are restricted by the search filters and tenancy, like its results.
+type Aggregatable interface {
+	Aggregate(ctx context.Context, s Search, groupBy []string, metrics []AggregateMetric) ([]AggregateGroup, error)
+}
+
+// ParseAggregateMetrics parses a metrics query parameter: comma separated operators, followed by their field when required (ie:
+// "count,avg:Time"). Each metric is named after its token.
+func ParseAggregateMetrics(param string) ([]AggregateMetric, error) {
+	if strings.TrimSpace(param) == "" {
+		return nil, nil
+	}
+
+	var metrics []AggregateMetric
+	for _, token := range strings.Split(param, ",") {
+		token = strings.TrimSpace(token)
+
+		op, field, _ := strings.Cut(token, ":")
+		if op == "" {
+			return nil, fmt.Errorf("invalid metrics '%s': empty operator", param)
+		}
+
+		metrics = append(metrics, AggregateMetric{Name: token, Operator: AggregateOperator(op), Field: field})
+	}
+
+	return metrics, nil
+}
+
+func ValidateAggregate(groupBy []string, metrics []AggregateMetric, queryableFields map[string]bool) error {
+	if len(groupBy) > MaxAggregateGroupBy {
+		return fmt.Errorf("at most %d group by fields can be requested (got %d)", MaxAggregateGroupBy, len(groupBy))
+	}
+
+	if len(metrics) == 0 || len(metrics) > MaxAggregateMetrics {
+		return fmt.Errorf("between 1 and %d metrics must be requested (got %d)", MaxAggregateMetrics, len(metrics))
+	}
+
+	for _, field := range groupBy {
+		if err := validateAggregateField(field, queryableFields); err != nil {
+			return fmt.Errorf("grouping by %v", err)
+		}
+	}
+
+	names := make(map[string]bool, len(metrics))
+	for _, metric := range metrics {
+		if metric.Name == "" {
+			return fmt.Errorf("metric name is required")
+		}
+
+		if names[metric.Name] {
+			return fmt.Errorf("metric '%s' is requested more than once", metric.Name)
+		}
+
+		names[metric.Name] = true
+
+		switch metric.Operator {
+		case CountAggregateOperator:
+			if metric.Field != "" {
+				return fmt.Errorf("metric '%s': count doesn't take a field", metric.Name)
+			}
+		case SumAggregateOperator, AvgAggregateOperator, MinAggregateOperator, MaxAggregateOperator:
+			if err := validateAggregateField(metric.Field, queryableFields); err != nil {
+				return fmt.Errorf("metric '%s' on %v", metric.Name, err)
+			}
+		default:
+			return fmt.Errorf("invalid metric operator '%s' (expected one of %v)", metric.Operator, AggregateOperators)
+		}
+	}
+
+	return nil
+}
+
+func validateAggregateField(field string, queryableFields map[string]bool) error {
+	if strings.HasSuffix(field, ".*") {
+		return fmt.Errorf("wildcard field '%s' is not permitted", field)
+	}
+
+	allowed, exists := queryableFields[field]
+	if !exists || !allowed {
+		return fmt.Errorf("field '%s' is not permitted", field)
+	}
+
+	return nil
+}
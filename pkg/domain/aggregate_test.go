@@ -0,0 +1,102 @@
+package common_test
+
+import (
+	"testing"
+
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateAggregate(t *testing.T) {
+	queryableFields := map[string]bool{"Type": true, "Time": true, "PlayerName": true, "MatchID": true, "Secret": false}
+
+	count := common.AggregateMetric{Name: "count", Operator: common.CountAggregateOperator}
+
+	tests := []struct {
+		name          string
+		groupBy       []string
+		metrics       []common.AggregateMetric
+		expectedError string
+	}{
+		{
+			name:    "Count By Field",
+			groupBy: []string{"Type"},
+			metrics: []common.AggregateMetric{count},
+		},
+		{
+			name:    "Field Metrics Without Group By",
+			metrics: []common.AggregateMetric{count, {Name: "avg", Operator: common.AvgAggregateOperator, Field: "Time"}},
+		},
+		{
+			name:          "No Metrics",
+			groupBy:       []string{"Type"},
+			expectedError: "between 1 and 10 metrics must be requested (got 0)",
+		},
+		{
+			name:          "Too Many Group By Fields",
+			groupBy:       []string{"Type", "Time", "PlayerName", "MatchID"},
+			metrics:       []common.AggregateMetric{count},
+			expectedError: "at most 3 group by fields can be requested (got 4)",
+		},
+		{
+			name:          "Group By Not Queryable",
+			groupBy:       []string{"Secret"},
+			metrics:       []common.AggregateMetric{count},
+			expectedError: "grouping by field 'Secret' is not permitted",
+		},
+		{
+			name:          "Group By Wildcard",
+			groupBy:       []string{"Type.*"},
+			metrics:       []common.AggregateMetric{count},
+			expectedError: "grouping by wildcard field 'Type.*' is not permitted",
+		},
+		{
+			name:          "Metric Field Not Queryable",
+			metrics:       []common.AggregateMetric{{Name: "max", Operator: common.MaxAggregateOperator, Field: "Secret"}},
+			expectedError: "metric 'max' on field 'Secret' is not permitted",
+		},
+		{
+			name:          "Metric Without Field",
+			metrics:       []common.AggregateMetric{{Name: "sum", Operator: common.SumAggregateOperator}},
+			expectedError: "metric 'sum' on field '' is not permitted",
+		},
+		{
+			name:          "Count With Field",
+			metrics:       []common.AggregateMetric{{Name: "count", Operator: common.CountAggregateOperator, Field: "Time"}},
+			expectedError: "metric 'count': count doesn't take a field",
+		},
+		{
+			name:          "Unknown Operator",
+			metrics:       []common.AggregateMetric{{Name: "p99", Operator: "p99", Field: "Time"}},
+			expectedError: "invalid metric operator 'p99' (expected one of [count sum avg min max])",
+		},
+		{
+			name:          "Repeated Metric",
+			metrics:       []common.AggregateMetric{count, count},
+			expectedError: "metric 'count' is requested more than once",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := common.ValidateAggregate(tt.groupBy, tt.metrics, queryableFields)
+			if tt.expectedError != "" {
+				assert.EqualError(t, err, tt.expectedError)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestParseAggregateMetrics(t *testing.T) {
+	metrics, err := common.ParseAggregateMetrics("count, avg:Time")
+	assert.NoError(t, err)
+	assert.Equal(t, []common.AggregateMetric{
+		{Name: "count", Operator: common.CountAggregateOperator},
+		{Name: "avg:Time", Operator: common.AvgAggregateOperator, Field: "Time"},
+	}, metrics)
+
+	_, err = common.ParseAggregateMetrics("count,:Time")
+	assert.Error(t, err)
+}
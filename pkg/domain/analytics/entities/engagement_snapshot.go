@@ -0,0 +1,40 @@
+package analytics_entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+)
+
+// EngagementSnapshot is the daily analytics read model consumed by the internal dashboards.
+// Activity is currently derived from replay uploads, the only user-driven signal persisted by the API.
+type EngagementSnapshot struct {
+	ID                uuid.UUID            `json:"id" bson:"_id"`
+	Day               time.Time            `json:"day" bson:"day"`
+	DailyActiveUsers  int                  `json:"dau" bson:"dau"`
+	WeeklyActiveUsers int                  `json:"wau" bson:"wau"`
+	UploadRetention   []RetentionCohort    `json:"upload_retention" bson:"upload_retention"`
+	ResourceOwner     common.ResourceOwner `json:"resource_owner" bson:"resource_owner"`
+	CreatedAt         time.Time            `json:"created_at" bson:"created_at"`
+	UpdatedAt         time.Time            `json:"updated_at" bson:"updated_at"`
+}
+
+func (e EngagementSnapshot) GetID() uuid.UUID {
+	return e.ID
+}
+
+// RetentionCohort groups users by the week of their first replay upload.
+// RetainedByWeek[n] is the number of users in the cohort that uploaded again n weeks after CohortStart.
+type RetentionCohort struct {
+	CohortStart    time.Time `json:"cohort_start" bson:"cohort_start"`
+	Size           int       `json:"size" bson:"size"`
+	RetainedByWeek []int     `json:"retained_by_week" bson:"retained_by_week"`
+}
+
+// UserUploadActivity lists the (UTC, truncated) days in which a user uploaded at least one replay file.
+type UserUploadActivity struct {
+	UserID      uuid.UUID   `json:"user_id" bson:"_id"`
+	FirstUpload time.Time   `json:"first_upload" bson:"first_upload"`
+	UploadDays  []time.Time `json:"upload_days" bson:"upload_days"`
+}
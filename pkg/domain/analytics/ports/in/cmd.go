@@ -0,0 +1,13 @@
+package analytics_in
+
+import (
+	"context"
+	"time"
+
+	analytics_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/analytics/entities"
+)
+
+// ComputeEngagementSnapshotCommand computes (or recomputes) the engagement snapshot of the given day.
+type ComputeEngagementSnapshotCommand interface {
+	Exec(ctx context.Context, day time.Time) (*analytics_entities.EngagementSnapshot, error)
+}
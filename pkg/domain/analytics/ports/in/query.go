@@ -0,0 +1,10 @@
+package analytics_in
+
+import (
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	analytics_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/analytics/entities"
+)
+
+type EngagementReader interface {
+	common.Searchable[analytics_entities.EngagementSnapshot]
+}
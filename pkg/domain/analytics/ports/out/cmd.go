@@ -0,0 +1,12 @@
+package analytics_out
+
+import (
+	"context"
+
+	analytics_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/analytics/entities"
+)
+
+type EngagementSnapshotWriter interface {
+	// Save creates or replaces the snapshot of the same tenant and day.
+	Save(ctx context.Context, snapshot *analytics_entities.EngagementSnapshot) (*analytics_entities.EngagementSnapshot, error)
+}
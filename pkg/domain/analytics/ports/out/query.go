@@ -0,0 +1,18 @@
+package analytics_out
+
+import (
+	"context"
+	"time"
+
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	analytics_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/analytics/entities"
+)
+
+type EngagementSnapshotReader interface {
+	common.Searchable[analytics_entities.EngagementSnapshot]
+}
+
+type UploadActivityReader interface {
+	// GetUploadActivity returns the upload days of every user (of the tenant in context) with at least one upload since the given time.
+	GetUploadActivity(ctx context.Context, since time.Time) ([]analytics_entities.UserUploadActivity, error)
+}
@@ -0,0 +1,44 @@
+package analytics_services
+
+import (
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	analytics_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/analytics/entities"
+	analytics_in "github.com/psavelis/team-pro/replay-api/pkg/domain/analytics/ports/in"
+	analytics_out "github.com/psavelis/team-pro/replay-api/pkg/domain/analytics/ports/out"
+)
+
+type EngagementQueryService struct {
+	common.BaseQueryService[analytics_entities.EngagementSnapshot]
+}
+
+func NewEngagementQueryService(snapshotReader analytics_out.EngagementSnapshotReader) analytics_in.EngagementReader {
+	queryableFields := map[string]bool{
+		"ID":                true,
+		"Day":               true,
+		"DailyActiveUsers":  true,
+		"WeeklyActiveUsers": true,
+		"UploadRetention":   common.DENY,
+		"ResourceOwner":     common.DENY,
+		"CreatedAt":         true,
+		"UpdatedAt":         true,
+	}
+
+	readableFields := map[string]bool{
+		"ID":                true,
+		"Day":               true,
+		"DailyActiveUsers":  true,
+		"WeeklyActiveUsers": true,
+		"UploadRetention":   true,
+		"ResourceOwner":     common.DENY,
+		"CreatedAt":         true,
+		"UpdatedAt":         true,
+	}
+
+	return &common.BaseQueryService[analytics_entities.EngagementSnapshot]{
+		Reader:          snapshotReader.(common.Searchable[analytics_entities.EngagementSnapshot]),
+		QueryableFields: queryableFields,
+		ReadableFields:  readableFields,
+		MaxPageSize:     100,
+		Audience:        common.ClientApplicationAudienceIDKey,
+	}
+}
@@ -0,0 +1,134 @@
+package analytics_use_cases
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	analytics_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/analytics/entities"
+	analytics_in "github.com/psavelis/team-pro/replay-api/pkg/domain/analytics/ports/in"
+	analytics_out "github.com/psavelis/team-pro/replay-api/pkg/domain/analytics/ports/out"
+)
+
+const (
+	Day            = 24 * time.Hour
+	Week           = 7 * Day
+	RetentionWeeks = 8
+)
+
+type ComputeEngagementSnapshotUseCase struct {
+	ActivityReader analytics_out.UploadActivityReader
+	SnapshotWriter analytics_out.EngagementSnapshotWriter
+}
+
+func NewComputeEngagementSnapshotUseCase(activityReader analytics_out.UploadActivityReader, snapshotWriter analytics_out.EngagementSnapshotWriter) analytics_in.ComputeEngagementSnapshotCommand {
+	return &ComputeEngagementSnapshotUseCase{
+		ActivityReader: activityReader,
+		SnapshotWriter: snapshotWriter,
+	}
+}
+
+func (usecase *ComputeEngagementSnapshotUseCase) Exec(ctx context.Context, day time.Time) (*analytics_entities.EngagementSnapshot, error) {
+	day = TruncateDay(day)
+	since := WeekStart(day).Add(-Week * (RetentionWeeks - 1))
+
+	activity, err := usecase.ActivityReader.GetUploadActivity(ctx, since)
+	if err != nil {
+		slog.ErrorContext(ctx, "unable to read upload activity for engagement snapshot", "err", err, "day", day, "since", since)
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+
+	snapshot := &analytics_entities.EngagementSnapshot{
+		ID:                uuid.New(),
+		Day:               day,
+		DailyActiveUsers:  CountActiveUsers(activity, day, day),
+		WeeklyActiveUsers: CountActiveUsers(activity, day.Add(-6*Day), day),
+		UploadRetention:   BuildRetentionCohorts(activity, since, day),
+		ResourceOwner:     common.GetResourceOwner(ctx),
+		CreatedAt:         now,
+		UpdatedAt:         now,
+	}
+
+	snapshot, err = usecase.SnapshotWriter.Save(ctx, snapshot)
+	if err != nil {
+		slog.ErrorContext(ctx, "unable to save engagement snapshot", "err", err, "day", day)
+		return nil, err
+	}
+
+	return snapshot, nil
+}
+
+// CountActiveUsers counts users with at least one upload between from and to (both inclusive, day granularity).
+func CountActiveUsers(activity []analytics_entities.UserUploadActivity, from time.Time, to time.Time) int {
+	count := 0
+
+	for _, user := range activity {
+		for _, d := range user.UploadDays {
+			d = TruncateDay(d)
+			if !d.Before(from) && !d.After(to) {
+				count++
+				break
+			}
+		}
+	}
+
+	return count
+}
+
+// BuildRetentionCohorts groups users by the week of their first upload (starting at since) and counts, for each
+// following week up to day, how many of them uploaded again.
+func BuildRetentionCohorts(activity []analytics_entities.UserUploadActivity, since time.Time, day time.Time) []analytics_entities.RetentionCohort {
+	since = WeekStart(since)
+	lastWeek := WeekStart(day)
+
+	cohorts := make([]analytics_entities.RetentionCohort, 0, RetentionWeeks)
+	for start := since; !start.After(lastWeek); start = start.Add(Week) {
+		elapsed := int(lastWeek.Sub(start)/Week) + 1
+		cohorts = append(cohorts, analytics_entities.RetentionCohort{
+			CohortStart:    start,
+			RetainedByWeek: make([]int, elapsed),
+		})
+	}
+
+	for _, user := range activity {
+		cohortStart := WeekStart(user.FirstUpload)
+		if cohortStart.Before(since) || cohortStart.After(lastWeek) {
+			continue
+		}
+
+		cohort := &cohorts[int(cohortStart.Sub(since)/Week)]
+		cohort.Size++
+
+		seen := make(map[int]bool)
+		for _, d := range user.UploadDays {
+			if TruncateDay(d).After(day) {
+				continue
+			}
+
+			offset := int(WeekStart(d).Sub(cohortStart) / Week)
+			if offset < 0 || offset >= len(cohort.RetainedByWeek) || seen[offset] {
+				continue
+			}
+
+			seen[offset] = true
+			cohort.RetainedByWeek[offset]++
+		}
+	}
+
+	return cohorts
+}
+
+func TruncateDay(t time.Time) time.Time {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// WeekStart returns the monday (UTC) of the week of t.
+func WeekStart(t time.Time) time.Time {
+	t = TruncateDay(t)
+	return t.Add(-Day * time.Duration((int(t.Weekday())+6)%7))
+}
@@ -0,0 +1,149 @@
+package analytics_use_cases_test
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	analytics_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/analytics/entities"
+	analytics_use_cases "github.com/psavelis/team-pro/replay-api/pkg/domain/analytics/use_cases"
+)
+
+type mockUploadActivityReader struct {
+	activity []analytics_entities.UserUploadActivity
+	since    time.Time
+}
+
+func (m *mockUploadActivityReader) GetUploadActivity(ctx context.Context, since time.Time) ([]analytics_entities.UserUploadActivity, error) {
+	m.since = since
+	return m.activity, nil
+}
+
+type mockEngagementSnapshotWriter struct {
+	saved *analytics_entities.EngagementSnapshot
+}
+
+func (m *mockEngagementSnapshotWriter) Save(ctx context.Context, snapshot *analytics_entities.EngagementSnapshot) (*analytics_entities.EngagementSnapshot, error) {
+	m.saved = snapshot
+	return snapshot, nil
+}
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+func TestWeekStart(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    time.Time
+		expected time.Time
+	}{
+		{"Monday", date(2024, time.June, 3), date(2024, time.June, 3)},
+		{"Wednesday afternoon", time.Date(2024, time.June, 5, 15, 30, 0, 0, time.UTC), date(2024, time.June, 3)},
+		{"Sunday", date(2024, time.June, 9), date(2024, time.June, 3)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := analytics_use_cases.WeekStart(tt.input); !got.Equal(tt.expected) {
+				t.Errorf("WeekStart(%v) = %v, expected %v", tt.input, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestBuildRetentionCohorts(t *testing.T) {
+	since := date(2024, time.June, 3)
+	day := date(2024, time.June, 19)
+
+	activity := []analytics_entities.UserUploadActivity{
+		{
+			UserID:      uuid.New(),
+			FirstUpload: date(2024, time.June, 4),
+			UploadDays:  []time.Time{date(2024, time.June, 4), date(2024, time.June, 5), date(2024, time.June, 18)},
+		},
+		{
+			UserID:      uuid.New(),
+			FirstUpload: date(2024, time.June, 6),
+			UploadDays:  []time.Time{date(2024, time.June, 6), date(2024, time.June, 12)},
+		},
+		{
+			UserID:      uuid.New(),
+			FirstUpload: date(2024, time.June, 11),
+			UploadDays:  []time.Time{date(2024, time.June, 11)},
+		},
+		{
+			// belongs to an older cohort
+			UserID:      uuid.New(),
+			FirstUpload: date(2024, time.January, 1),
+			UploadDays:  []time.Time{date(2024, time.June, 19)},
+		},
+	}
+
+	expected := []analytics_entities.RetentionCohort{
+		{CohortStart: date(2024, time.June, 3), Size: 2, RetainedByWeek: []int{2, 1, 1}},
+		{CohortStart: date(2024, time.June, 10), Size: 1, RetainedByWeek: []int{1, 0}},
+		{CohortStart: date(2024, time.June, 17), Size: 0, RetainedByWeek: []int{0}},
+	}
+
+	got := analytics_use_cases.BuildRetentionCohorts(activity, since, day)
+
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("BuildRetentionCohorts() = %+v, expected %+v", got, expected)
+	}
+}
+
+func TestComputeEngagementSnapshotUseCase_Exec(t *testing.T) {
+	day := date(2024, time.June, 19)
+
+	reader := &mockUploadActivityReader{
+		activity: []analytics_entities.UserUploadActivity{
+			{UserID: uuid.New(), FirstUpload: date(2024, time.June, 19), UploadDays: []time.Time{date(2024, time.June, 19)}},
+			{UserID: uuid.New(), FirstUpload: date(2024, time.June, 14), UploadDays: []time.Time{date(2024, time.June, 14)}},
+			{UserID: uuid.New(), FirstUpload: date(2024, time.June, 1), UploadDays: []time.Time{date(2024, time.June, 1)}},
+		},
+	}
+
+	writer := &mockEngagementSnapshotWriter{}
+
+	ctx := common.WithResourceOwner(context.Background(), common.ResourceOwner{TenantID: common.TeamPROTenantID, ClientID: common.TeamPROAppClientID})
+
+	usecase := analytics_use_cases.NewComputeEngagementSnapshotUseCase(reader, writer)
+
+	snapshot, err := usecase.Exec(ctx, time.Date(2024, time.June, 19, 22, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if snapshot != writer.saved {
+		t.Errorf("expected snapshot to be saved")
+	}
+
+	if !snapshot.Day.Equal(day) {
+		t.Errorf("expected day %v, got %v", day, snapshot.Day)
+	}
+
+	if snapshot.DailyActiveUsers != 1 {
+		t.Errorf("expected DAU 1, got %d", snapshot.DailyActiveUsers)
+	}
+
+	if snapshot.WeeklyActiveUsers != 2 {
+		t.Errorf("expected WAU 2, got %d", snapshot.WeeklyActiveUsers)
+	}
+
+	if len(snapshot.UploadRetention) != analytics_use_cases.RetentionWeeks {
+		t.Errorf("expected %d cohorts, got %d", analytics_use_cases.RetentionWeeks, len(snapshot.UploadRetention))
+	}
+
+	expectedSince := date(2024, time.April, 29)
+	if !reader.since.Equal(expectedSince) {
+		t.Errorf("expected activity since %v, got %v", expectedSince, reader.since)
+	}
+
+	if snapshot.ResourceOwner.TenantID != common.TeamPROTenantID || snapshot.ResourceOwner.ClientID != common.TeamPROAppClientID {
+		t.Errorf("unexpected resource owner %+v", snapshot.ResourceOwner)
+	}
+}
@@ -0,0 +1,120 @@
+package badge_entities
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+)
+
+// BadgeTrigger is the occurrence a badge is evaluated on, it tells the facts available to its rule.
+type BadgeTrigger string
+
+const (
+	BadgeTriggerReplayProcessed BadgeTrigger = "replay_processed" // the game events of a replay (each player of the replay)
+	BadgeTriggerMatchCompleted  BadgeTrigger = "match_completed"  // the result of a matchmaking match (each player of its lobby)
+)
+
+// facts of the replay_processed trigger: the stats of the player in the replay, and their stats over every replay processed so far
+const (
+	FactMatchKills     = "match.kills"
+	FactMatchDeaths    = "match.deaths"
+	FactMatchAssists   = "match.assists"
+	FactMatchHeadshots = "match.headshots"
+	FactMatchDamage    = "match.damage"
+	FactMatchADR       = "match.adr"
+	FactMatchRating    = "match.rating"
+	FactMatchMVP       = "match.mvp"  // 1 when MVP of the match
+	FactMatchAces      = "match.aces" // rounds with 5 kills
+	FactMatchRounds    = "match.rounds"
+
+	FactCareerMatches     = "career.matches"
+	FactCareerKills       = "career.kills"
+	FactCareerDeaths      = "career.deaths"
+	FactCareerAssists     = "career.assists"
+	FactCareerHeadshots   = "career.headshots"
+	FactCareerDamage      = "career.damage"
+	FactCareerClutchesWon = "career.clutches_won"
+	FactCareerMVPs        = "career.mvps"
+)
+
+// facts of the match_completed trigger: the result of the team of the player, and their rating after the match
+const (
+	FactMatchWon   = "match.won" // 1 when the team of the player won, 0 on a draw or loss
+	FactMatchScore = "match.score"
+
+	FactRating                    = "rating.rating"
+	FactRatingMatchesPlayed       = "rating.matches_played"
+	FactRatingSeasonMatchesPlayed = "rating.season_matches_played"
+)
+
+// BadgeFacts lists the facts each trigger provides to the rules of its badges.
+var BadgeFacts = map[BadgeTrigger][]string{
+	BadgeTriggerReplayProcessed: {
+		FactMatchKills, FactMatchDeaths, FactMatchAssists, FactMatchHeadshots, FactMatchDamage, FactMatchADR, FactMatchRating, FactMatchMVP, FactMatchAces, FactMatchRounds,
+		FactCareerMatches, FactCareerKills, FactCareerDeaths, FactCareerAssists, FactCareerHeadshots, FactCareerDamage, FactCareerClutchesWon, FactCareerMVPs,
+	},
+	BadgeTriggerMatchCompleted: {
+		FactMatchWon, FactMatchScore,
+		FactRating, FactRatingMatchesPlayed, FactRatingSeasonMatchesPlayed,
+	},
+}
+
+var badgeKeyPattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]{1,63}$`)
+
+// Badge is the definition of a badge of a game: awarded once to each player whose facts satisfy its rule (ie: "career.headshots >= 100")
+// when its trigger occurs.
+type Badge struct {
+	ID            uuid.UUID            `json:"id" bson:"_id"`
+	GameID        common.GameIDKey     `json:"game_id" bson:"game_id"`
+	Key           string               `json:"key" bson:"key"` // unique per game, ie: "headshots-100"
+	Name          string               `json:"name" bson:"name"`
+	Description   string               `json:"description" bson:"description"`
+	ImageURL      string               `json:"image_url" bson:"image_url"`
+	Trigger       BadgeTrigger         `json:"trigger" bson:"trigger"`
+	Rule          string               `json:"rule" bson:"rule"`
+	Enabled       bool                 `json:"enabled" bson:"enabled"`
+	ResourceOwner common.ResourceOwner `json:"resource_owner" bson:"resource_owner"`
+	CreatedAt     time.Time            `json:"created_at" bson:"created_at"`
+	UpdatedAt     time.Time            `json:"updated_at" bson:"updated_at"`
+}
+
+func (b Badge) GetID() uuid.UUID {
+	return b.ID
+}
+
+// Validate checks the key, the trigger and the rule of the badge, whose conditions may only read the facts of its trigger.
+func (b Badge) Validate() error {
+	if !badgeKeyPattern.MatchString(b.Key) {
+		return fmt.Errorf("key %q must be 2 to 64 lowercase letters, digits, '-' or '_'", b.Key)
+	}
+
+	if b.Name == "" {
+		return fmt.Errorf("name is required")
+	}
+
+	if b.GameID == "" {
+		return fmt.Errorf("game_id is required")
+	}
+
+	facts, ok := BadgeFacts[b.Trigger]
+	if !ok {
+		return fmt.Errorf("unknown trigger %q", b.Trigger)
+	}
+
+	rule, err := ParseBadgeRule(b.Rule)
+	if err != nil {
+		return err
+	}
+
+	for _, c := range rule.Conditions {
+		if !slices.Contains(facts, c.Fact) {
+			return fmt.Errorf("fact %q isn't available to the %s trigger", c.Fact, b.Trigger)
+		}
+	}
+
+	return nil
+}
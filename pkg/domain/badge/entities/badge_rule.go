@@ -0,0 +1,89 @@
+package badge_entities
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+type BadgeRuleOperator string
+
+const (
+	BadgeRuleGreaterThanOrEqual BadgeRuleOperator = ">="
+	BadgeRuleGreaterThan        BadgeRuleOperator = ">"
+	BadgeRuleLessThanOrEqual    BadgeRuleOperator = "<="
+	BadgeRuleLessThan           BadgeRuleOperator = "<"
+	BadgeRuleEqual              BadgeRuleOperator = "=="
+)
+
+// a condition reads "<fact> <operator> <number>", ie: "career.headshots >= 100"
+var badgeConditionPattern = regexp.MustCompile(`^([a-z0-9_.]+)\s*(>=|<=|==|>|<)\s*(-?[0-9]+(?:\.[0-9]+)?)$`)
+
+// BadgeCondition compares a fact of the player to a value.
+type BadgeCondition struct {
+	Fact     string            `json:"fact"`
+	Operator BadgeRuleOperator `json:"operator"`
+	Value    float64           `json:"value"`
+}
+
+// BadgeRule is a parsed rule expression: its conditions are joined by "and", all of them must hold for the badge to be awarded.
+type BadgeRule struct {
+	Conditions []BadgeCondition `json:"conditions"`
+}
+
+// ParseBadgeRule parses a rule expression, ie: "career.headshots >= 100" or "match.won == 1 and match.mvp == 1".
+func ParseBadgeRule(expr string) (BadgeRule, error) {
+	rule := BadgeRule{Conditions: make([]BadgeCondition, 0)}
+
+	for _, part := range strings.Split(strings.ToLower(expr), " and ") {
+		part = strings.TrimSpace(part)
+
+		m := badgeConditionPattern.FindStringSubmatch(part)
+		if m == nil {
+			return BadgeRule{}, fmt.Errorf("invalid condition %q: expected \"<fact> <operator> <number>\"", part)
+		}
+
+		value, err := strconv.ParseFloat(m[3], 64)
+		if err != nil {
+			return BadgeRule{}, fmt.Errorf("invalid value of condition %q: %w", part, err)
+		}
+
+		rule.Conditions = append(rule.Conditions, BadgeCondition{Fact: m[1], Operator: BadgeRuleOperator(m[2]), Value: value})
+	}
+
+	return rule, nil
+}
+
+// Matches tells whether the facts of a player satisfy every condition (a condition on a missing fact doesn't hold).
+func (r BadgeRule) Matches(facts map[string]float64) bool {
+	if len(r.Conditions) == 0 {
+		return false
+	}
+
+	for _, c := range r.Conditions {
+		fact, ok := facts[c.Fact]
+		if !ok || !c.holds(fact) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (c BadgeCondition) holds(fact float64) bool {
+	switch c.Operator {
+	case BadgeRuleGreaterThanOrEqual:
+		return fact >= c.Value
+	case BadgeRuleGreaterThan:
+		return fact > c.Value
+	case BadgeRuleLessThanOrEqual:
+		return fact <= c.Value
+	case BadgeRuleLessThan:
+		return fact < c.Value
+	case BadgeRuleEqual:
+		return fact == c.Value
+	default:
+		return false
+	}
+}
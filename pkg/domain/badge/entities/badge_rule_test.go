@@ -0,0 +1,77 @@
+package badge_entities_test
+
+import (
+	"testing"
+
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	badge_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/badge/entities"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseBadgeRule(t *testing.T) {
+	rule, err := badge_entities.ParseBadgeRule("career.headshots >= 100 AND match.mvp==1")
+
+	if assert.NoError(t, err) {
+		assert.Equal(t, []badge_entities.BadgeCondition{
+			{Fact: "career.headshots", Operator: badge_entities.BadgeRuleGreaterThanOrEqual, Value: 100},
+			{Fact: "match.mvp", Operator: badge_entities.BadgeRuleEqual, Value: 1},
+		}, rule.Conditions)
+	}
+
+	for _, expr := range []string{"", "career.headshots", "career.headshots >= many", "career.headshots => 100", "match.won == 1 or match.mvp == 1"} {
+		_, err := badge_entities.ParseBadgeRule(expr)
+		assert.Error(t, err, expr)
+	}
+}
+
+func TestBadgeRule_Matches(t *testing.T) {
+	rule, _ := badge_entities.ParseBadgeRule("match.kills > 25 and match.deaths <= 10")
+
+	tests := []struct {
+		name     string
+		facts    map[string]float64
+		expected bool
+	}{
+		{"All Conditions Hold", map[string]float64{"match.kills": 30, "match.deaths": 10}, true},
+		{"A Condition Fails", map[string]float64{"match.kills": 25, "match.deaths": 4}, false},
+		{"Missing Fact", map[string]float64{"match.kills": 30}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, rule.Matches(tt.facts))
+		})
+	}
+}
+
+func TestBadge_Validate(t *testing.T) {
+	valid := badge_entities.Badge{
+		GameID:  common.CS2_GAME_ID,
+		Key:     "headshots-100",
+		Name:    "Headhunter",
+		Trigger: badge_entities.BadgeTriggerReplayProcessed,
+		Rule:    "career.headshots >= 100",
+	}
+
+	assert.NoError(t, valid.Validate())
+
+	tests := []struct {
+		name   string
+		update func(b *badge_entities.Badge)
+	}{
+		{"Invalid Key", func(b *badge_entities.Badge) { b.Key = "Head Hunter" }},
+		{"Missing Name", func(b *badge_entities.Badge) { b.Name = "" }},
+		{"Unknown Trigger", func(b *badge_entities.Badge) { b.Trigger = "tournament_won" }},
+		{"Invalid Rule", func(b *badge_entities.Badge) { b.Rule = "headshots" }},
+		{"Fact Of Another Trigger", func(b *badge_entities.Badge) { b.Rule = "match.won == 1" }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := valid
+			tt.update(&b)
+
+			assert.Error(t, b.Validate())
+		})
+	}
+}
@@ -0,0 +1,73 @@
+package badge_entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+)
+
+// playerBadgeNamespace keeps PlayerBadge IDs stable (badge+player), so a badge is awarded once to each player.
+var playerBadgeNamespace = uuid.MustParse("8e1f5b37-a2c6-4d90-b7e4-3f9a0c6d12e8")
+
+// PlayerFacts are the facts of a player on a trigger, ie: {"match.kills": 31, "career.headshots": 104}.
+type PlayerFacts struct {
+	PlayerID        uuid.UUID          `json:"player_id"`
+	NetworkPlayerID string             `json:"network_player_id,omitempty"` // replay players, identified across replays by their network ID (SteamID64)
+	Facts           map[string]float64 `json:"facts"`
+}
+
+// BadgeEvaluation holds the facts of the players on an occurrence of a trigger.
+type BadgeEvaluation struct {
+	Trigger BadgeTrigger     `json:"trigger"`
+	GameID  common.GameIDKey `json:"game_id"`
+	MatchID *uuid.UUID       `json:"match_id,omitempty"`
+	Players []PlayerFacts    `json:"players"`
+}
+
+// PlayerBadge is a badge awarded to a player, along with the match that earned it.
+type PlayerBadge struct {
+	ID              uuid.UUID            `json:"id" bson:"_id"`
+	BadgeID         uuid.UUID            `json:"badge_id" bson:"badge_id"`
+	GameID          common.GameIDKey     `json:"game_id" bson:"game_id"`
+	PlayerID        uuid.UUID            `json:"player_id" bson:"player_id"`
+	NetworkPlayerID string               `json:"network_player_id,omitempty" bson:"network_player_id,omitempty"`
+	Key             string               `json:"key" bson:"key"`
+	Name            string               `json:"name" bson:"name"`
+	Description     string               `json:"description" bson:"description"`
+	ImageURL        string               `json:"image_url" bson:"image_url"`
+	MatchID         *uuid.UUID           `json:"match_id,omitempty" bson:"match_id,omitempty"`
+	ResourceOwner   common.ResourceOwner `json:"resource_owner" bson:"resource_owner"`
+	AwardedAt       time.Time            `json:"awarded_at" bson:"awarded_at"`
+}
+
+func (b PlayerBadge) GetID() uuid.UUID {
+	return b.ID
+}
+
+// PlayerBadgeID identifies the badge of a player by their network ID when known (their replay player IDs differ across replays).
+func PlayerBadgeID(badgeID uuid.UUID, player PlayerFacts) uuid.UUID {
+	subject := player.PlayerID.String()
+	if player.NetworkPlayerID != "" {
+		subject = player.NetworkPlayerID
+	}
+
+	return uuid.NewSHA1(playerBadgeNamespace, []byte(badgeID.String()+subject))
+}
+
+func NewPlayerBadge(badge Badge, player PlayerFacts, matchID *uuid.UUID, owner common.ResourceOwner, now time.Time) PlayerBadge {
+	return PlayerBadge{
+		ID:              PlayerBadgeID(badge.ID, player),
+		BadgeID:         badge.ID,
+		GameID:          badge.GameID,
+		PlayerID:        player.PlayerID,
+		NetworkPlayerID: player.NetworkPlayerID,
+		Key:             badge.Key,
+		Name:            badge.Name,
+		Description:     badge.Description,
+		ImageURL:        badge.ImageURL,
+		MatchID:         matchID,
+		ResourceOwner:   owner,
+		AwardedAt:       now,
+	}
+}
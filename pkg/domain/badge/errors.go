@@ -0,0 +1,38 @@
+package badge
+
+import (
+	"fmt"
+
+	"github.com/google/uuid"
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+)
+
+// Invalid Badge Error (ie: unknown trigger, a rule reading a fact its trigger doesn't provide)
+type InvalidBadgeError struct {
+	Message string
+}
+
+func (e *InvalidBadgeError) Error() string {
+	return e.Message
+}
+
+func NewInvalidBadgeError(message string) *InvalidBadgeError {
+	return &InvalidBadgeError{
+		Message: message,
+	}
+}
+
+// Badge Key Taken Error (another badge of the game has the key)
+type BadgeKeyTakenError struct {
+	Message string
+}
+
+func (e *BadgeKeyTakenError) Error() string {
+	return e.Message
+}
+
+func NewBadgeKeyTakenError(gameID common.GameIDKey, key string, badgeID uuid.UUID) *BadgeKeyTakenError {
+	return &BadgeKeyTakenError{
+		Message: fmt.Sprintf("badge key %s of game %s is taken by badge %s", key, gameID, badgeID),
+	}
+}
@@ -0,0 +1,18 @@
+package badge_in
+
+import (
+	"context"
+
+	badge_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/badge/entities"
+)
+
+// CreateBadgeCommand defines a badge of a game, a *badge.BadgeKeyTakenError when another badge of the game has its key.
+type CreateBadgeCommand interface {
+	Exec(ctx context.Context, badge badge_entities.Badge) (*badge_entities.Badge, error)
+}
+
+// EvaluateBadgesCommand awards the enabled badges of the trigger to the players whose facts satisfy their rule, returning the badges
+// awarded (the ones a player already had aren't awarded again).
+type EvaluateBadgesCommand interface {
+	Exec(ctx context.Context, evaluation badge_entities.BadgeEvaluation) ([]badge_entities.PlayerBadge, error)
+}
@@ -0,0 +1,27 @@
+package badge_in
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	badge_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/badge/entities"
+)
+
+type BadgeReader interface {
+	common.Searchable[badge_entities.Badge]
+}
+
+type PlayerBadgeReader interface {
+	common.Searchable[badge_entities.PlayerBadge]
+}
+
+type PlayerBadgesQueryParams struct {
+	PlayerID uuid.UUID
+	GameID   common.GameIDKey // optional
+}
+
+// PlayerBadgesQuery returns the badges of a player (most recent first), including the ones earned in other replays by the same network player.
+type PlayerBadgesQuery interface {
+	Exec(ctx context.Context, params PlayerBadgesQueryParams) ([]badge_entities.PlayerBadge, error)
+}
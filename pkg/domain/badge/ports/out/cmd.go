@@ -0,0 +1,16 @@
+package badge_out
+
+import (
+	"context"
+
+	badge_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/badge/entities"
+)
+
+type BadgeWriter interface {
+	Create(ctx context.Context, badge *badge_entities.Badge) (*badge_entities.Badge, error)
+}
+
+type PlayerBadgeWriter interface {
+	// Award inserts the badges a player doesn't have yet (by their stable IDs) in a single unordered bulk, returning the ones inserted.
+	Award(ctx context.Context, badges []badge_entities.PlayerBadge) ([]badge_entities.PlayerBadge, error)
+}
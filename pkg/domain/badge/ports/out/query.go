@@ -0,0 +1,22 @@
+package badge_out
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	badge_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/badge/entities"
+)
+
+type BadgeReader interface {
+	common.Searchable[badge_entities.Badge]
+}
+
+type PlayerBadgeReader interface {
+	common.Searchable[badge_entities.PlayerBadge]
+}
+
+type PlayerNetworkIDReader interface {
+	// GetNetworkPlayerID returns the network ID (SteamID64) of a replay player, empty when the player isn't a replay player.
+	GetNetworkPlayerID(ctx context.Context, playerID uuid.UUID) (string, error)
+}
@@ -0,0 +1,46 @@
+package badge_services
+
+import (
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	badge_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/badge/entities"
+	badge_in "github.com/psavelis/team-pro/replay-api/pkg/domain/badge/ports/in"
+	badge_out "github.com/psavelis/team-pro/replay-api/pkg/domain/badge/ports/out"
+)
+
+// NewBadgeQueryService serves the badges defined by the client application in context.
+func NewBadgeQueryService(badgeReader badge_out.BadgeReader) badge_in.BadgeReader {
+	queryableFields := map[string]bool{
+		"ID":            true,
+		"GameID":        true,
+		"Key":           true,
+		"Name":          true,
+		"Trigger":       true,
+		"Enabled":       true,
+		"ResourceOwner": common.DENY,
+		"CreatedAt":     true,
+		"UpdatedAt":     true,
+	}
+
+	readableFields := map[string]bool{
+		"ID":            true,
+		"GameID":        true,
+		"Key":           true,
+		"Name":          true,
+		"Description":   true,
+		"ImageURL":      true,
+		"Trigger":       true,
+		"Rule":          true,
+		"Enabled":       true,
+		"ResourceOwner": common.DENY,
+		"CreatedAt":     true,
+		"UpdatedAt":     true,
+	}
+
+	return &common.BaseQueryService[badge_entities.Badge]{
+		Reader:          badgeReader.(common.Searchable[badge_entities.Badge]),
+		QueryableFields: queryableFields,
+		ReadableFields:  readableFields,
+		MaxPageSize:     100,
+		Audience:        common.ClientApplicationAudienceIDKey,
+	}
+}
@@ -0,0 +1,46 @@
+package badge_services
+
+import (
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	badge_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/badge/entities"
+	badge_in "github.com/psavelis/team-pro/replay-api/pkg/domain/badge/ports/in"
+	badge_out "github.com/psavelis/team-pro/replay-api/pkg/domain/badge/ports/out"
+)
+
+// NewPlayerBadgeQueryService serves the badges awarded by the client application in context.
+func NewPlayerBadgeQueryService(playerBadgeReader badge_out.PlayerBadgeReader) badge_in.PlayerBadgeReader {
+	queryableFields := map[string]bool{
+		"ID":              true,
+		"BadgeID":         true,
+		"GameID":          true,
+		"PlayerID":        true,
+		"NetworkPlayerID": true,
+		"Key":             true,
+		"MatchID":         true,
+		"ResourceOwner":   common.DENY,
+		"AwardedAt":       true,
+	}
+
+	readableFields := map[string]bool{
+		"ID":              true,
+		"BadgeID":         true,
+		"GameID":          true,
+		"PlayerID":        true,
+		"NetworkPlayerID": true,
+		"Key":             true,
+		"Name":            true,
+		"Description":     true,
+		"ImageURL":        true,
+		"MatchID":         true,
+		"ResourceOwner":   common.DENY,
+		"AwardedAt":       true,
+	}
+
+	return &common.BaseQueryService[badge_entities.PlayerBadge]{
+		Reader:          playerBadgeReader.(common.Searchable[badge_entities.PlayerBadge]),
+		QueryableFields: queryableFields,
+		ReadableFields:  readableFields,
+		MaxPageSize:     100,
+		Audience:        common.ClientApplicationAudienceIDKey,
+	}
+}
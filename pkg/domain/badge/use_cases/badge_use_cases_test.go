@@ -0,0 +1,158 @@
+package badge_use_cases_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	"github.com/psavelis/team-pro/replay-api/pkg/domain/badge"
+	badge_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/badge/entities"
+	badge_use_cases "github.com/psavelis/team-pro/replay-api/pkg/domain/badge/use_cases"
+	"github.com/stretchr/testify/assert"
+)
+
+// mockBadgeStore keeps the badges and the badges awarded by ID, searches return all the badges.
+type mockBadgeStore struct {
+	badges  map[uuid.UUID]badge_entities.Badge
+	awarded map[uuid.UUID]badge_entities.PlayerBadge
+}
+
+func newMockBadgeStore(badges ...badge_entities.Badge) *mockBadgeStore {
+	m := &mockBadgeStore{badges: make(map[uuid.UUID]badge_entities.Badge), awarded: make(map[uuid.UUID]badge_entities.PlayerBadge)}
+	for _, b := range badges {
+		m.badges[b.ID] = b
+	}
+
+	return m
+}
+
+func (m *mockBadgeStore) Search(ctx context.Context, s common.Search) ([]badge_entities.Badge, error) {
+	badges := make([]badge_entities.Badge, 0, len(m.badges))
+	for _, b := range m.badges {
+		badges = append(badges, b)
+	}
+
+	return badges, nil
+}
+
+func (m *mockBadgeStore) Compile(ctx context.Context, searchParams []common.SearchAggregation, resultOptions common.SearchResultOptions) (*common.Search, error) {
+	return nil, nil
+}
+
+func (m *mockBadgeStore) Create(ctx context.Context, b *badge_entities.Badge) (*badge_entities.Badge, error) {
+	m.badges[b.ID] = *b
+	return b, nil
+}
+
+func (m *mockBadgeStore) Award(ctx context.Context, badges []badge_entities.PlayerBadge) ([]badge_entities.PlayerBadge, error) {
+	awarded := make([]badge_entities.PlayerBadge, 0)
+	for _, b := range badges {
+		if _, ok := m.awarded[b.ID]; ok {
+			continue
+		}
+
+		m.awarded[b.ID] = b
+		awarded = append(awarded, b)
+	}
+
+	return awarded, nil
+}
+
+func testContext() context.Context {
+	return common.WithResourceOwner(context.Background(), common.ResourceOwner{TenantID: common.TeamPROTenantID, ClientID: common.ServerClientID})
+}
+
+func TestCreateBadge(t *testing.T) {
+	existing := badge_entities.Badge{ID: uuid.New(), GameID: common.CS2_GAME_ID, Key: "headshots-100"}
+
+	tests := []struct {
+		name        string
+		badge       badge_entities.Badge
+		expectedErr interface{}
+	}{
+		{
+			name:  "Created",
+			badge: badge_entities.Badge{GameID: common.CS2_GAME_ID, Key: " Ace ", Name: "Ace", Trigger: badge_entities.BadgeTriggerReplayProcessed, Rule: "match.aces >= 1"},
+		},
+		{
+			name:        "Invalid",
+			badge:       badge_entities.Badge{GameID: common.CS2_GAME_ID, Key: "ace", Name: "Ace", Trigger: badge_entities.BadgeTriggerMatchCompleted, Rule: "match.aces >= 1"},
+			expectedErr: &badge.InvalidBadgeError{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := newMockBadgeStore()
+
+			usecase := badge_use_cases.NewCreateBadgeUseCase(store, store)
+
+			created, err := usecase.Exec(testContext(), tt.badge)
+
+			if tt.expectedErr != nil {
+				assert.IsType(t, tt.expectedErr, err)
+				assert.Empty(t, store.badges)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, "ace", created.Key)
+			assert.True(t, created.Enabled)
+			assert.NotEqual(t, uuid.Nil, created.ID)
+		})
+	}
+
+	t.Run("Key Taken", func(t *testing.T) {
+		store := newMockBadgeStore(existing)
+
+		usecase := badge_use_cases.NewCreateBadgeUseCase(store, store)
+
+		_, err := usecase.Exec(testContext(), badge_entities.Badge{GameID: common.CS2_GAME_ID, Key: "headshots-100", Name: "Headhunter", Trigger: badge_entities.BadgeTriggerReplayProcessed, Rule: "career.headshots >= 100"})
+
+		assert.IsType(t, &badge.BadgeKeyTakenError{}, err)
+		assert.Len(t, store.badges, 1)
+	})
+}
+
+func TestEvaluateBadges(t *testing.T) {
+	headhunter := badge_entities.Badge{ID: uuid.New(), GameID: common.CS2_GAME_ID, Key: "headshots-100", Name: "Headhunter", Trigger: badge_entities.BadgeTriggerReplayProcessed, Rule: "career.headshots >= 100", Enabled: true}
+	ace := badge_entities.Badge{ID: uuid.New(), GameID: common.CS2_GAME_ID, Key: "ace", Name: "Ace", Trigger: badge_entities.BadgeTriggerReplayProcessed, Rule: "match.aces >= 1", Enabled: true}
+
+	store := newMockBadgeStore(headhunter, ace)
+
+	usecase := badge_use_cases.NewEvaluateBadgesUseCase(store, store)
+
+	matchID := uuid.New()
+
+	veteran := badge_entities.PlayerFacts{PlayerID: uuid.New(), NetworkPlayerID: "76561198000000001", Facts: map[string]float64{"career.headshots": 120, "match.aces": 1}}
+	rookie := badge_entities.PlayerFacts{PlayerID: uuid.New(), NetworkPlayerID: "76561198000000002", Facts: map[string]float64{"career.headshots": 12, "match.aces": 0}}
+
+	evaluation := badge_entities.BadgeEvaluation{
+		Trigger: badge_entities.BadgeTriggerReplayProcessed,
+		GameID:  common.CS2_GAME_ID,
+		MatchID: &matchID,
+		Players: []badge_entities.PlayerFacts{veteran, rookie},
+	}
+
+	awarded, err := usecase.Exec(testContext(), evaluation)
+
+	assert.NoError(t, err)
+	if assert.Len(t, awarded, 2) {
+		for _, b := range awarded {
+			assert.Equal(t, veteran.PlayerID, b.PlayerID)
+			assert.Equal(t, &matchID, b.MatchID)
+			assert.Equal(t, common.ServerClientID, b.ResourceOwner.ClientID)
+		}
+	}
+
+	// the same network player in another replay (another replay player ID) isn't awarded again
+	veteran.PlayerID = uuid.New()
+	evaluation.Players = []badge_entities.PlayerFacts{veteran}
+
+	awarded, err = usecase.Exec(testContext(), evaluation)
+
+	assert.NoError(t, err)
+	assert.Empty(t, awarded)
+	assert.Len(t, store.awarded, 2)
+}
@@ -0,0 +1,70 @@
+package badge_use_cases
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	"github.com/psavelis/team-pro/replay-api/pkg/domain/badge"
+	badge_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/badge/entities"
+	badge_in "github.com/psavelis/team-pro/replay-api/pkg/domain/badge/ports/in"
+	badge_out "github.com/psavelis/team-pro/replay-api/pkg/domain/badge/ports/out"
+)
+
+type CreateBadgeUseCase struct {
+	BadgeReader badge_out.BadgeReader
+	BadgeWriter badge_out.BadgeWriter
+}
+
+func NewCreateBadgeUseCase(badgeReader badge_out.BadgeReader, badgeWriter badge_out.BadgeWriter) badge_in.CreateBadgeCommand {
+	return &CreateBadgeUseCase{
+		BadgeReader: badgeReader,
+		BadgeWriter: badgeWriter,
+	}
+}
+
+// Exec defines an enabled badge, awarded from the next occurrence of its trigger on (players aren't evaluated retroactively).
+func (usecase *CreateBadgeUseCase) Exec(ctx context.Context, b badge_entities.Badge) (*badge_entities.Badge, error) {
+	b.Key = strings.ToLower(strings.TrimSpace(b.Key))
+	b.Rule = strings.TrimSpace(b.Rule)
+
+	err := b.Validate()
+	if err != nil {
+		return nil, badge.NewInvalidBadgeError(err.Error())
+	}
+
+	badges, err := usecase.BadgeReader.Search(ctx, common.NewSearchByValues(ctx, []common.SearchableValue{
+		{Field: "GameID", Values: []interface{}{b.GameID}},
+		{Field: "Key", Values: []interface{}{b.Key}},
+	}, common.NewSearchResultOptions(0, 1), common.ClientApplicationAudienceIDKey))
+
+	if err != nil {
+		slog.ErrorContext(ctx, "unable to search badges by key", "gameID", b.GameID, "key", b.Key, "err", err)
+		return nil, err
+	}
+
+	if len(badges) > 0 {
+		return nil, badge.NewBadgeKeyTakenError(b.GameID, b.Key, badges[0].ID)
+	}
+
+	now := time.Now().UTC()
+
+	b.ID = uuid.New()
+	b.Enabled = true
+	b.ResourceOwner = common.GetResourceOwner(ctx)
+	b.CreatedAt = now
+	b.UpdatedAt = now
+
+	created, err := usecase.BadgeWriter.Create(ctx, &b)
+	if err != nil {
+		slog.ErrorContext(ctx, "unable to create badge", "gameID", b.GameID, "key", b.Key, "err", err)
+		return nil, err
+	}
+
+	slog.InfoContext(ctx, "badge created", "badgeID", created.ID, "gameID", created.GameID, "key", created.Key, "trigger", created.Trigger)
+
+	return created, nil
+}
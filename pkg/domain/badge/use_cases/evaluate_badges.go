@@ -0,0 +1,82 @@
+package badge_use_cases
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	badge_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/badge/entities"
+	badge_in "github.com/psavelis/team-pro/replay-api/pkg/domain/badge/ports/in"
+	badge_out "github.com/psavelis/team-pro/replay-api/pkg/domain/badge/ports/out"
+)
+
+// max badges of a trigger evaluated per game
+const BadgeBatchSize = 200
+
+type EvaluateBadgesUseCase struct {
+	BadgeReader       badge_out.BadgeReader
+	PlayerBadgeWriter badge_out.PlayerBadgeWriter
+}
+
+func NewEvaluateBadgesUseCase(badgeReader badge_out.BadgeReader, playerBadgeWriter badge_out.PlayerBadgeWriter) badge_in.EvaluateBadgesCommand {
+	return &EvaluateBadgesUseCase{
+		BadgeReader:       badgeReader,
+		PlayerBadgeWriter: playerBadgeWriter,
+	}
+}
+
+// Exec evaluates the badges defined by the client application in context, badges are owned by the tenant and client (not the players).
+func (usecase *EvaluateBadgesUseCase) Exec(ctx context.Context, evaluation badge_entities.BadgeEvaluation) ([]badge_entities.PlayerBadge, error) {
+	if len(evaluation.Players) == 0 {
+		return []badge_entities.PlayerBadge{}, nil
+	}
+
+	badges, err := usecase.BadgeReader.Search(ctx, common.NewSearchByValues(ctx, []common.SearchableValue{
+		{Field: "GameID", Values: []interface{}{evaluation.GameID}},
+		{Field: "Trigger", Values: []interface{}{evaluation.Trigger}},
+		{Field: "Enabled", Values: []interface{}{true}},
+	}, common.NewSearchResultOptions(0, BadgeBatchSize), common.ClientApplicationAudienceIDKey))
+
+	if err != nil {
+		slog.ErrorContext(ctx, "unable to search badges of the trigger", "gameID", evaluation.GameID, "trigger", evaluation.Trigger, "err", err)
+		return nil, err
+	}
+
+	reso := common.GetResourceOwner(ctx)
+	owner := common.ResourceOwner{TenantID: reso.TenantID, ClientID: reso.ClientID}
+	now := time.Now().UTC()
+
+	candidates := make([]badge_entities.PlayerBadge, 0)
+
+	for _, b := range badges {
+		rule, err := badge_entities.ParseBadgeRule(b.Rule)
+		if err != nil {
+			// validated when created, skipped rather than failing the other badges
+			slog.WarnContext(ctx, "skipping badge with an invalid rule", "badgeID", b.ID, "rule", b.Rule, "err", err)
+			continue
+		}
+
+		for _, player := range evaluation.Players {
+			if rule.Matches(player.Facts) {
+				candidates = append(candidates, badge_entities.NewPlayerBadge(b, player, evaluation.MatchID, owner, now))
+			}
+		}
+	}
+
+	if len(candidates) == 0 {
+		return []badge_entities.PlayerBadge{}, nil
+	}
+
+	awarded, err := usecase.PlayerBadgeWriter.Award(ctx, candidates)
+	if err != nil {
+		slog.ErrorContext(ctx, "unable to award badges", "gameID", evaluation.GameID, "trigger", evaluation.Trigger, "candidates", len(candidates), "err", err)
+		return nil, err
+	}
+
+	if len(awarded) > 0 {
+		slog.InfoContext(ctx, "badges awarded", "gameID", evaluation.GameID, "trigger", evaluation.Trigger, "matchID", evaluation.MatchID, "awarded", len(awarded))
+	}
+
+	return awarded, nil
+}
@@ -0,0 +1,63 @@
+package badge_use_cases
+
+import (
+	"context"
+	"log/slog"
+
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	badge_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/badge/entities"
+	badge_in "github.com/psavelis/team-pro/replay-api/pkg/domain/badge/ports/in"
+	badge_out "github.com/psavelis/team-pro/replay-api/pkg/domain/badge/ports/out"
+)
+
+// max badges returned per player
+const PlayerBadgesLimit = 500
+
+type GetPlayerBadgesUseCase struct {
+	PlayerBadgeReader badge_out.PlayerBadgeReader
+	NetworkIDReader   badge_out.PlayerNetworkIDReader
+}
+
+func NewGetPlayerBadgesUseCase(playerBadgeReader badge_out.PlayerBadgeReader, networkIDReader badge_out.PlayerNetworkIDReader) badge_in.PlayerBadgesQuery {
+	return &GetPlayerBadgesUseCase{
+		PlayerBadgeReader: playerBadgeReader,
+		NetworkIDReader:   networkIDReader,
+	}
+}
+
+func (usecase *GetPlayerBadgesUseCase) Exec(ctx context.Context, params badge_in.PlayerBadgesQueryParams) ([]badge_entities.PlayerBadge, error) {
+	networkPlayerID, err := usecase.NetworkIDReader.GetNetworkPlayerID(ctx, params.PlayerID)
+	if err != nil {
+		slog.ErrorContext(ctx, "unable to get the network ID of the player for player badges", "playerID", params.PlayerID, "err", err)
+		return nil, err
+	}
+
+	// awarded to this player, or to the same network player in another replay
+	subject := common.SearchParameter{
+		ValueParams:       []common.SearchableValue{{Field: "PlayerID", Values: []interface{}{params.PlayerID}}},
+		AggregationClause: common.OrAggregationClause,
+	}
+
+	if networkPlayerID != "" {
+		subject.ValueParams = append(subject.ValueParams, common.SearchableValue{Field: "NetworkPlayerID", Values: []interface{}{networkPlayerID}})
+	}
+
+	aggregations := []common.SearchAggregation{{Params: []common.SearchParameter{subject}}}
+
+	if params.GameID != "" {
+		aggregations = append(aggregations, common.SearchAggregation{Params: []common.SearchParameter{{
+			ValueParams: []common.SearchableValue{{Field: "GameID", Values: []interface{}{params.GameID}}},
+		}}})
+	}
+
+	s := common.NewSearchByAggregation(ctx, aggregations, common.NewSearchResultOptions(0, PlayerBadgesLimit), common.ClientApplicationAudienceIDKey)
+	s.SortOptions = []common.SearchSortOption{{Field: "AwardedAt", Direction: common.DescendingIDKey}}
+
+	badges, err := usecase.PlayerBadgeReader.Search(ctx, s)
+	if err != nil {
+		slog.ErrorContext(ctx, "unable to search player badges", "playerID", params.PlayerID, "err", err)
+		return nil, err
+	}
+
+	return badges, nil
+}
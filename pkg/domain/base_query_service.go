@@ -25,6 +25,20 @@ func (service *BaseQueryService[T]) GetName() string {
 	return service.name
 }
 
+// GetEntityName is the type name of the entity served, matching its repository EntityMetadata.
+func (service *BaseQueryService[T]) GetEntityName() string {
+	entityType := reflect.TypeOf((*T)(nil)).Elem()
+	for entityType.Kind() == reflect.Ptr {
+		entityType = entityType.Elem()
+	}
+
+	return entityType.Name()
+}
+
+func (service *BaseQueryService[T]) GetQueryableFields() map[string]bool {
+	return service.QueryableFields
+}
+
 func (service *BaseQueryService[T]) Search(ctx context.Context, s Search) ([]T, error) {
 	gameEvents, err := service.Reader.Search(ctx, s)
 
@@ -32,12 +46,70 @@ func (service *BaseQueryService[T]) Search(ctx context.Context, s Search) ([]T,
 		var typeDef T
 		typeName := reflect.TypeOf(typeDef).Name()
 		svcName := service.GetName()
-		return nil, fmt.Errorf("error filtering. Service: %v. Entity: %v. Error: %v", svcName, typeName, err)
+		return nil, fmt.Errorf("error filtering. Service: %v. Entity: %v. Error: %w", svcName, typeName, err)
 	}
 
 	return gameEvents, nil
 }
 
+func (service *BaseQueryService[T]) Facets(ctx context.Context, s Search) (Facets, error) {
+	if len(s.ResultOptions.Facets) == 0 {
+		return Facets{}, nil
+	}
+
+	reader, ok := service.Reader.(Facetable)
+	if !ok {
+		return nil, fmt.Errorf("error computing facets. Service: %v. Reader %T does not support facets", service.GetName(), service.Reader)
+	}
+
+	facets, err := reader.Facets(ctx, s)
+	if err != nil {
+		var typeDef T
+		typeName := reflect.TypeOf(typeDef).Name()
+		return nil, fmt.Errorf("error computing facets. Service: %v. Entity: %v. Error: %v", service.GetName(), typeName, err)
+	}
+
+	return facets, nil
+}
+
+// Aggregate only groups by (and computes metrics on) the queryable fields of the service, the reader checks its own.
+func (service *BaseQueryService[T]) Aggregate(ctx context.Context, s Search, groupBy []string, metrics []AggregateMetric) ([]AggregateGroup, error) {
+	err := ValidateAggregate(groupBy, metrics, service.QueryableFields)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidAggregate, err)
+	}
+
+	reader, ok := service.Reader.(Aggregatable)
+	if !ok {
+		return nil, fmt.Errorf("error aggregating. Service: %v. Reader %T does not support aggregations", service.GetName(), service.Reader)
+	}
+
+	groups, err := reader.Aggregate(ctx, s, groupBy, metrics)
+	if err != nil {
+		var typeDef T
+		typeName := reflect.TypeOf(typeDef).Name()
+		return nil, fmt.Errorf("error aggregating. Service: %v. Entity: %v. Error: %v", service.GetName(), typeName, err)
+	}
+
+	return groups, nil
+}
+
+func (service *BaseQueryService[T]) NextCursor(ctx context.Context, s Search, last interface{}) (string, error) {
+	reader, ok := service.Reader.(CursorPaginated)
+	if !ok {
+		return "", fmt.Errorf("error building next cursor. Service: %v. Reader %T does not support cursor pagination", service.GetName(), service.Reader)
+	}
+
+	cursor, err := reader.NextCursor(ctx, s, last)
+	if err != nil {
+		var typeDef T
+		typeName := reflect.TypeOf(typeDef).Name()
+		return "", fmt.Errorf("error building next cursor. Service: %v. Entity: %v. Error: %v", service.GetName(), typeName, err)
+	}
+
+	return cursor, nil
+}
+
 func (svc *BaseQueryService[T]) Compile(ctx context.Context, searchParams []SearchAggregation, resultOptions SearchResultOptions) (*Search, error) {
 	err := ValidateSearchParameters(searchParams, svc.QueryableFields)
 	if err != nil {
@@ -49,6 +121,18 @@ func (svc *BaseQueryService[T]) Compile(ctx context.Context, searchParams []Sear
 		return nil, fmt.Errorf("error validating result options: %v", err)
 	}
 
+	err = ValidateFacetFields(resultOptions.Facets, svc.QueryableFields)
+	if err != nil {
+		return nil, fmt.Errorf("error validating facets: %v", err)
+	}
+
+	// the repositories only check their own (wider) queryable fields: sorting on a denied field would leak its values through the order
+	// of the results and the cursors
+	err = ValidateSortOptions(resultOptions.Sort, svc.QueryableFields)
+	if err != nil {
+		return nil, fmt.Errorf("error validating sort options: %v", err)
+	}
+
 	s := NewSearchByAggregation(ctx, searchParams, resultOptions, svc.Audience)
 
 	return &s, nil
@@ -0,0 +1,86 @@
+package consent_entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+)
+
+// ConsentRecord is the acceptance of a policy version by a user. Records are only appended, they are the proof of consent.
+type ConsentRecord struct {
+	ID          uuid.UUID  `json:"id" bson:"_id"`
+	UserID      uuid.UUID  `json:"user_id" bson:"user_id"`
+	PolicyID    uuid.UUID  `json:"policy_id" bson:"policy_id"`
+	Kind        PolicyKind `json:"kind" bson:"kind"`
+	Version     int        `json:"version" bson:"version"`
+	ContentHash string     `json:"content_hash" bson:"content_hash"`
+	AcceptedAt  time.Time  `json:"accepted_at" bson:"accepted_at"`
+	// IPAddress and UserAgent of the request accepting the policy
+	IPAddress     string               `json:"ip_address" bson:"ip_address"`
+	UserAgent     string               `json:"user_agent" bson:"user_agent"`
+	ResourceOwner common.ResourceOwner `json:"resource_owner" bson:"resource_owner"`
+	CreatedAt     time.Time            `json:"created_at" bson:"created_at"`
+	UpdatedAt     time.Time            `json:"updated_at" bson:"updated_at"`
+}
+
+func (r ConsentRecord) GetID() uuid.UUID {
+	return r.ID
+}
+
+// PolicyConsent is the consent of a user to the current version of a policy.
+type PolicyConsent struct {
+	Kind           PolicyKind `json:"kind"`
+	PolicyID       uuid.UUID  `json:"policy_id"`
+	CurrentVersion int        `json:"current_version"`
+	URL            string     `json:"url"`
+	// AcceptedVersion is the last version accepted by the user (0: never accepted)
+	AcceptedVersion int        `json:"accepted_version"`
+	AcceptedAt      *time.Time `json:"accepted_at,omitempty"`
+	Accepted        bool       `json:"accepted"` // the current version
+	// Reacceptance is set when the user only accepted previous versions, and has to accept the new one.
+	Reacceptance bool `json:"reacceptance"`
+}
+
+// NewPolicyConsent returns the consent to the current version of a policy, given the last acceptance of the user (nil when none).
+func NewPolicyConsent(current PolicyVersion, last *ConsentRecord) PolicyConsent {
+	consent := PolicyConsent{
+		Kind:           current.Kind,
+		PolicyID:       current.ID,
+		CurrentVersion: current.Version,
+		URL:            current.URL,
+	}
+
+	if last != nil {
+		acceptedAt := last.AcceptedAt
+		consent.AcceptedVersion = last.Version
+		consent.AcceptedAt = &acceptedAt
+	}
+
+	consent.Accepted = consent.AcceptedVersion >= consent.CurrentVersion
+	consent.Reacceptance = consent.AcceptedVersion > 0 && !consent.Accepted
+
+	return consent
+}
+
+// ConsentStatus is the consent of a user to the current version of every policy published by the tenant.
+type ConsentStatus struct {
+	UserID   uuid.UUID       `json:"user_id"`
+	Policies []PolicyConsent `json:"policies"`
+	// Current is set when the user accepted the current version of every policy (required to be paid prizes).
+	Current bool `json:"current"`
+}
+
+func NewConsentStatus(userID uuid.UUID, policies []PolicyConsent) *ConsentStatus {
+	status := &ConsentStatus{
+		UserID:   userID,
+		Policies: policies,
+		Current:  true,
+	}
+
+	for _, c := range policies {
+		status.Current = status.Current && c.Accepted
+	}
+
+	return status
+}
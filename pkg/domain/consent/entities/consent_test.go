@@ -0,0 +1,68 @@
+package consent_entities_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	consent_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/consent/entities"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPolicyVersion_Validate(t *testing.T) {
+	policy := consent_entities.PolicyVersion{
+		Kind:        consent_entities.PolicyKindTermsOfService,
+		URL:         "https://example.com/terms",
+		ContentHash: " " + strings.Repeat("AB", 32) + " ",
+	}
+
+	assert.NoError(t, policy.Validate())
+	assert.Equal(t, strings.Repeat("ab", 32), policy.ContentHash)
+
+	tests := []struct {
+		name   string
+		mutate func(p *consent_entities.PolicyVersion)
+	}{
+		{"Unknown Kind", func(p *consent_entities.PolicyVersion) { p.Kind = "cookie_policy" }},
+		{"Plain HTTP URL", func(p *consent_entities.PolicyVersion) { p.URL = "http://example.com/terms" }},
+		{"Relative URL", func(p *consent_entities.PolicyVersion) { p.URL = "/terms" }},
+		{"Short Hash", func(p *consent_entities.PolicyVersion) { p.ContentHash = "abcd" }},
+		{"Non Hex Hash", func(p *consent_entities.PolicyVersion) { p.ContentHash = strings.Repeat("zz", 32) }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := policy
+			tt.mutate(&p)
+			assert.Error(t, p.Validate())
+		})
+	}
+}
+
+func TestNewConsentStatus(t *testing.T) {
+	userID := uuid.New()
+	acceptedAt := time.Now().UTC()
+
+	terms := consent_entities.PolicyVersion{ID: uuid.New(), Kind: consent_entities.PolicyKindTermsOfService, Version: 2}
+	privacy := consent_entities.PolicyVersion{ID: uuid.New(), Kind: consent_entities.PolicyKindPrivacyPolicy, Version: 1}
+
+	outdated := consent_entities.NewPolicyConsent(terms, &consent_entities.ConsentRecord{Version: 1, AcceptedAt: acceptedAt})
+	assert.False(t, outdated.Accepted)
+	assert.True(t, outdated.Reacceptance)
+	assert.Equal(t, 1, outdated.AcceptedVersion)
+	assert.Equal(t, acceptedAt, *outdated.AcceptedAt)
+
+	never := consent_entities.NewPolicyConsent(privacy, nil)
+	assert.False(t, never.Accepted)
+	assert.False(t, never.Reacceptance, "never accepted, not a re-acceptance")
+	assert.Nil(t, never.AcceptedAt)
+
+	current := consent_entities.NewPolicyConsent(terms, &consent_entities.ConsentRecord{Version: 2, AcceptedAt: acceptedAt})
+	assert.True(t, current.Accepted)
+	assert.False(t, current.Reacceptance)
+
+	assert.False(t, consent_entities.NewConsentStatus(userID, []consent_entities.PolicyConsent{current, never}).Current)
+	assert.True(t, consent_entities.NewConsentStatus(userID, []consent_entities.PolicyConsent{current}).Current)
+	assert.True(t, consent_entities.NewConsentStatus(userID, []consent_entities.PolicyConsent{}).Current, "nothing published, nothing to accept")
+}
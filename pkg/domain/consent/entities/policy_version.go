@@ -0,0 +1,70 @@
+package consent_entities
+
+import (
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+)
+
+type PolicyKind string
+
+const (
+	PolicyKindTermsOfService PolicyKind = "terms_of_service"
+	PolicyKindPrivacyPolicy  PolicyKind = "privacy_policy"
+)
+
+// PolicyKinds users are asked to accept, in order.
+var PolicyKinds = []PolicyKind{PolicyKindTermsOfService, PolicyKindPrivacyPolicy}
+
+func (k PolicyKind) Valid() bool {
+	for _, kind := range PolicyKinds {
+		if k == kind {
+			return true
+		}
+	}
+
+	return false
+}
+
+// PolicyVersion is a published version of a legal document of the tenant. Versions aren't edited: a change is a new version, effective
+// from EffectiveFrom, that every user has to accept again.
+type PolicyVersion struct {
+	ID      uuid.UUID  `json:"id" bson:"_id"`
+	Kind    PolicyKind `json:"kind" bson:"kind"`
+	Version int        `json:"version" bson:"version"`
+	URL     string     `json:"url" bson:"url"`
+	// ContentHash is the sha256 (hex) of the published document, so an acceptance proves which text was agreed to.
+	ContentHash   string               `json:"content_hash" bson:"content_hash"`
+	EffectiveFrom time.Time            `json:"effective_from" bson:"effective_from"`
+	ResourceOwner common.ResourceOwner `json:"resource_owner" bson:"resource_owner"`
+	CreatedAt     time.Time            `json:"created_at" bson:"created_at"`
+	UpdatedAt     time.Time            `json:"updated_at" bson:"updated_at"`
+}
+
+func (p PolicyVersion) GetID() uuid.UUID {
+	return p.ID
+}
+
+// Validate checks the document of the version, normalizing its content hash.
+func (p *PolicyVersion) Validate() error {
+	if !p.Kind.Valid() {
+		return fmt.Errorf("invalid policy kind '%s'", p.Kind)
+	}
+
+	u, err := url.Parse(p.URL)
+	if err != nil || u.Scheme != "https" || u.Host == "" {
+		return fmt.Errorf("url must be an https url")
+	}
+
+	p.ContentHash = strings.ToLower(strings.TrimSpace(p.ContentHash))
+	if b, err := hex.DecodeString(p.ContentHash); err != nil || len(b) != 32 {
+		return fmt.Errorf("content_hash must be the sha256 of the document (hex)")
+	}
+
+	return nil
+}
@@ -0,0 +1,52 @@
+package consent
+
+import (
+	"fmt"
+
+	consent_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/consent/entities"
+)
+
+// Invalid Policy Error (policy version rejected)
+type InvalidPolicyError struct {
+	Message string
+}
+
+func (e *InvalidPolicyError) Error() string {
+	return e.Message
+}
+
+func NewInvalidPolicyError(message string) *InvalidPolicyError {
+	return &InvalidPolicyError{
+		Message: message,
+	}
+}
+
+// Policy Not Found Error (no version of the policy is effective)
+type PolicyNotFoundError struct {
+	Message string
+}
+
+func (e *PolicyNotFoundError) Error() string {
+	return e.Message
+}
+
+func NewPolicyNotFoundError(kind consent_entities.PolicyKind) *PolicyNotFoundError {
+	return &PolicyNotFoundError{
+		Message: fmt.Sprintf("no %s is in effect", kind),
+	}
+}
+
+// Outdated Policy Error (the version accepted isn't the current one)
+type OutdatedPolicyError struct {
+	Message string
+}
+
+func (e *OutdatedPolicyError) Error() string {
+	return e.Message
+}
+
+func NewOutdatedPolicyError(current consent_entities.PolicyVersion, version int) *OutdatedPolicyError {
+	return &OutdatedPolicyError{
+		Message: fmt.Sprintf("%s v%d is not the current version (v%d)", current.Kind, version, current.Version),
+	}
+}
@@ -0,0 +1,26 @@
+package consent_in
+
+import (
+	"context"
+
+	consent_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/consent/entities"
+)
+
+// PublishPolicyVersionCommand publishes a new version of a policy, effective from its EffectiveFrom (now when empty). Users have to
+// accept it once effective (client level, internal).
+type PublishPolicyVersionCommand interface {
+	Exec(ctx context.Context, policy consent_entities.PolicyVersion) (*consent_entities.PolicyVersion, error)
+}
+
+type AcceptPolicyCommand struct {
+	Kind    consent_entities.PolicyKind `json:"kind"`
+	Version int                         `json:"version"`
+	// IPAddress and UserAgent of the request, recorded as evidence
+	IPAddress string `json:"-"`
+	UserAgent string `json:"-"`
+}
+
+// AcceptPolicyCommandHandler records the acceptance of the current version of a policy by the user in context.
+type AcceptPolicyCommandHandler interface {
+	Exec(ctx context.Context, cmd AcceptPolicyCommand) (*consent_entities.ConsentRecord, error)
+}
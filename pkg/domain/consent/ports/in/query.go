@@ -0,0 +1,14 @@
+package consent_in
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	consent_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/consent/entities"
+)
+
+// GetConsentStatusQuery returns the consent of a user to the current version of every policy: the versions pending (re-)acceptance,
+// and whether the consent is current (ie: before paying out prizes).
+type GetConsentStatusQuery interface {
+	Exec(ctx context.Context, userID uuid.UUID) (*consent_entities.ConsentStatus, error)
+}
@@ -0,0 +1,15 @@
+package consent_out
+
+import (
+	"context"
+
+	consent_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/consent/entities"
+)
+
+type PolicyVersionWriter interface {
+	Create(ctx context.Context, policy *consent_entities.PolicyVersion) (*consent_entities.PolicyVersion, error)
+}
+
+type ConsentRecordWriter interface {
+	Create(ctx context.Context, record *consent_entities.ConsentRecord) (*consent_entities.ConsentRecord, error)
+}
@@ -0,0 +1,14 @@
+package consent_out
+
+import (
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	consent_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/consent/entities"
+)
+
+type PolicyVersionReader interface {
+	common.Searchable[consent_entities.PolicyVersion]
+}
+
+type ConsentRecordReader interface {
+	common.Searchable[consent_entities.ConsentRecord]
+}
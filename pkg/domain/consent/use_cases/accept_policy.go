@@ -0,0 +1,78 @@
+package consent_use_cases
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	"github.com/psavelis/team-pro/replay-api/pkg/domain/consent"
+	consent_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/consent/entities"
+	consent_in "github.com/psavelis/team-pro/replay-api/pkg/domain/consent/ports/in"
+	consent_out "github.com/psavelis/team-pro/replay-api/pkg/domain/consent/ports/out"
+)
+
+type AcceptPolicyUseCase struct {
+	PolicyReader  consent_out.PolicyVersionReader
+	ConsentWriter consent_out.ConsentRecordWriter
+}
+
+func NewAcceptPolicyUseCase(policyReader consent_out.PolicyVersionReader, consentWriter consent_out.ConsentRecordWriter) consent_in.AcceptPolicyCommandHandler {
+	return &AcceptPolicyUseCase{
+		PolicyReader:  policyReader,
+		ConsentWriter: consentWriter,
+	}
+}
+
+// Exec only accepts the current version: the user has to have been shown the text in effect (a stale client gets an OutdatedPolicyError).
+func (usecase *AcceptPolicyUseCase) Exec(ctx context.Context, cmd consent_in.AcceptPolicyCommand) (*consent_entities.ConsentRecord, error) {
+	if !cmd.Kind.Valid() {
+		return nil, consent.NewInvalidPolicyError(fmt.Sprintf("invalid policy kind '%s'", cmd.Kind))
+	}
+
+	if !common.IsAuthenticatedUser(ctx) {
+		return nil, consent.NewInvalidPolicyError("policies can only be accepted by a user")
+	}
+
+	resourceOwner := common.GetResourceOwner(ctx)
+
+	now := time.Now().UTC()
+
+	current, err := latestPolicyVersion(ctx, usecase.PolicyReader, cmd.Kind, &now)
+	if err != nil {
+		return nil, err
+	}
+
+	if current == nil {
+		return nil, consent.NewPolicyNotFoundError(cmd.Kind)
+	}
+
+	if cmd.Version != current.Version {
+		return nil, consent.NewOutdatedPolicyError(*current, cmd.Version)
+	}
+
+	record := &consent_entities.ConsentRecord{
+		ID:            uuid.New(),
+		UserID:        resourceOwner.UserID,
+		PolicyID:      current.ID,
+		Kind:          current.Kind,
+		Version:       current.Version,
+		ContentHash:   current.ContentHash,
+		AcceptedAt:    now,
+		IPAddress:     cmd.IPAddress,
+		UserAgent:     cmd.UserAgent,
+		ResourceOwner: resourceOwner,
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	}
+
+	created, err := usecase.ConsentWriter.Create(ctx, record)
+	if err != nil {
+		slog.ErrorContext(ctx, "unable to record consent", "userID", record.UserID, "kind", record.Kind, "version", record.Version, "err", err)
+		return nil, err
+	}
+
+	return created, nil
+}
@@ -0,0 +1,265 @@
+package consent_use_cases_test
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	"github.com/psavelis/team-pro/replay-api/pkg/domain/consent"
+	consent_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/consent/entities"
+	consent_in "github.com/psavelis/team-pro/replay-api/pkg/domain/consent/ports/in"
+	consent_use_cases "github.com/psavelis/team-pro/replay-api/pkg/domain/consent/use_cases"
+	"github.com/stretchr/testify/assert"
+)
+
+var tenantID = uuid.New()
+
+func systemContext() context.Context {
+	return common.WithResourceOwner(context.Background(), common.ResourceOwner{TenantID: tenantID, ClientID: common.TeamPROAppClientID})
+}
+
+func userContext(userID uuid.UUID) context.Context {
+	return common.WithAuthenticated(common.WithResourceOwner(context.Background(), common.ResourceOwner{TenantID: tenantID, ClientID: common.TeamPROAppClientID, UserID: userID}))
+}
+
+// mockPolicyStore filters by kind and effective date, highest version first.
+type mockPolicyStore struct {
+	policies []consent_entities.PolicyVersion
+}
+
+func (m *mockPolicyStore) Search(ctx context.Context, s common.Search) ([]consent_entities.PolicyVersion, error) {
+	res := make([]consent_entities.PolicyVersion, 0)
+
+	for _, p := range m.policies {
+		match := true
+		for _, v := range s.SearchParams[0].Params[0].ValueParams {
+			switch v.Field {
+			case "Kind":
+				match = match && p.Kind == v.Values[0]
+			case "EffectiveFrom":
+				match = match && !p.EffectiveFrom.After(v.Values[0].(time.Time))
+			}
+		}
+
+		if match {
+			res = append(res, p)
+		}
+	}
+
+	sort.Slice(res, func(i, j int) bool { return res[i].Version > res[j].Version })
+
+	if limit := int(s.ResultOptions.Limit); limit > 0 && len(res) > limit {
+		res = res[:limit]
+	}
+
+	return res, nil
+}
+
+func (m *mockPolicyStore) Compile(ctx context.Context, searchParams []common.SearchAggregation, resultOptions common.SearchResultOptions) (*common.Search, error) {
+	return nil, nil
+}
+
+func (m *mockPolicyStore) Create(ctx context.Context, policy *consent_entities.PolicyVersion) (*consent_entities.PolicyVersion, error) {
+	m.policies = append(m.policies, *policy)
+	return policy, nil
+}
+
+// mockConsentStore filters by user and kind, highest version first.
+type mockConsentStore struct {
+	records []consent_entities.ConsentRecord
+}
+
+func (m *mockConsentStore) Search(ctx context.Context, s common.Search) ([]consent_entities.ConsentRecord, error) {
+	res := make([]consent_entities.ConsentRecord, 0)
+
+	for _, r := range m.records {
+		match := true
+		for _, v := range s.SearchParams[0].Params[0].ValueParams {
+			switch v.Field {
+			case "UserID":
+				match = match && r.UserID == v.Values[0]
+			case "Kind":
+				match = match && r.Kind == v.Values[0]
+			}
+		}
+
+		if match {
+			res = append(res, r)
+		}
+	}
+
+	sort.Slice(res, func(i, j int) bool { return res[i].Version > res[j].Version })
+
+	if limit := int(s.ResultOptions.Limit); limit > 0 && len(res) > limit {
+		res = res[:limit]
+	}
+
+	return res, nil
+}
+
+func (m *mockConsentStore) Compile(ctx context.Context, searchParams []common.SearchAggregation, resultOptions common.SearchResultOptions) (*common.Search, error) {
+	return nil, nil
+}
+
+func (m *mockConsentStore) Create(ctx context.Context, record *consent_entities.ConsentRecord) (*consent_entities.ConsentRecord, error) {
+	m.records = append(m.records, *record)
+	return record, nil
+}
+
+func newPolicyVersion(kind consent_entities.PolicyKind, effectiveFrom time.Time) consent_entities.PolicyVersion {
+	return consent_entities.PolicyVersion{
+		Kind:          kind,
+		URL:           "https://example.com/" + string(kind),
+		ContentHash:   strings.Repeat("ab", 32),
+		EffectiveFrom: effectiveFrom,
+	}
+}
+
+func TestPublishPolicyVersionUseCase_Exec(t *testing.T) {
+	store := &mockPolicyStore{}
+	usecase := consent_use_cases.NewPublishPolicyVersionUseCase(store, store)
+
+	first, err := usecase.Exec(systemContext(), newPolicyVersion(consent_entities.PolicyKindTermsOfService, time.Time{}))
+	assert.NoError(t, err)
+	assert.Equal(t, 1, first.Version)
+	assert.False(t, first.EffectiveFrom.IsZero())
+	assert.Equal(t, tenantID, first.ResourceOwner.TenantID)
+
+	second, err := usecase.Exec(systemContext(), newPolicyVersion(consent_entities.PolicyKindTermsOfService, time.Now().Add(24*time.Hour)))
+	assert.NoError(t, err)
+	assert.Equal(t, 2, second.Version)
+
+	// versioned per kind
+	privacy, err := usecase.Exec(systemContext(), newPolicyVersion(consent_entities.PolicyKindPrivacyPolicy, time.Time{}))
+	assert.NoError(t, err)
+	assert.Equal(t, 1, privacy.Version)
+
+	var invalidErr *consent.InvalidPolicyError
+
+	_, err = usecase.Exec(systemContext(), newPolicyVersion(consent_entities.PolicyKindTermsOfService, time.Now()))
+	assert.True(t, errors.As(err, &invalidErr), "effective before the latest version")
+
+	_, err = usecase.Exec(systemContext(), newPolicyVersion("cookie_policy", time.Time{}))
+	assert.True(t, errors.As(err, &invalidErr))
+}
+
+func TestAcceptPolicyUseCase_Exec(t *testing.T) {
+	userID := uuid.New()
+	now := time.Now().UTC()
+
+	policies := &mockPolicyStore{}
+	publish := consent_use_cases.NewPublishPolicyVersionUseCase(policies, policies)
+
+	_, err := publish.Exec(systemContext(), newPolicyVersion(consent_entities.PolicyKindTermsOfService, now.Add(-48*time.Hour)))
+	assert.NoError(t, err)
+
+	current, err := publish.Exec(systemContext(), newPolicyVersion(consent_entities.PolicyKindTermsOfService, now.Add(-time.Hour)))
+	assert.NoError(t, err)
+
+	// published, not yet effective
+	_, err = publish.Exec(systemContext(), newPolicyVersion(consent_entities.PolicyKindTermsOfService, now.Add(time.Hour)))
+	assert.NoError(t, err)
+
+	records := &mockConsentStore{}
+	usecase := consent_use_cases.NewAcceptPolicyUseCase(policies, records)
+
+	t.Run("Current Version", func(t *testing.T) {
+		record, err := usecase.Exec(userContext(userID), consent_in.AcceptPolicyCommand{Kind: consent_entities.PolicyKindTermsOfService, Version: 2, IPAddress: "203.0.113.7", UserAgent: "test"})
+		if !assert.NoError(t, err) {
+			return
+		}
+
+		assert.Equal(t, userID, record.UserID)
+		assert.Equal(t, current.ID, record.PolicyID)
+		assert.Equal(t, current.ContentHash, record.ContentHash)
+		assert.Equal(t, "203.0.113.7", record.IPAddress)
+		assert.Equal(t, "test", record.UserAgent)
+		assert.Len(t, records.records, 1)
+	})
+
+	t.Run("Outdated Or Not Yet Effective Version", func(t *testing.T) {
+		var outdatedErr *consent.OutdatedPolicyError
+
+		for _, version := range []int{1, 3} {
+			_, err := usecase.Exec(userContext(userID), consent_in.AcceptPolicyCommand{Kind: consent_entities.PolicyKindTermsOfService, Version: version})
+			assert.True(t, errors.As(err, &outdatedErr), "v%d", version)
+		}
+	})
+
+	t.Run("Nothing Published", func(t *testing.T) {
+		var notFoundErr *consent.PolicyNotFoundError
+
+		_, err := usecase.Exec(userContext(userID), consent_in.AcceptPolicyCommand{Kind: consent_entities.PolicyKindPrivacyPolicy, Version: 1})
+		assert.True(t, errors.As(err, &notFoundErr))
+	})
+
+	t.Run("Not A User", func(t *testing.T) {
+		var invalidErr *consent.InvalidPolicyError
+
+		_, err := usecase.Exec(systemContext(), consent_in.AcceptPolicyCommand{Kind: consent_entities.PolicyKindTermsOfService, Version: 2})
+		assert.True(t, errors.As(err, &invalidErr))
+	})
+
+	t.Run("Anonymous", func(t *testing.T) {
+		var invalidErr *consent.InvalidPolicyError
+
+		// the placeholder user of a request without a RID
+		anonymous := common.WithResourceOwner(context.Background(), common.ResourceOwner{TenantID: tenantID, ClientID: common.TeamPROAppClientID, UserID: uuid.New()})
+
+		_, err := usecase.Exec(anonymous, consent_in.AcceptPolicyCommand{Kind: consent_entities.PolicyKindTermsOfService, Version: 2})
+		assert.True(t, errors.As(err, &invalidErr))
+	})
+}
+
+func TestGetConsentStatusUseCase_Exec(t *testing.T) {
+	userID := uuid.New()
+	now := time.Now().UTC()
+
+	policies := &mockPolicyStore{}
+	publish := consent_use_cases.NewPublishPolicyVersionUseCase(policies, policies)
+
+	_, err := publish.Exec(systemContext(), newPolicyVersion(consent_entities.PolicyKindTermsOfService, now.Add(-time.Hour)))
+	assert.NoError(t, err)
+
+	_, err = publish.Exec(systemContext(), newPolicyVersion(consent_entities.PolicyKindPrivacyPolicy, now.Add(-time.Hour)))
+	assert.NoError(t, err)
+
+	records := &mockConsentStore{}
+	accept := consent_use_cases.NewAcceptPolicyUseCase(policies, records)
+	usecase := consent_use_cases.NewGetConsentStatusUseCase(policies, records)
+
+	for _, kind := range consent_entities.PolicyKinds {
+		_, err := accept.Exec(userContext(userID), consent_in.AcceptPolicyCommand{Kind: kind, Version: 1})
+		assert.NoError(t, err)
+	}
+
+	status, err := usecase.Exec(userContext(userID), userID)
+	if assert.NoError(t, err) {
+		assert.True(t, status.Current)
+		assert.Len(t, status.Policies, 2)
+	}
+
+	// a new version of the terms takes effect: re-acceptance pending
+	policies.policies = append(policies.policies, newPolicyVersion(consent_entities.PolicyKindTermsOfService, now.Add(-time.Minute)))
+	policies.policies[len(policies.policies)-1].Version = 2
+
+	status, err = usecase.Exec(userContext(userID), userID)
+	if assert.NoError(t, err) && assert.Len(t, status.Policies, 2) {
+		assert.False(t, status.Current)
+		assert.Equal(t, consent_entities.PolicyKindTermsOfService, status.Policies[0].Kind)
+		assert.True(t, status.Policies[0].Reacceptance)
+		assert.True(t, status.Policies[1].Accepted)
+	}
+
+	// other users haven't accepted anything
+	status, err = usecase.Exec(userContext(userID), uuid.New())
+	if assert.NoError(t, err) {
+		assert.False(t, status.Current)
+		assert.False(t, status.Policies[0].Reacceptance)
+	}
+}
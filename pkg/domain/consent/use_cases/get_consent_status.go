@@ -0,0 +1,49 @@
+package consent_use_cases
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	consent_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/consent/entities"
+	consent_in "github.com/psavelis/team-pro/replay-api/pkg/domain/consent/ports/in"
+	consent_out "github.com/psavelis/team-pro/replay-api/pkg/domain/consent/ports/out"
+)
+
+type GetConsentStatusUseCase struct {
+	PolicyReader  consent_out.PolicyVersionReader
+	ConsentReader consent_out.ConsentRecordReader
+}
+
+func NewGetConsentStatusUseCase(policyReader consent_out.PolicyVersionReader, consentReader consent_out.ConsentRecordReader) consent_in.GetConsentStatusQuery {
+	return &GetConsentStatusUseCase{
+		PolicyReader:  policyReader,
+		ConsentReader: consentReader,
+	}
+}
+
+// Exec skips the policies the tenant didn't publish (yet).
+func (usecase *GetConsentStatusUseCase) Exec(ctx context.Context, userID uuid.UUID) (*consent_entities.ConsentStatus, error) {
+	now := time.Now().UTC()
+
+	policies := []consent_entities.PolicyConsent{}
+	for _, kind := range consent_entities.PolicyKinds {
+		current, err := latestPolicyVersion(ctx, usecase.PolicyReader, kind, &now)
+		if err != nil {
+			return nil, err
+		}
+
+		if current == nil {
+			continue
+		}
+
+		last, err := lastConsent(ctx, usecase.ConsentReader, userID, kind)
+		if err != nil {
+			return nil, err
+		}
+
+		policies = append(policies, consent_entities.NewPolicyConsent(*current, last))
+	}
+
+	return consent_entities.NewConsentStatus(userID, policies), nil
+}
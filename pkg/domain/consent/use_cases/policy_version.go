@@ -0,0 +1,60 @@
+package consent_use_cases
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	consent_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/consent/entities"
+	consent_out "github.com/psavelis/team-pro/replay-api/pkg/domain/consent/ports/out"
+)
+
+// latestPolicyVersion returns the highest version of the policy (effective at the given time, when not nil), nil when there's none.
+func latestPolicyVersion(ctx context.Context, reader consent_out.PolicyVersionReader, kind consent_entities.PolicyKind, at *time.Time) (*consent_entities.PolicyVersion, error) {
+	values := []common.SearchableValue{
+		{Field: "Kind", Values: []interface{}{kind}},
+	}
+
+	if at != nil {
+		values = append(values, common.SearchableValue{Field: "EffectiveFrom", Operator: common.LessThanOrEqualOperator, Values: []interface{}{*at}})
+	}
+
+	search := common.NewSearchByValues(ctx, values, common.NewSearchResultOptions(0, 1), common.ClientApplicationAudienceIDKey)
+	search.SortOptions = []common.SearchSortOption{{Field: "Version", Direction: common.DescendingIDKey}}
+
+	policies, err := reader.Search(ctx, search)
+	if err != nil {
+		slog.ErrorContext(ctx, "unable to search policy versions", "kind", kind, "err", err)
+		return nil, err
+	}
+
+	if len(policies) == 0 {
+		return nil, nil
+	}
+
+	return &policies[0], nil
+}
+
+// lastConsent returns the acceptance of the policy with the highest version by the user, nil when the user never accepted it.
+func lastConsent(ctx context.Context, reader consent_out.ConsentRecordReader, userID uuid.UUID, kind consent_entities.PolicyKind) (*consent_entities.ConsentRecord, error) {
+	search := common.NewSearchByValues(ctx, []common.SearchableValue{
+		{Field: "UserID", Values: []interface{}{userID}},
+		{Field: "Kind", Values: []interface{}{kind}},
+	}, common.NewSearchResultOptions(0, 1), common.ClientApplicationAudienceIDKey)
+
+	search.SortOptions = []common.SearchSortOption{{Field: "Version", Direction: common.DescendingIDKey}}
+
+	records, err := reader.Search(ctx, search)
+	if err != nil {
+		slog.ErrorContext(ctx, "unable to search consent records", "userID", userID, "kind", kind, "err", err)
+		return nil, err
+	}
+
+	if len(records) == 0 {
+		return nil, nil
+	}
+
+	return &records[0], nil
+}
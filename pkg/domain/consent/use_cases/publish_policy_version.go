@@ -0,0 +1,71 @@
+package consent_use_cases
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	"github.com/psavelis/team-pro/replay-api/pkg/domain/consent"
+	consent_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/consent/entities"
+	consent_in "github.com/psavelis/team-pro/replay-api/pkg/domain/consent/ports/in"
+	consent_out "github.com/psavelis/team-pro/replay-api/pkg/domain/consent/ports/out"
+)
+
+type PublishPolicyVersionUseCase struct {
+	PolicyReader consent_out.PolicyVersionReader
+	PolicyWriter consent_out.PolicyVersionWriter
+}
+
+func NewPublishPolicyVersionUseCase(policyReader consent_out.PolicyVersionReader, policyWriter consent_out.PolicyVersionWriter) consent_in.PublishPolicyVersionCommand {
+	return &PublishPolicyVersionUseCase{
+		PolicyReader: policyReader,
+		PolicyWriter: policyWriter,
+	}
+}
+
+func (usecase *PublishPolicyVersionUseCase) Exec(ctx context.Context, policy consent_entities.PolicyVersion) (*consent_entities.PolicyVersion, error) {
+	err := policy.Validate()
+	if err != nil {
+		return nil, consent.NewInvalidPolicyError(err.Error())
+	}
+
+	now := time.Now().UTC()
+
+	if policy.EffectiveFrom.IsZero() {
+		policy.EffectiveFrom = now
+	}
+
+	policy.EffectiveFrom = policy.EffectiveFrom.UTC()
+
+	latest, err := latestPolicyVersion(ctx, usecase.PolicyReader, policy.Kind, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	policy.Version = 1
+
+	// versions take effect in order, a version can't go back in time over the previous one
+	if latest != nil {
+		if policy.EffectiveFrom.Before(latest.EffectiveFrom) {
+			return nil, consent.NewInvalidPolicyError(fmt.Sprintf("effective_from can't be earlier than the current version (v%d, effective from %s)", latest.Version, latest.EffectiveFrom.Format(time.RFC3339)))
+		}
+
+		policy.Version = latest.Version + 1
+	}
+
+	policy.ID = uuid.New()
+	policy.ResourceOwner = common.GetResourceOwner(ctx)
+	policy.CreatedAt = now
+	policy.UpdatedAt = now
+
+	created, err := usecase.PolicyWriter.Create(ctx, &policy)
+	if err != nil {
+		slog.ErrorContext(ctx, "unable to publish policy version", "kind", policy.Kind, "version", policy.Version, "err", err)
+		return nil, err
+	}
+
+	return created, nil
+}
@@ -39,6 +39,10 @@ const (
 	SouthAmerica_RegionIDKey RegionIDKey = "SA"
 	NorthAmerica_RegionIDKey RegionIDKey = "NA"
 	Asia_RegionIDKey         RegionIDKey = "AS"
+	Europe_RegionIDKey       RegionIDKey = "EU"
+	Oceania_RegionIDKey      RegionIDKey = "OC"
+	Africa_RegionIDKey       RegionIDKey = "AF"
+	MiddleEast_RegionIDKey   RegionIDKey = "ME"
 	Global_RegionIDKey       RegionIDKey = "GL"
 	// TODO: espelhar do cs2 ou usar mais granular como por server msm?
 )
@@ -1,19 +1,16 @@
 package common
 
+// ContextKey names request parameters and headers. Tenancy isn't set with raw keys, see RequestScope.
 type ContextKey string
 
 const (
-	// Tenancy (internal)
-	TenantIDKey ContextKey = "tenant_id"
-	ClientIDKey ContextKey = "client_id"
-	GroupIDKey  ContextKey = "group_id"
-	UserIDKey   ContextKey = "user_id"
-
 	// Parameters
-	GameIDParamKey  ContextKey = "game_id"
-	MatchIDParamKey ContextKey = "match_id"
+	GameIDParamKey     ContextKey = "game_id"
+	MatchIDParamKey    ContextKey = "match_id"
+	VisibilityParamKey ContextKey = "visibility" // requested by the creator (common.Visibility), the default of its policy otherwise
 
 	// Request (ie: msg header, meta)
-	RequestIDKey            ContextKey = "x-request-id"
+	RequestIDParamKey       ContextKey = "X-Request-ID"
 	ResourceOwnerIDParamKey ContextKey = "X-Resource-Owner-ID"
+	APIKeyParamKey          ContextKey = "X-API-Key"
 )
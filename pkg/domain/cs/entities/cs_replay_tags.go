@@ -0,0 +1,89 @@
+package entities
+
+import (
+	"math"
+	"regexp"
+	"strings"
+
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+)
+
+var (
+	// ie: "Valve Counter-Strike 2 south_america Server (srcds1021-gru5.379.33)"
+	valveServerPattern     = regexp.MustCompile(`^Valve .*Server\b`)
+	valveRegionPattern     = regexp.MustCompile(`^Valve .+ ([a-z_]+) Server\b`)
+	valveDatacenterPattern = regexp.MustCompile(`srcds\d*-([a-z]{3})\d*\.`)
+	lanServerPattern       = regexp.MustCompile(`\bLAN\b`)
+)
+
+// valveServerRegions are the regions named by the official servers.
+var valveServerRegions = map[string]common.RegionIDKey{
+	"south_america": common.SouthAmerica_RegionIDKey,
+	"north_america": common.NorthAmerica_RegionIDKey,
+	"us_east":       common.NorthAmerica_RegionIDKey,
+	"us_west":       common.NorthAmerica_RegionIDKey,
+	"europe":        common.Europe_RegionIDKey,
+	"eu_west":       common.Europe_RegionIDKey,
+	"eu_east":       common.Europe_RegionIDKey,
+	"asia":          common.Asia_RegionIDKey,
+	"japan":         common.Asia_RegionIDKey,
+	"korea":         common.Asia_RegionIDKey,
+	"india":         common.Asia_RegionIDKey,
+	"china":         common.Asia_RegionIDKey,
+	"australia":     common.Oceania_RegionIDKey,
+	"south_africa":  common.Africa_RegionIDKey,
+	"middle_east":   common.MiddleEast_RegionIDKey,
+}
+
+// valveDatacenterRegions are the regions of the datacenters (Steam Datagram Relay POPs) hosting the official servers, for the servers
+// whose name has no region.
+var valveDatacenterRegions = map[string]common.RegionIDKey{
+	"gru": common.SouthAmerica_RegionIDKey, "scl": common.SouthAmerica_RegionIDKey, "lim": common.SouthAmerica_RegionIDKey, "eze": common.SouthAmerica_RegionIDKey,
+	"iad": common.NorthAmerica_RegionIDKey, "atl": common.NorthAmerica_RegionIDKey, "ord": common.NorthAmerica_RegionIDKey, "dfw": common.NorthAmerica_RegionIDKey, "lax": common.NorthAmerica_RegionIDKey, "sea": common.NorthAmerica_RegionIDKey,
+	"ams": common.Europe_RegionIDKey, "fra": common.Europe_RegionIDKey, "lhr": common.Europe_RegionIDKey, "par": common.Europe_RegionIDKey, "mad": common.Europe_RegionIDKey, "vie": common.Europe_RegionIDKey, "waw": common.Europe_RegionIDKey, "sto": common.Europe_RegionIDKey, "hel": common.Europe_RegionIDKey, "lux": common.Europe_RegionIDKey,
+	"sgp": common.Asia_RegionIDKey, "hkg": common.Asia_RegionIDKey, "tyo": common.Asia_RegionIDKey, "seo": common.Asia_RegionIDKey, "bom": common.Asia_RegionIDKey, "maa": common.Asia_RegionIDKey, "sha": common.Asia_RegionIDKey, "can": common.Asia_RegionIDKey, "tsn": common.Asia_RegionIDKey,
+	"syd": common.Oceania_RegionIDKey,
+	"jnb": common.Africa_RegionIDKey,
+	"dxb": common.MiddleEast_RegionIDKey,
+}
+
+// DetectReplayTags tags the replay by the server it was recorded on: LAN servers (sv_lan, or named so), FACEIT servers, and the official
+// servers (Valve matchmaking, whose name holds their region and datacenter). The tickrate is the one sent by the server, or the one of the
+// recorded ticks when it wasn't received.
+func DetectReplayTags(h CSReplayFileHeader) common.ReplayTags {
+	tags := common.ReplayTags{
+		Platform: common.ReplayPlatformCommunity,
+		Tickrate: int(math.Round(h.TickRate)),
+	}
+
+	if tags.Tickrate == 0 && h.Length > 0 {
+		tags.Tickrate = int(math.Round(float64(h.Ticks) / h.Length.Seconds()))
+	}
+
+	switch {
+	case h.LAN || lanServerPattern.MatchString(h.ServerName):
+		tags.Platform = common.ReplayPlatformLAN
+
+	case strings.Contains(strings.ToLower(h.ServerName), "faceit") || strings.Contains(strings.ToLower(h.ClientName), "faceit"):
+		tags.Platform = common.ReplayPlatformFaceit
+
+	case valveServerPattern.MatchString(h.ServerName):
+		tags.Platform = common.ReplayPlatformValveMM
+
+		if m := valveDatacenterPattern.FindStringSubmatch(h.ServerName); m != nil {
+			tags.ServerLocation = m[1]
+			tags.ServerRegion = valveDatacenterRegions[m[1]]
+		}
+
+		if m := valveRegionPattern.FindStringSubmatch(h.ServerName); m != nil {
+			if region, ok := valveServerRegions[m[1]]; ok {
+				tags.ServerRegion = region
+			}
+		}
+
+	case strings.TrimSpace(h.ServerName) == "":
+		tags.Platform = common.ReplayPlatformUnknown
+	}
+
+	return tags
+}
@@ -0,0 +1,52 @@
+package entities_test
+
+import (
+	"testing"
+	"time"
+
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	cs_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/cs/entities"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDetectReplayTags(t *testing.T) {
+	cases := []struct {
+		header   cs_entities.CSReplayFileHeader
+		expected common.ReplayTags
+	}{
+		{
+			header:   cs_entities.CSReplayFileHeader{ServerName: "Valve Counter-Strike 2 south_america Server (srcds1021-gru5.379.33)", ClientName: "SourceTV Demo", TickRate: 64},
+			expected: common.ReplayTags{Platform: common.ReplayPlatformValveMM, ServerRegion: common.SouthAmerica_RegionIDKey, ServerLocation: "gru", Tickrate: 64},
+		},
+		{
+			// no region named, the one of the datacenter
+			header:   cs_entities.CSReplayFileHeader{ServerName: "Valve Counter-Strike 2 Server (srcds2048-fra2.128.12)", TickRate: 63.99},
+			expected: common.ReplayTags{Platform: common.ReplayPlatformValveMM, ServerRegion: common.Europe_RegionIDKey, ServerLocation: "fra", Tickrate: 64},
+		},
+		{
+			header:   cs_entities.CSReplayFileHeader{ServerName: "FACEIT.com register to play here", TickRate: 128},
+			expected: common.ReplayTags{Platform: common.ReplayPlatformFaceit, Tickrate: 128},
+		},
+		{
+			// the tickrate of the recorded ticks, when not sent by the server
+			header:   cs_entities.CSReplayFileHeader{ServerName: "Major Stage 1", LAN: true, Ticks: 64 * 60, Length: time.Minute},
+			expected: common.ReplayTags{Platform: common.ReplayPlatformLAN, Tickrate: 64},
+		},
+		{
+			header:   cs_entities.CSReplayFileHeader{ServerName: "BLAST LAN Server #2", TickRate: 128},
+			expected: common.ReplayTags{Platform: common.ReplayPlatformLAN, Tickrate: 128},
+		},
+		{
+			header:   cs_entities.CSReplayFileHeader{ServerName: "Retake | Brasil #1", TickRate: 64},
+			expected: common.ReplayTags{Platform: common.ReplayPlatformCommunity, Tickrate: 64},
+		},
+		{
+			header:   cs_entities.CSReplayFileHeader{},
+			expected: common.ReplayTags{Platform: common.ReplayPlatformUnknown},
+		},
+	}
+
+	for _, c := range cases {
+		assert.Equal(t, c.expected, cs_entities.DetectReplayTags(c.header), c.header.ServerName)
+	}
+}
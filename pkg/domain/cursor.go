@@ -0,0 +1,26 @@
+package common
+
+import (
+	"context"
+	"fmt"
+)
+
+// CursorPaginated is implemented by readers able to resume a search right after the last result of a page, sorting by the search
+// sort options and the entity ID (ties). Unlike Skip, the cost of a page doesn't grow with its position.
+type CursorPaginated interface {
+	// NextCursor returns the continuation token resuming the search after last (the last result of a page of s).
+	NextCursor(ctx context.Context, s Search, last interface{}) (string, error)
+}
+
+func ValidateCursorOptions(resultOptions SearchResultOptions) error {
+	if resultOptions.Cursor != nil && resultOptions.Skip > 0 {
+		return fmt.Errorf("skip can't be combined with cursor pagination")
+	}
+
+	return nil
+}
+
+// HasNextPage tells whether a page of results may be followed by another one (a full page).
+func HasNextPage(resultOptions SearchResultOptions, results int) bool {
+	return resultOptions.Limit > 0 && uint(results) >= resultOptions.Limit
+}
@@ -0,0 +1,41 @@
+package common
+
+import (
+	"regexp"
+	"strings"
+)
+
+// CustomFieldsField is the field of the custom fields of an entity, each one searched (and sorted) by its key: "CustomFields.<key>".
+const CustomFieldsField = "CustomFields"
+
+var customFieldKeyPattern = regexp.MustCompile(`^[a-z][a-z0-9_]{0,39}$`)
+
+// CustomFields are the values of the custom fields a tenant defined for its entities (ie: the "team_code" of its squads), by key.
+// Validated against the definitions of the tenant before being set.
+type CustomFields map[string]interface{}
+
+// IsCustomFieldKey accepts up to 40 lowercase letters, digits or '_', starting with a letter (the key is a bson field name).
+func IsCustomFieldKey(key string) bool {
+	return customFieldKeyPattern.MatchString(key)
+}
+
+// CustomFieldKey returns the key of a custom field searched, ie: "team_code" of "CustomFields.team_code".
+func CustomFieldKey(field string) (string, bool) {
+	key, ok := strings.CutPrefix(field, CustomFieldsField+".")
+	if !ok || !IsCustomFieldKey(key) {
+		return "", false
+	}
+
+	return key, true
+}
+
+// isQueryableField reports whether a field may be searched: a queryable field, or a custom field of an entity whose custom fields are.
+func isQueryableField(field string, queryableFields map[string]bool) bool {
+	if _, ok := CustomFieldKey(field); ok {
+		field = CustomFieldsField
+	}
+
+	allowed, exists := queryableFields[field]
+
+	return exists && allowed
+}
@@ -0,0 +1,236 @@
+package customfield_entities
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+)
+
+// CustomFieldEntityType is the type of the entities a custom field is set on.
+type CustomFieldEntityType string
+
+const (
+	CustomFieldEntitySquad  CustomFieldEntityType = "squad"
+	CustomFieldEntityPlayer CustomFieldEntityType = "player"
+)
+
+var CustomFieldEntityTypes = []CustomFieldEntityType{CustomFieldEntitySquad, CustomFieldEntityPlayer}
+
+// CustomFieldType is the type of the values of a custom field, as stored (and searched).
+type CustomFieldType string
+
+const (
+	CustomFieldText    CustomFieldType = "text"
+	CustomFieldNumber  CustomFieldType = "number"
+	CustomFieldBoolean CustomFieldType = "boolean"
+	CustomFieldEnum    CustomFieldType = "enum" // one of the options of the field
+	CustomFieldDate    CustomFieldType = "date" // "2006-01-02", sorted as text
+)
+
+const (
+	CustomFieldDateLayout = "2006-01-02"
+
+	MaxCustomFieldDefinitions = 50 // per entity type, of a client application
+	MaxCustomFieldOptions     = 50
+	DefaultCustomFieldLength  = 256 // of the text values, when the field sets no MaxLength
+	MaxCustomFieldLength      = 1024
+	maxCustomFieldLabelLength = 64
+)
+
+// customFieldDefinitionNamespace keeps a single definition per key (tenant+client+entity type+key): defining it again updates it.
+var customFieldDefinitionNamespace = uuid.MustParse("5c2e8f71-0b3d-4e96-a1f4-7d8c9b2a6e35")
+
+// CustomFieldDefinition is a field a client application (ie: a B2B tenant) sets on its entities of a type, besides theirs (ie: the
+// internal code of its squads, or the sponsor of its players). The values set are checked against the definitions of the type.
+type CustomFieldDefinition struct {
+	ID            uuid.UUID             `json:"id" bson:"_id"`
+	EntityType    CustomFieldEntityType `json:"entity_type" bson:"entity_type"`
+	Key           string                `json:"key" bson:"key"` // searched as "CustomFields.<key>"
+	Label         string                `json:"label" bson:"label"`
+	Type          CustomFieldType       `json:"type" bson:"type"`
+	Options       []string              `json:"options,omitempty" bson:"options,omitempty"`       // of the enum fields
+	MaxLength     int                   `json:"max_length,omitempty" bson:"max_length,omitempty"` // of the text fields
+	Required      bool                  `json:"required" bson:"required"`
+	ResourceOwner common.ResourceOwner  `json:"resource_owner" bson:"resource_owner"`
+	CreatedAt     time.Time             `json:"created_at" bson:"created_at"`
+	UpdatedAt     time.Time             `json:"updated_at" bson:"updated_at"`
+}
+
+func (d CustomFieldDefinition) GetID() uuid.UUID {
+	return d.ID
+}
+
+func CustomFieldDefinitionID(resourceOwner common.ResourceOwner, entityType CustomFieldEntityType, key string) uuid.UUID {
+	return uuid.NewSHA1(customFieldDefinitionNamespace, []byte(fmt.Sprintf("%s|%s|%s|%s", resourceOwner.TenantID, resourceOwner.ClientID, entityType, key)))
+}
+
+// Validate checks the key, the type and the constraints of the field.
+func (d CustomFieldDefinition) Validate() error {
+	if !slices.Contains(CustomFieldEntityTypes, d.EntityType) {
+		return fmt.Errorf("unknown entity type %q", d.EntityType)
+	}
+
+	if !common.IsCustomFieldKey(d.Key) {
+		return fmt.Errorf("key %q must be up to 40 lowercase letters, digits or '_', starting with a letter", d.Key)
+	}
+
+	if d.Label == "" || len(d.Label) > maxCustomFieldLabelLength {
+		return fmt.Errorf("label is required (up to %d characters)", maxCustomFieldLabelLength)
+	}
+
+	switch d.Type {
+	case CustomFieldText, CustomFieldNumber, CustomFieldBoolean, CustomFieldDate:
+		if len(d.Options) > 0 {
+			return fmt.Errorf("options are only allowed on %s fields", CustomFieldEnum)
+		}
+	case CustomFieldEnum:
+		if len(d.Options) == 0 || len(d.Options) > MaxCustomFieldOptions {
+			return fmt.Errorf("%s fields require 1 to %d options", CustomFieldEnum, MaxCustomFieldOptions)
+		}
+
+		for i, option := range d.Options {
+			if option == "" || slices.Contains(d.Options[:i], option) {
+				return fmt.Errorf("options must be distinct and not empty")
+			}
+		}
+	default:
+		return fmt.Errorf("unknown type %q", d.Type)
+	}
+
+	if d.MaxLength != 0 && (d.Type != CustomFieldText || d.MaxLength < 0 || d.MaxLength > MaxCustomFieldLength) {
+		return fmt.Errorf("max_length is only allowed on %s fields (up to %d)", CustomFieldText, MaxCustomFieldLength)
+	}
+
+	return nil
+}
+
+// Normalize checks a value against the field, returning it as stored: numbers as float64 (the same type of the values searched,
+// decoded from JSON), and dates as "2006-01-02".
+func (d CustomFieldDefinition) Normalize(value interface{}) (interface{}, error) {
+	switch d.Type {
+	case CustomFieldText:
+		s, ok := value.(string)
+		if !ok {
+			return nil, fmt.Errorf("%s must be a text", d.Key)
+		}
+
+		maxLength := d.MaxLength
+		if maxLength == 0 {
+			maxLength = DefaultCustomFieldLength
+		}
+
+		if len([]rune(s)) > maxLength {
+			return nil, fmt.Errorf("%s is longer than %d characters", d.Key, maxLength)
+		}
+
+		return s, nil
+
+	case CustomFieldNumber:
+		var n float64
+		switch v := value.(type) {
+		case float64:
+			n = v
+		case float32:
+			n = float64(v)
+		case int:
+			n = float64(v)
+		case int32:
+			n = float64(v)
+		case int64:
+			n = float64(v)
+		default:
+			return nil, fmt.Errorf("%s must be a number", d.Key)
+		}
+
+		if math.IsNaN(n) || math.IsInf(n, 0) {
+			return nil, fmt.Errorf("%s must be a finite number", d.Key)
+		}
+
+		return n, nil
+
+	case CustomFieldBoolean:
+		b, ok := value.(bool)
+		if !ok {
+			return nil, fmt.Errorf("%s must be true or false", d.Key)
+		}
+
+		return b, nil
+
+	case CustomFieldEnum:
+		s, ok := value.(string)
+		if !ok || !slices.Contains(d.Options, s) {
+			return nil, fmt.Errorf("%s must be one of %v", d.Key, d.Options)
+		}
+
+		return s, nil
+
+	case CustomFieldDate:
+		s, ok := value.(string)
+		if !ok {
+			return nil, fmt.Errorf("%s must be a date (%s)", d.Key, CustomFieldDateLayout)
+		}
+
+		date, err := time.Parse(CustomFieldDateLayout, s)
+		if err != nil {
+			return nil, fmt.Errorf("%s must be a date (%s)", d.Key, CustomFieldDateLayout)
+		}
+
+		return date.Format(CustomFieldDateLayout), nil
+
+	default:
+		return nil, fmt.Errorf("unknown type %q of %s", d.Type, d.Key)
+	}
+}
+
+// CustomFieldSchema are the definitions of the custom fields of an entity type.
+type CustomFieldSchema []CustomFieldDefinition
+
+func (schema CustomFieldSchema) Definition(key string) (CustomFieldDefinition, bool) {
+	for _, d := range schema {
+		if d.Key == key {
+			return d, true
+		}
+	}
+
+	return CustomFieldDefinition{}, false
+}
+
+// Validate checks the values set on an entity, returning them as stored. Values of keys not defined are rejected, null values unset
+// the field (the required ones can't be unset).
+func (schema CustomFieldSchema) Validate(fields common.CustomFields) (common.CustomFields, error) {
+	definitions := make(map[string]CustomFieldDefinition, len(schema))
+	for _, d := range schema {
+		definitions[d.Key] = d
+	}
+
+	res := make(common.CustomFields, len(fields))
+	for key, value := range fields {
+		d, ok := definitions[key]
+		if !ok {
+			return nil, fmt.Errorf("custom field %q isn't defined", key)
+		}
+
+		if value == nil {
+			continue
+		}
+
+		v, err := d.Normalize(value)
+		if err != nil {
+			return nil, err
+		}
+
+		res[key] = v
+	}
+
+	for _, d := range schema {
+		if _, ok := res[d.Key]; d.Required && !ok {
+			return nil, fmt.Errorf("%s is required", d.Key)
+		}
+	}
+
+	return res, nil
+}
@@ -0,0 +1,86 @@
+package customfield_entities_test
+
+import (
+	"testing"
+
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	customfield_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/customfield/entities"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCustomFieldDefinition_Validate(t *testing.T) {
+	valid := customfield_entities.CustomFieldDefinition{EntityType: customfield_entities.CustomFieldEntitySquad, Key: "team_code", Label: "Team code", Type: customfield_entities.CustomFieldText, MaxLength: 16}
+	assert.NoError(t, valid.Validate())
+
+	invalid := []func(d *customfield_entities.CustomFieldDefinition){
+		func(d *customfield_entities.CustomFieldDefinition) { d.EntityType = "tournament" },
+		func(d *customfield_entities.CustomFieldDefinition) { d.Key = "Team.Code" },
+		func(d *customfield_entities.CustomFieldDefinition) { d.Key = "1st" },
+		func(d *customfield_entities.CustomFieldDefinition) { d.Label = "" },
+		func(d *customfield_entities.CustomFieldDefinition) { d.Type = "json" },
+		func(d *customfield_entities.CustomFieldDefinition) { d.MaxLength = 4096 },
+		func(d *customfield_entities.CustomFieldDefinition) { d.Options = []string{"gold"} },
+		func(d *customfield_entities.CustomFieldDefinition) {
+			d.Type, d.MaxLength = customfield_entities.CustomFieldEnum, 0
+		},
+		func(d *customfield_entities.CustomFieldDefinition) {
+			d.Type, d.MaxLength, d.Options = customfield_entities.CustomFieldEnum, 0, []string{"gold", "gold"}
+		},
+	}
+
+	for i, invalidate := range invalid {
+		d := valid
+		invalidate(&d)
+
+		assert.Error(t, d.Validate(), i)
+	}
+}
+
+func TestCustomFieldSchema_Validate(t *testing.T) {
+	schema := customfield_entities.CustomFieldSchema{
+		{Key: "team_code", Type: customfield_entities.CustomFieldText, MaxLength: 4, Required: true},
+		{Key: "budget", Type: customfield_entities.CustomFieldNumber},
+		{Key: "academy", Type: customfield_entities.CustomFieldBoolean},
+		{Key: "sponsor_tier", Type: customfield_entities.CustomFieldEnum, Options: []string{"gold", "silver"}},
+		{Key: "sponsor_since", Type: customfield_entities.CustomFieldDate},
+	}
+
+	fields, err := schema.Validate(common.CustomFields{
+		"team_code":     "A-1",
+		"budget":        1500,
+		"academy":       true,
+		"sponsor_tier":  "gold",
+		"sponsor_since": "2026-01-31",
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, common.CustomFields{
+		"team_code":     "A-1",
+		"budget":        float64(1500),
+		"academy":       true,
+		"sponsor_tier":  "gold",
+		"sponsor_since": "2026-01-31",
+	}, fields)
+
+	// null values unset the field
+	fields, err = schema.Validate(common.CustomFields{"team_code": "A-1", "budget": nil})
+	assert.NoError(t, err)
+	assert.Equal(t, common.CustomFields{"team_code": "A-1"}, fields)
+
+	invalid := []common.CustomFields{
+		{},                                    // required
+		{"team_code": nil},                    // required
+		{"team_code": "A-100"},                // too long
+		{"team_code": 1},                      // not a text
+		{"team_code": "A-1", "budget": "10"},  // not a number
+		{"team_code": "A-1", "academy": "no"}, // not a boolean
+		{"team_code": "A-1", "sponsor_tier": "bronze"},
+		{"team_code": "A-1", "sponsor_since": "31/01/2026"},
+		{"team_code": "A-1", "league": "ESL"}, // not defined
+	}
+
+	for i, f := range invalid {
+		_, err := schema.Validate(f)
+		assert.Error(t, err, i)
+	}
+}
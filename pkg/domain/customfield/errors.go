@@ -0,0 +1,53 @@
+package customfield
+
+import (
+	"fmt"
+
+	"github.com/google/uuid"
+	customfield_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/customfield/entities"
+)
+
+// Invalid Custom Field Definition Error (ie: unknown type, an enum without options, too many fields of the entity type)
+type InvalidCustomFieldDefinitionError struct {
+	Message string
+}
+
+func (e *InvalidCustomFieldDefinitionError) Error() string {
+	return e.Message
+}
+
+func NewInvalidCustomFieldDefinitionError(message string) *InvalidCustomFieldDefinitionError {
+	return &InvalidCustomFieldDefinitionError{
+		Message: message,
+	}
+}
+
+// Invalid Custom Fields Error (the values set don't match the definitions of the entity type)
+type InvalidCustomFieldsError struct {
+	Message string
+}
+
+func (e *InvalidCustomFieldsError) Error() string {
+	return e.Message
+}
+
+func NewInvalidCustomFieldsError(message string) *InvalidCustomFieldsError {
+	return &InvalidCustomFieldsError{
+		Message: message,
+	}
+}
+
+// Custom Fields Entity Not Found Error (no entity of the type with the ID, in the tenant)
+type CustomFieldsEntityNotFoundError struct {
+	Message string
+}
+
+func (e *CustomFieldsEntityNotFoundError) Error() string {
+	return e.Message
+}
+
+func NewCustomFieldsEntityNotFoundError(entityType customfield_entities.CustomFieldEntityType, id uuid.UUID) *CustomFieldsEntityNotFoundError {
+	return &CustomFieldsEntityNotFoundError{
+		Message: fmt.Sprintf("%s %s not found", entityType, id),
+	}
+}
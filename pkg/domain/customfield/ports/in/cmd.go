@@ -0,0 +1,26 @@
+package customfield_in
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	customfield_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/customfield/entities"
+)
+
+// DefineCustomFieldCommand defines (or updates) a custom field of an entity type, a *customfield.InvalidCustomFieldDefinitionError
+// when invalid.
+type DefineCustomFieldCommand interface {
+	Exec(ctx context.Context, definition customfield_entities.CustomFieldDefinition) (*customfield_entities.CustomFieldDefinition, error)
+}
+
+type SetCustomFieldsCommand struct {
+	EntityType customfield_entities.CustomFieldEntityType `json:"-"`
+	EntityID   uuid.UUID                                  `json:"-"`
+	Fields     common.CustomFields                        `json:"custom_fields"`
+}
+
+// SetCustomFieldsCommandHandler replaces the custom fields of an entity, returning them as stored.
+type SetCustomFieldsCommandHandler interface {
+	Exec(ctx context.Context, cmd SetCustomFieldsCommand) (common.CustomFields, error)
+}
@@ -0,0 +1,10 @@
+package customfield_in
+
+import (
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	customfield_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/customfield/entities"
+)
+
+type CustomFieldDefinitionReader interface {
+	common.Searchable[customfield_entities.CustomFieldDefinition]
+}
@@ -0,0 +1,21 @@
+package customfield_out
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	customfield_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/customfield/entities"
+)
+
+type CustomFieldDefinitionWriter interface {
+	// Save creates or replaces the definition (by its stable ID).
+	Save(ctx context.Context, definition *customfield_entities.CustomFieldDefinition) (*customfield_entities.CustomFieldDefinition, error)
+}
+
+// CustomFieldsWriter sets the custom fields of the entities of a type (ie: the squads).
+type CustomFieldsWriter interface {
+	// SetCustomFields replaces the custom fields of the entity of the tenant in context, a *customfield.CustomFieldsEntityNotFoundError
+	// when there's none with the ID.
+	SetCustomFields(ctx context.Context, id uuid.UUID, fields common.CustomFields) error
+}
@@ -0,0 +1,45 @@
+package customfield_services
+
+import (
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	customfield_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/customfield/entities"
+	customfield_in "github.com/psavelis/team-pro/replay-api/pkg/domain/customfield/ports/in"
+	customfield_out "github.com/psavelis/team-pro/replay-api/pkg/domain/customfield/ports/out"
+)
+
+// NewCustomFieldDefinitionQueryService serves the custom fields defined by the client application in context.
+func NewCustomFieldDefinitionQueryService(definitionReader customfield_out.CustomFieldDefinitionReader) customfield_in.CustomFieldDefinitionReader {
+	queryableFields := map[string]bool{
+		"ID":            true,
+		"EntityType":    true,
+		"Key":           true,
+		"Label":         true,
+		"Type":          true,
+		"Required":      true,
+		"ResourceOwner": common.DENY,
+		"CreatedAt":     true,
+		"UpdatedAt":     true,
+	}
+
+	readableFields := map[string]bool{
+		"ID":            true,
+		"EntityType":    true,
+		"Key":           true,
+		"Label":         true,
+		"Type":          true,
+		"Options":       true,
+		"MaxLength":     true,
+		"Required":      true,
+		"ResourceOwner": common.DENY,
+		"CreatedAt":     true,
+		"UpdatedAt":     true,
+	}
+
+	return &common.BaseQueryService[customfield_entities.CustomFieldDefinition]{
+		Reader:          definitionReader.(common.Searchable[customfield_entities.CustomFieldDefinition]),
+		QueryableFields: queryableFields,
+		ReadableFields:  readableFields,
+		MaxPageSize:     100,
+		Audience:        common.ClientApplicationAudienceIDKey,
+	}
+}
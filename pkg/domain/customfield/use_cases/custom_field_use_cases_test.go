@@ -0,0 +1,138 @@
+package customfield_use_cases_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/google/uuid"
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	"github.com/psavelis/team-pro/replay-api/pkg/domain/customfield"
+	customfield_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/customfield/entities"
+	customfield_in "github.com/psavelis/team-pro/replay-api/pkg/domain/customfield/ports/in"
+	customfield_out "github.com/psavelis/team-pro/replay-api/pkg/domain/customfield/ports/out"
+	customfield_use_cases "github.com/psavelis/team-pro/replay-api/pkg/domain/customfield/use_cases"
+	"github.com/stretchr/testify/assert"
+)
+
+// mockDefinitionStore keeps the definitions by ID, searches return all the definitions.
+type mockDefinitionStore struct {
+	definitions map[uuid.UUID]customfield_entities.CustomFieldDefinition
+}
+
+func newMockDefinitionStore() *mockDefinitionStore {
+	return &mockDefinitionStore{definitions: make(map[uuid.UUID]customfield_entities.CustomFieldDefinition)}
+}
+
+func (m *mockDefinitionStore) Search(ctx context.Context, s common.Search) ([]customfield_entities.CustomFieldDefinition, error) {
+	definitions := make([]customfield_entities.CustomFieldDefinition, 0, len(m.definitions))
+	for _, d := range m.definitions {
+		definitions = append(definitions, d)
+	}
+
+	return definitions, nil
+}
+
+func (m *mockDefinitionStore) Compile(ctx context.Context, searchParams []common.SearchAggregation, resultOptions common.SearchResultOptions) (*common.Search, error) {
+	return nil, nil
+}
+
+func (m *mockDefinitionStore) Save(ctx context.Context, d *customfield_entities.CustomFieldDefinition) (*customfield_entities.CustomFieldDefinition, error) {
+	m.definitions[d.ID] = *d
+	return d, nil
+}
+
+// mockCustomFieldsWriter keeps the fields set on the entities it has.
+type mockCustomFieldsWriter struct {
+	fields map[uuid.UUID]common.CustomFields
+}
+
+func (m *mockCustomFieldsWriter) SetCustomFields(ctx context.Context, id uuid.UUID, fields common.CustomFields) error {
+	if _, ok := m.fields[id]; !ok {
+		return customfield.NewCustomFieldsEntityNotFoundError(customfield_entities.CustomFieldEntitySquad, id)
+	}
+
+	m.fields[id] = fields
+
+	return nil
+}
+
+func testContext() context.Context {
+	return common.WithResourceOwner(context.Background(), common.ResourceOwner{TenantID: common.TeamPROTenantID, ClientID: common.ServerClientID, UserID: uuid.New()})
+}
+
+func TestDefineCustomField(t *testing.T) {
+	ctx := testContext()
+	store := newMockDefinitionStore()
+	usecase := customfield_use_cases.NewDefineCustomFieldUseCase(store, store)
+
+	d, err := usecase.Exec(ctx, customfield_entities.CustomFieldDefinition{EntityType: customfield_entities.CustomFieldEntitySquad, Key: " Team_Code ", Label: "Team code", Type: customfield_entities.CustomFieldText})
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	// owned by the client application, not the user defining it
+	assert.Equal(t, "team_code", d.Key)
+	assert.Equal(t, uuid.Nil, d.ResourceOwner.UserID)
+	assert.Equal(t, customfield_entities.CustomFieldDefinitionID(d.ResourceOwner, d.EntityType, d.Key), d.ID)
+
+	// defined again: updated
+	updated, err := usecase.Exec(ctx, customfield_entities.CustomFieldDefinition{EntityType: customfield_entities.CustomFieldEntitySquad, Key: "team_code", Label: "Code", Type: customfield_entities.CustomFieldText, Required: true})
+	assert.NoError(t, err)
+	assert.Equal(t, d.ID, updated.ID)
+	assert.Equal(t, d.CreatedAt, updated.CreatedAt)
+	assert.Len(t, store.definitions, 1)
+
+	var invalidErr *customfield.InvalidCustomFieldDefinitionError
+
+	// the type can't be changed
+	_, err = usecase.Exec(ctx, customfield_entities.CustomFieldDefinition{EntityType: customfield_entities.CustomFieldEntitySquad, Key: "team_code", Label: "Code", Type: customfield_entities.CustomFieldNumber})
+	assert.ErrorAs(t, err, &invalidErr)
+
+	_, err = usecase.Exec(ctx, customfield_entities.CustomFieldDefinition{EntityType: customfield_entities.CustomFieldEntitySquad, Key: "tier", Label: "Tier", Type: customfield_entities.CustomFieldEnum})
+	assert.ErrorAs(t, err, &invalidErr)
+
+	for i := len(store.definitions); i < customfield_entities.MaxCustomFieldDefinitions; i++ {
+		_, err = usecase.Exec(ctx, customfield_entities.CustomFieldDefinition{EntityType: customfield_entities.CustomFieldEntitySquad, Key: fmt.Sprintf("field_%d", i), Label: "Field", Type: customfield_entities.CustomFieldBoolean})
+		assert.NoError(t, err)
+	}
+
+	_, err = usecase.Exec(ctx, customfield_entities.CustomFieldDefinition{EntityType: customfield_entities.CustomFieldEntitySquad, Key: "one_too_many", Label: "Field", Type: customfield_entities.CustomFieldBoolean})
+	assert.ErrorAs(t, err, &invalidErr)
+}
+
+func TestSetCustomFields(t *testing.T) {
+	ctx := testContext()
+	store := newMockDefinitionStore()
+
+	_, err := customfield_use_cases.NewDefineCustomFieldUseCase(store, store).Exec(ctx, customfield_entities.CustomFieldDefinition{EntityType: customfield_entities.CustomFieldEntitySquad, Key: "budget", Label: "Budget", Type: customfield_entities.CustomFieldNumber})
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	squadID := uuid.New()
+	squads := &mockCustomFieldsWriter{fields: map[uuid.UUID]common.CustomFields{squadID: nil}}
+
+	usecase := customfield_use_cases.NewSetCustomFieldsUseCase(store, map[customfield_entities.CustomFieldEntityType]customfield_out.CustomFieldsWriter{
+		customfield_entities.CustomFieldEntitySquad: squads,
+	})
+
+	fields, err := usecase.Exec(ctx, customfield_in.SetCustomFieldsCommand{EntityType: customfield_entities.CustomFieldEntitySquad, EntityID: squadID, Fields: common.CustomFields{"budget": 1500}})
+	assert.NoError(t, err)
+	assert.Equal(t, common.CustomFields{"budget": float64(1500)}, fields)
+	assert.Equal(t, fields, squads.fields[squadID])
+
+	var invalidErr *customfield.InvalidCustomFieldsError
+
+	_, err = usecase.Exec(ctx, customfield_in.SetCustomFieldsCommand{EntityType: customfield_entities.CustomFieldEntitySquad, EntityID: squadID, Fields: common.CustomFields{"sponsor": "ACME"}})
+	assert.ErrorAs(t, err, &invalidErr)
+
+	// no writer of the entity type
+	_, err = usecase.Exec(ctx, customfield_in.SetCustomFieldsCommand{EntityType: customfield_entities.CustomFieldEntityPlayer, EntityID: uuid.New()})
+	assert.ErrorAs(t, err, &invalidErr)
+
+	var notFoundErr *customfield.CustomFieldsEntityNotFoundError
+
+	_, err = usecase.Exec(ctx, customfield_in.SetCustomFieldsCommand{EntityType: customfield_entities.CustomFieldEntitySquad, EntityID: uuid.New(), Fields: common.CustomFields{}})
+	assert.ErrorAs(t, err, &notFoundErr)
+}
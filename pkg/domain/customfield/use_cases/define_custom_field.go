@@ -0,0 +1,88 @@
+package customfield_use_cases
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	"github.com/psavelis/team-pro/replay-api/pkg/domain/customfield"
+	customfield_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/customfield/entities"
+	customfield_in "github.com/psavelis/team-pro/replay-api/pkg/domain/customfield/ports/in"
+	customfield_out "github.com/psavelis/team-pro/replay-api/pkg/domain/customfield/ports/out"
+)
+
+type DefineCustomFieldUseCase struct {
+	DefinitionReader customfield_out.CustomFieldDefinitionReader
+	DefinitionWriter customfield_out.CustomFieldDefinitionWriter
+}
+
+func NewDefineCustomFieldUseCase(definitionReader customfield_out.CustomFieldDefinitionReader, definitionWriter customfield_out.CustomFieldDefinitionWriter) customfield_in.DefineCustomFieldCommand {
+	return &DefineCustomFieldUseCase{
+		DefinitionReader: definitionReader,
+		DefinitionWriter: definitionWriter,
+	}
+}
+
+// Exec defines the field for the client application in context, or updates it when the key is defined already. The type of a field
+// can't be changed (the values set would no longer match it), the values set before a change of its options or constraints are kept
+// as they are until set again.
+func (usecase *DefineCustomFieldUseCase) Exec(ctx context.Context, d customfield_entities.CustomFieldDefinition) (*customfield_entities.CustomFieldDefinition, error) {
+	d.Key = strings.ToLower(strings.TrimSpace(d.Key))
+	d.Label = strings.TrimSpace(d.Label)
+
+	err := d.Validate()
+	if err != nil {
+		return nil, customfield.NewInvalidCustomFieldDefinitionError(err.Error())
+	}
+
+	schema, err := schemaOf(ctx, usecase.DefinitionReader, d.EntityType)
+	if err != nil {
+		return nil, err
+	}
+
+	owner := common.GetResourceOwner(ctx)
+	now := time.Now().UTC()
+
+	d.ResourceOwner = common.ResourceOwner{TenantID: owner.TenantID, ClientID: owner.ClientID}
+	d.ID = customfield_entities.CustomFieldDefinitionID(d.ResourceOwner, d.EntityType, d.Key)
+	d.CreatedAt = now
+	d.UpdatedAt = now
+
+	existing, ok := schema.Definition(d.Key)
+
+	switch {
+	case ok && existing.Type != d.Type:
+		return nil, customfield.NewInvalidCustomFieldDefinitionError(fmt.Sprintf("the type of %s is %s, it can't be changed", d.Key, existing.Type))
+	case ok:
+		d.CreatedAt = existing.CreatedAt
+	case len(schema) >= customfield_entities.MaxCustomFieldDefinitions:
+		return nil, customfield.NewInvalidCustomFieldDefinitionError(fmt.Sprintf("at most %d custom fields can be defined for the %s entities", customfield_entities.MaxCustomFieldDefinitions, d.EntityType))
+	}
+
+	saved, err := usecase.DefinitionWriter.Save(ctx, &d)
+	if err != nil {
+		slog.ErrorContext(ctx, "unable to save custom field definition", "entityType", d.EntityType, "key", d.Key, "err", err)
+		return nil, err
+	}
+
+	slog.InfoContext(ctx, "custom field defined", "definitionID", saved.ID, "entityType", saved.EntityType, "key", saved.Key, "type", saved.Type)
+
+	return saved, nil
+}
+
+// schemaOf returns the definitions of the entity type, of the client application in context.
+func schemaOf(ctx context.Context, reader customfield_out.CustomFieldDefinitionReader, entityType customfield_entities.CustomFieldEntityType) (customfield_entities.CustomFieldSchema, error) {
+	definitions, err := reader.Search(ctx, common.NewSearchByValues(ctx, []common.SearchableValue{
+		{Field: "EntityType", Values: []interface{}{entityType}},
+	}, common.NewSearchResultOptions(0, customfield_entities.MaxCustomFieldDefinitions), common.ClientApplicationAudienceIDKey))
+
+	if err != nil {
+		slog.ErrorContext(ctx, "unable to search custom field definitions", "entityType", entityType, "err", err)
+		return nil, err
+	}
+
+	return customfield_entities.CustomFieldSchema(definitions), nil
+}
@@ -0,0 +1,55 @@
+package customfield_use_cases
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	"github.com/psavelis/team-pro/replay-api/pkg/domain/customfield"
+	customfield_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/customfield/entities"
+	customfield_in "github.com/psavelis/team-pro/replay-api/pkg/domain/customfield/ports/in"
+	customfield_out "github.com/psavelis/team-pro/replay-api/pkg/domain/customfield/ports/out"
+)
+
+type SetCustomFieldsUseCase struct {
+	DefinitionReader customfield_out.CustomFieldDefinitionReader
+	Writers          map[customfield_entities.CustomFieldEntityType]customfield_out.CustomFieldsWriter
+}
+
+// NewSetCustomFieldsUseCase sets the custom fields of the entity types given a writer.
+func NewSetCustomFieldsUseCase(definitionReader customfield_out.CustomFieldDefinitionReader, writers map[customfield_entities.CustomFieldEntityType]customfield_out.CustomFieldsWriter) customfield_in.SetCustomFieldsCommandHandler {
+	return &SetCustomFieldsUseCase{
+		DefinitionReader: definitionReader,
+		Writers:          writers,
+	}
+}
+
+// Exec checks the fields against the definitions of the entity type before replacing the ones of the entity (the fields left out are
+// unset).
+func (usecase *SetCustomFieldsUseCase) Exec(ctx context.Context, cmd customfield_in.SetCustomFieldsCommand) (common.CustomFields, error) {
+	writer, ok := usecase.Writers[cmd.EntityType]
+	if !ok {
+		return nil, customfield.NewInvalidCustomFieldsError(fmt.Sprintf("custom fields can't be set on %s entities", cmd.EntityType))
+	}
+
+	schema, err := schemaOf(ctx, usecase.DefinitionReader, cmd.EntityType)
+	if err != nil {
+		return nil, err
+	}
+
+	fields, err := schema.Validate(cmd.Fields)
+	if err != nil {
+		return nil, customfield.NewInvalidCustomFieldsError(err.Error())
+	}
+
+	err = writer.SetCustomFields(ctx, cmd.EntityID, fields)
+	if err != nil {
+		slog.ErrorContext(ctx, "unable to set custom fields", "entityType", cmd.EntityType, "entityID", cmd.EntityID, "err", err)
+		return nil, err
+	}
+
+	slog.InfoContext(ctx, "custom fields set", "entityType", cmd.EntityType, "entityID", cmd.EntityID, "fields", len(fields))
+
+	return fields, nil
+}
@@ -0,0 +1,38 @@
+package discord
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+)
+
+// DiscordUser is the Discord account of a user, either signed in with Discord or linked to an existing account (so squad
+// invitations and match notifications can be delivered on Discord). The ID is the user ID: a user has at most one Discord account.
+type DiscordUser struct {
+	ID            uuid.UUID            `json:"id" bson:"_id"`
+	VHash         string               `json:"v_hash" bson:"v_hash"`
+	DiscordID     string               `json:"discord_id" bson:"discord_id"` // snowflake
+	Username      string               `json:"username" bson:"username"`
+	GlobalName    string               `json:"global_name" bson:"global_name"`
+	Email         string               `json:"email" bson:"email"`
+	Verified      bool                 `json:"verified" bson:"verified"`
+	Avatar        string               `json:"avatar" bson:"avatar"`
+	ResourceOwner common.ResourceOwner `json:"resource_owner" bson:"resource_owner"`
+	LinkedAt      *time.Time           `json:"linked_at,omitempty" bson:"linked_at,omitempty"` // linked to an existing account
+	CreatedAt     time.Time            `json:"created_at" bson:"created_at"`
+	UpdatedAt     time.Time            `json:"updated_at" bson:"updated_at"`
+}
+
+func (s DiscordUser) GetID() uuid.UUID {
+	return s.ID
+}
+
+// DisplayName is the name shown on Discord (the global name, when the user set one).
+func (s DiscordUser) DisplayName() string {
+	if s.GlobalName != "" {
+		return s.GlobalName
+	}
+
+	return s.Username
+}
@@ -0,0 +1,126 @@
+package discord
+
+import "fmt"
+
+// Invalid VHash Error
+type InvalidVHashError struct {
+	// Error message
+	Message string
+}
+
+// Error returns the error message
+func (e *InvalidVHashError) Error() string {
+	return e.Message
+}
+
+// NewInvalidVHashError creates a new InvalidVHashError
+func NewInvalidVHashError(invalidVHash string) *InvalidVHashError {
+	return &InvalidVHashError{
+		Message: fmt.Sprintf("Invalid vHash: %s", invalidVHash),
+	}
+}
+
+// Discord User Creation Error
+type DiscordUserCreationError struct {
+	// Error message
+	Message string
+}
+
+// Error returns the error message
+func (e *DiscordUserCreationError) Error() string {
+	return e.Message
+}
+
+// NewDiscordUserCreationError creates a new DiscordUserCreationError
+func NewDiscordUserCreationError(message string) *DiscordUserCreationError {
+	return &DiscordUserCreationError{
+		Message: message,
+	}
+}
+
+type DiscordIDMismatchError struct {
+	// Error message
+	Message string
+}
+
+// Error returns the error message
+func (e *DiscordIDMismatchError) Error() string {
+	return e.Message
+}
+
+// NewDiscordIDMismatchError creates a new DiscordIDMismatchError
+func NewDiscordIDMismatchError(receivedDiscordID string) *DiscordIDMismatchError {
+	return &DiscordIDMismatchError{
+		Message: fmt.Sprintf("DiscordID mismatch: %s", receivedDiscordID),
+	}
+}
+
+type DiscordIDRequiredError struct {
+	// Error message
+	Message string
+}
+
+// Error returns the error message
+func (e *DiscordIDRequiredError) Error() string {
+	return e.Message
+}
+
+// NewDiscordIDRequiredError creates a new DiscordIDRequiredError
+func NewDiscordIDRequiredError() *DiscordIDRequiredError {
+	return &DiscordIDRequiredError{
+		Message: "DiscordID is required",
+	}
+}
+
+type VHashRequiredError struct {
+	// Error message
+	Message string
+}
+
+// Error returns the error message
+func (e *VHashRequiredError) Error() string {
+	return e.Message
+}
+
+// NewVHashRequiredError creates a new VHashRequiredError
+func NewVHashRequiredError() *VHashRequiredError {
+	return &VHashRequiredError{
+		Message: "vHash is required",
+	}
+}
+
+// Discord Account Already Linked Error (the Discord account belongs to another user, or the user already has another one)
+type DiscordAccountAlreadyLinkedError struct {
+	// Error message
+	Message string
+}
+
+// Error returns the error message
+func (e *DiscordAccountAlreadyLinkedError) Error() string {
+	return e.Message
+}
+
+// NewDiscordAccountAlreadyLinkedError creates a new DiscordAccountAlreadyLinkedError
+func NewDiscordAccountAlreadyLinkedError(message string) *DiscordAccountAlreadyLinkedError {
+	return &DiscordAccountAlreadyLinkedError{
+		Message: message,
+	}
+}
+
+// Discord Link Forbidden Error (only a signed in user can link a Discord account)
+type DiscordLinkForbiddenError struct {
+	// Error message
+	Message string
+}
+
+// Error returns the error message
+func (e *DiscordLinkForbiddenError) Error() string {
+	return e.Message
+}
+
+// NewDiscordLinkForbiddenError creates a new DiscordLinkForbiddenError
+func NewDiscordLinkForbiddenError() *DiscordLinkForbiddenError {
+	return &DiscordLinkForbiddenError{
+		Message: "a Discord account can only be linked by a signed in user",
+	}
+}
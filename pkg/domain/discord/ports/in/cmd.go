@@ -0,0 +1,18 @@
+package discord_in
+
+import (
+	"context"
+
+	discord_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/discord/entities"
+	iam_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/iam/entities"
+)
+
+type OnboardDiscordUserCommand interface {
+	Exec(ctx context.Context, discordUser *discord_entities.DiscordUser) (*discord_entities.DiscordUser, *iam_entities.RIDToken, error)
+	Validate(ctx context.Context, discordUser *discord_entities.DiscordUser) error
+}
+
+// LinkDiscordAccountCommand links a Discord account to the user in context, who can then also sign in with it.
+type LinkDiscordAccountCommand interface {
+	Exec(ctx context.Context, discordUser *discord_entities.DiscordUser) (*discord_entities.DiscordUser, error)
+}
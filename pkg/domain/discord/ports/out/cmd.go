@@ -0,0 +1,15 @@
+package discord_out
+
+import (
+	"context"
+
+	discord_entity "github.com/psavelis/team-pro/replay-api/pkg/domain/discord/entities"
+)
+
+type DiscordUserWriter interface {
+	Create(ctx context.Context, user *discord_entity.DiscordUser) (*discord_entity.DiscordUser, error)
+}
+
+type VHashWriter interface {
+	CreateVHash(ctx context.Context, discordID string) string
+}
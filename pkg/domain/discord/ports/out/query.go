@@ -0,0 +1,12 @@
+package discord_out
+
+import (
+	"context"
+
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	discord_entity "github.com/psavelis/team-pro/replay-api/pkg/domain/discord/entities"
+)
+
+type DiscordUserReader interface {
+	Search(ctx context.Context, s common.Search) ([]discord_entity.DiscordUser, error)
+}
@@ -0,0 +1,161 @@
+package discord_use_cases_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	"github.com/psavelis/team-pro/replay-api/pkg/domain/discord"
+	discord_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/discord/entities"
+	discord_use_cases "github.com/psavelis/team-pro/replay-api/pkg/domain/discord/use_cases"
+	iam_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/iam/entities"
+	iam_in "github.com/psavelis/team-pro/replay-api/pkg/domain/iam/ports/in"
+	"github.com/stretchr/testify/assert"
+)
+
+const discordID = "80351110224678912"
+
+type mockVHashWriter struct{}
+
+func (m mockVHashWriter) CreateVHash(ctx context.Context, id string) string {
+	return "vhash:" + id
+}
+
+type mockDiscordUserStore struct {
+	users []discord_entities.DiscordUser
+}
+
+func (m *mockDiscordUserStore) Create(ctx context.Context, user *discord_entities.DiscordUser) (*discord_entities.DiscordUser, error) {
+	m.users = append(m.users, *user)
+	return user, nil
+}
+
+func (m *mockDiscordUserStore) Search(ctx context.Context, s common.Search) ([]discord_entities.DiscordUser, error) {
+	res := make([]discord_entities.DiscordUser, 0)
+
+	v := s.SearchParams[0].Params[0].ValueParams[0]
+	for _, user := range m.users {
+		if (v.Field == "VHash" && user.VHash == v.Values[0]) || (v.Field == "ID" && user.ID == v.Values[0]) {
+			res = append(res, user)
+		}
+	}
+
+	return res, nil
+}
+
+type mockProfileStore struct {
+	profiles []iam_entities.Profile
+}
+
+func (m *mockProfileStore) Create(ctx context.Context, profile *iam_entities.Profile) (*iam_entities.Profile, error) {
+	m.profiles = append(m.profiles, *profile)
+	return profile, nil
+}
+
+func (m *mockProfileStore) CreateMany(ctx context.Context, profiles []*iam_entities.Profile) error {
+	return nil
+}
+
+// mockOnboardOpenIDUser resolves the profiles by their source key, creating a user otherwise.
+type mockOnboardOpenIDUser struct {
+	profiles *mockProfileStore
+}
+
+func (m *mockOnboardOpenIDUser) Exec(ctx context.Context, cmd iam_in.OnboardOpenIDUserCommand) (*iam_entities.Profile, *iam_entities.RIDToken, error) {
+	for _, profile := range m.profiles.profiles {
+		if profile.RIDSource == cmd.Source && profile.SourceKey == cmd.Key {
+			return &profile, &iam_entities.RIDToken{ID: uuid.New(), Source: cmd.Source, ResourceOwner: profile.ResourceOwner}, nil
+		}
+	}
+
+	profile := iam_entities.NewProfile(uuid.New(), uuid.New(), cmd.Source, cmd.Key, cmd.ProfileDetails, common.GetResourceOwner(ctx))
+	m.profiles.profiles = append(m.profiles.profiles, *profile)
+
+	return profile, &iam_entities.RIDToken{ID: uuid.New(), Source: cmd.Source, ResourceOwner: profile.ResourceOwner}, nil
+}
+
+func appContext() context.Context {
+	return common.WithResourceOwner(context.Background(), common.ResourceOwner{TenantID: common.TeamPROTenantID, ClientID: common.TeamPROAppClientID})
+}
+
+// anonymousContext is the scope of a request without a RID: its user is a placeholder.
+func anonymousContext() context.Context {
+	return common.WithResourceOwner(context.Background(), common.ResourceOwner{TenantID: common.TeamPROTenantID, ClientID: common.TeamPROAppClientID, UserID: uuid.New(), GroupID: uuid.New()})
+}
+
+func userContext(userID uuid.UUID) context.Context {
+	return common.WithAuthenticated(common.WithResourceOwner(context.Background(), common.ResourceOwner{TenantID: common.TeamPROTenantID, ClientID: common.TeamPROAppClientID, UserID: userID, GroupID: uuid.New()}))
+}
+
+func newDiscordUser(id string) *discord_entities.DiscordUser {
+	return &discord_entities.DiscordUser{DiscordID: id, VHash: "vhash:" + id, Username: "nightowl", GlobalName: "Night Owl"}
+}
+
+func TestOnboardDiscordUserUseCase_Exec(t *testing.T) {
+	store := &mockDiscordUserStore{}
+	profiles := &mockProfileStore{}
+
+	usecase := discord_use_cases.NewOnboardDiscordUserUseCase(store, store, mockVHashWriter{}, &mockOnboardOpenIDUser{profiles: profiles})
+
+	assert.NoError(t, usecase.Validate(appContext(), newDiscordUser(discordID)))
+	assert.IsType(t, &discord.InvalidVHashError{}, usecase.Validate(appContext(), &discord_entities.DiscordUser{DiscordID: discordID, VHash: "vhash:other"}))
+	assert.IsType(t, &discord.DiscordIDRequiredError{}, usecase.Validate(appContext(), &discord_entities.DiscordUser{VHash: "vhash:" + discordID}))
+
+	user, token, err := usecase.Exec(appContext(), newDiscordUser(discordID))
+	assert.NoError(t, err)
+	assert.Equal(t, iam_entities.RIDSource_Discord, token.Source)
+	assert.Equal(t, profiles.profiles[0].ResourceOwner.UserID, user.ID)
+	assert.Equal(t, "Night Owl", profiles.profiles[0].Details.(*discord_entities.DiscordUser).DisplayName())
+	assert.Nil(t, user.LinkedAt)
+
+	again, _, err := usecase.Exec(appContext(), newDiscordUser(discordID))
+	assert.NoError(t, err)
+	assert.Equal(t, user.ID, again.ID)
+	assert.Len(t, store.users, 1)
+	assert.Len(t, profiles.profiles, 1)
+}
+
+func TestLinkDiscordAccountUseCase_Exec(t *testing.T) {
+	store := &mockDiscordUserStore{}
+	profiles := &mockProfileStore{}
+
+	link := discord_use_cases.NewLinkDiscordAccountUseCase(store, store, mockVHashWriter{}, profiles)
+	onboard := discord_use_cases.NewOnboardDiscordUserUseCase(store, store, mockVHashWriter{}, &mockOnboardOpenIDUser{profiles: profiles})
+
+	userID := uuid.New()
+
+	_, err := link.Exec(appContext(), newDiscordUser(discordID))
+	assert.IsType(t, &discord.DiscordLinkForbiddenError{}, err)
+
+	_, err = link.Exec(anonymousContext(), newDiscordUser(discordID))
+	assert.IsType(t, &discord.DiscordLinkForbiddenError{}, err)
+
+	_, err = link.Exec(userContext(userID), &discord_entities.DiscordUser{DiscordID: discordID, VHash: "vhash:other"})
+	assert.IsType(t, &discord.InvalidVHashError{}, err)
+
+	linked, err := link.Exec(userContext(userID), newDiscordUser(discordID))
+	assert.NoError(t, err)
+	assert.Equal(t, userID, linked.ID)
+	assert.Equal(t, userID, linked.ResourceOwner.UserID)
+	assert.NotNil(t, linked.LinkedAt)
+
+	// linking it again is a no-op
+	_, err = link.Exec(userContext(userID), newDiscordUser(discordID))
+	assert.NoError(t, err)
+	assert.Len(t, store.users, 1)
+
+	// the account belongs to the user: nobody else can link it, and the user can't link a second one
+	_, err = link.Exec(userContext(uuid.New()), newDiscordUser(discordID))
+	assert.IsType(t, &discord.DiscordAccountAlreadyLinkedError{}, err)
+
+	_, err = link.Exec(userContext(userID), newDiscordUser("175928847299117063"))
+	assert.IsType(t, &discord.DiscordAccountAlreadyLinkedError{}, err)
+
+	// signing in with Discord resolves to the linked user
+	user, token, err := onboard.Exec(appContext(), newDiscordUser(discordID))
+	assert.NoError(t, err)
+	assert.Equal(t, userID, user.ID)
+	assert.Equal(t, userID, token.ResourceOwner.UserID)
+	assert.Len(t, profiles.profiles, 1)
+}
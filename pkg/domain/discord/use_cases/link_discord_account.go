@@ -0,0 +1,101 @@
+package discord_use_cases
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+
+	"github.com/psavelis/team-pro/replay-api/pkg/domain/discord"
+	discord_entity "github.com/psavelis/team-pro/replay-api/pkg/domain/discord/entities"
+	iam_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/iam/entities"
+	iam_out "github.com/psavelis/team-pro/replay-api/pkg/domain/iam/ports/out"
+
+	discord_in "github.com/psavelis/team-pro/replay-api/pkg/domain/discord/ports/in"
+	discord_out "github.com/psavelis/team-pro/replay-api/pkg/domain/discord/ports/out"
+)
+
+type LinkDiscordAccountUseCase struct {
+	DiscordUserWriter discord_out.DiscordUserWriter
+	DiscordUserReader discord_out.DiscordUserReader
+	VHashWriter       discord_out.VHashWriter
+	ProfileWriter     iam_out.ProfileWriter
+}
+
+func NewLinkDiscordAccountUseCase(discordUserWriter discord_out.DiscordUserWriter, discordUserReader discord_out.DiscordUserReader, vHashWriter discord_out.VHashWriter, profileWriter iam_out.ProfileWriter) discord_in.LinkDiscordAccountCommand {
+	return &LinkDiscordAccountUseCase{
+		DiscordUserWriter: discordUserWriter,
+		DiscordUserReader: discordUserReader,
+		VHashWriter:       vHashWriter,
+		ProfileWriter:     profileWriter,
+	}
+}
+
+// Exec links the Discord account to the user in context. Linking the same account again is a no-op; an account can't be linked to
+// two users, nor a user to two accounts.
+func (usecase *LinkDiscordAccountUseCase) Exec(ctx context.Context, discordUser *discord_entity.DiscordUser) (*discord_entity.DiscordUser, error) {
+	if !common.IsAuthenticatedUser(ctx) {
+		return nil, discord.NewDiscordLinkForbiddenError()
+	}
+
+	resourceOwner := common.GetResourceOwner(ctx)
+
+	err := validateDiscordUser(ctx, usecase.VHashWriter, discordUser)
+	if err != nil {
+		return nil, err
+	}
+
+	linked, err := usecase.DiscordUserReader.Search(ctx, newSearchByVHash(ctx, discordUser.VHash))
+	if err != nil {
+		slog.ErrorContext(ctx, "error getting discord user", "discordID", discordUser.DiscordID, "err", err)
+		return nil, err
+	}
+
+	if len(linked) > 0 {
+		if linked[0].ResourceOwner.UserID != resourceOwner.UserID {
+			slog.WarnContext(ctx, "discord account linked to another user", "discordID", discordUser.DiscordID, "userID", resourceOwner.UserID)
+			return nil, discord.NewDiscordAccountAlreadyLinkedError("the Discord account is linked to another user")
+		}
+
+		return &linked[0], nil
+	}
+
+	current, err := usecase.DiscordUserReader.Search(ctx, common.NewSearchByID(ctx, resourceOwner.UserID, common.ClientApplicationAudienceIDKey))
+	if err != nil {
+		slog.ErrorContext(ctx, "error getting the discord account of the user", "userID", resourceOwner.UserID, "err", err)
+		return nil, err
+	}
+
+	if len(current) > 0 {
+		return nil, discord.NewDiscordAccountAlreadyLinkedError("another Discord account is linked to the user")
+	}
+
+	// the Discord profile first: signing in with Discord then resolves to this user, even if the Discord user isn't created below
+	// (it will be, on that sign in)
+	profile := iam_entities.NewProfile(resourceOwner.UserID, resourceOwner.GroupID, iam_entities.RIDSource_Discord, discordUser.DiscordID, discordUser, resourceOwner)
+
+	_, err = usecase.ProfileWriter.Create(ctx, profile)
+	if err != nil {
+		slog.ErrorContext(ctx, "error creating discord profile", "discordID", discordUser.DiscordID, "userID", resourceOwner.UserID, "err", err)
+		return nil, discord.NewDiscordUserCreationError("error linking the Discord account")
+	}
+
+	now := time.Now().UTC()
+
+	discordUser.ID = resourceOwner.UserID
+	discordUser.ResourceOwner = resourceOwner
+	discordUser.LinkedAt = &now
+	discordUser.CreatedAt = now
+	discordUser.UpdatedAt = now
+
+	created, err := usecase.DiscordUserWriter.Create(ctx, discordUser)
+	if err != nil {
+		slog.ErrorContext(ctx, "error creating linked discord user", "discordID", discordUser.DiscordID, "userID", resourceOwner.UserID, "err", err)
+		return nil, discord.NewDiscordUserCreationError("error linking the Discord account")
+	}
+
+	slog.InfoContext(ctx, "discord account linked", "discordID", discordUser.DiscordID, "userID", resourceOwner.UserID)
+
+	return created, nil
+}
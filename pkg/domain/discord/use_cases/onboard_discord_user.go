@@ -0,0 +1,161 @@
+package discord_use_cases
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+
+	"github.com/psavelis/team-pro/replay-api/pkg/domain/discord"
+	discord_entity "github.com/psavelis/team-pro/replay-api/pkg/domain/discord/entities"
+	iam_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/iam/entities"
+	iam_in "github.com/psavelis/team-pro/replay-api/pkg/domain/iam/ports/in"
+
+	discord_in "github.com/psavelis/team-pro/replay-api/pkg/domain/discord/ports/in"
+	discord_out "github.com/psavelis/team-pro/replay-api/pkg/domain/discord/ports/out"
+)
+
+type OnboardDiscordUserUseCase struct {
+	DiscordUserWriter discord_out.DiscordUserWriter
+	DiscordUserReader discord_out.DiscordUserReader
+	VHashWriter       discord_out.VHashWriter
+	OnboardOpenIDUser iam_in.OnboardOpenIDUserCommandHandler
+}
+
+func NewOnboardDiscordUserUseCase(discordUserWriter discord_out.DiscordUserWriter, discordUserReader discord_out.DiscordUserReader, vHashWriter discord_out.VHashWriter, onboardOpenIDUser iam_in.OnboardOpenIDUserCommandHandler) discord_in.OnboardDiscordUserCommand {
+	return &OnboardDiscordUserUseCase{
+		DiscordUserWriter: discordUserWriter, DiscordUserReader: discordUserReader, VHashWriter: vHashWriter, OnboardOpenIDUser: onboardOpenIDUser,
+	}
+}
+
+func (usecase *OnboardDiscordUserUseCase) Validate(ctx context.Context, discordUser *discord_entity.DiscordUser) error {
+	return validateDiscordUser(ctx, usecase.VHashWriter, discordUser)
+}
+
+func (usecase *OnboardDiscordUserUseCase) Exec(ctx context.Context, discordUser *discord_entity.DiscordUser) (*discord_entity.DiscordUser, *iam_entities.RIDToken, error) {
+	discordUserResult, err := usecase.DiscordUserReader.Search(ctx, newSearchByVHash(ctx, discordUser.VHash))
+	if err != nil {
+		slog.ErrorContext(ctx, "error getting discord user", "err", err)
+		return nil, nil, err
+	}
+
+	if len(discordUserResult) > 0 {
+		if discordUser.DiscordID != discordUserResult[0].DiscordID {
+			slog.ErrorContext(ctx, "discordID does not match", "discordID", discordUser.DiscordID, "discordUser.DiscordID", discordUserResult[0].DiscordID)
+			return nil, nil, discord.NewDiscordIDMismatchError(discordUser.DiscordID)
+		}
+
+		// signed up with Discord, or linked it to an existing account
+		discordUser = &discordUserResult[0]
+
+		ctx = common.WithResourceOwner(ctx, common.ResourceOwner{GroupID: discordUser.ResourceOwner.GroupID, UserID: discordUser.ResourceOwner.UserID})
+	}
+
+	profile, ridToken, err := usecase.OnboardOpenIDUser.Exec(ctx, iam_in.OnboardOpenIDUserCommand{
+		Name:           discordUser.DisplayName(),
+		Source:         iam_entities.RIDSource_Discord,
+		Key:            discordUser.DiscordID,
+		ProfileDetails: discordUser,
+	})
+
+	if err != nil {
+		slog.ErrorContext(ctx, "error creating user profile", "err", err)
+		return nil, nil, discord.NewDiscordUserCreationError(fmt.Sprintf("error creating user profile: %v", discordUser.DiscordID))
+	}
+
+	if ridToken == nil {
+		slog.ErrorContext(ctx, "error creating rid token", "discordID", discordUser.DiscordID)
+		return nil, nil, discord.NewDiscordUserCreationError(fmt.Sprintf("error creating rid token: %v", discordUser.DiscordID))
+	}
+
+	ctx = common.WithResourceOwner(ctx, common.ResourceOwner{GroupID: profile.ResourceOwner.GroupID, UserID: profile.ResourceOwner.UserID})
+
+	discordUser.ResourceOwner = common.GetResourceOwner(ctx)
+
+	if discordUser.ID == uuid.Nil {
+		discordUser.ID = profile.ResourceOwner.UserID
+	}
+
+	if len(discordUserResult) == 0 {
+		now := time.Now().UTC()
+		discordUser.CreatedAt = now
+		discordUser.UpdatedAt = now
+
+		slog.InfoContext(ctx, "attempt to create discord user", "discordID", discordUser.DiscordID)
+		created, err := usecase.DiscordUserWriter.Create(ctx, discordUser)
+
+		if err != nil {
+			slog.ErrorContext(ctx, "error creating discord user", "err", err)
+			return nil, nil, discord.NewDiscordUserCreationError(fmt.Sprintf("error creating discord user: %v", discordUser.ID))
+		}
+
+		if created == nil {
+			slog.ErrorContext(ctx, "error creating discord user: user is nil", "discordID", discordUser.DiscordID)
+			return nil, nil, discord.NewDiscordUserCreationError(fmt.Sprintf("unable to create discord user: %v", discordUser.ID))
+		}
+
+		discordUser = created
+	}
+
+	return discordUser, ridToken, nil
+}
+
+// validateDiscordUser checks the vHash against the Discord ID, proving the account was authorized on Discord.
+func validateDiscordUser(ctx context.Context, vHashWriter discord_out.VHashWriter, discordUser *discord_entity.DiscordUser) error {
+	if discordUser.DiscordID == "" {
+		slog.ErrorContext(ctx, "discord id is required", "discord.Username", discordUser.Username)
+		return discord.NewDiscordIDRequiredError()
+	}
+
+	if discordUser.VHash == "" {
+		slog.ErrorContext(ctx, "vHash is required", "vHash", discordUser.VHash)
+		return discord.NewVHashRequiredError()
+	}
+
+	expectedVHash := vHashWriter.CreateVHash(ctx, discordUser.DiscordID)
+
+	if discordUser.VHash != expectedVHash {
+		slog.ErrorContext(ctx, "vHash does not match", "discord.DiscordID", discordUser.DiscordID, "vHash", discordUser.VHash, "expectedVHash", expectedVHash)
+		return discord.NewInvalidVHashError(discordUser.VHash)
+	}
+
+	return nil
+}
+
+func newSearchByVHash(ctx context.Context, vhashString string) common.Search {
+	params := []common.SearchAggregation{
+		{
+			Params: []common.SearchParameter{
+				{
+					ValueParams: []common.SearchableValue{
+						{
+							Field: "VHash",
+							Values: []interface{}{
+								vhashString,
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	visibility := common.SearchVisibilityOptions{
+		RequestSource:    common.GetResourceOwner(ctx),
+		IntendedAudience: common.ClientApplicationAudienceIDKey,
+	}
+
+	result := common.SearchResultOptions{
+		Skip:  0,
+		Limit: 1,
+	}
+
+	return common.Search{
+		SearchParams:      params,
+		ResultOptions:     result,
+		VisibilityOptions: visibility,
+	}
+}
@@ -0,0 +1,128 @@
+package email_entities
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"net/mail"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+)
+
+// MagicLinkTTL is how long a magic link can be used after it's sent.
+const MagicLinkTTL = 15 * time.Minute
+
+// DisposableDomains are throwaway inbox providers refused on signup (extended through configuration).
+var DisposableDomains = []string{
+	"10minutemail.com",
+	"discard.email",
+	"dispostable.com",
+	"fakeinbox.com",
+	"getnada.com",
+	"guerrillamail.com",
+	"maildrop.cc",
+	"mailinator.com",
+	"mintemail.com",
+	"mohmal.com",
+	"sharklasers.com",
+	"temp-mail.org",
+	"tempmail.com",
+	"throwawaymail.com",
+	"trashmail.com",
+	"yopmail.com",
+}
+
+// EmailLoginToken is a magic link sent to an address. Following it proves the address is owned (verifying it on signup) and logs the
+// user in. Only the sha256 of the token is stored: the token itself is only known to the inbox.
+type EmailLoginToken struct {
+	ID            uuid.UUID            `json:"id" bson:"_id"`
+	Email         string               `json:"email" bson:"email"`
+	TokenHash     string               `json:"-" bson:"token_hash"`
+	RequestIP     string               `json:"request_ip" bson:"request_ip"`
+	ExpiresAt     time.Time            `json:"expires_at" bson:"expires_at"`
+	ConsumedAt    *time.Time           `json:"consumed_at,omitempty" bson:"consumed_at,omitempty"`
+	ResourceOwner common.ResourceOwner `json:"resource_owner" bson:"resource_owner"`
+	CreatedAt     time.Time            `json:"created_at" bson:"created_at"`
+	UpdatedAt     time.Time            `json:"updated_at" bson:"updated_at"`
+}
+
+func (t EmailLoginToken) GetID() uuid.UUID {
+	return t.ID
+}
+
+// NewEmailLoginToken creates the login token of an address, returning it along with the (plain) token to be sent.
+func NewEmailLoginToken(email, requestIP string, now time.Time, resourceOwner common.ResourceOwner) (*EmailLoginToken, string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return nil, "", fmt.Errorf("unable to generate login token: %w", err)
+	}
+
+	token := base64.RawURLEncoding.EncodeToString(b)
+
+	return &EmailLoginToken{
+		ID:            uuid.New(),
+		Email:         email,
+		TokenHash:     HashLoginToken(token),
+		RequestIP:     requestIP,
+		ExpiresAt:     now.Add(MagicLinkTTL),
+		ResourceOwner: resourceOwner,
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	}, token, nil
+}
+
+func HashLoginToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// Usable reports whether the token can still log in (not expired nor used).
+func (t *EmailLoginToken) Usable(now time.Time) bool {
+	return t.ConsumedAt == nil && now.Before(t.ExpiresAt)
+}
+
+func (t *EmailLoginToken) Consume(now time.Time) {
+	t.ConsumedAt = &now
+	t.UpdatedAt = now
+}
+
+// NormalizeEmail parses a bare address (no display name), lower casing it so an inbox maps to a single profile.
+func NormalizeEmail(address string) (string, error) {
+	address = strings.ToLower(strings.TrimSpace(address))
+
+	parsed, err := mail.ParseAddress(address)
+	if err != nil || parsed.Address != address || parsed.Name != "" {
+		return "", fmt.Errorf("invalid email address '%s'", address)
+	}
+
+	if !strings.Contains(EmailDomain(address), ".") {
+		return "", fmt.Errorf("invalid email domain '%s'", EmailDomain(address))
+	}
+
+	return address, nil
+}
+
+func EmailDomain(address string) string {
+	return address[strings.LastIndex(address, "@")+1:]
+}
+
+// IsBlockedDomain reports whether the domain, or a parent domain of it, is blocked (ie: "eu.mailinator.com").
+func IsBlockedDomain(domain string, blocked map[string]bool) bool {
+	for {
+		if blocked[domain] {
+			return true
+		}
+
+		i := strings.Index(domain, ".")
+		if i < 0 {
+			return false
+		}
+
+		domain = domain[i+1:]
+	}
+}
@@ -0,0 +1,49 @@
+package email_entities_test
+
+import (
+	"testing"
+	"time"
+
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	email_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/email/entities"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalizeEmail(t *testing.T) {
+	address, err := email_entities.NormalizeEmail("  Player@Example.COM ")
+	assert.NoError(t, err)
+	assert.Equal(t, "player@example.com", address)
+
+	for _, invalid := range []string{"", "player", "player@", "Player <player@example.com>", "player@localhost", "a@b@example.com"} {
+		_, err := email_entities.NormalizeEmail(invalid)
+		assert.Error(t, err, invalid)
+	}
+}
+
+func TestIsBlockedDomain(t *testing.T) {
+	blocked := map[string]bool{"mailinator.com": true}
+
+	assert.True(t, email_entities.IsBlockedDomain("mailinator.com", blocked))
+	assert.True(t, email_entities.IsBlockedDomain("eu.mailinator.com", blocked))
+	assert.False(t, email_entities.IsBlockedDomain("notmailinator.com", blocked))
+	assert.False(t, email_entities.IsBlockedDomain("example.com", blocked))
+}
+
+func TestEmailLoginToken(t *testing.T) {
+	now := time.Now().UTC()
+
+	token, plain, err := email_entities.NewEmailLoginToken("player@example.com", "10.0.0.1", now, common.ResourceOwner{})
+	assert.NoError(t, err)
+	assert.NotEmpty(t, plain)
+	assert.NotEqual(t, plain, token.TokenHash, "only the hash of the token is stored")
+	assert.Equal(t, email_entities.HashLoginToken(plain), token.TokenHash)
+
+	_, other, _ := email_entities.NewEmailLoginToken("player@example.com", "10.0.0.1", now, common.ResourceOwner{})
+	assert.NotEqual(t, plain, other)
+
+	assert.True(t, token.Usable(now))
+	assert.False(t, token.Usable(now.Add(email_entities.MagicLinkTTL)), "expired")
+
+	token.Consume(now)
+	assert.False(t, token.Usable(now), "already used")
+}
@@ -0,0 +1,53 @@
+package email
+
+import (
+	"fmt"
+	"time"
+)
+
+// Invalid Email Error (malformed address, or a disposable domain)
+type InvalidEmailError struct {
+	Message string
+}
+
+func (e *InvalidEmailError) Error() string {
+	return e.Message
+}
+
+func NewInvalidEmailError(message string) *InvalidEmailError {
+	return &InvalidEmailError{
+		Message: message,
+	}
+}
+
+// Email Rate Limited Error (too many magic links requested for an address or from an IP)
+type EmailRateLimitedError struct {
+	Message    string
+	RetryAfter time.Duration
+}
+
+func (e *EmailRateLimitedError) Error() string {
+	return e.Message
+}
+
+func NewEmailRateLimitedError(limit int, window time.Duration) *EmailRateLimitedError {
+	return &EmailRateLimitedError{
+		Message:    fmt.Sprintf("at most %d magic links can be requested every %s", limit, window),
+		RetryAfter: window,
+	}
+}
+
+// Invalid Login Token Error (unknown, expired or already used magic link)
+type InvalidLoginTokenError struct {
+	Message string
+}
+
+func (e *InvalidLoginTokenError) Error() string {
+	return e.Message
+}
+
+func NewInvalidLoginTokenError() *InvalidLoginTokenError {
+	return &InvalidLoginTokenError{
+		Message: "magic link is invalid or expired",
+	}
+}
@@ -0,0 +1,20 @@
+package email_in
+
+import (
+	"context"
+
+	iam_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/iam/entities"
+)
+
+type RequestMagicLinkCommand struct {
+	Email     string `json:"email"`
+	IPAddress string `json:"-"`
+}
+
+type RequestMagicLinkCommandHandler interface {
+	Exec(ctx context.Context, cmd RequestMagicLinkCommand) error
+}
+
+type VerifyMagicLinkCommandHandler interface {
+	Exec(ctx context.Context, token string) (*iam_entities.Profile, *iam_entities.RIDToken, error)
+}
@@ -0,0 +1,20 @@
+package email_out
+
+import (
+	"context"
+	"time"
+
+	email_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/email/entities"
+)
+
+type EmailLoginTokenWriter interface {
+	Create(ctx context.Context, token *email_entities.EmailLoginToken) (*email_entities.EmailLoginToken, error)
+	// Consume marks the token of the hash consumed at now, returning it; nil when there's no usable token (consumed or expired). A token is
+	// consumed once, even when verified concurrently.
+	Consume(ctx context.Context, tokenHash string, now time.Time) (*email_entities.EmailLoginToken, error)
+}
+
+// MagicLinkSender delivers the magic link of a login token to its address.
+type MagicLinkSender interface {
+	SendMagicLink(ctx context.Context, email string, token string, expiresAt time.Time) error
+}
@@ -0,0 +1,10 @@
+package email_out
+
+import (
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	email_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/email/entities"
+)
+
+type EmailLoginTokenReader interface {
+	common.Searchable[email_entities.EmailLoginToken]
+}
@@ -0,0 +1,185 @@
+package email_use_cases_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	"github.com/psavelis/team-pro/replay-api/pkg/domain/email"
+	email_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/email/entities"
+	email_in "github.com/psavelis/team-pro/replay-api/pkg/domain/email/ports/in"
+	email_use_cases "github.com/psavelis/team-pro/replay-api/pkg/domain/email/use_cases"
+	iam_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/iam/entities"
+	iam_in "github.com/psavelis/team-pro/replay-api/pkg/domain/iam/ports/in"
+	"github.com/stretchr/testify/assert"
+)
+
+func requestContext() context.Context {
+	return common.WithResourceOwner(context.Background(), common.ResourceOwner{TenantID: uuid.New(), ClientID: common.TeamPROAppClientID, UserID: uuid.New(), GroupID: uuid.New()})
+}
+
+// mockTokenStore filters by the fields searched by the use cases.
+type mockTokenStore struct {
+	tokens []email_entities.EmailLoginToken
+}
+
+func (m *mockTokenStore) Search(ctx context.Context, s common.Search) ([]email_entities.EmailLoginToken, error) {
+	res := make([]email_entities.EmailLoginToken, 0)
+
+	for _, t := range m.tokens {
+		match := true
+		for _, v := range s.SearchParams[0].Params[0].ValueParams {
+			switch v.Field {
+			case "Email":
+				match = match && t.Email == v.Values[0]
+			case "RequestIP":
+				match = match && t.RequestIP == v.Values[0]
+			case "TokenHash":
+				match = match && t.TokenHash == v.Values[0]
+			case "CreatedAt":
+				match = match && t.CreatedAt.After(v.Values[0].(time.Time))
+			}
+		}
+
+		if match {
+			res = append(res, t)
+		}
+	}
+
+	if limit := int(s.ResultOptions.Limit); limit > 0 && len(res) > limit {
+		res = res[:limit]
+	}
+
+	return res, nil
+}
+
+func (m *mockTokenStore) Compile(ctx context.Context, searchParams []common.SearchAggregation, resultOptions common.SearchResultOptions) (*common.Search, error) {
+	return nil, nil
+}
+
+func (m *mockTokenStore) Create(ctx context.Context, token *email_entities.EmailLoginToken) (*email_entities.EmailLoginToken, error) {
+	m.tokens = append(m.tokens, *token)
+	return token, nil
+}
+
+func (m *mockTokenStore) Consume(ctx context.Context, tokenHash string, now time.Time) (*email_entities.EmailLoginToken, error) {
+	for i := range m.tokens {
+		if m.tokens[i].TokenHash == tokenHash && m.tokens[i].Usable(now) {
+			m.tokens[i].Consume(now)
+			token := m.tokens[i]
+			return &token, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// mockSender keeps the last token sent to each address.
+type mockSender struct {
+	sent map[string]string
+}
+
+func (m *mockSender) SendMagicLink(ctx context.Context, address string, token string, expiresAt time.Time) error {
+	m.sent[address] = token
+	return nil
+}
+
+type mockOnboardOpenIDUser struct {
+	commands []iam_in.OnboardOpenIDUserCommand
+}
+
+func (m *mockOnboardOpenIDUser) Exec(ctx context.Context, cmd iam_in.OnboardOpenIDUserCommand) (*iam_entities.Profile, *iam_entities.RIDToken, error) {
+	m.commands = append(m.commands, cmd)
+	return &iam_entities.Profile{ID: uuid.New(), RIDSource: cmd.Source, SourceKey: cmd.Key}, &iam_entities.RIDToken{ID: uuid.New(), Source: cmd.Source}, nil
+}
+
+func TestRequestMagicLink_RejectsInvalidAndDisposableAddresses(t *testing.T) {
+	store := &mockTokenStore{}
+	sender := &mockSender{sent: map[string]string{}}
+	usecase := email_use_cases.NewRequestMagicLinkUseCase(store, store, sender, "blocked.example.com")
+
+	for _, address := range []string{"not-an-email", "player@mailinator.com", "player@eu.mailinator.com", "player@blocked.example.com"} {
+		err := usecase.Exec(requestContext(), email_in.RequestMagicLinkCommand{Email: address})
+
+		var invalidEmailErr *email.InvalidEmailError
+		assert.True(t, errors.As(err, &invalidEmailErr), address)
+	}
+
+	assert.Empty(t, store.tokens)
+	assert.Empty(t, sender.sent)
+}
+
+func TestRequestMagicLink_RateLimited(t *testing.T) {
+	store := &mockTokenStore{}
+	sender := &mockSender{sent: map[string]string{}}
+	usecase := email_use_cases.NewRequestMagicLinkUseCase(store, store, sender, "")
+
+	for i := 0; i < email_use_cases.MaxMagicLinksPerEmail; i++ {
+		err := usecase.Exec(requestContext(), email_in.RequestMagicLinkCommand{Email: "Player@Example.com", IPAddress: "10.0.0.1"})
+		assert.NoError(t, err)
+	}
+
+	err := usecase.Exec(requestContext(), email_in.RequestMagicLinkCommand{Email: "player@example.com", IPAddress: "10.0.0.2"})
+
+	var rateLimitedErr *email.EmailRateLimitedError
+	assert.True(t, errors.As(err, &rateLimitedErr), "per address")
+
+	for i := email_use_cases.MaxMagicLinksPerEmail; i < email_use_cases.MaxMagicLinksPerIP; i++ {
+		err := usecase.Exec(requestContext(), email_in.RequestMagicLinkCommand{Email: uuid.NewString() + "@example.com", IPAddress: "10.0.0.1"})
+		assert.NoError(t, err)
+	}
+
+	err = usecase.Exec(requestContext(), email_in.RequestMagicLinkCommand{Email: "other@example.com", IPAddress: "10.0.0.1"})
+	assert.True(t, errors.As(err, &rateLimitedErr), "per IP")
+
+	// links requested before the window are not counted
+	for i := range store.tokens {
+		store.tokens[i].CreatedAt = store.tokens[i].CreatedAt.Add(-email_use_cases.MagicLinkRateWindow)
+	}
+
+	err = usecase.Exec(requestContext(), email_in.RequestMagicLinkCommand{Email: "player@example.com", IPAddress: "10.0.0.1"})
+	assert.NoError(t, err)
+}
+
+func TestVerifyMagicLink(t *testing.T) {
+	store := &mockTokenStore{}
+	sender := &mockSender{sent: map[string]string{}}
+	onboard := &mockOnboardOpenIDUser{}
+
+	request := email_use_cases.NewRequestMagicLinkUseCase(store, store, sender, "")
+	verify := email_use_cases.NewVerifyMagicLinkUseCase(store, onboard)
+
+	err := request.Exec(requestContext(), email_in.RequestMagicLinkCommand{Email: "Player@Example.com"})
+	assert.NoError(t, err)
+
+	token := sender.sent["player@example.com"]
+	assert.NotEmpty(t, token)
+
+	_, _, err = verify.Exec(requestContext(), "unknown")
+	var invalidTokenErr *email.InvalidLoginTokenError
+	assert.True(t, errors.As(err, &invalidTokenErr))
+
+	profile, ridToken, err := verify.Exec(requestContext(), token)
+	assert.NoError(t, err)
+	assert.NotNil(t, ridToken)
+	assert.Equal(t, "player@example.com", profile.SourceKey)
+
+	assert.Len(t, onboard.commands, 1)
+	assert.Equal(t, iam_entities.RIDSource_Email, onboard.commands[0].Source)
+	assert.Equal(t, "player", onboard.commands[0].Name)
+
+	_, _, err = verify.Exec(requestContext(), token)
+	assert.True(t, errors.As(err, &invalidTokenErr), "magic links are single use")
+
+	err = request.Exec(requestContext(), email_in.RequestMagicLinkCommand{Email: "late@example.com"})
+	assert.NoError(t, err)
+
+	store.tokens[1].ExpiresAt = time.Now().UTC().Add(-time.Second)
+
+	_, _, err = verify.Exec(requestContext(), sender.sent["late@example.com"])
+	assert.True(t, errors.As(err, &invalidTokenErr), "expired")
+	assert.Len(t, onboard.commands, 1)
+}
@@ -0,0 +1,117 @@
+package email_use_cases
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	"github.com/psavelis/team-pro/replay-api/pkg/domain/email"
+	email_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/email/entities"
+	email_in "github.com/psavelis/team-pro/replay-api/pkg/domain/email/ports/in"
+	email_out "github.com/psavelis/team-pro/replay-api/pkg/domain/email/ports/out"
+)
+
+const (
+	// MagicLinkRateWindow is the window the magic links requested are counted over.
+	MagicLinkRateWindow = 15 * time.Minute
+	// MaxMagicLinksPerEmail caps the links sent to an address per window (so an inbox can't be flooded).
+	MaxMagicLinksPerEmail = 3
+	// MaxMagicLinksPerIP caps the links requested from an IP per window (so addresses can't be enumerated).
+	MaxMagicLinksPerIP = 10
+)
+
+type RequestMagicLinkUseCase struct {
+	TokenReader    email_out.EmailLoginTokenReader
+	TokenWriter    email_out.EmailLoginTokenWriter
+	Sender         email_out.MagicLinkSender
+	BlockedDomains map[string]bool
+}
+
+// NewRequestMagicLinkUseCase blocks the DisposableDomains along with the blockedDomains (comma separated) configured.
+func NewRequestMagicLinkUseCase(tokenReader email_out.EmailLoginTokenReader, tokenWriter email_out.EmailLoginTokenWriter, sender email_out.MagicLinkSender, blockedDomains string) email_in.RequestMagicLinkCommandHandler {
+	blocked := make(map[string]bool, len(email_entities.DisposableDomains))
+	for _, domain := range email_entities.DisposableDomains {
+		blocked[domain] = true
+	}
+
+	for _, domain := range strings.Split(blockedDomains, ",") {
+		if domain = strings.ToLower(strings.TrimSpace(domain)); domain != "" {
+			blocked[domain] = true
+		}
+	}
+
+	return &RequestMagicLinkUseCase{
+		TokenReader:    tokenReader,
+		TokenWriter:    tokenWriter,
+		Sender:         sender,
+		BlockedDomains: blocked,
+	}
+}
+
+// Exec sends a magic link to the address, both to sign up (the first link verifies the address) and to log in.
+func (usecase *RequestMagicLinkUseCase) Exec(ctx context.Context, cmd email_in.RequestMagicLinkCommand) error {
+	address, err := email_entities.NormalizeEmail(cmd.Email)
+	if err != nil {
+		return email.NewInvalidEmailError(err.Error())
+	}
+
+	if email_entities.IsBlockedDomain(email_entities.EmailDomain(address), usecase.BlockedDomains) {
+		return email.NewInvalidEmailError(fmt.Sprintf("disposable email domains are not accepted (%s)", email_entities.EmailDomain(address)))
+	}
+
+	now := time.Now().UTC()
+
+	err = usecase.checkRate(ctx, "Email", address, MaxMagicLinksPerEmail, now)
+	if err != nil {
+		return err
+	}
+
+	if cmd.IPAddress != "" {
+		err = usecase.checkRate(ctx, "RequestIP", cmd.IPAddress, MaxMagicLinksPerIP, now)
+		if err != nil {
+			return err
+		}
+	}
+
+	token, plain, err := email_entities.NewEmailLoginToken(address, cmd.IPAddress, now, common.GetResourceOwner(ctx))
+	if err != nil {
+		return err
+	}
+
+	_, err = usecase.TokenWriter.Create(ctx, token)
+	if err != nil {
+		slog.ErrorContext(ctx, "unable to create email login token", "tokenID", token.ID, "err", err)
+		return err
+	}
+
+	err = usecase.Sender.SendMagicLink(ctx, address, plain, token.ExpiresAt)
+	if err != nil {
+		slog.ErrorContext(ctx, "unable to send magic link", "tokenID", token.ID, "err", err)
+		return err
+	}
+
+	return nil
+}
+
+// checkRate counts the links requested within the window with the same value of field (the address, or the IP).
+func (usecase *RequestMagicLinkUseCase) checkRate(ctx context.Context, field string, value string, limit int, now time.Time) error {
+	recent, err := usecase.TokenReader.Search(ctx, common.NewSearchByValues(ctx, []common.SearchableValue{
+		{Field: field, Values: []interface{}{value}},
+		{Field: "CreatedAt", Operator: common.GreaterThanOperator, Values: []interface{}{now.Add(-MagicLinkRateWindow)}},
+	}, common.NewSearchResultOptions(0, uint(limit)), common.ClientApplicationAudienceIDKey))
+
+	if err != nil {
+		slog.ErrorContext(ctx, "unable to search recent email login tokens", "field", field, "err", err)
+		return err
+	}
+
+	if len(recent) >= limit {
+		slog.WarnContext(ctx, "magic link rate limited", "field", field, "limit", limit)
+		return email.NewEmailRateLimitedError(limit, MagicLinkRateWindow)
+	}
+
+	return nil
+}
@@ -0,0 +1,64 @@
+package email_use_cases
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/psavelis/team-pro/replay-api/pkg/domain/email"
+	email_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/email/entities"
+	email_in "github.com/psavelis/team-pro/replay-api/pkg/domain/email/ports/in"
+	email_out "github.com/psavelis/team-pro/replay-api/pkg/domain/email/ports/out"
+	iam_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/iam/entities"
+	iam_in "github.com/psavelis/team-pro/replay-api/pkg/domain/iam/ports/in"
+)
+
+type VerifyMagicLinkUseCase struct {
+	TokenWriter       email_out.EmailLoginTokenWriter
+	OnboardOpenIDUser iam_in.OnboardOpenIDUserCommandHandler
+}
+
+func NewVerifyMagicLinkUseCase(tokenWriter email_out.EmailLoginTokenWriter, onboardOpenIDUser iam_in.OnboardOpenIDUserCommandHandler) email_in.VerifyMagicLinkCommandHandler {
+	return &VerifyMagicLinkUseCase{
+		TokenWriter:       tokenWriter,
+		OnboardOpenIDUser: onboardOpenIDUser,
+	}
+}
+
+type emailProfileDetails struct {
+	Email         string `json:"email" bson:"email"`
+	EmailVerified bool   `json:"email_verified" bson:"email_verified"`
+}
+
+// Exec consumes the token of a magic link, onboarding the owner of the address on its first login, and returns its RID token.
+func (usecase *VerifyMagicLinkUseCase) Exec(ctx context.Context, token string) (*iam_entities.Profile, *iam_entities.RIDToken, error) {
+	if token == "" {
+		return nil, nil, email.NewInvalidLoginTokenError()
+	}
+
+	// consumed before onboarding: a link is single use even when onboarding fails (a new one can be requested)
+	loginToken, err := usecase.TokenWriter.Consume(ctx, email_entities.HashLoginToken(token), time.Now().UTC())
+	if err != nil {
+		slog.ErrorContext(ctx, "unable to consume email login token", "err", err)
+		return nil, nil, err
+	}
+
+	if loginToken == nil {
+		return nil, nil, email.NewInvalidLoginTokenError()
+	}
+
+	profile, ridToken, err := usecase.OnboardOpenIDUser.Exec(ctx, iam_in.OnboardOpenIDUserCommand{
+		Name:           loginToken.Email[:strings.LastIndex(loginToken.Email, "@")],
+		Source:         iam_entities.RIDSource_Email,
+		Key:            loginToken.Email,
+		ProfileDetails: emailProfileDetails{Email: loginToken.Email, EmailVerified: true},
+	})
+
+	if err != nil {
+		slog.ErrorContext(ctx, "unable to onboard email user", "tokenID", loginToken.ID, "err", err)
+		return nil, nil, err
+	}
+
+	return profile, ridToken, nil
+}
@@ -9,6 +9,7 @@ import (
 type BaseEntity struct {
 	ID            uuid.UUID     `json:"id" bson:"_id"`
 	ResourceOwner ResourceOwner `json:"resource_owner" bson:"resource_owner"`
+	Visibility    Visibility    `json:"visibility,omitempty" bson:"visibility,omitempty"`
 	CreatedAt     time.Time     `json:"created_at" bson:"created_at"`
 	UpdatedAt     time.Time     `json:"updated_at" bson:"updated_at"`
 }
@@ -29,3 +30,36 @@ func NewEntity(resourceOwner ResourceOwner) BaseEntity {
 		UpdatedAt:     time.Now(),
 	}
 }
+
+// NewEntityWithVisibility is a NewEntity visible as requested by its creator, or as the default of the policy applying to the owner
+// when none is requested. Requesting other than the default of a policy returns the override, for it to be audited.
+func NewEntityWithVisibility(resourceType ResourceType, resourceOwner ResourceOwner, policies []VisibilityPolicy, requested Visibility) (BaseEntity, *VisibilityOverride) {
+	entity := NewEntity(resourceOwner)
+	entity.Visibility = requested
+
+	policy := ApplicableVisibilityPolicy(policies, resourceOwner)
+	if policy == nil {
+		return entity, nil
+	}
+
+	if requested == "" {
+		entity.Visibility = policy.Visibility
+		return entity, nil
+	}
+
+	if requested == policy.Visibility {
+		return entity, nil
+	}
+
+	return entity, &VisibilityOverride{
+		ID:            uuid.New(),
+		ResourceType:  resourceType,
+		ResourceID:    entity.ID,
+		PolicyID:      policy.ID,
+		Default:       policy.Visibility,
+		Requested:     requested,
+		RequestedBy:   resourceOwner.UserID,
+		ResourceOwner: resourceOwner,
+		CreatedAt:     entity.CreatedAt,
+	}
+}
@@ -0,0 +1,28 @@
+package common
+
+import "context"
+
+// EntityMetadata describes how a persisted entity can be searched (used by generic admin filter forms).
+type EntityMetadata struct {
+	Name       string           `json:"name"`
+	Collection string           `json:"collection"`
+	Fields     []FieldMetadata  `json:"fields"`
+	Operators  []SearchOperator `json:"operators"`
+}
+
+type FieldMetadata struct {
+	Name      string `json:"name"`
+	BSONField string `json:"bson_field"`
+	Kind      string `json:"kind"` // string, number, bool, time, duration, uuid, object, array or unknown
+	Queryable bool   `json:"queryable"`
+}
+
+type EntityMetadataReader interface {
+	ListEntityMetadata(ctx context.Context) []EntityMetadata
+}
+
+// QueryableEntity is implemented by the query services, describing the fields their searches accept.
+type QueryableEntity interface {
+	GetEntityName() string
+	GetQueryableFields() map[string]bool
+}
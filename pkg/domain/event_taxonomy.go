@@ -0,0 +1,109 @@
+package common
+
+import "strings"
+
+// EventTaxonomyVersion is the version of the normalized event types. A game adapter tags every event with the version it normalized
+// it with: renaming or splitting a normalized type bumps it, so events stored under an older version can be told apart (and migrated).
+const EventTaxonomyVersion = 1
+
+// EventCategory groups the normalized event types, for the analytics across games.
+type EventCategory string
+
+const (
+	EventCategoryKill      EventCategory = "kill"      // kills and the duels around them (damage, clutches)
+	EventCategoryObjective EventCategory = "objective" // bomb (CS) and spike (Valorant) plants, defuses and detonations
+	EventCategoryUtility   EventCategory = "utility"   // grenades (CS) and abilities (Valorant)
+	EventCategoryEconomy   EventCategory = "economy"   // buys and credits
+	EventCategoryFlow      EventCategory = "flow"      // match and round lifecycle
+	EventCategoryTelemetry EventCategory = "telemetry" // samples of the game state (positions)
+)
+
+// NormalizedEventType is the type of an in-game event shared by every game, named `<category>.<event>`.
+type NormalizedEventType string
+
+const (
+	NormalizedKill           NormalizedEventType = "kill"
+	NormalizedRoundKills     NormalizedEventType = "kill.round_summary"
+	NormalizedDamage         NormalizedEventType = "kill.damage"
+	NormalizedWeaponFire     NormalizedEventType = "kill.weapon_fire"
+	NormalizedClutchStart    NormalizedEventType = "kill.clutch_start"
+	NormalizedClutchProgress NormalizedEventType = "kill.clutch_progress"
+	NormalizedClutchEnd      NormalizedEventType = "kill.clutch_end"
+
+	NormalizedObjectivePlant    NormalizedEventType = "objective.plant"
+	NormalizedObjectiveDefuse   NormalizedEventType = "objective.defuse"
+	NormalizedObjectiveDetonate NormalizedEventType = "objective.detonate"
+
+	NormalizedUtilityThrow    NormalizedEventType = "utility.throw"
+	NormalizedUtilityDetonate NormalizedEventType = "utility.detonate"
+
+	NormalizedEconomyBuy NormalizedEventType = "economy.buy"
+
+	NormalizedMatchStart NormalizedEventType = "flow.match_start"
+	NormalizedRoundStart NormalizedEventType = "flow.round_start"
+	NormalizedRoundEnd   NormalizedEventType = "flow.round_end"
+	NormalizedRoundMVP   NormalizedEventType = "flow.round_mvp"
+	NormalizedScoreboard NormalizedEventType = "flow.scoreboard"
+
+	NormalizedPositions NormalizedEventType = "telemetry.positions"
+)
+
+// Category of the normalized type (its prefix).
+func (t NormalizedEventType) Category() EventCategory {
+	category, _, _ := strings.Cut(string(t), ".")
+
+	return EventCategory(category)
+}
+
+// NormalizedEvent tags a game event with its normalized type. The payload of the event is the extension of the game: the normalized
+// type tells what it is about, the payload keeps the details only that game has (ie: the CS bomb site, the Valorant agent).
+type NormalizedEvent struct {
+	Version  int                 `json:"version" bson:"version"`
+	Category EventCategory       `json:"category" bson:"category"`
+	Type     NormalizedEventType `json:"type" bson:"type"`
+}
+
+// EventTaxonomy maps the event types of a game to their normalized types.
+type EventTaxonomy struct {
+	Version int                                `json:"version"`
+	Types   map[EventIDKey]NormalizedEventType `json:"types"`
+}
+
+// Normalize returns the normalized event of an event type of the game, nil when the type has no normalized counterpart (ie: the
+// generic game events).
+func (t *EventTaxonomy) Normalize(eventType EventIDKey) *NormalizedEvent {
+	normalizedType, ok := t.Types[eventType]
+	if !ok {
+		return nil
+	}
+
+	return &NormalizedEvent{
+		Version:  t.Version,
+		Category: normalizedType.Category(),
+		Type:     normalizedType,
+	}
+}
+
+// CSEventTaxonomy normalizes the events of the CS2 (and CS:GO) replays.
+var CSEventTaxonomy = &EventTaxonomy{
+	Version: EventTaxonomyVersion,
+	Types: map[EventIDKey]NormalizedEventType{
+		Event_FragOrScoreID:          NormalizedKill,
+		Event_RoundKillsID:           NormalizedRoundKills,
+		Event_HitID:                  NormalizedDamage,
+		Event_WeaponFireID:           NormalizedWeaponFire,
+		Event_ClutchStartID:          NormalizedClutchStart,
+		Event_ClutchProgressID:       NormalizedClutchProgress,
+		Event_ClutchEndID:            NormalizedClutchEnd,
+		Event_BombPlantedID:          NormalizedObjectivePlant,
+		Event_BombDefusedID:          NormalizedObjectiveDefuse,
+		Event_BombExplodedID:         NormalizedObjectiveDetonate,
+		Event_Economy:                NormalizedEconomyBuy,
+		Event_MatchStartID:           NormalizedMatchStart,
+		Event_RoundStartID:           NormalizedRoundStart,
+		Event_RoundEndID:             NormalizedRoundEnd,
+		Event_RoundMVPAnnouncementID: NormalizedRoundMVP,
+		Event_PlayerScoreboardID:     NormalizedScoreboard,
+		Event_PositionSamplesID:      NormalizedPositions,
+	},
+}
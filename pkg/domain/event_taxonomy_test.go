@@ -0,0 +1,42 @@
+package common_test
+
+import (
+	"testing"
+
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCSEventTaxonomy_NormalizesTheSupportedEvents(t *testing.T) {
+	categories := map[common.EventCategory]bool{
+		common.EventCategoryKill:      true,
+		common.EventCategoryObjective: true,
+		common.EventCategoryUtility:   true,
+		common.EventCategoryEconomy:   true,
+		common.EventCategoryFlow:      true,
+		common.EventCategoryTelemetry: true,
+	}
+
+	for _, game := range []*common.Game{common.CS2, common.CSGO} {
+		for _, eventType := range game.Events {
+			normalized := game.Taxonomy.Normalize(eventType)
+
+			if eventType == common.Event_GenericGameEventID {
+				assert.Nil(t, normalized)
+				continue
+			}
+
+			if assert.NotNil(t, normalized, "%s of %s isn't normalized", eventType, game.ID) {
+				assert.Equal(t, common.EventTaxonomyVersion, normalized.Version)
+				assert.True(t, categories[normalized.Category], "%s of %s is normalized to an unknown category %s", eventType, game.ID, normalized.Category)
+			}
+		}
+	}
+}
+
+func TestNormalizedEventType_Category(t *testing.T) {
+	assert.Equal(t, common.EventCategoryKill, common.NormalizedKill.Category())
+	assert.Equal(t, common.EventCategoryKill, common.NormalizedClutchEnd.Category())
+	assert.Equal(t, common.EventCategoryObjective, common.NormalizedObjectivePlant.Category())
+	assert.Equal(t, common.EventCategoryEconomy, common.NormalizedEconomyBuy.Category())
+}
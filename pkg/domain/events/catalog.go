@@ -0,0 +1,71 @@
+package events
+
+import (
+	"sort"
+)
+
+// Type identifies an event (ie: the rabbitmq routing key).
+type Type string
+
+// Topic groups the events of a bounded context (ie: the rabbitmq exchange they're published on).
+type Topic string
+
+const (
+	ReplayTopic      Topic = "replay"
+	MatchmakingTopic Topic = "matchmaking"
+	SquadTopic       Topic = "squad"
+)
+
+const (
+	ReplayFileUploaded       Type = "replay.uploaded"
+	ReplayProcessingProgress Type = "replay.progress"
+	LobbyCreated             Type = "lobby.created"
+	LobbyCancelled           Type = "lobby.cancelled"
+	QueueTicketExpired       Type = "queue.ticket_expired"
+	MatchCompleted           Type = "match.completed"
+
+	// steps of squad join requests: invites and applications are sent, then accepted or declined
+	SquadInviteSent           Type = "squad.invite_sent"
+	SquadApplicationSubmitted Type = "squad.application_submitted"
+	SquadJoinRequestAccepted  Type = "squad.join_request_accepted"
+	SquadJoinRequestDeclined  Type = "squad.join_request_declined"
+)
+
+// Definition is the catalog entry of an event: where it's routed and whether the broker persists it.
+type Definition struct {
+	Type    Type
+	Topic   Topic
+	Durable bool // transient events are only relevant while followed (ie: progress)
+}
+
+var catalog = map[Type]Definition{
+	ReplayFileUploaded:        {Type: ReplayFileUploaded, Topic: ReplayTopic, Durable: true},
+	ReplayProcessingProgress:  {Type: ReplayProcessingProgress, Topic: ReplayTopic, Durable: false},
+	LobbyCreated:              {Type: LobbyCreated, Topic: MatchmakingTopic, Durable: true},
+	LobbyCancelled:            {Type: LobbyCancelled, Topic: MatchmakingTopic, Durable: true},
+	QueueTicketExpired:        {Type: QueueTicketExpired, Topic: MatchmakingTopic, Durable: true},
+	MatchCompleted:            {Type: MatchCompleted, Topic: MatchmakingTopic, Durable: true},
+	SquadInviteSent:           {Type: SquadInviteSent, Topic: SquadTopic, Durable: true},
+	SquadApplicationSubmitted: {Type: SquadApplicationSubmitted, Topic: SquadTopic, Durable: true},
+	SquadJoinRequestAccepted:  {Type: SquadJoinRequestAccepted, Topic: SquadTopic, Durable: true},
+	SquadJoinRequestDeclined:  {Type: SquadJoinRequestDeclined, Topic: SquadTopic, Durable: true},
+}
+
+// Lookup returns the definition of a cataloged event type.
+func Lookup(t Type) (Definition, bool) {
+	def, ok := catalog[t]
+
+	return def, ok
+}
+
+// Catalog returns every event definition, by type.
+func Catalog() []Definition {
+	defs := make([]Definition, 0, len(catalog))
+	for _, def := range catalog {
+		defs = append(defs, def)
+	}
+
+	sort.Slice(defs, func(i, j int) bool { return defs[i].Type < defs[j].Type })
+
+	return defs
+}
@@ -0,0 +1,124 @@
+package events_test
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/psavelis/team-pro/replay-api/pkg/domain/events"
+)
+
+const moduleRoot = "../../.."
+
+// the only places allowed to build broker messages: the catalog publisher, and the consumer rerouting a delivery it received
+var allowedPublishings = map[string]bool{
+	"pkg/infra/events/rabbitmq/publisher.go:publish": true,
+	"pkg/infra/events/rabbitmq/consumer.go:reroute":  true,
+	"pkg/infra/events/pub_rabbit.go:main":            true, // rabbitmq poc, not an event
+}
+
+// TestCatalog_Lint fails when an event is published outside the catalog: a broker message built by hand, an events.Event not built
+// by its typed constructor, or an event type spelled as a string.
+func TestCatalog_Lint(t *testing.T) {
+	types := map[string]bool{}
+	for _, def := range events.Catalog() {
+		types[string(def.Type)] = true
+	}
+
+	err := filepath.WalkDir(moduleRoot, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if d.IsDir() {
+			if d.Name() == "vendor" || strings.HasPrefix(d.Name(), ".") && path != moduleRoot {
+				return filepath.SkipDir
+			}
+
+			return nil
+		}
+
+		if !strings.HasSuffix(path, ".go") || strings.HasSuffix(path, "_test.go") {
+			return nil
+		}
+
+		rel, _ := filepath.Rel(moduleRoot, path)
+		rel = filepath.ToSlash(rel)
+
+		if strings.HasPrefix(rel, "pkg/domain/events/") {
+			return nil
+		}
+
+		lintFile(t, rel, path, types)
+
+		return nil
+	})
+
+	if err != nil {
+		t.Fatalf("unable to walk the module: %v", err)
+	}
+}
+
+func lintFile(t *testing.T, rel string, path string, types map[string]bool) {
+	fset := token.NewFileSet()
+
+	file, err := parser.ParseFile(fset, path, nil, 0)
+	if err != nil {
+		t.Errorf("unable to parse %s: %v", rel, err)
+		return
+	}
+
+	imports := map[string]string{}
+	for _, spec := range file.Imports {
+		importPath, _ := strconv.Unquote(spec.Path.Value)
+
+		name := importPath[strings.LastIndex(importPath, "/")+1:]
+		if spec.Name != nil {
+			name = spec.Name.Name
+		}
+
+		imports[name] = importPath
+	}
+
+	for _, decl := range file.Decls {
+		funcName := ""
+		if fn, ok := decl.(*ast.FuncDecl); ok {
+			funcName = fn.Name.Name
+		}
+
+		ast.Inspect(decl, func(n ast.Node) bool {
+			switch node := n.(type) {
+			case *ast.BasicLit:
+				value, err := strconv.Unquote(node.Value)
+				if node.Kind == token.STRING && err == nil && types[value] {
+					t.Errorf("%s: event type %q spelled outside the catalog, use the events constant", fset.Position(node.Pos()), value)
+				}
+
+			case *ast.CompositeLit:
+				sel, ok := node.Type.(*ast.SelectorExpr)
+				if !ok {
+					return true
+				}
+
+				pkg, ok := sel.X.(*ast.Ident)
+				if !ok {
+					return true
+				}
+
+				switch {
+				case imports[pkg.Name] == "github.com/streadway/amqp" && sel.Sel.Name == "Publishing" && !allowedPublishings[rel+":"+funcName]:
+					t.Errorf("%s: amqp.Publishing built outside the catalog publisher, publish an events.Event", fset.Position(node.Pos()))
+				case imports[pkg.Name] == "github.com/psavelis/team-pro/replay-api/pkg/domain/events" && sel.Sel.Name == "Event":
+					t.Errorf("%s: events.Event built outside the catalog, use its typed constructor", fset.Position(node.Pos()))
+				}
+			}
+
+			return true
+		})
+	}
+}
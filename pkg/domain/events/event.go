@@ -0,0 +1,111 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	matchmaking_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/matchmaking/entities"
+	replay_entity "github.com/psavelis/team-pro/replay-api/pkg/domain/replay/entities"
+	squad_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/squad/entities"
+)
+
+var ErrMissingCorrelationID = errors.New("events: correlation id (request id) missing in context")
+
+// Event is a cataloged event ready to publish. Events are only built by the typed constructors below: the correlation ID is the
+// request (or job run) the event belongs to, the causation ID is the event consumed when it was published, or the request itself.
+type Event struct {
+	ID            uuid.UUID
+	Type          Type
+	Topic         Topic
+	Durable       bool
+	CorrelationID string
+	CausationID   string
+	OccurredAt    time.Time
+	Payload       interface{}
+}
+
+type causationKey struct{}
+
+// WithCause sets the event being handled in the context: the events published while handling it share its correlation ID and are
+// caused by it.
+func WithCause(ctx context.Context, correlationID string, eventID uuid.UUID) context.Context {
+	ctx = common.WithRequestID(ctx, correlationID)
+
+	return context.WithValue(ctx, causationKey{}, eventID)
+}
+
+func NewReplayFileUploaded(ctx context.Context, payload replay_entity.ReplayFileUploaded) (Event, error) {
+	return newEvent(ctx, ReplayFileUploaded, payload.UploadedAt, payload)
+}
+
+func NewReplayProcessingProgress(ctx context.Context, payload replay_entity.ReplayProcessingProgress) (Event, error) {
+	return newEvent(ctx, ReplayProcessingProgress, payload.UpdatedAt, payload)
+}
+
+func NewLobbyCreated(ctx context.Context, payload matchmaking_entities.LobbyCreated) (Event, error) {
+	return newEvent(ctx, LobbyCreated, payload.CreatedAt, payload)
+}
+
+func NewLobbyCancelled(ctx context.Context, payload matchmaking_entities.LobbyCancelled) (Event, error) {
+	return newEvent(ctx, LobbyCancelled, payload.CancelledAt, payload)
+}
+
+func NewQueueTicketExpired(ctx context.Context, payload matchmaking_entities.QueueTicketExpired) (Event, error) {
+	return newEvent(ctx, QueueTicketExpired, payload.ExpiredAt, payload)
+}
+
+func NewMatchCompleted(ctx context.Context, payload matchmaking_entities.MatchCompleted) (Event, error) {
+	return newEvent(ctx, MatchCompleted, payload.CompletedAt, payload)
+}
+
+// NewSquadJoinRequestUpdated builds the event of the step the join request is in (sent, accepted or declined).
+func NewSquadJoinRequestUpdated(ctx context.Context, payload squad_entities.JoinRequestUpdated) (Event, error) {
+	t := SquadApplicationSubmitted
+
+	switch {
+	case payload.Status == squad_entities.JoinRequestStatusAccepted:
+		t = SquadJoinRequestAccepted
+	case payload.Status == squad_entities.JoinRequestStatusDeclined:
+		t = SquadJoinRequestDeclined
+	case payload.Kind == squad_entities.JoinRequestKindInvite:
+		t = SquadInviteSent
+	}
+
+	return newEvent(ctx, t, payload.UpdatedAt, payload)
+}
+
+func newEvent(ctx context.Context, t Type, occurredAt time.Time, payload interface{}) (Event, error) {
+	def, ok := Lookup(t)
+	if !ok {
+		return Event{}, fmt.Errorf("events: %s is not cataloged", t)
+	}
+
+	scope, _ := common.GetRequestScope(ctx)
+	if scope.RequestID == "" {
+		return Event{}, fmt.Errorf("%w: %s", ErrMissingCorrelationID, t)
+	}
+
+	causationID := scope.RequestID
+	if cause, ok := ctx.Value(causationKey{}).(uuid.UUID); ok {
+		causationID = cause.String()
+	}
+
+	if occurredAt.IsZero() {
+		occurredAt = time.Now().UTC()
+	}
+
+	return Event{
+		ID:            uuid.New(),
+		Type:          def.Type,
+		Topic:         def.Topic,
+		Durable:       def.Durable,
+		CorrelationID: scope.RequestID,
+		CausationID:   causationID,
+		OccurredAt:    occurredAt,
+		Payload:       payload,
+	}, nil
+}
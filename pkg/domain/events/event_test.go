@@ -0,0 +1,74 @@
+package events_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	"github.com/psavelis/team-pro/replay-api/pkg/domain/events"
+	matchmaking_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/matchmaking/entities"
+	replay_entity "github.com/psavelis/team-pro/replay-api/pkg/domain/replay/entities"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewReplayFileUploaded(t *testing.T) {
+	uploaded := replay_entity.ReplayFileUploaded{ReplayFileID: uuid.New(), UploadedAt: time.Now()}
+
+	_, err := events.NewReplayFileUploaded(context.Background(), uploaded)
+	assert.ErrorIs(t, err, events.ErrMissingCorrelationID)
+
+	// published while handling a request: the request is the cause
+	ctx := common.WithRequestID(context.Background(), "req-1")
+
+	event, err := events.NewReplayFileUploaded(ctx, uploaded)
+	if assert.NoError(t, err) {
+		assert.NotEqual(t, uuid.Nil, event.ID)
+		assert.Equal(t, events.ReplayFileUploaded, event.Type)
+		assert.Equal(t, events.ReplayTopic, event.Topic)
+		assert.True(t, event.Durable)
+		assert.Equal(t, "req-1", event.CorrelationID)
+		assert.Equal(t, "req-1", event.CausationID)
+		assert.Equal(t, uploaded.UploadedAt, event.OccurredAt)
+		assert.Equal(t, uploaded, event.Payload)
+	}
+}
+
+func TestWithCause(t *testing.T) {
+	causeID := uuid.New()
+
+	// published while handling the uploaded event
+	ctx := events.WithCause(context.Background(), "req-1", causeID)
+
+	event, err := events.NewReplayProcessingProgress(ctx, replay_entity.ReplayProcessingProgress{ReplayFileID: uuid.New()})
+	if assert.NoError(t, err) {
+		assert.Equal(t, "req-1", event.CorrelationID)
+		assert.Equal(t, causeID.String(), event.CausationID)
+		assert.False(t, event.Durable)
+		assert.False(t, event.OccurredAt.IsZero())
+	}
+
+	event, err = events.NewLobbyCreated(ctx, matchmaking_entities.LobbyCreated{LobbyID: uuid.New()})
+	if assert.NoError(t, err) {
+		assert.Equal(t, events.MatchmakingTopic, event.Topic)
+		assert.Equal(t, causeID.String(), event.CausationID)
+	}
+}
+
+func TestCatalog(t *testing.T) {
+	defs := events.Catalog()
+	assert.NotEmpty(t, defs)
+
+	for _, def := range defs {
+		assert.NotEmpty(t, def.Type)
+		assert.NotEmpty(t, def.Topic)
+
+		found, ok := events.Lookup(def.Type)
+		assert.True(t, ok)
+		assert.Equal(t, def, found)
+	}
+
+	_, ok := events.Lookup("replay.deleted")
+	assert.False(t, ok)
+}
@@ -0,0 +1,167 @@
+package export_entities
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+)
+
+type ExportDataset string
+
+const (
+	ExportDatasetMatches        ExportDataset = "matches"
+	ExportDatasetPlayerStats    ExportDataset = "player_stats"
+	ExportDatasetRoundTimelines ExportDataset = "round_timelines"
+)
+
+// ExportDatasets that can be exported, in export order.
+var ExportDatasets = []ExportDataset{ExportDatasetMatches, ExportDatasetPlayerStats, ExportDatasetRoundTimelines}
+
+func (d ExportDataset) Valid() bool {
+	return slices.Contains(ExportDatasets, d)
+}
+
+type ExportFormat string
+
+const (
+	ExportFormatJSON ExportFormat = "json" // newline delimited, gzipped
+)
+
+var ExportFormats = []ExportFormat{ExportFormatJSON}
+
+func (f ExportFormat) Valid() bool {
+	return slices.Contains(ExportFormats, f)
+}
+
+const DefaultExportRegion = "us-east-1"
+
+var (
+	bucketNamePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9.-]{1,61}[a-z0-9]$`)
+	roleARNPattern    = regexp.MustCompile(`^arn:aws[a-z-]*:iam::\d{12}:role/[\w+=,.@/-]+$`)
+)
+
+// ExportDestination is a bucket of the tenant. It's written with the credentials of a role of the tenant's account, assumed with the
+// ExternalID (generated on creation) that its trust policy has to require.
+type ExportDestination struct {
+	Bucket     string `json:"bucket" bson:"bucket"`
+	Region     string `json:"region" bson:"region"`
+	KeyPrefix  string `json:"key_prefix,omitempty" bson:"key_prefix"`
+	RoleARN    string `json:"role_arn" bson:"role_arn"`
+	ExternalID string `json:"external_id" bson:"external_id"`
+}
+
+// ExportConfig is a nightly export of datasets of a client application to a bucket of its tenant. Each run exports the records
+// updated since the last delivery of the dataset (all of them on the first run).
+type ExportConfig struct {
+	ID          uuid.UUID         `json:"id" bson:"_id"`
+	Name        string            `json:"name" bson:"name"`
+	Datasets    []ExportDataset   `json:"datasets" bson:"datasets"`
+	Format      ExportFormat      `json:"format" bson:"format"`
+	Destination ExportDestination `json:"destination" bson:"destination"`
+	HourUTC     int               `json:"hour_utc" bson:"hour_utc"` // of the nightly run
+	Enabled     bool              `json:"enabled" bson:"enabled"`
+	NextRunAt   time.Time         `json:"next_run_at" bson:"next_run_at"`
+	LastRunAt   *time.Time        `json:"last_run_at,omitempty" bson:"last_run_at"`
+	// ExportedUntil is the end of the window of the last delivery of each dataset
+	ExportedUntil map[ExportDataset]time.Time `json:"exported_until" bson:"exported_until"`
+	// ClaimedUntil is set while a scheduler runs the export, so it's only run once (and retried once expired when the run is lost)
+	ClaimedUntil  *time.Time           `json:"-" bson:"claimed_until"`
+	ResourceOwner common.ResourceOwner `json:"resource_owner" bson:"resource_owner"`
+	CreatedAt     time.Time            `json:"created_at" bson:"created_at"`
+	UpdatedAt     time.Time            `json:"updated_at" bson:"updated_at"`
+}
+
+func (c ExportConfig) GetID() uuid.UUID {
+	return c.ID
+}
+
+// Validate checks the datasets and destination of the export, normalizing them (datasets in export order, json by default).
+func (c *ExportConfig) Validate() error {
+	c.Name = strings.TrimSpace(c.Name)
+	if c.Name == "" {
+		return fmt.Errorf("name is required")
+	}
+
+	if len(c.Datasets) == 0 {
+		return fmt.Errorf("at least one dataset is required (%s)", joinDatasets(ExportDatasets))
+	}
+
+	for _, dataset := range c.Datasets {
+		if !dataset.Valid() {
+			return fmt.Errorf("invalid dataset '%s' (expected %s)", dataset, joinDatasets(ExportDatasets))
+		}
+	}
+
+	datasets := make([]ExportDataset, 0, len(c.Datasets))
+	for _, dataset := range ExportDatasets {
+		if slices.Contains(c.Datasets, dataset) {
+			datasets = append(datasets, dataset)
+		}
+	}
+
+	c.Datasets = datasets
+
+	if c.Format == "" {
+		c.Format = ExportFormatJSON
+	}
+
+	if !c.Format.Valid() {
+		return fmt.Errorf("unsupported format '%s' (expected %s)", c.Format, ExportFormatJSON)
+	}
+
+	if c.HourUTC < 0 || c.HourUTC > 23 {
+		return fmt.Errorf("hour_utc must be from 0 to 23")
+	}
+
+	if !bucketNamePattern.MatchString(c.Destination.Bucket) {
+		return fmt.Errorf("invalid destination bucket '%s'", c.Destination.Bucket)
+	}
+
+	if !roleARNPattern.MatchString(c.Destination.RoleARN) {
+		return fmt.Errorf("destination role_arn must be the arn of an iam role (arn:aws:iam::<account>:role/<name>)")
+	}
+
+	if c.Destination.Region == "" {
+		c.Destination.Region = DefaultExportRegion
+	}
+
+	c.Destination.KeyPrefix = strings.Trim(c.Destination.KeyPrefix, "/")
+
+	return nil
+}
+
+// ObjectKey of a dataset exported by a run: <prefix>/<dataset>/date=<yyyy-mm-dd>/<run_id>.ndjson.gz (date of the end of its window).
+func (c ExportConfig) ObjectKey(dataset ExportDataset, windowTo time.Time, runID uuid.UUID) string {
+	key := fmt.Sprintf("%s/date=%s/%s.ndjson.gz", dataset, windowTo.UTC().Format(time.DateOnly), runID)
+	if c.Destination.KeyPrefix == "" {
+		return key
+	}
+
+	return c.Destination.KeyPrefix + "/" + key
+}
+
+// NextExportRunAt is the first time at hourUTC after the given time.
+func NextExportRunAt(hourUTC int, after time.Time) time.Time {
+	after = after.UTC()
+
+	next := time.Date(after.Year(), after.Month(), after.Day(), hourUTC, 0, 0, 0, time.UTC)
+	if !next.After(after) {
+		next = next.AddDate(0, 0, 1)
+	}
+
+	return next
+}
+
+func joinDatasets(datasets []ExportDataset) string {
+	names := make([]string, len(datasets))
+	for i, dataset := range datasets {
+		names[i] = string(dataset)
+	}
+
+	return strings.Join(names, ", ")
+}
@@ -0,0 +1,85 @@
+package export_entities_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	export_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/export/entities"
+	"github.com/stretchr/testify/assert"
+)
+
+func validExportConfig() export_entities.ExportConfig {
+	return export_entities.ExportConfig{
+		Name:     "nightly",
+		Datasets: []export_entities.ExportDataset{export_entities.ExportDatasetPlayerStats, export_entities.ExportDatasetMatches, export_entities.ExportDatasetMatches},
+		HourUTC:  3,
+		Destination: export_entities.ExportDestination{
+			Bucket:    "tenant-exports",
+			KeyPrefix: "/replay-api/",
+			RoleARN:   "arn:aws:iam::123456789012:role/replay-api-export",
+		},
+	}
+}
+
+func TestExportConfig_Validate(t *testing.T) {
+	config := validExportConfig()
+
+	if !assert.NoError(t, config.Validate()) {
+		return
+	}
+
+	assert.Equal(t, []export_entities.ExportDataset{export_entities.ExportDatasetMatches, export_entities.ExportDatasetPlayerStats}, config.Datasets)
+	assert.Equal(t, export_entities.ExportFormatJSON, config.Format)
+	assert.Equal(t, export_entities.DefaultExportRegion, config.Destination.Region)
+	assert.Equal(t, "replay-api", config.Destination.KeyPrefix)
+
+	tests := []struct {
+		name   string
+		modify func(c *export_entities.ExportConfig)
+	}{
+		{"no name", func(c *export_entities.ExportConfig) { c.Name = " " }},
+		{"no datasets", func(c *export_entities.ExportConfig) { c.Datasets = nil }},
+		{"unknown dataset", func(c *export_entities.ExportConfig) { c.Datasets = []export_entities.ExportDataset{"tournaments"} }},
+		{"unsupported format", func(c *export_entities.ExportConfig) { c.Format = "parquet" }},
+		{"invalid hour", func(c *export_entities.ExportConfig) { c.HourUTC = 24 }},
+		{"invalid bucket", func(c *export_entities.ExportConfig) { c.Destination.Bucket = "Tenant_Exports" }},
+		{"invalid role", func(c *export_entities.ExportConfig) {
+			c.Destination.RoleARN = "arn:aws:iam::123456789012:user/exports"
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := validExportConfig()
+			tt.modify(&config)
+
+			assert.Error(t, config.Validate())
+		})
+	}
+}
+
+func TestNextExportRunAt(t *testing.T) {
+	before := time.Date(2026, 10, 16, 2, 59, 0, 0, time.UTC)
+	assert.Equal(t, time.Date(2026, 10, 16, 3, 0, 0, 0, time.UTC), export_entities.NextExportRunAt(3, before))
+
+	at := time.Date(2026, 10, 16, 3, 0, 0, 0, time.UTC)
+	assert.Equal(t, time.Date(2026, 10, 17, 3, 0, 0, 0, time.UTC), export_entities.NextExportRunAt(3, at))
+
+	// other time zones are converted (02:30 UTC)
+	local := time.Date(2026, 10, 16, 23, 30, 0, 0, time.FixedZone("BRT", -3*60*60))
+	assert.Equal(t, time.Date(2026, 10, 17, 3, 0, 0, 0, time.UTC), export_entities.NextExportRunAt(3, local))
+}
+
+func TestExportConfig_ObjectKey(t *testing.T) {
+	runID := uuid.MustParse("00000000-0000-0000-0000-000000000001")
+	windowTo := time.Date(2026, 10, 16, 3, 0, 0, 0, time.UTC)
+
+	config := validExportConfig()
+	assert.NoError(t, config.Validate())
+
+	assert.Equal(t, "replay-api/matches/date=2026-10-16/00000000-0000-0000-0000-000000000001.ndjson.gz", config.ObjectKey(export_entities.ExportDatasetMatches, windowTo, runID))
+
+	config.Destination.KeyPrefix = ""
+	assert.Equal(t, "matches/date=2026-10-16/00000000-0000-0000-0000-000000000001.ndjson.gz", config.ObjectKey(export_entities.ExportDatasetMatches, windowTo, runID))
+}
@@ -0,0 +1,41 @@
+package export_entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+)
+
+type ExportReceiptStatus string
+
+const (
+	ExportReceiptDelivered ExportReceiptStatus = "delivered"
+	ExportReceiptFailed    ExportReceiptStatus = "failed"
+)
+
+// ExportReceipt records the delivery (or failed attempt) of a dataset by an export run, so tenants can reconcile their bucket: the
+// object written, its record count and checksum, and the window of the records.
+type ExportReceipt struct {
+	ID             uuid.UUID            `json:"id" bson:"_id"`
+	ExportConfigID uuid.UUID            `json:"export_config_id" bson:"export_config_id"`
+	RunID          uuid.UUID            `json:"run_id" bson:"run_id"`
+	Dataset        ExportDataset        `json:"dataset" bson:"dataset"`
+	Format         ExportFormat         `json:"format" bson:"format"`
+	Status         ExportReceiptStatus  `json:"status" bson:"status"`
+	Bucket         string               `json:"bucket" bson:"bucket"`
+	Key            string               `json:"key" bson:"key"`
+	Records        int                  `json:"records" bson:"records"`
+	Bytes          int                  `json:"bytes" bson:"bytes"`
+	SHA256         string               `json:"sha256,omitempty" bson:"sha256"` // of the object (hex)
+	WindowFrom     *time.Time           `json:"window_from,omitempty" bson:"window_from"`
+	WindowTo       time.Time            `json:"window_to" bson:"window_to"`
+	Error          string               `json:"error,omitempty" bson:"error"`
+	ResourceOwner  common.ResourceOwner `json:"resource_owner" bson:"resource_owner"`
+	CreatedAt      time.Time            `json:"created_at" bson:"created_at"`
+	UpdatedAt      time.Time            `json:"updated_at" bson:"updated_at"`
+}
+
+func (r ExportReceipt) GetID() uuid.UUID {
+	return r.ID
+}
@@ -0,0 +1,37 @@
+package export
+
+import (
+	"fmt"
+
+	"github.com/google/uuid"
+)
+
+// Invalid Export Config Error (export config rejected)
+type InvalidExportConfigError struct {
+	Message string
+}
+
+func (e *InvalidExportConfigError) Error() string {
+	return e.Message
+}
+
+func NewInvalidExportConfigError(message string) *InvalidExportConfigError {
+	return &InvalidExportConfigError{
+		Message: message,
+	}
+}
+
+// Export Dataset Unavailable Error (no reader registered for the dataset)
+type ExportDatasetUnavailableError struct {
+	Message string
+}
+
+func (e *ExportDatasetUnavailableError) Error() string {
+	return e.Message
+}
+
+func NewExportDatasetUnavailableError(configID uuid.UUID, dataset string) *ExportDatasetUnavailableError {
+	return &ExportDatasetUnavailableError{
+		Message: fmt.Sprintf("dataset %s of export %s is not available", dataset, configID),
+	}
+}
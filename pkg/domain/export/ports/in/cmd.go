@@ -0,0 +1,20 @@
+package export_in
+
+import (
+	"context"
+	"time"
+
+	export_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/export/entities"
+)
+
+// CreateExportConfigCommand creates a nightly export of datasets of the client application in context to a bucket of its tenant. The
+// external ID to require in the trust policy of the destination role is returned with it.
+type CreateExportConfigCommand interface {
+	Exec(ctx context.Context, config export_entities.ExportConfig) (*export_entities.ExportConfig, error)
+}
+
+// RunScheduledExportsCommand runs the exports due (of every tenant), recording a receipt of each dataset delivered (or failed), and
+// returns the count of datasets delivered.
+type RunScheduledExportsCommand interface {
+	Exec(ctx context.Context, now time.Time) (int, error)
+}
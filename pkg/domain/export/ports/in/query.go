@@ -0,0 +1,14 @@
+package export_in
+
+import (
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	export_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/export/entities"
+)
+
+type ExportConfigReader interface {
+	common.Searchable[export_entities.ExportConfig]
+}
+
+type ExportReceiptReader interface {
+	common.Searchable[export_entities.ExportReceipt]
+}
@@ -0,0 +1,24 @@
+package export_out
+
+import (
+	"context"
+	"time"
+
+	export_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/export/entities"
+)
+
+type ExportConfigWriter interface {
+	Create(ctx context.Context, config *export_entities.ExportConfig) (*export_entities.ExportConfig, error)
+	Update(ctx context.Context, config *export_entities.ExportConfig) (*export_entities.ExportConfig, error)
+	// ClaimDue claims an enabled export due at now (of any tenant) for the lease, nil when none is due.
+	ClaimDue(ctx context.Context, now time.Time, lease time.Duration) (*export_entities.ExportConfig, error)
+}
+
+type ExportReceiptWriter interface {
+	Create(ctx context.Context, receipt *export_entities.ExportReceipt) (*export_entities.ExportReceipt, error)
+}
+
+// ExportDelivery writes an object to the bucket of a destination, with the credentials of its role.
+type ExportDelivery interface {
+	Deliver(ctx context.Context, destination export_entities.ExportDestination, key string, content []byte) error
+}
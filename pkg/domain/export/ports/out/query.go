@@ -0,0 +1,23 @@
+package export_out
+
+import (
+	"context"
+	"time"
+
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	export_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/export/entities"
+)
+
+type ExportConfigReader interface {
+	common.Searchable[export_entities.ExportConfig]
+}
+
+type ExportReceiptReader interface {
+	common.Searchable[export_entities.ExportReceipt]
+}
+
+// ExportDatasetReader reads a page of the records of a dataset updated from `from` (the first one when nil) to `to`, oldest first, at
+// the client level of the context.
+type ExportDatasetReader interface {
+	Read(ctx context.Context, from *time.Time, to time.Time, skip uint, limit uint) ([]interface{}, error)
+}
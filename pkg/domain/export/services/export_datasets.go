@@ -0,0 +1,72 @@
+package export_services
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	export_out "github.com/psavelis/team-pro/replay-api/pkg/domain/export/ports/out"
+	replay_entity "github.com/psavelis/team-pro/replay-api/pkg/domain/replay/entities"
+	replay_out "github.com/psavelis/team-pro/replay-api/pkg/domain/replay/ports/out"
+)
+
+// searchableDataset reads a dataset from a reader whose entities have an UpdatedAt, mapping each one to its exported record.
+type searchableDataset[T any] struct {
+	name   string
+	reader common.Searchable[T]
+	record func(T) interface{}
+}
+
+func (d *searchableDataset[T]) Read(ctx context.Context, from *time.Time, to time.Time, skip uint, limit uint) ([]interface{}, error) {
+	s := common.NewSearchByRange(ctx, []common.SearchableDateRange{{Field: "UpdatedAt", Min: from, Max: &to}}, common.NewSearchResultOptions(skip, limit), common.ClientApplicationAudienceIDKey)
+	s.SortOptions = []common.SearchSortOption{{Field: "UpdatedAt", Direction: common.AscendingIDKey}, {Field: "ID", Direction: common.AscendingIDKey}}
+
+	entities, err := d.reader.Search(ctx, s)
+	if err != nil {
+		slog.ErrorContext(ctx, "unable to read export dataset", "dataset", d.name, "skip", skip, "err", err)
+		return nil, err
+	}
+
+	records := make([]interface{}, len(entities))
+	for i, entity := range entities {
+		records[i] = d.record(entity)
+	}
+
+	return records, nil
+}
+
+// NewMatchesExportDataset exports matches without their game events (served by the events API, and by far the bulk of a match).
+func NewMatchesExportDataset(matchReader replay_out.MatchMetadataReader) export_out.ExportDatasetReader {
+	return &searchableDataset[replay_entity.Match]{
+		name:   "matches",
+		reader: matchReader,
+		record: func(match replay_entity.Match) interface{} {
+			match.Events = nil
+			match.ShareTokens = nil
+
+			return match
+		},
+	}
+}
+
+// NewPlayerStatsExportDataset exports the daily stats buckets of players (by map).
+func NewPlayerStatsExportDataset(statsReader replay_out.PlayerStatsReader) export_out.ExportDatasetReader {
+	return &searchableDataset[replay_entity.PlayerStatsBucket]{
+		name:   "player_stats",
+		reader: statsReader,
+		record: func(bucket replay_entity.PlayerStatsBucket) interface{} {
+			return bucket
+		},
+	}
+}
+
+func NewRoundTimelinesExportDataset(timelineReader replay_out.RoundTimelineReader) export_out.ExportDatasetReader {
+	return &searchableDataset[replay_entity.RoundTimeline]{
+		name:   "round_timelines",
+		reader: timelineReader,
+		record: func(timeline replay_entity.RoundTimeline) interface{} {
+			return timeline
+		},
+	}
+}
@@ -0,0 +1,110 @@
+package export_services
+
+import (
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	export_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/export/entities"
+	export_in "github.com/psavelis/team-pro/replay-api/pkg/domain/export/ports/in"
+	export_out "github.com/psavelis/team-pro/replay-api/pkg/domain/export/ports/out"
+)
+
+type ExportConfigQueryService struct {
+	common.BaseQueryService[export_entities.ExportConfig]
+}
+
+func NewExportConfigQueryService(configReader export_out.ExportConfigReader) export_in.ExportConfigReader {
+	queryableFields := map[string]bool{
+		"ID":            true,
+		"Name":          true,
+		"Datasets":      true,
+		"Format":        true,
+		"Destination":   common.DENY,
+		"HourUTC":       true,
+		"Enabled":       true,
+		"NextRunAt":     true,
+		"LastRunAt":     true,
+		"ExportedUntil": common.DENY,
+		"ClaimedUntil":  common.DENY,
+		"ResourceOwner": common.DENY,
+		"CreatedAt":     true,
+		"UpdatedAt":     true,
+	}
+
+	readableFields := map[string]bool{
+		"ID":            true,
+		"Name":          true,
+		"Datasets":      true,
+		"Format":        true,
+		"Destination":   common.DENY,
+		"HourUTC":       true,
+		"Enabled":       true,
+		"NextRunAt":     true,
+		"LastRunAt":     true,
+		"ExportedUntil": true,
+		"ClaimedUntil":  common.DENY,
+		"ResourceOwner": common.DENY,
+		"CreatedAt":     true,
+		"UpdatedAt":     true,
+	}
+
+	return &common.BaseQueryService[export_entities.ExportConfig]{
+		Reader:          configReader.(common.Searchable[export_entities.ExportConfig]),
+		QueryableFields: queryableFields,
+		ReadableFields:  readableFields,
+		MaxPageSize:     100,
+		Audience:        common.ClientApplicationAudienceIDKey,
+	}
+}
+
+type ExportReceiptQueryService struct {
+	common.BaseQueryService[export_entities.ExportReceipt]
+}
+
+func NewExportReceiptQueryService(receiptReader export_out.ExportReceiptReader) export_in.ExportReceiptReader {
+	queryableFields := map[string]bool{
+		"ID":             true,
+		"ExportConfigID": true,
+		"RunID":          true,
+		"Dataset":        true,
+		"Format":         true,
+		"Status":         true,
+		"Bucket":         true,
+		"Key":            true,
+		"Records":        true,
+		"Bytes":          true,
+		"SHA256":         true,
+		"WindowFrom":     true,
+		"WindowTo":       true,
+		"Error":          common.DENY,
+		"ResourceOwner":  common.DENY,
+		"CreatedAt":      true,
+		"UpdatedAt":      true,
+	}
+
+	readableFields := map[string]bool{
+		"ID":             true,
+		"ExportConfigID": true,
+		"RunID":          true,
+		"Dataset":        true,
+		"Format":         true,
+		"Status":         true,
+		"Bucket":         true,
+		"Key":            true,
+		"Records":        true,
+		"Bytes":          true,
+		"SHA256":         true,
+		"WindowFrom":     true,
+		"WindowTo":       true,
+		"Error":          true,
+		"ResourceOwner":  common.DENY,
+		"CreatedAt":      true,
+		"UpdatedAt":      true,
+	}
+
+	return &common.BaseQueryService[export_entities.ExportReceipt]{
+		Reader:          receiptReader.(common.Searchable[export_entities.ExportReceipt]),
+		QueryableFields: queryableFields,
+		ReadableFields:  readableFields,
+		MaxPageSize:     100,
+		Audience:        common.ClientApplicationAudienceIDKey,
+	}
+}
@@ -0,0 +1,59 @@
+package export_use_cases
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	"github.com/psavelis/team-pro/replay-api/pkg/domain/export"
+	export_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/export/entities"
+	export_in "github.com/psavelis/team-pro/replay-api/pkg/domain/export/ports/in"
+	export_out "github.com/psavelis/team-pro/replay-api/pkg/domain/export/ports/out"
+)
+
+type CreateExportConfigUseCase struct {
+	ConfigWriter export_out.ExportConfigWriter
+}
+
+func NewCreateExportConfigUseCase(configWriter export_out.ExportConfigWriter) export_in.CreateExportConfigCommand {
+	return &CreateExportConfigUseCase{
+		ConfigWriter: configWriter,
+	}
+}
+
+// Exec schedules the first run of the export for its next hour_utc. The external ID is always generated: it's what keeps other tenants
+// from having their exports delivered to a bucket they don't own.
+func (usecase *CreateExportConfigUseCase) Exec(ctx context.Context, config export_entities.ExportConfig) (*export_entities.ExportConfig, error) {
+	resourceOwner := common.GetResourceOwner(ctx)
+	if resourceOwner.TenantID == uuid.Nil || resourceOwner.ClientID == uuid.Nil {
+		return nil, export.NewInvalidExportConfigError("exports can only be configured by a client application")
+	}
+
+	err := config.Validate()
+	if err != nil {
+		return nil, export.NewInvalidExportConfigError(err.Error())
+	}
+
+	now := time.Now().UTC()
+
+	config.ID = uuid.New()
+	config.Destination.ExternalID = uuid.NewString()
+	config.Enabled = true
+	config.NextRunAt = export_entities.NextExportRunAt(config.HourUTC, now)
+	config.LastRunAt = nil
+	config.ExportedUntil = make(map[export_entities.ExportDataset]time.Time)
+	config.ClaimedUntil = nil
+	config.ResourceOwner = common.ResourceOwner{TenantID: resourceOwner.TenantID, ClientID: resourceOwner.ClientID, UserID: resourceOwner.UserID}
+	config.CreatedAt = now
+	config.UpdatedAt = now
+
+	created, err := usecase.ConfigWriter.Create(ctx, &config)
+	if err != nil {
+		slog.ErrorContext(ctx, "unable to create export config", "name", config.Name, "err", err)
+		return nil, err
+	}
+
+	return created, nil
+}
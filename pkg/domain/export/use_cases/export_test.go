@@ -0,0 +1,286 @@
+package export_use_cases_test
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	"github.com/psavelis/team-pro/replay-api/pkg/domain/export"
+	export_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/export/entities"
+	export_out "github.com/psavelis/team-pro/replay-api/pkg/domain/export/ports/out"
+	export_use_cases "github.com/psavelis/team-pro/replay-api/pkg/domain/export/use_cases"
+	"github.com/stretchr/testify/assert"
+)
+
+// mockConfigStore claims the configs due in order, as long as they aren't claimed.
+type mockConfigStore struct {
+	configs []*export_entities.ExportConfig
+	updated []export_entities.ExportConfig
+}
+
+func (m *mockConfigStore) Create(ctx context.Context, config *export_entities.ExportConfig) (*export_entities.ExportConfig, error) {
+	m.configs = append(m.configs, config)
+	return config, nil
+}
+
+func (m *mockConfigStore) Update(ctx context.Context, config *export_entities.ExportConfig) (*export_entities.ExportConfig, error) {
+	m.updated = append(m.updated, *config)
+	return config, nil
+}
+
+func (m *mockConfigStore) ClaimDue(ctx context.Context, now time.Time, lease time.Duration) (*export_entities.ExportConfig, error) {
+	for _, c := range m.configs {
+		if c.Enabled && !c.NextRunAt.After(now) && (c.ClaimedUntil == nil || !c.ClaimedUntil.After(now)) {
+			claimedUntil := now.Add(lease)
+			c.ClaimedUntil = &claimedUntil
+
+			claimed := *c
+			return &claimed, nil
+		}
+	}
+
+	return nil, nil
+}
+
+type mockReceiptStore struct {
+	receipts []export_entities.ExportReceipt
+	owners   []common.ResourceOwner
+}
+
+func (m *mockReceiptStore) Create(ctx context.Context, receipt *export_entities.ExportReceipt) (*export_entities.ExportReceipt, error) {
+	m.receipts = append(m.receipts, *receipt)
+	m.owners = append(m.owners, common.GetResourceOwner(ctx))
+	return receipt, nil
+}
+
+type mockDelivery struct {
+	objects map[string][]byte
+	err     error
+}
+
+func (m *mockDelivery) Deliver(ctx context.Context, destination export_entities.ExportDestination, key string, content []byte) error {
+	if m.err != nil {
+		return m.err
+	}
+
+	m.objects[destination.Bucket+"/"+key] = content
+	return nil
+}
+
+// mockDataset pages its records, keeping the windows requested.
+type mockDataset struct {
+	records []interface{}
+	froms   []*time.Time
+	owners  []common.ResourceOwner
+	err     error
+}
+
+func (m *mockDataset) Read(ctx context.Context, from *time.Time, to time.Time, skip uint, limit uint) ([]interface{}, error) {
+	m.froms = append(m.froms, from)
+	m.owners = append(m.owners, common.GetResourceOwner(ctx))
+
+	if m.err != nil {
+		return nil, m.err
+	}
+
+	if int(skip) >= len(m.records) {
+		return []interface{}{}, nil
+	}
+
+	return m.records[skip:min(int(skip+limit), len(m.records))], nil
+}
+
+func readLines(t *testing.T, content []byte) []string {
+	gz, err := gzip.NewReader(bytes.NewReader(content))
+	if !assert.NoError(t, err) {
+		return nil
+	}
+
+	lines := make([]string, 0)
+
+	scanner := bufio.NewScanner(gz)
+	for scanner.Scan() {
+		lines = append(lines, scanner.Text())
+	}
+
+	return lines
+}
+
+func TestCreateExportConfig(t *testing.T) {
+	owner := common.ResourceOwner{TenantID: uuid.New(), ClientID: uuid.New(), UserID: uuid.New()}
+	store := &mockConfigStore{}
+
+	usecase := export_use_cases.NewCreateExportConfigUseCase(store)
+
+	created, err := usecase.Exec(common.WithResourceOwner(context.Background(), owner), export_entities.ExportConfig{
+		Name:     "nightly",
+		Datasets: []export_entities.ExportDataset{export_entities.ExportDatasetMatches},
+		HourUTC:  3,
+		Destination: export_entities.ExportDestination{
+			Bucket:     "tenant-exports",
+			RoleARN:    "arn:aws:iam::123456789012:role/replay-api-export",
+			ExternalID: "chosen-by-the-client",
+		},
+	})
+
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	assert.NotEqual(t, uuid.Nil, created.ID)
+	assert.NotEqual(t, "chosen-by-the-client", created.Destination.ExternalID)
+	assert.True(t, created.Enabled)
+	assert.Equal(t, 3, created.NextRunAt.Hour())
+	assert.True(t, created.NextRunAt.After(time.Now()))
+	assert.Equal(t, owner, created.ResourceOwner)
+	assert.Len(t, store.configs, 1)
+
+	_, err = usecase.Exec(common.WithResourceOwner(context.Background(), owner), export_entities.ExportConfig{Name: "parquet", Datasets: []export_entities.ExportDataset{export_entities.ExportDatasetMatches}, Format: "parquet"})
+
+	var invalidErr *export.InvalidExportConfigError
+	assert.True(t, errors.As(err, &invalidErr))
+	assert.Len(t, store.configs, 1)
+}
+
+func TestRunScheduledExports(t *testing.T) {
+	now := time.Date(2026, 10, 16, 3, 5, 0, 0, time.UTC)
+	lastRun := now.Add(-24 * time.Hour)
+	owner := common.ResourceOwner{TenantID: uuid.New(), ClientID: uuid.New(), UserID: uuid.New()}
+
+	config := &export_entities.ExportConfig{
+		ID:            uuid.New(),
+		Name:          "nightly",
+		Datasets:      []export_entities.ExportDataset{export_entities.ExportDatasetMatches, export_entities.ExportDatasetPlayerStats, export_entities.ExportDatasetRoundTimelines},
+		Format:        export_entities.ExportFormatJSON,
+		Destination:   export_entities.ExportDestination{Bucket: "tenant-exports", KeyPrefix: "replay-api"},
+		HourUTC:       3,
+		Enabled:       true,
+		NextRunAt:     now.Add(-5 * time.Minute),
+		ExportedUntil: map[export_entities.ExportDataset]time.Time{export_entities.ExportDatasetMatches: lastRun},
+		ResourceOwner: owner,
+	}
+
+	disabled := &export_entities.ExportConfig{ID: uuid.New(), NextRunAt: now.Add(-time.Hour)}
+	notDue := &export_entities.ExportConfig{ID: uuid.New(), Enabled: true, NextRunAt: now.Add(time.Hour)}
+
+	matches := &mockDataset{}
+	for i := 0; i < export_use_cases.ExportPageSize+1; i++ {
+		matches.records = append(matches.records, map[string]int{"n": i})
+	}
+
+	stats := &mockDataset{err: errors.New("unavailable")}
+
+	configs := &mockConfigStore{configs: []*export_entities.ExportConfig{disabled, notDue, config}}
+	receipts := &mockReceiptStore{}
+	delivery := &mockDelivery{objects: make(map[string][]byte)}
+
+	// round timelines have no reader: their receipt is a failure too
+	usecase := export_use_cases.NewRunScheduledExportsUseCase(configs, receipts, delivery, map[export_entities.ExportDataset]export_out.ExportDatasetReader{
+		export_entities.ExportDatasetMatches:     matches,
+		export_entities.ExportDatasetPlayerStats: stats,
+	})
+
+	ctx := common.WithResourceOwner(context.Background(), common.ResourceOwner{TenantID: common.TeamPROTenantID, ClientID: common.ServerClientID})
+
+	delivered, err := usecase.Exec(ctx, now)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	assert.Equal(t, 1, delivered)
+
+	// matches: from the last delivery, on behalf of the owner (client level)
+	if assert.Len(t, matches.froms, 2) {
+		assert.Equal(t, lastRun, *matches.froms[0])
+	}
+
+	assert.Equal(t, common.ResourceOwner{TenantID: owner.TenantID, ClientID: owner.ClientID}, matches.owners[0])
+
+	if !assert.Len(t, receipts.receipts, 3) {
+		return
+	}
+
+	receipt := receipts.receipts[0]
+	assert.Equal(t, export_entities.ExportReceiptDelivered, receipt.Status)
+	assert.Equal(t, export_use_cases.ExportPageSize+1, receipt.Records)
+	assert.Equal(t, lastRun, *receipt.WindowFrom)
+	assert.Equal(t, now, receipt.WindowTo)
+	assert.Equal(t, config.ObjectKey(export_entities.ExportDatasetMatches, now, receipt.RunID), receipt.Key)
+
+	content := delivery.objects["tenant-exports/"+receipt.Key]
+	checksum := sha256.Sum256(content)
+
+	assert.Equal(t, hex.EncodeToString(checksum[:]), receipt.SHA256)
+	assert.Equal(t, len(content), receipt.Bytes)
+
+	lines := readLines(t, content)
+	if assert.Len(t, lines, export_use_cases.ExportPageSize+1) {
+		assert.Equal(t, `{"n":0}`, lines[0])
+	}
+
+	assert.Equal(t, export_entities.ExportReceiptFailed, receipts.receipts[1].Status)
+	assert.Equal(t, "unavailable", receipts.receipts[1].Error)
+	assert.Nil(t, receipts.receipts[1].WindowFrom)
+
+	assert.Equal(t, export_entities.ExportReceiptFailed, receipts.receipts[2].Status)
+	assert.Equal(t, receipt.RunID, receipts.receipts[2].RunID)
+
+	for _, o := range receipts.owners {
+		assert.Equal(t, owner.TenantID, o.TenantID)
+		assert.Equal(t, owner.ClientID, o.ClientID)
+	}
+
+	// only the datasets delivered move forward, the next run is the next night
+	if assert.Len(t, configs.updated, 1) {
+		updated := configs.updated[0]
+
+		assert.Equal(t, map[export_entities.ExportDataset]time.Time{export_entities.ExportDatasetMatches: now}, updated.ExportedUntil)
+		assert.Equal(t, time.Date(2026, 10, 17, 3, 0, 0, 0, time.UTC), updated.NextRunAt)
+		assert.Equal(t, now, *updated.LastRunAt)
+		assert.Nil(t, updated.ClaimedUntil)
+	}
+}
+
+func TestRunScheduledExports_DeliveryFailed(t *testing.T) {
+	now := time.Date(2026, 10, 16, 3, 5, 0, 0, time.UTC)
+
+	config := &export_entities.ExportConfig{
+		ID:            uuid.New(),
+		Datasets:      []export_entities.ExportDataset{export_entities.ExportDatasetMatches},
+		Format:        export_entities.ExportFormatJSON,
+		HourUTC:       3,
+		Enabled:       true,
+		NextRunAt:     now,
+		ResourceOwner: common.ResourceOwner{TenantID: uuid.New(), ClientID: uuid.New()},
+	}
+
+	configs := &mockConfigStore{configs: []*export_entities.ExportConfig{config}}
+	receipts := &mockReceiptStore{}
+
+	usecase := export_use_cases.NewRunScheduledExportsUseCase(configs, receipts, &mockDelivery{err: errors.New("AccessDenied")}, map[export_entities.ExportDataset]export_out.ExportDatasetReader{
+		export_entities.ExportDatasetMatches: &mockDataset{records: []interface{}{1, 2}},
+	})
+
+	delivered, err := usecase.Exec(context.Background(), now)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 0, delivered)
+
+	if assert.Len(t, receipts.receipts, 1) {
+		assert.Equal(t, export_entities.ExportReceiptFailed, receipts.receipts[0].Status)
+		assert.Equal(t, "AccessDenied", receipts.receipts[0].Error)
+		assert.Equal(t, 2, receipts.receipts[0].Records)
+	}
+
+	if assert.Len(t, configs.updated, 1) {
+		assert.Empty(t, configs.updated[0].ExportedUntil)
+	}
+}
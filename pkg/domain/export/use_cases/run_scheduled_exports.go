@@ -0,0 +1,173 @@
+package export_use_cases
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	"github.com/psavelis/team-pro/replay-api/pkg/domain/export"
+	export_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/export/entities"
+	export_in "github.com/psavelis/team-pro/replay-api/pkg/domain/export/ports/in"
+	export_out "github.com/psavelis/team-pro/replay-api/pkg/domain/export/ports/out"
+)
+
+const (
+	// ExportRunLease is how long an export is claimed by a run: a lost run is retried once it expires
+	ExportRunLease = time.Hour
+
+	// ExportPageSize is the records read per page of a dataset
+	ExportPageSize = 500
+)
+
+type RunScheduledExportsUseCase struct {
+	ConfigWriter  export_out.ExportConfigWriter
+	ReceiptWriter export_out.ExportReceiptWriter
+	Delivery      export_out.ExportDelivery
+	Datasets      map[export_entities.ExportDataset]export_out.ExportDatasetReader
+}
+
+func NewRunScheduledExportsUseCase(configWriter export_out.ExportConfigWriter, receiptWriter export_out.ExportReceiptWriter, delivery export_out.ExportDelivery, datasets map[export_entities.ExportDataset]export_out.ExportDatasetReader) export_in.RunScheduledExportsCommand {
+	return &RunScheduledExportsUseCase{
+		ConfigWriter:  configWriter,
+		ReceiptWriter: receiptWriter,
+		Delivery:      delivery,
+		Datasets:      datasets,
+	}
+}
+
+// Exec claims the exports due one at a time (so concurrent schedulers share them) and runs each on behalf of its owner. A dataset that
+// fails is exported again on the next run, from the end of its last delivered window.
+func (usecase *RunScheduledExportsUseCase) Exec(ctx context.Context, now time.Time) (int, error) {
+	delivered := 0
+
+	for {
+		config, err := usecase.ConfigWriter.ClaimDue(ctx, now, ExportRunLease)
+		if err != nil {
+			slog.ErrorContext(ctx, "unable to claim due export", "err", err)
+			return delivered, err
+		}
+
+		if config == nil {
+			return delivered, nil
+		}
+
+		delivered += usecase.run(ctx, config, now)
+	}
+}
+
+func (usecase *RunScheduledExportsUseCase) run(ctx context.Context, config *export_entities.ExportConfig, now time.Time) int {
+	scope, _ := common.GetRequestScope(ctx)
+	scope.ResourceOwner = common.ResourceOwner{TenantID: config.ResourceOwner.TenantID, ClientID: config.ResourceOwner.ClientID}
+
+	ownerCtx := common.WithRequestScope(ctx, scope)
+
+	runID := uuid.New()
+	delivered := 0
+
+	if config.ExportedUntil == nil {
+		config.ExportedUntil = make(map[export_entities.ExportDataset]time.Time)
+	}
+
+	for _, dataset := range config.Datasets {
+		receipt := &export_entities.ExportReceipt{
+			ID:             uuid.New(),
+			ExportConfigID: config.ID,
+			RunID:          runID,
+			Dataset:        dataset,
+			Format:         config.Format,
+			Bucket:         config.Destination.Bucket,
+			Key:            config.ObjectKey(dataset, now, runID),
+			WindowTo:       now,
+			ResourceOwner:  scope.ResourceOwner,
+			CreatedAt:      now,
+			UpdatedAt:      now,
+		}
+
+		if exportedUntil, ok := config.ExportedUntil[dataset]; ok {
+			receipt.WindowFrom = &exportedUntil
+		}
+
+		err := usecase.deliver(ownerCtx, config, receipt)
+		if err != nil {
+			slog.ErrorContext(ownerCtx, "unable to deliver export", "exportID", config.ID, "dataset", dataset, "runID", runID, "err", err)
+
+			receipt.Status = export_entities.ExportReceiptFailed
+			receipt.Error = err.Error()
+		} else {
+			receipt.Status = export_entities.ExportReceiptDelivered
+			config.ExportedUntil[dataset] = now
+			delivered++
+		}
+
+		_, err = usecase.ReceiptWriter.Create(ownerCtx, receipt)
+		if err != nil {
+			slog.ErrorContext(ownerCtx, "unable to record export receipt", "exportID", config.ID, "dataset", dataset, "runID", runID, "status", receipt.Status, "err", err)
+		}
+	}
+
+	config.LastRunAt = &now
+	config.NextRunAt = export_entities.NextExportRunAt(config.HourUTC, now)
+	config.ClaimedUntil = nil
+	config.UpdatedAt = now
+
+	_, err := usecase.ConfigWriter.Update(ctx, config)
+	if err != nil {
+		// the claim expires: the run is retried (the datasets delivered are delivered again, records are keyed by id)
+		slog.ErrorContext(ctx, "unable to update export after run", "exportID", config.ID, "runID", runID, "err", err)
+	}
+
+	return delivered
+}
+
+// deliver writes the records of the window of the receipt as gzipped json lines, filling in their count, size and checksum.
+func (usecase *RunScheduledExportsUseCase) deliver(ctx context.Context, config *export_entities.ExportConfig, receipt *export_entities.ExportReceipt) error {
+	reader, ok := usecase.Datasets[receipt.Dataset]
+	if !ok {
+		return export.NewExportDatasetUnavailableError(config.ID, string(receipt.Dataset))
+	}
+
+	var buf bytes.Buffer
+
+	gz := gzip.NewWriter(&buf)
+	encoder := json.NewEncoder(gz)
+
+	for skip := uint(0); ; skip += ExportPageSize {
+		records, err := reader.Read(ctx, receipt.WindowFrom, receipt.WindowTo, skip, ExportPageSize)
+		if err != nil {
+			return err
+		}
+
+		for _, record := range records {
+			err = encoder.Encode(record)
+			if err != nil {
+				return err
+			}
+		}
+
+		receipt.Records += len(records)
+
+		if len(records) < ExportPageSize {
+			break
+		}
+	}
+
+	err := gz.Close()
+	if err != nil {
+		return err
+	}
+
+	content := buf.Bytes()
+	checksum := sha256.Sum256(content)
+
+	receipt.Bytes = len(content)
+	receipt.SHA256 = hex.EncodeToString(checksum[:])
+
+	return usecase.Delivery.Deliver(ctx, config.Destination, receipt.Key, content)
+}
@@ -0,0 +1,71 @@
+package faceit_entities
+
+import (
+	"errors"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+)
+
+const FaceitMatchStatusFinished = "FINISHED"
+
+var (
+	ErrInvalidFaceitMatchID = errors.New("invalid faceit match id")
+
+	// ie: 1-0d3b7f0e-8f4c-4a8e-9f6a-2f0a4f6e8a1b (older matches have no "1-" prefix)
+	faceitMatchIDPattern = regexp.MustCompile(`^(\d+-)?[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}$`)
+)
+
+// ParseFaceitMatchID returns the match ID of a FACEIT match room URL (ie: https://www.faceit.com/en/cs2/room/1-0d3b.../scoreboard),
+// or the match ID itself.
+func ParseFaceitMatchID(match string) (string, error) {
+	match = strings.TrimSpace(match)
+
+	if strings.Contains(match, "/") {
+		u, err := url.Parse(match)
+		if err != nil || (u.Hostname() != "faceit.com" && !strings.HasSuffix(u.Hostname(), ".faceit.com")) {
+			return "", ErrInvalidFaceitMatchID
+		}
+
+		segments := strings.Split(strings.Trim(u.Path, "/"), "/")
+
+		match = ""
+		for i := 0; i < len(segments)-1; i++ {
+			if segments[i] == "room" {
+				match = segments[i+1]
+				break
+			}
+		}
+	}
+
+	match = strings.ToLower(match)
+	if !faceitMatchIDPattern.MatchString(match) {
+		return "", ErrInvalidFaceitMatchID
+	}
+
+	return match, nil
+}
+
+// FaceitMatch is a match as described by the FACEIT Data API.
+type FaceitMatch struct {
+	ID         string         `json:"match_id"`
+	Game       string         `json:"game"`
+	Status     string         `json:"status"`
+	URL        string         `json:"faceit_url"`
+	DemoURLs   []string       `json:"demo_url"`
+	Players    []FaceitPlayer `json:"players"`
+	StartedAt  *time.Time     `json:"started_at,omitempty"`
+	FinishedAt *time.Time     `json:"finished_at,omitempty"`
+}
+
+type FaceitPlayer struct {
+	ID           string `json:"player_id"`
+	Nickname     string `json:"nickname"`
+	GamePlayerID string `json:"game_player_id"` // steam64 id
+	Faction      string `json:"faction"`        // faction1 or faction2
+}
+
+func (m FaceitMatch) IsFinished() bool {
+	return m.Status == FaceitMatchStatusFinished
+}
@@ -0,0 +1,40 @@
+package faceit_entities_test
+
+import (
+	"testing"
+
+	faceit_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/faceit/entities"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseFaceitMatchID(t *testing.T) {
+	const matchID = "1-0d3b7f0e-8f4c-4a8e-9f6a-2f0a4f6e8a1b"
+
+	tests := []struct {
+		name  string
+		match string
+		want  string
+		err   error
+	}{
+		{name: "match id", match: matchID, want: matchID},
+		{name: "room url", match: "https://www.faceit.com/en/cs2/room/" + matchID, want: matchID},
+		{name: "scoreboard url", match: "https://www.faceit.com/pt-br/cs2/room/" + matchID + "/scoreboard", want: matchID},
+		{name: "legacy match id", match: "0D3B7F0E-8F4C-4A8E-9F6A-2F0A4F6E8A1B", want: "0d3b7f0e-8f4c-4a8e-9f6a-2f0a4f6e8a1b"},
+		{name: "other host", match: "https://example.com/en/cs2/room/" + matchID, err: faceit_entities.ErrInvalidFaceitMatchID},
+		{name: "player url", match: "https://www.faceit.com/en/players/s1mple", err: faceit_entities.ErrInvalidFaceitMatchID},
+		{name: "garbage", match: "1-not-a-match", err: faceit_entities.ErrInvalidFaceitMatchID},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := faceit_entities.ParseFaceitMatchID(tt.match)
+			if tt.err != nil {
+				assert.ErrorIs(t, err, tt.err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
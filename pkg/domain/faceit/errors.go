@@ -0,0 +1,57 @@
+package faceit
+
+import "fmt"
+
+// Invalid FACEIT Match Error (neither a match ID nor a match room URL)
+type InvalidFaceitMatchError struct {
+	// Error message
+	Message string
+}
+
+// Error returns the error message
+func (e *InvalidFaceitMatchError) Error() string {
+	return e.Message
+}
+
+// NewInvalidFaceitMatchError creates a new InvalidFaceitMatchError
+func NewInvalidFaceitMatchError(match string) *InvalidFaceitMatchError {
+	return &InvalidFaceitMatchError{
+		Message: fmt.Sprintf("Invalid FACEIT match: %s", match),
+	}
+}
+
+// FACEIT Match Not Found Error (unknown to FACEIT, or its demo isn't available)
+type FaceitMatchNotFoundError struct {
+	// Error message
+	Message string
+}
+
+// Error returns the error message
+func (e *FaceitMatchNotFoundError) Error() string {
+	return e.Message
+}
+
+// NewFaceitMatchNotFoundError creates a new FaceitMatchNotFoundError
+func NewFaceitMatchNotFoundError(message string) *FaceitMatchNotFoundError {
+	return &FaceitMatchNotFoundError{
+		Message: message,
+	}
+}
+
+// FACEIT Match Not Finished Error (demos are only recorded once the match is over)
+type FaceitMatchNotFinishedError struct {
+	// Error message
+	Message string
+}
+
+// Error returns the error message
+func (e *FaceitMatchNotFinishedError) Error() string {
+	return e.Message
+}
+
+// NewFaceitMatchNotFinishedError creates a new FaceitMatchNotFinishedError
+func NewFaceitMatchNotFinishedError(matchID string, status string) *FaceitMatchNotFinishedError {
+	return &FaceitMatchNotFinishedError{
+		Message: fmt.Sprintf("FACEIT match %s is not finished (%s)", matchID, status),
+	}
+}
@@ -0,0 +1,13 @@
+package faceit_in
+
+import (
+	"context"
+
+	replay_entity "github.com/psavelis/team-pro/replay-api/pkg/domain/replay/entities"
+)
+
+// ImportFaceitMatchCommand imports a FACEIT match from its room URL or match ID: its demo is processed as an uploaded replay file and
+// the resulting match linked to the FACEIT accounts of its players. A match already imported by the user is returned as is.
+type ImportFaceitMatchCommand interface {
+	Exec(ctx context.Context, match string) (*replay_entity.Match, error)
+}
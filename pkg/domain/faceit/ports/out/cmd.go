@@ -0,0 +1,24 @@
+package faceit_out
+
+import (
+	"context"
+	"io"
+
+	"github.com/google/uuid"
+	faceit_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/faceit/entities"
+	replay_entity "github.com/psavelis/team-pro/replay-api/pkg/domain/replay/entities"
+)
+
+// FaceitMatchFetcher pulls matches and their demos through the FACEIT API.
+type FaceitMatchFetcher interface {
+	// GetMatch returns a FaceitMatchNotFoundError when FACEIT doesn't know the match.
+	GetMatch(ctx context.Context, matchID string) (*faceit_entities.FaceitMatch, error)
+	// DownloadDemo streams the (decompressed) demo, a FaceitMatchNotFoundError when it's no longer available.
+	DownloadDemo(ctx context.Context, demoURL string) (io.ReadCloser, error)
+}
+
+type ExternalMatchWriter interface {
+	// AttachExternal links the match (of the tenant in context) to the match it was imported from. Returns false when the match isn't
+	// found.
+	AttachExternal(ctx context.Context, matchID uuid.UUID, external replay_entity.ExternalMatch) (bool, error)
+}
@@ -0,0 +1,118 @@
+package use_cases
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	"github.com/psavelis/team-pro/replay-api/pkg/domain/faceit"
+	faceit_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/faceit/entities"
+	faceit_in "github.com/psavelis/team-pro/replay-api/pkg/domain/faceit/ports/in"
+	faceit_out "github.com/psavelis/team-pro/replay-api/pkg/domain/faceit/ports/out"
+	replay_entity "github.com/psavelis/team-pro/replay-api/pkg/domain/replay/entities"
+	replay_in "github.com/psavelis/team-pro/replay-api/pkg/domain/replay/ports/in"
+	replay_out "github.com/psavelis/team-pro/replay-api/pkg/domain/replay/ports/out"
+)
+
+type ImportFaceitMatchUseCase struct {
+	MatchFetcher               faceit_out.FaceitMatchFetcher
+	ExternalMatchWriter        faceit_out.ExternalMatchWriter
+	MatchReader                replay_out.MatchMetadataReader
+	UploadAndProcessReplayFile replay_in.UploadAndProcessReplayFileCommand
+}
+
+func NewImportFaceitMatchUseCase(matchFetcher faceit_out.FaceitMatchFetcher, externalMatchWriter faceit_out.ExternalMatchWriter, matchReader replay_out.MatchMetadataReader, uploadAndProcessReplayFile replay_in.UploadAndProcessReplayFileCommand) faceit_in.ImportFaceitMatchCommand {
+	return &ImportFaceitMatchUseCase{
+		MatchFetcher:               matchFetcher,
+		ExternalMatchWriter:        externalMatchWriter,
+		MatchReader:                matchReader,
+		UploadAndProcessReplayFile: uploadAndProcessReplayFile,
+	}
+}
+
+func (usecase *ImportFaceitMatchUseCase) Exec(ctx context.Context, match string) (*replay_entity.Match, error) {
+	matchID, err := faceit_entities.ParseFaceitMatchID(match)
+	if err != nil {
+		slog.ErrorContext(ctx, "invalid faceit match", "match", match, "err", err)
+		return nil, faceit.NewInvalidFaceitMatchError(match)
+	}
+
+	imported, err := usecase.MatchReader.Search(ctx, common.NewSearchByValues(ctx, []common.SearchableValue{
+		{Field: "External.NetworkID", Values: []interface{}{common.FaceItNetworkIDKey}},
+		{Field: "External.MatchID", Values: []interface{}{matchID}},
+	}, common.NewSearchResultOptions(0, 1), common.UserAudienceIDKey))
+
+	if err != nil {
+		slog.ErrorContext(ctx, "unable to search imported faceit matches", "faceitMatchID", matchID, "err", err)
+		return nil, err
+	}
+
+	if len(imported) > 0 {
+		slog.InfoContext(ctx, "faceit match already imported", "faceitMatchID", matchID, "matchID", imported[0].ID)
+		imported[0].Events = nil
+
+		return &imported[0], nil
+	}
+
+	faceitMatch, err := usecase.MatchFetcher.GetMatch(ctx, matchID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !faceitMatch.IsFinished() {
+		return nil, faceit.NewFaceitMatchNotFinishedError(matchID, faceitMatch.Status)
+	}
+
+	if len(faceitMatch.DemoURLs) == 0 {
+		return nil, faceit.NewFaceitMatchNotFoundError("no demo available for FACEIT match " + matchID)
+	}
+
+	demo, err := usecase.MatchFetcher.DownloadDemo(ctx, faceitMatch.DemoURLs[0])
+	if err != nil {
+		slog.ErrorContext(ctx, "unable to download faceit demo", "faceitMatchID", matchID, "demoURL", faceitMatch.DemoURLs[0], "err", err)
+		return nil, err
+	}
+
+	defer demo.Close()
+
+	slog.InfoContext(ctx, "importing faceit match", "faceitMatchID", matchID, "demoURL", faceitMatch.DemoURLs[0])
+
+	result, err := usecase.UploadAndProcessReplayFile.Exec(ctx, demo)
+	if err != nil {
+		return nil, err
+	}
+
+	external := newExternalMatch(*faceitMatch)
+
+	_, err = usecase.ExternalMatchWriter.AttachExternal(ctx, result.ID, external)
+	if err != nil {
+		slog.ErrorContext(ctx, "unable to link match to faceit match", "matchID", result.ID, "faceitMatchID", matchID, "err", err)
+		return nil, err
+	}
+
+	result.External = &external
+
+	return result, nil
+}
+
+func newExternalMatch(match faceit_entities.FaceitMatch) replay_entity.ExternalMatch {
+	players := make([]replay_entity.ExternalMatchPlayer, 0, len(match.Players))
+	for _, p := range match.Players {
+		players = append(players, replay_entity.ExternalMatchPlayer{
+			NetworkUserID: p.ID,
+			Nickname:      p.Nickname,
+			SteamID:       p.GamePlayerID,
+			Faction:       p.Faction,
+		})
+	}
+
+	return replay_entity.ExternalMatch{
+		NetworkID:  common.FaceItNetworkIDKey,
+		MatchID:    match.ID,
+		URL:        match.URL,
+		Players:    players,
+		FinishedAt: match.FinishedAt,
+		ImportedAt: time.Now().UTC(),
+	}
+}
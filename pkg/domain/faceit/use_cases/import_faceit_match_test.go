@@ -0,0 +1,123 @@
+package use_cases_test
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	"github.com/psavelis/team-pro/replay-api/pkg/domain/faceit"
+	faceit_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/faceit/entities"
+	"github.com/psavelis/team-pro/replay-api/pkg/domain/faceit/use_cases"
+	replay_entity "github.com/psavelis/team-pro/replay-api/pkg/domain/replay/entities"
+	"github.com/stretchr/testify/assert"
+)
+
+const faceitMatchID = "1-0d3b7f0e-8f4c-4a8e-9f6a-2f0a4f6e8a1b"
+
+type mockFaceitFetcher struct {
+	matches   map[string]faceit_entities.FaceitMatch
+	downloads int
+}
+
+func (m *mockFaceitFetcher) GetMatch(ctx context.Context, matchID string) (*faceit_entities.FaceitMatch, error) {
+	match, ok := m.matches[matchID]
+	if !ok {
+		return nil, faceit.NewFaceitMatchNotFoundError("not found")
+	}
+
+	return &match, nil
+}
+
+func (m *mockFaceitFetcher) DownloadDemo(ctx context.Context, demoURL string) (io.ReadCloser, error) {
+	m.downloads++
+	return io.NopCloser(strings.NewReader("demo")), nil
+}
+
+// mockMatchStore imports matches (UploadAndProcessReplayFileCommand), links them and searches them by their external match ID.
+type mockMatchStore struct {
+	matches []replay_entity.Match
+}
+
+func (m *mockMatchStore) Exec(ctx context.Context, file io.Reader) (*replay_entity.Match, error) {
+	io.ReadAll(file)
+
+	match := replay_entity.NewCS2Match(ctx, uuid.New())
+	m.matches = append(m.matches, *match)
+
+	return match, nil
+}
+
+func (m *mockMatchStore) AttachExternal(ctx context.Context, matchID uuid.UUID, external replay_entity.ExternalMatch) (bool, error) {
+	for i := range m.matches {
+		if m.matches[i].ID == matchID {
+			m.matches[i].External = &external
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+func (m *mockMatchStore) Search(ctx context.Context, s common.Search) ([]replay_entity.Match, error) {
+	res := make([]replay_entity.Match, 0)
+
+	for _, match := range m.matches {
+		for _, v := range s.SearchParams[0].Params[0].ValueParams {
+			if v.Field == "External.MatchID" && match.External != nil && match.External.MatchID == v.Values[0] {
+				res = append(res, match)
+			}
+		}
+	}
+
+	return res, nil
+}
+
+func (m *mockMatchStore) Compile(ctx context.Context, searchParams []common.SearchAggregation, resultOptions common.SearchResultOptions) (*common.Search, error) {
+	return nil, nil
+}
+
+func TestImportFaceitMatchUseCase_Exec(t *testing.T) {
+	fetcher := &mockFaceitFetcher{matches: map[string]faceit_entities.FaceitMatch{
+		faceitMatchID: {
+			ID:       faceitMatchID,
+			Status:   faceit_entities.FaceitMatchStatusFinished,
+			DemoURLs: []string{"https://demos.faceit-cdn.net/cs2/" + faceitMatchID + ".dem.gz"},
+			Players:  []faceit_entities.FaceitPlayer{{ID: "p1", Nickname: "one", GamePlayerID: "76561198000000001", Faction: "faction1"}},
+		},
+		"1-00000000-0000-0000-0000-000000000000": {ID: "1-00000000-0000-0000-0000-000000000000", Status: "ONGOING"},
+	}}
+
+	store := &mockMatchStore{}
+	usecase := use_cases.NewImportFaceitMatchUseCase(fetcher, store, store, store)
+
+	ctx := common.WithResourceOwner(context.Background(), common.ResourceOwner{TenantID: uuid.New(), UserID: uuid.New()})
+
+	match, err := usecase.Exec(ctx, "https://www.faceit.com/en/cs2/room/"+faceitMatchID)
+	if assert.NoError(t, err) && assert.NotNil(t, match.External) {
+		assert.Equal(t, common.FaceItNetworkIDKey, match.External.NetworkID)
+		assert.Equal(t, faceitMatchID, match.External.MatchID)
+		assert.Equal(t, "p1", match.External.Players[0].NetworkUserID)
+		assert.Equal(t, "76561198000000001", match.External.Players[0].SteamID)
+	}
+
+	// imported once
+	again, err := usecase.Exec(ctx, faceitMatchID)
+	assert.NoError(t, err)
+	assert.Equal(t, match.ID, again.ID)
+	assert.Equal(t, 1, fetcher.downloads)
+
+	var invalidErr *faceit.InvalidFaceitMatchError
+	_, err = usecase.Exec(ctx, "https://www.faceit.com/en/players/one")
+	assert.ErrorAs(t, err, &invalidErr)
+
+	var notFinishedErr *faceit.FaceitMatchNotFinishedError
+	_, err = usecase.Exec(ctx, "1-00000000-0000-0000-0000-000000000000")
+	assert.ErrorAs(t, err, &notFinishedErr)
+
+	var notFoundErr *faceit.FaceitMatchNotFoundError
+	_, err = usecase.Exec(ctx, "1-11111111-1111-1111-1111-111111111111")
+	assert.ErrorAs(t, err, &notFoundErr)
+}
@@ -0,0 +1,50 @@
+package common
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// MaxFacetFields caps how many facets a single search may request (each one is a $group over the matching documents).
+const MaxFacetFields = 5
+
+// FacetBucket is the number of documents matching a search that share the same Value in a faceted field.
+type FacetBucket struct {
+	Value interface{} `json:"value" bson:"_id"`
+	Count int64       `json:"count" bson:"count"`
+}
+
+// Facets maps each requested field (SearchResultOptions.Facets) to its buckets, ordered by count.
+type Facets map[string][]FacetBucket
+
+// Facetable is implemented by readers able to count search matches grouped by field.
+type Facetable interface {
+	Facets(ctx context.Context, s Search) (Facets, error)
+}
+
+// SearchResultPage is returned instead of the plain result list when facets or cursor pagination are requested.
+type SearchResultPage[T any] struct {
+	Results    []T    `json:"results"`
+	Facets     Facets `json:"facets,omitempty"`
+	NextCursor string `json:"next_cursor,omitempty"` // empty on the last page
+}
+
+func ValidateFacetFields(fields []string, queryableFields map[string]bool) error {
+	if len(fields) > MaxFacetFields {
+		return fmt.Errorf("at most %d facets can be requested (got %d)", MaxFacetFields, len(fields))
+	}
+
+	for _, field := range fields {
+		if strings.HasSuffix(field, ".*") {
+			return fmt.Errorf("facet on wildcard field '%s' is not permitted", field)
+		}
+
+		allowed, exists := queryableFields[field]
+		if !exists || !allowed {
+			return fmt.Errorf("facet on field '%s' is not permitted", field)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,61 @@
+package common_test
+
+import (
+	"testing"
+
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+)
+
+func TestValidateFacetFields(t *testing.T) {
+	queryableFields := map[string]bool{"Region": true, "Status": true, "Role": true, "Secret": false}
+
+	tests := []struct {
+		name          string
+		fields        []string
+		expectedError string
+	}{
+		{
+			name:   "No Facets",
+			fields: nil,
+		},
+		{
+			name:   "Queryable Fields",
+			fields: []string{"Region", "Status"},
+		},
+		{
+			name:          "Not Queryable",
+			fields:        []string{"Secret"},
+			expectedError: "facet on field 'Secret' is not permitted",
+		},
+		{
+			name:          "Unknown Field",
+			fields:        []string{"Nickname"},
+			expectedError: "facet on field 'Nickname' is not permitted",
+		},
+		{
+			name:          "Wildcard",
+			fields:        []string{"Region.*"},
+			expectedError: "facet on wildcard field 'Region.*' is not permitted",
+		},
+		{
+			name:          "Too Many Facets",
+			fields:        []string{"Region", "Status", "Role", "Region", "Status", "Role"},
+			expectedError: "at most 5 facets can be requested (got 6)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := common.ValidateFacetFields(tt.fields, queryableFields)
+			if tt.expectedError != "" {
+				if err == nil {
+					t.Errorf("Expected error '%s', but got no error", tt.expectedError)
+				} else if err.Error() != tt.expectedError {
+					t.Errorf("Expected error '%s', but got '%s'", tt.expectedError, err.Error())
+				}
+			} else if err != nil {
+				t.Errorf("Expected no error, but got '%s'", err.Error())
+			}
+		})
+	}
+}
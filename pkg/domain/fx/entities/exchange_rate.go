@@ -0,0 +1,49 @@
+package fx_entities
+
+import (
+	"math"
+	"strings"
+	"time"
+)
+
+// ExchangeRate is the price of one unit of Base in Quote (ie: BTC/USD 67000.5), as published by Source. Codes are ISO 4217
+// currencies (ie: "USD", "EUR") or crypto asset symbols (ie: "BTC", "USDC"), upper case.
+type ExchangeRate struct {
+	Base       string    `json:"base"`
+	Quote      string    `json:"quote"`
+	Rate       float64   `json:"rate"`
+	Source     string    `json:"source"`
+	ObservedAt time.Time `json:"observed_at"` // when the source published the rate (not when it was fetched)
+}
+
+func (r ExchangeRate) Pair() string {
+	return r.Base + "/" + r.Quote
+}
+
+// Inverse is the same rate quoted the other way around (ie: USD/BTC from BTC/USD).
+func (r ExchangeRate) Inverse() ExchangeRate {
+	return ExchangeRate{
+		Base:       r.Quote,
+		Quote:      r.Base,
+		Rate:       1 / r.Rate,
+		Source:     r.Source,
+		ObservedAt: r.ObservedAt,
+	}
+}
+
+// DeviationFrom is the relative difference (ie: 0.02 = 2%) between the rate and a reference rate of the same pair.
+func (r ExchangeRate) DeviationFrom(reference ExchangeRate) float64 {
+	return math.Abs(r.Rate-reference.Rate) / reference.Rate
+}
+
+// RateDeviationAlert is raised when two sources disagree on a pair beyond the accepted deviation.
+type RateDeviationAlert struct {
+	Rate         ExchangeRate `json:"rate"`
+	Reference    ExchangeRate `json:"reference"`
+	Deviation    float64      `json:"deviation"`
+	MaxDeviation float64      `json:"max_deviation"`
+}
+
+func NormalizeCurrency(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
@@ -0,0 +1,55 @@
+package fx
+
+import (
+	"fmt"
+	"time"
+
+	fx_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/fx/entities"
+)
+
+// Unsupported Pair Error (no source quotes the pair)
+type UnsupportedPairError struct {
+	Message string
+}
+
+func (e *UnsupportedPairError) Error() string {
+	return e.Message
+}
+
+func NewUnsupportedPairError(base string, quote string) *UnsupportedPairError {
+	return &UnsupportedPairError{
+		Message: fmt.Sprintf("exchange rate %s/%s is not available", base, quote),
+	}
+}
+
+// Stale Rate Error (the freshest rate available is older than its source allows)
+type StaleRateError struct {
+	Message string
+}
+
+func (e *StaleRateError) Error() string {
+	return e.Message
+}
+
+func NewStaleRateError(rate fx_entities.ExchangeRate, maxAge time.Duration) *StaleRateError {
+	return &StaleRateError{
+		Message: fmt.Sprintf("exchange rate %s from %s is stale (observed at %s, max age %s)", rate.Pair(), rate.Source, rate.ObservedAt.Format(time.RFC3339), maxAge),
+	}
+}
+
+// Rate Deviation Error (sources disagree on the rate, it can't be trusted)
+type RateDeviationError struct {
+	Message string
+	Alert   fx_entities.RateDeviationAlert
+}
+
+func (e *RateDeviationError) Error() string {
+	return e.Message
+}
+
+func NewRateDeviationError(alert fx_entities.RateDeviationAlert) *RateDeviationError {
+	return &RateDeviationError{
+		Message: fmt.Sprintf("exchange rate %s from %s (%g) deviates %.2f%% from %s (%g), max %.2f%%", alert.Rate.Pair(), alert.Rate.Source, alert.Rate.Rate, alert.Deviation*100, alert.Reference.Source, alert.Reference.Rate, alert.MaxDeviation*100),
+		Alert:   alert,
+	}
+}
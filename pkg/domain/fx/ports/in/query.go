@@ -0,0 +1,13 @@
+package fx_in
+
+import (
+	"context"
+
+	fx_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/fx/entities"
+)
+
+// GetExchangeRateQuery returns a rate fit for financial postings: fresh for its source and consistent with the other sources
+// quoting the pair.
+type GetExchangeRateQuery interface {
+	Exec(ctx context.Context, base string, quote string) (*fx_entities.ExchangeRate, error)
+}
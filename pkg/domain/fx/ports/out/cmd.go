@@ -0,0 +1,11 @@
+package fx_out
+
+import (
+	"context"
+
+	fx_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/fx/entities"
+)
+
+type RateAlertNotifier interface {
+	NotifyRateDeviation(ctx context.Context, alert fx_entities.RateDeviationAlert) error
+}
@@ -0,0 +1,19 @@
+package fx_out
+
+import (
+	"context"
+	"time"
+
+	fx_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/fx/entities"
+)
+
+// ExchangeRateProvider quotes rates from an external source (ie: ECB reference rates, Coingecko). Pairs the source doesn't quote
+// are answered with a fx.UnsupportedPairError.
+type ExchangeRateProvider interface {
+	Name() string
+
+	// MaxAge is how old a rate of the source can be before it is stale (ie: the ECB publishes once per business day).
+	MaxAge() time.Duration
+
+	GetRate(ctx context.Context, base string, quote string) (*fx_entities.ExchangeRate, error)
+}
@@ -0,0 +1,145 @@
+package fx_use_cases
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/psavelis/team-pro/replay-api/pkg/domain/fx"
+	fx_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/fx/entities"
+	fx_in "github.com/psavelis/team-pro/replay-api/pkg/domain/fx/ports/in"
+	fx_out "github.com/psavelis/team-pro/replay-api/pkg/domain/fx/ports/out"
+)
+
+const (
+	// max relative difference between two sources quoting the same pair (daily reference rates lag behind live crypto prices)
+	DefaultMaxRateDeviation = 0.03
+
+	// a pair deviating for a while is alerted once per interval, every refused rate is logged
+	RateAlertInterval = 15 * time.Minute
+)
+
+type GetExchangeRateUseCase struct {
+	// Providers by priority: the first one with a fresh rate for the pair is used, the others cross-check it
+	Providers     []fx_out.ExchangeRateProvider
+	AlertNotifier fx_out.RateAlertNotifier
+	MaxDeviation  float64
+
+	mu      sync.Mutex
+	alerted map[string]time.Time
+}
+
+func NewGetExchangeRateUseCase(providers []fx_out.ExchangeRateProvider, alertNotifier fx_out.RateAlertNotifier, maxDeviation float64) fx_in.GetExchangeRateQuery {
+	if maxDeviation <= 0 {
+		maxDeviation = DefaultMaxRateDeviation
+	}
+
+	return &GetExchangeRateUseCase{
+		Providers:     providers,
+		AlertNotifier: alertNotifier,
+		MaxDeviation:  maxDeviation,
+		alerted:       make(map[string]time.Time),
+	}
+}
+
+func (usecase *GetExchangeRateUseCase) Exec(ctx context.Context, base string, quote string) (*fx_entities.ExchangeRate, error) {
+	base = fx_entities.NormalizeCurrency(base)
+	quote = fx_entities.NormalizeCurrency(quote)
+
+	now := time.Now().UTC()
+
+	if base == quote {
+		return &fx_entities.ExchangeRate{Base: base, Quote: quote, Rate: 1, ObservedAt: now}, nil
+	}
+
+	var rate *fx_entities.ExchangeRate
+	references := make([]fx_entities.ExchangeRate, 0, len(usecase.Providers))
+
+	// the error returned when no provider has a fresh rate: stale over failed over unsupported
+	var staleErr, failedErr error
+
+	for _, provider := range usecase.Providers {
+		r, err := provider.GetRate(ctx, base, quote)
+		if err != nil {
+			var unsupportedErr *fx.UnsupportedPairError
+			if !errors.As(err, &unsupportedErr) {
+				slog.WarnContext(ctx, "unable to get exchange rate", "source", provider.Name(), "base", base, "quote", quote, "err", err)
+				failedErr = err
+			}
+
+			continue
+		}
+
+		if now.Sub(r.ObservedAt) > provider.MaxAge() {
+			slog.WarnContext(ctx, "stale exchange rate skipped", "source", provider.Name(), "pair", r.Pair(), "observedAt", r.ObservedAt)
+
+			if staleErr == nil {
+				staleErr = fx.NewStaleRateError(*r, provider.MaxAge())
+			}
+
+			continue
+		}
+
+		if rate == nil {
+			rate = r
+			continue
+		}
+
+		references = append(references, *r)
+	}
+
+	if rate == nil {
+		switch {
+		case staleErr != nil:
+			return nil, staleErr
+		case failedErr != nil:
+			return nil, failedErr
+		default:
+			return nil, fx.NewUnsupportedPairError(base, quote)
+		}
+	}
+
+	for _, reference := range references {
+		deviation := rate.DeviationFrom(reference)
+		if deviation <= usecase.MaxDeviation {
+			continue
+		}
+
+		alert := fx_entities.RateDeviationAlert{
+			Rate:         *rate,
+			Reference:    reference,
+			Deviation:    deviation,
+			MaxDeviation: usecase.MaxDeviation,
+		}
+
+		deviationErr := fx.NewRateDeviationError(alert)
+
+		slog.WarnContext(ctx, deviationErr.Message, "pair", rate.Pair(), "source", rate.Source, "reference", reference.Source)
+
+		if usecase.shouldAlert(rate.Pair(), now) {
+			err := usecase.AlertNotifier.NotifyRateDeviation(ctx, alert)
+			if err != nil {
+				slog.ErrorContext(ctx, "unable to notify exchange rate deviation", "pair", rate.Pair(), "err", err)
+			}
+		}
+
+		return nil, deviationErr
+	}
+
+	return rate, nil
+}
+
+func (usecase *GetExchangeRateUseCase) shouldAlert(pair string, now time.Time) bool {
+	usecase.mu.Lock()
+	defer usecase.mu.Unlock()
+
+	if last, ok := usecase.alerted[pair]; ok && now.Sub(last) < RateAlertInterval {
+		return false
+	}
+
+	usecase.alerted[pair] = now
+
+	return true
+}
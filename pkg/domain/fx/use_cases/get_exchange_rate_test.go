@@ -0,0 +1,180 @@
+package fx_use_cases_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/psavelis/team-pro/replay-api/pkg/domain/fx"
+	fx_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/fx/entities"
+	fx_out "github.com/psavelis/team-pro/replay-api/pkg/domain/fx/ports/out"
+	fx_use_cases "github.com/psavelis/team-pro/replay-api/pkg/domain/fx/use_cases"
+	"github.com/stretchr/testify/assert"
+)
+
+type mockProvider struct {
+	name   string
+	rate   float64
+	age    time.Duration
+	maxAge time.Duration
+	err    error
+}
+
+func (m *mockProvider) Name() string {
+	return m.name
+}
+
+func (m *mockProvider) MaxAge() time.Duration {
+	return m.maxAge
+}
+
+func (m *mockProvider) GetRate(ctx context.Context, base string, quote string) (*fx_entities.ExchangeRate, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+
+	if m.rate == 0 {
+		return nil, fx.NewUnsupportedPairError(base, quote)
+	}
+
+	return &fx_entities.ExchangeRate{Base: base, Quote: quote, Rate: m.rate, Source: m.name, ObservedAt: time.Now().Add(-m.age)}, nil
+}
+
+type mockRateAlertNotifier struct {
+	alerts []fx_entities.RateDeviationAlert
+}
+
+func (m *mockRateAlertNotifier) NotifyRateDeviation(ctx context.Context, alert fx_entities.RateDeviationAlert) error {
+	m.alerts = append(m.alerts, alert)
+	return nil
+}
+
+func TestGetExchangeRateUseCase_Exec(t *testing.T) {
+	failure := errors.New("connection refused")
+
+	tests := []struct {
+		name       string
+		providers  []fx_out.ExchangeRateProvider
+		base       string
+		quote      string
+		wantRate   float64
+		wantSource string
+		wantErr    interface{}
+	}{
+		{
+			name:     "same currency",
+			base:     "usd",
+			quote:    "USD",
+			wantRate: 1,
+		},
+		{
+			name: "primary provider",
+			providers: []fx_out.ExchangeRateProvider{
+				&mockProvider{name: "primary", rate: 1.08, maxAge: time.Hour},
+				&mockProvider{name: "secondary", rate: 1.09, maxAge: time.Hour},
+			},
+			base:       "EUR",
+			quote:      "USD",
+			wantRate:   1.08,
+			wantSource: "primary",
+		},
+		{
+			name: "fallback when primary is stale",
+			providers: []fx_out.ExchangeRateProvider{
+				&mockProvider{name: "primary", rate: 1.5, age: 2 * time.Hour, maxAge: time.Hour},
+				&mockProvider{name: "secondary", rate: 1.09, maxAge: time.Hour},
+			},
+			base:       "EUR",
+			quote:      "USD",
+			wantRate:   1.09,
+			wantSource: "secondary",
+		},
+		{
+			name: "fallback when primary fails",
+			providers: []fx_out.ExchangeRateProvider{
+				&mockProvider{name: "primary", err: failure, maxAge: time.Hour},
+				&mockProvider{name: "secondary", rate: 1.09, maxAge: time.Hour},
+			},
+			base:       "EUR",
+			quote:      "USD",
+			wantRate:   1.09,
+			wantSource: "secondary",
+		},
+		{
+			name: "only stale rates",
+			providers: []fx_out.ExchangeRateProvider{
+				&mockProvider{name: "primary", err: failure, maxAge: time.Hour},
+				&mockProvider{name: "secondary", rate: 1.09, age: 2 * time.Hour, maxAge: time.Hour},
+			},
+			base:    "EUR",
+			quote:   "USD",
+			wantErr: &fx.StaleRateError{},
+		},
+		{
+			name: "unsupported pair",
+			providers: []fx_out.ExchangeRateProvider{
+				&mockProvider{name: "primary", maxAge: time.Hour},
+			},
+			base:    "XYZ",
+			quote:   "USD",
+			wantErr: &fx.UnsupportedPairError{},
+		},
+		{
+			name: "sources disagree",
+			providers: []fx_out.ExchangeRateProvider{
+				&mockProvider{name: "primary", rate: 1.2, maxAge: time.Hour},
+				&mockProvider{name: "secondary", rate: 1.09, maxAge: time.Hour},
+			},
+			base:    "EUR",
+			quote:   "USD",
+			wantErr: &fx.RateDeviationError{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			usecase := fx_use_cases.NewGetExchangeRateUseCase(tt.providers, &mockRateAlertNotifier{}, 0)
+
+			rate, err := usecase.Exec(context.Background(), tt.base, tt.quote)
+
+			switch want := tt.wantErr.(type) {
+			case *fx.StaleRateError:
+				assert.ErrorAs(t, err, &want)
+			case *fx.UnsupportedPairError:
+				assert.ErrorAs(t, err, &want)
+			case *fx.RateDeviationError:
+				assert.ErrorAs(t, err, &want)
+			default:
+				if assert.NoError(t, err) {
+					assert.Equal(t, tt.wantRate, rate.Rate)
+					assert.Equal(t, tt.wantSource, rate.Source)
+				}
+			}
+		})
+	}
+}
+
+func TestGetExchangeRateUseCase_Exec_DeviationAlertedOnce(t *testing.T) {
+	notifier := &mockRateAlertNotifier{}
+	providers := []fx_out.ExchangeRateProvider{
+		&mockProvider{name: "coingecko", rate: 70000, maxAge: time.Hour},
+		&mockProvider{name: "reference", rate: 67000, maxAge: time.Hour},
+	}
+
+	usecase := fx_use_cases.NewGetExchangeRateUseCase(providers, notifier, 0.02)
+
+	for i := 0; i < 3; i++ {
+		_, err := usecase.Exec(context.Background(), "BTC", "USD")
+
+		var deviationErr *fx.RateDeviationError
+		if assert.ErrorAs(t, err, &deviationErr) {
+			assert.InDelta(t, 3000.0/67000.0, deviationErr.Alert.Deviation, 1e-9)
+		}
+	}
+
+	if assert.Len(t, notifier.alerts, 1) {
+		assert.Equal(t, "BTC/USD", notifier.alerts[0].Rate.Pair())
+		assert.Equal(t, "reference", notifier.alerts[0].Reference.Source)
+	}
+}
@@ -14,6 +14,7 @@ type TickIDType float64
 
 const (
 	Event_MatchStartID           EventIDKey = "MatchStart"
+	Event_RoundStartID           EventIDKey = "RoundStart"
 	Event_RoundMVPAnnouncementID EventIDKey = "RoundMVPAnnouncement"
 	Event_RoundEndID             EventIDKey = "RoundEndID"
 	Event_FragOrScoreID          EventIDKey = "FragOrScoreID"
@@ -24,17 +25,25 @@ const (
 	Event_ClutchProgressID       EventIDKey = "ClutchProgress"
 	Event_ClutchEndID            EventIDKey = "ClutchEnd"
 	Event_Economy                EventIDKey = "EconomyEvent"
+	Event_BombPlantedID          EventIDKey = "BombPlanted"
+	Event_BombDefusedID          EventIDKey = "BombDefused"
+	Event_BombExplodedID         EventIDKey = "BombExploded"
+	Event_PlayerScoreboardID     EventIDKey = "PlayerScoreboard"
+	Event_PositionSamplesID      EventIDKey = "PositionSamples"
+	Event_RoundKillsID           EventIDKey = "RoundKills"
 )
 
 type Game struct {
-	ID     GameIDKey    `json:"id"`             // ID is the unique identifier of the game.
-	Name   string       `json:"name"`           // Name is the name of the game.
-	Events []EventIDKey `json:"in_game_events"` // Events is a map of SUPPORTED/IMPLEMENTED in-game events to their corresponding event names.
+	ID       GameIDKey      `json:"id"`             // ID is the unique identifier of the game.
+	Name     string         `json:"name"`           // Name is the name of the game.
+	Events   []EventIDKey   `json:"in_game_events"` // Events is a map of SUPPORTED/IMPLEMENTED in-game events to their corresponding event names.
+	Taxonomy *EventTaxonomy `json:"taxonomy"`       // Taxonomy normalizes the in-game events, for the analytics across games.
 }
 
 func mapCSEvents() []EventIDKey {
 	return []EventIDKey{
 		Event_MatchStartID,
+		Event_RoundStartID,
 		Event_RoundMVPAnnouncementID,
 		Event_RoundEndID,
 		Event_GenericGameEventID,
@@ -42,6 +51,12 @@ func mapCSEvents() []EventIDKey {
 		Event_ClutchProgressID,
 		Event_ClutchEndID,
 		Event_Economy,
+		Event_BombPlantedID,
+		Event_BombDefusedID,
+		Event_BombExplodedID,
+		Event_PlayerScoreboardID,
+		Event_PositionSamplesID,
+		Event_RoundKillsID,
 	}
 }
 
@@ -56,15 +71,17 @@ func mapCSEvents() []EventIDKey {
 
 var (
 	CS2 = &Game{
-		ID:     CS2_GAME_ID,
-		Name:   "Counter-Strike: 2",
-		Events: mapCSEvents(),
+		ID:       CS2_GAME_ID,
+		Name:     "Counter-Strike: 2",
+		Events:   mapCSEvents(),
+		Taxonomy: CSEventTaxonomy,
 	}
 
 	CSGO = &Game{
-		ID:     CSGO_GAME_ID,
-		Name:   "Counter-Strike: Global Offensive",
-		Events: mapCSEvents(),
+		ID:       CSGO_GAME_ID,
+		Name:     "Counter-Strike: Global Offensive",
+		Events:   mapCSEvents(),
+		Taxonomy: CSEventTaxonomy,
 	}
 
 	// VLRNT = &Game{
@@ -70,8 +70,7 @@ func (usecase *OnboardGoogleUserUseCase) Exec(ctx context.Context, googleUser *g
 
 		googleUser = &googleUserResult[0]
 
-		ctx = context.WithValue(ctx, common.UserIDKey, googleUser.ResourceOwner.UserID)
-		ctx = context.WithValue(ctx, common.GroupIDKey, googleUser.ResourceOwner.GroupID)
+		ctx = common.WithResourceOwner(ctx, common.ResourceOwner{GroupID: googleUser.ResourceOwner.GroupID, UserID: googleUser.ResourceOwner.UserID})
 	}
 
 	profile, ridToken, err := usecase.OnboardOpenIDUser.Exec(ctx, iam_in.OnboardOpenIDUserCommand{
@@ -91,8 +90,7 @@ func (usecase *OnboardGoogleUserUseCase) Exec(ctx context.Context, googleUser *g
 		return nil, nil, google.NewGoogleUserCreationError(fmt.Sprintf("error creating rid token: %v", googleUser.Email))
 	}
 
-	ctx = context.WithValue(ctx, common.UserIDKey, profile.ResourceOwner.UserID)
-	ctx = context.WithValue(ctx, common.GroupIDKey, profile.ResourceOwner.GroupID)
+	ctx = common.WithResourceOwner(ctx, common.ResourceOwner{GroupID: profile.ResourceOwner.GroupID, UserID: profile.ResourceOwner.UserID})
 
 	googleUser.ResourceOwner = common.GetResourceOwner(ctx)
 
@@ -0,0 +1,173 @@
+package iam_entities
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+)
+
+// APIKeyPrefix marks the API keys (ie: for secret scanners): keys are "tpk_<id>_<secret>".
+const APIKeyPrefix = "tpk_"
+
+// APIKeyTier sets the rate limit of the public API for the callers of a key.
+type APIKeyTier string
+
+const (
+	APIKeyTierCommunity APIKeyTier = "community" // community stat sites (default)
+	APIKeyTierPartner   APIKeyTier = "partner"   // partners with an agreement
+)
+
+// APIKeyTierRateLimits are the requests per minute allowed to the public API callers of each tier.
+var APIKeyTierRateLimits = map[APIKeyTier]int{
+	APIKeyTierCommunity: 60,
+	APIKeyTierPartner:   600,
+}
+
+func (t APIKeyTier) Known() bool {
+	_, ok := APIKeyTierRateLimits[t]
+	return ok
+}
+
+// RateLimit is the requests per minute allowed to the tier (unknown tiers are limited as community keys).
+func (t APIKeyTier) RateLimit() int {
+	if limit, ok := APIKeyTierRateLimits[t]; ok {
+		return limit
+	}
+
+	return APIKeyTierRateLimits[APIKeyTierCommunity]
+}
+
+// APIKey authenticates a server-to-server caller (ie: game server plugins, bots) sent as X-API-Key. The caller acts on behalf of the
+// client application the key was issued by (with no user), holding the permissions of the key instead of roles. Only the sha256 of the
+// key is stored: the key itself is only handed out when issued or rotated.
+type APIKey struct {
+	ID            uuid.UUID            `json:"id" bson:"_id"`
+	Name          string               `json:"name" bson:"name"`
+	Key           string               `json:"key,omitempty" bson:"-"` // only known when the key is issued or rotated
+	KeyHash       string               `json:"-" bson:"key_hash"`
+	Permissions   []Permission         `json:"permissions" bson:"permissions"`
+	Tier          APIKeyTier           `json:"tier" bson:"tier"`
+	IssuedBy      uuid.UUID            `json:"issued_by" bson:"issued_by"`
+	ExpiresAt     *time.Time           `json:"expires_at,omitempty" bson:"expires_at"`
+	LastUsedAt    *time.Time           `json:"last_used_at,omitempty" bson:"last_used_at"`
+	RotatedAt     *time.Time           `json:"rotated_at,omitempty" bson:"rotated_at"`
+	RevokedAt     *time.Time           `json:"revoked_at,omitempty" bson:"revoked_at"`
+	ResourceOwner common.ResourceOwner `json:"resource_owner" bson:"resource_owner"`
+	CreatedAt     time.Time            `json:"created_at" bson:"created_at"`
+	UpdatedAt     time.Time            `json:"updated_at" bson:"updated_at"`
+}
+
+func (k APIKey) GetID() uuid.UUID {
+	return k.ID
+}
+
+// NewAPIKey issues a key of the client application of the issuer.
+func NewAPIKey(name string, permissions []Permission, tier APIKeyTier, expiresAt *time.Time, issuer common.ResourceOwner, now time.Time) (*APIKey, error) {
+	k := &APIKey{
+		ID:            uuid.New(),
+		Name:          name,
+		Permissions:   permissions,
+		Tier:          tier,
+		IssuedBy:      issuer.UserID,
+		ExpiresAt:     expiresAt,
+		ResourceOwner: common.ResourceOwner{TenantID: issuer.TenantID, ClientID: issuer.ClientID},
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	}
+
+	err := k.generate()
+	if err != nil {
+		return nil, err
+	}
+
+	return k, nil
+}
+
+func (k *APIKey) generate() error {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return fmt.Errorf("unable to generate api key: %w", err)
+	}
+
+	k.Key = APIKeyPrefix + hex.EncodeToString(k.ID[:]) + "_" + base64.RawURLEncoding.EncodeToString(b)
+	k.KeyHash = HashAPIKey(k.Key)
+
+	return nil
+}
+
+// Rotate replaces the key (the previous one stops working), keeping its ID and permissions.
+func (k *APIKey) Rotate(now time.Time) error {
+	err := k.generate()
+	if err != nil {
+		return err
+	}
+
+	k.RotatedAt = &now
+	k.UpdatedAt = now
+
+	return nil
+}
+
+func (k *APIKey) Revoke(now time.Time) {
+	k.RevokedAt = &now
+	k.UpdatedAt = now
+}
+
+// Active reports whether the key can still be used (not revoked nor expired).
+func (k *APIKey) Active(now time.Time) bool {
+	return k.RevokedAt == nil && (k.ExpiresAt == nil || now.Before(*k.ExpiresAt))
+}
+
+// Matches compares the key in constant time with the hash of the key.
+func (k *APIKey) Matches(key string) bool {
+	return subtle.ConstantTimeCompare([]byte(HashAPIKey(key)), []byte(k.KeyHash)) == 1
+}
+
+// Grants reports whether a permission of the key allows the required one.
+func (k *APIKey) Grants(required Permission) bool {
+	for _, p := range k.Permissions {
+		if p.Grants(required) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ParseAPIKeyID returns the ID of an API key, false when it isn't one.
+func ParseAPIKeyID(key string) (uuid.UUID, bool) {
+	rest, ok := strings.CutPrefix(key, APIKeyPrefix)
+	if !ok {
+		return uuid.Nil, false
+	}
+
+	id, secret, ok := strings.Cut(rest, "_")
+	if !ok || secret == "" {
+		return uuid.Nil, false
+	}
+
+	b, err := hex.DecodeString(id)
+	if err != nil {
+		return uuid.Nil, false
+	}
+
+	keyID, err := uuid.FromBytes(b)
+	if err != nil {
+		return uuid.Nil, false
+	}
+
+	return keyID, true
+}
+
+func HashAPIKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
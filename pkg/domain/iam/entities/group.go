@@ -14,11 +14,17 @@ const (
 	GroupTypeSystem GroupType = "System" // Public, Public(Anyone with the link, link/:slug-id route), Private, Namespace (directory/path trees), TagXyz, Friends, BugReport#1, Users(Region,Match, etc... ==> tag!! user-defined tag ())
 )
 
+// MaxGroupDepth caps the nesting of groups (ie: org → division → team → roster).
+const MaxGroupDepth = 4
+
+// Group is a set of users of a tenant. Groups nest under a parent (ie: the teams of an org, the rosters of a team): the members of a
+// group are members of its subgroups too (see Membership).
 type Group struct {
 	ID            uuid.UUID            `json:"id" bson:"_id"`
 	Name          string               `json:"name" bson:"name"`
 	Type          GroupType            `json:"type" bson:"type"`
-	ParentGroupID *uuid.UUID           `json:"parent_group_id" bson:"parent_group_id"` // (REVIEW: already has a parent in the resource_owner)
+	ParentGroupID *uuid.UUID           `json:"parent_group_id" bson:"parent_group_id"` // nil for a root group (ie: an org)
+	Ancestors     []uuid.UUID          `json:"ancestors" bson:"ancestors"`             // the parents of the group, root first
 	ResourceOwner common.ResourceOwner `json:"resource_owner" bson:"resource_owner"`
 	CreatedAt     time.Time            `json:"created_at" bson:"created_at"`
 	UpdatedAt     time.Time            `json:"updated_at" bson:"updated_at"`
@@ -44,3 +50,27 @@ func NewGroup(groupID uuid.UUID, name string, groupType GroupType, resourceOwner
 func (e Group) GetID() uuid.UUID {
 	return e.ID
 }
+
+// Path returns the ancestors of the group followed by the group itself.
+func (e Group) Path() []uuid.UUID {
+	path := make([]uuid.UUID, 0, len(e.Ancestors)+1)
+	path = append(path, e.Ancestors...)
+
+	return append(path, e.ID)
+}
+
+// Depth is the level of the group in its hierarchy, 1 for a root group.
+func (e Group) Depth() int {
+	return len(e.Ancestors) + 1
+}
+
+// IsDescendantOf reports whether the group is nested (at any level) under another one.
+func (e Group) IsDescendantOf(groupID uuid.UUID) bool {
+	for _, id := range e.Ancestors {
+		if id == groupID {
+			return true
+		}
+	}
+
+	return false
+}
@@ -15,6 +15,29 @@ const (
 	MembershipTypeMember MembershipType = "Member"
 )
 
+// Rank orders the membership types, owners first (0 for unknown types).
+func (t MembershipType) Rank() int {
+	switch t {
+	case MembershipTypeOwner:
+		return 3
+	case MembershipTypeAdmin:
+		return 2
+	case MembershipTypeMember:
+		return 1
+	}
+
+	return 0
+}
+
+// Manages reports whether members of the type manage the group (its members and subgroups).
+func (t MembershipType) Manages() bool {
+	return t == MembershipTypeOwner || t == MembershipTypeAdmin
+}
+
+// membershipNamespace keeps Membership IDs stable (group+user), so a user is a member of a group once.
+var membershipNamespace = uuid.MustParse("3f9d2c71-8a4e-4f06-b1d5-6e7c0a9b2d48")
+
+// Membership makes a user a member of a group, and of its subgroups: a membership of a parent group is inherited by its children.
 type Membership struct {
 	ID            uuid.UUID            `json:"id" bson:"_id"`
 	GroupID       uuid.UUID            `json:"group_id" bson:"group_id"`
@@ -24,3 +47,11 @@ type Membership struct {
 	CreatedAt     time.Time            `json:"created_at" bson:"created_at"`
 	UpdatedAt     time.Time            `json:"updated_at" bson:"updated_at"`
 }
+
+func (m Membership) GetID() uuid.UUID {
+	return m.ID
+}
+
+func MembershipID(groupID uuid.UUID, userID uuid.UUID) uuid.UUID {
+	return uuid.NewSHA1(membershipNamespace, append(groupID[:], userID[:]...))
+}
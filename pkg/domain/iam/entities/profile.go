@@ -34,15 +34,13 @@ func NewProfile(userID uuid.UUID, groupID uuid.UUID, ridSource RIDSourceKey, sou
 }
 
 func (p *Profile) GetContext(ctx context.Context) context.Context {
-	ctx = context.WithValue(ctx, common.GroupIDKey, p.ResourceOwner.GroupID)
-	ctx = context.WithValue(ctx, common.UserIDKey, p.ResourceOwner.UserID)
+	ctx = common.WithResourceOwner(ctx, common.ResourceOwner{GroupID: p.ResourceOwner.GroupID, UserID: p.ResourceOwner.UserID})
 
 	return ctx
 }
 
 func (p *Profile) GetResourceOwner(ctx context.Context) common.ResourceOwner {
-	ctx = context.WithValue(ctx, common.GroupIDKey, p.ResourceOwner.GroupID)
-	ctx = context.WithValue(ctx, common.UserIDKey, p.ResourceOwner.UserID)
+	ctx = common.WithResourceOwner(ctx, common.ResourceOwner{GroupID: p.ResourceOwner.GroupID, UserID: p.ResourceOwner.UserID})
 
 	return common.GetResourceOwner(ctx)
 }
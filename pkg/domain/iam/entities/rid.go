@@ -1,6 +1,11 @@
 package iam_entities
 
 import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
 	"time"
 
 	"github.com/google/uuid"
@@ -10,10 +15,22 @@ import (
 type RIDSourceKey string
 
 const (
-	RIDSource_Steam  RIDSourceKey = "steam"
-	RIDSource_Google RIDSourceKey = "google"
+	RIDSource_Steam   RIDSourceKey = "steam"
+	RIDSource_Google  RIDSourceKey = "google"
+	RIDSource_Email   RIDSourceKey = "email"
+	RIDSource_Riot    RIDSourceKey = "riot"
+	RIDSource_Discord RIDSourceKey = "discord"
 )
 
+const (
+	// RIDTokenTTL is how long a RID can be used, before being refreshed.
+	RIDTokenTTL = time.Hour
+	// RIDRefreshTTL is how long the refresh key of a RID can be used (refreshing it extends its session by as much).
+	RIDRefreshTTL = 30 * 24 * time.Hour
+)
+
+// RIDToken is the RID of a resource owner (sent as X-Resource-Owner-ID). End user RIDs belong to a RIDSession and carry a single use
+// refresh key: refreshing rotates the RID, and reusing a rotated refresh key revokes the whole session (it was likely stolen).
 type RIDToken struct {
 	ID               uuid.UUID                  `json:"-" bson:"_id"`
 	Key              uuid.UUID                  `json:"-" bson:"key"`
@@ -21,6 +38,12 @@ type RIDToken struct {
 	ResourceOwner    common.ResourceOwner       `json:"-" bson:"resource_owner"`
 	IntendedAudience common.IntendedAudienceKey `json:"-" bson:"intended_audience"`
 	GrantType        string                     `json:"-" bson:"grant_type"`
+	SessionID        uuid.UUID                  `json:"-" bson:"session_id"`
+	RefreshKey       string                     `json:"-" bson:"-"`                // only known when the token is issued
+	RefreshKeyHash   string                     `json:"-" bson:"refresh_key_hash"` // sha256 of the refresh key
+	RefreshExpiresAt time.Time                  `json:"-" bson:"refresh_expires_at"`
+	RotatedAt        *time.Time                 `json:"-" bson:"rotated_at,omitempty"`
+	RevokedAt        *time.Time                 `json:"-" bson:"revoked_at,omitempty"`
 	ExpiresAt        time.Time                  `json:"-" bson:"expires_at"`
 	CreatedAt        time.Time                  `json:"-" bson:"created_at"`
 	UpdatedAt        time.Time                  `json:"-" bson:"updated_at"`
@@ -29,3 +52,27 @@ type RIDToken struct {
 func (t RIDToken) GetID() uuid.UUID {
 	return t.ID
 }
+
+// WithRefreshKey generates the refresh key of the token (the token keeps its hash, and the plain key to be handed out once).
+func (t *RIDToken) WithRefreshKey(now time.Time) error {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return fmt.Errorf("unable to generate refresh key: %w", err)
+	}
+
+	t.RefreshKey = base64.RawURLEncoding.EncodeToString(b)
+	t.RefreshKeyHash = HashRefreshKey(t.RefreshKey)
+	t.RefreshExpiresAt = now.Add(RIDRefreshTTL)
+
+	return nil
+}
+
+// Refreshable reports whether the refresh key of the token can still be used (not yet rotated, revoked nor expired).
+func (t *RIDToken) Refreshable(now time.Time) bool {
+	return t.RefreshKeyHash != "" && t.RotatedAt == nil && t.RevokedAt == nil && now.Before(t.RefreshExpiresAt)
+}
+
+func HashRefreshKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,71 @@
+package iam_entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+)
+
+type RIDSessionRevocationReason string
+
+const (
+	RIDSessionRevokedByUser    RIDSessionRevocationReason = "revoked_by_user"
+	RIDSessionRefreshKeyReused RIDSessionRevocationReason = "refresh_key_reused"
+)
+
+// RIDSession is a sign-in of a user on a device: the RIDs issued since, each rotated into the next one when refreshed. Users list their
+// active sessions and revoke the ones they don't recognize.
+type RIDSession struct {
+	ID            uuid.UUID                  `json:"id" bson:"_id"`
+	Source        RIDSourceKey               `json:"rid_source" bson:"rid_source"`
+	UserAgent     string                     `json:"user_agent" bson:"user_agent"`
+	IPAddress     string                     `json:"ip_address" bson:"ip_address"` // of the last sign-in or refresh
+	LastUsedAt    time.Time                  `json:"last_used_at" bson:"last_used_at"`
+	ExpiresAt     time.Time                  `json:"expires_at" bson:"expires_at"` // of its last refresh key
+	RevokedAt     *time.Time                 `json:"revoked_at,omitempty" bson:"revoked_at,omitempty"`
+	RevokedReason RIDSessionRevocationReason `json:"revoked_reason,omitempty" bson:"revoked_reason,omitempty"`
+	ResourceOwner common.ResourceOwner       `json:"resource_owner" bson:"resource_owner"`
+	CreatedAt     time.Time                  `json:"created_at" bson:"created_at"`
+	UpdatedAt     time.Time                  `json:"updated_at" bson:"updated_at"`
+}
+
+func (s RIDSession) GetID() uuid.UUID {
+	return s.ID
+}
+
+func NewRIDSession(source RIDSourceKey, userAgent string, ipAddress string, resourceOwner common.ResourceOwner, now time.Time) *RIDSession {
+	return &RIDSession{
+		ID:            uuid.New(),
+		Source:        source,
+		UserAgent:     userAgent,
+		IPAddress:     ipAddress,
+		LastUsedAt:    now,
+		ExpiresAt:     now.Add(RIDRefreshTTL),
+		ResourceOwner: resourceOwner,
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	}
+}
+
+// Active reports whether RIDs of the session can still be used or refreshed.
+func (s *RIDSession) Active(now time.Time) bool {
+	return s.RevokedAt == nil && now.Before(s.ExpiresAt)
+}
+
+// Refreshed records the use of the refresh key issued at now.
+func (s *RIDSession) Refreshed(ipAddress string, now time.Time) {
+	if ipAddress != "" {
+		s.IPAddress = ipAddress
+	}
+
+	s.LastUsedAt = now
+	s.ExpiresAt = now.Add(RIDRefreshTTL)
+	s.UpdatedAt = now
+}
+
+func (s *RIDSession) Revoke(reason RIDSessionRevocationReason, now time.Time) {
+	s.RevokedAt = &now
+	s.RevokedReason = reason
+	s.UpdatedAt = now
+}
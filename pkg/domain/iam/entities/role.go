@@ -0,0 +1,139 @@
+package iam_entities
+
+import (
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+)
+
+// Permission is an action on a resource ("<resource>:<action>"), granted to users by their roles. "<resource>:*" grants every action
+// on the resource, "*" grants everything.
+type Permission string
+
+const (
+	PermissionAll Permission = "*"
+
+	PermissionRolesManage        Permission = "roles:manage"
+	PermissionTournamentManage   Permission = "tournament:manage"
+	PermissionWalletAdmin        Permission = "wallet:admin"
+	PermissionAnalyticsRead      Permission = "analytics:read"
+	PermissionQualityRead        Permission = "quality:read"
+	PermissionMetaRead           Permission = "meta:read"
+	PermissionMatchmakingManage  Permission = "matchmaking:manage"
+	PermissionConsentPublish     Permission = "consent:publish"
+	PermissionNotificationsRead  Permission = "notifications:read"
+	PermissionWebSocketRead      Permission = "websocket:read"
+	PermissionJobsManage         Permission = "jobs:manage"
+	PermissionAPIKeysManage      Permission = "api_keys:manage"
+	PermissionPublicRead         Permission = "public:read"
+	PermissionVisibilityManage   Permission = "visibility:manage"
+	PermissionReplaysManage      Permission = "replays:manage"
+	PermissionSeasonsManage      Permission = "seasons:manage"
+	PermissionBadgesManage       Permission = "badges:manage"
+	PermissionCustomFieldsManage Permission = "custom_fields:manage"
+	PermissionExportsManage      Permission = "exports:manage"
+	PermissionAPIKeysTiers       Permission = "api_keys:tiers" // issue keys of the tiers above community
+)
+
+// Permissions are the permissions known to the API (the ones roles can grant, besides the wildcards).
+var Permissions = []Permission{
+	PermissionRolesManage,
+	PermissionTournamentManage,
+	PermissionWalletAdmin,
+	PermissionAnalyticsRead,
+	PermissionQualityRead,
+	PermissionMetaRead,
+	PermissionMatchmakingManage,
+	PermissionConsentPublish,
+	PermissionNotificationsRead,
+	PermissionWebSocketRead,
+	PermissionJobsManage,
+	PermissionAPIKeysManage,
+	PermissionPublicRead,
+	PermissionVisibilityManage,
+	PermissionReplaysManage,
+	PermissionSeasonsManage,
+	PermissionBadgesManage,
+	PermissionCustomFieldsManage,
+	PermissionExportsManage,
+	PermissionAPIKeysTiers,
+}
+
+func (p Permission) resource() string {
+	return strings.SplitN(string(p), ":", 2)[0]
+}
+
+// Grants reports whether holding the permission allows the required one.
+func (p Permission) Grants(required Permission) bool {
+	if p == PermissionAll || p == required {
+		return true
+	}
+
+	return strings.HasSuffix(string(p), ":*") && p.resource() == required.resource()
+}
+
+// Known reports whether the permission (or wildcard) is one of the known Permissions.
+func (p Permission) Known() bool {
+	if p == PermissionAll {
+		return true
+	}
+
+	for _, known := range Permissions {
+		if known == p || (strings.HasSuffix(string(p), ":*") && known.resource() == p.resource()) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Role is a named set of permissions of a tenant, assigned to its users (see RoleAssignment).
+type Role struct {
+	ID            uuid.UUID            `json:"id" bson:"_id"`
+	Name          string               `json:"name" bson:"name"`
+	Description   string               `json:"description" bson:"description"`
+	Permissions   []Permission         `json:"permissions" bson:"permissions"`
+	ResourceOwner common.ResourceOwner `json:"resource_owner" bson:"resource_owner"`
+	CreatedAt     time.Time            `json:"created_at" bson:"created_at"`
+	UpdatedAt     time.Time            `json:"updated_at" bson:"updated_at"`
+}
+
+func (r Role) GetID() uuid.UUID {
+	return r.ID
+}
+
+// Grants reports whether a permission of the role allows the required one.
+func (r Role) Grants(required Permission) bool {
+	for _, p := range r.Permissions {
+		if p.Grants(required) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// roleAssignmentNamespace keeps RoleAssignment IDs stable (role+user), so a role is assigned to a user once.
+var roleAssignmentNamespace = uuid.MustParse("b6a1f0d2-4e3c-4b8a-9d7e-52c0a8f3e914")
+
+// RoleAssignment grants the permissions of a role to a user, until revoked.
+type RoleAssignment struct {
+	ID            uuid.UUID            `json:"id" bson:"_id"`
+	RoleID        uuid.UUID            `json:"role_id" bson:"role_id"`
+	UserID        uuid.UUID            `json:"user_id" bson:"user_id"`
+	AssignedBy    uuid.UUID            `json:"assigned_by" bson:"assigned_by"`
+	RevokedAt     *time.Time           `json:"revoked_at,omitempty" bson:"revoked_at"`
+	ResourceOwner common.ResourceOwner `json:"resource_owner" bson:"resource_owner"`
+	CreatedAt     time.Time            `json:"created_at" bson:"created_at"`
+	UpdatedAt     time.Time            `json:"updated_at" bson:"updated_at"`
+}
+
+func (a RoleAssignment) GetID() uuid.UUID {
+	return a.ID
+}
+
+func RoleAssignmentID(roleID uuid.UUID, userID uuid.UUID) uuid.UUID {
+	return uuid.NewSHA1(roleAssignmentNamespace, append(roleID[:], userID[:]...))
+}
@@ -0,0 +1,206 @@
+package iam
+
+import (
+	"fmt"
+
+	"github.com/google/uuid"
+)
+
+// Invalid Refresh Key Error (unknown, expired or revoked refresh key)
+type InvalidRefreshKeyError struct {
+	Message string
+}
+
+func (e *InvalidRefreshKeyError) Error() string {
+	return e.Message
+}
+
+func NewInvalidRefreshKeyError() *InvalidRefreshKeyError {
+	return &InvalidRefreshKeyError{
+		Message: "refresh key is invalid or expired",
+	}
+}
+
+// Refresh Key Reused Error (a rotated refresh key was used again: its session is revoked)
+type RefreshKeyReusedError struct {
+	Message   string
+	SessionID uuid.UUID
+}
+
+func (e *RefreshKeyReusedError) Error() string {
+	return e.Message
+}
+
+func NewRefreshKeyReusedError(sessionID uuid.UUID) *RefreshKeyReusedError {
+	return &RefreshKeyReusedError{
+		Message:   "refresh key was already used: the session is revoked, sign in again",
+		SessionID: sessionID,
+	}
+}
+
+// RID Session Not Found Error (unknown session, or a session of another user)
+type RIDSessionNotFoundError struct {
+	Message string
+}
+
+func (e *RIDSessionNotFoundError) Error() string {
+	return e.Message
+}
+
+func NewRIDSessionNotFoundError(sessionID uuid.UUID) *RIDSessionNotFoundError {
+	return &RIDSessionNotFoundError{
+		Message: fmt.Sprintf("session %s not found", sessionID),
+	}
+}
+
+// Invalid Role Error (no name, or unknown permissions)
+type InvalidRoleError struct {
+	Message string
+}
+
+func (e *InvalidRoleError) Error() string {
+	return e.Message
+}
+
+func NewInvalidRoleError(message string) *InvalidRoleError {
+	return &InvalidRoleError{
+		Message: message,
+	}
+}
+
+// Role Not Found Error (unknown role, or a role of another tenant)
+type RoleNotFoundError struct {
+	Message string
+}
+
+func (e *RoleNotFoundError) Error() string {
+	return e.Message
+}
+
+func NewRoleNotFoundError(roleID uuid.UUID) *RoleNotFoundError {
+	return &RoleNotFoundError{
+		Message: fmt.Sprintf("role %s not found", roleID),
+	}
+}
+
+// Permission Denied Error (the user doesn't hold a permission required by the action)
+type PermissionDeniedError struct {
+	Message    string
+	Permission string
+}
+
+func (e *PermissionDeniedError) Error() string {
+	return e.Message
+}
+
+func NewPermissionDeniedError(permission string) *PermissionDeniedError {
+	return &PermissionDeniedError{
+		Message:    fmt.Sprintf("permission %s is required", permission),
+		Permission: permission,
+	}
+}
+
+// Invalid API Key Error (no name, unknown permissions, or already expired)
+type InvalidAPIKeyError struct {
+	Message string
+}
+
+func (e *InvalidAPIKeyError) Error() string {
+	return e.Message
+}
+
+func NewInvalidAPIKeyError(message string) *InvalidAPIKeyError {
+	return &InvalidAPIKeyError{
+		Message: message,
+	}
+}
+
+// API Key Not Found Error (unknown key, or a key of another client application)
+type APIKeyNotFoundError struct {
+	Message string
+}
+
+func (e *APIKeyNotFoundError) Error() string {
+	return e.Message
+}
+
+func NewAPIKeyNotFoundError(keyID uuid.UUID) *APIKeyNotFoundError {
+	return &APIKeyNotFoundError{
+		Message: fmt.Sprintf("api key %s not found", keyID),
+	}
+}
+
+// Unauthenticated API Key Error (malformed, unknown, revoked or expired X-API-Key)
+type UnauthenticatedAPIKeyError struct {
+	Message string
+}
+
+func (e *UnauthenticatedAPIKeyError) Error() string {
+	return e.Message
+}
+
+func NewUnauthenticatedAPIKeyError() *UnauthenticatedAPIKeyError {
+	return &UnauthenticatedAPIKeyError{
+		Message: "invalid api key",
+	}
+}
+
+// Invalid Visibility Policy Error
+type InvalidVisibilityPolicyError struct {
+	Message string
+}
+
+func (e *InvalidVisibilityPolicyError) Error() string {
+	return e.Message
+}
+
+func NewInvalidVisibilityPolicyError(message string) *InvalidVisibilityPolicyError {
+	return &InvalidVisibilityPolicyError{
+		Message: message,
+	}
+}
+
+// Group Not Found Error (unknown group, or a group of another tenant)
+type GroupNotFoundError struct {
+	Message string
+}
+
+func (e *GroupNotFoundError) Error() string {
+	return e.Message
+}
+
+func NewGroupNotFoundError(groupID uuid.UUID) *GroupNotFoundError {
+	return &GroupNotFoundError{
+		Message: fmt.Sprintf("group %s not found", groupID),
+	}
+}
+
+// Invalid Group Error (no name, a cycle in its hierarchy, or nested too deep)
+type InvalidGroupError struct {
+	Message string
+}
+
+func (e *InvalidGroupError) Error() string {
+	return e.Message
+}
+
+func NewInvalidGroupError(message string) *InvalidGroupError {
+	return &InvalidGroupError{
+		Message: message,
+	}
+}
+
+// Group Access Denied Error (the user isn't a member, or doesn't manage the group or any of its parents)
+type GroupAccessDeniedError struct {
+	Message string
+}
+
+func (e *GroupAccessDeniedError) Error() string {
+	return e.Message
+}
+
+func NewGroupAccessDeniedError(groupID uuid.UUID) *GroupAccessDeniedError {
+	return &GroupAccessDeniedError{
+		Message: fmt.Sprintf("access to group %s is denied", groupID),
+	}
+}
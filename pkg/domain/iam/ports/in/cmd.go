@@ -2,6 +2,7 @@ package iam_in
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
@@ -16,6 +17,16 @@ type VerifyRIDKeyCommand interface {
 	Exec(ctx context.Context, key uuid.UUID) (common.ResourceOwner, error)
 }
 
+// RefreshRIDTokenCommand rotates the RID of a refresh key: the key is single use, the new RID carries the next one.
+type RefreshRIDTokenCommand interface {
+	Exec(ctx context.Context, refreshKey string) (*iam_entities.RIDToken, error)
+}
+
+// RevokeRIDSessionCommand signs a session of the user in context out (its RIDs and refresh key stop working).
+type RevokeRIDSessionCommand interface {
+	Exec(ctx context.Context, sessionID uuid.UUID) (*iam_entities.RIDSession, error)
+}
+
 type OnboardOpenIDUserCommand struct {
 	Source         iam_entities.RIDSourceKey `json:"rid_source" bson:"rid_source"`
 	Key            string                    `json:"key" bson:"key"`
@@ -26,3 +37,101 @@ type OnboardOpenIDUserCommand struct {
 type OnboardOpenIDUserCommandHandler interface {
 	Exec(ctx context.Context, cmd OnboardOpenIDUserCommand) (*iam_entities.Profile, *iam_entities.RIDToken, error)
 }
+
+type CreateRoleCommand struct {
+	Name        string                    `json:"name"`
+	Description string                    `json:"description"`
+	Permissions []iam_entities.Permission `json:"permissions"`
+}
+
+type CreateRoleCommandHandler interface {
+	Exec(ctx context.Context, cmd CreateRoleCommand) (*iam_entities.Role, error)
+}
+
+type RoleAssignmentCommand struct {
+	RoleID uuid.UUID `json:"role_id"`
+	UserID uuid.UUID `json:"user_id"`
+}
+
+// AssignRoleCommandHandler grants a role of the tenant to a user (assigning it again is a no-op).
+type AssignRoleCommandHandler interface {
+	Exec(ctx context.Context, cmd RoleAssignmentCommand) (*iam_entities.RoleAssignment, error)
+}
+
+// UnassignRoleCommandHandler revokes a role from a user.
+type UnassignRoleCommandHandler interface {
+	Exec(ctx context.Context, cmd RoleAssignmentCommand) (*iam_entities.RoleAssignment, error)
+}
+
+type SetVisibilityPolicyCommand struct {
+	GroupID    uuid.UUID         `json:"group_id"` // uuid.Nil for the whole tenant
+	Visibility common.Visibility `json:"visibility"`
+}
+
+// SetVisibilityPolicyCommandHandler sets the default visibility of the entities created in the tenant in context, or in a group of it
+// (replacing the previous policy of the tenant or group).
+type SetVisibilityPolicyCommandHandler interface {
+	Exec(ctx context.Context, cmd SetVisibilityPolicyCommand) (*common.VisibilityPolicy, error)
+}
+
+type IssueAPIKeyCommand struct {
+	Name        string                    `json:"name"`
+	Permissions []iam_entities.Permission `json:"permissions"`
+	Tier        iam_entities.APIKeyTier   `json:"tier,omitempty"`       // community when empty
+	ExpiresAt   *time.Time                `json:"expires_at,omitempty"` // never expires when nil
+}
+
+// IssueAPIKeyCommandHandler issues a key of the client application in context, granting permissions the issuer holds. The returned key
+// is the only time it's known.
+type IssueAPIKeyCommandHandler interface {
+	Exec(ctx context.Context, cmd IssueAPIKeyCommand) (*iam_entities.APIKey, error)
+}
+
+// RotateAPIKeyCommandHandler replaces an active key (the previous one stops working), returning the new one.
+type RotateAPIKeyCommandHandler interface {
+	Exec(ctx context.Context, keyID uuid.UUID) (*iam_entities.APIKey, error)
+}
+
+// RevokeAPIKeyCommandHandler revokes a key (revoking it again is a no-op).
+type RevokeAPIKeyCommandHandler interface {
+	Exec(ctx context.Context, keyID uuid.UUID) (*iam_entities.APIKey, error)
+}
+
+// AuthenticateAPIKeyCommand returns the active key matching an X-API-Key, a *iam.UnauthenticatedAPIKeyError otherwise.
+type AuthenticateAPIKeyCommand interface {
+	Exec(ctx context.Context, key string) (*iam_entities.APIKey, error)
+}
+
+type CreateGroupCommand struct {
+	Name          string     `json:"name"`
+	ParentGroupID *uuid.UUID `json:"parent_group_id,omitempty"` // a root group (ie: an org) when nil
+}
+
+// CreateGroupCommandHandler creates a group of the tenant in context, owned by the user in context. Creating a subgroup requires
+// managing its parent.
+type CreateGroupCommandHandler interface {
+	Exec(ctx context.Context, cmd CreateGroupCommand) (*iam_entities.Group, error)
+}
+
+type SetGroupParentCommand struct {
+	GroupID       uuid.UUID  `json:"group_id"`
+	ParentGroupID *uuid.UUID `json:"parent_group_id"` // nil makes it a root group
+}
+
+// SetGroupParentCommandHandler moves a group, with its subgroups, under another parent. It requires managing the group and the new
+// parent.
+type SetGroupParentCommandHandler interface {
+	Exec(ctx context.Context, cmd SetGroupParentCommand) (*iam_entities.Group, error)
+}
+
+type SetGroupMemberCommand struct {
+	GroupID uuid.UUID                   `json:"group_id"`
+	UserID  uuid.UUID                   `json:"user_id"`
+	Type    iam_entities.MembershipType `json:"type"`
+}
+
+// SetGroupMemberCommandHandler adds a user to a group managed by the user in context, or changes the type of their membership (only
+// owners grant ownership).
+type SetGroupMemberCommandHandler interface {
+	Exec(ctx context.Context, cmd SetGroupMemberCommand) (*iam_entities.Membership, error)
+}
@@ -1,10 +1,45 @@
 package iam_in
 
 import (
+	"context"
+
+	"github.com/google/uuid"
 	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
 	iam_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/iam/entities"
 )
 
+// ListRIDSessionsQuery lists the active sessions (signed in devices) of the user in context, most recently used first.
+type ListRIDSessionsQuery interface {
+	Exec(ctx context.Context) ([]iam_entities.RIDSession, error)
+}
+
+// AuthorizeQuery checks the user in context holds every required permission (through their roles), returning an
+// *iam.PermissionDeniedError otherwise.
+type AuthorizeQuery interface {
+	Exec(ctx context.Context, required ...iam_entities.Permission) error
+}
+
 type ProfileReader interface {
 	common.Searchable[iam_entities.Profile]
 }
+
+type VisibilityPolicyReader interface {
+	common.Searchable[common.VisibilityPolicy]
+}
+
+type VisibilityOverrideReader interface {
+	common.Searchable[common.VisibilityOverride]
+}
+
+// GroupHierarchyReader reads the hierarchy of the groups of the tenant in context. A group is visible to its members and to the
+// members of its parents, returning an *iam.GroupAccessDeniedError to anyone else.
+type GroupHierarchyReader interface {
+	// Subgroups returns the groups nested (at any level) under a group, parents first.
+	Subgroups(ctx context.Context, groupID uuid.UUID) ([]iam_entities.Group, error)
+	// Members returns the memberships of a group and of its subgroups.
+	Members(ctx context.Context, groupID uuid.UUID) ([]iam_entities.Membership, error)
+	// EffectiveMembership returns the strongest membership of a user in a group or in any of its parents, nil when there's none.
+	EffectiveMembership(ctx context.Context, groupID uuid.UUID, userID uuid.UUID) (*iam_entities.Membership, error)
+	// UserGroups returns the groups of the user in context, with the subgroups of the ones they're a member of.
+	UserGroups(ctx context.Context) ([]iam_entities.Group, error)
+}
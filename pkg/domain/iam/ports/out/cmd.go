@@ -2,13 +2,26 @@ package iam_out
 
 import (
 	"context"
+	"time"
 
+	"github.com/google/uuid"
+
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
 	iam_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/iam/entities"
 	iam_entity "github.com/psavelis/team-pro/replay-api/pkg/domain/iam/entities"
 )
 
 type RIDTokenWriter interface {
 	Create(ctx context.Context, rid *iam_entity.RIDToken) (*iam_entity.RIDToken, error)
+	// Rotate marks the refresh key of the token used (expiring the token), false when it was already rotated.
+	Rotate(ctx context.Context, id uuid.UUID, at time.Time) (bool, error)
+	// RevokeSession revokes (expiring) every token of the session.
+	RevokeSession(ctx context.Context, sessionID uuid.UUID, at time.Time) error
+}
+
+type RIDSessionWriter interface {
+	Create(ctx context.Context, session *iam_entity.RIDSession) (*iam_entity.RIDSession, error)
+	Update(ctx context.Context, session *iam_entity.RIDSession) (*iam_entity.RIDSession, error)
 }
 
 type UserWriter interface {
@@ -19,9 +32,38 @@ type UserWriter interface {
 type GroupWriter interface {
 	CreateMany(createCtx context.Context, events []*iam_entities.Group) error
 	Create(createCtx context.Context, events *iam_entities.Group) (*iam_entities.Group, error)
+	Update(ctx context.Context, group *iam_entities.Group) (*iam_entities.Group, error)
+}
+
+type MembershipWriter interface {
+	Create(ctx context.Context, membership *iam_entity.Membership) (*iam_entity.Membership, error)
+	Update(ctx context.Context, membership *iam_entity.Membership) (*iam_entity.Membership, error)
 }
 
 type ProfileWriter interface {
 	CreateMany(createCtx context.Context, events []*iam_entities.Profile) error
 	Create(createCtx context.Context, events *iam_entities.Profile) (*iam_entities.Profile, error)
 }
+
+type RoleWriter interface {
+	Create(ctx context.Context, role *iam_entity.Role) (*iam_entity.Role, error)
+}
+
+type APIKeyWriter interface {
+	Create(ctx context.Context, key *iam_entity.APIKey) (*iam_entity.APIKey, error)
+	Update(ctx context.Context, key *iam_entity.APIKey) (*iam_entity.APIKey, error)
+}
+
+type RoleAssignmentWriter interface {
+	Create(ctx context.Context, assignment *iam_entity.RoleAssignment) (*iam_entity.RoleAssignment, error)
+	Update(ctx context.Context, assignment *iam_entity.RoleAssignment) (*iam_entity.RoleAssignment, error)
+}
+
+type VisibilityPolicyWriter interface {
+	Create(ctx context.Context, policy *common.VisibilityPolicy) (*common.VisibilityPolicy, error)
+	Update(ctx context.Context, policy *common.VisibilityPolicy) (*common.VisibilityPolicy, error)
+}
+
+type VisibilityOverrideWriter interface {
+	Create(ctx context.Context, override *common.VisibilityOverride) (*common.VisibilityOverride, error)
+}
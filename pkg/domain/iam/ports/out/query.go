@@ -11,6 +11,22 @@ type RIDTokenReader interface {
 	Search(ctx context.Context, s common.Search) ([]iam_entity.RIDToken, error)
 }
 
+type RIDSessionReader interface {
+	Search(ctx context.Context, s common.Search) ([]iam_entity.RIDSession, error)
+}
+
+type RoleReader interface {
+	Search(ctx context.Context, s common.Search) ([]iam_entity.Role, error)
+}
+
+type APIKeyReader interface {
+	Search(ctx context.Context, s common.Search) ([]iam_entity.APIKey, error)
+}
+
+type RoleAssignmentReader interface {
+	Search(ctx context.Context, s common.Search) ([]iam_entity.RoleAssignment, error)
+}
+
 type ProfileReader interface {
 	common.Searchable[iam_entity.Profile]
 }
@@ -23,6 +39,18 @@ type GroupReader interface {
 	Search(ctx context.Context, s common.Search) ([]iam_entity.Group, error)
 }
 
+type MembershipReader interface {
+	Search(ctx context.Context, s common.Search) ([]iam_entity.Membership, error)
+}
+
+type VisibilityPolicyReader interface {
+	common.Searchable[common.VisibilityPolicy]
+}
+
+type VisibilityOverrideReader interface {
+	common.Searchable[common.VisibilityOverride]
+}
+
 // type RIDTokenReader interface {
 // 	common.Searchable[iam_entity.RIDToken]
 // }
@@ -0,0 +1,73 @@
+package iam_query_services
+
+import (
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	iam_in "github.com/psavelis/team-pro/replay-api/pkg/domain/iam/ports/in"
+	iam_out "github.com/psavelis/team-pro/replay-api/pkg/domain/iam/ports/out"
+)
+
+// NewVisibilityPolicyQueryService reads the visibility policies of the client application (ie: the admin UI).
+func NewVisibilityPolicyQueryService(policyReader iam_out.VisibilityPolicyReader) iam_in.VisibilityPolicyReader {
+	queryableFields := map[string]bool{
+		"ID":            true,
+		"GroupID":       true,
+		"Visibility":    true,
+		"UpdatedBy":     true,
+		"ResourceOwner": common.DENY,
+		"CreatedAt":     true,
+		"UpdatedAt":     true,
+	}
+
+	readableFields := map[string]bool{
+		"ID":            true,
+		"GroupID":       true,
+		"Visibility":    true,
+		"UpdatedBy":     true,
+		"ResourceOwner": common.DENY,
+		"CreatedAt":     true,
+		"UpdatedAt":     true,
+	}
+
+	return &common.BaseQueryService[common.VisibilityPolicy]{
+		Reader:          policyReader.(common.Searchable[common.VisibilityPolicy]),
+		QueryableFields: queryableFields,
+		ReadableFields:  readableFields,
+		MaxPageSize:     100,
+		Audience:        common.ClientApplicationAudienceIDKey,
+	}
+}
+
+// NewVisibilityOverrideQueryService reads the audit of the entities created with other than the default visibility of their policy.
+func NewVisibilityOverrideQueryService(overrideReader iam_out.VisibilityOverrideReader) iam_in.VisibilityOverrideReader {
+	queryableFields := map[string]bool{
+		"ID":            true,
+		"ResourceType":  true,
+		"ResourceID":    true,
+		"PolicyID":      true,
+		"Default":       true,
+		"Requested":     true,
+		"RequestedBy":   true,
+		"ResourceOwner": common.DENY,
+		"CreatedAt":     true,
+	}
+
+	readableFields := map[string]bool{
+		"ID":            true,
+		"ResourceType":  true,
+		"ResourceID":    true,
+		"PolicyID":      true,
+		"Default":       true,
+		"Requested":     true,
+		"RequestedBy":   true,
+		"ResourceOwner": common.DENY,
+		"CreatedAt":     true,
+	}
+
+	return &common.BaseQueryService[common.VisibilityOverride]{
+		Reader:          overrideReader.(common.Searchable[common.VisibilityOverride]),
+		QueryableFields: queryableFields,
+		ReadableFields:  readableFields,
+		MaxPageSize:     100,
+		Audience:        common.ClientApplicationAudienceIDKey,
+	}
+}
@@ -0,0 +1,240 @@
+package iam_use_cases
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	"github.com/psavelis/team-pro/replay-api/pkg/domain/iam"
+	iam_entity "github.com/psavelis/team-pro/replay-api/pkg/domain/iam/entities"
+	iam_in "github.com/psavelis/team-pro/replay-api/pkg/domain/iam/ports/in"
+	iam_out "github.com/psavelis/team-pro/replay-api/pkg/domain/iam/ports/out"
+)
+
+// APIKeyLastUsedInterval throttles the writes of the last use of the keys (callers authenticate on every request).
+const APIKeyLastUsedInterval = time.Minute
+
+type IssueAPIKeyUseCase struct {
+	KeyWriter iam_out.APIKeyWriter
+	Authorize iam_in.AuthorizeQuery
+}
+
+func NewIssueAPIKeyUseCase(keyWriter iam_out.APIKeyWriter, authorize iam_in.AuthorizeQuery) iam_in.IssueAPIKeyCommandHandler {
+	return &IssueAPIKeyUseCase{
+		KeyWriter: keyWriter,
+		Authorize: authorize,
+	}
+}
+
+// Exec issues a key granting known permissions only, and only the ones the issuer holds (a key can't escalate its issuer). Keys of the
+// tiers above community also require the issuer to hold api_keys:tiers.
+func (usecase *IssueAPIKeyUseCase) Exec(ctx context.Context, cmd iam_in.IssueAPIKeyCommand) (*iam_entity.APIKey, error) {
+	name := strings.TrimSpace(cmd.Name)
+	if name == "" {
+		return nil, iam.NewInvalidAPIKeyError("api key name is required")
+	}
+
+	if len(cmd.Permissions) == 0 {
+		return nil, iam.NewInvalidAPIKeyError("api key must grant at least one permission")
+	}
+
+	for _, p := range cmd.Permissions {
+		if !p.Known() {
+			return nil, iam.NewInvalidAPIKeyError(fmt.Sprintf("unknown permission %s", p))
+		}
+	}
+
+	tier := cmd.Tier
+	if tier == "" {
+		tier = iam_entity.APIKeyTierCommunity
+	}
+
+	if !tier.Known() {
+		return nil, iam.NewInvalidAPIKeyError(fmt.Sprintf("unknown tier %s", tier))
+	}
+
+	now := time.Now()
+
+	if cmd.ExpiresAt != nil && !cmd.ExpiresAt.After(now) {
+		return nil, iam.NewInvalidAPIKeyError("api key expiration must be in the future")
+	}
+
+	required := cmd.Permissions
+	if tier != iam_entity.APIKeyTierCommunity {
+		// tiers above the default raise the rate limit of the key: only granted to whoever manages the agreements
+		required = append(append([]iam_entity.Permission{}, cmd.Permissions...), iam_entity.PermissionAPIKeysTiers)
+	}
+
+	err := usecase.Authorize.Exec(ctx, required...)
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := iam_entity.NewAPIKey(name, cmd.Permissions, tier, cmd.ExpiresAt, common.GetResourceOwner(ctx), now)
+	if err != nil {
+		slog.ErrorContext(ctx, "unable to generate api key", "name", name, "err", err)
+		return nil, err
+	}
+
+	plain := key.Key
+
+	key, err = usecase.KeyWriter.Create(ctx, key)
+	if err != nil {
+		slog.ErrorContext(ctx, "unable to create api key", "name", name, "err", err)
+		return nil, err
+	}
+
+	key.Key = plain
+
+	slog.InfoContext(ctx, "api key issued", "keyID", key.ID, "name", name, "permissions", key.Permissions, "tier", tier)
+
+	return key, nil
+}
+
+type RotateAPIKeyUseCase struct {
+	KeyWriter iam_out.APIKeyWriter
+	KeyReader iam_out.APIKeyReader
+}
+
+func NewRotateAPIKeyUseCase(keyWriter iam_out.APIKeyWriter, keyReader iam_out.APIKeyReader) iam_in.RotateAPIKeyCommandHandler {
+	return &RotateAPIKeyUseCase{
+		KeyWriter: keyWriter,
+		KeyReader: keyReader,
+	}
+}
+
+func (usecase *RotateAPIKeyUseCase) Exec(ctx context.Context, keyID uuid.UUID) (*iam_entity.APIKey, error) {
+	key, err := getAPIKey(ctx, usecase.KeyReader, keyID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+
+	if key == nil || !key.Active(now) {
+		return nil, iam.NewAPIKeyNotFoundError(keyID)
+	}
+
+	err = key.Rotate(now)
+	if err != nil {
+		slog.ErrorContext(ctx, "unable to generate api key", "keyID", keyID, "err", err)
+		return nil, err
+	}
+
+	plain := key.Key
+
+	key, err = usecase.KeyWriter.Update(ctx, key)
+	if err != nil {
+		slog.ErrorContext(ctx, "unable to rotate api key", "keyID", keyID, "err", err)
+		return nil, err
+	}
+
+	key.Key = plain
+
+	slog.InfoContext(ctx, "api key rotated", "keyID", keyID)
+
+	return key, nil
+}
+
+type RevokeAPIKeyUseCase struct {
+	KeyWriter iam_out.APIKeyWriter
+	KeyReader iam_out.APIKeyReader
+}
+
+func NewRevokeAPIKeyUseCase(keyWriter iam_out.APIKeyWriter, keyReader iam_out.APIKeyReader) iam_in.RevokeAPIKeyCommandHandler {
+	return &RevokeAPIKeyUseCase{
+		KeyWriter: keyWriter,
+		KeyReader: keyReader,
+	}
+}
+
+func (usecase *RevokeAPIKeyUseCase) Exec(ctx context.Context, keyID uuid.UUID) (*iam_entity.APIKey, error) {
+	key, err := getAPIKey(ctx, usecase.KeyReader, keyID)
+	if err != nil {
+		return nil, err
+	}
+
+	if key == nil {
+		return nil, iam.NewAPIKeyNotFoundError(keyID)
+	}
+
+	if key.RevokedAt != nil {
+		return key, nil
+	}
+
+	key.Revoke(time.Now())
+
+	key, err = usecase.KeyWriter.Update(ctx, key)
+	if err != nil {
+		slog.ErrorContext(ctx, "unable to revoke api key", "keyID", keyID, "err", err)
+		return nil, err
+	}
+
+	slog.InfoContext(ctx, "api key revoked", "keyID", keyID)
+
+	return key, nil
+}
+
+type AuthenticateAPIKeyUseCase struct {
+	KeyWriter iam_out.APIKeyWriter
+	KeyReader iam_out.APIKeyReader
+}
+
+func NewAuthenticateAPIKeyUseCase(keyWriter iam_out.APIKeyWriter, keyReader iam_out.APIKeyReader) iam_in.AuthenticateAPIKeyCommand {
+	return &AuthenticateAPIKeyUseCase{
+		KeyWriter: keyWriter,
+		KeyReader: keyReader,
+	}
+}
+
+func (usecase *AuthenticateAPIKeyUseCase) Exec(ctx context.Context, plain string) (*iam_entity.APIKey, error) {
+	keyID, ok := iam_entity.ParseAPIKeyID(plain)
+	if !ok {
+		return nil, iam.NewUnauthenticatedAPIKeyError()
+	}
+
+	key, err := getAPIKey(ctx, usecase.KeyReader, keyID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+
+	if key == nil || !key.Matches(plain) || !key.Active(now) {
+		slog.WarnContext(ctx, "api key rejected", "keyID", keyID)
+		return nil, iam.NewUnauthenticatedAPIKeyError()
+	}
+
+	if key.LastUsedAt == nil || now.Sub(*key.LastUsedAt) >= APIKeyLastUsedInterval {
+		key.LastUsedAt = &now
+
+		// best effort: the caller is authenticated either way
+		_, err = usecase.KeyWriter.Update(ctx, key)
+		if err != nil {
+			slog.WarnContext(ctx, "unable to record api key use", "keyID", keyID, "err", err)
+		}
+	}
+
+	return key, nil
+}
+
+func getAPIKey(ctx context.Context, reader iam_out.APIKeyReader, keyID uuid.UUID) (*iam_entity.APIKey, error) {
+	keys, err := reader.Search(ctx, common.NewSearchByValues(ctx, []common.SearchableValue{
+		{Field: "ID", Values: []interface{}{keyID}},
+	}, common.NewSearchResultOptions(0, 1), common.ClientApplicationAudienceIDKey))
+
+	if err != nil {
+		slog.ErrorContext(ctx, "unable to search api key", "keyID", keyID, "err", err)
+		return nil, err
+	}
+
+	if len(keys) == 0 {
+		return nil, nil
+	}
+
+	return &keys[0], nil
+}
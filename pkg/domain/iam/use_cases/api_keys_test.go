@@ -0,0 +1,229 @@
+package iam_use_cases_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	"github.com/psavelis/team-pro/replay-api/pkg/domain/iam"
+	iam_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/iam/entities"
+	iam_in "github.com/psavelis/team-pro/replay-api/pkg/domain/iam/ports/in"
+	iam_use_cases "github.com/psavelis/team-pro/replay-api/pkg/domain/iam/use_cases"
+	"github.com/stretchr/testify/assert"
+)
+
+type mockAPIKeyStore struct {
+	keys []iam_entities.APIKey
+}
+
+func (m *mockAPIKeyStore) Create(ctx context.Context, key *iam_entities.APIKey) (*iam_entities.APIKey, error) {
+	stored := *key
+	stored.Key = ""
+	m.keys = append(m.keys, stored)
+	return key, nil
+}
+
+func (m *mockAPIKeyStore) Update(ctx context.Context, key *iam_entities.APIKey) (*iam_entities.APIKey, error) {
+	for i := range m.keys {
+		if m.keys[i].ID == key.ID {
+			m.keys[i] = *key
+			m.keys[i].Key = ""
+		}
+	}
+
+	return key, nil
+}
+
+func (m *mockAPIKeyStore) Search(ctx context.Context, s common.Search) ([]iam_entities.APIKey, error) {
+	_, values := searched(s)
+
+	res := make([]iam_entities.APIKey, 0)
+	for _, key := range m.keys {
+		if contains(values, key.ID) {
+			res = append(res, key)
+		}
+	}
+
+	return res, nil
+}
+
+// keyContext is the context set by the APIKeyMiddleware for the key.
+func keyContext(key *iam_entities.APIKey) context.Context {
+	scope := common.RequestScope{ResourceOwner: key.ResourceOwner, APIKeyID: key.ID}
+	for _, p := range key.Permissions {
+		scope.APIKeyPermissions = append(scope.APIKeyPermissions, string(p))
+	}
+
+	return common.WithRequestScope(context.Background(), scope)
+}
+
+func TestAPIKeys_IssueAuthenticateRotateRevoke(t *testing.T) {
+	roles := &mockRoleStore{}
+	assignments := &mockRoleAssignmentStore{roles}
+	keys := &mockAPIKeyStore{}
+
+	adminID := uuid.New()
+	adminCtx := userContext(adminID)
+
+	authorize := iam_use_cases.NewAuthorizeUseCase(roles, assignments, []uuid.UUID{adminID})
+	issue := iam_use_cases.NewIssueAPIKeyUseCase(keys, authorize)
+	rotate := iam_use_cases.NewRotateAPIKeyUseCase(keys, keys)
+	revoke := iam_use_cases.NewRevokeAPIKeyUseCase(keys, keys)
+	authenticate := iam_use_cases.NewAuthenticateAPIKeyUseCase(keys, keys)
+
+	key, err := issue.Exec(adminCtx, iam_in.IssueAPIKeyCommand{Name: " CS2 server plugin ", Permissions: []iam_entities.Permission{"matchmaking:*"}})
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	assert.Equal(t, "CS2 server plugin", key.Name)
+	assert.True(t, strings.HasPrefix(key.Key, iam_entities.APIKeyPrefix))
+	assert.Equal(t, adminID, key.IssuedBy)
+	assert.Equal(t, iam_entities.APIKeyTierCommunity, key.Tier)
+	assert.Equal(t, common.ResourceOwner{TenantID: common.TeamPROTenantID, ClientID: common.TeamPROAppClientID}, key.ResourceOwner)
+
+	// only the hash is stored
+	assert.Empty(t, keys.keys[0].Key)
+	assert.Equal(t, iam_entities.HashAPIKey(key.Key), keys.keys[0].KeyHash)
+
+	authenticated, err := authenticate.Exec(adminCtx, key.Key)
+	if assert.NoError(t, err) {
+		assert.Equal(t, key.ID, authenticated.ID)
+		assert.NotNil(t, keys.keys[0].LastUsedAt)
+	}
+
+	var unauthenticatedErr *iam.UnauthenticatedAPIKeyError
+	_, err = authenticate.Exec(adminCtx, key.Key+"x")
+	assert.ErrorAs(t, err, &unauthenticatedErr)
+
+	_, err = authenticate.Exec(adminCtx, "tpk_garbage")
+	assert.ErrorAs(t, err, &unauthenticatedErr)
+
+	// the key acts with its own permissions
+	assert.NoError(t, authorize.Exec(keyContext(authenticated), iam_entities.PermissionMatchmakingManage))
+
+	var deniedErr *iam.PermissionDeniedError
+	assert.ErrorAs(t, authorize.Exec(keyContext(authenticated), iam_entities.PermissionAPIKeysManage), &deniedErr)
+
+	rotated, err := rotate.Exec(adminCtx, key.ID)
+	if assert.NoError(t, err) {
+		assert.NotEqual(t, key.Key, rotated.Key)
+		assert.NotNil(t, rotated.RotatedAt)
+	}
+
+	_, err = authenticate.Exec(adminCtx, key.Key)
+	assert.ErrorAs(t, err, &unauthenticatedErr)
+
+	_, err = authenticate.Exec(adminCtx, rotated.Key)
+	assert.NoError(t, err)
+
+	_, err = revoke.Exec(adminCtx, key.ID)
+	assert.NoError(t, err)
+
+	_, err = authenticate.Exec(adminCtx, rotated.Key)
+	assert.ErrorAs(t, err, &unauthenticatedErr)
+
+	var notFoundErr *iam.APIKeyNotFoundError
+	_, err = rotate.Exec(adminCtx, key.ID)
+	assert.ErrorAs(t, err, &notFoundErr)
+
+	_, err = revoke.Exec(adminCtx, uuid.New())
+	assert.ErrorAs(t, err, &notFoundErr)
+}
+
+func TestAPIKeys_InvalidKeys(t *testing.T) {
+	roles := &mockRoleStore{}
+	assignments := &mockRoleAssignmentStore{roles}
+	keys := &mockAPIKeyStore{}
+
+	adminID := uuid.New()
+	past := time.Now().Add(-time.Hour)
+
+	authorize := iam_use_cases.NewAuthorizeUseCase(roles, assignments, []uuid.UUID{adminID})
+	issue := iam_use_cases.NewIssueAPIKeyUseCase(keys, authorize)
+
+	tests := []struct {
+		name string
+		cmd  iam_in.IssueAPIKeyCommand
+	}{
+		{"missing name", iam_in.IssueAPIKeyCommand{Name: " ", Permissions: []iam_entities.Permission{iam_entities.PermissionMetaRead}}},
+		{"no permissions", iam_in.IssueAPIKeyCommand{Name: "bot"}},
+		{"unknown permission", iam_in.IssueAPIKeyCommand{Name: "bot", Permissions: []iam_entities.Permission{"wallet:drain"}}},
+		{"unknown tier", iam_in.IssueAPIKeyCommand{Name: "bot", Permissions: []iam_entities.Permission{iam_entities.PermissionPublicRead}, Tier: "platinum"}},
+		{"expired", iam_in.IssueAPIKeyCommand{Name: "bot", Permissions: []iam_entities.Permission{iam_entities.PermissionMetaRead}, ExpiresAt: &past}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var invalidErr *iam.InvalidAPIKeyError
+			_, err := issue.Exec(userContext(adminID), tt.cmd)
+			assert.ErrorAs(t, err, &invalidErr)
+		})
+	}
+
+	// issuers can't grant permissions they don't hold
+	var deniedErr *iam.PermissionDeniedError
+	_, err := issue.Exec(userContext(uuid.New()), iam_in.IssueAPIKeyCommand{Name: "bot", Permissions: []iam_entities.Permission{iam_entities.PermissionAll}})
+	assert.ErrorAs(t, err, &deniedErr)
+
+	assert.Empty(t, keys.keys)
+}
+
+func TestAPIKeys_IssueTierRequiresPermission(t *testing.T) {
+	roles := &mockRoleStore{}
+	assignments := &mockRoleAssignmentStore{roles}
+	keys := &mockAPIKeyStore{}
+
+	adminID := uuid.New()
+	userID := uuid.New()
+	adminCtx := userContext(adminID)
+	userCtx := userContext(userID)
+
+	authorize := iam_use_cases.NewAuthorizeUseCase(roles, assignments, []uuid.UUID{adminID})
+	issue := iam_use_cases.NewIssueAPIKeyUseCase(keys, authorize)
+
+	role, err := iam_use_cases.NewCreateRoleUseCase(roles).Exec(adminCtx, iam_in.CreateRoleCommand{Name: "Developer", Permissions: []iam_entities.Permission{iam_entities.PermissionAPIKeysManage, iam_entities.PermissionPublicRead}})
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	_, err = iam_use_cases.NewAssignRoleUseCase(roles, assignments, assignments).Exec(adminCtx, iam_in.RoleAssignmentCommand{RoleID: role.ID, UserID: userID})
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	cmd := iam_in.IssueAPIKeyCommand{Name: "stats site", Permissions: []iam_entities.Permission{iam_entities.PermissionPublicRead}}
+
+	key, err := issue.Exec(userCtx, cmd)
+	if assert.NoError(t, err) {
+		assert.Equal(t, iam_entities.APIKeyTierCommunity, key.Tier)
+	}
+
+	// holding the granted permissions isn't enough for a higher rate limit
+	cmd.Tier = iam_entities.APIKeyTierPartner
+
+	var deniedErr *iam.PermissionDeniedError
+	_, err = issue.Exec(userCtx, cmd)
+	if assert.ErrorAs(t, err, &deniedErr) {
+		assert.Equal(t, string(iam_entities.PermissionAPIKeysTiers), deniedErr.Permission)
+	}
+
+	assert.Len(t, keys.keys, 1)
+
+	key, err = issue.Exec(adminCtx, cmd)
+	if assert.NoError(t, err) {
+		assert.Equal(t, iam_entities.APIKeyTierPartner, key.Tier)
+		assert.Equal(t, []iam_entities.Permission{iam_entities.PermissionPublicRead}, key.Permissions)
+	}
+}
+
+func TestAPIKeyTier_RateLimit(t *testing.T) {
+	assert.Equal(t, 60, iam_entities.APIKeyTierCommunity.RateLimit())
+	assert.Equal(t, 600, iam_entities.APIKeyTierPartner.RateLimit())
+
+	// keys issued before the tiers are limited as community keys
+	assert.Equal(t, 60, iam_entities.APIKeyTier("").RateLimit())
+}
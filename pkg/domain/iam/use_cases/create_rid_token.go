@@ -13,20 +13,23 @@ import (
 )
 
 type CreateRIDTokenUseCase struct {
-	RIDWriter iam_out.RIDTokenWriter
-	RIDReader iam_out.RIDTokenReader
+	RIDWriter     iam_out.RIDTokenWriter
+	RIDReader     iam_out.RIDTokenReader
+	SessionWriter iam_out.RIDSessionWriter
 }
 
-func NewCreateRIDTokenUseCase(rIDWriter iam_out.RIDTokenWriter, rIDReader iam_out.RIDTokenReader) iam_in.CreateRIDTokenCommand {
+func NewCreateRIDTokenUseCase(rIDWriter iam_out.RIDTokenWriter, rIDReader iam_out.RIDTokenReader, sessionWriter iam_out.RIDSessionWriter) iam_in.CreateRIDTokenCommand {
 	return &CreateRIDTokenUseCase{
-		RIDWriter: rIDWriter,
-		RIDReader: rIDReader,
+		RIDWriter:     rIDWriter,
+		RIDReader:     rIDReader,
+		SessionWriter: sessionWriter,
 	}
 }
 
+// Exec issues a RID. End user RIDs start a session (on the device of the request) and carry its first refresh key.
 func (usecase *CreateRIDTokenUseCase) Exec(ctx context.Context, reso common.ResourceOwner, source iam_entity.RIDSourceKey, aud common.IntendedAudienceKey) (*iam_entity.RIDToken, error) {
-	duration, _ := time.ParseDuration("1h")
-	expiresAt := time.Now().Add(duration)
+	now := time.Now()
+	expiresAt := now.Add(iam_entity.RIDTokenTTL)
 
 	// TODO: verificar existencia, consistir usuario
 
@@ -38,7 +41,7 @@ func (usecase *CreateRIDTokenUseCase) Exec(ctx context.Context, reso common.Reso
 		grantType = "client_credentials"
 	}
 
-	token, err := usecase.RIDWriter.Create(ctx, &iam_entity.RIDToken{
+	token := &iam_entity.RIDToken{
 		ID:               uuid.New(),
 		Key:              uuid.New(),
 		Source:           source,
@@ -46,9 +49,29 @@ func (usecase *CreateRIDTokenUseCase) Exec(ctx context.Context, reso common.Reso
 		IntendedAudience: aud,
 		GrantType:        grantType,
 		ExpiresAt:        expiresAt,
-		CreatedAt:        time.Now(),
-	})
+		CreatedAt:        now,
+		UpdatedAt:        now,
+	}
+
+	if aud == common.UserAudienceIDKey {
+		scope, _ := common.GetRequestScope(ctx)
+
+		session, err := usecase.SessionWriter.Create(ctx, iam_entity.NewRIDSession(source, scope.UserAgent, scope.ClientIP, reso, now))
+		if err != nil {
+			slog.ErrorContext(ctx, "unable to create rid session", "err", err)
+			return nil, err
+		}
+
+		token.SessionID = session.ID
+
+		err = token.WithRefreshKey(now)
+		if err != nil {
+			slog.ErrorContext(ctx, "unable to create rid refresh key", "err", err)
+			return nil, err
+		}
+	}
 
+	token, err := usecase.RIDWriter.Create(ctx, token)
 	if err != nil {
 		slog.ErrorContext(ctx, "unable to create rid token", "err", err)
 		return nil, err
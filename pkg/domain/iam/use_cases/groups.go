@@ -0,0 +1,489 @@
+package iam_use_cases
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	"github.com/psavelis/team-pro/replay-api/pkg/domain/iam"
+	iam_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/iam/entities"
+	iam_in "github.com/psavelis/team-pro/replay-api/pkg/domain/iam/ports/in"
+	iam_out "github.com/psavelis/team-pro/replay-api/pkg/domain/iam/ports/out"
+)
+
+const (
+	// MaxSubgroups caps the subgroups listed under a group (the subgroups moved along with it are read in pages of this size).
+	MaxSubgroups = 500
+	// MaxGroupMembers caps the memberships read for a group and its subgroups.
+	MaxGroupMembers = 1000
+	// MaxUserMemberships caps the memberships read for a user.
+	MaxUserMemberships = 100
+)
+
+type CreateGroupUseCase struct {
+	GroupReader      iam_out.GroupReader
+	GroupWriter      iam_out.GroupWriter
+	MembershipReader iam_out.MembershipReader
+	MembershipWriter iam_out.MembershipWriter
+}
+
+func NewCreateGroupUseCase(groupReader iam_out.GroupReader, groupWriter iam_out.GroupWriter, membershipReader iam_out.MembershipReader, membershipWriter iam_out.MembershipWriter) iam_in.CreateGroupCommandHandler {
+	return &CreateGroupUseCase{
+		GroupReader:      groupReader,
+		GroupWriter:      groupWriter,
+		MembershipReader: membershipReader,
+		MembershipWriter: membershipWriter,
+	}
+}
+
+// Exec creates the group, under its parent when given, making the user in context its owner.
+func (usecase *CreateGroupUseCase) Exec(ctx context.Context, cmd iam_in.CreateGroupCommand) (*iam_entities.Group, error) {
+	name := strings.TrimSpace(cmd.Name)
+	if name == "" {
+		return nil, iam.NewInvalidGroupError("group name is required")
+	}
+
+	reso := common.GetResourceOwner(ctx)
+
+	group := iam_entities.NewGroup(uuid.New(), name, iam_entities.GroupTypeUser, common.ResourceOwner{TenantID: reso.TenantID, ClientID: reso.ClientID, UserID: reso.UserID})
+	group.Ancestors = []uuid.UUID{}
+
+	if cmd.ParentGroupID != nil {
+		parent, _, err := authorizeGroup(ctx, usecase.GroupReader, usecase.MembershipReader, *cmd.ParentGroupID, true)
+		if err != nil {
+			return nil, err
+		}
+
+		if parent.Depth() >= iam_entities.MaxGroupDepth {
+			return nil, iam.NewInvalidGroupError(fmt.Sprintf("groups can't be nested more than %d levels deep", iam_entities.MaxGroupDepth))
+		}
+
+		group.ParentGroupID = &parent.ID
+		group.Ancestors = parent.Path()
+	}
+
+	group, err := usecase.GroupWriter.Create(ctx, group)
+	if err != nil {
+		slog.ErrorContext(ctx, "unable to create group", "name", name, "err", err)
+		return nil, err
+	}
+
+	_, err = usecase.MembershipWriter.Create(ctx, &iam_entities.Membership{
+		ID:            iam_entities.MembershipID(group.ID, reso.UserID),
+		GroupID:       group.ID,
+		UserID:        reso.UserID,
+		Type:          iam_entities.MembershipTypeOwner,
+		ResourceOwner: common.ResourceOwner{TenantID: reso.TenantID, ClientID: reso.ClientID, GroupID: group.ID, UserID: reso.UserID},
+		CreatedAt:     group.CreatedAt,
+		UpdatedAt:     group.CreatedAt,
+	})
+
+	if err != nil {
+		slog.ErrorContext(ctx, "unable to create group owner membership", "groupID", group.ID, "err", err)
+		return nil, err
+	}
+
+	return group, nil
+}
+
+type SetGroupParentUseCase struct {
+	GroupReader      iam_out.GroupReader
+	GroupWriter      iam_out.GroupWriter
+	MembershipReader iam_out.MembershipReader
+}
+
+func NewSetGroupParentUseCase(groupReader iam_out.GroupReader, groupWriter iam_out.GroupWriter, membershipReader iam_out.MembershipReader) iam_in.SetGroupParentCommandHandler {
+	return &SetGroupParentUseCase{
+		GroupReader:      groupReader,
+		GroupWriter:      groupWriter,
+		MembershipReader: membershipReader,
+	}
+}
+
+// Exec moves the group under the new parent, rewriting the ancestors of its subgroups.
+func (usecase *SetGroupParentUseCase) Exec(ctx context.Context, cmd iam_in.SetGroupParentCommand) (*iam_entities.Group, error) {
+	group, _, err := authorizeGroup(ctx, usecase.GroupReader, usecase.MembershipReader, cmd.GroupID, true)
+	if err != nil {
+		return nil, err
+	}
+
+	ancestors := []uuid.UUID{}
+
+	if cmd.ParentGroupID != nil {
+		parent, _, err := authorizeGroup(ctx, usecase.GroupReader, usecase.MembershipReader, *cmd.ParentGroupID, true)
+		if err != nil {
+			return nil, err
+		}
+
+		if parent.ID == group.ID || parent.IsDescendantOf(group.ID) {
+			return nil, iam.NewInvalidGroupError("a group can't be nested under itself or one of its subgroups")
+		}
+
+		ancestors = parent.Path()
+	}
+
+	subgroups, err := getAllSubgroups(ctx, usecase.GroupReader, *group)
+	if err != nil {
+		return nil, err
+	}
+
+	// the deepest subgroup must still fit under the new parent
+	height := 1
+	for _, subgroup := range subgroups {
+		if levels := subgroup.Depth() - group.Depth() + 1; levels > height {
+			height = levels
+		}
+	}
+
+	if len(ancestors)+height > iam_entities.MaxGroupDepth {
+		return nil, iam.NewInvalidGroupError(fmt.Sprintf("groups can't be nested more than %d levels deep", iam_entities.MaxGroupDepth))
+	}
+
+	now := time.Now().UTC()
+	previousDepth := group.Depth()
+
+	group.ParentGroupID = cmd.ParentGroupID
+	group.Ancestors = ancestors
+	group.UpdatedAt = now
+
+	group, err = usecase.GroupWriter.Update(ctx, group)
+	if err != nil {
+		slog.ErrorContext(ctx, "unable to update group parent", "groupID", cmd.GroupID, "err", err)
+		return nil, err
+	}
+
+	for i := range subgroups {
+		subgroup := &subgroups[i]
+
+		// the ancestors of a subgroup start with the path of the group moved
+		subgroupAncestors := group.Path()
+		subgroupAncestors = append(subgroupAncestors, subgroup.Ancestors[previousDepth:]...)
+
+		subgroup.Ancestors = subgroupAncestors
+		subgroup.UpdatedAt = now
+
+		_, err = usecase.GroupWriter.Update(ctx, subgroup)
+		if err != nil {
+			slog.ErrorContext(ctx, "unable to update subgroup ancestors", "groupID", cmd.GroupID, "subgroupID", subgroup.ID, "err", err)
+			return nil, err
+		}
+	}
+
+	return group, nil
+}
+
+type SetGroupMemberUseCase struct {
+	GroupReader      iam_out.GroupReader
+	MembershipReader iam_out.MembershipReader
+	MembershipWriter iam_out.MembershipWriter
+}
+
+func NewSetGroupMemberUseCase(groupReader iam_out.GroupReader, membershipReader iam_out.MembershipReader, membershipWriter iam_out.MembershipWriter) iam_in.SetGroupMemberCommandHandler {
+	return &SetGroupMemberUseCase{
+		GroupReader:      groupReader,
+		MembershipReader: membershipReader,
+		MembershipWriter: membershipWriter,
+	}
+}
+
+// Exec adds the member, or replaces the type of its membership (a single membership per group and user).
+func (usecase *SetGroupMemberUseCase) Exec(ctx context.Context, cmd iam_in.SetGroupMemberCommand) (*iam_entities.Membership, error) {
+	if cmd.Type.Rank() == 0 {
+		return nil, iam.NewInvalidGroupError("unknown membership type '" + string(cmd.Type) + "'")
+	}
+
+	if cmd.UserID == uuid.Nil {
+		return nil, iam.NewInvalidGroupError("member user_id is required")
+	}
+
+	group, manager, err := authorizeGroup(ctx, usecase.GroupReader, usecase.MembershipReader, cmd.GroupID, true)
+	if err != nil {
+		return nil, err
+	}
+
+	memberships, err := usecase.MembershipReader.Search(ctx, common.NewSearchByValues(ctx, []common.SearchableValue{
+		{Field: "ID", Values: []interface{}{iam_entities.MembershipID(group.ID, cmd.UserID)}},
+	}, common.NewSearchResultOptions(0, 1), common.ClientApplicationAudienceIDKey))
+
+	if err != nil {
+		slog.ErrorContext(ctx, "unable to search membership", "groupID", group.ID, "userID", cmd.UserID, "err", err)
+		return nil, err
+	}
+
+	// only owners grant (or take) ownership
+	isOwnershipChange := cmd.Type == iam_entities.MembershipTypeOwner || (len(memberships) > 0 && memberships[0].Type == iam_entities.MembershipTypeOwner)
+	if isOwnershipChange && manager.Type != iam_entities.MembershipTypeOwner {
+		return nil, iam.NewGroupAccessDeniedError(group.ID)
+	}
+
+	now := time.Now().UTC()
+
+	if len(memberships) > 0 {
+		membership := &memberships[0]
+		membership.Type = cmd.Type
+		membership.UpdatedAt = now
+
+		membership, err = usecase.MembershipWriter.Update(ctx, membership)
+		if err != nil {
+			slog.ErrorContext(ctx, "unable to update membership", "membershipID", memberships[0].ID, "err", err)
+			return nil, err
+		}
+
+		return membership, nil
+	}
+
+	reso := common.GetResourceOwner(ctx)
+
+	membership, err := usecase.MembershipWriter.Create(ctx, &iam_entities.Membership{
+		ID:            iam_entities.MembershipID(group.ID, cmd.UserID),
+		GroupID:       group.ID,
+		UserID:        cmd.UserID,
+		Type:          cmd.Type,
+		ResourceOwner: common.ResourceOwner{TenantID: reso.TenantID, ClientID: reso.ClientID, GroupID: group.ID, UserID: cmd.UserID},
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	})
+
+	if err != nil {
+		slog.ErrorContext(ctx, "unable to create membership", "groupID", group.ID, "userID", cmd.UserID, "err", err)
+		return nil, err
+	}
+
+	return membership, nil
+}
+
+type GroupHierarchyUseCase struct {
+	GroupReader      iam_out.GroupReader
+	MembershipReader iam_out.MembershipReader
+}
+
+func NewGroupHierarchyUseCase(groupReader iam_out.GroupReader, membershipReader iam_out.MembershipReader) iam_in.GroupHierarchyReader {
+	return &GroupHierarchyUseCase{
+		GroupReader:      groupReader,
+		MembershipReader: membershipReader,
+	}
+}
+
+func (usecase *GroupHierarchyUseCase) Subgroups(ctx context.Context, groupID uuid.UUID) ([]iam_entities.Group, error) {
+	group, _, err := authorizeGroup(ctx, usecase.GroupReader, usecase.MembershipReader, groupID, false)
+	if err != nil {
+		return nil, err
+	}
+
+	return getSubgroups(ctx, usecase.GroupReader, *group)
+}
+
+func (usecase *GroupHierarchyUseCase) Members(ctx context.Context, groupID uuid.UUID) ([]iam_entities.Membership, error) {
+	group, _, err := authorizeGroup(ctx, usecase.GroupReader, usecase.MembershipReader, groupID, false)
+	if err != nil {
+		return nil, err
+	}
+
+	subgroups, err := getSubgroups(ctx, usecase.GroupReader, *group)
+	if err != nil {
+		return nil, err
+	}
+
+	groupIDs := []interface{}{group.ID}
+	for _, subgroup := range subgroups {
+		groupIDs = append(groupIDs, subgroup.ID)
+	}
+
+	memberships, err := usecase.MembershipReader.Search(ctx, common.NewSearchByValues(ctx, []common.SearchableValue{
+		{Field: "GroupID", Values: groupIDs},
+	}, common.NewSearchResultOptions(0, MaxGroupMembers), common.ClientApplicationAudienceIDKey))
+
+	if err != nil {
+		slog.ErrorContext(ctx, "unable to search group members", "groupID", groupID, "err", err)
+		return nil, err
+	}
+
+	return memberships, nil
+}
+
+func (usecase *GroupHierarchyUseCase) EffectiveMembership(ctx context.Context, groupID uuid.UUID, userID uuid.UUID) (*iam_entities.Membership, error) {
+	group, _, err := authorizeGroup(ctx, usecase.GroupReader, usecase.MembershipReader, groupID, false)
+	if err != nil {
+		return nil, err
+	}
+
+	return getEffectiveMembership(ctx, usecase.MembershipReader, *group, userID)
+}
+
+func (usecase *GroupHierarchyUseCase) UserGroups(ctx context.Context) ([]iam_entities.Group, error) {
+	reso := common.GetResourceOwner(ctx)
+
+	memberships, err := usecase.MembershipReader.Search(ctx, common.NewSearchByValues(ctx, []common.SearchableValue{
+		{Field: "UserID", Values: []interface{}{reso.UserID}},
+	}, common.NewSearchResultOptions(0, MaxUserMemberships), common.ClientApplicationAudienceIDKey))
+
+	if err != nil {
+		slog.ErrorContext(ctx, "unable to search user memberships", "userID", reso.UserID, "err", err)
+		return nil, err
+	}
+
+	if len(memberships) == 0 {
+		return []iam_entities.Group{}, nil
+	}
+
+	groupIDs := make([]interface{}, 0, len(memberships))
+	for _, m := range memberships {
+		groupIDs = append(groupIDs, m.GroupID)
+	}
+
+	// the groups of the memberships, and their subgroups
+	groups, err := usecase.GroupReader.Search(ctx, common.NewSearchByAggregation(ctx, []common.SearchAggregation{
+		{
+			Params: []common.SearchParameter{
+				{
+					ValueParams: []common.SearchableValue{
+						{Field: "ID", Values: groupIDs},
+						{Field: "Ancestors", Values: groupIDs},
+					},
+					AggregationClause: common.OrAggregationClause,
+				},
+			},
+		},
+	}, common.NewSearchResultOptions(0, MaxSubgroups), common.ClientApplicationAudienceIDKey))
+
+	if err != nil {
+		slog.ErrorContext(ctx, "unable to search user groups", "userID", reso.UserID, "err", err)
+		return nil, err
+	}
+
+	sortByDepth(groups)
+
+	return groups, nil
+}
+
+// authorizeGroup returns the group with the effective membership of the user in context, which must manage the group when required.
+func authorizeGroup(ctx context.Context, groupReader iam_out.GroupReader, membershipReader iam_out.MembershipReader, groupID uuid.UUID, manage bool) (*iam_entities.Group, *iam_entities.Membership, error) {
+	group, err := getGroup(ctx, groupReader, groupID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	membership, err := getEffectiveMembership(ctx, membershipReader, *group, common.GetResourceOwner(ctx).UserID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if membership == nil || (manage && !membership.Type.Manages()) {
+		return nil, nil, iam.NewGroupAccessDeniedError(groupID)
+	}
+
+	return group, membership, nil
+}
+
+func getGroup(ctx context.Context, groupReader iam_out.GroupReader, groupID uuid.UUID) (*iam_entities.Group, error) {
+	groups, err := groupReader.Search(ctx, common.NewSearchByValues(ctx, []common.SearchableValue{
+		{Field: "ID", Values: []interface{}{groupID}},
+	}, common.NewSearchResultOptions(0, 1), common.ClientApplicationAudienceIDKey))
+
+	if err != nil {
+		slog.ErrorContext(ctx, "unable to search group", "groupID", groupID, "err", err)
+		return nil, err
+	}
+
+	if len(groups) == 0 {
+		return nil, iam.NewGroupNotFoundError(groupID)
+	}
+
+	return &groups[0], nil
+}
+
+// getSubgroups returns the groups nested (at any level) under the group, parents first.
+func getSubgroups(ctx context.Context, groupReader iam_out.GroupReader, group iam_entities.Group) ([]iam_entities.Group, error) {
+	subgroups, err := groupReader.Search(ctx, common.NewSearchByValues(ctx, []common.SearchableValue{
+		{Field: "Ancestors", Values: []interface{}{group.ID}},
+	}, common.NewSearchResultOptions(0, MaxSubgroups), common.ClientApplicationAudienceIDKey))
+
+	if err != nil {
+		slog.ErrorContext(ctx, "unable to search subgroups", "groupID", group.ID, "err", err)
+		return nil, err
+	}
+
+	sortByDepth(subgroups)
+
+	return subgroups, nil
+}
+
+// getAllSubgroups returns every group nested (at any level) under the group, read in pages of MaxSubgroups, parents first.
+func getAllSubgroups(ctx context.Context, groupReader iam_out.GroupReader, group iam_entities.Group) ([]iam_entities.Group, error) {
+	subgroups := make([]iam_entities.Group, 0)
+
+	for skip := uint(0); ; skip += MaxSubgroups {
+		s := common.NewSearchByValues(ctx, []common.SearchableValue{
+			{Field: "Ancestors", Values: []interface{}{group.ID}},
+		}, common.NewSearchResultOptions(skip, MaxSubgroups), common.ClientApplicationAudienceIDKey)
+		s.SortOptions = []common.SearchSortOption{{Field: "ID", Direction: common.AscendingIDKey}}
+
+		page, err := groupReader.Search(ctx, s)
+		if err != nil {
+			slog.ErrorContext(ctx, "unable to search subgroups", "groupID", group.ID, "skip", skip, "err", err)
+			return nil, err
+		}
+
+		subgroups = append(subgroups, page...)
+
+		if len(page) < MaxSubgroups {
+			break
+		}
+	}
+
+	sortByDepth(subgroups)
+
+	return subgroups, nil
+}
+
+// getEffectiveMembership returns the strongest membership of the user in the group or in any of its parents (the creator of a group
+// owns it), nil when there's none.
+func getEffectiveMembership(ctx context.Context, membershipReader iam_out.MembershipReader, group iam_entities.Group, userID uuid.UUID) (*iam_entities.Membership, error) {
+	var effective *iam_entities.Membership
+
+	if userID != uuid.Nil && group.ResourceOwner.UserID == userID {
+		effective = &iam_entities.Membership{
+			ID:            iam_entities.MembershipID(group.ID, userID),
+			GroupID:       group.ID,
+			UserID:        userID,
+			Type:          iam_entities.MembershipTypeOwner,
+			ResourceOwner: group.ResourceOwner,
+			CreatedAt:     group.CreatedAt,
+			UpdatedAt:     group.UpdatedAt,
+		}
+	}
+
+	path := make([]interface{}, 0, group.Depth())
+	for _, id := range group.Path() {
+		path = append(path, id)
+	}
+
+	memberships, err := membershipReader.Search(ctx, common.NewSearchByValues(ctx, []common.SearchableValue{
+		{Field: "UserID", Values: []interface{}{userID}},
+		{Field: "GroupID", Values: path},
+	}, common.NewSearchResultOptions(0, uint(len(path))), common.ClientApplicationAudienceIDKey))
+
+	if err != nil {
+		slog.ErrorContext(ctx, "unable to search memberships", "groupID", group.ID, "userID", userID, "err", err)
+		return nil, err
+	}
+
+	for i := range memberships {
+		if effective == nil || memberships[i].Type.Rank() > effective.Type.Rank() {
+			effective = &memberships[i]
+		}
+	}
+
+	return effective, nil
+}
+
+func sortByDepth(groups []iam_entities.Group) {
+	sort.SliceStable(groups, func(i, j int) bool {
+		return groups[i].Depth() < groups[j].Depth()
+	})
+}
@@ -0,0 +1,352 @@
+package iam_use_cases_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	"github.com/psavelis/team-pro/replay-api/pkg/domain/iam"
+	iam_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/iam/entities"
+	iam_in "github.com/psavelis/team-pro/replay-api/pkg/domain/iam/ports/in"
+	iam_use_cases "github.com/psavelis/team-pro/replay-api/pkg/domain/iam/use_cases"
+	"github.com/stretchr/testify/assert"
+)
+
+// matchesIDs evaluates the uuid filters of the searches built by the group use cases (and their or-groups).
+func matchesIDs(s common.Search, field func(name string) []uuid.UUID) bool {
+	for _, param := range s.SearchParams[0].Params {
+		or := param.AggregationClause == common.OrAggregationClause
+		matches := !or
+
+		for _, v := range param.ValueParams {
+			found := false
+			for _, id := range field(v.Field) {
+				found = found || contains(v.Values, id)
+			}
+
+			if or {
+				matches = matches || found
+			} else {
+				matches = matches && found
+			}
+		}
+
+		if !matches {
+			return false
+		}
+	}
+
+	return true
+}
+
+type mockGroupStore struct {
+	groups      []iam_entities.Group
+	memberships []iam_entities.Membership
+}
+
+func (m *mockGroupStore) CreateMany(ctx context.Context, groups []*iam_entities.Group) error {
+	for _, g := range groups {
+		m.groups = append(m.groups, *g)
+	}
+
+	return nil
+}
+
+func (m *mockGroupStore) Create(ctx context.Context, group *iam_entities.Group) (*iam_entities.Group, error) {
+	m.groups = append(m.groups, *group)
+	return group, nil
+}
+
+func (m *mockGroupStore) Update(ctx context.Context, group *iam_entities.Group) (*iam_entities.Group, error) {
+	for i := range m.groups {
+		if m.groups[i].ID == group.ID {
+			m.groups[i] = *group
+		}
+	}
+
+	return group, nil
+}
+
+func (m *mockGroupStore) Search(ctx context.Context, s common.Search) ([]iam_entities.Group, error) {
+	res := make([]iam_entities.Group, 0)
+
+	for _, g := range m.groups {
+		matches := matchesIDs(s, func(name string) []uuid.UUID {
+			switch name {
+			case "ID":
+				return []uuid.UUID{g.ID}
+			case "Ancestors":
+				return g.Ancestors
+			}
+
+			return nil
+		})
+
+		if matches {
+			res = append(res, g)
+		}
+	}
+
+	// paged (in insertion order)
+	skip := int(s.ResultOptions.Skip)
+	if skip > len(res) {
+		skip = len(res)
+	}
+
+	res = res[skip:]
+
+	if limit := int(s.ResultOptions.Limit); limit > 0 && limit < len(res) {
+		res = res[:limit]
+	}
+
+	return res, nil
+}
+
+type mockMembershipStore struct {
+	store *mockGroupStore
+}
+
+func (m *mockMembershipStore) Create(ctx context.Context, membership *iam_entities.Membership) (*iam_entities.Membership, error) {
+	m.store.memberships = append(m.store.memberships, *membership)
+	return membership, nil
+}
+
+func (m *mockMembershipStore) Update(ctx context.Context, membership *iam_entities.Membership) (*iam_entities.Membership, error) {
+	for i := range m.store.memberships {
+		if m.store.memberships[i].ID == membership.ID {
+			m.store.memberships[i] = *membership
+		}
+	}
+
+	return membership, nil
+}
+
+func (m *mockMembershipStore) Search(ctx context.Context, s common.Search) ([]iam_entities.Membership, error) {
+	res := make([]iam_entities.Membership, 0)
+
+	for _, membership := range m.store.memberships {
+		matches := matchesIDs(s, func(name string) []uuid.UUID {
+			switch name {
+			case "ID":
+				return []uuid.UUID{membership.ID}
+			case "GroupID":
+				return []uuid.UUID{membership.GroupID}
+			case "UserID":
+				return []uuid.UUID{membership.UserID}
+			}
+
+			return nil
+		})
+
+		if matches {
+			res = append(res, membership)
+		}
+	}
+
+	return res, nil
+}
+
+type groupUseCases struct {
+	store     *mockGroupStore
+	create    iam_in.CreateGroupCommandHandler
+	setParent iam_in.SetGroupParentCommandHandler
+	setMember iam_in.SetGroupMemberCommandHandler
+	hierarchy iam_in.GroupHierarchyReader
+}
+
+func newGroupUseCases() groupUseCases {
+	store := &mockGroupStore{}
+	memberships := &mockMembershipStore{store}
+
+	return groupUseCases{
+		store:     store,
+		create:    iam_use_cases.NewCreateGroupUseCase(store, store, memberships, memberships),
+		setParent: iam_use_cases.NewSetGroupParentUseCase(store, store, memberships),
+		setMember: iam_use_cases.NewSetGroupMemberUseCase(store, memberships, memberships),
+		hierarchy: iam_use_cases.NewGroupHierarchyUseCase(store, memberships),
+	}
+}
+
+func (u groupUseCases) group(id uuid.UUID) iam_entities.Group {
+	for _, g := range u.store.groups {
+		if g.ID == id {
+			return g
+		}
+	}
+
+	return iam_entities.Group{}
+}
+
+func TestGroups_HierarchyInheritsMemberships(t *testing.T) {
+	u := newGroupUseCases()
+
+	ownerID := uuid.New()
+	coachID := uuid.New()
+	playerID := uuid.New()
+	ownerCtx := userContext(ownerID)
+
+	org, err := u.create.Exec(ownerCtx, iam_in.CreateGroupCommand{Name: "Org"})
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	academy, err := u.create.Exec(ownerCtx, iam_in.CreateGroupCommand{Name: "Academy", ParentGroupID: &org.ID})
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	roster, err := u.create.Exec(ownerCtx, iam_in.CreateGroupCommand{Name: "Academy CS2", ParentGroupID: &academy.ID})
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	assert.Equal(t, []uuid.UUID{org.ID, academy.ID}, roster.Ancestors)
+
+	_, err = u.setMember.Exec(ownerCtx, iam_in.SetGroupMemberCommand{GroupID: org.ID, UserID: coachID, Type: iam_entities.MembershipTypeAdmin})
+	assert.NoError(t, err)
+
+	_, err = u.setMember.Exec(ownerCtx, iam_in.SetGroupMemberCommand{GroupID: roster.ID, UserID: playerID, Type: iam_entities.MembershipTypeMember})
+	assert.NoError(t, err)
+
+	// the admin of the org manages its rosters
+	membership, err := u.hierarchy.EffectiveMembership(ownerCtx, roster.ID, coachID)
+	if assert.NoError(t, err) && assert.NotNil(t, membership) {
+		assert.Equal(t, iam_entities.MembershipTypeAdmin, membership.Type)
+		assert.Equal(t, org.ID, membership.GroupID)
+	}
+
+	_, err = u.setMember.Exec(userContext(coachID), iam_in.SetGroupMemberCommand{GroupID: roster.ID, UserID: uuid.New(), Type: iam_entities.MembershipTypeMember})
+	assert.NoError(t, err)
+
+	// but only owners grant ownership
+	var deniedErr *iam.GroupAccessDeniedError
+	_, err = u.setMember.Exec(userContext(coachID), iam_in.SetGroupMemberCommand{GroupID: roster.ID, UserID: playerID, Type: iam_entities.MembershipTypeOwner})
+	assert.ErrorAs(t, err, &deniedErr)
+
+	// members of a roster don't see its parents
+	_, err = u.hierarchy.Subgroups(userContext(playerID), org.ID)
+	assert.ErrorAs(t, err, &deniedErr)
+
+	subgroups, err := u.hierarchy.Subgroups(userContext(coachID), org.ID)
+	if assert.NoError(t, err) && assert.Len(t, subgroups, 2) {
+		assert.Equal(t, academy.ID, subgroups[0].ID)
+		assert.Equal(t, roster.ID, subgroups[1].ID)
+	}
+
+	members, err := u.hierarchy.Members(ownerCtx, org.ID)
+	if assert.NoError(t, err) {
+		// the owner of each group, the coach, the player and the member added by the coach
+		assert.Len(t, members, 6)
+	}
+
+	members, err = u.hierarchy.Members(ownerCtx, roster.ID)
+	if assert.NoError(t, err) {
+		assert.Len(t, members, 3)
+	}
+
+	groups, err := u.hierarchy.UserGroups(userContext(coachID))
+	if assert.NoError(t, err) {
+		assert.Len(t, groups, 3)
+	}
+
+	groups, err = u.hierarchy.UserGroups(userContext(playerID))
+	if assert.NoError(t, err) && assert.Len(t, groups, 1) {
+		assert.Equal(t, roster.ID, groups[0].ID)
+	}
+}
+
+func TestGroups_SetParentMovesSubgroups(t *testing.T) {
+	u := newGroupUseCases()
+
+	ownerCtx := userContext(uuid.New())
+
+	org, _ := u.create.Exec(ownerCtx, iam_in.CreateGroupCommand{Name: "Org"})
+	na, _ := u.create.Exec(ownerCtx, iam_in.CreateGroupCommand{Name: "NA", ParentGroupID: &org.ID})
+	team, _ := u.create.Exec(ownerCtx, iam_in.CreateGroupCommand{Name: "Team"})
+	roster, _ := u.create.Exec(ownerCtx, iam_in.CreateGroupCommand{Name: "Roster", ParentGroupID: &team.ID})
+
+	moved, err := u.setParent.Exec(ownerCtx, iam_in.SetGroupParentCommand{GroupID: team.ID, ParentGroupID: &na.ID})
+	if assert.NoError(t, err) {
+		assert.Equal(t, &na.ID, moved.ParentGroupID)
+		assert.Equal(t, []uuid.UUID{org.ID, na.ID}, moved.Ancestors)
+	}
+
+	assert.Equal(t, []uuid.UUID{org.ID, na.ID, team.ID}, u.group(roster.ID).Ancestors)
+
+	var invalidErr *iam.InvalidGroupError
+
+	// no cycles
+	_, err = u.setParent.Exec(ownerCtx, iam_in.SetGroupParentCommand{GroupID: org.ID, ParentGroupID: &roster.ID})
+	assert.ErrorAs(t, err, &invalidErr)
+
+	_, err = u.setParent.Exec(ownerCtx, iam_in.SetGroupParentCommand{GroupID: team.ID, ParentGroupID: &team.ID})
+	assert.ErrorAs(t, err, &invalidErr)
+
+	// org → NA → team → roster is as deep as it gets
+	_, err = u.create.Exec(ownerCtx, iam_in.CreateGroupCommand{Name: "Too deep", ParentGroupID: &roster.ID})
+	assert.ErrorAs(t, err, &invalidErr)
+
+	other, _ := u.create.Exec(ownerCtx, iam_in.CreateGroupCommand{Name: "Other"})
+	_, err = u.setParent.Exec(ownerCtx, iam_in.SetGroupParentCommand{GroupID: other.ID, ParentGroupID: &team.ID})
+	assert.NoError(t, err)
+
+	_, err = u.setParent.Exec(ownerCtx, iam_in.SetGroupParentCommand{GroupID: na.ID, ParentGroupID: &other.ID})
+	assert.ErrorAs(t, err, &invalidErr)
+
+	// detaching makes it a root group again
+	detached, err := u.setParent.Exec(ownerCtx, iam_in.SetGroupParentCommand{GroupID: team.ID})
+	if assert.NoError(t, err) {
+		assert.Nil(t, detached.ParentGroupID)
+		assert.Empty(t, detached.Ancestors)
+	}
+
+	assert.Equal(t, []uuid.UUID{team.ID}, u.group(roster.ID).Ancestors)
+
+	// moving a group requires managing the new parent too
+	var deniedErr *iam.GroupAccessDeniedError
+	strangerCtx := userContext(uuid.New())
+	foreign, _ := u.create.Exec(strangerCtx, iam_in.CreateGroupCommand{Name: "Foreign"})
+
+	_, err = u.setParent.Exec(ownerCtx, iam_in.SetGroupParentCommand{GroupID: team.ID, ParentGroupID: &foreign.ID})
+	assert.ErrorAs(t, err, &deniedErr)
+
+	var notFoundErr *iam.GroupNotFoundError
+	missingID := uuid.New()
+	_, err = u.setParent.Exec(ownerCtx, iam_in.SetGroupParentCommand{GroupID: missingID})
+	assert.ErrorAs(t, err, &notFoundErr)
+}
+
+func TestGroups_SetParentMovesAllSubgroups(t *testing.T) {
+	u := newGroupUseCases()
+
+	ownerCtx := userContext(uuid.New())
+
+	org, _ := u.create.Exec(ownerCtx, iam_in.CreateGroupCommand{Name: "Org"})
+	team, _ := u.create.Exec(ownerCtx, iam_in.CreateGroupCommand{Name: "Team"})
+
+	// more subgroups than a page
+	for i := 0; i <= iam_use_cases.MaxSubgroups; i++ {
+		u.store.groups = append(u.store.groups, iam_entities.Group{
+			ID:            uuid.New(),
+			ParentGroupID: &team.ID,
+			Ancestors:     []uuid.UUID{team.ID},
+			ResourceOwner: team.ResourceOwner,
+		})
+	}
+
+	_, err := u.setParent.Exec(ownerCtx, iam_in.SetGroupParentCommand{GroupID: team.ID, ParentGroupID: &org.ID})
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	moved := 0
+	for _, g := range u.store.groups {
+		if g.ParentGroupID != nil && *g.ParentGroupID == team.ID {
+			assert.Equal(t, []uuid.UUID{org.ID, team.ID}, g.Ancestors)
+			moved++
+		}
+	}
+
+	assert.Equal(t, iam_use_cases.MaxSubgroups+1, moved)
+}
@@ -0,0 +1,163 @@
+package iam_use_cases
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	"github.com/psavelis/team-pro/replay-api/pkg/domain/iam"
+	iam_entity "github.com/psavelis/team-pro/replay-api/pkg/domain/iam/entities"
+	iam_in "github.com/psavelis/team-pro/replay-api/pkg/domain/iam/ports/in"
+	iam_out "github.com/psavelis/team-pro/replay-api/pkg/domain/iam/ports/out"
+)
+
+type RefreshRIDTokenUseCase struct {
+	RIDWriter     iam_out.RIDTokenWriter
+	RIDReader     iam_out.RIDTokenReader
+	SessionWriter iam_out.RIDSessionWriter
+	SessionReader iam_out.RIDSessionReader
+}
+
+func NewRefreshRIDTokenUseCase(rIDWriter iam_out.RIDTokenWriter, rIDReader iam_out.RIDTokenReader, sessionWriter iam_out.RIDSessionWriter, sessionReader iam_out.RIDSessionReader) iam_in.RefreshRIDTokenCommand {
+	return &RefreshRIDTokenUseCase{
+		RIDWriter:     rIDWriter,
+		RIDReader:     rIDReader,
+		SessionWriter: sessionWriter,
+		SessionReader: sessionReader,
+	}
+}
+
+// Exec rotates the RID of the refresh key into a new one (with the next refresh key) of the same session. A refresh key is single
+// use: using a rotated one again means it leaked (the legitimate client already has the next one), so the session is revoked.
+func (usecase *RefreshRIDTokenUseCase) Exec(ctx context.Context, refreshKey string) (*iam_entity.RIDToken, error) {
+	if refreshKey == "" {
+		return nil, iam.NewInvalidRefreshKeyError()
+	}
+
+	tokens, err := usecase.RIDReader.Search(ctx, common.NewSearchByValues(ctx, []common.SearchableValue{
+		{Field: "RefreshKeyHash", Values: []interface{}{iam_entity.HashRefreshKey(refreshKey)}},
+	}, common.NewSearchResultOptions(0, 1), common.ClientApplicationAudienceIDKey))
+
+	if err != nil {
+		slog.ErrorContext(ctx, "unable to search rid token by refresh key", "err", err)
+		return nil, err
+	}
+
+	if len(tokens) == 0 {
+		return nil, iam.NewInvalidRefreshKeyError()
+	}
+
+	token := tokens[0]
+	now := time.Now()
+
+	if token.RotatedAt != nil && token.RevokedAt == nil {
+		return nil, usecase.revokeReused(ctx, token, now)
+	}
+
+	if !token.Refreshable(now) {
+		return nil, iam.NewInvalidRefreshKeyError()
+	}
+
+	session, err := getRIDSession(ctx, usecase.SessionReader, token.SessionID, common.ClientApplicationAudienceIDKey)
+	if err != nil {
+		return nil, err
+	}
+
+	if session == nil || !session.Active(now) {
+		return nil, iam.NewInvalidRefreshKeyError()
+	}
+
+	rotated, err := usecase.RIDWriter.Rotate(ctx, token.ID, now)
+	if err != nil {
+		slog.ErrorContext(ctx, "unable to rotate rid token", "tokenID", token.ID, "err", err)
+		return nil, err
+	}
+
+	// a concurrent refresh rotated it first
+	if !rotated {
+		return nil, usecase.revokeReused(ctx, token, now)
+	}
+
+	next := &iam_entity.RIDToken{
+		ID:               uuid.New(),
+		Key:              uuid.New(),
+		Source:           token.Source,
+		ResourceOwner:    token.ResourceOwner,
+		IntendedAudience: token.IntendedAudience,
+		GrantType:        "refresh_token",
+		SessionID:        token.SessionID,
+		ExpiresAt:        now.Add(iam_entity.RIDTokenTTL),
+		CreatedAt:        now,
+		UpdatedAt:        now,
+	}
+
+	err = next.WithRefreshKey(now)
+	if err != nil {
+		slog.ErrorContext(ctx, "unable to create rid refresh key", "err", err)
+		return nil, err
+	}
+
+	next, err = usecase.RIDWriter.Create(ctx, next)
+	if err != nil {
+		slog.ErrorContext(ctx, "unable to create refreshed rid token", "sessionID", token.SessionID, "err", err)
+		return nil, err
+	}
+
+	scope, _ := common.GetRequestScope(ctx)
+	session.Refreshed(scope.ClientIP, now)
+
+	_, err = usecase.SessionWriter.Update(ctx, session)
+	if err != nil {
+		slog.WarnContext(ctx, "unable to update refreshed rid session", "sessionID", session.ID, "err", err)
+	}
+
+	return next, nil
+}
+
+// revokeReused revokes the session of a reused refresh key, returning the RefreshKeyReusedError.
+func (usecase *RefreshRIDTokenUseCase) revokeReused(ctx context.Context, token iam_entity.RIDToken, now time.Time) error {
+	slog.WarnContext(ctx, "rid refresh key reused: revoking its session", "tokenID", token.ID, "sessionID", token.SessionID, "userID", token.ResourceOwner.UserID)
+
+	err := usecase.RIDWriter.RevokeSession(ctx, token.SessionID, now)
+	if err != nil {
+		slog.ErrorContext(ctx, "unable to revoke rid tokens of reused refresh key", "sessionID", token.SessionID, "err", err)
+		return err
+	}
+
+	session, err := getRIDSession(ctx, usecase.SessionReader, token.SessionID, common.ClientApplicationAudienceIDKey)
+	if err != nil {
+		return err
+	}
+
+	if session != nil && session.RevokedAt == nil {
+		session.Revoke(iam_entity.RIDSessionRefreshKeyReused, now)
+
+		_, err = usecase.SessionWriter.Update(ctx, session)
+		if err != nil {
+			slog.ErrorContext(ctx, "unable to revoke rid session of reused refresh key", "sessionID", token.SessionID, "err", err)
+			return err
+		}
+	}
+
+	return iam.NewRefreshKeyReusedError(token.SessionID)
+}
+
+// getRIDSession returns the session, nil when it's not visible to the audience.
+func getRIDSession(ctx context.Context, reader iam_out.RIDSessionReader, sessionID uuid.UUID, aud common.IntendedAudienceKey) (*iam_entity.RIDSession, error) {
+	sessions, err := reader.Search(ctx, common.NewSearchByValues(ctx, []common.SearchableValue{
+		{Field: "ID", Values: []interface{}{sessionID}},
+	}, common.NewSearchResultOptions(0, 1), aud))
+
+	if err != nil {
+		slog.ErrorContext(ctx, "unable to search rid session", "sessionID", sessionID, "err", err)
+		return nil, err
+	}
+
+	if len(sessions) == 0 {
+		return nil, nil
+	}
+
+	return &sessions[0], nil
+}
@@ -0,0 +1,258 @@
+package iam_use_cases_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	"github.com/psavelis/team-pro/replay-api/pkg/domain/iam"
+	iam_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/iam/entities"
+	iam_use_cases "github.com/psavelis/team-pro/replay-api/pkg/domain/iam/use_cases"
+	"github.com/stretchr/testify/assert"
+)
+
+func userContext(userID uuid.UUID) context.Context {
+	ctx := common.WithRequestScope(context.Background(), common.RequestScope{UserAgent: "TeamPRO/1.0 (Android)", ClientIP: "203.0.113.7"})
+
+	return common.WithResourceOwner(ctx, common.ResourceOwner{TenantID: common.TeamPROTenantID, ClientID: common.TeamPROAppClientID, UserID: userID, GroupID: uuid.New()})
+}
+
+// mockRIDStore filters by the fields searched by the use cases.
+type mockRIDStore struct {
+	tokens   []iam_entities.RIDToken
+	sessions []iam_entities.RIDSession
+}
+
+func (m *mockRIDStore) Search(ctx context.Context, s common.Search) ([]iam_entities.RIDToken, error) {
+	res := make([]iam_entities.RIDToken, 0)
+
+	for _, t := range m.tokens {
+		if t.RefreshKeyHash == s.SearchParams[0].Params[0].ValueParams[0].Values[0] {
+			res = append(res, t)
+		}
+	}
+
+	return res, nil
+}
+
+func (m *mockRIDStore) Create(ctx context.Context, rid *iam_entities.RIDToken) (*iam_entities.RIDToken, error) {
+	m.tokens = append(m.tokens, *rid)
+	return rid, nil
+}
+
+func (m *mockRIDStore) Rotate(ctx context.Context, id uuid.UUID, at time.Time) (bool, error) {
+	for i := range m.tokens {
+		if m.tokens[i].ID == id && m.tokens[i].RotatedAt == nil {
+			m.tokens[i].RotatedAt = &at
+			m.tokens[i].ExpiresAt = at
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+func (m *mockRIDStore) RevokeSession(ctx context.Context, sessionID uuid.UUID, at time.Time) error {
+	for i := range m.tokens {
+		if m.tokens[i].SessionID == sessionID && m.tokens[i].RevokedAt == nil {
+			m.tokens[i].RevokedAt = &at
+			m.tokens[i].ExpiresAt = at
+		}
+	}
+
+	return nil
+}
+
+func (m *mockRIDStore) token(id uuid.UUID) iam_entities.RIDToken {
+	for _, t := range m.tokens {
+		if t.ID == id {
+			return t
+		}
+	}
+
+	return iam_entities.RIDToken{}
+}
+
+type mockRIDSessionStore struct {
+	store *mockRIDStore
+}
+
+func (m *mockRIDSessionStore) Search(ctx context.Context, s common.Search) ([]iam_entities.RIDSession, error) {
+	res := make([]iam_entities.RIDSession, 0)
+
+	for _, session := range m.store.sessions {
+		if s.VisibilityOptions.IntendedAudience == common.UserAudienceIDKey && session.ResourceOwner.UserID != s.VisibilityOptions.RequestSource.UserID {
+			continue
+		}
+
+		params := s.SearchParams[0].Params[0]
+		if len(params.ValueParams) > 0 && session.ID != params.ValueParams[0].Values[0] {
+			continue
+		}
+
+		res = append(res, session)
+	}
+
+	return res, nil
+}
+
+func (m *mockRIDSessionStore) Create(ctx context.Context, session *iam_entities.RIDSession) (*iam_entities.RIDSession, error) {
+	m.store.sessions = append(m.store.sessions, *session)
+	return session, nil
+}
+
+func (m *mockRIDSessionStore) Update(ctx context.Context, session *iam_entities.RIDSession) (*iam_entities.RIDSession, error) {
+	for i := range m.store.sessions {
+		if m.store.sessions[i].ID == session.ID {
+			m.store.sessions[i] = *session
+		}
+	}
+
+	return session, nil
+}
+
+func TestRIDSession_RefreshRotatesTheRID(t *testing.T) {
+	store := &mockRIDStore{}
+	sessions := &mockRIDSessionStore{store}
+
+	userID := uuid.New()
+	ctx := userContext(userID)
+	reso := common.GetResourceOwner(ctx)
+
+	create := iam_use_cases.NewCreateRIDTokenUseCase(store, store, sessions)
+	refresh := iam_use_cases.NewRefreshRIDTokenUseCase(store, store, sessions, sessions)
+
+	token, err := create.Exec(ctx, reso, iam_entities.RIDSource_Steam, common.UserAudienceIDKey)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	assert.NotEmpty(t, token.RefreshKey)
+	assert.Equal(t, iam_entities.HashRefreshKey(token.RefreshKey), token.RefreshKeyHash)
+
+	if assert.Len(t, store.sessions, 1) {
+		assert.Equal(t, token.SessionID, store.sessions[0].ID)
+		assert.Equal(t, "TeamPRO/1.0 (Android)", store.sessions[0].UserAgent)
+		assert.Equal(t, "203.0.113.7", store.sessions[0].IPAddress)
+	}
+
+	next, err := refresh.Exec(ctx, token.RefreshKey)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	assert.NotEqual(t, token.ID, next.ID)
+	assert.NotEqual(t, token.RefreshKey, next.RefreshKey)
+	assert.Equal(t, token.SessionID, next.SessionID)
+	assert.Equal(t, reso, next.ResourceOwner)
+	assert.NotNil(t, store.token(token.ID).RotatedAt, "the refreshed RID is rotated")
+
+	// the next refresh key is usable in turn
+	_, err = refresh.Exec(ctx, next.RefreshKey)
+	assert.NoError(t, err)
+}
+
+func TestRIDSession_RefreshKeyReuseRevokesTheSession(t *testing.T) {
+	store := &mockRIDStore{}
+	sessions := &mockRIDSessionStore{store}
+
+	ctx := userContext(uuid.New())
+
+	create := iam_use_cases.NewCreateRIDTokenUseCase(store, store, sessions)
+	refresh := iam_use_cases.NewRefreshRIDTokenUseCase(store, store, sessions, sessions)
+
+	token, _ := create.Exec(ctx, common.GetResourceOwner(ctx), iam_entities.RIDSource_Google, common.UserAudienceIDKey)
+	next, _ := refresh.Exec(ctx, token.RefreshKey)
+
+	_, err := refresh.Exec(ctx, token.RefreshKey)
+
+	var reusedErr *iam.RefreshKeyReusedError
+	if assert.ErrorAs(t, err, &reusedErr) {
+		assert.Equal(t, token.SessionID, reusedErr.SessionID)
+	}
+
+	assert.Equal(t, iam_entities.RIDSessionRefreshKeyReused, store.sessions[0].RevokedReason)
+	assert.NotNil(t, store.token(next.ID).RevokedAt, "the RIDs of the session are revoked")
+
+	// the legitimate client has to sign in again
+	_, err = refresh.Exec(ctx, next.RefreshKey)
+
+	var invalidErr *iam.InvalidRefreshKeyError
+	assert.ErrorAs(t, err, &invalidErr)
+}
+
+func TestRIDSession_UnknownRefreshKey(t *testing.T) {
+	store := &mockRIDStore{}
+	sessions := &mockRIDSessionStore{store}
+
+	refresh := iam_use_cases.NewRefreshRIDTokenUseCase(store, store, sessions, sessions)
+
+	var invalidErr *iam.InvalidRefreshKeyError
+
+	_, err := refresh.Exec(userContext(uuid.New()), "unknown")
+	assert.ErrorAs(t, err, &invalidErr)
+
+	_, err = refresh.Exec(userContext(uuid.New()), "")
+	assert.ErrorAs(t, err, &invalidErr)
+}
+
+func TestRIDSession_ClientApplicationRIDsHaveNoSession(t *testing.T) {
+	store := &mockRIDStore{}
+	sessions := &mockRIDSessionStore{store}
+
+	ctx := userContext(uuid.New())
+
+	token, err := iam_use_cases.NewCreateRIDTokenUseCase(store, store, sessions).Exec(ctx, common.GetResourceOwner(ctx), iam_entities.RIDSource_Steam, common.ClientApplicationAudienceIDKey)
+
+	assert.NoError(t, err)
+	assert.Empty(t, token.RefreshKey)
+	assert.Equal(t, uuid.Nil, token.SessionID)
+	assert.Empty(t, store.sessions)
+}
+
+func TestRIDSession_ListAndRevoke(t *testing.T) {
+	store := &mockRIDStore{}
+	sessions := &mockRIDSessionStore{store}
+
+	userID := uuid.New()
+	ctx := userContext(userID)
+	otherCtx := userContext(uuid.New())
+
+	create := iam_use_cases.NewCreateRIDTokenUseCase(store, store, sessions)
+	refresh := iam_use_cases.NewRefreshRIDTokenUseCase(store, store, sessions, sessions)
+	list := iam_use_cases.NewListRIDSessionsUseCase(sessions)
+	revoke := iam_use_cases.NewRevokeRIDSessionUseCase(store, sessions, sessions)
+
+	phone, _ := create.Exec(ctx, common.GetResourceOwner(ctx), iam_entities.RIDSource_Steam, common.UserAudienceIDKey)
+	_, _ = create.Exec(ctx, common.GetResourceOwner(ctx), iam_entities.RIDSource_Google, common.UserAudienceIDKey)
+	_, _ = create.Exec(otherCtx, common.GetResourceOwner(otherCtx), iam_entities.RIDSource_Steam, common.UserAudienceIDKey)
+
+	active, err := list.Exec(ctx)
+	assert.NoError(t, err)
+	assert.Len(t, active, 2)
+
+	// sessions of other users aren't found
+	_, err = revoke.Exec(otherCtx, phone.SessionID)
+
+	var notFoundErr *iam.RIDSessionNotFoundError
+	assert.ErrorAs(t, err, &notFoundErr)
+
+	revoked, err := revoke.Exec(ctx, phone.SessionID)
+	if assert.NoError(t, err) {
+		assert.Equal(t, iam_entities.RIDSessionRevokedByUser, revoked.RevokedReason)
+	}
+
+	assert.NotNil(t, store.token(phone.ID).RevokedAt)
+
+	active, _ = list.Exec(ctx)
+	if assert.Len(t, active, 1) {
+		assert.Equal(t, iam_entities.RIDSource_Google, active[0].Source)
+	}
+
+	var invalidErr *iam.InvalidRefreshKeyError
+
+	_, err = refresh.Exec(ctx, phone.RefreshKey)
+	assert.ErrorAs(t, err, &invalidErr)
+}
@@ -0,0 +1,108 @@
+package iam_use_cases
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	"github.com/psavelis/team-pro/replay-api/pkg/domain/iam"
+	iam_entity "github.com/psavelis/team-pro/replay-api/pkg/domain/iam/entities"
+	iam_in "github.com/psavelis/team-pro/replay-api/pkg/domain/iam/ports/in"
+	iam_out "github.com/psavelis/team-pro/replay-api/pkg/domain/iam/ports/out"
+)
+
+// MaxListedRIDSessions caps the sessions listed to a user.
+const MaxListedRIDSessions = 100
+
+type ListRIDSessionsUseCase struct {
+	SessionReader iam_out.RIDSessionReader
+}
+
+func NewListRIDSessionsUseCase(sessionReader iam_out.RIDSessionReader) iam_in.ListRIDSessionsQuery {
+	return &ListRIDSessionsUseCase{
+		SessionReader: sessionReader,
+	}
+}
+
+func (usecase *ListRIDSessionsUseCase) Exec(ctx context.Context) ([]iam_entity.RIDSession, error) {
+	now := time.Now()
+
+	s := common.NewSearchByAggregation(ctx, []common.SearchAggregation{
+		{
+			Params: []common.SearchParameter{
+				{
+					DateParams: []common.SearchableDateRange{
+						{Field: "ExpiresAt", Min: &now},
+					},
+				},
+			},
+		},
+	}, common.NewSearchResultOptions(0, MaxListedRIDSessions), common.UserAudienceIDKey)
+
+	s.SortOptions = []common.SearchSortOption{{Field: "LastUsedAt", Direction: common.DescendingIDKey}}
+
+	sessions, err := usecase.SessionReader.Search(ctx, s)
+	if err != nil {
+		slog.ErrorContext(ctx, "unable to list rid sessions", "err", err)
+		return nil, err
+	}
+
+	active := make([]iam_entity.RIDSession, 0, len(sessions))
+	for _, session := range sessions {
+		if session.Active(now) {
+			active = append(active, session)
+		}
+	}
+
+	return active, nil
+}
+
+type RevokeRIDSessionUseCase struct {
+	RIDWriter     iam_out.RIDTokenWriter
+	SessionWriter iam_out.RIDSessionWriter
+	SessionReader iam_out.RIDSessionReader
+}
+
+func NewRevokeRIDSessionUseCase(rIDWriter iam_out.RIDTokenWriter, sessionWriter iam_out.RIDSessionWriter, sessionReader iam_out.RIDSessionReader) iam_in.RevokeRIDSessionCommand {
+	return &RevokeRIDSessionUseCase{
+		RIDWriter:     rIDWriter,
+		SessionWriter: sessionWriter,
+		SessionReader: sessionReader,
+	}
+}
+
+// Exec revokes a session of the user in context (revoking it again is a no-op).
+func (usecase *RevokeRIDSessionUseCase) Exec(ctx context.Context, sessionID uuid.UUID) (*iam_entity.RIDSession, error) {
+	session, err := getRIDSession(ctx, usecase.SessionReader, sessionID, common.UserAudienceIDKey)
+	if err != nil {
+		return nil, err
+	}
+
+	if session == nil {
+		return nil, iam.NewRIDSessionNotFoundError(sessionID)
+	}
+
+	if session.RevokedAt != nil {
+		return session, nil
+	}
+
+	now := time.Now()
+
+	err = usecase.RIDWriter.RevokeSession(ctx, session.ID, now)
+	if err != nil {
+		slog.ErrorContext(ctx, "unable to revoke rid tokens of session", "sessionID", session.ID, "err", err)
+		return nil, err
+	}
+
+	session.Revoke(iam_entity.RIDSessionRevokedByUser, now)
+
+	session, err = usecase.SessionWriter.Update(ctx, session)
+	if err != nil {
+		slog.ErrorContext(ctx, "unable to revoke rid session", "sessionID", sessionID, "err", err)
+		return nil, err
+	}
+
+	return session, nil
+}
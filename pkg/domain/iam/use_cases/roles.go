@@ -0,0 +1,312 @@
+package iam_use_cases
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	"github.com/psavelis/team-pro/replay-api/pkg/domain/iam"
+	iam_entity "github.com/psavelis/team-pro/replay-api/pkg/domain/iam/entities"
+	iam_in "github.com/psavelis/team-pro/replay-api/pkg/domain/iam/ports/in"
+	iam_out "github.com/psavelis/team-pro/replay-api/pkg/domain/iam/ports/out"
+)
+
+// MaxUserRoles caps the role assignments read to authorize a user.
+const MaxUserRoles = 100
+
+type CreateRoleUseCase struct {
+	RoleWriter iam_out.RoleWriter
+}
+
+func NewCreateRoleUseCase(roleWriter iam_out.RoleWriter) iam_in.CreateRoleCommandHandler {
+	return &CreateRoleUseCase{
+		RoleWriter: roleWriter,
+	}
+}
+
+// Exec creates a role of the tenant in context, granting known permissions only.
+func (usecase *CreateRoleUseCase) Exec(ctx context.Context, cmd iam_in.CreateRoleCommand) (*iam_entity.Role, error) {
+	name := strings.TrimSpace(cmd.Name)
+	if name == "" {
+		return nil, iam.NewInvalidRoleError("role name is required")
+	}
+
+	if len(cmd.Permissions) == 0 {
+		return nil, iam.NewInvalidRoleError("role must grant at least one permission")
+	}
+
+	for _, p := range cmd.Permissions {
+		if !p.Known() {
+			return nil, iam.NewInvalidRoleError(fmt.Sprintf("unknown permission %s", p))
+		}
+	}
+
+	reso := common.GetResourceOwner(ctx)
+	now := time.Now()
+
+	role := &iam_entity.Role{
+		ID:            uuid.New(),
+		Name:          name,
+		Description:   cmd.Description,
+		Permissions:   cmd.Permissions,
+		ResourceOwner: common.ResourceOwner{TenantID: reso.TenantID, ClientID: reso.ClientID},
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	}
+
+	role, err := usecase.RoleWriter.Create(ctx, role)
+	if err != nil {
+		slog.ErrorContext(ctx, "unable to create role", "name", name, "err", err)
+		return nil, err
+	}
+
+	return role, nil
+}
+
+type AssignRoleUseCase struct {
+	RoleReader       iam_out.RoleReader
+	AssignmentWriter iam_out.RoleAssignmentWriter
+	AssignmentReader iam_out.RoleAssignmentReader
+}
+
+func NewAssignRoleUseCase(roleReader iam_out.RoleReader, assignmentWriter iam_out.RoleAssignmentWriter, assignmentReader iam_out.RoleAssignmentReader) iam_in.AssignRoleCommandHandler {
+	return &AssignRoleUseCase{
+		RoleReader:       roleReader,
+		AssignmentWriter: assignmentWriter,
+		AssignmentReader: assignmentReader,
+	}
+}
+
+func (usecase *AssignRoleUseCase) Exec(ctx context.Context, cmd iam_in.RoleAssignmentCommand) (*iam_entity.RoleAssignment, error) {
+	role, err := getRole(ctx, usecase.RoleReader, cmd.RoleID)
+	if err != nil {
+		return nil, err
+	}
+
+	if role == nil {
+		return nil, iam.NewRoleNotFoundError(cmd.RoleID)
+	}
+
+	assignment, err := getRoleAssignment(ctx, usecase.AssignmentReader, iam_entity.RoleAssignmentID(cmd.RoleID, cmd.UserID))
+	if err != nil {
+		return nil, err
+	}
+
+	reso := common.GetResourceOwner(ctx)
+	now := time.Now()
+
+	if assignment == nil {
+		assignment = &iam_entity.RoleAssignment{
+			ID:            iam_entity.RoleAssignmentID(cmd.RoleID, cmd.UserID),
+			RoleID:        cmd.RoleID,
+			UserID:        cmd.UserID,
+			AssignedBy:    reso.UserID,
+			ResourceOwner: common.ResourceOwner{TenantID: reso.TenantID, ClientID: reso.ClientID, UserID: cmd.UserID},
+			CreatedAt:     now,
+			UpdatedAt:     now,
+		}
+
+		assignment, err = usecase.AssignmentWriter.Create(ctx, assignment)
+		if err != nil {
+			slog.ErrorContext(ctx, "unable to assign role", "roleID", cmd.RoleID, "userID", cmd.UserID, "err", err)
+			return nil, err
+		}
+
+		return assignment, nil
+	}
+
+	if assignment.RevokedAt == nil {
+		return assignment, nil
+	}
+
+	assignment.RevokedAt = nil
+	assignment.AssignedBy = reso.UserID
+	assignment.UpdatedAt = now
+
+	assignment, err = usecase.AssignmentWriter.Update(ctx, assignment)
+	if err != nil {
+		slog.ErrorContext(ctx, "unable to reassign role", "roleID", cmd.RoleID, "userID", cmd.UserID, "err", err)
+		return nil, err
+	}
+
+	return assignment, nil
+}
+
+type UnassignRoleUseCase struct {
+	AssignmentWriter iam_out.RoleAssignmentWriter
+	AssignmentReader iam_out.RoleAssignmentReader
+}
+
+func NewUnassignRoleUseCase(assignmentWriter iam_out.RoleAssignmentWriter, assignmentReader iam_out.RoleAssignmentReader) iam_in.UnassignRoleCommandHandler {
+	return &UnassignRoleUseCase{
+		AssignmentWriter: assignmentWriter,
+		AssignmentReader: assignmentReader,
+	}
+}
+
+// Exec revokes the role from the user (revoking it again is a no-op).
+func (usecase *UnassignRoleUseCase) Exec(ctx context.Context, cmd iam_in.RoleAssignmentCommand) (*iam_entity.RoleAssignment, error) {
+	assignment, err := getRoleAssignment(ctx, usecase.AssignmentReader, iam_entity.RoleAssignmentID(cmd.RoleID, cmd.UserID))
+	if err != nil {
+		return nil, err
+	}
+
+	if assignment == nil {
+		return nil, iam.NewRoleNotFoundError(cmd.RoleID)
+	}
+
+	if assignment.RevokedAt != nil {
+		return assignment, nil
+	}
+
+	now := time.Now()
+
+	assignment.RevokedAt = &now
+	assignment.UpdatedAt = now
+
+	assignment, err = usecase.AssignmentWriter.Update(ctx, assignment)
+	if err != nil {
+		slog.ErrorContext(ctx, "unable to unassign role", "roleID", cmd.RoleID, "userID", cmd.UserID, "err", err)
+		return nil, err
+	}
+
+	return assignment, nil
+}
+
+type AuthorizeUseCase struct {
+	RoleReader        iam_out.RoleReader
+	AssignmentReader  iam_out.RoleAssignmentReader
+	SuperAdminUserIDs []uuid.UUID
+}
+
+func NewAuthorizeUseCase(roleReader iam_out.RoleReader, assignmentReader iam_out.RoleAssignmentReader, superAdminUserIDs []uuid.UUID) iam_in.AuthorizeQuery {
+	return &AuthorizeUseCase{
+		RoleReader:        roleReader,
+		AssignmentReader:  assignmentReader,
+		SuperAdminUserIDs: superAdminUserIDs,
+	}
+}
+
+func (usecase *AuthorizeUseCase) Exec(ctx context.Context, required ...iam_entity.Permission) error {
+	if len(required) == 0 {
+		return nil
+	}
+
+	// callers authenticated by an API key hold the permissions of the key
+	if scope, _ := common.GetRequestScope(ctx); scope.APIKeyID != uuid.Nil {
+		key := iam_entity.APIKey{ID: scope.APIKeyID, Permissions: make([]iam_entity.Permission, 0, len(scope.APIKeyPermissions))}
+		for _, p := range scope.APIKeyPermissions {
+			key.Permissions = append(key.Permissions, iam_entity.Permission(p))
+		}
+
+		for _, p := range required {
+			if !key.Grants(p) {
+				return iam.NewPermissionDeniedError(string(p))
+			}
+		}
+
+		return nil
+	}
+
+	// anonymous requests have a random user, with no roles
+	reso := common.GetResourceOwner(ctx)
+
+	for _, id := range usecase.SuperAdminUserIDs {
+		if id == reso.UserID {
+			return nil
+		}
+	}
+
+	roles, err := usecase.userRoles(ctx, reso.UserID)
+	if err != nil {
+		return err
+	}
+
+	for _, p := range required {
+		granted := false
+		for _, role := range roles {
+			if role.Grants(p) {
+				granted = true
+				break
+			}
+		}
+
+		if !granted {
+			return iam.NewPermissionDeniedError(string(p))
+		}
+	}
+
+	return nil
+}
+
+// userRoles returns the roles of the tenant currently assigned to the user.
+func (usecase *AuthorizeUseCase) userRoles(ctx context.Context, userID uuid.UUID) ([]iam_entity.Role, error) {
+	assignments, err := usecase.AssignmentReader.Search(ctx, common.NewSearchByValues(ctx, []common.SearchableValue{
+		{Field: "UserID", Values: []interface{}{userID}},
+	}, common.NewSearchResultOptions(0, MaxUserRoles), common.ClientApplicationAudienceIDKey))
+
+	if err != nil {
+		slog.ErrorContext(ctx, "unable to search role assignments", "userID", userID, "err", err)
+		return nil, err
+	}
+
+	roleIDs := make([]interface{}, 0, len(assignments))
+	for _, a := range assignments {
+		if a.RevokedAt == nil {
+			roleIDs = append(roleIDs, a.RoleID)
+		}
+	}
+
+	if len(roleIDs) == 0 {
+		return []iam_entity.Role{}, nil
+	}
+
+	roles, err := usecase.RoleReader.Search(ctx, common.NewSearchByValues(ctx, []common.SearchableValue{
+		{Field: "ID", Values: roleIDs},
+	}, common.NewSearchResultOptions(0, MaxUserRoles), common.ClientApplicationAudienceIDKey))
+
+	if err != nil {
+		slog.ErrorContext(ctx, "unable to search roles", "userID", userID, "err", err)
+		return nil, err
+	}
+
+	return roles, nil
+}
+
+func getRole(ctx context.Context, reader iam_out.RoleReader, roleID uuid.UUID) (*iam_entity.Role, error) {
+	roles, err := reader.Search(ctx, common.NewSearchByValues(ctx, []common.SearchableValue{
+		{Field: "ID", Values: []interface{}{roleID}},
+	}, common.NewSearchResultOptions(0, 1), common.ClientApplicationAudienceIDKey))
+
+	if err != nil {
+		slog.ErrorContext(ctx, "unable to search role", "roleID", roleID, "err", err)
+		return nil, err
+	}
+
+	if len(roles) == 0 {
+		return nil, nil
+	}
+
+	return &roles[0], nil
+}
+
+func getRoleAssignment(ctx context.Context, reader iam_out.RoleAssignmentReader, assignmentID uuid.UUID) (*iam_entity.RoleAssignment, error) {
+	assignments, err := reader.Search(ctx, common.NewSearchByValues(ctx, []common.SearchableValue{
+		{Field: "ID", Values: []interface{}{assignmentID}},
+	}, common.NewSearchResultOptions(0, 1), common.ClientApplicationAudienceIDKey))
+
+	if err != nil {
+		slog.ErrorContext(ctx, "unable to search role assignment", "assignmentID", assignmentID, "err", err)
+		return nil, err
+	}
+
+	if len(assignments) == 0 {
+		return nil, nil
+	}
+
+	return &assignments[0], nil
+}
@@ -0,0 +1,186 @@
+package iam_use_cases_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	"github.com/psavelis/team-pro/replay-api/pkg/domain/iam"
+	iam_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/iam/entities"
+	iam_in "github.com/psavelis/team-pro/replay-api/pkg/domain/iam/ports/in"
+	iam_use_cases "github.com/psavelis/team-pro/replay-api/pkg/domain/iam/use_cases"
+	"github.com/stretchr/testify/assert"
+)
+
+// mockRoleStore filters roles and assignments by the first field searched by the use cases.
+type mockRoleStore struct {
+	roles       []iam_entities.Role
+	assignments []iam_entities.RoleAssignment
+}
+
+func searched(s common.Search) (string, []interface{}) {
+	value := s.SearchParams[0].Params[0].ValueParams[0]
+	return value.Field, value.Values
+}
+
+func contains(values []interface{}, id uuid.UUID) bool {
+	for _, v := range values {
+		if v == id {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (m *mockRoleStore) Create(ctx context.Context, role *iam_entities.Role) (*iam_entities.Role, error) {
+	m.roles = append(m.roles, *role)
+	return role, nil
+}
+
+func (m *mockRoleStore) Search(ctx context.Context, s common.Search) ([]iam_entities.Role, error) {
+	_, values := searched(s)
+
+	res := make([]iam_entities.Role, 0)
+	for _, role := range m.roles {
+		if contains(values, role.ID) {
+			res = append(res, role)
+		}
+	}
+
+	return res, nil
+}
+
+type mockRoleAssignmentStore struct {
+	store *mockRoleStore
+}
+
+func (m *mockRoleAssignmentStore) Create(ctx context.Context, assignment *iam_entities.RoleAssignment) (*iam_entities.RoleAssignment, error) {
+	m.store.assignments = append(m.store.assignments, *assignment)
+	return assignment, nil
+}
+
+func (m *mockRoleAssignmentStore) Update(ctx context.Context, assignment *iam_entities.RoleAssignment) (*iam_entities.RoleAssignment, error) {
+	for i := range m.store.assignments {
+		if m.store.assignments[i].ID == assignment.ID {
+			m.store.assignments[i] = *assignment
+		}
+	}
+
+	return assignment, nil
+}
+
+func (m *mockRoleAssignmentStore) Search(ctx context.Context, s common.Search) ([]iam_entities.RoleAssignment, error) {
+	field, values := searched(s)
+
+	res := make([]iam_entities.RoleAssignment, 0)
+	for _, a := range m.store.assignments {
+		if (field == "ID" && contains(values, a.ID)) || (field == "UserID" && contains(values, a.UserID)) {
+			res = append(res, a)
+		}
+	}
+
+	return res, nil
+}
+
+func TestPermission_Grants(t *testing.T) {
+	tests := []struct {
+		held     iam_entities.Permission
+		required iam_entities.Permission
+		expected bool
+	}{
+		{iam_entities.PermissionWalletAdmin, iam_entities.PermissionWalletAdmin, true},
+		{iam_entities.PermissionAll, iam_entities.PermissionTournamentManage, true},
+		{"matchmaking:*", iam_entities.PermissionMatchmakingManage, true},
+		{"matchmaking:*", iam_entities.PermissionMetaRead, false},
+		{iam_entities.PermissionAnalyticsRead, iam_entities.PermissionQualityRead, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.held)+" "+string(tt.required), func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.held.Grants(tt.required))
+		})
+	}
+
+	assert.True(t, iam_entities.Permission("wallet:*").Known())
+	assert.False(t, iam_entities.Permission("wallet:drain").Known())
+}
+
+func TestRoles_AssignedPermissionsAreAuthorized(t *testing.T) {
+	store := &mockRoleStore{}
+	assignments := &mockRoleAssignmentStore{store}
+
+	adminID := uuid.New()
+	userID := uuid.New()
+	adminCtx := userContext(adminID)
+	userCtx := userContext(userID)
+
+	createRole := iam_use_cases.NewCreateRoleUseCase(store)
+	assign := iam_use_cases.NewAssignRoleUseCase(store, assignments, assignments)
+	unassign := iam_use_cases.NewUnassignRoleUseCase(assignments, assignments)
+	authorize := iam_use_cases.NewAuthorizeUseCase(store, assignments, []uuid.UUID{adminID})
+
+	// super admins bootstrap the roles
+	assert.NoError(t, authorize.Exec(adminCtx, iam_entities.PermissionRolesManage))
+
+	var deniedErr *iam.PermissionDeniedError
+	if assert.ErrorAs(t, authorize.Exec(userCtx, iam_entities.PermissionTournamentManage), &deniedErr) {
+		assert.Equal(t, string(iam_entities.PermissionTournamentManage), deniedErr.Permission)
+	}
+
+	role, err := createRole.Exec(adminCtx, iam_in.CreateRoleCommand{Name: "Organizer", Permissions: []iam_entities.Permission{iam_entities.PermissionTournamentManage, "matchmaking:*"}})
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	cmd := iam_in.RoleAssignmentCommand{RoleID: role.ID, UserID: userID}
+
+	assignment, err := assign.Exec(adminCtx, cmd)
+	if assert.NoError(t, err) {
+		assert.Equal(t, adminID, assignment.AssignedBy)
+	}
+
+	// assigning it again is a no-op
+	_, err = assign.Exec(adminCtx, cmd)
+	assert.NoError(t, err)
+	assert.Len(t, store.assignments, 1)
+
+	assert.NoError(t, authorize.Exec(userCtx, iam_entities.PermissionTournamentManage, iam_entities.PermissionMatchmakingManage))
+	assert.ErrorAs(t, authorize.Exec(userCtx, iam_entities.PermissionTournamentManage, iam_entities.PermissionWalletAdmin), &deniedErr)
+
+	_, err = unassign.Exec(adminCtx, cmd)
+	assert.NoError(t, err)
+	assert.ErrorAs(t, authorize.Exec(userCtx, iam_entities.PermissionTournamentManage), &deniedErr)
+
+	// reassigning a revoked role grants it again
+	_, err = assign.Exec(adminCtx, cmd)
+	assert.NoError(t, err)
+	assert.NoError(t, authorize.Exec(userCtx, iam_entities.PermissionTournamentManage))
+}
+
+func TestRoles_InvalidRoles(t *testing.T) {
+	store := &mockRoleStore{}
+	assignments := &mockRoleAssignmentStore{store}
+	ctx := userContext(uuid.New())
+
+	createRole := iam_use_cases.NewCreateRoleUseCase(store)
+
+	var invalidErr *iam.InvalidRoleError
+
+	_, err := createRole.Exec(ctx, iam_in.CreateRoleCommand{Name: " ", Permissions: []iam_entities.Permission{iam_entities.PermissionMetaRead}})
+	assert.ErrorAs(t, err, &invalidErr)
+
+	_, err = createRole.Exec(ctx, iam_in.CreateRoleCommand{Name: "Empty"})
+	assert.ErrorAs(t, err, &invalidErr)
+
+	_, err = createRole.Exec(ctx, iam_in.CreateRoleCommand{Name: "Unknown", Permissions: []iam_entities.Permission{"wallet:drain"}})
+	assert.ErrorAs(t, err, &invalidErr)
+
+	assert.Empty(t, store.roles)
+
+	var notFoundErr *iam.RoleNotFoundError
+
+	_, err = iam_use_cases.NewAssignRoleUseCase(store, assignments, assignments).Exec(ctx, iam_in.RoleAssignmentCommand{RoleID: uuid.New(), UserID: uuid.New()})
+	assert.ErrorAs(t, err, &notFoundErr)
+}
@@ -0,0 +1,76 @@
+package iam_use_cases
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	"github.com/psavelis/team-pro/replay-api/pkg/domain/iam"
+	iam_in "github.com/psavelis/team-pro/replay-api/pkg/domain/iam/ports/in"
+	iam_out "github.com/psavelis/team-pro/replay-api/pkg/domain/iam/ports/out"
+)
+
+type SetVisibilityPolicyUseCase struct {
+	PolicyReader iam_out.VisibilityPolicyReader
+	PolicyWriter iam_out.VisibilityPolicyWriter
+}
+
+func NewSetVisibilityPolicyUseCase(policyReader iam_out.VisibilityPolicyReader, policyWriter iam_out.VisibilityPolicyWriter) iam_in.SetVisibilityPolicyCommandHandler {
+	return &SetVisibilityPolicyUseCase{
+		PolicyReader: policyReader,
+		PolicyWriter: policyWriter,
+	}
+}
+
+// Exec replaces the policy of the tenant (or group) in context, a single policy per tenant and group.
+func (usecase *SetVisibilityPolicyUseCase) Exec(ctx context.Context, cmd iam_in.SetVisibilityPolicyCommand) (*common.VisibilityPolicy, error) {
+	if !cmd.Visibility.Known() {
+		return nil, iam.NewInvalidVisibilityPolicyError("unknown visibility '" + string(cmd.Visibility) + "'")
+	}
+
+	policies, err := usecase.PolicyReader.Search(ctx, common.NewSearchByValues(ctx, []common.SearchableValue{
+		{Field: "GroupID", Values: []interface{}{cmd.GroupID}},
+	}, common.NewSearchResultOptions(0, 1), common.ClientApplicationAudienceIDKey))
+
+	if err != nil {
+		slog.ErrorContext(ctx, "unable to search visibility policy", "groupID", cmd.GroupID, "err", err)
+		return nil, err
+	}
+
+	reso := common.GetResourceOwner(ctx)
+	now := time.Now().UTC()
+
+	if len(policies) > 0 {
+		policy := &policies[0]
+		policy.Visibility = cmd.Visibility
+		policy.UpdatedBy = reso.UserID
+		policy.UpdatedAt = now
+
+		policy, err = usecase.PolicyWriter.Update(ctx, policy)
+		if err != nil {
+			slog.ErrorContext(ctx, "unable to update visibility policy", "policyID", policies[0].ID, "err", err)
+			return nil, err
+		}
+
+		return policy, nil
+	}
+
+	policy, err := usecase.PolicyWriter.Create(ctx, &common.VisibilityPolicy{
+		ID:            uuid.New(),
+		GroupID:       cmd.GroupID,
+		Visibility:    cmd.Visibility,
+		UpdatedBy:     reso.UserID,
+		ResourceOwner: common.ResourceOwner{TenantID: reso.TenantID, ClientID: reso.ClientID},
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	})
+
+	if err != nil {
+		slog.ErrorContext(ctx, "unable to create visibility policy", "groupID", cmd.GroupID, "err", err)
+		return nil, err
+	}
+
+	return policy, nil
+}
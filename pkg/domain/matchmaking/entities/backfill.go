@@ -0,0 +1,149 @@
+package matchmaking_entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// open slots not filled within the TTL expire, the team keeps playing short-handed
+	BackfillSlotTTL = 10 * time.Minute
+
+	// joiners filling a slot later than this after it opened played too little of the match to be prize eligible
+	BackfillPrizeEligibilityWindow = 3 * time.Minute
+)
+
+type BackfillSlotStatus string
+
+const (
+	BackfillSlotStatusOpen    BackfillSlotStatus = "open"
+	BackfillSlotStatusFilled  BackfillSlotStatus = "filled"
+	BackfillSlotStatusExpired BackfillSlotStatus = "expired"
+)
+
+// BackfillSlot replaces a player that abandoned the lobby. The joiner should match the rating and role of the player replaced.
+type BackfillSlot struct {
+	ID              uuid.UUID          `json:"id" bson:"id"`
+	Team            LobbyTeam          `json:"team" bson:"team"`
+	Role            string             `json:"role,omitempty" bson:"role"`
+	Rating          int                `json:"rating" bson:"rating"`
+	AbandonedUserID uuid.UUID          `json:"abandoned_user_id" bson:"abandoned_user_id"`
+	Status          BackfillSlotStatus `json:"status" bson:"status"`
+	OpenedAt        time.Time          `json:"opened_at" bson:"opened_at"`
+	FilledByUserID  *uuid.UUID         `json:"filled_by_user_id,omitempty" bson:"filled_by_user_id"`
+	ClosedAt        *time.Time         `json:"closed_at,omitempty" bson:"closed_at"`
+}
+
+func (s BackfillSlot) IsExpired(now time.Time) bool {
+	return now.Sub(s.OpenedAt) >= BackfillSlotTTL
+}
+
+// Abandon marks the player as gone, forfeiting prize eligibility, and opens a backfill slot for their team (pool lobbies only, the
+// slot is filled from the pool queue). Leadership passes to the earliest remaining player when the leader abandons.
+func (l *Lobby) Abandon(userID uuid.UUID, now time.Time) *BackfillSlot {
+	player := l.GetPlayer(userID)
+
+	player.AbandonedAt = &now
+	player.PrizeEligible = false
+
+	if l.LeaderUserID == userID {
+		for _, p := range l.ActivePlayers() {
+			if p.UserID != userID {
+				l.LeaderUserID = p.UserID
+				break
+			}
+		}
+	}
+
+	l.UpdatedAt = now
+
+	if l.PoolID == nil {
+		return nil
+	}
+
+	slot := BackfillSlot{
+		ID:              uuid.New(),
+		Team:            player.Team,
+		Role:            player.Role,
+		Rating:          player.Rating,
+		AbandonedUserID: userID,
+		Status:          BackfillSlotStatusOpen,
+		OpenedAt:        now,
+	}
+
+	l.Backfills = append(l.Backfills, slot)
+
+	return &slot
+}
+
+// ActivePlayers returns the players that didn't abandon the lobby, earliest to join first.
+func (l Lobby) ActivePlayers() []LobbyPlayer {
+	active := make([]LobbyPlayer, 0, len(l.Players))
+	for _, p := range l.Players {
+		if p.AbandonedAt == nil {
+			active = append(active, p)
+		}
+	}
+
+	return active
+}
+
+// OpenBackfills returns the slots still waiting for a joiner.
+func (l Lobby) OpenBackfills() []BackfillSlot {
+	open := make([]BackfillSlot, 0)
+	for _, s := range l.Backfills {
+		if s.Status == BackfillSlotStatusOpen {
+			open = append(open, s)
+		}
+	}
+
+	return open
+}
+
+// FillBackfill adds the joiner to the team of the slot. Joiners are prize eligible only when the slot is filled within BackfillPrizeEligibilityWindow.
+func (l *Lobby) FillBackfill(slotID uuid.UUID, joiner LobbyPlayer, now time.Time) {
+	for i := range l.Backfills {
+		slot := &l.Backfills[i]
+		if slot.ID != slotID {
+			continue
+		}
+
+		joiner.Team = slot.Team
+		joiner.Role = slot.Role
+		joiner.Backfill = true
+		joiner.JoinedAt = now
+		joiner.PrizeEligible = now.Sub(slot.OpenedAt) <= BackfillPrizeEligibilityWindow
+
+		slot.Status = BackfillSlotStatusFilled
+		slot.FilledByUserID = &joiner.UserID
+		slot.ClosedAt = &now
+
+		l.Players = append(l.Players, joiner)
+		l.UpdatedAt = now
+
+		return
+	}
+}
+
+// ExpireBackfills closes the open slots past their TTL (or all of them once the lobby is finished), returning how many expired.
+func (l *Lobby) ExpireBackfills(now time.Time) int {
+	expired := 0
+
+	for i := range l.Backfills {
+		slot := &l.Backfills[i]
+		if slot.Status != BackfillSlotStatusOpen || !(l.IsFinished() || slot.IsExpired(now)) {
+			continue
+		}
+
+		slot.Status = BackfillSlotStatusExpired
+		slot.ClosedAt = &now
+		expired++
+	}
+
+	if expired > 0 {
+		l.UpdatedAt = now
+	}
+
+	return expired
+}
@@ -0,0 +1,110 @@
+package matchmaking_entities_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	matchmaking_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/matchmaking/entities"
+	"github.com/stretchr/testify/assert"
+)
+
+func newPoolLobby() matchmaking_entities.Lobby {
+	poolID := uuid.New()
+	now := time.Now()
+
+	l := matchmaking_entities.Lobby{ID: uuid.New(), PoolID: &poolID, Status: matchmaking_entities.LobbyStatusInMatch}
+	for i := 0; i < 4; i++ {
+		l.Players = append(l.Players, matchmaking_entities.LobbyPlayer{
+			UserID:        uuid.New(),
+			Rating:        1000 + i*100,
+			Role:          "entry",
+			Team:          matchmaking_entities.LobbyTeam(i%2 + 1),
+			JoinedAt:      now.Add(time.Duration(i) * time.Second),
+			PrizeEligible: true,
+		})
+	}
+
+	l.LeaderUserID = l.Players[0].UserID
+
+	return l
+}
+
+func TestLobby_Abandon(t *testing.T) {
+	l := newPoolLobby()
+	leader := l.Players[0]
+	now := time.Now()
+
+	slot := l.Abandon(leader.UserID, now)
+
+	if assert.NotNil(t, slot) {
+		assert.Equal(t, leader.Team, slot.Team)
+		assert.Equal(t, leader.Rating, slot.Rating)
+		assert.Equal(t, "entry", slot.Role)
+		assert.Equal(t, matchmaking_entities.BackfillSlotStatusOpen, slot.Status)
+	}
+
+	abandoned := l.GetPlayer(leader.UserID)
+	assert.Equal(t, &now, abandoned.AbandonedAt)
+	assert.False(t, abandoned.PrizeEligible)
+	assert.Equal(t, l.Players[1].UserID, l.LeaderUserID, "leadership passes to the earliest remaining player")
+	assert.Len(t, l.ActivePlayers(), 3)
+	assert.Len(t, l.OpenBackfills(), 1)
+
+	// lobbies formed outside of a pool have no queue to backfill from
+	l = newPoolLobby()
+	l.PoolID = nil
+	assert.Nil(t, l.Abandon(l.Players[1].UserID, now))
+	assert.Empty(t, l.Backfills)
+}
+
+func TestLobby_FillBackfill(t *testing.T) {
+	tests := []struct {
+		name         string
+		filledAfter  time.Duration
+		wantEligible bool
+	}{
+		{"early joiner is eligible", time.Minute, true},
+		{"late joiner isn't eligible", matchmaking_entities.BackfillPrizeEligibilityWindow + time.Second, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := newPoolLobby()
+			opened := time.Now()
+			slot := l.Abandon(l.Players[3].UserID, opened)
+
+			joiner := matchmaking_entities.LobbyPlayer{UserID: uuid.New(), Rating: 1290}
+			l.FillBackfill(slot.ID, joiner, opened.Add(tt.filledAfter))
+
+			player := l.GetPlayer(joiner.UserID)
+			if assert.NotNil(t, player) {
+				assert.True(t, player.Backfill)
+				assert.Equal(t, slot.Team, player.Team)
+				assert.Equal(t, slot.Role, player.Role)
+				assert.Equal(t, tt.wantEligible, player.PrizeEligible)
+			}
+
+			assert.Empty(t, l.OpenBackfills())
+			assert.Equal(t, matchmaking_entities.BackfillSlotStatusFilled, l.Backfills[0].Status)
+			assert.Equal(t, &joiner.UserID, l.Backfills[0].FilledByUserID)
+			assert.Len(t, l.ActivePlayers(), 4)
+		})
+	}
+}
+
+func TestLobby_ExpireBackfills(t *testing.T) {
+	l := newPoolLobby()
+	opened := time.Now()
+
+	l.Abandon(l.Players[1].UserID, opened)
+	l.Abandon(l.Players[2].UserID, opened.Add(5*time.Minute))
+
+	assert.Equal(t, 0, l.ExpireBackfills(opened.Add(time.Minute)))
+	assert.Equal(t, 1, l.ExpireBackfills(opened.Add(matchmaking_entities.BackfillSlotTTL)))
+	assert.Len(t, l.OpenBackfills(), 1)
+
+	l.Status = matchmaking_entities.LobbyStatusCompleted
+	assert.Equal(t, 1, l.ExpireBackfills(opened.Add(matchmaking_entities.BackfillSlotTTL)), "finished lobbies close every slot")
+	assert.Empty(t, l.OpenBackfills())
+}
@@ -0,0 +1,166 @@
+package matchmaking_entities
+
+import (
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	DefaultDraftPickTimeout = 30 * time.Second
+
+	// captains plus at least one pick each
+	MinDraftPlayers = 4
+)
+
+type DraftPick struct {
+	Order         int       `json:"order" bson:"order"`
+	CaptainUserID uuid.UUID `json:"captain_user_id" bson:"captain_user_id"`
+	UserID        uuid.UUID `json:"user_id" bson:"user_id"`
+	Team          LobbyTeam `json:"team" bson:"team"`
+	AutoPicked    bool      `json:"auto_picked" bson:"auto_picked"` // pick timer expired (or last remaining player)
+	PickedAt      time.Time `json:"picked_at" bson:"picked_at"`
+}
+
+// LobbyDraft is the captain draft of a lobby. Captains[0] leads team A and Captains[1] team B; captains alternate picks, the lower rated captain (B) picking first.
+type LobbyDraft struct {
+	Captains           []uuid.UUID `json:"captains" bson:"captains"`
+	Picks              []DraftPick `json:"picks" bson:"picks"`
+	PickTimeoutSeconds int         `json:"pick_timeout_seconds" bson:"pick_timeout_seconds"`
+	TurnDeadline       *time.Time  `json:"turn_deadline,omitempty" bson:"turn_deadline"`
+	StartedAt          time.Time   `json:"started_at" bson:"started_at"`
+	CompletedAt        *time.Time  `json:"completed_at,omitempty" bson:"completed_at"`
+}
+
+func (d LobbyDraft) IsCompleted() bool {
+	return d.CompletedAt != nil
+}
+
+func (d LobbyDraft) PickTimeout() time.Duration {
+	return time.Duration(d.PickTimeoutSeconds) * time.Second
+}
+
+// CurrentCaptain returns the captain on the clock.
+func (d LobbyDraft) CurrentCaptain() uuid.UUID {
+	return d.Captains[(len(d.Picks)+1)%2]
+}
+
+func (d LobbyDraft) CurrentTeam() LobbyTeam {
+	if (len(d.Picks)+1)%2 == 0 {
+		return LobbyTeamA
+	}
+
+	return LobbyTeamB
+}
+
+// StartDraft makes the two highest rated players (earliest to join on ties) captains and starts the first pick timer.
+func (l *Lobby) StartDraft(now time.Time, pickTimeout time.Duration) {
+	ranked := make([]LobbyPlayer, len(l.Players))
+	copy(ranked, l.Players)
+
+	sort.SliceStable(ranked, func(i, j int) bool {
+		if ranked[i].Rating != ranked[j].Rating {
+			return ranked[i].Rating > ranked[j].Rating
+		}
+
+		return ranked[i].JoinedAt.Before(ranked[j].JoinedAt)
+	})
+
+	for i := range l.Players {
+		l.Players[i].Team = LobbyTeamUnassigned
+	}
+
+	l.GetPlayer(ranked[0].UserID).Team = LobbyTeamA
+	l.GetPlayer(ranked[1].UserID).Team = LobbyTeamB
+
+	deadline := now.Add(pickTimeout)
+
+	l.Draft = &LobbyDraft{
+		Captains:           []uuid.UUID{ranked[0].UserID, ranked[1].UserID},
+		Picks:              []DraftPick{},
+		PickTimeoutSeconds: int(pickTimeout.Seconds()),
+		TurnDeadline:       &deadline,
+		StartedAt:          now,
+	}
+
+	l.Status = LobbyStatusDrafting
+	l.UpdatedAt = now
+}
+
+// UnassignedPlayers returns the players still available to be picked, best rated first.
+func (l Lobby) UnassignedPlayers() []LobbyPlayer {
+	available := make([]LobbyPlayer, 0, len(l.Players))
+	for _, p := range l.Players {
+		if p.Team == LobbyTeamUnassigned {
+			available = append(available, p)
+		}
+	}
+
+	sort.SliceStable(available, func(i, j int) bool {
+		if available[i].Rating != available[j].Rating {
+			return available[i].Rating > available[j].Rating
+		}
+
+		return available[i].JoinedAt.Before(available[j].JoinedAt)
+	})
+
+	return available
+}
+
+// Pick assigns the (unassigned) player to the team of the captain on the clock. The last remaining player is assigned automatically, completing the draft.
+func (l *Lobby) Pick(userID uuid.UUID, now time.Time, auto bool) {
+	l.assign(userID, now, auto)
+
+	remaining := l.UnassignedPlayers()
+	if len(remaining) == 1 {
+		l.assign(remaining[0].UserID, now, true)
+		remaining = remaining[1:]
+	}
+
+	if len(remaining) == 0 {
+		l.Draft.TurnDeadline = nil
+		l.Draft.CompletedAt = &now
+		l.Status = LobbyStatusReady
+	}
+
+	l.UpdatedAt = now
+}
+
+func (l *Lobby) assign(userID uuid.UUID, now time.Time, auto bool) {
+	team := l.Draft.CurrentTeam()
+
+	l.Draft.Picks = append(l.Draft.Picks, DraftPick{
+		Order:         len(l.Draft.Picks) + 1,
+		CaptainUserID: l.Draft.CurrentCaptain(),
+		UserID:        userID,
+		Team:          team,
+		AutoPicked:    auto,
+		PickedAt:      now,
+	})
+
+	l.GetPlayer(userID).Team = team
+
+	deadline := now.Add(l.Draft.PickTimeout())
+	l.Draft.TurnDeadline = &deadline
+}
+
+// AutoPickExpired picks the best rated available player for every turn whose timer ran out before now, returning the number of picks made. Turn deadlines chain from the expired ones, so a late run doesn't extend the draft.
+func (l *Lobby) AutoPickExpired(now time.Time) int {
+	if l.Draft == nil {
+		return 0
+	}
+
+	before := len(l.Draft.Picks)
+
+	for l.Status == LobbyStatusDrafting && !l.Draft.IsCompleted() && l.Draft.TurnDeadline.Before(now) {
+		l.Pick(l.UnassignedPlayers()[0].UserID, *l.Draft.TurnDeadline, true)
+	}
+
+	picks := len(l.Draft.Picks) - before
+	if picks > 0 {
+		l.UpdatedAt = now
+	}
+
+	return picks
+}
@@ -0,0 +1,120 @@
+package matchmaking_entities_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	matchmaking_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/matchmaking/entities"
+	"github.com/stretchr/testify/assert"
+)
+
+func newDraftLobby(ratings ...int) matchmaking_entities.Lobby {
+	joinedAt := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	players := make([]matchmaking_entities.LobbyPlayer, 0, len(ratings))
+	for i, rating := range ratings {
+		players = append(players, matchmaking_entities.LobbyPlayer{
+			PlayerID: uuid.New(),
+			UserID:   uuid.New(),
+			Rating:   rating,
+			JoinedAt: joinedAt.Add(time.Duration(i) * time.Second),
+		})
+	}
+
+	return matchmaking_entities.Lobby{
+		ID:      uuid.New(),
+		Players: players,
+		Mode:    matchmaking_entities.LobbyModeCaptainDraft,
+		Status:  matchmaking_entities.LobbyStatusForming,
+	}
+}
+
+func TestLobby_StartDraft(t *testing.T) {
+	lobby := newDraftLobby(1200, 1800, 1500, 1800)
+	now := time.Now()
+
+	lobby.StartDraft(now, 30*time.Second)
+
+	// ties are broken by join order
+	assert.Equal(t, []uuid.UUID{lobby.Players[1].UserID, lobby.Players[3].UserID}, lobby.Draft.Captains)
+	assert.Equal(t, matchmaking_entities.LobbyTeamA, lobby.Players[1].Team)
+	assert.Equal(t, matchmaking_entities.LobbyTeamB, lobby.Players[3].Team)
+	assert.Equal(t, matchmaking_entities.LobbyStatusDrafting, lobby.Status)
+	assert.Equal(t, now.Add(30*time.Second), *lobby.Draft.TurnDeadline)
+
+	// lower rated captain picks first
+	assert.Equal(t, lobby.Players[3].UserID, lobby.Draft.CurrentCaptain())
+	assert.Equal(t, matchmaking_entities.LobbyTeamB, lobby.Draft.CurrentTeam())
+}
+
+func TestLobby_Pick(t *testing.T) {
+	lobby := newDraftLobby(2000, 1900, 1000, 1100, 1200, 1300)
+	now := time.Now()
+
+	lobby.StartDraft(now, 30*time.Second)
+
+	lobby.Pick(lobby.Players[2].UserID, now.Add(time.Second), false)
+
+	assert.Equal(t, matchmaking_entities.LobbyTeamB, lobby.Players[2].Team)
+	assert.Equal(t, lobby.Players[0].UserID, lobby.Draft.CurrentCaptain())
+	assert.Equal(t, now.Add(31*time.Second), *lobby.Draft.TurnDeadline)
+
+	lobby.Pick(lobby.Players[3].UserID, now.Add(2*time.Second), false)
+	lobby.Pick(lobby.Players[4].UserID, now.Add(3*time.Second), false)
+
+	// last player is assigned automatically
+	assert.Len(t, lobby.Draft.Picks, 4)
+	assert.True(t, lobby.Draft.Picks[3].AutoPicked)
+	assert.Equal(t, lobby.Players[5].UserID, lobby.Draft.Picks[3].UserID)
+	assert.Equal(t, matchmaking_entities.LobbyTeamA, lobby.Players[5].Team)
+
+	assert.True(t, lobby.Draft.IsCompleted())
+	assert.Nil(t, lobby.Draft.TurnDeadline)
+	assert.Equal(t, matchmaking_entities.LobbyStatusReady, lobby.Status)
+
+	for i, p := range lobby.Draft.Picks {
+		assert.Equal(t, i+1, p.Order)
+	}
+
+	teams := map[matchmaking_entities.LobbyTeam]int{}
+	for _, p := range lobby.Players {
+		teams[p.Team]++
+	}
+
+	assert.Equal(t, map[matchmaking_entities.LobbyTeam]int{matchmaking_entities.LobbyTeamA: 3, matchmaking_entities.LobbyTeamB: 3}, teams)
+}
+
+func TestLobby_AutoPickExpired(t *testing.T) {
+	tests := []struct {
+		name          string
+		elapsed       time.Duration
+		expectedPicks int
+	}{
+		{name: "Timer Running", elapsed: 10 * time.Second, expectedPicks: 0},
+		{name: "One Turn Expired", elapsed: 45 * time.Second, expectedPicks: 1},
+		{name: "Deadlines Chain From Expired Turn", elapsed: 65 * time.Second, expectedPicks: 2},
+		{name: "Draft Completes", elapsed: time.Hour, expectedPicks: 6},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lobby := newDraftLobby(2000, 1900, 1000, 1100, 1200, 1300, 1400, 1500)
+			now := time.Now()
+
+			lobby.StartDraft(now, 30*time.Second)
+
+			picks := lobby.AutoPickExpired(now.Add(tt.elapsed))
+
+			assert.Equal(t, tt.expectedPicks, picks)
+			assert.Len(t, lobby.Draft.Picks, tt.expectedPicks)
+
+			if tt.expectedPicks > 0 {
+				// best rated available player goes first
+				assert.Equal(t, lobby.Players[7].UserID, lobby.Draft.Picks[0].UserID)
+				assert.True(t, lobby.Draft.Picks[0].AutoPicked)
+				assert.Equal(t, now.Add(30*time.Second), lobby.Draft.Picks[0].PickedAt)
+			}
+		})
+	}
+}
@@ -0,0 +1,188 @@
+package matchmaking_entities
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+)
+
+// basis points of a whole (100%)
+const BasisPoints = 10000
+
+// EconomyConfig is the entry fee and prize terms of the matches of a game mode (per tenant). Terms aren't edited: every change is a
+// new version, effective from EffectiveFrom, and lobbies keep the version they were formed with (see LobbyEconomy).
+type EconomyConfig struct {
+	ID            uuid.UUID        `json:"id" bson:"_id"`
+	GameID        common.GameIDKey `json:"game_id" bson:"game_id"`
+	Mode          LobbyMode        `json:"mode" bson:"mode"`
+	Version       int              `json:"version" bson:"version"`
+	EffectiveFrom time.Time        `json:"effective_from" bson:"effective_from"`
+	Currency      string           `json:"currency" bson:"currency"`
+	// EntryFee is paid by every player of a lobby, in minor units (ie: cents).
+	EntryFee int64 `json:"entry_fee" bson:"entry_fee"`
+	// PlatformContribution is added by the platform to the prize pool of every lobby, in minor units.
+	PlatformContribution int64 `json:"platform_contribution" bson:"platform_contribution"`
+	// RakeBasisPoints is the share of the entry fees kept by the platform.
+	RakeBasisPoints int `json:"rake_basis_points" bson:"rake_basis_points"`
+	// WinnerSplitBasisPoints is the share of the prize pool of each placement (the winning team first), adding up to BasisPoints.
+	WinnerSplitBasisPoints []int `json:"winner_split_basis_points" bson:"winner_split_basis_points"`
+
+	ResourceOwner common.ResourceOwner `json:"resource_owner" bson:"resource_owner"`
+	CreatedAt     time.Time            `json:"created_at" bson:"created_at"`
+	UpdatedAt     time.Time            `json:"updated_at" bson:"updated_at"`
+}
+
+func (c EconomyConfig) GetID() uuid.UUID {
+	return c.ID
+}
+
+// Validate checks the terms of the config, normalizing the currency code.
+func (c *EconomyConfig) Validate() error {
+	if c.GameID == "" {
+		return fmt.Errorf("game_id is required")
+	}
+
+	if c.Mode != LobbyModeAutoBalance && c.Mode != LobbyModeCaptainDraft {
+		return fmt.Errorf("invalid mode '%s'", c.Mode)
+	}
+
+	if c.EntryFee < 0 || c.PlatformContribution < 0 {
+		return fmt.Errorf("entry_fee and platform_contribution can't be negative")
+	}
+
+	c.Currency = strings.ToUpper(strings.TrimSpace(c.Currency))
+	if len(c.Currency) != 3 {
+		return fmt.Errorf("currency must be a 3 letter code")
+	}
+
+	if c.RakeBasisPoints < 0 || c.RakeBasisPoints > BasisPoints {
+		return fmt.Errorf("rake_basis_points must be between 0 and %d", BasisPoints)
+	}
+
+	// two teams per lobby: a share for the winners, and optionally one for the runners-up
+	if len(c.WinnerSplitBasisPoints) < 1 || len(c.WinnerSplitBasisPoints) > 2 {
+		return fmt.Errorf("winner_split_basis_points must have a share for the winners, and optionally one for the runners-up")
+	}
+
+	total := 0
+	for _, share := range c.WinnerSplitBasisPoints {
+		if share < 0 {
+			return fmt.Errorf("winner_split_basis_points can't be negative")
+		}
+
+		total += share
+	}
+
+	if total != BasisPoints {
+		return fmt.Errorf("winner_split_basis_points must add up to %d", BasisPoints)
+	}
+
+	return nil
+}
+
+// LobbyEconomy returns the snapshot of the terms kept by the lobbies formed while the config is effective.
+func (c EconomyConfig) LobbyEconomy() *LobbyEconomy {
+	return &LobbyEconomy{
+		ConfigID:               c.ID,
+		Version:                c.Version,
+		Currency:               c.Currency,
+		EntryFee:               c.EntryFee,
+		PlatformContribution:   c.PlatformContribution,
+		RakeBasisPoints:        c.RakeBasisPoints,
+		WinnerSplitBasisPoints: c.WinnerSplitBasisPoints,
+	}
+}
+
+// LobbyEconomy is the economy config version a lobby was formed with, lobbies without one are free.
+type LobbyEconomy struct {
+	ConfigID               uuid.UUID `json:"config_id" bson:"config_id"`
+	Version                int       `json:"version" bson:"version"`
+	Currency               string    `json:"currency" bson:"currency"`
+	EntryFee               int64     `json:"entry_fee" bson:"entry_fee"`
+	PlatformContribution   int64     `json:"platform_contribution" bson:"platform_contribution"`
+	RakeBasisPoints        int       `json:"rake_basis_points" bson:"rake_basis_points"`
+	WinnerSplitBasisPoints []int     `json:"winner_split_basis_points" bson:"winner_split_basis_points"`
+}
+
+type LobbyPayout struct {
+	UserID   uuid.UUID `json:"user_id"`
+	PlayerID uuid.UUID `json:"player_id"`
+	Team     LobbyTeam `json:"team"`
+	Amount   int64     `json:"amount"` // minor units
+}
+
+// LobbySettlement is the split of the entry fees of a lobby once its match result is known. Amounts are in minor units.
+type LobbySettlement struct {
+	LobbyID   uuid.UUID     `json:"lobby_id"`
+	Currency  string        `json:"currency"`
+	EntryFees int64         `json:"entry_fees"`
+	Rake      int64         `json:"rake"`
+	PrizePool int64         `json:"prize_pool"` // entry fees, minus the rake, plus the platform contribution
+	Payouts   []LobbyPayout `json:"payouts"`
+	// Unpaid is kept by the platform: rounding remainders and the shares of teams without prize eligible players.
+	Unpaid int64 `json:"unpaid"`
+}
+
+// Settle splits the prize pool of the lobby by placement (the winner team first, a draw is LobbyTeamUnassigned and splits the shares
+// of both placements evenly). Each team share goes in equal parts to its prize eligible players. Every player of the lobby paid the
+// entry fee, backfill joiners included. Free lobbies have nothing to settle (nil).
+func (l Lobby) Settle(winner LobbyTeam) *LobbySettlement {
+	if l.Economy == nil {
+		return nil
+	}
+
+	e := l.Economy
+
+	settlement := &LobbySettlement{
+		LobbyID:   l.ID,
+		Currency:  e.Currency,
+		EntryFees: e.EntryFee * int64(len(l.Players)),
+		Payouts:   []LobbyPayout{},
+	}
+
+	settlement.Rake = settlement.EntryFees * int64(e.RakeBasisPoints) / BasisPoints
+	settlement.PrizePool = settlement.EntryFees - settlement.Rake + e.PlatformContribution
+
+	shares := make([]int64, 2)
+	for i, share := range e.WinnerSplitBasisPoints {
+		shares[i] = settlement.PrizePool * int64(share) / BasisPoints
+	}
+
+	teamShares := map[LobbyTeam]int64{}
+	switch winner {
+	case LobbyTeamA:
+		teamShares[LobbyTeamA], teamShares[LobbyTeamB] = shares[0], shares[1]
+	case LobbyTeamB:
+		teamShares[LobbyTeamB], teamShares[LobbyTeamA] = shares[0], shares[1]
+	default:
+		draw := (shares[0] + shares[1]) / 2
+		teamShares[LobbyTeamA], teamShares[LobbyTeamB] = draw, draw
+	}
+
+	paid := int64(0)
+	for _, team := range []LobbyTeam{LobbyTeamA, LobbyTeamB} {
+		var eligible []LobbyPlayer
+		for _, p := range l.Players {
+			if p.Team == team && p.PrizeEligible {
+				eligible = append(eligible, p)
+			}
+		}
+
+		if len(eligible) == 0 || teamShares[team] == 0 {
+			continue
+		}
+
+		amount := teamShares[team] / int64(len(eligible))
+		for _, p := range eligible {
+			settlement.Payouts = append(settlement.Payouts, LobbyPayout{UserID: p.UserID, PlayerID: p.PlayerID, Team: team, Amount: amount})
+			paid += amount
+		}
+	}
+
+	settlement.Unpaid = settlement.PrizePool - paid
+
+	return settlement
+}
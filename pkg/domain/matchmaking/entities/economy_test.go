@@ -0,0 +1,108 @@
+package matchmaking_entities_test
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	matchmaking_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/matchmaking/entities"
+	"github.com/stretchr/testify/assert"
+)
+
+func newEconomyConfig() matchmaking_entities.EconomyConfig {
+	return matchmaking_entities.EconomyConfig{
+		GameID:                 common.CS2_GAME_ID,
+		Mode:                   matchmaking_entities.LobbyModeAutoBalance,
+		Currency:               "usd",
+		EntryFee:               500,
+		PlatformContribution:   1000,
+		RakeBasisPoints:        1000,
+		WinnerSplitBasisPoints: []int{8000, 2000},
+	}
+}
+
+func TestEconomyConfig_Validate(t *testing.T) {
+	config := newEconomyConfig()
+	assert.NoError(t, config.Validate())
+	assert.Equal(t, "USD", config.Currency)
+
+	tests := []struct {
+		name   string
+		change func(c *matchmaking_entities.EconomyConfig)
+	}{
+		{"missing game", func(c *matchmaking_entities.EconomyConfig) { c.GameID = "" }},
+		{"invalid mode", func(c *matchmaking_entities.EconomyConfig) { c.Mode = "solo" }},
+		{"negative fee", func(c *matchmaking_entities.EconomyConfig) { c.EntryFee = -1 }},
+		{"invalid currency", func(c *matchmaking_entities.EconomyConfig) { c.Currency = "dollars" }},
+		{"rake over 100%", func(c *matchmaking_entities.EconomyConfig) { c.RakeBasisPoints = matchmaking_entities.BasisPoints + 1 }},
+		{"no split", func(c *matchmaking_entities.EconomyConfig) { c.WinnerSplitBasisPoints = nil }},
+		{"three placements", func(c *matchmaking_entities.EconomyConfig) { c.WinnerSplitBasisPoints = []int{5000, 3000, 2000} }},
+		{"split under 100%", func(c *matchmaking_entities.EconomyConfig) { c.WinnerSplitBasisPoints = []int{7000, 2000} }},
+		{"negative share", func(c *matchmaking_entities.EconomyConfig) { c.WinnerSplitBasisPoints = []int{11000, -1000} }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := newEconomyConfig()
+			tt.change(&config)
+
+			assert.Error(t, config.Validate())
+		})
+	}
+}
+
+func newEconomyLobby(economy *matchmaking_entities.LobbyEconomy) matchmaking_entities.Lobby {
+	lobby := matchmaking_entities.Lobby{ID: uuid.New(), Economy: economy}
+
+	for _, team := range []matchmaking_entities.LobbyTeam{matchmaking_entities.LobbyTeamA, matchmaking_entities.LobbyTeamA, matchmaking_entities.LobbyTeamB, matchmaking_entities.LobbyTeamB} {
+		lobby.Players = append(lobby.Players, matchmaking_entities.LobbyPlayer{UserID: uuid.New(), PlayerID: uuid.New(), Team: team, PrizeEligible: true})
+	}
+
+	return lobby
+}
+
+func payouts(settlement *matchmaking_entities.LobbySettlement) map[matchmaking_entities.LobbyTeam][]int64 {
+	amounts := map[matchmaking_entities.LobbyTeam][]int64{}
+	for _, p := range settlement.Payouts {
+		amounts[p.Team] = append(amounts[p.Team], p.Amount)
+	}
+
+	return amounts
+}
+
+func TestLobby_Settle(t *testing.T) {
+	assert.Nil(t, newEconomyLobby(nil).Settle(matchmaking_entities.LobbyTeamA), "free lobby")
+
+	config := newEconomyConfig()
+	config.ID = uuid.New()
+	config.Version = 3
+
+	lobby := newEconomyLobby(config.LobbyEconomy())
+
+	// 4 x 500 in fees, 10% rake (200), plus 1000 from the platform
+	settlement := lobby.Settle(matchmaking_entities.LobbyTeamB)
+	assert.Equal(t, int64(2000), settlement.EntryFees)
+	assert.Equal(t, int64(200), settlement.Rake)
+	assert.Equal(t, int64(2800), settlement.PrizePool)
+	assert.Equal(t, map[matchmaking_entities.LobbyTeam][]int64{matchmaking_entities.LobbyTeamB: {1120, 1120}, matchmaking_entities.LobbyTeamA: {280, 280}}, payouts(settlement))
+	assert.Equal(t, int64(0), settlement.Unpaid)
+
+	// a draw splits both shares evenly
+	settlement = lobby.Settle(matchmaking_entities.LobbyTeamUnassigned)
+	assert.Equal(t, map[matchmaking_entities.LobbyTeam][]int64{matchmaking_entities.LobbyTeamA: {700, 700}, matchmaking_entities.LobbyTeamB: {700, 700}}, payouts(settlement))
+
+	// players that aren't prize eligible paid their fee, their part of the share goes to the other team players
+	lobby.Players[0].PrizeEligible = false
+	lobby.Economy.WinnerSplitBasisPoints = []int{matchmaking_entities.BasisPoints}
+	lobby.Economy.PlatformContribution = 1
+
+	settlement = lobby.Settle(matchmaking_entities.LobbyTeamA)
+	assert.Equal(t, int64(1801), settlement.PrizePool)
+	assert.Equal(t, map[matchmaking_entities.LobbyTeam][]int64{matchmaking_entities.LobbyTeamA: {1801}}, payouts(settlement))
+
+	// nobody eligible in the winner team: the share is kept by the platform
+	lobby.Players[1].PrizeEligible = false
+
+	settlement = lobby.Settle(matchmaking_entities.LobbyTeamA)
+	assert.Equal(t, settlement.PrizePool, settlement.Unpaid)
+}
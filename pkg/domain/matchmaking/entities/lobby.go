@@ -0,0 +1,187 @@
+package matchmaking_entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+)
+
+type LobbyStatus string
+
+const (
+	LobbyStatusReadyCheck LobbyStatus = "ready_check"
+	LobbyStatusForming    LobbyStatus = "forming"
+	LobbyStatusDrafting   LobbyStatus = "drafting"
+	LobbyStatusReady      LobbyStatus = "ready"
+	LobbyStatusInMatch    LobbyStatus = "in_match"
+	LobbyStatusCompleted  LobbyStatus = "completed"
+	LobbyStatusCancelled  LobbyStatus = "cancelled"
+)
+
+// LobbyCancelReason tells why a lobby was cancelled before its match was played.
+type LobbyCancelReason string
+
+const (
+	LobbyCancelReasonReadyCheckFailed LobbyCancelReason = "ready_check_failed"
+	LobbyCancelReasonExpired          LobbyCancelReason = "expired" // the match didn't start within the pool lobby timeout
+)
+
+type LobbyMode string
+
+const (
+	LobbyModeAutoBalance  LobbyMode = "auto_balance"
+	LobbyModeCaptainDraft LobbyMode = "captain_draft"
+)
+
+type LobbyTeam int
+
+const (
+	LobbyTeamUnassigned LobbyTeam = 0
+	LobbyTeamA          LobbyTeam = 1
+	LobbyTeamB          LobbyTeam = 2
+)
+
+type LobbyPlayer struct {
+	PlayerID      uuid.UUID  `json:"player_id" bson:"player_id"`
+	UserID        uuid.UUID  `json:"user_id" bson:"user_id"`
+	QueueTicketID uuid.UUID  `json:"queue_ticket_id" bson:"queue_ticket_id"` // the queue session the player was matched from
+	Rating        int        `json:"rating" bson:"rating"`                   // rating snapshot taken when the player joined
+	Role          string     `json:"role,omitempty" bson:"role"`
+	Team          LobbyTeam  `json:"team" bson:"team"`
+	JoinedAt      time.Time  `json:"joined_at" bson:"joined_at"`
+	Backfill      bool       `json:"backfill" bson:"backfill"` // joined through a backfill slot
+	AbandonedAt   *time.Time `json:"abandoned_at,omitempty" bson:"abandoned_at"`
+	PrizeEligible bool       `json:"prize_eligible" bson:"prize_eligible"`
+}
+
+// Lobby groups the players selected for a match, from formation until the match is completed (or the lobby is cancelled).
+type Lobby struct {
+	ID            uuid.UUID            `json:"id" bson:"_id"`
+	PoolID        *uuid.UUID           `json:"pool_id,omitempty" bson:"pool_id"`
+	GameID        common.GameIDKey     `json:"game_id" bson:"game_id"`
+	RegionID      common.RegionIDKey   `json:"region_id" bson:"region_id"`
+	LeaderUserID  uuid.UUID            `json:"leader_user_id" bson:"leader_user_id"`
+	Players       []LobbyPlayer        `json:"players" bson:"players"`
+	Mode          LobbyMode            `json:"mode" bson:"mode"`
+	Status        LobbyStatus          `json:"status" bson:"status"`
+	CancelReason  LobbyCancelReason    `json:"cancel_reason,omitempty" bson:"cancel_reason"`
+	ReadyCheck    *LobbyReadyCheck     `json:"ready_check,omitempty" bson:"ready_check"`
+	Draft         *LobbyDraft          `json:"draft,omitempty" bson:"draft"`
+	MatchID       *uuid.UUID           `json:"match_id,omitempty" bson:"match_id"`
+	Result        *LobbyResult         `json:"result,omitempty" bson:"result"` // reported once the match is played
+	Voice         *LobbyVoiceChannel   `json:"voice,omitempty" bson:"voice"`
+	Backfills     []BackfillSlot       `json:"backfills,omitempty" bson:"backfills"`
+	Economy       *LobbyEconomy        `json:"economy,omitempty" bson:"economy"` // entry fee and prize terms, free lobby when nil
+	ResourceOwner common.ResourceOwner `json:"resource_owner" bson:"resource_owner"`
+	CreatedAt     time.Time            `json:"created_at" bson:"created_at"`
+	UpdatedAt     time.Time            `json:"updated_at" bson:"updated_at"`
+}
+
+func (l Lobby) GetID() uuid.UUID {
+	return l.ID
+}
+
+// LobbyCreated is published when the matcher forms a lobby from the queue of a pool (ie: for the game servers and notifications).
+type LobbyCreated struct {
+	LobbyID            uuid.UUID            `json:"lobby_id"`
+	PoolID             uuid.UUID            `json:"pool_id"`
+	GameID             common.GameIDKey     `json:"game_id"`
+	RegionID           common.RegionIDKey   `json:"region_id"`
+	Mode               LobbyMode            `json:"mode"`
+	Status             LobbyStatus          `json:"status"`
+	Players            []LobbyPlayer        `json:"players"`
+	Economy            *LobbyEconomy        `json:"economy,omitempty"`              // the entry fees to charge, free lobby when nil
+	ReadyCheckDeadline *time.Time           `json:"ready_check_deadline,omitempty"` // the players have to accept the match before it, when set
+	ResourceOwner      common.ResourceOwner `json:"resource_owner"`
+	CreatedAt          time.Time            `json:"created_at"`
+}
+
+func NewLobbyCreated(lobby Lobby) LobbyCreated {
+	event := LobbyCreated{
+		LobbyID:       lobby.ID,
+		GameID:        lobby.GameID,
+		RegionID:      lobby.RegionID,
+		Mode:          lobby.Mode,
+		Status:        lobby.Status,
+		Players:       lobby.Players,
+		Economy:       lobby.Economy,
+		ResourceOwner: lobby.ResourceOwner,
+		CreatedAt:     lobby.CreatedAt,
+	}
+
+	if lobby.PoolID != nil {
+		event.PoolID = *lobby.PoolID
+	}
+
+	if lobby.ReadyCheck != nil {
+		deadline := lobby.ReadyCheck.Deadline
+		event.ReadyCheckDeadline = &deadline
+	}
+
+	return event
+}
+
+// LobbyCancelled is published when a lobby is cancelled before its match started, with the players queued again (the others have to
+// be told to queue again).
+type LobbyCancelled struct {
+	LobbyID       uuid.UUID            `json:"lobby_id"`
+	PoolID        uuid.UUID            `json:"pool_id"`
+	Reason        LobbyCancelReason    `json:"reason"`
+	Players       []LobbyPlayer        `json:"players"`
+	Requeued      []uuid.UUID          `json:"requeued"` // user IDs
+	ResourceOwner common.ResourceOwner `json:"resource_owner"`
+	CancelledAt   time.Time            `json:"cancelled_at"`
+}
+
+func NewLobbyCancelled(lobby Lobby, requeued []uuid.UUID) LobbyCancelled {
+	event := LobbyCancelled{
+		LobbyID:       lobby.ID,
+		Reason:        lobby.CancelReason,
+		Players:       lobby.Players,
+		Requeued:      requeued,
+		ResourceOwner: lobby.ResourceOwner,
+		CancelledAt:   lobby.UpdatedAt,
+	}
+
+	if lobby.PoolID != nil {
+		event.PoolID = *lobby.PoolID
+	}
+
+	return event
+}
+
+func (l Lobby) HasUser(userID uuid.UUID) bool {
+	return l.GetPlayer(userID) != nil
+}
+
+func (l Lobby) GetPlayer(userID uuid.UUID) *LobbyPlayer {
+	for i := range l.Players {
+		if l.Players[i].UserID == userID {
+			return &l.Players[i]
+		}
+	}
+
+	return nil
+}
+
+func (l Lobby) IsLeader(userID uuid.UUID) bool {
+	return userID != uuid.Nil && l.LeaderUserID == userID
+}
+
+// IsPending reports whether the lobby is formed (and accepted, when there's a ready check) but its match didn't start yet.
+func (l Lobby) IsPending() bool {
+	return l.Status == LobbyStatusForming || l.Status == LobbyStatusDrafting || l.Status == LobbyStatusReady
+}
+
+// Expire cancels a pending lobby whose match didn't start in time.
+func (l *Lobby) Expire(now time.Time) {
+	l.Status = LobbyStatusCancelled
+	l.CancelReason = LobbyCancelReasonExpired
+	l.UpdatedAt = now
+}
+
+// IsFinished reports whether the lobby reached a terminal status.
+func (l Lobby) IsFinished() bool {
+	return l.Status == LobbyStatusCompleted || l.Status == LobbyStatusCancelled
+}
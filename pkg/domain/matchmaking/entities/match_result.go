@@ -0,0 +1,138 @@
+package matchmaking_entities
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+)
+
+// LobbyTeamResult is the final score of a team of the lobby, and the squad it played as (if any).
+type LobbyTeamResult struct {
+	Team    LobbyTeam  `json:"team" bson:"team"`
+	Score   int        `json:"score" bson:"score"`
+	SquadID *uuid.UUID `json:"squad_id,omitempty" bson:"squad_id"`
+}
+
+// LobbyResult is the result of the match of a lobby, as reported by its leader.
+type LobbyResult struct {
+	Teams      []LobbyTeamResult `json:"teams" bson:"teams"`
+	ReportedBy uuid.UUID         `json:"reported_by" bson:"reported_by"`
+	ReportedAt time.Time         `json:"reported_at" bson:"reported_at"`
+}
+
+// Validate checks the result has one score per team of the lobby (A and B).
+func (r LobbyResult) Validate() error {
+	if len(r.Teams) != 2 {
+		return errors.New("the result must have the score of both teams")
+	}
+
+	seen := make(map[LobbyTeam]bool, len(r.Teams))
+	for _, t := range r.Teams {
+		if t.Team != LobbyTeamA && t.Team != LobbyTeamB {
+			return fmt.Errorf("invalid team %d", t.Team)
+		}
+
+		if seen[t.Team] {
+			return fmt.Errorf("team %d is reported twice", t.Team)
+		}
+
+		if t.Score < 0 {
+			return fmt.Errorf("the score of team %d can't be negative", t.Team)
+		}
+
+		seen[t.Team] = true
+	}
+
+	return nil
+}
+
+// Winner is the team with the highest score, LobbyTeamUnassigned on a draw.
+func (r LobbyResult) Winner() LobbyTeam {
+	winner, best, draw := LobbyTeamUnassigned, -1, false
+
+	for _, t := range r.Teams {
+		switch {
+		case t.Score > best:
+			winner, best, draw = t.Team, t.Score, false
+		case t.Score == best:
+			draw = true
+		}
+	}
+
+	if draw {
+		return LobbyTeamUnassigned
+	}
+
+	return winner
+}
+
+// Complete records the result of the match played by the lobby.
+func (l *Lobby) Complete(result LobbyResult) {
+	l.Result = &result
+	l.Status = LobbyStatusCompleted
+	l.UpdatedAt = result.ReportedAt
+}
+
+type MatchCompletedPlayer struct {
+	PlayerID uuid.UUID `json:"player_id"`
+	UserID   uuid.UUID `json:"user_id"`
+}
+
+type MatchCompletedTeam struct {
+	Team    LobbyTeam              `json:"team"`
+	Score   int                    `json:"score"`
+	SquadID *uuid.UUID             `json:"squad_id,omitempty"`
+	Players []MatchCompletedPlayer `json:"players"` // including the players that abandoned the match
+}
+
+// MatchCompleted is published when the result of the match of a lobby is reported (ie: to update the ratings of its players).
+type MatchCompleted struct {
+	MatchID       uuid.UUID            `json:"match_id"`
+	LobbyID       uuid.UUID            `json:"lobby_id"`
+	PoolID        uuid.UUID            `json:"pool_id"`
+	GameID        common.GameIDKey     `json:"game_id"`
+	Teams         []MatchCompletedTeam `json:"teams"`
+	ResourceOwner common.ResourceOwner `json:"resource_owner"`
+	CompletedAt   time.Time            `json:"completed_at"`
+}
+
+// NewMatchCompleted builds the event of a completed lobby (with its result and match).
+func NewMatchCompleted(lobby Lobby) MatchCompleted {
+	event := MatchCompleted{
+		LobbyID:       lobby.ID,
+		GameID:        lobby.GameID,
+		Teams:         make([]MatchCompletedTeam, 0, len(lobby.Result.Teams)),
+		ResourceOwner: lobby.ResourceOwner,
+		CompletedAt:   lobby.Result.ReportedAt,
+	}
+
+	if lobby.MatchID != nil {
+		event.MatchID = *lobby.MatchID
+	}
+
+	if lobby.PoolID != nil {
+		event.PoolID = *lobby.PoolID
+	}
+
+	for _, result := range lobby.Result.Teams {
+		team := MatchCompletedTeam{
+			Team:    result.Team,
+			Score:   result.Score,
+			SquadID: result.SquadID,
+			Players: make([]MatchCompletedPlayer, 0),
+		}
+
+		for _, p := range lobby.Players {
+			if p.Team == result.Team {
+				team.Players = append(team.Players, MatchCompletedPlayer{PlayerID: p.PlayerID, UserID: p.UserID})
+			}
+		}
+
+		event.Teams = append(event.Teams, team)
+	}
+
+	return event
+}
@@ -0,0 +1,31 @@
+package matchmaking_entities_test
+
+import (
+	"testing"
+
+	matchmaking_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/matchmaking/entities"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLobbyResult_Winner(t *testing.T) {
+	tests := []struct {
+		name     string
+		scores   []int // of teams A and B
+		expected matchmaking_entities.LobbyTeam
+	}{
+		{name: "Team A Wins", scores: []int{13, 9}, expected: matchmaking_entities.LobbyTeamA},
+		{name: "Team B Wins", scores: []int{11, 13}, expected: matchmaking_entities.LobbyTeamB},
+		{name: "Draw", scores: []int{15, 15}, expected: matchmaking_entities.LobbyTeamUnassigned},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := matchmaking_entities.LobbyResult{Teams: []matchmaking_entities.LobbyTeamResult{
+				{Team: matchmaking_entities.LobbyTeamA, Score: tt.scores[0]},
+				{Team: matchmaking_entities.LobbyTeamB, Score: tt.scores[1]},
+			}}
+
+			assert.Equal(t, tt.expected, result.Winner())
+		})
+	}
+}
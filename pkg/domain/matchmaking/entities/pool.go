@@ -0,0 +1,73 @@
+package matchmaking_entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+)
+
+const (
+	// min MatchmakingPool.LobbyTimeoutSeconds and QueueTimeoutSeconds (when set)
+	MinLobbyTimeout = time.Minute
+	MinQueueTimeout = time.Minute
+)
+
+// MatchmakingPool is a queue of players (per game, region and mode) matched together by the pool strategy.
+type MatchmakingPool struct {
+	ID              uuid.UUID          `json:"id" bson:"_id"`
+	Name            string             `json:"name" bson:"name"`
+	GameID          common.GameIDKey   `json:"game_id" bson:"game_id"`
+	RegionID        common.RegionIDKey `json:"region_id" bson:"region_id"`
+	Mode            LobbyMode          `json:"mode" bson:"mode"`
+	TeamSize        int                `json:"team_size" bson:"team_size"`
+	MaxRatingSpread int                `json:"max_rating_spread" bson:"max_rating_spread"` // max rating difference between any two players of a match
+	RoleSlots       []string           `json:"role_slots" bson:"role_slots"`               // roles every team needs (ie: entry, awp, support...), empty when the pool has no roles
+	Enabled         bool               `json:"enabled" bson:"enabled"`
+	Schedule        *PoolSchedule      `json:"schedule,omitempty" bson:"schedule"` // playlist windows, always open when nil
+
+	// SearchWindow widens MaxRatingSpread for the tickets waiting longer, the spread is fixed when nil.
+	SearchWindow *PoolSearchWindow `json:"search_window,omitempty" bson:"search_window"`
+
+	// ReadyCheckTimeoutSeconds is how long the players of a new lobby have to accept the match, lobbies are ready as formed when 0.
+	ReadyCheckTimeoutSeconds int `json:"ready_check_timeout_seconds" bson:"ready_check_timeout_seconds"`
+
+	// LobbyTimeoutSeconds is how long a lobby has to start its match once formed before it's cancelled and its players queued again,
+	// lobbies never expire when 0.
+	LobbyTimeoutSeconds int `json:"lobby_timeout_seconds" bson:"lobby_timeout_seconds"`
+
+	// QueueTimeoutSeconds is how long a ticket waits (since it was last queued) before it expires, tickets never expire when 0.
+	QueueTimeoutSeconds int `json:"queue_timeout_seconds" bson:"queue_timeout_seconds"`
+
+	// Availability is computed when listing pools, it isn't persisted.
+	Availability *PoolAvailability `json:"availability,omitempty" bson:"-"`
+
+	// Strategy forms the matches of the pool. ShadowStrategies run on the same queue snapshot for comparison only.
+	Strategy         string   `json:"strategy" bson:"strategy"`
+	ShadowStrategies []string `json:"shadow_strategies" bson:"shadow_strategies"`
+
+	ResourceOwner common.ResourceOwner `json:"resource_owner" bson:"resource_owner"`
+	CreatedAt     time.Time            `json:"created_at" bson:"created_at"`
+	UpdatedAt     time.Time            `json:"updated_at" bson:"updated_at"`
+}
+
+func (p MatchmakingPool) GetID() uuid.UUID {
+	return p.ID
+}
+
+// MatchSize is the number of players of a match (two teams).
+func (p MatchmakingPool) MatchSize() int {
+	return p.TeamSize * 2
+}
+
+func (p MatchmakingPool) ReadyCheckTimeout() time.Duration {
+	return time.Duration(p.ReadyCheckTimeoutSeconds) * time.Second
+}
+
+func (p MatchmakingPool) LobbyTimeout() time.Duration {
+	return time.Duration(p.LobbyTimeoutSeconds) * time.Second
+}
+
+func (p MatchmakingPool) QueueTimeout() time.Duration {
+	return time.Duration(p.QueueTimeoutSeconds) * time.Second
+}
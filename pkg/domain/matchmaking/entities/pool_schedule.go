@@ -0,0 +1,167 @@
+package matchmaking_entities
+
+import (
+	"fmt"
+	"time"
+)
+
+// max length of a window; longer events are configured as consecutive daily windows
+const MaxScheduleWindowDuration = 24 * time.Hour
+
+// PoolSchedule restricts a pool (ie: weekend cup, double-XP hours) to recurring windows. Pools without a schedule are always open.
+type PoolSchedule struct {
+	Timezone string               `json:"timezone" bson:"timezone"` // IANA name (ie: America/Sao_Paulo), UTC when empty
+	Windows  []PoolScheduleWindow `json:"windows" bson:"windows"`
+}
+
+type PoolScheduleWindow struct {
+	Name            string         `json:"name" bson:"name"`
+	Days            []time.Weekday `json:"days" bson:"days"`   // 0 = Sunday; every day when empty
+	Start           string         `json:"start" bson:"start"` // local time of day, HH:MM
+	DurationMinutes int            `json:"duration_minutes" bson:"duration_minutes"`
+}
+
+// PoolAvailability tells whether the pool accepts players now, and when that changes.
+type PoolAvailability struct {
+	Open            bool       `json:"open"`
+	Window          string     `json:"window,omitempty"` // current window when open, next one when closed
+	OpensAt         *time.Time `json:"opens_at,omitempty"`
+	OpensInSeconds  *int       `json:"opens_in_seconds,omitempty"`
+	ClosesAt        *time.Time `json:"closes_at,omitempty"`
+	ClosesInSeconds *int       `json:"closes_in_seconds,omitempty"`
+}
+
+func (s PoolSchedule) Location() (*time.Location, error) {
+	if s.Timezone == "" {
+		return time.UTC, nil
+	}
+
+	return time.LoadLocation(s.Timezone)
+}
+
+// Validate checks the timezone and that every window has a valid start, duration and days.
+func (s PoolSchedule) Validate() error {
+	_, err := s.Location()
+	if err != nil {
+		return fmt.Errorf("invalid schedule timezone '%s'", s.Timezone)
+	}
+
+	if len(s.Windows) == 0 {
+		return fmt.Errorf("schedule has no windows")
+	}
+
+	for _, w := range s.Windows {
+		_, _, err := w.clock()
+		if err != nil {
+			return err
+		}
+
+		if w.DurationMinutes <= 0 || w.Duration() > MaxScheduleWindowDuration {
+			return fmt.Errorf("window '%s' duration must be between 1 and %d minutes", w.Name, int(MaxScheduleWindowDuration.Minutes()))
+		}
+
+		for _, d := range w.Days {
+			if d < time.Sunday || d > time.Saturday {
+				return fmt.Errorf("window '%s' has an invalid day %d (0 = Sunday ... 6 = Saturday)", w.Name, d)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (w PoolScheduleWindow) Duration() time.Duration {
+	return time.Duration(w.DurationMinutes) * time.Minute
+}
+
+func (w PoolScheduleWindow) clock() (int, int, error) {
+	t, err := time.Parse("15:04", w.Start)
+	if err != nil {
+		return 0, 0, fmt.Errorf("window '%s' start '%s' isn't a HH:MM time", w.Name, w.Start)
+	}
+
+	return t.Hour(), t.Minute(), nil
+}
+
+func (w PoolScheduleWindow) runsOn(day time.Weekday) bool {
+	if len(w.Days) == 0 {
+		return true
+	}
+
+	for _, d := range w.Days {
+		if d == day {
+			return true
+		}
+	}
+
+	return false
+}
+
+// AvailabilityAt returns whether the pool is open at now, with the countdown to its next opening or closing (in the pool timezone,
+// so windows follow daylight saving changes). A disabled pool is never open.
+func (p MatchmakingPool) AvailabilityAt(now time.Time) PoolAvailability {
+	if !p.Enabled {
+		return PoolAvailability{}
+	}
+
+	if p.Schedule == nil {
+		return PoolAvailability{Open: true}
+	}
+
+	loc, err := p.Schedule.Location()
+	if err != nil {
+		return PoolAvailability{}
+	}
+
+	local := now.In(loc)
+
+	var current, next *PoolScheduleWindow
+	var closesAt, opensAt time.Time
+
+	for i := range p.Schedule.Windows {
+		w := &p.Schedule.Windows[i]
+
+		hour, minute, err := w.clock()
+		if err != nil {
+			continue
+		}
+
+		// from yesterday (windows running past midnight) to a week ahead
+		for offset := -1; offset <= 7; offset++ {
+			day := local.AddDate(0, 0, offset)
+			if !w.runsOn(day.Weekday()) {
+				continue
+			}
+
+			start := time.Date(day.Year(), day.Month(), day.Day(), hour, minute, 0, 0, loc)
+			end := start.Add(w.Duration())
+
+			switch {
+			case !now.Before(start) && now.Before(end):
+				if current == nil || end.After(closesAt) {
+					current, closesAt = w, end
+				}
+			case start.After(now):
+				if next == nil || start.Before(opensAt) {
+					next, opensAt = w, start
+				}
+			}
+		}
+	}
+
+	if current != nil {
+		closesAt = closesAt.UTC()
+		seconds := int(closesAt.Sub(now).Seconds())
+
+		return PoolAvailability{Open: true, Window: current.Name, ClosesAt: &closesAt, ClosesInSeconds: &seconds}
+	}
+
+	if next != nil {
+		opensAt = opensAt.UTC()
+		seconds := int(opensAt.Sub(now).Seconds())
+
+		return PoolAvailability{Window: next.Name, OpensAt: &opensAt, OpensInSeconds: &seconds}
+	}
+
+	return PoolAvailability{}
+}
@@ -0,0 +1,89 @@
+package matchmaking_entities_test
+
+import (
+	"testing"
+	"time"
+
+	matchmaking_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/matchmaking/entities"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPoolSchedule_Validate(t *testing.T) {
+	tests := []struct {
+		name     string
+		schedule matchmaking_entities.PoolSchedule
+		wantErr  bool
+	}{
+		{"valid", matchmaking_entities.PoolSchedule{Timezone: "America/Sao_Paulo", Windows: []matchmaking_entities.PoolScheduleWindow{{Name: "cup", Days: []time.Weekday{time.Saturday}, Start: "20:00", DurationMinutes: 120}}}, false},
+		{"utc by default", matchmaking_entities.PoolSchedule{Windows: []matchmaking_entities.PoolScheduleWindow{{Name: "xp", Start: "18:00", DurationMinutes: 60}}}, false},
+		{"unknown timezone", matchmaking_entities.PoolSchedule{Timezone: "Mars/Olympus", Windows: []matchmaking_entities.PoolScheduleWindow{{Name: "xp", Start: "18:00", DurationMinutes: 60}}}, true},
+		{"no windows", matchmaking_entities.PoolSchedule{}, true},
+		{"invalid start", matchmaking_entities.PoolSchedule{Windows: []matchmaking_entities.PoolScheduleWindow{{Name: "xp", Start: "6pm", DurationMinutes: 60}}}, true},
+		{"window too long", matchmaking_entities.PoolSchedule{Windows: []matchmaking_entities.PoolScheduleWindow{{Name: "xp", Start: "18:00", DurationMinutes: 24*60 + 1}}}, true},
+		{"invalid day", matchmaking_entities.PoolSchedule{Windows: []matchmaking_entities.PoolScheduleWindow{{Name: "xp", Days: []time.Weekday{7}, Start: "18:00", DurationMinutes: 60}}}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.schedule.Validate()
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestMatchmakingPool_AvailabilityAt(t *testing.T) {
+	// weekend cup: saturdays and sundays, 22:00 to 02:00 in Sao Paulo (UTC-3)
+	pool := matchmaking_entities.MatchmakingPool{
+		Enabled: true,
+		Schedule: &matchmaking_entities.PoolSchedule{
+			Timezone: "America/Sao_Paulo",
+			Windows: []matchmaking_entities.PoolScheduleWindow{
+				{Name: "weekend cup", Days: []time.Weekday{time.Saturday, time.Sunday}, Start: "22:00", DurationMinutes: 240},
+			},
+		},
+	}
+
+	saturdayOpening := time.Date(2026, time.October, 18, 1, 0, 0, 0, time.UTC) // saturday 22:00 local
+	sundayEarly := time.Date(2026, time.October, 18, 4, 30, 0, 0, time.UTC)    // sunday 01:30 local, still saturday's window
+
+	tests := []struct {
+		name          string
+		now           time.Time
+		wantOpen      bool
+		wantOpensAt   *time.Time
+		wantClosesAt  *time.Time
+		wantCountdown int
+	}{
+		{"friday night, opens saturday", saturdayOpening.Add(-24 * time.Hour), false, &saturdayOpening, nil, 24 * 3600},
+		{"after midnight of a window started the day before", sundayEarly, true, nil, timePtr(saturdayOpening.Add(4 * time.Hour)), 30 * 60},
+		{"between weekend windows", saturdayOpening.Add(6 * time.Hour), false, timePtr(saturdayOpening.Add(24 * time.Hour)), nil, 18 * 3600},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			availability := pool.AvailabilityAt(tt.now)
+
+			assert.Equal(t, tt.wantOpen, availability.Open)
+			assert.Equal(t, "weekend cup", availability.Window)
+
+			if tt.wantOpen {
+				assert.Equal(t, tt.wantClosesAt, availability.ClosesAt)
+				assert.Equal(t, tt.wantCountdown, *availability.ClosesInSeconds)
+			} else {
+				assert.Equal(t, tt.wantOpensAt, availability.OpensAt)
+				assert.Equal(t, tt.wantCountdown, *availability.OpensInSeconds)
+			}
+		})
+	}
+
+	assert.True(t, matchmaking_entities.MatchmakingPool{Enabled: true}.AvailabilityAt(sundayEarly).Open, "pools without schedule are always open")
+	assert.False(t, matchmaking_entities.MatchmakingPool{}.AvailabilityAt(sundayEarly).Open, "disabled pools are never open")
+}
+
+func timePtr(t time.Time) *time.Time {
+	return &t
+}
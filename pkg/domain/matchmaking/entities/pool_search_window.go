@@ -0,0 +1,52 @@
+package matchmaking_entities
+
+import (
+	"fmt"
+	"time"
+)
+
+// PoolSearchWindow widens the rating spread a ticket accepts while it waits: StepRating every StepSeconds, up to MaxRatingSpread.
+// Close matches are preferred while the queue can provide them, players waiting longer trade match quality for a shorter wait.
+type PoolSearchWindow struct {
+	StepSeconds     int `json:"step_seconds" bson:"step_seconds"`
+	StepRating      int `json:"step_rating" bson:"step_rating"`
+	MaxRatingSpread int `json:"max_rating_spread" bson:"max_rating_spread"`
+}
+
+// Validate checks the window widens (in steps) up to a spread wider than the pool spread.
+func (w PoolSearchWindow) Validate(poolSpread int) error {
+	if w.StepSeconds <= 0 || w.StepRating <= 0 {
+		return fmt.Errorf("search window step_seconds and step_rating must be positive")
+	}
+
+	if w.MaxRatingSpread <= poolSpread {
+		return fmt.Errorf("search window max_rating_spread must be wider than the pool max_rating_spread (%d)", poolSpread)
+	}
+
+	return nil
+}
+
+// RatingSpreadAt is the rating spread the ticket accepts at now: the pool spread, widened by the search window of the pool (if any)
+// for every full step waited.
+func (p MatchmakingPool) RatingSpreadAt(ticket QueueTicket, now time.Time) int {
+	w := p.SearchWindow
+	if w == nil || w.StepSeconds <= 0 || w.StepRating <= 0 {
+		return p.MaxRatingSpread
+	}
+
+	steps := int(now.Sub(ticket.EnqueuedAt) / (time.Duration(w.StepSeconds) * time.Second))
+	if steps <= 0 {
+		return p.MaxRatingSpread
+	}
+
+	return min(p.MaxRatingSpread+steps*w.StepRating, p.MaxSearchRatingSpread())
+}
+
+// MaxSearchRatingSpread is the widest spread any ticket of the pool can reach.
+func (p MatchmakingPool) MaxSearchRatingSpread() int {
+	if p.SearchWindow == nil || p.SearchWindow.MaxRatingSpread < p.MaxRatingSpread {
+		return p.MaxRatingSpread
+	}
+
+	return p.SearchWindow.MaxRatingSpread
+}
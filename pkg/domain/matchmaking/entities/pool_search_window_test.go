@@ -0,0 +1,64 @@
+package matchmaking_entities_test
+
+import (
+	"testing"
+	"time"
+
+	matchmaking_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/matchmaking/entities"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPoolSearchWindow_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		window  matchmaking_entities.PoolSearchWindow
+		wantErr bool
+	}{
+		{"valid", matchmaking_entities.PoolSearchWindow{StepSeconds: 30, StepRating: 50, MaxRatingSpread: 500}, false},
+		{"no step", matchmaking_entities.PoolSearchWindow{StepRating: 50, MaxRatingSpread: 500}, true},
+		{"no step rating", matchmaking_entities.PoolSearchWindow{StepSeconds: 30, MaxRatingSpread: 500}, true},
+		{"narrower than the pool", matchmaking_entities.PoolSearchWindow{StepSeconds: 30, StepRating: 50, MaxRatingSpread: 200}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.window.Validate(200)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestMatchmakingPool_RatingSpreadAt(t *testing.T) {
+	now := time.Date(2026, time.October, 16, 20, 0, 0, 0, time.UTC)
+
+	pool := matchmaking_entities.MatchmakingPool{
+		MaxRatingSpread: 200,
+		SearchWindow:    &matchmaking_entities.PoolSearchWindow{StepSeconds: 30, StepRating: 50, MaxRatingSpread: 400},
+	}
+
+	tests := []struct {
+		name     string
+		pool     matchmaking_entities.MatchmakingPool
+		waited   time.Duration
+		expected int
+	}{
+		{"just queued", pool, 0, 200},
+		{"before the first step", pool, 29 * time.Second, 200},
+		{"one step", pool, 30 * time.Second, 250},
+		{"three steps", pool, 100 * time.Second, 350},
+		{"capped", pool, 10 * time.Minute, 400},
+		{"no search window", matchmaking_entities.MatchmakingPool{MaxRatingSpread: 200}, 10 * time.Minute, 200},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ticket := matchmaking_entities.QueueTicket{EnqueuedAt: now.Add(-tt.waited)}
+
+			assert.Equal(t, tt.expected, tt.pool.RatingSpreadAt(ticket, now))
+		})
+	}
+}
@@ -0,0 +1,51 @@
+package matchmaking_entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+)
+
+// declines older than the decay no longer count towards the next cooldown
+const QueuePenaltyDecay = 24 * time.Hour
+
+// QueuePenaltyCooldowns escalate with every ready check declined (or missed) within the decay, the last one repeating.
+var QueuePenaltyCooldowns = []time.Duration{
+	time.Minute,
+	5 * time.Minute,
+	15 * time.Minute,
+	time.Hour,
+}
+
+// QueuePenalty keeps a user who keeps declining matches out of the queues for a while. One per user.
+type QueuePenalty struct {
+	ID             uuid.UUID            `json:"id" bson:"_id"`
+	UserID         uuid.UUID            `json:"user_id" bson:"user_id"`
+	Declines       int                  `json:"declines" bson:"declines"` // within the decay of the last decline
+	LastDeclinedAt time.Time            `json:"last_declined_at" bson:"last_declined_at"`
+	CooldownUntil  time.Time            `json:"cooldown_until" bson:"cooldown_until"`
+	ResourceOwner  common.ResourceOwner `json:"resource_owner" bson:"resource_owner"`
+	CreatedAt      time.Time            `json:"created_at" bson:"created_at"`
+	UpdatedAt      time.Time            `json:"updated_at" bson:"updated_at"`
+}
+
+func (p QueuePenalty) GetID() uuid.UUID {
+	return p.ID
+}
+
+// Decline counts a declined ready check and starts the next cooldown.
+func (p *QueuePenalty) Decline(now time.Time) {
+	if now.Sub(p.LastDeclinedAt) > QueuePenaltyDecay {
+		p.Declines = 0
+	}
+
+	p.Declines++
+	p.LastDeclinedAt = now
+	p.CooldownUntil = now.Add(QueuePenaltyCooldowns[min(p.Declines, len(QueuePenaltyCooldowns))-1])
+	p.UpdatedAt = now
+}
+
+func (p QueuePenalty) InCooldown(now time.Time) bool {
+	return now.Before(p.CooldownUntil)
+}
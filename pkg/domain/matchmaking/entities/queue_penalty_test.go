@@ -0,0 +1,33 @@
+package matchmaking_entities_test
+
+import (
+	"testing"
+	"time"
+
+	matchmaking_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/matchmaking/entities"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQueuePenalty_Decline(t *testing.T) {
+	now := time.Date(2026, 1, 1, 20, 0, 0, 0, time.UTC)
+
+	var penalty matchmaking_entities.QueuePenalty
+
+	expected := []time.Duration{time.Minute, 5 * time.Minute, 15 * time.Minute, time.Hour, time.Hour}
+	for i, cooldown := range expected {
+		declinedAt := now.Add(time.Duration(i) * time.Hour)
+		penalty.Decline(declinedAt)
+
+		assert.Equal(t, i+1, penalty.Declines)
+		assert.Equal(t, declinedAt.Add(cooldown), penalty.CooldownUntil)
+		assert.True(t, penalty.InCooldown(declinedAt))
+		assert.False(t, penalty.InCooldown(penalty.CooldownUntil))
+	}
+
+	// a day without declines starts over
+	later := penalty.LastDeclinedAt.Add(matchmaking_entities.QueuePenaltyDecay + time.Second)
+	penalty.Decline(later)
+
+	assert.Equal(t, 1, penalty.Declines)
+	assert.Equal(t, later.Add(time.Minute), penalty.CooldownUntil)
+}
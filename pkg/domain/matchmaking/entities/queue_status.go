@@ -0,0 +1,68 @@
+package matchmaking_entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// QueueStatus is the position of a ticket in its pool and how much longer it is expected to wait.
+type QueueStatus struct {
+	TicketID             uuid.UUID         `json:"ticket_id"`
+	PoolID               uuid.UUID         `json:"pool_id"`
+	Status               QueueTicketStatus `json:"status"`
+	LobbyID              *uuid.UUID        `json:"lobby_id,omitempty"`
+	BackfillSlotID       *uuid.UUID        `json:"backfill_slot_id,omitempty"` // set when matched into an ongoing match
+	Position             int               `json:"position"`                   // 1 = next in line (0 when no longer waiting)
+	WaitedSeconds        int               `json:"waited_seconds"`
+	EstimatedWaitSeconds *int              `json:"estimated_wait_seconds"` // null when the pool has no recent matches to estimate from
+	Stats                PoolQueueStats    `json:"stats"`
+	EstimatedAt          time.Time         `json:"estimated_at"`
+}
+
+// PoolQueueStats summarizes the matches formed in a pool during the last WindowSeconds.
+type PoolQueueStats struct {
+	WindowSeconds  int     `json:"window_seconds"`
+	PlayersMatched int     `json:"players_matched"`
+	AvgWaitSeconds float64 `json:"avg_wait_seconds"`
+}
+
+// NewPoolQueueStats computes the stats from the tickets matched during the window.
+func NewPoolQueueStats(window time.Duration, matched []QueueTicket) PoolQueueStats {
+	stats := PoolQueueStats{WindowSeconds: int(window.Seconds())}
+
+	var wait float64
+	for _, t := range matched {
+		if t.MatchedAt == nil {
+			continue
+		}
+
+		stats.PlayersMatched++
+		wait += t.MatchedAt.Sub(t.EnqueuedAt).Seconds()
+	}
+
+	if stats.PlayersMatched > 0 {
+		stats.AvgWaitSeconds = wait / float64(stats.PlayersMatched)
+	}
+
+	return stats
+}
+
+// EstimateWait returns the remaining wait of the player at position. It is the longest of the time the pool takes to serve
+// everyone up to the position (at the recent match rate) and what is left of the recent average wait.
+func (s PoolQueueStats) EstimateWait(position int, waited time.Duration) *int {
+	if s.PlayersMatched == 0 || s.WindowSeconds == 0 {
+		return nil
+	}
+
+	playersPerSecond := float64(s.PlayersMatched) / float64(s.WindowSeconds)
+
+	estimate := float64(position) / playersPerSecond
+	if remaining := s.AvgWaitSeconds - waited.Seconds(); remaining > estimate {
+		estimate = remaining
+	}
+
+	seconds := int(estimate + 0.5)
+
+	return &seconds
+}
@@ -0,0 +1,55 @@
+package matchmaking_entities_test
+
+import (
+	"testing"
+	"time"
+
+	matchmaking_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/matchmaking/entities"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewPoolQueueStats(t *testing.T) {
+	now := time.Now()
+	matchedAt := now.Add(-time.Minute)
+
+	matched := []matchmaking_entities.QueueTicket{
+		{EnqueuedAt: matchedAt.Add(-30 * time.Second), MatchedAt: &matchedAt},
+		{EnqueuedAt: matchedAt.Add(-90 * time.Second), MatchedAt: &matchedAt},
+		{EnqueuedAt: matchedAt.Add(-time.Hour)}, // not matched, ignored
+	}
+
+	stats := matchmaking_entities.NewPoolQueueStats(15*time.Minute, matched)
+
+	assert.Equal(t, 900, stats.WindowSeconds)
+	assert.Equal(t, 2, stats.PlayersMatched)
+	assert.Equal(t, 60.0, stats.AvgWaitSeconds)
+}
+
+func TestPoolQueueStats_EstimateWait(t *testing.T) {
+	// 10 players per minute, 2 minutes average wait
+	stats := matchmaking_entities.PoolQueueStats{WindowSeconds: 600, PlayersMatched: 100, AvgWaitSeconds: 120}
+
+	tests := []struct {
+		name     string
+		stats    matchmaking_entities.PoolQueueStats
+		position int
+		waited   time.Duration
+		want     *int
+	}{
+		{"no recent matches", matchmaking_entities.PoolQueueStats{WindowSeconds: 600}, 1, 0, nil},
+		{"average wait dominates for new players", stats, 1, 0, intPtr(120)},
+		{"average wait shrinks while waiting", stats, 1, 100 * time.Second, intPtr(20)},
+		{"match rate dominates deep in the queue", stats, 30, 0, intPtr(180)},
+		{"match rate when waited longer than average", stats, 2, 5 * time.Minute, intPtr(12)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.stats.EstimateWait(tt.position, tt.waited))
+		})
+	}
+}
+
+func intPtr(v int) *int {
+	return &v
+}
@@ -0,0 +1,78 @@
+package matchmaking_entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+)
+
+type QueueTicketStatus string
+
+const (
+	QueueTicketStatusWaiting   QueueTicketStatus = "waiting"
+	QueueTicketStatusMatched   QueueTicketStatus = "matched"
+	QueueTicketStatusCancelled QueueTicketStatus = "cancelled"
+	QueueTicketStatusExpired   QueueTicketStatus = "expired" // waited longer than the pool queue timeout
+)
+
+// QueueTicket is a player waiting in a pool. Its ID identifies the queue session (see PlayerMatchHistory.QueueSessionID).
+type QueueTicket struct {
+	ID             uuid.UUID            `json:"id" bson:"_id"`
+	PoolID         uuid.UUID            `json:"pool_id" bson:"pool_id"`
+	UserID         uuid.UUID            `json:"user_id" bson:"user_id"`
+	PlayerID       uuid.UUID            `json:"player_id" bson:"player_id"`
+	Rating         int                  `json:"rating" bson:"rating"`
+	Roles          []string             `json:"roles" bson:"roles"` // preferred roles, most preferred first (any role when empty)
+	Status         QueueTicketStatus    `json:"status" bson:"status"`
+	AcceptBackfill bool                 `json:"accept_backfill" bson:"accept_backfill"` // can be matched into a slot left by a player of an ongoing match
+	LobbyID        *uuid.UUID           `json:"lobby_id,omitempty" bson:"lobby_id"`
+	BackfillSlotID *uuid.UUID           `json:"backfill_slot_id,omitempty" bson:"backfill_slot_id"`
+	EnqueuedAt     time.Time            `json:"enqueued_at" bson:"enqueued_at"`
+	MatchedAt      *time.Time           `json:"matched_at,omitempty" bson:"matched_at"`
+	ResourceOwner  common.ResourceOwner `json:"resource_owner" bson:"resource_owner"`
+	CreatedAt      time.Time            `json:"created_at" bson:"created_at"`
+	UpdatedAt      time.Time            `json:"updated_at" bson:"updated_at"`
+}
+
+func (t QueueTicket) GetID() uuid.UUID {
+	return t.ID
+}
+
+// QueueTicketExpired is published when a ticket waited longer than the pool queue timeout (ie: to tell the player to queue again).
+type QueueTicketExpired struct {
+	TicketID      uuid.UUID            `json:"ticket_id"`
+	PoolID        uuid.UUID            `json:"pool_id"`
+	UserID        uuid.UUID            `json:"user_id"`
+	PlayerID      uuid.UUID            `json:"player_id"`
+	ResourceOwner common.ResourceOwner `json:"resource_owner"`
+	EnqueuedAt    time.Time            `json:"enqueued_at"`
+	ExpiredAt     time.Time            `json:"expired_at"`
+}
+
+func NewQueueTicketExpired(ticket QueueTicket) QueueTicketExpired {
+	return QueueTicketExpired{
+		TicketID:      ticket.ID,
+		PoolID:        ticket.PoolID,
+		UserID:        ticket.UserID,
+		PlayerID:      ticket.PlayerID,
+		ResourceOwner: ticket.ResourceOwner,
+		EnqueuedAt:    ticket.EnqueuedAt,
+		ExpiredAt:     ticket.UpdatedAt,
+	}
+}
+
+// CanPlay reports whether the player accepts the role (flex players accept any).
+func (t QueueTicket) CanPlay(role string) bool {
+	if len(t.Roles) == 0 {
+		return true
+	}
+
+	for _, r := range t.Roles {
+		if r == role {
+			return true
+		}
+	}
+
+	return false
+}
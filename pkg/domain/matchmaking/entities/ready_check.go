@@ -0,0 +1,120 @@
+package matchmaking_entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// bounds of MatchmakingPool.ReadyCheckTimeoutSeconds
+	MinReadyCheckTimeout = 5 * time.Second
+	MaxReadyCheckTimeout = 2 * time.Minute
+)
+
+// LobbyReadyCheck asks the players of a lobby formed by the matcher to accept the match before the deadline. A single decline (or
+// a player missing the deadline) cancels the lobby.
+type LobbyReadyCheck struct {
+	TimeoutSeconds int         `json:"timeout_seconds" bson:"timeout_seconds"`
+	Deadline       time.Time   `json:"deadline" bson:"deadline"`
+	Accepted       []uuid.UUID `json:"accepted" bson:"accepted"`
+	Declined       []uuid.UUID `json:"declined" bson:"declined"` // declined or missed the deadline
+	StartedAt      time.Time   `json:"started_at" bson:"started_at"`
+	CompletedAt    *time.Time  `json:"completed_at,omitempty" bson:"completed_at"`
+}
+
+func (c LobbyReadyCheck) IsCompleted() bool {
+	return c.CompletedAt != nil
+}
+
+func (c LobbyReadyCheck) HasAccepted(userID uuid.UUID) bool {
+	for _, id := range c.Accepted {
+		if id == userID {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (c LobbyReadyCheck) HasDeclined(userID uuid.UUID) bool {
+	for _, id := range c.Declined {
+		if id == userID {
+			return true
+		}
+	}
+
+	return false
+}
+
+// IsExpired reports whether the players ran out of time to accept.
+func (c LobbyReadyCheck) IsExpired(now time.Time) bool {
+	return !c.IsCompleted() && c.Deadline.Before(now)
+}
+
+// StartReadyCheck holds the lobby until every player accepts (or the timeout runs out).
+func (l *Lobby) StartReadyCheck(now time.Time, timeout time.Duration) {
+	l.ReadyCheck = &LobbyReadyCheck{
+		TimeoutSeconds: int(timeout.Seconds()),
+		Deadline:       now.Add(timeout),
+		Accepted:       []uuid.UUID{},
+		Declined:       []uuid.UUID{},
+		StartedAt:      now,
+	}
+
+	l.Status = LobbyStatusReadyCheck
+	l.UpdatedAt = now
+}
+
+// PendingReadyCheck returns the players that haven't accepted yet.
+func (l Lobby) PendingReadyCheck() []uuid.UUID {
+	pending := make([]uuid.UUID, 0, len(l.Players))
+	if l.ReadyCheck == nil {
+		return pending
+	}
+
+	for _, p := range l.Players {
+		if !l.ReadyCheck.HasAccepted(p.UserID) {
+			pending = append(pending, p.UserID)
+		}
+	}
+
+	return pending
+}
+
+// AcceptReadyCheck records the player as ready. Once every player accepted the lobby moves on: ready to play, or forming for the
+// captain draft.
+func (l *Lobby) AcceptReadyCheck(userID uuid.UUID, now time.Time) {
+	if l.ReadyCheck.HasAccepted(userID) {
+		return
+	}
+
+	l.ReadyCheck.Accepted = append(l.ReadyCheck.Accepted, userID)
+	l.UpdatedAt = now
+
+	if len(l.PendingReadyCheck()) > 0 {
+		return
+	}
+
+	l.ReadyCheck.CompletedAt = &now
+
+	l.Status = LobbyStatusReady
+	if l.Mode == LobbyModeCaptainDraft {
+		l.Status = LobbyStatusForming
+	}
+}
+
+// FailReadyCheck cancels the lobby, recording the players that declined (or missed the deadline).
+func (l *Lobby) FailReadyCheck(declined []uuid.UUID, now time.Time) {
+	for _, userID := range declined {
+		if !l.ReadyCheck.HasDeclined(userID) {
+			l.ReadyCheck.Declined = append(l.ReadyCheck.Declined, userID)
+		}
+	}
+
+	l.ReadyCheck.CompletedAt = &now
+
+	l.Status = LobbyStatusCancelled
+	l.CancelReason = LobbyCancelReasonReadyCheckFailed
+	l.UpdatedAt = now
+}
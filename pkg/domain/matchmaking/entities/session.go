@@ -0,0 +1,68 @@
+package matchmaking_entities
+
+import (
+	"fmt"
+	"hash/fnv"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// MatchmakingSession is the matchmaking state of a user, for the clients restoring it once reconnected: the ticket waiting in a
+// queue (with its position), the lobby the user plays in and the ready check awaiting their answer.
+type MatchmakingSession struct {
+	UserID        uuid.UUID      `json:"user_id"`
+	Queue         *QueueStatus   `json:"queue,omitempty"`
+	Lobby         *Lobby         `json:"lobby,omitempty"`
+	PendingAccept *PendingAccept `json:"pending_accept,omitempty"`
+	Version       string         `json:"version"` // changes with the state (not with the wait times)
+	RestoredAt    time.Time      `json:"restored_at"`
+}
+
+// PendingAccept is the ready check of the lobby of the user, while it's running.
+type PendingAccept struct {
+	LobbyID  uuid.UUID `json:"lobby_id"`
+	Deadline time.Time `json:"deadline"`
+	Accepted bool      `json:"accepted"` // the user already accepted, waiting for the others
+	Pending  int       `json:"pending"`  // players that haven't accepted yet
+}
+
+func NewMatchmakingSession(userID uuid.UUID, queue *QueueStatus, lobby *Lobby, now time.Time) MatchmakingSession {
+	session := MatchmakingSession{
+		UserID:     userID,
+		Queue:      queue,
+		Lobby:      lobby,
+		RestoredAt: now,
+	}
+
+	if lobby != nil && lobby.Status == LobbyStatusReadyCheck && lobby.ReadyCheck != nil && !lobby.ReadyCheck.IsCompleted() {
+		session.PendingAccept = &PendingAccept{
+			LobbyID:  lobby.ID,
+			Deadline: lobby.ReadyCheck.Deadline,
+			Accepted: lobby.ReadyCheck.HasAccepted(userID),
+			Pending:  len(lobby.PendingReadyCheck()),
+		}
+	}
+
+	session.Version = session.version()
+
+	return session
+}
+
+func (s MatchmakingSession) version() string {
+	h := fnv.New64a()
+
+	if s.Queue != nil {
+		fmt.Fprintf(h, "queue:%s:%s:%d;", s.Queue.TicketID, s.Queue.Status, s.Queue.Position)
+	}
+
+	if s.Lobby != nil {
+		fmt.Fprintf(h, "lobby:%s:%s:%d;", s.Lobby.ID, s.Lobby.Status, s.Lobby.UpdatedAt.UnixNano())
+	}
+
+	if s.PendingAccept != nil {
+		fmt.Fprintf(h, "accept:%t:%d;", s.PendingAccept.Accepted, s.PendingAccept.Pending)
+	}
+
+	return fmt.Sprintf("%016x", h.Sum64())
+}
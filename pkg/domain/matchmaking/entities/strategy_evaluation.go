@@ -0,0 +1,33 @@
+package matchmaking_entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+)
+
+// StrategyEvaluation summarizes the matches a strategy formed (or would have formed, when Shadow) from a pool queue snapshot. Evaluations of the same matcher run share the RunID.
+type StrategyEvaluation struct {
+	ID                uuid.UUID            `json:"id" bson:"_id"`
+	RunID             uuid.UUID            `json:"run_id" bson:"run_id"`
+	PoolID            uuid.UUID            `json:"pool_id" bson:"pool_id"`
+	Strategy          string               `json:"strategy" bson:"strategy"`
+	Shadow            bool                 `json:"shadow" bson:"shadow"`
+	TicketsConsidered int                  `json:"tickets_considered" bson:"tickets_considered"`
+	MatchesFormed     int                  `json:"matches_formed" bson:"matches_formed"`
+	PlayersMatched    int                  `json:"players_matched" bson:"players_matched"`
+	AvgRatingSpread   float64              `json:"avg_rating_spread" bson:"avg_rating_spread"`
+	AvgTeamRatingDiff float64              `json:"avg_team_rating_diff" bson:"avg_team_rating_diff"`
+	AvgWaitSeconds    float64              `json:"avg_wait_seconds" bson:"avg_wait_seconds"`
+	RolesSatisfied    float64              `json:"roles_satisfied" bson:"roles_satisfied"` // share of matched players playing a preferred role (1 when the pool has no roles)
+	ElapsedMicros     int64                `json:"elapsed_micros" bson:"elapsed_micros"`
+	EvaluatedAt       time.Time            `json:"evaluated_at" bson:"evaluated_at"`
+	ResourceOwner     common.ResourceOwner `json:"resource_owner" bson:"resource_owner"`
+	CreatedAt         time.Time            `json:"created_at" bson:"created_at"`
+	UpdatedAt         time.Time            `json:"updated_at" bson:"updated_at"`
+}
+
+func (e StrategyEvaluation) GetID() uuid.UUID {
+	return e.ID
+}
@@ -0,0 +1,78 @@
+package matchmaking_entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type VoiceModerationAction string
+
+const (
+	VoiceModerationMute   VoiceModerationAction = "mute"
+	VoiceModerationUnmute VoiceModerationAction = "unmute"
+	VoiceModerationKick   VoiceModerationAction = "kick"
+)
+
+// LobbyVoiceChannel is the temporary voice channel of a lobby, created when the lobby is ready and deleted once it is finished.
+type LobbyVoiceChannel struct {
+	Provider      string      `json:"provider" bson:"provider"`
+	ChannelID     string      `json:"channel_id" bson:"channel_id"`
+	MutedUserIDs  []uuid.UUID `json:"muted_user_ids" bson:"muted_user_ids"`
+	KickedUserIDs []uuid.UUID `json:"kicked_user_ids" bson:"kicked_user_ids"`
+	CreatedAt     time.Time   `json:"created_at" bson:"created_at"`
+}
+
+func (c LobbyVoiceChannel) IsKicked(userID uuid.UUID) bool {
+	return containsUserID(c.KickedUserIDs, userID)
+}
+
+func (c LobbyVoiceChannel) IsMuted(userID uuid.UUID) bool {
+	return containsUserID(c.MutedUserIDs, userID)
+}
+
+// VoiceInvite holds what a client needs to join a lobby voice channel.
+type VoiceInvite struct {
+	Provider  string    `json:"provider"`
+	ChannelID string    `json:"channel_id"`
+	URL       string    `json:"url"`
+	Token     string    `json:"token,omitempty"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+func containsUserID(ids []uuid.UUID, userID uuid.UUID) bool {
+	for _, id := range ids {
+		if id == userID {
+			return true
+		}
+	}
+
+	return false
+}
+
+func removeUserID(ids []uuid.UUID, userID uuid.UUID) []uuid.UUID {
+	result := make([]uuid.UUID, 0, len(ids))
+	for _, id := range ids {
+		if id != userID {
+			result = append(result, id)
+		}
+	}
+
+	return result
+}
+
+// Apply records the moderation action on the channel state.
+func (c *LobbyVoiceChannel) Apply(action VoiceModerationAction, userID uuid.UUID) {
+	switch action {
+	case VoiceModerationMute:
+		if !c.IsMuted(userID) {
+			c.MutedUserIDs = append(c.MutedUserIDs, userID)
+		}
+	case VoiceModerationUnmute:
+		c.MutedUserIDs = removeUserID(c.MutedUserIDs, userID)
+	case VoiceModerationKick:
+		if !c.IsKicked(userID) {
+			c.KickedUserIDs = append(c.KickedUserIDs, userID)
+		}
+	}
+}
@@ -0,0 +1,142 @@
+package matchmaking
+
+import (
+	"fmt"
+
+	"github.com/google/uuid"
+)
+
+// Lobby Not Found Error
+type LobbyNotFoundError struct {
+	Message string
+}
+
+func (e *LobbyNotFoundError) Error() string {
+	return e.Message
+}
+
+func NewLobbyNotFoundError(lobbyID uuid.UUID) *LobbyNotFoundError {
+	return &LobbyNotFoundError{
+		Message: fmt.Sprintf("lobby %s not found", lobbyID),
+	}
+}
+
+// Lobby Forbidden Error (caller isn't allowed to perform the action on the lobby)
+type LobbyForbiddenError struct {
+	Message string
+}
+
+func (e *LobbyForbiddenError) Error() string {
+	return e.Message
+}
+
+func NewLobbyForbiddenError(message string) *LobbyForbiddenError {
+	return &LobbyForbiddenError{
+		Message: message,
+	}
+}
+
+// Lobby State Error (action not allowed in the current lobby state)
+type LobbyStateError struct {
+	Message string
+}
+
+func (e *LobbyStateError) Error() string {
+	return e.Message
+}
+
+func NewLobbyStateError(message string) *LobbyStateError {
+	return &LobbyStateError{
+		Message: message,
+	}
+}
+
+// Match Not Found Error (match linked to a lobby)
+type MatchNotFoundError struct {
+	Message string
+}
+
+func (e *MatchNotFoundError) Error() string {
+	return e.Message
+}
+
+func NewMatchNotFoundError(matchID uuid.UUID) *MatchNotFoundError {
+	return &MatchNotFoundError{
+		Message: fmt.Sprintf("match %s not found", matchID),
+	}
+}
+
+// Match Linked Error (match already linked to a lobby)
+type MatchLinkedError struct {
+	Message string
+}
+
+func (e *MatchLinkedError) Error() string {
+	return e.Message
+}
+
+func NewMatchLinkedError(matchID uuid.UUID) *MatchLinkedError {
+	return &MatchLinkedError{
+		Message: fmt.Sprintf("match %s is already linked to a lobby", matchID),
+	}
+}
+
+// Pool Not Found Error
+type PoolNotFoundError struct {
+	Message string
+}
+
+func (e *PoolNotFoundError) Error() string {
+	return e.Message
+}
+
+func NewPoolNotFoundError(poolID uuid.UUID) *PoolNotFoundError {
+	return &PoolNotFoundError{
+		Message: fmt.Sprintf("matchmaking pool %s not found", poolID),
+	}
+}
+
+// Invalid Pool Error (pool configuration rejected)
+type InvalidPoolError struct {
+	Message string
+}
+
+func (e *InvalidPoolError) Error() string {
+	return e.Message
+}
+
+func NewInvalidPoolError(message string) *InvalidPoolError {
+	return &InvalidPoolError{
+		Message: message,
+	}
+}
+
+// Queue State Error (ie: already queued, ticket no longer waiting)
+type QueueStateError struct {
+	Message string
+}
+
+func (e *QueueStateError) Error() string {
+	return e.Message
+}
+
+func NewQueueStateError(message string) *QueueStateError {
+	return &QueueStateError{
+		Message: message,
+	}
+}
+
+// Invalid Economy Config Error (economy terms rejected)
+type InvalidEconomyConfigError struct {
+	Message string
+}
+
+func (e *InvalidEconomyConfigError) Error() string {
+	return e.Message
+}
+
+func NewInvalidEconomyConfigError(message string) *InvalidEconomyConfigError {
+	return &InvalidEconomyConfigError{
+		Message: message,
+	}
+}
@@ -0,0 +1,134 @@
+package matchmaking_in
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	matchmaking_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/matchmaking/entities"
+)
+
+// SyncLobbyVoiceChannelsCommand creates the voice channel of ready lobbies and tears down the ones of finished lobbies.
+type SyncLobbyVoiceChannelsCommand interface {
+	// Exec returns how many channels were provisioned and torn down.
+	Exec(ctx context.Context) (int, int, error)
+}
+
+// JoinLobbyVoiceCommand issues a voice channel invite for the user in context (must be a lobby player).
+type JoinLobbyVoiceCommand interface {
+	Exec(ctx context.Context, lobbyID uuid.UUID) (*matchmaking_entities.VoiceInvite, error)
+}
+
+// ModerateLobbyVoiceCommand mutes, unmutes or kicks a lobby player from the voice channel (lobby leader only).
+type ModerateLobbyVoiceCommand interface {
+	Exec(ctx context.Context, lobbyID uuid.UUID, targetUserID uuid.UUID, action matchmaking_entities.VoiceModerationAction) (*matchmaking_entities.Lobby, error)
+}
+
+// StartCaptainDraftCommand starts the captain draft of a captain_draft lobby (lobby leader only).
+type StartCaptainDraftCommand interface {
+	Exec(ctx context.Context, lobbyID uuid.UUID) (*matchmaking_entities.Lobby, error)
+}
+
+// PickDraftPlayerCommand picks a player for the team of the captain in context, when on the clock.
+type PickDraftPlayerCommand interface {
+	Exec(ctx context.Context, lobbyID uuid.UUID, userID uuid.UUID) (*matchmaking_entities.Lobby, error)
+}
+
+// ExpireDraftPicksCommand auto-picks on behalf of captains whose pick timer ran out.
+type ExpireDraftPicksCommand interface {
+	// Exec returns how many picks were made.
+	Exec(ctx context.Context) (int, error)
+}
+
+// RespondReadyCheckCommand accepts or declines the ready check of a lobby on behalf of the user in context (must be a lobby player).
+// A decline cancels the lobby: the other players are queued again and the user is penalized with a queue cooldown.
+type RespondReadyCheckCommand interface {
+	Exec(ctx context.Context, lobbyID uuid.UUID, accept bool) (*matchmaking_entities.Lobby, error)
+}
+
+// ExpireReadyChecksCommand cancels the lobbies whose ready check ran out: players that accepted are queued again, the others penalized.
+type ExpireReadyChecksCommand interface {
+	// Exec returns how many lobbies were cancelled.
+	Exec(ctx context.Context) (int, error)
+}
+
+// ExpireStaleLobbiesCommand cancels the lobbies that didn't start their match within the pool lobby timeout (queueing their players
+// again) and expires the tickets waiting longer than the pool queue timeout.
+type ExpireStaleLobbiesCommand interface {
+	// Exec returns how many lobbies were cancelled and tickets expired.
+	Exec(ctx context.Context) (int, int, error)
+}
+
+// LinkLobbyMatchCommand links the lobby to the replay match it played, persisting the draft order on the match (lobby leader only).
+type LinkLobbyMatchCommand interface {
+	Exec(ctx context.Context, lobbyID uuid.UUID, matchID uuid.UUID) (*matchmaking_entities.Lobby, error)
+}
+
+// ReportLobbyResultCommand reports the result of the match linked to the lobby (lobby leader only), completing the lobby. The result
+// is published (match.completed) to update the ratings of its players and squads.
+type ReportLobbyResultCommand interface {
+	Exec(ctx context.Context, lobbyID uuid.UUID, teams []matchmaking_entities.LobbyTeamResult) (*matchmaking_entities.Lobby, error)
+}
+
+// AbandonLobbyCommand reports a player that left a ready or ongoing match (the player itself or the lobby leader), opening a backfill slot.
+type AbandonLobbyCommand interface {
+	Exec(ctx context.Context, lobbyID uuid.UUID, userID uuid.UUID) (*matchmaking_entities.Lobby, error)
+}
+
+// DrainQueueCommand cancels the waiting tickets of a pool (client level, internal), ie: while the matcher of the pool is broken. The
+// pool stays open, players can queue again.
+type DrainQueueCommand interface {
+	// Exec returns how many tickets were cancelled.
+	Exec(ctx context.Context, poolID uuid.UUID) (int, error)
+}
+
+// CreateMatchmakingPoolCommand creates a pool (client level, internal).
+type CreateMatchmakingPoolCommand interface {
+	Exec(ctx context.Context, pool matchmaking_entities.MatchmakingPool) (*matchmaking_entities.MatchmakingPool, error)
+}
+
+// UpdatePoolStrategyCommand changes the strategy (and shadow strategies) of a pool (client level, internal).
+type UpdatePoolStrategyCommand interface {
+	Exec(ctx context.Context, poolID uuid.UUID, strategy string, shadowStrategies []string) (*matchmaking_entities.MatchmakingPool, error)
+}
+
+// UpdatePoolScheduleCommand sets the playlist windows of a pool, a nil schedule keeps the pool always open (client level, internal).
+type UpdatePoolScheduleCommand interface {
+	Exec(ctx context.Context, poolID uuid.UUID, schedule *matchmaking_entities.PoolSchedule) (*matchmaking_entities.MatchmakingPool, error)
+}
+
+// CreateEconomyConfigCommand publishes a new version of the economy terms of a game mode, effective from its EffectiveFrom (now when
+// empty). Lobbies formed before keep the version they were created with (client level, internal).
+type CreateEconomyConfigCommand interface {
+	Exec(ctx context.Context, config matchmaking_entities.EconomyConfig) (*matchmaking_entities.EconomyConfig, error)
+}
+
+type EnqueuePlayerCommand struct {
+	PoolID         uuid.UUID `json:"pool_id"`
+	PlayerID       uuid.UUID `json:"player_id"`
+	Roles          []string  `json:"roles"`
+	AcceptBackfill bool      `json:"accept_backfill"`
+}
+
+// EnqueuePlayerCommandHandler queues the user in context (one waiting ticket per user, not while in a queue cooldown).
+type EnqueuePlayerCommandHandler interface {
+	Exec(ctx context.Context, cmd EnqueuePlayerCommand) (*matchmaking_entities.QueueTicket, error)
+}
+
+// LeaveQueueCommand cancels the waiting ticket of the user in context.
+type LeaveQueueCommand interface {
+	Exec(ctx context.Context, ticketID uuid.UUID) (*matchmaking_entities.QueueTicket, error)
+}
+
+// RunMatchmakingCommand runs the matcher once over every enabled pool: fills open backfill slots, then creates a lobby per match formed.
+// Waiting tickets of pools outside their schedule windows are cancelled.
+type RunMatchmakingCommand interface {
+	// Exec returns how many lobbies were created.
+	Exec(ctx context.Context) (int, error)
+}
+
+// RecoverMatchmakingQueuesCommand marks as matched the waiting tickets of players already placed in a lobby by a matcher that died
+// before updating their tickets. Run by a standby matcher when taking over.
+type RecoverMatchmakingQueuesCommand interface {
+	// Exec returns how many tickets were recovered.
+	Exec(ctx context.Context) (int, error)
+}
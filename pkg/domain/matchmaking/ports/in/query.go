@@ -0,0 +1,33 @@
+package matchmaking_in
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	matchmaking_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/matchmaking/entities"
+)
+
+// GetLobbyQuery returns the lobby to one of its players.
+type GetLobbyQuery interface {
+	Exec(ctx context.Context, lobbyID uuid.UUID) (*matchmaking_entities.Lobby, error)
+}
+
+type MatchmakingPoolReader interface {
+	common.Searchable[matchmaking_entities.MatchmakingPool]
+}
+
+type StrategyEvaluationReader interface {
+	common.Searchable[matchmaking_entities.StrategyEvaluation]
+}
+
+// GetQueueStatusQuery returns the position and estimated wait of a ticket to the user that queued it.
+type GetQueueStatusQuery interface {
+	Exec(ctx context.Context, ticketID uuid.UUID) (*matchmaking_entities.QueueStatus, error)
+}
+
+// GetMatchmakingSessionQuery restores the matchmaking state of the user in context (ie: once reconnected): the ticket waiting in a
+// queue, the active lobby and the ready check awaiting their answer.
+type GetMatchmakingSessionQuery interface {
+	Exec(ctx context.Context) (*matchmaking_entities.MatchmakingSession, error)
+}
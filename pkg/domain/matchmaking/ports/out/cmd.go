@@ -0,0 +1,64 @@
+package matchmaking_out
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	matchmaking_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/matchmaking/entities"
+	replay_entity "github.com/psavelis/team-pro/replay-api/pkg/domain/replay/entities"
+)
+
+type MatchmakingPoolWriter interface {
+	Create(ctx context.Context, pool *matchmaking_entities.MatchmakingPool) (*matchmaking_entities.MatchmakingPool, error)
+	Update(ctx context.Context, pool *matchmaking_entities.MatchmakingPool) (*matchmaking_entities.MatchmakingPool, error)
+}
+
+type QueueTicketWriter interface {
+	Create(ctx context.Context, ticket *matchmaking_entities.QueueTicket) (*matchmaking_entities.QueueTicket, error)
+	Update(ctx context.Context, ticket *matchmaking_entities.QueueTicket) (*matchmaking_entities.QueueTicket, error)
+}
+
+type QueuePenaltyWriter interface {
+	Create(ctx context.Context, penalty *matchmaking_entities.QueuePenalty) (*matchmaking_entities.QueuePenalty, error)
+	Update(ctx context.Context, penalty *matchmaking_entities.QueuePenalty) (*matchmaking_entities.QueuePenalty, error)
+}
+
+type EconomyConfigWriter interface {
+	Create(ctx context.Context, config *matchmaking_entities.EconomyConfig) (*matchmaking_entities.EconomyConfig, error)
+}
+
+type StrategyEvaluationWriter interface {
+	CreateMany(ctx context.Context, evaluations []*matchmaking_entities.StrategyEvaluation) error
+}
+
+type LobbyWriter interface {
+	Create(ctx context.Context, lobby *matchmaking_entities.Lobby) (*matchmaking_entities.Lobby, error)
+	Update(ctx context.Context, lobby *matchmaking_entities.Lobby) (*matchmaking_entities.Lobby, error)
+}
+
+type LobbyEventPublisher interface {
+	PublishLobbyCreated(ctx context.Context, event matchmaking_entities.LobbyCreated) error
+	PublishLobbyCancelled(ctx context.Context, event matchmaking_entities.LobbyCancelled) error
+	PublishQueueTicketExpired(ctx context.Context, event matchmaking_entities.QueueTicketExpired) error
+	PublishMatchCompleted(ctx context.Context, event matchmaking_entities.MatchCompleted) error
+}
+
+// VoiceChannelProvider manages temporary voice channels on an external provider (ie: LiveKit, Discord). Members are identified by their user ID.
+type VoiceChannelProvider interface {
+	Name() string
+	CreateChannel(ctx context.Context, lobby matchmaking_entities.Lobby) (string, error)
+	Invite(ctx context.Context, channelID string, userID uuid.UUID, muted bool) (*matchmaking_entities.VoiceInvite, error)
+	SetMuted(ctx context.Context, channelID string, userID uuid.UUID, muted bool) error
+	Kick(ctx context.Context, channelID string, userID uuid.UUID) error
+	DeleteChannel(ctx context.Context, channelID string) error
+}
+
+// LobbyMatchWriter links a replay match to the lobby that played it (projected to the match history of its players).
+type LobbyMatchWriter interface {
+	// AttachLobby returns false when the match doesn't exist (for the owner in context), and a MatchLinkedError when it's already linked
+	// to a lobby.
+	AttachLobby(ctx context.Context, matchID uuid.UUID, lobbyID uuid.UUID, draft *replay_entity.MatchDraft, queueSessions []replay_entity.MatchQueueSession) (bool, error)
+	// AttachSettlement records the settlement of the lobby on its match (of the tenant in context), returns false when the match isn't
+	// linked to the lobby.
+	AttachSettlement(ctx context.Context, matchID uuid.UUID, lobbyID uuid.UUID, settlement replay_entity.MatchSettlement) (bool, error)
+}
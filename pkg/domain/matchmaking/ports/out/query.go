@@ -0,0 +1,41 @@
+package matchmaking_out
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	matchmaking_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/matchmaking/entities"
+)
+
+type LobbyReader interface {
+	common.Searchable[matchmaking_entities.Lobby]
+	GetByID(ctx context.Context, lobbyID uuid.UUID) (*matchmaking_entities.Lobby, error)
+}
+
+type MatchmakingPoolReader interface {
+	common.Searchable[matchmaking_entities.MatchmakingPool]
+	GetByID(ctx context.Context, poolID uuid.UUID) (*matchmaking_entities.MatchmakingPool, error)
+}
+
+type QueueTicketReader interface {
+	common.Searchable[matchmaking_entities.QueueTicket]
+	GetByID(ctx context.Context, ticketID uuid.UUID) (*matchmaking_entities.QueueTicket, error)
+}
+
+type QueuePenaltyReader interface {
+	common.Searchable[matchmaking_entities.QueuePenalty]
+}
+
+type EconomyConfigReader interface {
+	common.Searchable[matchmaking_entities.EconomyConfig]
+}
+
+type StrategyEvaluationReader interface {
+	common.Searchable[matchmaking_entities.StrategyEvaluation]
+}
+
+// PlayerRatingReader provides the current matchmaking rating of a player.
+type PlayerRatingReader interface {
+	GetRating(ctx context.Context, gameID common.GameIDKey, playerID uuid.UUID) (int, error)
+}
@@ -0,0 +1,76 @@
+package matchmaking_services
+
+import (
+	"context"
+	"time"
+
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	matchmaking_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/matchmaking/entities"
+	matchmaking_in "github.com/psavelis/team-pro/replay-api/pkg/domain/matchmaking/ports/in"
+	matchmaking_out "github.com/psavelis/team-pro/replay-api/pkg/domain/matchmaking/ports/out"
+)
+
+type MatchmakingPoolQueryService struct {
+	common.BaseQueryService[matchmaking_entities.MatchmakingPool]
+}
+
+func NewMatchmakingPoolQueryService(poolReader matchmaking_out.MatchmakingPoolReader) matchmaking_in.MatchmakingPoolReader {
+	queryableFields := map[string]bool{
+		"ID":               true,
+		"Name":             true,
+		"GameID":           true,
+		"RegionID":         true,
+		"Mode":             true,
+		"TeamSize":         true,
+		"Enabled":          true,
+		"Strategy":         true,
+		"ShadowStrategies": common.DENY,
+		"ResourceOwner":    common.DENY,
+		"CreatedAt":        true,
+		"UpdatedAt":        true,
+	}
+
+	readableFields := map[string]bool{
+		"ID":               true,
+		"Name":             true,
+		"GameID":           true,
+		"RegionID":         true,
+		"Mode":             true,
+		"TeamSize":         true,
+		"MaxRatingSpread":  true,
+		"RoleSlots":        true,
+		"Enabled":          true,
+		"Schedule":         true,
+		"Strategy":         true,
+		"ShadowStrategies": true,
+		"ResourceOwner":    common.DENY,
+		"CreatedAt":        true,
+		"UpdatedAt":        true,
+	}
+
+	return &MatchmakingPoolQueryService{
+		common.BaseQueryService[matchmaking_entities.MatchmakingPool]{
+			Reader:          poolReader.(common.Searchable[matchmaking_entities.MatchmakingPool]),
+			QueryableFields: queryableFields,
+			ReadableFields:  readableFields,
+			MaxPageSize:     100,
+			Audience:        common.ClientApplicationAudienceIDKey,
+		},
+	}
+}
+
+// Search returns the pools with their current availability (open now, or the countdown to the next window).
+func (service *MatchmakingPoolQueryService) Search(ctx context.Context, s common.Search) ([]matchmaking_entities.MatchmakingPool, error) {
+	pools, err := service.BaseQueryService.Search(ctx, s)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	for i := range pools {
+		availability := pools[i].AvailabilityAt(now)
+		pools[i].Availability = &availability
+	}
+
+	return pools, nil
+}
@@ -0,0 +1,55 @@
+package matchmaking_services
+
+import (
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	matchmaking_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/matchmaking/entities"
+	matchmaking_in "github.com/psavelis/team-pro/replay-api/pkg/domain/matchmaking/ports/in"
+	matchmaking_out "github.com/psavelis/team-pro/replay-api/pkg/domain/matchmaking/ports/out"
+)
+
+type StrategyEvaluationQueryService struct {
+	common.BaseQueryService[matchmaking_entities.StrategyEvaluation]
+}
+
+func NewStrategyEvaluationQueryService(evaluationReader matchmaking_out.StrategyEvaluationReader) matchmaking_in.StrategyEvaluationReader {
+	queryableFields := map[string]bool{
+		"ID":            true,
+		"RunID":         true,
+		"PoolID":        true,
+		"Strategy":      true,
+		"Shadow":        true,
+		"MatchesFormed": true,
+		"EvaluatedAt":   true,
+		"ResourceOwner": common.DENY,
+		"CreatedAt":     true,
+		"UpdatedAt":     true,
+	}
+
+	readableFields := map[string]bool{
+		"ID":                true,
+		"RunID":             true,
+		"PoolID":            true,
+		"Strategy":          true,
+		"Shadow":            true,
+		"TicketsConsidered": true,
+		"MatchesFormed":     true,
+		"PlayersMatched":    true,
+		"AvgRatingSpread":   true,
+		"AvgTeamRatingDiff": true,
+		"AvgWaitSeconds":    true,
+		"RolesSatisfied":    true,
+		"ElapsedMicros":     true,
+		"EvaluatedAt":       true,
+		"ResourceOwner":     common.DENY,
+		"CreatedAt":         true,
+		"UpdatedAt":         true,
+	}
+
+	return &common.BaseQueryService[matchmaking_entities.StrategyEvaluation]{
+		Reader:          evaluationReader.(common.Searchable[matchmaking_entities.StrategyEvaluation]),
+		QueryableFields: queryableFields,
+		ReadableFields:  readableFields,
+		MaxPageSize:     100,
+		Audience:        common.ClientApplicationAudienceIDKey,
+	}
+}
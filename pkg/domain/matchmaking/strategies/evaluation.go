@@ -0,0 +1,55 @@
+package matchmaking_strategies
+
+import (
+	"time"
+
+	matchmaking_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/matchmaking/entities"
+)
+
+// Summarize computes the quality metrics of the proposals a strategy made from the given tickets. IDs, run and ownership are left to the caller.
+func Summarize(strategy string, pool matchmaking_entities.MatchmakingPool, tickets []matchmaking_entities.QueueTicket, proposals []MatchProposal, now time.Time, elapsed time.Duration) matchmaking_entities.StrategyEvaluation {
+	e := matchmaking_entities.StrategyEvaluation{
+		PoolID:            pool.ID,
+		Strategy:          strategy,
+		TicketsConsidered: len(tickets),
+		MatchesFormed:     len(proposals),
+		RolesSatisfied:    1,
+		ElapsedMicros:     elapsed.Microseconds(),
+		EvaluatedAt:       now,
+	}
+
+	if len(proposals) == 0 {
+		return e
+	}
+
+	var spread, diff, wait float64
+	preferred := 0
+
+	for _, p := range proposals {
+		spread += float64(p.RatingSpread())
+		diff += p.TeamRatingDiff()
+
+		for _, t := range p.Tickets() {
+			e.PlayersMatched++
+			wait += now.Sub(t.EnqueuedAt).Seconds()
+
+			if len(pool.RoleSlots) == 0 {
+				preferred++
+				continue
+			}
+
+			// without a role assignment (role agnostic strategies) a player is satisfied by luck only: count flex players
+			role, assigned := p.Roles[t.ID]
+			if (assigned && len(t.Roles) > 0 && t.Roles[0] == role) || len(t.Roles) == 0 {
+				preferred++
+			}
+		}
+	}
+
+	e.AvgRatingSpread = spread / float64(len(proposals))
+	e.AvgTeamRatingDiff = diff / float64(len(proposals))
+	e.AvgWaitSeconds = wait / float64(e.PlayersMatched)
+	e.RolesSatisfied = float64(preferred) / float64(e.PlayersMatched)
+
+	return e
+}
@@ -0,0 +1,99 @@
+package matchmaking_strategies
+
+import (
+	"sort"
+	"time"
+
+	matchmaking_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/matchmaking/entities"
+)
+
+// GreedyMMRBandStrategy serves the longest waiting ticket first, completing its match with the closest rated tickets within the search spread of every ticket.
+type GreedyMMRBandStrategy struct{}
+
+func (s *GreedyMMRBandStrategy) Name() string {
+	return GreedyMMRBandStrategyName
+}
+
+func (s *GreedyMMRBandStrategy) Match(pool matchmaking_entities.MatchmakingPool, tickets []matchmaking_entities.QueueTicket, now time.Time) []MatchProposal {
+	size := pool.MatchSize()
+	proposals := make([]MatchProposal, 0)
+
+	if size == 0 || len(tickets) < size {
+		return proposals
+	}
+
+	queue := append([]matchmaking_entities.QueueTicket{}, tickets...)
+	sort.SliceStable(queue, func(i, j int) bool { return queue[i].EnqueuedAt.Before(queue[j].EnqueuedAt) })
+
+	spreads := searchSpreads(pool, queue, now)
+	used := make([]bool, len(queue))
+
+	for a := range queue {
+		if used[a] {
+			continue
+		}
+
+		anchor := queue[a]
+
+		candidates := make([]int, 0, len(queue))
+		for i := range queue {
+			if i != a && !used[i] {
+				candidates = append(candidates, i)
+			}
+		}
+
+		if len(candidates) < size-1 {
+			break
+		}
+
+		sort.SliceStable(candidates, func(i, j int) bool {
+			return abs(queue[candidates[i]].Rating-anchor.Rating) < abs(queue[candidates[j]].Rating-anchor.Rating)
+		})
+
+		group := []int{a}
+		min, max := anchor.Rating, anchor.Rating
+		spread := spreads[a]
+
+		for _, c := range candidates {
+			if len(group) == size {
+				break
+			}
+
+			r := queue[c].Rating
+			lo, hi := min, max
+			if r < lo {
+				lo = r
+			}
+
+			if r > hi {
+				hi = r
+			}
+
+			sp := spread
+			if spreads[c] < sp {
+				sp = spreads[c]
+			}
+
+			if hi-lo > sp {
+				continue
+			}
+
+			group = append(group, c)
+			min, max, spread = lo, hi, sp
+		}
+
+		if len(group) < size {
+			continue
+		}
+
+		matched := make([]matchmaking_entities.QueueTicket, 0, size)
+		for _, i := range group {
+			used[i] = true
+			matched = append(matched, queue[i])
+		}
+
+		proposals = append(proposals, MatchProposal{Teams: balanceTeams(matched)})
+	}
+
+	return proposals
+}
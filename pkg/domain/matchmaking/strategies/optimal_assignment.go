@@ -0,0 +1,83 @@
+package matchmaking_strategies
+
+import (
+	"slices"
+	"sort"
+	"time"
+
+	matchmaking_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/matchmaking/entities"
+)
+
+// OptimalAssignmentStrategy forms the most matches possible from the snapshot and, among those, the ones with the lowest total rating spread. Players are sorted by rating: an optimal assignment only groups neighbours, so a dynamic program over the sorted queue finds it exactly (O(n log n)).
+type OptimalAssignmentStrategy struct{}
+
+func (s *OptimalAssignmentStrategy) Name() string {
+	return OptimalAssignmentStrategyName
+}
+
+type assignment struct {
+	matches int
+	spread  int
+	take    bool // whether the best solution for the prefix ends with a match
+}
+
+func (a assignment) better(b assignment) bool {
+	if a.matches != b.matches {
+		return a.matches > b.matches
+	}
+
+	return a.spread < b.spread
+}
+
+func (s *OptimalAssignmentStrategy) Match(pool matchmaking_entities.MatchmakingPool, tickets []matchmaking_entities.QueueTicket, now time.Time) []MatchProposal {
+	size := pool.MatchSize()
+	proposals := make([]MatchProposal, 0)
+
+	if size == 0 || len(tickets) < size {
+		return proposals
+	}
+
+	sorted := append([]matchmaking_entities.QueueTicket{}, tickets...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if sorted[i].Rating != sorted[j].Rating {
+			return sorted[i].Rating < sorted[j].Rating
+		}
+
+		return sorted[i].EnqueuedAt.Before(sorted[j].EnqueuedAt)
+	})
+
+	spreads := searchSpreads(pool, sorted, now)
+
+	// best[i]: best assignment of the first i tickets
+	best := make([]assignment, len(sorted)+1)
+
+	for i := 1; i <= len(sorted); i++ {
+		best[i] = assignment{matches: best[i-1].matches, spread: best[i-1].spread}
+
+		if i < size {
+			continue
+		}
+
+		spread := sorted[i-1].Rating - sorted[i-size].Rating
+		if spread > slices.Min(spreads[i-size:i]) {
+			continue
+		}
+
+		candidate := assignment{matches: best[i-size].matches + 1, spread: best[i-size].spread + spread, take: true}
+		if candidate.better(best[i]) {
+			best[i] = candidate
+		}
+	}
+
+	for i := len(sorted); i >= size; {
+		if !best[i].take {
+			i--
+			continue
+		}
+
+		proposals = append(proposals, MatchProposal{Teams: balanceTeams(sorted[i-size : i])})
+		i -= size
+	}
+
+	return proposals
+}
@@ -0,0 +1,196 @@
+package matchmaking_strategies
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	matchmaking_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/matchmaking/entities"
+)
+
+// RoleFirstStrategy fills every role slot of both teams before looking at team balance: the longest waiting ticket anchors the match, each slot takes the closest rated ticket that prefers the role (then the ones that accept it), within the search spread of every ticket. Teams are balanced by swapping same-role players.
+type RoleFirstStrategy struct{}
+
+func (s *RoleFirstStrategy) Name() string {
+	return RoleFirstStrategyName
+}
+
+func (s *RoleFirstStrategy) Match(pool matchmaking_entities.MatchmakingPool, tickets []matchmaking_entities.QueueTicket, now time.Time) []MatchProposal {
+	proposals := make([]MatchProposal, 0)
+
+	if len(pool.RoleSlots) == 0 || len(pool.RoleSlots) != pool.TeamSize || len(tickets) < pool.MatchSize() {
+		return proposals
+	}
+
+	queue := append([]matchmaking_entities.QueueTicket{}, tickets...)
+	sort.SliceStable(queue, func(i, j int) bool { return queue[i].EnqueuedAt.Before(queue[j].EnqueuedAt) })
+
+	spreads := searchSpreads(pool, queue, now)
+	used := make([]bool, len(queue))
+
+	for a := range queue {
+		if used[a] {
+			continue
+		}
+
+		slots, ok := fillRoleSlots(pool, queue, spreads, used, a)
+		if !ok {
+			continue
+		}
+
+		proposal := MatchProposal{
+			Teams: [][]matchmaking_entities.QueueTicket{{}, {}},
+			Roles: make(map[uuid.UUID]string, len(slots)),
+		}
+
+		for i, q := range balanceRoleSlots(queue, slots, pool.TeamSize) {
+			used[q] = true
+
+			proposal.Teams[i/pool.TeamSize] = append(proposal.Teams[i/pool.TeamSize], queue[q])
+			proposal.Roles[queue[q].ID] = pool.RoleSlots[i%pool.TeamSize]
+		}
+
+		proposals = append(proposals, proposal)
+	}
+
+	return proposals
+}
+
+// fillRoleSlots returns the queue index assigned to each slot (team A slots, then team B slots).
+func fillRoleSlots(pool matchmaking_entities.MatchmakingPool, queue []matchmaking_entities.QueueTicket, spreads []int, used []bool, anchor int) ([]int, bool) {
+	slotCount := pool.MatchSize()
+	slots := make([]int, slotCount)
+	for i := range slots {
+		slots[i] = -1
+	}
+
+	taken := map[int]bool{anchor: true}
+	min, max := queue[anchor].Rating, queue[anchor].Rating
+	spread := spreads[anchor]
+
+	// anchor takes its most preferred open role on team A
+	anchorSlot := -1
+	preferred := append(append([]string{}, queue[anchor].Roles...), pool.RoleSlots...)
+	for _, role := range preferred {
+		if !queue[anchor].CanPlay(role) {
+			continue
+		}
+
+		for i := 0; i < pool.TeamSize; i++ {
+			if pool.RoleSlots[i] == role {
+				anchorSlot = i
+				break
+			}
+		}
+
+		if anchorSlot >= 0 {
+			break
+		}
+	}
+
+	if anchorSlot < 0 {
+		return nil, false
+	}
+
+	slots[anchorSlot] = anchor
+
+	candidates := make([]int, 0, len(queue))
+	for i := range queue {
+		if i != anchor && !used[i] {
+			candidates = append(candidates, i)
+		}
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return abs(queue[candidates[i]].Rating-queue[anchor].Rating) < abs(queue[candidates[j]].Rating-queue[anchor].Rating)
+	})
+
+	// pass 0: most preferred role only; pass 1: any accepted role
+	for pass := 0; pass < 2; pass++ {
+		for s := 0; s < slotCount; s++ {
+			if slots[s] >= 0 {
+				continue
+			}
+
+			role := pool.RoleSlots[s%pool.TeamSize]
+
+			for _, c := range candidates {
+				if taken[c] {
+					continue
+				}
+
+				t := queue[c]
+				if pass == 0 && (len(t.Roles) == 0 || t.Roles[0] != role) {
+					continue
+				}
+
+				if pass == 1 && !t.CanPlay(role) {
+					continue
+				}
+
+				lo, hi := min, max
+				if t.Rating < lo {
+					lo = t.Rating
+				}
+
+				if t.Rating > hi {
+					hi = t.Rating
+				}
+
+				sp := spread
+				if spreads[c] < sp {
+					sp = spreads[c]
+				}
+
+				if hi-lo > sp {
+					continue
+				}
+
+				slots[s] = c
+				taken[c] = true
+				min, max, spread = lo, hi, sp
+
+				break
+			}
+		}
+	}
+
+	for _, q := range slots {
+		if q < 0 {
+			return nil, false
+		}
+	}
+
+	return slots, true
+}
+
+// balanceRoleSlots swaps the players of the same role between teams to get the closest rating sums.
+func balanceRoleSlots(queue []matchmaking_entities.QueueTicket, slots []int, teamSize int) []int {
+	bestMask, bestDiff := 0, math.MaxInt
+
+	for mask := 0; mask < 1<<teamSize; mask++ {
+		diff := 0
+		for i := 0; i < teamSize; i++ {
+			a, b := queue[slots[i]].Rating, queue[slots[teamSize+i]].Rating
+			if mask&(1<<i) != 0 {
+				a, b = b, a
+			}
+
+			diff += a - b
+		}
+
+		if abs(diff) < bestDiff {
+			bestMask, bestDiff = mask, abs(diff)
+		}
+	}
+
+	balanced := append([]int{}, slots...)
+	for i := 0; i < teamSize; i++ {
+		if bestMask&(1<<i) != 0 {
+			balanced[i], balanced[teamSize+i] = balanced[teamSize+i], balanced[i]
+		}
+	}
+
+	return balanced
+}
@@ -0,0 +1,180 @@
+package matchmaking_strategies
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	matchmaking_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/matchmaking/entities"
+)
+
+const (
+	// below this queue size a single shard is faster than splitting
+	ShardingThreshold = 64
+
+	// narrowest rating bracket of a shard, so pools with a tight spread aren't split into near empty shards
+	MinShardBracketWidth = 100
+)
+
+// strategies comparing each anchor with the whole queue; optimal_assignment is a sort and a linear pass, sharding only adds overhead to it
+var shardableStrategies = map[string]bool{
+	GreedyMMRBandStrategyName: true,
+	RoleFirstStrategyName:     true,
+}
+
+// NewMatcherStrategy returns the strategy the matcher runs for the name, sharded when the strategy benefits from it.
+func NewMatcherStrategy(name string) (MatchingStrategy, error) {
+	strategy, err := NewMatchingStrategy(name)
+	if err != nil {
+		return nil, err
+	}
+
+	if !shardableStrategies[name] {
+		return strategy, nil
+	}
+
+	return NewShardedStrategy(strategy), nil
+}
+
+// ShardedStrategy splits the queue of a pool into rating brackets matched independently (and concurrently) by the inner strategy,
+// keeping each run close to linear in the queue size for strategies that compare every pair of tickets.
+//
+// Spillover: tickets left unmatched within their search spread of a bracket boundary get a second chance together with the leftovers
+// of the adjacent shard across the boundary. Tickets further from the boundary can't form a valid match across it, so they never spill.
+type ShardedStrategy struct {
+	Inner MatchingStrategy
+}
+
+func NewShardedStrategy(inner MatchingStrategy) *ShardedStrategy {
+	return &ShardedStrategy{Inner: inner}
+}
+
+// Name is the inner strategy name: sharding is internal to the matcher.
+func (s *ShardedStrategy) Name() string {
+	return s.Inner.Name()
+}
+
+func (s *ShardedStrategy) Match(pool matchmaking_entities.MatchmakingPool, tickets []matchmaking_entities.QueueTicket, now time.Time) []MatchProposal {
+	shards := Shard(pool, tickets)
+	if len(shards) <= 1 {
+		return s.Inner.Match(pool, tickets, now)
+	}
+
+	results := make([][]MatchProposal, len(shards))
+
+	var wg sync.WaitGroup
+	for i := range shards {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			results[i] = s.Inner.Match(pool, shards[i].Tickets, now)
+		}(i)
+	}
+
+	wg.Wait()
+
+	proposals := make([]MatchProposal, 0)
+	leftovers := make([][]matchmaking_entities.QueueTicket, len(shards))
+
+	for i, shard := range shards {
+		proposals = append(proposals, results[i]...)
+		leftovers[i] = unmatched(shard.Tickets, results[i])
+	}
+
+	for i := 0; i < len(shards)-1; i++ {
+		boundary := shards[i+1].Lower
+
+		spill := make([]matchmaking_entities.QueueTicket, 0)
+		for _, t := range leftovers[i] {
+			if boundary-t.Rating <= pool.RatingSpreadAt(t, now) {
+				spill = append(spill, t)
+			}
+		}
+
+		for _, t := range leftovers[i+1] {
+			if t.Rating-boundary <= pool.RatingSpreadAt(t, now) {
+				spill = append(spill, t)
+			}
+		}
+
+		if len(spill) < pool.MatchSize() {
+			continue
+		}
+
+		spilled := s.Inner.Match(pool, spill, now)
+		proposals = append(proposals, spilled...)
+
+		// the shard above keeps its unmatched tickets for the next boundary
+		leftovers[i+1] = unmatched(leftovers[i+1], spilled)
+	}
+
+	return proposals
+}
+
+// RatingShard is the bracket [Lower, Lower+width) of a pool queue.
+type RatingShard struct {
+	Lower   int
+	Tickets []matchmaking_entities.QueueTicket
+}
+
+// Shard splits the tickets by rating bracket (a single shard for small queues). Shards are sorted by bracket and keep the ticket order.
+func Shard(pool matchmaking_entities.MatchmakingPool, tickets []matchmaking_entities.QueueTicket) []RatingShard {
+	if len(tickets) <= ShardingThreshold {
+		return []RatingShard{{Tickets: tickets}}
+	}
+
+	width := ShardBracketWidth(pool)
+
+	byLower := make(map[int][]matchmaking_entities.QueueTicket)
+	for _, t := range tickets {
+		lower := floorDiv(t.Rating, width) * width
+		byLower[lower] = append(byLower[lower], t)
+	}
+
+	shards := make([]RatingShard, 0, len(byLower))
+	for lower, shardTickets := range byLower {
+		shards = append(shards, RatingShard{Lower: lower, Tickets: shardTickets})
+	}
+
+	sort.Slice(shards, func(i, j int) bool { return shards[i].Lower < shards[j].Lower })
+
+	return shards
+}
+
+// ShardBracketWidth is twice the pool spread: most valid matches fall inside a bracket, leaving few to spillover.
+func ShardBracketWidth(pool matchmaking_entities.MatchmakingPool) int {
+	width := 2 * pool.MaxRatingSpread
+	if width < MinShardBracketWidth {
+		return MinShardBracketWidth
+	}
+
+	return width
+}
+
+func unmatched(tickets []matchmaking_entities.QueueTicket, proposals []MatchProposal) []matchmaking_entities.QueueTicket {
+	matched := make(map[uuid.UUID]bool)
+	for _, p := range proposals {
+		for _, t := range p.Tickets() {
+			matched[t.ID] = true
+		}
+	}
+
+	left := make([]matchmaking_entities.QueueTicket, 0, len(tickets))
+	for _, t := range tickets {
+		if !matched[t.ID] {
+			left = append(left, t)
+		}
+	}
+
+	return left
+}
+
+func floorDiv(a, b int) int {
+	q := a / b
+	if a%b != 0 && a < 0 {
+		q--
+	}
+
+	return q
+}
@@ -0,0 +1,143 @@
+package matchmaking_strategies_test
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	matchmaking_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/matchmaking/entities"
+	matchmaking_strategies "github.com/psavelis/team-pro/replay-api/pkg/domain/matchmaking/strategies"
+	"github.com/stretchr/testify/assert"
+)
+
+// peakQueue returns n tickets with normally distributed ratings (mean 1500, sd 300), as seen in a busy region.
+func peakQueue(n int, seed int64) []matchmaking_entities.QueueTicket {
+	r := rand.New(rand.NewSource(seed))
+
+	ratings := make([]int, n)
+	for i := range ratings {
+		ratings[i] = int(r.NormFloat64()*300) + 1500
+	}
+
+	return newTickets(ratings...)
+}
+
+func TestShard(t *testing.T) {
+	pool := newPool(5, 100)
+
+	small := peakQueue(matchmaking_strategies.ShardingThreshold, 1)
+	assert.Len(t, matchmaking_strategies.Shard(pool, small), 1, "small queues aren't sharded")
+
+	tickets := peakQueue(500, 1)
+	shards := matchmaking_strategies.Shard(pool, tickets)
+
+	assert.Greater(t, len(shards), 1)
+
+	width := matchmaking_strategies.ShardBracketWidth(pool)
+	total := 0
+	for i, shard := range shards {
+		if i > 0 {
+			assert.Greater(t, shard.Lower, shards[i-1].Lower)
+		}
+
+		for _, ticket := range shard.Tickets {
+			assert.GreaterOrEqual(t, ticket.Rating, shard.Lower)
+			assert.Less(t, ticket.Rating, shard.Lower+width)
+		}
+
+		total += len(shard.Tickets)
+	}
+
+	assert.Equal(t, len(tickets), total)
+}
+
+func TestShardedStrategy_Spillover(t *testing.T) {
+	pool := newPool(1, 100)
+	width := matchmaking_strategies.ShardBracketWidth(pool)
+
+	// two sparse shards plus a pair straddling their boundary, only matchable across it
+	ratings := make([]int, 0)
+	for i := 0; i < matchmaking_strategies.ShardingThreshold; i++ {
+		ratings = append(ratings, i*10000)
+	}
+
+	ratings = append(ratings, width-10, width+10)
+	tickets := newTickets(ratings...)
+
+	proposals := matchmaking_strategies.NewShardedStrategy(&matchmaking_strategies.GreedyMMRBandStrategy{}).Match(pool, tickets, now)
+
+	assertValidProposals(t, pool, proposals)
+	if assert.Len(t, proposals, 1) {
+		assert.ElementsMatch(t, []int{width - 10, width + 10}, ratingsOf(proposals[0].Tickets()))
+	}
+}
+
+func TestShardedStrategy_Match(t *testing.T) {
+	for _, name := range []string{matchmaking_strategies.GreedyMMRBandStrategyName, matchmaking_strategies.OptimalAssignmentStrategyName} {
+		t.Run(name, func(t *testing.T) {
+			pool := newPool(5, 150)
+			tickets := peakQueue(600, 2)
+
+			inner, _ := matchmaking_strategies.NewMatchingStrategy(name)
+			sharded := matchmaking_strategies.NewShardedStrategy(inner)
+
+			assert.Equal(t, name, sharded.Name())
+
+			shardedProposals := sharded.Match(pool, tickets, now)
+			assertValidProposals(t, pool, shardedProposals)
+
+			// sharding trades a few boundary matches for throughput
+			unsharded := len(inner.Match(pool, tickets, now))
+			assert.GreaterOrEqual(t, len(shardedProposals), unsharded*9/10)
+		})
+	}
+}
+
+func TestNewMatcherStrategy(t *testing.T) {
+	for _, name := range []string{matchmaking_strategies.GreedyMMRBandStrategyName, matchmaking_strategies.RoleFirstStrategyName} {
+		strategy, err := matchmaking_strategies.NewMatcherStrategy(name)
+		assert.NoError(t, err)
+		assert.IsType(t, &matchmaking_strategies.ShardedStrategy{}, strategy, name)
+	}
+
+	strategy, err := matchmaking_strategies.NewMatcherStrategy(matchmaking_strategies.OptimalAssignmentStrategyName)
+	assert.NoError(t, err)
+	assert.IsType(t, &matchmaking_strategies.OptimalAssignmentStrategy{}, strategy)
+
+	_, err = matchmaking_strategies.NewMatcherStrategy("random")
+	assert.Error(t, err)
+}
+
+// make bench-matchmaking
+func BenchmarkMatch(b *testing.B) {
+	roles := []string{"entry", "awp", "support", "lurk", "igl"}
+
+	for _, name := range []string{matchmaking_strategies.GreedyMMRBandStrategyName, matchmaking_strategies.RoleFirstStrategyName, matchmaking_strategies.OptimalAssignmentStrategyName} {
+		pool := newPool(5, 150)
+		if name == matchmaking_strategies.RoleFirstStrategyName {
+			pool.RoleSlots = roles
+		}
+
+		inner, _ := matchmaking_strategies.NewMatchingStrategy(name)
+
+		for _, n := range []int{200, 1000, 5000} {
+			tickets := peakQueue(n, 3)
+			for i := range tickets {
+				tickets[i].Roles = []string{roles[i%len(roles)]}
+			}
+
+			b.Run(fmt.Sprintf("%s/n=%d/unsharded", name, n), func(b *testing.B) {
+				for i := 0; i < b.N; i++ {
+					inner.Match(pool, tickets, now)
+				}
+			})
+
+			b.Run(fmt.Sprintf("%s/n=%d/sharded", name, n), func(b *testing.B) {
+				sharded := matchmaking_strategies.NewShardedStrategy(inner)
+				for i := 0; i < b.N; i++ {
+					sharded.Match(pool, tickets, now)
+				}
+			})
+		}
+	}
+}
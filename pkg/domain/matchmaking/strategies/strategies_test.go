@@ -0,0 +1,236 @@
+package matchmaking_strategies_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	matchmaking_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/matchmaking/entities"
+	matchmaking_strategies "github.com/psavelis/team-pro/replay-api/pkg/domain/matchmaking/strategies"
+	"github.com/stretchr/testify/assert"
+)
+
+var now = time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+
+// newTickets returns one ticket per rating, the first one being the longest waiting.
+func newTickets(ratings ...int) []matchmaking_entities.QueueTicket {
+	tickets := make([]matchmaking_entities.QueueTicket, 0, len(ratings))
+	for i, rating := range ratings {
+		tickets = append(tickets, matchmaking_entities.QueueTicket{
+			ID:         uuid.New(),
+			UserID:     uuid.New(),
+			Rating:     rating,
+			Status:     matchmaking_entities.QueueTicketStatusWaiting,
+			EnqueuedAt: now.Add(-time.Duration(len(ratings)-i) * time.Minute),
+		})
+	}
+
+	return tickets
+}
+
+func newPool(teamSize int, maxSpread int, roles ...string) matchmaking_entities.MatchmakingPool {
+	return matchmaking_entities.MatchmakingPool{ID: uuid.New(), TeamSize: teamSize, MaxRatingSpread: maxSpread, RoleSlots: roles}
+}
+
+func ratingsOf(tickets []matchmaking_entities.QueueTicket) []int {
+	ratings := make([]int, 0, len(tickets))
+	for _, t := range tickets {
+		ratings = append(ratings, t.Rating)
+	}
+
+	return ratings
+}
+
+func assertValidProposals(t *testing.T, pool matchmaking_entities.MatchmakingPool, proposals []matchmaking_strategies.MatchProposal) {
+	seen := map[uuid.UUID]bool{}
+
+	for _, p := range proposals {
+		assert.Len(t, p.Teams, 2)
+		assert.Len(t, p.Teams[0], pool.TeamSize)
+		assert.Len(t, p.Teams[1], pool.TeamSize)
+		assert.LessOrEqual(t, p.RatingSpread(), pool.MaxSearchRatingSpread())
+
+		for _, ticket := range p.Tickets() {
+			assert.False(t, seen[ticket.ID], "ticket matched twice")
+			seen[ticket.ID] = true
+		}
+	}
+}
+
+func TestNewMatchingStrategy(t *testing.T) {
+	for _, name := range []string{matchmaking_strategies.GreedyMMRBandStrategyName, matchmaking_strategies.OptimalAssignmentStrategyName, matchmaking_strategies.RoleFirstStrategyName} {
+		s, err := matchmaking_strategies.NewMatchingStrategy(name)
+
+		assert.NoError(t, err)
+		assert.Equal(t, name, s.Name())
+	}
+
+	_, err := matchmaking_strategies.NewMatchingStrategy("random")
+	assert.Error(t, err)
+}
+
+func TestValidateStrategy(t *testing.T) {
+	assert.NoError(t, matchmaking_strategies.ValidateStrategy(matchmaking_strategies.RoleFirstStrategyName, newPool(2, 100, "awp", "entry")))
+	assert.Error(t, matchmaking_strategies.ValidateStrategy(matchmaking_strategies.RoleFirstStrategyName, newPool(2, 100)))
+	assert.NoError(t, matchmaking_strategies.ValidateStrategy(matchmaking_strategies.GreedyMMRBandStrategyName, newPool(2, 100)))
+	assert.Error(t, matchmaking_strategies.ValidateStrategy("random", newPool(2, 100)))
+}
+
+func TestGreedyMMRBandStrategy_Match(t *testing.T) {
+	tests := []struct {
+		name            string
+		pool            matchmaking_entities.MatchmakingPool
+		ratings         []int
+		expectedMatches [][]int // sorted ratings of each match
+	}{
+		{
+			name:            "Not Enough Tickets",
+			pool:            newPool(2, 100),
+			ratings:         []int{1000, 1000, 1000},
+			expectedMatches: [][]int{},
+		},
+		{
+			name:            "Anchor Takes Closest Ratings",
+			pool:            newPool(1, 100),
+			ratings:         []int{1000, 1500, 1050, 1020},
+			expectedMatches: [][]int{{1000, 1020}},
+		},
+		{
+			name:            "Out Of Band Anchor Is Skipped",
+			pool:            newPool(2, 100),
+			ratings:         []int{2000, 1000, 1010, 1020, 1030},
+			expectedMatches: [][]int{{1000, 1010, 1020, 1030}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			proposals := (&matchmaking_strategies.GreedyMMRBandStrategy{}).Match(tt.pool, newTickets(tt.ratings...), now)
+
+			assertValidProposals(t, tt.pool, proposals)
+			assert.Len(t, proposals, len(tt.expectedMatches))
+
+			for i, p := range proposals {
+				assert.ElementsMatch(t, tt.expectedMatches[i], ratingsOf(p.Tickets()))
+			}
+		})
+	}
+}
+
+func TestMatchingStrategy_Match_SearchWindow(t *testing.T) {
+	pool := newPool(1, 100)
+	pool.SearchWindow = &matchmaking_entities.PoolSearchWindow{StepSeconds: 60, StepRating: 100, MaxRatingSpread: 300}
+
+	// waiting 2 and 1 minutes: 300 and 200 spreads, the 250 gap is still out of reach of the second ticket
+	tickets := newTickets(1000, 1250)
+
+	strategies := []matchmaking_strategies.MatchingStrategy{
+		&matchmaking_strategies.GreedyMMRBandStrategy{},
+		&matchmaking_strategies.OptimalAssignmentStrategy{},
+	}
+
+	for _, strategy := range strategies {
+		t.Run(strategy.Name(), func(t *testing.T) {
+			assert.Empty(t, strategy.Match(pool, tickets, now))
+
+			// a minute later both reach the 300 cap
+			proposals := strategy.Match(pool, tickets, now.Add(time.Minute))
+
+			assertValidProposals(t, pool, proposals)
+			if assert.Len(t, proposals, 1) {
+				assert.ElementsMatch(t, []int{1000, 1250}, ratingsOf(proposals[0].Tickets()))
+			}
+		})
+	}
+}
+
+func TestOptimalAssignmentStrategy_Match(t *testing.T) {
+	pool := newPool(1, 100)
+
+	// greedy serves the oldest (1000) with 1050 (tied with 950, but waiting longer), stranding 950 and 1100: 1 match.
+	// the optimal assignment pairs 950-1000 and 1050-1100: 2 matches.
+	tickets := newTickets(1000, 1050, 950, 1100)
+
+	greedy := (&matchmaking_strategies.GreedyMMRBandStrategy{}).Match(pool, tickets, now)
+	optimal := (&matchmaking_strategies.OptimalAssignmentStrategy{}).Match(pool, tickets, now)
+
+	assertValidProposals(t, pool, optimal)
+	assert.Len(t, greedy, 1)
+	assert.Len(t, optimal, 2)
+
+	total := 0
+	for _, p := range optimal {
+		total += p.RatingSpread()
+	}
+
+	assert.Equal(t, 100, total)
+}
+
+func TestOptimalAssignmentStrategy_Match_BalancesTeams(t *testing.T) {
+	pool := newPool(2, 1000)
+
+	proposals := (&matchmaking_strategies.OptimalAssignmentStrategy{}).Match(pool, newTickets(1000, 1100, 1200, 1300), now)
+
+	assert.Len(t, proposals, 1)
+	assert.Equal(t, float64(0), proposals[0].TeamRatingDiff())
+}
+
+func TestRoleFirstStrategy_Match(t *testing.T) {
+	pool := newPool(2, 200, "awp", "entry")
+
+	tickets := newTickets(1000, 1010, 1020, 1030, 1040)
+	tickets[0].Roles = []string{"awp"}
+	tickets[1].Roles = []string{"awp", "entry"}
+	tickets[2].Roles = []string{"awp"}
+	tickets[3].Roles = []string{"entry"}
+	tickets[4].Roles = []string{} // flex
+
+	proposals := (&matchmaking_strategies.RoleFirstStrategy{}).Match(pool, tickets, now)
+
+	assertValidProposals(t, pool, proposals)
+	assert.Len(t, proposals, 1)
+
+	p := proposals[0]
+	for _, ticket := range p.Tickets() {
+		assert.True(t, ticket.CanPlay(p.Roles[ticket.ID]), "ticket assigned to a role it doesn't play")
+	}
+
+	// one of each role per team
+	for _, team := range p.Teams {
+		roles := []string{}
+		for _, ticket := range team {
+			roles = append(roles, p.Roles[ticket.ID])
+		}
+
+		assert.ElementsMatch(t, []string{"awp", "entry"}, roles)
+	}
+}
+
+func TestRoleFirstStrategy_Match_MissingRole(t *testing.T) {
+	pool := newPool(2, 200, "awp", "entry")
+
+	tickets := newTickets(1000, 1010, 1020, 1030)
+	for i := range tickets {
+		tickets[i].Roles = []string{"awp"}
+	}
+
+	assert.Empty(t, (&matchmaking_strategies.RoleFirstStrategy{}).Match(pool, tickets, now))
+}
+
+func TestSummarize(t *testing.T) {
+	pool := newPool(1, 100)
+	tickets := newTickets(1000, 1050, 2000)
+
+	proposals := (&matchmaking_strategies.GreedyMMRBandStrategy{}).Match(pool, tickets, now)
+
+	e := matchmaking_strategies.Summarize("greedy_mmr_band", pool, tickets, proposals, now, time.Millisecond)
+
+	assert.Equal(t, 3, e.TicketsConsidered)
+	assert.Equal(t, 1, e.MatchesFormed)
+	assert.Equal(t, 2, e.PlayersMatched)
+	assert.Equal(t, float64(50), e.AvgRatingSpread)
+	assert.Equal(t, float64(50), e.AvgTeamRatingDiff)
+	assert.Equal(t, float64(150), e.AvgWaitSeconds) // 3 and 2 minutes
+	assert.Equal(t, float64(1), e.RolesSatisfied)
+	assert.Equal(t, int64(1000), e.ElapsedMicros)
+}
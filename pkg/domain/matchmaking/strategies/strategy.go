@@ -0,0 +1,132 @@
+package matchmaking_strategies
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+	matchmaking_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/matchmaking/entities"
+)
+
+const (
+	GreedyMMRBandStrategyName     = "greedy_mmr_band"
+	OptimalAssignmentStrategyName = "optimal_assignment"
+	RoleFirstStrategyName         = "role_first"
+
+	DefaultStrategyName = GreedyMMRBandStrategyName
+)
+
+// MatchingStrategy forms matches from the waiting tickets of a pool. Strategies are pure: they only propose matches, the matcher persists them.
+type MatchingStrategy interface {
+	Name() string
+	Match(pool matchmaking_entities.MatchmakingPool, tickets []matchmaking_entities.QueueTicket, now time.Time) []MatchProposal
+}
+
+// MatchProposal is a match of two teams. Roles holds the role assigned to each ticket (role_first only).
+type MatchProposal struct {
+	Teams [][]matchmaking_entities.QueueTicket
+	Roles map[uuid.UUID]string
+}
+
+func (p MatchProposal) Tickets() []matchmaking_entities.QueueTicket {
+	tickets := make([]matchmaking_entities.QueueTicket, 0)
+	for _, team := range p.Teams {
+		tickets = append(tickets, team...)
+	}
+
+	return tickets
+}
+
+// RatingSpread is the rating difference between the best and worst rated players.
+func (p MatchProposal) RatingSpread() int {
+	return ratingSpread(p.Tickets())
+}
+
+// TeamRatingDiff is the difference between the average ratings of the teams.
+func (p MatchProposal) TeamRatingDiff() float64 {
+	if len(p.Teams) != 2 {
+		return 0
+	}
+
+	return math.Abs(averageRating(p.Teams[0]) - averageRating(p.Teams[1]))
+}
+
+func NewMatchingStrategy(name string) (MatchingStrategy, error) {
+	switch name {
+	case GreedyMMRBandStrategyName:
+		return &GreedyMMRBandStrategy{}, nil
+	case OptimalAssignmentStrategyName:
+		return &OptimalAssignmentStrategy{}, nil
+	case RoleFirstStrategyName:
+		return &RoleFirstStrategy{}, nil
+	default:
+		return nil, fmt.Errorf("unknown matching strategy '%s'", name)
+	}
+}
+
+// ValidateStrategy checks the strategy can run on the pool.
+func ValidateStrategy(name string, pool matchmaking_entities.MatchmakingPool) error {
+	_, err := NewMatchingStrategy(name)
+	if err != nil {
+		return err
+	}
+
+	if name == RoleFirstStrategyName && len(pool.RoleSlots) != pool.TeamSize {
+		return fmt.Errorf("strategy '%s' requires one role slot per team member (team size %d, %d role slots)", name, pool.TeamSize, len(pool.RoleSlots))
+	}
+
+	return nil
+}
+
+// searchSpreads returns the rating spread each ticket accepts at now (see MatchmakingPool.SearchWindow). A match is valid when its
+// spread is within the spread of every one of its tickets: a long waiting ticket widens its own search, not the search of the
+// players it's matched with.
+func searchSpreads(pool matchmaking_entities.MatchmakingPool, tickets []matchmaking_entities.QueueTicket, now time.Time) []int {
+	spreads := make([]int, len(tickets))
+	for i, t := range tickets {
+		spreads[i] = pool.RatingSpreadAt(t, now)
+	}
+
+	return spreads
+}
+
+func ratingSpread(tickets []matchmaking_entities.QueueTicket) int {
+	if len(tickets) == 0 {
+		return 0
+	}
+
+	min, max := tickets[0].Rating, tickets[0].Rating
+	for _, t := range tickets[1:] {
+		if t.Rating < min {
+			min = t.Rating
+		}
+
+		if t.Rating > max {
+			max = t.Rating
+		}
+	}
+
+	return max - min
+}
+
+func averageRating(tickets []matchmaking_entities.QueueTicket) float64 {
+	if len(tickets) == 0 {
+		return 0
+	}
+
+	sum := 0
+	for _, t := range tickets {
+		sum += t.Rating
+	}
+
+	return float64(sum) / float64(len(tickets))
+}
+
+func abs(v int) int {
+	if v < 0 {
+		return -v
+	}
+
+	return v
+}
@@ -0,0 +1,80 @@
+package matchmaking_strategies
+
+import (
+	"sort"
+
+	matchmaking_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/matchmaking/entities"
+)
+
+// up to this many players the best split is searched exhaustively (C(11,5) = 462 candidates for 5v5)
+const maxExhaustiveBalancePlayers = 12
+
+// balanceTeams splits the tickets into two teams of equal size with the closest rating sums.
+func balanceTeams(tickets []matchmaking_entities.QueueTicket) [][]matchmaking_entities.QueueTicket {
+	if len(tickets) > maxExhaustiveBalancePlayers {
+		return snakeTeams(tickets)
+	}
+
+	teamSize := len(tickets) / 2
+
+	total := 0
+	for _, t := range tickets {
+		total += t.Rating
+	}
+
+	// the first ticket is fixed on team A, so mirrored splits aren't evaluated twice
+	best := []int{}
+	bestDiff := -1
+
+	var search func(next int, picked []int, sum int)
+	search = func(next int, picked []int, sum int) {
+		if len(picked) == teamSize {
+			diff := abs(total - 2*sum)
+			if bestDiff < 0 || diff < bestDiff {
+				bestDiff = diff
+				best = append([]int{}, picked...)
+			}
+
+			return
+		}
+
+		for i := next; i <= len(tickets)-(teamSize-len(picked)); i++ {
+			search(i+1, append(picked, i), sum+tickets[i].Rating)
+		}
+	}
+
+	search(1, []int{0}, tickets[0].Rating)
+
+	inA := make(map[int]bool, teamSize)
+	for _, i := range best {
+		inA[i] = true
+	}
+
+	teams := [][]matchmaking_entities.QueueTicket{{}, {}}
+	for i, t := range tickets {
+		if inA[i] {
+			teams[0] = append(teams[0], t)
+		} else {
+			teams[1] = append(teams[1], t)
+		}
+	}
+
+	return teams
+}
+
+// snakeTeams splits by rating in A-B-B-A order, good enough for large teams.
+func snakeTeams(tickets []matchmaking_entities.QueueTicket) [][]matchmaking_entities.QueueTicket {
+	sorted := append([]matchmaking_entities.QueueTicket{}, tickets...)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].Rating > sorted[j].Rating })
+
+	teams := [][]matchmaking_entities.QueueTicket{{}, {}}
+	for i, t := range sorted {
+		if i%4 == 0 || i%4 == 3 {
+			teams[0] = append(teams[0], t)
+		} else {
+			teams[1] = append(teams[1], t)
+		}
+	}
+
+	return teams
+}
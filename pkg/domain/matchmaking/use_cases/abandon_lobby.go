@@ -0,0 +1,62 @@
+package matchmaking_use_cases
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	"github.com/psavelis/team-pro/replay-api/pkg/domain/matchmaking"
+	matchmaking_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/matchmaking/entities"
+	matchmaking_in "github.com/psavelis/team-pro/replay-api/pkg/domain/matchmaking/ports/in"
+	matchmaking_out "github.com/psavelis/team-pro/replay-api/pkg/domain/matchmaking/ports/out"
+)
+
+type AbandonLobbyUseCase struct {
+	LobbyReader matchmaking_out.LobbyReader
+	LobbyWriter matchmaking_out.LobbyWriter
+}
+
+func NewAbandonLobbyUseCase(lobbyReader matchmaking_out.LobbyReader, lobbyWriter matchmaking_out.LobbyWriter) matchmaking_in.AbandonLobbyCommand {
+	return &AbandonLobbyUseCase{
+		LobbyReader: lobbyReader,
+		LobbyWriter: lobbyWriter,
+	}
+}
+
+func (usecase *AbandonLobbyUseCase) Exec(ctx context.Context, lobbyID uuid.UUID, userID uuid.UUID) (*matchmaking_entities.Lobby, error) {
+	lobby, err := getTenantLobby(ctx, usecase.LobbyReader, lobbyID)
+	if err != nil {
+		return nil, err
+	}
+
+	callerID := common.GetResourceOwner(ctx).UserID
+	if callerID != userID && !lobby.IsLeader(callerID) {
+		return nil, matchmaking.NewLobbyForbiddenError("only the lobby leader can report another player")
+	}
+
+	player := lobby.GetPlayer(userID)
+	if player == nil || player.AbandonedAt != nil {
+		return nil, matchmaking.NewLobbyStateError(fmt.Sprintf("user %s isn't playing in the lobby", userID))
+	}
+
+	if lobby.Status != matchmaking_entities.LobbyStatusReady && lobby.Status != matchmaking_entities.LobbyStatusInMatch {
+		return nil, matchmaking.NewLobbyStateError(fmt.Sprintf("players can't abandon a lobby in status '%s'", lobby.Status))
+	}
+
+	slot := lobby.Abandon(userID, time.Now().UTC())
+
+	lobby, err = usecase.LobbyWriter.Update(ctx, lobby)
+	if err != nil {
+		slog.ErrorContext(ctx, "unable to save lobby abandon", "lobbyID", lobbyID, "userID", userID, "err", err)
+		return nil, err
+	}
+
+	if slot != nil {
+		slog.InfoContext(ctx, "backfill slot opened", "lobbyID", lobbyID, "slotID", slot.ID, "team", slot.Team, "rating", slot.Rating, "role", slot.Role)
+	}
+
+	return lobby, nil
+}
@@ -0,0 +1,250 @@
+package matchmaking_use_cases_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/psavelis/team-pro/replay-api/pkg/domain/matchmaking"
+	matchmaking_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/matchmaking/entities"
+	matchmaking_use_cases "github.com/psavelis/team-pro/replay-api/pkg/domain/matchmaking/use_cases"
+	replay_entity "github.com/psavelis/team-pro/replay-api/pkg/domain/replay/entities"
+	"github.com/stretchr/testify/assert"
+)
+
+type mockLobbyMatchWriter struct {
+	matches       map[uuid.UUID]*replay_entity.MatchDraft
+	linked        map[uuid.UUID]bool
+	queueSessions map[uuid.UUID][]replay_entity.MatchQueueSession
+	settlements   map[uuid.UUID]replay_entity.MatchSettlement
+}
+
+func (m *mockLobbyMatchWriter) AttachSettlement(ctx context.Context, matchID uuid.UUID, lobbyID uuid.UUID, settlement replay_entity.MatchSettlement) (bool, error) {
+	m.settlements[matchID] = settlement
+
+	return true, nil
+}
+
+func (m *mockLobbyMatchWriter) AttachLobby(ctx context.Context, matchID uuid.UUID, lobbyID uuid.UUID, draft *replay_entity.MatchDraft, queueSessions []replay_entity.MatchQueueSession) (bool, error) {
+	if _, ok := m.matches[matchID]; !ok {
+		return false, nil
+	}
+
+	if m.linked[matchID] {
+		return false, matchmaking.NewMatchLinkedError(matchID)
+	}
+
+	m.linked[matchID] = true
+
+	m.matches[matchID] = draft
+	m.queueSessions[matchID] = queueSessions
+
+	return true, nil
+}
+
+// newDraftLobby returns a forming captain draft lobby: the leader (rated 1500) and the member (rated 2000) plus unrated players.
+func newDraftLobby(players int) matchmaking_entities.Lobby {
+	l := newLobby(matchmaking_entities.LobbyStatusForming, false)
+	l.Mode = matchmaking_entities.LobbyModeCaptainDraft
+	l.Players[0].Rating = 1500
+	l.Players[1].Rating = 2000
+
+	for len(l.Players) < players {
+		l.Players = append(l.Players, matchmaking_entities.LobbyPlayer{PlayerID: uuid.New(), UserID: uuid.New(), Rating: 1000 + len(l.Players), JoinedAt: time.Now()})
+	}
+
+	return l
+}
+
+func TestStartCaptainDraftUseCase_Exec(t *testing.T) {
+	autoBalance := newDraftLobby(4)
+	autoBalance.Mode = matchmaking_entities.LobbyModeAutoBalance
+
+	drafting := newDraftLobby(4)
+	drafting.Status = matchmaking_entities.LobbyStatusDrafting
+
+	tests := []struct {
+		name        string
+		lobby       matchmaking_entities.Lobby
+		callerID    uuid.UUID
+		expectedErr interface{}
+	}{
+		{name: "Leader Starts Draft", lobby: newDraftLobby(4), callerID: leaderID},
+		{name: "Member Can't Start Draft", lobby: newDraftLobby(4), callerID: memberID, expectedErr: &matchmaking.LobbyForbiddenError{}},
+		{name: "Auto Balance Lobby", lobby: autoBalance, callerID: leaderID, expectedErr: &matchmaking.LobbyStateError{}},
+		{name: "Already Drafting", lobby: drafting, callerID: leaderID, expectedErr: &matchmaking.LobbyStateError{}},
+		{name: "Not Enough Players", lobby: newDraftLobby(2), callerID: leaderID, expectedErr: &matchmaking.LobbyStateError{}},
+		{name: "Odd Number Of Players", lobby: newDraftLobby(5), callerID: leaderID, expectedErr: &matchmaking.LobbyStateError{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := newMockLobbyStore(tt.lobby)
+
+			usecase := matchmaking_use_cases.NewStartCaptainDraftUseCase(store, store)
+
+			lobby, err := usecase.Exec(userContext(tt.callerID), tt.lobby.ID)
+
+			if tt.expectedErr != nil {
+				assert.Error(t, err)
+				assert.IsType(t, tt.expectedErr, err)
+				assert.Equal(t, 0, store.updates)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, matchmaking_entities.LobbyStatusDrafting, lobby.Status)
+			assert.Equal(t, []uuid.UUID{memberID, leaderID}, lobby.Draft.Captains)
+			assert.Equal(t, leaderID, lobby.Draft.CurrentCaptain())
+		})
+	}
+}
+
+func TestPickDraftPlayerUseCase_Exec(t *testing.T) {
+	start := func() matchmaking_entities.Lobby {
+		l := newDraftLobby(6)
+		l.StartDraft(time.Now().UTC(), time.Minute)
+		return l
+	}
+
+	t.Run("Captain On The Clock Picks", func(t *testing.T) {
+		lobby := start()
+		store := newMockLobbyStore(lobby)
+
+		usecase := matchmaking_use_cases.NewPickDraftPlayerUseCase(store, store)
+
+		updated, err := usecase.Exec(userContext(leaderID), lobby.ID, lobby.Players[2].UserID)
+
+		assert.NoError(t, err)
+		assert.Len(t, updated.Draft.Picks, 1)
+		assert.Equal(t, matchmaking_entities.LobbyTeamB, updated.GetPlayer(lobby.Players[2].UserID).Team)
+		assert.Equal(t, memberID, updated.Draft.CurrentCaptain())
+	})
+
+	t.Run("Other Captain Is Forbidden", func(t *testing.T) {
+		lobby := start()
+		store := newMockLobbyStore(lobby)
+
+		usecase := matchmaking_use_cases.NewPickDraftPlayerUseCase(store, store)
+
+		_, err := usecase.Exec(userContext(memberID), lobby.ID, lobby.Players[2].UserID)
+
+		assert.IsType(t, &matchmaking.LobbyForbiddenError{}, err)
+	})
+
+	t.Run("Picked Player Is Unavailable", func(t *testing.T) {
+		lobby := start()
+		store := newMockLobbyStore(lobby)
+
+		usecase := matchmaking_use_cases.NewPickDraftPlayerUseCase(store, store)
+
+		_, err := usecase.Exec(userContext(leaderID), lobby.ID, memberID)
+
+		assert.IsType(t, &matchmaking.LobbyStateError{}, err)
+	})
+
+	t.Run("Expired Turn Is Auto Picked First", func(t *testing.T) {
+		lobby := start()
+		expired := time.Now().UTC().Add(-time.Second)
+		lobby.Draft.TurnDeadline = &expired
+
+		store := newMockLobbyStore(lobby)
+
+		usecase := matchmaking_use_cases.NewPickDraftPlayerUseCase(store, store)
+
+		_, err := usecase.Exec(userContext(leaderID), lobby.ID, lobby.Players[2].UserID)
+
+		assert.IsType(t, &matchmaking.LobbyForbiddenError{}, err)
+
+		saved := store.lobbies[lobby.ID]
+		assert.Len(t, saved.Draft.Picks, 1)
+		assert.True(t, saved.Draft.Picks[0].AutoPicked)
+		assert.Equal(t, memberID, saved.Draft.CurrentCaptain())
+	})
+}
+
+func TestExpireDraftPicksUseCase_Exec(t *testing.T) {
+	running := newDraftLobby(4)
+	running.StartDraft(time.Now().UTC(), time.Minute)
+
+	expired := newDraftLobby(4)
+	expired.StartDraft(time.Now().UTC().Add(-2*time.Minute), time.Minute)
+
+	store := newMockLobbyStore(running, expired)
+
+	usecase := matchmaking_use_cases.NewExpireDraftPicksUseCase(store, store)
+
+	picks, err := usecase.Exec(systemContext())
+
+	assert.NoError(t, err)
+	assert.Equal(t, 2, picks)
+	assert.Empty(t, store.lobbies[running.ID].Draft.Picks)
+	assert.True(t, store.lobbies[expired.ID].Draft.IsCompleted())
+	assert.Equal(t, matchmaking_entities.LobbyStatusReady, store.lobbies[expired.ID].Status)
+}
+
+func TestLinkLobbyMatchUseCase_Exec(t *testing.T) {
+	drafted := newDraftLobby(4)
+	drafted.StartDraft(time.Now().UTC(), time.Minute)
+	drafted.Pick(drafted.Players[2].UserID, time.Now().UTC(), false)
+	drafted.Players[1].QueueTicketID = uuid.New()
+
+	matchID := uuid.New()
+	linkedMatchID := uuid.New()
+
+	tests := []struct {
+		name          string
+		lobby         matchmaking_entities.Lobby
+		callerID      uuid.UUID
+		matchID       uuid.UUID
+		expectedErr   interface{}
+		expectedDraft bool
+	}{
+		{name: "Drafted Lobby", lobby: drafted, callerID: leaderID, matchID: matchID, expectedDraft: true},
+		{name: "Auto Balanced Lobby", lobby: newLobby(matchmaking_entities.LobbyStatusReady, false), callerID: leaderID, matchID: matchID},
+		{name: "Member Can't Link", lobby: drafted, callerID: memberID, matchID: matchID, expectedErr: &matchmaking.LobbyForbiddenError{}},
+		{name: "Lobby Not Ready", lobby: newDraftLobby(4), callerID: leaderID, matchID: matchID, expectedErr: &matchmaking.LobbyStateError{}},
+		{name: "Unknown Match", lobby: drafted, callerID: leaderID, matchID: uuid.New(), expectedErr: &matchmaking.MatchNotFoundError{}},
+		{name: "Match Already Linked", lobby: drafted, callerID: leaderID, matchID: linkedMatchID, expectedErr: &matchmaking.MatchLinkedError{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := newMockLobbyStore(tt.lobby)
+			matches := &mockLobbyMatchWriter{
+				matches:       map[uuid.UUID]*replay_entity.MatchDraft{matchID: nil, linkedMatchID: nil},
+				linked:        map[uuid.UUID]bool{linkedMatchID: true},
+				queueSessions: map[uuid.UUID][]replay_entity.MatchQueueSession{},
+			}
+
+			usecase := matchmaking_use_cases.NewLinkLobbyMatchUseCase(store, store, matches)
+
+			lobby, err := usecase.Exec(userContext(tt.callerID), tt.lobby.ID, tt.matchID)
+
+			if tt.expectedErr != nil {
+				assert.IsType(t, tt.expectedErr, err)
+				assert.Equal(t, 0, store.updates)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, matchmaking_entities.LobbyStatusInMatch, lobby.Status)
+			assert.Equal(t, matchID, *lobby.MatchID)
+
+			if !tt.expectedDraft {
+				assert.Nil(t, matches.matches[matchID])
+				return
+			}
+
+			// only the players matched from the queue have a queue session
+			assert.Equal(t, []replay_entity.MatchQueueSession{{PlayerID: tt.lobby.Players[1].PlayerID, QueueSessionID: tt.lobby.Players[1].QueueTicketID}}, matches.queueSessions[matchID])
+
+			draft := matches.matches[matchID]
+			assert.Equal(t, tt.lobby.Draft.Captains, draft.Captains)
+			assert.Len(t, draft.Picks, 2)
+			assert.Equal(t, tt.lobby.Players[2].UserID, draft.Picks[0].UserID)
+			assert.Equal(t, int(matchmaking_entities.LobbyTeamB), draft.Picks[0].Team)
+		})
+	}
+}
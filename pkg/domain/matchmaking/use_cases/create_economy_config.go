@@ -0,0 +1,99 @@
+package matchmaking_use_cases
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	"github.com/psavelis/team-pro/replay-api/pkg/domain/matchmaking"
+	matchmaking_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/matchmaking/entities"
+	matchmaking_in "github.com/psavelis/team-pro/replay-api/pkg/domain/matchmaking/ports/in"
+	matchmaking_out "github.com/psavelis/team-pro/replay-api/pkg/domain/matchmaking/ports/out"
+)
+
+type CreateEconomyConfigUseCase struct {
+	EconomyReader matchmaking_out.EconomyConfigReader
+	EconomyWriter matchmaking_out.EconomyConfigWriter
+}
+
+func NewCreateEconomyConfigUseCase(economyReader matchmaking_out.EconomyConfigReader, economyWriter matchmaking_out.EconomyConfigWriter) matchmaking_in.CreateEconomyConfigCommand {
+	return &CreateEconomyConfigUseCase{
+		EconomyReader: economyReader,
+		EconomyWriter: economyWriter,
+	}
+}
+
+func (usecase *CreateEconomyConfigUseCase) Exec(ctx context.Context, config matchmaking_entities.EconomyConfig) (*matchmaking_entities.EconomyConfig, error) {
+	err := config.Validate()
+	if err != nil {
+		return nil, matchmaking.NewInvalidEconomyConfigError(err.Error())
+	}
+
+	now := time.Now().UTC()
+
+	if config.EffectiveFrom.IsZero() {
+		config.EffectiveFrom = now
+	}
+
+	config.EffectiveFrom = config.EffectiveFrom.UTC()
+
+	latest, err := latestEconomyConfig(ctx, usecase.EconomyReader, config.GameID, config.Mode, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	config.Version = 1
+
+	// versions take effect in order, a version can't go back in time over the previous one
+	if latest != nil {
+		if config.EffectiveFrom.Before(latest.EffectiveFrom) {
+			return nil, matchmaking.NewInvalidEconomyConfigError(fmt.Sprintf("effective_from can't be earlier than the current version (v%d, effective from %s)", latest.Version, latest.EffectiveFrom.Format(time.RFC3339)))
+		}
+
+		config.Version = latest.Version + 1
+	}
+
+	config.ID = uuid.New()
+	config.ResourceOwner = common.GetResourceOwner(ctx)
+	config.CreatedAt = now
+	config.UpdatedAt = now
+
+	created, err := usecase.EconomyWriter.Create(ctx, &config)
+	if err != nil {
+		slog.ErrorContext(ctx, "unable to create economy config", "gameID", config.GameID, "mode", config.Mode, "version", config.Version, "err", err)
+		return nil, err
+	}
+
+	return created, nil
+}
+
+// latestEconomyConfig returns the highest version of the economy config of the game mode (effective at the given time, when not nil).
+// Returns nil when there's none, the matches of the mode are then free.
+func latestEconomyConfig(ctx context.Context, reader matchmaking_out.EconomyConfigReader, gameID common.GameIDKey, mode matchmaking_entities.LobbyMode, at *time.Time) (*matchmaking_entities.EconomyConfig, error) {
+	values := []common.SearchableValue{
+		{Field: "GameID", Values: []interface{}{gameID}},
+		{Field: "Mode", Values: []interface{}{mode}},
+	}
+
+	if at != nil {
+		values = append(values, common.SearchableValue{Field: "EffectiveFrom", Operator: common.LessThanOrEqualOperator, Values: []interface{}{*at}})
+	}
+
+	search := common.NewSearchByValues(ctx, values, common.NewSearchResultOptions(0, 1), common.ClientApplicationAudienceIDKey)
+	search.SortOptions = []common.SearchSortOption{{Field: "Version", Direction: common.DescendingIDKey}}
+
+	configs, err := reader.Search(ctx, search)
+	if err != nil {
+		slog.ErrorContext(ctx, "unable to search economy configs", "gameID", gameID, "mode", mode, "err", err)
+		return nil, err
+	}
+
+	if len(configs) == 0 {
+		return nil, nil
+	}
+
+	return &configs[0], nil
+}
@@ -0,0 +1,63 @@
+package matchmaking_use_cases
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	matchmaking_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/matchmaking/entities"
+	matchmaking_in "github.com/psavelis/team-pro/replay-api/pkg/domain/matchmaking/ports/in"
+	matchmaking_out "github.com/psavelis/team-pro/replay-api/pkg/domain/matchmaking/ports/out"
+	matchmaking_strategies "github.com/psavelis/team-pro/replay-api/pkg/domain/matchmaking/strategies"
+)
+
+type CreateMatchmakingPoolUseCase struct {
+	PoolWriter matchmaking_out.MatchmakingPoolWriter
+}
+
+func NewCreateMatchmakingPoolUseCase(poolWriter matchmaking_out.MatchmakingPoolWriter) matchmaking_in.CreateMatchmakingPoolCommand {
+	return &CreateMatchmakingPoolUseCase{
+		PoolWriter: poolWriter,
+	}
+}
+
+func (usecase *CreateMatchmakingPoolUseCase) Exec(ctx context.Context, pool matchmaking_entities.MatchmakingPool) (*matchmaking_entities.MatchmakingPool, error) {
+	if pool.Mode == "" {
+		pool.Mode = matchmaking_entities.LobbyModeAutoBalance
+	}
+
+	if pool.Strategy == "" {
+		pool.Strategy = matchmaking_strategies.DefaultStrategyName
+	}
+
+	if pool.RoleSlots == nil {
+		pool.RoleSlots = []string{}
+	}
+
+	if pool.ShadowStrategies == nil {
+		pool.ShadowStrategies = []string{}
+	}
+
+	err := validatePool(pool)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+
+	pool.ID = uuid.New()
+	pool.Availability = nil
+	pool.ResourceOwner = common.GetResourceOwner(ctx)
+	pool.CreatedAt = now
+	pool.UpdatedAt = now
+
+	created, err := usecase.PoolWriter.Create(ctx, &pool)
+	if err != nil {
+		slog.ErrorContext(ctx, "unable to create matchmaking pool", "name", pool.Name, "err", err)
+		return nil, err
+	}
+
+	return created, nil
+}
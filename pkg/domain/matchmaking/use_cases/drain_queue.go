@@ -0,0 +1,63 @@
+package matchmaking_use_cases
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	matchmaking_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/matchmaking/entities"
+	matchmaking_in "github.com/psavelis/team-pro/replay-api/pkg/domain/matchmaking/ports/in"
+	matchmaking_out "github.com/psavelis/team-pro/replay-api/pkg/domain/matchmaking/ports/out"
+)
+
+type DrainQueueUseCase struct {
+	PoolReader   matchmaking_out.MatchmakingPoolReader
+	TicketReader matchmaking_out.QueueTicketReader
+	TicketWriter matchmaking_out.QueueTicketWriter
+}
+
+func NewDrainQueueUseCase(poolReader matchmaking_out.MatchmakingPoolReader, ticketReader matchmaking_out.QueueTicketReader, ticketWriter matchmaking_out.QueueTicketWriter) matchmaking_in.DrainQueueCommand {
+	return &DrainQueueUseCase{
+		PoolReader:   poolReader,
+		TicketReader: ticketReader,
+		TicketWriter: ticketWriter,
+	}
+}
+
+// Exec cancels up to MatchmakingTicketWindow waiting tickets per call (the oldest first): a longer queue takes several calls.
+func (usecase *DrainQueueUseCase) Exec(ctx context.Context, poolID uuid.UUID) (int, error) {
+	pool, err := getTenantPool(ctx, usecase.PoolReader, poolID)
+	if err != nil {
+		return 0, err
+	}
+
+	tickets, err := waitingTickets(ctx, usecase.TicketReader, *pool)
+	if err != nil {
+		return 0, err
+	}
+
+	now := time.Now().UTC()
+
+	var errs []error
+
+	drained := 0
+	for _, ticket := range tickets {
+		ticket.Status = matchmaking_entities.QueueTicketStatusCancelled
+		ticket.UpdatedAt = now
+
+		_, err := usecase.TicketWriter.Update(ctx, &ticket)
+		if err != nil {
+			slog.ErrorContext(ctx, "unable to cancel queue ticket of drained pool", "ticketID", ticket.ID, "poolID", poolID, "err", err)
+			errs = append(errs, err)
+			continue
+		}
+
+		drained++
+	}
+
+	slog.InfoContext(ctx, "matchmaking pool queue drained", "poolID", poolID, "tickets", drained)
+
+	return drained, errors.Join(errs...)
+}
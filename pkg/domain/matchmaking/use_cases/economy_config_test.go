@@ -0,0 +1,135 @@
+package matchmaking_use_cases_test
+
+import (
+	"context"
+	"sort"
+	"testing"
+	"time"
+
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	"github.com/psavelis/team-pro/replay-api/pkg/domain/matchmaking"
+	matchmaking_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/matchmaking/entities"
+	matchmaking_use_cases "github.com/psavelis/team-pro/replay-api/pkg/domain/matchmaking/use_cases"
+	"github.com/stretchr/testify/assert"
+)
+
+// mockEconomyStore filters by game, mode and effective date, highest version first.
+type mockEconomyStore struct {
+	configs []matchmaking_entities.EconomyConfig
+}
+
+func (m *mockEconomyStore) Search(ctx context.Context, s common.Search) ([]matchmaking_entities.EconomyConfig, error) {
+	res := make([]matchmaking_entities.EconomyConfig, 0)
+
+	for _, c := range m.configs {
+		match := true
+		for _, v := range s.SearchParams[0].Params[0].ValueParams {
+			switch v.Field {
+			case "GameID":
+				match = match && c.GameID == v.Values[0]
+			case "Mode":
+				match = match && c.Mode == v.Values[0]
+			case "EffectiveFrom":
+				match = match && !c.EffectiveFrom.After(v.Values[0].(time.Time))
+			}
+		}
+
+		if match {
+			res = append(res, c)
+		}
+	}
+
+	sort.Slice(res, func(i, j int) bool { return res[i].Version > res[j].Version })
+
+	if limit := int(s.ResultOptions.Limit); limit > 0 && len(res) > limit {
+		res = res[:limit]
+	}
+
+	return res, nil
+}
+
+func (m *mockEconomyStore) Compile(ctx context.Context, searchParams []common.SearchAggregation, resultOptions common.SearchResultOptions) (*common.Search, error) {
+	return nil, nil
+}
+
+func (m *mockEconomyStore) Create(ctx context.Context, config *matchmaking_entities.EconomyConfig) (*matchmaking_entities.EconomyConfig, error) {
+	m.configs = append(m.configs, *config)
+	return config, nil
+}
+
+func newEconomyConfig(entryFee int64, effectiveFrom time.Time) matchmaking_entities.EconomyConfig {
+	return matchmaking_entities.EconomyConfig{
+		GameID:                 common.CS2_GAME_ID,
+		Mode:                   matchmaking_entities.LobbyModeAutoBalance,
+		EffectiveFrom:          effectiveFrom,
+		Currency:               "USD",
+		EntryFee:               entryFee,
+		RakeBasisPoints:        1000,
+		WinnerSplitBasisPoints: []int{matchmaking_entities.BasisPoints},
+	}
+}
+
+func TestCreateEconomyConfigUseCase_Exec(t *testing.T) {
+	store := &mockEconomyStore{}
+	usecase := matchmaking_use_cases.NewCreateEconomyConfigUseCase(store, store)
+
+	first, err := usecase.Exec(systemContext(), newEconomyConfig(500, time.Time{}))
+	assert.NoError(t, err)
+	assert.Equal(t, 1, first.Version)
+	assert.False(t, first.EffectiveFrom.IsZero())
+	assert.Equal(t, tenantID, first.ResourceOwner.TenantID)
+
+	second, err := usecase.Exec(systemContext(), newEconomyConfig(1000, time.Now().Add(time.Hour)))
+	assert.NoError(t, err)
+	assert.Equal(t, 2, second.Version)
+
+	// versions of other modes are numbered apart
+	draft := newEconomyConfig(1000, time.Time{})
+	draft.Mode = matchmaking_entities.LobbyModeCaptainDraft
+
+	other, err := usecase.Exec(systemContext(), draft)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, other.Version)
+
+	var invalid *matchmaking.InvalidEconomyConfigError
+
+	_, err = usecase.Exec(systemContext(), newEconomyConfig(1000, time.Now()))
+	assert.ErrorAs(t, err, &invalid, "effective before the latest version")
+
+	_, err = usecase.Exec(systemContext(), newEconomyConfig(-1, time.Time{}))
+	assert.ErrorAs(t, err, &invalid)
+
+	assert.Len(t, store.configs, 3)
+}
+
+func TestRunMatchmakingUseCase_Exec_Economy(t *testing.T) {
+	pool := newPool(1)
+
+	current := newEconomyConfig(500, time.Now().Add(-time.Hour))
+	current.Version = 1
+
+	// not effective yet
+	upcoming := newEconomyConfig(1000, time.Now().Add(time.Hour))
+	upcoming.Version = 2
+
+	economy := &mockEconomyStore{configs: []matchmaking_entities.EconomyConfig{current, upcoming}}
+
+	tickets := newMockTicketStore(newTicket(pool, 1000, time.Minute), newTicket(pool, 1000, time.Minute))
+	lobbies := newMockLobbyStore()
+	events := &mockLobbyEventPublisher{}
+
+	n, err := matchmaking_use_cases.NewRunMatchmakingUseCase(newMockPoolStore(pool), tickets, tickets, lobbies, lobbies, &mockEvaluationWriter{}, economy, events).Exec(systemContext())
+	assert.NoError(t, err)
+	assert.Equal(t, 1, n)
+
+	for _, lobby := range lobbies.lobbies {
+		if assert.NotNil(t, lobby.Economy) {
+			assert.Equal(t, 1, lobby.Economy.Version)
+			assert.Equal(t, int64(500), lobby.Economy.EntryFee)
+		}
+	}
+
+	if assert.Len(t, events.events, 1) {
+		assert.Equal(t, 1, events.events[0].Economy.Version)
+	}
+}
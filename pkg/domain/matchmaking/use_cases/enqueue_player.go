@@ -0,0 +1,138 @@
+package matchmaking_use_cases
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	"github.com/psavelis/team-pro/replay-api/pkg/domain/matchmaking"
+	matchmaking_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/matchmaking/entities"
+	matchmaking_in "github.com/psavelis/team-pro/replay-api/pkg/domain/matchmaking/ports/in"
+	matchmaking_out "github.com/psavelis/team-pro/replay-api/pkg/domain/matchmaking/ports/out"
+)
+
+type EnqueuePlayerUseCase struct {
+	PoolReader    matchmaking_out.MatchmakingPoolReader
+	TicketReader  matchmaking_out.QueueTicketReader
+	TicketWriter  matchmaking_out.QueueTicketWriter
+	RatingReader  matchmaking_out.PlayerRatingReader
+	PenaltyReader matchmaking_out.QueuePenaltyReader
+}
+
+func NewEnqueuePlayerUseCase(poolReader matchmaking_out.MatchmakingPoolReader, ticketReader matchmaking_out.QueueTicketReader, ticketWriter matchmaking_out.QueueTicketWriter, ratingReader matchmaking_out.PlayerRatingReader, penaltyReader matchmaking_out.QueuePenaltyReader) matchmaking_in.EnqueuePlayerCommandHandler {
+	return &EnqueuePlayerUseCase{
+		PoolReader:    poolReader,
+		TicketReader:  ticketReader,
+		TicketWriter:  ticketWriter,
+		RatingReader:  ratingReader,
+		PenaltyReader: penaltyReader,
+	}
+}
+
+func (usecase *EnqueuePlayerUseCase) Exec(ctx context.Context, cmd matchmaking_in.EnqueuePlayerCommand) (*matchmaking_entities.QueueTicket, error) {
+	pool, err := getTenantPool(ctx, usecase.PoolReader, cmd.PoolID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !pool.Enabled {
+		return nil, matchmaking.NewQueueStateError(fmt.Sprintf("matchmaking pool '%s' is not accepting players", pool.Name))
+	}
+
+	availability := pool.AvailabilityAt(time.Now().UTC())
+	if !availability.Open {
+		if availability.OpensAt == nil {
+			return nil, matchmaking.NewQueueStateError(fmt.Sprintf("matchmaking pool '%s' has no upcoming window", pool.Name))
+		}
+
+		return nil, matchmaking.NewQueueStateError(fmt.Sprintf("matchmaking pool '%s' is closed, '%s' opens at %s", pool.Name, availability.Window, availability.OpensAt.Format(time.RFC3339)))
+	}
+
+	if cmd.PlayerID == uuid.Nil {
+		return nil, matchmaking.NewQueueStateError("player_id is required")
+	}
+
+	for _, role := range cmd.Roles {
+		if !containsRole(pool.RoleSlots, role) {
+			return nil, matchmaking.NewQueueStateError(fmt.Sprintf("role '%s' is not available in matchmaking pool '%s'", role, pool.Name))
+		}
+	}
+
+	resourceOwner := common.GetResourceOwner(ctx)
+
+	waiting, err := usecase.TicketReader.Search(ctx, common.NewSearchByValues(ctx, []common.SearchableValue{
+		{Field: "UserID", Values: []interface{}{resourceOwner.UserID}},
+		{Field: "Status", Values: []interface{}{matchmaking_entities.QueueTicketStatusWaiting}},
+	}, common.NewSearchResultOptions(0, 1), common.UserAudienceIDKey))
+
+	if err != nil {
+		slog.ErrorContext(ctx, "unable to search waiting queue tickets", "err", err)
+		return nil, err
+	}
+
+	if len(waiting) > 0 {
+		return nil, matchmaking.NewQueueStateError(fmt.Sprintf("player is already queued (ticket %s)", waiting[0].ID))
+	}
+
+	now := time.Now().UTC()
+
+	penalties, err := usecase.PenaltyReader.Search(ctx, common.NewSearchByValues(ctx, []common.SearchableValue{
+		{Field: "UserID", Values: []interface{}{resourceOwner.UserID}},
+	}, common.NewSearchResultOptions(0, 1), common.UserAudienceIDKey))
+
+	if err != nil {
+		slog.ErrorContext(ctx, "unable to search queue penalties", "err", err)
+		return nil, err
+	}
+
+	if len(penalties) > 0 && penalties[0].InCooldown(now) {
+		return nil, matchmaking.NewQueueStateError(fmt.Sprintf("player declined %d match(es), queue cooldown until %s", penalties[0].Declines, penalties[0].CooldownUntil.Format(time.RFC3339)))
+	}
+
+	rating, err := usecase.RatingReader.GetRating(ctx, pool.GameID, cmd.PlayerID)
+	if err != nil {
+		slog.ErrorContext(ctx, "unable to get player rating", "playerID", cmd.PlayerID, "err", err)
+		return nil, err
+	}
+
+	roles := cmd.Roles
+	if roles == nil {
+		roles = []string{}
+	}
+
+	ticket := &matchmaking_entities.QueueTicket{
+		ID:             uuid.New(),
+		PoolID:         pool.ID,
+		UserID:         resourceOwner.UserID,
+		PlayerID:       cmd.PlayerID,
+		Rating:         rating,
+		Roles:          roles,
+		Status:         matchmaking_entities.QueueTicketStatusWaiting,
+		AcceptBackfill: cmd.AcceptBackfill,
+		EnqueuedAt:     now,
+		ResourceOwner:  resourceOwner,
+		CreatedAt:      now,
+		UpdatedAt:      now,
+	}
+
+	ticket, err = usecase.TicketWriter.Create(ctx, ticket)
+	if err != nil {
+		slog.ErrorContext(ctx, "unable to create queue ticket", "poolID", pool.ID, "err", err)
+		return nil, err
+	}
+
+	return ticket, nil
+}
+
+func containsRole(roles []string, role string) bool {
+	for _, r := range roles {
+		if r == role {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,65 @@
+package matchmaking_use_cases
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	matchmaking_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/matchmaking/entities"
+	matchmaking_in "github.com/psavelis/team-pro/replay-api/pkg/domain/matchmaking/ports/in"
+	matchmaking_out "github.com/psavelis/team-pro/replay-api/pkg/domain/matchmaking/ports/out"
+)
+
+// max lobbies handled per run; the remainder is picked up by the next run.
+const DraftExpirationBatchSize = 100
+
+type ExpireDraftPicksUseCase struct {
+	LobbyReader matchmaking_out.LobbyReader
+	LobbyWriter matchmaking_out.LobbyWriter
+}
+
+func NewExpireDraftPicksUseCase(lobbyReader matchmaking_out.LobbyReader, lobbyWriter matchmaking_out.LobbyWriter) matchmaking_in.ExpireDraftPicksCommand {
+	return &ExpireDraftPicksUseCase{
+		LobbyReader: lobbyReader,
+		LobbyWriter: lobbyWriter,
+	}
+}
+
+func (usecase *ExpireDraftPicksUseCase) Exec(ctx context.Context) (int, error) {
+	now := time.Now().UTC()
+
+	lobbies, err := usecase.LobbyReader.Search(ctx, common.NewSearchByValues(ctx, []common.SearchableValue{
+		{Field: "Status", Values: []interface{}{matchmaking_entities.LobbyStatusDrafting}},
+		{Field: "Draft.TurnDeadline", Operator: common.LessThanOperator, Values: []interface{}{now}},
+	}, common.NewSearchResultOptions(0, DraftExpirationBatchSize), common.ClientApplicationAudienceIDKey))
+
+	if err != nil {
+		slog.ErrorContext(ctx, "unable to search lobbies with expired draft picks", "err", err)
+		return 0, err
+	}
+
+	var errs []error
+
+	picks := 0
+	for i := range lobbies {
+		lobby := &lobbies[i]
+
+		n := lobby.AutoPickExpired(now)
+		if n == 0 {
+			continue
+		}
+
+		_, err := usecase.LobbyWriter.Update(ctx, lobby)
+		if err != nil {
+			slog.ErrorContext(ctx, "unable to save expired draft picks", "lobbyID", lobby.ID, "err", err)
+			errs = append(errs, err)
+			continue
+		}
+
+		picks += n
+	}
+
+	return picks, errors.Join(errs...)
+}
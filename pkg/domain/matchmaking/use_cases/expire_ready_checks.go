@@ -0,0 +1,80 @@
+package matchmaking_use_cases
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	matchmaking_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/matchmaking/entities"
+	matchmaking_in "github.com/psavelis/team-pro/replay-api/pkg/domain/matchmaking/ports/in"
+	matchmaking_out "github.com/psavelis/team-pro/replay-api/pkg/domain/matchmaking/ports/out"
+)
+
+// max lobbies handled per run; the remainder is picked up by the next run.
+const ReadyCheckExpirationBatchSize = 100
+
+type ExpireReadyChecksUseCase struct {
+	LobbyReader   matchmaking_out.LobbyReader
+	LobbyWriter   matchmaking_out.LobbyWriter
+	TicketReader  matchmaking_out.QueueTicketReader
+	TicketWriter  matchmaking_out.QueueTicketWriter
+	PenaltyReader matchmaking_out.QueuePenaltyReader
+	PenaltyWriter matchmaking_out.QueuePenaltyWriter
+}
+
+func NewExpireReadyChecksUseCase(lobbyReader matchmaking_out.LobbyReader, lobbyWriter matchmaking_out.LobbyWriter, ticketReader matchmaking_out.QueueTicketReader, ticketWriter matchmaking_out.QueueTicketWriter, penaltyReader matchmaking_out.QueuePenaltyReader, penaltyWriter matchmaking_out.QueuePenaltyWriter) matchmaking_in.ExpireReadyChecksCommand {
+	return &ExpireReadyChecksUseCase{
+		LobbyReader:   lobbyReader,
+		LobbyWriter:   lobbyWriter,
+		TicketReader:  ticketReader,
+		TicketWriter:  ticketWriter,
+		PenaltyReader: penaltyReader,
+		PenaltyWriter: penaltyWriter,
+	}
+}
+
+func (usecase *ExpireReadyChecksUseCase) Exec(ctx context.Context) (int, error) {
+	now := time.Now().UTC()
+
+	lobbies, err := usecase.LobbyReader.Search(ctx, common.NewSearchByValues(ctx, []common.SearchableValue{
+		{Field: "Status", Values: []interface{}{matchmaking_entities.LobbyStatusReadyCheck}},
+		{Field: "ReadyCheck.Deadline", Operator: common.LessThanOperator, Values: []interface{}{now}},
+	}, common.NewSearchResultOptions(0, ReadyCheckExpirationBatchSize), common.ClientApplicationAudienceIDKey))
+
+	if err != nil {
+		slog.ErrorContext(ctx, "unable to search lobbies with expired ready checks", "err", err)
+		return 0, err
+	}
+
+	ports := readyCheckPorts{
+		LobbyWriter:   usecase.LobbyWriter,
+		TicketReader:  usecase.TicketReader,
+		TicketWriter:  usecase.TicketWriter,
+		PenaltyReader: usecase.PenaltyReader,
+		PenaltyWriter: usecase.PenaltyWriter,
+	}
+
+	var errs []error
+
+	cancelled := 0
+	for i := range lobbies {
+		lobby := &lobbies[i]
+
+		if lobby.ReadyCheck == nil || !lobby.ReadyCheck.IsExpired(now) {
+			continue
+		}
+
+		// players that didn't accept in time count as declined
+		err := failReadyCheck(ctx, ports, lobby, lobby.PendingReadyCheck(), now)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+
+		cancelled++
+	}
+
+	return cancelled, errors.Join(errs...)
+}
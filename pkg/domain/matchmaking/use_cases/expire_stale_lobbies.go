@@ -0,0 +1,212 @@
+package matchmaking_use_cases
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	matchmaking_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/matchmaking/entities"
+	matchmaking_in "github.com/psavelis/team-pro/replay-api/pkg/domain/matchmaking/ports/in"
+	matchmaking_out "github.com/psavelis/team-pro/replay-api/pkg/domain/matchmaking/ports/out"
+)
+
+// max lobbies (and tickets) of a pool expired per run; the remainder is picked up by the next run.
+const StaleLobbyBatchSize = 100
+
+// ExpireStaleLobbiesUseCase is the matchmaking janitor: lobbies stuck before their match (ie: the leader never started the draft,
+// the match was never linked) are cancelled, and tickets nobody could be matched with leave the queue.
+type ExpireStaleLobbiesUseCase struct {
+	PoolReader     matchmaking_out.MatchmakingPoolReader
+	LobbyReader    matchmaking_out.LobbyReader
+	LobbyWriter    matchmaking_out.LobbyWriter
+	TicketReader   matchmaking_out.QueueTicketReader
+	TicketWriter   matchmaking_out.QueueTicketWriter
+	EventPublisher matchmaking_out.LobbyEventPublisher
+}
+
+func NewExpireStaleLobbiesUseCase(poolReader matchmaking_out.MatchmakingPoolReader, lobbyReader matchmaking_out.LobbyReader, lobbyWriter matchmaking_out.LobbyWriter, ticketReader matchmaking_out.QueueTicketReader, ticketWriter matchmaking_out.QueueTicketWriter, eventPublisher matchmaking_out.LobbyEventPublisher) matchmaking_in.ExpireStaleLobbiesCommand {
+	return &ExpireStaleLobbiesUseCase{
+		PoolReader:     poolReader,
+		LobbyReader:    lobbyReader,
+		LobbyWriter:    lobbyWriter,
+		TicketReader:   ticketReader,
+		TicketWriter:   ticketWriter,
+		EventPublisher: eventPublisher,
+	}
+}
+
+func (usecase *ExpireStaleLobbiesUseCase) Exec(ctx context.Context) (int, int, error) {
+	// disabled pools included: their lobbies and tickets are left behind when the pool is disabled
+	pools, err := usecase.PoolReader.Search(ctx, common.NewSearchByValues(ctx, []common.SearchableValue{}, common.NewSearchResultOptions(0, MatchmakingPoolBatchSize), common.ClientApplicationAudienceIDKey))
+	if err != nil {
+		slog.ErrorContext(ctx, "unable to search matchmaking pools", "err", err)
+		return 0, 0, err
+	}
+
+	now := time.Now().UTC()
+
+	var errs []error
+
+	cancelled, expired := 0, 0
+	for _, pool := range pools {
+		if pool.LobbyTimeoutSeconds > 0 {
+			n, err := usecase.expireLobbies(ctx, pool, now)
+			cancelled += n
+
+			if err != nil {
+				errs = append(errs, err)
+			}
+		}
+
+		if pool.QueueTimeoutSeconds > 0 {
+			n, err := usecase.expireTickets(ctx, pool, now)
+			expired += n
+
+			if err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}
+
+	return cancelled, expired, errors.Join(errs...)
+}
+
+// expireLobbies cancels the pending lobbies of the pool formed before the lobby timeout, queueing their players again in their
+// original place (abandoned players excluded).
+func (usecase *ExpireStaleLobbiesUseCase) expireLobbies(ctx context.Context, pool matchmaking_entities.MatchmakingPool, now time.Time) (int, error) {
+	lobbies, err := usecase.LobbyReader.Search(ctx, common.NewSearchByValues(ctx, []common.SearchableValue{
+		{Field: "PoolID", Values: []interface{}{pool.ID}},
+		{Field: "Status", Operator: common.InOperator, Values: []interface{}{
+			matchmaking_entities.LobbyStatusForming,
+			matchmaking_entities.LobbyStatusDrafting,
+			matchmaking_entities.LobbyStatusReady,
+		}},
+		{Field: "CreatedAt", Operator: common.LessThanOperator, Values: []interface{}{now.Add(-pool.LobbyTimeout())}},
+	}, common.NewSearchResultOptions(0, StaleLobbyBatchSize), common.ClientApplicationAudienceIDKey))
+
+	if err != nil {
+		slog.ErrorContext(ctx, "unable to search stale lobbies of pool", "poolID", pool.ID, "err", err)
+		return 0, err
+	}
+
+	var errs []error
+
+	cancelled := 0
+	for i := range lobbies {
+		lobby := &lobbies[i]
+
+		if !lobby.IsPending() {
+			continue
+		}
+
+		status := lobby.Status
+		lobby.Expire(now)
+
+		_, err := usecase.LobbyWriter.Update(ctx, lobby)
+		if err != nil {
+			slog.ErrorContext(ctx, "unable to cancel stale lobby", "lobbyID", lobby.ID, "err", err)
+			errs = append(errs, err)
+			continue
+		}
+
+		cancelled++
+
+		requeued, err := usecase.requeuePlayers(ctx, *lobby, now)
+		if err != nil {
+			errs = append(errs, err)
+		}
+
+		slog.InfoContext(ctx, "stale lobby cancelled", "lobbyID", lobby.ID, "poolID", pool.ID, "status", status, "requeued", len(requeued))
+
+		// the lobby is already cancelled: a failed publish is logged, not retried (players can still find it by polling)
+		if usecase.EventPublisher != nil {
+			err = usecase.EventPublisher.PublishLobbyCancelled(ctx, matchmaking_entities.NewLobbyCancelled(*lobby, requeued))
+			if err != nil {
+				slog.WarnContext(ctx, "unable to publish lobby cancelled event", "lobbyID", lobby.ID, "err", err)
+			}
+		}
+	}
+
+	return cancelled, errors.Join(errs...)
+}
+
+// requeuePlayers puts the matched tickets of the lobby back in the queue, returning the users queued again.
+func (usecase *ExpireStaleLobbiesUseCase) requeuePlayers(ctx context.Context, lobby matchmaking_entities.Lobby, now time.Time) ([]uuid.UUID, error) {
+	tickets, err := usecase.TicketReader.Search(ctx, common.NewSearchByValues(ctx, []common.SearchableValue{
+		{Field: "LobbyID", Values: []interface{}{lobby.ID}},
+		{Field: "Status", Values: []interface{}{matchmaking_entities.QueueTicketStatusMatched}},
+	}, common.NewSearchResultOptions(0, uint(len(lobby.Players))), common.ClientApplicationAudienceIDKey))
+
+	if err != nil {
+		slog.ErrorContext(ctx, "unable to search queue tickets of cancelled lobby", "lobbyID", lobby.ID, "err", err)
+		return nil, err
+	}
+
+	var errs []error
+
+	requeued := make([]uuid.UUID, 0, len(tickets))
+	for _, ticket := range tickets {
+		player := lobby.GetPlayer(ticket.UserID)
+		if player == nil || player.AbandonedAt != nil {
+			continue
+		}
+
+		err := requeueTicket(ctx, usecase.TicketReader, usecase.TicketWriter, ticket, now)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+
+		requeued = append(requeued, ticket.UserID)
+	}
+
+	return requeued, errors.Join(errs...)
+}
+
+// expireTickets takes the tickets waiting longer than the queue timeout out of the queue. The wait is counted from the last time the
+// ticket was queued (UpdatedAt): tickets queued again after a cancelled lobby keep their EnqueuedAt.
+func (usecase *ExpireStaleLobbiesUseCase) expireTickets(ctx context.Context, pool matchmaking_entities.MatchmakingPool, now time.Time) (int, error) {
+	tickets, err := usecase.TicketReader.Search(ctx, common.NewSearchByValues(ctx, []common.SearchableValue{
+		{Field: "PoolID", Values: []interface{}{pool.ID}},
+		{Field: "Status", Values: []interface{}{matchmaking_entities.QueueTicketStatusWaiting}},
+		{Field: "UpdatedAt", Operator: common.LessThanOperator, Values: []interface{}{now.Add(-pool.QueueTimeout())}},
+	}, common.NewSearchResultOptions(0, StaleLobbyBatchSize), common.ClientApplicationAudienceIDKey))
+
+	if err != nil {
+		slog.ErrorContext(ctx, "unable to search stale queue tickets of pool", "poolID", pool.ID, "err", err)
+		return 0, err
+	}
+
+	var errs []error
+
+	expired := 0
+	for _, ticket := range tickets {
+		ticket.Status = matchmaking_entities.QueueTicketStatusExpired
+		ticket.UpdatedAt = now
+
+		_, err := usecase.TicketWriter.Update(ctx, &ticket)
+		if err != nil {
+			slog.ErrorContext(ctx, "unable to expire queue ticket", "ticketID", ticket.ID, "err", err)
+			errs = append(errs, err)
+			continue
+		}
+
+		expired++
+
+		if usecase.EventPublisher != nil {
+			err = usecase.EventPublisher.PublishQueueTicketExpired(ctx, matchmaking_entities.NewQueueTicketExpired(ticket))
+			if err != nil {
+				slog.WarnContext(ctx, "unable to publish queue ticket expired event", "ticketID", ticket.ID, "err", err)
+			}
+		}
+	}
+
+	if expired > 0 {
+		slog.InfoContext(ctx, "stale queue tickets expired", "poolID", pool.ID, "tickets", expired)
+	}
+
+	return expired, errors.Join(errs...)
+}
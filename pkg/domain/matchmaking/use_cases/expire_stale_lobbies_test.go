@@ -0,0 +1,110 @@
+package matchmaking_use_cases_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	matchmaking_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/matchmaking/entities"
+	matchmaking_use_cases "github.com/psavelis/team-pro/replay-api/pkg/domain/matchmaking/use_cases"
+	"github.com/stretchr/testify/assert"
+)
+
+// newPoolLobby returns a lobby of the pool formed age ago, with the matched tickets of its players.
+func newPoolLobby(pool matchmaking_entities.MatchmakingPool, status matchmaking_entities.LobbyStatus, age time.Duration) (matchmaking_entities.Lobby, []matchmaking_entities.QueueTicket) {
+	lobby := newLobby(status, false)
+	lobby.PoolID = &pool.ID
+	lobby.CreatedAt = time.Now().Add(-age)
+	lobby.UpdatedAt = lobby.CreatedAt
+
+	tickets := make([]matchmaking_entities.QueueTicket, 0, len(lobby.Players))
+	for _, p := range lobby.Players {
+		t := newTicket(pool, 1000, age+time.Minute)
+		t.UserID = p.UserID
+		t.Status = matchmaking_entities.QueueTicketStatusMatched
+		t.LobbyID = &lobby.ID
+		t.MatchedAt = &lobby.CreatedAt
+		t.UpdatedAt = lobby.CreatedAt
+
+		tickets = append(tickets, t)
+	}
+
+	return lobby, tickets
+}
+
+func TestExpireStaleLobbiesUseCase_Exec(t *testing.T) {
+	pool := newPool(1)
+	pool.Enabled = false // left behind lobbies and tickets of disabled pools are expired too
+	pool.LobbyTimeoutSeconds = 600
+	pool.QueueTimeoutSeconds = 900
+
+	stale, staleTickets := newPoolLobby(pool, matchmaking_entities.LobbyStatusReady, 20*time.Minute)
+	abandonedAt := time.Now().Add(-15 * time.Minute)
+	stale.Players[1].AbandonedAt = &abandonedAt
+
+	fresh, freshTickets := newPoolLobby(pool, matchmaking_entities.LobbyStatusReady, time.Minute)
+	playing, playingTickets := newPoolLobby(pool, matchmaking_entities.LobbyStatusInMatch, time.Hour)
+
+	waitingLong := newTicket(pool, 1000, time.Hour)
+	waitingLong.UpdatedAt = time.Now().Add(-20 * time.Minute)
+
+	waiting := newTicket(pool, 1000, time.Hour)
+	waiting.UpdatedAt = time.Now().Add(-time.Minute)
+
+	tickets := append(append(append([]matchmaking_entities.QueueTicket{waitingLong, waiting}, staleTickets...), freshTickets...), playingTickets...)
+
+	lobbies := newMockLobbyStore(stale, fresh, playing)
+	ticketStore := newMockTicketStore(tickets...)
+	publisher := &mockLobbyEventPublisher{}
+
+	usecase := matchmaking_use_cases.NewExpireStaleLobbiesUseCase(newMockPoolStore(pool), lobbies, lobbies, ticketStore, ticketStore, publisher)
+
+	cancelled, expired, err := usecase.Exec(systemContext())
+	assert.NoError(t, err)
+	assert.Equal(t, 1, cancelled)
+	assert.Equal(t, 1, expired)
+
+	assert.Equal(t, matchmaking_entities.LobbyStatusCancelled, lobbies.lobbies[stale.ID].Status)
+	assert.Equal(t, matchmaking_entities.LobbyCancelReasonExpired, lobbies.lobbies[stale.ID].CancelReason)
+	assert.Equal(t, matchmaking_entities.LobbyStatusReady, lobbies.lobbies[fresh.ID].Status)
+	assert.Equal(t, matchmaking_entities.LobbyStatusInMatch, lobbies.lobbies[playing.ID].Status)
+
+	// queued again in their original place, not expired right away
+	requeued := ticketStore.tickets[staleTickets[0].ID]
+	assert.Equal(t, matchmaking_entities.QueueTicketStatusWaiting, requeued.Status)
+	assert.Nil(t, requeued.LobbyID)
+	assert.Equal(t, staleTickets[0].EnqueuedAt, requeued.EnqueuedAt)
+
+	// abandoned players aren't queued again
+	assert.Equal(t, matchmaking_entities.QueueTicketStatusMatched, ticketStore.tickets[staleTickets[1].ID].Status)
+
+	assert.Equal(t, matchmaking_entities.QueueTicketStatusExpired, ticketStore.tickets[waitingLong.ID].Status)
+	assert.Equal(t, matchmaking_entities.QueueTicketStatusWaiting, ticketStore.tickets[waiting.ID].Status)
+
+	if assert.Len(t, publisher.cancelled, 1) {
+		assert.Equal(t, stale.ID, publisher.cancelled[0].LobbyID)
+		assert.Equal(t, pool.ID, publisher.cancelled[0].PoolID)
+		assert.Equal(t, []uuid.UUID{stale.Players[0].UserID}, publisher.cancelled[0].Requeued)
+	}
+
+	if assert.Len(t, publisher.expired, 1) {
+		assert.Equal(t, waitingLong.ID, publisher.expired[0].TicketID)
+	}
+}
+
+func TestExpireStaleLobbiesUseCase_Exec_NoTimeouts(t *testing.T) {
+	pool := newPool(1)
+
+	stale, staleTickets := newPoolLobby(pool, matchmaking_entities.LobbyStatusForming, 24*time.Hour)
+
+	lobbies := newMockLobbyStore(stale)
+	ticketStore := newMockTicketStore(staleTickets...)
+
+	usecase := matchmaking_use_cases.NewExpireStaleLobbiesUseCase(newMockPoolStore(pool), lobbies, lobbies, ticketStore, ticketStore, nil)
+
+	cancelled, expired, err := usecase.Exec(systemContext())
+	assert.NoError(t, err)
+	assert.Zero(t, cancelled)
+	assert.Zero(t, expired)
+	assert.Equal(t, matchmaking_entities.LobbyStatusForming, lobbies.lobbies[stale.ID].Status)
+}
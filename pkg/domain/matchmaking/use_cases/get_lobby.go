@@ -0,0 +1,35 @@
+package matchmaking_use_cases
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	"github.com/psavelis/team-pro/replay-api/pkg/domain/matchmaking"
+	matchmaking_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/matchmaking/entities"
+	matchmaking_in "github.com/psavelis/team-pro/replay-api/pkg/domain/matchmaking/ports/in"
+	matchmaking_out "github.com/psavelis/team-pro/replay-api/pkg/domain/matchmaking/ports/out"
+)
+
+type GetLobbyUseCase struct {
+	LobbyReader matchmaking_out.LobbyReader
+}
+
+func NewGetLobbyUseCase(lobbyReader matchmaking_out.LobbyReader) matchmaking_in.GetLobbyQuery {
+	return &GetLobbyUseCase{
+		LobbyReader: lobbyReader,
+	}
+}
+
+func (usecase *GetLobbyUseCase) Exec(ctx context.Context, lobbyID uuid.UUID) (*matchmaking_entities.Lobby, error) {
+	lobby, err := getTenantLobby(ctx, usecase.LobbyReader, lobbyID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !lobby.HasUser(common.GetResourceOwner(ctx).UserID) {
+		return nil, matchmaking.NewLobbyForbiddenError("only lobby players can view the lobby")
+	}
+
+	return lobby, nil
+}
@@ -0,0 +1,85 @@
+package matchmaking_use_cases
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	"github.com/psavelis/team-pro/replay-api/pkg/domain/matchmaking"
+	matchmaking_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/matchmaking/entities"
+	matchmaking_in "github.com/psavelis/team-pro/replay-api/pkg/domain/matchmaking/ports/in"
+	matchmaking_out "github.com/psavelis/team-pro/replay-api/pkg/domain/matchmaking/ports/out"
+)
+
+// lobbies a player is still part of (until the match is completed, or the lobby cancelled)
+var activeLobbyStatuses = []interface{}{
+	matchmaking_entities.LobbyStatusReadyCheck,
+	matchmaking_entities.LobbyStatusForming,
+	matchmaking_entities.LobbyStatusDrafting,
+	matchmaking_entities.LobbyStatusReady,
+	matchmaking_entities.LobbyStatusInMatch,
+}
+
+type GetMatchmakingSessionUseCase struct {
+	TicketReader        matchmaking_out.QueueTicketReader
+	LobbyReader         matchmaking_out.LobbyReader
+	GetQueueStatusQuery matchmaking_in.GetQueueStatusQuery
+}
+
+func NewGetMatchmakingSessionUseCase(ticketReader matchmaking_out.QueueTicketReader, lobbyReader matchmaking_out.LobbyReader, getQueueStatusQuery matchmaking_in.GetQueueStatusQuery) matchmaking_in.GetMatchmakingSessionQuery {
+	return &GetMatchmakingSessionUseCase{
+		TicketReader:        ticketReader,
+		LobbyReader:         lobbyReader,
+		GetQueueStatusQuery: getQueueStatusQuery,
+	}
+}
+
+func (usecase *GetMatchmakingSessionUseCase) Exec(ctx context.Context) (*matchmaking_entities.MatchmakingSession, error) {
+	if !common.IsAuthenticatedUser(ctx) {
+		return nil, matchmaking.NewLobbyForbiddenError("only users have a matchmaking session")
+	}
+
+	resourceOwner := common.GetResourceOwner(ctx)
+
+	waiting, err := usecase.TicketReader.Search(ctx, common.NewSearchByValues(ctx, []common.SearchableValue{
+		{Field: "UserID", Values: []interface{}{resourceOwner.UserID}},
+		{Field: "Status", Values: []interface{}{matchmaking_entities.QueueTicketStatusWaiting}},
+	}, common.NewSearchResultOptions(0, 1), common.UserAudienceIDKey))
+
+	if err != nil {
+		slog.ErrorContext(ctx, "unable to search waiting queue tickets", "err", err)
+		return nil, err
+	}
+
+	var queue *matchmaking_entities.QueueStatus
+	if len(waiting) > 0 {
+		queue, err = usecase.GetQueueStatusQuery.Exec(ctx, waiting[0].ID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// lobbies are created by the matcher (at client level): the user is found among their players
+	search := common.NewSearchByValues(ctx, []common.SearchableValue{
+		{Field: "Players.UserID", Values: []interface{}{resourceOwner.UserID}},
+		{Field: "Status", Operator: common.InOperator, Values: activeLobbyStatuses},
+	}, common.NewSearchResultOptions(0, 1), common.ClientApplicationAudienceIDKey)
+
+	search.SortOptions = []common.SearchSortOption{{Field: "CreatedAt", Direction: common.DescendingIDKey}}
+
+	lobbies, err := usecase.LobbyReader.Search(ctx, search)
+	if err != nil {
+		slog.ErrorContext(ctx, "unable to search active lobbies of the user", "err", err)
+		return nil, err
+	}
+
+	var lobby *matchmaking_entities.Lobby
+	if len(lobbies) > 0 && lobbies[0].ResourceOwner.TenantID == resourceOwner.TenantID {
+		lobby = &lobbies[0]
+	}
+
+	session := matchmaking_entities.NewMatchmakingSession(resourceOwner.UserID, queue, lobby, time.Now().UTC())
+
+	return &session, nil
+}
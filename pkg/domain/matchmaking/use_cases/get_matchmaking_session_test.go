@@ -0,0 +1,85 @@
+package matchmaking_use_cases_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	"github.com/psavelis/team-pro/replay-api/pkg/domain/matchmaking"
+	matchmaking_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/matchmaking/entities"
+	matchmaking_use_cases "github.com/psavelis/team-pro/replay-api/pkg/domain/matchmaking/use_cases"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetMatchmakingSessionUseCase_Exec(t *testing.T) {
+	pool := newPool(1)
+
+	ahead := newTicket(pool, 1000, 2*time.Minute)
+	mine := newTicket(pool, 1000, time.Minute)
+	mine.UserID = memberID
+
+	tickets := newMockTicketStore(ahead, mine)
+
+	readyCheck := newLobby(matchmaking_entities.LobbyStatusReadyCheck, false)
+	readyCheck.StartReadyCheck(time.Now(), 30*time.Second)
+	readyCheck.ReadyCheck.Accepted = append(readyCheck.ReadyCheck.Accepted, leaderID)
+
+	completed := newLobby(matchmaking_entities.LobbyStatusCompleted, false)
+	lobbies := newMockLobbyStore(readyCheck, completed)
+
+	usecase := matchmaking_use_cases.NewGetMatchmakingSessionUseCase(tickets, lobbies, matchmaking_use_cases.NewGetQueueStatusUseCase(tickets))
+
+	session, err := usecase.Exec(userContext(memberID))
+	assert.NoError(t, err)
+	if assert.NotNil(t, session.Queue) {
+		assert.Equal(t, mine.ID, session.Queue.TicketID)
+		assert.Equal(t, 2, session.Queue.Position)
+	}
+
+	if assert.NotNil(t, session.Lobby) {
+		assert.Equal(t, readyCheck.ID, session.Lobby.ID)
+	}
+
+	if assert.NotNil(t, session.PendingAccept) {
+		assert.False(t, session.PendingAccept.Accepted)
+		assert.Equal(t, 1, session.PendingAccept.Pending)
+	}
+
+	// the version changes with the state, once the user accepts
+	again, err := usecase.Exec(userContext(memberID))
+	assert.NoError(t, err)
+	assert.Equal(t, session.Version, again.Version)
+
+	accepted := readyCheck
+	accepted.ReadyCheck.Accepted = append(accepted.ReadyCheck.Accepted, memberID)
+	lobbies.lobbies[accepted.ID] = accepted
+
+	again, err = usecase.Exec(userContext(memberID))
+	assert.NoError(t, err)
+	assert.NotEqual(t, session.Version, again.Version)
+	assert.True(t, again.PendingAccept.Accepted)
+
+	// nothing to restore
+	empty, err := usecase.Exec(userContext(uuid.New()))
+	assert.NoError(t, err)
+	assert.Nil(t, empty.Queue)
+	assert.Nil(t, empty.Lobby)
+	assert.Nil(t, empty.PendingAccept)
+
+	// the lobbies of other tenants aren't restored
+	other := newLobby(matchmaking_entities.LobbyStatusInMatch, false)
+	other.ResourceOwner = common.ResourceOwner{TenantID: uuid.New()}
+	session, err = matchmaking_use_cases.NewGetMatchmakingSessionUseCase(newMockTicketStore(), newMockLobbyStore(other), matchmaking_use_cases.NewGetQueueStatusUseCase(tickets)).Exec(userContext(memberID))
+	assert.NoError(t, err)
+	assert.Nil(t, session.Lobby)
+
+	var forbiddenErr *matchmaking.LobbyForbiddenError
+	_, err = usecase.Exec(systemContext())
+	assert.ErrorAs(t, err, &forbiddenErr)
+
+	// the placeholder user of a request without a RID
+	_, err = usecase.Exec(common.WithResourceOwner(context.Background(), common.ResourceOwner{TenantID: tenantID, UserID: memberID}))
+	assert.ErrorAs(t, err, &forbiddenErr)
+}
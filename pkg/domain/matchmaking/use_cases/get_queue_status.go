@@ -0,0 +1,89 @@
+package matchmaking_use_cases
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	matchmaking_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/matchmaking/entities"
+	matchmaking_in "github.com/psavelis/team-pro/replay-api/pkg/domain/matchmaking/ports/in"
+	matchmaking_out "github.com/psavelis/team-pro/replay-api/pkg/domain/matchmaking/ports/out"
+)
+
+const (
+	// recent matches the wait estimate is based on
+	QueueStatsWindow = 15 * time.Minute
+	// max tickets read to compute a position or the pool stats (deeper positions are capped at QueueStatusTicketWindow + 1)
+	QueueStatusTicketWindow = 200
+)
+
+type GetQueueStatusUseCase struct {
+	TicketReader matchmaking_out.QueueTicketReader
+}
+
+func NewGetQueueStatusUseCase(ticketReader matchmaking_out.QueueTicketReader) matchmaking_in.GetQueueStatusQuery {
+	return &GetQueueStatusUseCase{
+		TicketReader: ticketReader,
+	}
+}
+
+func (usecase *GetQueueStatusUseCase) Exec(ctx context.Context, ticketID uuid.UUID) (*matchmaking_entities.QueueStatus, error) {
+	ticket, err := getUserTicket(ctx, usecase.TicketReader, ticketID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+
+	status := &matchmaking_entities.QueueStatus{
+		TicketID:       ticket.ID,
+		PoolID:         ticket.PoolID,
+		Status:         ticket.Status,
+		LobbyID:        ticket.LobbyID,
+		BackfillSlotID: ticket.BackfillSlotID,
+		EstimatedAt:    now,
+	}
+
+	if ticket.Status != matchmaking_entities.QueueTicketStatusWaiting {
+		if ticket.MatchedAt != nil {
+			status.WaitedSeconds = int(ticket.MatchedAt.Sub(ticket.EnqueuedAt).Seconds())
+		}
+
+		return status, nil
+	}
+
+	waited := now.Sub(ticket.EnqueuedAt)
+	status.WaitedSeconds = int(waited.Seconds())
+
+	// tickets are read at client level: the ones ahead belong to other users
+	ahead, err := usecase.TicketReader.Search(ctx, common.NewSearchByValues(ctx, []common.SearchableValue{
+		{Field: "PoolID", Values: []interface{}{ticket.PoolID}},
+		{Field: "Status", Values: []interface{}{matchmaking_entities.QueueTicketStatusWaiting}},
+		{Field: "EnqueuedAt", Operator: common.LessThanOperator, Values: []interface{}{ticket.EnqueuedAt}},
+	}, common.NewSearchResultOptions(0, QueueStatusTicketWindow), common.ClientApplicationAudienceIDKey))
+
+	if err != nil {
+		slog.ErrorContext(ctx, "unable to search tickets ahead in queue", "ticketID", ticketID, "err", err)
+		return nil, err
+	}
+
+	status.Position = len(ahead) + 1
+
+	matched, err := usecase.TicketReader.Search(ctx, common.NewSearchByValues(ctx, []common.SearchableValue{
+		{Field: "PoolID", Values: []interface{}{ticket.PoolID}},
+		{Field: "Status", Values: []interface{}{matchmaking_entities.QueueTicketStatusMatched}},
+		{Field: "MatchedAt", Operator: common.GreaterThanOperator, Values: []interface{}{now.Add(-QueueStatsWindow)}},
+	}, common.NewSearchResultOptions(0, QueueStatusTicketWindow), common.ClientApplicationAudienceIDKey))
+
+	if err != nil {
+		slog.ErrorContext(ctx, "unable to search recently matched tickets", "poolID", ticket.PoolID, "err", err)
+		return nil, err
+	}
+
+	status.Stats = matchmaking_entities.NewPoolQueueStats(QueueStatsWindow, matched)
+	status.EstimatedWaitSeconds = status.Stats.EstimateWait(status.Position, waited)
+
+	return status, nil
+}
@@ -0,0 +1,54 @@
+package matchmaking_use_cases
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/google/uuid"
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	"github.com/psavelis/team-pro/replay-api/pkg/domain/matchmaking"
+	matchmaking_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/matchmaking/entities"
+	matchmaking_in "github.com/psavelis/team-pro/replay-api/pkg/domain/matchmaking/ports/in"
+	matchmaking_out "github.com/psavelis/team-pro/replay-api/pkg/domain/matchmaking/ports/out"
+)
+
+type JoinLobbyVoiceUseCase struct {
+	LobbyReader   matchmaking_out.LobbyReader
+	VoiceProvider matchmaking_out.VoiceChannelProvider
+}
+
+func NewJoinLobbyVoiceUseCase(lobbyReader matchmaking_out.LobbyReader, voiceProvider matchmaking_out.VoiceChannelProvider) matchmaking_in.JoinLobbyVoiceCommand {
+	return &JoinLobbyVoiceUseCase{
+		LobbyReader:   lobbyReader,
+		VoiceProvider: voiceProvider,
+	}
+}
+
+func (usecase *JoinLobbyVoiceUseCase) Exec(ctx context.Context, lobbyID uuid.UUID) (*matchmaking_entities.VoiceInvite, error) {
+	lobby, err := getTenantLobby(ctx, usecase.LobbyReader, lobbyID)
+	if err != nil {
+		return nil, err
+	}
+
+	userID := common.GetResourceOwner(ctx).UserID
+
+	if !lobby.HasUser(userID) {
+		return nil, matchmaking.NewLobbyForbiddenError("only lobby players can join the lobby voice channel")
+	}
+
+	if lobby.Voice == nil || lobby.IsFinished() {
+		return nil, matchmaking.NewLobbyStateError("lobby voice channel is not available")
+	}
+
+	if lobby.Voice.IsKicked(userID) {
+		return nil, matchmaking.NewLobbyForbiddenError("player was removed from the lobby voice channel")
+	}
+
+	invite, err := usecase.VoiceProvider.Invite(ctx, lobby.Voice.ChannelID, userID, lobby.Voice.IsMuted(userID))
+	if err != nil {
+		slog.ErrorContext(ctx, "unable to create lobby voice invite", "lobbyID", lobbyID, "err", err)
+		return nil, err
+	}
+
+	return invite, nil
+}
@@ -0,0 +1,48 @@
+package matchmaking_use_cases
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/psavelis/team-pro/replay-api/pkg/domain/matchmaking"
+	matchmaking_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/matchmaking/entities"
+	matchmaking_in "github.com/psavelis/team-pro/replay-api/pkg/domain/matchmaking/ports/in"
+	matchmaking_out "github.com/psavelis/team-pro/replay-api/pkg/domain/matchmaking/ports/out"
+)
+
+type LeaveQueueUseCase struct {
+	TicketReader matchmaking_out.QueueTicketReader
+	TicketWriter matchmaking_out.QueueTicketWriter
+}
+
+func NewLeaveQueueUseCase(ticketReader matchmaking_out.QueueTicketReader, ticketWriter matchmaking_out.QueueTicketWriter) matchmaking_in.LeaveQueueCommand {
+	return &LeaveQueueUseCase{
+		TicketReader: ticketReader,
+		TicketWriter: ticketWriter,
+	}
+}
+
+func (usecase *LeaveQueueUseCase) Exec(ctx context.Context, ticketID uuid.UUID) (*matchmaking_entities.QueueTicket, error) {
+	ticket, err := getUserTicket(ctx, usecase.TicketReader, ticketID)
+	if err != nil {
+		return nil, err
+	}
+
+	if ticket.Status != matchmaking_entities.QueueTicketStatusWaiting {
+		return nil, matchmaking.NewQueueStateError(fmt.Sprintf("queue ticket is no longer waiting (status '%s')", ticket.Status))
+	}
+
+	ticket.Status = matchmaking_entities.QueueTicketStatusCancelled
+	ticket.UpdatedAt = time.Now().UTC()
+
+	ticket, err = usecase.TicketWriter.Update(ctx, ticket)
+	if err != nil {
+		slog.ErrorContext(ctx, "unable to cancel queue ticket", "ticketID", ticketID, "err", err)
+		return nil, err
+	}
+
+	return ticket, nil
+}
@@ -0,0 +1,105 @@
+package matchmaking_use_cases
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	"github.com/psavelis/team-pro/replay-api/pkg/domain/matchmaking"
+	matchmaking_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/matchmaking/entities"
+	matchmaking_in "github.com/psavelis/team-pro/replay-api/pkg/domain/matchmaking/ports/in"
+	matchmaking_out "github.com/psavelis/team-pro/replay-api/pkg/domain/matchmaking/ports/out"
+	replay_entity "github.com/psavelis/team-pro/replay-api/pkg/domain/replay/entities"
+)
+
+type LinkLobbyMatchUseCase struct {
+	LobbyReader      matchmaking_out.LobbyReader
+	LobbyWriter      matchmaking_out.LobbyWriter
+	LobbyMatchWriter matchmaking_out.LobbyMatchWriter
+}
+
+func NewLinkLobbyMatchUseCase(lobbyReader matchmaking_out.LobbyReader, lobbyWriter matchmaking_out.LobbyWriter, lobbyMatchWriter matchmaking_out.LobbyMatchWriter) matchmaking_in.LinkLobbyMatchCommand {
+	return &LinkLobbyMatchUseCase{
+		LobbyReader:      lobbyReader,
+		LobbyWriter:      lobbyWriter,
+		LobbyMatchWriter: lobbyMatchWriter,
+	}
+}
+
+func (usecase *LinkLobbyMatchUseCase) Exec(ctx context.Context, lobbyID uuid.UUID, matchID uuid.UUID) (*matchmaking_entities.Lobby, error) {
+	lobby, err := getTenantLobby(ctx, usecase.LobbyReader, lobbyID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !lobby.IsLeader(common.GetResourceOwner(ctx).UserID) {
+		return nil, matchmaking.NewLobbyForbiddenError("only the lobby leader can link the lobby match")
+	}
+
+	if lobby.Status != matchmaking_entities.LobbyStatusReady {
+		return nil, matchmaking.NewLobbyStateError(fmt.Sprintf("match can't be linked to a lobby in status '%s'", lobby.Status))
+	}
+
+	found, err := usecase.LobbyMatchWriter.AttachLobby(ctx, matchID, lobbyID, newMatchDraft(lobby.Draft), newMatchQueueSessions(lobby.Players))
+	if err != nil {
+		slog.ErrorContext(ctx, "unable to attach lobby to match", "lobbyID", lobbyID, "matchID", matchID, "err", err)
+		return nil, err
+	}
+
+	if !found {
+		return nil, matchmaking.NewMatchNotFoundError(matchID)
+	}
+
+	lobby.MatchID = &matchID
+	lobby.Status = matchmaking_entities.LobbyStatusInMatch
+	lobby.UpdatedAt = time.Now().UTC()
+
+	lobby, err = usecase.LobbyWriter.Update(ctx, lobby)
+	if err != nil {
+		slog.ErrorContext(ctx, "unable to save lobby match", "lobbyID", lobbyID, "matchID", matchID, "err", err)
+		return nil, err
+	}
+
+	return lobby, nil
+}
+
+func newMatchDraft(draft *matchmaking_entities.LobbyDraft) *replay_entity.MatchDraft {
+	if draft == nil || !draft.IsCompleted() {
+		return nil
+	}
+
+	picks := make([]replay_entity.MatchDraftPick, 0, len(draft.Picks))
+	for _, p := range draft.Picks {
+		picks = append(picks, replay_entity.MatchDraftPick{
+			Order:         p.Order,
+			CaptainUserID: p.CaptainUserID,
+			UserID:        p.UserID,
+			Team:          int(p.Team),
+			AutoPicked:    p.AutoPicked,
+			PickedAt:      p.PickedAt,
+		})
+	}
+
+	return &replay_entity.MatchDraft{
+		Captains:    draft.Captains,
+		Picks:       picks,
+		CompletedAt: *draft.CompletedAt,
+	}
+}
+
+// newMatchQueueSessions returns the queue session of the lobby players matched from the queue.
+func newMatchQueueSessions(players []matchmaking_entities.LobbyPlayer) []replay_entity.MatchQueueSession {
+	sessions := make([]replay_entity.MatchQueueSession, 0, len(players))
+	for _, p := range players {
+		if p.QueueTicketID == uuid.Nil {
+			continue
+		}
+
+		sessions = append(sessions, replay_entity.MatchQueueSession{PlayerID: p.PlayerID, QueueSessionID: p.QueueTicketID})
+	}
+
+	return sessions
+}
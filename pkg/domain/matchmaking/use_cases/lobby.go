@@ -0,0 +1,28 @@
+package matchmaking_use_cases
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/google/uuid"
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	"github.com/psavelis/team-pro/replay-api/pkg/domain/matchmaking"
+	matchmaking_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/matchmaking/entities"
+	matchmaking_out "github.com/psavelis/team-pro/replay-api/pkg/domain/matchmaking/ports/out"
+)
+
+// getTenantLobby loads the lobby, hiding lobbies of other tenants as not found.
+func getTenantLobby(ctx context.Context, reader matchmaking_out.LobbyReader, lobbyID uuid.UUID) (*matchmaking_entities.Lobby, error) {
+	lobby, err := reader.GetByID(ctx, lobbyID)
+	if err != nil || lobby == nil {
+		slog.ErrorContext(ctx, "unable to get lobby", "lobbyID", lobbyID, "err", err)
+		return nil, matchmaking.NewLobbyNotFoundError(lobbyID)
+	}
+
+	if lobby.ResourceOwner.TenantID != common.GetResourceOwner(ctx).TenantID {
+		slog.WarnContext(ctx, "lobby requested from another tenant", "lobbyID", lobbyID)
+		return nil, matchmaking.NewLobbyNotFoundError(lobbyID)
+	}
+
+	return lobby, nil
+}
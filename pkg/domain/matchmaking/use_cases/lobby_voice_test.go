@@ -0,0 +1,352 @@
+package matchmaking_use_cases_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	"github.com/psavelis/team-pro/replay-api/pkg/domain/matchmaking"
+	matchmaking_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/matchmaking/entities"
+	matchmaking_use_cases "github.com/psavelis/team-pro/replay-api/pkg/domain/matchmaking/use_cases"
+	"github.com/stretchr/testify/assert"
+)
+
+type mockLobbyStore struct {
+	lobbies   map[uuid.UUID]matchmaking_entities.Lobby
+	updateErr error
+	updates   int
+}
+
+func newMockLobbyStore(lobbies ...matchmaking_entities.Lobby) *mockLobbyStore {
+	m := &mockLobbyStore{lobbies: make(map[uuid.UUID]matchmaking_entities.Lobby)}
+	for _, l := range lobbies {
+		m.lobbies[l.ID] = l
+	}
+
+	return m
+}
+
+// Search evaluates the value params used by the sync, draft and ready check expiration, backfill, queue recovery, session and janitor use
+// cases.
+func (m *mockLobbyStore) Search(ctx context.Context, s common.Search) ([]matchmaking_entities.Lobby, error) {
+	res := make([]matchmaking_entities.Lobby, 0)
+
+	for _, l := range m.lobbies {
+		if matchesLobby(l, s.SearchParams[0].Params[0].ValueParams) {
+			res = append(res, l)
+		}
+	}
+
+	return res, nil
+}
+
+func matchesLobby(l matchmaking_entities.Lobby, values []common.SearchableValue) bool {
+	for _, v := range values {
+		switch v.Field {
+		case "Status":
+			found := false
+			for _, status := range v.Values {
+				found = found || status == l.Status
+			}
+
+			if !found {
+				return false
+			}
+		case "Voice":
+			if (v.Operator == common.EqualsOperator) != (l.Voice == nil) {
+				return false
+			}
+		case "Players.UserID":
+			found := false
+			for _, p := range l.Players {
+				found = found || p.UserID == v.Values[0]
+			}
+
+			if !found {
+				return false
+			}
+		case "PoolID":
+			if l.PoolID == nil || *l.PoolID != v.Values[0] {
+				return false
+			}
+		case "Backfills.Status":
+			if len(l.OpenBackfills()) == 0 {
+				return false
+			}
+		case "UpdatedAt":
+			if !l.UpdatedAt.After(v.Values[0].(time.Time)) {
+				return false
+			}
+		case "CreatedAt":
+			if !l.CreatedAt.Before(v.Values[0].(time.Time)) {
+				return false
+			}
+		case "ReadyCheck.Deadline":
+			if l.ReadyCheck == nil || !l.ReadyCheck.Deadline.Before(v.Values[0].(time.Time)) {
+				return false
+			}
+		case "Draft.TurnDeadline":
+			if l.Draft == nil || l.Draft.TurnDeadline == nil || !l.Draft.TurnDeadline.Before(v.Values[0].(time.Time)) {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+func (m *mockLobbyStore) Compile(ctx context.Context, searchParams []common.SearchAggregation, resultOptions common.SearchResultOptions) (*common.Search, error) {
+	return nil, nil
+}
+
+func (m *mockLobbyStore) GetByID(ctx context.Context, lobbyID uuid.UUID) (*matchmaking_entities.Lobby, error) {
+	l, ok := m.lobbies[lobbyID]
+	if !ok {
+		return nil, errors.New("not found")
+	}
+
+	return &l, nil
+}
+
+func (m *mockLobbyStore) Create(ctx context.Context, lobby *matchmaking_entities.Lobby) (*matchmaking_entities.Lobby, error) {
+	m.lobbies[lobby.ID] = *lobby
+	return lobby, nil
+}
+
+func (m *mockLobbyStore) Update(ctx context.Context, lobby *matchmaking_entities.Lobby) (*matchmaking_entities.Lobby, error) {
+	if m.updateErr != nil {
+		return nil, m.updateErr
+	}
+
+	m.updates++
+	m.lobbies[lobby.ID] = *lobby
+
+	return lobby, nil
+}
+
+type mockVoiceProvider struct {
+	created []string
+	deleted []string
+	kicked  []uuid.UUID
+	muted   map[uuid.UUID]bool
+}
+
+func newMockVoiceProvider() *mockVoiceProvider {
+	return &mockVoiceProvider{muted: make(map[uuid.UUID]bool)}
+}
+
+func (p *mockVoiceProvider) Name() string {
+	return "mock"
+}
+
+func (p *mockVoiceProvider) CreateChannel(ctx context.Context, lobby matchmaking_entities.Lobby) (string, error) {
+	channelID := "room-" + lobby.ID.String()
+	p.created = append(p.created, channelID)
+
+	return channelID, nil
+}
+
+func (p *mockVoiceProvider) Invite(ctx context.Context, channelID string, userID uuid.UUID, muted bool) (*matchmaking_entities.VoiceInvite, error) {
+	p.muted[userID] = muted
+	return &matchmaking_entities.VoiceInvite{Provider: "mock", ChannelID: channelID, Token: userID.String()}, nil
+}
+
+func (p *mockVoiceProvider) SetMuted(ctx context.Context, channelID string, userID uuid.UUID, muted bool) error {
+	p.muted[userID] = muted
+	return nil
+}
+
+func (p *mockVoiceProvider) Kick(ctx context.Context, channelID string, userID uuid.UUID) error {
+	p.kicked = append(p.kicked, userID)
+	return nil
+}
+
+func (p *mockVoiceProvider) DeleteChannel(ctx context.Context, channelID string) error {
+	p.deleted = append(p.deleted, channelID)
+	return nil
+}
+
+var (
+	tenantID = uuid.New()
+	leaderID = uuid.New()
+	memberID = uuid.New()
+)
+
+func newLobby(status matchmaking_entities.LobbyStatus, withVoice bool) matchmaking_entities.Lobby {
+	l := matchmaking_entities.Lobby{
+		ID:           uuid.New(),
+		GameID:       common.CS2_GAME_ID,
+		LeaderUserID: leaderID,
+		Players: []matchmaking_entities.LobbyPlayer{
+			{PlayerID: uuid.New(), UserID: leaderID, JoinedAt: time.Now()},
+			{PlayerID: uuid.New(), UserID: memberID, JoinedAt: time.Now()},
+		},
+		Status:        status,
+		ResourceOwner: common.ResourceOwner{TenantID: tenantID},
+	}
+
+	if withVoice {
+		l.Voice = &matchmaking_entities.LobbyVoiceChannel{Provider: "mock", ChannelID: "room-" + l.ID.String()}
+	}
+
+	return l
+}
+
+func userContext(userID uuid.UUID) context.Context {
+	return common.WithAuthenticated(common.WithResourceOwner(context.Background(), common.ResourceOwner{TenantID: tenantID, UserID: userID}))
+}
+
+// systemContext mirrors the scheduler (client level) context
+func systemContext() context.Context {
+	return common.WithResourceOwner(context.Background(), common.ResourceOwner{TenantID: tenantID, ClientID: common.ServerClientID})
+}
+
+func TestSyncLobbyVoiceChannelsUseCase_Exec(t *testing.T) {
+	ready := newLobby(matchmaking_entities.LobbyStatusReady, false)
+	forming := newLobby(matchmaking_entities.LobbyStatusForming, false)
+	completed := newLobby(matchmaking_entities.LobbyStatusCompleted, true)
+	cancelledWithoutVoice := newLobby(matchmaking_entities.LobbyStatusCancelled, false)
+
+	store := newMockLobbyStore(ready, forming, completed, cancelledWithoutVoice)
+	provider := newMockVoiceProvider()
+
+	usecase := matchmaking_use_cases.NewSyncLobbyVoiceChannelsUseCase(store, store, provider)
+
+	provisioned, tornDown, err := usecase.Exec(systemContext())
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, provisioned)
+	assert.Equal(t, 1, tornDown)
+
+	assert.NotNil(t, store.lobbies[ready.ID].Voice)
+	assert.Equal(t, "room-"+ready.ID.String(), store.lobbies[ready.ID].Voice.ChannelID)
+	assert.Nil(t, store.lobbies[forming.ID].Voice)
+	assert.Nil(t, store.lobbies[completed.ID].Voice)
+	assert.Equal(t, []string{"room-" + completed.ID.String()}, provider.deleted)
+
+	// already in sync
+	provisioned, tornDown, err = usecase.Exec(systemContext())
+
+	assert.NoError(t, err)
+	assert.Equal(t, 0, provisioned)
+	assert.Equal(t, 0, tornDown)
+}
+
+func TestSyncLobbyVoiceChannelsUseCase_Exec_DeletesChannelWhenLobbyUpdateFails(t *testing.T) {
+	ready := newLobby(matchmaking_entities.LobbyStatusReady, false)
+
+	store := newMockLobbyStore(ready)
+	store.updateErr = errors.New("update failed")
+	provider := newMockVoiceProvider()
+
+	usecase := matchmaking_use_cases.NewSyncLobbyVoiceChannelsUseCase(store, store, provider)
+
+	provisioned, _, err := usecase.Exec(systemContext())
+
+	assert.Error(t, err)
+	assert.Equal(t, 0, provisioned)
+	assert.Equal(t, provider.created, provider.deleted)
+	assert.Nil(t, store.lobbies[ready.ID].Voice)
+}
+
+func TestJoinLobbyVoiceUseCase_Exec(t *testing.T) {
+	active := newLobby(matchmaking_entities.LobbyStatusReady, true)
+	active.Voice.MutedUserIDs = []uuid.UUID{memberID}
+
+	kicked := newLobby(matchmaking_entities.LobbyStatusReady, true)
+	kicked.Voice.KickedUserIDs = []uuid.UUID{memberID}
+
+	withoutVoice := newLobby(matchmaking_entities.LobbyStatusForming, false)
+	finished := newLobby(matchmaking_entities.LobbyStatusCompleted, true)
+
+	otherTenant := newLobby(matchmaking_entities.LobbyStatusReady, true)
+	otherTenant.ResourceOwner.TenantID = uuid.New()
+
+	tests := []struct {
+		name          string
+		lobby         matchmaking_entities.Lobby
+		userID        uuid.UUID
+		expectedErr   interface{}
+		expectedMuted bool
+	}{
+		{name: "Leader Joins", lobby: active, userID: leaderID},
+		{name: "Muted Member Joins Muted", lobby: active, userID: memberID, expectedMuted: true},
+		{name: "Non Member Is Forbidden", lobby: active, userID: uuid.New(), expectedErr: &matchmaking.LobbyForbiddenError{}},
+		{name: "Kicked Member Is Forbidden", lobby: kicked, userID: memberID, expectedErr: &matchmaking.LobbyForbiddenError{}},
+		{name: "Lobby Without Voice", lobby: withoutVoice, userID: memberID, expectedErr: &matchmaking.LobbyStateError{}},
+		{name: "Finished Lobby", lobby: finished, userID: memberID, expectedErr: &matchmaking.LobbyStateError{}},
+		{name: "Lobby Of Another Tenant", lobby: otherTenant, userID: memberID, expectedErr: &matchmaking.LobbyNotFoundError{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := newMockLobbyStore(tt.lobby)
+			provider := newMockVoiceProvider()
+
+			usecase := matchmaking_use_cases.NewJoinLobbyVoiceUseCase(store, provider)
+
+			invite, err := usecase.Exec(userContext(tt.userID), tt.lobby.ID)
+
+			if tt.expectedErr != nil {
+				assert.Error(t, err)
+				assert.IsType(t, tt.expectedErr, err)
+				assert.Nil(t, invite)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.lobby.Voice.ChannelID, invite.ChannelID)
+			assert.Equal(t, tt.expectedMuted, provider.muted[tt.userID])
+		})
+	}
+}
+
+func TestModerateLobbyVoiceUseCase_Exec(t *testing.T) {
+	tests := []struct {
+		name         string
+		status       matchmaking_entities.LobbyStatus
+		callerID     uuid.UUID
+		targetID     uuid.UUID
+		action       matchmaking_entities.VoiceModerationAction
+		expectedErr  interface{}
+		expectMuted  bool
+		expectKicked bool
+	}{
+		{name: "Leader Mutes Member", status: matchmaking_entities.LobbyStatusReady, callerID: leaderID, targetID: memberID, action: matchmaking_entities.VoiceModerationMute, expectMuted: true},
+		{name: "Leader Kicks Member", status: matchmaking_entities.LobbyStatusInMatch, callerID: leaderID, targetID: memberID, action: matchmaking_entities.VoiceModerationKick, expectKicked: true},
+		{name: "Member Can't Moderate", status: matchmaking_entities.LobbyStatusReady, callerID: memberID, targetID: leaderID, action: matchmaking_entities.VoiceModerationMute, expectedErr: &matchmaking.LobbyForbiddenError{}},
+		{name: "Leader Can't Moderate Self", status: matchmaking_entities.LobbyStatusReady, callerID: leaderID, targetID: leaderID, action: matchmaking_entities.VoiceModerationKick, expectedErr: &matchmaking.LobbyForbiddenError{}},
+		{name: "Non Member Target", status: matchmaking_entities.LobbyStatusReady, callerID: leaderID, targetID: uuid.New(), action: matchmaking_entities.VoiceModerationMute, expectedErr: &matchmaking.LobbyForbiddenError{}},
+		{name: "Finished Lobby", status: matchmaking_entities.LobbyStatusCompleted, callerID: leaderID, targetID: memberID, action: matchmaking_entities.VoiceModerationMute, expectedErr: &matchmaking.LobbyStateError{}},
+		{name: "Invalid Action", status: matchmaking_entities.LobbyStatusReady, callerID: leaderID, targetID: memberID, action: "ban", expectedErr: &matchmaking.LobbyStateError{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lobby := newLobby(tt.status, true)
+
+			store := newMockLobbyStore(lobby)
+			provider := newMockVoiceProvider()
+
+			usecase := matchmaking_use_cases.NewModerateLobbyVoiceUseCase(store, store, provider)
+
+			updated, err := usecase.Exec(userContext(tt.callerID), lobby.ID, tt.targetID, tt.action)
+
+			if tt.expectedErr != nil {
+				assert.Error(t, err)
+				assert.IsType(t, tt.expectedErr, err)
+				assert.Equal(t, 0, store.updates)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectMuted, updated.Voice.IsMuted(tt.targetID))
+			assert.Equal(t, tt.expectKicked, updated.Voice.IsKicked(tt.targetID))
+			assert.Equal(t, tt.expectMuted, provider.muted[tt.targetID])
+			assert.Equal(t, tt.expectKicked, len(provider.kicked) == 1)
+		})
+	}
+}
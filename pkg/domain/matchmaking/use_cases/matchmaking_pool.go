@@ -0,0 +1,116 @@
+package matchmaking_use_cases
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/google/uuid"
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	"github.com/psavelis/team-pro/replay-api/pkg/domain/matchmaking"
+	matchmaking_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/matchmaking/entities"
+	matchmaking_out "github.com/psavelis/team-pro/replay-api/pkg/domain/matchmaking/ports/out"
+	matchmaking_strategies "github.com/psavelis/team-pro/replay-api/pkg/domain/matchmaking/strategies"
+)
+
+const MaxPoolTeamSize = 8
+
+// getTenantPool loads the pool, hiding pools of other tenants as not found.
+func getTenantPool(ctx context.Context, reader matchmaking_out.MatchmakingPoolReader, poolID uuid.UUID) (*matchmaking_entities.MatchmakingPool, error) {
+	pool, err := reader.GetByID(ctx, poolID)
+	if err != nil || pool == nil {
+		slog.ErrorContext(ctx, "unable to get matchmaking pool", "poolID", poolID, "err", err)
+		return nil, matchmaking.NewPoolNotFoundError(poolID)
+	}
+
+	if pool.ResourceOwner.TenantID != common.GetResourceOwner(ctx).TenantID {
+		slog.WarnContext(ctx, "matchmaking pool requested from another tenant", "poolID", poolID)
+		return nil, matchmaking.NewPoolNotFoundError(poolID)
+	}
+
+	return pool, nil
+}
+
+// getUserTicket loads a ticket of the user in context, hiding tickets of other users as not found.
+func getUserTicket(ctx context.Context, reader matchmaking_out.QueueTicketReader, ticketID uuid.UUID) (*matchmaking_entities.QueueTicket, error) {
+	ticket, err := reader.GetByID(ctx, ticketID)
+	if err != nil || ticket == nil {
+		slog.ErrorContext(ctx, "unable to get queue ticket", "ticketID", ticketID, "err", err)
+		return nil, matchmaking.NewQueueStateError(fmt.Sprintf("queue ticket %s not found", ticketID))
+	}
+
+	resourceOwner := common.GetResourceOwner(ctx)
+	if ticket.ResourceOwner.TenantID != resourceOwner.TenantID || ticket.UserID != resourceOwner.UserID {
+		return nil, matchmaking.NewQueueStateError(fmt.Sprintf("queue ticket %s not found", ticketID))
+	}
+
+	return ticket, nil
+}
+
+func validatePool(pool matchmaking_entities.MatchmakingPool) error {
+	if pool.Name == "" || pool.GameID == "" {
+		return matchmaking.NewInvalidPoolError("pool name and game_id are required")
+	}
+
+	if pool.TeamSize < 1 || pool.TeamSize > MaxPoolTeamSize {
+		return matchmaking.NewInvalidPoolError(fmt.Sprintf("team_size must be between 1 and %d", MaxPoolTeamSize))
+	}
+
+	if pool.MaxRatingSpread < 0 {
+		return matchmaking.NewInvalidPoolError("max_rating_spread can't be negative")
+	}
+
+	if len(pool.RoleSlots) > 0 && len(pool.RoleSlots) != pool.TeamSize {
+		return matchmaking.NewInvalidPoolError(fmt.Sprintf("role_slots must have one role per team member (%d)", pool.TeamSize))
+	}
+
+	if pool.Mode != matchmaking_entities.LobbyModeAutoBalance && pool.Mode != matchmaking_entities.LobbyModeCaptainDraft {
+		return matchmaking.NewInvalidPoolError(fmt.Sprintf("invalid pool mode '%s'", pool.Mode))
+	}
+
+	if pool.Mode == matchmaking_entities.LobbyModeCaptainDraft && pool.MatchSize() < matchmaking_entities.MinDraftPlayers {
+		return matchmaking.NewInvalidPoolError(fmt.Sprintf("captain draft pools need at least %d players per match", matchmaking_entities.MinDraftPlayers))
+	}
+
+	if pool.Schedule != nil {
+		if err := pool.Schedule.Validate(); err != nil {
+			return matchmaking.NewInvalidPoolError(err.Error())
+		}
+	}
+
+	if pool.SearchWindow != nil {
+		if err := pool.SearchWindow.Validate(pool.MaxRatingSpread); err != nil {
+			return matchmaking.NewInvalidPoolError(err.Error())
+		}
+	}
+
+	if timeout := pool.ReadyCheckTimeout(); timeout != 0 && (timeout < matchmaking_entities.MinReadyCheckTimeout || timeout > matchmaking_entities.MaxReadyCheckTimeout) {
+		return matchmaking.NewInvalidPoolError(fmt.Sprintf("ready_check_timeout_seconds must be 0 (no ready check) or between %d and %d", int(matchmaking_entities.MinReadyCheckTimeout.Seconds()), int(matchmaking_entities.MaxReadyCheckTimeout.Seconds())))
+	}
+
+	if timeout := pool.LobbyTimeout(); timeout != 0 && timeout < matchmaking_entities.MinLobbyTimeout {
+		return matchmaking.NewInvalidPoolError(fmt.Sprintf("lobby_timeout_seconds must be 0 (lobbies never expire) or at least %d", int(matchmaking_entities.MinLobbyTimeout.Seconds())))
+	}
+
+	if timeout := pool.QueueTimeout(); timeout != 0 && timeout < matchmaking_entities.MinQueueTimeout {
+		return matchmaking.NewInvalidPoolError(fmt.Sprintf("queue_timeout_seconds must be 0 (tickets never expire) or at least %d", int(matchmaking_entities.MinQueueTimeout.Seconds())))
+	}
+
+	seen := map[string]bool{pool.Strategy: true}
+
+	for _, name := range append([]string{pool.Strategy}, pool.ShadowStrategies...) {
+		if err := matchmaking_strategies.ValidateStrategy(name, pool); err != nil {
+			return matchmaking.NewInvalidPoolError(err.Error())
+		}
+	}
+
+	for _, name := range pool.ShadowStrategies {
+		if seen[name] {
+			return matchmaking.NewInvalidPoolError(fmt.Sprintf("strategy '%s' is evaluated more than once", name))
+		}
+
+		seen[name] = true
+	}
+
+	return nil
+}
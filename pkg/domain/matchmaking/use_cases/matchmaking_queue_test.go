@@ -0,0 +1,634 @@
+package matchmaking_use_cases_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	"github.com/psavelis/team-pro/replay-api/pkg/domain/matchmaking"
+	matchmaking_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/matchmaking/entities"
+	matchmaking_in "github.com/psavelis/team-pro/replay-api/pkg/domain/matchmaking/ports/in"
+	matchmaking_strategies "github.com/psavelis/team-pro/replay-api/pkg/domain/matchmaking/strategies"
+	matchmaking_use_cases "github.com/psavelis/team-pro/replay-api/pkg/domain/matchmaking/use_cases"
+	"github.com/stretchr/testify/assert"
+)
+
+type mockPoolStore struct {
+	pools map[uuid.UUID]matchmaking_entities.MatchmakingPool
+}
+
+func newMockPoolStore(pools ...matchmaking_entities.MatchmakingPool) *mockPoolStore {
+	m := &mockPoolStore{pools: make(map[uuid.UUID]matchmaking_entities.MatchmakingPool)}
+	for _, p := range pools {
+		m.pools[p.ID] = p
+	}
+
+	return m
+}
+
+// Search returns the enabled pools, or every pool when searched without the Enabled value param (ie: by the janitor).
+func (m *mockPoolStore) Search(ctx context.Context, s common.Search) ([]matchmaking_entities.MatchmakingPool, error) {
+	enabledOnly := false
+	for _, v := range s.SearchParams[0].Params[0].ValueParams {
+		enabledOnly = enabledOnly || v.Field == "Enabled"
+	}
+
+	res := make([]matchmaking_entities.MatchmakingPool, 0)
+	for _, p := range m.pools {
+		if p.Enabled || !enabledOnly {
+			res = append(res, p)
+		}
+	}
+
+	return res, nil
+}
+
+func (m *mockPoolStore) Compile(ctx context.Context, searchParams []common.SearchAggregation, resultOptions common.SearchResultOptions) (*common.Search, error) {
+	return nil, nil
+}
+
+func (m *mockPoolStore) GetByID(ctx context.Context, poolID uuid.UUID) (*matchmaking_entities.MatchmakingPool, error) {
+	p, ok := m.pools[poolID]
+	if !ok {
+		return nil, errors.New("not found")
+	}
+
+	return &p, nil
+}
+
+func (m *mockPoolStore) Create(ctx context.Context, pool *matchmaking_entities.MatchmakingPool) (*matchmaking_entities.MatchmakingPool, error) {
+	m.pools[pool.ID] = *pool
+	return pool, nil
+}
+
+func (m *mockPoolStore) Update(ctx context.Context, pool *matchmaking_entities.MatchmakingPool) (*matchmaking_entities.MatchmakingPool, error) {
+	m.pools[pool.ID] = *pool
+	return pool, nil
+}
+
+type mockTicketStore struct {
+	tickets map[uuid.UUID]matchmaking_entities.QueueTicket
+}
+
+func newMockTicketStore(tickets ...matchmaking_entities.QueueTicket) *mockTicketStore {
+	m := &mockTicketStore{tickets: make(map[uuid.UUID]matchmaking_entities.QueueTicket)}
+	for _, t := range tickets {
+		m.tickets[t.ID] = t
+	}
+
+	return m
+}
+
+// Search evaluates the PoolID, UserID, LobbyID, Status, EnqueuedAt (lt), UpdatedAt (lt) and MatchedAt (gt) value params.
+func (m *mockTicketStore) Search(ctx context.Context, s common.Search) ([]matchmaking_entities.QueueTicket, error) {
+	res := make([]matchmaking_entities.QueueTicket, 0)
+
+	for _, t := range m.tickets {
+		match := true
+		for _, v := range s.SearchParams[0].Params[0].ValueParams {
+			switch v.Field {
+			case "PoolID":
+				match = match && v.Values[0] == t.PoolID
+			case "UserID":
+				match = match && v.Values[0] == t.UserID
+			case "LobbyID":
+				match = match && t.LobbyID != nil && v.Values[0] == *t.LobbyID
+			case "Status":
+				match = match && v.Values[0] == t.Status
+			case "EnqueuedAt":
+				match = match && t.EnqueuedAt.Before(v.Values[0].(time.Time))
+			case "UpdatedAt":
+				match = match && t.UpdatedAt.Before(v.Values[0].(time.Time))
+			case "MatchedAt":
+				match = match && t.MatchedAt != nil && t.MatchedAt.After(v.Values[0].(time.Time))
+			}
+		}
+
+		if match {
+			res = append(res, t)
+		}
+	}
+
+	return res, nil
+}
+
+func (m *mockTicketStore) Compile(ctx context.Context, searchParams []common.SearchAggregation, resultOptions common.SearchResultOptions) (*common.Search, error) {
+	return nil, nil
+}
+
+func (m *mockTicketStore) GetByID(ctx context.Context, ticketID uuid.UUID) (*matchmaking_entities.QueueTicket, error) {
+	t, ok := m.tickets[ticketID]
+	if !ok {
+		return nil, errors.New("not found")
+	}
+
+	return &t, nil
+}
+
+func (m *mockTicketStore) Create(ctx context.Context, ticket *matchmaking_entities.QueueTicket) (*matchmaking_entities.QueueTicket, error) {
+	m.tickets[ticket.ID] = *ticket
+	return ticket, nil
+}
+
+func (m *mockTicketStore) Update(ctx context.Context, ticket *matchmaking_entities.QueueTicket) (*matchmaking_entities.QueueTicket, error) {
+	m.tickets[ticket.ID] = *ticket
+	return ticket, nil
+}
+
+type mockEvaluationWriter struct {
+	evaluations []*matchmaking_entities.StrategyEvaluation
+}
+
+func (w *mockEvaluationWriter) CreateMany(ctx context.Context, evaluations []*matchmaking_entities.StrategyEvaluation) error {
+	w.evaluations = append(w.evaluations, evaluations...)
+	return nil
+}
+
+type mockLobbyEventPublisher struct {
+	events    []matchmaking_entities.LobbyCreated
+	cancelled []matchmaking_entities.LobbyCancelled
+	expired   []matchmaking_entities.QueueTicketExpired
+	completed []matchmaking_entities.MatchCompleted
+}
+
+func (p *mockLobbyEventPublisher) PublishLobbyCreated(ctx context.Context, event matchmaking_entities.LobbyCreated) error {
+	p.events = append(p.events, event)
+	return nil
+}
+
+func (p *mockLobbyEventPublisher) PublishLobbyCancelled(ctx context.Context, event matchmaking_entities.LobbyCancelled) error {
+	p.cancelled = append(p.cancelled, event)
+	return nil
+}
+
+func (p *mockLobbyEventPublisher) PublishQueueTicketExpired(ctx context.Context, event matchmaking_entities.QueueTicketExpired) error {
+	p.expired = append(p.expired, event)
+	return nil
+}
+
+func (p *mockLobbyEventPublisher) PublishMatchCompleted(ctx context.Context, event matchmaking_entities.MatchCompleted) error {
+	p.completed = append(p.completed, event)
+	return nil
+}
+
+type fixedRatingReader int
+
+func (r fixedRatingReader) GetRating(ctx context.Context, gameID common.GameIDKey, playerID uuid.UUID) (int, error) {
+	return int(r), nil
+}
+
+func newPool(teamSize int, roleSlots ...string) matchmaking_entities.MatchmakingPool {
+	return matchmaking_entities.MatchmakingPool{
+		ID:              uuid.New(),
+		Name:            "ranked",
+		GameID:          common.CS2_GAME_ID,
+		Mode:            matchmaking_entities.LobbyModeAutoBalance,
+		TeamSize:        teamSize,
+		MaxRatingSpread: 200,
+		RoleSlots:       roleSlots,
+		Enabled:         true,
+		Strategy:        matchmaking_strategies.DefaultStrategyName,
+		ResourceOwner:   common.ResourceOwner{TenantID: tenantID},
+	}
+}
+
+func newTicket(pool matchmaking_entities.MatchmakingPool, rating int, waited time.Duration) matchmaking_entities.QueueTicket {
+	return matchmaking_entities.QueueTicket{
+		ID:            uuid.New(),
+		PoolID:        pool.ID,
+		UserID:        uuid.New(),
+		PlayerID:      uuid.New(),
+		Rating:        rating,
+		Roles:         []string{},
+		Status:        matchmaking_entities.QueueTicketStatusWaiting,
+		EnqueuedAt:    time.Now().Add(-waited),
+		ResourceOwner: common.ResourceOwner{TenantID: tenantID},
+	}
+}
+
+func TestCreateMatchmakingPoolUseCase_Exec(t *testing.T) {
+	tests := []struct {
+		name    string
+		pool    matchmaking_entities.MatchmakingPool
+		wantErr bool
+	}{
+		{"defaults", matchmaking_entities.MatchmakingPool{Name: "ranked", GameID: common.CS2_GAME_ID, TeamSize: 5, MaxRatingSpread: 300}, false},
+		{"missing name", matchmaking_entities.MatchmakingPool{GameID: common.CS2_GAME_ID, TeamSize: 5}, true},
+		{"team too large", matchmaking_entities.MatchmakingPool{Name: "ranked", GameID: common.CS2_GAME_ID, TeamSize: 9}, true},
+		{"unknown strategy", matchmaking_entities.MatchmakingPool{Name: "ranked", GameID: common.CS2_GAME_ID, TeamSize: 5, Strategy: "random"}, true},
+		{"role first without roles", matchmaking_entities.MatchmakingPool{Name: "ranked", GameID: common.CS2_GAME_ID, TeamSize: 2, Strategy: matchmaking_strategies.RoleFirstStrategyName}, true},
+		{"shadow same as primary", matchmaking_entities.MatchmakingPool{Name: "ranked", GameID: common.CS2_GAME_ID, TeamSize: 5, ShadowStrategies: []string{matchmaking_strategies.DefaultStrategyName}}, true},
+		{"ready check too short", matchmaking_entities.MatchmakingPool{Name: "ranked", GameID: common.CS2_GAME_ID, TeamSize: 5, ReadyCheckTimeoutSeconds: 1}, true},
+		{"lobby timeout too short", matchmaking_entities.MatchmakingPool{Name: "ranked", GameID: common.CS2_GAME_ID, TeamSize: 5, LobbyTimeoutSeconds: 30}, true},
+		{"negative queue timeout", matchmaking_entities.MatchmakingPool{Name: "ranked", GameID: common.CS2_GAME_ID, TeamSize: 5, QueueTimeoutSeconds: -1}, true},
+		{"role first with shadow", matchmaking_entities.MatchmakingPool{Name: "ranked", GameID: common.CS2_GAME_ID, TeamSize: 2, RoleSlots: []string{"entry", "awp"}, Strategy: matchmaking_strategies.RoleFirstStrategyName, ShadowStrategies: []string{matchmaking_strategies.OptimalAssignmentStrategyName}}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := newMockPoolStore()
+			usecase := matchmaking_use_cases.NewCreateMatchmakingPoolUseCase(store)
+
+			pool, err := usecase.Exec(systemContext(), tt.pool)
+			if tt.wantErr {
+				var invalid *matchmaking.InvalidPoolError
+				assert.ErrorAs(t, err, &invalid)
+				assert.Empty(t, store.pools)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.NotEqual(t, uuid.Nil, pool.ID)
+			assert.Equal(t, tenantID, pool.ResourceOwner.TenantID)
+			assert.NotEmpty(t, pool.Strategy)
+			assert.NotEmpty(t, pool.Mode)
+		})
+	}
+}
+
+func TestEnqueuePlayerUseCase_Exec(t *testing.T) {
+	pool := newPool(1, "awp")
+	disabled := newPool(1)
+	disabled.Enabled = false
+
+	queued := newTicket(pool, 1000, 0)
+	queued.UserID = memberID
+
+	pools := newMockPoolStore(pool, disabled)
+	tickets := newMockTicketStore(queued)
+	usecase := matchmaking_use_cases.NewEnqueuePlayerUseCase(pools, tickets, tickets, fixedRatingReader(1250), newMockPenaltyStore())
+
+	ticket, err := usecase.Exec(userContext(leaderID), matchmaking_in.EnqueuePlayerCommand{PoolID: pool.ID, PlayerID: uuid.New(), Roles: []string{"awp"}})
+	assert.NoError(t, err)
+	assert.Equal(t, 1250, ticket.Rating)
+	assert.Equal(t, leaderID, ticket.UserID)
+	assert.Equal(t, matchmaking_entities.QueueTicketStatusWaiting, ticket.Status)
+
+	var queueErr *matchmaking.QueueStateError
+
+	_, err = usecase.Exec(userContext(memberID), matchmaking_in.EnqueuePlayerCommand{PoolID: pool.ID, PlayerID: uuid.New()})
+	assert.ErrorAs(t, err, &queueErr, "already queued")
+
+	_, err = usecase.Exec(userContext(uuid.New()), matchmaking_in.EnqueuePlayerCommand{PoolID: pool.ID, PlayerID: uuid.New(), Roles: []string{"igl"}})
+	assert.ErrorAs(t, err, &queueErr, "role outside of the pool")
+
+	_, err = usecase.Exec(userContext(uuid.New()), matchmaking_in.EnqueuePlayerCommand{PoolID: disabled.ID, PlayerID: uuid.New()})
+	assert.ErrorAs(t, err, &queueErr, "disabled pool")
+
+	var notFound *matchmaking.PoolNotFoundError
+	_, err = usecase.Exec(userContext(uuid.New()), matchmaking_in.EnqueuePlayerCommand{PoolID: uuid.New(), PlayerID: uuid.New()})
+	assert.ErrorAs(t, err, &notFound)
+}
+
+func TestLeaveQueueUseCase_Exec(t *testing.T) {
+	pool := newPool(1)
+	waiting := newTicket(pool, 1000, 0)
+	waiting.UserID = memberID
+
+	tickets := newMockTicketStore(waiting)
+	usecase := matchmaking_use_cases.NewLeaveQueueUseCase(tickets, tickets)
+
+	var queueErr *matchmaking.QueueStateError
+
+	_, err := usecase.Exec(userContext(leaderID), waiting.ID)
+	assert.ErrorAs(t, err, &queueErr, "ticket of another user")
+
+	ticket, err := usecase.Exec(userContext(memberID), waiting.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, matchmaking_entities.QueueTicketStatusCancelled, ticket.Status)
+
+	_, err = usecase.Exec(userContext(memberID), waiting.ID)
+	assert.ErrorAs(t, err, &queueErr, "already cancelled")
+}
+
+func TestDrainQueueUseCase_Exec(t *testing.T) {
+	pool := newPool(1)
+	otherPool := newPool(1)
+
+	waiting := []matchmaking_entities.QueueTicket{newTicket(pool, 1000, time.Minute), newTicket(pool, 1200, 0)}
+
+	matched := newTicket(pool, 1000, time.Minute)
+	matched.Status = matchmaking_entities.QueueTicketStatusMatched
+
+	other := newTicket(otherPool, 1000, time.Minute)
+
+	tickets := newMockTicketStore(append(waiting, matched, other)...)
+	usecase := matchmaking_use_cases.NewDrainQueueUseCase(newMockPoolStore(pool, otherPool), tickets, tickets)
+
+	var notFoundErr *matchmaking.PoolNotFoundError
+
+	_, err := usecase.Exec(common.WithResourceOwner(context.Background(), common.ResourceOwner{TenantID: uuid.New()}), pool.ID)
+	assert.ErrorAs(t, err, &notFoundErr, "pool of another tenant")
+
+	n, err := usecase.Exec(systemContext(), pool.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, n)
+
+	for _, ticket := range waiting {
+		assert.Equal(t, matchmaking_entities.QueueTicketStatusCancelled, tickets.tickets[ticket.ID].Status)
+	}
+
+	assert.Equal(t, matchmaking_entities.QueueTicketStatusMatched, tickets.tickets[matched.ID].Status)
+	assert.Equal(t, matchmaking_entities.QueueTicketStatusWaiting, tickets.tickets[other.ID].Status)
+
+	n, err = usecase.Exec(systemContext(), pool.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, n)
+}
+
+func TestRunMatchmakingUseCase_Exec(t *testing.T) {
+	pool := newPool(2)
+	pool.ShadowStrategies = []string{matchmaking_strategies.OptimalAssignmentStrategyName}
+
+	oldest := newTicket(pool, 1000, 3*time.Minute)
+	matched := []matchmaking_entities.QueueTicket{
+		oldest,
+		newTicket(pool, 1100, 2*time.Minute),
+		newTicket(pool, 1050, time.Minute),
+		newTicket(pool, 1150, time.Minute),
+	}
+
+	outlier := newTicket(pool, 2000, 5*time.Minute)
+
+	pools := newMockPoolStore(pool)
+	tickets := newMockTicketStore(append(matched, outlier)...)
+	lobbies := newMockLobbyStore()
+	evaluations := &mockEvaluationWriter{}
+	events := &mockLobbyEventPublisher{}
+
+	usecase := matchmaking_use_cases.NewRunMatchmakingUseCase(pools, tickets, tickets, lobbies, lobbies, evaluations, nil, events)
+
+	n, err := usecase.Exec(systemContext())
+	assert.NoError(t, err)
+	assert.Equal(t, 1, n)
+	assert.Len(t, lobbies.lobbies, 1)
+
+	for _, lobby := range lobbies.lobbies {
+		assert.Equal(t, pool.ID, *lobby.PoolID)
+		assert.Equal(t, matchmaking_entities.LobbyStatusReady, lobby.Status)
+		assert.Equal(t, oldest.UserID, lobby.LeaderUserID)
+		assert.Len(t, lobby.Players, 4)
+
+		teams := map[matchmaking_entities.LobbyTeam]int{}
+		for _, p := range lobby.Players {
+			teams[p.Team]++
+		}
+
+		assert.Equal(t, map[matchmaking_entities.LobbyTeam]int{matchmaking_entities.LobbyTeamA: 2, matchmaking_entities.LobbyTeamB: 2}, teams)
+
+		for _, ticket := range matched {
+			assert.Equal(t, matchmaking_entities.QueueTicketStatusMatched, tickets.tickets[ticket.ID].Status)
+			assert.Equal(t, lobby.ID, *tickets.tickets[ticket.ID].LobbyID)
+		}
+
+		if assert.Len(t, events.events, 1) {
+			assert.Equal(t, lobby.ID, events.events[0].LobbyID)
+			assert.Equal(t, pool.ID, events.events[0].PoolID)
+			assert.Len(t, events.events[0].Players, 4)
+		}
+	}
+
+	assert.Equal(t, matchmaking_entities.QueueTicketStatusWaiting, tickets.tickets[outlier.ID].Status)
+
+	assert.Len(t, evaluations.evaluations, 2)
+	assert.False(t, evaluations.evaluations[0].Shadow)
+	assert.Equal(t, pool.Strategy, evaluations.evaluations[0].Strategy)
+	assert.True(t, evaluations.evaluations[1].Shadow)
+	assert.Equal(t, matchmaking_strategies.OptimalAssignmentStrategyName, evaluations.evaluations[1].Strategy)
+	assert.Equal(t, evaluations.evaluations[0].RunID, evaluations.evaluations[1].RunID)
+	assert.Equal(t, 5, evaluations.evaluations[0].TicketsConsidered)
+
+	// a second run has nothing left to match
+	n, err = usecase.Exec(systemContext())
+	assert.NoError(t, err)
+	assert.Equal(t, 0, n)
+	assert.Len(t, events.events, 1)
+}
+
+func TestGetQueueStatusUseCase_Exec(t *testing.T) {
+	pool := newPool(1)
+
+	ahead := newTicket(pool, 1000, 2*time.Minute)
+	mine := newTicket(pool, 1000, time.Minute)
+	mine.UserID = memberID
+	behind := newTicket(pool, 1000, 0)
+
+	// 6 players matched in the last 15 minutes (one every 150s), after waiting 10 minutes on average
+	recent := make([]matchmaking_entities.QueueTicket, 0)
+	for i := 0; i < 6; i++ {
+		matchedAt := time.Now().Add(-time.Duration(i+1) * time.Minute)
+
+		matched := newTicket(pool, 1000, time.Duration(i+1)*time.Minute+10*time.Minute)
+		matched.Status = matchmaking_entities.QueueTicketStatusMatched
+		matched.MatchedAt = &matchedAt
+
+		recent = append(recent, matched)
+	}
+
+	tickets := newMockTicketStore(append(recent, ahead, mine, behind)...)
+	usecase := matchmaking_use_cases.NewGetQueueStatusUseCase(tickets)
+
+	status, err := usecase.Exec(userContext(memberID), mine.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, status.Position)
+	assert.Equal(t, 6, status.Stats.PlayersMatched)
+	assert.InDelta(t, 600, status.Stats.AvgWaitSeconds, 1)
+	assert.InDelta(t, 60, status.WaitedSeconds, 1)
+	if assert.NotNil(t, status.EstimatedWaitSeconds) {
+		// 9 minutes left of the average wait vs 2 positions at 150s per player
+		assert.InDelta(t, 540, *status.EstimatedWaitSeconds, 1)
+	}
+
+	var queueErr *matchmaking.QueueStateError
+	_, err = usecase.Exec(userContext(leaderID), mine.ID)
+	assert.ErrorAs(t, err, &queueErr, "ticket of another user")
+
+	matchedStatus, err := usecase.Exec(userContext(recent[0].UserID), recent[0].ID)
+	assert.NoError(t, err)
+	assert.Equal(t, matchmaking_entities.QueueTicketStatusMatched, matchedStatus.Status)
+	assert.Equal(t, 0, matchedStatus.Position)
+	assert.Nil(t, matchedStatus.EstimatedWaitSeconds)
+}
+
+func TestAbandonLobbyUseCase_Exec(t *testing.T) {
+	poolID := uuid.New()
+
+	inMatch := newLobby(matchmaking_entities.LobbyStatusInMatch, false)
+	inMatch.PoolID = &poolID
+	forming := newLobby(matchmaking_entities.LobbyStatusForming, false)
+
+	store := newMockLobbyStore(inMatch, forming)
+	usecase := matchmaking_use_cases.NewAbandonLobbyUseCase(store, store)
+
+	var forbiddenErr *matchmaking.LobbyForbiddenError
+	_, err := usecase.Exec(userContext(memberID), inMatch.ID, leaderID)
+	assert.ErrorAs(t, err, &forbiddenErr, "members can only report themselves")
+
+	var stateErr *matchmaking.LobbyStateError
+	_, err = usecase.Exec(userContext(memberID), forming.ID, memberID)
+	assert.ErrorAs(t, err, &stateErr, "lobby not playing")
+
+	lobby, err := usecase.Exec(userContext(leaderID), inMatch.ID, memberID)
+	assert.NoError(t, err)
+	assert.NotNil(t, lobby.GetPlayer(memberID).AbandonedAt)
+	assert.Len(t, lobby.OpenBackfills(), 1)
+
+	_, err = usecase.Exec(userContext(memberID), inMatch.ID, memberID)
+	assert.ErrorAs(t, err, &stateErr, "already abandoned")
+}
+
+func TestRunMatchmakingUseCase_Exec_Backfill(t *testing.T) {
+	pool := newPool(2, "entry", "awp")
+
+	lobby := newLobby(matchmaking_entities.LobbyStatusInMatch, false)
+	lobby.PoolID = &pool.ID
+	lobby.GetPlayer(memberID).Rating = 1500
+	lobby.GetPlayer(memberID).Role = "awp"
+	slot := lobby.Abandon(memberID, time.Now())
+
+	outOfRange := newTicket(pool, 1800, 5*time.Minute)
+	outOfRange.AcceptBackfill = true
+
+	notOptedIn := newTicket(pool, 1500, 4*time.Minute)
+
+	wrongRole := newTicket(pool, 1500, 3*time.Minute)
+	wrongRole.AcceptBackfill = true
+	wrongRole.Roles = []string{"entry"}
+
+	farther := newTicket(pool, 1400, 2*time.Minute)
+	farther.AcceptBackfill = true
+
+	closest := newTicket(pool, 1550, time.Minute)
+	closest.AcceptBackfill = true
+
+	pools := newMockPoolStore(pool)
+	tickets := newMockTicketStore(outOfRange, notOptedIn, wrongRole, farther, closest)
+	lobbies := newMockLobbyStore(lobby)
+
+	usecase := matchmaking_use_cases.NewRunMatchmakingUseCase(pools, tickets, tickets, lobbies, lobbies, &mockEvaluationWriter{}, nil, nil)
+
+	_, err := usecase.Exec(systemContext())
+	assert.NoError(t, err)
+
+	backfilled := lobbies.lobbies[lobby.ID]
+	assert.Empty(t, backfilled.OpenBackfills())
+
+	joiner := backfilled.GetPlayer(closest.UserID)
+	if assert.NotNil(t, joiner) {
+		assert.True(t, joiner.Backfill)
+		assert.True(t, joiner.PrizeEligible)
+		assert.Equal(t, "awp", joiner.Role)
+	}
+
+	ticket := tickets.tickets[closest.ID]
+	assert.Equal(t, matchmaking_entities.QueueTicketStatusMatched, ticket.Status)
+	assert.Equal(t, lobby.ID, *ticket.LobbyID)
+	assert.Equal(t, slot.ID, *ticket.BackfillSlotID)
+
+	for _, waiting := range []matchmaking_entities.QueueTicket{outOfRange, notOptedIn, wrongRole, farther} {
+		assert.Equal(t, matchmaking_entities.QueueTicketStatusWaiting, tickets.tickets[waiting.ID].Status)
+	}
+}
+
+// closedPool has a single one minute window starting in an hour
+func closedPool() matchmaking_entities.MatchmakingPool {
+	pool := newPool(1)
+	pool.Schedule = &matchmaking_entities.PoolSchedule{
+		Windows: []matchmaking_entities.PoolScheduleWindow{
+			{Name: "happy hour", Start: time.Now().UTC().Add(time.Hour).Format("15:04"), DurationMinutes: 1},
+		},
+	}
+
+	return pool
+}
+
+func TestEnqueuePlayerUseCase_Exec_Schedule(t *testing.T) {
+	pool := closedPool()
+
+	tickets := newMockTicketStore()
+	usecase := matchmaking_use_cases.NewEnqueuePlayerUseCase(newMockPoolStore(pool), tickets, tickets, fixedRatingReader(1000), newMockPenaltyStore())
+
+	var queueErr *matchmaking.QueueStateError
+	_, err := usecase.Exec(userContext(memberID), matchmaking_in.EnqueuePlayerCommand{PoolID: pool.ID, PlayerID: uuid.New()})
+	if assert.ErrorAs(t, err, &queueErr) {
+		assert.Contains(t, queueErr.Message, "'happy hour' opens at")
+	}
+
+	assert.Empty(t, tickets.tickets)
+}
+
+func TestRunMatchmakingUseCase_Exec_ClosedPool(t *testing.T) {
+	pool := closedPool()
+	waiting := []matchmaking_entities.QueueTicket{newTicket(pool, 1000, time.Minute), newTicket(pool, 1000, time.Minute)}
+
+	tickets := newMockTicketStore(waiting...)
+	lobbies := newMockLobbyStore()
+	evaluations := &mockEvaluationWriter{}
+
+	usecase := matchmaking_use_cases.NewRunMatchmakingUseCase(newMockPoolStore(pool), tickets, tickets, lobbies, lobbies, evaluations, nil, nil)
+
+	n, err := usecase.Exec(systemContext())
+	assert.NoError(t, err)
+	assert.Equal(t, 0, n)
+	assert.Empty(t, lobbies.lobbies)
+	assert.Empty(t, evaluations.evaluations)
+
+	for _, ticket := range waiting {
+		assert.Equal(t, matchmaking_entities.QueueTicketStatusCancelled, tickets.tickets[ticket.ID].Status)
+	}
+}
+
+func TestRecoverMatchmakingQueuesUseCase_Exec(t *testing.T) {
+	pool := newPool(1)
+
+	// the previous matcher saved the lobby (and later a backfill) but died before updating the tickets
+	formed := newTicket(pool, 1000, 2*time.Minute)
+	joiner := newTicket(pool, 1000, time.Minute)
+	requeued := newTicket(pool, 1000, time.Minute)
+	unmatched := newTicket(pool, 1000, time.Minute)
+
+	lobby := newLobby(matchmaking_entities.LobbyStatusInMatch, false)
+	lobby.PoolID = &pool.ID
+	lobby.UpdatedAt = time.Now()
+	lobby.Players = append(lobby.Players, matchmaking_entities.LobbyPlayer{UserID: formed.UserID, JoinedAt: time.Now().Add(-time.Minute)})
+
+	slot := lobby.Abandon(memberID, time.Now())
+	lobby.FillBackfill(slot.ID, matchmaking_entities.LobbyPlayer{UserID: joiner.UserID}, time.Now())
+
+	// abandoned the lobby and queued again
+	abandonedAt := time.Now().Add(-2 * time.Minute)
+	lobby.Players = append(lobby.Players, matchmaking_entities.LobbyPlayer{UserID: requeued.UserID, JoinedAt: time.Now().Add(-3 * time.Minute), AbandonedAt: &abandonedAt})
+
+	finished := newLobby(matchmaking_entities.LobbyStatusCompleted, false)
+	finished.PoolID = &pool.ID
+	finished.UpdatedAt = time.Now()
+	finished.Players = append(finished.Players, matchmaking_entities.LobbyPlayer{UserID: unmatched.UserID, JoinedAt: time.Now()})
+
+	tickets := newMockTicketStore(formed, joiner, requeued, unmatched)
+	usecase := matchmaking_use_cases.NewRecoverMatchmakingQueuesUseCase(newMockPoolStore(pool), tickets, tickets, newMockLobbyStore(lobby, finished))
+
+	recovered, err := usecase.Exec(systemContext())
+	assert.NoError(t, err)
+	assert.Equal(t, 2, recovered)
+
+	for _, id := range []uuid.UUID{formed.ID, joiner.ID} {
+		ticket := tickets.tickets[id]
+		assert.Equal(t, matchmaking_entities.QueueTicketStatusMatched, ticket.Status)
+		assert.Equal(t, lobby.ID, *ticket.LobbyID)
+	}
+
+	assert.Nil(t, tickets.tickets[formed.ID].BackfillSlotID)
+	assert.Equal(t, slot.ID, *tickets.tickets[joiner.ID].BackfillSlotID)
+
+	for _, id := range []uuid.UUID{requeued.ID, unmatched.ID} {
+		assert.Equal(t, matchmaking_entities.QueueTicketStatusWaiting, tickets.tickets[id].Status)
+	}
+
+	// recovered tickets aren't matched again
+	lobbies, err := matchmaking_use_cases.NewRunMatchmakingUseCase(newMockPoolStore(pool), tickets, tickets, newMockLobbyStore(), newMockLobbyStore(), &mockEvaluationWriter{}, nil, nil).Exec(systemContext())
+	assert.NoError(t, err)
+	assert.Equal(t, 1, lobbies)
+}
@@ -0,0 +1,75 @@
+package matchmaking_use_cases
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	"github.com/psavelis/team-pro/replay-api/pkg/domain/matchmaking"
+	matchmaking_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/matchmaking/entities"
+	matchmaking_in "github.com/psavelis/team-pro/replay-api/pkg/domain/matchmaking/ports/in"
+	matchmaking_out "github.com/psavelis/team-pro/replay-api/pkg/domain/matchmaking/ports/out"
+)
+
+type ModerateLobbyVoiceUseCase struct {
+	LobbyReader   matchmaking_out.LobbyReader
+	LobbyWriter   matchmaking_out.LobbyWriter
+	VoiceProvider matchmaking_out.VoiceChannelProvider
+}
+
+func NewModerateLobbyVoiceUseCase(lobbyReader matchmaking_out.LobbyReader, lobbyWriter matchmaking_out.LobbyWriter, voiceProvider matchmaking_out.VoiceChannelProvider) matchmaking_in.ModerateLobbyVoiceCommand {
+	return &ModerateLobbyVoiceUseCase{
+		LobbyReader:   lobbyReader,
+		LobbyWriter:   lobbyWriter,
+		VoiceProvider: voiceProvider,
+	}
+}
+
+func (usecase *ModerateLobbyVoiceUseCase) Exec(ctx context.Context, lobbyID uuid.UUID, targetUserID uuid.UUID, action matchmaking_entities.VoiceModerationAction) (*matchmaking_entities.Lobby, error) {
+	lobby, err := getTenantLobby(ctx, usecase.LobbyReader, lobbyID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !lobby.IsLeader(common.GetResourceOwner(ctx).UserID) {
+		return nil, matchmaking.NewLobbyForbiddenError("only the lobby leader can moderate the lobby voice channel")
+	}
+
+	if targetUserID == lobby.LeaderUserID || !lobby.HasUser(targetUserID) {
+		return nil, matchmaking.NewLobbyForbiddenError(fmt.Sprintf("user %s can't be moderated in this lobby", targetUserID))
+	}
+
+	if lobby.Voice == nil || lobby.IsFinished() {
+		return nil, matchmaking.NewLobbyStateError("lobby voice channel is not available")
+	}
+
+	switch action {
+	case matchmaking_entities.VoiceModerationMute:
+		err = usecase.VoiceProvider.SetMuted(ctx, lobby.Voice.ChannelID, targetUserID, true)
+	case matchmaking_entities.VoiceModerationUnmute:
+		err = usecase.VoiceProvider.SetMuted(ctx, lobby.Voice.ChannelID, targetUserID, false)
+	case matchmaking_entities.VoiceModerationKick:
+		err = usecase.VoiceProvider.Kick(ctx, lobby.Voice.ChannelID, targetUserID)
+	default:
+		return nil, matchmaking.NewLobbyStateError(fmt.Sprintf("invalid voice moderation action '%s'", action))
+	}
+
+	if err != nil {
+		slog.ErrorContext(ctx, "unable to moderate lobby voice channel", "lobbyID", lobbyID, "action", action, "err", err)
+		return nil, err
+	}
+
+	lobby.Voice.Apply(action, targetUserID)
+	lobby.UpdatedAt = time.Now().UTC()
+
+	lobby, err = usecase.LobbyWriter.Update(ctx, lobby)
+	if err != nil {
+		slog.ErrorContext(ctx, "unable to save lobby voice moderation", "lobbyID", lobbyID, "action", action, "err", err)
+		return nil, err
+	}
+
+	return lobby, nil
+}
@@ -0,0 +1,72 @@
+package matchmaking_use_cases
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	"github.com/psavelis/team-pro/replay-api/pkg/domain/matchmaking"
+	matchmaking_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/matchmaking/entities"
+	matchmaking_in "github.com/psavelis/team-pro/replay-api/pkg/domain/matchmaking/ports/in"
+	matchmaking_out "github.com/psavelis/team-pro/replay-api/pkg/domain/matchmaking/ports/out"
+)
+
+type PickDraftPlayerUseCase struct {
+	LobbyReader matchmaking_out.LobbyReader
+	LobbyWriter matchmaking_out.LobbyWriter
+}
+
+func NewPickDraftPlayerUseCase(lobbyReader matchmaking_out.LobbyReader, lobbyWriter matchmaking_out.LobbyWriter) matchmaking_in.PickDraftPlayerCommand {
+	return &PickDraftPlayerUseCase{
+		LobbyReader: lobbyReader,
+		LobbyWriter: lobbyWriter,
+	}
+}
+
+func (usecase *PickDraftPlayerUseCase) Exec(ctx context.Context, lobbyID uuid.UUID, userID uuid.UUID) (*matchmaking_entities.Lobby, error) {
+	lobby, err := getTenantLobby(ctx, usecase.LobbyReader, lobbyID)
+	if err != nil {
+		return nil, err
+	}
+
+	if lobby.Status != matchmaking_entities.LobbyStatusDrafting || lobby.Draft == nil {
+		return nil, matchmaking.NewLobbyStateError(fmt.Sprintf("lobby is not drafting (status '%s')", lobby.Status))
+	}
+
+	now := time.Now().UTC()
+
+	// timers may have run out since the last expiration job run: those turns are already lost
+	if lobby.AutoPickExpired(now) > 0 {
+		lobby, err = usecase.LobbyWriter.Update(ctx, lobby)
+		if err != nil {
+			slog.ErrorContext(ctx, "unable to save expired draft picks", "lobbyID", lobbyID, "err", err)
+			return nil, err
+		}
+
+		if lobby.Draft.IsCompleted() {
+			return nil, matchmaking.NewLobbyStateError("draft is already completed")
+		}
+	}
+
+	if lobby.Draft.CurrentCaptain() != common.GetResourceOwner(ctx).UserID {
+		return nil, matchmaking.NewLobbyForbiddenError("only the captain on the clock can pick")
+	}
+
+	player := lobby.GetPlayer(userID)
+	if player == nil || player.Team != matchmaking_entities.LobbyTeamUnassigned {
+		return nil, matchmaking.NewLobbyStateError(fmt.Sprintf("user %s is not available to be picked", userID))
+	}
+
+	lobby.Pick(userID, now, false)
+
+	lobby, err = usecase.LobbyWriter.Update(ctx, lobby)
+	if err != nil {
+		slog.ErrorContext(ctx, "unable to save draft pick", "lobbyID", lobbyID, "userID", userID, "err", err)
+		return nil, err
+	}
+
+	return lobby, nil
+}
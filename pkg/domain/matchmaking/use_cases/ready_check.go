@@ -0,0 +1,127 @@
+package matchmaking_use_cases
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	matchmaking_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/matchmaking/entities"
+	matchmaking_out "github.com/psavelis/team-pro/replay-api/pkg/domain/matchmaking/ports/out"
+)
+
+// readyCheckPorts are the ports needed to settle a failed ready check.
+type readyCheckPorts struct {
+	LobbyWriter   matchmaking_out.LobbyWriter
+	TicketReader  matchmaking_out.QueueTicketReader
+	TicketWriter  matchmaking_out.QueueTicketWriter
+	PenaltyReader matchmaking_out.QueuePenaltyReader
+	PenaltyWriter matchmaking_out.QueuePenaltyWriter
+}
+
+// failReadyCheck cancels the lobby. The players that declined are penalized, everyone else is queued again in their original place:
+// they accepted (or could still have) when the match fell through.
+func failReadyCheck(ctx context.Context, ports readyCheckPorts, lobby *matchmaking_entities.Lobby, declined []uuid.UUID, now time.Time) error {
+	lobby.FailReadyCheck(declined, now)
+
+	_, err := ports.LobbyWriter.Update(ctx, lobby)
+	if err != nil {
+		slog.ErrorContext(ctx, "unable to cancel lobby of failed ready check", "lobbyID", lobby.ID, "err", err)
+		return err
+	}
+
+	tickets, err := ports.TicketReader.Search(ctx, common.NewSearchByValues(ctx, []common.SearchableValue{
+		{Field: "LobbyID", Values: []interface{}{lobby.ID}},
+		{Field: "Status", Values: []interface{}{matchmaking_entities.QueueTicketStatusMatched}},
+	}, common.NewSearchResultOptions(0, uint(len(lobby.Players))), common.ClientApplicationAudienceIDKey))
+
+	if err != nil {
+		slog.ErrorContext(ctx, "unable to search queue tickets of cancelled lobby", "lobbyID", lobby.ID, "err", err)
+		return err
+	}
+
+	var errs []error
+
+	for _, ticket := range tickets {
+		if lobby.ReadyCheck.HasDeclined(ticket.UserID) {
+			errs = append(errs, penalizeDecline(ctx, ports, ticket, now))
+			continue
+		}
+
+		errs = append(errs, requeueTicket(ctx, ports.TicketReader, ports.TicketWriter, ticket, now))
+	}
+
+	slog.InfoContext(ctx, "ready check failed, lobby cancelled", "lobbyID", lobby.ID, "declined", len(lobby.ReadyCheck.Declined))
+
+	return errors.Join(errs...)
+}
+
+// requeueTicket puts the ticket back in the queue, keeping its EnqueuedAt (and so its place and search window).
+func requeueTicket(ctx context.Context, ticketReader matchmaking_out.QueueTicketReader, ticketWriter matchmaking_out.QueueTicketWriter, ticket matchmaking_entities.QueueTicket, now time.Time) error {
+	waiting, err := ticketReader.Search(ctx, common.NewSearchByValues(ctx, []common.SearchableValue{
+		{Field: "UserID", Values: []interface{}{ticket.UserID}},
+		{Field: "Status", Values: []interface{}{matchmaking_entities.QueueTicketStatusWaiting}},
+	}, common.NewSearchResultOptions(0, 1), common.ClientApplicationAudienceIDKey))
+
+	if err != nil {
+		slog.ErrorContext(ctx, "unable to search waiting queue tickets", "userID", ticket.UserID, "err", err)
+		return err
+	}
+
+	// queued again on their own meanwhile
+	if len(waiting) > 0 {
+		return nil
+	}
+
+	ticket.Status = matchmaking_entities.QueueTicketStatusWaiting
+	ticket.LobbyID = nil
+	ticket.MatchedAt = nil
+	ticket.UpdatedAt = now
+
+	_, err = ticketWriter.Update(ctx, &ticket)
+	if err != nil {
+		slog.ErrorContext(ctx, "unable to requeue ticket of cancelled lobby", "ticketID", ticket.ID, "err", err)
+		return err
+	}
+
+	return nil
+}
+
+// penalizeDecline counts the decline against the player of the ticket, starting their next queue cooldown.
+func penalizeDecline(ctx context.Context, ports readyCheckPorts, ticket matchmaking_entities.QueueTicket, now time.Time) error {
+	penalties, err := ports.PenaltyReader.Search(ctx, common.NewSearchByValues(ctx, []common.SearchableValue{
+		{Field: "UserID", Values: []interface{}{ticket.UserID}},
+	}, common.NewSearchResultOptions(0, 1), common.ClientApplicationAudienceIDKey))
+
+	if err != nil {
+		slog.ErrorContext(ctx, "unable to search queue penalties", "userID", ticket.UserID, "err", err)
+		return err
+	}
+
+	if len(penalties) == 0 {
+		penalty := &matchmaking_entities.QueuePenalty{
+			ID:            uuid.New(),
+			UserID:        ticket.UserID,
+			ResourceOwner: ticket.ResourceOwner,
+			CreatedAt:     now,
+		}
+
+		penalty.Decline(now)
+
+		_, err = ports.PenaltyWriter.Create(ctx, penalty)
+	} else {
+		penalty := &penalties[0]
+		penalty.Decline(now)
+
+		_, err = ports.PenaltyWriter.Update(ctx, penalty)
+	}
+
+	if err != nil {
+		slog.ErrorContext(ctx, "unable to save queue penalty", "userID", ticket.UserID, "err", err)
+		return err
+	}
+
+	return nil
+}
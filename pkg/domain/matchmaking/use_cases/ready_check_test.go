@@ -0,0 +1,213 @@
+package matchmaking_use_cases_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	"github.com/psavelis/team-pro/replay-api/pkg/domain/matchmaking"
+	matchmaking_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/matchmaking/entities"
+	matchmaking_in "github.com/psavelis/team-pro/replay-api/pkg/domain/matchmaking/ports/in"
+	matchmaking_use_cases "github.com/psavelis/team-pro/replay-api/pkg/domain/matchmaking/use_cases"
+	"github.com/stretchr/testify/assert"
+)
+
+type mockPenaltyStore struct {
+	penalties map[uuid.UUID]matchmaking_entities.QueuePenalty
+}
+
+func newMockPenaltyStore() *mockPenaltyStore {
+	return &mockPenaltyStore{penalties: make(map[uuid.UUID]matchmaking_entities.QueuePenalty)}
+}
+
+// Search evaluates the UserID value param.
+func (m *mockPenaltyStore) Search(ctx context.Context, s common.Search) ([]matchmaking_entities.QueuePenalty, error) {
+	res := make([]matchmaking_entities.QueuePenalty, 0)
+	for _, p := range m.penalties {
+		if p.UserID == s.SearchParams[0].Params[0].ValueParams[0].Values[0] {
+			res = append(res, p)
+		}
+	}
+
+	return res, nil
+}
+
+func (m *mockPenaltyStore) Compile(ctx context.Context, searchParams []common.SearchAggregation, resultOptions common.SearchResultOptions) (*common.Search, error) {
+	return nil, nil
+}
+
+func (m *mockPenaltyStore) Create(ctx context.Context, penalty *matchmaking_entities.QueuePenalty) (*matchmaking_entities.QueuePenalty, error) {
+	m.penalties[penalty.ID] = *penalty
+	return penalty, nil
+}
+
+func (m *mockPenaltyStore) Update(ctx context.Context, penalty *matchmaking_entities.QueuePenalty) (*matchmaking_entities.QueuePenalty, error) {
+	m.penalties[penalty.ID] = *penalty
+	return penalty, nil
+}
+
+func (m *mockPenaltyStore) get(userID uuid.UUID) *matchmaking_entities.QueuePenalty {
+	for _, p := range m.penalties {
+		if p.UserID == userID {
+			return &p
+		}
+	}
+
+	return nil
+}
+
+// readyCheckFixture matches the leader and the member into a lobby of a 1v1 pool with a ready check.
+type readyCheckFixture struct {
+	pool      matchmaking_entities.MatchmakingPool
+	lobbies   *mockLobbyStore
+	tickets   *mockTicketStore
+	penalties *mockPenaltyStore
+	lobby     matchmaking_entities.Lobby
+	leader    matchmaking_entities.QueueTicket
+	member    matchmaking_entities.QueueTicket
+}
+
+func newReadyCheckFixture(t *testing.T) *readyCheckFixture {
+	pool := newPool(1)
+	pool.ReadyCheckTimeoutSeconds = 20
+
+	leader := newTicket(pool, 1000, 2*time.Minute)
+	leader.UserID = leaderID
+
+	member := newTicket(pool, 1050, time.Minute)
+	member.UserID = memberID
+
+	f := &readyCheckFixture{
+		pool:      pool,
+		lobbies:   newMockLobbyStore(),
+		tickets:   newMockTicketStore(leader, member),
+		penalties: newMockPenaltyStore(),
+		leader:    leader,
+		member:    member,
+	}
+
+	matcher := matchmaking_use_cases.NewRunMatchmakingUseCase(newMockPoolStore(pool), f.tickets, f.tickets, f.lobbies, f.lobbies, &mockEvaluationWriter{}, nil, nil)
+
+	n, err := matcher.Exec(systemContext())
+	if !assert.NoError(t, err) || !assert.Equal(t, 1, n) {
+		t.FailNow()
+	}
+
+	for _, l := range f.lobbies.lobbies {
+		f.lobby = l
+	}
+
+	return f
+}
+
+func (f *readyCheckFixture) respond() matchmaking_in.RespondReadyCheckCommand {
+	return matchmaking_use_cases.NewRespondReadyCheckUseCase(f.lobbies, f.lobbies, f.tickets, f.tickets, f.penalties, f.penalties)
+}
+
+func TestRunMatchmakingUseCase_Exec_ReadyCheck(t *testing.T) {
+	f := newReadyCheckFixture(t)
+
+	assert.Equal(t, matchmaking_entities.LobbyStatusReadyCheck, f.lobby.Status)
+	if assert.NotNil(t, f.lobby.ReadyCheck) {
+		assert.Equal(t, 20, f.lobby.ReadyCheck.TimeoutSeconds)
+		assert.Equal(t, f.lobby.CreatedAt.Add(20*time.Second), f.lobby.ReadyCheck.Deadline)
+	}
+
+	// players are matched (out of the queue) while the ready check is in progress
+	assert.Equal(t, matchmaking_entities.QueueTicketStatusMatched, f.tickets.tickets[f.leader.ID].Status)
+	assert.Equal(t, matchmaking_entities.QueueTicketStatusMatched, f.tickets.tickets[f.member.ID].Status)
+}
+
+func TestRespondReadyCheckUseCase_Exec_Accept(t *testing.T) {
+	f := newReadyCheckFixture(t)
+	usecase := f.respond()
+
+	var forbiddenErr *matchmaking.LobbyForbiddenError
+	_, err := usecase.Exec(userContext(uuid.New()), f.lobby.ID, true)
+	assert.ErrorAs(t, err, &forbiddenErr, "not a lobby player")
+
+	lobby, err := usecase.Exec(userContext(leaderID), f.lobby.ID, true)
+	assert.NoError(t, err)
+	assert.Equal(t, matchmaking_entities.LobbyStatusReadyCheck, lobby.Status)
+	assert.Equal(t, []uuid.UUID{memberID}, lobby.PendingReadyCheck())
+
+	// accepting twice is a no-op
+	lobby, err = usecase.Exec(userContext(leaderID), f.lobby.ID, true)
+	assert.NoError(t, err)
+	assert.Len(t, lobby.ReadyCheck.Accepted, 1)
+
+	lobby, err = usecase.Exec(userContext(memberID), f.lobby.ID, true)
+	assert.NoError(t, err)
+	assert.Equal(t, matchmaking_entities.LobbyStatusReady, lobby.Status)
+	assert.True(t, lobby.ReadyCheck.IsCompleted())
+
+	var stateErr *matchmaking.LobbyStateError
+	_, err = usecase.Exec(userContext(memberID), f.lobby.ID, false)
+	assert.ErrorAs(t, err, &stateErr, "ready check already completed")
+	assert.Empty(t, f.penalties.penalties)
+}
+
+func TestRespondReadyCheckUseCase_Exec_Decline(t *testing.T) {
+	f := newReadyCheckFixture(t)
+
+	lobby, err := f.respond().Exec(userContext(memberID), f.lobby.ID, false)
+	assert.NoError(t, err)
+	assert.Equal(t, matchmaking_entities.LobbyStatusCancelled, lobby.Status)
+	assert.Equal(t, []uuid.UUID{memberID}, lobby.ReadyCheck.Declined)
+
+	// the leader is back in the queue, in the same place
+	requeued := f.tickets.tickets[f.leader.ID]
+	assert.Equal(t, matchmaking_entities.QueueTicketStatusWaiting, requeued.Status)
+	assert.Nil(t, requeued.LobbyID)
+	assert.Nil(t, requeued.MatchedAt)
+	assert.Equal(t, f.leader.EnqueuedAt, requeued.EnqueuedAt)
+
+	// the member isn't, and can't queue again until the cooldown ends
+	assert.Equal(t, matchmaking_entities.QueueTicketStatusMatched, f.tickets.tickets[f.member.ID].Status)
+
+	penalty := f.penalties.get(memberID)
+	if assert.NotNil(t, penalty) {
+		assert.Equal(t, 1, penalty.Declines)
+		assert.Equal(t, penalty.LastDeclinedAt.Add(matchmaking_entities.QueuePenaltyCooldowns[0]), penalty.CooldownUntil)
+	}
+
+	enqueue := matchmaking_use_cases.NewEnqueuePlayerUseCase(newMockPoolStore(f.pool), f.tickets, f.tickets, fixedRatingReader(1000), f.penalties)
+
+	var queueErr *matchmaking.QueueStateError
+	_, err = enqueue.Exec(userContext(memberID), matchmaking_in.EnqueuePlayerCommand{PoolID: f.pool.ID, PlayerID: uuid.New()})
+	if assert.ErrorAs(t, err, &queueErr) {
+		assert.Contains(t, queueErr.Message, "queue cooldown until")
+	}
+}
+
+func TestExpireReadyChecksUseCase_Exec(t *testing.T) {
+	f := newReadyCheckFixture(t)
+
+	_, err := f.respond().Exec(userContext(leaderID), f.lobby.ID, true)
+	assert.NoError(t, err)
+
+	usecase := matchmaking_use_cases.NewExpireReadyChecksUseCase(f.lobbies, f.lobbies, f.tickets, f.tickets, f.penalties, f.penalties)
+
+	// still within the deadline
+	n, err := usecase.Exec(systemContext())
+	assert.NoError(t, err)
+	assert.Equal(t, 0, n)
+
+	lobby := f.lobbies.lobbies[f.lobby.ID]
+	lobby.ReadyCheck.Deadline = time.Now().Add(-time.Second)
+	f.lobbies.lobbies[f.lobby.ID] = lobby
+
+	n, err = usecase.Exec(systemContext())
+	assert.NoError(t, err)
+	assert.Equal(t, 1, n)
+
+	lobby = f.lobbies.lobbies[f.lobby.ID]
+	assert.Equal(t, matchmaking_entities.LobbyStatusCancelled, lobby.Status)
+	assert.Equal(t, []uuid.UUID{memberID}, lobby.ReadyCheck.Declined, "missed the deadline")
+
+	assert.Equal(t, matchmaking_entities.QueueTicketStatusWaiting, f.tickets.tickets[f.leader.ID].Status)
+	assert.Nil(t, f.penalties.get(leaderID))
+	assert.NotNil(t, f.penalties.get(memberID))
+}
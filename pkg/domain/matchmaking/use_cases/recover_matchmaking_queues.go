@@ -0,0 +1,152 @@
+package matchmaking_use_cases
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	matchmaking_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/matchmaking/entities"
+	matchmaking_in "github.com/psavelis/team-pro/replay-api/pkg/domain/matchmaking/ports/in"
+	matchmaking_out "github.com/psavelis/team-pro/replay-api/pkg/domain/matchmaking/ports/out"
+)
+
+// max lobbies of a pool checked for players of waiting tickets per recovery
+const RecoveryLobbyBatchSize = 200
+
+// RecoverMatchmakingQueuesUseCase repairs the queues left behind by a matcher that died mid-run. The matcher saves the lobby (or the
+// filled backfill slot) before marking its tickets as matched, so a crash in between leaves tickets waiting for players that already
+// joined a lobby; the next run would match them twice.
+type RecoverMatchmakingQueuesUseCase struct {
+	PoolReader   matchmaking_out.MatchmakingPoolReader
+	TicketReader matchmaking_out.QueueTicketReader
+	TicketWriter matchmaking_out.QueueTicketWriter
+	LobbyReader  matchmaking_out.LobbyReader
+}
+
+func NewRecoverMatchmakingQueuesUseCase(poolReader matchmaking_out.MatchmakingPoolReader, ticketReader matchmaking_out.QueueTicketReader, ticketWriter matchmaking_out.QueueTicketWriter, lobbyReader matchmaking_out.LobbyReader) matchmaking_in.RecoverMatchmakingQueuesCommand {
+	return &RecoverMatchmakingQueuesUseCase{
+		PoolReader:   poolReader,
+		TicketReader: ticketReader,
+		TicketWriter: ticketWriter,
+		LobbyReader:  lobbyReader,
+	}
+}
+
+func (usecase *RecoverMatchmakingQueuesUseCase) Exec(ctx context.Context) (int, error) {
+	pools, err := usecase.PoolReader.Search(ctx, common.NewSearchByValues(ctx, []common.SearchableValue{
+		{Field: "Enabled", Values: []interface{}{true}},
+	}, common.NewSearchResultOptions(0, MatchmakingPoolBatchSize), common.ClientApplicationAudienceIDKey))
+
+	if err != nil {
+		slog.ErrorContext(ctx, "unable to search enabled matchmaking pools", "err", err)
+		return 0, err
+	}
+
+	var errs []error
+
+	recovered := 0
+	for _, pool := range pools {
+		n, err := usecase.recoverPool(ctx, pool)
+		recovered += n
+
+		if err != nil {
+			slog.ErrorContext(ctx, "unable to recover matchmaking pool queue", "poolID", pool.ID, "err", err)
+			errs = append(errs, err)
+		}
+	}
+
+	return recovered, errors.Join(errs...)
+}
+
+// recoverPool marks as matched the waiting tickets whose players joined an active lobby of the pool after enqueueing.
+func (usecase *RecoverMatchmakingQueuesUseCase) recoverPool(ctx context.Context, pool matchmaking_entities.MatchmakingPool) (int, error) {
+	tickets, err := waitingTickets(ctx, usecase.TicketReader, pool)
+	if err != nil || len(tickets) == 0 {
+		return 0, err
+	}
+
+	oldest := tickets[0].EnqueuedAt
+	for _, t := range tickets {
+		if t.EnqueuedAt.Before(oldest) {
+			oldest = t.EnqueuedAt
+		}
+	}
+
+	// lobbies not touched since the oldest ticket was enqueued can't hold any of their players
+	lobbies, err := usecase.LobbyReader.Search(ctx, common.NewSearchByValues(ctx, []common.SearchableValue{
+		{Field: "PoolID", Values: []interface{}{pool.ID}},
+		{Field: "Status", Values: []interface{}{
+			matchmaking_entities.LobbyStatusReadyCheck,
+			matchmaking_entities.LobbyStatusForming,
+			matchmaking_entities.LobbyStatusDrafting,
+			matchmaking_entities.LobbyStatusReady,
+			matchmaking_entities.LobbyStatusInMatch,
+		}},
+		{Field: "UpdatedAt", Operator: common.GreaterThanOperator, Values: []interface{}{oldest}},
+	}, common.NewSearchResultOptions(0, RecoveryLobbyBatchSize), common.ClientApplicationAudienceIDKey))
+
+	if err != nil {
+		slog.ErrorContext(ctx, "unable to search active lobbies of pool", "poolID", pool.ID, "err", err)
+		return 0, err
+	}
+
+	if len(lobbies) == 0 {
+		return 0, nil
+	}
+
+	now := time.Now().UTC()
+
+	var errs []error
+
+	recovered := 0
+	for _, ticket := range tickets {
+		lobby, player := joinedLobby(lobbies, ticket)
+		if lobby == nil {
+			continue
+		}
+
+		joinedAt := player.JoinedAt
+
+		ticket.Status = matchmaking_entities.QueueTicketStatusMatched
+		ticket.LobbyID = &lobby.ID
+		ticket.MatchedAt = &joinedAt
+		ticket.UpdatedAt = now
+
+		if player.Backfill {
+			for _, slot := range lobby.Backfills {
+				if slot.FilledByUserID != nil && *slot.FilledByUserID == ticket.UserID {
+					slotID := slot.ID
+					ticket.BackfillSlotID = &slotID
+				}
+			}
+		}
+
+		_, err := usecase.TicketWriter.Update(ctx, &ticket)
+		if err != nil {
+			slog.ErrorContext(ctx, "unable to recover queue ticket", "ticketID", ticket.ID, "lobbyID", lobby.ID, "err", err)
+			errs = append(errs, err)
+			continue
+		}
+
+		slog.InfoContext(ctx, "queue ticket recovered as matched", "ticketID", ticket.ID, "lobbyID", lobby.ID, "poolID", pool.ID)
+		recovered++
+	}
+
+	return recovered, errors.Join(errs...)
+}
+
+// joinedLobby returns the lobby the player of the ticket joined (and didn't abandon) since enqueueing, if any.
+func joinedLobby(lobbies []matchmaking_entities.Lobby, ticket matchmaking_entities.QueueTicket) (*matchmaking_entities.Lobby, *matchmaking_entities.LobbyPlayer) {
+	for i := range lobbies {
+		player := lobbies[i].GetPlayer(ticket.UserID)
+		if player == nil || player.AbandonedAt != nil || player.JoinedAt.Before(ticket.EnqueuedAt) {
+			continue
+		}
+
+		return &lobbies[i], player
+	}
+
+	return nil, nil
+}
@@ -0,0 +1,103 @@
+package matchmaking_use_cases
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	"github.com/psavelis/team-pro/replay-api/pkg/domain/matchmaking"
+	matchmaking_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/matchmaking/entities"
+	matchmaking_in "github.com/psavelis/team-pro/replay-api/pkg/domain/matchmaking/ports/in"
+	matchmaking_out "github.com/psavelis/team-pro/replay-api/pkg/domain/matchmaking/ports/out"
+	replay_entity "github.com/psavelis/team-pro/replay-api/pkg/domain/replay/entities"
+)
+
+type ReportLobbyResultUseCase struct {
+	LobbyReader      matchmaking_out.LobbyReader
+	LobbyWriter      matchmaking_out.LobbyWriter
+	LobbyMatchWriter matchmaking_out.LobbyMatchWriter
+	EventPublisher   matchmaking_out.LobbyEventPublisher
+}
+
+func NewReportLobbyResultUseCase(lobbyReader matchmaking_out.LobbyReader, lobbyWriter matchmaking_out.LobbyWriter, lobbyMatchWriter matchmaking_out.LobbyMatchWriter, eventPublisher matchmaking_out.LobbyEventPublisher) matchmaking_in.ReportLobbyResultCommand {
+	return &ReportLobbyResultUseCase{
+		LobbyReader:      lobbyReader,
+		LobbyWriter:      lobbyWriter,
+		LobbyMatchWriter: lobbyMatchWriter,
+		EventPublisher:   eventPublisher,
+	}
+}
+
+func (usecase *ReportLobbyResultUseCase) Exec(ctx context.Context, lobbyID uuid.UUID, teams []matchmaking_entities.LobbyTeamResult) (*matchmaking_entities.Lobby, error) {
+	lobby, err := getTenantLobby(ctx, usecase.LobbyReader, lobbyID)
+	if err != nil {
+		return nil, err
+	}
+
+	callerID := common.GetResourceOwner(ctx).UserID
+	if !lobby.IsLeader(callerID) {
+		return nil, matchmaking.NewLobbyForbiddenError("only the lobby leader can report the match result")
+	}
+
+	if lobby.Status != matchmaking_entities.LobbyStatusInMatch || lobby.MatchID == nil {
+		return nil, matchmaking.NewLobbyStateError(fmt.Sprintf("the result can't be reported for a lobby in status '%s'", lobby.Status))
+	}
+
+	result := matchmaking_entities.LobbyResult{
+		Teams:      teams,
+		ReportedBy: callerID,
+		ReportedAt: time.Now().UTC(),
+	}
+
+	err = result.Validate()
+	if err != nil {
+		return nil, matchmaking.NewLobbyStateError(err.Error())
+	}
+
+	lobby.Complete(result)
+
+	// published before the lobby is completed: a failed publish fails the report (so it's reported again), the ratings ignore a
+	// match rated twice
+	if usecase.EventPublisher != nil {
+		err = usecase.EventPublisher.PublishMatchCompleted(ctx, matchmaking_entities.NewMatchCompleted(*lobby))
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		slog.WarnContext(ctx, "match result not published, players won't be rated", "lobbyID", lobbyID, "matchID", lobby.MatchID)
+	}
+
+	// paid lobbies: the payouts are recorded on the match for the match history of the players (a failure fails the report too)
+	settlement := lobby.Settle(result.Winner())
+	if settlement != nil {
+		_, err = usecase.LobbyMatchWriter.AttachSettlement(ctx, *lobby.MatchID, lobby.ID, newMatchSettlement(*settlement, result.ReportedAt))
+		if err != nil {
+			slog.ErrorContext(ctx, "unable to attach lobby settlement to match", "lobbyID", lobbyID, "matchID", lobby.MatchID, "err", err)
+			return nil, err
+		}
+	}
+
+	lobby, err = usecase.LobbyWriter.Update(ctx, lobby)
+	if err != nil {
+		slog.ErrorContext(ctx, "unable to save lobby result", "lobbyID", lobbyID, "err", err)
+		return nil, err
+	}
+
+	return lobby, nil
+}
+
+func newMatchSettlement(settlement matchmaking_entities.LobbySettlement, settledAt time.Time) replay_entity.MatchSettlement {
+	payouts := make([]replay_entity.MatchSettlementPayout, 0, len(settlement.Payouts))
+	for _, p := range settlement.Payouts {
+		payouts = append(payouts, replay_entity.MatchSettlementPayout{PlayerID: p.PlayerID, Amount: p.Amount})
+	}
+
+	return replay_entity.MatchSettlement{
+		Currency:  settlement.Currency,
+		Payouts:   payouts,
+		SettledAt: settledAt,
+	}
+}
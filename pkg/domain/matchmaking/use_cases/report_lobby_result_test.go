@@ -0,0 +1,97 @@
+package matchmaking_use_cases_test
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/psavelis/team-pro/replay-api/pkg/domain/matchmaking"
+	matchmaking_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/matchmaking/entities"
+	matchmaking_use_cases "github.com/psavelis/team-pro/replay-api/pkg/domain/matchmaking/use_cases"
+	replay_entity "github.com/psavelis/team-pro/replay-api/pkg/domain/replay/entities"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReportLobbyResultUseCase_Exec(t *testing.T) {
+	matchID := uuid.New()
+	squadID := uuid.New()
+
+	inMatch := newLobby(matchmaking_entities.LobbyStatusInMatch, false)
+	inMatch.MatchID = &matchID
+	inMatch.Players[0].Team = matchmaking_entities.LobbyTeamA
+	inMatch.Players[1].Team = matchmaking_entities.LobbyTeamB
+
+	paid := inMatch
+	paid.ID = uuid.New()
+	paid.Players = append([]matchmaking_entities.LobbyPlayer{}, inMatch.Players...)
+	paid.Players[0].PrizeEligible = true
+	paid.Players[1].PrizeEligible = true
+	paid.Economy = &matchmaking_entities.LobbyEconomy{Currency: "USD", EntryFee: 500, WinnerSplitBasisPoints: []int{matchmaking_entities.BasisPoints}}
+
+	result := []matchmaking_entities.LobbyTeamResult{
+		{Team: matchmaking_entities.LobbyTeamA, Score: 13, SquadID: &squadID},
+		{Team: matchmaking_entities.LobbyTeamB, Score: 9},
+	}
+
+	tests := []struct {
+		name        string
+		lobby       matchmaking_entities.Lobby
+		callerID    uuid.UUID
+		teams       []matchmaking_entities.LobbyTeamResult
+		expectedErr interface{}
+	}{
+		{name: "Leader Reports", lobby: inMatch, callerID: leaderID, teams: result},
+		{name: "Paid Lobby Is Settled", lobby: paid, callerID: leaderID, teams: result},
+		{name: "Member Can't Report", lobby: inMatch, callerID: memberID, teams: result, expectedErr: &matchmaking.LobbyForbiddenError{}},
+		{name: "Match Not Linked", lobby: newLobby(matchmaking_entities.LobbyStatusReady, false), callerID: leaderID, teams: result, expectedErr: &matchmaking.LobbyStateError{}},
+		{name: "Missing Team", lobby: inMatch, callerID: leaderID, teams: result[:1], expectedErr: &matchmaking.LobbyStateError{}},
+		{name: "Team Reported Twice", lobby: inMatch, callerID: leaderID, teams: []matchmaking_entities.LobbyTeamResult{result[0], result[0]}, expectedErr: &matchmaking.LobbyStateError{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := newMockLobbyStore(tt.lobby)
+			publisher := &mockLobbyEventPublisher{}
+			matches := &mockLobbyMatchWriter{settlements: map[uuid.UUID]replay_entity.MatchSettlement{}}
+
+			usecase := matchmaking_use_cases.NewReportLobbyResultUseCase(store, store, matches, publisher)
+
+			lobby, err := usecase.Exec(userContext(tt.callerID), tt.lobby.ID, tt.teams)
+
+			if tt.expectedErr != nil {
+				assert.IsType(t, tt.expectedErr, err)
+				assert.Equal(t, 0, store.updates)
+				assert.Empty(t, publisher.completed)
+				assert.Empty(t, matches.settlements)
+				return
+			}
+
+			// the winners (team A) split the prize pool, free lobbies have nothing to settle
+			if tt.lobby.Economy == nil {
+				assert.Empty(t, matches.settlements)
+			} else if assert.Contains(t, matches.settlements, matchID) {
+				settlement := matches.settlements[matchID]
+				assert.Equal(t, "USD", settlement.Currency)
+				assert.Equal(t, []replay_entity.MatchSettlementPayout{{PlayerID: tt.lobby.Players[0].PlayerID, Amount: 1000}}, settlement.Payouts)
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, matchmaking_entities.LobbyStatusCompleted, lobby.Status)
+			assert.Equal(t, leaderID, lobby.Result.ReportedBy)
+
+			if !assert.Len(t, publisher.completed, 1) {
+				return
+			}
+
+			completed := publisher.completed[0]
+			assert.Equal(t, matchID, completed.MatchID)
+			assert.Equal(t, tt.lobby.ID, completed.LobbyID)
+
+			if assert.Len(t, completed.Teams, 2) {
+				assert.Equal(t, 13, completed.Teams[0].Score)
+				assert.Equal(t, &squadID, completed.Teams[0].SquadID)
+				assert.Equal(t, []matchmaking_entities.MatchCompletedPlayer{{PlayerID: tt.lobby.Players[0].PlayerID, UserID: leaderID}}, completed.Teams[0].Players)
+				assert.Equal(t, memberID, completed.Teams[1].Players[0].UserID)
+			}
+		})
+	}
+}
@@ -0,0 +1,90 @@
+package matchmaking_use_cases
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	"github.com/psavelis/team-pro/replay-api/pkg/domain/matchmaking"
+	matchmaking_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/matchmaking/entities"
+	matchmaking_in "github.com/psavelis/team-pro/replay-api/pkg/domain/matchmaking/ports/in"
+	matchmaking_out "github.com/psavelis/team-pro/replay-api/pkg/domain/matchmaking/ports/out"
+)
+
+type RespondReadyCheckUseCase struct {
+	LobbyReader   matchmaking_out.LobbyReader
+	LobbyWriter   matchmaking_out.LobbyWriter
+	TicketReader  matchmaking_out.QueueTicketReader
+	TicketWriter  matchmaking_out.QueueTicketWriter
+	PenaltyReader matchmaking_out.QueuePenaltyReader
+	PenaltyWriter matchmaking_out.QueuePenaltyWriter
+}
+
+func NewRespondReadyCheckUseCase(lobbyReader matchmaking_out.LobbyReader, lobbyWriter matchmaking_out.LobbyWriter, ticketReader matchmaking_out.QueueTicketReader, ticketWriter matchmaking_out.QueueTicketWriter, penaltyReader matchmaking_out.QueuePenaltyReader, penaltyWriter matchmaking_out.QueuePenaltyWriter) matchmaking_in.RespondReadyCheckCommand {
+	return &RespondReadyCheckUseCase{
+		LobbyReader:   lobbyReader,
+		LobbyWriter:   lobbyWriter,
+		TicketReader:  ticketReader,
+		TicketWriter:  ticketWriter,
+		PenaltyReader: penaltyReader,
+		PenaltyWriter: penaltyWriter,
+	}
+}
+
+func (usecase *RespondReadyCheckUseCase) Exec(ctx context.Context, lobbyID uuid.UUID, accept bool) (*matchmaking_entities.Lobby, error) {
+	lobby, err := getTenantLobby(ctx, usecase.LobbyReader, lobbyID)
+	if err != nil {
+		return nil, err
+	}
+
+	userID := common.GetResourceOwner(ctx).UserID
+	if !lobby.HasUser(userID) {
+		return nil, matchmaking.NewLobbyForbiddenError("only the lobby players can respond to the ready check")
+	}
+
+	if lobby.Status != matchmaking_entities.LobbyStatusReadyCheck || lobby.ReadyCheck == nil {
+		return nil, matchmaking.NewLobbyStateError(fmt.Sprintf("lobby has no ready check in progress (status '%s')", lobby.Status))
+	}
+
+	ports := readyCheckPorts{
+		LobbyWriter:   usecase.LobbyWriter,
+		TicketReader:  usecase.TicketReader,
+		TicketWriter:  usecase.TicketWriter,
+		PenaltyReader: usecase.PenaltyReader,
+		PenaltyWriter: usecase.PenaltyWriter,
+	}
+
+	now := time.Now().UTC()
+
+	// the deadline may have passed since the last expiration job run: the ready check is already lost
+	if lobby.ReadyCheck.IsExpired(now) {
+		err = failReadyCheck(ctx, ports, lobby, lobby.PendingReadyCheck(), now)
+		if err != nil {
+			return nil, err
+		}
+
+		return nil, matchmaking.NewLobbyStateError("ready check expired")
+	}
+
+	if !accept {
+		err = failReadyCheck(ctx, ports, lobby, []uuid.UUID{userID}, now)
+		if err != nil {
+			return nil, err
+		}
+
+		return lobby, nil
+	}
+
+	lobby.AcceptReadyCheck(userID, now)
+
+	lobby, err = usecase.LobbyWriter.Update(ctx, lobby)
+	if err != nil {
+		slog.ErrorContext(ctx, "unable to save ready check acceptance", "lobbyID", lobbyID, "userID", userID, "err", err)
+		return nil, err
+	}
+
+	return lobby, nil
+}
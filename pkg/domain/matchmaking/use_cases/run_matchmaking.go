@@ -0,0 +1,458 @@
+package matchmaking_use_cases
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	matchmaking_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/matchmaking/entities"
+	matchmaking_in "github.com/psavelis/team-pro/replay-api/pkg/domain/matchmaking/ports/in"
+	matchmaking_out "github.com/psavelis/team-pro/replay-api/pkg/domain/matchmaking/ports/out"
+	matchmaking_strategies "github.com/psavelis/team-pro/replay-api/pkg/domain/matchmaking/strategies"
+)
+
+const (
+	// max pools matched per run
+	MatchmakingPoolBatchSize = 100
+	// max waiting tickets (oldest first) considered per pool and run, read in pages (the strategies shard large queues by rating)
+	MatchmakingTicketWindow   = 1000
+	MatchmakingTicketPageSize = 200
+	// max lobbies with open backfill slots handled per pool and run
+	BackfillLobbyBatchSize = 50
+)
+
+type RunMatchmakingUseCase struct {
+	PoolReader       matchmaking_out.MatchmakingPoolReader
+	TicketReader     matchmaking_out.QueueTicketReader
+	TicketWriter     matchmaking_out.QueueTicketWriter
+	LobbyReader      matchmaking_out.LobbyReader
+	LobbyWriter      matchmaking_out.LobbyWriter
+	EvaluationWriter matchmaking_out.StrategyEvaluationWriter
+
+	// EconomyReader is optional, without it (or an economy config for the pool mode) lobbies are free
+	EconomyReader matchmaking_out.EconomyConfigReader
+
+	// EventPublisher is optional (no broker), lobbies are then only found by polling
+	EventPublisher matchmaking_out.LobbyEventPublisher
+}
+
+func NewRunMatchmakingUseCase(poolReader matchmaking_out.MatchmakingPoolReader, ticketReader matchmaking_out.QueueTicketReader, ticketWriter matchmaking_out.QueueTicketWriter, lobbyReader matchmaking_out.LobbyReader, lobbyWriter matchmaking_out.LobbyWriter, evaluationWriter matchmaking_out.StrategyEvaluationWriter, economyReader matchmaking_out.EconomyConfigReader, eventPublisher matchmaking_out.LobbyEventPublisher) matchmaking_in.RunMatchmakingCommand {
+	return &RunMatchmakingUseCase{
+		PoolReader:       poolReader,
+		TicketReader:     ticketReader,
+		TicketWriter:     ticketWriter,
+		LobbyReader:      lobbyReader,
+		LobbyWriter:      lobbyWriter,
+		EvaluationWriter: evaluationWriter,
+		EconomyReader:    economyReader,
+		EventPublisher:   eventPublisher,
+	}
+}
+
+func (usecase *RunMatchmakingUseCase) Exec(ctx context.Context) (int, error) {
+	pools, err := usecase.PoolReader.Search(ctx, common.NewSearchByValues(ctx, []common.SearchableValue{
+		{Field: "Enabled", Values: []interface{}{true}},
+	}, common.NewSearchResultOptions(0, MatchmakingPoolBatchSize), common.ClientApplicationAudienceIDKey))
+
+	if err != nil {
+		slog.ErrorContext(ctx, "unable to search enabled matchmaking pools", "err", err)
+		return 0, err
+	}
+
+	var errs []error
+
+	lobbies := 0
+	for _, pool := range pools {
+		n, err := usecase.matchPool(ctx, pool)
+		lobbies += n
+
+		if err != nil {
+			slog.ErrorContext(ctx, "unable to run matchmaking pool", "poolID", pool.ID, "err", err)
+			errs = append(errs, err)
+		}
+	}
+
+	return lobbies, errors.Join(errs...)
+}
+
+// matchPool fills the open backfill slots of the pool first (ongoing matches are short-handed), then forms the lobbies of the pool
+// with its strategy and replays the same tickets through the shadow strategies for comparison.
+func (usecase *RunMatchmakingUseCase) matchPool(ctx context.Context, pool matchmaking_entities.MatchmakingPool) (int, error) {
+	tickets, err := waitingTickets(ctx, usecase.TicketReader, pool)
+	if err != nil {
+		return 0, err
+	}
+
+	if !pool.AvailabilityAt(time.Now().UTC()).Open {
+		return 0, usecase.closeQueue(ctx, pool, tickets)
+	}
+
+	tickets, err = usecase.fillBackfills(ctx, pool, tickets)
+	if err != nil {
+		return 0, err
+	}
+
+	if len(tickets) < pool.MatchSize() {
+		return 0, nil
+	}
+
+	strategy, err := matchmaking_strategies.NewMatcherStrategy(pool.Strategy)
+	if err != nil {
+		return 0, err
+	}
+
+	now := time.Now().UTC()
+
+	economy, err := usecase.poolEconomy(ctx, pool, now)
+	if err != nil {
+		return 0, err
+	}
+
+	start := time.Now()
+	proposals := strategy.Match(pool, tickets, now)
+	evaluations := []matchmaking_entities.StrategyEvaluation{
+		matchmaking_strategies.Summarize(strategy.Name(), pool, tickets, proposals, now, time.Since(start)),
+	}
+
+	for _, name := range pool.ShadowStrategies {
+		shadow, err := matchmaking_strategies.NewMatcherStrategy(name)
+		if err != nil {
+			slog.WarnContext(ctx, "skipping unknown shadow strategy", "poolID", pool.ID, "strategy", name)
+			continue
+		}
+
+		start := time.Now()
+		shadowProposals := shadow.Match(pool, tickets, now)
+
+		evaluation := matchmaking_strategies.Summarize(shadow.Name(), pool, tickets, shadowProposals, now, time.Since(start))
+		evaluation.Shadow = true
+
+		evaluations = append(evaluations, evaluation)
+	}
+
+	var errs []error
+
+	lobbies := 0
+	for _, proposal := range proposals {
+		err := usecase.createLobby(ctx, pool, economy, proposal, now)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+
+		lobbies++
+	}
+
+	err = usecase.saveEvaluations(ctx, pool, evaluations, now)
+	if err != nil {
+		errs = append(errs, err)
+	}
+
+	return lobbies, errors.Join(errs...)
+}
+
+// poolEconomy returns the terms of the economy config of the pool mode effective now (nil: free lobbies).
+func (usecase *RunMatchmakingUseCase) poolEconomy(ctx context.Context, pool matchmaking_entities.MatchmakingPool, now time.Time) (*matchmaking_entities.LobbyEconomy, error) {
+	if usecase.EconomyReader == nil {
+		return nil, nil
+	}
+
+	config, err := latestEconomyConfig(ctx, usecase.EconomyReader, pool.GameID, pool.Mode, &now)
+	if err != nil || config == nil {
+		return nil, err
+	}
+
+	return config.LobbyEconomy(), nil
+}
+
+func (usecase *RunMatchmakingUseCase) createLobby(ctx context.Context, pool matchmaking_entities.MatchmakingPool, economy *matchmaking_entities.LobbyEconomy, proposal matchmaking_strategies.MatchProposal, now time.Time) error {
+	leader := proposal.Tickets()[0]
+	for _, t := range proposal.Tickets() {
+		if t.EnqueuedAt.Before(leader.EnqueuedAt) {
+			leader = t
+		}
+	}
+
+	poolID := pool.ID
+
+	lobby := &matchmaking_entities.Lobby{
+		ID:            uuid.New(),
+		PoolID:        &poolID,
+		GameID:        pool.GameID,
+		RegionID:      pool.RegionID,
+		LeaderUserID:  leader.UserID,
+		Players:       make([]matchmaking_entities.LobbyPlayer, 0, pool.MatchSize()),
+		Mode:          pool.Mode,
+		Status:        matchmaking_entities.LobbyStatusReady,
+		Economy:       economy,
+		ResourceOwner: pool.ResourceOwner,
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	}
+
+	// captain draft lobbies are only grouped by the matcher, teams are drafted by the captains
+	if pool.Mode == matchmaking_entities.LobbyModeCaptainDraft {
+		lobby.Status = matchmaking_entities.LobbyStatusForming
+	}
+
+	// players have to accept the match first (see RespondReadyCheckUseCase)
+	if pool.ReadyCheckTimeoutSeconds > 0 {
+		lobby.StartReadyCheck(now, pool.ReadyCheckTimeout())
+	}
+
+	for i, team := range proposal.Teams {
+		for _, t := range team {
+			player := matchmaking_entities.LobbyPlayer{
+				PlayerID:      t.PlayerID,
+				UserID:        t.UserID,
+				QueueTicketID: t.ID,
+				Rating:        t.Rating,
+				Role:          proposal.Roles[t.ID],
+				JoinedAt:      now,
+
+				PrizeEligible: true,
+			}
+
+			if pool.Mode == matchmaking_entities.LobbyModeAutoBalance {
+				player.Team = matchmaking_entities.LobbyTeam(i + 1)
+			}
+
+			lobby.Players = append(lobby.Players, player)
+		}
+	}
+
+	_, err := usecase.LobbyWriter.Create(ctx, lobby)
+	if err != nil {
+		slog.ErrorContext(ctx, "unable to create matchmaking lobby", "poolID", pool.ID, "err", err)
+		return err
+	}
+
+	var errs []error
+
+	for _, t := range proposal.Tickets() {
+		ticket := t
+		ticket.Status = matchmaking_entities.QueueTicketStatusMatched
+		ticket.LobbyID = &lobby.ID
+		ticket.MatchedAt = &now
+		ticket.UpdatedAt = now
+
+		_, err := usecase.TicketWriter.Update(ctx, &ticket)
+		if err != nil {
+			slog.ErrorContext(ctx, "unable to mark queue ticket as matched", "ticketID", ticket.ID, "lobbyID", lobby.ID, "err", err)
+			errs = append(errs, err)
+		}
+	}
+
+	// the lobby is already formed: a failed publish is logged, not retried (the lobby can still be found by polling)
+	if usecase.EventPublisher != nil {
+		err = usecase.EventPublisher.PublishLobbyCreated(ctx, matchmaking_entities.NewLobbyCreated(*lobby))
+		if err != nil {
+			slog.WarnContext(ctx, "unable to publish lobby created event", "lobbyID", lobby.ID, "poolID", pool.ID, "err", err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// waitingTickets returns the oldest MatchmakingTicketWindow waiting tickets of the pool.
+func waitingTickets(ctx context.Context, reader matchmaking_out.QueueTicketReader, pool matchmaking_entities.MatchmakingPool) ([]matchmaking_entities.QueueTicket, error) {
+	tickets := make([]matchmaking_entities.QueueTicket, 0)
+
+	for skip := 0; skip < MatchmakingTicketWindow; skip += MatchmakingTicketPageSize {
+		search := common.NewSearchByValues(ctx, []common.SearchableValue{
+			{Field: "PoolID", Values: []interface{}{pool.ID}},
+			{Field: "Status", Values: []interface{}{matchmaking_entities.QueueTicketStatusWaiting}},
+		}, common.NewSearchResultOptions(uint(skip), MatchmakingTicketPageSize), common.ClientApplicationAudienceIDKey)
+
+		search.SortOptions = []common.SearchSortOption{{Field: "EnqueuedAt", Direction: common.AscendingIDKey}}
+
+		page, err := reader.Search(ctx, search)
+		if err != nil {
+			slog.ErrorContext(ctx, "unable to search waiting queue tickets", "poolID", pool.ID, "skip", skip, "err", err)
+			return nil, err
+		}
+
+		tickets = append(tickets, page...)
+
+		if len(page) < MatchmakingTicketPageSize {
+			break
+		}
+	}
+
+	return tickets, nil
+}
+
+// closeQueue cancels the waiting tickets of a pool outside its schedule windows; players queue again when the next window opens.
+func (usecase *RunMatchmakingUseCase) closeQueue(ctx context.Context, pool matchmaking_entities.MatchmakingPool, tickets []matchmaking_entities.QueueTicket) error {
+	now := time.Now().UTC()
+
+	var errs []error
+
+	for _, ticket := range tickets {
+		ticket.Status = matchmaking_entities.QueueTicketStatusCancelled
+		ticket.UpdatedAt = now
+
+		_, err := usecase.TicketWriter.Update(ctx, &ticket)
+		if err != nil {
+			slog.ErrorContext(ctx, "unable to cancel queue ticket of closed pool", "ticketID", ticket.ID, "poolID", pool.ID, "err", err)
+			errs = append(errs, err)
+		}
+	}
+
+	if len(tickets) > 0 {
+		slog.InfoContext(ctx, "matchmaking pool closed, waiting tickets cancelled", "poolID", pool.ID, "tickets", len(tickets))
+	}
+
+	return errors.Join(errs...)
+}
+
+// fillBackfills assigns to each open slot of the pool the closest rated waiting ticket that accepts backfill and can play the role,
+// returning the tickets left for regular matching.
+func (usecase *RunMatchmakingUseCase) fillBackfills(ctx context.Context, pool matchmaking_entities.MatchmakingPool, tickets []matchmaking_entities.QueueTicket) ([]matchmaking_entities.QueueTicket, error) {
+	lobbies, err := usecase.LobbyReader.Search(ctx, common.NewSearchByValues(ctx, []common.SearchableValue{
+		{Field: "PoolID", Values: []interface{}{pool.ID}},
+		{Field: "Backfills.Status", Values: []interface{}{matchmaking_entities.BackfillSlotStatusOpen}},
+	}, common.NewSearchResultOptions(0, BackfillLobbyBatchSize), common.ClientApplicationAudienceIDKey))
+
+	if err != nil {
+		slog.ErrorContext(ctx, "unable to search lobbies with open backfill slots", "poolID", pool.ID, "err", err)
+		return tickets, err
+	}
+
+	now := time.Now().UTC()
+
+	// tickets matched into a slot (or tentatively, until the lobby is saved)
+	taken := make(map[uuid.UUID]bool)
+
+	var errs []error
+
+	for i := range lobbies {
+		lobby := &lobbies[i]
+
+		changed := lobby.ExpireBackfills(now) > 0
+		filled := make([]matchmaking_entities.QueueTicket, 0)
+
+		for _, slot := range lobby.OpenBackfills() {
+			candidate := backfillCandidate(pool, *lobby, slot, tickets, taken, now)
+			if candidate < 0 {
+				continue
+			}
+
+			ticket := tickets[candidate]
+			taken[ticket.ID] = true
+
+			lobby.FillBackfill(slot.ID, matchmaking_entities.LobbyPlayer{
+				PlayerID:      ticket.PlayerID,
+				UserID:        ticket.UserID,
+				QueueTicketID: ticket.ID,
+				Rating:        ticket.Rating,
+			}, now)
+
+			slotID := slot.ID
+			ticket.BackfillSlotID = &slotID
+			filled = append(filled, ticket)
+			changed = true
+		}
+
+		if !changed {
+			continue
+		}
+
+		_, err := usecase.LobbyWriter.Update(ctx, lobby)
+		if err != nil {
+			slog.ErrorContext(ctx, "unable to save lobby backfills", "lobbyID", lobby.ID, "err", err)
+			errs = append(errs, err)
+
+			// the joiners stay in the queue
+			for _, ticket := range filled {
+				delete(taken, ticket.ID)
+			}
+
+			continue
+		}
+
+		for _, ticket := range filled {
+			ticket.Status = matchmaking_entities.QueueTicketStatusMatched
+			ticket.LobbyID = &lobby.ID
+			ticket.MatchedAt = &now
+			ticket.UpdatedAt = now
+
+			_, err := usecase.TicketWriter.Update(ctx, &ticket)
+			if err != nil {
+				slog.ErrorContext(ctx, "unable to mark backfill ticket as matched", "ticketID", ticket.ID, "lobbyID", lobby.ID, "err", err)
+				errs = append(errs, err)
+				continue
+			}
+
+			slog.InfoContext(ctx, "backfill slot filled", "lobbyID", lobby.ID, "slotID", *ticket.BackfillSlotID, "ticketID", ticket.ID)
+		}
+	}
+
+	remaining := make([]matchmaking_entities.QueueTicket, 0, len(tickets))
+	for _, t := range tickets {
+		if !taken[t.ID] {
+			remaining = append(remaining, t)
+		}
+	}
+
+	return remaining, errors.Join(errs...)
+}
+
+// backfillCandidate returns the index of the ticket to fill the slot with, or -1 when none qualifies.
+func backfillCandidate(pool matchmaking_entities.MatchmakingPool, lobby matchmaking_entities.Lobby, slot matchmaking_entities.BackfillSlot, tickets []matchmaking_entities.QueueTicket, taken map[uuid.UUID]bool, now time.Time) int {
+	best := -1
+	bestDiff := 0
+
+	for i, t := range tickets {
+		if !t.AcceptBackfill || taken[t.ID] || lobby.HasUser(t.UserID) {
+			continue
+		}
+
+		if slot.Role != "" && !t.CanPlay(slot.Role) {
+			continue
+		}
+
+		diff := t.Rating - slot.Rating
+		if diff < 0 {
+			diff = -diff
+		}
+
+		if diff > pool.RatingSpreadAt(t, now) {
+			continue
+		}
+
+		// tickets are sorted by wait: on ties the oldest wins
+		if best < 0 || diff < bestDiff {
+			best, bestDiff = i, diff
+		}
+	}
+
+	return best
+}
+
+func (usecase *RunMatchmakingUseCase) saveEvaluations(ctx context.Context, pool matchmaking_entities.MatchmakingPool, evaluations []matchmaking_entities.StrategyEvaluation, now time.Time) error {
+	runID := uuid.New()
+
+	records := make([]*matchmaking_entities.StrategyEvaluation, 0, len(evaluations))
+	for i := range evaluations {
+		e := &evaluations[i]
+		e.ID = uuid.New()
+		e.RunID = runID
+		e.ResourceOwner = pool.ResourceOwner
+		e.CreatedAt = now
+		e.UpdatedAt = now
+
+		records = append(records, e)
+	}
+
+	err := usecase.EvaluationWriter.CreateMany(ctx, records)
+	if err != nil {
+		slog.ErrorContext(ctx, "unable to save strategy evaluations", "poolID", pool.ID, "runID", runID, "err", err)
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,64 @@
+package matchmaking_use_cases
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	"github.com/psavelis/team-pro/replay-api/pkg/domain/matchmaking"
+	matchmaking_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/matchmaking/entities"
+	matchmaking_in "github.com/psavelis/team-pro/replay-api/pkg/domain/matchmaking/ports/in"
+	matchmaking_out "github.com/psavelis/team-pro/replay-api/pkg/domain/matchmaking/ports/out"
+)
+
+type StartCaptainDraftUseCase struct {
+	LobbyReader matchmaking_out.LobbyReader
+	LobbyWriter matchmaking_out.LobbyWriter
+	PickTimeout time.Duration
+}
+
+func NewStartCaptainDraftUseCase(lobbyReader matchmaking_out.LobbyReader, lobbyWriter matchmaking_out.LobbyWriter) matchmaking_in.StartCaptainDraftCommand {
+	return &StartCaptainDraftUseCase{
+		LobbyReader: lobbyReader,
+		LobbyWriter: lobbyWriter,
+		PickTimeout: matchmaking_entities.DefaultDraftPickTimeout,
+	}
+}
+
+func (usecase *StartCaptainDraftUseCase) Exec(ctx context.Context, lobbyID uuid.UUID) (*matchmaking_entities.Lobby, error) {
+	lobby, err := getTenantLobby(ctx, usecase.LobbyReader, lobbyID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !lobby.IsLeader(common.GetResourceOwner(ctx).UserID) {
+		return nil, matchmaking.NewLobbyForbiddenError("only the lobby leader can start the draft")
+	}
+
+	if lobby.Mode != matchmaking_entities.LobbyModeCaptainDraft {
+		return nil, matchmaking.NewLobbyStateError(fmt.Sprintf("lobby mode '%s' doesn't support captain draft", lobby.Mode))
+	}
+
+	if lobby.Status != matchmaking_entities.LobbyStatusForming {
+		return nil, matchmaking.NewLobbyStateError(fmt.Sprintf("draft can't be started on a lobby in status '%s'", lobby.Status))
+	}
+
+	if len(lobby.Players) < matchmaking_entities.MinDraftPlayers || len(lobby.Players)%2 != 0 {
+		return nil, matchmaking.NewLobbyStateError(fmt.Sprintf("draft requires an even number of players (at least %d), lobby has %d", matchmaking_entities.MinDraftPlayers, len(lobby.Players)))
+	}
+
+	lobby.StartDraft(time.Now().UTC(), usecase.PickTimeout)
+
+	lobby, err = usecase.LobbyWriter.Update(ctx, lobby)
+	if err != nil {
+		slog.ErrorContext(ctx, "unable to start lobby draft", "lobbyID", lobbyID, "err", err)
+		return nil, err
+	}
+
+	slog.InfoContext(ctx, "lobby draft started", "lobbyID", lobbyID, "captains", lobby.Draft.Captains)
+
+	return lobby, nil
+}
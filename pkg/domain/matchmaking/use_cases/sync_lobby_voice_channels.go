@@ -0,0 +1,136 @@
+package matchmaking_use_cases
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	matchmaking_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/matchmaking/entities"
+	matchmaking_in "github.com/psavelis/team-pro/replay-api/pkg/domain/matchmaking/ports/in"
+	matchmaking_out "github.com/psavelis/team-pro/replay-api/pkg/domain/matchmaking/ports/out"
+)
+
+// max lobbies handled per sync (per direction); the remainder is picked up by the next run.
+const VoiceSyncBatchSize = 100
+
+type SyncLobbyVoiceChannelsUseCase struct {
+	LobbyReader   matchmaking_out.LobbyReader
+	LobbyWriter   matchmaking_out.LobbyWriter
+	VoiceProvider matchmaking_out.VoiceChannelProvider
+}
+
+func NewSyncLobbyVoiceChannelsUseCase(lobbyReader matchmaking_out.LobbyReader, lobbyWriter matchmaking_out.LobbyWriter, voiceProvider matchmaking_out.VoiceChannelProvider) matchmaking_in.SyncLobbyVoiceChannelsCommand {
+	return &SyncLobbyVoiceChannelsUseCase{
+		LobbyReader:   lobbyReader,
+		LobbyWriter:   lobbyWriter,
+		VoiceProvider: voiceProvider,
+	}
+}
+
+func (usecase *SyncLobbyVoiceChannelsUseCase) Exec(ctx context.Context) (int, int, error) {
+	var errs []error
+
+	ready, err := usecase.LobbyReader.Search(ctx, common.NewSearchByValues(ctx, []common.SearchableValue{
+		{Field: "Status", Values: []interface{}{matchmaking_entities.LobbyStatusReady}},
+		{Field: "Voice", Operator: common.EqualsOperator, Values: []interface{}{nil}},
+	}, common.NewSearchResultOptions(0, VoiceSyncBatchSize), common.ClientApplicationAudienceIDKey))
+
+	if err != nil {
+		slog.ErrorContext(ctx, "unable to search ready lobbies without voice channel", "err", err)
+		return 0, 0, err
+	}
+
+	provisioned := 0
+	for i := range ready {
+		err := usecase.provision(ctx, &ready[i])
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+
+		provisioned++
+	}
+
+	finished, err := usecase.LobbyReader.Search(ctx, common.NewSearchByValues(ctx, []common.SearchableValue{
+		{Field: "Status", Operator: common.InOperator, Values: []interface{}{matchmaking_entities.LobbyStatusCompleted, matchmaking_entities.LobbyStatusCancelled}},
+		{Field: "Voice", Operator: common.NotEqualsOperator, Values: []interface{}{nil}},
+	}, common.NewSearchResultOptions(0, VoiceSyncBatchSize), common.ClientApplicationAudienceIDKey))
+
+	if err != nil {
+		slog.ErrorContext(ctx, "unable to search finished lobbies with voice channel", "err", err)
+		return provisioned, 0, errors.Join(append(errs, err)...)
+	}
+
+	tornDown := 0
+	for i := range finished {
+		err := usecase.tearDown(ctx, &finished[i])
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+
+		tornDown++
+	}
+
+	return provisioned, tornDown, errors.Join(errs...)
+}
+
+func (usecase *SyncLobbyVoiceChannelsUseCase) provision(ctx context.Context, lobby *matchmaking_entities.Lobby) error {
+	channelID, err := usecase.VoiceProvider.CreateChannel(ctx, *lobby)
+	if err != nil {
+		slog.ErrorContext(ctx, "unable to create lobby voice channel", "lobbyID", lobby.ID, "provider", usecase.VoiceProvider.Name(), "err", err)
+		return err
+	}
+
+	now := time.Now().UTC()
+
+	lobby.Voice = &matchmaking_entities.LobbyVoiceChannel{
+		Provider:      usecase.VoiceProvider.Name(),
+		ChannelID:     channelID,
+		MutedUserIDs:  []uuid.UUID{},
+		KickedUserIDs: []uuid.UUID{},
+		CreatedAt:     now,
+	}
+	lobby.UpdatedAt = now
+
+	_, err = usecase.LobbyWriter.Update(ctx, lobby)
+	if err != nil {
+		slog.ErrorContext(ctx, "unable to save lobby voice channel, deleting it", "lobbyID", lobby.ID, "channelID", channelID, "err", err)
+
+		// otherwise the channel would be orphaned (the lobby is still seen without voice on the next sync)
+		if deleteErr := usecase.VoiceProvider.DeleteChannel(ctx, channelID); deleteErr != nil {
+			slog.ErrorContext(ctx, "unable to delete orphan lobby voice channel", "lobbyID", lobby.ID, "channelID", channelID, "err", deleteErr)
+		}
+
+		return err
+	}
+
+	slog.InfoContext(ctx, "lobby voice channel provisioned", "lobbyID", lobby.ID, "channelID", channelID)
+
+	return nil
+}
+
+func (usecase *SyncLobbyVoiceChannelsUseCase) tearDown(ctx context.Context, lobby *matchmaking_entities.Lobby) error {
+	err := usecase.VoiceProvider.DeleteChannel(ctx, lobby.Voice.ChannelID)
+	if err != nil {
+		slog.ErrorContext(ctx, "unable to delete lobby voice channel", "lobbyID", lobby.ID, "channelID", lobby.Voice.ChannelID, "err", err)
+		return err
+	}
+
+	lobby.Voice = nil
+	lobby.UpdatedAt = time.Now().UTC()
+
+	_, err = usecase.LobbyWriter.Update(ctx, lobby)
+	if err != nil {
+		slog.ErrorContext(ctx, "unable to clear lobby voice channel", "lobbyID", lobby.ID, "err", err)
+		return err
+	}
+
+	slog.InfoContext(ctx, "lobby voice channel torn down", "lobbyID", lobby.ID)
+
+	return nil
+}
@@ -0,0 +1,52 @@
+package matchmaking_use_cases
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	matchmaking_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/matchmaking/entities"
+	matchmaking_in "github.com/psavelis/team-pro/replay-api/pkg/domain/matchmaking/ports/in"
+	matchmaking_out "github.com/psavelis/team-pro/replay-api/pkg/domain/matchmaking/ports/out"
+)
+
+type UpdatePoolScheduleUseCase struct {
+	PoolReader matchmaking_out.MatchmakingPoolReader
+	PoolWriter matchmaking_out.MatchmakingPoolWriter
+}
+
+func NewUpdatePoolScheduleUseCase(poolReader matchmaking_out.MatchmakingPoolReader, poolWriter matchmaking_out.MatchmakingPoolWriter) matchmaking_in.UpdatePoolScheduleCommand {
+	return &UpdatePoolScheduleUseCase{
+		PoolReader: poolReader,
+		PoolWriter: poolWriter,
+	}
+}
+
+func (usecase *UpdatePoolScheduleUseCase) Exec(ctx context.Context, poolID uuid.UUID, schedule *matchmaking_entities.PoolSchedule) (*matchmaking_entities.MatchmakingPool, error) {
+	pool, err := getTenantPool(ctx, usecase.PoolReader, poolID)
+	if err != nil {
+		return nil, err
+	}
+
+	pool.Schedule = schedule
+
+	err = validatePool(*pool)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	pool.UpdatedAt = now
+
+	pool, err = usecase.PoolWriter.Update(ctx, pool)
+	if err != nil {
+		slog.ErrorContext(ctx, "unable to update matchmaking pool schedule", "poolID", poolID, "err", err)
+		return nil, err
+	}
+
+	availability := pool.AvailabilityAt(now)
+	pool.Availability = &availability
+
+	return pool, nil
+}
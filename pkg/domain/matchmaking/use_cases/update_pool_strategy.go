@@ -0,0 +1,55 @@
+package matchmaking_use_cases
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	matchmaking_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/matchmaking/entities"
+	matchmaking_in "github.com/psavelis/team-pro/replay-api/pkg/domain/matchmaking/ports/in"
+	matchmaking_out "github.com/psavelis/team-pro/replay-api/pkg/domain/matchmaking/ports/out"
+)
+
+type UpdatePoolStrategyUseCase struct {
+	PoolReader matchmaking_out.MatchmakingPoolReader
+	PoolWriter matchmaking_out.MatchmakingPoolWriter
+}
+
+func NewUpdatePoolStrategyUseCase(poolReader matchmaking_out.MatchmakingPoolReader, poolWriter matchmaking_out.MatchmakingPoolWriter) matchmaking_in.UpdatePoolStrategyCommand {
+	return &UpdatePoolStrategyUseCase{
+		PoolReader: poolReader,
+		PoolWriter: poolWriter,
+	}
+}
+
+func (usecase *UpdatePoolStrategyUseCase) Exec(ctx context.Context, poolID uuid.UUID, strategy string, shadowStrategies []string) (*matchmaking_entities.MatchmakingPool, error) {
+	pool, err := getTenantPool(ctx, usecase.PoolReader, poolID)
+	if err != nil {
+		return nil, err
+	}
+
+	if shadowStrategies == nil {
+		shadowStrategies = []string{}
+	}
+
+	pool.Strategy = strategy
+	pool.ShadowStrategies = shadowStrategies
+
+	err = validatePool(*pool)
+	if err != nil {
+		return nil, err
+	}
+
+	pool.UpdatedAt = time.Now().UTC()
+
+	pool, err = usecase.PoolWriter.Update(ctx, pool)
+	if err != nil {
+		slog.ErrorContext(ctx, "unable to update matchmaking pool strategy", "poolID", poolID, "err", err)
+		return nil, err
+	}
+
+	slog.InfoContext(ctx, "matchmaking pool strategy updated", "poolID", poolID, "strategy", strategy, "shadow", shadowStrategies)
+
+	return pool, nil
+}
@@ -6,6 +6,7 @@ const (
 	SteamNetworkIDKey     NetworkIDKey = "steam"
 	FaceItNetworkIDKey    NetworkIDKey = "faceit"
 	BattleNetNetworkIDKey NetworkIDKey = "battlenet"
+	RiotNetworkIDKey      NetworkIDKey = "riot" // the network user id is the PUUID of the Riot account
 )
 
 type Network struct {
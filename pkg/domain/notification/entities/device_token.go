@@ -0,0 +1,93 @@
+package notification_entities
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+)
+
+type DevicePlatform string
+
+const (
+	DevicePlatformAndroid DevicePlatform = "android"
+	DevicePlatformIOS     DevicePlatform = "ios"
+	DevicePlatformWeb     DevicePlatform = "web" // browsers, through the web push of FCM
+)
+
+type PushProvider string
+
+const (
+	PushProviderFCM  PushProvider = "fcm"
+	PushProviderAPNs PushProvider = "apns"
+)
+
+// Provider is the push service the devices of the platform are reached through.
+func (p DevicePlatform) Provider() PushProvider {
+	if p == DevicePlatformIOS {
+		return PushProviderAPNs
+	}
+
+	return PushProviderFCM
+}
+
+// MaxDeviceTokenLength bounds the tokens accepted on registration (FCM tokens are ~160 chars, APNs tokens 64 hex chars).
+const MaxDeviceTokenLength = 4096
+
+// deviceTokenNamespace keeps DeviceToken IDs stable (tenant+platform+token): a device registered again (ie: another user logged in the
+// app) is moved to the new user instead of being notified twice.
+var deviceTokenNamespace = uuid.MustParse("6a0c3e1f-7b52-4d8e-9f14-2c8b5e7a3d91")
+
+// DeviceToken is the push token of an app install, registered by the user logged in it.
+type DeviceToken struct {
+	ID            uuid.UUID            `json:"id" bson:"_id"`
+	UserID        uuid.UUID            `json:"user_id" bson:"user_id"`
+	Platform      DevicePlatform       `json:"platform" bson:"platform"`
+	Token         string               `json:"token" bson:"token"`
+	DisabledAt    *time.Time           `json:"disabled_at,omitempty" bson:"disabled_at"` // unregistered, or rejected by the push service
+	ResourceOwner common.ResourceOwner `json:"resource_owner" bson:"resource_owner"`
+	CreatedAt     time.Time            `json:"created_at" bson:"created_at"`
+	UpdatedAt     time.Time            `json:"updated_at" bson:"updated_at"`
+}
+
+func (t DeviceToken) GetID() uuid.UUID {
+	return t.ID
+}
+
+func (t DeviceToken) IsActive() bool {
+	return t.DisabledAt == nil
+}
+
+// Disable stops the notifications to the device.
+func (t *DeviceToken) Disable(now time.Time) {
+	t.DisabledAt = &now
+	t.UpdatedAt = now
+}
+
+// NewDeviceToken validates the token of the platform, registered by the user of the resource owner.
+func NewDeviceToken(platform DevicePlatform, token string, resourceOwner common.ResourceOwner, now time.Time) (*DeviceToken, error) {
+	if platform != DevicePlatformAndroid && platform != DevicePlatformIOS && platform != DevicePlatformWeb {
+		return nil, fmt.Errorf("platform must be one of: %s, %s, %s", DevicePlatformAndroid, DevicePlatformIOS, DevicePlatformWeb)
+	}
+
+	token = strings.TrimSpace(token)
+	if token == "" || len(token) > MaxDeviceTokenLength || strings.ContainsAny(token, " \t\r\n/") {
+		return nil, fmt.Errorf("token is invalid")
+	}
+
+	return &DeviceToken{
+		ID:            DeviceTokenID(resourceOwner.TenantID, platform, token),
+		UserID:        resourceOwner.UserID,
+		Platform:      platform,
+		Token:         token,
+		ResourceOwner: resourceOwner,
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	}, nil
+}
+
+func DeviceTokenID(tenantID uuid.UUID, platform DevicePlatform, token string) uuid.UUID {
+	return uuid.NewSHA1(deviceTokenNamespace, []byte(fmt.Sprintf("%s|%s|%s", tenantID, platform, token)))
+}
@@ -0,0 +1,144 @@
+package notification_entities
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+)
+
+type NotificationType string
+
+const (
+	NotificationSquadInvite       NotificationType = "squad_invite"
+	NotificationSquadApplication  NotificationType = "squad_application"
+	NotificationSquadJoinAccepted NotificationType = "squad_join_accepted"
+	NotificationSquadJoinDeclined NotificationType = "squad_join_declined"
+)
+
+type NotificationChannel string
+
+const (
+	NotificationChannelInApp   NotificationChannel = "in_app" // the inbox of the user, always delivered
+	NotificationChannelPush    NotificationChannel = "push"   // the devices of the user (app installs and browsers)
+	NotificationChannelEmail   NotificationChannel = "email"
+	NotificationChannelDiscord NotificationChannel = "discord" // a webhook of a channel of the user (ie: their squad's server)
+)
+
+var NotificationChannels = []NotificationChannel{NotificationChannelInApp, NotificationChannelPush, NotificationChannelEmail, NotificationChannelDiscord}
+
+type NotificationDeliveryStatus string
+
+const (
+	NotificationDeliverySent    NotificationDeliveryStatus = "sent"
+	NotificationDeliveryFailed  NotificationDeliveryStatus = "failed"
+	NotificationDeliverySkipped NotificationDeliveryStatus = "skipped" // nowhere to send it (ie: no devices, or no address set)
+)
+
+// NotificationDelivery is the outcome of a notification on one of the channels of the user.
+type NotificationDelivery struct {
+	Channel NotificationChannel        `json:"channel" bson:"channel"`
+	Status  NotificationDeliveryStatus `json:"status" bson:"status"`
+	Error   string                     `json:"error,omitempty" bson:"error"`
+	At      time.Time                  `json:"at" bson:"at"`
+}
+
+// notificationNamespace keeps Notification IDs stable (tenant+user+type+reference): an event delivered again doesn't notify twice.
+var notificationNamespace = uuid.MustParse("3f6d2b8e-91c4-4a7f-b0e5-5d1c7a9e2f48")
+
+// Notification is an entry of the inbox of a user, rendered from a template of its type and sent to the channels the user chose.
+type Notification struct {
+	ID            uuid.UUID              `json:"id" bson:"_id"`
+	UserID        uuid.UUID              `json:"user_id" bson:"user_id"`
+	Type          NotificationType       `json:"type" bson:"type"`
+	Title         string                 `json:"title" bson:"title"`
+	Body          string                 `json:"body" bson:"body"`
+	Data          map[string]string      `json:"data" bson:"data"`
+	Reference     string                 `json:"reference" bson:"reference"` // what the notification is about (ie: the join request)
+	Deliveries    []NotificationDelivery `json:"deliveries" bson:"deliveries"`
+	ReadAt        *time.Time             `json:"read_at,omitempty" bson:"read_at"`
+	ResourceOwner common.ResourceOwner   `json:"resource_owner" bson:"resource_owner"` // the user notified
+	CreatedAt     time.Time              `json:"created_at" bson:"created_at"`
+	UpdatedAt     time.Time              `json:"updated_at" bson:"updated_at"`
+}
+
+func (n Notification) GetID() uuid.UUID {
+	return n.ID
+}
+
+func (n Notification) IsRead() bool {
+	return n.ReadAt != nil
+}
+
+func (n *Notification) MarkRead(now time.Time) {
+	n.ReadAt = &now
+	n.UpdatedAt = now
+}
+
+// Delivered records the outcome of a channel.
+func (n *Notification) Delivered(channel NotificationChannel, status NotificationDeliveryStatus, err error, now time.Time) {
+	delivery := NotificationDelivery{Channel: channel, Status: status, At: now}
+	if err != nil {
+		delivery.Error = err.Error()
+	}
+
+	n.Deliveries = append(n.Deliveries, delivery)
+	n.UpdatedAt = now
+}
+
+// Push is the alert of the notification, replacing on the device the alert of a previous step of the same reference (ie: an invite
+// once accepted).
+func (n Notification) Push(ttl time.Duration) PushNotification {
+	data := map[string]string{
+		"type":            string(n.Type),
+		"notification_id": n.ID.String(),
+	}
+
+	for k, v := range n.Data {
+		data[k] = v
+	}
+
+	return PushNotification{
+		Type:        PushNotificationType(n.Type),
+		Title:       n.Title,
+		Body:        n.Body,
+		Data:        data,
+		CollapseKey: n.Reference,
+		ExpiresAt:   n.CreatedAt.Add(ttl),
+	}
+}
+
+// NotificationEvent is a domain event the users are notified about, the data of the event fills the template of its type.
+type NotificationEvent struct {
+	Type          NotificationType     `json:"type"`
+	UserIDs       []uuid.UUID          `json:"user_ids"`
+	Data          map[string]string    `json:"data"`
+	Reference     string               `json:"reference"`
+	ResourceOwner common.ResourceOwner `json:"resource_owner"`
+	CreatedAt     time.Time            `json:"created_at"`
+}
+
+// NewNotification is the inbox entry of a user of the event (the resource owner is the user, on the client application of the event).
+func NewNotification(event NotificationEvent, userID uuid.UUID, title string, body string, now time.Time) *Notification {
+	return &Notification{
+		ID:        NotificationID(event.ResourceOwner.TenantID, userID, event.Type, event.Reference),
+		UserID:    userID,
+		Type:      event.Type,
+		Title:     title,
+		Body:      body,
+		Data:      event.Data,
+		Reference: event.Reference,
+		ResourceOwner: common.ResourceOwner{
+			TenantID: event.ResourceOwner.TenantID,
+			ClientID: event.ResourceOwner.ClientID,
+			UserID:   userID,
+		},
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+}
+
+func NotificationID(tenantID uuid.UUID, userID uuid.UUID, t NotificationType, reference string) uuid.UUID {
+	return uuid.NewSHA1(notificationNamespace, []byte(fmt.Sprintf("%s|%s|%s|%s", tenantID, userID, t, reference)))
+}
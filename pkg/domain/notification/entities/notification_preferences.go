@@ -0,0 +1,134 @@
+package notification_entities
+
+import (
+	"fmt"
+	"net/mail"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+)
+
+// DiscordWebhookHosts are the hosts of the webhooks accepted: notifications are only posted to Discord.
+var DiscordWebhookHosts = []string{"discord.com", "discordapp.com", "ptb.discord.com", "canary.discord.com"}
+
+// notificationPreferencesNamespace keeps a single NotificationPreferences per user (tenant+user).
+var notificationPreferencesNamespace = uuid.MustParse("b7e41c09-2d6a-4f35-8c8e-0a9d3f5b6e12")
+
+// NotificationPreferences are the channels a user is notified through, for each type of notification, and where to. Types not set are
+// sent to the channels of their template. The inbox (in app) is always notified.
+type NotificationPreferences struct {
+	ID                uuid.UUID                                  `json:"id" bson:"_id"`
+	UserID            uuid.UUID                                  `json:"user_id" bson:"user_id"`
+	Channels          map[NotificationType][]NotificationChannel `json:"channels" bson:"channels"`
+	Email             string                                     `json:"email,omitempty" bson:"email"`
+	DiscordWebhookURL string                                     `json:"discord_webhook_url,omitempty" bson:"discord_webhook_url"`
+	ResourceOwner     common.ResourceOwner                       `json:"resource_owner" bson:"resource_owner"`
+	CreatedAt         time.Time                                  `json:"created_at" bson:"created_at"`
+	UpdatedAt         time.Time                                  `json:"updated_at" bson:"updated_at"`
+}
+
+func (p NotificationPreferences) GetID() uuid.UUID {
+	return p.ID
+}
+
+// NewNotificationPreferences are the template channels of each type, for the user of the resource owner.
+func NewNotificationPreferences(resourceOwner common.ResourceOwner, now time.Time) *NotificationPreferences {
+	return &NotificationPreferences{
+		ID:            NotificationPreferencesID(resourceOwner.TenantID, resourceOwner.UserID),
+		UserID:        resourceOwner.UserID,
+		Channels:      make(map[NotificationType][]NotificationChannel),
+		ResourceOwner: resourceOwner,
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	}
+}
+
+func NotificationPreferencesID(tenantID uuid.UUID, userID uuid.UUID) uuid.UUID {
+	return uuid.NewSHA1(notificationPreferencesNamespace, []byte(fmt.Sprintf("%s|%s", tenantID, userID)))
+}
+
+// ChannelsFor returns the channels of a type, the inbox first.
+func (p NotificationPreferences) ChannelsFor(t NotificationType) []NotificationChannel {
+	channels, ok := p.Channels[t]
+	if !ok {
+		channels = NotificationTemplates[t].Channels
+	}
+
+	res := []NotificationChannel{NotificationChannelInApp}
+	for _, channel := range channels {
+		if channel != NotificationChannelInApp {
+			res = append(res, channel)
+		}
+	}
+
+	return res
+}
+
+// Address is where the notifications of the channel are sent to, empty when not set (or for the channels without one).
+func (p NotificationPreferences) Address(channel NotificationChannel) string {
+	switch channel {
+	case NotificationChannelEmail:
+		return p.Email
+	case NotificationChannelDiscord:
+		return p.DiscordWebhookURL
+	default:
+		return ""
+	}
+}
+
+// Validate checks the types and channels chosen, and where they are sent to.
+func (p NotificationPreferences) Validate() error {
+	for t, channels := range p.Channels {
+		if _, ok := NotificationTemplates[t]; !ok {
+			return fmt.Errorf("unknown notification type %s", t)
+		}
+
+		for _, channel := range channels {
+			if !isNotificationChannel(channel) {
+				return fmt.Errorf("unknown channel %s of %s", channel, t)
+			}
+		}
+	}
+
+	if p.Email != "" {
+		address, err := mail.ParseAddress(p.Email)
+		if err != nil || address.Address != p.Email {
+			return fmt.Errorf("email is invalid")
+		}
+	}
+
+	if p.DiscordWebhookURL != "" && !IsDiscordWebhookURL(p.DiscordWebhookURL) {
+		return fmt.Errorf("discord_webhook_url must be a Discord webhook (https://discord.com/api/webhooks/...)")
+	}
+
+	return nil
+}
+
+// IsDiscordWebhookURL accepts the https webhooks of the DiscordWebhookHosts.
+func IsDiscordWebhookURL(raw string) bool {
+	u, err := url.Parse(raw)
+	if err != nil || u.Scheme != "https" || u.User != nil || u.Port() != "" || !strings.HasPrefix(u.Path, "/api/webhooks/") {
+		return false
+	}
+
+	for _, host := range DiscordWebhookHosts {
+		if strings.EqualFold(u.Hostname(), host) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func isNotificationChannel(channel NotificationChannel) bool {
+	for _, c := range NotificationChannels {
+		if c == channel {
+			return true
+		}
+	}
+
+	return false
+}
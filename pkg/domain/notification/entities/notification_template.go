@@ -0,0 +1,80 @@
+package notification_entities
+
+import (
+	"fmt"
+	"strings"
+	"text/template"
+	"time"
+)
+
+// NotificationTemplate renders the notifications of a type from the data of their event (text/template, a key missing in the data
+// fails the rendering), and sets the channels of the users who haven't chosen theirs.
+type NotificationTemplate struct {
+	Title    string
+	Body     string
+	Channels []NotificationChannel
+	TTL      time.Duration // of the push alerts
+}
+
+// SquadNotificationTTL keeps the squad alerts until the request is likely answered (join requests don't expire).
+const SquadNotificationTTL = 3 * 24 * time.Hour
+
+// NotificationTemplates of the join requests: pending requests are notified to who answers them, answers to who asked (the squad member
+// who invited, or the applicant).
+var NotificationTemplates = map[NotificationType]NotificationTemplate{
+	NotificationSquadInvite: {
+		Title:    "Squad invite",
+		Body:     "You were invited to join {{.squad_name}}.",
+		Channels: []NotificationChannel{NotificationChannelInApp, NotificationChannelPush, NotificationChannelEmail},
+		TTL:      SquadNotificationTTL,
+	},
+	NotificationSquadApplication: {
+		Title:    "New squad application",
+		Body:     "{{.user_name}} applied to join {{.squad_name}}.",
+		Channels: []NotificationChannel{NotificationChannelInApp, NotificationChannelPush, NotificationChannelDiscord},
+		TTL:      SquadNotificationTTL,
+	},
+	NotificationSquadJoinAccepted: {
+		Title:    "{{if eq .kind \"invite\"}}Invite accepted{{else}}Application accepted{{end}}",
+		Body:     "{{if eq .kind \"invite\"}}{{.user_name}} joined {{.squad_name}}.{{else}}You joined {{.squad_name}}.{{end}}",
+		Channels: []NotificationChannel{NotificationChannelInApp, NotificationChannelPush},
+		TTL:      SquadNotificationTTL,
+	},
+	NotificationSquadJoinDeclined: {
+		Title:    "{{if eq .kind \"invite\"}}Invite declined{{else}}Application declined{{end}}",
+		Body:     "{{if eq .kind \"invite\"}}{{.user_name}} declined the invite to {{.squad_name}}.{{else}}Your application to {{.squad_name}} was declined.{{end}}",
+		Channels: []NotificationChannel{NotificationChannelInApp},
+		TTL:      SquadNotificationTTL,
+	},
+}
+
+// Render returns the title and body of the notification.
+func (t NotificationTemplate) Render(data map[string]string) (string, string, error) {
+	title, err := render(t.Title, data)
+	if err != nil {
+		return "", "", fmt.Errorf("invalid title: %w", err)
+	}
+
+	body, err := render(t.Body, data)
+	if err != nil {
+		return "", "", fmt.Errorf("invalid body: %w", err)
+	}
+
+	return title, body, nil
+}
+
+func render(text string, data map[string]string) (string, error) {
+	tmpl, err := template.New("notification").Option("missingkey=error").Parse(text)
+	if err != nil {
+		return "", err
+	}
+
+	var b strings.Builder
+
+	err = tmpl.Execute(&b, data)
+	if err != nil {
+		return "", err
+	}
+
+	return b.String(), nil
+}
@@ -0,0 +1,120 @@
+package notification_entities_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	notification_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/notification/entities"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNotificationTemplates(t *testing.T) {
+	data := map[string]string{"squad_name": "Alpha", "user_name": "s1mple", "kind": "invite"}
+
+	for notificationType, template := range notification_entities.NotificationTemplates {
+		title, body, err := template.Render(data)
+		assert.NoError(t, err, notificationType)
+		assert.NotEmpty(t, title, notificationType)
+		assert.NotEmpty(t, body, notificationType)
+		assert.NotZero(t, template.TTL, notificationType)
+	}
+
+	accepted := notification_entities.NotificationTemplates[notification_entities.NotificationSquadJoinAccepted]
+
+	_, body, _ := accepted.Render(data)
+	assert.Equal(t, "s1mple joined Alpha.", body)
+
+	data["kind"] = "application"
+	_, body, _ = accepted.Render(data)
+	assert.Equal(t, "You joined Alpha.", body)
+
+	// the data of the event is incomplete
+	_, _, err := accepted.Render(map[string]string{"kind": "invite"})
+	assert.Error(t, err)
+}
+
+func TestNotificationPush(t *testing.T) {
+	owner := common.ResourceOwner{TenantID: uuid.New(), ClientID: uuid.New()}
+	userID := uuid.New()
+	now := time.Date(2026, 10, 16, 20, 0, 0, 0, time.UTC)
+
+	event := notification_entities.NotificationEvent{
+		Type:          notification_entities.NotificationSquadInvite,
+		UserIDs:       []uuid.UUID{userID},
+		Data:          map[string]string{"squad_id": uuid.NewString()},
+		Reference:     uuid.NewString(),
+		ResourceOwner: owner,
+		CreatedAt:     now,
+	}
+
+	n := notification_entities.NewNotification(event, userID, "Squad invite", "You were invited to join Alpha.", now)
+
+	// stable, and owned by the user notified
+	assert.Equal(t, notification_entities.NotificationID(owner.TenantID, userID, event.Type, event.Reference), n.ID)
+	assert.Equal(t, userID, n.ResourceOwner.UserID)
+	assert.Equal(t, owner.ClientID, n.ResourceOwner.ClientID)
+
+	push := n.Push(time.Hour)
+	assert.Equal(t, event.Reference, push.CollapseKey)
+	assert.Equal(t, now.Add(time.Hour), push.ExpiresAt)
+	assert.Equal(t, n.ID.String(), push.Data["notification_id"])
+	assert.Equal(t, event.Data["squad_id"], push.Data["squad_id"])
+}
+
+func TestNotificationPreferences(t *testing.T) {
+	preferences := notification_entities.NewNotificationPreferences(common.ResourceOwner{TenantID: uuid.New(), UserID: uuid.New()}, time.Now())
+
+	// the template channels until set, the inbox is always notified
+	assert.Equal(t, []notification_entities.NotificationChannel{
+		notification_entities.NotificationChannelInApp,
+		notification_entities.NotificationChannelPush,
+		notification_entities.NotificationChannelEmail,
+	}, preferences.ChannelsFor(notification_entities.NotificationSquadInvite))
+
+	preferences.Channels[notification_entities.NotificationSquadInvite] = []notification_entities.NotificationChannel{notification_entities.NotificationChannelDiscord}
+	preferences.Channels[notification_entities.NotificationSquadApplication] = []notification_entities.NotificationChannel{}
+
+	assert.Equal(t, []notification_entities.NotificationChannel{
+		notification_entities.NotificationChannelInApp,
+		notification_entities.NotificationChannelDiscord,
+	}, preferences.ChannelsFor(notification_entities.NotificationSquadInvite))
+
+	assert.Equal(t, []notification_entities.NotificationChannel{notification_entities.NotificationChannelInApp}, preferences.ChannelsFor(notification_entities.NotificationSquadApplication))
+
+	preferences.Email = "player@example.com"
+	preferences.DiscordWebhookURL = "https://discord.com/api/webhooks/123/token"
+	assert.NoError(t, preferences.Validate())
+
+	invalid := []func(p *notification_entities.NotificationPreferences){
+		func(p *notification_entities.NotificationPreferences) { p.Email = "Player <player@example.com>" },
+		func(p *notification_entities.NotificationPreferences) { p.Email = "not an email" },
+		func(p *notification_entities.NotificationPreferences) {
+			p.DiscordWebhookURL = "http://discord.com/api/webhooks/123/token"
+		},
+		func(p *notification_entities.NotificationPreferences) {
+			p.DiscordWebhookURL = "https://discord.com.evil.io/api/webhooks/123/token"
+		},
+		func(p *notification_entities.NotificationPreferences) {
+			p.DiscordWebhookURL = "https://discord.com/channels/123"
+		},
+		func(p *notification_entities.NotificationPreferences) {
+			p.DiscordWebhookURL = "https://discord.com:8443/api/webhooks/123/token"
+		},
+		func(p *notification_entities.NotificationPreferences) {
+			p.Channels[notification_entities.NotificationSquadInvite] = []notification_entities.NotificationChannel{"sms"}
+		},
+		func(p *notification_entities.NotificationPreferences) {
+			p.Channels["prize_paid"] = []notification_entities.NotificationChannel{notification_entities.NotificationChannelEmail}
+		},
+	}
+
+	for i, invalidate := range invalid {
+		p := *preferences
+		p.Channels = map[notification_entities.NotificationType][]notification_entities.NotificationChannel{}
+		invalidate(&p)
+
+		assert.Error(t, p.Validate(), i)
+	}
+}
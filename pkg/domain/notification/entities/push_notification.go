@@ -0,0 +1,122 @@
+package notification_entities
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+)
+
+type PushNotificationType string
+
+const (
+	PushNotificationMatchFound  PushNotificationType = "match_found"
+	PushNotificationWeeklyRecap PushNotificationType = "weekly_recap"
+)
+
+const (
+	// MatchFoundCollapseKey collapses the match found alerts of a device: a newer match replaces the alert of a stale one.
+	MatchFoundCollapseKey = "match_found"
+	// DefaultMatchFoundTTL is the TTL of the match found alerts of lobbies without a ready check (the lobby doesn't wait on the players).
+	DefaultMatchFoundTTL = 30 * time.Second
+
+	// WeeklyRecapCollapseKey collapses the recap alerts of a device: the recap of a week replaces the alert of the previous one.
+	WeeklyRecapCollapseKey = "weekly_recap"
+	// WeeklyRecapTTL is the lifetime of the recap alerts (until the recap of the next week).
+	WeeklyRecapTTL = 7 * 24 * time.Hour
+)
+
+// PushNotification is an alert sent to the devices of a user. The push services drop it once expired, and a newer notification of
+// the same collapse key replaces it on the device.
+type PushNotification struct {
+	Type        PushNotificationType `json:"type"`
+	Title       string               `json:"title"`
+	Body        string               `json:"body"`
+	Data        map[string]string    `json:"data"`
+	CollapseKey string               `json:"collapse_key"`
+	ExpiresAt   time.Time            `json:"expires_at"`
+}
+
+// TTL is what's left of the notification lifetime (none once expired).
+func (n PushNotification) TTL(now time.Time) time.Duration {
+	ttl := n.ExpiresAt.Sub(now)
+	if ttl < 0 {
+		return 0
+	}
+
+	return ttl
+}
+
+// MatchFound tells the players of a lobby formed by the matcher that their match was found.
+type MatchFound struct {
+	LobbyID            uuid.UUID            `json:"lobby_id"`
+	GameID             common.GameIDKey     `json:"game_id"`
+	UserIDs            []uuid.UUID          `json:"user_ids"`
+	ReadyCheckDeadline *time.Time           `json:"ready_check_deadline,omitempty"`
+	ResourceOwner      common.ResourceOwner `json:"resource_owner"`
+	CreatedAt          time.Time            `json:"created_at"`
+}
+
+// NewMatchFoundNotification expires with the accept window of the lobby (the ready check deadline), or DefaultMatchFoundTTL after the
+// lobby was formed when it has no ready check.
+func NewMatchFoundNotification(matchFound MatchFound) PushNotification {
+	expiresAt := matchFound.CreatedAt.Add(DefaultMatchFoundTTL)
+	if matchFound.ReadyCheckDeadline != nil {
+		expiresAt = *matchFound.ReadyCheckDeadline
+	}
+
+	notification := PushNotification{
+		Type:  PushNotificationMatchFound,
+		Title: "Match found",
+		Body:  "Your match is ready.",
+		Data: map[string]string{
+			"type":     string(PushNotificationMatchFound),
+			"lobby_id": matchFound.LobbyID.String(),
+			"game_id":  string(matchFound.GameID),
+		},
+		CollapseKey: MatchFoundCollapseKey,
+		ExpiresAt:   expiresAt,
+	}
+
+	if matchFound.ReadyCheckDeadline != nil {
+		notification.Body = "Accept it before it expires."
+		notification.Data["ready_check_deadline"] = matchFound.ReadyCheckDeadline.UTC().Format(time.RFC3339)
+	}
+
+	return notification
+}
+
+// WeeklyRecapReady tells the users of a recap (the player, or the members of a squad) that their recap of a week is ready.
+type WeeklyRecapReady struct {
+	RecapID       uuid.UUID            `json:"recap_id"`
+	Subject       string               `json:"subject"` // player or squad
+	Name          string               `json:"name"`    // of the squad
+	WeekStart     time.Time            `json:"week_start"`
+	MatchesPlayed int                  `json:"matches_played"`
+	UserIDs       []uuid.UUID          `json:"user_ids"`
+	ResourceOwner common.ResourceOwner `json:"resource_owner"`
+	CreatedAt     time.Time            `json:"created_at"`
+}
+
+// NewWeeklyRecapNotification expires WeeklyRecapTTL after the recap was generated.
+func NewWeeklyRecapNotification(recap WeeklyRecapReady) PushNotification {
+	body := fmt.Sprintf("You played %d matches last week, see how it went.", recap.MatchesPlayed)
+	if recap.Subject == "squad" {
+		body = fmt.Sprintf("%s played %d matches last week, see how it went.", recap.Name, recap.MatchesPlayed)
+	}
+
+	return PushNotification{
+		Type:  PushNotificationWeeklyRecap,
+		Title: "Your weekly recap",
+		Body:  body,
+		Data: map[string]string{
+			"type":       string(PushNotificationWeeklyRecap),
+			"recap_id":   recap.RecapID.String(),
+			"subject":    recap.Subject,
+			"week_start": recap.WeekStart.UTC().Format(time.DateOnly),
+		},
+		CollapseKey: WeeklyRecapCollapseKey,
+		ExpiresAt:   recap.CreatedAt.Add(WeeklyRecapTTL),
+	}
+}
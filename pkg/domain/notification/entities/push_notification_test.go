@@ -0,0 +1,71 @@
+package notification_entities_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	notification_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/notification/entities"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewMatchFoundNotification(t *testing.T) {
+	createdAt := time.Date(2026, 10, 16, 20, 0, 0, 0, time.UTC)
+	deadline := createdAt.Add(20 * time.Second)
+
+	matchFound := notification_entities.MatchFound{
+		LobbyID:   uuid.New(),
+		GameID:    common.CS2_GAME_ID,
+		UserIDs:   []uuid.UUID{uuid.New()},
+		CreatedAt: createdAt,
+	}
+
+	push := notification_entities.NewMatchFoundNotification(matchFound)
+
+	assert.Equal(t, notification_entities.MatchFoundCollapseKey, push.CollapseKey)
+	assert.Equal(t, createdAt.Add(notification_entities.DefaultMatchFoundTTL), push.ExpiresAt)
+	assert.Equal(t, matchFound.LobbyID.String(), push.Data["lobby_id"])
+	assert.NotContains(t, push.Data, "ready_check_deadline")
+
+	// the alert expires with the accept window
+	matchFound.ReadyCheckDeadline = &deadline
+	push = notification_entities.NewMatchFoundNotification(matchFound)
+
+	assert.Equal(t, deadline, push.ExpiresAt)
+	assert.Equal(t, deadline.Format(time.RFC3339), push.Data["ready_check_deadline"])
+	assert.Equal(t, 15*time.Second, push.TTL(createdAt.Add(5*time.Second)))
+	assert.Equal(t, time.Duration(0), push.TTL(deadline.Add(time.Second)))
+}
+
+func TestNewDeviceToken(t *testing.T) {
+	owner := common.ResourceOwner{TenantID: uuid.New(), ClientID: uuid.New(), UserID: uuid.New()}
+	now := time.Now().UTC()
+
+	token, err := notification_entities.NewDeviceToken(notification_entities.DevicePlatformIOS, " 740f4707bebcf74f9b7c25d48e3358945f6aa01da5ddb387462c7eaf61bb78ad ", owner, now)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	assert.Equal(t, "740f4707bebcf74f9b7c25d48e3358945f6aa01da5ddb387462c7eaf61bb78ad", token.Token)
+	assert.Equal(t, owner.UserID, token.UserID)
+	assert.Equal(t, notification_entities.PushProviderAPNs, token.Platform.Provider())
+	assert.True(t, token.IsActive())
+
+	// the same install registered by another user keeps its ID
+	other := owner
+	other.UserID = uuid.New()
+
+	again, err := notification_entities.NewDeviceToken(notification_entities.DevicePlatformIOS, token.Token, other, now)
+	assert.NoError(t, err)
+	assert.Equal(t, token.ID, again.ID)
+
+	_, err = notification_entities.NewDeviceToken("windows", token.Token, owner, now)
+	assert.Error(t, err)
+
+	_, err = notification_entities.NewDeviceToken(notification_entities.DevicePlatformAndroid, "token with spaces", owner, now)
+	assert.Error(t, err)
+
+	token.Disable(now)
+	assert.False(t, token.IsActive())
+}
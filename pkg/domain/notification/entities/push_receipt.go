@@ -0,0 +1,57 @@
+package notification_entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+)
+
+type PushReceiptStatus string
+
+const (
+	PushReceiptStatusSent         PushReceiptStatus = "sent"          // accepted by the push service
+	PushReceiptStatusFailed       PushReceiptStatus = "failed"        // refused by (or unable to reach) the push service
+	PushReceiptStatusInvalidToken PushReceiptStatus = "invalid_token" // unknown to the push service: the device token was disabled
+	PushReceiptStatusExpired      PushReceiptStatus = "expired"       // not sent: the notification expired before it was its turn
+)
+
+// PushReceipt records the outcome of a notification sent to a device (one per device), for the notification analytics.
+type PushReceipt struct {
+	ID                uuid.UUID            `json:"id" bson:"_id"`
+	Type              PushNotificationType `json:"type" bson:"type"`
+	Reference         string               `json:"reference" bson:"reference"` // what the notification is about (ie: the lobby of a match found)
+	DeviceTokenID     uuid.UUID            `json:"device_token_id" bson:"device_token_id"`
+	UserID            uuid.UUID            `json:"user_id" bson:"user_id"`
+	Platform          DevicePlatform       `json:"platform" bson:"platform"`
+	Provider          PushProvider         `json:"provider" bson:"provider"`
+	CollapseKey       string               `json:"collapse_key" bson:"collapse_key"`
+	Status            PushReceiptStatus    `json:"status" bson:"status"`
+	ProviderMessageID string               `json:"provider_message_id,omitempty" bson:"provider_message_id"`
+	Error             string               `json:"error,omitempty" bson:"error"`
+	TTLSeconds        int                  `json:"ttl_seconds" bson:"ttl_seconds"` // left when sent
+	ExpiresAt         time.Time            `json:"expires_at" bson:"expires_at"`
+	ResourceOwner     common.ResourceOwner `json:"resource_owner" bson:"resource_owner"`
+	CreatedAt         time.Time            `json:"created_at" bson:"created_at"`
+}
+
+func (r PushReceipt) GetID() uuid.UUID {
+	return r.ID
+}
+
+func NewPushReceipt(notification PushNotification, reference string, device DeviceToken, now time.Time) *PushReceipt {
+	return &PushReceipt{
+		ID:            uuid.New(),
+		Type:          notification.Type,
+		Reference:     reference,
+		DeviceTokenID: device.ID,
+		UserID:        device.UserID,
+		Platform:      device.Platform,
+		Provider:      device.Platform.Provider(),
+		CollapseKey:   notification.CollapseKey,
+		TTLSeconds:    int(notification.TTL(now).Seconds()),
+		ExpiresAt:     notification.ExpiresAt,
+		ResourceOwner: device.ResourceOwner,
+		CreatedAt:     now,
+	}
+}
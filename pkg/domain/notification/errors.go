@@ -0,0 +1,78 @@
+package notification
+
+import "fmt"
+
+// Invalid Device Token Error (unknown platform, or a malformed token)
+type InvalidDeviceTokenError struct {
+	Message string
+}
+
+func (e *InvalidDeviceTokenError) Error() string {
+	return e.Message
+}
+
+func NewInvalidDeviceTokenError(message string) *InvalidDeviceTokenError {
+	return &InvalidDeviceTokenError{
+		Message: message,
+	}
+}
+
+// Device Token Not Found Error (unknown, or registered by another user)
+type DeviceTokenNotFoundError struct {
+	Message string
+}
+
+func (e *DeviceTokenNotFoundError) Error() string {
+	return e.Message
+}
+
+func NewDeviceTokenNotFoundError(id string) *DeviceTokenNotFoundError {
+	return &DeviceTokenNotFoundError{
+		Message: fmt.Sprintf("device token %s not found", id),
+	}
+}
+
+// Device Token Rejected Error (the push service doesn't know the token anymore, ie: the app was uninstalled)
+type DeviceTokenRejectedError struct {
+	Message string
+}
+
+func (e *DeviceTokenRejectedError) Error() string {
+	return e.Message
+}
+
+func NewDeviceTokenRejectedError(reason string) *DeviceTokenRejectedError {
+	return &DeviceTokenRejectedError{
+		Message: fmt.Sprintf("device token rejected by the push service: %s", reason),
+	}
+}
+
+// Invalid Notification Preferences Error (unknown type or channel, or an invalid address)
+type InvalidNotificationPreferencesError struct {
+	Message string
+}
+
+func (e *InvalidNotificationPreferencesError) Error() string {
+	return e.Message
+}
+
+func NewInvalidNotificationPreferencesError(message string) *InvalidNotificationPreferencesError {
+	return &InvalidNotificationPreferencesError{
+		Message: message,
+	}
+}
+
+// Notification Not Found Error (unknown, or of another user)
+type NotificationNotFoundError struct {
+	Message string
+}
+
+func (e *NotificationNotFoundError) Error() string {
+	return e.Message
+}
+
+func NewNotificationNotFoundError(id string) *NotificationNotFoundError {
+	return &NotificationNotFoundError{
+		Message: fmt.Sprintf("notification %s not found", id),
+	}
+}
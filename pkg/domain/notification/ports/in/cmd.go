@@ -0,0 +1,59 @@
+package notification_in
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	notification_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/notification/entities"
+)
+
+type RegisterDeviceTokenCommand struct {
+	Platform notification_entities.DevicePlatform `json:"platform"`
+	Token    string                               `json:"token"`
+}
+
+// RegisterDeviceTokenCommandHandler registers the push token of an app install for the user in context. A token already registered
+// (ie: by the user previously logged in the app) is moved to the user and enabled again.
+type RegisterDeviceTokenCommandHandler interface {
+	Exec(ctx context.Context, cmd RegisterDeviceTokenCommand) (*notification_entities.DeviceToken, error)
+}
+
+// UnregisterDeviceTokenCommandHandler stops the notifications to a device of the user in context (ie: on logout).
+type UnregisterDeviceTokenCommandHandler interface {
+	Exec(ctx context.Context, deviceTokenID uuid.UUID) error
+}
+
+// NotifyMatchFoundCommandHandler alerts the devices of the players of a lobby formed by the matcher, recording a receipt of each
+// device, and returns the count of devices the alert was sent to.
+type NotifyMatchFoundCommandHandler interface {
+	Exec(ctx context.Context, matchFound notification_entities.MatchFound) (int, error)
+}
+
+// NotifyWeeklyRecapCommandHandler alerts the devices of the users of a weekly recap, recording a receipt of each device, and returns
+// the count of devices the alert was sent to.
+type NotifyWeeklyRecapCommandHandler interface {
+	Exec(ctx context.Context, recap notification_entities.WeeklyRecapReady) (int, error)
+}
+
+// DispatchNotificationCommandHandler notifies the users of an event: an inbox entry of each user, rendered from the template of the
+// event type, sent to the channels each user chose. Users already notified of the event are skipped. Returns the count of users notified.
+type DispatchNotificationCommandHandler interface {
+	Exec(ctx context.Context, event notification_entities.NotificationEvent) (int, error)
+}
+
+type UpdateNotificationPreferencesCommand struct {
+	Channels          map[notification_entities.NotificationType][]notification_entities.NotificationChannel `json:"channels"`
+	Email             string                                                                                 `json:"email"`
+	DiscordWebhookURL string                                                                                 `json:"discord_webhook_url"`
+}
+
+// UpdateNotificationPreferencesCommandHandler sets the channels of the user in context (the types not set go to the channels of their
+// template), and where they are sent to.
+type UpdateNotificationPreferencesCommandHandler interface {
+	Exec(ctx context.Context, cmd UpdateNotificationPreferencesCommand) (*notification_entities.NotificationPreferences, error)
+}
+
+// MarkNotificationReadCommandHandler marks a notification of the inbox of the user in context as read.
+type MarkNotificationReadCommandHandler interface {
+	Exec(ctx context.Context, notificationID uuid.UUID) (*notification_entities.Notification, error)
+}
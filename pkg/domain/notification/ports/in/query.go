@@ -0,0 +1,22 @@
+package notification_in
+
+import (
+	"context"
+
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	notification_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/notification/entities"
+)
+
+type PushReceiptReader interface {
+	common.Searchable[notification_entities.PushReceipt]
+}
+
+// NotificationReader reads the inbox of the user in context.
+type NotificationReader interface {
+	common.Searchable[notification_entities.Notification]
+}
+
+// NotificationPreferencesQuery returns the preferences of the user in context (the template channels when never set).
+type NotificationPreferencesQuery interface {
+	Exec(ctx context.Context) (*notification_entities.NotificationPreferences, error)
+}
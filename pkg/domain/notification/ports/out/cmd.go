@@ -0,0 +1,37 @@
+package notification_out
+
+import (
+	"context"
+
+	notification_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/notification/entities"
+)
+
+type DeviceTokenWriter interface {
+	Create(ctx context.Context, token *notification_entities.DeviceToken) (*notification_entities.DeviceToken, error)
+	Update(ctx context.Context, token *notification_entities.DeviceToken) (*notification_entities.DeviceToken, error)
+}
+
+type PushReceiptWriter interface {
+	Create(ctx context.Context, receipt *notification_entities.PushReceipt) (*notification_entities.PushReceipt, error)
+}
+
+// PushSender sends a notification to a device through the push service of its platform, returning the ID of the message in the push
+// service. A *notification.DeviceTokenRejectedError is returned when the push service doesn't know the token anymore.
+type PushSender interface {
+	Send(ctx context.Context, device notification_entities.DeviceToken, notification notification_entities.PushNotification) (string, error)
+}
+
+type NotificationWriter interface {
+	Create(ctx context.Context, notification *notification_entities.Notification) (*notification_entities.Notification, error)
+	Update(ctx context.Context, notification *notification_entities.Notification) (*notification_entities.Notification, error)
+}
+
+// NotificationPreferencesWriter creates or replaces the preferences of a user.
+type NotificationPreferencesWriter interface {
+	Save(ctx context.Context, preferences *notification_entities.NotificationPreferences) (*notification_entities.NotificationPreferences, error)
+}
+
+// NotificationChannelSender sends a notification to the address of the channel in the preferences of the user (ie: their email).
+type NotificationChannelSender interface {
+	Send(ctx context.Context, preferences notification_entities.NotificationPreferences, notification notification_entities.Notification) error
+}
@@ -0,0 +1,22 @@
+package notification_out
+
+import (
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	notification_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/notification/entities"
+)
+
+type DeviceTokenReader interface {
+	common.Searchable[notification_entities.DeviceToken]
+}
+
+type PushReceiptReader interface {
+	common.Searchable[notification_entities.PushReceipt]
+}
+
+type NotificationReader interface {
+	common.Searchable[notification_entities.Notification]
+}
+
+type NotificationPreferencesReader interface {
+	common.Searchable[notification_entities.NotificationPreferences]
+}
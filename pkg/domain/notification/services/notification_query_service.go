@@ -0,0 +1,53 @@
+package notification_services
+
+import (
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	notification_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/notification/entities"
+	notification_in "github.com/psavelis/team-pro/replay-api/pkg/domain/notification/ports/in"
+	notification_out "github.com/psavelis/team-pro/replay-api/pkg/domain/notification/ports/out"
+)
+
+type NotificationQueryService struct {
+	common.BaseQueryService[notification_entities.Notification]
+}
+
+// NewNotificationQueryService reads the inbox of the user in context (ie: the unread notifications, the most recent first).
+func NewNotificationQueryService(notificationReader notification_out.NotificationReader) notification_in.NotificationReader {
+	queryableFields := map[string]bool{
+		"ID":            true,
+		"UserID":        true,
+		"Type":          true,
+		"Title":         common.DENY,
+		"Body":          common.DENY,
+		"Data":          common.DENY,
+		"Reference":     true,
+		"Deliveries":    common.DENY,
+		"ReadAt":        true,
+		"ResourceOwner": common.DENY,
+		"CreatedAt":     true,
+		"UpdatedAt":     true,
+	}
+
+	readableFields := map[string]bool{
+		"ID":            true,
+		"UserID":        true,
+		"Type":          true,
+		"Title":         true,
+		"Body":          true,
+		"Data":          true,
+		"Reference":     true,
+		"Deliveries":    true,
+		"ReadAt":        true,
+		"ResourceOwner": common.DENY,
+		"CreatedAt":     true,
+		"UpdatedAt":     true,
+	}
+
+	return &common.BaseQueryService[notification_entities.Notification]{
+		Reader:          notificationReader.(common.Searchable[notification_entities.Notification]),
+		QueryableFields: queryableFields,
+		ReadableFields:  readableFields,
+		MaxPageSize:     100,
+		Audience:        common.UserAudienceIDKey,
+	}
+}
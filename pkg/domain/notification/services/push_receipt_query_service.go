@@ -0,0 +1,59 @@
+package notification_services
+
+import (
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	notification_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/notification/entities"
+	notification_in "github.com/psavelis/team-pro/replay-api/pkg/domain/notification/ports/in"
+	notification_out "github.com/psavelis/team-pro/replay-api/pkg/domain/notification/ports/out"
+)
+
+type PushReceiptQueryService struct {
+	common.BaseQueryService[notification_entities.PushReceipt]
+}
+
+// NewPushReceiptQueryService reads the receipts of the client application (ie: the delivery rates of the notification analytics).
+func NewPushReceiptQueryService(receiptReader notification_out.PushReceiptReader) notification_in.PushReceiptReader {
+	queryableFields := map[string]bool{
+		"ID":                true,
+		"Type":              true,
+		"Reference":         true,
+		"DeviceTokenID":     true,
+		"UserID":            true,
+		"Platform":          true,
+		"Provider":          true,
+		"CollapseKey":       true,
+		"Status":            true,
+		"ProviderMessageID": true,
+		"Error":             common.DENY,
+		"TTLSeconds":        true,
+		"ExpiresAt":         true,
+		"ResourceOwner":     common.DENY,
+		"CreatedAt":         true,
+	}
+
+	readableFields := map[string]bool{
+		"ID":                true,
+		"Type":              true,
+		"Reference":         true,
+		"DeviceTokenID":     true,
+		"UserID":            true,
+		"Platform":          true,
+		"Provider":          true,
+		"CollapseKey":       true,
+		"Status":            true,
+		"ProviderMessageID": true,
+		"Error":             true,
+		"TTLSeconds":        true,
+		"ExpiresAt":         true,
+		"ResourceOwner":     common.DENY,
+		"CreatedAt":         true,
+	}
+
+	return &common.BaseQueryService[notification_entities.PushReceipt]{
+		Reader:          receiptReader.(common.Searchable[notification_entities.PushReceipt]),
+		QueryableFields: queryableFields,
+		ReadableFields:  readableFields,
+		MaxPageSize:     100,
+		Audience:        common.ClientApplicationAudienceIDKey,
+	}
+}
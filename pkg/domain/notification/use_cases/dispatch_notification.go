@@ -0,0 +1,210 @@
+package notification_use_cases
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	notification_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/notification/entities"
+	notification_in "github.com/psavelis/team-pro/replay-api/pkg/domain/notification/ports/in"
+	notification_out "github.com/psavelis/team-pro/replay-api/pkg/domain/notification/ports/out"
+)
+
+type DispatchNotificationUseCase struct {
+	pushDelivery
+	NotificationReader notification_out.NotificationReader
+	NotificationWriter notification_out.NotificationWriter
+	PreferencesReader  notification_out.NotificationPreferencesReader
+	Channels           map[notification_entities.NotificationChannel]notification_out.NotificationChannelSender
+}
+
+// NewDispatchNotificationUseCase sends the notifications to the inbox, the devices of the users (push), and the channel senders given
+// (ie: email, discord). Channels without a sender are skipped.
+func NewDispatchNotificationUseCase(notificationReader notification_out.NotificationReader, notificationWriter notification_out.NotificationWriter, preferencesReader notification_out.NotificationPreferencesReader, tokenReader notification_out.DeviceTokenReader, tokenWriter notification_out.DeviceTokenWriter, receiptWriter notification_out.PushReceiptWriter, pushSender notification_out.PushSender, channels map[notification_entities.NotificationChannel]notification_out.NotificationChannelSender) notification_in.DispatchNotificationCommandHandler {
+	return &DispatchNotificationUseCase{
+		pushDelivery: pushDelivery{
+			TokenReader:   tokenReader,
+			TokenWriter:   tokenWriter,
+			ReceiptWriter: receiptWriter,
+			Sender:        pushSender,
+		},
+		NotificationReader: notificationReader,
+		NotificationWriter: notificationWriter,
+		PreferencesReader:  preferencesReader,
+		Channels:           channels,
+	}
+}
+
+// Exec creates the inbox entry of each user before sending it to their other channels, once: an event delivered again only notifies the
+// users it didn't reach (a failed channel isn't retried, the notification stays in the inbox).
+func (usecase *DispatchNotificationUseCase) Exec(ctx context.Context, event notification_entities.NotificationEvent) (int, error) {
+	template, ok := notification_entities.NotificationTemplates[event.Type]
+	if !ok {
+		return 0, fmt.Errorf("no template of notification type %s", event.Type)
+	}
+
+	title, body, err := template.Render(event.Data)
+	if err != nil {
+		slog.ErrorContext(ctx, "unable to render notification", "type", event.Type, "reference", event.Reference, "err", err)
+		return 0, err
+	}
+
+	userIDs := distinctUserIDs(event.UserIDs)
+	if len(userIDs) == 0 {
+		return 0, nil
+	}
+
+	notified, err := usecase.notified(ctx, event, userIDs)
+	if err != nil {
+		return 0, err
+	}
+
+	preferences, err := usecase.preferences(ctx, userIDs)
+	if err != nil {
+		return 0, err
+	}
+
+	created := 0
+	for _, userID := range userIDs {
+		now := time.Now().UTC()
+
+		notification := notification_entities.NewNotification(event, userID, title, body, now)
+		if notified[notification.ID] {
+			continue
+		}
+
+		notification.Delivered(notification_entities.NotificationChannelInApp, notification_entities.NotificationDeliverySent, nil, now)
+
+		_, err = usecase.NotificationWriter.Create(ctx, notification)
+		if err != nil {
+			slog.ErrorContext(ctx, "unable to create notification", "notificationID", notification.ID, "type", event.Type, "err", err)
+			return created, err
+		}
+
+		created++
+
+		userPreferences, ok := preferences[userID]
+		if !ok {
+			userPreferences = *notification_entities.NewNotificationPreferences(notification.ResourceOwner, now)
+		}
+
+		channels := userPreferences.ChannelsFor(event.Type)
+		if len(channels) == 1 {
+			continue
+		}
+
+		for _, channel := range channels[1:] {
+			status, err := usecase.send(ctx, channel, userPreferences, *notification, template.TTL)
+			notification.Delivered(channel, status, err, time.Now().UTC())
+		}
+
+		_, err = usecase.NotificationWriter.Update(ctx, notification)
+		if err != nil {
+			slog.ErrorContext(ctx, "unable to record notification deliveries", "notificationID", notification.ID, "err", err)
+		}
+	}
+
+	slog.InfoContext(ctx, "notification dispatched", "type", event.Type, "reference", event.Reference, "users", len(userIDs), "created", created)
+
+	return created, nil
+}
+
+// send delivers the notification to a channel other than the inbox.
+func (usecase *DispatchNotificationUseCase) send(ctx context.Context, channel notification_entities.NotificationChannel, preferences notification_entities.NotificationPreferences, notification notification_entities.Notification, ttl time.Duration) (notification_entities.NotificationDeliveryStatus, error) {
+	if channel == notification_entities.NotificationChannelPush {
+		devices, sent, err := usecase.deliver(ctx, []uuid.UUID{notification.UserID}, notification.Push(ttl), notification.Reference)
+
+		switch {
+		case err != nil:
+			return notification_entities.NotificationDeliveryFailed, err
+		case devices == 0:
+			return notification_entities.NotificationDeliverySkipped, nil
+		case sent == 0:
+			return notification_entities.NotificationDeliveryFailed, nil // see the push receipts
+		default:
+			return notification_entities.NotificationDeliverySent, nil
+		}
+	}
+
+	sender, ok := usecase.Channels[channel]
+	if !ok || preferences.Address(channel) == "" {
+		return notification_entities.NotificationDeliverySkipped, nil
+	}
+
+	err := sender.Send(ctx, preferences, notification)
+	if err != nil {
+		slog.WarnContext(ctx, "unable to send notification", "notificationID", notification.ID, "channel", channel, "err", err)
+		return notification_entities.NotificationDeliveryFailed, err
+	}
+
+	return notification_entities.NotificationDeliverySent, nil
+}
+
+// notified returns the notifications of the event already created.
+func (usecase *DispatchNotificationUseCase) notified(ctx context.Context, event notification_entities.NotificationEvent, userIDs []uuid.UUID) (map[uuid.UUID]bool, error) {
+	ids := make([]interface{}, len(userIDs))
+	for i, userID := range userIDs {
+		ids[i] = notification_entities.NotificationID(event.ResourceOwner.TenantID, userID, event.Type, event.Reference)
+	}
+
+	search := common.NewSearchByValues(ctx, []common.SearchableValue{
+		{Field: "ID", Operator: common.InOperator, Values: ids},
+	}, common.NewSearchResultOptions(0, uint(len(ids))), common.ClientApplicationAudienceIDKey)
+
+	existing, err := usecase.NotificationReader.Search(ctx, search)
+	if err != nil {
+		slog.ErrorContext(ctx, "unable to search notifications", "type", event.Type, "reference", event.Reference, "err", err)
+		return nil, err
+	}
+
+	notified := make(map[uuid.UUID]bool, len(existing))
+	for _, n := range existing {
+		notified[n.ID] = true
+	}
+
+	return notified, nil
+}
+
+// preferences of the users who set theirs.
+func (usecase *DispatchNotificationUseCase) preferences(ctx context.Context, userIDs []uuid.UUID) (map[uuid.UUID]notification_entities.NotificationPreferences, error) {
+	values := make([]interface{}, len(userIDs))
+	for i, userID := range userIDs {
+		values[i] = userID
+	}
+
+	search := common.NewSearchByValues(ctx, []common.SearchableValue{
+		{Field: "UserID", Operator: common.InOperator, Values: values},
+	}, common.NewSearchResultOptions(0, uint(len(values))), common.ClientApplicationAudienceIDKey)
+
+	found, err := usecase.PreferencesReader.Search(ctx, search)
+	if err != nil {
+		slog.ErrorContext(ctx, "unable to search notification preferences", "err", err)
+		return nil, err
+	}
+
+	preferences := make(map[uuid.UUID]notification_entities.NotificationPreferences, len(found))
+	for _, p := range found {
+		preferences[p.UserID] = p
+	}
+
+	return preferences, nil
+}
+
+func distinctUserIDs(userIDs []uuid.UUID) []uuid.UUID {
+	seen := make(map[uuid.UUID]bool, len(userIDs))
+	res := make([]uuid.UUID, 0, len(userIDs))
+
+	for _, userID := range userIDs {
+		if userID == uuid.Nil || seen[userID] {
+			continue
+		}
+
+		seen[userID] = true
+		res = append(res, userID)
+	}
+
+	return res
+}
@@ -0,0 +1,58 @@
+package notification_use_cases
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	"github.com/psavelis/team-pro/replay-api/pkg/domain/notification"
+	notification_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/notification/entities"
+	notification_in "github.com/psavelis/team-pro/replay-api/pkg/domain/notification/ports/in"
+	notification_out "github.com/psavelis/team-pro/replay-api/pkg/domain/notification/ports/out"
+)
+
+type MarkNotificationReadUseCase struct {
+	NotificationReader notification_out.NotificationReader
+	NotificationWriter notification_out.NotificationWriter
+}
+
+func NewMarkNotificationReadUseCase(notificationReader notification_out.NotificationReader, notificationWriter notification_out.NotificationWriter) notification_in.MarkNotificationReadCommandHandler {
+	return &MarkNotificationReadUseCase{
+		NotificationReader: notificationReader,
+		NotificationWriter: notificationWriter,
+	}
+}
+
+// Exec only marks the notifications of the user in context, the ones already read keep when they were read.
+func (usecase *MarkNotificationReadUseCase) Exec(ctx context.Context, notificationID uuid.UUID) (*notification_entities.Notification, error) {
+	if !common.IsAuthenticatedUser(ctx) {
+		return nil, notification.NewNotificationNotFoundError(notificationID.String())
+	}
+
+	found, err := usecase.NotificationReader.Search(ctx, common.NewSearchByID(ctx, notificationID, common.UserAudienceIDKey))
+	if err != nil {
+		slog.ErrorContext(ctx, "unable to search notification", "notificationID", notificationID, "err", err)
+		return nil, err
+	}
+
+	if len(found) == 0 {
+		return nil, notification.NewNotificationNotFoundError(notificationID.String())
+	}
+
+	n := found[0]
+	if n.IsRead() {
+		return &n, nil
+	}
+
+	n.MarkRead(time.Now().UTC())
+
+	updated, err := usecase.NotificationWriter.Update(ctx, &n)
+	if err != nil {
+		slog.ErrorContext(ctx, "unable to mark notification read", "notificationID", notificationID, "err", err)
+		return nil, err
+	}
+
+	return updated, nil
+}
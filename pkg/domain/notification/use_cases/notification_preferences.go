@@ -0,0 +1,97 @@
+package notification_use_cases
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	"github.com/psavelis/team-pro/replay-api/pkg/domain/notification"
+	notification_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/notification/entities"
+	notification_in "github.com/psavelis/team-pro/replay-api/pkg/domain/notification/ports/in"
+	notification_out "github.com/psavelis/team-pro/replay-api/pkg/domain/notification/ports/out"
+)
+
+type GetNotificationPreferencesUseCase struct {
+	PreferencesReader notification_out.NotificationPreferencesReader
+}
+
+func NewGetNotificationPreferencesUseCase(preferencesReader notification_out.NotificationPreferencesReader) notification_in.NotificationPreferencesQuery {
+	return &GetNotificationPreferencesUseCase{
+		PreferencesReader: preferencesReader,
+	}
+}
+
+func (usecase *GetNotificationPreferencesUseCase) Exec(ctx context.Context) (*notification_entities.NotificationPreferences, error) {
+	if !common.IsAuthenticatedUser(ctx) {
+		return nil, notification.NewInvalidNotificationPreferencesError("notification preferences are only set by a user")
+	}
+
+	resourceOwner := common.GetResourceOwner(ctx)
+
+	return findNotificationPreferences(ctx, usecase.PreferencesReader, resourceOwner)
+}
+
+type UpdateNotificationPreferencesUseCase struct {
+	PreferencesReader notification_out.NotificationPreferencesReader
+	PreferencesWriter notification_out.NotificationPreferencesWriter
+}
+
+func NewUpdateNotificationPreferencesUseCase(preferencesReader notification_out.NotificationPreferencesReader, preferencesWriter notification_out.NotificationPreferencesWriter) notification_in.UpdateNotificationPreferencesCommandHandler {
+	return &UpdateNotificationPreferencesUseCase{
+		PreferencesReader: preferencesReader,
+		PreferencesWriter: preferencesWriter,
+	}
+}
+
+func (usecase *UpdateNotificationPreferencesUseCase) Exec(ctx context.Context, cmd notification_in.UpdateNotificationPreferencesCommand) (*notification_entities.NotificationPreferences, error) {
+	if !common.IsAuthenticatedUser(ctx) {
+		return nil, notification.NewInvalidNotificationPreferencesError("notification preferences are only set by a user")
+	}
+
+	resourceOwner := common.GetResourceOwner(ctx)
+
+	preferences, err := findNotificationPreferences(ctx, usecase.PreferencesReader, resourceOwner)
+	if err != nil {
+		return nil, err
+	}
+
+	preferences.Channels = cmd.Channels
+	if preferences.Channels == nil {
+		preferences.Channels = make(map[notification_entities.NotificationType][]notification_entities.NotificationChannel)
+	}
+
+	preferences.Email = cmd.Email
+	preferences.DiscordWebhookURL = cmd.DiscordWebhookURL
+	preferences.UpdatedAt = time.Now().UTC()
+
+	err = preferences.Validate()
+	if err != nil {
+		return nil, notification.NewInvalidNotificationPreferencesError(err.Error())
+	}
+
+	saved, err := usecase.PreferencesWriter.Save(ctx, preferences)
+	if err != nil {
+		slog.ErrorContext(ctx, "unable to save notification preferences", "userID", resourceOwner.UserID, "err", err)
+		return nil, err
+	}
+
+	return saved, nil
+}
+
+// findNotificationPreferences returns the preferences of the user, or the template channels when never set.
+func findNotificationPreferences(ctx context.Context, reader notification_out.NotificationPreferencesReader, resourceOwner common.ResourceOwner) (*notification_entities.NotificationPreferences, error) {
+	id := notification_entities.NotificationPreferencesID(resourceOwner.TenantID, resourceOwner.UserID)
+
+	found, err := reader.Search(ctx, common.NewSearchByID(ctx, id, common.UserAudienceIDKey))
+	if err != nil {
+		slog.ErrorContext(ctx, "unable to search notification preferences", "userID", resourceOwner.UserID, "err", err)
+		return nil, err
+	}
+
+	if len(found) == 0 {
+		return notification_entities.NewNotificationPreferences(resourceOwner, time.Now().UTC()), nil
+	}
+
+	return &found[0], nil
+}
@@ -0,0 +1,622 @@
+package notification_use_cases_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	"github.com/psavelis/team-pro/replay-api/pkg/domain/notification"
+	notification_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/notification/entities"
+	notification_in "github.com/psavelis/team-pro/replay-api/pkg/domain/notification/ports/in"
+	notification_out "github.com/psavelis/team-pro/replay-api/pkg/domain/notification/ports/out"
+	notification_use_cases "github.com/psavelis/team-pro/replay-api/pkg/domain/notification/use_cases"
+	"github.com/stretchr/testify/assert"
+)
+
+// mockTokenStore filters by the fields searched by the use cases, and by user on the user audience.
+type mockTokenStore struct {
+	tokens  map[uuid.UUID]*notification_entities.DeviceToken
+	created int
+	updated int
+}
+
+func newMockTokenStore(tokens ...notification_entities.DeviceToken) *mockTokenStore {
+	m := &mockTokenStore{tokens: make(map[uuid.UUID]*notification_entities.DeviceToken)}
+	for i := range tokens {
+		m.tokens[tokens[i].ID] = &tokens[i]
+	}
+
+	return m
+}
+
+func (m *mockTokenStore) Search(ctx context.Context, s common.Search) ([]notification_entities.DeviceToken, error) {
+	res := make([]notification_entities.DeviceToken, 0)
+
+	for _, t := range m.tokens {
+		if s.VisibilityOptions.IntendedAudience == common.UserAudienceIDKey && t.UserID != s.VisibilityOptions.RequestSource.UserID {
+			continue
+		}
+
+		match := true
+		for _, v := range s.SearchParams[0].Params[0].ValueParams {
+			switch v.Field {
+			case "ID":
+				match = match && t.ID == v.Values[0]
+			case "UserID":
+				found := false
+				for _, userID := range v.Values {
+					found = found || t.UserID == userID
+				}
+
+				match = match && found
+			}
+		}
+
+		if match {
+			res = append(res, *t)
+		}
+	}
+
+	return res, nil
+}
+
+func (m *mockTokenStore) Compile(ctx context.Context, searchParams []common.SearchAggregation, resultOptions common.SearchResultOptions) (*common.Search, error) {
+	return nil, nil
+}
+
+func (m *mockTokenStore) Create(ctx context.Context, token *notification_entities.DeviceToken) (*notification_entities.DeviceToken, error) {
+	m.created++
+	m.tokens[token.ID] = token
+	return token, nil
+}
+
+func (m *mockTokenStore) Update(ctx context.Context, token *notification_entities.DeviceToken) (*notification_entities.DeviceToken, error) {
+	m.updated++
+	updated := *token
+	m.tokens[token.ID] = &updated
+	return token, nil
+}
+
+type mockReceiptStore struct {
+	receipts []notification_entities.PushReceipt
+}
+
+func (m *mockReceiptStore) Create(ctx context.Context, receipt *notification_entities.PushReceipt) (*notification_entities.PushReceipt, error) {
+	m.receipts = append(m.receipts, *receipt)
+	return receipt, nil
+}
+
+// mockSender fails the devices of errs, sending to the others.
+type mockSender struct {
+	sent []notification_entities.PushNotification
+	errs map[uuid.UUID]error
+}
+
+func (m *mockSender) Send(ctx context.Context, device notification_entities.DeviceToken, push notification_entities.PushNotification) (string, error) {
+	if err := m.errs[device.ID]; err != nil {
+		return "", err
+	}
+
+	m.sent = append(m.sent, push)
+	return "msg-" + device.ID.String(), nil
+}
+
+func userContext(owner common.ResourceOwner) context.Context {
+	return common.WithAuthenticated(common.WithResourceOwner(context.Background(), owner))
+}
+
+// anonymousContext is the scope of a request without a RID, its user being a placeholder.
+func anonymousContext(owner common.ResourceOwner) context.Context {
+	return common.WithResourceOwner(context.Background(), owner)
+}
+
+func newOwner(tenantID uuid.UUID) common.ResourceOwner {
+	return common.ResourceOwner{TenantID: tenantID, ClientID: common.TeamPROAppClientID, UserID: uuid.New(), GroupID: uuid.New()}
+}
+
+func TestRegisterDeviceToken(t *testing.T) {
+	tenantID := uuid.New()
+	first := newOwner(tenantID)
+	second := newOwner(tenantID)
+
+	store := newMockTokenStore()
+	usecase := notification_use_cases.NewRegisterDeviceTokenUseCase(store, store)
+
+	cmd := notification_in.RegisterDeviceTokenCommand{Platform: notification_entities.DevicePlatformAndroid, Token: "fcm-token-1"}
+
+	device, err := usecase.Exec(userContext(first), cmd)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	assert.Equal(t, first.UserID, device.UserID)
+	assert.Equal(t, 1, store.created)
+
+	// the install is now used by another user: the token moves, instead of notifying both
+	store.tokens[device.ID].Disable(time.Now())
+
+	moved, err := usecase.Exec(userContext(second), cmd)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	assert.Equal(t, device.ID, moved.ID)
+	assert.Equal(t, second.UserID, moved.UserID)
+	assert.True(t, moved.IsActive())
+	assert.Equal(t, 1, store.created)
+	assert.Equal(t, 1, store.updated)
+
+	var invalidErr *notification.InvalidDeviceTokenError
+
+	_, err = usecase.Exec(userContext(first), notification_in.RegisterDeviceTokenCommand{Platform: "windows", Token: "token"})
+	assert.True(t, errors.As(err, &invalidErr))
+
+	_, err = usecase.Exec(userContext(common.ResourceOwner{TenantID: tenantID, ClientID: common.TeamPROAppClientID}), cmd)
+	assert.True(t, errors.As(err, &invalidErr))
+
+	_, err = usecase.Exec(anonymousContext(newOwner(tenantID)), cmd)
+	assert.True(t, errors.As(err, &invalidErr))
+}
+
+func TestUnregisterDeviceToken(t *testing.T) {
+	owner := newOwner(uuid.New())
+
+	device, err := notification_entities.NewDeviceToken(notification_entities.DevicePlatformIOS, "apns-token", owner, time.Now())
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	store := newMockTokenStore(*device)
+	usecase := notification_use_cases.NewUnregisterDeviceTokenUseCase(store, store)
+
+	// only the user of the device can unregister it
+	var notFoundErr *notification.DeviceTokenNotFoundError
+
+	err = usecase.Exec(userContext(newOwner(owner.TenantID)), device.ID)
+	assert.True(t, errors.As(err, &notFoundErr))
+	assert.True(t, store.tokens[device.ID].IsActive())
+
+	err = usecase.Exec(anonymousContext(owner), device.ID)
+	assert.True(t, errors.As(err, &notFoundErr))
+	assert.True(t, store.tokens[device.ID].IsActive())
+
+	assert.NoError(t, usecase.Exec(userContext(owner), device.ID))
+	assert.False(t, store.tokens[device.ID].IsActive())
+}
+
+func TestNotifyMatchFound(t *testing.T) {
+	tenantID := uuid.New()
+	lobbyOwner := common.ResourceOwner{TenantID: tenantID, ClientID: common.TeamPROAppClientID}
+
+	alice := newOwner(tenantID)
+	bob := newOwner(tenantID)
+	carol := newOwner(tenantID)
+
+	now := time.Now()
+	device := func(owner common.ResourceOwner, platform notification_entities.DevicePlatform, token string) notification_entities.DeviceToken {
+		d, err := notification_entities.NewDeviceToken(platform, token, owner, now)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		return *d
+	}
+
+	aliceAndroid := device(alice, notification_entities.DevicePlatformAndroid, "alice-android")
+	aliceIOS := device(alice, notification_entities.DevicePlatformIOS, "alice-ios")
+	bobIOS := device(bob, notification_entities.DevicePlatformIOS, "bob-ios")
+	bobOld := device(bob, notification_entities.DevicePlatformAndroid, "bob-old")
+	bobOld.Disable(now)
+	carolAndroid := device(carol, notification_entities.DevicePlatformAndroid, "carol-android")
+	outsider := device(newOwner(tenantID), notification_entities.DevicePlatformAndroid, "outsider")
+
+	deadline := now.Add(20 * time.Second)
+	matchFound := notification_entities.MatchFound{
+		LobbyID:            uuid.New(),
+		GameID:             common.CS2_GAME_ID,
+		UserIDs:            []uuid.UUID{alice.UserID, bob.UserID, carol.UserID},
+		ReadyCheckDeadline: &deadline,
+		ResourceOwner:      lobbyOwner,
+		CreatedAt:          now,
+	}
+
+	tokens := newMockTokenStore(aliceAndroid, aliceIOS, bobIOS, bobOld, carolAndroid, outsider)
+	receipts := &mockReceiptStore{}
+	sender := &mockSender{errs: map[uuid.UUID]error{
+		aliceIOS.ID:     notification.NewDeviceTokenRejectedError("Unregistered"),
+		carolAndroid.ID: errors.New("fcm request failed with status 503"),
+	}}
+
+	usecase := notification_use_cases.NewNotifyMatchFoundUseCase(tokens, tokens, receipts, sender)
+
+	sent, err := usecase.Exec(userContext(lobbyOwner), matchFound)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	assert.Equal(t, 2, sent)
+	assert.Len(t, receipts.receipts, 4)
+
+	statuses := make(map[uuid.UUID]notification_entities.PushReceiptStatus)
+	for _, r := range receipts.receipts {
+		statuses[r.DeviceTokenID] = r.Status
+
+		assert.Equal(t, matchFound.LobbyID.String(), r.Reference)
+		assert.Equal(t, notification_entities.MatchFoundCollapseKey, r.CollapseKey)
+		assert.Equal(t, deadline, r.ExpiresAt)
+		assert.InDelta(t, 20, r.TTLSeconds, 1)
+	}
+
+	assert.Equal(t, notification_entities.PushReceiptStatusSent, statuses[aliceAndroid.ID])
+	assert.Equal(t, notification_entities.PushReceiptStatusSent, statuses[bobIOS.ID])
+	assert.Equal(t, notification_entities.PushReceiptStatusInvalidToken, statuses[aliceIOS.ID])
+	assert.Equal(t, notification_entities.PushReceiptStatusFailed, statuses[carolAndroid.ID])
+
+	// the rejected token isn't notified anymore, the failed one is kept
+	assert.False(t, tokens.tokens[aliceIOS.ID].IsActive())
+	assert.True(t, tokens.tokens[carolAndroid.ID].IsActive())
+}
+
+func TestNotifyMatchFound_Expired(t *testing.T) {
+	tenantID := uuid.New()
+	lobbyOwner := common.ResourceOwner{TenantID: tenantID, ClientID: common.TeamPROAppClientID}
+	player := newOwner(tenantID)
+
+	device, err := notification_entities.NewDeviceToken(notification_entities.DevicePlatformAndroid, "player-android", player, time.Now())
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	tokens := newMockTokenStore(*device)
+	receipts := &mockReceiptStore{}
+	sender := &mockSender{}
+
+	usecase := notification_use_cases.NewNotifyMatchFoundUseCase(tokens, tokens, receipts, sender)
+
+	// the lobby was formed before the worker got to it: the accept window is over
+	sent, err := usecase.Exec(userContext(lobbyOwner), notification_entities.MatchFound{
+		LobbyID:       uuid.New(),
+		UserIDs:       []uuid.UUID{player.UserID},
+		ResourceOwner: lobbyOwner,
+		CreatedAt:     time.Now().Add(-time.Minute),
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 0, sent)
+	assert.Empty(t, sender.sent)
+
+	if assert.Len(t, receipts.receipts, 1) {
+		assert.Equal(t, notification_entities.PushReceiptStatusExpired, receipts.receipts[0].Status)
+		assert.Equal(t, 0, receipts.receipts[0].TTLSeconds)
+	}
+}
+
+func TestNotifyWeeklyRecap(t *testing.T) {
+	tenantID := uuid.New()
+	recapOwner := newOwner(tenantID)
+	member := newOwner(tenantID)
+
+	now := time.Now()
+	device, err := notification_entities.NewDeviceToken(notification_entities.DevicePlatformAndroid, "member-android", member, now)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	tokens := newMockTokenStore(*device)
+	receipts := &mockReceiptStore{}
+	sender := &mockSender{}
+
+	usecase := notification_use_cases.NewNotifyWeeklyRecapUseCase(tokens, tokens, receipts, sender)
+
+	recap := notification_entities.WeeklyRecapReady{
+		RecapID:       uuid.New(),
+		Subject:       "squad",
+		Name:          "Alpha",
+		MatchesPlayed: 4,
+		UserIDs:       []uuid.UUID{recapOwner.UserID, member.UserID},
+		ResourceOwner: recapOwner,
+		CreatedAt:     now,
+	}
+
+	sent, err := usecase.Exec(userContext(common.ResourceOwner{TenantID: tenantID, ClientID: recapOwner.ClientID}), recap)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	assert.Equal(t, 1, sent)
+
+	if assert.Len(t, sender.sent, 1) {
+		assert.Equal(t, notification_entities.PushNotificationWeeklyRecap, sender.sent[0].Type)
+		assert.Equal(t, "Alpha played 4 matches last week, see how it went.", sender.sent[0].Body)
+		assert.Equal(t, recap.RecapID.String(), sender.sent[0].Data["recap_id"])
+	}
+
+	if assert.Len(t, receipts.receipts, 1) {
+		assert.Equal(t, recap.RecapID.String(), receipts.receipts[0].Reference)
+		assert.Equal(t, notification_entities.WeeklyRecapCollapseKey, receipts.receipts[0].CollapseKey)
+	}
+}
+
+// mockNotificationStore filters by the IDs searched, and by user on the user audience.
+type mockNotificationStore struct {
+	notifications map[uuid.UUID]notification_entities.Notification
+	created       int
+}
+
+func (m *mockNotificationStore) Search(ctx context.Context, s common.Search) ([]notification_entities.Notification, error) {
+	res := make([]notification_entities.Notification, 0)
+
+	for _, n := range m.notifications {
+		if s.VisibilityOptions.IntendedAudience == common.UserAudienceIDKey && n.UserID != s.VisibilityOptions.RequestSource.UserID {
+			continue
+		}
+
+		for _, id := range s.SearchParams[0].Params[0].ValueParams[0].Values {
+			if n.ID == id {
+				res = append(res, n)
+			}
+		}
+	}
+
+	return res, nil
+}
+
+func (m *mockNotificationStore) Compile(ctx context.Context, searchParams []common.SearchAggregation, resultOptions common.SearchResultOptions) (*common.Search, error) {
+	return nil, nil
+}
+
+func (m *mockNotificationStore) Create(ctx context.Context, n *notification_entities.Notification) (*notification_entities.Notification, error) {
+	m.created++
+	m.notifications[n.ID] = *n
+	return n, nil
+}
+
+func (m *mockNotificationStore) Update(ctx context.Context, n *notification_entities.Notification) (*notification_entities.Notification, error) {
+	m.notifications[n.ID] = *n
+	return n, nil
+}
+
+type mockPreferencesStore struct {
+	preferences []notification_entities.NotificationPreferences
+}
+
+func (m *mockPreferencesStore) Search(ctx context.Context, s common.Search) ([]notification_entities.NotificationPreferences, error) {
+	res := make([]notification_entities.NotificationPreferences, 0)
+
+	for _, p := range m.preferences {
+		v := s.SearchParams[0].Params[0].ValueParams[0]
+		for _, value := range v.Values {
+			if (v.Field == "ID" && p.ID == value) || (v.Field == "UserID" && p.UserID == value) {
+				res = append(res, p)
+			}
+		}
+	}
+
+	return res, nil
+}
+
+func (m *mockPreferencesStore) Compile(ctx context.Context, searchParams []common.SearchAggregation, resultOptions common.SearchResultOptions) (*common.Search, error) {
+	return nil, nil
+}
+
+func (m *mockPreferencesStore) Save(ctx context.Context, p *notification_entities.NotificationPreferences) (*notification_entities.NotificationPreferences, error) {
+	m.preferences = []notification_entities.NotificationPreferences{*p}
+	return p, nil
+}
+
+// mockChannelSender keeps the addresses sent to, failing with err.
+type mockChannelSender struct {
+	channel notification_entities.NotificationChannel
+	sent    []string
+	err     error
+}
+
+func (m *mockChannelSender) Send(ctx context.Context, p notification_entities.NotificationPreferences, n notification_entities.Notification) error {
+	if m.err != nil {
+		return m.err
+	}
+
+	m.sent = append(m.sent, p.Address(m.channel))
+	return nil
+}
+
+func deliveries(n notification_entities.Notification) map[notification_entities.NotificationChannel]notification_entities.NotificationDeliveryStatus {
+	res := make(map[notification_entities.NotificationChannel]notification_entities.NotificationDeliveryStatus)
+	for _, d := range n.Deliveries {
+		res[d.Channel] = d.Status
+	}
+
+	return res
+}
+
+func TestDispatchNotification(t *testing.T) {
+	tenantID := uuid.New()
+	squadOwner := common.ResourceOwner{TenantID: tenantID, ClientID: common.TeamPROAppClientID}
+
+	invited := newOwner(tenantID)
+	emailOnly := newOwner(tenantID)
+
+	browser, err := notification_entities.NewDeviceToken(notification_entities.DevicePlatformWeb, "invited-browser", invited, time.Now())
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	emailOnlyPreferences := notification_entities.NewNotificationPreferences(emailOnly, time.Now())
+	emailOnlyPreferences.Email = "player@example.com"
+	emailOnlyPreferences.Channels[notification_entities.NotificationSquadInvite] = []notification_entities.NotificationChannel{notification_entities.NotificationChannelEmail}
+
+	notifications := &mockNotificationStore{notifications: make(map[uuid.UUID]notification_entities.Notification)}
+	preferences := &mockPreferencesStore{preferences: []notification_entities.NotificationPreferences{*emailOnlyPreferences}}
+	tokens := newMockTokenStore(*browser)
+	receipts := &mockReceiptStore{}
+	pushSender := &mockSender{}
+	email := &mockChannelSender{channel: notification_entities.NotificationChannelEmail}
+
+	usecase := notification_use_cases.NewDispatchNotificationUseCase(notifications, notifications, preferences, tokens, tokens, receipts, pushSender, map[notification_entities.NotificationChannel]notification_out.NotificationChannelSender{
+		notification_entities.NotificationChannelEmail: email,
+	})
+
+	event := notification_entities.NotificationEvent{
+		Type:          notification_entities.NotificationSquadInvite,
+		UserIDs:       []uuid.UUID{invited.UserID, emailOnly.UserID, invited.UserID},
+		Data:          map[string]string{"squad_name": "Alpha", "user_name": "s1mple", "kind": "invite"},
+		Reference:     uuid.NewString(),
+		ResourceOwner: squadOwner,
+		CreatedAt:     time.Now(),
+	}
+
+	created, err := usecase.Exec(userContext(squadOwner), event)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	assert.Equal(t, 2, created)
+
+	invitedNotification := notifications.notifications[notification_entities.NotificationID(tenantID, invited.UserID, event.Type, event.Reference)]
+	assert.Equal(t, "You were invited to join Alpha.", invitedNotification.Body)
+	assert.Equal(t, invited.UserID, invitedNotification.ResourceOwner.UserID)
+
+	// template channels: the browser of the user is notified, they have no email set
+	assert.Equal(t, map[notification_entities.NotificationChannel]notification_entities.NotificationDeliveryStatus{
+		notification_entities.NotificationChannelInApp: notification_entities.NotificationDeliverySent,
+		notification_entities.NotificationChannelPush:  notification_entities.NotificationDeliverySent,
+		notification_entities.NotificationChannelEmail: notification_entities.NotificationDeliverySkipped,
+	}, deliveries(invitedNotification))
+
+	if assert.Len(t, receipts.receipts, 1) {
+		assert.Equal(t, notification_entities.PushProviderFCM, receipts.receipts[0].Provider)
+		assert.Equal(t, event.Reference, receipts.receipts[0].CollapseKey)
+	}
+
+	// chosen channels
+	emailOnlyNotification := notifications.notifications[notification_entities.NotificationID(tenantID, emailOnly.UserID, event.Type, event.Reference)]
+	assert.Equal(t, map[notification_entities.NotificationChannel]notification_entities.NotificationDeliveryStatus{
+		notification_entities.NotificationChannelInApp: notification_entities.NotificationDeliverySent,
+		notification_entities.NotificationChannelEmail: notification_entities.NotificationDeliverySent,
+	}, deliveries(emailOnlyNotification))
+
+	assert.Equal(t, []string{"player@example.com"}, email.sent)
+
+	// delivered again: nobody is notified twice
+	created, err = usecase.Exec(userContext(squadOwner), event)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, created)
+	assert.Equal(t, 2, notifications.created)
+	assert.Len(t, pushSender.sent, 1)
+
+	// a failed channel is recorded, the inbox entry is kept
+	email.err = errors.New("smtp relay unavailable")
+	event.Reference = uuid.NewString()
+	event.UserIDs = []uuid.UUID{emailOnly.UserID}
+
+	created, err = usecase.Exec(userContext(squadOwner), event)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, created)
+
+	failed := notifications.notifications[notification_entities.NotificationID(tenantID, emailOnly.UserID, event.Type, event.Reference)]
+	assert.Equal(t, notification_entities.NotificationDeliveryFailed, deliveries(failed)[notification_entities.NotificationChannelEmail])
+
+	// events without a template, or missing the data of the template
+	_, err = usecase.Exec(userContext(squadOwner), notification_entities.NotificationEvent{Type: "prize_paid", UserIDs: event.UserIDs})
+	assert.Error(t, err)
+
+	event.Data = map[string]string{}
+	_, err = usecase.Exec(userContext(squadOwner), event)
+	assert.Error(t, err)
+}
+
+func TestUpdateNotificationPreferences(t *testing.T) {
+	owner := newOwner(uuid.New())
+	store := &mockPreferencesStore{}
+
+	update := notification_use_cases.NewUpdateNotificationPreferencesUseCase(store, store)
+	get := notification_use_cases.NewGetNotificationPreferencesUseCase(store)
+
+	// the template channels until set
+	preferences, err := get.Exec(userContext(owner))
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	assert.Empty(t, preferences.Channels)
+
+	saved, err := update.Exec(userContext(owner), notification_in.UpdateNotificationPreferencesCommand{
+		Channels: map[notification_entities.NotificationType][]notification_entities.NotificationChannel{
+			notification_entities.NotificationSquadApplication: {notification_entities.NotificationChannelDiscord},
+		},
+		DiscordWebhookURL: "https://discord.com/api/webhooks/123/token",
+	})
+
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	assert.Equal(t, notification_entities.NotificationPreferencesID(owner.TenantID, owner.UserID), saved.ID)
+
+	preferences, err = get.Exec(userContext(owner))
+	assert.NoError(t, err)
+	assert.Equal(t, "https://discord.com/api/webhooks/123/token", preferences.DiscordWebhookURL)
+
+	var invalidErr *notification.InvalidNotificationPreferencesError
+
+	_, err = update.Exec(userContext(owner), notification_in.UpdateNotificationPreferencesCommand{DiscordWebhookURL: "https://example.com/hook"})
+	assert.True(t, errors.As(err, &invalidErr))
+
+	_, err = update.Exec(userContext(common.ResourceOwner{TenantID: owner.TenantID, ClientID: owner.ClientID}), notification_in.UpdateNotificationPreferencesCommand{})
+	assert.True(t, errors.As(err, &invalidErr))
+}
+
+func TestMarkNotificationRead(t *testing.T) {
+	owner := newOwner(uuid.New())
+
+	n := notification_entities.NewNotification(notification_entities.NotificationEvent{
+		Type:          notification_entities.NotificationSquadJoinDeclined,
+		Reference:     uuid.NewString(),
+		ResourceOwner: common.ResourceOwner{TenantID: owner.TenantID, ClientID: owner.ClientID},
+	}, owner.UserID, "Invite declined", "s1mple declined the invite to Alpha.", time.Now())
+
+	store := &mockNotificationStore{notifications: map[uuid.UUID]notification_entities.Notification{n.ID: *n}}
+	usecase := notification_use_cases.NewMarkNotificationReadUseCase(store, store)
+
+	// only the user notified can mark it
+	var notFoundErr *notification.NotificationNotFoundError
+
+	_, err := usecase.Exec(userContext(newOwner(owner.TenantID)), n.ID)
+	assert.True(t, errors.As(err, &notFoundErr))
+	assert.False(t, store.notifications[n.ID].IsRead())
+
+	read, err := usecase.Exec(userContext(owner), n.ID)
+	assert.NoError(t, err)
+	assert.True(t, read.IsRead())
+	assert.True(t, store.notifications[n.ID].IsRead())
+}
+
+func TestNotificationInbox_RequiresUser(t *testing.T) {
+	owner := newOwner(uuid.New())
+
+	n := notification_entities.Notification{ID: uuid.New(), UserID: owner.UserID}
+	notifications := &mockNotificationStore{notifications: map[uuid.UUID]notification_entities.Notification{n.ID: n}}
+	preferences := &mockPreferencesStore{}
+
+	// the placeholder user of a request without a RID, even when it happens to match
+	anonymous := anonymousContext(owner)
+
+	var notFoundErr *notification.NotificationNotFoundError
+	_, err := notification_use_cases.NewMarkNotificationReadUseCase(notifications, notifications).Exec(anonymous, n.ID)
+	assert.True(t, errors.As(err, &notFoundErr))
+
+	var invalidErr *notification.InvalidNotificationPreferencesError
+	_, err = notification_use_cases.NewGetNotificationPreferencesUseCase(preferences).Exec(anonymous)
+	assert.True(t, errors.As(err, &invalidErr))
+
+	_, err = notification_use_cases.NewUpdateNotificationPreferencesUseCase(preferences, preferences).Exec(anonymous, notification_in.UpdateNotificationPreferencesCommand{})
+	assert.True(t, errors.As(err, &invalidErr))
+	assert.Empty(t, preferences.preferences)
+
+	read, err := notification_use_cases.NewMarkNotificationReadUseCase(notifications, notifications).Exec(userContext(owner), n.ID)
+	assert.NoError(t, err)
+	assert.NotNil(t, read.ReadAt)
+}
@@ -0,0 +1,38 @@
+package notification_use_cases
+
+import (
+	"context"
+	"log/slog"
+
+	notification_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/notification/entities"
+	notification_in "github.com/psavelis/team-pro/replay-api/pkg/domain/notification/ports/in"
+	notification_out "github.com/psavelis/team-pro/replay-api/pkg/domain/notification/ports/out"
+)
+
+type NotifyMatchFoundUseCase struct {
+	pushDelivery
+}
+
+func NewNotifyMatchFoundUseCase(tokenReader notification_out.DeviceTokenReader, tokenWriter notification_out.DeviceTokenWriter, receiptWriter notification_out.PushReceiptWriter, sender notification_out.PushSender) notification_in.NotifyMatchFoundCommandHandler {
+	return &NotifyMatchFoundUseCase{
+		pushDelivery{
+			TokenReader:   tokenReader,
+			TokenWriter:   tokenWriter,
+			ReceiptWriter: receiptWriter,
+			Sender:        sender,
+		},
+	}
+}
+
+// Exec sends the alert once to each active device of the players: a failed alert isn't retried, it would only be delivered after the
+// accept window. Devices rejected by the push service are disabled.
+func (usecase *NotifyMatchFoundUseCase) Exec(ctx context.Context, matchFound notification_entities.MatchFound) (int, error) {
+	devices, sent, err := usecase.deliver(ctx, matchFound.UserIDs, notification_entities.NewMatchFoundNotification(matchFound), matchFound.LobbyID.String())
+	if err != nil {
+		return 0, err
+	}
+
+	slog.InfoContext(ctx, "match found notified", "lobbyID", matchFound.LobbyID, "devices", devices, "sent", sent)
+
+	return sent, nil
+}
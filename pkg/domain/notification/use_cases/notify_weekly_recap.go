@@ -0,0 +1,37 @@
+package notification_use_cases
+
+import (
+	"context"
+	"log/slog"
+
+	notification_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/notification/entities"
+	notification_in "github.com/psavelis/team-pro/replay-api/pkg/domain/notification/ports/in"
+	notification_out "github.com/psavelis/team-pro/replay-api/pkg/domain/notification/ports/out"
+)
+
+type NotifyWeeklyRecapUseCase struct {
+	pushDelivery
+}
+
+func NewNotifyWeeklyRecapUseCase(tokenReader notification_out.DeviceTokenReader, tokenWriter notification_out.DeviceTokenWriter, receiptWriter notification_out.PushReceiptWriter, sender notification_out.PushSender) notification_in.NotifyWeeklyRecapCommandHandler {
+	return &NotifyWeeklyRecapUseCase{
+		pushDelivery{
+			TokenReader:   tokenReader,
+			TokenWriter:   tokenWriter,
+			ReceiptWriter: receiptWriter,
+			Sender:        sender,
+		},
+	}
+}
+
+// Exec sends the alert once to each active device of the users (the recap stays retrievable, a failed alert isn't retried).
+func (usecase *NotifyWeeklyRecapUseCase) Exec(ctx context.Context, recap notification_entities.WeeklyRecapReady) (int, error) {
+	devices, sent, err := usecase.deliver(ctx, recap.UserIDs, notification_entities.NewWeeklyRecapNotification(recap), recap.RecapID.String())
+	if err != nil {
+		return 0, err
+	}
+
+	slog.InfoContext(ctx, "weekly recap notified", "recapID", recap.RecapID, "subject", recap.Subject, "devices", devices, "sent", sent)
+
+	return sent, nil
+}
@@ -0,0 +1,102 @@
+package notification_use_cases
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	"github.com/psavelis/team-pro/replay-api/pkg/domain/notification"
+	notification_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/notification/entities"
+	notification_out "github.com/psavelis/team-pro/replay-api/pkg/domain/notification/ports/out"
+)
+
+// MaxDevicesPerUser sizes the page of devices notified: MaxDevicesPerUser per user, the most recently registered first.
+const MaxDevicesPerUser = 10
+
+// pushDelivery sends the notifications of the use cases to the devices of their users.
+type pushDelivery struct {
+	TokenReader   notification_out.DeviceTokenReader
+	TokenWriter   notification_out.DeviceTokenWriter
+	ReceiptWriter notification_out.PushReceiptWriter
+	Sender        notification_out.PushSender
+}
+
+// deliver sends the notification once to each active device of the users, recording a receipt of each device, and returns the count
+// of devices found and of the ones the notification was sent to. Devices rejected by the push service are disabled.
+func (d *pushDelivery) deliver(ctx context.Context, userIDs []uuid.UUID, push notification_entities.PushNotification, reference string) (int, int, error) {
+	if len(userIDs) == 0 {
+		return 0, 0, nil
+	}
+
+	values := make([]interface{}, len(userIDs))
+	for i, userID := range userIDs {
+		values[i] = userID
+	}
+
+	search := common.NewSearchByValues(ctx, []common.SearchableValue{
+		{Field: "UserID", Operator: common.InOperator, Values: values},
+	}, common.NewSearchResultOptions(0, uint(len(values)*MaxDevicesPerUser)), common.ClientApplicationAudienceIDKey)
+
+	search.SortOptions = []common.SearchSortOption{{Field: "UpdatedAt", Direction: common.DescendingIDKey}}
+
+	devices, err := d.TokenReader.Search(ctx, search)
+	if err != nil {
+		slog.ErrorContext(ctx, "unable to search device tokens", "type", push.Type, "reference", reference, "err", err)
+		return 0, 0, err
+	}
+
+	sent := 0
+	for _, device := range devices {
+		if !device.IsActive() {
+			continue
+		}
+
+		now := time.Now().UTC()
+		receipt := notification_entities.NewPushReceipt(push, reference, device, now)
+
+		if push.TTL(now) == 0 {
+			receipt.Status = notification_entities.PushReceiptStatusExpired
+		} else {
+			receipt.ProviderMessageID, err = d.Sender.Send(ctx, device, push)
+			receipt.Status = d.status(ctx, &device, err, now)
+
+			if err != nil {
+				receipt.Error = err.Error()
+			} else {
+				sent++
+			}
+		}
+
+		_, err = d.ReceiptWriter.Create(ctx, receipt)
+		if err != nil {
+			slog.ErrorContext(ctx, "unable to create push receipt", "deviceTokenID", device.ID, "status", receipt.Status, "err", err)
+		}
+	}
+
+	return len(devices), sent, nil
+}
+
+// status of a sent notification, the device is disabled when its token was rejected.
+func (d *pushDelivery) status(ctx context.Context, device *notification_entities.DeviceToken, err error, now time.Time) notification_entities.PushReceiptStatus {
+	if err == nil {
+		return notification_entities.PushReceiptStatusSent
+	}
+
+	var rejectedErr *notification.DeviceTokenRejectedError
+	if !errors.As(err, &rejectedErr) {
+		slog.WarnContext(ctx, "unable to send push notification", "deviceTokenID", device.ID, "provider", device.Platform.Provider(), "err", err)
+		return notification_entities.PushReceiptStatusFailed
+	}
+
+	device.Disable(now)
+
+	_, updateErr := d.TokenWriter.Update(ctx, device)
+	if updateErr != nil {
+		slog.ErrorContext(ctx, "unable to disable rejected device token", "deviceTokenID", device.ID, "err", updateErr)
+	}
+
+	return notification_entities.PushReceiptStatusInvalidToken
+}
@@ -0,0 +1,71 @@
+package notification_use_cases
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	"github.com/psavelis/team-pro/replay-api/pkg/domain/notification"
+	notification_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/notification/entities"
+	notification_in "github.com/psavelis/team-pro/replay-api/pkg/domain/notification/ports/in"
+	notification_out "github.com/psavelis/team-pro/replay-api/pkg/domain/notification/ports/out"
+)
+
+type RegisterDeviceTokenUseCase struct {
+	TokenReader notification_out.DeviceTokenReader
+	TokenWriter notification_out.DeviceTokenWriter
+}
+
+func NewRegisterDeviceTokenUseCase(tokenReader notification_out.DeviceTokenReader, tokenWriter notification_out.DeviceTokenWriter) notification_in.RegisterDeviceTokenCommandHandler {
+	return &RegisterDeviceTokenUseCase{
+		TokenReader: tokenReader,
+		TokenWriter: tokenWriter,
+	}
+}
+
+func (usecase *RegisterDeviceTokenUseCase) Exec(ctx context.Context, cmd notification_in.RegisterDeviceTokenCommand) (*notification_entities.DeviceToken, error) {
+	if !common.IsAuthenticatedUser(ctx) {
+		return nil, notification.NewInvalidDeviceTokenError("devices can only be registered by a user")
+	}
+
+	resourceOwner := common.GetResourceOwner(ctx)
+
+	now := time.Now().UTC()
+
+	token, err := notification_entities.NewDeviceToken(cmd.Platform, cmd.Token, resourceOwner, now)
+	if err != nil {
+		return nil, notification.NewInvalidDeviceTokenError(err.Error())
+	}
+
+	existing, err := usecase.TokenReader.Search(ctx, common.NewSearchByID(ctx, token.ID, common.ClientApplicationAudienceIDKey))
+	if err != nil {
+		slog.ErrorContext(ctx, "unable to search device token", "deviceTokenID", token.ID, "err", err)
+		return nil, err
+	}
+
+	if len(existing) == 0 {
+		created, err := usecase.TokenWriter.Create(ctx, token)
+		if err != nil {
+			slog.ErrorContext(ctx, "unable to create device token", "deviceTokenID", token.ID, "err", err)
+			return nil, err
+		}
+
+		return created, nil
+	}
+
+	// the app install is now used by this user (or the token was refreshed after a logout): the previous user stops being notified
+	registered := existing[0]
+	registered.UserID = resourceOwner.UserID
+	registered.ResourceOwner = resourceOwner
+	registered.DisabledAt = nil
+	registered.UpdatedAt = now
+
+	updated, err := usecase.TokenWriter.Update(ctx, &registered)
+	if err != nil {
+		slog.ErrorContext(ctx, "unable to update device token", "deviceTokenID", token.ID, "err", err)
+		return nil, err
+	}
+
+	return updated, nil
+}
@@ -0,0 +1,57 @@
+package notification_use_cases
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	"github.com/psavelis/team-pro/replay-api/pkg/domain/notification"
+	notification_in "github.com/psavelis/team-pro/replay-api/pkg/domain/notification/ports/in"
+	notification_out "github.com/psavelis/team-pro/replay-api/pkg/domain/notification/ports/out"
+)
+
+type UnregisterDeviceTokenUseCase struct {
+	TokenReader notification_out.DeviceTokenReader
+	TokenWriter notification_out.DeviceTokenWriter
+}
+
+func NewUnregisterDeviceTokenUseCase(tokenReader notification_out.DeviceTokenReader, tokenWriter notification_out.DeviceTokenWriter) notification_in.UnregisterDeviceTokenCommandHandler {
+	return &UnregisterDeviceTokenUseCase{
+		TokenReader: tokenReader,
+		TokenWriter: tokenWriter,
+	}
+}
+
+// Exec only disables the devices of the user in context, the token is kept in case the app registers it again.
+func (usecase *UnregisterDeviceTokenUseCase) Exec(ctx context.Context, deviceTokenID uuid.UUID) error {
+	if !common.IsAuthenticatedUser(ctx) {
+		return notification.NewDeviceTokenNotFoundError(deviceTokenID.String())
+	}
+
+	tokens, err := usecase.TokenReader.Search(ctx, common.NewSearchByID(ctx, deviceTokenID, common.UserAudienceIDKey))
+	if err != nil {
+		slog.ErrorContext(ctx, "unable to search device token", "deviceTokenID", deviceTokenID, "err", err)
+		return err
+	}
+
+	if len(tokens) == 0 {
+		return notification.NewDeviceTokenNotFoundError(deviceTokenID.String())
+	}
+
+	token := tokens[0]
+	if !token.IsActive() {
+		return nil
+	}
+
+	token.Disable(time.Now().UTC())
+
+	_, err = usecase.TokenWriter.Update(ctx, &token)
+	if err != nil {
+		slog.ErrorContext(ctx, "unable to disable device token", "deviceTokenID", deviceTokenID, "err", err)
+		return err
+	}
+
+	return nil
+}
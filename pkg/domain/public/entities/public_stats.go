@@ -0,0 +1,123 @@
+package public_entities
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	replay_entity "github.com/psavelis/team-pro/replay-api/pkg/domain/replay/entities"
+)
+
+// DefaultAttributionText credits the stats served to third-party sites, when no other text is configured.
+const DefaultAttributionText = "Stats provided by TeamPRO"
+
+// Attribution is the credit third-party sites must display next to the stats of the public API.
+type Attribution struct {
+	Text     string `json:"text"`
+	URL      string `json:"url,omitempty"` // linked by the credit
+	Required bool   `json:"required"`
+}
+
+func NewAttribution(text string, url string) Attribution {
+	if text == "" {
+		text = DefaultAttributionText
+	}
+
+	return Attribution{
+		Text:     text,
+		URL:      url,
+		Required: true,
+	}
+}
+
+// Attributed is a response of the public API: the stats along with the attribution they require.
+type Attributed[T any] struct {
+	Data        T           `json:"data"`
+	Attribution Attribution `json:"attribution"`
+}
+
+type PublicTeam struct {
+	Name  string `json:"name" bson:"name"`
+	Score int    `json:"score" bson:"score"`
+}
+
+// PublicMatch is a match made public by its owner, with the summary of its replay (no tenancy, share tokens or game events). Its
+// players are only listed by the detail of the match.
+type PublicMatch struct {
+	ID       uuid.UUID                        `json:"id" bson:"_id"`
+	GameID   common.GameIDKey                 `json:"game_id" bson:"game_id"`
+	MapName  string                           `json:"map_name" bson:"map_name"`
+	Rounds   int                              `json:"rounds" bson:"rounds"`
+	Teams    []PublicTeam                     `json:"teams" bson:"teams"`
+	MVP      *replay_entity.PlayerMatchStats  `json:"mvp,omitempty" bson:"mvp"`
+	Players  []replay_entity.PlayerMatchStats `json:"players,omitempty" bson:"players"` // best rated first
+	PlayedAt time.Time                        `json:"played_at" bson:"played_at"`
+}
+
+// LeaderboardEntry holds the totals of a player over the public matches of the period, and their average match rating.
+type LeaderboardEntry struct {
+	Rank            int     `json:"rank" bson:"-"`
+	NetworkPlayerID string  `json:"network_player_id" bson:"_id"`
+	Name            string  `json:"name" bson:"name"`
+	Matches         int     `json:"matches" bson:"matches"`
+	Rounds          int     `json:"rounds" bson:"rounds"`
+	Kills           int     `json:"kills" bson:"kills"`
+	Deaths          int     `json:"deaths" bson:"deaths"`
+	Assists         int     `json:"assists" bson:"assists"`
+	Headshots       int     `json:"headshots" bson:"headshots"`
+	Damage          int     `json:"damage" bson:"damage"`
+	MVPs            int     `json:"mvps" bson:"mvps"`
+	ADR             float64 `json:"adr" bson:"-"`
+	Rating          float64 `json:"rating" bson:"rating"`
+}
+
+type Leaderboard struct {
+	GameID      common.GameIDKey   `json:"game_id"`
+	Since       time.Time          `json:"since"`
+	SeasonID    *uuid.UUID         `json:"season_id,omitempty"` // the season ranked, all matches of the period when nil
+	MinMatches  int                `json:"min_matches"`         // players with fewer public matches in the period aren't ranked
+	Entries     []LeaderboardEntry `json:"entries"`
+	GeneratedAt time.Time          `json:"generated_at"`
+}
+
+// NewLeaderboard ranks the players by their average rating, as displayed (ties go to the most matches, then the most kills). Tied
+// players share their rank.
+func NewLeaderboard(gameID common.GameIDKey, since time.Time, seasonID *uuid.UUID, minMatches int, entries []LeaderboardEntry, now time.Time) *Leaderboard {
+	for i := range entries {
+		entries[i].Rating = math.Round(entries[i].Rating*100) / 100
+
+		if entries[i].Rounds > 0 {
+			entries[i].ADR = math.Round(float64(entries[i].Damage)/float64(entries[i].Rounds)*10) / 10
+		}
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		if entries[i].Rating != entries[j].Rating {
+			return entries[i].Rating > entries[j].Rating
+		}
+
+		if entries[i].Matches != entries[j].Matches {
+			return entries[i].Matches > entries[j].Matches
+		}
+
+		return entries[i].Kills > entries[j].Kills
+	})
+
+	for i := range entries {
+		entries[i].Rank = i + 1
+		if i > 0 && entries[i].Rating == entries[i-1].Rating && entries[i].Matches == entries[i-1].Matches && entries[i].Kills == entries[i-1].Kills {
+			entries[i].Rank = entries[i-1].Rank
+		}
+	}
+
+	return &Leaderboard{
+		GameID:      gameID,
+		Since:       since,
+		SeasonID:    seasonID,
+		MinMatches:  minMatches,
+		Entries:     entries,
+		GeneratedAt: now,
+	}
+}
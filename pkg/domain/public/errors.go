@@ -0,0 +1,22 @@
+package public
+
+import (
+	"fmt"
+
+	"github.com/google/uuid"
+)
+
+// Public Match Not Found Error (unknown match, or a match not made public by its owner)
+type PublicMatchNotFoundError struct {
+	Message string
+}
+
+func (e *PublicMatchNotFoundError) Error() string {
+	return e.Message
+}
+
+func NewPublicMatchNotFoundError(matchID uuid.UUID) *PublicMatchNotFoundError {
+	return &PublicMatchNotFoundError{
+		Message: fmt.Sprintf("public match %s not found", matchID),
+	}
+}
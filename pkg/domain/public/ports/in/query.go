@@ -0,0 +1,38 @@
+package public_in
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	public_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/public/entities"
+)
+
+type PublicMatchesQueryParams struct {
+	GameID common.GameIDKey
+	Before *time.Time // matches played before it (the played_at of the last match of the previous page), latest when nil
+	Limit  int        // defaults to DefaultPublicMatchesLimit, up to MaxPublicMatchesLimit
+}
+
+// PublicMatchesQuery lists the latest public matches of a game, served to third-party sites.
+type PublicMatchesQuery interface {
+	Exec(ctx context.Context, params PublicMatchesQueryParams) ([]public_entities.PublicMatch, error)
+}
+
+// PublicMatchQuery returns a public match with the summary of its players, a *public.PublicMatchNotFoundError when it isn't public.
+type PublicMatchQuery interface {
+	Exec(ctx context.Context, gameID common.GameIDKey, matchID uuid.UUID) (*public_entities.PublicMatch, error)
+}
+
+type LeaderboardQueryParams struct {
+	GameID   common.GameIDKey
+	Days     int        // period ranked, up to today; defaults to DefaultLeaderboardDays, up to MaxLeaderboardDays
+	SeasonID *uuid.UUID // only the matches of the season (within the period) are ranked, when set
+	Limit    int        // defaults to MaxLeaderboardLimit
+}
+
+// LeaderboardQuery ranks the players of the public matches of a game, served to third-party sites.
+type LeaderboardQuery interface {
+	Exec(ctx context.Context, params LeaderboardQueryParams) (*public_entities.Leaderboard, error)
+}
@@ -0,0 +1,23 @@
+package public_out
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	public_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/public/entities"
+)
+
+// PublicStatsReader reads the public matches of the tenant in context (whatever client application they were uploaded through).
+type PublicStatsReader interface {
+	// GetPublicMatches returns the latest public matches of the game (played before `before`, when set), without their players.
+	GetPublicMatches(ctx context.Context, gameID common.GameIDKey, before *time.Time, limit int) ([]public_entities.PublicMatch, error)
+
+	// GetPublicMatch returns a public match with its players, nil when the match isn't public.
+	GetPublicMatch(ctx context.Context, gameID common.GameIDKey, matchID uuid.UUID) (*public_entities.PublicMatch, error)
+
+	// GetLeaderboardEntries returns the best rated players of the public matches of the game played since `since` (unranked), with at
+	// least `minMatches` matches. Only the matches of the season are ranked when `seasonID` is set.
+	GetLeaderboardEntries(ctx context.Context, gameID common.GameIDKey, since time.Time, seasonID *uuid.UUID, minMatches int, limit int) ([]public_entities.LeaderboardEntry, error)
+}
@@ -0,0 +1,117 @@
+package public_use_cases
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	"github.com/psavelis/team-pro/replay-api/pkg/domain/public"
+	public_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/public/entities"
+	public_in "github.com/psavelis/team-pro/replay-api/pkg/domain/public/ports/in"
+	public_out "github.com/psavelis/team-pro/replay-api/pkg/domain/public/ports/out"
+)
+
+const (
+	DefaultPublicMatchesLimit = 20
+	MaxPublicMatchesLimit     = 100
+
+	DefaultLeaderboardDays = 30
+	MaxLeaderboardDays     = 90
+	MaxLeaderboardLimit    = 100
+
+	// LeaderboardMinMatches keeps the players with a handful of lucky matches off the leaderboard
+	LeaderboardMinMatches = 5
+)
+
+type ListPublicMatchesUseCase struct {
+	StatsReader public_out.PublicStatsReader
+}
+
+func NewListPublicMatchesUseCase(statsReader public_out.PublicStatsReader) public_in.PublicMatchesQuery {
+	return &ListPublicMatchesUseCase{
+		StatsReader: statsReader,
+	}
+}
+
+func (usecase *ListPublicMatchesUseCase) Exec(ctx context.Context, params public_in.PublicMatchesQueryParams) ([]public_entities.PublicMatch, error) {
+	limit := params.Limit
+	if limit <= 0 {
+		limit = DefaultPublicMatchesLimit
+	}
+
+	if limit > MaxPublicMatchesLimit {
+		limit = MaxPublicMatchesLimit
+	}
+
+	matches, err := usecase.StatsReader.GetPublicMatches(ctx, params.GameID, params.Before, limit)
+	if err != nil {
+		slog.ErrorContext(ctx, "unable to get public matches", "gameID", params.GameID, "err", err)
+		return nil, err
+	}
+
+	return matches, nil
+}
+
+type GetPublicMatchUseCase struct {
+	StatsReader public_out.PublicStatsReader
+}
+
+func NewGetPublicMatchUseCase(statsReader public_out.PublicStatsReader) public_in.PublicMatchQuery {
+	return &GetPublicMatchUseCase{
+		StatsReader: statsReader,
+	}
+}
+
+func (usecase *GetPublicMatchUseCase) Exec(ctx context.Context, gameID common.GameIDKey, matchID uuid.UUID) (*public_entities.PublicMatch, error) {
+	match, err := usecase.StatsReader.GetPublicMatch(ctx, gameID, matchID)
+	if err != nil {
+		slog.ErrorContext(ctx, "unable to get public match", "matchID", matchID, "err", err)
+		return nil, err
+	}
+
+	if match == nil {
+		return nil, public.NewPublicMatchNotFoundError(matchID)
+	}
+
+	return match, nil
+}
+
+type GetLeaderboardUseCase struct {
+	StatsReader public_out.PublicStatsReader
+}
+
+func NewGetLeaderboardUseCase(statsReader public_out.PublicStatsReader) public_in.LeaderboardQuery {
+	return &GetLeaderboardUseCase{
+		StatsReader: statsReader,
+	}
+}
+
+func (usecase *GetLeaderboardUseCase) Exec(ctx context.Context, params public_in.LeaderboardQueryParams) (*public_entities.Leaderboard, error) {
+	days := params.Days
+	if days <= 0 {
+		days = DefaultLeaderboardDays
+	}
+
+	if days > MaxLeaderboardDays {
+		days = MaxLeaderboardDays
+	}
+
+	limit := params.Limit
+	if limit <= 0 || limit > MaxLeaderboardLimit {
+		limit = MaxLeaderboardLimit
+	}
+
+	// whole days, so the leaderboard of a period is the same all day long
+	now := time.Now().UTC()
+	since := now.Truncate(24*time.Hour).AddDate(0, 0, -(days - 1))
+
+	entries, err := usecase.StatsReader.GetLeaderboardEntries(ctx, params.GameID, since, params.SeasonID, LeaderboardMinMatches, limit)
+	if err != nil {
+		slog.ErrorContext(ctx, "unable to get leaderboard entries", "gameID", params.GameID, "since", since, "seasonID", params.SeasonID, "err", err)
+		return nil, err
+	}
+
+	return public_entities.NewLeaderboard(params.GameID, since, params.SeasonID, LeaderboardMinMatches, entries, now), nil
+}
@@ -0,0 +1,143 @@
+package public_use_cases_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	"github.com/psavelis/team-pro/replay-api/pkg/domain/public"
+	public_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/public/entities"
+	public_in "github.com/psavelis/team-pro/replay-api/pkg/domain/public/ports/in"
+	public_use_cases "github.com/psavelis/team-pro/replay-api/pkg/domain/public/use_cases"
+	"github.com/stretchr/testify/assert"
+)
+
+// mockPublicStatsReader records the arguments of the last read.
+type mockPublicStatsReader struct {
+	matches []public_entities.PublicMatch
+	entries []public_entities.LeaderboardEntry
+
+	before     *time.Time
+	since      time.Time
+	seasonID   *uuid.UUID
+	minMatches int
+	limit      int
+}
+
+func (m *mockPublicStatsReader) GetPublicMatches(ctx context.Context, gameID common.GameIDKey, before *time.Time, limit int) ([]public_entities.PublicMatch, error) {
+	m.before = before
+	m.limit = limit
+	return m.matches, nil
+}
+
+func (m *mockPublicStatsReader) GetPublicMatch(ctx context.Context, gameID common.GameIDKey, matchID uuid.UUID) (*public_entities.PublicMatch, error) {
+	for i := range m.matches {
+		if m.matches[i].ID == matchID && m.matches[i].GameID == gameID {
+			return &m.matches[i], nil
+		}
+	}
+
+	return nil, nil
+}
+
+func (m *mockPublicStatsReader) GetLeaderboardEntries(ctx context.Context, gameID common.GameIDKey, since time.Time, seasonID *uuid.UUID, minMatches int, limit int) ([]public_entities.LeaderboardEntry, error) {
+	m.since = since
+	m.seasonID = seasonID
+	m.minMatches = minMatches
+	m.limit = limit
+	return m.entries, nil
+}
+
+func TestListPublicMatches_Limits(t *testing.T) {
+	reader := &mockPublicStatsReader{}
+	usecase := public_use_cases.NewListPublicMatchesUseCase(reader)
+
+	tests := []struct {
+		limit    int
+		expected int
+	}{
+		{0, public_use_cases.DefaultPublicMatchesLimit},
+		{10, 10},
+		{1000, public_use_cases.MaxPublicMatchesLimit},
+	}
+
+	for _, tt := range tests {
+		_, err := usecase.Exec(context.Background(), public_in.PublicMatchesQueryParams{GameID: common.CS2_GAME_ID, Limit: tt.limit})
+		assert.NoError(t, err)
+		assert.Equal(t, tt.expected, reader.limit)
+	}
+
+	before := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	_, err := usecase.Exec(context.Background(), public_in.PublicMatchesQueryParams{GameID: common.CS2_GAME_ID, Before: &before})
+	if assert.NoError(t, err) {
+		assert.Equal(t, &before, reader.before)
+	}
+}
+
+func TestGetPublicMatch(t *testing.T) {
+	match := public_entities.PublicMatch{ID: uuid.New(), GameID: common.CS2_GAME_ID, MapName: "de_ancient"}
+	usecase := public_use_cases.NewGetPublicMatchUseCase(&mockPublicStatsReader{matches: []public_entities.PublicMatch{match}})
+
+	found, err := usecase.Exec(context.Background(), common.CS2_GAME_ID, match.ID)
+	if assert.NoError(t, err) {
+		assert.Equal(t, "de_ancient", found.MapName)
+	}
+
+	var notFoundErr *public.PublicMatchNotFoundError
+	_, err = usecase.Exec(context.Background(), common.CS2_GAME_ID, uuid.New())
+	assert.ErrorAs(t, err, &notFoundErr)
+
+	_, err = usecase.Exec(context.Background(), common.CSGO_GAME_ID, match.ID)
+	assert.ErrorAs(t, err, &notFoundErr)
+}
+
+func TestGetLeaderboard_RanksByRating(t *testing.T) {
+	reader := &mockPublicStatsReader{entries: []public_entities.LeaderboardEntry{
+		{NetworkPlayerID: "1", Matches: 5, Kills: 80, Rating: 1.104},
+		{NetworkPlayerID: "2", Matches: 9, Kills: 150, Rounds: 200, Damage: 16432, Rating: 1.31},
+		{NetworkPlayerID: "3", Matches: 5, Kills: 80, Rating: 1.101},
+		{NetworkPlayerID: "4", Matches: 7, Kills: 90, Rating: 1.1},
+	}}
+
+	usecase := public_use_cases.NewGetLeaderboardUseCase(reader)
+
+	leaderboard, err := usecase.Exec(context.Background(), public_in.LeaderboardQueryParams{GameID: common.CS2_GAME_ID, Days: 365})
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	assert.Equal(t, public_use_cases.MaxLeaderboardLimit, reader.limit)
+	assert.Equal(t, public_use_cases.LeaderboardMinMatches, reader.minMatches)
+
+	// whole days, up to the max period
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+	assert.Equal(t, today.AddDate(0, 0, -(public_use_cases.MaxLeaderboardDays-1)), reader.since)
+	assert.Equal(t, reader.since, leaderboard.Since)
+
+	ranks := make(map[string]int)
+	for _, e := range leaderboard.Entries {
+		ranks[e.NetworkPlayerID] = e.Rank
+	}
+
+	assert.Equal(t, "2", leaderboard.Entries[0].NetworkPlayerID)
+	assert.Equal(t, 82.2, leaderboard.Entries[0].ADR)
+	// ratings are ranked as displayed (1.10 each): the most matches first
+	assert.Equal(t, map[string]int{"2": 1, "4": 2, "1": 3, "3": 3}, ranks)
+}
+
+func TestGetLeaderboard_Season(t *testing.T) {
+	reader := &mockPublicStatsReader{}
+	usecase := public_use_cases.NewGetLeaderboardUseCase(reader)
+
+	seasonID := uuid.New()
+
+	leaderboard, err := usecase.Exec(context.Background(), public_in.LeaderboardQueryParams{GameID: common.CS2_GAME_ID, SeasonID: &seasonID})
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	assert.Equal(t, &seasonID, reader.seasonID)
+	assert.Equal(t, &seasonID, leaderboard.SeasonID)
+}
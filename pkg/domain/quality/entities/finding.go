@@ -0,0 +1,56 @@
+package quality_entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+)
+
+type FindingSeverity string
+
+const (
+	FindingSeverityWarning  FindingSeverity = "Warning"
+	FindingSeverityCritical FindingSeverity = "Critical"
+)
+
+// Finding is an inconsistency detected by a data quality check. Each run of a check replaces its previous findings.
+type Finding struct {
+	ID            uuid.UUID            `json:"id" bson:"_id"`
+	CheckName     string               `json:"check_name" bson:"check_name"`
+	Severity      FindingSeverity      `json:"severity" bson:"severity"`
+	ResourceType  common.ResourceType  `json:"resource_type" bson:"resource_type"`
+	ResourceID    uuid.UUID            `json:"resource_id" bson:"resource_id"`
+	Description   string               `json:"description" bson:"description"`
+	ResourceOwner common.ResourceOwner `json:"resource_owner" bson:"resource_owner"`
+	CreatedAt     time.Time            `json:"created_at" bson:"created_at"`
+	UpdatedAt     time.Time            `json:"updated_at" bson:"updated_at"`
+}
+
+func NewFinding(checkName string, severity FindingSeverity, resourceType common.ResourceType, resourceID uuid.UUID, description string, resourceOwner common.ResourceOwner) Finding {
+	entity := common.NewEntity(resourceOwner)
+
+	return Finding{
+		ID:            entity.ID,
+		CheckName:     checkName,
+		Severity:      severity,
+		ResourceType:  resourceType,
+		ResourceID:    resourceID,
+		Description:   description,
+		ResourceOwner: resourceOwner,
+		CreatedAt:     entity.CreatedAt,
+		UpdatedAt:     entity.UpdatedAt,
+	}
+}
+
+func (f Finding) GetID() uuid.UUID {
+	return f.ID
+}
+
+// CheckResult summarizes a single check execution.
+type CheckResult struct {
+	CheckName string        `json:"check_name"`
+	Findings  int           `json:"findings"`
+	Elapsed   time.Duration `json:"elapsed"`
+	Error     string        `json:"error,omitempty"`
+}
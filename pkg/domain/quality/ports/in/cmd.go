@@ -0,0 +1,12 @@
+package quality_in
+
+import (
+	"context"
+
+	quality_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/quality/entities"
+)
+
+// RunDataQualityChecksCommand runs every registered data quality check, stores its findings and alerts on-call when needed.
+type RunDataQualityChecksCommand interface {
+	Exec(ctx context.Context) ([]quality_entities.CheckResult, error)
+}
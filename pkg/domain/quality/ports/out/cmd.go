@@ -0,0 +1,22 @@
+package quality_out
+
+import (
+	"context"
+
+	quality_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/quality/entities"
+)
+
+// DataQualityCheck detects inconsistencies in (or across) collections.
+type DataQualityCheck interface {
+	Name() string
+	Run(ctx context.Context) ([]quality_entities.Finding, error)
+}
+
+type FindingWriter interface {
+	// ReplaceByCheck drops the previous findings of the check (for the tenant in context) and stores the given ones.
+	ReplaceByCheck(ctx context.Context, checkName string, findings []quality_entities.Finding) error
+}
+
+type AlertNotifier interface {
+	Notify(ctx context.Context, checkName string, findings []quality_entities.Finding) error
+}
@@ -0,0 +1,10 @@
+package quality_out
+
+import (
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	quality_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/quality/entities"
+)
+
+type FindingReader interface {
+	common.Searchable[quality_entities.Finding]
+}
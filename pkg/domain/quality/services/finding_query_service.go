@@ -0,0 +1,46 @@
+package quality_services
+
+import (
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	quality_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/quality/entities"
+	quality_in "github.com/psavelis/team-pro/replay-api/pkg/domain/quality/ports/in"
+	quality_out "github.com/psavelis/team-pro/replay-api/pkg/domain/quality/ports/out"
+)
+
+type FindingQueryService struct {
+	common.BaseQueryService[quality_entities.Finding]
+}
+
+func NewFindingQueryService(findingReader quality_out.FindingReader) quality_in.FindingReader {
+	queryableFields := map[string]bool{
+		"ID":            true,
+		"CheckName":     true,
+		"Severity":      true,
+		"ResourceType":  true,
+		"ResourceID":    true,
+		"Description":   common.DENY,
+		"ResourceOwner": common.DENY,
+		"CreatedAt":     true,
+		"UpdatedAt":     true,
+	}
+
+	readableFields := map[string]bool{
+		"ID":            true,
+		"CheckName":     true,
+		"Severity":      true,
+		"ResourceType":  true,
+		"ResourceID":    true,
+		"Description":   true,
+		"ResourceOwner": common.DENY,
+		"CreatedAt":     true,
+		"UpdatedAt":     true,
+	}
+
+	return &common.BaseQueryService[quality_entities.Finding]{
+		Reader:          findingReader.(common.Searchable[quality_entities.Finding]),
+		QueryableFields: queryableFields,
+		ReadableFields:  readableFields,
+		MaxPageSize:     100,
+		Audience:        common.ClientApplicationAudienceIDKey,
+	}
+}
@@ -0,0 +1,77 @@
+package quality_use_cases
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	quality_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/quality/entities"
+	quality_in "github.com/psavelis/team-pro/replay-api/pkg/domain/quality/ports/in"
+	quality_out "github.com/psavelis/team-pro/replay-api/pkg/domain/quality/ports/out"
+)
+
+type RunDataQualityChecksUseCase struct {
+	Checks        []quality_out.DataQualityCheck
+	FindingWriter quality_out.FindingWriter
+	AlertNotifier quality_out.AlertNotifier
+}
+
+func NewRunDataQualityChecksUseCase(checks []quality_out.DataQualityCheck, findingWriter quality_out.FindingWriter, alertNotifier quality_out.AlertNotifier) quality_in.RunDataQualityChecksCommand {
+	return &RunDataQualityChecksUseCase{
+		Checks:        checks,
+		FindingWriter: findingWriter,
+		AlertNotifier: alertNotifier,
+	}
+}
+
+// Exec runs all checks even if some of them fail; the returned error joins every failure.
+func (usecase *RunDataQualityChecksUseCase) Exec(ctx context.Context) ([]quality_entities.CheckResult, error) {
+	results := make([]quality_entities.CheckResult, 0, len(usecase.Checks))
+
+	var errs []error
+
+	for _, check := range usecase.Checks {
+		start := time.Now()
+		result := quality_entities.CheckResult{CheckName: check.Name()}
+
+		err := usecase.run(ctx, check, &result)
+		if err != nil {
+			result.Error = err.Error()
+			errs = append(errs, err)
+		}
+
+		result.Elapsed = time.Since(start)
+		results = append(results, result)
+	}
+
+	return results, errors.Join(errs...)
+}
+
+func (usecase *RunDataQualityChecksUseCase) run(ctx context.Context, check quality_out.DataQualityCheck, result *quality_entities.CheckResult) error {
+	findings, err := check.Run(ctx)
+	if err != nil {
+		slog.ErrorContext(ctx, "data quality check failed", "check", check.Name(), "err", err)
+		return err
+	}
+
+	result.Findings = len(findings)
+
+	err = usecase.FindingWriter.ReplaceByCheck(ctx, check.Name(), findings)
+	if err != nil {
+		slog.ErrorContext(ctx, "unable to store data quality findings", "check", check.Name(), "err", err)
+		return err
+	}
+
+	if len(findings) == 0 {
+		return nil
+	}
+
+	err = usecase.AlertNotifier.Notify(ctx, check.Name(), findings)
+	if err != nil {
+		slog.ErrorContext(ctx, "unable to notify data quality findings", "check", check.Name(), "findings", len(findings), "err", err)
+		return err
+	}
+
+	return nil
+}
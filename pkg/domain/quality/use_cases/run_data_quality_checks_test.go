@@ -0,0 +1,93 @@
+package quality_use_cases_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	quality_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/quality/entities"
+	quality_out "github.com/psavelis/team-pro/replay-api/pkg/domain/quality/ports/out"
+	quality_use_cases "github.com/psavelis/team-pro/replay-api/pkg/domain/quality/use_cases"
+)
+
+type mockCheck struct {
+	name     string
+	findings []quality_entities.Finding
+	err      error
+}
+
+func (m *mockCheck) Name() string {
+	return m.name
+}
+
+func (m *mockCheck) Run(ctx context.Context) ([]quality_entities.Finding, error) {
+	return m.findings, m.err
+}
+
+type mockFindingWriter struct {
+	replaced map[string]int
+}
+
+func (m *mockFindingWriter) ReplaceByCheck(ctx context.Context, checkName string, findings []quality_entities.Finding) error {
+	m.replaced[checkName] = len(findings)
+	return nil
+}
+
+type mockAlertNotifier struct {
+	notified []string
+}
+
+func (m *mockAlertNotifier) Notify(ctx context.Context, checkName string, findings []quality_entities.Finding) error {
+	m.notified = append(m.notified, checkName)
+	return nil
+}
+
+func TestRunDataQualityChecksUseCase_Exec(t *testing.T) {
+	reso := common.ResourceOwner{TenantID: common.TeamPROTenantID, ClientID: common.TeamPROAppClientID}
+	finding := quality_entities.NewFinding("with_findings", quality_entities.FindingSeverityWarning, common.ResourceTypeMatch, uuid.New(), "match has no game events", reso)
+
+	failure := errors.New("aggregation failed")
+
+	checks := []quality_out.DataQualityCheck{
+		&mockCheck{name: "clean"},
+		&mockCheck{name: "failing", err: failure},
+		&mockCheck{name: "with_findings", findings: []quality_entities.Finding{finding}},
+	}
+
+	writer := &mockFindingWriter{replaced: make(map[string]int)}
+	notifier := &mockAlertNotifier{}
+
+	usecase := quality_use_cases.NewRunDataQualityChecksUseCase(checks, writer, notifier)
+
+	results, err := usecase.Exec(context.Background())
+
+	if !errors.Is(err, failure) {
+		t.Errorf("expected error to wrap %v, got %v", failure, err)
+	}
+
+	if len(results) != len(checks) {
+		t.Fatalf("expected %d results, got %d", len(checks), len(results))
+	}
+
+	if results[1].Error != failure.Error() {
+		t.Errorf("expected failing check result error %q, got %q", failure.Error(), results[1].Error)
+	}
+
+	if results[2].Findings != 1 {
+		t.Errorf("expected 1 finding, got %d", results[2].Findings)
+	}
+
+	if _, ok := writer.replaced["clean"]; !ok {
+		t.Errorf("expected findings of clean check to be replaced (cleared)")
+	}
+
+	if _, ok := writer.replaced["failing"]; ok {
+		t.Errorf("expected findings of failing check to be kept")
+	}
+
+	if len(notifier.notified) != 1 || notifier.notified[0] != "with_findings" {
+		t.Errorf("expected only with_findings to be notified, got %v", notifier.notified)
+	}
+}
@@ -0,0 +1,122 @@
+package rating_entities
+
+import (
+	"math"
+)
+
+const (
+	// Tau constrains the change of the volatility over time (Glickman suggests 0.3 to 1.2)
+	Tau = 0.5
+
+	glicko2Scale       = 173.7178 // 400 / ln(10)
+	glicko2Center      = 1500
+	glicko2Convergence = 0.000001
+)
+
+// GameResult is the score of a rated subject against an opponent: 1 for a win, 0.5 for a draw, 0 for a loss.
+type GameResult struct {
+	Opponent Rating
+	Score    float64
+}
+
+// CompositeOpponent rates a team as a single opponent: the average rating of its members, with the quadratic mean of their deviations.
+func CompositeOpponent(members []Rating) Rating {
+	if len(members) == 0 {
+		return Rating{Rating: InitialRating, Deviation: InitialDeviation, Volatility: InitialVolatility}
+	}
+
+	var rating, variance float64
+	for _, m := range members {
+		rating += m.Rating
+		variance += m.Deviation * m.Deviation
+	}
+
+	n := float64(len(members))
+
+	return Rating{Rating: rating / n, Deviation: math.Sqrt(variance / n), Volatility: InitialVolatility}
+}
+
+// Rate returns the rating updated by the results of a rating period (ie: a match), following Glickman's Glicko-2 algorithm. Without
+// results only the deviation grows.
+func (r Rating) Rate(results []GameResult) Rating {
+	mu := (r.Rating - glicko2Center) / glicko2Scale
+	phi := r.Deviation / glicko2Scale
+	sigma := r.Volatility
+
+	if len(results) == 0 {
+		r.Deviation = math.Min(math.Sqrt(phi*phi+sigma*sigma)*glicko2Scale, InitialDeviation)
+		return r
+	}
+
+	var invV, sum float64
+	for _, result := range results {
+		muJ := (result.Opponent.Rating - glicko2Center) / glicko2Scale
+		g := glicko2G(result.Opponent.Deviation / glicko2Scale)
+		e := 1 / (1 + math.Exp(-g*(mu-muJ)))
+
+		invV += g * g * e * (1 - e)
+		sum += g * (result.Score - e)
+	}
+
+	v := 1 / invV
+	delta := v * sum
+
+	sigma = glicko2Volatility(phi, sigma, v, delta)
+
+	phiStar := math.Sqrt(phi*phi + sigma*sigma)
+	phi = 1 / math.Sqrt(1/(phiStar*phiStar)+1/v)
+	mu += phi * phi * sum
+
+	r.Rating = mu*glicko2Scale + glicko2Center
+	r.Deviation = math.Min(phi*glicko2Scale, InitialDeviation)
+	r.Volatility = sigma
+
+	return r
+}
+
+func glicko2G(phi float64) float64 {
+	return 1 / math.Sqrt(1+3*phi*phi/(math.Pi*math.Pi))
+}
+
+// glicko2Volatility solves the new volatility with the Illinois algorithm (step 5 of the algorithm).
+func glicko2Volatility(phi float64, sigma float64, v float64, delta float64) float64 {
+	a := math.Log(sigma * sigma)
+
+	f := func(x float64) float64 {
+		ex := math.Exp(x)
+		d := phi*phi + v + ex
+
+		return ex*(delta*delta-phi*phi-v-ex)/(2*d*d) - (x-a)/(Tau*Tau)
+	}
+
+	A := a
+
+	var B float64
+	if delta*delta > phi*phi+v {
+		B = math.Log(delta*delta - phi*phi - v)
+	} else {
+		k := 1.0
+		for f(a-k*Tau) < 0 {
+			k++
+		}
+
+		B = a - k*Tau
+	}
+
+	fA, fB := f(A), f(B)
+
+	for math.Abs(B-A) > glicko2Convergence {
+		C := A + (A-B)*fA/(fB-fA)
+		fC := f(C)
+
+		if fC*fB <= 0 {
+			A, fA = B, fB
+		} else {
+			fA /= 2
+		}
+
+		B, fB = C, fC
+	}
+
+	return math.Exp(A / 2)
+}
@@ -0,0 +1,46 @@
+package rating_entities_test
+
+import (
+	"testing"
+
+	rating_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/rating/entities"
+	"github.com/stretchr/testify/assert"
+)
+
+// the example of Glickman's "Example of the Glicko-2 system"
+func TestRating_Rate(t *testing.T) {
+	r := rating_entities.Rating{Rating: 1500, Deviation: 200, Volatility: 0.06}
+
+	rated := r.Rate([]rating_entities.GameResult{
+		{Opponent: rating_entities.Rating{Rating: 1400, Deviation: 30}, Score: 1},
+		{Opponent: rating_entities.Rating{Rating: 1550, Deviation: 100}, Score: 0},
+		{Opponent: rating_entities.Rating{Rating: 1700, Deviation: 300}, Score: 0},
+	})
+
+	assert.InDelta(t, 1464.06, rated.Rating, 0.01)
+	assert.InDelta(t, 151.52, rated.Deviation, 0.01)
+	assert.InDelta(t, 0.05999, rated.Volatility, 0.00001)
+}
+
+func TestRating_Rate_NoResults(t *testing.T) {
+	r := rating_entities.Rating{Rating: 1500, Deviation: 50, Volatility: 0.06}
+
+	rated := r.Rate(nil)
+
+	assert.Equal(t, 1500.0, rated.Rating)
+	assert.Greater(t, rated.Deviation, 50.0)
+
+	// the deviation never exceeds the one of an unrated subject
+	unrated := rating_entities.Rating{Rating: 1500, Deviation: rating_entities.InitialDeviation, Volatility: 0.06}
+	assert.Equal(t, float64(rating_entities.InitialDeviation), unrated.Rate(nil).Deviation)
+}
+
+func TestCompositeOpponent(t *testing.T) {
+	composite := rating_entities.CompositeOpponent([]rating_entities.Rating{
+		{Rating: 1200, Deviation: 30},
+		{Rating: 1400, Deviation: 40},
+	})
+
+	assert.Equal(t, 1300.0, composite.Rating)
+	assert.InDelta(t, 35.36, composite.Deviation, 0.01)
+}
@@ -0,0 +1,125 @@
+package rating_entities
+
+import (
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+)
+
+type RatingSubject string
+
+const (
+	RatingSubjectPlayer RatingSubject = "player"
+	RatingSubjectSquad  RatingSubject = "squad"
+)
+
+const (
+	// unrated players and squads start at the matchmaking default rating, with the largest deviation (their rating moves fast)
+	InitialRating     = 1000
+	InitialDeviation  = 350
+	InitialVolatility = 0.06
+)
+
+// ratingNamespace keeps Rating IDs stable (subject+game), so a subject has a single rating per game.
+var ratingNamespace = uuid.MustParse("b1e4c8d2-6a3f-4f7e-9c05-7d2e8a1f4b63")
+
+// ratingHistoryNamespace keeps RatingHistory IDs stable (subject+match), so rating a match twice overwrites its entries.
+var ratingHistoryNamespace = uuid.MustParse("0d8f3a6e-2c4b-4b19-a7e5-5f1c9d3e8b24")
+
+// Rating is the Glicko-2 rating of a player or a squad in a game.
+type Rating struct {
+	ID                  uuid.UUID            `json:"id" bson:"_id"`
+	Subject             RatingSubject        `json:"subject" bson:"subject"`
+	SubjectID           uuid.UUID            `json:"subject_id" bson:"subject_id"`
+	GameID              common.GameIDKey     `json:"game_id" bson:"game_id"`
+	Rating              float64              `json:"rating" bson:"rating"`
+	Deviation           float64              `json:"deviation" bson:"deviation"`
+	Volatility          float64              `json:"volatility" bson:"volatility"`
+	MatchesPlayed       int                  `json:"matches_played" bson:"matches_played"`
+	SeasonID            *uuid.UUID           `json:"season_id,omitempty" bson:"season_id,omitempty"` // the season the rating was last reset for
+	SeasonMatchesPlayed int                  `json:"season_matches_played" bson:"season_matches_played"`
+	Version             int                  `json:"version" bson:"version"`                                 // incremented by every rated match
+	LastMatchID         *uuid.UUID           `json:"last_match_id,omitempty" bson:"last_match_id,omitempty"` // the last match rated
+	ResourceOwner       common.ResourceOwner `json:"resource_owner" bson:"resource_owner"`
+	CreatedAt           time.Time            `json:"created_at" bson:"created_at"`
+	UpdatedAt           time.Time            `json:"updated_at" bson:"updated_at"`
+}
+
+func (r Rating) GetID() uuid.UUID {
+	return r.ID
+}
+
+func RatingID(subject RatingSubject, subjectID uuid.UUID, gameID common.GameIDKey) uuid.UUID {
+	return uuid.NewSHA1(ratingNamespace, []byte(string(subject)+subjectID.String()+string(gameID)))
+}
+
+// NewRating returns the initial rating of a subject that has no rated match yet.
+func NewRating(subject RatingSubject, subjectID uuid.UUID, gameID common.GameIDKey, owner common.ResourceOwner, now time.Time) Rating {
+	return Rating{
+		ID:            RatingID(subject, subjectID, gameID),
+		Subject:       subject,
+		SubjectID:     subjectID,
+		GameID:        gameID,
+		Rating:        InitialRating,
+		Deviation:     InitialDeviation,
+		Volatility:    InitialVolatility,
+		ResourceOwner: owner,
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	}
+}
+
+// MMR is the rating used by the matchmaking.
+func (r Rating) MMR() int {
+	return int(math.Round(r.Rating))
+}
+
+// RatingHistory is the rating of a player or a squad after a match, along with the rating they had before it.
+type RatingHistory struct {
+	ID            uuid.UUID            `json:"id" bson:"_id"`
+	Subject       RatingSubject        `json:"subject" bson:"subject"`
+	SubjectID     uuid.UUID            `json:"subject_id" bson:"subject_id"`
+	GameID        common.GameIDKey     `json:"game_id" bson:"game_id"`
+	MatchID       uuid.UUID            `json:"match_id" bson:"match_id"`
+	LobbyID       uuid.UUID            `json:"lobby_id" bson:"lobby_id"`
+	Score         float64              `json:"score" bson:"score"` // 1 for a win, 0.5 for a draw, 0 for a loss
+	RatingBefore  float64              `json:"rating_before" bson:"rating_before"`
+	Rating        float64              `json:"rating" bson:"rating"`
+	Deviation     float64              `json:"deviation" bson:"deviation"`
+	Volatility    float64              `json:"volatility" bson:"volatility"`
+	SeasonID      *uuid.UUID           `json:"season_id,omitempty" bson:"season_id,omitempty"` // the season the match was rated in
+	ResourceOwner common.ResourceOwner `json:"resource_owner" bson:"resource_owner"`
+	PlayedAt      time.Time            `json:"played_at" bson:"played_at"`
+	CreatedAt     time.Time            `json:"created_at" bson:"created_at"`
+}
+
+func (h RatingHistory) GetID() uuid.UUID {
+	return h.ID
+}
+
+func RatingHistoryID(subject RatingSubject, subjectID uuid.UUID, matchID uuid.UUID) uuid.UUID {
+	return uuid.NewSHA1(ratingHistoryNamespace, []byte(string(subject)+subjectID.String()+matchID.String()))
+}
+
+// NewRatingHistory records the update of a rating by a match.
+func NewRatingHistory(before Rating, after Rating, matchID uuid.UUID, lobbyID uuid.UUID, score float64, playedAt time.Time, now time.Time) RatingHistory {
+	return RatingHistory{
+		ID:            RatingHistoryID(after.Subject, after.SubjectID, matchID),
+		Subject:       after.Subject,
+		SubjectID:     after.SubjectID,
+		GameID:        after.GameID,
+		MatchID:       matchID,
+		LobbyID:       lobbyID,
+		Score:         score,
+		RatingBefore:  before.Rating,
+		Rating:        after.Rating,
+		Deviation:     after.Deviation,
+		Volatility:    after.Volatility,
+		SeasonID:      after.SeasonID,
+		ResourceOwner: after.ResourceOwner,
+		PlayedAt:      playedAt,
+		CreatedAt:     now,
+	}
+}
@@ -0,0 +1,24 @@
+package rating
+
+import (
+	"fmt"
+
+	"github.com/google/uuid"
+)
+
+// Rating Conflict Error (ratings updated by another match since they were read, these weren't written)
+type RatingConflictError struct {
+	Message   string
+	RatingIDs []uuid.UUID
+}
+
+func (e *RatingConflictError) Error() string {
+	return e.Message
+}
+
+func NewRatingConflictError(ratingIDs []uuid.UUID) *RatingConflictError {
+	return &RatingConflictError{
+		Message:   fmt.Sprintf("%d rating(s) were updated concurrently", len(ratingIDs)),
+		RatingIDs: ratingIDs,
+	}
+}
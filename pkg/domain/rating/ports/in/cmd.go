@@ -0,0 +1,13 @@
+package rating_in
+
+import (
+	"context"
+
+	matchmaking_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/matchmaking/entities"
+)
+
+// RateMatchCommand updates the Glicko-2 ratings of the players (and squads) of a completed match, recording their rating history.
+// Matches already rated are ignored.
+type RateMatchCommand interface {
+	Exec(ctx context.Context, event matchmaking_entities.MatchCompleted) error
+}
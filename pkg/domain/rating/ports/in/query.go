@@ -0,0 +1,10 @@
+package rating_in
+
+import (
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	rating_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/rating/entities"
+)
+
+type RatingHistoryReader interface {
+	common.Searchable[rating_entities.RatingHistory]
+}
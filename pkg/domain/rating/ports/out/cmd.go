@@ -0,0 +1,18 @@
+package rating_out
+
+import (
+	"context"
+
+	rating_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/rating/entities"
+)
+
+type RatingWriter interface {
+	// Upsert writes the ratings in a single unordered bulk (by their stable IDs). A rating is only written when its stored version is the
+	// one it was rated from (Version-1), the others are returned in a RatingConflictError.
+	Upsert(ctx context.Context, ratings []rating_entities.Rating) error
+}
+
+type RatingHistoryWriter interface {
+	// Upsert writes the entries in a single unordered bulk (by their stable IDs), so rating a match twice overwrites its entries.
+	Upsert(ctx context.Context, entries []rating_entities.RatingHistory) error
+}
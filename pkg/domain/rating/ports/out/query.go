@@ -0,0 +1,22 @@
+package rating_out
+
+import (
+	"context"
+
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	rating_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/rating/entities"
+	season_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/season/entities"
+)
+
+type RatingReader interface {
+	common.Searchable[rating_entities.Rating]
+}
+
+type RatingHistoryReader interface {
+	common.Searchable[rating_entities.RatingHistory]
+}
+
+// ActiveSeasonReader reads the season of the game currently active, nil between seasons.
+type ActiveSeasonReader interface {
+	GetActiveSeason(ctx context.Context, gameID common.GameIDKey) (*season_entities.Season, error)
+}
@@ -0,0 +1,54 @@
+package rating_services
+
+import (
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	rating_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/rating/entities"
+	rating_in "github.com/psavelis/team-pro/replay-api/pkg/domain/rating/ports/in"
+	rating_out "github.com/psavelis/team-pro/replay-api/pkg/domain/rating/ports/out"
+)
+
+type RatingHistoryQueryService struct {
+	common.BaseQueryService[rating_entities.RatingHistory]
+}
+
+// NewRatingHistoryQueryService serves the rating history of the players and squads rated by the client application in context.
+func NewRatingHistoryQueryService(historyReader rating_out.RatingHistoryReader) rating_in.RatingHistoryReader {
+	queryableFields := map[string]bool{
+		"ID":            true,
+		"Subject":       true,
+		"SubjectID":     true,
+		"GameID":        true,
+		"MatchID":       true,
+		"LobbyID":       true,
+		"SeasonID":      true,
+		"PlayedAt":      true,
+		"ResourceOwner": common.DENY,
+		"CreatedAt":     true,
+	}
+
+	readableFields := map[string]bool{
+		"ID":            true,
+		"Subject":       true,
+		"SubjectID":     true,
+		"GameID":        true,
+		"MatchID":       true,
+		"LobbyID":       true,
+		"Score":         true,
+		"RatingBefore":  true,
+		"Rating":        true,
+		"Deviation":     true,
+		"Volatility":    true,
+		"SeasonID":      true,
+		"PlayedAt":      true,
+		"ResourceOwner": common.DENY,
+		"CreatedAt":     true,
+	}
+
+	return &common.BaseQueryService[rating_entities.RatingHistory]{
+		Reader:          historyReader.(common.Searchable[rating_entities.RatingHistory]),
+		QueryableFields: queryableFields,
+		ReadableFields:  readableFields,
+		MaxPageSize:     100,
+		Audience:        common.ClientApplicationAudienceIDKey,
+	}
+}
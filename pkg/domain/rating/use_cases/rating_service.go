@@ -0,0 +1,340 @@
+package rating_use_cases
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	matchmaking_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/matchmaking/entities"
+	"github.com/psavelis/team-pro/replay-api/pkg/domain/rating"
+	rating_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/rating/entities"
+	rating_in "github.com/psavelis/team-pro/replay-api/pkg/domain/rating/ports/in"
+	rating_out "github.com/psavelis/team-pro/replay-api/pkg/domain/rating/ports/out"
+	season_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/season/entities"
+	squad_out "github.com/psavelis/team-pro/replay-api/pkg/domain/squad/ports/out"
+)
+
+// RatingService rates the players (and the squads) of every completed match: each team plays the others (as a single opponent, see
+// rating_entities.CompositeOpponent), every match being a Glicko-2 rating period. Matches are rated in the active season of the game:
+// ratings are reset by its rule on their first match in it.
+type RatingService struct {
+	RatingReader  rating_out.RatingReader
+	RatingWriter  rating_out.RatingWriter
+	HistoryReader rating_out.RatingHistoryReader
+	HistoryWriter rating_out.RatingHistoryWriter
+	SquadReader   squad_out.SquadReader
+	SeasonReader  rating_out.ActiveSeasonReader
+}
+
+func NewRatingService(ratingReader rating_out.RatingReader, ratingWriter rating_out.RatingWriter, historyReader rating_out.RatingHistoryReader, historyWriter rating_out.RatingHistoryWriter, squadReader squad_out.SquadReader, seasonReader rating_out.ActiveSeasonReader) rating_in.RateMatchCommand {
+	return &RatingService{
+		RatingReader:  ratingReader,
+		RatingWriter:  ratingWriter,
+		HistoryReader: historyReader,
+		HistoryWriter: historyWriter,
+		SquadReader:   squadReader,
+		SeasonReader:  seasonReader,
+	}
+}
+
+// ratedTeam holds the ratings of a team before the match: its players', and its squad's when it played as one.
+type ratedTeam struct {
+	matchmaking_entities.MatchCompletedTeam
+	players []rating_entities.Rating
+	squad   *rating_entities.Rating
+}
+
+// MaxRateAttempts bounds the attempts to rate a match whose players (or squads) are concurrently rated by another match.
+const MaxRateAttempts = 3
+
+func (usecase *RatingService) Exec(ctx context.Context, event matchmaking_entities.MatchCompleted) error {
+	if event.MatchID == uuid.Nil || len(event.Teams) < 2 {
+		slog.WarnContext(ctx, "completed match can't be rated", "lobbyID", event.LobbyID, "matchID", event.MatchID, "teams", len(event.Teams))
+		return nil
+	}
+
+	for attempt := 1; ; attempt++ {
+		err := usecase.rateMatch(ctx, event)
+
+		var conflict *rating.RatingConflictError
+		if !errors.As(err, &conflict) || attempt == MaxRateAttempts {
+			return err
+		}
+
+		slog.WarnContext(ctx, "ratings updated by another match, rating the match again", "matchID", event.MatchID, "conflicts", len(conflict.RatingIDs), "attempt", attempt)
+	}
+}
+
+// rateMatch rates the subjects of the match not rated by it yet: those with a history entry of the match, or last rated by it (their
+// history wasn't written), keep their current rating.
+func (usecase *RatingService) rateMatch(ctx context.Context, event matchmaking_entities.MatchCompleted) error {
+	subjects := len(event.Teams)
+	for _, team := range event.Teams {
+		subjects += len(team.Players)
+	}
+
+	rated, err := usecase.HistoryReader.Search(ctx, common.NewSearchByValues(ctx, []common.SearchableValue{
+		{Field: "MatchID", Values: []interface{}{event.MatchID}},
+	}, common.NewSearchResultOptions(0, uint(subjects)), common.ClientApplicationAudienceIDKey))
+
+	if err != nil {
+		slog.ErrorContext(ctx, "unable to search the rating history of the match", "matchID", event.MatchID, "err", err)
+		return err
+	}
+
+	ratedBy := make(map[uuid.UUID]bool, len(rated))
+	for _, h := range rated {
+		ratedBy[h.ID] = true
+	}
+
+	season, err := usecase.SeasonReader.GetActiveSeason(ctx, event.GameID)
+	if err != nil {
+		slog.ErrorContext(ctx, "unable to get the active season of the match", "matchID", event.MatchID, "gameID", event.GameID, "err", err)
+		return err
+	}
+
+	now := time.Now().UTC()
+
+	teams, err := usecase.loadTeams(ctx, event, season, now)
+	if err != nil {
+		return err
+	}
+
+	ratings := make([]rating_entities.Rating, 0)
+	history := make([]rating_entities.RatingHistory, 0)
+
+	rate := func(before rating_entities.Rating, score float64, opponents []rating_entities.GameResult) {
+		if ratedBy[rating_entities.RatingHistoryID(before.Subject, before.SubjectID, event.MatchID)] || before.LastMatchID != nil && *before.LastMatchID == event.MatchID {
+			return
+		}
+
+		after := before.Rate(opponents)
+		after.MatchesPlayed++
+		after.Version++
+		after.LastMatchID = &event.MatchID
+		after.UpdatedAt = now
+
+		if after.SeasonID != nil {
+			after.SeasonMatchesPlayed++
+		}
+
+		ratings = append(ratings, after)
+		history = append(history, rating_entities.NewRatingHistory(before, after, event.MatchID, event.LobbyID, score, event.CompletedAt, now))
+	}
+
+	for i, team := range teams {
+		score := matchScore(teams, i)
+
+		opponents := make([]rating_entities.GameResult, 0, len(teams)-1)
+		squadOpponents := make([]rating_entities.GameResult, 0, len(teams)-1)
+
+		for j, opponent := range teams {
+			if i == j {
+				continue
+			}
+
+			s := gameScore(team.Score, opponent.Score)
+			composite := rating_entities.CompositeOpponent(opponent.players)
+
+			opponents = append(opponents, rating_entities.GameResult{Opponent: composite, Score: s})
+
+			// squads are rated against the opposing squad, or the opposing players
+			if opponent.squad != nil {
+				composite = *opponent.squad
+			}
+
+			squadOpponents = append(squadOpponents, rating_entities.GameResult{Opponent: composite, Score: s})
+		}
+
+		for _, player := range team.players {
+			rate(player, score, opponents)
+		}
+
+		if team.squad != nil {
+			rate(*team.squad, score, squadOpponents)
+		}
+	}
+
+	if len(ratings) == 0 {
+		slog.InfoContext(ctx, "match already rated", "matchID", event.MatchID)
+		return nil
+	}
+
+	// ratings are written first (only over the versions they were rated from): the history marks their subjects as rated by the match
+	err = usecase.RatingWriter.Upsert(ctx, ratings)
+
+	var conflict *rating.RatingConflictError
+	if err != nil && !errors.As(err, &conflict) {
+		slog.ErrorContext(ctx, "unable to write ratings", "matchID", event.MatchID, "err", err)
+		return err
+	}
+
+	if conflict != nil {
+		history = withoutConflicts(history, conflict.RatingIDs)
+	}
+
+	if len(history) > 0 {
+		historyErr := usecase.HistoryWriter.Upsert(ctx, history)
+		if historyErr != nil {
+			slog.ErrorContext(ctx, "unable to write rating history", "matchID", event.MatchID, "err", historyErr)
+			return historyErr
+		}
+	}
+
+	if conflict != nil {
+		return conflict
+	}
+
+	slog.InfoContext(ctx, "match rated", "matchID", event.MatchID, "lobbyID", event.LobbyID, "ratings", len(ratings))
+
+	return nil
+}
+
+// withoutConflicts leaves out the history of the ratings that weren't written.
+func withoutConflicts(history []rating_entities.RatingHistory, ratingIDs []uuid.UUID) []rating_entities.RatingHistory {
+	conflicts := make(map[uuid.UUID]bool, len(ratingIDs))
+	for _, id := range ratingIDs {
+		conflicts[id] = true
+	}
+
+	written := make([]rating_entities.RatingHistory, 0, len(history))
+	for _, h := range history {
+		if !conflicts[rating_entities.RatingID(h.Subject, h.SubjectID, h.GameID)] {
+			written = append(written, h)
+		}
+	}
+
+	return written
+}
+
+// loadTeams reads the current ratings of the players and squads of the match, unrated ones get the initial rating. Ratings are reset
+// for the season (when one is active) on their first match in it.
+func (usecase *RatingService) loadTeams(ctx context.Context, event matchmaking_entities.MatchCompleted, season *season_entities.Season, now time.Time) ([]ratedTeam, error) {
+	playerIDs := make([]interface{}, 0)
+	squadIDs := make([]interface{}, 0)
+	squads := make(map[uuid.UUID]bool)
+
+	for _, team := range event.Teams {
+		for _, p := range team.Players {
+			playerIDs = append(playerIDs, p.PlayerID)
+		}
+
+		if team.SquadID == nil {
+			continue
+		}
+
+		ok, err := usecase.isSquadTeam(ctx, event, team)
+		if err != nil {
+			return nil, err
+		}
+
+		if !ok {
+			slog.WarnContext(ctx, "team didn't play as its squad, squad not rated", "matchID", event.MatchID, "squadID", team.SquadID)
+			continue
+		}
+
+		squads[*team.SquadID] = true
+		squadIDs = append(squadIDs, *team.SquadID)
+	}
+
+	current := make(map[uuid.UUID]rating_entities.Rating)
+
+	for subject, ids := range map[rating_entities.RatingSubject][]interface{}{rating_entities.RatingSubjectPlayer: playerIDs, rating_entities.RatingSubjectSquad: squadIDs} {
+		if len(ids) == 0 {
+			continue
+		}
+
+		ratings, err := usecase.RatingReader.Search(ctx, common.NewSearchByValues(ctx, []common.SearchableValue{
+			{Field: "Subject", Values: []interface{}{subject}},
+			{Field: "GameID", Values: []interface{}{event.GameID}},
+			{Field: "SubjectID", Values: ids, Operator: common.InOperator},
+		}, common.NewSearchResultOptions(0, uint(len(ids))), common.ClientApplicationAudienceIDKey))
+
+		if err != nil {
+			slog.ErrorContext(ctx, "unable to search ratings", "matchID", event.MatchID, "subject", subject, "err", err)
+			return nil, err
+		}
+
+		for _, r := range ratings {
+			current[r.ID] = r
+		}
+	}
+
+	get := func(subject rating_entities.RatingSubject, subjectID uuid.UUID) rating_entities.Rating {
+		r, ok := current[rating_entities.RatingID(subject, subjectID, event.GameID)]
+		if !ok {
+			r = rating_entities.NewRating(subject, subjectID, event.GameID, event.ResourceOwner, now)
+		}
+
+		if season != nil {
+			r = season.ResetRating(r)
+		}
+
+		return r
+	}
+
+	teams := make([]ratedTeam, 0, len(event.Teams))
+	for _, team := range event.Teams {
+		rt := ratedTeam{MatchCompletedTeam: team, players: make([]rating_entities.Rating, 0, len(team.Players))}
+
+		for _, p := range team.Players {
+			rt.players = append(rt.players, get(rating_entities.RatingSubjectPlayer, p.PlayerID))
+		}
+
+		if team.SquadID != nil && squads[*team.SquadID] {
+			squad := get(rating_entities.RatingSubjectSquad, *team.SquadID)
+			rt.squad = &squad
+		}
+
+		teams = append(teams, rt)
+	}
+
+	return teams, nil
+}
+
+// isSquadTeam reports whether the squad reported for the team plays the game of the match, with all the players of the team in it.
+func (usecase *RatingService) isSquadTeam(ctx context.Context, event matchmaking_entities.MatchCompleted, team matchmaking_entities.MatchCompletedTeam) (bool, error) {
+	squads, err := usecase.SquadReader.Search(ctx, common.NewSearchByID(ctx, *team.SquadID, common.ClientApplicationAudienceIDKey))
+	if err != nil {
+		slog.ErrorContext(ctx, "unable to search squad", "squadID", team.SquadID, "err", err)
+		return false, err
+	}
+
+	if len(squads) == 0 || squads[0].GameID != event.GameID || len(team.Players) == 0 {
+		return false, nil
+	}
+
+	for _, p := range team.Players {
+		if !squads[0].IsMember(p.UserID) {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+
+// matchScore is the score of a team in the match: the average of its games against each other team.
+func matchScore(teams []ratedTeam, index int) float64 {
+	var score float64
+	for i, team := range teams {
+		if i != index {
+			score += gameScore(teams[index].Score, team.Score)
+		}
+	}
+
+	return score / float64(len(teams)-1)
+}
+
+func gameScore(score int, opponentScore int) float64 {
+	switch {
+	case score > opponentScore:
+		return 1
+	case score < opponentScore:
+		return 0
+	default:
+		return 0.5
+	}
+}
@@ -0,0 +1,320 @@
+package rating_use_cases_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	matchmaking_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/matchmaking/entities"
+	"github.com/psavelis/team-pro/replay-api/pkg/domain/rating"
+	rating_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/rating/entities"
+	rating_use_cases "github.com/psavelis/team-pro/replay-api/pkg/domain/rating/use_cases"
+	season_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/season/entities"
+	squad_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/squad/entities"
+	squad_value_objects "github.com/psavelis/team-pro/replay-api/pkg/domain/squad/value-objects"
+	"github.com/stretchr/testify/assert"
+)
+
+// mockRatingStore keeps the ratings and the history by ID, searches return all of them (the use case looks them up by ID).
+type mockRatingStore struct {
+	ratings map[uuid.UUID]rating_entities.Rating
+	history map[uuid.UUID]rating_entities.RatingHistory
+	// concurrently runs before the next upsert (ie: another match rating the same players)
+	concurrently func()
+}
+
+func newMockRatingStore(ratings ...rating_entities.Rating) *mockRatingStore {
+	m := &mockRatingStore{ratings: make(map[uuid.UUID]rating_entities.Rating), history: make(map[uuid.UUID]rating_entities.RatingHistory)}
+	for _, r := range ratings {
+		m.ratings[r.ID] = r
+	}
+
+	return m
+}
+
+func (m *mockRatingStore) Search(ctx context.Context, s common.Search) ([]rating_entities.Rating, error) {
+	ratings := make([]rating_entities.Rating, 0, len(m.ratings))
+	for _, r := range m.ratings {
+		ratings = append(ratings, r)
+	}
+
+	return ratings, nil
+}
+
+func (m *mockRatingStore) Compile(ctx context.Context, searchParams []common.SearchAggregation, resultOptions common.SearchResultOptions) (*common.Search, error) {
+	return nil, nil
+}
+
+func (m *mockRatingStore) Upsert(ctx context.Context, ratings []rating_entities.Rating) error {
+	if m.concurrently != nil {
+		m.concurrently()
+		m.concurrently = nil
+	}
+
+	conflicts := make([]uuid.UUID, 0)
+	for _, r := range ratings {
+		if m.ratings[r.ID].Version != r.Version-1 {
+			conflicts = append(conflicts, r.ID)
+			continue
+		}
+
+		m.ratings[r.ID] = r
+	}
+
+	if len(conflicts) > 0 {
+		return rating.NewRatingConflictError(conflicts)
+	}
+
+	return nil
+}
+
+type mockRatingHistoryStore struct {
+	store *mockRatingStore
+}
+
+func (m mockRatingHistoryStore) Search(ctx context.Context, s common.Search) ([]rating_entities.RatingHistory, error) {
+	history := make([]rating_entities.RatingHistory, 0, len(m.store.history))
+	for _, h := range m.store.history {
+		history = append(history, h)
+	}
+
+	return history, nil
+}
+
+func (m mockRatingHistoryStore) Compile(ctx context.Context, searchParams []common.SearchAggregation, resultOptions common.SearchResultOptions) (*common.Search, error) {
+	return nil, nil
+}
+
+func (m mockRatingHistoryStore) Upsert(ctx context.Context, entries []rating_entities.RatingHistory) error {
+	for _, h := range entries {
+		m.store.history[h.ID] = h
+	}
+
+	return nil
+}
+
+type mockSquadReader struct {
+	squads []squad_entities.Squad
+}
+
+func (m *mockSquadReader) Search(ctx context.Context, s common.Search) ([]squad_entities.Squad, error) {
+	id := s.SearchParams[0].Params[0].ValueParams[0].Values[0]
+
+	for _, squad := range m.squads {
+		if squad.ID == id {
+			return []squad_entities.Squad{squad}, nil
+		}
+	}
+
+	return nil, nil
+}
+
+func (m *mockSquadReader) Compile(ctx context.Context, searchParams []common.SearchAggregation, resultOptions common.SearchResultOptions) (*common.Search, error) {
+	return nil, nil
+}
+
+type mockActiveSeasonReader struct {
+	season *season_entities.Season
+}
+
+func (m mockActiveSeasonReader) GetActiveSeason(ctx context.Context, gameID common.GameIDKey) (*season_entities.Season, error) {
+	return m.season, nil
+}
+
+func newTeam(team matchmaking_entities.LobbyTeam, score int, players int) matchmaking_entities.MatchCompletedTeam {
+	t := matchmaking_entities.MatchCompletedTeam{Team: team, Score: score}
+	for i := 0; i < players; i++ {
+		t.Players = append(t.Players, matchmaking_entities.MatchCompletedPlayer{PlayerID: uuid.New(), UserID: uuid.New()})
+	}
+
+	return t
+}
+
+func TestRatingService_Exec(t *testing.T) {
+	ctx := common.WithResourceOwner(context.Background(), common.ResourceOwner{TenantID: common.TeamPROTenantID, ClientID: common.ServerClientID})
+
+	winners := newTeam(matchmaking_entities.LobbyTeamA, 13, 2)
+	losers := newTeam(matchmaking_entities.LobbyTeamB, 7, 2)
+
+	// the winners played as their squad, the losers reported a squad they aren't members of
+	squad := squad_entities.Squad{ID: uuid.New(), GameID: common.CS2_GAME_ID, Profiles: map[string]squad_value_objects.Profile{
+		squad_entities.MemberKey(winners.Players[0].UserID): {},
+		squad_entities.MemberKey(winners.Players[1].UserID): {},
+	}}
+
+	otherSquad := squad_entities.Squad{ID: uuid.New(), GameID: common.CS2_GAME_ID}
+
+	winners.SquadID = &squad.ID
+	losers.SquadID = &otherSquad.ID
+
+	// the first loser is a veteran, rated higher than the rest
+	veteran := rating_entities.NewRating(rating_entities.RatingSubjectPlayer, losers.Players[0].PlayerID, common.CS2_GAME_ID, common.ResourceOwner{}, time.Now())
+	veteran.Rating = 1400
+	veteran.Deviation = 60
+	veteran.MatchesPlayed = 80
+
+	store := newMockRatingStore(veteran)
+	history := mockRatingHistoryStore{store: store}
+
+	service := rating_use_cases.NewRatingService(store, store, history, history, &mockSquadReader{squads: []squad_entities.Squad{squad, otherSquad}}, mockActiveSeasonReader{})
+
+	event := matchmaking_entities.MatchCompleted{
+		MatchID:     uuid.New(),
+		LobbyID:     uuid.New(),
+		GameID:      common.CS2_GAME_ID,
+		Teams:       []matchmaking_entities.MatchCompletedTeam{winners, losers},
+		CompletedAt: time.Now().UTC(),
+	}
+
+	err := service.Exec(ctx, event)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	// 4 players and the winning squad
+	assert.Len(t, store.ratings, 5)
+	assert.Len(t, store.history, 5)
+
+	rating := func(subject rating_entities.RatingSubject, id uuid.UUID) rating_entities.Rating {
+		return store.ratings[rating_entities.RatingID(subject, id, common.CS2_GAME_ID)]
+	}
+
+	winner := rating(rating_entities.RatingSubjectPlayer, winners.Players[0].PlayerID)
+	assert.Greater(t, winner.Rating, float64(rating_entities.InitialRating))
+	assert.Less(t, winner.Deviation, float64(rating_entities.InitialDeviation))
+	assert.Equal(t, 1, winner.MatchesPlayed)
+
+	// the veteran's rating moves far less than the new players'
+	ratedVeteran := rating(rating_entities.RatingSubjectPlayer, veteran.SubjectID)
+	newLoser := rating(rating_entities.RatingSubjectPlayer, losers.Players[1].PlayerID)
+	assert.Less(t, ratedVeteran.Rating, 1400.0)
+	assert.Less(t, 1400-ratedVeteran.Rating, float64(rating_entities.InitialRating)-newLoser.Rating)
+	assert.Equal(t, 81, ratedVeteran.MatchesPlayed)
+
+	squadRating := rating(rating_entities.RatingSubjectSquad, squad.ID)
+	assert.Greater(t, squadRating.Rating, float64(rating_entities.InitialRating))
+	assert.NotContains(t, store.ratings, rating_entities.RatingID(rating_entities.RatingSubjectSquad, otherSquad.ID, common.CS2_GAME_ID))
+
+	entry := store.history[rating_entities.RatingHistoryID(rating_entities.RatingSubjectPlayer, veteran.SubjectID, event.MatchID)]
+	assert.Equal(t, 1400.0, entry.RatingBefore)
+	assert.Equal(t, ratedVeteran.Rating, entry.Rating)
+	assert.Equal(t, 0.0, entry.Score)
+	assert.Equal(t, event.LobbyID, entry.LobbyID)
+
+	// a redelivered event isn't rated twice
+	err = service.Exec(ctx, event)
+	assert.NoError(t, err)
+	assert.Equal(t, ratedVeteran, rating(rating_entities.RatingSubjectPlayer, veteran.SubjectID))
+}
+
+func TestRatingService_Exec_Season(t *testing.T) {
+	ctx := common.WithResourceOwner(context.Background(), common.ResourceOwner{TenantID: common.TeamPROTenantID, ClientID: common.ServerClientID})
+
+	winners := newTeam(matchmaking_entities.LobbyTeamA, 13, 1)
+	losers := newTeam(matchmaking_entities.LobbyTeamB, 7, 1)
+
+	season := &season_entities.Season{
+		ID:     uuid.New(),
+		GameID: common.CS2_GAME_ID,
+		Status: season_entities.SeasonStatusActive,
+		Reset:  season_entities.ResetRule{Mode: season_entities.ResetModeSoft, Factor: 0.5, Deviation: 250},
+	}
+
+	// the veteran played the previous season
+	previousSeasonID := uuid.New()
+	veteran := rating_entities.NewRating(rating_entities.RatingSubjectPlayer, losers.Players[0].PlayerID, common.CS2_GAME_ID, common.ResourceOwner{}, time.Now())
+	veteran.Rating = 1400
+	veteran.Deviation = 60
+	veteran.MatchesPlayed = 80
+	veteran.SeasonID = &previousSeasonID
+	veteran.SeasonMatchesPlayed = 40
+
+	store := newMockRatingStore(veteran)
+	history := mockRatingHistoryStore{store: store}
+
+	service := rating_use_cases.NewRatingService(store, store, history, history, &mockSquadReader{}, mockActiveSeasonReader{season: season})
+
+	event := matchmaking_entities.MatchCompleted{
+		MatchID:     uuid.New(),
+		LobbyID:     uuid.New(),
+		GameID:      common.CS2_GAME_ID,
+		Teams:       []matchmaking_entities.MatchCompletedTeam{winners, losers},
+		CompletedAt: time.Now().UTC(),
+	}
+
+	err := service.Exec(ctx, event)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	// soft reset: half way back to the initial rating, with the reset deviation
+	entry := store.history[rating_entities.RatingHistoryID(rating_entities.RatingSubjectPlayer, veteran.SubjectID, event.MatchID)]
+	assert.Equal(t, 1200.0, entry.RatingBefore)
+	assert.Equal(t, &season.ID, entry.SeasonID)
+
+	ratedVeteran := store.ratings[veteran.ID]
+	assert.Equal(t, &season.ID, ratedVeteran.SeasonID)
+	assert.Equal(t, 1, ratedVeteran.SeasonMatchesPlayed)
+	assert.Equal(t, 81, ratedVeteran.MatchesPlayed)
+
+	// new players enter the season at the initial rating
+	winner := store.ratings[rating_entities.RatingID(rating_entities.RatingSubjectPlayer, winners.Players[0].PlayerID, common.CS2_GAME_ID)]
+	assert.Equal(t, &season.ID, winner.SeasonID)
+	assert.Equal(t, 1, winner.SeasonMatchesPlayed)
+}
+
+func TestRatingService_Exec_ConcurrentMatch(t *testing.T) {
+	ctx := common.WithResourceOwner(context.Background(), common.ResourceOwner{TenantID: common.TeamPROTenantID, ClientID: common.ServerClientID})
+
+	winners := newTeam(matchmaking_entities.LobbyTeamA, 13, 2)
+	losers := newTeam(matchmaking_entities.LobbyTeamB, 7, 2)
+
+	store := newMockRatingStore()
+	history := mockRatingHistoryStore{store: store}
+
+	service := rating_use_cases.NewRatingService(store, store, history, history, &mockSquadReader{}, mockActiveSeasonReader{})
+
+	event := matchmaking_entities.MatchCompleted{
+		MatchID:     uuid.New(),
+		LobbyID:     uuid.New(),
+		GameID:      common.CS2_GAME_ID,
+		Teams:       []matchmaking_entities.MatchCompletedTeam{winners, losers},
+		CompletedAt: time.Now().UTC(),
+	}
+
+	// another match rates the first winner after their rating was read
+	otherMatchID := uuid.New()
+	contestedID := rating_entities.RatingID(rating_entities.RatingSubjectPlayer, winners.Players[0].PlayerID, common.CS2_GAME_ID)
+
+	store.concurrently = func() {
+		r := rating_entities.NewRating(rating_entities.RatingSubjectPlayer, winners.Players[0].PlayerID, common.CS2_GAME_ID, common.ResourceOwner{}, time.Now())
+		r.Rating = 1100
+		r.MatchesPlayed = 1
+		r.Version = 1
+		r.LastMatchID = &otherMatchID
+		store.ratings[contestedID] = r
+	}
+
+	err := service.Exec(ctx, event)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	// the update of the other match isn't lost, and the players rated by the first attempt aren't rated twice
+	contested := store.ratings[contestedID]
+	assert.Equal(t, 2, contested.MatchesPlayed)
+	assert.Equal(t, 2, contested.Version)
+	assert.Greater(t, contested.Rating, 1100.0)
+	assert.Equal(t, &event.MatchID, contested.LastMatchID)
+
+	for _, p := range append(winners.Players[1:], losers.Players...) {
+		r := store.ratings[rating_entities.RatingID(rating_entities.RatingSubjectPlayer, p.PlayerID, common.CS2_GAME_ID)]
+		assert.Equal(t, 1, r.MatchesPlayed)
+		assert.Equal(t, 1, r.Version)
+	}
+
+	assert.Len(t, store.history, 4)
+	assert.Equal(t, 1100.0, store.history[rating_entities.RatingHistoryID(rating_entities.RatingSubjectPlayer, winners.Players[0].PlayerID, event.MatchID)].RatingBefore)
+}
@@ -0,0 +1,226 @@
+package recap_entities
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	replay_entity "github.com/psavelis/team-pro/replay-api/pkg/domain/replay/entities"
+)
+
+type RecapSubject string
+
+const (
+	RecapSubjectPlayer RecapSubject = "player"
+	RecapSubjectSquad  RecapSubject = "squad"
+)
+
+// weeklyRecapNamespace keeps WeeklyRecap IDs stable (subject+week), so a recap is generated once per subject and week.
+var weeklyRecapNamespace = uuid.MustParse("3c9e1d7a-5b2f-4e8c-b6a4-91d0f2e7c358")
+
+// RecapMatch is a match played in the week by the player (or a member of the squad).
+type RecapMatch struct {
+	MatchID    uuid.UUID                  `json:"match_id" bson:"match_id"`
+	Outcome    replay_entity.MatchOutcome `json:"outcome" bson:"outcome"`
+	Rating     *float64                   `json:"rating,omitempty" bson:"rating"` // nil when the replay of the match wasn't rated
+	Payout     *replay_entity.MatchPayout `json:"payout,omitempty" bson:"payout"`
+	Highlights []replay_entity.Highlight  `json:"highlights" bson:"highlights"` // of the player only
+	PlayedAt   time.Time                  `json:"played_at" bson:"played_at"`
+}
+
+// PlayerActivity holds the matches a user played in a week (through the players linked to the user).
+type PlayerActivity struct {
+	UserID        uuid.UUID            `json:"user_id" bson:"_id"`
+	Matches       []RecapMatch         `json:"matches" bson:"matches"`
+	ResourceOwner common.ResourceOwner `json:"resource_owner" bson:"resource_owner"`
+}
+
+// SquadRoster holds the members of a squad, its recap sums up their matches.
+type SquadRoster struct {
+	SquadID       uuid.UUID            `json:"squad_id" bson:"_id"`
+	Name          string               `json:"name" bson:"name"`
+	UserIDs       []uuid.UUID          `json:"user_ids" bson:"user_ids"`
+	ResourceOwner common.ResourceOwner `json:"resource_owner" bson:"resource_owner"`
+}
+
+// RecapHighlight is the best highlight of the week, along with its match (for the playback to be deep-linked to it).
+type RecapHighlight struct {
+	MatchID   uuid.UUID               `json:"match_id" bson:"match_id"`
+	Highlight replay_entity.Highlight `json:"highlight" bson:"highlight"`
+}
+
+// Earnings are the payouts of the week in a currency.
+type Earnings struct {
+	Amount   int64  `json:"amount" bson:"amount"` // minor units (ie: cents)
+	Currency string `json:"currency" bson:"currency"`
+}
+
+// WeeklyRecap sums up the week (monday to sunday, UTC) of a player or a squad: their matches and outcomes, their average match rating
+// compared to the previous week, their best highlight and their earnings.
+type WeeklyRecap struct {
+	ID            uuid.UUID            `json:"id" bson:"_id"`
+	Subject       RecapSubject         `json:"subject" bson:"subject"`
+	SubjectID     uuid.UUID            `json:"subject_id" bson:"subject_id"` // the user of a player, or the squad
+	Name          string               `json:"name,omitempty" bson:"name"`   // of the squad
+	WeekStart     time.Time            `json:"week_start" bson:"week_start"`
+	MatchesPlayed int                  `json:"matches_played" bson:"matches_played"`
+	Wins          int                  `json:"wins" bson:"wins"`
+	Losses        int                  `json:"losses" bson:"losses"`
+	Draws         int                  `json:"draws" bson:"draws"`
+	Rating        *float64             `json:"rating,omitempty" bson:"rating"`             // average of the rated matches, nil when none was rated
+	RatingDelta   *float64             `json:"rating_delta,omitempty" bson:"rating_delta"` // since the previous week
+	BestHighlight *RecapHighlight      `json:"best_highlight,omitempty" bson:"best_highlight"`
+	Earnings      []Earnings           `json:"earnings" bson:"earnings"`
+	UserIDs       []uuid.UUID          `json:"-" bson:"user_ids"` // notified of the recap
+	ResourceOwner common.ResourceOwner `json:"resource_owner" bson:"resource_owner"`
+	CreatedAt     time.Time            `json:"created_at" bson:"created_at"`
+	UpdatedAt     time.Time            `json:"updated_at" bson:"updated_at"`
+}
+
+func (r WeeklyRecap) GetID() uuid.UUID {
+	return r.ID
+}
+
+func WeeklyRecapID(subject RecapSubject, subjectID uuid.UUID, weekStart time.Time) uuid.UUID {
+	return uuid.NewSHA1(weeklyRecapNamespace, []byte(string(subject)+subjectID.String()+weekStart.UTC().Format(time.DateOnly)))
+}
+
+// NewWeeklyRecap sums up the matches of a subject in the week. The rating delta is only set when the recap of the previous week (when
+// the subject played in it) was rated too.
+func NewWeeklyRecap(subject RecapSubject, subjectID uuid.UUID, weekStart time.Time, matches []RecapMatch, previous *WeeklyRecap, owner common.ResourceOwner, now time.Time) *WeeklyRecap {
+	recap := &WeeklyRecap{
+		ID:            WeeklyRecapID(subject, subjectID, weekStart),
+		Subject:       subject,
+		SubjectID:     subjectID,
+		WeekStart:     weekStart,
+		MatchesPlayed: len(matches),
+		Earnings:      make([]Earnings, 0),
+		UserIDs:       make([]uuid.UUID, 0),
+		ResourceOwner: owner,
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	}
+
+	ratingSum, rated := 0.0, 0
+	earnings := make(map[string]int64)
+
+	for _, match := range matches {
+		switch match.Outcome {
+		case replay_entity.MatchOutcomeWin:
+			recap.Wins++
+		case replay_entity.MatchOutcomeLoss:
+			recap.Losses++
+		case replay_entity.MatchOutcomeDraw:
+			recap.Draws++
+		}
+
+		if match.Rating != nil {
+			ratingSum += *match.Rating
+			rated++
+		}
+
+		if match.Payout != nil {
+			earnings[match.Payout.Currency] += match.Payout.Amount
+		}
+
+		for _, highlight := range match.Highlights {
+			if recap.BestHighlight == nil || HighlightScore(highlight) > HighlightScore(recap.BestHighlight.Highlight) {
+				recap.BestHighlight = &RecapHighlight{MatchID: match.MatchID, Highlight: highlight}
+			}
+		}
+	}
+
+	if rated > 0 {
+		rating := math.Round(ratingSum/float64(rated)*100) / 100
+		recap.Rating = &rating
+
+		if previous != nil && previous.Rating != nil {
+			delta := math.Round((rating-*previous.Rating)*100) / 100
+			recap.RatingDelta = &delta
+		}
+	}
+
+	for currency, amount := range earnings {
+		recap.Earnings = append(recap.Earnings, Earnings{Amount: amount, Currency: currency})
+	}
+
+	sort.Slice(recap.Earnings, func(i, j int) bool {
+		return recap.Earnings[i].Currency < recap.Earnings[j].Currency
+	})
+
+	return recap
+}
+
+// SquadMatches merges the matches of the members of a squad: a match played by several members counts once, rated by the average of
+// their ratings, paid the sum of their payouts and with the highlights of all of them.
+func SquadMatches(members [][]RecapMatch) []RecapMatch {
+	type rated struct {
+		sum   float64
+		count int
+	}
+
+	matches := make([]RecapMatch, 0)
+	index := make(map[uuid.UUID]int)
+	ratings := make(map[uuid.UUID]*rated)
+
+	for _, memberMatches := range members {
+		for _, match := range memberMatches {
+			i, ok := index[match.MatchID]
+			if !ok {
+				i = len(matches)
+				index[match.MatchID] = i
+				ratings[match.MatchID] = &rated{}
+
+				matches = append(matches, RecapMatch{
+					MatchID:    match.MatchID,
+					Outcome:    match.Outcome,
+					Highlights: make([]replay_entity.Highlight, 0),
+					PlayedAt:   match.PlayedAt,
+				})
+			}
+
+			if match.Payout != nil {
+				if matches[i].Payout == nil {
+					matches[i].Payout = &replay_entity.MatchPayout{Currency: match.Payout.Currency}
+				}
+
+				// payouts in another currency than the first member's are left out
+				if matches[i].Payout.Currency == match.Payout.Currency {
+					matches[i].Payout.Amount += match.Payout.Amount
+				}
+			}
+
+			matches[i].Highlights = append(matches[i].Highlights, match.Highlights...)
+
+			if match.Rating != nil {
+				ratings[match.MatchID].sum += *match.Rating
+				ratings[match.MatchID].count++
+			}
+		}
+	}
+
+	for i := range matches {
+		if r := ratings[matches[i].MatchID]; r.count > 0 {
+			rating := math.Round(r.sum/float64(r.count)*100) / 100
+			matches[i].Rating = &rating
+		}
+	}
+
+	return matches
+}
+
+// HighlightScore ranks the highlights: aces first, then clutches, then multi-kills (the most kills, then the most opponents first).
+func HighlightScore(h replay_entity.Highlight) int {
+	score := h.Kills*10 + h.Opponents
+
+	switch h.Type {
+	case replay_entity.HighlightTypeAce:
+		score += 1000
+	case replay_entity.HighlightTypeClutch:
+		score += 100
+	}
+
+	return score
+}
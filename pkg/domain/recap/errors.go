@@ -0,0 +1,16 @@
+package recap
+
+// Weekly Recap Not Found Error (no match played in the weeks recapped so far)
+type WeeklyRecapNotFoundError struct {
+	Message string
+}
+
+func (e *WeeklyRecapNotFoundError) Error() string {
+	return e.Message
+}
+
+func NewWeeklyRecapNotFoundError() *WeeklyRecapNotFoundError {
+	return &WeeklyRecapNotFoundError{
+		Message: "no weekly recap yet",
+	}
+}
@@ -0,0 +1,14 @@
+package recap_in
+
+import (
+	"context"
+	"time"
+
+	recap_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/recap/entities"
+)
+
+// GenerateWeeklyRecapsCommand generates the recaps of the last week closed at `now` for the players and squads that played in it,
+// returning the ones generated by this run (a recap is generated once).
+type GenerateWeeklyRecapsCommand interface {
+	Exec(ctx context.Context, now time.Time) ([]recap_entities.WeeklyRecap, error)
+}
@@ -0,0 +1,12 @@
+package recap_in
+
+import (
+	"context"
+
+	recap_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/recap/entities"
+)
+
+// LatestPlayerRecapQuery returns the latest weekly recap of the user in context, a *recap.WeeklyRecapNotFoundError when they have none.
+type LatestPlayerRecapQuery interface {
+	Exec(ctx context.Context) (*recap_entities.WeeklyRecap, error)
+}
@@ -0,0 +1,11 @@
+package recap_out
+
+import (
+	"context"
+
+	recap_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/recap/entities"
+)
+
+type WeeklyRecapWriter interface {
+	Create(ctx context.Context, recap *recap_entities.WeeklyRecap) (*recap_entities.WeeklyRecap, error)
+}
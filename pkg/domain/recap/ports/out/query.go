@@ -0,0 +1,22 @@
+package recap_out
+
+import (
+	"context"
+	"time"
+
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	recap_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/recap/entities"
+)
+
+type WeeklyRecapReader interface {
+	common.Searchable[recap_entities.WeeklyRecap]
+}
+
+type RecapActivityReader interface {
+	// GetPlayerActivity returns the matches played from `from` (inclusive) to `to` by every user (of the tenant in context) linked to a
+	// player, along with their match rating and highlights.
+	GetPlayerActivity(ctx context.Context, from time.Time, to time.Time) ([]recap_entities.PlayerActivity, error)
+
+	// GetSquadRosters returns the squads (of the tenant in context) with their members.
+	GetSquadRosters(ctx context.Context) ([]recap_entities.SquadRoster, error)
+}
@@ -0,0 +1,205 @@
+package recap_use_cases
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	recap_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/recap/entities"
+	recap_in "github.com/psavelis/team-pro/replay-api/pkg/domain/recap/ports/in"
+	recap_out "github.com/psavelis/team-pro/replay-api/pkg/domain/recap/ports/out"
+)
+
+const (
+	Day  = 24 * time.Hour
+	Week = 7 * Day
+
+	// RecapPageSize sizes the pages of recaps read by ID.
+	RecapPageSize = 100
+)
+
+// recapSubject is a player or squad to recap.
+type recapSubject struct {
+	subject recap_entities.RecapSubject
+	id      uuid.UUID
+	name    string
+	matches []recap_entities.RecapMatch
+	userIDs []uuid.UUID
+	owner   common.ResourceOwner
+}
+
+type GenerateWeeklyRecapsUseCase struct {
+	ActivityReader recap_out.RecapActivityReader
+	RecapWriter    recap_out.WeeklyRecapWriter
+	RecapReader    recap_out.WeeklyRecapReader
+}
+
+func NewGenerateWeeklyRecapsUseCase(activityReader recap_out.RecapActivityReader, recapWriter recap_out.WeeklyRecapWriter, recapReader recap_out.WeeklyRecapReader) recap_in.GenerateWeeklyRecapsCommand {
+	return &GenerateWeeklyRecapsUseCase{
+		ActivityReader: activityReader,
+		RecapWriter:    recapWriter,
+		RecapReader:    recapReader,
+	}
+}
+
+// Exec recaps the players and squads with matches in the week, on behalf of the client application of each (so they're notified
+// on the devices registered with it): the subjects already recapped are skipped, so later runs only add the subjects of the matches
+// projected late.
+func (usecase *GenerateWeeklyRecapsUseCase) Exec(ctx context.Context, now time.Time) ([]recap_entities.WeeklyRecap, error) {
+	weekStart := WeekStart(now).Add(-Week)
+
+	activity, err := usecase.ActivityReader.GetPlayerActivity(ctx, weekStart, weekStart.Add(Week))
+	if err != nil {
+		slog.ErrorContext(ctx, "unable to read player activity for weekly recaps", "weekStart", weekStart, "err", err)
+		return nil, err
+	}
+
+	rosters, err := usecase.ActivityReader.GetSquadRosters(ctx)
+	if err != nil {
+		slog.ErrorContext(ctx, "unable to read squad rosters for weekly recaps", "err", err)
+		return nil, err
+	}
+
+	reso := common.GetResourceOwner(ctx)
+	now = now.UTC()
+
+	players := make(map[uuid.UUID]recap_entities.PlayerActivity, len(activity))
+	subjects := make([]recapSubject, 0, len(activity))
+
+	for _, a := range activity {
+		players[a.UserID] = a
+
+		subjects = append(subjects, recapSubject{
+			subject: recap_entities.RecapSubjectPlayer,
+			id:      a.UserID,
+			matches: a.Matches,
+			userIDs: []uuid.UUID{a.UserID},
+			owner:   common.ResourceOwner{TenantID: reso.TenantID, ClientID: clientOf(a.ResourceOwner, reso), UserID: a.UserID},
+		})
+	}
+
+	for _, roster := range rosters {
+		members := make([][]recap_entities.RecapMatch, 0, len(roster.UserIDs))
+		for _, userID := range roster.UserIDs {
+			if a, ok := players[userID]; ok {
+				members = append(members, a.Matches)
+			}
+		}
+
+		if len(members) == 0 {
+			continue
+		}
+
+		subjects = append(subjects, recapSubject{
+			subject: recap_entities.RecapSubjectSquad,
+			id:      roster.SquadID,
+			name:    roster.Name,
+			matches: recap_entities.SquadMatches(members),
+			userIDs: roster.UserIDs,
+			owner:   common.ResourceOwner{TenantID: reso.TenantID, ClientID: clientOf(roster.ResourceOwner, reso), UserID: roster.ResourceOwner.UserID},
+		})
+	}
+
+	currentIDs := make([]uuid.UUID, len(subjects))
+	previousIDs := make([]uuid.UUID, len(subjects))
+
+	// recaps are read on behalf of the client owning them
+	idsByClient := make(map[uuid.UUID][]uuid.UUID)
+
+	for i, s := range subjects {
+		currentIDs[i] = recap_entities.WeeklyRecapID(s.subject, s.id, weekStart)
+		previousIDs[i] = recap_entities.WeeklyRecapID(s.subject, s.id, weekStart.Add(-Week))
+
+		idsByClient[s.owner.ClientID] = append(idsByClient[s.owner.ClientID], currentIDs[i], previousIDs[i])
+	}
+
+	recaps := make(map[uuid.UUID]recap_entities.WeeklyRecap)
+
+	for clientID, ids := range idsByClient {
+		clientCtx := common.WithResourceOwner(ctx, common.ResourceOwner{TenantID: reso.TenantID, ClientID: clientID})
+
+		err = usecase.getRecaps(clientCtx, ids, recaps)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	generated := make([]recap_entities.WeeklyRecap, 0)
+
+	for i, s := range subjects {
+		if _, ok := recaps[currentIDs[i]]; ok {
+			continue
+		}
+
+		var last *recap_entities.WeeklyRecap
+		if p, ok := recaps[previousIDs[i]]; ok {
+			last = &p
+		}
+
+		recap := recap_entities.NewWeeklyRecap(s.subject, s.id, weekStart, s.matches, last, s.owner, now)
+		recap.Name = s.name
+		recap.UserIDs = s.userIDs
+
+		recap, err = usecase.RecapWriter.Create(common.WithResourceOwner(ctx, s.owner), recap)
+		if err != nil {
+			slog.ErrorContext(ctx, "unable to create weekly recap", "subject", s.subject, "subjectID", s.id, "weekStart", weekStart, "err", err)
+			return generated, err
+		}
+
+		generated = append(generated, *recap)
+	}
+
+	slog.InfoContext(ctx, "weekly recaps generated", "weekStart", weekStart, "players", len(activity), "generated", len(generated))
+
+	return generated, nil
+}
+
+// getRecaps reads the recaps of the client in context by their IDs into recaps.
+func (usecase *GenerateWeeklyRecapsUseCase) getRecaps(ctx context.Context, ids []uuid.UUID, recaps map[uuid.UUID]recap_entities.WeeklyRecap) error {
+	for start := 0; start < len(ids); start += RecapPageSize {
+		end := min(start+RecapPageSize, len(ids))
+
+		values := make([]interface{}, 0, end-start)
+		for _, id := range ids[start:end] {
+			values = append(values, id)
+		}
+
+		page, err := usecase.RecapReader.Search(ctx, common.NewSearchByValues(ctx, []common.SearchableValue{
+			{Field: "ID", Operator: common.InOperator, Values: values},
+		}, common.NewSearchResultOptions(0, RecapPageSize), common.ClientApplicationAudienceIDKey))
+
+		if err != nil {
+			slog.ErrorContext(ctx, "unable to search weekly recaps", "err", err)
+			return err
+		}
+
+		for _, recap := range page {
+			recaps[recap.ID] = recap
+		}
+	}
+
+	return nil
+}
+
+// clientOf returns the client application of the subject (the one their devices are registered with), or the client in context when
+// unknown.
+func clientOf(subject common.ResourceOwner, reso common.ResourceOwner) uuid.UUID {
+	if subject.ClientID == uuid.Nil {
+		return reso.ClientID
+	}
+
+	return subject.ClientID
+}
+
+func TruncateDay(t time.Time) time.Time {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// WeekStart returns the monday (UTC) of the week of t.
+func WeekStart(t time.Time) time.Time {
+	t = TruncateDay(t)
+	return t.Add(-Day * time.Duration((int(t.Weekday())+6)%7))
+}
@@ -0,0 +1,50 @@
+package recap_use_cases
+
+import (
+	"context"
+	"log/slog"
+
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	"github.com/psavelis/team-pro/replay-api/pkg/domain/recap"
+	recap_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/recap/entities"
+	recap_in "github.com/psavelis/team-pro/replay-api/pkg/domain/recap/ports/in"
+	recap_out "github.com/psavelis/team-pro/replay-api/pkg/domain/recap/ports/out"
+)
+
+type GetLatestPlayerRecapUseCase struct {
+	RecapReader recap_out.WeeklyRecapReader
+}
+
+func NewGetLatestPlayerRecapUseCase(recapReader recap_out.WeeklyRecapReader) recap_in.LatestPlayerRecapQuery {
+	return &GetLatestPlayerRecapUseCase{
+		RecapReader: recapReader,
+	}
+}
+
+func (usecase *GetLatestPlayerRecapUseCase) Exec(ctx context.Context) (*recap_entities.WeeklyRecap, error) {
+	if !common.IsAuthenticatedUser(ctx) {
+		return nil, recap.NewWeeklyRecapNotFoundError()
+	}
+
+	resourceOwner := common.GetResourceOwner(ctx)
+
+	// player recaps are owned by their user (squad recaps by the owner of the squad)
+	search := common.NewSearchByValues(ctx, []common.SearchableValue{
+		{Field: "Subject", Values: []interface{}{recap_entities.RecapSubjectPlayer}},
+		{Field: "SubjectID", Values: []interface{}{resourceOwner.UserID}},
+	}, common.NewSearchResultOptions(0, 1), common.UserAudienceIDKey)
+
+	search.SortOptions = []common.SearchSortOption{{Field: "WeekStart", Direction: common.DescendingIDKey}}
+
+	recaps, err := usecase.RecapReader.Search(ctx, search)
+	if err != nil {
+		slog.ErrorContext(ctx, "unable to search the weekly recaps of the user", "err", err)
+		return nil, err
+	}
+
+	if len(recaps) == 0 {
+		return nil, recap.NewWeeklyRecapNotFoundError()
+	}
+
+	return &recaps[0], nil
+}
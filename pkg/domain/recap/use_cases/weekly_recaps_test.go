@@ -0,0 +1,270 @@
+package recap_use_cases_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	"github.com/psavelis/team-pro/replay-api/pkg/domain/recap"
+	recap_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/recap/entities"
+	recap_use_cases "github.com/psavelis/team-pro/replay-api/pkg/domain/recap/use_cases"
+	replay_entity "github.com/psavelis/team-pro/replay-api/pkg/domain/replay/entities"
+	"github.com/stretchr/testify/assert"
+)
+
+type mockActivityReader struct {
+	activity []recap_entities.PlayerActivity
+	rosters  []recap_entities.SquadRoster
+}
+
+func (m *mockActivityReader) GetPlayerActivity(ctx context.Context, from time.Time, to time.Time) ([]recap_entities.PlayerActivity, error) {
+	res := make([]recap_entities.PlayerActivity, 0)
+	for _, a := range m.activity {
+		matches := make([]recap_entities.RecapMatch, 0)
+		for _, match := range a.Matches {
+			if !match.PlayedAt.Before(from) && match.PlayedAt.Before(to) {
+				matches = append(matches, match)
+			}
+		}
+
+		if len(matches) > 0 {
+			res = append(res, recap_entities.PlayerActivity{UserID: a.UserID, Matches: matches, ResourceOwner: a.ResourceOwner})
+		}
+	}
+
+	return res, nil
+}
+
+func (m *mockActivityReader) GetSquadRosters(ctx context.Context) ([]recap_entities.SquadRoster, error) {
+	return m.rosters, nil
+}
+
+// mockRecapStore filters by the fields searched by the use cases, by client on the client audience and by user on the user audience.
+type mockRecapStore struct {
+	recaps []recap_entities.WeeklyRecap
+}
+
+func (m *mockRecapStore) Create(ctx context.Context, r *recap_entities.WeeklyRecap) (*recap_entities.WeeklyRecap, error) {
+	for _, existing := range m.recaps {
+		if existing.ID == r.ID {
+			return nil, errors.New("duplicate key")
+		}
+	}
+
+	m.recaps = append(m.recaps, *r)
+	return r, nil
+}
+
+func (m *mockRecapStore) Search(ctx context.Context, s common.Search) ([]recap_entities.WeeklyRecap, error) {
+	source := s.VisibilityOptions.RequestSource
+
+	res := make([]recap_entities.WeeklyRecap, 0)
+	for _, r := range m.recaps {
+		switch s.VisibilityOptions.IntendedAudience {
+		case common.ClientApplicationAudienceIDKey:
+			if r.ResourceOwner.ClientID != source.ClientID {
+				continue
+			}
+		case common.UserAudienceIDKey:
+			if r.ResourceOwner.UserID != source.UserID {
+				continue
+			}
+		}
+
+		match := true
+		for _, v := range s.SearchParams[0].Params[0].ValueParams {
+			found := false
+			for _, value := range v.Values {
+				switch v.Field {
+				case "ID":
+					found = found || r.ID == value
+				case "Subject":
+					found = found || r.Subject == value
+				case "SubjectID":
+					found = found || r.SubjectID == value
+				}
+			}
+
+			match = match && found
+		}
+
+		if match {
+			res = append(res, r)
+		}
+	}
+
+	// latest first (the only sort used)
+	if len(s.SortOptions) > 0 {
+		for i := 1; i < len(res); i++ {
+			for j := i; j > 0 && res[j].WeekStart.After(res[j-1].WeekStart); j-- {
+				res[j], res[j-1] = res[j-1], res[j]
+			}
+		}
+	}
+
+	if limit := int(s.ResultOptions.Limit); limit > 0 && len(res) > limit {
+		res = res[:limit]
+	}
+
+	return res, nil
+}
+
+func (m *mockRecapStore) Compile(ctx context.Context, searchParams []common.SearchAggregation, resultOptions common.SearchResultOptions) (*common.Search, error) {
+	return nil, nil
+}
+
+func rating(r float64) *float64 {
+	return &r
+}
+
+func TestWeekStart(t *testing.T) {
+	sunday := time.Date(2024, 6, 16, 23, 59, 0, 0, time.UTC)
+	monday := time.Date(2024, 6, 17, 0, 0, 0, 0, time.UTC)
+
+	assert.Equal(t, time.Date(2024, 6, 10, 0, 0, 0, 0, time.UTC), recap_use_cases.WeekStart(sunday))
+	assert.Equal(t, monday, recap_use_cases.WeekStart(monday))
+	assert.Equal(t, monday, recap_use_cases.WeekStart(monday.Add(50*time.Hour)))
+}
+
+func TestGenerateWeeklyRecaps(t *testing.T) {
+	tenantID := uuid.New()
+	appClientID := uuid.New()
+
+	// the job runs on behalf of the server, the players were onboarded by the app
+	serverCtx := common.WithResourceOwner(context.Background(), common.ResourceOwner{TenantID: tenantID, ClientID: common.ServerClientID})
+	playerOwner := common.ResourceOwner{TenantID: tenantID, ClientID: appClientID}
+
+	alice, bob, carol := uuid.New(), uuid.New(), uuid.New()
+	squadID := uuid.New()
+
+	now := time.Date(2024, 6, 19, 12, 0, 0, 0, time.UTC) // wednesday
+	weekStart := time.Date(2024, 6, 10, 0, 0, 0, 0, time.UTC)
+	playedAt := weekStart.Add(2 * 24 * time.Hour)
+
+	sharedMatch := uuid.New()
+	ace := replay_entity.Highlight{Type: replay_entity.HighlightTypeAce, Kills: 5, Opponents: 5}
+	clutch := replay_entity.Highlight{Type: replay_entity.HighlightTypeClutch, Kills: 3, Opponents: 3}
+
+	activity := &mockActivityReader{
+		activity: []recap_entities.PlayerActivity{
+			{UserID: alice, ResourceOwner: playerOwner, Matches: []recap_entities.RecapMatch{
+				{MatchID: sharedMatch, Outcome: replay_entity.MatchOutcomeWin, Rating: rating(1.5), Payout: &replay_entity.MatchPayout{Amount: 500, Currency: "USD"}, Highlights: []replay_entity.Highlight{clutch}, PlayedAt: playedAt},
+				{MatchID: uuid.New(), Outcome: replay_entity.MatchOutcomeLoss, Rating: rating(0.75), PlayedAt: playedAt},
+				// played this week, recapped next week
+				{MatchID: uuid.New(), Outcome: replay_entity.MatchOutcomeWin, Rating: rating(2), PlayedAt: now},
+			}},
+			{UserID: bob, ResourceOwner: playerOwner, Matches: []recap_entities.RecapMatch{
+				{MatchID: sharedMatch, Outcome: replay_entity.MatchOutcomeWin, Rating: rating(0.5), Payout: &replay_entity.MatchPayout{Amount: 300, Currency: "USD"}, Highlights: []replay_entity.Highlight{ace}, PlayedAt: playedAt},
+			}},
+			{UserID: carol, ResourceOwner: playerOwner, Matches: []recap_entities.RecapMatch{
+				{MatchID: uuid.New(), Outcome: replay_entity.MatchOutcomeDraw, Payout: &replay_entity.MatchPayout{Amount: 100, Currency: "EUR"}, PlayedAt: playedAt},
+			}},
+		},
+		rosters: []recap_entities.SquadRoster{
+			{SquadID: squadID, Name: "Alpha", UserIDs: []uuid.UUID{alice, bob}, ResourceOwner: common.ResourceOwner{TenantID: tenantID, ClientID: appClientID, UserID: alice}},
+			// no member played
+			{SquadID: uuid.New(), Name: "Idle", UserIDs: []uuid.UUID{uuid.New()}, ResourceOwner: playerOwner},
+		},
+	}
+
+	store := &mockRecapStore{}
+
+	// alice was rated 1.0 the week before
+	previous := recap_entities.NewWeeklyRecap(recap_entities.RecapSubjectPlayer, alice, weekStart.Add(-7*24*time.Hour), []recap_entities.RecapMatch{
+		{MatchID: uuid.New(), Outcome: replay_entity.MatchOutcomeWin, Rating: rating(1)},
+	}, nil, common.ResourceOwner{TenantID: tenantID, ClientID: appClientID, UserID: alice}, now)
+
+	store.recaps = append(store.recaps, *previous)
+
+	usecase := recap_use_cases.NewGenerateWeeklyRecapsUseCase(activity, store, store)
+
+	recaps, err := usecase.Exec(serverCtx, now)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	assert.Len(t, recaps, 4)
+
+	bySubject := make(map[uuid.UUID]recap_entities.WeeklyRecap)
+	for _, r := range recaps {
+		bySubject[r.SubjectID] = r
+
+		assert.Equal(t, weekStart, r.WeekStart)
+		assert.Equal(t, appClientID, r.ResourceOwner.ClientID)
+	}
+
+	aliceRecap := bySubject[alice]
+	assert.Equal(t, 2, aliceRecap.MatchesPlayed)
+	assert.Equal(t, 1, aliceRecap.Wins)
+	assert.Equal(t, 1, aliceRecap.Losses)
+	assert.Equal(t, 1.13, *aliceRecap.Rating)
+	assert.Equal(t, 0.13, *aliceRecap.RatingDelta)
+	assert.Equal(t, clutch, aliceRecap.BestHighlight.Highlight)
+	assert.Equal(t, []recap_entities.Earnings{{Amount: 500, Currency: "USD"}}, aliceRecap.Earnings)
+	assert.Equal(t, []uuid.UUID{alice}, aliceRecap.UserIDs)
+	assert.Equal(t, alice, aliceRecap.ResourceOwner.UserID)
+
+	// no previous recap: no delta
+	assert.Nil(t, bySubject[bob].RatingDelta)
+
+	// unrated matches
+	assert.Nil(t, bySubject[carol].Rating)
+	assert.Equal(t, 1, bySubject[carol].Draws)
+
+	// the shared match counts once, paid to both members
+	squadRecap := bySubject[squadID]
+	assert.Equal(t, recap_entities.RecapSubjectSquad, squadRecap.Subject)
+	assert.Equal(t, "Alpha", squadRecap.Name)
+	assert.Equal(t, 2, squadRecap.MatchesPlayed)
+	assert.Equal(t, 1, squadRecap.Wins)
+	assert.Equal(t, 0.88, *squadRecap.Rating) // (1.0 + 0.75) / 2
+	assert.Equal(t, ace, squadRecap.BestHighlight.Highlight)
+	assert.Equal(t, sharedMatch, squadRecap.BestHighlight.MatchID)
+	assert.Equal(t, []recap_entities.Earnings{{Amount: 800, Currency: "USD"}}, squadRecap.Earnings)
+	assert.ElementsMatch(t, []uuid.UUID{alice, bob}, squadRecap.UserIDs)
+
+	// later runs of the week don't recap the subjects again
+	recaps, err = usecase.Exec(serverCtx, now.Add(6*time.Hour))
+	assert.NoError(t, err)
+	assert.Empty(t, recaps)
+	assert.Len(t, store.recaps, 5)
+}
+
+func TestGetLatestPlayerRecap(t *testing.T) {
+	tenantID := uuid.New()
+	alice := common.ResourceOwner{TenantID: tenantID, ClientID: common.TeamPROAppClientID, UserID: uuid.New()}
+	bob := common.ResourceOwner{TenantID: tenantID, ClientID: common.TeamPROAppClientID, UserID: uuid.New()}
+
+	weekStart := time.Date(2024, 6, 10, 0, 0, 0, 0, time.UTC)
+	now := time.Now()
+
+	store := &mockRecapStore{}
+	for _, r := range []*recap_entities.WeeklyRecap{
+		recap_entities.NewWeeklyRecap(recap_entities.RecapSubjectPlayer, alice.UserID, weekStart.Add(-7*24*time.Hour), nil, nil, alice, now),
+		recap_entities.NewWeeklyRecap(recap_entities.RecapSubjectPlayer, alice.UserID, weekStart, nil, nil, alice, now),
+		// owned by alice, but the recap of her squad
+		recap_entities.NewWeeklyRecap(recap_entities.RecapSubjectSquad, uuid.New(), weekStart.Add(7*24*time.Hour), nil, nil, alice, now),
+	} {
+		store.recaps = append(store.recaps, *r)
+	}
+
+	usecase := recap_use_cases.NewGetLatestPlayerRecapUseCase(store)
+
+	latest, err := usecase.Exec(common.WithAuthenticated(common.WithResourceOwner(context.Background(), alice)))
+	if assert.NoError(t, err) {
+		assert.Equal(t, recap_entities.RecapSubjectPlayer, latest.Subject)
+		assert.Equal(t, weekStart, latest.WeekStart)
+	}
+
+	var notFoundErr *recap.WeeklyRecapNotFoundError
+
+	_, err = usecase.Exec(common.WithAuthenticated(common.WithResourceOwner(context.Background(), bob)))
+	assert.ErrorAs(t, err, &notFoundErr)
+
+	// the placeholder user of a request without a RID, even when it happens to match
+	_, err = usecase.Exec(common.WithResourceOwner(context.Background(), alice))
+	assert.ErrorAs(t, err, &notFoundErr)
+}
@@ -17,6 +17,9 @@ type GameEvent struct {
 	TickID   common.TickIDType `json:"tick_id" bson:"tick_id"`
 	GameTime time.Duration     `json:"event_time" bson:"event_time"` // // CurrentTime
 
+	// normalized type (set by the game adapter), the payload being the extension of the game
+	Taxonomy *common.NormalizedEvent `json:"taxonomy,omitempty" bson:"taxonomy,omitempty"`
+
 	// data
 	Payload  interface{}                           `json:"-" bson:"payload"`
 	Entities map[common.ResourceType][]interface{} `json:"-" bson:"-"`
@@ -27,6 +27,13 @@ type Match struct {
 	Events        []*GameEvent         `json:"game_events" bson:"game_events"`
 	Visibility    MatchVisibility      `json:"visibility" bson:"visibility"`
 	ShareTokens   []ShareToken         `json:"share_tokens" bson:"share_tokens"`
+	LobbyID       *uuid.UUID           `json:"lobby_id,omitempty" bson:"lobby_id,omitempty"`
+	Draft         *MatchDraft          `json:"draft,omitempty" bson:"draft,omitempty"`
+	QueueSessions []MatchQueueSession  `json:"-" bson:"queue_sessions,omitempty"` // of the lobby players, only projected to their match history
+	Settlement    *MatchSettlement     `json:"-" bson:"settlement,omitempty"`     // only projected to the match history of the paid players
+	External      *ExternalMatch       `json:"external,omitempty" bson:"external,omitempty"`
+	SeasonID      *uuid.UUID           `json:"season_id,omitempty" bson:"season_id,omitempty"` // the season active when the match was processed
+	Tags          *common.ReplayTags   `json:"tags,omitempty" bson:"tags,omitempty"`           // of its replay (platform, server region, tickrate)
 	ResourceOwner common.ResourceOwner `json:"resource_owner" bson:"resource_owner"`
 	CreatedAt     time.Time            `json:"created_at" bson:"created_at"`
 	UpdatedAt     time.Time            `json:"updated_at" bson:"updated_at"`
@@ -58,6 +65,23 @@ type RoundInfo struct {
 	Events           []GameEvent `json:"events" bson:"events"`
 }
 
+// ExternalMatch is the match on the network it was imported from (ie: FACEIT), with the network accounts of its players.
+type ExternalMatch struct {
+	NetworkID  common.NetworkIDKey   `json:"network_id" bson:"network_id"`
+	MatchID    string                `json:"match_id" bson:"match_id"`
+	URL        string                `json:"url" bson:"url"`
+	Players    []ExternalMatchPlayer `json:"players" bson:"players"`
+	FinishedAt *time.Time            `json:"finished_at,omitempty" bson:"finished_at,omitempty"`
+	ImportedAt time.Time             `json:"imported_at" bson:"imported_at"`
+}
+
+type ExternalMatchPlayer struct {
+	NetworkUserID string `json:"network_user_id" bson:"network_user_id"` // ie: the FACEIT player_id
+	Nickname      string `json:"nickname" bson:"nickname"`
+	SteamID       string `json:"steam_id,omitempty" bson:"steam_id,omitempty"` // steam64 id, as recorded in the demo
+	Faction       string `json:"faction" bson:"faction"`
+}
+
 func NewCS2Match(userContext context.Context, replayFileID uuid.UUID) *Match {
 	return &Match{
 		ID:            uuid.New(),
@@ -0,0 +1,23 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type MatchDraftPick struct {
+	Order         int       `json:"order" bson:"order"`
+	CaptainUserID uuid.UUID `json:"captain_user_id" bson:"captain_user_id"`
+	UserID        uuid.UUID `json:"user_id" bson:"user_id"`
+	Team          int       `json:"team" bson:"team"`
+	AutoPicked    bool      `json:"auto_picked" bson:"auto_picked"`
+	PickedAt      time.Time `json:"picked_at" bson:"picked_at"`
+}
+
+// MatchDraft is the captain draft that formed the match teams (copied from the lobby), kept for post-game analysis.
+type MatchDraft struct {
+	Captains    []uuid.UUID      `json:"captains" bson:"captains"`
+	Picks       []MatchDraftPick `json:"picks" bson:"picks"`
+	CompletedAt time.Time        `json:"completed_at" bson:"completed_at"`
+}
@@ -0,0 +1,25 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// MatchQueueSession is the queue session (ticket) a player of the lobby was matched from.
+type MatchQueueSession struct {
+	PlayerID       uuid.UUID `bson:"player_id"`
+	QueueSessionID uuid.UUID `bson:"queue_session_id"`
+}
+
+type MatchSettlementPayout struct {
+	PlayerID uuid.UUID `bson:"player_id"`
+	Amount   int64     `bson:"amount"` // minor units (ie: cents)
+}
+
+// MatchSettlement is the prize split of the lobby that played the match, once its result is reported (copied from the lobby).
+type MatchSettlement struct {
+	Currency  string                  `bson:"currency"`
+	Payouts   []MatchSettlementPayout `bson:"payouts"`
+	SettledAt time.Time               `bson:"settled_at"`
+}
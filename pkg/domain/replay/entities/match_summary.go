@@ -0,0 +1,223 @@
+package entities
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	cs_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/cs/entities"
+)
+
+const (
+	// MaxMatchRating caps the match rating of a player.
+	MaxMatchRating = 2.0
+
+	// averages of the rating formula (HLTV 1.0): a rating of 1.0 is an average performance
+	averageKillsPerRound     = 0.679
+	averageSurvivedPerRound  = 0.317
+	averageMultiKillPerRound = 1.277
+)
+
+// PlayerMatchStats are the totals of a player in a match, along with their match rating.
+type PlayerMatchStats struct {
+	NetworkPlayerID string  `json:"network_player_id" bson:"network_player_id"`
+	Name            string  `json:"name" bson:"name"`
+	Kills           int     `json:"kills" bson:"kills"`
+	Deaths          int     `json:"deaths" bson:"deaths"`
+	Assists         int     `json:"assists" bson:"assists"`
+	Headshots       int     `json:"headshots" bson:"headshots"`
+	Damage          int     `json:"damage" bson:"damage"`
+	Rounds          int     `json:"rounds" bson:"rounds"`
+	MultiKills      [5]int  `json:"multi_kills" bson:"multi_kills"` // rounds with 1 to 5 kills
+	ADR             float64 `json:"adr" bson:"adr"`
+	Rating          float64 `json:"rating" bson:"rating"` // 0 to MaxMatchRating
+	MVP             bool    `json:"mvp" bson:"mvp"`
+}
+
+// MatchSummary holds the players of a match ranked by their match rating, and its MVP (one document per replay).
+type MatchSummary struct {
+	ID            uuid.UUID            `json:"id" bson:"_id"`
+	GameID        common.GameIDKey     `json:"game_id" bson:"game_id"`
+	MatchID       uuid.UUID            `json:"match_id" bson:"match_id"`
+	ReplayFileID  uuid.UUID            `json:"replay_file_id" bson:"replay_file_id"`
+	MapName       string               `json:"map_name" bson:"map_name"`
+	Rounds        int                  `json:"rounds" bson:"rounds"`
+	MVP           *PlayerMatchStats    `json:"mvp,omitempty" bson:"mvp"`
+	Players       []PlayerMatchStats   `json:"players" bson:"players"` // best rated first
+	SeasonID      *uuid.UUID           `json:"season_id,omitempty" bson:"season_id,omitempty"`
+	Tags          *common.ReplayTags   `json:"tags,omitempty" bson:"tags,omitempty"`
+	ResourceOwner common.ResourceOwner `json:"resource_owner" bson:"resource_owner"`
+	CreatedAt     time.Time            `json:"created_at" bson:"created_at"`
+	UpdatedAt     time.Time            `json:"updated_at" bson:"updated_at"`
+}
+
+func (s MatchSummary) GetID() uuid.UUID {
+	return s.ID
+}
+
+// Player returns the stats of a player in the match, or nil when they didn't play it.
+func (s *MatchSummary) Player(networkPlayerID string) *PlayerMatchStats {
+	for i := range s.Players {
+		if s.Players[i].NetworkPlayerID == networkPlayerID {
+			return &s.Players[i]
+		}
+	}
+
+	return nil
+}
+
+// Rate adds the match rating and MVP of each player to their PlayerStatsBucket of the match.
+func (s *MatchSummary) Rate(buckets []PlayerStatsBucket) {
+	for i := range buckets {
+		player := s.Player(buckets[i].NetworkPlayerID)
+		if player == nil {
+			continue
+		}
+
+		buckets[i].RatingPoints += int(math.Round(player.Rating * 1000))
+		if player.MVP {
+			buckets[i].MVPs++
+		}
+	}
+}
+
+// NewMatchSummary rates the players of a parsed replay: their totals are read from the last PlayerScoreboard event and their
+// multi-kill rounds from the RoundKills events (replays without them count each kill as a 1k round). The best rated player is the
+// MVP (ties go to the most kills, then the most damage).
+func NewMatchSummary(replayFile *ReplayFile, matchID uuid.UUID, events []*GameEvent, now time.Time) *MatchSummary {
+	var scoreboard *PlayerScoreboardPayload
+
+	roundKills := make(map[int]RoundKillsPayload)
+
+	for _, event := range events {
+		switch payload := event.Payload.(type) {
+		case PlayerScoreboardPayload:
+			if scoreboard == nil || payload.RoundNumber >= scoreboard.RoundNumber {
+				scoreboard = &payload
+			}
+		case RoundKillsPayload:
+			roundKills[payload.RoundNumber] = payload
+		}
+	}
+
+	summary := &MatchSummary{
+		ID:            uuid.New(),
+		GameID:        replayFile.GameID,
+		MatchID:       matchID,
+		ReplayFileID:  replayFile.ID,
+		Players:       make([]PlayerMatchStats, 0),
+		ResourceOwner: replayFile.ResourceOwner,
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	}
+
+	if scoreboard == nil {
+		return summary
+	}
+
+	summary.MapName = scoreboard.MapName
+	summary.Rounds = scoreboard.RoundNumber
+
+	multiKills := countMultiKills(roundKills)
+
+	for _, player := range scoreboard.Players {
+		stats := PlayerMatchStats{
+			NetworkPlayerID: player.NetworkPlayerID,
+			Name:            player.Name,
+			Kills:           player.Kills,
+			Deaths:          player.Deaths,
+			Assists:         player.Assists,
+			Headshots:       player.Headshots,
+			Damage:          player.Damage,
+			Rounds:          player.RoundsPlayed,
+			ADR:             cs_entities.CalculateADR(player.Damage, player.RoundsPlayed),
+		}
+
+		stats.MultiKills[0] = player.Kills
+		if len(roundKills) > 0 {
+			stats.MultiKills = multiKills[player.NetworkPlayerID]
+		}
+
+		stats.Rating = MatchRating(stats.Kills, stats.Deaths, stats.Rounds, stats.MultiKills)
+
+		summary.Players = append(summary.Players, stats)
+	}
+
+	sort.SliceStable(summary.Players, func(i, j int) bool {
+		a, b := summary.Players[i], summary.Players[j]
+
+		if a.Rating != b.Rating {
+			return a.Rating > b.Rating
+		}
+
+		if a.Kills != b.Kills {
+			return a.Kills > b.Kills
+		}
+
+		if a.Damage != b.Damage {
+			return a.Damage > b.Damage
+		}
+
+		return a.NetworkPlayerID < b.NetworkPlayerID
+	})
+
+	if len(summary.Players) > 0 && summary.Players[0].Rounds > 0 {
+		summary.Players[0].MVP = true
+
+		mvp := summary.Players[0]
+		summary.MVP = &mvp
+	}
+
+	return summary
+}
+
+// countMultiKills counts the rounds of each player by their enemy kills (5+ counted as 5k).
+func countMultiKills(roundKills map[int]RoundKillsPayload) map[string][5]int {
+	multiKills := make(map[string][5]int)
+
+	for _, round := range roundKills {
+		kills := make(map[string]int)
+
+		for _, kill := range round.Kills {
+			if kill.KillerNetworkPlayerID == "" || kill.KillerSide == kill.VictimSide {
+				continue
+			}
+
+			kills[kill.KillerNetworkPlayerID]++
+		}
+
+		for networkPlayerID, count := range kills {
+			counts := multiKills[networkPlayerID]
+			counts[min(count, 5)-1]++
+			multiKills[networkPlayerID] = counts
+		}
+	}
+
+	return multiKills
+}
+
+// MatchRating is the rating of a player in a match (HLTV 1.0): their kills, survived rounds and multi-kill rounds per round, each
+// relative to its average, so an average performance rates 1.0. Ratings are capped to MaxMatchRating and rounded to 2 decimals.
+func MatchRating(kills int, deaths int, rounds int, multiKills [5]int) float64 {
+	if rounds <= 0 {
+		return 0
+	}
+
+	r := float64(rounds)
+
+	killRating := float64(kills) / r / averageKillsPerRound
+	survivalRating := float64(max(rounds-deaths, 0)) / r / averageSurvivedPerRound
+
+	multiKillPoints := 0
+	for i, count := range multiKills {
+		multiKillPoints += (i + 1) * (i + 1) * count
+	}
+
+	multiKillRating := float64(multiKillPoints) / r / averageMultiKillPerRound
+
+	rating := (killRating + 0.7*survivalRating + multiKillRating) / 2.7
+
+	return math.Round(math.Min(math.Max(rating, 0), MaxMatchRating)*100) / 100
+}
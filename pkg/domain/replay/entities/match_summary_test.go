@@ -0,0 +1,107 @@
+package entities_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	replay_entity "github.com/psavelis/team-pro/replay-api/pkg/domain/replay/entities"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMatchRating(t *testing.T) {
+	tests := []struct {
+		name       string
+		kills      int
+		deaths     int
+		rounds     int
+		multiKills [5]int
+		expected   float64
+	}{
+		{"average", 17, 17, 25, [5]int{9, 3, 1, 0, 0}, 0.98},
+		{"no kills, always dead", 0, 25, 25, [5]int{}, 0},
+		{"capped", 50, 0, 20, [5]int{0, 0, 0, 0, 10}, replay_entity.MaxMatchRating},
+		{"no rounds", 3, 1, 0, [5]int{3}, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, replay_entity.MatchRating(tt.kills, tt.deaths, tt.rounds, tt.multiKills))
+		})
+	}
+}
+
+func TestNewMatchSummary(t *testing.T) {
+	owner := common.ResourceOwner{TenantID: uuid.New(), ClientID: uuid.New(), UserID: uuid.New()}
+	replayFile := &replay_entity.ReplayFile{ID: uuid.New(), GameID: common.CS2_GAME_ID, ResourceOwner: owner}
+	matchID := uuid.New()
+	now := time.Now()
+
+	event := func(eventType common.EventIDKey, payload interface{}) *replay_entity.GameEvent {
+		return replay_entity.NewGameEvent(uuid.New(), 0, 0, eventType, payload, nil, nil, owner)
+	}
+
+	kill := func(killer string, killerSide, victimSide replay_entity.RoundSide) replay_entity.RoundKill {
+		return replay_entity.RoundKill{KillerNetworkPlayerID: killer, KillerSide: killerSide, VictimSide: victimSide}
+	}
+
+	ct, tr := replay_entity.RoundSideCT, replay_entity.RoundSideT
+
+	events := []*replay_entity.GameEvent{
+		event(common.Event_RoundKillsID, replay_entity.RoundKillsPayload{RoundNumber: 1, Kills: []replay_entity.RoundKill{
+			kill("1", ct, tr), kill("1", ct, tr), kill("2", tr, ct),
+			kill("2", tr, tr), // team kills don't count
+		}}),
+		event(common.Event_RoundKillsID, replay_entity.RoundKillsPayload{RoundNumber: 2, Kills: []replay_entity.RoundKill{
+			kill("1", ct, tr), kill("2", tr, ct), kill("3", tr, ct),
+		}}),
+		event(common.Event_PlayerScoreboardID, replay_entity.PlayerScoreboardPayload{RoundNumber: 2, MapName: "de_nuke", Players: []replay_entity.PlayerScoreboardEntry{
+			{NetworkPlayerID: "3", Name: "c", Kills: 1, Deaths: 1, Damage: 100, RoundsPlayed: 2},
+			{NetworkPlayerID: "2", Name: "b", Kills: 2, Deaths: 2, Damage: 150, RoundsPlayed: 2},
+			{NetworkPlayerID: "1", Name: "a", Kills: 3, Deaths: 1, Damage: 300, RoundsPlayed: 2},
+		}}),
+	}
+
+	summary := replay_entity.NewMatchSummary(replayFile, matchID, events, now)
+
+	assert.Equal(t, matchID, summary.MatchID)
+	assert.Equal(t, replayFile.ID, summary.ReplayFileID)
+	assert.Equal(t, "de_nuke", summary.MapName)
+	assert.Equal(t, 2, summary.Rounds)
+
+	if !assert.Len(t, summary.Players, 3) {
+		return
+	}
+
+	assert.Equal(t, []string{"1", "2", "3"}, []string{summary.Players[0].NetworkPlayerID, summary.Players[1].NetworkPlayerID, summary.Players[2].NetworkPlayerID})
+	assert.Equal(t, [5]int{1, 1, 0, 0, 0}, summary.Players[0].MultiKills)
+	assert.Equal(t, [5]int{2, 0, 0, 0, 0}, summary.Player("2").MultiKills)
+	assert.Equal(t, []float64{1.95, 0.84, 0.83}, []float64{summary.Players[0].Rating, summary.Players[1].Rating, summary.Players[2].Rating})
+	assert.Equal(t, 150.0, summary.Players[0].ADR)
+
+	if assert.NotNil(t, summary.MVP) {
+		assert.Equal(t, "1", summary.MVP.NetworkPlayerID)
+		assert.True(t, summary.Players[0].MVP)
+		assert.False(t, summary.Players[1].MVP)
+	}
+
+	buckets := replay_entity.NewPlayerStatsBuckets(replayFile, events, now, now)
+	summary.Rate(buckets)
+
+	assert.Equal(t, 1, buckets[0].MVPs)
+	assert.Equal(t, 1950, buckets[0].RatingPoints)
+	assert.Equal(t, 0, buckets[1].MVPs)
+
+	totals := replay_entity.PlayerStatsTotals{Matches: 2, RatingPoints: 2150}.WithRatios()
+	assert.Equal(t, 1.08, totals.Rating)
+}
+
+func TestNewMatchSummary_WithoutScoreboard(t *testing.T) {
+	replayFile := &replay_entity.ReplayFile{ID: uuid.New(), GameID: common.CS2_GAME_ID}
+
+	summary := replay_entity.NewMatchSummary(replayFile, uuid.New(), []*replay_entity.GameEvent{}, time.Now())
+
+	assert.Empty(t, summary.Players)
+	assert.Nil(t, summary.MVP)
+}
@@ -21,6 +21,14 @@ type Player struct {
 	NetworkClanID string     `json:"network_clan_id" bson:"network_clan_id"`
 	VerifiedAt    *time.Time `json:"verified_at" bson:"verified_at"`
 
+	// stats of the player on Steam/FACEIT/Riot, refreshed by the profile sync job
+	ExternalStats []ExternalStats `json:"external_stats,omitempty" bson:"external_stats,omitempty"`
+	RankBadges    []RankBadge     `json:"rank_badges,omitempty" bson:"rank_badges,omitempty"`
+	StatsSyncedAt *time.Time      `json:"stats_synced_at,omitempty" bson:"stats_synced_at,omitempty"`
+
+	// as defined by the tenant (ie: its internal player codes)
+	CustomFields common.CustomFields `json:"custom_fields,omitempty" bson:"custom_fields,omitempty"`
+
 	ResourceOwner common.ResourceOwner `json:"-" bson:"resource_owner"`
 	ShareTokens   []ShareToken         `json:"-" bson:"share_tokens"`
 	CreatedAt     time.Time            `json:"-" bson:"created_at"`
@@ -0,0 +1,37 @@
+package entities
+
+import (
+	"github.com/google/uuid"
+)
+
+const (
+	PlayerCardRecentMatches = 5 // outcomes in the recent form of a card
+	PlayerCardBadges        = 4 // most recent badges shown on a card
+)
+
+// PlayerCard is what the card of a player (the preview image of a shared profile link) shows: who the player is, their rank and
+// overall stats, the outcome of their last matches (most recent first) and their latest badges.
+type PlayerCard struct {
+	PlayerID   uuid.UUID
+	Name       string
+	ClanName   string
+	AvatarURI  string
+	Rank       *RankBadge // nil when unranked
+	Stats      PlayerStatsTotals
+	RecentForm []MatchOutcome
+	Badges     []string // names
+}
+
+// FormLetter is the letter of the outcome shown in the recent form of a card.
+func FormLetter(outcome MatchOutcome) string {
+	switch outcome {
+	case MatchOutcomeWin:
+		return "W"
+	case MatchOutcomeLoss:
+		return "L"
+	case MatchOutcomeDraw:
+		return "D"
+	default:
+		return "-"
+	}
+}
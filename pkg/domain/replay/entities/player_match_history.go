@@ -0,0 +1,138 @@
+package entities
+
+import (
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+)
+
+type MatchOutcome string
+
+const (
+	MatchOutcomeWin     MatchOutcome = "win"
+	MatchOutcomeLoss    MatchOutcome = "loss"
+	MatchOutcomeDraw    MatchOutcome = "draw"
+	MatchOutcomeUnknown MatchOutcome = "unknown"
+)
+
+// playerMatchHistoryNamespace keeps PlayerMatchHistory IDs stable (player+match), so projecting the same match twice overwrites the entry.
+var playerMatchHistoryNamespace = uuid.MustParse("6f0b7a44-3f1e-4a55-9a65-0f2d8f9c1c11")
+
+type MatchPayout struct {
+	Amount   int64  `json:"amount" bson:"amount"` // minor units (ie: cents)
+	Currency string `json:"currency" bson:"currency"`
+}
+
+// PlayerMatchHistory is a read model (one entry per player per match) so a player's history is served from a single collection.
+type PlayerMatchHistory struct {
+	ID             uuid.UUID            `json:"id" bson:"_id"`
+	PlayerID       uuid.UUID            `json:"player_id" bson:"player_id"`
+	MatchID        uuid.UUID            `json:"match_id" bson:"match_id"`
+	GameID         common.GameIDKey     `json:"game_id" bson:"game_id"`
+	QueueSessionID *uuid.UUID           `json:"queue_session_id,omitempty" bson:"queue_session_id"` // set when the match was formed by matchmaking
+	LobbyID        *uuid.UUID           `json:"lobby_id,omitempty" bson:"lobby_id"`                 // set when the match was formed by matchmaking
+	TeamID         uuid.UUID            `json:"team_id" bson:"team_id"`
+	TeamName       string               `json:"team_name" bson:"team_name"`
+	Outcome        MatchOutcome         `json:"outcome" bson:"outcome"`
+	TeamScore      int                  `json:"team_score" bson:"team_score"`
+	OpponentScore  int                  `json:"opponent_score" bson:"opponent_score"`
+	Payout         *MatchPayout         `json:"payout,omitempty" bson:"payout"`
+	Tags           *common.ReplayTags   `json:"tags,omitempty" bson:"tags,omitempty"` // of the match
+	PlayedAt       time.Time            `json:"played_at" bson:"played_at"`
+	ResourceOwner  common.ResourceOwner `json:"resource_owner" bson:"resource_owner"`
+	CreatedAt      time.Time            `json:"created_at" bson:"created_at"`
+	UpdatedAt      time.Time            `json:"updated_at" bson:"updated_at"`
+}
+
+func (e PlayerMatchHistory) GetID() uuid.UUID {
+	return e.ID
+}
+
+func PlayerMatchHistoryID(playerID uuid.UUID, matchID uuid.UUID) uuid.UUID {
+	return uuid.NewSHA1(playerMatchHistoryNamespace, append(playerID[:], matchID[:]...))
+}
+
+// NewPlayerMatchHistoryFromMatch projects the scoreboard of a match into one history entry per player. Matches formed by matchmaking
+// also project the lobby, the queue session of each player and (once settled) their payout.
+func NewPlayerMatchHistoryFromMatch(match Match, now time.Time) []PlayerMatchHistory {
+	teams := match.Scoreboard.TeamScoreboards
+
+	queueSessions := make(map[uuid.UUID]uuid.UUID, len(match.QueueSessions))
+	for _, s := range match.QueueSessions {
+		queueSessions[s.PlayerID] = s.QueueSessionID
+	}
+
+	payouts := make(map[uuid.UUID]*MatchPayout)
+	if match.Settlement != nil {
+		for _, p := range match.Settlement.Payouts {
+			payouts[p.PlayerID] = &MatchPayout{Amount: p.Amount, Currency: match.Settlement.Currency}
+		}
+	}
+
+	entries := make([]PlayerMatchHistory, 0)
+
+	for i, team := range teams {
+		opponentScore, outcome := opponentScoreAndOutcome(teams, i)
+
+		for _, player := range team.Players {
+			playerID := uuid.UUID(player.ID)
+
+			entry := PlayerMatchHistory{
+				ID:            PlayerMatchHistoryID(playerID, match.ID),
+				PlayerID:      playerID,
+				MatchID:       match.ID,
+				GameID:        match.GameID,
+				LobbyID:       match.LobbyID,
+				TeamID:        team.Team.ID,
+				TeamName:      team.Team.CurrentDisplayName,
+				Outcome:       outcome,
+				TeamScore:     team.TeamScore,
+				OpponentScore: opponentScore,
+				Payout:        payouts[playerID],
+				Tags:          match.Tags,
+				PlayedAt:      match.CreatedAt,
+				ResourceOwner: match.ResourceOwner,
+				CreatedAt:     now,
+				UpdatedAt:     now,
+			}
+
+			if queueSessionID, ok := queueSessions[playerID]; ok {
+				entry.QueueSessionID = &queueSessionID
+			}
+
+			entries = append(entries, entry)
+		}
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].PlayerID.String() < entries[j].PlayerID.String()
+	})
+
+	return entries
+}
+
+// opponentScoreAndOutcome compares a team with the best scoring opponent (outcome is unknown without opponents).
+func opponentScoreAndOutcome(teams []TeamScoreboard, index int) (int, MatchOutcome) {
+	best := -1
+
+	for i, team := range teams {
+		if i != index && team.TeamScore > best {
+			best = team.TeamScore
+		}
+	}
+
+	if best < 0 {
+		return 0, MatchOutcomeUnknown
+	}
+
+	switch score := teams[index].TeamScore; {
+	case score > best:
+		return best, MatchOutcomeWin
+	case score < best:
+		return best, MatchOutcomeLoss
+	default:
+		return best, MatchOutcomeDraw
+	}
+}
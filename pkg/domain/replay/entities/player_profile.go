@@ -0,0 +1,127 @@
+package entities
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+)
+
+type ExternalStatsSource string
+
+const (
+	SteamStatsSource  ExternalStatsSource = "steam"  // hours played (CS2)
+	FaceitStatsSource ExternalStatsSource = "faceit" // elo and skill level (CS2)
+	RiotStatsSource   ExternalStatsSource = "riot"   // competitive rank (Valorant)
+)
+
+// ExternalAccounts are the accounts of a player on the platforms their external stats are read from (empty when not linked).
+type ExternalAccounts struct {
+	SteamID   string // SteamID64
+	RiotPUUID string
+}
+
+// ExternalStats are the stats of a player on an external platform, as of SyncedAt.
+type ExternalStats struct {
+	Source      ExternalStatsSource `json:"source" bson:"source"`
+	AccountID   string              `json:"-" bson:"account_id"`
+	HoursPlayed float64             `json:"hours_played,omitempty" bson:"hours_played,omitempty"`
+	Elo         int                 `json:"elo,omitempty" bson:"elo,omitempty"`
+	SkillLevel  int                 `json:"skill_level,omitempty" bson:"skill_level,omitempty"`
+	RankTier    int                 `json:"rank_tier,omitempty" bson:"rank_tier,omitempty"`
+	SyncedAt    time.Time           `json:"synced_at" bson:"synced_at"`
+}
+
+// RankBadge is the rank of a player on an external platform, as displayed on their profile (ie: "FACEIT Level 10", "Diamond 2").
+type RankBadge struct {
+	Source ExternalStatsSource `json:"source" bson:"source"`
+	Label  string              `json:"label" bson:"label"`
+	Tier   int                 `json:"tier" bson:"tier"`
+	Value  int                 `json:"value,omitempty" bson:"value,omitempty"` // FACEIT elo
+}
+
+// RankBadge returns the badge of the rank in the stats, nil when the source has no ranks (Steam) or the player is unranked.
+func (s ExternalStats) RankBadge() *RankBadge {
+	switch s.Source {
+	case FaceitStatsSource:
+		if s.SkillLevel <= 0 {
+			return nil
+		}
+
+		return &RankBadge{Source: s.Source, Label: fmt.Sprintf("FACEIT Level %d", s.SkillLevel), Tier: s.SkillLevel, Value: s.Elo}
+	case RiotStatsSource:
+		name := ValorantRankName(s.RankTier)
+		if name == "" {
+			return nil
+		}
+
+		return &RankBadge{Source: s.Source, Label: name, Tier: s.RankTier}
+	}
+
+	return nil
+}
+
+var valorantRanks = []string{"Iron", "Bronze", "Silver", "Gold", "Platinum", "Diamond", "Ascendant", "Immortal"}
+
+// ValorantRankName returns the name of a Valorant competitive tier (ie: 20 is "Diamond 3"), empty when unranked.
+func ValorantRankName(tier int) string {
+	switch {
+	case tier == 27:
+		return "Radiant"
+	case tier >= 3 && tier < 27:
+		return fmt.Sprintf("%s %d", valorantRanks[(tier-3)/3], (tier-3)%3+1)
+	}
+
+	return ""
+}
+
+// ExternalAccounts returns the account the player is linked to by its network (FACEIT stats are read by SteamID).
+func (e Player) ExternalAccounts() ExternalAccounts {
+	switch e.NetworkID {
+	case common.SteamNetworkIDKey:
+		return ExternalAccounts{SteamID: e.NetworkUserID}
+	case common.RiotNetworkIDKey:
+		return ExternalAccounts{RiotPUUID: e.NetworkUserID}
+	}
+
+	return ExternalAccounts{}
+}
+
+// SetExternalStats replaces the external stats of the player, and the rank badges shown on their profile.
+func (e *Player) SetExternalStats(stats []ExternalStats, syncedAt time.Time) {
+	e.ExternalStats = stats
+	e.RankBadges = make([]RankBadge, 0, len(stats))
+	e.StatsSyncedAt = &syncedAt
+
+	for _, s := range stats {
+		if badge := s.RankBadge(); badge != nil {
+			e.RankBadges = append(e.RankBadges, *badge)
+		}
+	}
+}
+
+// PlayerProfileHistory is a snapshot of the external stats of a player, taken each time they're synced.
+type PlayerProfileHistory struct {
+	ID            uuid.UUID            `json:"id" bson:"_id"`
+	PlayerID      common.PlayerIDType  `json:"player_id" bson:"player_id"`
+	Stats         []ExternalStats      `json:"stats" bson:"stats"`
+	RankBadges    []RankBadge          `json:"rank_badges" bson:"rank_badges"`
+	ResourceOwner common.ResourceOwner `json:"-" bson:"resource_owner"`
+	CreatedAt     time.Time            `json:"created_at" bson:"created_at"`
+}
+
+func NewPlayerProfileHistory(player Player, createdAt time.Time) *PlayerProfileHistory {
+	return &PlayerProfileHistory{
+		ID:            uuid.New(),
+		PlayerID:      player.ID,
+		Stats:         player.ExternalStats,
+		RankBadges:    player.RankBadges,
+		ResourceOwner: player.ResourceOwner,
+		CreatedAt:     createdAt,
+	}
+}
+
+func (e PlayerProfileHistory) GetID() uuid.UUID {
+	return e.ID
+}
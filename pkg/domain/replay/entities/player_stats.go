@@ -0,0 +1,266 @@
+package entities
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	cs_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/cs/entities"
+)
+
+// playerStatsBucketNamespace keeps PlayerStatsBucket IDs stable (owner+game+player+map+day+replay tags), so every replay of the same day
+// (and tags) increments the same bucket.
+var playerStatsBucketNamespace = uuid.MustParse("0d3f6a52-8c1b-4f7e-a0a9-2b5c7e4d9f13")
+
+// PlayerScoreboardPayload is the payload of the PlayerScoreboard game events: the scoreboard of the match as of the end of a round
+// (the last one holds the totals of the match).
+type PlayerScoreboardPayload struct {
+	RoundNumber int                     `json:"round_number" bson:"round_number"`
+	MapName     string                  `json:"map_name" bson:"map_name"`
+	Players     []PlayerScoreboardEntry `json:"players" bson:"players"`
+}
+
+type PlayerScoreboardEntry struct {
+	NetworkPlayerID string `json:"network_player_id" bson:"network_player_id"` // (SteamID64)
+	Name            string `json:"name" bson:"name"`
+	Kills           int    `json:"kills" bson:"kills"` // enemies only
+	Deaths          int    `json:"deaths" bson:"deaths"`
+	Assists         int    `json:"assists" bson:"assists"`
+	Headshots       int    `json:"headshots" bson:"headshots"` // kills by headshot
+	Damage          int    `json:"damage" bson:"damage"`       // health damage to enemies
+	RoundsPlayed    int    `json:"rounds_played" bson:"rounds_played"`
+}
+
+// PlayerStatsBucket holds the counters of a player on a map in a day, of the replays with the same tags. Buckets are incremented by each
+// processed replay, and summed (see PlayerStats) to serve the stats of a player for any map, time window and tags.
+type PlayerStatsBucket struct {
+	ID              uuid.UUID            `json:"id" bson:"_id"`
+	GameID          common.GameIDKey     `json:"game_id" bson:"game_id"`
+	NetworkPlayerID string               `json:"network_player_id" bson:"network_player_id"`
+	MapName         string               `json:"map_name" bson:"map_name"`
+	Day             time.Time            `json:"day" bson:"day"` // UTC midnight
+	Tags            common.ReplayTags    `json:"tags" bson:"tags"`
+	Matches         int                  `json:"matches" bson:"matches"`
+	Rounds          int                  `json:"rounds" bson:"rounds"`
+	Kills           int                  `json:"kills" bson:"kills"`
+	Deaths          int                  `json:"deaths" bson:"deaths"`
+	Assists         int                  `json:"assists" bson:"assists"`
+	Headshots       int                  `json:"headshots" bson:"headshots"`
+	Damage          int                  `json:"damage" bson:"damage"`
+	ClutchesPlayed  int                  `json:"clutches_played" bson:"clutches_played"`
+	ClutchesWon     int                  `json:"clutches_won" bson:"clutches_won"`
+	MVPs            int                  `json:"mvps" bson:"mvps"`
+	RatingPoints    int                  `json:"rating_points" bson:"rating_points"` // match ratings (in thousandths), summed
+	ResourceOwner   common.ResourceOwner `json:"resource_owner" bson:"resource_owner"`
+	CreatedAt       time.Time            `json:"created_at" bson:"created_at"`
+	UpdatedAt       time.Time            `json:"updated_at" bson:"updated_at"`
+}
+
+func (b PlayerStatsBucket) GetID() uuid.UUID {
+	return b.ID
+}
+
+// PlayerStatsBucketCounters are the summed fields of PlayerStatsBucket.
+var PlayerStatsBucketCounters = []string{"Matches", "Rounds", "Kills", "Deaths", "Assists", "Headshots", "Damage", "ClutchesPlayed", "ClutchesWon", "MVPs", "RatingPoints"}
+
+// NewPlayerStatsBuckets reads the match totals of each player from the last PlayerScoreboard event of a parsed replay, and their
+// clutches from the ClutchEnd events (the ones won or lost). Buckets are owned by the tenant and client of the replay (not its uploader), so
+// replays uploaded by different users add up.
+func NewPlayerStatsBuckets(replayFile *ReplayFile, events []*GameEvent, playedAt time.Time, now time.Time) []PlayerStatsBucket {
+	var scoreboard *PlayerScoreboardPayload
+
+	clutches := make(map[int]cs_entities.CSClutchStats)
+
+	for _, event := range events {
+		switch payload := event.Payload.(type) {
+		case PlayerScoreboardPayload:
+			if scoreboard == nil || payload.RoundNumber >= scoreboard.RoundNumber {
+				scoreboard = &payload
+			}
+		case cs_entities.CSMatchStats:
+			if event.Type != common.Event_ClutchEndID {
+				continue
+			}
+
+			for _, stats := range payload.RoundsStats {
+				clutch := stats.ClutchStats
+				if clutch != nil && (clutch.Status == cs_entities.ClutchWonKey || clutch.Status == cs_entities.ClutchLostKey) {
+					clutches[clutch.RoundNumber] = *clutch
+				}
+			}
+		}
+	}
+
+	if scoreboard == nil {
+		return []PlayerStatsBucket{}
+	}
+
+	owner := common.ResourceOwner{TenantID: replayFile.ResourceOwner.TenantID, ClientID: replayFile.ResourceOwner.ClientID}
+	day := playedAt.UTC().Truncate(24 * time.Hour)
+
+	var tags common.ReplayTags
+	if replayFile.Tags != nil {
+		tags = *replayFile.Tags
+	}
+
+	buckets := make([]PlayerStatsBucket, 0, len(scoreboard.Players))
+	for _, player := range scoreboard.Players {
+		bucket := PlayerStatsBucket{
+			ID:              PlayerStatsBucketID(owner, replayFile.GameID, player.NetworkPlayerID, scoreboard.MapName, day, tags),
+			GameID:          replayFile.GameID,
+			NetworkPlayerID: player.NetworkPlayerID,
+			MapName:         scoreboard.MapName,
+			Day:             day,
+			Tags:            tags,
+			Matches:         1,
+			Rounds:          player.RoundsPlayed,
+			Kills:           player.Kills,
+			Deaths:          player.Deaths,
+			Assists:         player.Assists,
+			Headshots:       player.Headshots,
+			Damage:          player.Damage,
+			ResourceOwner:   owner,
+			CreatedAt:       now,
+			UpdatedAt:       now,
+		}
+
+		for _, clutch := range clutches {
+			if fmt.Sprintf("%d", clutch.NetworkPlayerID) != player.NetworkPlayerID {
+				continue
+			}
+
+			bucket.ClutchesPlayed++
+			if clutch.Status == cs_entities.ClutchWonKey {
+				bucket.ClutchesWon++
+			}
+		}
+
+		buckets = append(buckets, bucket)
+	}
+
+	sort.Slice(buckets, func(i, j int) bool {
+		return buckets[i].NetworkPlayerID < buckets[j].NetworkPlayerID
+	})
+
+	return buckets
+}
+
+// PlayerStatsBucketID of untagged replays is the one of the buckets incremented before replays were tagged.
+func PlayerStatsBucketID(owner common.ResourceOwner, gameID common.GameIDKey, networkPlayerID string, mapName string, day time.Time, tags common.ReplayTags) uuid.UUID {
+	key := fmt.Sprintf("%s|%s|%s|%s|%s|%s", owner.TenantID, owner.ClientID, gameID, networkPlayerID, mapName, day.UTC().Format(time.DateOnly))
+	if tags != (common.ReplayTags{}) {
+		key = fmt.Sprintf("%s|%s|%s|%s|%d", key, tags.Platform, tags.ServerRegion, tags.ServerLocation, tags.Tickrate)
+	}
+
+	return uuid.NewSHA1(playerStatsBucketNamespace, []byte(key))
+}
+
+// PlayerStatsTotals are the summed counters of a player, along with the ratios computed from them.
+type PlayerStatsTotals struct {
+	Matches        int     `json:"matches"`
+	Rounds         int     `json:"rounds"`
+	Kills          int     `json:"kills"`
+	Deaths         int     `json:"deaths"`
+	Assists        int     `json:"assists"`
+	Headshots      int     `json:"headshots"`
+	Damage         int     `json:"damage"`
+	ClutchesPlayed int     `json:"clutches_played"`
+	ClutchesWon    int     `json:"clutches_won"`
+	MVPs           int     `json:"mvps"`
+	RatingPoints   int     `json:"-"`
+	KD             float64 `json:"kd"`          // kills per death (kills when never died)
+	ADR            float64 `json:"adr"`         // average damage per round
+	HSPercentage   float64 `json:"hs_pct"`      // kills by headshot, in percent
+	ClutchRate     float64 `json:"clutch_rate"` // clutches won per clutch played
+	Rating         float64 `json:"rating"`      // average match rating
+}
+
+type MapPlayerStats struct {
+	MapName string `json:"map_name"`
+	PlayerStatsTotals
+}
+
+// PlayerStats are the stats of a player over the requested map and time window: overall, and by map (most played first).
+type PlayerStats struct {
+	PlayerID        uuid.UUID  `json:"player_id"`
+	NetworkPlayerID string     `json:"network_player_id"`
+	From            *time.Time `json:"from,omitempty"`
+	To              *time.Time `json:"to,omitempty"`
+	PlayerStatsTotals
+	Maps []MapPlayerStats `json:"maps"`
+}
+
+// WithRatios computes the ratios of the summed counters.
+func (t PlayerStatsTotals) WithRatios() PlayerStatsTotals {
+	t.KD = float64(t.Kills)
+	if t.Deaths > 0 {
+		t.KD = float64(t.Kills) / float64(t.Deaths)
+	}
+
+	t.ADR = cs_entities.CalculateADR(t.Damage, t.Rounds)
+
+	t.HSPercentage = 0
+	if t.Kills > 0 {
+		t.HSPercentage = float64(t.Headshots) * 100 / float64(t.Kills)
+	}
+
+	t.ClutchRate = 0
+	if t.ClutchesPlayed > 0 {
+		t.ClutchRate = float64(t.ClutchesWon) / float64(t.ClutchesPlayed)
+	}
+
+	t.Rating = 0
+	if t.Matches > 0 {
+		t.Rating = math.Round(float64(t.RatingPoints)/float64(t.Matches)/10) / 100
+	}
+
+	return t
+}
+
+// Add sums the counters (ratios are to be recomputed, see WithRatios).
+func (t PlayerStatsTotals) Add(o PlayerStatsTotals) PlayerStatsTotals {
+	t.Matches += o.Matches
+	t.Rounds += o.Rounds
+	t.Kills += o.Kills
+	t.Deaths += o.Deaths
+	t.Assists += o.Assists
+	t.Headshots += o.Headshots
+	t.Damage += o.Damage
+	t.ClutchesPlayed += o.ClutchesPlayed
+	t.ClutchesWon += o.ClutchesWon
+	t.MVPs += o.MVPs
+	t.RatingPoints += o.RatingPoints
+
+	return t
+}
+
+// NewPlayerStats sums the totals of each map into the overall stats of the player.
+func NewPlayerStats(playerID uuid.UUID, networkPlayerID string, from *time.Time, to *time.Time, maps []MapPlayerStats) *PlayerStats {
+	stats := &PlayerStats{
+		PlayerID:        playerID,
+		NetworkPlayerID: networkPlayerID,
+		From:            from,
+		To:              to,
+		Maps:            make([]MapPlayerStats, 0, len(maps)),
+	}
+
+	for _, m := range maps {
+		stats.PlayerStatsTotals = stats.PlayerStatsTotals.Add(m.PlayerStatsTotals)
+		stats.Maps = append(stats.Maps, MapPlayerStats{MapName: m.MapName, PlayerStatsTotals: m.PlayerStatsTotals.WithRatios()})
+	}
+
+	stats.PlayerStatsTotals = stats.PlayerStatsTotals.WithRatios()
+
+	sort.SliceStable(stats.Maps, func(i, j int) bool {
+		if stats.Maps[i].Matches != stats.Maps[j].Matches {
+			return stats.Maps[i].Matches > stats.Maps[j].Matches
+		}
+
+		return stats.Maps[i].MapName < stats.Maps[j].MapName
+	})
+
+	return stats
+}
@@ -0,0 +1,104 @@
+package entities_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	cs_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/cs/entities"
+	replay_entity "github.com/psavelis/team-pro/replay-api/pkg/domain/replay/entities"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewPlayerStatsBuckets(t *testing.T) {
+	owner := common.ResourceOwner{TenantID: uuid.New(), ClientID: uuid.New(), UserID: uuid.New()}
+	replayFile := &replay_entity.ReplayFile{ID: uuid.New(), GameID: common.CS2_GAME_ID, ResourceOwner: owner}
+	playedAt := time.Date(2026, 10, 16, 21, 30, 0, 0, time.UTC)
+	now := time.Now()
+
+	event := func(eventType common.EventIDKey, payload interface{}) *replay_entity.GameEvent {
+		return replay_entity.NewGameEvent(uuid.New(), 0, 0, eventType, payload, nil, nil, owner)
+	}
+
+	clutch := func(round int, networkPlayerID uint64, status cs_entities.ClutchSituationStatusKey) cs_entities.CSRoundStats {
+		return cs_entities.CSRoundStats{RoundNumber: round, ClutchStats: &cs_entities.CSClutchStats{RoundNumber: round, NetworkPlayerID: networkPlayerID, Status: status}}
+	}
+
+	events := []*replay_entity.GameEvent{
+		event(common.Event_PlayerScoreboardID, replay_entity.PlayerScoreboardPayload{RoundNumber: 1, MapName: "de_inferno", Players: []replay_entity.PlayerScoreboardEntry{
+			{NetworkPlayerID: "1", Kills: 1, RoundsPlayed: 1},
+		}}),
+		event(common.Event_PlayerScoreboardID, replay_entity.PlayerScoreboardPayload{RoundNumber: 2, MapName: "de_inferno", Players: []replay_entity.PlayerScoreboardEntry{
+			{NetworkPlayerID: "2", Kills: 1, Deaths: 2, RoundsPlayed: 2},
+			{NetworkPlayerID: "1", Kills: 3, Deaths: 1, Assists: 1, Headshots: 2, Damage: 250, RoundsPlayed: 2},
+		}}),
+		// clutches are repeated by the ClutchEnd of the following rounds
+		event(common.Event_ClutchEndID, cs_entities.CSMatchStats{RoundsStats: []cs_entities.CSRoundStats{clutch(1, 1, cs_entities.ClutchWonKey)}}),
+		event(common.Event_ClutchEndID, cs_entities.CSMatchStats{RoundsStats: []cs_entities.CSRoundStats{clutch(1, 1, cs_entities.ClutchWonKey), clutch(2, 1, cs_entities.ClutchLostKey)}}),
+		event(common.Event_ClutchProgressID, cs_entities.CSMatchStats{RoundsStats: []cs_entities.CSRoundStats{clutch(3, 2, cs_entities.ClutchWonKey)}}),
+	}
+
+	buckets := replay_entity.NewPlayerStatsBuckets(replayFile, events, playedAt, now)
+
+	if !assert.Len(t, buckets, 2) {
+		return
+	}
+
+	day := time.Date(2026, 10, 16, 0, 0, 0, 0, time.UTC)
+	first := buckets[0]
+
+	assert.Equal(t, "1", first.NetworkPlayerID)
+	assert.Equal(t, "de_inferno", first.MapName)
+	assert.Equal(t, day, first.Day)
+	assert.Equal(t, 1, first.Matches)
+	assert.Equal(t, []int{2, 3, 1, 1, 2, 250}, []int{first.Rounds, first.Kills, first.Deaths, first.Assists, first.Headshots, first.Damage})
+	assert.Equal(t, 2, first.ClutchesPlayed)
+	assert.Equal(t, 1, first.ClutchesWon)
+	assert.Equal(t, common.ResourceOwner{TenantID: owner.TenantID, ClientID: owner.ClientID}, first.ResourceOwner, "buckets are shared by the users of the tenant")
+	assert.Equal(t, replay_entity.PlayerStatsBucketID(first.ResourceOwner, common.CS2_GAME_ID, "1", "de_inferno", playedAt.Add(-time.Hour), common.ReplayTags{}), first.ID, "replays of the same day share the bucket")
+
+	assert.Equal(t, 0, buckets[1].ClutchesPlayed)
+
+	assert.Empty(t, replay_entity.NewPlayerStatsBuckets(replayFile, events[2:], playedAt, now), "no scoreboard, no buckets")
+
+	// tagged replays are bucketed by their tags
+	replayFile.Tags = &common.ReplayTags{Platform: common.ReplayPlatformValveMM, ServerRegion: common.SouthAmerica_RegionIDKey, ServerLocation: "gru", Tickrate: 64}
+
+	tagged := replay_entity.NewPlayerStatsBuckets(replayFile, events, playedAt, now)[0]
+	assert.Equal(t, *replayFile.Tags, tagged.Tags)
+	assert.NotEqual(t, first.ID, tagged.ID)
+}
+
+func TestReplayTagsOf(t *testing.T) {
+	owner := common.ResourceOwner{TenantID: uuid.New()}
+	tags := common.ReplayTags{Platform: common.ReplayPlatformFaceit, Tickrate: 128}
+
+	events := []*replay_entity.GameEvent{
+		replay_entity.NewGameEvent(uuid.New(), 0, 0, common.Event_RoundStartID, cs_entities.CSMatchStats{}, nil, nil, owner),
+		replay_entity.NewGameEvent(uuid.New(), 0, 0, common.Event_MatchStartID, cs_entities.CSMatchStats{Header: &cs_entities.CSReplayFileHeader{Tags: tags}}, nil, nil, owner),
+	}
+
+	assert.Equal(t, &tags, replay_entity.ReplayTagsOf(events))
+	assert.Nil(t, replay_entity.ReplayTagsOf(events[:1]), "no match start, no tags")
+}
+
+func TestNewPlayerStats(t *testing.T) {
+	stats := replay_entity.NewPlayerStats(uuid.New(), "1", nil, nil, []replay_entity.MapPlayerStats{
+		{MapName: "de_inferno", PlayerStatsTotals: replay_entity.PlayerStatsTotals{Matches: 1, Rounds: 10, Kills: 10, Deaths: 5, Headshots: 5, Damage: 1000, ClutchesPlayed: 1}},
+		{MapName: "de_mirage", PlayerStatsTotals: replay_entity.PlayerStatsTotals{Matches: 2, Rounds: 30, Kills: 10, Damage: 2000, ClutchesPlayed: 1, ClutchesWon: 1}},
+	})
+
+	assert.Equal(t, 3, stats.Matches)
+	assert.Equal(t, 20, stats.Kills)
+	assert.InDelta(t, 4.0, stats.KD, 0.001)
+	assert.InDelta(t, 75.0, stats.ADR, 0.001)
+	assert.InDelta(t, 25.0, stats.HSPercentage, 0.001)
+	assert.InDelta(t, 0.5, stats.ClutchRate, 0.001)
+
+	if assert.Len(t, stats.Maps, 2) {
+		assert.Equal(t, "de_mirage", stats.Maps[0].MapName, "most played first")
+		assert.InDelta(t, 10.0, stats.Maps[0].KD, 0.001, "kills when never died")
+		assert.InDelta(t, 100.0, stats.Maps[1].ADR, 0.001)
+	}
+}
@@ -0,0 +1,75 @@
+package entities
+
+import (
+	"github.com/google/uuid"
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	cs_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/cs/entities"
+)
+
+// EconomyDecision is the buy of a side in a round, as reconstructed from its equipment and spending (or as simulated).
+type EconomyDecision string
+
+const (
+	EconomyDecisionSave    EconomyDecision = "save"
+	EconomyDecisionHalfBuy EconomyDecision = "half_buy" // a partial buy: a force buy, or an upgrade
+	EconomyDecisionFullBuy EconomyDecision = "full_buy"
+)
+
+var EconomyDecisions = []EconomyDecision{EconomyDecisionSave, EconomyDecisionHalfBuy, EconomyDecisionFullBuy}
+
+// EconomyProjection is the money of a side at the start of the next round, had it made the decision (kill rewards aren't projected).
+type EconomyProjection struct {
+	Decision      EconomyDecision `json:"decision"`
+	Spent         int             `json:"spent"`
+	MoneyIfWon    int             `json:"money_if_won"`
+	MoneyIfLost   int             `json:"money_if_lost"`
+	FullBuyIfWon  bool            `json:"full_buy_if_won"`  // the side affords a full buy in the next round, after winning this one
+	FullBuyIfLost bool            `json:"full_buy_if_lost"` // the side affords a full buy in the next round, even after losing this one
+}
+
+// TeamEconomyAnalysis is the buy of a side in a round, the alternatives it had and the one recommended. Money is summed over the
+// players of the side (the projections assume it evenly split among them).
+type TeamEconomyAnalysis struct {
+	Side           RoundSide                  `json:"side"`
+	Players        int                        `json:"players"`
+	BuyType        cs_entities.CSEconomyState `json:"buy_type"` // as detected while parsing
+	Decision       EconomyDecision            `json:"decision"`
+	MoneyStart     int                        `json:"money_start"` // before buying
+	Spent          int                        `json:"spent"`
+	EquipmentValue int                        `json:"equipment_value"`
+	LossStreak     int                        `json:"loss_streak"` // loss bonus level before the round (0 pays the least)
+	Won            bool                       `json:"won"`
+	ExpectedIncome int                        `json:"expected_income"`  // round reward (win, loss bonus and plant bonus)
+	Income         *int                       `json:"income,omitempty"` // as seen in the next round of the half (the round reward plus kill rewards)
+	Projections    []EconomyProjection        `json:"projections"`      // of the decisions the side could afford
+	Recommended    EconomyDecision            `json:"recommended"`
+	Reason         string                     `json:"reason"` // of the recommendation
+	Misbuy         bool                       `json:"misbuy"` // bought other than recommended
+}
+
+// Projection of a decision, when the side could afford it.
+func (a TeamEconomyAnalysis) Projection(decision EconomyDecision) (EconomyProjection, bool) {
+	for _, p := range a.Projections {
+		if p.Decision == decision {
+			return p, true
+		}
+	}
+
+	return EconomyProjection{}, false
+}
+
+type RoundEconomyAnalysis struct {
+	RoundNumber int                   `json:"round_number"`
+	Pistol      bool                  `json:"pistol"`
+	LastOfHalf  bool                  `json:"last_of_half"` // money is reset after it: spending it all is recommended
+	Winner      RoundSide             `json:"winner,omitempty"`
+	Teams       []TeamEconomyAnalysis `json:"teams"`
+}
+
+// ReplayEconomy is the economy simulation of the rounds of a replay: what each side bought, and what it should have bought.
+type ReplayEconomy struct {
+	ReplayFileID uuid.UUID              `json:"replay_file_id"`
+	MatchID      uuid.UUID              `json:"match_id"`
+	GameID       common.GameIDKey       `json:"game_id"`
+	Rounds       []RoundEconomyAnalysis `json:"rounds"` // of the rounds with economy (the ones played after the buy time)
+}
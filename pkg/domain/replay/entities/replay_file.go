@@ -14,10 +14,37 @@ const (
 	ReplayFileStatusProcessing ReplayFileStatus = "Processing"
 	ReplayFileStatusFailed     ReplayFileStatus = "Failed"
 	ReplayFileStatusCompleted  ReplayFileStatus = "Completed"
+
+	// the parser crashed on the replay file too many times (see MaxReplayParseCrashes): it's no longer processed
+	ReplayFileStatusQuarantined ReplayFileStatus = "Quarantined"
+)
+
+// replay files whose parsing killed the parser process this many times are quarantined
+const MaxReplayParseCrashes = 2
+
+type ParseFailureReason string
+
+const (
+	ParseFailureCrashed     ParseFailureReason = "crashed" // panicked or killed by a signal
+	ParseFailureOutOfMemory ParseFailureReason = "out_of_memory"
+	ParseFailureCPULimit    ParseFailureReason = "cpu_limit"
+	ParseFailureTimeout     ParseFailureReason = "timeout"
 )
 
-func NewReplayFile(gameID common.GameIDKey, networkID common.NetworkIDKey, size int, uri string, resourceOwner common.ResourceOwner) *ReplayFile {
-	entity := common.NewEntity(resourceOwner)
+// ParseFailure is a crash of the (sandboxed) parser process on a replay file, as seen by the replay worker running it.
+type ParseFailure struct {
+	Attempt  int                `json:"attempt" bson:"attempt"`
+	Reason   ParseFailureReason `json:"reason" bson:"reason"`
+	ExitCode int                `json:"exit_code" bson:"exit_code"` // -1 when killed by a signal
+	Signal   string             `json:"signal,omitempty" bson:"signal,omitempty"`
+	Detail   string             `json:"detail,omitempty" bson:"detail,omitempty"` // tail of the parser output (ie: the panic)
+	At       time.Time          `json:"at" bson:"at"`
+}
+
+// NewReplayFile is visible as requested by the uploader, or as the default of the visibility policy of its owner (the override of a
+// policy is returned, for it to be audited).
+func NewReplayFile(gameID common.GameIDKey, networkID common.NetworkIDKey, size int, uri string, resourceOwner common.ResourceOwner, policies []common.VisibilityPolicy, requested common.Visibility) (*ReplayFile, *common.VisibilityOverride) {
+	entity, override := common.NewEntityWithVisibility(common.ResourceTypeReplayFile, resourceOwner, policies, requested)
 	return &ReplayFile{
 		ID:            entity.ID,
 		GameID:        gameID,
@@ -27,10 +54,11 @@ func NewReplayFile(gameID common.GameIDKey, networkID common.NetworkIDKey, size
 		Status:        ReplayFileStatusPending,
 		Error:         "",
 		Header:        nil,
+		Visibility:    entity.Visibility,
 		ResourceOwner: resourceOwner,
 		CreatedAt:     entity.CreatedAt,
 		UpdatedAt:     entity.UpdatedAt,
-	}
+	}, override
 }
 
 type ReplayFile struct {
@@ -45,8 +73,51 @@ type ReplayFile struct {
 	Status        ReplayFileStatus     `json:"status" bson:"status"`
 	Error         string               `json:"error" bson:"error"`
 	Header        interface{}          `json:"header" bson:"header"`
+	ParseFailures []ParseFailure       `json:"parse_failures,omitempty" bson:"parse_failures,omitempty"`
+	Visibility    common.Visibility    `json:"visibility,omitempty" bson:"visibility,omitempty"` // inherited by its match
+	Tags          *common.ReplayTags   `json:"tags,omitempty" bson:"tags,omitempty"`             // detected while parsing, inherited by its match
 }
 
 func (r ReplayFile) GetID() uuid.UUID {
 	return r.ID
 }
+
+// ReplayFileUploaded is published once the content of a replay file is stored, for a replay worker to process it.
+type ReplayFileUploaded struct {
+	ReplayFileID  uuid.UUID            `json:"replay_file_id"`
+	GameID        common.GameIDKey     `json:"game_id"`
+	Size          int                  `json:"size"`
+	ResourceOwner common.ResourceOwner `json:"resource_owner"`
+	UploadedAt    time.Time            `json:"uploaded_at"`
+
+	// delivery attempt (starting at 1), tracked by the consumer
+	Attempt int `json:"-"`
+}
+
+// ReplayProcessingProgress is published while a replay file is processed, so clients can follow it live.
+type ReplayProcessingProgress struct {
+	ReplayFileID    uuid.UUID        `json:"replay_file_id"`
+	Status          ReplayFileStatus `json:"status"`
+	Percent         float64          `json:"percent"` // share of the content parsed (0..100)
+	EventsExtracted int              `json:"events_extracted"`
+	CurrentRound    int              `json:"current_round"`
+	UpdatedAt       time.Time        `json:"updated_at"`
+
+	// id of the update in the stream delivering it (for clients resuming the stream), unset when not delivered through a stream
+	EventID uint64 `json:"event_id,omitempty"`
+}
+
+// NewReplayProcessingProgress is the progress known from the replay file alone (ie: before processing starts or once it's done).
+func NewReplayProcessingProgress(replayFile ReplayFile) ReplayProcessingProgress {
+	progress := ReplayProcessingProgress{
+		ReplayFileID: replayFile.ID,
+		Status:       replayFile.Status,
+		UpdatedAt:    replayFile.UpdatedAt,
+	}
+
+	if replayFile.Status == ReplayFileStatusCompleted {
+		progress.Percent = 100
+	}
+
+	return progress
+}
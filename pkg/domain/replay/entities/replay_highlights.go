@@ -0,0 +1,71 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+)
+
+// RoundKillsPayload is the payload of the RoundKills game events: the players and the kills of a round, emitted at its end.
+type RoundKillsPayload struct {
+	RoundNumber int                `json:"round_number" bson:"round_number"`
+	Players     []RoundKillsPlayer `json:"players" bson:"players"` // connected at the round end
+	Kills       []RoundKill        `json:"kills" bson:"kills"`     // in tick order
+}
+
+type RoundKillsPlayer struct {
+	NetworkPlayerID string    `json:"network_player_id,omitempty" bson:"network_player_id"` // empty for bots
+	Name            string    `json:"name" bson:"name"`
+	Side            RoundSide `json:"side" bson:"side"`
+}
+
+type RoundKill struct {
+	TickID                common.TickIDType `json:"tick_id" bson:"tick_id"`
+	KillerNetworkPlayerID string            `json:"killer_network_player_id,omitempty" bson:"killer_network_player_id"` // empty for bots and the world
+	KillerName            string            `json:"killer_name,omitempty" bson:"killer_name"`
+	KillerSide            RoundSide         `json:"killer_side,omitempty" bson:"killer_side"`
+	VictimNetworkPlayerID string            `json:"victim_network_player_id,omitempty" bson:"victim_network_player_id"`
+	VictimName            string            `json:"victim_name" bson:"victim_name"`
+	VictimSide            RoundSide         `json:"victim_side" bson:"victim_side"`
+	Weapon                string            `json:"weapon,omitempty" bson:"weapon"`
+	Headshot              bool              `json:"headshot" bson:"headshot"`
+}
+
+type HighlightType string
+
+const (
+	HighlightTypeAce         HighlightType = "ace"          // all the opponents killed by a single player
+	HighlightTypeMultiKill   HighlightType = "multi_kill"   // 4k+ rounds (but aces)
+	HighlightTypeClutch      HighlightType = "clutch"       // 1vX won
+	HighlightTypeNinjaDefuse HighlightType = "ninja_defuse" // bomb defused with terrorists alive
+)
+
+// Highlight is a moment of a replay, by its tick range (so the playback can be deep-linked to it).
+type Highlight struct {
+	Type            HighlightType     `json:"type" bson:"type"`
+	RoundNumber     int               `json:"round_number" bson:"round_number"`
+	NetworkPlayerID string            `json:"network_player_id" bson:"network_player_id"`
+	PlayerName      string            `json:"player_name" bson:"player_name"`
+	Side            RoundSide         `json:"side" bson:"side"`
+	Kills           int               `json:"kills" bson:"kills"`
+	Opponents       int               `json:"opponents,omitempty" bson:"opponents"` // clutches: the X of the 1vX; ninja defuses: terrorists alive
+	StartTick       common.TickIDType `json:"start_tick" bson:"start_tick"`
+	EndTick         common.TickIDType `json:"end_tick" bson:"end_tick"`
+}
+
+// ReplayHighlights holds the highlights of a replay (one document per replay), detected once it's parsed (see HighlightDetectionService).
+type ReplayHighlights struct {
+	ID            uuid.UUID            `json:"id" bson:"_id"`
+	GameID        common.GameIDKey     `json:"game_id" bson:"game_id"`
+	MatchID       uuid.UUID            `json:"match_id" bson:"match_id"`
+	ReplayFileID  uuid.UUID            `json:"replay_file_id" bson:"replay_file_id"`
+	Highlights    []Highlight          `json:"highlights" bson:"highlights"`
+	ResourceOwner common.ResourceOwner `json:"resource_owner" bson:"resource_owner"`
+	CreatedAt     time.Time            `json:"created_at" bson:"created_at"`
+	UpdatedAt     time.Time            `json:"updated_at" bson:"updated_at"`
+}
+
+func (h ReplayHighlights) GetID() uuid.UUID {
+	return h.ID
+}
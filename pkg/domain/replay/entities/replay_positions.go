@@ -0,0 +1,159 @@
+package entities
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+)
+
+type PositionSampleType string
+
+const (
+	PositionSampleTypePosition PositionSampleType = "position" // alive player, sampled at a fixed tick interval
+	PositionSampleTypeDeath    PositionSampleType = "death"    // victim, where killed
+	PositionSampleTypeGrenade  PositionSampleType = "grenade"  // detonation
+)
+
+// PositionSample is a point of the map, in whole game units. Bson names are kept short: a replay holds tens of thousands of samples.
+type PositionSample struct {
+	Type            PositionSampleType `json:"type" bson:"t"`
+	Side            RoundSide          `json:"side" bson:"s"` // side of the player (the victim's on deaths, the thrower's on grenades)
+	NetworkPlayerID string             `json:"network_player_id,omitempty" bson:"p,omitempty"`
+	Grenade         string             `json:"grenade,omitempty" bson:"g,omitempty"` // ie: "he", "flash", "smoke", "fire"
+	TickID          common.TickIDType  `json:"tick_id" bson:"k"`
+	X               int                `json:"x" bson:"x"`
+	Y               int                `json:"y" bson:"y"`
+	Z               int                `json:"z" bson:"z"`
+}
+
+// PositionSamplesPayload is the payload of the PositionSamples game events: the samples of a round, emitted at its end.
+type PositionSamplesPayload struct {
+	RoundNumber int              `json:"round_number" bson:"round_number"`
+	MapName     string           `json:"map_name" bson:"map_name"`
+	Samples     []PositionSample `json:"samples" bson:"samples"`
+}
+
+// ReplayPositions holds the position samples of a round of a replay (one document per round keeps them well under the document size limit).
+type ReplayPositions struct {
+	ID            uuid.UUID            `json:"id" bson:"_id"`
+	GameID        common.GameIDKey     `json:"game_id" bson:"game_id"`
+	MatchID       uuid.UUID            `json:"match_id" bson:"match_id"`
+	ReplayFileID  uuid.UUID            `json:"replay_file_id" bson:"replay_file_id"`
+	MapName       string               `json:"map_name" bson:"map_name"`
+	RoundNumber   int                  `json:"round_number" bson:"round_number"`
+	Samples       []PositionSample     `json:"samples" bson:"samples"`
+	ResourceOwner common.ResourceOwner `json:"resource_owner" bson:"resource_owner"`
+	CreatedAt     time.Time            `json:"created_at" bson:"created_at"`
+}
+
+func (p ReplayPositions) GetID() uuid.UUID {
+	return p.ID
+}
+
+// NewReplayPositions keeps the PositionSamples events of a parsed replay, by round (the last event of a round wins).
+func NewReplayPositions(replayFile *ReplayFile, matchID uuid.UUID, events []*GameEvent, now time.Time) []*ReplayPositions {
+	rounds := make(map[int]PositionSamplesPayload)
+
+	for _, event := range events {
+		payload, ok := event.Payload.(PositionSamplesPayload)
+		if !ok || payload.RoundNumber <= 0 || len(payload.Samples) == 0 {
+			continue
+		}
+
+		rounds[payload.RoundNumber] = payload
+	}
+
+	positions := make([]*ReplayPositions, 0, len(rounds))
+	for _, round := range rounds {
+		positions = append(positions, &ReplayPositions{
+			ID:            uuid.New(),
+			GameID:        replayFile.GameID,
+			MatchID:       matchID,
+			ReplayFileID:  replayFile.ID,
+			MapName:       round.MapName,
+			RoundNumber:   round.RoundNumber,
+			Samples:       round.Samples,
+			ResourceOwner: replayFile.ResourceOwner,
+			CreatedAt:     now,
+		})
+	}
+
+	sort.Slice(positions, func(i, j int) bool {
+		return positions[i].RoundNumber < positions[j].RoundNumber
+	})
+
+	return positions
+}
+
+const (
+	DefaultHeatmapCellSize = 64
+	MinHeatmapCellSize     = 8
+	MaxHeatmapCellSize     = 1024
+)
+
+// HeatmapCell counts the samples of a square of the map, from (X, Y) to (X+CellSize, Y+CellSize).
+type HeatmapCell struct {
+	X     int `json:"x"`
+	Y     int `json:"y"`
+	Count int `json:"count"`
+}
+
+type ReplayHeatmap struct {
+	ReplayFileID uuid.UUID          `json:"replay_file_id"`
+	MapName      string             `json:"map_name"`
+	Type         PositionSampleType `json:"type"`
+	Side         RoundSide          `json:"side,omitempty"` // both sides when empty
+	CellSize     int                `json:"cell_size"`
+	Samples      int                `json:"samples"`
+	Cells        []HeatmapCell      `json:"cells"`
+}
+
+// NewReplayHeatmap bins the samples of the given type (and side, when informed) of a replay into a grid of cellSize units (top-down:
+// Z is ignored).
+func NewReplayHeatmap(replayFileID uuid.UUID, positions []ReplayPositions, sampleType PositionSampleType, side RoundSide, cellSize int) *ReplayHeatmap {
+	heatmap := &ReplayHeatmap{
+		ReplayFileID: replayFileID,
+		Type:         sampleType,
+		Side:         side,
+		CellSize:     cellSize,
+		Cells:        make([]HeatmapCell, 0),
+	}
+
+	type cellKey struct{ x, y int }
+	counts := make(map[cellKey]int)
+
+	for _, round := range positions {
+		heatmap.MapName = round.MapName
+
+		for _, sample := range round.Samples {
+			if sample.Type != sampleType || (side != "" && sample.Side != side) {
+				continue
+			}
+
+			key := cellKey{
+				x: int(math.Floor(float64(sample.X)/float64(cellSize))) * cellSize,
+				y: int(math.Floor(float64(sample.Y)/float64(cellSize))) * cellSize,
+			}
+
+			counts[key]++
+			heatmap.Samples++
+		}
+	}
+
+	for key, count := range counts {
+		heatmap.Cells = append(heatmap.Cells, HeatmapCell{X: key.x, Y: key.y, Count: count})
+	}
+
+	sort.Slice(heatmap.Cells, func(i, j int) bool {
+		if heatmap.Cells[i].Y != heatmap.Cells[j].Y {
+			return heatmap.Cells[i].Y < heatmap.Cells[j].Y
+		}
+
+		return heatmap.Cells[i].X < heatmap.Cells[j].X
+	})
+
+	return heatmap
+}
@@ -0,0 +1,80 @@
+package entities_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	replay_entity "github.com/psavelis/team-pro/replay-api/pkg/domain/replay/entities"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewReplayPositions(t *testing.T) {
+	owner := common.ResourceOwner{TenantID: uuid.New(), ClientID: uuid.New(), UserID: uuid.New()}
+	replayFile := &replay_entity.ReplayFile{ID: uuid.New(), GameID: common.CS2_GAME_ID, ResourceOwner: owner}
+	matchID := uuid.New()
+
+	event := func(eventType common.EventIDKey, payload interface{}) *replay_entity.GameEvent {
+		return replay_entity.NewGameEvent(matchID, 0, 0, eventType, payload, nil, nil, owner)
+	}
+
+	sample := replay_entity.PositionSample{Type: replay_entity.PositionSampleTypePosition, Side: replay_entity.RoundSideCT, X: 10, Y: 20}
+
+	events := []*replay_entity.GameEvent{
+		event(common.Event_PositionSamplesID, replay_entity.PositionSamplesPayload{RoundNumber: 2, MapName: "de_inferno", Samples: []replay_entity.PositionSample{sample, sample}}),
+		event(common.Event_PositionSamplesID, replay_entity.PositionSamplesPayload{RoundNumber: 1, MapName: "de_inferno", Samples: []replay_entity.PositionSample{sample}}),
+		event(common.Event_PositionSamplesID, replay_entity.PositionSamplesPayload{RoundNumber: 3, MapName: "de_inferno"}),
+		event(common.Event_RoundStartID, replay_entity.RoundStartPayload{RoundNumber: 4}),
+	}
+
+	positions := replay_entity.NewReplayPositions(replayFile, matchID, events, time.Now())
+
+	if !assert.Len(t, positions, 2) {
+		return
+	}
+
+	assert.Equal(t, 1, positions[0].RoundNumber)
+	assert.Equal(t, 2, positions[1].RoundNumber)
+	assert.Len(t, positions[1].Samples, 2)
+	assert.Equal(t, replayFile.ID, positions[0].ReplayFileID)
+	assert.Equal(t, matchID, positions[0].MatchID)
+	assert.Equal(t, owner, positions[0].ResourceOwner)
+	assert.Equal(t, "de_inferno", positions[0].MapName)
+}
+
+func TestNewReplayHeatmap(t *testing.T) {
+	replayFileID := uuid.New()
+
+	positions := []replay_entity.ReplayPositions{
+		{MapName: "de_inferno", RoundNumber: 1, Samples: []replay_entity.PositionSample{
+			{Type: replay_entity.PositionSampleTypePosition, Side: replay_entity.RoundSideCT, X: 10, Y: 10},
+			{Type: replay_entity.PositionSampleTypePosition, Side: replay_entity.RoundSideT, X: 63, Y: 0},
+			{Type: replay_entity.PositionSampleTypePosition, Side: replay_entity.RoundSideCT, X: -1, Y: 70},
+			{Type: replay_entity.PositionSampleTypeDeath, Side: replay_entity.RoundSideCT, X: 10, Y: 10},
+		}},
+		{MapName: "de_inferno", RoundNumber: 2, Samples: []replay_entity.PositionSample{
+			{Type: replay_entity.PositionSampleTypePosition, Side: replay_entity.RoundSideCT, X: 64, Y: 0},
+		}},
+	}
+
+	heatmap := replay_entity.NewReplayHeatmap(replayFileID, positions, replay_entity.PositionSampleTypePosition, "", 64)
+
+	assert.Equal(t, "de_inferno", heatmap.MapName)
+	assert.Equal(t, 4, heatmap.Samples)
+	assert.Equal(t, []replay_entity.HeatmapCell{
+		{X: 0, Y: 0, Count: 2},
+		{X: 64, Y: 0, Count: 1},
+		{X: -64, Y: 64, Count: 1},
+	}, heatmap.Cells)
+
+	heatmap = replay_entity.NewReplayHeatmap(replayFileID, positions, replay_entity.PositionSampleTypePosition, replay_entity.RoundSideT, 64)
+
+	assert.Equal(t, 1, heatmap.Samples)
+	assert.Equal(t, []replay_entity.HeatmapCell{{X: 0, Y: 0, Count: 1}}, heatmap.Cells)
+
+	heatmap = replay_entity.NewReplayHeatmap(replayFileID, positions, replay_entity.PositionSampleTypeGrenade, "", 64)
+
+	assert.Equal(t, 0, heatmap.Samples)
+	assert.Empty(t, heatmap.Cells)
+}
@@ -0,0 +1,24 @@
+package entities
+
+import (
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	cs_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/cs/entities"
+)
+
+// ReplayTagsOf returns the tags detected while parsing a replay, from the header of its last match start (nil when it has none).
+func ReplayTagsOf(events []*GameEvent) *common.ReplayTags {
+	var tags *common.ReplayTags
+
+	for _, event := range events {
+		if event.Type != common.Event_MatchStartID {
+			continue
+		}
+
+		if payload, ok := event.Payload.(cs_entities.CSMatchStats); ok && payload.Header != nil {
+			t := payload.Header.Tags
+			tags = &t
+		}
+	}
+
+	return tags
+}
@@ -0,0 +1,193 @@
+package entities
+
+import (
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	cs_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/cs/entities"
+)
+
+type RoundSide string
+
+const (
+	RoundSideCT RoundSide = "CT"
+	RoundSideT  RoundSide = "T"
+)
+
+type BombEventType string
+
+const (
+	BombEventPlanted  BombEventType = "planted"
+	BombEventDefused  BombEventType = "defused"
+	BombEventExploded BombEventType = "exploded"
+)
+
+// RoundStartPayload is the payload of the RoundStart game events.
+type RoundStartPayload struct {
+	RoundNumber int `json:"round_number" bson:"round_number"`
+}
+
+// RoundEndPayload is the payload of the RoundEnd game events emitted for the round timeline.
+type RoundEndPayload struct {
+	RoundNumber int       `json:"round_number" bson:"round_number"`
+	Winner      RoundSide `json:"winner,omitempty" bson:"winner"` // empty on a draw
+	Reason      string    `json:"reason" bson:"reason"`           // ie: "target_bombed", "bomb_defused", "ct_win", "t_win", "target_saved"
+}
+
+// BombPayload is the payload of the BombPlanted, BombDefused and BombExploded game events.
+type BombPayload struct {
+	RoundNumber     int    `json:"round_number" bson:"round_number"`
+	Site            string `json:"site,omitempty" bson:"site"`
+	NetworkPlayerID string `json:"network_player_id,omitempty" bson:"network_player_id"`
+	PlayerName      string `json:"player_name,omitempty" bson:"player_name"`
+}
+
+// RoundEconomyPayload is the payload of the Economy game events emitted for the round timeline, once the buy time is over.
+type RoundEconomyPayload struct {
+	RoundNumber int                `json:"round_number" bson:"round_number"`
+	Teams       []TeamRoundEconomy `json:"teams" bson:"teams"`
+}
+
+type TeamRoundEconomy struct {
+	Side           RoundSide                  `json:"side" bson:"side"`
+	BuyType        cs_entities.CSEconomyState `json:"buy_type" bson:"buy_type"`
+	EquipmentValue int                        `json:"equipment_value" bson:"equipment_value"`
+	Money          int                        `json:"money" bson:"money"` // left after buying
+	Spent          int                        `json:"spent" bson:"spent"`
+	Players        int                        `json:"players" bson:"players"` // connected, 0 on the timelines built before it was tracked
+}
+
+type RoundBombEvent struct {
+	Type            BombEventType     `json:"type" bson:"type"`
+	Site            string            `json:"site,omitempty" bson:"site"`
+	NetworkPlayerID string            `json:"network_player_id,omitempty" bson:"network_player_id"`
+	PlayerName      string            `json:"player_name,omitempty" bson:"player_name"`
+	TickID          common.TickIDType `json:"tick_id" bson:"tick_id"`
+	GameTime        time.Duration     `json:"event_time" bson:"event_time"`
+}
+
+type RoundClutch struct {
+	NetworkPlayerID uint64                               `json:"network_player_id" bson:"network_player_id"`
+	Opponents       int                                  `json:"opponents" bson:"opponents"`
+	Status          cs_entities.ClutchSituationStatusKey `json:"status" bson:"status"`
+}
+
+type RoundTimelineEntry struct {
+	RoundNumber int                `json:"round_number" bson:"round_number"`
+	StartTick   common.TickIDType  `json:"start_tick" bson:"start_tick"`
+	EndTick     common.TickIDType  `json:"end_tick" bson:"end_tick"`
+	StartTime   time.Duration      `json:"start_time" bson:"start_time"`
+	EndTime     time.Duration      `json:"end_time" bson:"end_time"`
+	Winner      RoundSide          `json:"winner,omitempty" bson:"winner"`
+	EndReason   string             `json:"end_reason,omitempty" bson:"end_reason"`
+	Bomb        []RoundBombEvent   `json:"bomb" bson:"bomb"`
+	Clutch      *RoundClutch       `json:"clutch,omitempty" bson:"clutch"`
+	Economy     []TeamRoundEconomy `json:"economy" bson:"economy"`
+}
+
+// RoundTimeline is a read model of the rounds of a replay (one document per replay), built from its game events once it's parsed.
+type RoundTimeline struct {
+	ID            uuid.UUID            `json:"id" bson:"_id"`
+	GameID        common.GameIDKey     `json:"game_id" bson:"game_id"`
+	MatchID       uuid.UUID            `json:"match_id" bson:"match_id"`
+	ReplayFileID  uuid.UUID            `json:"replay_file_id" bson:"replay_file_id"`
+	Rounds        []RoundTimelineEntry `json:"rounds" bson:"rounds"`
+	ResourceOwner common.ResourceOwner `json:"resource_owner" bson:"resource_owner"`
+	CreatedAt     time.Time            `json:"created_at" bson:"created_at"`
+	UpdatedAt     time.Time            `json:"updated_at" bson:"updated_at"`
+}
+
+func (t RoundTimeline) GetID() uuid.UUID {
+	return t.ID
+}
+
+// NewRoundTimeline groups the round events of a parsed replay by round, in round order. Events of other types (or payloads) are
+// ignored, and clutches are read from the round stats of the ClutchEnd events.
+func NewRoundTimeline(replayFile *ReplayFile, matchID uuid.UUID, events []*GameEvent, now time.Time) *RoundTimeline {
+	rounds := make(map[int]*RoundTimelineEntry)
+
+	round := func(number int) *RoundTimelineEntry {
+		if rounds[number] == nil {
+			rounds[number] = &RoundTimelineEntry{RoundNumber: number, Bomb: make([]RoundBombEvent, 0), Economy: make([]TeamRoundEconomy, 0)}
+		}
+
+		return rounds[number]
+	}
+
+	for _, event := range events {
+		switch payload := event.Payload.(type) {
+		case RoundStartPayload:
+			r := round(payload.RoundNumber)
+			r.StartTick = event.TickID
+			r.StartTime = event.GameTime
+		case RoundEndPayload:
+			r := round(payload.RoundNumber)
+			r.EndTick = event.TickID
+			r.EndTime = event.GameTime
+			r.Winner = payload.Winner
+			r.EndReason = payload.Reason
+		case BombPayload:
+			r := round(payload.RoundNumber)
+			r.Bomb = append(r.Bomb, RoundBombEvent{
+				Type:            bombEventTypes[event.Type],
+				Site:            payload.Site,
+				NetworkPlayerID: payload.NetworkPlayerID,
+				PlayerName:      payload.PlayerName,
+				TickID:          event.TickID,
+				GameTime:        event.GameTime,
+			})
+		case RoundEconomyPayload:
+			round(payload.RoundNumber).Economy = payload.Teams
+		case cs_entities.CSMatchStats:
+			if event.Type != common.Event_ClutchEndID {
+				continue
+			}
+
+			for _, stats := range payload.RoundsStats {
+				clutch := stats.ClutchStats
+				if clutch == nil || (clutch.Status != cs_entities.ClutchWonKey && clutch.Status != cs_entities.ClutchLostKey) {
+					continue
+				}
+
+				round(clutch.RoundNumber).Clutch = &RoundClutch{
+					NetworkPlayerID: clutch.NetworkPlayerID,
+					Opponents:       len(clutch.OpponentsStats),
+					Status:          clutch.Status,
+				}
+			}
+		}
+	}
+
+	timeline := &RoundTimeline{
+		ID:            uuid.New(),
+		GameID:        replayFile.GameID,
+		MatchID:       matchID,
+		ReplayFileID:  replayFile.ID,
+		Rounds:        make([]RoundTimelineEntry, 0, len(rounds)),
+		ResourceOwner: replayFile.ResourceOwner,
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	}
+
+	for number, r := range rounds {
+		if number <= 0 {
+			continue
+		}
+
+		timeline.Rounds = append(timeline.Rounds, *r)
+	}
+
+	sort.Slice(timeline.Rounds, func(i, j int) bool {
+		return timeline.Rounds[i].RoundNumber < timeline.Rounds[j].RoundNumber
+	})
+
+	return timeline
+}
+
+var bombEventTypes = map[common.EventIDKey]BombEventType{
+	common.Event_BombPlantedID:  BombEventPlanted,
+	common.Event_BombDefusedID:  BombEventDefused,
+	common.Event_BombExplodedID: BombEventExploded,
+}
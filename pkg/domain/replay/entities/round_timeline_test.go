@@ -0,0 +1,73 @@
+package entities_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	cs_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/cs/entities"
+	replay_entity "github.com/psavelis/team-pro/replay-api/pkg/domain/replay/entities"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewRoundTimeline(t *testing.T) {
+	replayFile := &replay_entity.ReplayFile{ID: uuid.New(), GameID: common.CS2_GAME_ID, ResourceOwner: common.ResourceOwner{TenantID: uuid.New()}}
+	matchID := uuid.New()
+
+	event := func(tick common.TickIDType, eventType common.EventIDKey, payload interface{}) *replay_entity.GameEvent {
+		return replay_entity.NewGameEvent(matchID, tick, time.Duration(tick)*time.Second, eventType, payload, nil, nil, replayFile.ResourceOwner)
+	}
+
+	events := []*replay_entity.GameEvent{
+		// warmup: not a round
+		event(1, common.Event_RoundEndID, replay_entity.RoundEndPayload{RoundNumber: 0, Reason: "draw"}),
+		event(20, common.Event_RoundStartID, replay_entity.RoundStartPayload{RoundNumber: 2}),
+		event(30, common.Event_RoundEndID, replay_entity.RoundEndPayload{RoundNumber: 2, Winner: replay_entity.RoundSideCT, Reason: "bomb_defused"}),
+		event(10, common.Event_RoundStartID, replay_entity.RoundStartPayload{RoundNumber: 1}),
+		event(12, common.Event_Economy, replay_entity.RoundEconomyPayload{RoundNumber: 1, Teams: []replay_entity.TeamRoundEconomy{{Side: replay_entity.RoundSideT, BuyType: cs_entities.CSEconomyStateEco}}}),
+		event(15, common.Event_BombPlantedID, replay_entity.BombPayload{RoundNumber: 1, Site: "A", PlayerName: "planter"}),
+		event(18, common.Event_BombExplodedID, replay_entity.BombPayload{RoundNumber: 1, Site: "A"}),
+		event(19, common.Event_RoundEndID, replay_entity.RoundEndPayload{RoundNumber: 1, Winner: replay_entity.RoundSideT, Reason: "target_bombed"}),
+		event(29, common.Event_ClutchEndID, cs_entities.CSMatchStats{RoundsStats: []cs_entities.CSRoundStats{
+			{RoundNumber: 2, ClutchStats: &cs_entities.CSClutchStats{RoundNumber: 2, NetworkPlayerID: 7, OpponentsStats: make([]*cs_entities.CSPlayerStats, 2), Status: cs_entities.ClutchWonKey}},
+			{RoundNumber: 1, ClutchStats: &cs_entities.CSClutchStats{RoundNumber: 1, NetworkPlayerID: 8, Status: cs_entities.ClutchProgressKey}},
+		}}),
+		// not a round timeline event
+		event(16, common.Event_FragOrScoreID, struct{}{}),
+	}
+
+	now := time.Now()
+	timeline := replay_entity.NewRoundTimeline(replayFile, matchID, events, now)
+
+	assert.Equal(t, replayFile.ID, timeline.ReplayFileID)
+	assert.Equal(t, replayFile.GameID, timeline.GameID)
+	assert.Equal(t, matchID, timeline.MatchID)
+	assert.Equal(t, replayFile.ResourceOwner, timeline.ResourceOwner)
+	assert.Equal(t, now, timeline.CreatedAt)
+
+	if !assert.Len(t, timeline.Rounds, 2) {
+		return
+	}
+
+	first := timeline.Rounds[0]
+	assert.Equal(t, 1, first.RoundNumber)
+	assert.Equal(t, common.TickIDType(10), first.StartTick)
+	assert.Equal(t, common.TickIDType(19), first.EndTick)
+	assert.Equal(t, replay_entity.RoundSideT, first.Winner)
+	assert.Equal(t, "target_bombed", first.EndReason)
+	assert.Equal(t, []replay_entity.BombEventType{replay_entity.BombEventPlanted, replay_entity.BombEventExploded}, []replay_entity.BombEventType{first.Bomb[0].Type, first.Bomb[1].Type})
+	assert.Equal(t, "planter", first.Bomb[0].PlayerName)
+	assert.Equal(t, cs_entities.CSEconomyStateEco, first.Economy[0].BuyType)
+	assert.Nil(t, first.Clutch, "clutches still in progress are not part of the timeline")
+
+	second := timeline.Rounds[1]
+	assert.Equal(t, 2, second.RoundNumber)
+	assert.Equal(t, replay_entity.RoundSideCT, second.Winner)
+	assert.Empty(t, second.Bomb)
+	if assert.NotNil(t, second.Clutch) {
+		assert.Equal(t, uint64(7), second.Clutch.NetworkPlayerID)
+		assert.Equal(t, 2, second.Clutch.Opponents)
+		assert.Equal(t, cs_entities.ClutchWonKey, second.Clutch.Status)
+	}
+}
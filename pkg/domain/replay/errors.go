@@ -0,0 +1,75 @@
+package replay
+
+import (
+	"fmt"
+
+	"github.com/google/uuid"
+	replay_entity "github.com/psavelis/team-pro/replay-api/pkg/domain/replay/entities"
+)
+
+type ReplayFileNotFoundError struct {
+	Message string
+}
+
+func (e *ReplayFileNotFoundError) Error() string {
+	return e.Message
+}
+
+func NewReplayFileNotFoundError(replayFileID uuid.UUID) *ReplayFileNotFoundError {
+	return &ReplayFileNotFoundError{
+		Message: fmt.Sprintf("replay file %s not found", replayFileID),
+	}
+}
+
+// ReplayFileStateError is returned when the replay file can't be acted on in its current status (ie: requeueing a replay file being
+// processed).
+type ReplayFileStateError struct {
+	Message string
+}
+
+func (e *ReplayFileStateError) Error() string {
+	return e.Message
+}
+
+func NewReplayFileStateError(message string) *ReplayFileStateError {
+	return &ReplayFileStateError{
+		Message: message,
+	}
+}
+
+type PlayerNotFoundError struct {
+	Message string
+}
+
+func (e *PlayerNotFoundError) Error() string {
+	return e.Message
+}
+
+func NewPlayerNotFoundError(playerID uuid.UUID) *PlayerNotFoundError {
+	return &PlayerNotFoundError{
+		Message: fmt.Sprintf("player %s not found", playerID),
+	}
+}
+
+// ReplayParserCrashError is returned when the parser process processing a replay file didn't exit on its own (ie: panicked, ran out
+// of memory or was killed on its limits).
+type ReplayParserCrashError struct {
+	Message string
+	Failure replay_entity.ParseFailure
+}
+
+func (e *ReplayParserCrashError) Error() string {
+	return e.Message
+}
+
+func NewReplayParserCrashError(replayFileID uuid.UUID, failure replay_entity.ParseFailure) *ReplayParserCrashError {
+	message := fmt.Sprintf("replay parser %s on replay file %s (exit code %d)", failure.Reason, replayFileID, failure.ExitCode)
+	if failure.Signal != "" {
+		message = fmt.Sprintf("replay parser %s on replay file %s (%s)", failure.Reason, replayFileID, failure.Signal)
+	}
+
+	return &ReplayParserCrashError{
+		Message: message,
+		Failure: failure,
+	}
+}
@@ -3,6 +3,7 @@ package replay_in
 import (
 	"context"
 	"io"
+	"time"
 
 	"github.com/google/uuid"
 	replay_entity "github.com/psavelis/team-pro/replay-api/pkg/domain/replay/entities"
@@ -21,6 +22,30 @@ type UploadAndProcessReplayFileCommand interface {
 	Exec(c context.Context, file io.Reader) (*replay_entity.Match, error)
 }
 
+// SubmitReplayFileCommand uploads a replay file and queues it for processing by a replay worker (the replay file stays Pending until processed).
+type SubmitReplayFileCommand interface {
+	Exec(c context.Context, file io.Reader) (*replay_entity.ReplayFile, error)
+}
+
+// ProcessUploadedReplayFileCommand processes a queued replay file on behalf of a replay worker. Failed attempts leave the replay file
+// Pending for a retry, the last one marks it as Failed. Replay files already Completed (redeliveries) or Quarantined are skipped with
+// a nil match.
+type ProcessUploadedReplayFileCommand interface {
+	Exec(ctx context.Context, event replay_entity.ReplayFileUploaded) (*replay_entity.Match, error)
+}
+
+// ReprocessReplayFileCommand queues a Failed, Quarantined or stuck Pending replay file of the tenant for processing again (ie: once
+// the parser is fixed), resetting its status to Pending. Completed replay files and the ones being processed can't be requeued.
+type ReprocessReplayFileCommand interface {
+	Exec(ctx context.Context, replayFileID uuid.UUID) (*replay_entity.ReplayFile, error)
+}
+
+// RecordReplayParseCrashCommand records a crash of the parser process on a replay file, leaving it Pending for a retry, or
+// Quarantined once the parser crashed on it MaxReplayParseCrashes times (it's no longer processed).
+type RecordReplayParseCrashCommand interface {
+	Exec(ctx context.Context, replayFileID uuid.UUID, failure replay_entity.ParseFailure) (*replay_entity.ReplayFile, error)
+}
+
 // ProcessReplayFileCommand is an interface that defines the contract for executing a command to process a replay file.
 type ProcessReplayFileCommand interface {
 	// Exec executes the command to process a replay file.
@@ -33,3 +58,16 @@ type ProcessReplayFileCommand interface {
 type UpdateReplayFileHeaderCommand interface {
 	Exec(ctx context.Context, replayFileID uuid.UUID) (*replay_entity.ReplayFile, error)
 }
+
+// ProjectPlayerMatchHistoryCommand refreshes the player match history projection from the matches updated since the given time.
+type ProjectPlayerMatchHistoryCommand interface {
+	// Exec returns the number of history entries written.
+	Exec(ctx context.Context, since time.Time) (int, error)
+}
+
+// SyncPlayerProfilesCommand refreshes the external stats (Steam hours, FACEIT elo, Valorant rank) of a batch of the linked players
+// last synced before the given time, keeping a snapshot of each in their profile history.
+type SyncPlayerProfilesCommand interface {
+	// Exec returns the number of players synced.
+	Exec(ctx context.Context, staleBefore time.Time) (int, error)
+}
@@ -1,12 +1,18 @@
 package replay_in
 
 import (
+	"context"
+	"io"
+	"time"
+
+	"github.com/google/uuid"
 	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
 	replay_entity "github.com/psavelis/team-pro/replay-api/pkg/domain/replay/entities"
 )
 
 type EventReader interface {
 	common.Searchable[replay_entity.GameEvent]
+	common.Aggregatable
 }
 
 type MatchReader interface {
@@ -29,6 +35,78 @@ type RoundReader interface {
 	common.Searchable[replay_entity.Round]
 }
 
-type BadgeReader interface {
-	common.Searchable[replay_entity.Badge]
+type PlayerMatchHistoryReader interface {
+	common.Searchable[replay_entity.PlayerMatchHistory]
+}
+
+type RoundTimelineReader interface {
+	common.Searchable[replay_entity.RoundTimeline]
+}
+
+type ReplayHighlightsReader interface {
+	common.Searchable[replay_entity.ReplayHighlights]
+}
+
+type MatchSummaryReader interface {
+	common.Searchable[replay_entity.MatchSummary]
+}
+
+type PlayerStatsQueryParams struct {
+	PlayerID uuid.UUID
+	MapName  string                  // all maps when empty
+	From     *time.Time              // day of the first replays included (UTC)
+	To       *time.Time              // day of the last replays included (UTC)
+	Tags     common.ReplayTagsFilter // of the replays included, all replays when empty
+}
+
+// PlayerStatsQuery returns the stats of a player from the replays processed in the tenant in context.
+type PlayerStatsQuery interface {
+	Exec(ctx context.Context, params PlayerStatsQueryParams) (*replay_entity.PlayerStats, error)
+}
+
+type ReplayHeatmapQueryParams struct {
+	GameID       common.GameIDKey
+	ReplayFileID uuid.UUID
+	Type         replay_entity.PositionSampleType
+	Side         replay_entity.RoundSide // both sides when empty
+	CellSize     int
+}
+
+// ReplayHeatmapQuery bins the position samples of a replay file of the tenant in context into a heatmap.
+type ReplayHeatmapQuery interface {
+	Exec(ctx context.Context, params ReplayHeatmapQueryParams) (*replay_entity.ReplayHeatmap, error)
+}
+
+// ReplayEconomyQuery simulates the economy of the rounds of a replay file of the tenant in context, from its round timeline.
+type ReplayEconomyQuery interface {
+	Exec(ctx context.Context, gameID common.GameIDKey, replayFileID uuid.UUID) (*replay_entity.ReplayEconomy, error)
+}
+
+// ReplayProcessingProgressSubscriber follows the processing of a replay file of the tenant in context. The current progress is
+// returned along with the stream of updates, which lasts until the returned cancel func is called.
+type ReplayProcessingProgressSubscriber interface {
+	Subscribe(ctx context.Context, replayFileID uuid.UUID, lastEventID uint64) (*replay_entity.ReplayProcessingProgress, <-chan replay_entity.ReplayProcessingProgress, func(), error)
+}
+
+// ReplayFileContentQuery opens the content of a replay file of the tenant in context. Only the bytes read are streamed from the store,
+// from the offset sought: clients can seek within a demo without downloading it first.
+type ReplayFileContentQuery interface {
+	Open(ctx context.Context, gameID common.GameIDKey, replayFileID uuid.UUID) (*replay_entity.ReplayFile, io.ReadSeekCloser, error)
+}
+
+type GroupMatchesQueryParams struct {
+	GroupID uuid.UUID
+	Limit   uint // DefaultGroupMatches when 0
+}
+
+// GroupMatchesQuery rolls up the matches of a group and of its subgroups (ie: every match played by the teams of an org), most recent
+// first. It's restricted to the members of the group or of its parents.
+type GroupMatchesQuery interface {
+	Exec(ctx context.Context, params GroupMatchesQueryParams) ([]replay_entity.Match, error)
+}
+
+// PlayerCardQuery renders the card of a player of the tenant in context as a PNG image (Open Graph sized), for the previews of the
+// shared links of their profile.
+type PlayerCardQuery interface {
+	Exec(ctx context.Context, playerID uuid.UUID) ([]byte, error)
 }
@@ -25,6 +25,12 @@ type MatchMetadataWriter interface {
 type PlayerMetadataWriter interface {
 	// CreateMany(createCtx context.Context, players []replay_entity.Player) error
 	CreateMany(createCtx context.Context, players []interface{}) error
+	// UpdateExternalStats stores the external stats and rank badges of the player.
+	UpdateExternalStats(ctx context.Context, player *replay_entity.Player) error
+}
+
+type PlayerProfileHistoryWriter interface {
+	Create(createCtx context.Context, snapshot *replay_entity.PlayerProfileHistory) (*replay_entity.PlayerProfileHistory, error)
 }
 
 type ReplayFileMetadataWriter interface {
@@ -32,6 +38,48 @@ type ReplayFileMetadataWriter interface {
 	Update(createCtx context.Context, replayFile *replay_entity.ReplayFile) (*replay_entity.ReplayFile, error)
 }
 
+// ReplayFileEventPublisher publishes replay file events to the message broker (delivery is confirmed when it returns).
+type ReplayFileEventPublisher interface {
+	PublishUploaded(ctx context.Context, event replay_entity.ReplayFileUploaded) error
+}
+
+// ReplayProcessingProgressPublisher publishes replay processing progress to its subscribers (best effort: updates may be dropped).
+type ReplayProcessingProgressPublisher interface {
+	PublishProgress(ctx context.Context, progress replay_entity.ReplayProcessingProgress) error
+}
+
 type ReplayFileContentWriter interface {
 	Put(createCtx context.Context, replayFileID uuid.UUID, reader io.ReadSeeker) (string, error)
 }
+
+type RoundTimelineWriter interface {
+	Create(createCtx context.Context, timeline *replay_entity.RoundTimeline) (*replay_entity.RoundTimeline, error)
+}
+
+type ReplayPositionsWriter interface {
+	CreateMany(createCtx context.Context, positions []*replay_entity.ReplayPositions) error
+}
+
+type ReplayHighlightsWriter interface {
+	Create(createCtx context.Context, highlights *replay_entity.ReplayHighlights) (*replay_entity.ReplayHighlights, error)
+}
+
+type MatchSummaryWriter interface {
+	Create(createCtx context.Context, summary *replay_entity.MatchSummary) (*replay_entity.MatchSummary, error)
+}
+
+type PlayerStatsWriter interface {
+	// Increment adds the counters of the buckets to the stored ones (creating the missing buckets).
+	Increment(ctx context.Context, buckets []replay_entity.PlayerStatsBucket) error
+}
+
+type PlayerMatchHistoryWriter interface {
+	// Upsert replaces the entries by ID (player+match), keeping their original CreatedAt.
+	Upsert(ctx context.Context, entries []replay_entity.PlayerMatchHistory) error
+}
+
+type ReplayBadgeEvaluator interface {
+	// EvaluateReplayBadges awards the badges triggered by a processed replay to its players (with the stats of the replay and their
+	// stats over every replay processed so far).
+	EvaluateReplayBadges(ctx context.Context, summary *replay_entity.MatchSummary, players []*replay_entity.Player) error
+}
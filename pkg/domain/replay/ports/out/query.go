@@ -3,10 +3,12 @@ package replay_out
 import (
 	"context"
 	"io"
+	"time"
 
 	"github.com/google/uuid"
 	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
 	replay_entity "github.com/psavelis/team-pro/replay-api/pkg/domain/replay/entities"
+	season_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/season/entities"
 )
 
 type EventsByGameReader interface {
@@ -30,6 +32,24 @@ type ReplayFileContentReader interface {
 	GetByID(ctx context.Context, replayFileID uuid.UUID) (io.ReadSeekCloser, error)
 }
 
+// ReplayFileContentStreamer streams a byte range of the content straight from the store, without downloading the rest of the file
+// (ie: for clients seeking within a demo).
+type ReplayFileContentStreamer interface {
+	Size(ctx context.Context, replayFileID uuid.UUID) (int64, error)
+	Stream(ctx context.Context, replayFileID uuid.UUID, offset int64, length int64) (io.ReadCloser, error)
+}
+
+// ReplayFileContentURLSigner issues temporary download URLs so clients fetch the content straight from the blob store (s3 backend only).
+type ReplayFileContentURLSigner interface {
+	DownloadURL(ctx context.Context, replayFileID uuid.UUID, ttl time.Duration) (string, error)
+}
+
+// ReplayProcessingProgressStream delivers the progress published for a replay file until the returned cancel func is called, starting
+// with the retained updates published after lastEventID (when resuming, 0 otherwise).
+type ReplayProcessingProgressStream interface {
+	Subscribe(ctx context.Context, replayFileID uuid.UUID, lastEventID uint64) (<-chan replay_entity.ReplayProcessingProgress, func())
+}
+
 type TeamReader interface {
 	common.Searchable[replay_entity.Team]
 }
@@ -38,6 +58,48 @@ type PlayerMetadataReader interface {
 	common.Searchable[replay_entity.Player]
 }
 
-type BadgeReader interface {
-	common.Searchable[replay_entity.Badge]
+type PlayerMatchHistoryReader interface {
+	common.Searchable[replay_entity.PlayerMatchHistory]
+}
+
+type RoundTimelineReader interface {
+	common.Searchable[replay_entity.RoundTimeline]
+}
+
+type PlayerStatsReader interface {
+	common.Searchable[replay_entity.PlayerStatsBucket]
+	common.Aggregatable
+}
+
+type ReplayPositionsReader interface {
+	common.Searchable[replay_entity.ReplayPositions]
+}
+
+type ReplayHighlightsReader interface {
+	common.Searchable[replay_entity.ReplayHighlights]
+}
+
+type MatchSummaryReader interface {
+	common.Searchable[replay_entity.MatchSummary]
+}
+
+// ExternalStatsFetcher reads the stats of a player on an external platform (nil stats when the player has no account there).
+type ExternalStatsFetcher interface {
+	Source() replay_entity.ExternalStatsSource
+	FetchStats(ctx context.Context, accounts replay_entity.ExternalAccounts) (*replay_entity.ExternalStats, error)
+}
+
+// ActiveSeasonReader reads the season of the game currently active, nil between seasons.
+type ActiveSeasonReader interface {
+	GetActiveSeason(ctx context.Context, gameID common.GameIDKey) (*season_entities.Season, error)
+}
+
+// PlayerBadgeNamesReader reads the names of the latest badges of a player (most recent first).
+type PlayerBadgeNamesReader interface {
+	GetLatestBadgeNames(ctx context.Context, playerID uuid.UUID, gameID common.GameIDKey, limit int) ([]string, error)
+}
+
+// PlayerCardRenderer renders the card of a player as a PNG image.
+type PlayerCardRenderer interface {
+	Render(ctx context.Context, card replay_entity.PlayerCard) ([]byte, error)
 }
@@ -0,0 +1,310 @@
+package economy
+
+import (
+	replay_entity "github.com/psavelis/team-pro/replay-api/pkg/domain/replay/entities"
+)
+
+// EconomyRules are the money rules the rounds are simulated with. Costs and rewards are per player.
+type EconomyRules struct {
+	HalfRounds         int // of the regulation halves
+	OvertimeHalfRounds int
+	MaxMoney           int
+	WinReward          int
+	ObjectiveWinReward int   // of the rounds won by the bomb exploding (T) or being defused (CT)
+	LossBonus          []int // by loss level, the last one is the max
+	StartingLossLevel  int   // at the start of each half
+	PlantBonus         int   // to the T side, when losing a round the bomb was planted
+	FullBuyCost        map[replay_entity.RoundSide]int
+	FullBuyEquipment   map[replay_entity.RoundSide]int // the least equipment of a full buy
+	HalfBuyCost        int
+	SaveSpend          int // the most a save spends
+}
+
+// CS2EconomyRules are the rules of competitive CS2 (MR12). A full buy is a rifle, kevlar+helmet and utility (plus a defuse kit on CT).
+var CS2EconomyRules = EconomyRules{
+	HalfRounds:         12,
+	OvertimeHalfRounds: 3,
+	MaxMoney:           16000,
+	WinReward:          3250,
+	ObjectiveWinReward: 3500,
+	LossBonus:          []int{1400, 1900, 2400, 2900, 3400},
+	StartingLossLevel:  1,
+	PlantBonus:         800,
+	FullBuyCost:        map[replay_entity.RoundSide]int{replay_entity.RoundSideCT: 4100, replay_entity.RoundSideT: 3700},
+	FullBuyEquipment:   map[replay_entity.RoundSide]int{replay_entity.RoundSideCT: 3700, replay_entity.RoundSideT: 3400},
+	HalfBuyCost:        2500,
+	SaveSpend:          1000,
+}
+
+// defaultPlayers of a side, on the timelines built before the players were tracked
+const defaultPlayers = 5
+
+// objective round end reasons, by the side winning them
+var objectiveWins = map[string]replay_entity.RoundSide{
+	"target_bombed": replay_entity.RoundSideT,
+	"bomb_defused":  replay_entity.RoundSideCT,
+}
+
+// EconomySimulationService reconstructs the buys of each side from the round timeline of a replay, and simulates the money each
+// alternative buy would have left for the next round, recommending one. Kill rewards aren't simulated: the projections are the least
+// money the side would have.
+type EconomySimulationService struct {
+	Rules EconomyRules
+}
+
+func NewEconomySimulationService(rules EconomyRules) *EconomySimulationService {
+	return &EconomySimulationService{Rules: rules}
+}
+
+func (s *EconomySimulationService) Simulate(timeline *replay_entity.RoundTimeline) *replay_entity.ReplayEconomy {
+	res := &replay_entity.ReplayEconomy{
+		ReplayFileID: timeline.ReplayFileID,
+		MatchID:      timeline.MatchID,
+		GameID:       timeline.GameID,
+		Rounds:       make([]replay_entity.RoundEconomyAnalysis, 0, len(timeline.Rounds)),
+	}
+
+	lossLevels := make(map[replay_entity.RoundSide]int)
+
+	for i, round := range timeline.Rounds {
+		first, last := s.half(round.RoundNumber)
+
+		if round.RoundNumber == first || i == 0 {
+			lossLevels[replay_entity.RoundSideCT] = s.Rules.StartingLossLevel
+			lossLevels[replay_entity.RoundSideT] = s.Rules.StartingLossLevel
+		}
+
+		if len(round.Economy) > 0 {
+			analysis := replay_entity.RoundEconomyAnalysis{
+				RoundNumber: round.RoundNumber,
+				Pistol:      round.RoundNumber == first && round.RoundNumber <= 2*s.Rules.HalfRounds,
+				LastOfHalf:  round.RoundNumber == last,
+				Winner:      round.Winner,
+				Teams:       make([]replay_entity.TeamEconomyAnalysis, 0, len(round.Economy)),
+			}
+
+			for _, economy := range round.Economy {
+				team := s.analyze(round, analysis, economy, lossLevels[economy.Side])
+
+				if !analysis.Pistol && !analysis.LastOfHalf {
+					opponent, ok := opponentEconomy(round, economy.Side)
+					s.recommend(&team, ok && s.perPlayer(opponent.Money+opponent.Spent, opponent.Players) >= s.Rules.FullBuyCost[opponent.Side])
+				}
+
+				if i+1 < len(timeline.Rounds) && timeline.Rounds[i+1].RoundNumber <= last {
+					if next, ok := sideEconomy(timeline.Rounds[i+1], economy.Side); ok {
+						income := next.Money + next.Spent - economy.Money
+						team.Income = &income
+					}
+				}
+
+				analysis.Teams = append(analysis.Teams, team)
+			}
+
+			res.Rounds = append(res.Rounds, analysis)
+		}
+
+		for side, level := range lossLevels {
+			switch round.Winner {
+			case "":
+			case side:
+				lossLevels[side] = max(level-1, 0)
+			default:
+				lossLevels[side] = min(level+1, len(s.Rules.LossBonus)-1)
+			}
+		}
+	}
+
+	return res
+}
+
+// analyze reconstructs the buy of a side, and projects the alternatives it could afford. Pistol rounds and the last rounds of a half are
+// recommended here (the others by recommend).
+func (s *EconomySimulationService) analyze(round replay_entity.RoundTimelineEntry, analysis replay_entity.RoundEconomyAnalysis, economy replay_entity.TeamRoundEconomy, lossLevel int) replay_entity.TeamEconomyAnalysis {
+	players := economy.Players
+	if players <= 0 {
+		players = defaultPlayers
+	}
+
+	team := replay_entity.TeamEconomyAnalysis{
+		Side:           economy.Side,
+		Players:        players,
+		BuyType:        economy.BuyType,
+		MoneyStart:     economy.Money + economy.Spent,
+		Spent:          economy.Spent,
+		EquipmentValue: economy.EquipmentValue,
+		LossStreak:     lossLevel,
+		Won:            round.Winner == economy.Side,
+	}
+
+	switch {
+	case economy.EquipmentValue/players >= s.Rules.FullBuyEquipment[economy.Side]:
+		team.Decision = replay_entity.EconomyDecisionFullBuy
+	case economy.Spent/players >= s.Rules.SaveSpend:
+		team.Decision = replay_entity.EconomyDecisionHalfBuy
+	default:
+		team.Decision = replay_entity.EconomyDecisionSave
+	}
+
+	team.ExpectedIncome = s.roundReward(round, economy.Side, lossLevel) * players
+
+	perPlayer := s.perPlayer(team.MoneyStart, players)
+
+	team.Projections = make([]replay_entity.EconomyProjection, 0, len(replay_entity.EconomyDecisions))
+	for _, decision := range replay_entity.EconomyDecisions {
+		spend, ok := s.spend(decision, economy.Side, perPlayer)
+		if !ok {
+			continue
+		}
+
+		ifWon := min(perPlayer-spend+s.Rules.WinReward, s.Rules.MaxMoney)
+		ifLost := min(perPlayer-spend+s.Rules.LossBonus[lossLevel], s.Rules.MaxMoney)
+
+		team.Projections = append(team.Projections, replay_entity.EconomyProjection{
+			Decision:      decision,
+			Spent:         spend * players,
+			MoneyIfWon:    ifWon * players,
+			MoneyIfLost:   ifLost * players,
+			FullBuyIfWon:  ifWon >= s.Rules.FullBuyCost[economy.Side],
+			FullBuyIfLost: ifLost >= s.Rules.FullBuyCost[economy.Side],
+		})
+	}
+
+	switch {
+	case analysis.Pistol:
+		// every side buys the same on a pistol round: nothing to recommend
+		team.Recommended = team.Decision
+		team.Reason = "pistol round"
+	case analysis.LastOfHalf:
+		team.Recommended = replay_entity.EconomyDecisionSave
+		if p, ok := team.Projection(replay_entity.EconomyDecisionFullBuy); ok {
+			team.Recommended = p.Decision
+		} else if p, ok := team.Projection(replay_entity.EconomyDecisionHalfBuy); ok {
+			team.Recommended = p.Decision
+		}
+
+		team.Reason = "last round of the half: the money is reset after it"
+	}
+
+	team.Misbuy = team.Decision != team.Recommended
+
+	return team
+}
+
+// recommend the buy of a side on a round not recommended by analyze: a full buy when affordable, otherwise the buy leaving a full buy
+// for the next round (when the opponents can't full buy, a half buy winning the round is enough).
+func (s *EconomySimulationService) recommend(team *replay_entity.TeamEconomyAnalysis, opponentFullBuy bool) {
+	_, fullBuyOk := team.Projection(replay_entity.EconomyDecisionFullBuy)
+	halfBuy, halfBuyOk := team.Projection(replay_entity.EconomyDecisionHalfBuy)
+	save, _ := team.Projection(replay_entity.EconomyDecisionSave)
+
+	switch {
+	case fullBuyOk:
+		team.Recommended = replay_entity.EconomyDecisionFullBuy
+		team.Reason = "a full buy is affordable"
+	case halfBuyOk && halfBuy.FullBuyIfLost:
+		team.Recommended = replay_entity.EconomyDecisionHalfBuy
+		team.Reason = "a half buy still affords a full buy in the next round, even losing"
+	case halfBuyOk && halfBuy.FullBuyIfWon && !opponentFullBuy:
+		team.Recommended = replay_entity.EconomyDecisionHalfBuy
+		team.Reason = "the opponents can't full buy: winning with a half buy affords a full buy in the next round"
+	case save.FullBuyIfLost:
+		team.Recommended = replay_entity.EconomyDecisionSave
+		team.Reason = "saving affords a full buy in the next round, even losing"
+	case halfBuyOk:
+		team.Recommended = replay_entity.EconomyDecisionHalfBuy
+		team.Reason = "not even saving affords a full buy in the next round: force"
+	default:
+		team.Recommended = replay_entity.EconomyDecisionSave
+		team.Reason = "no buy is affordable"
+	}
+
+	team.Misbuy = team.Decision != team.Recommended
+}
+
+// spend is the money per player a decision spends, if affordable.
+func (s *EconomySimulationService) spend(decision replay_entity.EconomyDecision, side replay_entity.RoundSide, perPlayer int) (int, bool) {
+	switch decision {
+	case replay_entity.EconomyDecisionSave:
+		return 0, true
+	case replay_entity.EconomyDecisionHalfBuy:
+		return min(perPlayer, s.Rules.HalfBuyCost), perPlayer >= s.Rules.SaveSpend
+	case replay_entity.EconomyDecisionFullBuy:
+		return s.Rules.FullBuyCost[side], perPlayer >= s.Rules.FullBuyCost[side]
+	}
+
+	return 0, false
+}
+
+// roundReward is the money per player a side is paid at the end of a round (kill rewards aside).
+func (s *EconomySimulationService) roundReward(round replay_entity.RoundTimelineEntry, side replay_entity.RoundSide, lossLevel int) int {
+	if round.Winner == "" {
+		return 0
+	}
+
+	if round.Winner == side {
+		if objectiveWins[round.EndReason] == side {
+			return s.Rules.ObjectiveWinReward
+		}
+
+		return s.Rules.WinReward
+	}
+
+	reward := s.Rules.LossBonus[lossLevel]
+	if side == replay_entity.RoundSideT && planted(round) {
+		reward += s.Rules.PlantBonus
+	}
+
+	return reward
+}
+
+// half returns the first and the last round of the half of a round (overtime halves included).
+func (s *EconomySimulationService) half(roundNumber int) (int, int) {
+	regulation := 2 * s.Rules.HalfRounds
+	if roundNumber <= regulation {
+		first := (roundNumber-1)/s.Rules.HalfRounds*s.Rules.HalfRounds + 1
+		return first, first + s.Rules.HalfRounds - 1
+	}
+
+	first := (roundNumber-regulation-1)/s.Rules.OvertimeHalfRounds*s.Rules.OvertimeHalfRounds + regulation + 1
+
+	return first, first + s.Rules.OvertimeHalfRounds - 1
+}
+
+func (s *EconomySimulationService) perPlayer(money int, players int) int {
+	if players <= 0 {
+		players = defaultPlayers
+	}
+
+	return money / players
+}
+
+func planted(round replay_entity.RoundTimelineEntry) bool {
+	for _, bomb := range round.Bomb {
+		if bomb.Type == replay_entity.BombEventPlanted {
+			return true
+		}
+	}
+
+	return false
+}
+
+func sideEconomy(round replay_entity.RoundTimelineEntry, side replay_entity.RoundSide) (replay_entity.TeamRoundEconomy, bool) {
+	for _, economy := range round.Economy {
+		if economy.Side == side {
+			return economy, true
+		}
+	}
+
+	return replay_entity.TeamRoundEconomy{}, false
+}
+
+func opponentEconomy(round replay_entity.RoundTimelineEntry, side replay_entity.RoundSide) (replay_entity.TeamRoundEconomy, bool) {
+	for _, economy := range round.Economy {
+		if economy.Side != side {
+			return economy, true
+		}
+	}
+
+	return replay_entity.TeamRoundEconomy{}, false
+}
@@ -0,0 +1,195 @@
+package economy_test
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	cs_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/cs/entities"
+	replay_entity "github.com/psavelis/team-pro/replay-api/pkg/domain/replay/entities"
+	"github.com/psavelis/team-pro/replay-api/pkg/domain/replay/services/economy"
+	"github.com/stretchr/testify/assert"
+)
+
+func team(side replay_entity.RoundSide, buyType cs_entities.CSEconomyState, equipmentValue, money, spent int) replay_entity.TeamRoundEconomy {
+	return replay_entity.TeamRoundEconomy{Side: side, BuyType: buyType, EquipmentValue: equipmentValue, Money: money, Spent: spent, Players: 5}
+}
+
+func round(number int, winner replay_entity.RoundSide, reason string, economy ...replay_entity.TeamRoundEconomy) replay_entity.RoundTimelineEntry {
+	return replay_entity.RoundTimelineEntry{RoundNumber: number, Winner: winner, EndReason: reason, Bomb: []replay_entity.RoundBombEvent{}, Economy: economy}
+}
+
+func teamAnalysis(t *testing.T, res *replay_entity.ReplayEconomy, roundNumber int, side replay_entity.RoundSide) replay_entity.TeamEconomyAnalysis {
+	for _, r := range res.Rounds {
+		if r.RoundNumber != roundNumber {
+			continue
+		}
+
+		for _, a := range r.Teams {
+			if a.Side == side {
+				return a
+			}
+		}
+	}
+
+	t.Fatalf("round %d of %s not simulated", roundNumber, side)
+
+	return replay_entity.TeamEconomyAnalysis{}
+}
+
+// rounds of test/sample_replays/cs2/sound.dem (see its golden events)
+func TestEconomySimulationService_Simulate(t *testing.T) {
+	timeline := &replay_entity.RoundTimeline{
+		ID:           uuid.New(),
+		GameID:       common.CS2_GAME_ID,
+		MatchID:      uuid.New(),
+		ReplayFileID: uuid.New(),
+		Rounds: []replay_entity.RoundTimelineEntry{
+			round(1, replay_entity.RoundSideCT, "ct_win",
+				team(replay_entity.RoundSideCT, cs_entities.CSEconomyStateSave, 3500, 1500, 2500),
+				team(replay_entity.RoundSideT, cs_entities.CSEconomyStateSave, 2550, 2250, 1750)),
+			round(2, replay_entity.RoundSideCT, "ct_win",
+				team(replay_entity.RoundSideCT, cs_entities.CSEconomyStateHalfBuy, 16500, 5950, 13300),
+				team(replay_entity.RoundSideT, cs_entities.CSEconomyStateHalfBuy, 8300, 5500, 6500)),
+			round(12, replay_entity.RoundSideCT, "ct_win",
+				team(replay_entity.RoundSideT, cs_entities.CSEconomyStateForceBuy, 13600, 5150, 12600)),
+			round(13, replay_entity.RoundSideT, "t_win",
+				team(replay_entity.RoundSideCT, cs_entities.CSEconomyStateAntiEco, 2250, 2750, 1250),
+				team(replay_entity.RoundSideT, cs_entities.CSEconomyStateSave, 4400, 600, 3400)),
+		},
+	}
+
+	res := economy.NewEconomySimulationService(economy.CS2EconomyRules).Simulate(timeline)
+
+	assert.Equal(t, timeline.ReplayFileID, res.ReplayFileID)
+	assert.Equal(t, timeline.MatchID, res.MatchID)
+	assert.Len(t, res.Rounds, 4)
+
+	// pistol rounds: nothing recommended
+	pistol := teamAnalysis(t, res, 1, replay_entity.RoundSideT)
+	assert.True(t, res.Rounds[0].Pistol)
+	assert.Equal(t, 4000, pistol.MoneyStart)
+	assert.Equal(t, replay_entity.EconomyDecisionSave, pistol.Decision)
+	assert.Equal(t, pistol.Decision, pistol.Recommended)
+	assert.False(t, pistol.Misbuy)
+	assert.Equal(t, 1900*5, pistol.ExpectedIncome)
+	if assert.NotNil(t, pistol.Income) {
+		assert.Equal(t, 12000-2250, *pistol.Income) // the loss bonus plus the kill rewards
+	}
+
+	// the T side can't full buy after losing the pistol round, saving affords it in round 3 (even losing round 2)
+	t2 := teamAnalysis(t, res, 2, replay_entity.RoundSideT)
+	assert.Equal(t, 12000, t2.MoneyStart)
+	assert.Equal(t, 2, t2.LossStreak)
+	assert.Equal(t, replay_entity.EconomyDecisionHalfBuy, t2.Decision)
+	assert.Equal(t, replay_entity.EconomyDecisionSave, t2.Recommended)
+	assert.True(t, t2.Misbuy)
+
+	assert.Len(t, t2.Projections, 2) // no full buy affordable
+	save, ok := t2.Projection(replay_entity.EconomyDecisionSave)
+	assert.True(t, ok)
+	assert.Equal(t, (2400+2400)*5, save.MoneyIfLost)
+	assert.True(t, save.FullBuyIfLost)
+
+	halfBuy, ok := t2.Projection(replay_entity.EconomyDecisionHalfBuy)
+	assert.True(t, ok)
+	assert.Equal(t, 2400*5, halfBuy.Spent) // all of it
+	assert.Equal(t, 2400*5, halfBuy.MoneyIfLost)
+	assert.False(t, halfBuy.FullBuyIfLost)
+
+	// the CT side half buys the bonus round: winning it affords a full buy, and the T side can't full buy
+	ct2 := teamAnalysis(t, res, 2, replay_entity.RoundSideCT)
+	assert.Equal(t, 0, ct2.LossStreak)
+	assert.Equal(t, replay_entity.EconomyDecisionHalfBuy, ct2.Decision)
+	assert.Equal(t, replay_entity.EconomyDecisionHalfBuy, ct2.Recommended)
+	assert.False(t, ct2.Misbuy)
+	assert.Equal(t, 3250*5, ct2.ExpectedIncome)
+	assert.Nil(t, ct2.Income) // round 3 isn't in the timeline
+
+	// the last round of the half spends it all
+	t12 := teamAnalysis(t, res, 12, replay_entity.RoundSideT)
+	assert.True(t, res.Rounds[2].LastOfHalf)
+	assert.Equal(t, replay_entity.EconomyDecisionHalfBuy, t12.Decision)
+	assert.Equal(t, replay_entity.EconomyDecisionHalfBuy, t12.Recommended)
+	assert.Nil(t, t12.Income) // the money is reset at the halftime
+
+	// the loss bonus is reset at the halftime
+	ct13 := teamAnalysis(t, res, 13, replay_entity.RoundSideCT)
+	assert.True(t, res.Rounds[3].Pistol)
+	assert.Equal(t, 1, ct13.LossStreak)
+	assert.Equal(t, 1900*5, ct13.ExpectedIncome)
+}
+
+func TestEconomySimulationService_Simulate_LossBonus(t *testing.T) {
+	broke := func(side replay_entity.RoundSide) replay_entity.TeamRoundEconomy {
+		return team(side, cs_entities.CSEconomyStateEco, 1000, 500, 0)
+	}
+
+	rich := team(replay_entity.RoundSideCT, cs_entities.CSEconomyStateFullBuy, 25000, 70000, 10000)
+
+	timeline := &replay_entity.RoundTimeline{
+		Rounds: []replay_entity.RoundTimelineEntry{
+			round(3, replay_entity.RoundSideCT, "ct_win", rich, broke(replay_entity.RoundSideT)),
+			round(4, replay_entity.RoundSideCT, "ct_win", rich, broke(replay_entity.RoundSideT)),
+			round(5, replay_entity.RoundSideCT, "ct_win", rich, broke(replay_entity.RoundSideT)),
+			round(6, replay_entity.RoundSideCT, "ct_win", rich, broke(replay_entity.RoundSideT)),
+			{RoundNumber: 7, Winner: replay_entity.RoundSideCT, EndReason: "bomb_defused", Bomb: []replay_entity.RoundBombEvent{{Type: replay_entity.BombEventPlanted}},
+				Economy: []replay_entity.TeamRoundEconomy{rich, broke(replay_entity.RoundSideT)}},
+			round(8, replay_entity.RoundSideT, "t_win", rich, broke(replay_entity.RoundSideT)),
+			round(9, replay_entity.RoundSideCT, "ct_win", rich, broke(replay_entity.RoundSideT)),
+		},
+	}
+
+	res := economy.NewEconomySimulationService(economy.CS2EconomyRules).Simulate(timeline)
+
+	// the timeline starts in the middle of a half: the loss levels start as on the first round of a half
+	tLevels := []int{1, 2, 3, 4, 4, 4, 3}
+	ctLevels := []int{1, 0, 0, 0, 0, 0, 1}
+	for i := range tLevels {
+		assert.Equal(t, tLevels[i], teamAnalysis(t, res, 3+i, replay_entity.RoundSideT).LossStreak, "round %d", 3+i)
+		assert.Equal(t, ctLevels[i], teamAnalysis(t, res, 3+i, replay_entity.RoundSideCT).LossStreak, "round %d", 3+i)
+	}
+
+	// the T side lost with the bomb planted, the CT side defused it
+	t7 := teamAnalysis(t, res, 7, replay_entity.RoundSideT)
+	assert.Equal(t, (3400+800)*5, t7.ExpectedIncome)
+	assert.Equal(t, 3500*5, teamAnalysis(t, res, 7, replay_entity.RoundSideCT).ExpectedIncome)
+
+	// the full buy is affordable (and capped projecting it)
+	ct := teamAnalysis(t, res, 3, replay_entity.RoundSideCT)
+	assert.Equal(t, replay_entity.EconomyDecisionFullBuy, ct.Decision)
+	assert.Equal(t, replay_entity.EconomyDecisionFullBuy, ct.Recommended)
+	assert.Len(t, ct.Projections, 3)
+
+	save, _ := ct.Projection(replay_entity.EconomyDecisionSave)
+	assert.Equal(t, 16000*5, save.MoneyIfWon)
+
+	// not even a half buy is affordable
+	t3 := teamAnalysis(t, res, 3, replay_entity.RoundSideT)
+	assert.Len(t, t3.Projections, 1)
+	assert.Equal(t, replay_entity.EconomyDecisionSave, t3.Recommended)
+	assert.False(t, t3.Misbuy)
+}
+
+func TestEconomySimulationService_Simulate_Overtime(t *testing.T) {
+	buy := func(side replay_entity.RoundSide) replay_entity.TeamRoundEconomy {
+		return team(side, cs_entities.CSEconomyStateFullBuy, 22500, 20000, 22500)
+	}
+
+	timeline := &replay_entity.RoundTimeline{
+		Rounds: []replay_entity.RoundTimelineEntry{
+			round(25, replay_entity.RoundSideCT, "ct_win", buy(replay_entity.RoundSideCT), buy(replay_entity.RoundSideT)),
+			round(27, replay_entity.RoundSideT, "t_win", buy(replay_entity.RoundSideCT), buy(replay_entity.RoundSideT)),
+			round(28, replay_entity.RoundSideT, "t_win", buy(replay_entity.RoundSideCT), buy(replay_entity.RoundSideT)),
+		},
+	}
+
+	res := economy.NewEconomySimulationService(economy.CS2EconomyRules).Simulate(timeline)
+
+	// overtime halves have no pistol rounds, they last 3 rounds
+	assert.False(t, res.Rounds[0].Pistol)
+	assert.False(t, res.Rounds[0].LastOfHalf)
+	assert.True(t, res.Rounds[1].LastOfHalf)
+	assert.False(t, res.Rounds[2].Pistol)
+	assert.Equal(t, 1, teamAnalysis(t, res, 28, replay_entity.RoundSideCT).LossStreak)
+}
@@ -0,0 +1,267 @@
+package highlights
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	cs_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/cs/entities"
+	replay_entity "github.com/psavelis/team-pro/replay-api/pkg/domain/replay/entities"
+)
+
+const (
+	// AceKills is the least number of kills of an ace (all the opponents, on a full team).
+	AceKills = 5
+	// MultiKillKills is the least number of kills in a round for a multi-kill highlight.
+	MultiKillKills = 4
+)
+
+// HighlightDetectionService scans the game events of a parsed replay for its highlights: aces, 4k+ rounds, won clutches and ninja
+// defuses. Rounds are read from the RoundKills events, the bomb from the BombPlanted/BombDefused ones and clutches from the round
+// stats of the ClutchEnd events.
+type HighlightDetectionService struct{}
+
+func NewHighlightDetectionService() *HighlightDetectionService {
+	return &HighlightDetectionService{}
+}
+
+type highlightRound struct {
+	kills      *replay_entity.RoundKillsPayload
+	endTick    common.TickIDType
+	plantTick  common.TickIDType
+	defuse     *replay_entity.BombPayload
+	defuseTick common.TickIDType
+	clutch     *cs_entities.CSClutchStats
+}
+
+func (s *HighlightDetectionService) Detect(replayFile *replay_entity.ReplayFile, matchID uuid.UUID, events []*replay_entity.GameEvent, now time.Time) *replay_entity.ReplayHighlights {
+	rounds := make(map[int]*highlightRound)
+
+	round := func(number int) *highlightRound {
+		if rounds[number] == nil {
+			rounds[number] = &highlightRound{}
+		}
+
+		return rounds[number]
+	}
+
+	for _, event := range events {
+		switch payload := event.Payload.(type) {
+		case replay_entity.RoundKillsPayload:
+			r := round(payload.RoundNumber)
+			r.kills = &payload
+			r.endTick = event.TickID
+		case replay_entity.BombPayload:
+			switch event.Type {
+			case common.Event_BombPlantedID:
+				round(payload.RoundNumber).plantTick = event.TickID
+			case common.Event_BombDefusedID:
+				r := round(payload.RoundNumber)
+				r.defuse = &payload
+				r.defuseTick = event.TickID
+			}
+		case cs_entities.CSMatchStats:
+			if event.Type != common.Event_ClutchEndID {
+				continue
+			}
+
+			for _, stats := range payload.RoundsStats {
+				if stats.ClutchStats != nil && stats.ClutchStats.Status == cs_entities.ClutchWonKey {
+					clutch := *stats.ClutchStats
+					round(clutch.RoundNumber).clutch = &clutch
+				}
+			}
+		}
+	}
+
+	highlights := &replay_entity.ReplayHighlights{
+		ID:            uuid.New(),
+		GameID:        replayFile.GameID,
+		MatchID:       matchID,
+		ReplayFileID:  replayFile.ID,
+		Highlights:    make([]replay_entity.Highlight, 0),
+		ResourceOwner: replayFile.ResourceOwner,
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	}
+
+	for number, r := range rounds {
+		// the kills are required for every highlight (a round without them wasn't played, ie: warmup)
+		if number <= 0 || r.kills == nil {
+			continue
+		}
+
+		highlights.Highlights = append(highlights.Highlights, s.multiKills(r)...)
+
+		if clutch := s.clutch(r); clutch != nil {
+			highlights.Highlights = append(highlights.Highlights, *clutch)
+		}
+
+		if defuse := s.ninjaDefuse(r); defuse != nil {
+			highlights.Highlights = append(highlights.Highlights, *defuse)
+		}
+	}
+
+	sort.Slice(highlights.Highlights, func(i, j int) bool {
+		a, b := highlights.Highlights[i], highlights.Highlights[j]
+		if a.StartTick != b.StartTick {
+			return a.StartTick < b.StartTick
+		}
+
+		if a.Type != b.Type {
+			return a.Type < b.Type
+		}
+
+		return a.NetworkPlayerID < b.NetworkPlayerID
+	})
+
+	return highlights
+}
+
+// multiKills tags the aces (every opponent killed, on a full team) and the 4k+ rounds, from the first to the last kill of the player.
+func (s *HighlightDetectionService) multiKills(r *highlightRound) []replay_entity.Highlight {
+	type killerKey struct{ networkPlayerID, name string }
+
+	byKiller := make(map[killerKey]*replay_entity.Highlight)
+
+	for _, kill := range r.kills.Kills {
+		// teamkills, suicides and the world don't count
+		if kill.KillerName == "" || kill.KillerSide == "" || kill.KillerSide == kill.VictimSide {
+			continue
+		}
+
+		key := killerKey{kill.KillerNetworkPlayerID, kill.KillerName}
+		if byKiller[key] == nil {
+			byKiller[key] = &replay_entity.Highlight{
+				RoundNumber:     r.kills.RoundNumber,
+				NetworkPlayerID: kill.KillerNetworkPlayerID,
+				PlayerName:      kill.KillerName,
+				Side:            kill.KillerSide,
+				StartTick:       kill.TickID,
+			}
+		}
+
+		h := byKiller[key]
+		h.Kills++
+		h.EndTick = kill.TickID
+	}
+
+	highlights := make([]replay_entity.Highlight, 0)
+	for _, h := range byKiller {
+		if h.Kills < MultiKillKills {
+			continue
+		}
+
+		h.Type = replay_entity.HighlightTypeMultiKill
+		if h.Kills >= AceKills && h.Kills >= countSide(r.kills.Players, opposite(h.Side)) {
+			h.Type = replay_entity.HighlightTypeAce
+		}
+
+		highlights = append(highlights, *h)
+	}
+
+	return highlights
+}
+
+// clutch tags a won 1vX, from the death of the last teammate of the player to the round end.
+func (s *HighlightDetectionService) clutch(r *highlightRound) *replay_entity.Highlight {
+	if r.clutch == nil {
+		return nil
+	}
+
+	networkPlayerID := fmt.Sprintf("%d", r.clutch.NetworkPlayerID)
+
+	h := &replay_entity.Highlight{
+		Type:            replay_entity.HighlightTypeClutch,
+		RoundNumber:     r.kills.RoundNumber,
+		NetworkPlayerID: networkPlayerID,
+		Opponents:       len(r.clutch.OpponentsStats),
+		EndTick:         r.endTick,
+	}
+
+	for _, player := range r.kills.Players {
+		if player.NetworkPlayerID == networkPlayerID {
+			h.PlayerName = player.Name
+			h.Side = player.Side
+		}
+	}
+
+	if h.Side == "" {
+		return nil
+	}
+
+	for _, kill := range r.kills.Kills {
+		if kill.VictimSide == h.Side && kill.VictimNetworkPlayerID != networkPlayerID {
+			h.StartTick = kill.TickID
+		}
+	}
+
+	for _, kill := range r.kills.Kills {
+		if kill.KillerNetworkPlayerID == networkPlayerID && kill.VictimSide != h.Side && kill.TickID >= h.StartTick {
+			h.Kills++
+		}
+	}
+
+	return h
+}
+
+// ninjaDefuse tags a bomb defused while terrorists are alive, from the plant (or the last kill before the defuse, when later) to the
+// defuse.
+func (s *HighlightDetectionService) ninjaDefuse(r *highlightRound) *replay_entity.Highlight {
+	if r.defuse == nil {
+		return nil
+	}
+
+	alive := countSide(r.kills.Players, replay_entity.RoundSideT)
+	start := r.plantTick
+
+	for _, kill := range r.kills.Kills {
+		if kill.TickID > r.defuseTick {
+			continue
+		}
+
+		if kill.VictimSide == replay_entity.RoundSideT {
+			alive--
+		}
+
+		if kill.TickID > start {
+			start = kill.TickID
+		}
+	}
+
+	if alive <= 0 {
+		return nil
+	}
+
+	return &replay_entity.Highlight{
+		Type:            replay_entity.HighlightTypeNinjaDefuse,
+		RoundNumber:     r.kills.RoundNumber,
+		NetworkPlayerID: r.defuse.NetworkPlayerID,
+		PlayerName:      r.defuse.PlayerName,
+		Side:            replay_entity.RoundSideCT,
+		Opponents:       alive,
+		StartTick:       start,
+		EndTick:         r.defuseTick,
+	}
+}
+
+func countSide(players []replay_entity.RoundKillsPlayer, side replay_entity.RoundSide) int {
+	count := 0
+	for _, player := range players {
+		if player.Side == side {
+			count++
+		}
+	}
+
+	return count
+}
+
+func opposite(side replay_entity.RoundSide) replay_entity.RoundSide {
+	if side == replay_entity.RoundSideCT {
+		return replay_entity.RoundSideT
+	}
+
+	return replay_entity.RoundSideCT
+}
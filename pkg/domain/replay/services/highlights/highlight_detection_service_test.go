@@ -0,0 +1,96 @@
+package highlights_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	cs_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/cs/entities"
+	replay_entity "github.com/psavelis/team-pro/replay-api/pkg/domain/replay/entities"
+	"github.com/psavelis/team-pro/replay-api/pkg/domain/replay/services/highlights"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHighlightDetectionService_Detect(t *testing.T) {
+	replayFile := &replay_entity.ReplayFile{ID: uuid.New(), GameID: common.CS2_GAME_ID, ResourceOwner: common.ResourceOwner{TenantID: uuid.New()}}
+	matchID := uuid.New()
+
+	event := func(tick common.TickIDType, eventType common.EventIDKey, payload interface{}) *replay_entity.GameEvent {
+		return replay_entity.NewGameEvent(matchID, tick, time.Duration(tick)*time.Second, eventType, payload, nil, nil, replayFile.ResourceOwner)
+	}
+
+	players := []replay_entity.RoundKillsPlayer{
+		{NetworkPlayerID: "1", Name: "ct1", Side: replay_entity.RoundSideCT},
+		{NetworkPlayerID: "2", Name: "ct2", Side: replay_entity.RoundSideCT},
+		{NetworkPlayerID: "3", Name: "ct3", Side: replay_entity.RoundSideCT},
+		{NetworkPlayerID: "4", Name: "ct4", Side: replay_entity.RoundSideCT},
+		{NetworkPlayerID: "5", Name: "ct5", Side: replay_entity.RoundSideCT},
+		{NetworkPlayerID: "6", Name: "t1", Side: replay_entity.RoundSideT},
+		{NetworkPlayerID: "7", Name: "t2", Side: replay_entity.RoundSideT},
+		{NetworkPlayerID: "8", Name: "t3", Side: replay_entity.RoundSideT},
+		{NetworkPlayerID: "9", Name: "t4", Side: replay_entity.RoundSideT},
+		{Name: "t5 (bot)", Side: replay_entity.RoundSideT},
+	}
+
+	kill := func(tick common.TickIDType, killer, victim replay_entity.RoundKillsPlayer) replay_entity.RoundKill {
+		return replay_entity.RoundKill{
+			TickID:                tick,
+			KillerNetworkPlayerID: killer.NetworkPlayerID,
+			KillerName:            killer.Name,
+			KillerSide:            killer.Side,
+			VictimNetworkPlayerID: victim.NetworkPlayerID,
+			VictimName:            victim.Name,
+			VictimSide:            victim.Side,
+		}
+	}
+
+	events := []*replay_entity.GameEvent{
+		// warmup: ignored
+		event(5, common.Event_RoundKillsID, replay_entity.RoundKillsPayload{RoundNumber: 0, Players: players, Kills: []replay_entity.RoundKill{
+			kill(1, players[0], players[5]), kill(2, players[0], players[6]), kill(3, players[0], players[7]), kill(4, players[0], players[8]),
+		}}),
+		// round 1: ct1 aces
+		event(200, common.Event_RoundKillsID, replay_entity.RoundKillsPayload{RoundNumber: 1, Players: players, Kills: []replay_entity.RoundKill{
+			kill(110, players[0], players[5]), kill(120, players[6], players[1]), kill(130, players[0], players[6]), kill(140, players[0], players[7]),
+			kill(150, players[0], players[8]), kill(160, players[0], players[9]),
+		}}),
+		// round 2: t1 gets a 4k (a teamkill doesn't count), then t2 is left alone vs 1 and wins it
+		event(400, common.Event_RoundKillsID, replay_entity.RoundKillsPayload{RoundNumber: 2, Players: players, Kills: []replay_entity.RoundKill{
+			kill(310, players[5], players[0]), kill(320, players[5], players[1]), kill(325, players[5], players[7]), kill(330, players[5], players[2]),
+			kill(340, players[5], players[3]), kill(350, players[4], players[5]), kill(352, players[4], players[8]), kill(354, players[4], players[9]),
+			kill(360, players[6], players[4]),
+		}}),
+		event(399, common.Event_ClutchEndID, cs_entities.CSMatchStats{RoundsStats: []cs_entities.CSRoundStats{
+			{RoundNumber: 2, ClutchStats: &cs_entities.CSClutchStats{RoundNumber: 2, NetworkPlayerID: 7, OpponentsStats: make([]*cs_entities.CSPlayerStats, 1), Status: cs_entities.ClutchWonKey}},
+			{RoundNumber: 1, ClutchStats: &cs_entities.CSClutchStats{RoundNumber: 1, NetworkPlayerID: 1, OpponentsStats: make([]*cs_entities.CSPlayerStats, 1), Status: cs_entities.ClutchLostKey}},
+		}}),
+		// round 3: ct5 defuses with 3 terrorists alive
+		event(510, common.Event_BombPlantedID, replay_entity.BombPayload{RoundNumber: 3, Site: "A", NetworkPlayerID: "6", PlayerName: "t1"}),
+		event(580, common.Event_BombDefusedID, replay_entity.BombPayload{RoundNumber: 3, Site: "A", NetworkPlayerID: "5", PlayerName: "ct5"}),
+		event(590, common.Event_RoundKillsID, replay_entity.RoundKillsPayload{RoundNumber: 3, Players: players, Kills: []replay_entity.RoundKill{
+			kill(505, players[4], players[5]), kill(520, players[4], players[6]), kill(585, players[7], players[4]),
+		}}),
+		// round 4: defused with every terrorist dead
+		event(680, common.Event_BombDefusedID, replay_entity.BombPayload{RoundNumber: 4, NetworkPlayerID: "5", PlayerName: "ct5"}),
+		event(690, common.Event_RoundKillsID, replay_entity.RoundKillsPayload{RoundNumber: 4, Players: players[:6], Kills: []replay_entity.RoundKill{
+			kill(610, players[4], players[5]),
+		}}),
+	}
+
+	now := time.Now()
+	detected := highlights.NewHighlightDetectionService().Detect(replayFile, matchID, events, now)
+
+	assert.Equal(t, replayFile.ID, detected.ReplayFileID)
+	assert.Equal(t, replayFile.GameID, detected.GameID)
+	assert.Equal(t, matchID, detected.MatchID)
+	assert.Equal(t, replayFile.ResourceOwner, detected.ResourceOwner)
+	assert.Equal(t, now, detected.CreatedAt)
+
+	assert.Equal(t, []replay_entity.Highlight{
+		{Type: replay_entity.HighlightTypeAce, RoundNumber: 1, NetworkPlayerID: "1", PlayerName: "ct1", Side: replay_entity.RoundSideCT, Kills: 5, StartTick: 110, EndTick: 160},
+		{Type: replay_entity.HighlightTypeMultiKill, RoundNumber: 2, NetworkPlayerID: "6", PlayerName: "t1", Side: replay_entity.RoundSideT, Kills: 4, StartTick: 310, EndTick: 340},
+		{Type: replay_entity.HighlightTypeClutch, RoundNumber: 2, NetworkPlayerID: "7", PlayerName: "t2", Side: replay_entity.RoundSideT, Kills: 1, Opponents: 1, StartTick: 354, EndTick: 400},
+		{Type: replay_entity.HighlightTypeNinjaDefuse, RoundNumber: 3, NetworkPlayerID: "5", PlayerName: "ct5", Side: replay_entity.RoundSideCT, Opponents: 3, StartTick: 520, EndTick: 580},
+	}, detected.Highlights)
+}
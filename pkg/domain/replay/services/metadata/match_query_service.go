@@ -13,15 +13,21 @@ type MatchQueryService struct {
 
 func NewMatchQueryService(matchReader replay_out.MatchMetadataReader) replay_in.MatchReader {
 	queryableFields := map[string]bool{
-		"ID":            true,
-		"GameID":        true,
-		"NetworkID":     true,
-		"Status":        true,
-		"Error":         common.DENY,
-		"Header.*":      true,
-		"ResourceOwner": true,
-		"CreatedAt":     true,
-		"UpdatedAt":     true,
+		"ID":                  true,
+		"GameID":              true,
+		"NetworkID":           true,
+		"Status":              true,
+		"Error":               common.DENY,
+		"Header.*":            true,
+		"LobbyID":             true,
+		"SeasonID":            true,
+		"ResourceOwner":       true,
+		"CreatedAt":           true,
+		"UpdatedAt":           true,
+		"Tags.Platform":       true,
+		"Tags.ServerRegion":   true,
+		"Tags.ServerLocation": true,
+		"Tags.Tickrate":       true,
 	}
 
 	readableFields := map[string]bool{
@@ -31,9 +37,13 @@ func NewMatchQueryService(matchReader replay_out.MatchMetadataReader) replay_in.
 		"Status":        true,
 		"Error":         common.DENY,
 		"Header.*":      true,
+		"LobbyID":       true,
+		"Draft":         true,
+		"SeasonID":      true,
 		"ResourceOwner": true,
 		"CreatedAt":     true,
 		"UpdatedAt":     true,
+		"Tags":          true,
 	}
 
 	return &common.BaseQueryService[replay_entity.Match]{
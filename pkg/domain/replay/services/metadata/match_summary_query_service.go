@@ -0,0 +1,53 @@
+package metadata
+
+import (
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	replay_entity "github.com/psavelis/team-pro/replay-api/pkg/domain/replay/entities"
+	replay_in "github.com/psavelis/team-pro/replay-api/pkg/domain/replay/ports/in"
+	replay_out "github.com/psavelis/team-pro/replay-api/pkg/domain/replay/ports/out"
+)
+
+type MatchSummaryQueryService struct {
+	common.BaseQueryService[replay_entity.MatchSummary]
+}
+
+func NewMatchSummaryQueryService(summaryReader replay_out.MatchSummaryReader) replay_in.MatchSummaryReader {
+	queryableFields := map[string]bool{
+		"ID":                  true,
+		"GameID":              true,
+		"MatchID":             true,
+		"ReplayFileID":        true,
+		"MapName":             true,
+		"MVP":                 true,
+		"ResourceOwner":       common.DENY,
+		"CreatedAt":           true,
+		"UpdatedAt":           true,
+		"Tags.Platform":       true,
+		"Tags.ServerRegion":   true,
+		"Tags.ServerLocation": true,
+		"Tags.Tickrate":       true,
+	}
+
+	readableFields := map[string]bool{
+		"ID":            true,
+		"GameID":        true,
+		"MatchID":       true,
+		"ReplayFileID":  true,
+		"MapName":       true,
+		"Rounds":        true,
+		"MVP":           true,
+		"Players":       true,
+		"ResourceOwner": common.DENY,
+		"CreatedAt":     true,
+		"UpdatedAt":     true,
+		"Tags":          true,
+	}
+
+	return &common.BaseQueryService[replay_entity.MatchSummary]{
+		Reader:          summaryReader.(common.Searchable[replay_entity.MatchSummary]),
+		QueryableFields: queryableFields,
+		ReadableFields:  readableFields,
+		MaxPageSize:     100,
+		Audience:        common.UserAudienceIDKey,
+	}
+}
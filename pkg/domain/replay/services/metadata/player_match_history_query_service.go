@@ -0,0 +1,61 @@
+package metadata
+
+import (
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	replay_entity "github.com/psavelis/team-pro/replay-api/pkg/domain/replay/entities"
+	replay_in "github.com/psavelis/team-pro/replay-api/pkg/domain/replay/ports/in"
+	replay_out "github.com/psavelis/team-pro/replay-api/pkg/domain/replay/ports/out"
+)
+
+type PlayerMatchHistoryQueryService struct {
+	common.BaseQueryService[replay_entity.PlayerMatchHistory]
+}
+
+func NewPlayerMatchHistoryQueryService(historyReader replay_out.PlayerMatchHistoryReader) replay_in.PlayerMatchHistoryReader {
+	queryableFields := map[string]bool{
+		"ID":                  true,
+		"PlayerID":            true,
+		"MatchID":             true,
+		"GameID":              true,
+		"QueueSessionID":      true,
+		"LobbyID":             true,
+		"TeamID":              true,
+		"Outcome":             true,
+		"PlayedAt":            true,
+		"ResourceOwner":       common.DENY,
+		"CreatedAt":           true,
+		"UpdatedAt":           true,
+		"Tags.Platform":       true,
+		"Tags.ServerRegion":   true,
+		"Tags.ServerLocation": true,
+		"Tags.Tickrate":       true,
+	}
+
+	readableFields := map[string]bool{
+		"ID":             true,
+		"PlayerID":       true,
+		"MatchID":        true,
+		"GameID":         true,
+		"QueueSessionID": true,
+		"LobbyID":        true,
+		"TeamID":         true,
+		"TeamName":       true,
+		"Outcome":        true,
+		"TeamScore":      true,
+		"OpponentScore":  true,
+		"Payout":         true,
+		"PlayedAt":       true,
+		"ResourceOwner":  common.DENY,
+		"CreatedAt":      true,
+		"UpdatedAt":      true,
+		"Tags":           true,
+	}
+
+	return &common.BaseQueryService[replay_entity.PlayerMatchHistory]{
+		Reader:          historyReader.(common.Searchable[replay_entity.PlayerMatchHistory]),
+		QueryableFields: queryableFields,
+		ReadableFields:  readableFields,
+		MaxPageSize:     100,
+		Audience:        common.ClientApplicationAudienceIDKey,
+	}
+}
@@ -13,17 +13,21 @@ type ReplayFileQueryService struct {
 
 func NewReplayFileQueryService(fileMetadataReader replay_out.ReplayFileMetadataReader) replay_in.ReplayFileReader {
 	queryableFields := map[string]bool{
-		"ID":            true,
-		"GameID":        true,
-		"NetworkID":     true,
-		"Size":          true,
-		"InternalURI":   common.DENY,
-		"Status":        true,
-		"Error":         common.DENY,
-		"Header.*":      true,
-		"ResourceOwner": true,
-		"CreatedAt":     true,
-		"UpdatedAt":     true,
+		"ID":                  true,
+		"GameID":              true,
+		"NetworkID":           true,
+		"Size":                true,
+		"InternalURI":         common.DENY,
+		"Status":              true,
+		"Error":               common.DENY,
+		"Header.*":            true,
+		"ResourceOwner":       true,
+		"CreatedAt":           true,
+		"UpdatedAt":           true,
+		"Tags.Platform":       true,
+		"Tags.ServerRegion":   true,
+		"Tags.ServerLocation": true,
+		"Tags.Tickrate":       true,
 	}
 
 	readableFields := map[string]bool{
@@ -38,6 +42,7 @@ func NewReplayFileQueryService(fileMetadataReader replay_out.ReplayFileMetadataR
 		"ResourceOwner": true,
 		"CreatedAt":     true,
 		"UpdatedAt":     true,
+		"Tags":          true,
 	}
 
 	return &common.BaseQueryService[replay_entity.ReplayFile]{
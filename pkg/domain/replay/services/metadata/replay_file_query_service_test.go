@@ -64,7 +64,7 @@ func TestReplayFileQueryService_Filter(t *testing.T) {
 		mockReader     replay_out.ReplayFileMetadataReader
 		expectedOutput []replay_entity.ReplayFile
 		expectedError  error
-		contextValues  map[interface{}]interface{}
+		requestScope   *common.RequestScope
 	}{
 		{
 			name: "Valid Query - GameID",
@@ -84,10 +84,8 @@ func TestReplayFileQueryService_Filter(t *testing.T) {
 			expectedOutput: []replay_entity.ReplayFile{
 				sampleReplayFiles[0],
 			},
-			contextValues: map[interface{}]interface{}{
-				common.TenantIDKey: tenantID,
-				common.ClientIDKey: clientID,
-				common.UserIDKey:   userID,
+			requestScope: &common.RequestScope{
+				ResourceOwner: common.ResourceOwner{TenantID: tenantID, ClientID: clientID, UserID: userID},
 			},
 		},
 		{
@@ -97,7 +95,7 @@ func TestReplayFileQueryService_Filter(t *testing.T) {
 			mockReader:     &mockReplayFileMetadataReader{replayFiles: sampleReplayFiles},
 			expectedOutput: nil,
 			expectedError:  fmt.Errorf("GetResourceOwner.IsMissingTenant: tenant_id missing in context context.Background"),
-			contextValues:  map[interface{}]interface{}{}, // Empty context to trigger the error
+			requestScope:   nil, // Empty context to trigger the error
 		},
 		{
 			name:           "Invalid Query - Mismatched TenantID in Context",
@@ -105,15 +103,15 @@ func TestReplayFileQueryService_Filter(t *testing.T) {
 			resultOptions:  common.SearchResultOptions{Limit: 10},
 			mockReader:     &mockReplayFileMetadataReader{replayFiles: sampleReplayFiles},
 			expectedOutput: nil,
-			expectedError:  fmt.Errorf("GetResourceOwner.IsMissingTenant: tenant_id missing in context context.Background.WithValue(common.ContextKey, 00000000-0000-0000-0000-000000000000)"),
-			contextValues:  map[interface{}]interface{}{common.TenantIDKey: uuid.Nil},
+			expectedError:  fmt.Errorf("GetResourceOwner.IsMissingTenant: tenant_id missing in context context.Background.WithValue(common.requestScopeKey, common.RequestScope)"),
+			requestScope:   &common.RequestScope{ResourceOwner: common.ResourceOwner{TenantID: uuid.Nil}},
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			ctx := context.Background()
-			for key, value := range tt.contextValues {
-				ctx = context.WithValue(ctx, key, value)
+			if tt.requestScope != nil {
+				ctx = common.WithRequestScope(ctx, *tt.requestScope)
 			}
 			service := replay_services_metadata.NewReplayFileQueryService(tt.mockReader)
 
@@ -0,0 +1,43 @@
+package metadata
+
+import (
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	replay_entity "github.com/psavelis/team-pro/replay-api/pkg/domain/replay/entities"
+	replay_in "github.com/psavelis/team-pro/replay-api/pkg/domain/replay/ports/in"
+	replay_out "github.com/psavelis/team-pro/replay-api/pkg/domain/replay/ports/out"
+)
+
+type RoundTimelineQueryService struct {
+	common.BaseQueryService[replay_entity.RoundTimeline]
+}
+
+func NewRoundTimelineQueryService(timelineReader replay_out.RoundTimelineReader) replay_in.RoundTimelineReader {
+	queryableFields := map[string]bool{
+		"ID":            true,
+		"GameID":        true,
+		"MatchID":       true,
+		"ReplayFileID":  true,
+		"ResourceOwner": common.DENY,
+		"CreatedAt":     true,
+		"UpdatedAt":     true,
+	}
+
+	readableFields := map[string]bool{
+		"ID":            true,
+		"GameID":        true,
+		"MatchID":       true,
+		"ReplayFileID":  true,
+		"Rounds":        true,
+		"ResourceOwner": common.DENY,
+		"CreatedAt":     true,
+		"UpdatedAt":     true,
+	}
+
+	return &common.BaseQueryService[replay_entity.RoundTimeline]{
+		Reader:          timelineReader.(common.Searchable[replay_entity.RoundTimeline]),
+		QueryableFields: queryableFields,
+		ReadableFields:  readableFields,
+		MaxPageSize:     100,
+		Audience:        common.UserAudienceIDKey,
+	}
+}
@@ -0,0 +1,67 @@
+package use_cases
+
+import (
+	"context"
+	"log/slog"
+
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	iam_in "github.com/psavelis/team-pro/replay-api/pkg/domain/iam/ports/in"
+	replay_entity "github.com/psavelis/team-pro/replay-api/pkg/domain/replay/entities"
+	replay_in "github.com/psavelis/team-pro/replay-api/pkg/domain/replay/ports/in"
+	replay_out "github.com/psavelis/team-pro/replay-api/pkg/domain/replay/ports/out"
+)
+
+const (
+	DefaultGroupMatches = 20
+	MaxGroupMatches     = 100
+)
+
+type GetGroupMatchesUseCase struct {
+	GroupHierarchy iam_in.GroupHierarchyReader
+	MatchReader    replay_out.MatchMetadataReader
+}
+
+func NewGetGroupMatchesUseCase(groupHierarchy iam_in.GroupHierarchyReader, matchReader replay_out.MatchMetadataReader) replay_in.GroupMatchesQuery {
+	return &GetGroupMatchesUseCase{
+		GroupHierarchy: groupHierarchy,
+		MatchReader:    matchReader,
+	}
+}
+
+// Exec searches the matches owned by the group or by any of its subgroups.
+func (usecase *GetGroupMatchesUseCase) Exec(ctx context.Context, params replay_in.GroupMatchesQueryParams) ([]replay_entity.Match, error) {
+	// also checks the user in context is a member of the group (or of a parent)
+	subgroups, err := usecase.GroupHierarchy.Subgroups(ctx, params.GroupID)
+	if err != nil {
+		return nil, err
+	}
+
+	groupIDs := []interface{}{params.GroupID}
+	for _, subgroup := range subgroups {
+		groupIDs = append(groupIDs, subgroup.ID)
+	}
+
+	limit := params.Limit
+	if limit == 0 {
+		limit = DefaultGroupMatches
+	}
+
+	if limit > MaxGroupMatches {
+		limit = MaxGroupMatches
+	}
+
+	options := common.NewSearchResultOptions(0, limit)
+	options.Sort = []common.SearchSortOption{{Field: "CreatedAt", Direction: common.DescendingIDKey}}
+
+	s := common.NewSearchByValues(ctx, []common.SearchableValue{
+		{Field: "ResourceOwner.GroupID", Values: groupIDs},
+	}, options, common.ClientApplicationAudienceIDKey)
+
+	matches, err := usecase.MatchReader.Search(ctx, s)
+	if err != nil {
+		slog.ErrorContext(ctx, "unable to search group matches", "groupID", params.GroupID, "err", err)
+		return nil, err
+	}
+
+	return matches, nil
+}
@@ -0,0 +1,96 @@
+package use_cases
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	"github.com/google/uuid"
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	"github.com/psavelis/team-pro/replay-api/pkg/domain/replay"
+	replay_entity "github.com/psavelis/team-pro/replay-api/pkg/domain/replay/entities"
+	replay_in "github.com/psavelis/team-pro/replay-api/pkg/domain/replay/ports/in"
+	replay_out "github.com/psavelis/team-pro/replay-api/pkg/domain/replay/ports/out"
+)
+
+type GetPlayerCardUseCase struct {
+	PlayerReader  replay_out.PlayerMetadataReader
+	StatsQuery    replay_in.PlayerStatsQuery
+	HistoryReader replay_out.PlayerMatchHistoryReader
+	BadgeReader   replay_out.PlayerBadgeNamesReader
+	Renderer      replay_out.PlayerCardRenderer
+}
+
+func NewGetPlayerCardUseCase(playerReader replay_out.PlayerMetadataReader, statsQuery replay_in.PlayerStatsQuery, historyReader replay_out.PlayerMatchHistoryReader, badgeReader replay_out.PlayerBadgeNamesReader, renderer replay_out.PlayerCardRenderer) replay_in.PlayerCardQuery {
+	return &GetPlayerCardUseCase{
+		PlayerReader:  playerReader,
+		StatsQuery:    statsQuery,
+		HistoryReader: historyReader,
+		BadgeReader:   badgeReader,
+		Renderer:      renderer,
+	}
+}
+
+// Exec renders the card of the player: their overall stats (zero when no replay of theirs was processed), the outcome of their last
+// PlayerCardRecentMatches matches and their latest PlayerCardBadges badges.
+func (usecase *GetPlayerCardUseCase) Exec(ctx context.Context, playerID uuid.UUID) ([]byte, error) {
+	// stored as PlayerIDType (not encoded as a uuid.UUID)
+	s := common.NewSearchByValues(ctx, []common.SearchableValue{{Field: "ID", Values: []interface{}{common.PlayerIDType(playerID)}}}, common.NewSearchResultOptions(0, 1), common.ClientApplicationAudienceIDKey)
+
+	players, err := usecase.PlayerReader.Search(ctx, s)
+	if err != nil {
+		slog.ErrorContext(ctx, "unable to get player for player card", "player_id", playerID, "err", err)
+		return nil, err
+	}
+
+	if len(players) == 0 {
+		return nil, replay.NewPlayerNotFoundError(playerID)
+	}
+
+	player := players[0]
+
+	card := replay_entity.PlayerCard{
+		PlayerID:  playerID,
+		Name:      player.Name,
+		ClanName:  player.ClanName,
+		AvatarURI: player.AvatarURI,
+	}
+
+	if len(player.RankBadges) > 0 {
+		rank := player.RankBadges[0]
+		card.Rank = &rank
+	}
+
+	stats, err := usecase.StatsQuery.Exec(ctx, replay_in.PlayerStatsQueryParams{PlayerID: playerID})
+	if err != nil {
+		// players without a network ID have no stats
+		var notFoundErr *replay.PlayerNotFoundError
+		if !errors.As(err, &notFoundErr) {
+			return nil, err
+		}
+	} else {
+		card.Stats = stats.PlayerStatsTotals
+	}
+
+	s = common.NewSearchByValues(ctx, []common.SearchableValue{{Field: "PlayerID", Values: []interface{}{playerID}}}, common.NewSearchResultOptions(0, replay_entity.PlayerCardRecentMatches), common.ClientApplicationAudienceIDKey)
+	s.SortOptions = []common.SearchSortOption{{Field: "PlayedAt", Direction: common.DescendingIDKey}}
+
+	history, err := usecase.HistoryReader.Search(ctx, s)
+	if err != nil {
+		slog.ErrorContext(ctx, "unable to get match history for player card", "player_id", playerID, "err", err)
+		return nil, err
+	}
+
+	card.RecentForm = make([]replay_entity.MatchOutcome, 0, len(history))
+	for _, entry := range history {
+		card.RecentForm = append(card.RecentForm, entry.Outcome)
+	}
+
+	card.Badges, err = usecase.BadgeReader.GetLatestBadgeNames(ctx, playerID, player.GameID, replay_entity.PlayerCardBadges)
+	if err != nil {
+		slog.ErrorContext(ctx, "unable to get badges for player card", "player_id", playerID, "err", err)
+		return nil, err
+	}
+
+	return usecase.Renderer.Render(ctx, card)
+}
@@ -0,0 +1,122 @@
+package use_cases_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	"github.com/psavelis/team-pro/replay-api/pkg/domain/replay"
+	replay_entity "github.com/psavelis/team-pro/replay-api/pkg/domain/replay/entities"
+	replay_in "github.com/psavelis/team-pro/replay-api/pkg/domain/replay/ports/in"
+	"github.com/psavelis/team-pro/replay-api/pkg/domain/replay/use_cases"
+	"github.com/stretchr/testify/assert"
+)
+
+type mockStatsQuery struct {
+	stats *replay_entity.PlayerStats
+	err   error
+}
+
+func (m *mockStatsQuery) Exec(ctx context.Context, params replay_in.PlayerStatsQueryParams) (*replay_entity.PlayerStats, error) {
+	return m.stats, m.err
+}
+
+// mockHistoryReader returns its entries, keeping the last search.
+type mockHistoryReader struct {
+	entries []replay_entity.PlayerMatchHistory
+	search  common.Search
+}
+
+func (m *mockHistoryReader) Search(ctx context.Context, s common.Search) ([]replay_entity.PlayerMatchHistory, error) {
+	m.search = s
+	return m.entries, nil
+}
+
+func (m *mockHistoryReader) Compile(ctx context.Context, searchParams []common.SearchAggregation, resultOptions common.SearchResultOptions) (*common.Search, error) {
+	return nil, nil
+}
+
+type mockBadgeNamesReader struct {
+	names []string
+	limit int
+}
+
+func (m *mockBadgeNamesReader) GetLatestBadgeNames(ctx context.Context, playerID uuid.UUID, gameID common.GameIDKey, limit int) ([]string, error) {
+	m.limit = limit
+	return m.names, nil
+}
+
+// mockCardRenderer keeps the card rendered.
+type mockCardRenderer struct {
+	card *replay_entity.PlayerCard
+}
+
+func (m *mockCardRenderer) Render(ctx context.Context, card replay_entity.PlayerCard) ([]byte, error) {
+	m.card = &card
+	return []byte("png"), nil
+}
+
+func TestGetPlayerCard(t *testing.T) {
+	ctx := common.WithResourceOwner(context.Background(), common.ResourceOwner{TenantID: uuid.New(), ClientID: uuid.New()})
+
+	playerID := uuid.New()
+	player := replay_entity.Player{
+		ID:            common.PlayerIDType(playerID),
+		GameID:        common.CS2_GAME_ID,
+		NetworkUserID: "76561198000000001",
+		Name:          "s1mple",
+		ClanName:      "NAVI",
+		RankBadges:    []replay_entity.RankBadge{{Source: replay_entity.FaceitStatsSource, Label: "Level 10", Tier: 10}},
+	}
+
+	stats := &replay_entity.PlayerStats{PlayerID: playerID, PlayerStatsTotals: replay_entity.PlayerStatsTotals{Matches: 12, KD: 1.31, Rating: 1.18}}
+	history := &mockHistoryReader{entries: []replay_entity.PlayerMatchHistory{
+		{PlayerID: playerID, Outcome: replay_entity.MatchOutcomeWin},
+		{PlayerID: playerID, Outcome: replay_entity.MatchOutcomeLoss},
+	}}
+	badges := &mockBadgeNamesReader{names: []string{"Ace", "Headhunter"}}
+	renderer := &mockCardRenderer{}
+
+	usecase := use_cases.NewGetPlayerCardUseCase(&mockPlayerReader{players: []replay_entity.Player{player}}, &mockStatsQuery{stats: stats}, history, badges, renderer)
+
+	png, err := usecase.Exec(ctx, playerID)
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("png"), png)
+
+	card := renderer.card
+	assert.Equal(t, "s1mple", card.Name)
+	assert.Equal(t, "NAVI", card.ClanName)
+	assert.Equal(t, "Level 10", card.Rank.Label)
+	assert.Equal(t, 12, card.Stats.Matches)
+	assert.Equal(t, 1.18, card.Stats.Rating)
+	assert.Equal(t, []replay_entity.MatchOutcome{replay_entity.MatchOutcomeWin, replay_entity.MatchOutcomeLoss}, card.RecentForm)
+	assert.Equal(t, []string{"Ace", "Headhunter"}, card.Badges)
+
+	// the last matches, most recent first
+	assert.Equal(t, uint(replay_entity.PlayerCardRecentMatches), history.search.ResultOptions.Limit)
+	assert.Equal(t, "PlayedAt", history.search.SortOptions[0].Field)
+	assert.Equal(t, common.DescendingIDKey, history.search.SortOptions[0].Direction)
+	assert.Equal(t, replay_entity.PlayerCardBadges, badges.limit)
+
+	// players without stats still have a card
+	renderer.card = nil
+	usecase = use_cases.NewGetPlayerCardUseCase(&mockPlayerReader{players: []replay_entity.Player{player}}, &mockStatsQuery{err: replay.NewPlayerNotFoundError(playerID)}, history, badges, renderer)
+
+	_, err = usecase.Exec(ctx, playerID)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, renderer.card.Stats.Matches)
+
+	// other failures aren't rendered
+	usecase = use_cases.NewGetPlayerCardUseCase(&mockPlayerReader{players: []replay_entity.Player{player}}, &mockStatsQuery{err: errors.New("timeout")}, history, badges, renderer)
+
+	_, err = usecase.Exec(ctx, playerID)
+	assert.Error(t, err)
+
+	// unknown players
+	_, err = usecase.Exec(ctx, uuid.New())
+
+	var notFoundErr *replay.PlayerNotFoundError
+	assert.ErrorAs(t, err, &notFoundErr)
+}
@@ -0,0 +1,113 @@
+package use_cases
+
+import (
+	"context"
+	"log/slog"
+
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	"github.com/psavelis/team-pro/replay-api/pkg/domain/replay"
+	replay_entity "github.com/psavelis/team-pro/replay-api/pkg/domain/replay/entities"
+	replay_in "github.com/psavelis/team-pro/replay-api/pkg/domain/replay/ports/in"
+	replay_out "github.com/psavelis/team-pro/replay-api/pkg/domain/replay/ports/out"
+)
+
+type GetPlayerStatsUseCase struct {
+	PlayerReader replay_out.PlayerMetadataReader
+	StatsReader  replay_out.PlayerStatsReader
+}
+
+func NewGetPlayerStatsUseCase(playerReader replay_out.PlayerMetadataReader, statsReader replay_out.PlayerStatsReader) replay_in.PlayerStatsQuery {
+	return &GetPlayerStatsUseCase{
+		PlayerReader: playerReader,
+		StatsReader:  statsReader,
+	}
+}
+
+// Exec sums the stats buckets of the player (identified across replays by its network ID) by map, in the store.
+func (usecase *GetPlayerStatsUseCase) Exec(ctx context.Context, params replay_in.PlayerStatsQueryParams) (*replay_entity.PlayerStats, error) {
+	// stored as PlayerIDType (not encoded as a uuid.UUID)
+	s := common.NewSearchByValues(ctx, []common.SearchableValue{{Field: "ID", Values: []interface{}{common.PlayerIDType(params.PlayerID)}}}, common.NewSearchResultOptions(0, 1), common.ClientApplicationAudienceIDKey)
+
+	players, err := usecase.PlayerReader.Search(ctx, s)
+	if err != nil {
+		slog.ErrorContext(ctx, "unable to get player for player stats", "player_id", params.PlayerID, "err", err)
+		return nil, err
+	}
+
+	if len(players) == 0 || players[0].NetworkUserID == "" {
+		return nil, replay.NewPlayerNotFoundError(params.PlayerID)
+	}
+
+	player := players[0]
+
+	values := []common.SearchableValue{
+		{Field: "NetworkPlayerID", Values: []interface{}{player.NetworkUserID}},
+		{Field: "GameID", Values: []interface{}{player.GameID}},
+	}
+
+	if params.MapName != "" {
+		values = append(values, common.SearchableValue{Field: "MapName", Values: []interface{}{params.MapName}})
+	}
+
+	values = append(values, params.Tags.SearchableValues("Tags")...)
+
+	filter := common.SearchParameter{ValueParams: values}
+
+	// an open range would match no bucket
+	if params.From != nil || params.To != nil {
+		filter.DateParams = []common.SearchableDateRange{{Field: "Day", Min: params.From, Max: params.To}}
+	}
+
+	s = common.NewSearchByAggregation(ctx, []common.SearchAggregation{{Params: []common.SearchParameter{filter}}}, common.NewSearchResultOptions(0, 0), common.ClientApplicationAudienceIDKey)
+
+	metrics := make([]common.AggregateMetric, 0, len(replay_entity.PlayerStatsBucketCounters))
+	for _, field := range replay_entity.PlayerStatsBucketCounters {
+		metrics = append(metrics, common.AggregateMetric{Name: field, Operator: common.SumAggregateOperator, Field: field})
+	}
+
+	groups, err := usecase.StatsReader.Aggregate(ctx, s, []string{"MapName"}, metrics)
+	if err != nil {
+		slog.ErrorContext(ctx, "unable to aggregate player stats", "player_id", params.PlayerID, "err", err)
+		return nil, err
+	}
+
+	maps := make([]replay_entity.MapPlayerStats, 0, len(groups))
+	for _, group := range groups {
+		mapName, _ := group.Key["MapName"].(string)
+
+		maps = append(maps, replay_entity.MapPlayerStats{
+			MapName: mapName,
+			PlayerStatsTotals: replay_entity.PlayerStatsTotals{
+				Matches:        aggregateInt(group.Metrics["Matches"]),
+				Rounds:         aggregateInt(group.Metrics["Rounds"]),
+				Kills:          aggregateInt(group.Metrics["Kills"]),
+				Deaths:         aggregateInt(group.Metrics["Deaths"]),
+				Assists:        aggregateInt(group.Metrics["Assists"]),
+				Headshots:      aggregateInt(group.Metrics["Headshots"]),
+				Damage:         aggregateInt(group.Metrics["Damage"]),
+				ClutchesPlayed: aggregateInt(group.Metrics["ClutchesPlayed"]),
+				ClutchesWon:    aggregateInt(group.Metrics["ClutchesWon"]),
+				MVPs:           aggregateInt(group.Metrics["MVPs"]),
+				RatingPoints:   aggregateInt(group.Metrics["RatingPoints"]),
+			},
+		})
+	}
+
+	return replay_entity.NewPlayerStats(params.PlayerID, player.NetworkUserID, params.From, params.To, maps), nil
+}
+
+// aggregateInt reads a sum metric (decoded as int32, int64 or float64 depending on its magnitude).
+func aggregateInt(v interface{}) int {
+	switch n := v.(type) {
+	case int32:
+		return int(n)
+	case int64:
+		return int(n)
+	case int:
+		return n
+	case float64:
+		return int(n)
+	default:
+		return 0
+	}
+}
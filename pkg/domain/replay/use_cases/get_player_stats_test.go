@@ -0,0 +1,113 @@
+package use_cases_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	"github.com/psavelis/team-pro/replay-api/pkg/domain/replay"
+	replay_entity "github.com/psavelis/team-pro/replay-api/pkg/domain/replay/entities"
+	replay_in "github.com/psavelis/team-pro/replay-api/pkg/domain/replay/ports/in"
+	"github.com/psavelis/team-pro/replay-api/pkg/domain/replay/use_cases"
+	"github.com/stretchr/testify/assert"
+)
+
+type mockPlayerReader struct {
+	players []replay_entity.Player
+}
+
+func (m *mockPlayerReader) Search(ctx context.Context, s common.Search) ([]replay_entity.Player, error) {
+	id := s.SearchParams[0].Params[0].ValueParams[0].Values[0]
+
+	for _, p := range m.players {
+		if p.ID == id {
+			return []replay_entity.Player{p}, nil
+		}
+	}
+
+	return []replay_entity.Player{}, nil
+}
+
+func (m *mockPlayerReader) Compile(ctx context.Context, searchParams []common.SearchAggregation, resultOptions common.SearchResultOptions) (*common.Search, error) {
+	return nil, nil
+}
+
+// mockStatsReader returns its groups, keeping the last aggregation requested.
+type mockStatsReader struct {
+	groups  []common.AggregateGroup
+	search  common.Search
+	groupBy []string
+	metrics []common.AggregateMetric
+}
+
+func (m *mockStatsReader) Search(ctx context.Context, s common.Search) ([]replay_entity.PlayerStatsBucket, error) {
+	return nil, errors.New("not expected")
+}
+
+func (m *mockStatsReader) Compile(ctx context.Context, searchParams []common.SearchAggregation, resultOptions common.SearchResultOptions) (*common.Search, error) {
+	return nil, nil
+}
+
+func (m *mockStatsReader) Aggregate(ctx context.Context, s common.Search, groupBy []string, metrics []common.AggregateMetric) ([]common.AggregateGroup, error) {
+	m.search, m.groupBy, m.metrics = s, groupBy, metrics
+	return m.groups, nil
+}
+
+func TestGetPlayerStats(t *testing.T) {
+	ctx := common.WithResourceOwner(context.Background(), common.ResourceOwner{TenantID: uuid.New(), ClientID: uuid.New()})
+
+	player := replay_entity.Player{ID: common.PlayerIDType(uuid.New()), GameID: common.CS2_GAME_ID, NetworkUserID: "76561198010751673"}
+	playerReader := &mockPlayerReader{players: []replay_entity.Player{player}}
+	statsReader := &mockStatsReader{groups: []common.AggregateGroup{
+		{Key: map[string]interface{}{"MapName": "de_inferno"}, Metrics: map[string]interface{}{"Matches": int32(2), "Rounds": int32(26), "Kills": int64(26), "Deaths": int32(13), "Headshots": int32(13), "Damage": int32(2600)}},
+	}}
+
+	usecase := use_cases.NewGetPlayerStatsUseCase(playerReader, statsReader)
+
+	from := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	stats, err := usecase.Exec(ctx, replay_in.PlayerStatsQueryParams{PlayerID: uuid.UUID(player.ID), MapName: "de_inferno", From: &from})
+
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	assert.Equal(t, player.NetworkUserID, stats.NetworkPlayerID)
+	assert.Equal(t, 2, stats.Matches)
+	assert.InDelta(t, 2.0, stats.KD, 0.001)
+	assert.InDelta(t, 100.0, stats.ADR, 0.001)
+	assert.InDelta(t, 50.0, stats.HSPercentage, 0.001)
+	assert.Len(t, stats.Maps, 1)
+
+	assert.Equal(t, []string{"MapName"}, statsReader.groupBy)
+	assert.Len(t, statsReader.metrics, len(replay_entity.PlayerStatsBucketCounters))
+
+	filter := statsReader.search.SearchParams[0].Params[0]
+	assert.Equal(t, []common.SearchableValue{
+		{Field: "NetworkPlayerID", Values: []interface{}{player.NetworkUserID}},
+		{Field: "GameID", Values: []interface{}{common.CS2_GAME_ID}},
+		{Field: "MapName", Values: []interface{}{"de_inferno"}},
+	}, filter.ValueParams)
+	assert.Equal(t, []common.SearchableDateRange{{Field: "Day", Min: &from}}, filter.DateParams)
+
+	// all time: no date range
+	_, err = usecase.Exec(ctx, replay_in.PlayerStatsQueryParams{PlayerID: uuid.UUID(player.ID)})
+	assert.NoError(t, err)
+	assert.Empty(t, statsReader.search.SearchParams[0].Params[0].DateParams)
+
+	// the replays of the tags
+	_, err = usecase.Exec(ctx, replay_in.PlayerStatsQueryParams{PlayerID: uuid.UUID(player.ID), Tags: common.ReplayTagsFilter{Platform: common.ReplayPlatformFaceit, Tickrate: 128}})
+	assert.NoError(t, err)
+	assert.Equal(t, []common.SearchableValue{
+		{Field: "NetworkPlayerID", Values: []interface{}{player.NetworkUserID}},
+		{Field: "GameID", Values: []interface{}{common.CS2_GAME_ID}},
+		{Field: "Tags.Platform", Values: []interface{}{common.ReplayPlatformFaceit}},
+		{Field: "Tags.Tickrate", Values: []interface{}{128}},
+	}, statsReader.search.SearchParams[0].Params[0].ValueParams)
+
+	var notFoundErr *replay.PlayerNotFoundError
+	_, err = usecase.Exec(ctx, replay_in.PlayerStatsQueryParams{PlayerID: uuid.New()})
+	assert.ErrorAs(t, err, &notFoundErr)
+}
@@ -0,0 +1,48 @@
+package use_cases
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/google/uuid"
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	"github.com/psavelis/team-pro/replay-api/pkg/domain/replay"
+	replay_entity "github.com/psavelis/team-pro/replay-api/pkg/domain/replay/entities"
+	replay_in "github.com/psavelis/team-pro/replay-api/pkg/domain/replay/ports/in"
+	replay_out "github.com/psavelis/team-pro/replay-api/pkg/domain/replay/ports/out"
+	"github.com/psavelis/team-pro/replay-api/pkg/domain/replay/services/economy"
+)
+
+type GetReplayEconomyUseCase struct {
+	TimelineReader replay_out.RoundTimelineReader
+	Simulation     *economy.EconomySimulationService
+}
+
+func NewGetReplayEconomyUseCase(timelineReader replay_out.RoundTimelineReader) replay_in.ReplayEconomyQuery {
+	return &GetReplayEconomyUseCase{
+		TimelineReader: timelineReader,
+		Simulation:     economy.NewEconomySimulationService(economy.CS2EconomyRules),
+	}
+}
+
+// Exec simulates the latest round timeline of the replay file (not found when it has none: unknown, from another tenant or not
+// processed yet).
+func (usecase *GetReplayEconomyUseCase) Exec(ctx context.Context, gameID common.GameIDKey, replayFileID uuid.UUID) (*replay_entity.ReplayEconomy, error) {
+	s := common.NewSearchByValues(ctx, []common.SearchableValue{
+		{Field: "ReplayFileID", Values: []interface{}{replayFileID}},
+		{Field: "GameID", Values: []interface{}{gameID}},
+	}, common.NewSearchResultOptions(0, 1), common.ClientApplicationAudienceIDKey)
+	s.SortOptions = []common.SearchSortOption{{Field: "CreatedAt", Direction: common.DescendingIDKey}}
+
+	timelines, err := usecase.TimelineReader.Search(ctx, s)
+	if err != nil {
+		slog.ErrorContext(ctx, "unable to read round timeline", "replayFileID", replayFileID, "err", err)
+		return nil, err
+	}
+
+	if len(timelines) == 0 {
+		return nil, replay.NewReplayFileNotFoundError(replayFileID)
+	}
+
+	return usecase.Simulation.Simulate(&timelines[0]), nil
+}
@@ -0,0 +1,73 @@
+package use_cases_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	cs_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/cs/entities"
+	"github.com/psavelis/team-pro/replay-api/pkg/domain/replay"
+	replay_entity "github.com/psavelis/team-pro/replay-api/pkg/domain/replay/entities"
+	"github.com/psavelis/team-pro/replay-api/pkg/domain/replay/use_cases"
+	"github.com/stretchr/testify/assert"
+)
+
+type mockRoundTimelineReader struct {
+	timelines []replay_entity.RoundTimeline
+	search    common.Search
+}
+
+func (m *mockRoundTimelineReader) Search(ctx context.Context, s common.Search) ([]replay_entity.RoundTimeline, error) {
+	m.search = s
+
+	return m.timelines, nil
+}
+
+func (m *mockRoundTimelineReader) Compile(ctx context.Context, searchParams []common.SearchAggregation, resultOptions common.SearchResultOptions) (*common.Search, error) {
+	return nil, nil
+}
+
+func TestGetReplayEconomy(t *testing.T) {
+	ctx := common.WithResourceOwner(context.Background(), common.ResourceOwner{TenantID: uuid.New(), ClientID: uuid.New()})
+
+	replayFileID := uuid.New()
+	reader := &mockRoundTimelineReader{timelines: []replay_entity.RoundTimeline{{
+		ID:           uuid.New(),
+		GameID:       common.CS2_GAME_ID,
+		ReplayFileID: replayFileID,
+		Rounds: []replay_entity.RoundTimelineEntry{
+			{RoundNumber: 1, Winner: replay_entity.RoundSideCT, EndReason: "ct_win", Economy: []replay_entity.TeamRoundEconomy{
+				{Side: replay_entity.RoundSideCT, BuyType: cs_entities.CSEconomyStateSave, EquipmentValue: 3500, Money: 1500, Spent: 2500, Players: 5},
+				{Side: replay_entity.RoundSideT, BuyType: cs_entities.CSEconomyStateSave, EquipmentValue: 2550, Money: 2250, Spent: 1750, Players: 5},
+			}},
+			{RoundNumber: 2, Winner: replay_entity.RoundSideCT, EndReason: "ct_win", Economy: []replay_entity.TeamRoundEconomy{
+				{Side: replay_entity.RoundSideCT, BuyType: cs_entities.CSEconomyStateHalfBuy, EquipmentValue: 16500, Money: 5950, Spent: 13300, Players: 5},
+				{Side: replay_entity.RoundSideT, BuyType: cs_entities.CSEconomyStateHalfBuy, EquipmentValue: 8300, Money: 5500, Spent: 6500, Players: 5},
+			}},
+		},
+	}}}
+
+	economy, err := use_cases.NewGetReplayEconomyUseCase(reader).Exec(ctx, common.CS2_GAME_ID, replayFileID)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	// the latest timeline of the replay file
+	assert.Equal(t, uint(1), reader.search.ResultOptions.Limit)
+	assert.Equal(t, []common.SearchSortOption{{Field: "CreatedAt", Direction: common.DescendingIDKey}}, reader.search.SortOptions)
+
+	assert.Equal(t, replayFileID, economy.ReplayFileID)
+	assert.Len(t, economy.Rounds, 2)
+	assert.Len(t, economy.Rounds[1].Teams, 2)
+}
+
+func TestGetReplayEconomy_NotFound(t *testing.T) {
+	ctx := common.WithResourceOwner(context.Background(), common.ResourceOwner{TenantID: uuid.New(), ClientID: uuid.New()})
+
+	_, err := use_cases.NewGetReplayEconomyUseCase(&mockRoundTimelineReader{}).Exec(ctx, common.CS2_GAME_ID, uuid.New())
+
+	var notFoundErr *replay.ReplayFileNotFoundError
+	assert.True(t, errors.As(err, &notFoundErr))
+}
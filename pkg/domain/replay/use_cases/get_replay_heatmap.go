@@ -0,0 +1,56 @@
+package use_cases
+
+import (
+	"context"
+	"log/slog"
+
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	"github.com/psavelis/team-pro/replay-api/pkg/domain/replay"
+	replay_entity "github.com/psavelis/team-pro/replay-api/pkg/domain/replay/entities"
+	replay_in "github.com/psavelis/team-pro/replay-api/pkg/domain/replay/ports/in"
+	replay_out "github.com/psavelis/team-pro/replay-api/pkg/domain/replay/ports/out"
+)
+
+// ReplayPositionsPageSize is the rounds read per page to build a heatmap.
+const ReplayPositionsPageSize = 30
+
+type GetReplayHeatmapUseCase struct {
+	PositionsReader replay_out.ReplayPositionsReader
+}
+
+func NewGetReplayHeatmapUseCase(positionsReader replay_out.ReplayPositionsReader) replay_in.ReplayHeatmapQuery {
+	return &GetReplayHeatmapUseCase{
+		PositionsReader: positionsReader,
+	}
+}
+
+// Exec reads every round of the replay file (not found when it has no samples: unknown, from another tenant or not processed yet).
+func (usecase *GetReplayHeatmapUseCase) Exec(ctx context.Context, params replay_in.ReplayHeatmapQueryParams) (*replay_entity.ReplayHeatmap, error) {
+	positions := make([]replay_entity.ReplayPositions, 0)
+
+	for skip := uint(0); ; skip += ReplayPositionsPageSize {
+		s := common.NewSearchByValues(ctx, []common.SearchableValue{
+			{Field: "ReplayFileID", Values: []interface{}{params.ReplayFileID}},
+			{Field: "GameID", Values: []interface{}{params.GameID}},
+		}, common.NewSearchResultOptions(skip, ReplayPositionsPageSize), common.ClientApplicationAudienceIDKey)
+		s.SortOptions = []common.SearchSortOption{{Field: "RoundNumber", Direction: common.AscendingIDKey}}
+
+		page, err := usecase.PositionsReader.Search(ctx, s)
+		if err != nil {
+			slog.ErrorContext(ctx, "unable to read replay positions", "replayFileID", params.ReplayFileID, "skip", skip, "err", err)
+			return nil, err
+		}
+
+		positions = append(positions, page...)
+
+		if len(page) < ReplayPositionsPageSize {
+			break
+		}
+	}
+
+	if len(positions) == 0 {
+		return nil, replay.NewReplayFileNotFoundError(params.ReplayFileID)
+	}
+
+	return replay_entity.NewReplayHeatmap(params.ReplayFileID, positions, params.Type, params.Side, params.CellSize), nil
+}
@@ -0,0 +1,74 @@
+package use_cases_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	"github.com/psavelis/team-pro/replay-api/pkg/domain/replay"
+	replay_entity "github.com/psavelis/team-pro/replay-api/pkg/domain/replay/entities"
+	replay_in "github.com/psavelis/team-pro/replay-api/pkg/domain/replay/ports/in"
+	"github.com/psavelis/team-pro/replay-api/pkg/domain/replay/use_cases"
+	"github.com/stretchr/testify/assert"
+)
+
+// mockPositionsReader pages its rounds by the skip and limit requested.
+type mockPositionsReader struct {
+	rounds   []replay_entity.ReplayPositions
+	searches int
+}
+
+func (m *mockPositionsReader) Search(ctx context.Context, s common.Search) ([]replay_entity.ReplayPositions, error) {
+	m.searches++
+
+	skip := int(s.ResultOptions.Skip)
+	if skip >= len(m.rounds) {
+		return []replay_entity.ReplayPositions{}, nil
+	}
+
+	end := min(skip+int(s.ResultOptions.Limit), len(m.rounds))
+
+	return m.rounds[skip:end], nil
+}
+
+func (m *mockPositionsReader) Compile(ctx context.Context, searchParams []common.SearchAggregation, resultOptions common.SearchResultOptions) (*common.Search, error) {
+	return nil, nil
+}
+
+func TestGetReplayHeatmap(t *testing.T) {
+	ctx := common.WithResourceOwner(context.Background(), common.ResourceOwner{TenantID: uuid.New(), ClientID: uuid.New()})
+
+	reader := &mockPositionsReader{}
+	for round := 1; round <= use_cases.ReplayPositionsPageSize+1; round++ {
+		reader.rounds = append(reader.rounds, replay_entity.ReplayPositions{MapName: "de_inferno", RoundNumber: round, Samples: []replay_entity.PositionSample{
+			{Type: replay_entity.PositionSampleTypeDeath, Side: replay_entity.RoundSideT, X: 100, Y: 100},
+		}})
+	}
+
+	usecase := use_cases.NewGetReplayHeatmapUseCase(reader)
+
+	params := replay_in.ReplayHeatmapQueryParams{GameID: common.CS2_GAME_ID, ReplayFileID: uuid.New(), Type: replay_entity.PositionSampleTypeDeath, CellSize: 64}
+	heatmap, err := usecase.Exec(ctx, params)
+
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	assert.Equal(t, 2, reader.searches)
+	assert.Equal(t, params.ReplayFileID, heatmap.ReplayFileID)
+	assert.Equal(t, use_cases.ReplayPositionsPageSize+1, heatmap.Samples)
+	assert.Equal(t, []replay_entity.HeatmapCell{{X: 64, Y: 64, Count: use_cases.ReplayPositionsPageSize + 1}}, heatmap.Cells)
+}
+
+func TestGetReplayHeatmap_NotFound(t *testing.T) {
+	ctx := common.WithResourceOwner(context.Background(), common.ResourceOwner{TenantID: uuid.New(), ClientID: uuid.New()})
+
+	usecase := use_cases.NewGetReplayHeatmapUseCase(&mockPositionsReader{})
+
+	_, err := usecase.Exec(ctx, replay_in.ReplayHeatmapQueryParams{ReplayFileID: uuid.New(), Type: replay_entity.PositionSampleTypePosition, CellSize: 64})
+
+	var notFoundErr *replay.ReplayFileNotFoundError
+	assert.True(t, errors.As(err, &notFoundErr))
+}
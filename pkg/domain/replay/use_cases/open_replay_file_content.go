@@ -0,0 +1,125 @@
+package use_cases
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+
+	"github.com/google/uuid"
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	"github.com/psavelis/team-pro/replay-api/pkg/domain/replay"
+	replay_entity "github.com/psavelis/team-pro/replay-api/pkg/domain/replay/entities"
+	replay_out "github.com/psavelis/team-pro/replay-api/pkg/domain/replay/ports/out"
+)
+
+type OpenReplayFileContentUseCase struct {
+	MetadataReader  replay_out.ReplayFileMetadataReader
+	ContentStreamer replay_out.ReplayFileContentStreamer
+}
+
+func NewOpenReplayFileContentUseCase(metadataReader replay_out.ReplayFileMetadataReader, contentStreamer replay_out.ReplayFileContentStreamer) *OpenReplayFileContentUseCase {
+	return &OpenReplayFileContentUseCase{
+		MetadataReader:  metadataReader,
+		ContentStreamer: contentStreamer,
+	}
+}
+
+func (usecase *OpenReplayFileContentUseCase) Open(ctx context.Context, gameID common.GameIDKey, replayFileID uuid.UUID) (*replay_entity.ReplayFile, io.ReadSeekCloser, error) {
+	replayFile, err := usecase.MetadataReader.GetByID(ctx, replayFileID)
+	if err != nil || replayFile == nil {
+		slog.ErrorContext(ctx, "unable to get replay file", "replayFileID", replayFileID, "err", err)
+		return nil, nil, replay.NewReplayFileNotFoundError(replayFileID)
+	}
+
+	if replayFile.ResourceOwner.TenantID != common.GetResourceOwner(ctx).TenantID || replayFile.GameID != gameID {
+		slog.WarnContext(ctx, "replay file content requested from another tenant or game", "replayFileID", replayFileID, "gameID", gameID)
+		return nil, nil, replay.NewReplayFileNotFoundError(replayFileID)
+	}
+
+	size, err := usecase.ContentStreamer.Size(ctx, replayFileID)
+	if err != nil {
+		slog.ErrorContext(ctx, "unable to get replay file content size", "replayFileID", replayFileID, "err", err)
+		return nil, nil, err
+	}
+
+	return replayFile, &rangedContent{ctx: ctx, streamer: usecase.ContentStreamer, replayFileID: replayFileID, size: size}, nil
+}
+
+// rangedContent reads the content from the offset sought: the stream is opened on the first read after a seek, from that offset to
+// the end of the content (and closed by the next seek elsewhere).
+type rangedContent struct {
+	ctx          context.Context
+	streamer     replay_out.ReplayFileContentStreamer
+	replayFileID uuid.UUID
+	size         int64
+
+	offset int64
+	stream io.ReadCloser
+}
+
+func (c *rangedContent) Read(p []byte) (int, error) {
+	if c.offset >= c.size {
+		return 0, io.EOF
+	}
+
+	if c.stream == nil {
+		stream, err := c.streamer.Stream(c.ctx, c.replayFileID, c.offset, c.size-c.offset)
+		if err != nil {
+			return 0, err
+		}
+
+		c.stream = stream
+	}
+
+	n, err := c.stream.Read(p)
+	c.offset += int64(n)
+
+	if err == io.EOF && c.offset < c.size {
+		err = io.ErrUnexpectedEOF
+	}
+
+	return n, err
+}
+
+func (c *rangedContent) Seek(offset int64, whence int) (int64, error) {
+	var position int64
+
+	switch whence {
+	case io.SeekStart:
+		position = offset
+	case io.SeekCurrent:
+		position = c.offset + offset
+	case io.SeekEnd:
+		position = c.size + offset
+	default:
+		return 0, errors.New("replay file content: invalid whence")
+	}
+
+	if position < 0 {
+		return 0, errors.New("replay file content: negative position")
+	}
+
+	if position != c.offset {
+		c.closeStream()
+	}
+
+	c.offset = position
+
+	return position, nil
+}
+
+func (c *rangedContent) Close() error {
+	return c.closeStream()
+}
+
+func (c *rangedContent) closeStream() error {
+	if c.stream == nil {
+		return nil
+	}
+
+	err := c.stream.Close()
+	c.stream = nil
+
+	return err
+}
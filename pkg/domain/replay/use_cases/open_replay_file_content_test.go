@@ -0,0 +1,110 @@
+package use_cases_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	"github.com/psavelis/team-pro/replay-api/pkg/domain/replay"
+	replay_entity "github.com/psavelis/team-pro/replay-api/pkg/domain/replay/entities"
+	"github.com/psavelis/team-pro/replay-api/pkg/domain/replay/use_cases"
+	"github.com/stretchr/testify/assert"
+)
+
+type streamedRange struct {
+	offset int64
+	length int64
+}
+
+type mockContentStreamer struct {
+	content []byte
+	streams []streamedRange
+}
+
+func (m *mockContentStreamer) Size(ctx context.Context, replayFileID uuid.UUID) (int64, error) {
+	return int64(len(m.content)), nil
+}
+
+func (m *mockContentStreamer) Stream(ctx context.Context, replayFileID uuid.UUID, offset int64, length int64) (io.ReadCloser, error) {
+	m.streams = append(m.streams, streamedRange{offset, length})
+
+	return io.NopCloser(bytes.NewReader(m.content[offset : offset+length])), nil
+}
+
+func TestOpenReplayFileContentUseCase_Open(t *testing.T) {
+	owner := common.ResourceOwner{TenantID: common.TeamPROTenantID, ClientID: common.TeamPROAppClientID, UserID: uuid.New()}
+	ctx := common.WithResourceOwner(context.Background(), owner)
+
+	replayFile := replay_entity.ReplayFile{ID: uuid.New(), GameID: common.CS2_GAME_ID, ResourceOwner: owner}
+	otherTenant := replay_entity.ReplayFile{ID: uuid.New(), GameID: common.CS2_GAME_ID, ResourceOwner: common.ResourceOwner{TenantID: uuid.New()}}
+
+	streamer := &mockContentStreamer{content: []byte("0123456789")}
+	usecase := use_cases.NewOpenReplayFileContentUseCase(newMockReplayFileStore(replayFile, otherTenant), streamer)
+
+	for _, id := range []uuid.UUID{uuid.New(), otherTenant.ID} {
+		_, _, err := usecase.Open(ctx, common.CS2_GAME_ID, id)
+
+		var notFoundErr *replay.ReplayFileNotFoundError
+		assert.True(t, errors.As(err, &notFoundErr))
+	}
+
+	_, _, err := usecase.Open(ctx, common.CSGO_GAME_ID, replayFile.ID)
+	assert.Error(t, err)
+
+	_, content, err := usecase.Open(ctx, common.CS2_GAME_ID, replayFile.ID)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	defer content.Close()
+
+	// nothing is streamed until read
+	size, err := content.Seek(0, io.SeekEnd)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(10), size)
+	assert.Empty(t, streamer.streams)
+
+	_, err = content.Seek(6, io.SeekStart)
+	assert.NoError(t, err)
+
+	read, err := io.ReadAll(content)
+	assert.NoError(t, err)
+	assert.Equal(t, "6789", string(read))
+	assert.Equal(t, []streamedRange{{6, 4}}, streamer.streams)
+}
+
+func TestOpenReplayFileContentUseCase_Open_ServeRange(t *testing.T) {
+	owner := common.ResourceOwner{TenantID: common.TeamPROTenantID, ClientID: common.TeamPROAppClientID, UserID: uuid.New()}
+	replayFile := replay_entity.ReplayFile{ID: uuid.New(), GameID: common.CS2_GAME_ID, ResourceOwner: owner}
+
+	streamer := &mockContentStreamer{content: []byte("0123456789")}
+	usecase := use_cases.NewOpenReplayFileContentUseCase(newMockReplayFileStore(replayFile), streamer)
+
+	_, content, err := usecase.Open(common.WithResourceOwner(context.Background(), owner), common.CS2_GAME_ID, replayFile.ID)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("Range", "bytes=2-4")
+
+	// (otherwise sniffed from the first bytes)
+	res := httptest.NewRecorder()
+	res.Header().Set("Content-Type", "application/octet-stream")
+
+	http.ServeContent(res, req, "demo.dem", time.Time{}, content)
+
+	assert.Equal(t, http.StatusPartialContent, res.Code)
+	assert.Equal(t, "bytes 2-4/10", res.Header().Get("Content-Range"))
+	assert.Equal(t, "234", res.Body.String())
+
+	// streamed from the start of the range, and closed once the range was served
+	assert.Equal(t, []streamedRange{{2, 8}}, streamer.streams)
+}
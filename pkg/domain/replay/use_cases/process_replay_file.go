@@ -3,11 +3,13 @@ package use_cases
 import (
 	"context"
 	"log/slog"
+	"time"
 
 	"github.com/google/uuid"
 	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
 	e "github.com/psavelis/team-pro/replay-api/pkg/domain/replay/entities"
 	replay_out "github.com/psavelis/team-pro/replay-api/pkg/domain/replay/ports/out"
+	"github.com/psavelis/team-pro/replay-api/pkg/domain/replay/services/highlights"
 )
 
 const CHUNK_SIZE = 10
@@ -23,9 +25,23 @@ type ProcessReplayFileUseCase struct {
 
 	Parser      replay_out.ReplayParser
 	EventWriter replay_out.GameEventWriter
+
+	RoundTimelineWriter replay_out.RoundTimelineWriter
+	PlayerStatsWriter   replay_out.PlayerStatsWriter
+	PositionsWriter     replay_out.ReplayPositionsWriter
+	HighlightsWriter    replay_out.ReplayHighlightsWriter
+	SummaryWriter       replay_out.MatchSummaryWriter
+
+	HighlightDetector *highlights.HighlightDetectionService
+
+	ProgressPublisher replay_out.ReplayProcessingProgressPublisher
+
+	SeasonReader replay_out.ActiveSeasonReader
+
+	BadgeEvaluator replay_out.ReplayBadgeEvaluator
 }
 
-func NewProcessReplayFileUseCase(metadataReader replay_out.ReplayFileMetadataReader, contentReader replay_out.ReplayFileContentReader, metadataWriter replay_out.ReplayFileMetadataWriter, contentWriter replay_out.ReplayFileContentWriter, parser replay_out.ReplayParser, eventWriter replay_out.GameEventWriter, playerMetadataWriter replay_out.PlayerMetadataWriter, matchMetadataWriter replay_out.MatchMetadataWriter) *ProcessReplayFileUseCase {
+func NewProcessReplayFileUseCase(metadataReader replay_out.ReplayFileMetadataReader, contentReader replay_out.ReplayFileContentReader, metadataWriter replay_out.ReplayFileMetadataWriter, contentWriter replay_out.ReplayFileContentWriter, parser replay_out.ReplayParser, eventWriter replay_out.GameEventWriter, playerMetadataWriter replay_out.PlayerMetadataWriter, matchMetadataWriter replay_out.MatchMetadataWriter, roundTimelineWriter replay_out.RoundTimelineWriter, playerStatsWriter replay_out.PlayerStatsWriter, positionsWriter replay_out.ReplayPositionsWriter, highlightsWriter replay_out.ReplayHighlightsWriter, summaryWriter replay_out.MatchSummaryWriter, progressPublisher replay_out.ReplayProcessingProgressPublisher, seasonReader replay_out.ActiveSeasonReader, badgeEvaluator replay_out.ReplayBadgeEvaluator) *ProcessReplayFileUseCase {
 	return &ProcessReplayFileUseCase{
 		ReplayMetadataReader: metadataReader,
 		ReplayContentReader:  contentReader,
@@ -37,6 +53,20 @@ func NewProcessReplayFileUseCase(metadataReader replay_out.ReplayFileMetadataRea
 
 		Parser:      parser,
 		EventWriter: eventWriter,
+
+		RoundTimelineWriter: roundTimelineWriter,
+		PlayerStatsWriter:   playerStatsWriter,
+		PositionsWriter:     positionsWriter,
+		HighlightsWriter:    highlightsWriter,
+		SummaryWriter:       summaryWriter,
+
+		HighlightDetector: highlights.NewHighlightDetectionService(),
+
+		ProgressPublisher: progressPublisher,
+
+		SeasonReader: seasonReader,
+
+		BadgeEvaluator: badgeEvaluator,
 	}
 }
 
@@ -58,14 +88,38 @@ func (usecase *ProcessReplayFileUseCase) Exec(ctx context.Context, replayFileID
 
 	slog.InfoContext(ctx, "processing replay file", "replayFile", replayFile)
 
+	// matches (and their summaries, ranked by the leaderboards) belong to the season active when they are processed
+	season, err := usecase.SeasonReader.GetActiveSeason(ctx, replayFile.GameID)
+	if err != nil {
+		slog.ErrorContext(ctx, "error getting the active season", "gameID", replayFile.GameID, "err", err)
+		return nil, err
+	}
+
+	var seasonID *uuid.UUID
+	if season != nil {
+		seasonID = &season.ID
+	}
+
 	match := &e.Match{
 		ID:            uuid.New(),
 		GameID:        replayFile.GameID,
 		ReplayFileID:  replayFile.ID,
+		Visibility:    e.MatchVisibility(replayFile.Visibility),
+		SeasonID:      seasonID,
 		ResourceOwner: replayFile.ResourceOwner,
 		Events:        make([]*e.GameEvent, 0),
 	}
 
+	progress := newReplayProgressTracker(usecase.ProgressPublisher, replayFile)
+	progress.Publish(ctx, e.ReplayFileStatusProcessing)
+
+	completed := false
+	defer func() {
+		if !completed {
+			progress.Publish(ctx, e.ReplayFileStatusFailed)
+		}
+	}()
+
 	file, err := usecase.ReplayContentReader.GetByID(ctx, replayFileID)
 	if err != nil {
 		slog.ErrorContext(ctx, "error getting replay file content data", "err", err)
@@ -84,26 +138,54 @@ func (usecase *ProcessReplayFileUseCase) Exec(ctx context.Context, replayFileID
 
 	go func() {
 		for event := range eventsChan {
+			progress.Extracted(event)
+
 			if event.Type != common.Event_GenericGameEventID {
 				match.Events = append(match.Events, event)
 			}
 
 			gameEvents = append(gameEvents, event)
 
-			entitiesMap = event.Entities
+			// only the entities of the last event carrying them (round timeline events don't)
+			if event.Entities != nil {
+				entitiesMap = event.Entities
+			}
 		}
 	}()
 
-	err = usecase.Parser.Parse(ctx, match.ID, file, eventsChan)
+	stopProgress := progress.PublishEvery(ctx, ProgressInterval)
+
+	err = usecase.Parser.Parse(ctx, match.ID, progress.Reader(file), eventsChan)
+
+	stopProgress()
 
 	if err != nil {
 		slog.ErrorContext(ctx, "error parsing replay events", "err", err)
 		return nil, err
 	}
 
+	// tags of the server the replay was recorded on, for its match (and analytics) to be filtered by them
+	tags := e.ReplayTagsOf(gameEvents)
+	if tags != nil {
+		replayFile.Tags = tags
+		match.Tags = tags
+
+		if tags.ServerRegion != "" {
+			match.RegionID = tags.ServerRegion
+		}
+	}
+
+	players := make([]*e.Player, 0)
+
 	for resourceKey, entities := range entitiesMap {
 		switch resourceKey {
 		case common.ResourceTypePlayer:
+			for _, entity := range entities {
+				if p, ok := entity.(*e.Player); ok {
+					players = append(players, p)
+				}
+			}
+
 			err = usecase.PlayerMetadataWriter.CreateMany(ctx, entities)
 
 			if err != nil {
@@ -112,6 +194,17 @@ func (usecase *ProcessReplayFileUseCase) Exec(ctx context.Context, replayFileID
 			}
 
 		case common.ResourceTypeMatch:
+			for _, entity := range entities {
+				if m, ok := entity.(*e.Match); ok {
+					m.SeasonID = seasonID
+					m.Tags = tags
+
+					if m.RegionID == "" && tags != nil {
+						m.RegionID = tags.ServerRegion
+					}
+				}
+			}
+
 			err = usecase.MatchMetadataWriter.CreateMany(ctx, entities)
 
 			if err != nil {
@@ -128,6 +221,72 @@ func (usecase *ProcessReplayFileUseCase) Exec(ctx context.Context, replayFileID
 		return nil, err
 	}
 
+	now := time.Now()
+
+	timeline := e.NewRoundTimeline(replayFile, match.ID, gameEvents, now)
+	if len(timeline.Rounds) > 0 {
+		_, err = usecase.RoundTimelineWriter.Create(ctx, timeline)
+
+		if err != nil {
+			slog.ErrorContext(ctx, "error writing RoundTimeline", "err", err, "len(timeline.Rounds)", len(timeline.Rounds))
+			return nil, err
+		}
+	}
+
+	positions := e.NewReplayPositions(replayFile, match.ID, gameEvents, now)
+	if len(positions) > 0 {
+		err = usecase.PositionsWriter.CreateMany(ctx, positions)
+
+		if err != nil {
+			slog.ErrorContext(ctx, "error writing ReplayPositions", "err", err, "len(positions)", len(positions))
+			return nil, err
+		}
+	}
+
+	replayHighlights := usecase.HighlightDetector.Detect(replayFile, match.ID, gameEvents, now)
+	if len(replayHighlights.Highlights) > 0 {
+		_, err = usecase.HighlightsWriter.Create(ctx, replayHighlights)
+
+		if err != nil {
+			slog.ErrorContext(ctx, "error writing ReplayHighlights", "err", err, "len(replayHighlights.Highlights)", len(replayHighlights.Highlights))
+			return nil, err
+		}
+	}
+
+	summary := e.NewMatchSummary(replayFile, match.ID, gameEvents, now)
+	summary.SeasonID = seasonID
+	summary.Tags = tags
+	if len(summary.Players) > 0 {
+		_, err = usecase.SummaryWriter.Create(ctx, summary)
+
+		if err != nil {
+			slog.ErrorContext(ctx, "error writing MatchSummary", "err", err, "len(summary.Players)", len(summary.Players))
+			return nil, err
+		}
+	}
+
+	// incremented once per replay: completed replays aren't processed again
+	buckets := e.NewPlayerStatsBuckets(replayFile, gameEvents, replayFile.CreatedAt, now)
+	summary.Rate(buckets)
+	if len(buckets) > 0 {
+		err = usecase.PlayerStatsWriter.Increment(ctx, buckets)
+
+		if err != nil {
+			slog.ErrorContext(ctx, "error writing PlayerStats", "err", err, "len(buckets)", len(buckets))
+			return nil, err
+		}
+	}
+
+	// evaluated once the stats of the replay add up to the career of its players. Not failing the replay: its match badges are lost, but
+	// career badges are awarded on the next replay of the player
+	if len(summary.Players) > 0 {
+		err = usecase.BadgeEvaluator.EvaluateReplayBadges(ctx, summary, players)
+
+		if err != nil {
+			slog.WarnContext(ctx, "unable to evaluate replay badges", "err", err, "matchID", match.ID)
+		}
+	}
+
 	// Update Metadata Status
 	replayFile.Status = e.ReplayFileStatusCompleted
 	replayFile, err = usecase.ReplayMetadataWriter.Update(ctx, replayFile)
@@ -137,6 +296,9 @@ func (usecase *ProcessReplayFileUseCase) Exec(ctx context.Context, replayFileID
 		return nil, err
 	}
 
+	completed = true
+	progress.Publish(ctx, e.ReplayFileStatusCompleted)
+
 	slog.InfoContext(ctx, "Replay file processed", "ReplayFileID", replayFileID)
 
 	return match, nil
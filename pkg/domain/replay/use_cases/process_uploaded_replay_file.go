@@ -0,0 +1,79 @@
+package use_cases
+
+import (
+	"context"
+	"log/slog"
+
+	replay_entity "github.com/psavelis/team-pro/replay-api/pkg/domain/replay/entities"
+	replay_in "github.com/psavelis/team-pro/replay-api/pkg/domain/replay/ports/in"
+	replay_out "github.com/psavelis/team-pro/replay-api/pkg/domain/replay/ports/out"
+)
+
+// attempts made by the replay workers before a replay file is marked as Failed (and its message dead-lettered)
+const MaxReplayProcessingAttempts = 3
+
+type ProcessUploadedReplayFileUseCase struct {
+	MetadataReader      replay_out.ReplayFileMetadataReader
+	MetadataWriter      replay_out.ReplayFileMetadataWriter
+	ProcessCommand      replay_in.ProcessReplayFileCommand
+	UpdateHeaderCommand replay_in.UpdateReplayFileHeaderCommand
+}
+
+func NewProcessUploadedReplayFileUseCase(metadataReader replay_out.ReplayFileMetadataReader, metadataWriter replay_out.ReplayFileMetadataWriter, processCommand replay_in.ProcessReplayFileCommand, updateHeaderCommand replay_in.UpdateReplayFileHeaderCommand) *ProcessUploadedReplayFileUseCase {
+	return &ProcessUploadedReplayFileUseCase{
+		MetadataReader:      metadataReader,
+		MetadataWriter:      metadataWriter,
+		ProcessCommand:      processCommand,
+		UpdateHeaderCommand: updateHeaderCommand,
+	}
+}
+
+func (usecase *ProcessUploadedReplayFileUseCase) Exec(ctx context.Context, event replay_entity.ReplayFileUploaded) (*replay_entity.Match, error) {
+	replayFile, err := usecase.MetadataReader.GetByID(ctx, event.ReplayFileID)
+	if err != nil {
+		slog.ErrorContext(ctx, "error getting queued replay file", "replayFileID", event.ReplayFileID, "err", err)
+		return nil, err
+	}
+
+	if replayFile.Status == replay_entity.ReplayFileStatusCompleted {
+		slog.InfoContext(ctx, "replay file already processed, skipping", "replayFileID", event.ReplayFileID, "attempt", event.Attempt)
+		return nil, nil
+	}
+
+	if replayFile.Status == replay_entity.ReplayFileStatusQuarantined {
+		slog.WarnContext(ctx, "replay file quarantined, skipping", "replayFileID", event.ReplayFileID, "attempt", event.Attempt)
+		return nil, nil
+	}
+
+	match, err := usecase.ProcessCommand.Exec(ctx, event.ReplayFileID)
+	if err == nil {
+		_, err = usecase.UpdateHeaderCommand.Exec(ctx, event.ReplayFileID)
+	}
+
+	if err == nil {
+		return match, nil
+	}
+
+	slog.ErrorContext(ctx, "error processing queued replay file", "replayFileID", event.ReplayFileID, "attempt", event.Attempt, "err", err)
+
+	// reloaded: processing updates the status
+	replayFile, getErr := usecase.MetadataReader.GetByID(ctx, event.ReplayFileID)
+	if getErr != nil {
+		slog.ErrorContext(ctx, "error getting failed replay file", "replayFileID", event.ReplayFileID, "err", getErr)
+		return nil, err
+	}
+
+	replayFile.Status = replay_entity.ReplayFileStatusPending
+	if event.Attempt >= MaxReplayProcessingAttempts {
+		replayFile.Status = replay_entity.ReplayFileStatusFailed
+	}
+
+	replayFile.Error = err.Error()
+
+	_, updateErr := usecase.MetadataWriter.Update(ctx, replayFile)
+	if updateErr != nil {
+		slog.ErrorContext(ctx, "error updating failed replay file status", "replayFileID", event.ReplayFileID, "status", replayFile.Status, "err", updateErr)
+	}
+
+	return nil, err
+}
@@ -0,0 +1,62 @@
+package use_cases
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	replay_entity "github.com/psavelis/team-pro/replay-api/pkg/domain/replay/entities"
+	replay_in "github.com/psavelis/team-pro/replay-api/pkg/domain/replay/ports/in"
+	replay_out "github.com/psavelis/team-pro/replay-api/pkg/domain/replay/ports/out"
+)
+
+const PlayerMatchHistoryPageSize = 100
+
+type ProjectPlayerMatchHistoryUseCase struct {
+	MatchReader   replay_out.MatchMetadataReader
+	HistoryWriter replay_out.PlayerMatchHistoryWriter
+}
+
+func NewProjectPlayerMatchHistoryUseCase(matchReader replay_out.MatchMetadataReader, historyWriter replay_out.PlayerMatchHistoryWriter) replay_in.ProjectPlayerMatchHistoryCommand {
+	return &ProjectPlayerMatchHistoryUseCase{
+		MatchReader:   matchReader,
+		HistoryWriter: historyWriter,
+	}
+}
+
+func (usecase *ProjectPlayerMatchHistoryUseCase) Exec(ctx context.Context, since time.Time) (int, error) {
+	written := 0
+
+	for skip := uint(0); ; skip += PlayerMatchHistoryPageSize {
+		s := common.NewSearchByRange(ctx, []common.SearchableDateRange{{Field: "UpdatedAt", Min: &since}}, common.NewSearchResultOptions(skip, PlayerMatchHistoryPageSize), common.ClientApplicationAudienceIDKey)
+		s.SortOptions = []common.SearchSortOption{{Field: "UpdatedAt", Direction: common.AscendingIDKey}, {Field: "ID", Direction: common.AscendingIDKey}}
+
+		matches, err := usecase.MatchReader.Search(ctx, s)
+		if err != nil {
+			slog.ErrorContext(ctx, "unable to read matches for player match history", "err", err, "since", since, "skip", skip)
+			return written, err
+		}
+
+		now := time.Now().UTC()
+
+		entries := make([]replay_entity.PlayerMatchHistory, 0)
+		for _, match := range matches {
+			entries = append(entries, replay_entity.NewPlayerMatchHistoryFromMatch(match, now)...)
+		}
+
+		if len(entries) > 0 {
+			err = usecase.HistoryWriter.Upsert(ctx, entries)
+			if err != nil {
+				slog.ErrorContext(ctx, "unable to write player match history", "err", err, "since", since, "skip", skip)
+				return written, err
+			}
+
+			written += len(entries)
+		}
+
+		if len(matches) < PlayerMatchHistoryPageSize {
+			return written, nil
+		}
+	}
+}
@@ -0,0 +1,177 @@
+package use_cases_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	replay_entity "github.com/psavelis/team-pro/replay-api/pkg/domain/replay/entities"
+	"github.com/psavelis/team-pro/replay-api/pkg/domain/replay/use_cases"
+	"github.com/stretchr/testify/assert"
+)
+
+type mockMatchReader struct {
+	matches  []replay_entity.Match
+	searches []common.Search
+}
+
+func (m *mockMatchReader) Search(ctx context.Context, s common.Search) ([]replay_entity.Match, error) {
+	m.searches = append(m.searches, s)
+
+	start := int(s.ResultOptions.Skip)
+	if start >= len(m.matches) {
+		return []replay_entity.Match{}, nil
+	}
+
+	end := start + int(s.ResultOptions.Limit)
+	if end > len(m.matches) {
+		end = len(m.matches)
+	}
+
+	return m.matches[start:end], nil
+}
+
+func (m *mockMatchReader) Compile(ctx context.Context, searchParams []common.SearchAggregation, resultOptions common.SearchResultOptions) (*common.Search, error) {
+	return nil, nil
+}
+
+type mockHistoryWriter struct {
+	entries []replay_entity.PlayerMatchHistory
+}
+
+func (m *mockHistoryWriter) Upsert(ctx context.Context, entries []replay_entity.PlayerMatchHistory) error {
+	m.entries = append(m.entries, entries...)
+	return nil
+}
+
+func newScoredMatch(scores ...int) replay_entity.Match {
+	teams := make([]replay_entity.TeamScoreboard, 0, len(scores))
+	for _, score := range scores {
+		teams = append(teams, replay_entity.TeamScoreboard{
+			Team:      replay_entity.Team{ID: uuid.New()},
+			TeamScore: score,
+			Players:   []replay_entity.Player{{ID: common.PlayerIDType(uuid.New())}},
+		})
+	}
+
+	return replay_entity.Match{
+		ID:         uuid.New(),
+		GameID:     common.CS2_GAME_ID,
+		Scoreboard: replay_entity.Scoreboard{TeamScoreboards: teams},
+		CreatedAt:  time.Now().Add(-time.Hour),
+	}
+}
+
+func TestNewPlayerMatchHistoryFromMatch(t *testing.T) {
+	tests := []struct {
+		name     string
+		match    replay_entity.Match
+		expected map[int]replay_entity.MatchOutcome // team index => outcome
+	}{
+		{
+			name:     "Win And Loss",
+			match:    newScoredMatch(16, 9),
+			expected: map[int]replay_entity.MatchOutcome{0: replay_entity.MatchOutcomeWin, 1: replay_entity.MatchOutcomeLoss},
+		},
+		{
+			name:     "Draw",
+			match:    newScoredMatch(15, 15),
+			expected: map[int]replay_entity.MatchOutcome{0: replay_entity.MatchOutcomeDraw, 1: replay_entity.MatchOutcomeDraw},
+		},
+		{
+			name:     "No Opponent",
+			match:    newScoredMatch(13),
+			expected: map[int]replay_entity.MatchOutcome{0: replay_entity.MatchOutcomeUnknown},
+		},
+		{
+			name:     "No Scoreboard",
+			match:    replay_entity.Match{ID: uuid.New()},
+			expected: map[int]replay_entity.MatchOutcome{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			entries := replay_entity.NewPlayerMatchHistoryFromMatch(tt.match, time.Now())
+
+			assert.Len(t, entries, len(tt.expected))
+
+			for i, outcome := range tt.expected {
+				team := tt.match.Scoreboard.TeamScoreboards[i]
+				playerID := uuid.UUID(team.Players[0].ID)
+
+				var entry *replay_entity.PlayerMatchHistory
+				for j := range entries {
+					if entries[j].PlayerID == playerID {
+						entry = &entries[j]
+					}
+				}
+
+				if assert.NotNil(t, entry) {
+					assert.Equal(t, outcome, entry.Outcome)
+					assert.Equal(t, team.TeamScore, entry.TeamScore)
+					assert.Equal(t, team.Team.ID, entry.TeamID)
+					assert.Equal(t, tt.match.CreatedAt, entry.PlayedAt)
+					assert.Equal(t, replay_entity.PlayerMatchHistoryID(playerID, tt.match.ID), entry.ID)
+				}
+			}
+		})
+	}
+}
+
+func TestNewPlayerMatchHistoryFromMatch_Lobby(t *testing.T) {
+	match := newScoredMatch(13, 9)
+
+	winnerID := uuid.UUID(match.Scoreboard.TeamScoreboards[0].Players[0].ID)
+	loserID := uuid.UUID(match.Scoreboard.TeamScoreboards[1].Players[0].ID)
+
+	lobbyID := uuid.New()
+	queueSessionID := uuid.New()
+
+	match.LobbyID = &lobbyID
+	match.QueueSessions = []replay_entity.MatchQueueSession{{PlayerID: winnerID, QueueSessionID: queueSessionID}}
+	match.Settlement = &replay_entity.MatchSettlement{Currency: "USD", Payouts: []replay_entity.MatchSettlementPayout{{PlayerID: winnerID, Amount: 1000}}}
+
+	entries := make(map[uuid.UUID]replay_entity.PlayerMatchHistory)
+	for _, e := range replay_entity.NewPlayerMatchHistoryFromMatch(match, time.Now()) {
+		entries[e.PlayerID] = e
+	}
+
+	assert.Equal(t, &lobbyID, entries[winnerID].LobbyID)
+	assert.Equal(t, &queueSessionID, entries[winnerID].QueueSessionID)
+	assert.Equal(t, &replay_entity.MatchPayout{Amount: 1000, Currency: "USD"}, entries[winnerID].Payout)
+
+	// not matched from the queue (ie: invited to the lobby), and not paid
+	assert.Equal(t, &lobbyID, entries[loserID].LobbyID)
+	assert.Nil(t, entries[loserID].QueueSessionID)
+	assert.Nil(t, entries[loserID].Payout)
+}
+
+func TestProjectPlayerMatchHistoryUseCase_Exec(t *testing.T) {
+	matches := make([]replay_entity.Match, 0)
+	for i := 0; i < use_cases.PlayerMatchHistoryPageSize+1; i++ {
+		matches = append(matches, newScoredMatch(16, i%16))
+	}
+
+	reader := &mockMatchReader{matches: matches}
+	writer := &mockHistoryWriter{}
+
+	usecase := use_cases.NewProjectPlayerMatchHistoryUseCase(reader, writer)
+
+	ctx := common.WithResourceOwner(context.Background(), common.ResourceOwner{TenantID: common.TeamPROTenantID, ClientID: common.TeamPROAppClientID})
+
+	since := time.Now().Add(-15 * time.Minute)
+
+	written, err := usecase.Exec(ctx, since)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 2*len(matches), written)
+	assert.Len(t, writer.entries, 2*len(matches))
+
+	// two pages: a full one and the remainder
+	assert.Len(t, reader.searches, 2)
+	assert.Equal(t, uint(use_cases.PlayerMatchHistoryPageSize), reader.searches[1].ResultOptions.Skip)
+	assert.Equal(t, &since, reader.searches[0].SearchParams[0].Params[0].DateParams[0].Min)
+}
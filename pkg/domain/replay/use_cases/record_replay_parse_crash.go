@@ -0,0 +1,57 @@
+package use_cases
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/google/uuid"
+	replay_entity "github.com/psavelis/team-pro/replay-api/pkg/domain/replay/entities"
+	replay_out "github.com/psavelis/team-pro/replay-api/pkg/domain/replay/ports/out"
+)
+
+type RecordReplayParseCrashUseCase struct {
+	MetadataReader    replay_out.ReplayFileMetadataReader
+	MetadataWriter    replay_out.ReplayFileMetadataWriter
+	ProgressPublisher replay_out.ReplayProcessingProgressPublisher
+}
+
+func NewRecordReplayParseCrashUseCase(metadataReader replay_out.ReplayFileMetadataReader, metadataWriter replay_out.ReplayFileMetadataWriter, progressPublisher replay_out.ReplayProcessingProgressPublisher) *RecordReplayParseCrashUseCase {
+	return &RecordReplayParseCrashUseCase{
+		MetadataReader:    metadataReader,
+		MetadataWriter:    metadataWriter,
+		ProgressPublisher: progressPublisher,
+	}
+}
+
+func (usecase *RecordReplayParseCrashUseCase) Exec(ctx context.Context, replayFileID uuid.UUID, failure replay_entity.ParseFailure) (*replay_entity.ReplayFile, error) {
+	replayFile, err := usecase.MetadataReader.GetByID(ctx, replayFileID)
+	if err != nil {
+		slog.ErrorContext(ctx, "error getting crashed replay file", "replayFileID", replayFileID, "err", err)
+		return nil, err
+	}
+
+	replayFile.ParseFailures = append(replayFile.ParseFailures, failure)
+	replayFile.Error = fmt.Sprintf("replay parser %s (attempt %d)", failure.Reason, failure.Attempt)
+
+	replayFile.Status = replay_entity.ReplayFileStatusPending
+	if len(replayFile.ParseFailures) >= replay_entity.MaxReplayParseCrashes {
+		replayFile.Status = replay_entity.ReplayFileStatusQuarantined
+
+		slog.WarnContext(ctx, "replay file quarantined: the parser crashed on it repeatedly", "replayFileID", replayFileID, "crashes", len(replayFile.ParseFailures))
+	}
+
+	replayFile, err = usecase.MetadataWriter.Update(ctx, replayFile)
+	if err != nil {
+		slog.ErrorContext(ctx, "error recording replay parse crash", "replayFileID", replayFileID, "reason", failure.Reason, "err", err)
+		return nil, err
+	}
+
+	// the parser didn't get to publish it
+	err = usecase.ProgressPublisher.PublishProgress(ctx, replay_entity.NewReplayProcessingProgress(*replayFile))
+	if err != nil {
+		slog.WarnContext(ctx, "unable to publish crashed replay file progress", "replayFileID", replayFileID, "err", err)
+	}
+
+	return replayFile, nil
+}
@@ -0,0 +1,270 @@
+package use_cases_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	replay_entity "github.com/psavelis/team-pro/replay-api/pkg/domain/replay/entities"
+	"github.com/psavelis/team-pro/replay-api/pkg/domain/replay/use_cases"
+	"github.com/stretchr/testify/assert"
+)
+
+type mockReplayFileStore struct {
+	files   map[uuid.UUID]replay_entity.ReplayFile
+	updates []replay_entity.ReplayFile
+}
+
+func newMockReplayFileStore(files ...replay_entity.ReplayFile) *mockReplayFileStore {
+	store := &mockReplayFileStore{files: make(map[uuid.UUID]replay_entity.ReplayFile)}
+	for _, f := range files {
+		store.files[f.ID] = f
+	}
+
+	return store
+}
+
+func (m *mockReplayFileStore) GetByID(ctx context.Context, replayFileID uuid.UUID) (*replay_entity.ReplayFile, error) {
+	f, ok := m.files[replayFileID]
+	if !ok {
+		return nil, errors.New("not found")
+	}
+
+	return &f, nil
+}
+
+func (m *mockReplayFileStore) Search(ctx context.Context, s common.Search) ([]replay_entity.ReplayFile, error) {
+	return nil, nil
+}
+
+func (m *mockReplayFileStore) Compile(ctx context.Context, searchParams []common.SearchAggregation, resultOptions common.SearchResultOptions) (*common.Search, error) {
+	return nil, nil
+}
+
+func (m *mockReplayFileStore) Create(ctx context.Context, replayFile *replay_entity.ReplayFile) (*replay_entity.ReplayFile, error) {
+	m.files[replayFile.ID] = *replayFile
+	return replayFile, nil
+}
+
+func (m *mockReplayFileStore) Update(ctx context.Context, replayFile *replay_entity.ReplayFile) (*replay_entity.ReplayFile, error) {
+	m.files[replayFile.ID] = *replayFile
+	m.updates = append(m.updates, *replayFile)
+	return replayFile, nil
+}
+
+type mockUploadCommand struct {
+	store *mockReplayFileStore
+}
+
+func (m *mockUploadCommand) Exec(ctx context.Context, file io.Reader) (*replay_entity.ReplayFile, error) {
+	replayFile := &replay_entity.ReplayFile{
+		ID:     uuid.New(),
+		GameID: common.CS2_GAME_ID,
+		Status: replay_entity.ReplayFileStatusProcessing,
+	}
+
+	return m.store.Create(ctx, replayFile)
+}
+
+type mockEventPublisher struct {
+	err    error
+	events []replay_entity.ReplayFileUploaded
+}
+
+func (m *mockEventPublisher) PublishUploaded(ctx context.Context, event replay_entity.ReplayFileUploaded) error {
+	if m.err != nil {
+		return m.err
+	}
+
+	m.events = append(m.events, event)
+	return nil
+}
+
+type mockProcessCommand struct {
+	err   error
+	calls int
+}
+
+func (m *mockProcessCommand) Exec(ctx context.Context, replayFileID uuid.UUID) (*replay_entity.Match, error) {
+	m.calls++
+	if m.err != nil {
+		return nil, m.err
+	}
+
+	return &replay_entity.Match{ID: uuid.New(), ReplayFileID: replayFileID}, nil
+}
+
+type mockProgressPublisher struct {
+	published []replay_entity.ReplayProcessingProgress
+}
+
+func (m *mockProgressPublisher) PublishProgress(ctx context.Context, progress replay_entity.ReplayProcessingProgress) error {
+	m.published = append(m.published, progress)
+	return nil
+}
+
+type mockUpdateHeaderCommand struct{}
+
+func (m *mockUpdateHeaderCommand) Exec(ctx context.Context, replayFileID uuid.UUID) (*replay_entity.ReplayFile, error) {
+	return nil, nil
+}
+
+func TestSubmitReplayFileUseCase_Exec(t *testing.T) {
+	tests := []struct {
+		name           string
+		publishErr     error
+		expectedStatus replay_entity.ReplayFileStatus
+	}{
+		{"Queued", nil, replay_entity.ReplayFileStatusPending},
+		{"Broker Unavailable", errors.New("connection refused"), replay_entity.ReplayFileStatusFailed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := newMockReplayFileStore()
+			publisher := &mockEventPublisher{err: tt.publishErr}
+
+			usecase := use_cases.NewSubmitReplayFileUseCase(&mockUploadCommand{store: store}, store, publisher)
+
+			replayFile, err := usecase.Exec(context.Background(), strings.NewReader("demo"))
+
+			if tt.publishErr != nil {
+				assert.ErrorIs(t, err, tt.publishErr)
+				assert.Nil(t, replayFile)
+				assert.Empty(t, publisher.events)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, replay_entity.ReplayFileStatusPending, replayFile.Status)
+				assert.Len(t, publisher.events, 1)
+				assert.Equal(t, replayFile.ID, publisher.events[0].ReplayFileID)
+			}
+
+			// Pending is stored before the event is published
+			assert.Equal(t, replay_entity.ReplayFileStatusPending, store.updates[0].Status)
+
+			for _, f := range store.files {
+				assert.Equal(t, tt.expectedStatus, f.Status)
+			}
+		})
+	}
+}
+
+func TestProcessUploadedReplayFileUseCase_Exec(t *testing.T) {
+	tests := []struct {
+		name            string
+		status          replay_entity.ReplayFileStatus
+		attempt         int
+		processErr      error
+		expectedStatus  replay_entity.ReplayFileStatus
+		expectedProcess int
+	}{
+		{"Processed", replay_entity.ReplayFileStatusPending, 1, nil, replay_entity.ReplayFileStatusPending, 1},
+		{"Already Completed", replay_entity.ReplayFileStatusCompleted, 2, nil, replay_entity.ReplayFileStatusCompleted, 0},
+		{"Quarantined", replay_entity.ReplayFileStatusQuarantined, 2, nil, replay_entity.ReplayFileStatusQuarantined, 0},
+		{"Failed Attempt", replay_entity.ReplayFileStatusPending, 1, errors.New("corrupted demo"), replay_entity.ReplayFileStatusPending, 1},
+		{"Last Attempt", replay_entity.ReplayFileStatusPending, use_cases.MaxReplayProcessingAttempts, errors.New("corrupted demo"), replay_entity.ReplayFileStatusFailed, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			replayFile := replay_entity.ReplayFile{ID: uuid.New(), Status: tt.status}
+			store := newMockReplayFileStore(replayFile)
+			process := &mockProcessCommand{err: tt.processErr}
+
+			usecase := use_cases.NewProcessUploadedReplayFileUseCase(store, store, process, &mockUpdateHeaderCommand{})
+
+			_, err := usecase.Exec(context.Background(), replay_entity.ReplayFileUploaded{ReplayFileID: replayFile.ID, Attempt: tt.attempt})
+
+			if tt.processErr != nil {
+				assert.ErrorIs(t, err, tt.processErr)
+				assert.Equal(t, tt.processErr.Error(), store.files[replayFile.ID].Error)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			assert.Equal(t, tt.expectedProcess, process.calls)
+			assert.Equal(t, tt.expectedStatus, store.files[replayFile.ID].Status)
+		})
+	}
+}
+
+func TestRecordReplayParseCrashUseCase_Exec(t *testing.T) {
+	replayFile := replay_entity.ReplayFile{ID: uuid.New(), Status: replay_entity.ReplayFileStatusProcessing}
+	store := newMockReplayFileStore(replayFile)
+	publisher := &mockProgressPublisher{}
+
+	usecase := use_cases.NewRecordReplayParseCrashUseCase(store, store, publisher)
+
+	crashed, err := usecase.Exec(context.Background(), replayFile.ID, replay_entity.ParseFailure{Attempt: 1, Reason: replay_entity.ParseFailureOutOfMemory, ExitCode: 2})
+
+	assert.NoError(t, err)
+	assert.Equal(t, replay_entity.ReplayFileStatusPending, crashed.Status)
+	assert.Equal(t, "replay parser out_of_memory (attempt 1)", crashed.Error)
+	assert.Len(t, crashed.ParseFailures, 1)
+
+	// killed the parser again: no more attempts
+	crashed, err = usecase.Exec(context.Background(), replayFile.ID, replay_entity.ParseFailure{Attempt: 2, Reason: replay_entity.ParseFailureCrashed, ExitCode: -1, Signal: "segmentation fault"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, replay_entity.ReplayFileStatusQuarantined, crashed.Status)
+	assert.Len(t, crashed.ParseFailures, replay_entity.MaxReplayParseCrashes)
+	assert.Equal(t, replay_entity.ReplayFileStatusQuarantined, store.files[replayFile.ID].Status)
+
+	assert.Len(t, publisher.published, 2)
+	assert.Equal(t, replay_entity.ReplayFileStatusQuarantined, publisher.published[1].Status)
+}
+
+func TestReprocessReplayFileUseCase_Exec(t *testing.T) {
+	ctx := common.WithResourceOwner(context.Background(), common.ResourceOwner{TenantID: common.TeamPROTenantID, ClientID: common.ServerClientID})
+
+	tests := []struct {
+		name          string
+		status        replay_entity.ReplayFileStatus
+		tenantID      uuid.UUID
+		publishErr    error
+		expectedErr   bool
+		expectedQueue int
+		expected      replay_entity.ReplayFileStatus
+	}{
+		{name: "Quarantined", status: replay_entity.ReplayFileStatusQuarantined, tenantID: common.TeamPROTenantID, expectedQueue: 1, expected: replay_entity.ReplayFileStatusPending},
+		{name: "Failed", status: replay_entity.ReplayFileStatusFailed, tenantID: common.TeamPROTenantID, expectedQueue: 1, expected: replay_entity.ReplayFileStatusPending},
+		{name: "Completed", status: replay_entity.ReplayFileStatusCompleted, tenantID: common.TeamPROTenantID, expectedErr: true, expected: replay_entity.ReplayFileStatusCompleted},
+		{name: "Processing", status: replay_entity.ReplayFileStatusProcessing, tenantID: common.TeamPROTenantID, expectedErr: true, expected: replay_entity.ReplayFileStatusProcessing},
+		{name: "Another Tenant", status: replay_entity.ReplayFileStatusFailed, tenantID: uuid.New(), expectedErr: true, expected: replay_entity.ReplayFileStatusFailed},
+		{name: "Broker Unavailable", status: replay_entity.ReplayFileStatusFailed, tenantID: common.TeamPROTenantID, publishErr: errors.New("broker unavailable"), expectedErr: true, expected: replay_entity.ReplayFileStatusFailed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			replayFile := replay_entity.ReplayFile{
+				ID:            uuid.New(),
+				GameID:        common.CS2_GAME_ID,
+				Status:        tt.status,
+				Error:         "replay parser crashed",
+				ParseFailures: []replay_entity.ParseFailure{{Attempt: 1, Reason: replay_entity.ParseFailureCrashed}},
+				ResourceOwner: common.ResourceOwner{TenantID: tt.tenantID},
+			}
+
+			store := newMockReplayFileStore(replayFile)
+			publisher := &mockEventPublisher{err: tt.publishErr}
+
+			usecase := use_cases.NewReprocessReplayFileUseCase(store, store, publisher)
+
+			requeued, err := usecase.Exec(ctx, replayFile.ID)
+
+			assert.Equal(t, tt.expectedErr, err != nil)
+			assert.Len(t, publisher.events, tt.expectedQueue)
+			assert.Equal(t, tt.expected, store.files[replayFile.ID].Status)
+
+			if !tt.expectedErr {
+				assert.Empty(t, requeued.Error)
+				assert.Empty(t, requeued.ParseFailures)
+				assert.Equal(t, replayFile.ID, publisher.events[0].ReplayFileID)
+			}
+		})
+	}
+}
@@ -0,0 +1,120 @@
+package use_cases
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"sync/atomic"
+	"time"
+
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	e "github.com/psavelis/team-pro/replay-api/pkg/domain/replay/entities"
+	replay_out "github.com/psavelis/team-pro/replay-api/pkg/domain/replay/ports/out"
+)
+
+// progress is published at most this often while a replay file is parsed
+const ProgressInterval = time.Second
+
+// replayProgressTracker counts the content read and the events extracted by the parser, and publishes them as progress. Counters
+// are updated by the parser and event goroutines.
+type replayProgressTracker struct {
+	publisher  replay_out.ReplayProcessingProgressPublisher
+	replayFile *e.ReplayFile
+
+	bytesRead atomic.Int64
+	events    atomic.Int64
+	rounds    atomic.Int64
+}
+
+func newReplayProgressTracker(publisher replay_out.ReplayProcessingProgressPublisher, replayFile *e.ReplayFile) *replayProgressTracker {
+	return &replayProgressTracker{
+		publisher:  publisher,
+		replayFile: replayFile,
+	}
+}
+
+func (t *replayProgressTracker) Reader(content io.Reader) io.Reader {
+	return &progressReader{Reader: content, bytesRead: &t.bytesRead}
+}
+
+func (t *replayProgressTracker) Extracted(event *e.GameEvent) {
+	t.events.Add(1)
+
+	// the MVP is announced once per round
+	if event.Type == common.Event_RoundMVPAnnouncementID {
+		t.rounds.Add(1)
+	}
+}
+
+func (t *replayProgressTracker) Publish(ctx context.Context, status e.ReplayFileStatus) {
+	if t.publisher == nil {
+		return
+	}
+
+	progress := e.ReplayProcessingProgress{
+		ReplayFileID:    t.replayFile.ID,
+		Status:          status,
+		EventsExtracted: int(t.events.Load()),
+		CurrentRound:    int(t.rounds.Load()) + 1,
+		UpdatedAt:       time.Now().UTC(),
+	}
+
+	switch {
+	case status == e.ReplayFileStatusCompleted:
+		progress.Percent = 100
+		progress.CurrentRound = int(t.rounds.Load())
+	case t.replayFile.Size > 0:
+		// the parser reads ahead: capped below 100 until the replay file is completed
+		progress.Percent = min(99, float64(t.bytesRead.Load())*100/float64(t.replayFile.Size))
+	}
+
+	err := t.publisher.PublishProgress(ctx, progress)
+	if err != nil {
+		slog.WarnContext(ctx, "unable to publish replay processing progress", "replayFileID", t.replayFile.ID, "status", status, "err", err)
+	}
+}
+
+// PublishEvery publishes the Processing progress on every interval until the returned func is called.
+func (t *replayProgressTracker) PublishEvery(ctx context.Context, interval time.Duration) func() {
+	if t.publisher == nil {
+		return func() {}
+	}
+
+	done := make(chan struct{})
+	stopped := make(chan struct{})
+
+	go func() {
+		defer close(stopped)
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-done:
+				return
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				t.Publish(ctx, e.ReplayFileStatusProcessing)
+			}
+		}
+	}()
+
+	return func() {
+		close(done)
+		<-stopped
+	}
+}
+
+type progressReader struct {
+	io.Reader
+	bytesRead *atomic.Int64
+}
+
+func (r *progressReader) Read(p []byte) (int, error) {
+	n, err := r.Reader.Read(p)
+	r.bytesRead.Add(int64(n))
+
+	return n, err
+}
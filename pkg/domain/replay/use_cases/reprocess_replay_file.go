@@ -0,0 +1,84 @@
+package use_cases
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	"github.com/psavelis/team-pro/replay-api/pkg/domain/replay"
+	replay_entity "github.com/psavelis/team-pro/replay-api/pkg/domain/replay/entities"
+	replay_in "github.com/psavelis/team-pro/replay-api/pkg/domain/replay/ports/in"
+	replay_out "github.com/psavelis/team-pro/replay-api/pkg/domain/replay/ports/out"
+)
+
+type ReprocessReplayFileUseCase struct {
+	MetadataReader replay_out.ReplayFileMetadataReader
+	MetadataWriter replay_out.ReplayFileMetadataWriter
+	EventPublisher replay_out.ReplayFileEventPublisher
+}
+
+func NewReprocessReplayFileUseCase(metadataReader replay_out.ReplayFileMetadataReader, metadataWriter replay_out.ReplayFileMetadataWriter, eventPublisher replay_out.ReplayFileEventPublisher) replay_in.ReprocessReplayFileCommand {
+	return &ReprocessReplayFileUseCase{
+		MetadataReader: metadataReader,
+		MetadataWriter: metadataWriter,
+		EventPublisher: eventPublisher,
+	}
+}
+
+func (usecase *ReprocessReplayFileUseCase) Exec(ctx context.Context, replayFileID uuid.UUID) (*replay_entity.ReplayFile, error) {
+	replayFile, err := usecase.MetadataReader.GetByID(ctx, replayFileID)
+	if err != nil || replayFile == nil {
+		slog.ErrorContext(ctx, "unable to get replay file to reprocess", "replayFileID", replayFileID, "err", err)
+		return nil, replay.NewReplayFileNotFoundError(replayFileID)
+	}
+
+	if replayFile.ResourceOwner.TenantID != common.GetResourceOwner(ctx).TenantID {
+		slog.WarnContext(ctx, "reprocessing requested for a replay file of another tenant", "replayFileID", replayFileID)
+		return nil, replay.NewReplayFileNotFoundError(replayFileID)
+	}
+
+	// processing creates a new match: a Completed replay file would be duplicated, one being processed would be processed twice
+	switch replayFile.Status {
+	case replay_entity.ReplayFileStatusFailed, replay_entity.ReplayFileStatusQuarantined, replay_entity.ReplayFileStatusPending:
+	default:
+		return nil, replay.NewReplayFileStateError(fmt.Sprintf("replay file %s can't be reprocessed (status '%s')", replayFileID, replayFile.Status))
+	}
+
+	previousStatus := replayFile.Status
+
+	// Pending is saved before publishing, as on submit (the worker skips Quarantined replay files)
+	replayFile.Status = replay_entity.ReplayFileStatusPending
+	replayFile.Error = ""
+	replayFile.ParseFailures = nil
+
+	replayFile, err = usecase.MetadataWriter.Update(ctx, replayFile)
+	if err != nil {
+		slog.ErrorContext(ctx, "error updating replay file status to Pending for reprocessing", "replayFileID", replayFileID, "err", err)
+		return nil, err
+	}
+
+	err = usecase.EventPublisher.PublishUploaded(ctx, replay_entity.ReplayFileUploaded{
+		ReplayFileID:  replayFile.ID,
+		GameID:        replayFile.GameID,
+		Size:          replayFile.Size,
+		ResourceOwner: replayFile.ResourceOwner,
+		UploadedAt:    time.Now().UTC(),
+	})
+
+	if err != nil {
+		slog.ErrorContext(ctx, "error queueing replay file for reprocessing", "replayFileID", replayFileID, "err", err)
+
+		replayFile.Status = replay_entity.ReplayFileStatusFailed
+		replayFile.Error = err.Error()
+		usecase.MetadataWriter.Update(ctx, replayFile)
+
+		return nil, err
+	}
+
+	slog.InfoContext(ctx, "replay file queued for reprocessing", "replayFileID", replayFileID, "previousStatus", previousStatus)
+
+	return replayFile, nil
+}
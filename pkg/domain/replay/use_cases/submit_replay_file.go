@@ -0,0 +1,64 @@
+package use_cases
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"time"
+
+	replay_entity "github.com/psavelis/team-pro/replay-api/pkg/domain/replay/entities"
+	replay_in "github.com/psavelis/team-pro/replay-api/pkg/domain/replay/ports/in"
+	replay_out "github.com/psavelis/team-pro/replay-api/pkg/domain/replay/ports/out"
+)
+
+type SubmitReplayFileUseCase struct {
+	UploadCommand  replay_in.UploadReplayFileCommand
+	MetadataWriter replay_out.ReplayFileMetadataWriter
+	EventPublisher replay_out.ReplayFileEventPublisher
+}
+
+func NewSubmitReplayFileUseCase(uploadCommand replay_in.UploadReplayFileCommand, metadataWriter replay_out.ReplayFileMetadataWriter, eventPublisher replay_out.ReplayFileEventPublisher) *SubmitReplayFileUseCase {
+	return &SubmitReplayFileUseCase{
+		UploadCommand:  uploadCommand,
+		MetadataWriter: metadataWriter,
+		EventPublisher: eventPublisher,
+	}
+}
+
+func (usecase *SubmitReplayFileUseCase) Exec(ctx context.Context, file io.Reader) (*replay_entity.ReplayFile, error) {
+	replayFile, err := usecase.UploadCommand.Exec(ctx, file)
+	if err != nil {
+		slog.ErrorContext(ctx, "error uploading replay file", "err", err)
+		return nil, err
+	}
+
+	// Pending is saved before publishing: a fast worker could otherwise complete the file before this update overwrites it
+	replayFile.Status = replay_entity.ReplayFileStatusPending
+	replayFile, err = usecase.MetadataWriter.Update(ctx, replayFile)
+	if err != nil {
+		slog.ErrorContext(ctx, "error updating replay file status to Pending", "err", err)
+		return nil, err
+	}
+
+	err = usecase.EventPublisher.PublishUploaded(ctx, replay_entity.ReplayFileUploaded{
+		ReplayFileID:  replayFile.ID,
+		GameID:        replayFile.GameID,
+		Size:          replayFile.Size,
+		ResourceOwner: replayFile.ResourceOwner,
+		UploadedAt:    time.Now().UTC(),
+	})
+
+	if err != nil {
+		slog.ErrorContext(ctx, "error queueing replay file for processing", "replayFileID", replayFile.ID, "err", err)
+
+		replayFile.Status = replay_entity.ReplayFileStatusFailed
+		replayFile.Error = err.Error()
+		usecase.MetadataWriter.Update(ctx, replayFile)
+
+		return nil, err
+	}
+
+	slog.InfoContext(ctx, "replay file queued for processing", "replayFileID", replayFile.ID)
+
+	return replayFile, nil
+}
@@ -0,0 +1,46 @@
+package use_cases
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/google/uuid"
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	"github.com/psavelis/team-pro/replay-api/pkg/domain/replay"
+	replay_entity "github.com/psavelis/team-pro/replay-api/pkg/domain/replay/entities"
+	replay_out "github.com/psavelis/team-pro/replay-api/pkg/domain/replay/ports/out"
+)
+
+type SubscribeReplayProcessingProgressUseCase struct {
+	MetadataReader replay_out.ReplayFileMetadataReader
+	ProgressStream replay_out.ReplayProcessingProgressStream
+}
+
+func NewSubscribeReplayProcessingProgressUseCase(metadataReader replay_out.ReplayFileMetadataReader, progressStream replay_out.ReplayProcessingProgressStream) *SubscribeReplayProcessingProgressUseCase {
+	return &SubscribeReplayProcessingProgressUseCase{
+		MetadataReader: metadataReader,
+		ProgressStream: progressStream,
+	}
+}
+
+func (usecase *SubscribeReplayProcessingProgressUseCase) Subscribe(ctx context.Context, replayFileID uuid.UUID, lastEventID uint64) (*replay_entity.ReplayProcessingProgress, <-chan replay_entity.ReplayProcessingProgress, func(), error) {
+	// subscribed before reading the replay file: an update published in between is delivered rather than lost
+	updates, cancel := usecase.ProgressStream.Subscribe(ctx, replayFileID, lastEventID)
+
+	replayFile, err := usecase.MetadataReader.GetByID(ctx, replayFileID)
+	if err != nil || replayFile == nil {
+		cancel()
+		slog.ErrorContext(ctx, "unable to get replay file", "replayFileID", replayFileID, "err", err)
+		return nil, nil, nil, replay.NewReplayFileNotFoundError(replayFileID)
+	}
+
+	if replayFile.ResourceOwner.TenantID != common.GetResourceOwner(ctx).TenantID {
+		cancel()
+		slog.WarnContext(ctx, "replay file progress requested from another tenant", "replayFileID", replayFileID)
+		return nil, nil, nil, replay.NewReplayFileNotFoundError(replayFileID)
+	}
+
+	current := replay_entity.NewReplayProcessingProgress(*replayFile)
+
+	return &current, updates, cancel, nil
+}
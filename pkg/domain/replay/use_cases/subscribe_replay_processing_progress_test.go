@@ -0,0 +1,77 @@
+package use_cases_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	"github.com/psavelis/team-pro/replay-api/pkg/domain/replay"
+	replay_entity "github.com/psavelis/team-pro/replay-api/pkg/domain/replay/entities"
+	"github.com/psavelis/team-pro/replay-api/pkg/domain/replay/use_cases"
+	"github.com/stretchr/testify/assert"
+)
+
+type mockProgressStream struct {
+	subscribed int
+	cancelled  int
+}
+
+func (m *mockProgressStream) Subscribe(ctx context.Context, replayFileID uuid.UUID, lastEventID uint64) (<-chan replay_entity.ReplayProcessingProgress, func()) {
+	m.subscribed++
+
+	return make(chan replay_entity.ReplayProcessingProgress), func() { m.cancelled++ }
+}
+
+func TestSubscribeReplayProcessingProgressUseCase_Subscribe(t *testing.T) {
+	owner := common.ResourceOwner{TenantID: common.TeamPROTenantID, ClientID: common.TeamPROAppClientID, UserID: uuid.New()}
+
+	processing := replay_entity.ReplayFile{ID: uuid.New(), Status: replay_entity.ReplayFileStatusProcessing, ResourceOwner: owner}
+	completed := replay_entity.ReplayFile{ID: uuid.New(), Status: replay_entity.ReplayFileStatusCompleted, ResourceOwner: owner}
+	otherTenant := replay_entity.ReplayFile{ID: uuid.New(), Status: replay_entity.ReplayFileStatusProcessing, ResourceOwner: common.ResourceOwner{TenantID: uuid.New()}}
+
+	tests := []struct {
+		name            string
+		replayFileID    uuid.UUID
+		expectedStatus  replay_entity.ReplayFileStatus
+		expectedPercent float64
+		expectedErr     bool
+	}{
+		{"Processing", processing.ID, replay_entity.ReplayFileStatusProcessing, 0, false},
+		{"Completed", completed.ID, replay_entity.ReplayFileStatusCompleted, 100, false},
+		{"Not Found", uuid.New(), "", 0, true},
+		{"Other Tenant", otherTenant.ID, "", 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stream := &mockProgressStream{}
+			usecase := use_cases.NewSubscribeReplayProcessingProgressUseCase(newMockReplayFileStore(processing, completed, otherTenant), stream)
+
+			current, updates, cancel, err := usecase.Subscribe(common.WithResourceOwner(context.Background(), owner), tt.replayFileID, 0)
+
+			assert.Equal(t, 1, stream.subscribed)
+
+			if tt.expectedErr {
+				var notFoundErr *replay.ReplayFileNotFoundError
+				assert.True(t, errors.As(err, &notFoundErr))
+				assert.Nil(t, current)
+				assert.Nil(t, updates)
+
+				// the subscription isn't leaked
+				assert.Equal(t, 1, stream.cancelled)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.NotNil(t, updates)
+			assert.Equal(t, tt.replayFileID, current.ReplayFileID)
+			assert.Equal(t, tt.expectedStatus, current.Status)
+			assert.Equal(t, tt.expectedPercent, current.Percent)
+
+			cancel()
+			assert.Equal(t, 1, stream.cancelled)
+		})
+	}
+}
@@ -0,0 +1,124 @@
+package use_cases
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	replay_entity "github.com/psavelis/team-pro/replay-api/pkg/domain/replay/entities"
+	replay_in "github.com/psavelis/team-pro/replay-api/pkg/domain/replay/ports/in"
+	replay_out "github.com/psavelis/team-pro/replay-api/pkg/domain/replay/ports/out"
+)
+
+// PlayerProfileSyncBatchSize bounds the players synced per run (each costs a few requests to every external API).
+const PlayerProfileSyncBatchSize = 100
+
+type SyncPlayerProfilesUseCase struct {
+	PlayerReader  replay_out.PlayerMetadataReader
+	PlayerWriter  replay_out.PlayerMetadataWriter
+	HistoryWriter replay_out.PlayerProfileHistoryWriter
+	Fetchers      []replay_out.ExternalStatsFetcher
+}
+
+func NewSyncPlayerProfilesUseCase(playerReader replay_out.PlayerMetadataReader, playerWriter replay_out.PlayerMetadataWriter, historyWriter replay_out.PlayerProfileHistoryWriter, fetchers []replay_out.ExternalStatsFetcher) replay_in.SyncPlayerProfilesCommand {
+	return &SyncPlayerProfilesUseCase{
+		PlayerReader:  playerReader,
+		PlayerWriter:  playerWriter,
+		HistoryWriter: historyWriter,
+		Fetchers:      fetchers,
+	}
+}
+
+func (usecase *SyncPlayerProfilesUseCase) Exec(ctx context.Context, staleBefore time.Time) (int, error) {
+	if len(usecase.Fetchers) == 0 {
+		return 0, nil
+	}
+
+	// never synced first, then the least recently synced
+	options := common.NewSearchResultOptions(0, PlayerProfileSyncBatchSize)
+	options.Sort = []common.SearchSortOption{{Field: "StatsSyncedAt", Direction: common.AscendingIDKey}}
+
+	players, err := usecase.PlayerReader.Search(ctx, common.NewSearchByAggregation(ctx, []common.SearchAggregation{
+		{
+			Params: []common.SearchParameter{
+				{
+					ValueParams: []common.SearchableValue{
+						{Field: "NetworkID", Values: []interface{}{common.SteamNetworkIDKey, common.RiotNetworkIDKey}, Operator: common.InOperator},
+					},
+				},
+				{
+					ValueParams: []common.SearchableValue{
+						{Field: "StatsSyncedAt", Values: []interface{}{staleBefore}, Operator: common.LessThanOrEqualOperator},
+						{Field: "StatsSyncedAt", Values: []interface{}{false}, Operator: common.ExistsOperator},
+					},
+					AggregationClause: common.OrAggregationClause,
+				},
+			},
+		},
+	}, options, common.ClientApplicationAudienceIDKey))
+
+	if err != nil {
+		slog.ErrorContext(ctx, "unable to search players to sync", "err", err, "staleBefore", staleBefore)
+		return 0, err
+	}
+
+	synced := 0
+
+	for i := range players {
+		player := &players[i]
+		now := time.Now().UTC()
+
+		player.SetExternalStats(usecase.fetchStats(ctx, *player, now), now)
+
+		if len(player.ExternalStats) > 0 {
+			_, err = usecase.HistoryWriter.Create(ctx, replay_entity.NewPlayerProfileHistory(*player, now))
+			if err != nil {
+				slog.ErrorContext(ctx, "unable to create player profile history", "err", err, "playerID", player.ID)
+				return synced, err
+			}
+		}
+
+		err = usecase.PlayerWriter.UpdateExternalStats(ctx, player)
+		if err != nil {
+			slog.ErrorContext(ctx, "unable to update player external stats", "err", err, "playerID", player.ID)
+			return synced, err
+		}
+
+		synced++
+	}
+
+	return synced, nil
+}
+
+// fetchStats reads the stats of the player from every source, keeping the previous stats of the sources that failed (until the
+// next sync) and dropping the ones the player has no account on.
+func (usecase *SyncPlayerProfilesUseCase) fetchStats(ctx context.Context, player replay_entity.Player, now time.Time) []replay_entity.ExternalStats {
+	accounts := player.ExternalAccounts()
+	stats := make([]replay_entity.ExternalStats, 0, len(usecase.Fetchers))
+
+	for _, fetcher := range usecase.Fetchers {
+		s, err := fetcher.FetchStats(ctx, accounts)
+		if err != nil {
+			slog.WarnContext(ctx, "unable to fetch player external stats", "err", err, "playerID", player.ID, "source", fetcher.Source())
+
+			for _, previous := range player.ExternalStats {
+				if previous.Source == fetcher.Source() {
+					stats = append(stats, previous)
+				}
+			}
+
+			continue
+		}
+
+		if s == nil {
+			continue
+		}
+
+		s.Source = fetcher.Source()
+		s.SyncedAt = now
+		stats = append(stats, *s)
+	}
+
+	return stats
+}
@@ -0,0 +1,149 @@
+package use_cases_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	replay_entity "github.com/psavelis/team-pro/replay-api/pkg/domain/replay/entities"
+	replay_out "github.com/psavelis/team-pro/replay-api/pkg/domain/replay/ports/out"
+	"github.com/psavelis/team-pro/replay-api/pkg/domain/replay/use_cases"
+	"github.com/stretchr/testify/assert"
+)
+
+// mockPlayerProfileStore returns every player to sync, keeping the updates and the snapshots.
+type mockPlayerProfileStore struct {
+	players   []replay_entity.Player
+	updated   []replay_entity.Player
+	snapshots []replay_entity.PlayerProfileHistory
+}
+
+func (m *mockPlayerProfileStore) Search(ctx context.Context, s common.Search) ([]replay_entity.Player, error) {
+	return m.players, nil
+}
+
+func (m *mockPlayerProfileStore) Compile(ctx context.Context, searchParams []common.SearchAggregation, resultOptions common.SearchResultOptions) (*common.Search, error) {
+	return nil, nil
+}
+
+func (m *mockPlayerProfileStore) CreateMany(createCtx context.Context, players []interface{}) error {
+	return nil
+}
+
+func (m *mockPlayerProfileStore) UpdateExternalStats(ctx context.Context, player *replay_entity.Player) error {
+	m.updated = append(m.updated, *player)
+	return nil
+}
+
+func (m *mockPlayerProfileStore) Create(createCtx context.Context, snapshot *replay_entity.PlayerProfileHistory) (*replay_entity.PlayerProfileHistory, error) {
+	m.snapshots = append(m.snapshots, *snapshot)
+	return snapshot, nil
+}
+
+// mockStatsFetcher answers the stats of the accounts it knows (by SteamID or PUUID), or its error.
+type mockStatsFetcher struct {
+	source replay_entity.ExternalStatsSource
+	stats  map[string]replay_entity.ExternalStats
+	err    error
+}
+
+func (m *mockStatsFetcher) Source() replay_entity.ExternalStatsSource {
+	return m.source
+}
+
+func (m *mockStatsFetcher) FetchStats(ctx context.Context, accounts replay_entity.ExternalAccounts) (*replay_entity.ExternalStats, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+
+	for _, id := range []string{accounts.SteamID, accounts.RiotPUUID} {
+		if s, ok := m.stats[id]; ok && id != "" {
+			return &s, nil
+		}
+	}
+
+	return nil, nil
+}
+
+func TestSyncPlayerProfiles(t *testing.T) {
+	previousSync := time.Now().Add(-48 * time.Hour)
+
+	csPlayer := replay_entity.Player{
+		ID:            common.PlayerIDType(uuid.New()),
+		NetworkID:     common.SteamNetworkIDKey,
+		NetworkUserID: "76561198000000001",
+		ExternalStats: []replay_entity.ExternalStats{
+			{Source: replay_entity.FaceitStatsSource, Elo: 1800, SkillLevel: 8, SyncedAt: previousSync},
+		},
+		StatsSyncedAt: &previousSync,
+	}
+
+	valorantPlayer := replay_entity.Player{
+		ID:            common.PlayerIDType(uuid.New()),
+		NetworkID:     common.RiotNetworkIDKey,
+		NetworkUserID: "puuid-1",
+	}
+
+	unlinkedPlayer := replay_entity.Player{
+		ID:            common.PlayerIDType(uuid.New()),
+		NetworkID:     common.SteamNetworkIDKey,
+		NetworkUserID: "76561198000000002",
+	}
+
+	store := &mockPlayerProfileStore{players: []replay_entity.Player{csPlayer, valorantPlayer, unlinkedPlayer}}
+
+	steam := &mockStatsFetcher{source: replay_entity.SteamStatsSource, stats: map[string]replay_entity.ExternalStats{
+		"76561198000000001": {HoursPlayed: 1520.5},
+	}}
+
+	faceit := &mockStatsFetcher{source: replay_entity.FaceitStatsSource, err: errors.New("faceit api answered 503")}
+
+	riot := &mockStatsFetcher{source: replay_entity.RiotStatsSource, stats: map[string]replay_entity.ExternalStats{
+		"puuid-1": {RankTier: 20},
+	}}
+
+	usecase := use_cases.NewSyncPlayerProfilesUseCase(store, store, store, []replay_out.ExternalStatsFetcher{steam, faceit, riot})
+
+	ctx := common.WithResourceOwner(context.Background(), common.ResourceOwner{TenantID: common.TeamPROTenantID, ClientID: common.ServerClientID})
+
+	synced, err := usecase.Exec(ctx, time.Now().Add(-24*time.Hour))
+	if !assert.NoError(t, err) || !assert.Len(t, store.updated, 3) {
+		return
+	}
+
+	assert.Equal(t, 3, synced)
+
+	// steam hours are refreshed, the FACEIT elo is kept while their API is down
+	cs := store.updated[0]
+	if assert.Len(t, cs.ExternalStats, 2) {
+		assert.Equal(t, replay_entity.SteamStatsSource, cs.ExternalStats[0].Source)
+		assert.Equal(t, 1520.5, cs.ExternalStats[0].HoursPlayed)
+		assert.Equal(t, previousSync, cs.ExternalStats[1].SyncedAt)
+	}
+
+	assert.Equal(t, []replay_entity.RankBadge{{Source: replay_entity.FaceitStatsSource, Label: "FACEIT Level 8", Tier: 8, Value: 1800}}, cs.RankBadges)
+	assert.True(t, cs.StatsSyncedAt.After(previousSync))
+
+	assert.Equal(t, []replay_entity.RankBadge{{Source: replay_entity.RiotStatsSource, Label: "Diamond 3", Tier: 20}}, store.updated[1].RankBadges)
+
+	// players without stats are marked as synced, without snapshots
+	assert.Empty(t, store.updated[2].ExternalStats)
+	assert.NotNil(t, store.updated[2].StatsSyncedAt)
+
+	if assert.Len(t, store.snapshots, 2) {
+		assert.Equal(t, csPlayer.ID, store.snapshots[0].PlayerID)
+		assert.Equal(t, valorantPlayer.ID, store.snapshots[1].PlayerID)
+		assert.Equal(t, "Diamond 3", store.snapshots[1].RankBadges[0].Label)
+	}
+}
+
+func TestValorantRankName(t *testing.T) {
+	assert.Equal(t, "", replay_entity.ValorantRankName(0))
+	assert.Equal(t, "Iron 1", replay_entity.ValorantRankName(3))
+	assert.Equal(t, "Gold 2", replay_entity.ValorantRankName(13))
+	assert.Equal(t, "Immortal 3", replay_entity.ValorantRankName(26))
+	assert.Equal(t, "Radiant", replay_entity.ValorantRankName(27))
+}
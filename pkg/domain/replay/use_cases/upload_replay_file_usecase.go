@@ -6,7 +6,9 @@ import (
 	"io"
 	"log/slog"
 
+	"github.com/google/uuid"
 	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	iam_out "github.com/psavelis/team-pro/replay-api/pkg/domain/iam/ports/out"
 	replay_entity "github.com/psavelis/team-pro/replay-api/pkg/domain/replay/entities"
 	replay_out "github.com/psavelis/team-pro/replay-api/pkg/domain/replay/ports/out"
 )
@@ -14,12 +16,16 @@ import (
 type UploadReplayFileUseCase struct {
 	MetadataWriter replay_out.ReplayFileMetadataWriter
 	ContentWriter  replay_out.ReplayFileContentWriter
+	PolicyReader   iam_out.VisibilityPolicyReader
+	OverrideWriter iam_out.VisibilityOverrideWriter
 }
 
-func NewUploadReplayFileUseCase(metadataWriter replay_out.ReplayFileMetadataWriter, dataCommand replay_out.ReplayFileContentWriter) *UploadReplayFileUseCase {
+func NewUploadReplayFileUseCase(metadataWriter replay_out.ReplayFileMetadataWriter, dataCommand replay_out.ReplayFileContentWriter, policyReader iam_out.VisibilityPolicyReader, overrideWriter iam_out.VisibilityOverrideWriter) *UploadReplayFileUseCase {
 	return &UploadReplayFileUseCase{
 		MetadataWriter: metadataWriter,
 		ContentWriter:  dataCommand,
+		PolicyReader:   policyReader,
+		OverrideWriter: overrideWriter,
 	}
 }
 
@@ -32,8 +38,21 @@ func (usecase *UploadReplayFileUseCase) Exec(ctx context.Context, reader io.Read
 
 	slog.InfoContext(ctx, "uploading replay file", "size", len(file))
 
+	reso := common.GetResourceOwner(ctx)
+
+	policies, err := usecase.PolicyReader.Search(ctx, common.NewSearchByValues(ctx, []common.SearchableValue{
+		{Field: "GroupID", Values: []interface{}{uuid.Nil, reso.GroupID}},
+	}, common.NewSearchResultOptions(0, 2), common.ClientApplicationAudienceIDKey))
+
+	if err != nil {
+		slog.ErrorContext(ctx, "error searching visibility policies", "err", err)
+		return nil, err
+	}
+
+	requested, _ := ctx.Value(common.VisibilityParamKey).(common.Visibility)
+
 	// create Metadata
-	entity := replay_entity.NewReplayFile("cs", "steam", len(file), "", common.GetResourceOwner(ctx))
+	entity, override := replay_entity.NewReplayFile("cs", "steam", len(file), "", reso, policies, requested)
 	replayFile, err := usecase.MetadataWriter.Create(ctx, entity)
 
 	if err != nil {
@@ -41,6 +60,14 @@ func (usecase *UploadReplayFileUseCase) Exec(ctx context.Context, reader io.Read
 		return nil, err
 	}
 
+	// the replay file is already created: a failed audit is logged, not retried
+	if override != nil {
+		_, err = usecase.OverrideWriter.Create(ctx, override)
+		if err != nil {
+			slog.ErrorContext(ctx, "error auditing visibility override", "replayFileID", replayFile.ID, "requested", override.Requested, "default", override.Default, "err", err)
+		}
+	}
+
 	slog.InfoContext(ctx, "created new replay metadata", "replayFile", replayFile)
 
 	// Put Contents into Blob Store
@@ -0,0 +1,84 @@
+package common
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// ReplayPlatform is where a match was played, as detected from its replay.
+type ReplayPlatform string
+
+const (
+	ReplayPlatformValveMM   ReplayPlatform = "valve_mm" // Valve matchmaking (official servers)
+	ReplayPlatformFaceit    ReplayPlatform = "faceit"
+	ReplayPlatformLAN       ReplayPlatform = "lan"
+	ReplayPlatformCommunity ReplayPlatform = "community" // any other server (ie: community or private servers)
+	ReplayPlatformUnknown   ReplayPlatform = "unknown"   // the replay has no server name
+)
+
+var ReplayPlatforms = []ReplayPlatform{ReplayPlatformValveMM, ReplayPlatformFaceit, ReplayPlatformLAN, ReplayPlatformCommunity, ReplayPlatformUnknown}
+
+// ReplayTags are detected from the headers and network data of a replay while it's parsed, and tag its match (and the analytics of the
+// match) so they can be filtered by them. Fields not detected are left empty.
+type ReplayTags struct {
+	Platform       ReplayPlatform `json:"platform" bson:"platform"`
+	ServerRegion   RegionIDKey    `json:"server_region,omitempty" bson:"server_region,omitempty"`
+	ServerLocation string         `json:"server_location,omitempty" bson:"server_location,omitempty"` // datacenter, ie: "gru" (São Paulo)
+	Tickrate       int            `json:"tickrate,omitempty" bson:"tickrate,omitempty"`
+}
+
+// ReplayTagsFilter are the tags the matches (or their analytics) are filtered by, unset fields match any.
+type ReplayTagsFilter struct {
+	Platform     ReplayPlatform
+	ServerRegion RegionIDKey
+	Tickrate     int
+}
+
+// NewReplayTagsFilter parses the tags filtered (ie: from the query string), empty ones match any.
+func NewReplayTagsFilter(platform string, serverRegion string, tickrate string) (ReplayTagsFilter, error) {
+	f := ReplayTagsFilter{Platform: ReplayPlatform(platform), ServerRegion: RegionIDKey(serverRegion)}
+
+	if platform != "" && !IsReplayPlatform(f.Platform) {
+		return ReplayTagsFilter{}, fmt.Errorf("unknown platform %q (one of %v)", platform, ReplayPlatforms)
+	}
+
+	if tickrate != "" {
+		n, err := strconv.Atoi(tickrate)
+		if err != nil || n <= 0 {
+			return ReplayTagsFilter{}, fmt.Errorf("tickrate must be a positive number")
+		}
+
+		f.Tickrate = n
+	}
+
+	return f, nil
+}
+
+// SearchableValues of the tags filtered, for entities holding their ReplayTags in the field given (ie: "Tags").
+func (f ReplayTagsFilter) SearchableValues(field string) []SearchableValue {
+	values := make([]SearchableValue, 0, 3)
+
+	if f.Platform != "" {
+		values = append(values, SearchableValue{Field: field + ".Platform", Values: []interface{}{f.Platform}})
+	}
+
+	if f.ServerRegion != "" {
+		values = append(values, SearchableValue{Field: field + ".ServerRegion", Values: []interface{}{f.ServerRegion}})
+	}
+
+	if f.Tickrate != 0 {
+		values = append(values, SearchableValue{Field: field + ".Tickrate", Values: []interface{}{f.Tickrate}})
+	}
+
+	return values
+}
+
+func IsReplayPlatform(platform ReplayPlatform) bool {
+	for _, p := range ReplayPlatforms {
+		if p == platform {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,86 @@
+package common
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+// RequestScope is the tenancy of a request (the resource owner acting), its correlation ID and the client it came from. It's the only
+// carrier of these values in a context: the key is unexported, so they can't be set or read (or mistyped) other than through the helpers
+// below.
+type RequestScope struct {
+	ResourceOwner
+	RequestID string
+	UserAgent string // empty out of HTTP requests
+	ClientIP  string // empty out of HTTP requests
+
+	// Authenticated is set once the caller is verified: by its RID (an end user) or by its API key (a client application). The resource
+	// owner of anonymous requests is a placeholder (random group and user IDs).
+	Authenticated bool
+
+	// set when the caller authenticated with an API key: it acts with the permissions of the key (instead of the roles of a user)
+	APIKeyID          uuid.UUID
+	APIKeyPermissions []string
+	APIKeyTier        string // rate limit tier of the public API
+}
+
+type requestScopeKey struct{}
+
+// WithRequestScope sets the scope of the request, replacing any previous one.
+func WithRequestScope(ctx context.Context, scope RequestScope) context.Context {
+	return context.WithValue(ctx, requestScopeKey{}, scope)
+}
+
+// GetRequestScope returns the scope of the request, false when none was set.
+func GetRequestScope(ctx context.Context) (RequestScope, bool) {
+	scope, ok := ctx.Value(requestScopeKey{}).(RequestScope)
+
+	return scope, ok
+}
+
+// WithRequestID sets the correlation ID of the request, keeping its resource owner.
+func WithRequestID(ctx context.Context, requestID string) context.Context {
+	scope, _ := GetRequestScope(ctx)
+	scope.RequestID = requestID
+
+	return WithRequestScope(ctx, scope)
+}
+
+// WithAuthenticated marks the caller of the request as verified, keeping its resource owner.
+func WithAuthenticated(ctx context.Context) context.Context {
+	scope, _ := GetRequestScope(ctx)
+	scope.Authenticated = true
+
+	return WithRequestScope(ctx, scope)
+}
+
+// IsAuthenticatedUser reports whether the request is from an end user verified by its RID (neither anonymous nor a client
+// application).
+func IsAuthenticatedUser(ctx context.Context) bool {
+	scope, _ := GetRequestScope(ctx)
+
+	return scope.Authenticated && scope.UserID != uuid.Nil
+}
+
+// WithResourceOwner sets the (non empty) IDs of the resource owner in the scope of the request, ie: to act on behalf of the owner of a
+// queued message, or as the user of a verified RID.
+func WithResourceOwner(ctx context.Context, resourceOwner ResourceOwner) context.Context {
+	scope, _ := GetRequestScope(ctx)
+
+	for _, id := range []struct {
+		value  uuid.UUID
+		target *uuid.UUID
+	}{
+		{resourceOwner.TenantID, &scope.TenantID},
+		{resourceOwner.ClientID, &scope.ClientID},
+		{resourceOwner.GroupID, &scope.GroupID},
+		{resourceOwner.UserID, &scope.UserID},
+	} {
+		if id.value != uuid.Nil {
+			*id.target = id.value
+		}
+	}
+
+	return WithRequestScope(ctx, scope)
+}
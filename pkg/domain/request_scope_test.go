@@ -0,0 +1,61 @@
+package common_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithResourceOwner(t *testing.T) {
+	tenantID, clientID, groupID, userID := uuid.New(), uuid.New(), uuid.New(), uuid.New()
+
+	ctx := common.WithRequestScope(context.Background(), common.RequestScope{
+		ResourceOwner: common.ResourceOwner{TenantID: tenantID, ClientID: clientID, GroupID: uuid.New(), UserID: uuid.New()},
+		RequestID:     "req-1",
+	})
+
+	// acting as the user of a verified RID: the empty IDs keep the ones of the request
+	ctx = common.WithResourceOwner(ctx, common.ResourceOwner{GroupID: groupID, UserID: userID})
+
+	scope, ok := common.GetRequestScope(ctx)
+	assert.True(t, ok)
+	assert.Equal(t, common.ResourceOwner{TenantID: tenantID, ClientID: clientID, GroupID: groupID, UserID: userID}, scope.ResourceOwner)
+	assert.Equal(t, "req-1", scope.RequestID)
+	assert.Equal(t, scope.ResourceOwner, common.GetResourceOwner(ctx))
+
+	ctx = common.WithRequestID(ctx, "req-2")
+
+	scope, _ = common.GetRequestScope(ctx)
+	assert.Equal(t, "req-2", scope.RequestID)
+	assert.Equal(t, userID, scope.UserID)
+}
+
+func TestGetResourceOwner_MissingTenant(t *testing.T) {
+	_, ok := common.GetRequestScope(context.Background())
+	assert.False(t, ok)
+
+	assert.Panics(t, func() { common.GetResourceOwner(context.Background()) })
+
+	ctx := common.WithResourceOwner(context.Background(), common.ResourceOwner{UserID: uuid.New()})
+	assert.Panics(t, func() { common.GetResourceOwner(ctx) })
+}
+
+func TestIsAuthenticatedUser(t *testing.T) {
+	// anonymous requests act as a placeholder user
+	anonymous := common.WithRequestScope(context.Background(), common.RequestScope{
+		ResourceOwner: common.ResourceOwner{TenantID: uuid.New(), ClientID: uuid.New(), GroupID: uuid.New(), UserID: uuid.New()},
+	})
+	assert.False(t, common.IsAuthenticatedUser(anonymous))
+
+	user := common.WithAuthenticated(common.WithResourceOwner(anonymous, common.ResourceOwner{GroupID: uuid.New(), UserID: uuid.New()}))
+	assert.True(t, common.IsAuthenticatedUser(user))
+
+	// a client application authenticated by its API key acts as no user
+	client := common.WithAuthenticated(common.WithRequestScope(context.Background(), common.RequestScope{
+		ResourceOwner: common.ResourceOwner{TenantID: uuid.New(), ClientID: uuid.New()},
+	}))
+	assert.False(t, common.IsAuthenticatedUser(client))
+}
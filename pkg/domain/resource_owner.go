@@ -16,29 +16,13 @@ type ResourceOwner struct {
 }
 
 func GetResourceOwner(userContext context.Context) ResourceOwner {
-	res := ResourceOwner{}
+	scope, _ := GetRequestScope(userContext)
 
-	if tenantID, ok := userContext.Value(TenantIDKey).(uuid.UUID); ok {
-		res.TenantID = tenantID
-	}
-
-	if clientID, ok := userContext.Value(ClientIDKey).(uuid.UUID); ok {
-		res.ClientID = clientID
-	}
-
-	if groupID, ok := userContext.Value(GroupIDKey).(uuid.UUID); ok {
-		res.GroupID = groupID
-	}
-
-	if userID, ok := userContext.Value(UserIDKey).(uuid.UUID); ok {
-		res.UserID = userID
-	}
-
-	if res.IsMissingTenant() {
+	if scope.IsMissingTenant() {
 		panic(fmt.Errorf("GetResourceOwner.IsMissingTenant: tenant_id missing in context %v", userContext))
 	}
 
-	return res
+	return scope.ResourceOwner
 }
 
 func (ro ResourceOwner) IsMissingTenant() bool {
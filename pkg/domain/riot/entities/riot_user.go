@@ -0,0 +1,35 @@
+package riot
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+)
+
+// RiotUser is the Riot account of a player signed in through Riot Sign-On (RSO): the PUUID is the same across all Riot titles and
+// regions, while the Riot ID (GameName#TagLine) can be changed by the player.
+type RiotUser struct {
+	ID            uuid.UUID            `json:"id" bson:"_id"`
+	VHash         string               `json:"v_hash" bson:"v_hash"`
+	PUUID         string               `json:"puuid" bson:"puuid"`
+	GameName      string               `json:"game_name" bson:"game_name"`
+	TagLine       string               `json:"tag_line" bson:"tag_line"`
+	ActiveShard   string               `json:"active_shard" bson:"active_shard"`
+	ResourceOwner common.ResourceOwner `json:"resource_owner" bson:"resource_owner"`
+	CreatedAt     time.Time            `json:"created_at" bson:"created_at"`
+	UpdatedAt     time.Time            `json:"updated_at" bson:"updated_at"`
+}
+
+func (s RiotUser) GetID() uuid.UUID {
+	return s.ID
+}
+
+// RiotID is the display name of the account, as shown in game (e.g. "player#BR1").
+func (s RiotUser) RiotID() string {
+	if s.TagLine == "" {
+		return s.GameName
+	}
+
+	return s.GameName + "#" + s.TagLine
+}
@@ -0,0 +1,90 @@
+package riot
+
+import "fmt"
+
+// Invalid VHash Error
+type InvalidVHashError struct {
+	// Error message
+	Message string
+}
+
+// Error returns the error message
+func (e *InvalidVHashError) Error() string {
+	return e.Message
+}
+
+// NewInvalidVHashError creates a new InvalidVHashError
+func NewInvalidVHashError(invalidVHash string) *InvalidVHashError {
+	return &InvalidVHashError{
+		Message: fmt.Sprintf("Invalid vHash: %s", invalidVHash),
+	}
+}
+
+// Riot User Creation Error
+type RiotUserCreationError struct {
+	// Error message
+	Message string
+}
+
+// Error returns the error message
+func (e *RiotUserCreationError) Error() string {
+	return e.Message
+}
+
+// NewRiotUserCreationError creates a new RiotUserCreationError
+func NewRiotUserCreationError(message string) *RiotUserCreationError {
+	return &RiotUserCreationError{
+		Message: message,
+	}
+}
+
+type PUUIDMismatchError struct {
+	// Error message
+	Message string
+}
+
+// Error returns the error message
+func (e *PUUIDMismatchError) Error() string {
+	return e.Message
+}
+
+// NewPUUIDMismatchError creates a new PUUIDMismatchError
+func NewPUUIDMismatchError(receivedPUUID string) *PUUIDMismatchError {
+	return &PUUIDMismatchError{
+		Message: fmt.Sprintf("PUUID mismatch: %s", receivedPUUID),
+	}
+}
+
+type PUUIDRequiredError struct {
+	// Error message
+	Message string
+}
+
+// Error returns the error message
+func (e *PUUIDRequiredError) Error() string {
+	return e.Message
+}
+
+// NewPUUIDRequiredError creates a new PUUIDRequiredError
+func NewPUUIDRequiredError() *PUUIDRequiredError {
+	return &PUUIDRequiredError{
+		Message: "PUUID is required",
+	}
+}
+
+type VHashRequiredError struct {
+	// Error message
+	Message string
+}
+
+// Error returns the error message
+func (e *VHashRequiredError) Error() string {
+	return e.Message
+}
+
+// NewVHashRequiredError creates a new VHashRequiredError
+func NewVHashRequiredError() *VHashRequiredError {
+	return &VHashRequiredError{
+		Message: "vHash is required",
+	}
+}
@@ -0,0 +1,13 @@
+package riot_in
+
+import (
+	"context"
+
+	iam_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/iam/entities"
+	riot_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/riot/entities"
+)
+
+type OnboardRiotUserCommand interface {
+	Exec(ctx context.Context, riotUser *riot_entities.RiotUser) (*riot_entities.RiotUser, *iam_entities.RIDToken, error)
+	Validate(ctx context.Context, riotUser *riot_entities.RiotUser) error
+}
@@ -0,0 +1,15 @@
+package riot_out
+
+import (
+	"context"
+
+	riot_entity "github.com/psavelis/team-pro/replay-api/pkg/domain/riot/entities"
+)
+
+type RiotUserWriter interface {
+	Create(ctx context.Context, user *riot_entity.RiotUser) (*riot_entity.RiotUser, error)
+}
+
+type VHashWriter interface {
+	CreateVHash(ctx context.Context, puuid string) string
+}
@@ -0,0 +1,12 @@
+package riot_out
+
+import (
+	"context"
+
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	riot_entity "github.com/psavelis/team-pro/replay-api/pkg/domain/riot/entities"
+)
+
+type RiotUserReader interface {
+	Search(ctx context.Context, s common.Search) ([]riot_entity.RiotUser, error)
+}
@@ -0,0 +1,156 @@
+package riot_use_cases
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+
+	iam_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/iam/entities"
+	iam_in "github.com/psavelis/team-pro/replay-api/pkg/domain/iam/ports/in"
+	"github.com/psavelis/team-pro/replay-api/pkg/domain/riot"
+	riot_entity "github.com/psavelis/team-pro/replay-api/pkg/domain/riot/entities"
+
+	riot_in "github.com/psavelis/team-pro/replay-api/pkg/domain/riot/ports/in"
+	riot_out "github.com/psavelis/team-pro/replay-api/pkg/domain/riot/ports/out"
+)
+
+type OnboardRiotUserUseCase struct {
+	RiotUserWriter    riot_out.RiotUserWriter
+	RiotUserReader    riot_out.RiotUserReader
+	VHashWriter       riot_out.VHashWriter
+	OnboardOpenIDUser iam_in.OnboardOpenIDUserCommandHandler
+}
+
+func NewOnboardRiotUserUseCase(riotUserWriter riot_out.RiotUserWriter, riotUserReader riot_out.RiotUserReader, vHashWriter riot_out.VHashWriter, onboardOpenIDUser iam_in.OnboardOpenIDUserCommandHandler) riot_in.OnboardRiotUserCommand {
+	return &OnboardRiotUserUseCase{
+		RiotUserWriter: riotUserWriter, RiotUserReader: riotUserReader, VHashWriter: vHashWriter, OnboardOpenIDUser: onboardOpenIDUser,
+	}
+}
+
+// Validate checks the vHash against the PUUID: the Riot ID can be changed by the player, the PUUID can't.
+func (usecase *OnboardRiotUserUseCase) Validate(ctx context.Context, riotUser *riot_entity.RiotUser) error {
+	if riotUser.PUUID == "" {
+		slog.ErrorContext(ctx, "riot puuid is required", "riot.GameName", riotUser.GameName)
+		return riot.NewPUUIDRequiredError()
+	}
+
+	if riotUser.VHash == "" {
+		slog.ErrorContext(ctx, "vHash is required", "vHash", riotUser.VHash)
+		return riot.NewVHashRequiredError()
+	}
+
+	expectedVHash := usecase.VHashWriter.CreateVHash(ctx, riotUser.PUUID)
+
+	if riotUser.VHash != expectedVHash {
+		slog.ErrorContext(ctx, "vHash does not match", "riot.PUUID", riotUser.PUUID, "vHash", riotUser.VHash, "expectedVHash", expectedVHash)
+		return riot.NewInvalidVHashError(riotUser.VHash)
+	}
+
+	return nil
+}
+
+func (usecase *OnboardRiotUserUseCase) Exec(ctx context.Context, riotUser *riot_entity.RiotUser) (*riot_entity.RiotUser, *iam_entities.RIDToken, error) {
+	riotUserResult, err := usecase.RiotUserReader.Search(ctx, usecase.newSearchByVHash(ctx, riotUser.VHash))
+	if err != nil {
+		slog.ErrorContext(ctx, "error getting riot user", "err", err)
+		return nil, nil, err
+	}
+
+	if len(riotUserResult) > 0 {
+		if riotUser.PUUID != riotUserResult[0].PUUID {
+			slog.ErrorContext(ctx, "riot puuid does not match", "puuid", riotUser.PUUID, "riotUser.PUUID", riotUserResult[0].PUUID)
+			return nil, nil, riot.NewPUUIDMismatchError(riotUser.PUUID)
+		}
+
+		riotUser = &riotUserResult[0]
+
+		ctx = common.WithResourceOwner(ctx, common.ResourceOwner{GroupID: riotUser.ResourceOwner.GroupID, UserID: riotUser.ResourceOwner.UserID})
+	}
+
+	profile, ridToken, err := usecase.OnboardOpenIDUser.Exec(ctx, iam_in.OnboardOpenIDUserCommand{
+		Name:           riotUser.RiotID(),
+		Source:         iam_entities.RIDSource_Riot,
+		Key:            riotUser.PUUID,
+		ProfileDetails: riotUser,
+	})
+
+	if err != nil {
+		slog.ErrorContext(ctx, "error creating user profile", "err", err)
+		return nil, nil, riot.NewRiotUserCreationError(fmt.Sprintf("error creating user profile: %v", riotUser.PUUID))
+	}
+
+	if ridToken == nil {
+		slog.ErrorContext(ctx, "error creating rid token", "puuid", riotUser.PUUID)
+		return nil, nil, riot.NewRiotUserCreationError(fmt.Sprintf("error creating rid token: %v", riotUser.PUUID))
+	}
+
+	ctx = common.WithResourceOwner(ctx, common.ResourceOwner{GroupID: profile.ResourceOwner.GroupID, UserID: profile.ResourceOwner.UserID})
+
+	riotUser.ResourceOwner = common.GetResourceOwner(ctx)
+
+	if riotUser.ID == uuid.Nil {
+		riotUser.ID = profile.ResourceOwner.UserID
+	}
+
+	if len(riotUserResult) == 0 {
+		now := time.Now().UTC()
+		riotUser.CreatedAt = now
+		riotUser.UpdatedAt = now
+
+		slog.InfoContext(ctx, "attempt to create riot user", "puuid", riotUser.PUUID, "riotID", riotUser.RiotID())
+		created, err := usecase.RiotUserWriter.Create(ctx, riotUser)
+
+		if err != nil {
+			slog.ErrorContext(ctx, "error creating riot user", "err", err)
+			return nil, nil, riot.NewRiotUserCreationError(fmt.Sprintf("error creating riot user: %v", riotUser.ID))
+		}
+
+		if created == nil {
+			slog.ErrorContext(ctx, "error creating riot user: user is nil", "puuid", riotUser.PUUID)
+			return nil, nil, riot.NewRiotUserCreationError(fmt.Sprintf("unable to create riot user: %v", riotUser.ID))
+		}
+
+		riotUser = created
+	}
+
+	return riotUser, ridToken, nil
+}
+
+func (uc *OnboardRiotUserUseCase) newSearchByVHash(ctx context.Context, vhashString string) common.Search {
+	params := []common.SearchAggregation{
+		{
+			Params: []common.SearchParameter{
+				{
+					ValueParams: []common.SearchableValue{
+						{
+							Field: "VHash",
+							Values: []interface{}{
+								vhashString,
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	visibility := common.SearchVisibilityOptions{
+		RequestSource:    common.GetResourceOwner(ctx),
+		IntendedAudience: common.ClientApplicationAudienceIDKey,
+	}
+
+	result := common.SearchResultOptions{
+		Skip:  0,
+		Limit: 1,
+	}
+
+	return common.Search{
+		SearchParams:      params,
+		ResultOptions:     result,
+		VisibilityOptions: visibility,
+	}
+}
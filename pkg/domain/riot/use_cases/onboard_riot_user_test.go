@@ -0,0 +1,106 @@
+package riot_use_cases_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	iam_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/iam/entities"
+	iam_in "github.com/psavelis/team-pro/replay-api/pkg/domain/iam/ports/in"
+	"github.com/psavelis/team-pro/replay-api/pkg/domain/riot"
+	riot_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/riot/entities"
+	riot_use_cases "github.com/psavelis/team-pro/replay-api/pkg/domain/riot/use_cases"
+	"github.com/stretchr/testify/assert"
+)
+
+const puuid = "u8Zk3bQ9-example-puuid"
+
+type mockVHashWriter struct{}
+
+func (m mockVHashWriter) CreateVHash(ctx context.Context, id string) string {
+	return "vhash:" + id
+}
+
+type mockRiotUserStore struct {
+	users []riot_entities.RiotUser
+}
+
+func (m *mockRiotUserStore) Create(ctx context.Context, user *riot_entities.RiotUser) (*riot_entities.RiotUser, error) {
+	m.users = append(m.users, *user)
+	return user, nil
+}
+
+func (m *mockRiotUserStore) Search(ctx context.Context, s common.Search) ([]riot_entities.RiotUser, error) {
+	res := make([]riot_entities.RiotUser, 0)
+
+	for _, user := range m.users {
+		if user.VHash == s.SearchParams[0].Params[0].ValueParams[0].Values[0] {
+			res = append(res, user)
+		}
+	}
+
+	return res, nil
+}
+
+type mockOnboardOpenIDUser struct {
+	commands []iam_in.OnboardOpenIDUserCommand
+	owner    common.ResourceOwner
+}
+
+func (m *mockOnboardOpenIDUser) Exec(ctx context.Context, cmd iam_in.OnboardOpenIDUserCommand) (*iam_entities.Profile, *iam_entities.RIDToken, error) {
+	m.commands = append(m.commands, cmd)
+
+	return &iam_entities.Profile{ResourceOwner: m.owner}, &iam_entities.RIDToken{ID: uuid.New(), Source: cmd.Source, ResourceOwner: m.owner}, nil
+}
+
+func TestOnboardRiotUserUseCase_Validate(t *testing.T) {
+	usecase := riot_use_cases.NewOnboardRiotUserUseCase(&mockRiotUserStore{}, &mockRiotUserStore{}, mockVHashWriter{}, &mockOnboardOpenIDUser{})
+
+	tests := []struct {
+		name     string
+		user     riot_entities.RiotUser
+		expected error
+	}{
+		{"valid", riot_entities.RiotUser{PUUID: puuid, VHash: "vhash:" + puuid}, nil},
+		{"missing puuid", riot_entities.RiotUser{VHash: "vhash:" + puuid}, riot.NewPUUIDRequiredError()},
+		{"missing vhash", riot_entities.RiotUser{PUUID: puuid}, riot.NewVHashRequiredError()},
+		{"vhash of another account", riot_entities.RiotUser{PUUID: puuid, VHash: "vhash:other"}, riot.NewInvalidVHashError("vhash:other")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, usecase.Validate(context.Background(), &tt.user))
+		})
+	}
+}
+
+func TestOnboardRiotUserUseCase_Exec(t *testing.T) {
+	// onboarding requests come from the client application, the profile is the user's
+	ctx := common.WithResourceOwner(context.Background(), common.ResourceOwner{TenantID: common.TeamPROTenantID, ClientID: common.TeamPROAppClientID})
+	owner := common.ResourceOwner{TenantID: common.TeamPROTenantID, UserID: uuid.New(), GroupID: uuid.New()}
+
+	store := &mockRiotUserStore{}
+	openID := &mockOnboardOpenIDUser{owner: owner}
+
+	usecase := riot_use_cases.NewOnboardRiotUserUseCase(store, store, mockVHashWriter{}, openID)
+
+	user, token, err := usecase.Exec(ctx, &riot_entities.RiotUser{PUUID: puuid, VHash: "vhash:" + puuid, GameName: "sova", TagLine: "BR1"})
+	assert.NoError(t, err)
+	assert.NotNil(t, token)
+	assert.Equal(t, owner.UserID, user.ID)
+	assert.Len(t, store.users, 1)
+
+	assert.Equal(t, iam_entities.RIDSource_Riot, openID.commands[0].Source)
+	assert.Equal(t, puuid, openID.commands[0].Key)
+	assert.Equal(t, "sova#BR1", openID.commands[0].Name)
+
+	// signing in again (even after a Riot ID change) reuses the account
+	user, _, err = usecase.Exec(ctx, &riot_entities.RiotUser{PUUID: puuid, VHash: "vhash:" + puuid, GameName: "jett", TagLine: "BR1"})
+	assert.NoError(t, err)
+	assert.Equal(t, owner.UserID, user.ID)
+	assert.Len(t, store.users, 1)
+
+	_, _, err = usecase.Exec(ctx, &riot_entities.RiotUser{PUUID: "another-puuid", VHash: "vhash:" + puuid})
+	assert.IsType(t, &riot.PUUIDMismatchError{}, err)
+}
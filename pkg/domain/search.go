@@ -2,6 +2,8 @@ package common
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"strings"
 	"time"
@@ -9,6 +11,9 @@ import (
 	"github.com/google/uuid"
 )
 
+// ErrInvalidSearch is wrapped by the errors of searches the repositories can't run (ie: a field not searchable, or a malformed range).
+var ErrInvalidSearch = errors.New("invalid search")
+
 type SortDirectionKey int
 
 const (
@@ -26,19 +31,41 @@ const (
 )
 
 const (
-	EqualsOperator             SearchOperator = "eq"       // Exact match (default)
-	NotEqualsOperator          SearchOperator = "ne"       // Not equal
-	GreaterThanOperator        SearchOperator = "gt"       // Greater than
-	LessThanOperator           SearchOperator = "lt"       // Less than
-	GreaterThanOrEqualOperator SearchOperator = "gte"      // Greater than or equal
-	LessThanOrEqualOperator    SearchOperator = "lte"      // Less than or equal
-	ContainsOperator           SearchOperator = "contains" // Case-insensitive substring match
-	StartsWithOperator         SearchOperator = "startswith"
-	EndsWithOperator           SearchOperator = "endswith"
-	InOperator                 SearchOperator = "in"  // Match any value in a list
-	NotInOperator              SearchOperator = "nin" // Not in a list
+	EqualsOperator             SearchOperator = "eq"         // Exact match (default)
+	NotEqualsOperator          SearchOperator = "ne"         // Not equal
+	GreaterThanOperator        SearchOperator = "gt"         // Greater than
+	LessThanOperator           SearchOperator = "lt"         // Less than
+	GreaterThanOrEqualOperator SearchOperator = "gte"        // Greater than or equal
+	LessThanOrEqualOperator    SearchOperator = "lte"        // Less than or equal
+	ContainsOperator           SearchOperator = "contains"   // Case-insensitive substring match (unanchored)
+	StartsWithOperator         SearchOperator = "startswith" // Case-sensitive prefix match (anchored, can use indexes)
+	EndsWithOperator           SearchOperator = "endswith"   // Case-insensitive suffix match (unanchored)
+	InOperator                 SearchOperator = "in"         // Match any value in a list
+	NotInOperator              SearchOperator = "nin"        // Not in a list
+	ExistsOperator             SearchOperator = "exists"     // Field presence (optional bool value, default true)
+	BetweenOperator            SearchOperator = "between"    // Inclusive numeric range: [min, max]
 )
 
+// MinUnanchoredPatternLength is the shortest pattern accepted by unanchored regex operators (contains/endswith), which can't use indexes and scan the whole collection.
+const MinUnanchoredPatternLength = 3
+
+// SearchOperators lists every operator supported by SearchableValue.
+var SearchOperators = []SearchOperator{
+	EqualsOperator,
+	NotEqualsOperator,
+	GreaterThanOperator,
+	LessThanOperator,
+	GreaterThanOrEqualOperator,
+	LessThanOrEqualOperator,
+	ContainsOperator,
+	StartsWithOperator,
+	EndsWithOperator,
+	InOperator,
+	NotInOperator,
+	ExistsOperator,
+	BetweenOperator,
+}
+
 const DefaultPageSize uint = 50
 
 type IntendedAudienceKey string
@@ -68,22 +95,21 @@ type SearchableDurationRange struct {
 	Max   *time.Duration
 }
 
-type SortableField struct {
-	Field     string
-	Direction SortDirectionKey
-}
-
+// SearchParameter is a boolean group: its value, date and duration filters and nested aggregations are combined by AggregationClause.
 type SearchParameter struct {
 	ValueParams       []SearchableValue         `json:"values" bson:"value_params"`
 	DateParams        []SearchableDateRange     `json:"date" bson:"date_params"`
 	DurationParams    []SearchableDurationRange `json:"time" bson:"duration_params"`
 	AggregationParams []SearchAggregation       `json:"aggregate" bson:"aggregation_params"`
-	AggregationClause SearchAggregationClause   `json:"clause" bson:"clause"` // if not provided, default to AndAggregationClause
+	AggregationClause SearchAggregationClause   `json:"clause" bson:"clause"` // if not provided, inherits the clause of the enclosing SearchAggregation
+	Negate            bool                      `json:"not" bson:"not"`       // NOT: matches when the group does not
 }
 
+// SearchAggregation is a boolean group of SearchParameters. Top level aggregations (Search.SearchParams) are always combined with AND.
 type SearchAggregation struct {
 	Params            []SearchParameter       `json:"params" bson:"params"`
-	AggregationClause SearchAggregationClause `json:"clause" bson:"clause"` // if not provided, default to AndAggregationClause
+	AggregationClause SearchAggregationClause `json:"clause" bson:"clause"` // if not provided, inherits the enclosing clause (AndAggregationClause at top level)
+	Negate            bool                    `json:"not" bson:"not"`       // NOT: matches when the group does not
 }
 
 type SearchResultOptions struct {
@@ -91,6 +117,12 @@ type SearchResultOptions struct {
 	Limit      uint     `json:"limit" bson:"limit"`      // default = 50
 	PickFields []string `json:"pick" bson:"pick_fields"` // if not informed, pick all
 	OmitFields []string `json:"omit" bson:"omit_fields"` // if not informed, doesnt omit any
+	Facets     []string `json:"facets" bson:"facets"`    // queryable fields to count matches by (see Facetable)
+	// Cursor pages by continuation token instead of Skip (see CursorPaginated): empty for the first page, then the next cursor of
+	// the previous page. Nil pages by Skip.
+	Cursor *string `json:"cursor,omitempty" bson:"cursor,omitempty"`
+	// Sort keys of the results, compiled into the SortOptions of the search. Insertion order when empty.
+	Sort []SearchSortOption `json:"sort,omitempty" bson:"sort,omitempty"`
 }
 
 type SearchVisibilityOptions struct {
@@ -101,7 +133,7 @@ type SearchVisibilityOptions struct {
 type Search struct {
 	SearchParams      []SearchAggregation     `json:"search_params" bson:"search_params"`
 	ResultOptions     SearchResultOptions     `json:"result_options" bson:"result_options"`
-	SortOptions       []SortableField         `json:"sort_options" bson:"sort_options"`
+	SortOptions       []SearchSortOption      `json:"sort_options" bson:"sort_options"`
 	VisibilityOptions SearchVisibilityOptions `json:"-" bson:"visibility_options"`
 }
 
@@ -161,6 +193,7 @@ func NewSearchByAggregation(ctx context.Context, aggregationParams []SearchAggre
 	return Search{
 		SearchParams:      aggregationParams,
 		ResultOptions:     resultOptions,
+		SortOptions:       resultOptions.Sort,
 		VisibilityOptions: visibility,
 	}
 }
@@ -215,9 +248,23 @@ const (
 )
 
 func ValidateSearchParameters(searchParams []SearchAggregation, queryableFields map[string]bool) error {
+	return validateSearchAggregations(searchParams, queryableFields, 0)
+}
+
+func validateSearchAggregations(searchParams []SearchAggregation, queryableFields map[string]bool, depth int) error {
 	for _, param := range searchParams {
+		err := ValidateAggregationClause(param.AggregationClause)
+		if err != nil {
+			return err
+		}
+
 		for _, valueParam := range param.Params {
-			err := ValidateValueParams(valueParam, queryableFields)
+			err := ValidateAggregationClause(valueParam.AggregationClause)
+			if err != nil {
+				return err
+			}
+
+			err = ValidateValueParams(valueParam, queryableFields)
 			if err != nil {
 				return err
 			}
@@ -238,15 +285,17 @@ func ValidateSearchParameters(searchParams []SearchAggregation, queryableFields
 		}
 
 		for _, aggregationParam := range param.Params {
-			for index, aggregation := range aggregationParam.AggregationParams {
-				if index >= MaxRecursiveDepth {
-					return fmt.Errorf("maximum AggregationParams recursive depth %d reached", MaxRecursiveDepth)
-				}
+			if len(aggregationParam.AggregationParams) == 0 {
+				continue
+			}
 
-				err := ValidateSearchParameters([]SearchAggregation{aggregation}, queryableFields)
-				if err != nil {
-					return err
-				}
+			if depth+1 >= MaxRecursiveDepth {
+				return fmt.Errorf("maximum AggregationParams recursive depth %d reached", MaxRecursiveDepth)
+			}
+
+			err := validateSearchAggregations(aggregationParam.AggregationParams, queryableFields, depth+1)
+			if err != nil {
+				return err
 			}
 		}
 	}
@@ -254,6 +303,15 @@ func ValidateSearchParameters(searchParams []SearchAggregation, queryableFields
 	return nil
 }
 
+func ValidateAggregationClause(clause SearchAggregationClause) error {
+	switch clause {
+	case "", AndAggregationClause, OrAggregationClause:
+		return nil
+	default:
+		return fmt.Errorf("invalid aggregation clause '%s' (expected '%s' or '%s')", clause, AndAggregationClause, OrAggregationClause)
+	}
+}
+
 func ValidateDurationParams(durationParam SearchParameter, queryableFields map[string]bool) error {
 	for _, duration := range durationParam.DurationParams {
 		field := duration.Field
@@ -319,16 +377,103 @@ func ValidateValueParams(valueParam SearchParameter, queryableFields map[string]
 			if !allowed {
 				return fmt.Errorf("filtering on ValueParams fields matching '%s.*' is not permitted", prefix)
 			}
-		} else {
-			allowed, exists := queryableFields[field]
-			if !exists || !allowed {
-				return fmt.Errorf("filtering on ValueParams field '%s' is not permitted", field)
+		} else if !isQueryableField(field, queryableFields) {
+			return fmt.Errorf("filtering on ValueParams field '%s' is not permitted", field)
+		}
+
+		err := ValidateOperatorValues(value)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ValidateOperatorValues checks that the values of a SearchableValue are consistent with its operator.
+func ValidateOperatorValues(value SearchableValue) error {
+	switch value.Operator {
+	case "", InOperator, NotInOperator:
+		return nil
+
+	case ExistsOperator:
+		if len(value.Values) > 1 {
+			return fmt.Errorf("operator '%s' on field '%s' accepts at most one value", value.Operator, value.Field)
+		}
+
+		if len(value.Values) == 1 {
+			if _, ok := value.Values[0].(bool); !ok {
+				return fmt.Errorf("operator '%s' on field '%s' requires a boolean value", value.Operator, value.Field)
 			}
 		}
+
+	case BetweenOperator:
+		if len(value.Values) != 2 {
+			return fmt.Errorf("operator '%s' on field '%s' requires exactly 2 values (min, max)", value.Operator, value.Field)
+		}
+
+		min, okMin := ToFloat64(value.Values[0])
+		max, okMax := ToFloat64(value.Values[1])
+		if !okMin || !okMax {
+			return fmt.Errorf("operator '%s' on field '%s' requires numeric values", value.Operator, value.Field)
+		}
+
+		if min > max {
+			return fmt.Errorf("operator '%s' on field '%s' requires min <= max (%v > %v)", value.Operator, value.Field, min, max)
+		}
+
+	case ContainsOperator, StartsWithOperator, EndsWithOperator:
+		if len(value.Values) != 1 {
+			return fmt.Errorf("operator '%s' on field '%s' requires exactly 1 value", value.Operator, value.Field)
+		}
+
+		pattern, ok := value.Values[0].(string)
+		if !ok || pattern == "" {
+			return fmt.Errorf("operator '%s' on field '%s' requires a non-empty string value", value.Operator, value.Field)
+		}
+
+		if value.Operator != StartsWithOperator && len([]rune(pattern)) < MinUnanchoredPatternLength {
+			return fmt.Errorf("operator '%s' on field '%s' requires at least %d characters", value.Operator, value.Field, MinUnanchoredPatternLength)
+		}
+
+	case EqualsOperator, NotEqualsOperator, GreaterThanOperator, LessThanOperator, GreaterThanOrEqualOperator, LessThanOrEqualOperator:
+		if len(value.Values) == 0 {
+			return fmt.Errorf("operator '%s' on field '%s' requires a value", value.Operator, value.Field)
+		}
+
+	default:
+		return fmt.Errorf("operator '%s' on field '%s' is not supported", value.Operator, value.Field)
 	}
+
 	return nil
 }
 
+// ToFloat64 converts the numeric types accepted in SearchableValue.Values (including json.Number) to float64.
+func ToFloat64(v interface{}) (float64, bool) {
+	switch n := v.(type) {
+	case int:
+		return float64(n), true
+	case int32:
+		return float64(n), true
+	case int64:
+		return float64(n), true
+	case uint:
+		return float64(n), true
+	case uint32:
+		return float64(n), true
+	case uint64:
+		return float64(n), true
+	case float32:
+		return float64(n), true
+	case float64:
+		return n, true
+	case json.Number:
+		f, err := n.Float64()
+		return f, err == nil
+	default:
+		return 0, false
+	}
+}
+
 func ValidateResultOptions(resultOptions SearchResultOptions, returnableFields map[string]bool) error {
 	for _, field := range resultOptions.PickFields {
 		if _, allowed := returnableFields[field]; !allowed {
@@ -362,5 +507,5 @@ func ValidateResultOptions(resultOptions SearchResultOptions, returnableFields m
 		return fmt.Errorf("limit must be a positive integer")
 	}
 
-	return nil
+	return ValidateCursorOptions(resultOptions)
 }
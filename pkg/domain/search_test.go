@@ -87,6 +87,54 @@ func TestValidateSearchParameters(t *testing.T) {
 			queryableFields: map[string]bool{"Header.Filestamp": true},
 			expectedError:   "filtering on ValueParams fields matching 'InvalidPrefix.*' is not permitted",
 		},
+		{
+			name: "Valid Custom Field Value Param",
+			searchParams: []common.SearchAggregation{
+				{
+					Params: []common.SearchParameter{
+						{
+							ValueParams: []common.SearchableValue{
+								{Field: "CustomFields.team_code", Values: []interface{}{"A-1"}},
+							},
+						},
+					},
+				},
+			},
+			queryableFields: map[string]bool{"CustomFields": true},
+			expectedError:   "",
+		},
+		{
+			name: "Invalid Custom Field Key",
+			searchParams: []common.SearchAggregation{
+				{
+					Params: []common.SearchParameter{
+						{
+							ValueParams: []common.SearchableValue{
+								{Field: "CustomFields.$where", Values: []interface{}{"1"}},
+							},
+						},
+					},
+				},
+			},
+			queryableFields: map[string]bool{"CustomFields": true},
+			expectedError:   "filtering on ValueParams field 'CustomFields.$where' is not permitted",
+		},
+		{
+			name: "Custom Fields Not Queryable",
+			searchParams: []common.SearchAggregation{
+				{
+					Params: []common.SearchParameter{
+						{
+							ValueParams: []common.SearchableValue{
+								{Field: "CustomFields.team_code", Values: []interface{}{"A-1"}},
+							},
+						},
+					},
+				},
+			},
+			queryableFields: map[string]bool{"GameID": true},
+			expectedError:   "filtering on ValueParams field 'CustomFields.team_code' is not permitted",
+		},
 		{
 			name: "Valid Date Param",
 			searchParams: []common.SearchAggregation{
@@ -217,6 +265,80 @@ func TestValidateSearchParameters(t *testing.T) {
 			queryableFields: map[string]bool{"Header.*": true, "Header.Filestamp": false}, // Filestamp disallowed specifically
 			expectedError:   "filtering on ValueParams field 'Header.Filestamp' is not permitted",
 		},
+		{
+			name: "Valid Nested OR Group With NOT",
+			searchParams: []common.SearchAggregation{
+				{
+					AggregationClause: common.OrAggregationClause,
+					Params: []common.SearchParameter{
+						{
+							ValueParams: []common.SearchableValue{{Field: "GameID", Values: []interface{}{"cs2"}}},
+						},
+						{
+							Negate: true,
+							AggregationParams: []common.SearchAggregation{
+								{
+									AggregationClause: common.AndAggregationClause,
+									Params: []common.SearchParameter{
+										{ValueParams: []common.SearchableValue{{Field: "Status", Values: []interface{}{"Failed"}}}},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			queryableFields: map[string]bool{"GameID": true, "Status": true},
+			expectedError:   "",
+		},
+		{
+			name: "Invalid Aggregation Clause",
+			searchParams: []common.SearchAggregation{
+				{
+					AggregationClause: "xor",
+					Params: []common.SearchParameter{
+						{ValueParams: []common.SearchableValue{{Field: "GameID", Values: []interface{}{"cs2"}}}},
+					},
+				},
+			},
+			queryableFields: map[string]bool{"GameID": true},
+			expectedError:   "invalid aggregation clause 'xor' (expected 'and' or 'or')",
+		},
+		{
+			name: "Invalid Nested Parameter Clause",
+			searchParams: []common.SearchAggregation{
+				{
+					Params: []common.SearchParameter{
+						{
+							AggregationParams: []common.SearchAggregation{
+								{
+									Params: []common.SearchParameter{
+										{
+											AggregationClause: "nand",
+											ValueParams:       []common.SearchableValue{{Field: "GameID", Values: []interface{}{"cs2"}}},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			queryableFields: map[string]bool{"GameID": true},
+			expectedError:   "invalid aggregation clause 'nand' (expected 'and' or 'or')",
+		},
+		{
+			name:            "Nested Groups Exceeding Max Depth",
+			searchParams:    nestedAggregations(common.MaxRecursiveDepth + 1),
+			queryableFields: map[string]bool{"GameID": true},
+			expectedError:   "maximum AggregationParams recursive depth 10 reached",
+		},
+		{
+			name:            "Nested Groups Within Max Depth",
+			searchParams:    nestedAggregations(common.MaxRecursiveDepth),
+			queryableFields: map[string]bool{"GameID": true},
+			expectedError:   "",
+		},
 
 		// // Invalid Date Range - Start After End
 		// {
@@ -315,6 +437,21 @@ func TestValidateSearchParameters(t *testing.T) {
 	}
 }
 
+// nestedAggregations builds a chain of groups nested `depth` levels deep.
+func nestedAggregations(depth int) []common.SearchAggregation {
+	aggregations := []common.SearchAggregation{
+		{Params: []common.SearchParameter{{ValueParams: []common.SearchableValue{{Field: "GameID", Values: []interface{}{"cs2"}}}}}},
+	}
+
+	for i := 1; i < depth; i++ {
+		aggregations = []common.SearchAggregation{
+			{Params: []common.SearchParameter{{AggregationParams: aggregations}}},
+		}
+	}
+
+	return aggregations
+}
+
 func TestValidateResultOptions(t *testing.T) {
 	tests := []struct {
 		name           string
@@ -373,3 +510,92 @@ func TestValidateResultOptions(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateOperatorValues(t *testing.T) {
+	tests := []struct {
+		name          string
+		value         common.SearchableValue
+		expectedError string
+	}{
+		{
+			name:  "Default Operator",
+			value: common.SearchableValue{Field: "Region", Values: []interface{}{"sa", "na"}},
+		},
+		{
+			name:  "Exists Without Value",
+			value: common.SearchableValue{Field: "Region", Operator: common.ExistsOperator},
+		},
+		{
+			name:  "Exists False",
+			value: common.SearchableValue{Field: "Region", Operator: common.ExistsOperator, Values: []interface{}{false}},
+		},
+		{
+			name:          "Exists Non Boolean",
+			value:         common.SearchableValue{Field: "Region", Operator: common.ExistsOperator, Values: []interface{}{"yes"}},
+			expectedError: "operator 'exists' on field 'Region' requires a boolean value",
+		},
+		{
+			name:  "Between Numbers",
+			value: common.SearchableValue{Field: "Rating", Operator: common.BetweenOperator, Values: []interface{}{1000, 1500.5}},
+		},
+		{
+			name:          "Between Single Value",
+			value:         common.SearchableValue{Field: "Rating", Operator: common.BetweenOperator, Values: []interface{}{1000}},
+			expectedError: "operator 'between' on field 'Rating' requires exactly 2 values (min, max)",
+		},
+		{
+			name:          "Between Non Numeric",
+			value:         common.SearchableValue{Field: "Rating", Operator: common.BetweenOperator, Values: []interface{}{"a", "z"}},
+			expectedError: "operator 'between' on field 'Rating' requires numeric values",
+		},
+		{
+			name:          "Between Inverted",
+			value:         common.SearchableValue{Field: "Rating", Operator: common.BetweenOperator, Values: []interface{}{2000, 1000}},
+			expectedError: "operator 'between' on field 'Rating' requires min <= max (2000 > 1000)",
+		},
+		{
+			name:  "StartsWith Short Prefix",
+			value: common.SearchableValue{Field: "Nickname", Operator: common.StartsWithOperator, Values: []interface{}{"a"}},
+		},
+		{
+			name:          "StartsWith Empty Prefix",
+			value:         common.SearchableValue{Field: "Nickname", Operator: common.StartsWithOperator, Values: []interface{}{""}},
+			expectedError: "operator 'startswith' on field 'Nickname' requires a non-empty string value",
+		},
+		{
+			name:          "Contains Short Pattern",
+			value:         common.SearchableValue{Field: "Nickname", Operator: common.ContainsOperator, Values: []interface{}{"ab"}},
+			expectedError: "operator 'contains' on field 'Nickname' requires at least 3 characters",
+		},
+		{
+			name:          "EndsWith Multiple Values",
+			value:         common.SearchableValue{Field: "Nickname", Operator: common.EndsWithOperator, Values: []interface{}{"abc", "def"}},
+			expectedError: "operator 'endswith' on field 'Nickname' requires exactly 1 value",
+		},
+		{
+			name:          "Equals Without Value",
+			value:         common.SearchableValue{Field: "Nickname", Operator: common.EqualsOperator},
+			expectedError: "operator 'eq' on field 'Nickname' requires a value",
+		},
+		{
+			name:          "Unknown Operator",
+			value:         common.SearchableValue{Field: "Nickname", Operator: "like", Values: []interface{}{"abc"}},
+			expectedError: "operator 'like' on field 'Nickname' is not supported",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := common.ValidateOperatorValues(tt.value)
+			if tt.expectedError != "" {
+				if err == nil {
+					t.Errorf("Expected error '%s', but got no error", tt.expectedError)
+				} else if err.Error() != tt.expectedError {
+					t.Errorf("Expected error '%s', but got '%s'", tt.expectedError, err.Error())
+				}
+			} else if err != nil {
+				t.Errorf("Expected no error, but got '%s'", err.Error())
+			}
+		})
+	}
+}
@@ -0,0 +1,180 @@
+package season_entities
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	rating_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/rating/entities"
+)
+
+type SeasonStatus string
+
+const (
+	SeasonStatusScheduled SeasonStatus = "scheduled"
+	SeasonStatusActive    SeasonStatus = "active"
+	SeasonStatusEnded     SeasonStatus = "ended"
+)
+
+type ResetMode string
+
+const (
+	// ResetModeSoft pulls the ratings towards the initial rating, keeping a share (Factor) of their distance to it
+	ResetModeSoft ResetMode = "soft"
+	// ResetModeHard starts every player over, at the initial rating
+	ResetModeHard ResetMode = "hard"
+	// ResetModeNone carries the ratings over as they are
+	ResetModeNone ResetMode = "none"
+)
+
+const (
+	DefaultSoftResetFactor    = 0.5
+	DefaultSoftResetDeviation = 250
+)
+
+// ResetRule is how the ratings of the game are reset when the season starts. Ratings are reset on the first match of each player in
+// the season (see Season.ResetRating), so players that sit the season out keep the rating of the last season they played.
+type ResetRule struct {
+	Mode      ResetMode `json:"mode" bson:"mode"`
+	Factor    float64   `json:"factor,omitempty" bson:"factor,omitempty"`       // soft: share of the distance to the initial rating kept (0 to 1)
+	Deviation float64   `json:"deviation,omitempty" bson:"deviation,omitempty"` // soft: minimum deviation after the reset, so ratings settle fast
+}
+
+func (rule ResetRule) Validate() error {
+	switch rule.Mode {
+	case ResetModeHard, ResetModeNone:
+		return nil
+	case ResetModeSoft:
+		if rule.Factor < 0 || rule.Factor > 1 {
+			return errors.New("reset factor must be between 0 and 1")
+		}
+
+		if rule.Deviation < 0 || rule.Deviation > rating_entities.InitialDeviation {
+			return fmt.Errorf("reset deviation must be between 0 and %d", rating_entities.InitialDeviation)
+		}
+
+		return nil
+	default:
+		return fmt.Errorf("invalid reset mode '%s'", rule.Mode)
+	}
+}
+
+// Apply returns the rating reset by the rule.
+func (rule ResetRule) Apply(r rating_entities.Rating) rating_entities.Rating {
+	switch rule.Mode {
+	case ResetModeHard:
+		r.Rating = rating_entities.InitialRating
+		r.Deviation = rating_entities.InitialDeviation
+		r.Volatility = rating_entities.InitialVolatility
+	case ResetModeSoft:
+		r.Rating = rating_entities.InitialRating + (r.Rating-rating_entities.InitialRating)*rule.Factor
+		r.Deviation = math.Min(math.Max(r.Deviation, rule.Deviation), rating_entities.InitialDeviation)
+	}
+
+	return r
+}
+
+// RewardTier is granted to the players that end the season with at least MinRating, after at least MinMatches matches in the season.
+type RewardTier struct {
+	Name       string  `json:"name" bson:"name"`
+	MinRating  float64 `json:"min_rating" bson:"min_rating"`
+	MinMatches int     `json:"min_matches" bson:"min_matches"`
+}
+
+// Season is a ranked split of a game: matches played between StartsAt and EndsAt are rated in the season, and its players are rewarded
+// by their final rating when it ends.
+type Season struct {
+	ID            uuid.UUID            `json:"id" bson:"_id"`
+	GameID        common.GameIDKey     `json:"game_id" bson:"game_id"`
+	Name          string               `json:"name" bson:"name"`
+	StartsAt      time.Time            `json:"starts_at" bson:"starts_at"`
+	EndsAt        time.Time            `json:"ends_at" bson:"ends_at"`
+	Status        SeasonStatus         `json:"status" bson:"status"`
+	Reset         ResetRule            `json:"reset" bson:"reset"`
+	RewardTiers   []RewardTier         `json:"reward_tiers" bson:"reward_tiers"` // best tier first
+	ActivatedAt   *time.Time           `json:"activated_at,omitempty" bson:"activated_at,omitempty"`
+	EndedAt       *time.Time           `json:"ended_at,omitempty" bson:"ended_at,omitempty"`
+	ResourceOwner common.ResourceOwner `json:"resource_owner" bson:"resource_owner"`
+	CreatedAt     time.Time            `json:"created_at" bson:"created_at"`
+	UpdatedAt     time.Time            `json:"updated_at" bson:"updated_at"`
+}
+
+func (s Season) GetID() uuid.UUID {
+	return s.ID
+}
+
+func (s Season) Validate() error {
+	if s.Name == "" || s.GameID == "" {
+		return errors.New("season name and game_id are required")
+	}
+
+	if !s.EndsAt.After(s.StartsAt) {
+		return errors.New("season must end after it starts")
+	}
+
+	err := s.Reset.Validate()
+	if err != nil {
+		return err
+	}
+
+	seen := make(map[string]bool)
+	for _, tier := range s.RewardTiers {
+		if tier.Name == "" {
+			return errors.New("reward tier name is required")
+		}
+
+		if seen[tier.Name] {
+			return fmt.Errorf("duplicate reward tier '%s'", tier.Name)
+		}
+
+		if tier.MinMatches < 0 {
+			return fmt.Errorf("reward tier '%s' min_matches can't be negative", tier.Name)
+		}
+
+		seen[tier.Name] = true
+	}
+
+	return nil
+}
+
+// Overlaps reports whether both seasons run at some point in time.
+func (s Season) Overlaps(other Season) bool {
+	return s.StartsAt.Before(other.EndsAt) && other.StartsAt.Before(s.EndsAt)
+}
+
+// SortRewardTiers orders the tiers best first (highest MinRating), as RewardTier expects them.
+func (s *Season) SortRewardTiers() {
+	sort.SliceStable(s.RewardTiers, func(i, j int) bool {
+		return s.RewardTiers[i].MinRating > s.RewardTiers[j].MinRating
+	})
+}
+
+// RewardTier returns the best tier reached by a rating, nil when none is.
+func (s Season) RewardTier(r rating_entities.Rating) *RewardTier {
+	for i, tier := range s.RewardTiers {
+		if r.Rating >= tier.MinRating && r.SeasonMatchesPlayed >= tier.MinMatches {
+			return &s.RewardTiers[i]
+		}
+	}
+
+	return nil
+}
+
+// ResetRating resets a rating of the game on its first match of the season (ratings already in the season are returned as they are).
+func (s Season) ResetRating(r rating_entities.Rating) rating_entities.Rating {
+	if r.SeasonID != nil && *r.SeasonID == s.ID {
+		return r
+	}
+
+	r = s.Reset.Apply(r)
+
+	id := s.ID
+	r.SeasonID = &id
+	r.SeasonMatchesPlayed = 0
+
+	return r
+}
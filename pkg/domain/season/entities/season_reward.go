@@ -0,0 +1,48 @@
+package season_entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	rating_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/rating/entities"
+)
+
+// seasonRewardNamespace keeps SeasonReward IDs stable (season+player), so ending a season twice overwrites its rewards.
+var seasonRewardNamespace = uuid.MustParse("5c2a9e71-3d84-4f06-b1a8-e6f47d0c93b2")
+
+// SeasonReward is the tier reached by a player in a season that ended.
+type SeasonReward struct {
+	ID            uuid.UUID            `json:"id" bson:"_id"`
+	SeasonID      uuid.UUID            `json:"season_id" bson:"season_id"`
+	GameID        common.GameIDKey     `json:"game_id" bson:"game_id"`
+	PlayerID      uuid.UUID            `json:"player_id" bson:"player_id"`
+	Tier          string               `json:"tier" bson:"tier"`
+	Rating        float64              `json:"rating" bson:"rating"`
+	MatchesPlayed int                  `json:"matches_played" bson:"matches_played"` // in the season
+	ResourceOwner common.ResourceOwner `json:"resource_owner" bson:"resource_owner"`
+	CreatedAt     time.Time            `json:"created_at" bson:"created_at"`
+}
+
+func (r SeasonReward) GetID() uuid.UUID {
+	return r.ID
+}
+
+func SeasonRewardID(seasonID uuid.UUID, playerID uuid.UUID) uuid.UUID {
+	return uuid.NewSHA1(seasonRewardNamespace, []byte(seasonID.String()+playerID.String()))
+}
+
+// NewSeasonReward rewards the final rating of a player in the season with the tier reached.
+func NewSeasonReward(season Season, r rating_entities.Rating, tier RewardTier, now time.Time) SeasonReward {
+	return SeasonReward{
+		ID:            SeasonRewardID(season.ID, r.SubjectID),
+		SeasonID:      season.ID,
+		GameID:        season.GameID,
+		PlayerID:      r.SubjectID,
+		Tier:          tier.Name,
+		Rating:        r.Rating,
+		MatchesPlayed: r.SeasonMatchesPlayed,
+		ResourceOwner: r.ResourceOwner,
+		CreatedAt:     now,
+	}
+}
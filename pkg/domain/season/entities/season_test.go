@@ -0,0 +1,128 @@
+package season_entities_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	rating_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/rating/entities"
+	season_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/season/entities"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResetRule_Apply(t *testing.T) {
+	r := rating_entities.Rating{Rating: 1600, Deviation: 80, Volatility: 0.07}
+
+	tests := []struct {
+		name               string
+		rule               season_entities.ResetRule
+		expectedRating     float64
+		expectedDeviation  float64
+		expectedVolatility float64
+	}{
+		{"Soft", season_entities.ResetRule{Mode: season_entities.ResetModeSoft, Factor: 0.5, Deviation: 200}, 1300, 200, 0.07},
+		{"Soft Keeps A Larger Deviation", season_entities.ResetRule{Mode: season_entities.ResetModeSoft, Factor: 0.25, Deviation: 50}, 1150, 80, 0.07},
+		{"Hard", season_entities.ResetRule{Mode: season_entities.ResetModeHard}, rating_entities.InitialRating, rating_entities.InitialDeviation, rating_entities.InitialVolatility},
+		{"None", season_entities.ResetRule{Mode: season_entities.ResetModeNone}, 1600, 80, 0.07},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reset := tt.rule.Apply(r)
+
+			assert.Equal(t, tt.expectedRating, reset.Rating)
+			assert.Equal(t, tt.expectedDeviation, reset.Deviation)
+			assert.Equal(t, tt.expectedVolatility, reset.Volatility)
+		})
+	}
+}
+
+func TestSeason_ResetRating(t *testing.T) {
+	season := season_entities.Season{ID: uuid.New(), Reset: season_entities.ResetRule{Mode: season_entities.ResetModeHard}}
+
+	r := rating_entities.Rating{Rating: 1600, Deviation: 80, Volatility: 0.06, MatchesPlayed: 30, SeasonMatchesPlayed: 12}
+
+	reset := season.ResetRating(r)
+	assert.Equal(t, float64(rating_entities.InitialRating), reset.Rating)
+	assert.Equal(t, &season.ID, reset.SeasonID)
+	assert.Equal(t, 0, reset.SeasonMatchesPlayed)
+	assert.Equal(t, 30, reset.MatchesPlayed)
+
+	// ratings already in the season aren't reset again
+	reset.Rating = 1100
+	reset.SeasonMatchesPlayed = 3
+	assert.Equal(t, reset, season.ResetRating(reset))
+}
+
+func TestSeason_RewardTier(t *testing.T) {
+	season := season_entities.Season{RewardTiers: []season_entities.RewardTier{
+		{Name: "silver", MinRating: 1000, MinMatches: 10},
+		{Name: "gold", MinRating: 1400, MinMatches: 10},
+		{Name: "participant", MinRating: 0, MinMatches: 1},
+	}}
+
+	season.SortRewardTiers()
+
+	tier := func(rating float64, matches int) string {
+		t := season.RewardTier(rating_entities.Rating{Rating: rating, SeasonMatchesPlayed: matches})
+		if t == nil {
+			return ""
+		}
+
+		return t.Name
+	}
+
+	assert.Equal(t, "gold", tier(1450, 20))
+	assert.Equal(t, "silver", tier(1200, 10))
+	assert.Equal(t, "participant", tier(1450, 9))
+	assert.Equal(t, "", tier(1450, 0))
+}
+
+func TestSeason_Validate(t *testing.T) {
+	startsAt := time.Date(2026, 11, 1, 0, 0, 0, 0, time.UTC)
+
+	valid := season_entities.Season{
+		GameID:      common.CS2_GAME_ID,
+		Name:        "Season 1",
+		StartsAt:    startsAt,
+		EndsAt:      startsAt.AddDate(0, 2, 0),
+		Reset:       season_entities.ResetRule{Mode: season_entities.ResetModeSoft, Factor: 0.5, Deviation: 250},
+		RewardTiers: []season_entities.RewardTier{{Name: "gold", MinRating: 1400}},
+	}
+
+	assert.NoError(t, valid.Validate())
+
+	tests := []struct {
+		name   string
+		update func(s *season_entities.Season)
+	}{
+		{"Missing Name", func(s *season_entities.Season) { s.Name = "" }},
+		{"Ends Before It Starts", func(s *season_entities.Season) { s.EndsAt = s.StartsAt }},
+		{"Unknown Reset Mode", func(s *season_entities.Season) { s.Reset.Mode = "partial" }},
+		{"Soft Reset Factor Out Of Range", func(s *season_entities.Season) { s.Reset.Factor = 1.5 }},
+		{"Duplicate Tier", func(s *season_entities.Season) { s.RewardTiers = append(s.RewardTiers, s.RewardTiers[0]) }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := valid
+			s.RewardTiers = append([]season_entities.RewardTier(nil), valid.RewardTiers...)
+			tt.update(&s)
+
+			assert.Error(t, s.Validate())
+		})
+	}
+}
+
+func TestSeason_Overlaps(t *testing.T) {
+	startsAt := time.Date(2026, 11, 1, 0, 0, 0, 0, time.UTC)
+
+	s := season_entities.Season{StartsAt: startsAt, EndsAt: startsAt.AddDate(0, 1, 0)}
+
+	assert.True(t, s.Overlaps(season_entities.Season{StartsAt: startsAt.AddDate(0, 0, 20), EndsAt: startsAt.AddDate(0, 2, 0)}))
+	assert.True(t, s.Overlaps(season_entities.Season{StartsAt: startsAt.AddDate(0, 0, -10), EndsAt: startsAt.AddDate(0, 0, 1)}))
+
+	// back to back
+	assert.False(t, s.Overlaps(season_entities.Season{StartsAt: s.EndsAt, EndsAt: s.EndsAt.AddDate(0, 1, 0)}))
+}
@@ -0,0 +1,52 @@
+package season
+
+import (
+	"fmt"
+
+	"github.com/google/uuid"
+)
+
+// Season Not Found Error
+type SeasonNotFoundError struct {
+	Message string
+}
+
+func (e *SeasonNotFoundError) Error() string {
+	return e.Message
+}
+
+func NewSeasonNotFoundError(seasonID uuid.UUID) *SeasonNotFoundError {
+	return &SeasonNotFoundError{
+		Message: fmt.Sprintf("season %s not found", seasonID),
+	}
+}
+
+// Invalid Season Error (ie: ends before it starts, unknown reset mode)
+type InvalidSeasonError struct {
+	Message string
+}
+
+func (e *InvalidSeasonError) Error() string {
+	return e.Message
+}
+
+func NewInvalidSeasonError(message string) *InvalidSeasonError {
+	return &InvalidSeasonError{
+		Message: message,
+	}
+}
+
+// Season Overlap Error (the season overlaps another season of the game)
+type SeasonOverlapError struct {
+	Message string
+}
+
+func (e *SeasonOverlapError) Error() string {
+	return e.Message
+}
+
+func NewSeasonOverlapError(seasonID uuid.UUID) *SeasonOverlapError {
+	return &SeasonOverlapError{
+		Message: fmt.Sprintf("season overlaps season %s of the game", seasonID),
+	}
+}
@@ -0,0 +1,18 @@
+package season_in
+
+import (
+	"context"
+
+	season_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/season/entities"
+)
+
+// ScheduleSeasonCommand schedules a season of a game, a *season.SeasonOverlapError when it overlaps another season of the game.
+type ScheduleSeasonCommand interface {
+	Exec(ctx context.Context, season season_entities.Season) (*season_entities.Season, error)
+}
+
+// TransitionSeasonsCommand ends the active seasons past their end (rewarding their players) and activates the scheduled seasons that
+// started, returning the number of seasons transitioned.
+type TransitionSeasonsCommand interface {
+	Exec(ctx context.Context) (int, error)
+}
@@ -0,0 +1,17 @@
+package season_out
+
+import (
+	"context"
+
+	season_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/season/entities"
+)
+
+type SeasonWriter interface {
+	Create(ctx context.Context, season *season_entities.Season) (*season_entities.Season, error)
+	Update(ctx context.Context, season *season_entities.Season) (*season_entities.Season, error)
+}
+
+type SeasonRewardWriter interface {
+	// Upsert writes the rewards in a single unordered bulk (by their stable IDs), so ending a season twice overwrites its rewards.
+	Upsert(ctx context.Context, rewards []season_entities.SeasonReward) error
+}
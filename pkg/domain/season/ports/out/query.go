@@ -0,0 +1,14 @@
+package season_out
+
+import (
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	season_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/season/entities"
+)
+
+type SeasonReader interface {
+	common.Searchable[season_entities.Season]
+}
+
+type SeasonRewardReader interface {
+	common.Searchable[season_entities.SeasonReward]
+}
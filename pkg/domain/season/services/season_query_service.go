@@ -0,0 +1,53 @@
+package season_services
+
+import (
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	season_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/season/entities"
+	season_in "github.com/psavelis/team-pro/replay-api/pkg/domain/season/ports/in"
+	season_out "github.com/psavelis/team-pro/replay-api/pkg/domain/season/ports/out"
+)
+
+type SeasonQueryService struct {
+	common.BaseQueryService[season_entities.Season]
+}
+
+// NewSeasonQueryService serves the seasons scheduled by the client application in context.
+func NewSeasonQueryService(seasonReader season_out.SeasonReader) season_in.SeasonReader {
+	queryableFields := map[string]bool{
+		"ID":            true,
+		"GameID":        true,
+		"Name":          true,
+		"StartsAt":      true,
+		"EndsAt":        true,
+		"Status":        true,
+		"ResourceOwner": common.DENY,
+		"CreatedAt":     true,
+		"UpdatedAt":     true,
+	}
+
+	readableFields := map[string]bool{
+		"ID":            true,
+		"GameID":        true,
+		"Name":          true,
+		"StartsAt":      true,
+		"EndsAt":        true,
+		"Status":        true,
+		"Reset":         true,
+		"RewardTiers":   true,
+		"ActivatedAt":   true,
+		"EndedAt":       true,
+		"ResourceOwner": common.DENY,
+		"CreatedAt":     true,
+		"UpdatedAt":     true,
+	}
+
+	return &SeasonQueryService{
+		common.BaseQueryService[season_entities.Season]{
+			Reader:          seasonReader.(common.Searchable[season_entities.Season]),
+			QueryableFields: queryableFields,
+			ReadableFields:  readableFields,
+			MaxPageSize:     100,
+			Audience:        common.ClientApplicationAudienceIDKey,
+		},
+	}
+}
@@ -0,0 +1,42 @@
+package season_services
+
+import (
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	season_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/season/entities"
+	season_in "github.com/psavelis/team-pro/replay-api/pkg/domain/season/ports/in"
+	season_out "github.com/psavelis/team-pro/replay-api/pkg/domain/season/ports/out"
+)
+
+// NewSeasonRewardQueryService serves the rewards of the seasons ended by the client application in context.
+func NewSeasonRewardQueryService(rewardReader season_out.SeasonRewardReader) season_in.SeasonRewardReader {
+	queryableFields := map[string]bool{
+		"ID":            true,
+		"SeasonID":      true,
+		"GameID":        true,
+		"PlayerID":      true,
+		"Tier":          true,
+		"Rating":        true,
+		"ResourceOwner": common.DENY,
+		"CreatedAt":     true,
+	}
+
+	readableFields := map[string]bool{
+		"ID":            true,
+		"SeasonID":      true,
+		"GameID":        true,
+		"PlayerID":      true,
+		"Tier":          true,
+		"Rating":        true,
+		"MatchesPlayed": true,
+		"ResourceOwner": common.DENY,
+		"CreatedAt":     true,
+	}
+
+	return &common.BaseQueryService[season_entities.SeasonReward]{
+		Reader:          rewardReader.(common.Searchable[season_entities.SeasonReward]),
+		QueryableFields: queryableFields,
+		ReadableFields:  readableFields,
+		MaxPageSize:     100,
+		Audience:        common.ClientApplicationAudienceIDKey,
+	}
+}
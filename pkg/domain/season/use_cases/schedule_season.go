@@ -0,0 +1,93 @@
+package season_use_cases
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	"github.com/psavelis/team-pro/replay-api/pkg/domain/season"
+	season_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/season/entities"
+	season_in "github.com/psavelis/team-pro/replay-api/pkg/domain/season/ports/in"
+	season_out "github.com/psavelis/team-pro/replay-api/pkg/domain/season/ports/out"
+)
+
+// max seasons read per game (scheduled or active) when looking for overlaps, and per transition run
+const SeasonBatchSize = 100
+
+type ScheduleSeasonUseCase struct {
+	SeasonReader season_out.SeasonReader
+	SeasonWriter season_out.SeasonWriter
+}
+
+func NewScheduleSeasonUseCase(seasonReader season_out.SeasonReader, seasonWriter season_out.SeasonWriter) season_in.ScheduleSeasonCommand {
+	return &ScheduleSeasonUseCase{
+		SeasonReader: seasonReader,
+		SeasonWriter: seasonWriter,
+	}
+}
+
+func (usecase *ScheduleSeasonUseCase) Exec(ctx context.Context, s season_entities.Season) (*season_entities.Season, error) {
+	if s.Reset.Mode == "" {
+		s.Reset = season_entities.ResetRule{
+			Mode:      season_entities.ResetModeSoft,
+			Factor:    season_entities.DefaultSoftResetFactor,
+			Deviation: season_entities.DefaultSoftResetDeviation,
+		}
+	}
+
+	if s.RewardTiers == nil {
+		s.RewardTiers = []season_entities.RewardTier{}
+	}
+
+	err := s.Validate()
+	if err != nil {
+		return nil, season.NewInvalidSeasonError(err.Error())
+	}
+
+	now := time.Now().UTC()
+
+	if !s.EndsAt.After(now) {
+		return nil, season.NewInvalidSeasonError("season must end in the future")
+	}
+
+	s.StartsAt = s.StartsAt.UTC()
+	s.EndsAt = s.EndsAt.UTC()
+
+	seasons, err := usecase.SeasonReader.Search(ctx, common.NewSearchByValues(ctx, []common.SearchableValue{
+		{Field: "GameID", Values: []interface{}{s.GameID}},
+		{Field: "Status", Values: []interface{}{season_entities.SeasonStatusScheduled, season_entities.SeasonStatusActive}, Operator: common.InOperator},
+	}, common.NewSearchResultOptions(0, SeasonBatchSize), common.ClientApplicationAudienceIDKey))
+
+	if err != nil {
+		slog.ErrorContext(ctx, "unable to search seasons of the game", "gameID", s.GameID, "err", err)
+		return nil, err
+	}
+
+	for _, other := range seasons {
+		if s.Overlaps(other) {
+			return nil, season.NewSeasonOverlapError(other.ID)
+		}
+	}
+
+	s.SortRewardTiers()
+
+	s.ID = uuid.New()
+	s.Status = season_entities.SeasonStatusScheduled
+	s.ActivatedAt = nil
+	s.EndedAt = nil
+	s.ResourceOwner = common.GetResourceOwner(ctx)
+	s.CreatedAt = now
+	s.UpdatedAt = now
+
+	created, err := usecase.SeasonWriter.Create(ctx, &s)
+	if err != nil {
+		slog.ErrorContext(ctx, "unable to create season", "name", s.Name, "gameID", s.GameID, "err", err)
+		return nil, err
+	}
+
+	slog.InfoContext(ctx, "season scheduled", "seasonID", created.ID, "gameID", created.GameID, "startsAt", created.StartsAt, "endsAt", created.EndsAt)
+
+	return created, nil
+}
@@ -0,0 +1,205 @@
+package season_use_cases_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	rating_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/rating/entities"
+	"github.com/psavelis/team-pro/replay-api/pkg/domain/season"
+	season_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/season/entities"
+	season_use_cases "github.com/psavelis/team-pro/replay-api/pkg/domain/season/use_cases"
+	"github.com/stretchr/testify/assert"
+)
+
+// mockSeasonStore keeps the seasons by ID, searches return all of them.
+type mockSeasonStore struct {
+	seasons map[uuid.UUID]season_entities.Season
+}
+
+func newMockSeasonStore(seasons ...season_entities.Season) *mockSeasonStore {
+	m := &mockSeasonStore{seasons: make(map[uuid.UUID]season_entities.Season)}
+	for _, s := range seasons {
+		m.seasons[s.ID] = s
+	}
+
+	return m
+}
+
+func (m *mockSeasonStore) Search(ctx context.Context, s common.Search) ([]season_entities.Season, error) {
+	seasons := make([]season_entities.Season, 0, len(m.seasons))
+	for _, s := range m.seasons {
+		seasons = append(seasons, s)
+	}
+
+	return seasons, nil
+}
+
+func (m *mockSeasonStore) Compile(ctx context.Context, searchParams []common.SearchAggregation, resultOptions common.SearchResultOptions) (*common.Search, error) {
+	return nil, nil
+}
+
+func (m *mockSeasonStore) Create(ctx context.Context, s *season_entities.Season) (*season_entities.Season, error) {
+	m.seasons[s.ID] = *s
+	return s, nil
+}
+
+func (m *mockSeasonStore) Update(ctx context.Context, s *season_entities.Season) (*season_entities.Season, error) {
+	m.seasons[s.ID] = *s
+	return s, nil
+}
+
+type mockRewardStore struct {
+	rewards map[uuid.UUID]season_entities.SeasonReward
+	err     error
+}
+
+func (m *mockRewardStore) Upsert(ctx context.Context, rewards []season_entities.SeasonReward) error {
+	if m.err != nil {
+		return m.err
+	}
+
+	for _, r := range rewards {
+		m.rewards[r.ID] = r
+	}
+
+	return nil
+}
+
+type mockRatingReader struct {
+	ratings []rating_entities.Rating
+}
+
+func (m mockRatingReader) Search(ctx context.Context, s common.Search) ([]rating_entities.Rating, error) {
+	return m.ratings, nil
+}
+
+func (m mockRatingReader) Compile(ctx context.Context, searchParams []common.SearchAggregation, resultOptions common.SearchResultOptions) (*common.Search, error) {
+	return nil, nil
+}
+
+func testContext() context.Context {
+	return common.WithResourceOwner(context.Background(), common.ResourceOwner{TenantID: common.TeamPROTenantID, ClientID: common.ServerClientID})
+}
+
+func TestScheduleSeason(t *testing.T) {
+	startsAt := time.Now().UTC().Add(24 * time.Hour).Truncate(time.Second)
+
+	existing := season_entities.Season{ID: uuid.New(), GameID: common.CS2_GAME_ID, Status: season_entities.SeasonStatusScheduled, StartsAt: startsAt, EndsAt: startsAt.AddDate(0, 1, 0)}
+
+	tests := []struct {
+		name        string
+		season      season_entities.Season
+		expectedErr interface{}
+	}{
+		{
+			name:   "Scheduled After The Existing Season",
+			season: season_entities.Season{GameID: common.CS2_GAME_ID, Name: "Season 2", StartsAt: existing.EndsAt, EndsAt: existing.EndsAt.AddDate(0, 1, 0)},
+		},
+		{
+			name:        "Overlapping",
+			season:      season_entities.Season{GameID: common.CS2_GAME_ID, Name: "Season 2", StartsAt: startsAt.AddDate(0, 0, 7), EndsAt: startsAt.AddDate(0, 2, 0)},
+			expectedErr: &season.SeasonOverlapError{},
+		},
+		{
+			name:        "Ended",
+			season:      season_entities.Season{GameID: common.CS2_GAME_ID, Name: "Season 0", StartsAt: startsAt.AddDate(0, -2, 0), EndsAt: startsAt.AddDate(0, -1, 0)},
+			expectedErr: &season.InvalidSeasonError{},
+		},
+		{
+			name:        "Invalid",
+			season:      season_entities.Season{GameID: common.CS2_GAME_ID, StartsAt: existing.EndsAt, EndsAt: existing.EndsAt.AddDate(0, 1, 0)},
+			expectedErr: &season.InvalidSeasonError{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := newMockSeasonStore(existing)
+
+			usecase := season_use_cases.NewScheduleSeasonUseCase(store, store)
+
+			scheduled, err := usecase.Exec(testContext(), tt.season)
+
+			if tt.expectedErr != nil {
+				assert.IsType(t, tt.expectedErr, err)
+				assert.Len(t, store.seasons, 1)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, season_entities.SeasonStatusScheduled, scheduled.Status)
+			assert.Equal(t, season_entities.ResetModeSoft, scheduled.Reset.Mode)
+			assert.Equal(t, season_entities.DefaultSoftResetFactor, scheduled.Reset.Factor)
+			assert.NotEqual(t, uuid.Nil, scheduled.ID)
+			assert.Len(t, store.seasons, 2)
+		})
+	}
+}
+
+func TestTransitionSeasons(t *testing.T) {
+	now := time.Now().UTC()
+
+	ending := season_entities.Season{
+		ID:          uuid.New(),
+		GameID:      common.CS2_GAME_ID,
+		Status:      season_entities.SeasonStatusActive,
+		StartsAt:    now.AddDate(0, -1, 0),
+		EndsAt:      now.Add(-time.Minute),
+		RewardTiers: []season_entities.RewardTier{{Name: "gold", MinRating: 1400, MinMatches: 5}, {Name: "participant", MinMatches: 1}},
+	}
+
+	starting := season_entities.Season{ID: uuid.New(), GameID: common.CS2_GAME_ID, Status: season_entities.SeasonStatusScheduled, StartsAt: now.Add(-time.Minute), EndsAt: now.AddDate(0, 1, 0)}
+	missed := season_entities.Season{ID: uuid.New(), GameID: common.VLRNT_GAME_ID, Status: season_entities.SeasonStatusScheduled, StartsAt: now.AddDate(0, -2, 0), EndsAt: now.AddDate(0, -1, 0)}
+	upcoming := season_entities.Season{ID: uuid.New(), GameID: common.CS2_GAME_ID, Status: season_entities.SeasonStatusScheduled, StartsAt: now.AddDate(0, 1, 0), EndsAt: now.AddDate(0, 2, 0)}
+
+	ratings := []rating_entities.Rating{
+		{ID: uuid.New(), SubjectID: uuid.New(), GameID: common.CS2_GAME_ID, Rating: 1500, SeasonID: &ending.ID, SeasonMatchesPlayed: 10},
+		{ID: uuid.New(), SubjectID: uuid.New(), GameID: common.CS2_GAME_ID, Rating: 1500, SeasonID: &ending.ID, SeasonMatchesPlayed: 2},
+		{ID: uuid.New(), SubjectID: uuid.New(), GameID: common.CS2_GAME_ID, Rating: 1500, SeasonID: &ending.ID},
+	}
+
+	t.Run("Transitioned", func(t *testing.T) {
+		store := newMockSeasonStore(ending, starting, missed, upcoming)
+		rewards := &mockRewardStore{rewards: make(map[uuid.UUID]season_entities.SeasonReward)}
+
+		usecase := season_use_cases.NewTransitionSeasonsUseCase(store, store, rewards, mockRatingReader{ratings: ratings})
+
+		transitioned, err := usecase.Exec(testContext())
+
+		assert.NoError(t, err)
+		assert.Equal(t, 3, transitioned)
+
+		assert.Equal(t, season_entities.SeasonStatusEnded, store.seasons[ending.ID].Status)
+		assert.NotNil(t, store.seasons[ending.ID].EndedAt)
+		assert.Equal(t, season_entities.SeasonStatusActive, store.seasons[starting.ID].Status)
+		assert.NotNil(t, store.seasons[starting.ID].ActivatedAt)
+		assert.Equal(t, season_entities.SeasonStatusEnded, store.seasons[missed.ID].Status)
+		assert.Equal(t, season_entities.SeasonStatusScheduled, store.seasons[upcoming.ID].Status)
+
+		if assert.Len(t, rewards.rewards, 2) {
+			gold := rewards.rewards[season_entities.SeasonRewardID(ending.ID, ratings[0].SubjectID)]
+			assert.Equal(t, "gold", gold.Tier)
+			assert.Equal(t, 10, gold.MatchesPlayed)
+
+			participant := rewards.rewards[season_entities.SeasonRewardID(ending.ID, ratings[1].SubjectID)]
+			assert.Equal(t, "participant", participant.Tier)
+		}
+	})
+
+	t.Run("Unrewarded Season Stays Active", func(t *testing.T) {
+		store := newMockSeasonStore(ending)
+		rewards := &mockRewardStore{rewards: make(map[uuid.UUID]season_entities.SeasonReward), err: errors.New("mongo unreachable")}
+
+		usecase := season_use_cases.NewTransitionSeasonsUseCase(store, store, rewards, mockRatingReader{ratings: ratings})
+
+		transitioned, err := usecase.Exec(testContext())
+
+		assert.Error(t, err)
+		assert.Equal(t, 0, transitioned)
+		assert.Equal(t, season_entities.SeasonStatusActive, store.seasons[ending.ID].Status)
+	})
+}
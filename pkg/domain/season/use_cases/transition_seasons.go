@@ -0,0 +1,178 @@
+package season_use_cases
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	rating_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/rating/entities"
+	rating_out "github.com/psavelis/team-pro/replay-api/pkg/domain/rating/ports/out"
+	season_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/season/entities"
+	season_in "github.com/psavelis/team-pro/replay-api/pkg/domain/season/ports/in"
+	season_out "github.com/psavelis/team-pro/replay-api/pkg/domain/season/ports/out"
+)
+
+// ratings rewarded per page when a season ends
+const SeasonRewardPageSize = 500
+
+// TransitionSeasonsUseCase moves the seasons along their schedule: active seasons past their end are ended (their players rewarded by
+// their final rating in the season), then the scheduled seasons that started are activated. Ratings aren't reset here, but on the
+// first match of each player in the new season (see season_entities.Season.ResetRating).
+type TransitionSeasonsUseCase struct {
+	SeasonReader season_out.SeasonReader
+	SeasonWriter season_out.SeasonWriter
+	RewardWriter season_out.SeasonRewardWriter
+	RatingReader rating_out.RatingReader
+}
+
+func NewTransitionSeasonsUseCase(seasonReader season_out.SeasonReader, seasonWriter season_out.SeasonWriter, rewardWriter season_out.SeasonRewardWriter, ratingReader rating_out.RatingReader) season_in.TransitionSeasonsCommand {
+	return &TransitionSeasonsUseCase{
+		SeasonReader: seasonReader,
+		SeasonWriter: seasonWriter,
+		RewardWriter: rewardWriter,
+		RatingReader: ratingReader,
+	}
+}
+
+func (usecase *TransitionSeasonsUseCase) Exec(ctx context.Context) (int, error) {
+	s := common.NewSearchByValues(ctx, []common.SearchableValue{
+		{Field: "Status", Values: []interface{}{season_entities.SeasonStatusScheduled, season_entities.SeasonStatusActive}, Operator: common.InOperator},
+	}, common.NewSearchResultOptions(0, SeasonBatchSize), common.ClientApplicationAudienceIDKey)
+	s.SortOptions = []common.SearchSortOption{{Field: "StartsAt", Direction: common.AscendingIDKey}}
+
+	seasons, err := usecase.SeasonReader.Search(ctx, s)
+	if err != nil {
+		slog.ErrorContext(ctx, "unable to search seasons", "err", err)
+		return 0, err
+	}
+
+	now := time.Now().UTC()
+
+	var errs []error
+
+	transitioned := 0
+
+	// seasons are ended first: the next season of a game starts once its predecessor ended
+	for _, season := range seasons {
+		if season.Status != season_entities.SeasonStatusActive || now.Before(season.EndsAt) {
+			continue
+		}
+
+		err = usecase.end(ctx, season, now)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+
+		transitioned++
+	}
+
+	for _, season := range seasons {
+		if season.Status != season_entities.SeasonStatusScheduled || now.Before(season.StartsAt) {
+			continue
+		}
+
+		// missed altogether (ie: the scheduler was down), nobody played it
+		if !now.Before(season.EndsAt) {
+			err = usecase.end(ctx, season, now)
+		} else {
+			err = usecase.activate(ctx, season, now)
+		}
+
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+
+		transitioned++
+	}
+
+	return transitioned, errors.Join(errs...)
+}
+
+func (usecase *TransitionSeasonsUseCase) activate(ctx context.Context, season season_entities.Season, now time.Time) error {
+	season.Status = season_entities.SeasonStatusActive
+	season.ActivatedAt = &now
+	season.UpdatedAt = now
+
+	_, err := usecase.SeasonWriter.Update(ctx, &season)
+	if err != nil {
+		slog.ErrorContext(ctx, "unable to activate season", "seasonID", season.ID, "err", err)
+		return err
+	}
+
+	slog.InfoContext(ctx, "season activated", "seasonID", season.ID, "gameID", season.GameID, "reset", season.Reset.Mode)
+
+	return nil
+}
+
+// end rewards the players of the season before ending it, a season that can't be rewarded stays active until the next run.
+func (usecase *TransitionSeasonsUseCase) end(ctx context.Context, season season_entities.Season, now time.Time) error {
+	rewarded := 0
+
+	if season.Status == season_entities.SeasonStatusActive && len(season.RewardTiers) > 0 {
+		var err error
+
+		rewarded, err = usecase.reward(ctx, season, now)
+		if err != nil {
+			return err
+		}
+	}
+
+	season.Status = season_entities.SeasonStatusEnded
+	season.EndedAt = &now
+	season.UpdatedAt = now
+
+	_, err := usecase.SeasonWriter.Update(ctx, &season)
+	if err != nil {
+		slog.ErrorContext(ctx, "unable to end season", "seasonID", season.ID, "err", err)
+		return err
+	}
+
+	slog.InfoContext(ctx, "season ended", "seasonID", season.ID, "gameID", season.GameID, "rewarded", rewarded)
+
+	return nil
+}
+
+// reward grants each player rated in the season the best tier their final rating reached.
+func (usecase *TransitionSeasonsUseCase) reward(ctx context.Context, season season_entities.Season, now time.Time) (int, error) {
+	rewarded := 0
+
+	for skip := uint(0); ; skip += SeasonRewardPageSize {
+		s := common.NewSearchByValues(ctx, []common.SearchableValue{
+			{Field: "Subject", Values: []interface{}{rating_entities.RatingSubjectPlayer}},
+			{Field: "GameID", Values: []interface{}{season.GameID}},
+			{Field: "SeasonID", Values: []interface{}{season.ID}},
+		}, common.NewSearchResultOptions(skip, SeasonRewardPageSize), common.ClientApplicationAudienceIDKey)
+		s.SortOptions = []common.SearchSortOption{{Field: "ID", Direction: common.AscendingIDKey}}
+
+		ratings, err := usecase.RatingReader.Search(ctx, s)
+		if err != nil {
+			slog.ErrorContext(ctx, "unable to read season ratings", "seasonID", season.ID, "skip", skip, "err", err)
+			return rewarded, err
+		}
+
+		rewards := make([]season_entities.SeasonReward, 0)
+		for _, r := range ratings {
+			if tier := season.RewardTier(r); tier != nil {
+				rewards = append(rewards, season_entities.NewSeasonReward(season, r, *tier, now))
+			}
+		}
+
+		if len(rewards) > 0 {
+			err = usecase.RewardWriter.Upsert(ctx, rewards)
+			if err != nil {
+				slog.ErrorContext(ctx, "unable to write season rewards", "seasonID", season.ID, "skip", skip, "err", err)
+				return rewarded, err
+			}
+
+			rewarded += len(rewards)
+		}
+
+		if len(ratings) < SeasonRewardPageSize {
+			return rewarded, nil
+		}
+	}
+}
@@ -0,0 +1,97 @@
+package common
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// MaxSortFields caps how many keys a single search may be sorted by.
+const MaxSortFields = 3
+
+// SearchSortOption orders the results of a search by a queryable field. A search sorted by multiple options orders the ties of each
+// option by the next one.
+type SearchSortOption struct {
+	Field     string           `json:"field" bson:"field"`
+	Direction SortDirectionKey `json:"direction" bson:"direction"`
+}
+
+// UnmarshalJSON accepts the direction as a number (1/-1) or by name ("asc"/"desc").
+func (d *SortDirectionKey) UnmarshalJSON(b []byte) error {
+	var name string
+	if err := json.Unmarshal(b, &name); err == nil {
+		switch strings.ToLower(name) {
+		case "asc":
+			*d = AscendingIDKey
+		case "desc":
+			*d = DescendingIDKey
+		default:
+			return fmt.Errorf("invalid sort direction '%s' (expected 'asc' or 'desc')", name)
+		}
+
+		return nil
+	}
+
+	var n int
+	if err := json.Unmarshal(b, &n); err != nil {
+		return fmt.Errorf("invalid sort direction %s", b)
+	}
+
+	*d = SortDirectionKey(n)
+
+	return nil
+}
+
+// ParseSortOptions parses a sort query parameter: comma separated fields, descending when prefixed with '-' (ie: "-CreatedAt,Name").
+func ParseSortOptions(param string) ([]SearchSortOption, error) {
+	if strings.TrimSpace(param) == "" {
+		return nil, nil
+	}
+
+	var options []SearchSortOption
+	for _, field := range strings.Split(param, ",") {
+		field = strings.TrimSpace(field)
+
+		option := SearchSortOption{Field: field, Direction: AscendingIDKey}
+		if strings.HasPrefix(field, "-") {
+			option = SearchSortOption{Field: strings.TrimPrefix(field, "-"), Direction: DescendingIDKey}
+		}
+
+		if option.Field == "" {
+			return nil, fmt.Errorf("invalid sort '%s': empty field", param)
+		}
+
+		options = append(options, option)
+	}
+
+	return options, nil
+}
+
+func ValidateSortOptions(options []SearchSortOption, queryableFields map[string]bool) error {
+	if len(options) > MaxSortFields {
+		return fmt.Errorf("at most %d sort fields can be requested (got %d)", MaxSortFields, len(options))
+	}
+
+	seen := make(map[string]bool, len(options))
+	for _, option := range options {
+		if strings.HasSuffix(option.Field, ".*") {
+			return fmt.Errorf("sorting on wildcard field '%s' is not permitted", option.Field)
+		}
+
+		if !isQueryableField(option.Field, queryableFields) {
+			return fmt.Errorf("sorting on field '%s' is not permitted", option.Field)
+		}
+
+		if option.Direction != AscendingIDKey && option.Direction != DescendingIDKey {
+			return fmt.Errorf("invalid sort direction %d on field '%s' (expected %d or %d)", option.Direction, option.Field, AscendingIDKey, DescendingIDKey)
+		}
+
+		if seen[option.Field] {
+			return fmt.Errorf("field '%s' is sorted more than once", option.Field)
+		}
+
+		seen[option.Field] = true
+	}
+
+	return nil
+}
@@ -0,0 +1,133 @@
+package common_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/google/uuid"
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateSortOptions(t *testing.T) {
+	queryableFields := map[string]bool{"CreatedAt": true, "Name": true, "Status": true, "Region": true, "Secret": false, "CustomFields": true}
+
+	asc := func(field string) common.SearchSortOption {
+		return common.SearchSortOption{Field: field, Direction: common.AscendingIDKey}
+	}
+
+	tests := []struct {
+		name          string
+		options       []common.SearchSortOption
+		expectedError string
+	}{
+		{
+			name:    "No Sort",
+			options: nil,
+		},
+		{
+			name:    "Multiple Keys",
+			options: []common.SearchSortOption{{Field: "CreatedAt", Direction: common.DescendingIDKey}, asc("Name")},
+		},
+		{
+			name:    "Custom Field",
+			options: []common.SearchSortOption{asc("CustomFields.team_code")},
+		},
+		{
+			name:          "Not Queryable",
+			options:       []common.SearchSortOption{asc("Secret")},
+			expectedError: "sorting on field 'Secret' is not permitted",
+		},
+		{
+			name:          "Unknown Field",
+			options:       []common.SearchSortOption{asc("created_at")},
+			expectedError: "sorting on field 'created_at' is not permitted",
+		},
+		{
+			name:          "Wildcard",
+			options:       []common.SearchSortOption{asc("Region.*")},
+			expectedError: "sorting on wildcard field 'Region.*' is not permitted",
+		},
+		{
+			name:          "Invalid Direction",
+			options:       []common.SearchSortOption{{Field: "Name"}},
+			expectedError: "invalid sort direction 0 on field 'Name' (expected 1 or -1)",
+		},
+		{
+			name:          "Repeated Field",
+			options:       []common.SearchSortOption{asc("Name"), {Field: "Name", Direction: common.DescendingIDKey}},
+			expectedError: "field 'Name' is sorted more than once",
+		},
+		{
+			name:          "Too Many Keys",
+			options:       []common.SearchSortOption{asc("CreatedAt"), asc("Name"), asc("Status"), asc("Region")},
+			expectedError: "at most 3 sort fields can be requested (got 4)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := common.ValidateSortOptions(tt.options, queryableFields)
+			if tt.expectedError != "" {
+				assert.EqualError(t, err, tt.expectedError)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestParseSortOptions(t *testing.T) {
+	options, err := common.ParseSortOptions("-CreatedAt, Name")
+	assert.NoError(t, err)
+	assert.Equal(t, []common.SearchSortOption{{Field: "CreatedAt", Direction: common.DescendingIDKey}, {Field: "Name", Direction: common.AscendingIDKey}}, options)
+
+	options, err = common.ParseSortOptions("")
+	assert.NoError(t, err)
+	assert.Nil(t, options)
+
+	_, err = common.ParseSortOptions("Name,-")
+	assert.Error(t, err)
+}
+
+func TestSearchSortOption_UnmarshalJSON(t *testing.T) {
+	var options []common.SearchSortOption
+	err := json.Unmarshal([]byte(`[{"field": "CreatedAt", "direction": "desc"}, {"field": "Name", "direction": 1}]`), &options)
+	assert.NoError(t, err)
+	assert.Equal(t, []common.SearchSortOption{{Field: "CreatedAt", Direction: common.DescendingIDKey}, {Field: "Name", Direction: common.AscendingIDKey}}, options)
+
+	err = json.Unmarshal([]byte(`[{"field": "Name", "direction": "up"}]`), &options)
+	assert.Error(t, err)
+
+	// the sort of the result options becomes the sort of the search
+	var resultOptions common.SearchResultOptions
+	assert.NoError(t, json.Unmarshal([]byte(`{"limit": 10, "sort": [{"field": "Name", "direction": "asc"}]}`), &resultOptions))
+
+	ctx := common.WithResourceOwner(context.Background(), common.ResourceOwner{TenantID: uuid.New(), UserID: uuid.New()})
+
+	s := common.NewSearchByValues(ctx, nil, resultOptions, common.UserAudienceIDKey)
+	assert.Equal(t, resultOptions.Sort, s.SortOptions)
+}
+
+func TestBaseQueryService_Compile_Sort(t *testing.T) {
+	ctx := common.WithResourceOwner(context.Background(), common.ResourceOwner{TenantID: uuid.New(), ClientID: uuid.New()})
+
+	// the repository of a service may query fields the service denies
+	svc := &common.BaseQueryService[struct{}]{
+		QueryableFields: map[string]bool{"Name": true, "ResourceOwner": common.DENY},
+		ReadableFields:  map[string]bool{"Name": true},
+		Audience:        common.ClientApplicationAudienceIDKey,
+	}
+
+	params := []common.SearchAggregation{}
+
+	s, err := svc.Compile(ctx, params, common.SearchResultOptions{Limit: 10, Sort: []common.SearchSortOption{{Field: "Name", Direction: common.AscendingIDKey}}})
+	assert.NoError(t, err)
+	assert.NotNil(t, s)
+
+	for _, field := range []string{"ResourceOwner", "Secret"} {
+		_, err = svc.Compile(ctx, params, common.SearchResultOptions{Limit: 10, Sort: []common.SearchSortOption{{Field: field, Direction: common.DescendingIDKey}}})
+		assert.Error(t, err, field)
+	}
+}
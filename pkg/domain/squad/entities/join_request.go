@@ -0,0 +1,145 @@
+package squad_entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+)
+
+// JoinRequestKind tells who asked for the membership: the squad (invite, answered by the user) or the user (application, answered by
+// the squad owner).
+type JoinRequestKind string
+
+const (
+	JoinRequestKindInvite      JoinRequestKind = "invite"
+	JoinRequestKindApplication JoinRequestKind = "application"
+)
+
+type JoinRequestStatus string
+
+const (
+	JoinRequestStatusPending  JoinRequestStatus = "pending"
+	JoinRequestStatusAccepted JoinRequestStatus = "accepted"
+	JoinRequestStatusDeclined JoinRequestStatus = "declined"
+)
+
+// JoinRequest is an invite to, or an application for, the membership of a user in a squad. The user joins the squad once it's accepted.
+type JoinRequest struct {
+	ID            uuid.UUID            `json:"id" bson:"_id"`
+	SquadID       uuid.UUID            `json:"squad_id" bson:"squad_id"`
+	UserID        uuid.UUID            `json:"user_id" bson:"user_id"` // invited or applying
+	UserName      string               `json:"user_name" bson:"user_name"`
+	Kind          JoinRequestKind      `json:"kind" bson:"kind"`
+	Status        JoinRequestStatus    `json:"status" bson:"status"`
+	Message       string               `json:"message,omitempty" bson:"message"`
+	RequestedBy   uuid.UUID            `json:"requested_by" bson:"requested_by"`
+	RespondedBy   *uuid.UUID           `json:"responded_by,omitempty" bson:"responded_by"`
+	RespondedAt   *time.Time           `json:"responded_at,omitempty" bson:"responded_at"`
+	ResourceOwner common.ResourceOwner `json:"resource_owner" bson:"resource_owner"`
+	CreatedAt     time.Time            `json:"created_at" bson:"created_at"`
+	UpdatedAt     time.Time            `json:"updated_at" bson:"updated_at"`
+}
+
+func NewJoinRequest(squad Squad, kind JoinRequestKind, userID uuid.UUID, userName, message string, requestedBy uuid.UUID, now time.Time) *JoinRequest {
+	return &JoinRequest{
+		ID:            uuid.New(),
+		SquadID:       squad.ID,
+		UserID:        userID,
+		UserName:      userName,
+		Kind:          kind,
+		Status:        JoinRequestStatusPending,
+		Message:       message,
+		RequestedBy:   requestedBy,
+		ResourceOwner: common.ResourceOwner{TenantID: squad.ResourceOwner.TenantID, ClientID: squad.ResourceOwner.ClientID, UserID: userID},
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	}
+}
+
+func (r JoinRequest) GetID() uuid.UUID {
+	return r.ID
+}
+
+func (r JoinRequest) IsPending() bool {
+	return r.Status == JoinRequestStatusPending
+}
+
+// Responder returns the user answering the request: the invited user, or the owner of the squad for applications.
+func (r JoinRequest) Responder(squad Squad) uuid.UUID {
+	if r.Kind == JoinRequestKindInvite {
+		return r.UserID
+	}
+
+	return squad.OwnerID()
+}
+
+// Respond accepts or declines the (pending) request.
+func (r *JoinRequest) Respond(accept bool, respondedBy uuid.UUID, now time.Time) {
+	r.Status = JoinRequestStatusDeclined
+	if accept {
+		r.Status = JoinRequestStatusAccepted
+	}
+
+	r.RespondedBy = &respondedBy
+	r.RespondedAt = &now
+	r.UpdatedAt = now
+}
+
+// HistoryAction is the squad history entry of the current status of the request.
+func (r JoinRequest) HistoryAction() SquadHistoryAction {
+	if r.Kind == JoinRequestKindInvite {
+		switch r.Status {
+		case JoinRequestStatusAccepted:
+			return SquadMembershipRequestAccepted
+		case JoinRequestStatusDeclined:
+			return SquadMembershipRequestDeclined
+		default:
+			return SquadMembershipRequest
+		}
+	}
+
+	switch r.Status {
+	case JoinRequestStatusAccepted:
+		return SquadMemberRequestAccepted
+	case JoinRequestStatusDeclined:
+		return SquadMemberRequestDeclined
+	default:
+		return SquadMemberJoinRequest
+	}
+}
+
+// JoinRequestUpdated is published on each step of a join request (sent, accepted or declined), for the other party to be notified.
+type JoinRequestUpdated struct {
+	JoinRequestID uuid.UUID            `json:"join_request_id"`
+	SquadID       uuid.UUID            `json:"squad_id"`
+	SquadName     string               `json:"squad_name"`
+	UserID        uuid.UUID            `json:"user_id"`
+	UserName      string               `json:"user_name"`
+	Kind          JoinRequestKind      `json:"kind"`
+	Status        JoinRequestStatus    `json:"status"`
+	RecipientID   uuid.UUID            `json:"recipient_id"` // user to notify
+	ResourceOwner common.ResourceOwner `json:"resource_owner"`
+	UpdatedAt     time.Time            `json:"updated_at"`
+}
+
+func NewJoinRequestUpdated(request JoinRequest, squad Squad) JoinRequestUpdated {
+	// pending requests are notified to who answers them, answers to who asked
+	recipientID := request.Responder(squad)
+	if !request.IsPending() {
+		recipientID = request.RequestedBy
+	}
+
+	return JoinRequestUpdated{
+		JoinRequestID: request.ID,
+		SquadID:       squad.ID,
+		SquadName:     squad.Name,
+		UserID:        request.UserID,
+		UserName:      request.UserName,
+		Kind:          request.Kind,
+		Status:        request.Status,
+		RecipientID:   recipientID,
+		ResourceOwner: request.ResourceOwner,
+		UpdatedAt:     request.UpdatedAt,
+	}
+}
@@ -0,0 +1,83 @@
+package squad_entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+)
+
+type RecruitmentStatus string
+
+const (
+	RecruitmentStatusOpen   RecruitmentStatus = "open"
+	RecruitmentStatusClosed RecruitmentStatus = "closed"
+)
+
+// FreeAgent is a player looking for a team (LFT) in a game, a single open listing per user and game.
+type FreeAgent struct {
+	ID            uuid.UUID            `json:"id" bson:"_id"`
+	UserID        uuid.UUID            `json:"user_id" bson:"user_id"`
+	PlayerID      uuid.UUID            `json:"player_id" bson:"player_id"`
+	UserName      string               `json:"user_name" bson:"user_name"`
+	GameID        common.GameIDKey     `json:"game_id" bson:"game_id"`
+	Roles         []string             `json:"roles" bson:"roles"` // roles the player plays, most preferred first
+	Regions       []common.RegionIDKey `json:"regions" bson:"regions"`
+	MMR           int                  `json:"mmr" bson:"mmr"`                             // matchmaking rating of the player when listed
+	Availability  string               `json:"availability,omitempty" bson:"availability"` // ie: "weeknights, CET"
+	Message       string               `json:"message,omitempty" bson:"message"`
+	Status        RecruitmentStatus    `json:"status" bson:"status"`
+	ResourceOwner common.ResourceOwner `json:"resource_owner" bson:"resource_owner"`
+	CreatedAt     time.Time            `json:"created_at" bson:"created_at"`
+	UpdatedAt     time.Time            `json:"updated_at" bson:"updated_at"`
+}
+
+func (f FreeAgent) GetID() uuid.UUID {
+	return f.ID
+}
+
+// SquadOpening is a spot a squad is recruiting for, open to the players within its MMR range (MaxMMR 0 has no upper bound).
+type SquadOpening struct {
+	ID            uuid.UUID            `json:"id" bson:"_id"`
+	SquadID       uuid.UUID            `json:"squad_id" bson:"squad_id"`
+	SquadName     string               `json:"squad_name" bson:"squad_name"`
+	GameID        common.GameIDKey     `json:"game_id" bson:"game_id"`
+	Roles         []string             `json:"roles" bson:"roles"` // any role when empty
+	Regions       []common.RegionIDKey `json:"regions" bson:"regions"`
+	MinMMR        int                  `json:"min_mmr" bson:"min_mmr"`
+	MaxMMR        int                  `json:"max_mmr,omitempty" bson:"max_mmr"`
+	Message       string               `json:"message,omitempty" bson:"message"`
+	Status        RecruitmentStatus    `json:"status" bson:"status"`
+	PostedBy      uuid.UUID            `json:"posted_by" bson:"posted_by"`
+	ResourceOwner common.ResourceOwner `json:"resource_owner" bson:"resource_owner"`
+	CreatedAt     time.Time            `json:"created_at" bson:"created_at"`
+	UpdatedAt     time.Time            `json:"updated_at" bson:"updated_at"`
+}
+
+func NewSquadOpening(squad Squad, roles []string, regions []common.RegionIDKey, minMMR, maxMMR int, message string, postedBy uuid.UUID, now time.Time) *SquadOpening {
+	return &SquadOpening{
+		ID:            uuid.New(),
+		SquadID:       squad.ID,
+		SquadName:     squad.Name,
+		GameID:        squad.GameID,
+		Roles:         roles,
+		Regions:       regions,
+		MinMMR:        minMMR,
+		MaxMMR:        maxMMR,
+		Message:       message,
+		Status:        RecruitmentStatusOpen,
+		PostedBy:      postedBy,
+		ResourceOwner: squad.ResourceOwner,
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	}
+}
+
+func (o SquadOpening) GetID() uuid.UUID {
+	return o.ID
+}
+
+// Accepts reports whether a player of the rating is within the MMR range of the opening.
+func (o SquadOpening) Accepts(mmr int) bool {
+	return mmr >= o.MinMMR && (o.MaxMMR == 0 || mmr <= o.MaxMMR)
+}
@@ -17,6 +17,7 @@ type Squad struct {
 	Description   string                                 `json:"description" bson:"description"`
 	LogoURI       string                                 `json:"logo_uri" bson:"logo_uri"`
 	Profiles      map[string]squad_value_objects.Profile `json:"profiles" bson:"profiles"`
+	CustomFields  common.CustomFields                    `json:"custom_fields,omitempty" bson:"custom_fields,omitempty"` // as defined by the tenant
 	ResourceOwner common.ResourceOwner                   `json:"resource_owner" bson:"resource_owner"`
 	CreatedAt     time.Time                              `json:"created_at" bson:"created_at"`
 	UpdatedAt     time.Time                              `json:"updated_at" bson:"updated_at"`
@@ -44,3 +45,42 @@ func NewSquad(groupID uuid.UUID, gameID common.GameIDKey, name, symbol, descript
 func (e Squad) GetID() uuid.UUID {
 	return e.ID
 }
+
+// OwnerID returns the user owning the squad (the one that created it), who manages its members.
+func (e Squad) OwnerID() uuid.UUID {
+	return e.ResourceOwner.UserID
+}
+
+// MemberKey is the key of the profile of a member (the ID of its user).
+func MemberKey(userID uuid.UUID) string {
+	return userID.String()
+}
+
+func (e Squad) IsMember(userID uuid.UUID) bool {
+	_, ok := e.Profiles[MemberKey(userID)]
+
+	return ok || e.OwnerID() == userID
+}
+
+// Member returns the profile of the member in the roster (the owner may have none).
+func (e Squad) Member(userID uuid.UUID) (squad_value_objects.Profile, bool) {
+	profile, ok := e.Profiles[MemberKey(userID)]
+
+	return profile, ok
+}
+
+// Captain returns the member holding the captain role, false when there's none.
+func (e Squad) Captain() (uuid.UUID, bool) {
+	for key, profile := range e.Profiles {
+		if profile.Role != squad_value_objects.MemberRoleCaptain {
+			continue
+		}
+
+		userID, err := uuid.Parse(key)
+		if err == nil {
+			return userID, true
+		}
+	}
+
+	return uuid.Nil, false
+}
@@ -34,8 +34,25 @@ const (
 type SquadHistory struct {
 	ID            uuid.UUID            `json:"id" bson:"_id"`
 	SquadID       uuid.UUID            `json:"squad_id" bson:"squad_id"`
-	UserID        uuid.UUID            `json:"user_id" bson:"user_id"`
+	UserID        uuid.UUID            `json:"user_id" bson:"user_id"`   // member the entry is about
+	ActorID       uuid.UUID            `json:"actor_id" bson:"actor_id"` // user performing the action
 	Action        SquadHistoryAction   `json:"action" bson:"action"`
 	ResourceOwner common.ResourceOwner `json:"resource_owner" bson:"resource_owner"`
 	CreatedAt     time.Time            `json:"created_at" bson:"created_at"`
 }
+
+func NewSquadHistory(squad Squad, userID, actorID uuid.UUID, action SquadHistoryAction, now time.Time) *SquadHistory {
+	return &SquadHistory{
+		ID:            uuid.New(),
+		SquadID:       squad.ID,
+		UserID:        userID,
+		ActorID:       actorID,
+		Action:        action,
+		ResourceOwner: squad.ResourceOwner,
+		CreatedAt:     now,
+	}
+}
+
+func (h SquadHistory) GetID() uuid.UUID {
+	return h.ID
+}
@@ -0,0 +1,142 @@
+package squad
+
+import (
+	"fmt"
+
+	"github.com/google/uuid"
+)
+
+// Squad Not Found Error
+type SquadNotFoundError struct {
+	Message string
+}
+
+func (e *SquadNotFoundError) Error() string {
+	return e.Message
+}
+
+func NewSquadNotFoundError(squadID uuid.UUID) *SquadNotFoundError {
+	return &SquadNotFoundError{
+		Message: fmt.Sprintf("squad %s not found", squadID),
+	}
+}
+
+// User Not Found Error (user invited to a squad)
+type UserNotFoundError struct {
+	Message string
+}
+
+func (e *UserNotFoundError) Error() string {
+	return e.Message
+}
+
+func NewUserNotFoundError(userID uuid.UUID) *UserNotFoundError {
+	return &UserNotFoundError{
+		Message: fmt.Sprintf("user %s not found", userID),
+	}
+}
+
+// Join Request Not Found Error (invite or application of a squad)
+type JoinRequestNotFoundError struct {
+	Message string
+}
+
+func (e *JoinRequestNotFoundError) Error() string {
+	return e.Message
+}
+
+func NewJoinRequestNotFoundError(joinRequestID uuid.UUID) *JoinRequestNotFoundError {
+	return &JoinRequestNotFoundError{
+		Message: fmt.Sprintf("join request %s not found", joinRequestID),
+	}
+}
+
+// Squad Forbidden Error (caller isn't allowed to perform the action on the squad)
+type SquadForbiddenError struct {
+	Message string
+}
+
+func (e *SquadForbiddenError) Error() string {
+	return e.Message
+}
+
+func NewSquadForbiddenError(message string) *SquadForbiddenError {
+	return &SquadForbiddenError{
+		Message: message,
+	}
+}
+
+// Membership State Error (ie: already a member, a request is already pending, the request was already answered)
+type MembershipStateError struct {
+	Message string
+}
+
+func (e *MembershipStateError) Error() string {
+	return e.Message
+}
+
+func NewMembershipStateError(message string) *MembershipStateError {
+	return &MembershipStateError{
+		Message: message,
+	}
+}
+
+// Member Not Found Error (user not in the roster of the squad)
+type MemberNotFoundError struct {
+	Message string
+}
+
+func (e *MemberNotFoundError) Error() string {
+	return e.Message
+}
+
+func NewMemberNotFoundError(squadID, userID uuid.UUID) *MemberNotFoundError {
+	return &MemberNotFoundError{
+		Message: fmt.Sprintf("user %s is not a member of squad %s", userID, squadID),
+	}
+}
+
+// Invalid Member Role Error
+type InvalidMemberRoleError struct {
+	Message string
+}
+
+func (e *InvalidMemberRoleError) Error() string {
+	return e.Message
+}
+
+func NewInvalidMemberRoleError(role string) *InvalidMemberRoleError {
+	return &InvalidMemberRoleError{
+		Message: fmt.Sprintf("unknown member role '%s'", role),
+	}
+}
+
+// Recruitment Not Found Error (free agent listing or squad opening)
+type RecruitmentNotFoundError struct {
+	Message string
+}
+
+func (e *RecruitmentNotFoundError) Error() string {
+	return e.Message
+}
+
+func NewRecruitmentNotFoundError(kind string, id uuid.UUID) *RecruitmentNotFoundError {
+	return &RecruitmentNotFoundError{
+		Message: fmt.Sprintf("%s %s not found", kind, id),
+	}
+}
+
+// Invalid Recruitment Error (ie: missing player, inverted MMR range)
+type InvalidRecruitmentError struct {
+	Message string
+}
+
+func (e *InvalidRecruitmentError) Error() string {
+	return e.Message
+}
+
+func NewInvalidRecruitmentError(message string) *InvalidRecruitmentError {
+	return &InvalidRecruitmentError{
+		Message: message,
+	}
+}
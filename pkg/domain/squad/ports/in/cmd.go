@@ -3,9 +3,11 @@ package squad_in
 import (
 	"context"
 
+	"github.com/google/uuid"
 	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
 	iam_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/iam/entities"
 	squad_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/squad/entities"
+	squad_value_objects "github.com/psavelis/team-pro/replay-api/pkg/domain/squad/value-objects"
 )
 
 // type CreateSquadCommandHandler interface {
@@ -24,3 +26,115 @@ type CreateSquadCommand struct {
 type CreateSquadCommandHandler interface {
 	Exec(c context.Context, cmd CreateSquadCommand) (*squad_entities.Squad, error)
 }
+
+type InviteToSquadCommand struct {
+	SquadID uuid.UUID `json:"-"`
+	UserID  uuid.UUID `json:"user_id"`
+	Message string    `json:"message"`
+}
+
+// InviteToSquadCommandHandler invites a user to the squad on behalf of its owner (the user in context).
+type InviteToSquadCommandHandler interface {
+	Exec(c context.Context, cmd InviteToSquadCommand) (*squad_entities.JoinRequest, error)
+}
+
+type ApplyToSquadCommand struct {
+	SquadID uuid.UUID `json:"-"`
+	Message string    `json:"message"`
+}
+
+// ApplyToSquadCommandHandler applies to the membership of the squad on behalf of the user in context.
+type ApplyToSquadCommandHandler interface {
+	Exec(c context.Context, cmd ApplyToSquadCommand) (*squad_entities.JoinRequest, error)
+}
+
+type RespondToJoinRequestCommand struct {
+	SquadID       uuid.UUID
+	JoinRequestID uuid.UUID
+	Accept        bool
+}
+
+// RespondToJoinRequestCommandHandler accepts or declines a pending invite (as the invited user) or application (as the squad owner),
+// the user joins the squad once accepted.
+type RespondToJoinRequestCommandHandler interface {
+	Exec(c context.Context, cmd RespondToJoinRequestCommand) (*squad_entities.JoinRequest, error)
+}
+
+type SetMemberRoleCommand struct {
+	SquadID uuid.UUID                      `json:"-"`
+	UserID  uuid.UUID                      `json:"-"`
+	Role    squad_value_objects.MemberRole `json:"role"`
+}
+
+// SetMemberRoleCommandHandler promotes or demotes a member (as the squad owner). Promoting a captain demotes the previous one.
+type SetMemberRoleCommandHandler interface {
+	Exec(c context.Context, cmd SetMemberRoleCommand) (*squad_entities.Squad, error)
+}
+
+type TransferSquadOwnershipCommand struct {
+	SquadID uuid.UUID `json:"-"`
+	UserID  uuid.UUID `json:"user_id"`
+}
+
+// TransferSquadOwnershipCommandHandler hands the squad to one of its members (as the squad owner).
+type TransferSquadOwnershipCommandHandler interface {
+	Exec(c context.Context, cmd TransferSquadOwnershipCommand) (*squad_entities.Squad, error)
+}
+
+type RemoveSquadMemberCommand struct {
+	SquadID uuid.UUID
+	UserID  uuid.UUID
+}
+
+// RemoveSquadMemberCommandHandler removes a member (as the squad owner), or the member in context leaves the squad.
+type RemoveSquadMemberCommandHandler interface {
+	Exec(c context.Context, cmd RemoveSquadMemberCommand) (*squad_entities.Squad, error)
+}
+
+type ListFreeAgentCommand struct {
+	GameID       common.GameIDKey     `json:"-"`
+	PlayerID     uuid.UUID            `json:"player_id"`
+	Roles        []string             `json:"roles"`
+	Regions      []common.RegionIDKey `json:"regions"`
+	Availability string               `json:"availability"`
+	Message      string               `json:"message"`
+}
+
+// ListFreeAgentCommandHandler lists the user in context as looking for a team (replacing the open listing of the user in the game), with
+// the current rating of the player.
+type ListFreeAgentCommandHandler interface {
+	Exec(c context.Context, cmd ListFreeAgentCommand) (*squad_entities.FreeAgent, error)
+}
+
+type UnlistFreeAgentCommand struct {
+	FreeAgentID uuid.UUID
+}
+
+// UnlistFreeAgentCommandHandler closes the listing of the user in context.
+type UnlistFreeAgentCommandHandler interface {
+	Exec(c context.Context, cmd UnlistFreeAgentCommand) (*squad_entities.FreeAgent, error)
+}
+
+type PostSquadOpeningCommand struct {
+	SquadID uuid.UUID            `json:"-"`
+	Roles   []string             `json:"roles"`
+	Regions []common.RegionIDKey `json:"regions"`
+	MinMMR  int                  `json:"min_mmr"`
+	MaxMMR  int                  `json:"max_mmr"` // no upper bound when 0
+	Message string               `json:"message"`
+}
+
+// PostSquadOpeningCommandHandler posts a spot the squad is recruiting for (as the squad owner).
+type PostSquadOpeningCommandHandler interface {
+	Exec(c context.Context, cmd PostSquadOpeningCommand) (*squad_entities.SquadOpening, error)
+}
+
+type CloseSquadOpeningCommand struct {
+	SquadID   uuid.UUID
+	OpeningID uuid.UUID
+}
+
+// CloseSquadOpeningCommandHandler closes an opening of the squad (as the squad owner), ie: once filled.
+type CloseSquadOpeningCommandHandler interface {
+	Exec(c context.Context, cmd CloseSquadOpeningCommand) (*squad_entities.SquadOpening, error)
+}
@@ -1,6 +1,9 @@
 package squad_in
 
 import (
+	"context"
+
+	"github.com/google/uuid"
 	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
 	squad_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/squad/entities"
 )
@@ -8,3 +11,40 @@ import (
 type SquadSearchableReader interface {
 	common.Searchable[squad_entities.Squad]
 }
+
+// RecruitmentSide restricts a recruitment search to the free agents or the squad openings (both when empty).
+type RecruitmentSide string
+
+const (
+	RecruitmentSideFreeAgents RecruitmentSide = "free_agents"
+	RecruitmentSideOpenings   RecruitmentSide = "openings"
+)
+
+// RecruitmentFilter matches the open free agents and squad openings of a game. Free agents match when they play any of the roles, in
+// any of the regions, rated within the MMR range; openings when they need any of the roles (or any role), in any of the regions, and
+// their MMR range overlaps the one of the filter. Empty filters match all (MaxMMR 0 has no upper bound).
+type RecruitmentFilter struct {
+	GameID  common.GameIDKey
+	Side    RecruitmentSide
+	Roles   []string
+	Regions []common.RegionIDKey
+	MinMMR  int
+	MaxMMR  int
+	Limit   uint
+}
+
+type RecruitmentSearchResult struct {
+	FreeAgents []squad_entities.FreeAgent    `json:"free_agents"`
+	Openings   []squad_entities.SquadOpening `json:"openings"`
+}
+
+// RecruitmentSearch matches both sides of the recruitment: players looking for a team and squads looking for players.
+type RecruitmentSearch interface {
+	Search(ctx context.Context, filter RecruitmentFilter) (*RecruitmentSearchResult, error)
+
+	// MatchOpening lists the free agents fitting the opening (its roles, regions and MMR range).
+	MatchOpening(ctx context.Context, openingID uuid.UUID) ([]squad_entities.FreeAgent, error)
+
+	// MatchFreeAgent lists the openings fitting the free agent (its roles, regions and MMR).
+	MatchFreeAgent(ctx context.Context, freeAgentID uuid.UUID) ([]squad_entities.SquadOpening, error)
+}
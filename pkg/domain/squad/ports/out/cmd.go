@@ -3,10 +3,48 @@ package squad_out
 import (
 	"context"
 
+	"github.com/google/uuid"
 	squad_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/squad/entities"
+	squad_value_objects "github.com/psavelis/team-pro/replay-api/pkg/domain/squad/value-objects"
 )
 
 type SquadWriter interface {
 	CreateMany(createCtx context.Context, events []*squad_entities.Squad) error
 	Create(createCtx context.Context, events *squad_entities.Squad) (*squad_entities.Squad, error)
+
+	// AddMember sets the profile of the member in the squad (atomically: concurrent members added aren't lost).
+	AddMember(ctx context.Context, squadID uuid.UUID, userID uuid.UUID, profile squad_value_objects.Profile) error
+
+	// SetMemberRole sets the role in the profile of the member (created when the owner has none yet).
+	SetMemberRole(ctx context.Context, squadID uuid.UUID, userID uuid.UUID, role squad_value_objects.MemberRole) error
+
+	RemoveMember(ctx context.Context, squadID uuid.UUID, userID uuid.UUID) error
+
+	// TransferOwnership hands the squad to a member, the previous owner stays in the roster (with the given profile). It fails with a
+	// *squad.MembershipStateError when the squad changed hands meanwhile.
+	TransferOwnership(ctx context.Context, squadID uuid.UUID, fromUserID uuid.UUID, toUserID uuid.UUID, previousOwner squad_value_objects.Profile) error
+}
+
+type JoinRequestWriter interface {
+	Create(ctx context.Context, request *squad_entities.JoinRequest) (*squad_entities.JoinRequest, error)
+	Update(ctx context.Context, request *squad_entities.JoinRequest) (*squad_entities.JoinRequest, error)
+}
+
+type FreeAgentWriter interface {
+	Create(ctx context.Context, freeAgent *squad_entities.FreeAgent) (*squad_entities.FreeAgent, error)
+	Update(ctx context.Context, freeAgent *squad_entities.FreeAgent) (*squad_entities.FreeAgent, error)
+}
+
+type SquadOpeningWriter interface {
+	Create(ctx context.Context, opening *squad_entities.SquadOpening) (*squad_entities.SquadOpening, error)
+	Update(ctx context.Context, opening *squad_entities.SquadOpening) (*squad_entities.SquadOpening, error)
+}
+
+type SquadHistoryWriter interface {
+	Create(ctx context.Context, history *squad_entities.SquadHistory) (*squad_entities.SquadHistory, error)
+}
+
+// SquadEventPublisher publishes the steps of join requests (ie: to notify the invited user or the squad owner).
+type SquadEventPublisher interface {
+	PublishJoinRequestUpdated(ctx context.Context, event squad_entities.JoinRequestUpdated) error
 }
@@ -8,3 +8,15 @@ import (
 type SquadReader interface {
 	common.Searchable[squad_entities.Squad]
 }
+
+type JoinRequestReader interface {
+	common.Searchable[squad_entities.JoinRequest]
+}
+
+type FreeAgentReader interface {
+	common.Searchable[squad_entities.FreeAgent]
+}
+
+type SquadOpeningReader interface {
+	common.Searchable[squad_entities.SquadOpening]
+}
@@ -21,6 +21,7 @@ func NewSquadQueryService(eventReader squad_out.SquadReader) squad_in.SquadSearc
 		"Symbol":        true,
 		"Description":   true,
 		"Profiles.*":    true,
+		"CustomFields":  true,
 		"ResourceOwner": true,
 		"CreatedAt":     true,
 		"UpdatedAt":     true,
@@ -35,6 +36,7 @@ func NewSquadQueryService(eventReader squad_out.SquadReader) squad_in.SquadSearc
 		"Symbol":        true,
 		"Description":   true,
 		"Profiles.*":    true,
+		"CustomFields":  true,
 		"ResourceOwner": common.DENY,
 		"CreatedAt":     true,
 		"UpdatedAt":     true,
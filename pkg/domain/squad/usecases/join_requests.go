@@ -0,0 +1,281 @@
+package squad_usecases
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	iam_out "github.com/psavelis/team-pro/replay-api/pkg/domain/iam/ports/out"
+	"github.com/psavelis/team-pro/replay-api/pkg/domain/squad"
+	squad_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/squad/entities"
+	squad_in "github.com/psavelis/team-pro/replay-api/pkg/domain/squad/ports/in"
+	squad_out "github.com/psavelis/team-pro/replay-api/pkg/domain/squad/ports/out"
+	squad_value_objects "github.com/psavelis/team-pro/replay-api/pkg/domain/squad/value-objects"
+)
+
+// MaxJoinRequestMessageLength caps the message sent along an invite or application (longer ones are truncated).
+const MaxJoinRequestMessageLength = 500
+
+type InviteToSquadUseCase struct {
+	SquadReader       squad_out.SquadReader
+	UserReader        iam_out.UserReader
+	JoinRequestReader squad_out.JoinRequestReader
+	JoinRequestWriter squad_out.JoinRequestWriter
+	HistoryWriter     squad_out.SquadHistoryWriter
+	EventPublisher    squad_out.SquadEventPublisher // optional (no broker)
+}
+
+func NewInviteToSquadUseCase(squadReader squad_out.SquadReader, userReader iam_out.UserReader, joinRequestReader squad_out.JoinRequestReader, joinRequestWriter squad_out.JoinRequestWriter, historyWriter squad_out.SquadHistoryWriter, eventPublisher squad_out.SquadEventPublisher) squad_in.InviteToSquadCommandHandler {
+	return &InviteToSquadUseCase{
+		SquadReader:       squadReader,
+		UserReader:        userReader,
+		JoinRequestReader: joinRequestReader,
+		JoinRequestWriter: joinRequestWriter,
+		HistoryWriter:     historyWriter,
+		EventPublisher:    eventPublisher,
+	}
+}
+
+func (usecase *InviteToSquadUseCase) Exec(ctx context.Context, cmd squad_in.InviteToSquadCommand) (*squad_entities.JoinRequest, error) {
+	s, err := getSquad(ctx, usecase.SquadReader, cmd.SquadID)
+	if err != nil {
+		return nil, err
+	}
+
+	callerID := common.GetResourceOwner(ctx).UserID
+	if callerID != s.OwnerID() {
+		return nil, squad.NewSquadForbiddenError("only the squad owner invites users")
+	}
+
+	userName, err := getUserName(ctx, usecase.UserReader, cmd.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	if userName == nil {
+		return nil, squad.NewUserNotFoundError(cmd.UserID)
+	}
+
+	return createJoinRequest(ctx, usecase.JoinRequestReader, usecase.JoinRequestWriter, usecase.HistoryWriter, usecase.EventPublisher, *s,
+		squad_entities.NewJoinRequest(*s, squad_entities.JoinRequestKindInvite, cmd.UserID, *userName, joinRequestMessage(cmd.Message), callerID, time.Now().UTC()))
+}
+
+type ApplyToSquadUseCase struct {
+	SquadReader       squad_out.SquadReader
+	UserReader        iam_out.UserReader
+	JoinRequestReader squad_out.JoinRequestReader
+	JoinRequestWriter squad_out.JoinRequestWriter
+	HistoryWriter     squad_out.SquadHistoryWriter
+	EventPublisher    squad_out.SquadEventPublisher // optional (no broker)
+}
+
+func NewApplyToSquadUseCase(squadReader squad_out.SquadReader, userReader iam_out.UserReader, joinRequestReader squad_out.JoinRequestReader, joinRequestWriter squad_out.JoinRequestWriter, historyWriter squad_out.SquadHistoryWriter, eventPublisher squad_out.SquadEventPublisher) squad_in.ApplyToSquadCommandHandler {
+	return &ApplyToSquadUseCase{
+		SquadReader:       squadReader,
+		UserReader:        userReader,
+		JoinRequestReader: joinRequestReader,
+		JoinRequestWriter: joinRequestWriter,
+		HistoryWriter:     historyWriter,
+		EventPublisher:    eventPublisher,
+	}
+}
+
+func (usecase *ApplyToSquadUseCase) Exec(ctx context.Context, cmd squad_in.ApplyToSquadCommand) (*squad_entities.JoinRequest, error) {
+	s, err := getSquad(ctx, usecase.SquadReader, cmd.SquadID)
+	if err != nil {
+		return nil, err
+	}
+
+	// anonymous requests have a (random) user of their own, only onboarded users apply
+	callerID := common.GetResourceOwner(ctx).UserID
+	userName, err := getUserName(ctx, usecase.UserReader, callerID)
+	if err != nil {
+		return nil, err
+	}
+
+	if userName == nil {
+		return nil, squad.NewSquadForbiddenError("only signed in users apply to squads")
+	}
+
+	return createJoinRequest(ctx, usecase.JoinRequestReader, usecase.JoinRequestWriter, usecase.HistoryWriter, usecase.EventPublisher, *s,
+		squad_entities.NewJoinRequest(*s, squad_entities.JoinRequestKindApplication, callerID, *userName, joinRequestMessage(cmd.Message), callerID, time.Now().UTC()))
+}
+
+type RespondToJoinRequestUseCase struct {
+	SquadReader       squad_out.SquadReader
+	SquadWriter       squad_out.SquadWriter
+	JoinRequestReader squad_out.JoinRequestReader
+	JoinRequestWriter squad_out.JoinRequestWriter
+	HistoryWriter     squad_out.SquadHistoryWriter
+	EventPublisher    squad_out.SquadEventPublisher // optional (no broker)
+}
+
+func NewRespondToJoinRequestUseCase(squadReader squad_out.SquadReader, squadWriter squad_out.SquadWriter, joinRequestReader squad_out.JoinRequestReader, joinRequestWriter squad_out.JoinRequestWriter, historyWriter squad_out.SquadHistoryWriter, eventPublisher squad_out.SquadEventPublisher) squad_in.RespondToJoinRequestCommandHandler {
+	return &RespondToJoinRequestUseCase{
+		SquadReader:       squadReader,
+		SquadWriter:       squadWriter,
+		JoinRequestReader: joinRequestReader,
+		JoinRequestWriter: joinRequestWriter,
+		HistoryWriter:     historyWriter,
+		EventPublisher:    eventPublisher,
+	}
+}
+
+func (usecase *RespondToJoinRequestUseCase) Exec(ctx context.Context, cmd squad_in.RespondToJoinRequestCommand) (*squad_entities.JoinRequest, error) {
+	s, err := getSquad(ctx, usecase.SquadReader, cmd.SquadID)
+	if err != nil {
+		return nil, err
+	}
+
+	requests, err := usecase.JoinRequestReader.Search(ctx, common.NewSearchByValues(ctx, []common.SearchableValue{
+		{Field: "ID", Values: []interface{}{cmd.JoinRequestID}},
+		{Field: "SquadID", Values: []interface{}{s.ID}},
+	}, common.NewSearchResultOptions(0, 1), common.ClientApplicationAudienceIDKey))
+
+	if err != nil {
+		slog.ErrorContext(ctx, "unable to search join request", "joinRequestID", cmd.JoinRequestID, "err", err)
+		return nil, err
+	}
+
+	if len(requests) == 0 {
+		return nil, squad.NewJoinRequestNotFoundError(cmd.JoinRequestID)
+	}
+
+	request := &requests[0]
+
+	callerID := common.GetResourceOwner(ctx).UserID
+	if callerID != request.Responder(*s) {
+		if request.Kind == squad_entities.JoinRequestKindInvite {
+			return nil, squad.NewSquadForbiddenError("only the invited user answers an invite")
+		}
+
+		return nil, squad.NewSquadForbiddenError("only the squad owner answers an application")
+	}
+
+	if !request.IsPending() {
+		return nil, squad.NewMembershipStateError("join request was already " + string(request.Status))
+	}
+
+	now := time.Now().UTC()
+	request.Respond(cmd.Accept, callerID, now)
+
+	// the member is added first: a failed update leaves the request pending (answered again), never accepted without the member
+	if cmd.Accept {
+		err = usecase.SquadWriter.AddMember(ctx, s.ID, request.UserID, squad_value_objects.Profile{Name: request.UserName})
+		if err != nil {
+			slog.ErrorContext(ctx, "unable to add squad member", "squadID", s.ID, "userID", request.UserID, "err", err)
+			return nil, err
+		}
+	}
+
+	request, err = usecase.JoinRequestWriter.Update(ctx, request)
+	if err != nil {
+		slog.ErrorContext(ctx, "unable to update join request", "joinRequestID", cmd.JoinRequestID, "err", err)
+		return nil, err
+	}
+
+	recordHistory(ctx, usecase.HistoryWriter, squad_entities.NewSquadHistory(*s, request.UserID, callerID, request.HistoryAction(), now))
+
+	if cmd.Accept {
+		recordHistory(ctx, usecase.HistoryWriter, squad_entities.NewSquadHistory(*s, request.UserID, callerID, squad_entities.SquadMemberJoined, now))
+	}
+
+	publishJoinRequestUpdated(ctx, usecase.EventPublisher, *request, *s)
+
+	return request, nil
+}
+
+// createJoinRequest stores a pending request for a user not yet in the squad (nor with another request pending).
+func createJoinRequest(ctx context.Context, reader squad_out.JoinRequestReader, writer squad_out.JoinRequestWriter, historyWriter squad_out.SquadHistoryWriter, publisher squad_out.SquadEventPublisher, s squad_entities.Squad, request *squad_entities.JoinRequest) (*squad_entities.JoinRequest, error) {
+	if s.IsMember(request.UserID) {
+		return nil, squad.NewMembershipStateError("user is already a member of the squad")
+	}
+
+	pending, err := reader.Search(ctx, common.NewSearchByValues(ctx, []common.SearchableValue{
+		{Field: "SquadID", Values: []interface{}{s.ID}},
+		{Field: "UserID", Values: []interface{}{request.UserID}},
+		{Field: "Status", Values: []interface{}{squad_entities.JoinRequestStatusPending}},
+	}, common.NewSearchResultOptions(0, 1), common.ClientApplicationAudienceIDKey))
+
+	if err != nil {
+		slog.ErrorContext(ctx, "unable to search pending join requests", "squadID", s.ID, "userID", request.UserID, "err", err)
+		return nil, err
+	}
+
+	if len(pending) > 0 {
+		return nil, squad.NewMembershipStateError("a join request (" + string(pending[0].Kind) + ") is already pending for the user")
+	}
+
+	created, err := writer.Create(ctx, request)
+	if err != nil {
+		slog.ErrorContext(ctx, "unable to create join request", "squadID", s.ID, "userID", request.UserID, "err", err)
+		return nil, err
+	}
+
+	recordHistory(ctx, historyWriter, squad_entities.NewSquadHistory(s, created.UserID, created.RequestedBy, created.HistoryAction(), created.CreatedAt))
+
+	publishJoinRequestUpdated(ctx, publisher, *created, s)
+
+	return created, nil
+}
+
+func getSquad(ctx context.Context, reader squad_out.SquadReader, squadID uuid.UUID) (*squad_entities.Squad, error) {
+	squads, err := reader.Search(ctx, common.NewSearchByID(ctx, squadID, common.ClientApplicationAudienceIDKey))
+	if err != nil {
+		slog.ErrorContext(ctx, "unable to search squad", "squadID", squadID, "err", err)
+		return nil, err
+	}
+
+	if len(squads) == 0 {
+		return nil, squad.NewSquadNotFoundError(squadID)
+	}
+
+	return &squads[0], nil
+}
+
+// getUserName returns the name of the user, nil when the user doesn't exist.
+func getUserName(ctx context.Context, reader iam_out.UserReader, userID uuid.UUID) (*string, error) {
+	users, err := reader.Search(ctx, common.NewSearchByID(ctx, userID, common.ClientApplicationAudienceIDKey))
+	if err != nil {
+		slog.ErrorContext(ctx, "unable to search user", "userID", userID, "err", err)
+		return nil, err
+	}
+
+	if len(users) == 0 {
+		return nil, nil
+	}
+
+	return &users[0].Name, nil
+}
+
+func joinRequestMessage(message string) string {
+	message = strings.TrimSpace(message)
+	if len([]rune(message)) > MaxJoinRequestMessageLength {
+		message = string([]rune(message)[:MaxJoinRequestMessageLength])
+	}
+
+	return message
+}
+
+// recordHistory appends to the history of the squad: the membership already changed, a failed write is logged, not retried.
+func recordHistory(ctx context.Context, writer squad_out.SquadHistoryWriter, history *squad_entities.SquadHistory) {
+	_, err := writer.Create(ctx, history)
+	if err != nil {
+		slog.ErrorContext(ctx, "unable to record squad history", "squadID", history.SquadID, "userID", history.UserID, "action", history.Action, "err", err)
+	}
+}
+
+// publishJoinRequestUpdated notifies the other party of the request, when there's a broker (logged, not retried, on failure).
+func publishJoinRequestUpdated(ctx context.Context, publisher squad_out.SquadEventPublisher, request squad_entities.JoinRequest, s squad_entities.Squad) {
+	if publisher == nil {
+		return
+	}
+
+	err := publisher.PublishJoinRequestUpdated(ctx, squad_entities.NewJoinRequestUpdated(request, s))
+	if err != nil {
+		slog.WarnContext(ctx, "unable to publish join request event", "joinRequestID", request.ID, "err", err)
+	}
+}
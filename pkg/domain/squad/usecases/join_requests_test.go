@@ -0,0 +1,351 @@
+package squad_usecases_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	iam_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/iam/entities"
+	"github.com/psavelis/team-pro/replay-api/pkg/domain/squad"
+	squad_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/squad/entities"
+	squad_in "github.com/psavelis/team-pro/replay-api/pkg/domain/squad/ports/in"
+	squad_usecases "github.com/psavelis/team-pro/replay-api/pkg/domain/squad/usecases"
+	squad_value_objects "github.com/psavelis/team-pro/replay-api/pkg/domain/squad/value-objects"
+	"github.com/stretchr/testify/assert"
+)
+
+func userContext(userID uuid.UUID) context.Context {
+	return common.WithRequestScope(context.Background(), common.RequestScope{
+		ResourceOwner: common.ResourceOwner{TenantID: common.TeamPROTenantID, ClientID: common.TeamPROAppClientID, UserID: userID},
+		RequestID:     uuid.NewString(),
+	})
+}
+
+type mockSquadStore struct {
+	squads []squad_entities.Squad
+}
+
+func (m *mockSquadStore) Search(ctx context.Context, s common.Search) ([]squad_entities.Squad, error) {
+	res := make([]squad_entities.Squad, 0)
+	for _, sq := range m.squads {
+		if sq.ID == s.SearchParams[0].Params[0].ValueParams[0].Values[0] {
+			res = append(res, sq)
+		}
+	}
+
+	return res, nil
+}
+
+func (m *mockSquadStore) Compile(ctx context.Context, searchParams []common.SearchAggregation, resultOptions common.SearchResultOptions) (*common.Search, error) {
+	return nil, nil
+}
+
+func (m *mockSquadStore) CreateMany(ctx context.Context, squads []*squad_entities.Squad) error {
+	return nil
+}
+
+func (m *mockSquadStore) Create(ctx context.Context, sq *squad_entities.Squad) (*squad_entities.Squad, error) {
+	m.squads = append(m.squads, *sq)
+	return sq, nil
+}
+
+func (m *mockSquadStore) AddMember(ctx context.Context, squadID uuid.UUID, userID uuid.UUID, profile squad_value_objects.Profile) error {
+	for i := range m.squads {
+		if m.squads[i].ID == squadID {
+			if m.squads[i].Profiles == nil {
+				m.squads[i].Profiles = make(map[string]squad_value_objects.Profile)
+			}
+
+			m.squads[i].Profiles[squad_entities.MemberKey(userID)] = profile
+			return nil
+		}
+	}
+
+	return squad.NewSquadNotFoundError(squadID)
+}
+
+func (m *mockSquadStore) SetMemberRole(ctx context.Context, squadID uuid.UUID, userID uuid.UUID, role squad_value_objects.MemberRole) error {
+	for i := range m.squads {
+		if m.squads[i].ID == squadID {
+			if m.squads[i].Profiles == nil {
+				m.squads[i].Profiles = make(map[string]squad_value_objects.Profile)
+			}
+
+			profile := m.squads[i].Profiles[squad_entities.MemberKey(userID)]
+			profile.Role = role
+			m.squads[i].Profiles[squad_entities.MemberKey(userID)] = profile
+			return nil
+		}
+	}
+
+	return squad.NewSquadNotFoundError(squadID)
+}
+
+func (m *mockSquadStore) RemoveMember(ctx context.Context, squadID uuid.UUID, userID uuid.UUID) error {
+	for i := range m.squads {
+		if m.squads[i].ID == squadID {
+			delete(m.squads[i].Profiles, squad_entities.MemberKey(userID))
+			return nil
+		}
+	}
+
+	return squad.NewSquadNotFoundError(squadID)
+}
+
+func (m *mockSquadStore) TransferOwnership(ctx context.Context, squadID uuid.UUID, fromUserID uuid.UUID, toUserID uuid.UUID, previousOwner squad_value_objects.Profile) error {
+	for i := range m.squads {
+		if m.squads[i].ID == squadID && m.squads[i].ResourceOwner.UserID == fromUserID {
+			m.squads[i].ResourceOwner.UserID = toUserID
+			m.squads[i].Profiles[squad_entities.MemberKey(fromUserID)] = previousOwner
+			return nil
+		}
+	}
+
+	return squad.NewMembershipStateError("squad ownership changed meanwhile")
+}
+
+type mockUserStore struct {
+	users []iam_entities.User
+}
+
+func (m *mockUserStore) Search(ctx context.Context, s common.Search) ([]iam_entities.User, error) {
+	res := make([]iam_entities.User, 0)
+	for _, u := range m.users {
+		if u.ID == s.SearchParams[0].Params[0].ValueParams[0].Values[0] {
+			res = append(res, u)
+		}
+	}
+
+	return res, nil
+}
+
+// mockJoinRequestStore filters by every field searched by the use cases.
+type mockJoinRequestStore struct {
+	requests []squad_entities.JoinRequest
+}
+
+func (m *mockJoinRequestStore) Search(ctx context.Context, s common.Search) ([]squad_entities.JoinRequest, error) {
+	res := make([]squad_entities.JoinRequest, 0)
+
+	for _, r := range m.requests {
+		matches := true
+		for _, v := range s.SearchParams[0].Params[0].ValueParams {
+			var value interface{}
+			switch v.Field {
+			case "ID":
+				value = r.ID
+			case "SquadID":
+				value = r.SquadID
+			case "UserID":
+				value = r.UserID
+			case "Status":
+				value = r.Status
+			}
+
+			matches = matches && value == v.Values[0]
+		}
+
+		if matches {
+			res = append(res, r)
+		}
+	}
+
+	return res, nil
+}
+
+func (m *mockJoinRequestStore) Compile(ctx context.Context, searchParams []common.SearchAggregation, resultOptions common.SearchResultOptions) (*common.Search, error) {
+	return nil, nil
+}
+
+func (m *mockJoinRequestStore) Create(ctx context.Context, r *squad_entities.JoinRequest) (*squad_entities.JoinRequest, error) {
+	m.requests = append(m.requests, *r)
+	return r, nil
+}
+
+func (m *mockJoinRequestStore) Update(ctx context.Context, r *squad_entities.JoinRequest) (*squad_entities.JoinRequest, error) {
+	for i := range m.requests {
+		if m.requests[i].ID == r.ID {
+			m.requests[i] = *r
+		}
+	}
+
+	return r, nil
+}
+
+type mockHistoryStore struct {
+	history []squad_entities.SquadHistory
+}
+
+func (m *mockHistoryStore) Create(ctx context.Context, h *squad_entities.SquadHistory) (*squad_entities.SquadHistory, error) {
+	m.history = append(m.history, *h)
+	return h, nil
+}
+
+func (m *mockHistoryStore) actions() []squad_entities.SquadHistoryAction {
+	actions := make([]squad_entities.SquadHistoryAction, len(m.history))
+	for i, h := range m.history {
+		actions[i] = h.Action
+	}
+
+	return actions
+}
+
+type mockSquadPublisher struct {
+	published []squad_entities.JoinRequestUpdated
+}
+
+func (m *mockSquadPublisher) PublishJoinRequestUpdated(ctx context.Context, event squad_entities.JoinRequestUpdated) error {
+	m.published = append(m.published, event)
+	return nil
+}
+
+type joinRequestFixture struct {
+	squads    *mockSquadStore
+	requests  *mockJoinRequestStore
+	history   *mockHistoryStore
+	publisher *mockSquadPublisher
+	ownerID   uuid.UUID
+	playerID  uuid.UUID
+	squad     squad_entities.Squad
+	invite    squad_in.InviteToSquadCommandHandler
+	apply     squad_in.ApplyToSquadCommandHandler
+	respond   squad_in.RespondToJoinRequestCommandHandler
+}
+
+func newJoinRequestFixture() *joinRequestFixture {
+	f := &joinRequestFixture{
+		requests:  &mockJoinRequestStore{},
+		history:   &mockHistoryStore{},
+		publisher: &mockSquadPublisher{},
+		ownerID:   uuid.New(),
+		playerID:  uuid.New(),
+	}
+
+	// seeded squads may have no profiles
+	f.squad = squad_entities.NewSquad(uuid.New(), common.CS2_GAME_ID, "Night Owls", "NO", "", nil, common.ResourceOwner{TenantID: common.TeamPROTenantID, ClientID: common.TeamPROAppClientID, UserID: f.ownerID})
+	f.squads = &mockSquadStore{squads: []squad_entities.Squad{f.squad}}
+
+	users := &mockUserStore{users: []iam_entities.User{{ID: f.ownerID, Name: "owner"}, {ID: f.playerID, Name: "player"}}}
+
+	f.invite = squad_usecases.NewInviteToSquadUseCase(f.squads, users, f.requests, f.requests, f.history, f.publisher)
+	f.apply = squad_usecases.NewApplyToSquadUseCase(f.squads, users, f.requests, f.requests, f.history, f.publisher)
+	f.respond = squad_usecases.NewRespondToJoinRequestUseCase(f.squads, f.squads, f.requests, f.requests, f.history, f.publisher)
+
+	return f
+}
+
+func TestJoinRequests_InviteAccepted(t *testing.T) {
+	f := newJoinRequestFixture()
+
+	// only the owner invites
+	_, err := f.invite.Exec(userContext(f.playerID), squad_in.InviteToSquadCommand{SquadID: f.squad.ID, UserID: f.playerID})
+	assert.IsType(t, &squad.SquadForbiddenError{}, err)
+
+	_, err = f.invite.Exec(userContext(f.ownerID), squad_in.InviteToSquadCommand{SquadID: f.squad.ID, UserID: uuid.New()})
+	assert.IsType(t, &squad.UserNotFoundError{}, err)
+
+	invite, err := f.invite.Exec(userContext(f.ownerID), squad_in.InviteToSquadCommand{SquadID: f.squad.ID, UserID: f.playerID, Message: "  gl hf  "})
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	assert.Equal(t, squad_entities.JoinRequestKindInvite, invite.Kind)
+	assert.Equal(t, squad_entities.JoinRequestStatusPending, invite.Status)
+	assert.Equal(t, "player", invite.UserName)
+	assert.Equal(t, "gl hf", invite.Message)
+	assert.Equal(t, f.ownerID, invite.RequestedBy)
+
+	// a single pending request per user
+	_, err = f.invite.Exec(userContext(f.ownerID), squad_in.InviteToSquadCommand{SquadID: f.squad.ID, UserID: f.playerID})
+	assert.IsType(t, &squad.MembershipStateError{}, err)
+
+	_, err = f.apply.Exec(userContext(f.playerID), squad_in.ApplyToSquadCommand{SquadID: f.squad.ID})
+	assert.IsType(t, &squad.MembershipStateError{}, err)
+
+	// invites are answered by the invited user
+	_, err = f.respond.Exec(userContext(f.ownerID), squad_in.RespondToJoinRequestCommand{SquadID: f.squad.ID, JoinRequestID: invite.ID, Accept: true})
+	assert.IsType(t, &squad.SquadForbiddenError{}, err)
+
+	_, err = f.respond.Exec(userContext(f.playerID), squad_in.RespondToJoinRequestCommand{SquadID: uuid.New(), JoinRequestID: invite.ID, Accept: true})
+	assert.IsType(t, &squad.SquadNotFoundError{}, err)
+
+	accepted, err := f.respond.Exec(userContext(f.playerID), squad_in.RespondToJoinRequestCommand{SquadID: f.squad.ID, JoinRequestID: invite.ID, Accept: true})
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	assert.Equal(t, squad_entities.JoinRequestStatusAccepted, accepted.Status)
+	assert.Equal(t, f.playerID, *accepted.RespondedBy)
+	assert.Equal(t, squad_value_objects.Profile{Name: "player"}, f.squads.squads[0].Profiles[squad_entities.MemberKey(f.playerID)])
+	assert.True(t, f.squads.squads[0].IsMember(f.playerID))
+
+	_, err = f.respond.Exec(userContext(f.playerID), squad_in.RespondToJoinRequestCommand{SquadID: f.squad.ID, JoinRequestID: invite.ID, Accept: false})
+	assert.IsType(t, &squad.MembershipStateError{}, err)
+
+	assert.Equal(t, []squad_entities.SquadHistoryAction{
+		squad_entities.SquadMembershipRequest,
+		squad_entities.SquadMembershipRequestAccepted,
+		squad_entities.SquadMemberJoined,
+	}, f.history.actions())
+
+	// the invited user is notified of the invite, the owner of the answer
+	if assert.Len(t, f.publisher.published, 2) {
+		assert.Equal(t, f.playerID, f.publisher.published[0].RecipientID)
+		assert.Equal(t, squad_entities.JoinRequestStatusPending, f.publisher.published[0].Status)
+		assert.Equal(t, f.ownerID, f.publisher.published[1].RecipientID)
+		assert.Equal(t, squad_entities.JoinRequestStatusAccepted, f.publisher.published[1].Status)
+	}
+
+	// members can't be invited again
+	_, err = f.invite.Exec(userContext(f.ownerID), squad_in.InviteToSquadCommand{SquadID: f.squad.ID, UserID: f.playerID})
+	assert.IsType(t, &squad.MembershipStateError{}, err)
+}
+
+func TestJoinRequests_ApplicationDeclined(t *testing.T) {
+	f := newJoinRequestFixture()
+
+	// anonymous requests (users never onboarded) can't apply
+	_, err := f.apply.Exec(userContext(uuid.New()), squad_in.ApplyToSquadCommand{SquadID: f.squad.ID})
+	assert.IsType(t, &squad.SquadForbiddenError{}, err)
+
+	_, err = f.apply.Exec(userContext(f.ownerID), squad_in.ApplyToSquadCommand{SquadID: f.squad.ID})
+	assert.IsType(t, &squad.MembershipStateError{}, err)
+
+	application, err := f.apply.Exec(userContext(f.playerID), squad_in.ApplyToSquadCommand{SquadID: f.squad.ID})
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	assert.Equal(t, squad_entities.JoinRequestKindApplication, application.Kind)
+	assert.Equal(t, f.playerID, application.RequestedBy)
+
+	// applications are answered by the squad owner
+	_, err = f.respond.Exec(userContext(f.playerID), squad_in.RespondToJoinRequestCommand{SquadID: f.squad.ID, JoinRequestID: application.ID, Accept: true})
+	assert.IsType(t, &squad.SquadForbiddenError{}, err)
+
+	_, err = f.respond.Exec(userContext(f.ownerID), squad_in.RespondToJoinRequestCommand{SquadID: f.squad.ID, JoinRequestID: uuid.New()})
+	assert.IsType(t, &squad.JoinRequestNotFoundError{}, err)
+
+	declined, err := f.respond.Exec(userContext(f.ownerID), squad_in.RespondToJoinRequestCommand{SquadID: f.squad.ID, JoinRequestID: application.ID, Accept: false})
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	assert.Equal(t, squad_entities.JoinRequestStatusDeclined, declined.Status)
+	assert.False(t, f.squads.squads[0].IsMember(f.playerID))
+
+	assert.Equal(t, []squad_entities.SquadHistoryAction{
+		squad_entities.SquadMemberJoinRequest,
+		squad_entities.SquadMemberRequestDeclined,
+	}, f.history.actions())
+
+	if assert.Len(t, f.publisher.published, 2) {
+		assert.Equal(t, f.ownerID, f.publisher.published[0].RecipientID)
+		assert.Equal(t, f.playerID, f.publisher.published[1].RecipientID)
+	}
+
+	// declined applicants apply again
+	_, err = f.apply.Exec(userContext(f.playerID), squad_in.ApplyToSquadCommand{SquadID: f.squad.ID})
+	assert.NoError(t, err)
+}
@@ -0,0 +1,461 @@
+package squad_usecases
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	iam_out "github.com/psavelis/team-pro/replay-api/pkg/domain/iam/ports/out"
+	matchmaking_out "github.com/psavelis/team-pro/replay-api/pkg/domain/matchmaking/ports/out"
+	"github.com/psavelis/team-pro/replay-api/pkg/domain/squad"
+	squad_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/squad/entities"
+	squad_in "github.com/psavelis/team-pro/replay-api/pkg/domain/squad/ports/in"
+	squad_out "github.com/psavelis/team-pro/replay-api/pkg/domain/squad/ports/out"
+)
+
+const (
+	// MaxOpenSquadOpenings caps the openings a squad has open at once.
+	MaxOpenSquadOpenings = 5
+
+	// MaxRecruitmentResults caps the results of each side of a recruitment search.
+	MaxRecruitmentResults uint = 100
+)
+
+type ListFreeAgentUseCase struct {
+	FreeAgentReader squad_out.FreeAgentReader
+	FreeAgentWriter squad_out.FreeAgentWriter
+	UserReader      iam_out.UserReader
+	RatingReader    matchmaking_out.PlayerRatingReader
+}
+
+func NewListFreeAgentUseCase(freeAgentReader squad_out.FreeAgentReader, freeAgentWriter squad_out.FreeAgentWriter, userReader iam_out.UserReader, ratingReader matchmaking_out.PlayerRatingReader) squad_in.ListFreeAgentCommandHandler {
+	return &ListFreeAgentUseCase{
+		FreeAgentReader: freeAgentReader,
+		FreeAgentWriter: freeAgentWriter,
+		UserReader:      userReader,
+		RatingReader:    ratingReader,
+	}
+}
+
+func (usecase *ListFreeAgentUseCase) Exec(ctx context.Context, cmd squad_in.ListFreeAgentCommand) (*squad_entities.FreeAgent, error) {
+	if cmd.PlayerID == uuid.Nil {
+		return nil, squad.NewInvalidRecruitmentError("player_id is required")
+	}
+
+	reso := common.GetResourceOwner(ctx)
+
+	userName, err := getUserName(ctx, usecase.UserReader, reso.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	if userName == nil {
+		return nil, squad.NewUserNotFoundError(reso.UserID)
+	}
+
+	mmr, err := usecase.RatingReader.GetRating(ctx, cmd.GameID, cmd.PlayerID)
+	if err != nil {
+		slog.ErrorContext(ctx, "unable to get player rating", "gameID", cmd.GameID, "playerID", cmd.PlayerID, "err", err)
+		return nil, err
+	}
+
+	open, err := usecase.FreeAgentReader.Search(ctx, common.NewSearchByValues(ctx, []common.SearchableValue{
+		{Field: "GameID", Values: []interface{}{cmd.GameID}},
+		{Field: "Status", Values: []interface{}{squad_entities.RecruitmentStatusOpen}},
+	}, common.NewSearchResultOptions(0, 1), common.UserAudienceIDKey))
+
+	if err != nil {
+		slog.ErrorContext(ctx, "unable to search free agent listing", "gameID", cmd.GameID, "err", err)
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+
+	freeAgent := &squad_entities.FreeAgent{
+		ID:            uuid.New(),
+		UserID:        reso.UserID,
+		ResourceOwner: reso,
+		CreatedAt:     now,
+	}
+
+	if len(open) > 0 {
+		freeAgent = &open[0]
+	}
+
+	freeAgent.PlayerID = cmd.PlayerID
+	freeAgent.UserName = *userName
+	freeAgent.GameID = cmd.GameID
+	freeAgent.Roles = recruitmentValues(cmd.Roles)
+	freeAgent.Regions = recruitmentValues(cmd.Regions)
+	freeAgent.MMR = mmr
+	freeAgent.Availability = strings.TrimSpace(cmd.Availability)
+	freeAgent.Message = joinRequestMessage(cmd.Message)
+	freeAgent.Status = squad_entities.RecruitmentStatusOpen
+	freeAgent.UpdatedAt = now
+
+	if len(open) > 0 {
+		freeAgent, err = usecase.FreeAgentWriter.Update(ctx, freeAgent)
+	} else {
+		freeAgent, err = usecase.FreeAgentWriter.Create(ctx, freeAgent)
+	}
+
+	if err != nil {
+		slog.ErrorContext(ctx, "unable to save free agent listing", "gameID", cmd.GameID, "userID", reso.UserID, "err", err)
+		return nil, err
+	}
+
+	return freeAgent, nil
+}
+
+type UnlistFreeAgentUseCase struct {
+	FreeAgentReader squad_out.FreeAgentReader
+	FreeAgentWriter squad_out.FreeAgentWriter
+}
+
+func NewUnlistFreeAgentUseCase(freeAgentReader squad_out.FreeAgentReader, freeAgentWriter squad_out.FreeAgentWriter) squad_in.UnlistFreeAgentCommandHandler {
+	return &UnlistFreeAgentUseCase{
+		FreeAgentReader: freeAgentReader,
+		FreeAgentWriter: freeAgentWriter,
+	}
+}
+
+func (usecase *UnlistFreeAgentUseCase) Exec(ctx context.Context, cmd squad_in.UnlistFreeAgentCommand) (*squad_entities.FreeAgent, error) {
+	// searched within the listings of the user: the others are not found
+	listings, err := usecase.FreeAgentReader.Search(ctx, common.NewSearchByID(ctx, cmd.FreeAgentID, common.UserAudienceIDKey))
+	if err != nil {
+		slog.ErrorContext(ctx, "unable to search free agent listing", "freeAgentID", cmd.FreeAgentID, "err", err)
+		return nil, err
+	}
+
+	if len(listings) == 0 {
+		return nil, squad.NewRecruitmentNotFoundError("free agent", cmd.FreeAgentID)
+	}
+
+	freeAgent := &listings[0]
+	if freeAgent.Status == squad_entities.RecruitmentStatusClosed {
+		return freeAgent, nil
+	}
+
+	freeAgent.Status = squad_entities.RecruitmentStatusClosed
+	freeAgent.UpdatedAt = time.Now().UTC()
+
+	freeAgent, err = usecase.FreeAgentWriter.Update(ctx, freeAgent)
+	if err != nil {
+		slog.ErrorContext(ctx, "unable to close free agent listing", "freeAgentID", cmd.FreeAgentID, "err", err)
+		return nil, err
+	}
+
+	return freeAgent, nil
+}
+
+type PostSquadOpeningUseCase struct {
+	SquadReader   squad_out.SquadReader
+	OpeningReader squad_out.SquadOpeningReader
+	OpeningWriter squad_out.SquadOpeningWriter
+}
+
+func NewPostSquadOpeningUseCase(squadReader squad_out.SquadReader, openingReader squad_out.SquadOpeningReader, openingWriter squad_out.SquadOpeningWriter) squad_in.PostSquadOpeningCommandHandler {
+	return &PostSquadOpeningUseCase{
+		SquadReader:   squadReader,
+		OpeningReader: openingReader,
+		OpeningWriter: openingWriter,
+	}
+}
+
+func (usecase *PostSquadOpeningUseCase) Exec(ctx context.Context, cmd squad_in.PostSquadOpeningCommand) (*squad_entities.SquadOpening, error) {
+	if cmd.MinMMR < 0 || cmd.MaxMMR < 0 || (cmd.MaxMMR > 0 && cmd.MinMMR > cmd.MaxMMR) {
+		return nil, squad.NewInvalidRecruitmentError(fmt.Sprintf("invalid MMR range [%d, %d]", cmd.MinMMR, cmd.MaxMMR))
+	}
+
+	s, err := getSquad(ctx, usecase.SquadReader, cmd.SquadID)
+	if err != nil {
+		return nil, err
+	}
+
+	callerID := common.GetResourceOwner(ctx).UserID
+	if callerID != s.OwnerID() {
+		return nil, squad.NewSquadForbiddenError("only the squad owner posts openings")
+	}
+
+	open, err := usecase.OpeningReader.Search(ctx, common.NewSearchByValues(ctx, []common.SearchableValue{
+		{Field: "SquadID", Values: []interface{}{s.ID}},
+		{Field: "Status", Values: []interface{}{squad_entities.RecruitmentStatusOpen}},
+	}, common.NewSearchResultOptions(0, MaxOpenSquadOpenings), common.ClientApplicationAudienceIDKey))
+
+	if err != nil {
+		slog.ErrorContext(ctx, "unable to search squad openings", "squadID", s.ID, "err", err)
+		return nil, err
+	}
+
+	if len(open) >= MaxOpenSquadOpenings {
+		return nil, squad.NewInvalidRecruitmentError(fmt.Sprintf("the squad already has %d open openings", len(open)))
+	}
+
+	opening, err := usecase.OpeningWriter.Create(ctx, squad_entities.NewSquadOpening(*s, recruitmentValues(cmd.Roles), recruitmentValues(cmd.Regions), cmd.MinMMR, cmd.MaxMMR, joinRequestMessage(cmd.Message), callerID, time.Now().UTC()))
+	if err != nil {
+		slog.ErrorContext(ctx, "unable to create squad opening", "squadID", s.ID, "err", err)
+		return nil, err
+	}
+
+	return opening, nil
+}
+
+type CloseSquadOpeningUseCase struct {
+	SquadReader   squad_out.SquadReader
+	OpeningReader squad_out.SquadOpeningReader
+	OpeningWriter squad_out.SquadOpeningWriter
+}
+
+func NewCloseSquadOpeningUseCase(squadReader squad_out.SquadReader, openingReader squad_out.SquadOpeningReader, openingWriter squad_out.SquadOpeningWriter) squad_in.CloseSquadOpeningCommandHandler {
+	return &CloseSquadOpeningUseCase{
+		SquadReader:   squadReader,
+		OpeningReader: openingReader,
+		OpeningWriter: openingWriter,
+	}
+}
+
+func (usecase *CloseSquadOpeningUseCase) Exec(ctx context.Context, cmd squad_in.CloseSquadOpeningCommand) (*squad_entities.SquadOpening, error) {
+	s, err := getSquad(ctx, usecase.SquadReader, cmd.SquadID)
+	if err != nil {
+		return nil, err
+	}
+
+	if common.GetResourceOwner(ctx).UserID != s.OwnerID() {
+		return nil, squad.NewSquadForbiddenError("only the squad owner closes openings")
+	}
+
+	opening, err := getSquadOpening(ctx, usecase.OpeningReader, cmd.OpeningID)
+	if err != nil {
+		return nil, err
+	}
+
+	if opening.SquadID != s.ID {
+		return nil, squad.NewRecruitmentNotFoundError("opening", cmd.OpeningID)
+	}
+
+	if opening.Status == squad_entities.RecruitmentStatusClosed {
+		return opening, nil
+	}
+
+	opening.Status = squad_entities.RecruitmentStatusClosed
+	opening.UpdatedAt = time.Now().UTC()
+
+	opening, err = usecase.OpeningWriter.Update(ctx, opening)
+	if err != nil {
+		slog.ErrorContext(ctx, "unable to close squad opening", "openingID", cmd.OpeningID, "err", err)
+		return nil, err
+	}
+
+	return opening, nil
+}
+
+type RecruitmentSearchUseCase struct {
+	FreeAgentReader squad_out.FreeAgentReader
+	OpeningReader   squad_out.SquadOpeningReader
+}
+
+func NewRecruitmentSearchUseCase(freeAgentReader squad_out.FreeAgentReader, openingReader squad_out.SquadOpeningReader) squad_in.RecruitmentSearch {
+	return &RecruitmentSearchUseCase{
+		FreeAgentReader: freeAgentReader,
+		OpeningReader:   openingReader,
+	}
+}
+
+func (usecase *RecruitmentSearchUseCase) Search(ctx context.Context, filter squad_in.RecruitmentFilter) (*squad_in.RecruitmentSearchResult, error) {
+	if filter.MinMMR < 0 || filter.MaxMMR < 0 || (filter.MaxMMR > 0 && filter.MinMMR > filter.MaxMMR) {
+		return nil, squad.NewInvalidRecruitmentError(fmt.Sprintf("invalid MMR range [%d, %d]", filter.MinMMR, filter.MaxMMR))
+	}
+
+	result := &squad_in.RecruitmentSearchResult{
+		FreeAgents: make([]squad_entities.FreeAgent, 0),
+		Openings:   make([]squad_entities.SquadOpening, 0),
+	}
+
+	var err error
+
+	if filter.Side != squad_in.RecruitmentSideOpenings {
+		result.FreeAgents, err = usecase.searchFreeAgents(ctx, filter)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if filter.Side != squad_in.RecruitmentSideFreeAgents {
+		result.Openings, err = usecase.searchOpenings(ctx, filter)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return result, nil
+}
+
+func (usecase *RecruitmentSearchUseCase) MatchOpening(ctx context.Context, openingID uuid.UUID) ([]squad_entities.FreeAgent, error) {
+	opening, err := getSquadOpening(ctx, usecase.OpeningReader, openingID)
+	if err != nil {
+		return nil, err
+	}
+
+	return usecase.searchFreeAgents(ctx, squad_in.RecruitmentFilter{
+		GameID:  opening.GameID,
+		Roles:   opening.Roles,
+		Regions: opening.Regions,
+		MinMMR:  opening.MinMMR,
+		MaxMMR:  opening.MaxMMR,
+	})
+}
+
+func (usecase *RecruitmentSearchUseCase) MatchFreeAgent(ctx context.Context, freeAgentID uuid.UUID) ([]squad_entities.SquadOpening, error) {
+	listings, err := usecase.FreeAgentReader.Search(ctx, common.NewSearchByID(ctx, freeAgentID, common.ClientApplicationAudienceIDKey))
+	if err != nil {
+		slog.ErrorContext(ctx, "unable to search free agent listing", "freeAgentID", freeAgentID, "err", err)
+		return nil, err
+	}
+
+	if len(listings) == 0 {
+		return nil, squad.NewRecruitmentNotFoundError("free agent", freeAgentID)
+	}
+
+	freeAgent := listings[0]
+
+	// an MMR range of the rating of the free agent only: the openings accepting it
+	return usecase.searchOpenings(ctx, squad_in.RecruitmentFilter{
+		GameID:  freeAgent.GameID,
+		Roles:   freeAgent.Roles,
+		Regions: freeAgent.Regions,
+		MinMMR:  freeAgent.MMR,
+		MaxMMR:  freeAgent.MMR,
+	})
+}
+
+func (usecase *RecruitmentSearchUseCase) searchFreeAgents(ctx context.Context, filter squad_in.RecruitmentFilter) ([]squad_entities.FreeAgent, error) {
+	params := recruitmentSearchParams(filter)
+
+	if filter.MinMMR > 0 {
+		params[0].ValueParams = append(params[0].ValueParams, common.SearchableValue{Field: "MMR", Values: []interface{}{filter.MinMMR}, Operator: common.GreaterThanOrEqualOperator})
+	}
+
+	if filter.MaxMMR > 0 {
+		params[0].ValueParams = append(params[0].ValueParams, common.SearchableValue{Field: "MMR", Values: []interface{}{filter.MaxMMR}, Operator: common.LessThanOrEqualOperator})
+	}
+
+	freeAgents, err := usecase.FreeAgentReader.Search(ctx, common.NewSearchByAggregation(ctx, []common.SearchAggregation{{Params: params}}, recruitmentResultOptions(filter), common.ClientApplicationAudienceIDKey))
+	if err != nil {
+		slog.ErrorContext(ctx, "unable to search free agents", "gameID", filter.GameID, "err", err)
+		return nil, err
+	}
+
+	return freeAgents, nil
+}
+
+func (usecase *RecruitmentSearchUseCase) searchOpenings(ctx context.Context, filter squad_in.RecruitmentFilter) ([]squad_entities.SquadOpening, error) {
+	params := recruitmentSearchParams(filter)
+
+	// the MMR range of the opening overlaps the one of the filter
+	if filter.MaxMMR > 0 {
+		params = append(params, common.SearchParameter{ValueParams: []common.SearchableValue{
+			{Field: "MinMMR", Values: []interface{}{filter.MaxMMR}, Operator: common.LessThanOrEqualOperator},
+		}})
+	}
+
+	if filter.MinMMR > 0 {
+		params = append(params, common.SearchParameter{
+			AggregationClause: common.OrAggregationClause,
+			ValueParams: []common.SearchableValue{
+				{Field: "MaxMMR", Values: []interface{}{filter.MinMMR}, Operator: common.GreaterThanOrEqualOperator},
+				{Field: "MaxMMR", Values: []interface{}{0}, Operator: common.EqualsOperator},
+			},
+		})
+	}
+
+	openings, err := usecase.OpeningReader.Search(ctx, common.NewSearchByAggregation(ctx, []common.SearchAggregation{{Params: params}}, recruitmentResultOptions(filter), common.ClientApplicationAudienceIDKey))
+	if err != nil {
+		slog.ErrorContext(ctx, "unable to search squad openings", "gameID", filter.GameID, "err", err)
+		return nil, err
+	}
+
+	return openings, nil
+}
+
+// recruitmentSearchParams are the filters shared by both sides: the open ones of the game, with any of the roles and regions (or with
+// none: any role or region).
+func recruitmentSearchParams(filter squad_in.RecruitmentFilter) []common.SearchParameter {
+	params := []common.SearchParameter{{ValueParams: []common.SearchableValue{
+		{Field: "GameID", Values: []interface{}{filter.GameID}},
+		{Field: "Status", Values: []interface{}{squad_entities.RecruitmentStatusOpen}},
+	}}}
+
+	if len(filter.Roles) > 0 {
+		params = append(params, anyOrNoneParam("Roles", filter.Roles))
+	}
+
+	if len(filter.Regions) > 0 {
+		params = append(params, anyOrNoneParam("Regions", filter.Regions))
+	}
+
+	return params
+}
+
+// anyOrNoneParam matches the lists of the field with any of the values, or empty.
+func anyOrNoneParam(field string, values []string) common.SearchParameter {
+	in := make([]interface{}, len(values))
+	for i, value := range values {
+		in[i] = value
+	}
+
+	return common.SearchParameter{
+		AggregationClause: common.OrAggregationClause,
+		ValueParams: []common.SearchableValue{
+			{Field: field, Values: in, Operator: common.InOperator},
+			{Field: field, Values: []interface{}{[]string{}}, Operator: common.EqualsOperator},
+		},
+	}
+}
+
+func recruitmentResultOptions(filter squad_in.RecruitmentFilter) common.SearchResultOptions {
+	limit := filter.Limit
+	if limit == 0 || limit > MaxRecruitmentResults {
+		limit = MaxRecruitmentResults
+	}
+
+	options := common.NewSearchResultOptions(0, limit)
+	options.Sort = []common.SearchSortOption{{Field: "UpdatedAt", Direction: common.DescendingIDKey}}
+
+	return options
+}
+
+func getSquadOpening(ctx context.Context, reader squad_out.SquadOpeningReader, openingID uuid.UUID) (*squad_entities.SquadOpening, error) {
+	openings, err := reader.Search(ctx, common.NewSearchByID(ctx, openingID, common.ClientApplicationAudienceIDKey))
+	if err != nil {
+		slog.ErrorContext(ctx, "unable to search squad opening", "openingID", openingID, "err", err)
+		return nil, err
+	}
+
+	if len(openings) == 0 {
+		return nil, squad.NewRecruitmentNotFoundError("opening", openingID)
+	}
+
+	return &openings[0], nil
+}
+
+// recruitmentValues trims the roles or regions, dropping the empty and repeated ones.
+func recruitmentValues(values []string) []string {
+	seen := make(map[string]bool, len(values))
+	trimmed := make([]string, 0, len(values))
+
+	for _, value := range values {
+		value = strings.TrimSpace(value)
+		if value == "" || seen[value] {
+			continue
+		}
+
+		seen[value] = true
+		trimmed = append(trimmed, value)
+	}
+
+	return trimmed
+}
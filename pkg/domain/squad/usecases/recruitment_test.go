@@ -0,0 +1,376 @@
+package squad_usecases_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	iam_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/iam/entities"
+	"github.com/psavelis/team-pro/replay-api/pkg/domain/squad"
+	squad_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/squad/entities"
+	squad_in "github.com/psavelis/team-pro/replay-api/pkg/domain/squad/ports/in"
+	squad_usecases "github.com/psavelis/team-pro/replay-api/pkg/domain/squad/usecases"
+	"github.com/stretchr/testify/assert"
+)
+
+// matchesSearch evaluates the value filters of the search built by the recruitment use cases (and their or-groups).
+func matchesSearch(s common.Search, field func(name string) interface{}) bool {
+	for _, param := range s.SearchParams[0].Params {
+		matches := param.AggregationClause != common.OrAggregationClause
+
+		for _, v := range param.ValueParams {
+			if param.AggregationClause == common.OrAggregationClause {
+				matches = matches || matchesValue(v, field(v.Field))
+			} else {
+				matches = matches && matchesValue(v, field(v.Field))
+			}
+		}
+
+		if !matches {
+			return false
+		}
+	}
+
+	return true
+}
+
+func matchesValue(v common.SearchableValue, value interface{}) bool {
+	switch v.Operator {
+	case common.GreaterThanOrEqualOperator:
+		return value.(int) >= v.Values[0].(int)
+	case common.LessThanOrEqualOperator:
+		return value.(int) <= v.Values[0].(int)
+	}
+
+	if expected, ok := v.Values[0].([]string); ok {
+		return len(expected) == 0 && len(value.([]string)) == 0
+	}
+
+	// arrays match when any of their elements is one of the values
+	elements := []interface{}{value}
+	if list, ok := value.([]string); ok {
+		elements = make([]interface{}, len(list))
+		for i, element := range list {
+			elements[i] = element
+		}
+	}
+
+	for _, element := range elements {
+		for _, expected := range v.Values {
+			if element == expected {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+type mockFreeAgentStore struct {
+	freeAgents []squad_entities.FreeAgent
+}
+
+func (m *mockFreeAgentStore) Search(ctx context.Context, s common.Search) ([]squad_entities.FreeAgent, error) {
+	res := make([]squad_entities.FreeAgent, 0)
+
+	for _, f := range m.freeAgents {
+		// the listings of the user only, for the user audience
+		if s.VisibilityOptions.IntendedAudience == common.UserAudienceIDKey && f.UserID != s.VisibilityOptions.RequestSource.UserID {
+			continue
+		}
+
+		matches := matchesSearch(s, func(name string) interface{} {
+			switch name {
+			case "ID":
+				return f.ID
+			case "GameID":
+				return f.GameID
+			case "Status":
+				return f.Status
+			case "Roles":
+				return f.Roles
+			case "Regions":
+				return f.Regions
+			case "MMR":
+				return f.MMR
+			}
+
+			return nil
+		})
+
+		if matches {
+			res = append(res, f)
+		}
+	}
+
+	return res, nil
+}
+
+func (m *mockFreeAgentStore) Compile(ctx context.Context, searchParams []common.SearchAggregation, resultOptions common.SearchResultOptions) (*common.Search, error) {
+	return nil, nil
+}
+
+func (m *mockFreeAgentStore) Create(ctx context.Context, f *squad_entities.FreeAgent) (*squad_entities.FreeAgent, error) {
+	m.freeAgents = append(m.freeAgents, *f)
+	return f, nil
+}
+
+func (m *mockFreeAgentStore) Update(ctx context.Context, f *squad_entities.FreeAgent) (*squad_entities.FreeAgent, error) {
+	for i := range m.freeAgents {
+		if m.freeAgents[i].ID == f.ID {
+			m.freeAgents[i] = *f
+		}
+	}
+
+	return f, nil
+}
+
+type mockOpeningStore struct {
+	openings []squad_entities.SquadOpening
+}
+
+func (m *mockOpeningStore) Search(ctx context.Context, s common.Search) ([]squad_entities.SquadOpening, error) {
+	res := make([]squad_entities.SquadOpening, 0)
+
+	for _, o := range m.openings {
+		matches := matchesSearch(s, func(name string) interface{} {
+			switch name {
+			case "ID":
+				return o.ID
+			case "SquadID":
+				return o.SquadID
+			case "GameID":
+				return o.GameID
+			case "Status":
+				return o.Status
+			case "Roles":
+				return o.Roles
+			case "Regions":
+				return o.Regions
+			case "MinMMR":
+				return o.MinMMR
+			case "MaxMMR":
+				return o.MaxMMR
+			}
+
+			return nil
+		})
+
+		if matches {
+			res = append(res, o)
+		}
+	}
+
+	return res, nil
+}
+
+func (m *mockOpeningStore) Compile(ctx context.Context, searchParams []common.SearchAggregation, resultOptions common.SearchResultOptions) (*common.Search, error) {
+	return nil, nil
+}
+
+func (m *mockOpeningStore) Create(ctx context.Context, o *squad_entities.SquadOpening) (*squad_entities.SquadOpening, error) {
+	m.openings = append(m.openings, *o)
+	return o, nil
+}
+
+func (m *mockOpeningStore) Update(ctx context.Context, o *squad_entities.SquadOpening) (*squad_entities.SquadOpening, error) {
+	for i := range m.openings {
+		if m.openings[i].ID == o.ID {
+			m.openings[i] = *o
+		}
+	}
+
+	return o, nil
+}
+
+type mockRatingReader struct {
+	ratings map[uuid.UUID]int
+}
+
+func (m *mockRatingReader) GetRating(ctx context.Context, gameID common.GameIDKey, playerID uuid.UUID) (int, error) {
+	return m.ratings[playerID], nil
+}
+
+type recruitmentFixture struct {
+	freeAgents *mockFreeAgentStore
+	openings   *mockOpeningStore
+	ratings    *mockRatingReader
+	ownerID    uuid.UUID
+	squad      squad_entities.Squad
+	list       squad_in.ListFreeAgentCommandHandler
+	unlist     squad_in.UnlistFreeAgentCommandHandler
+	post       squad_in.PostSquadOpeningCommandHandler
+	close      squad_in.CloseSquadOpeningCommandHandler
+	search     squad_in.RecruitmentSearch
+}
+
+func newRecruitmentFixture(userIDs ...uuid.UUID) *recruitmentFixture {
+	f := &recruitmentFixture{
+		freeAgents: &mockFreeAgentStore{},
+		openings:   &mockOpeningStore{},
+		ratings:    &mockRatingReader{ratings: make(map[uuid.UUID]int)},
+		ownerID:    uuid.New(),
+	}
+
+	f.squad = squad_entities.NewSquad(uuid.New(), common.CS2_GAME_ID, "Night Owls", "NO", "", nil, common.ResourceOwner{TenantID: common.TeamPROTenantID, ClientID: common.TeamPROAppClientID, UserID: f.ownerID})
+	squads := &mockSquadStore{squads: []squad_entities.Squad{f.squad}}
+
+	users := &mockUserStore{users: []iam_entities.User{{ID: f.ownerID, Name: "owner"}}}
+	for _, userID := range userIDs {
+		users.users = append(users.users, iam_entities.User{ID: userID, Name: "player"})
+	}
+
+	f.list = squad_usecases.NewListFreeAgentUseCase(f.freeAgents, f.freeAgents, users, f.ratings)
+	f.unlist = squad_usecases.NewUnlistFreeAgentUseCase(f.freeAgents, f.freeAgents)
+	f.post = squad_usecases.NewPostSquadOpeningUseCase(squads, f.openings, f.openings)
+	f.close = squad_usecases.NewCloseSquadOpeningUseCase(squads, f.openings, f.openings)
+	f.search = squad_usecases.NewRecruitmentSearchUseCase(f.freeAgents, f.openings)
+
+	return f
+}
+
+// listFreeAgent lists a new player (of the rating) as a free agent.
+func (f *recruitmentFixture) listFreeAgent(t *testing.T, userID uuid.UUID, mmr int, roles []string, regions []string) *squad_entities.FreeAgent {
+	playerID := uuid.New()
+	f.ratings.ratings[playerID] = mmr
+
+	freeAgent, err := f.list.Exec(userContext(userID), squad_in.ListFreeAgentCommand{GameID: common.CS2_GAME_ID, PlayerID: playerID, Roles: roles, Regions: regions})
+	assert.NoError(t, err)
+
+	return freeAgent
+}
+
+func freeAgentIDs(freeAgents []squad_entities.FreeAgent) []uuid.UUID {
+	ids := make([]uuid.UUID, len(freeAgents))
+	for i, f := range freeAgents {
+		ids[i] = f.ID
+	}
+
+	return ids
+}
+
+func openingIDs(openings []squad_entities.SquadOpening) []uuid.UUID {
+	ids := make([]uuid.UUID, len(openings))
+	for i, o := range openings {
+		ids[i] = o.ID
+	}
+
+	return ids
+}
+
+func TestRecruitment_FreeAgentListing(t *testing.T) {
+	userID := uuid.New()
+	f := newRecruitmentFixture(userID)
+
+	_, err := f.list.Exec(userContext(userID), squad_in.ListFreeAgentCommand{GameID: common.CS2_GAME_ID})
+	var invalidErr *squad.InvalidRecruitmentError
+	assert.ErrorAs(t, err, &invalidErr)
+
+	listed := f.listFreeAgent(t, userID, 1800, []string{"awp", " ", "awp", "entry"}, []string{"SA"})
+	assert.Equal(t, 1800, listed.MMR)
+	assert.Equal(t, []string{"awp", "entry"}, listed.Roles)
+	assert.Equal(t, "player", listed.UserName)
+	assert.Equal(t, squad_entities.RecruitmentStatusOpen, listed.Status)
+
+	// listing again updates the open listing of the user
+	relisted := f.listFreeAgent(t, userID, 1900, []string{"support"}, []string{"NA"})
+	assert.Equal(t, listed.ID, relisted.ID)
+	assert.Len(t, f.freeAgents.freeAgents, 1)
+	assert.Equal(t, 1900, f.freeAgents.freeAgents[0].MMR)
+
+	_, err = f.unlist.Exec(userContext(userID), squad_in.UnlistFreeAgentCommand{FreeAgentID: listed.ID})
+	assert.NoError(t, err)
+
+	result, err := f.search.Search(userContext(userID), squad_in.RecruitmentFilter{GameID: common.CS2_GAME_ID})
+	assert.NoError(t, err)
+	assert.Empty(t, result.FreeAgents)
+
+	_, err = f.unlist.Exec(userContext(userID), squad_in.UnlistFreeAgentCommand{FreeAgentID: uuid.New()})
+	var notFoundErr *squad.RecruitmentNotFoundError
+	assert.ErrorAs(t, err, &notFoundErr)
+}
+
+func TestRecruitment_SquadOpenings(t *testing.T) {
+	f := newRecruitmentFixture()
+
+	_, err := f.post.Exec(userContext(uuid.New()), squad_in.PostSquadOpeningCommand{SquadID: f.squad.ID})
+	var forbiddenErr *squad.SquadForbiddenError
+	assert.ErrorAs(t, err, &forbiddenErr)
+
+	_, err = f.post.Exec(userContext(f.ownerID), squad_in.PostSquadOpeningCommand{SquadID: f.squad.ID, MinMMR: 2000, MaxMMR: 1000})
+	var invalidErr *squad.InvalidRecruitmentError
+	assert.ErrorAs(t, err, &invalidErr)
+
+	opening, err := f.post.Exec(userContext(f.ownerID), squad_in.PostSquadOpeningCommand{SquadID: f.squad.ID, Roles: []string{"awp"}, MinMMR: 1500, MaxMMR: 2000})
+	assert.NoError(t, err)
+	assert.Equal(t, f.squad.Name, opening.SquadName)
+	assert.Equal(t, f.squad.GameID, opening.GameID)
+	assert.True(t, opening.Accepts(1500))
+	assert.False(t, opening.Accepts(2001))
+
+	for i := 1; i < squad_usecases.MaxOpenSquadOpenings; i++ {
+		_, err = f.post.Exec(userContext(f.ownerID), squad_in.PostSquadOpeningCommand{SquadID: f.squad.ID})
+		assert.NoError(t, err)
+	}
+
+	_, err = f.post.Exec(userContext(f.ownerID), squad_in.PostSquadOpeningCommand{SquadID: f.squad.ID})
+	assert.ErrorAs(t, err, &invalidErr)
+
+	closed, err := f.close.Exec(userContext(f.ownerID), squad_in.CloseSquadOpeningCommand{SquadID: f.squad.ID, OpeningID: opening.ID})
+	assert.NoError(t, err)
+	assert.Equal(t, squad_entities.RecruitmentStatusClosed, closed.Status)
+
+	// another spot opens once closed
+	_, err = f.post.Exec(userContext(f.ownerID), squad_in.PostSquadOpeningCommand{SquadID: f.squad.ID})
+	assert.NoError(t, err)
+}
+
+func TestRecruitment_SearchAndMatch(t *testing.T) {
+	awpID, entryID, supportID := uuid.New(), uuid.New(), uuid.New()
+	f := newRecruitmentFixture(awpID, entryID, supportID)
+
+	awp := f.listFreeAgent(t, awpID, 1800, []string{"awp"}, []string{"SA"})
+	entry := f.listFreeAgent(t, entryID, 1200, []string{"entry", "awp"}, []string{"SA", "NA"})
+	support := f.listFreeAgent(t, supportID, 2500, []string{"support"}, []string{"NA"})
+
+	awpOpening, err := f.post.Exec(userContext(f.ownerID), squad_in.PostSquadOpeningCommand{SquadID: f.squad.ID, Roles: []string{"awp"}, Regions: []string{"SA"}, MinMMR: 1500, MaxMMR: 2000})
+	assert.NoError(t, err)
+
+	anyOpening, err := f.post.Exec(userContext(f.ownerID), squad_in.PostSquadOpeningCommand{SquadID: f.squad.ID, MinMMR: 2200})
+	assert.NoError(t, err)
+
+	ctx := userContext(awpID)
+
+	result, err := f.search.Search(ctx, squad_in.RecruitmentFilter{GameID: common.CS2_GAME_ID, Roles: []string{"awp"}})
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []uuid.UUID{awp.ID, entry.ID}, freeAgentIDs(result.FreeAgents))
+	// openings without roles need any role
+	assert.ElementsMatch(t, []uuid.UUID{awpOpening.ID, anyOpening.ID}, openingIDs(result.Openings))
+
+	result, err = f.search.Search(ctx, squad_in.RecruitmentFilter{GameID: common.CS2_GAME_ID, Regions: []string{"NA"}, MinMMR: 1000, MaxMMR: 2000})
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []uuid.UUID{entry.ID}, freeAgentIDs(result.FreeAgents))
+
+	// openings without an upper bound overlap any range above their minimum
+	result, err = f.search.Search(ctx, squad_in.RecruitmentFilter{GameID: common.CS2_GAME_ID, Side: squad_in.RecruitmentSideOpenings, MinMMR: 3000})
+	assert.NoError(t, err)
+	assert.Empty(t, result.FreeAgents)
+	assert.ElementsMatch(t, []uuid.UUID{anyOpening.ID}, openingIDs(result.Openings))
+
+	_, err = f.search.Search(ctx, squad_in.RecruitmentFilter{GameID: common.CS2_GAME_ID, MinMMR: 2000, MaxMMR: 1000})
+	var invalidErr *squad.InvalidRecruitmentError
+	assert.ErrorAs(t, err, &invalidErr)
+
+	freeAgents, err := f.search.MatchOpening(ctx, awpOpening.ID)
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []uuid.UUID{awp.ID}, freeAgentIDs(freeAgents))
+
+	openings, err := f.search.MatchFreeAgent(ctx, support.ID)
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []uuid.UUID{anyOpening.ID}, openingIDs(openings))
+
+	openings, err = f.search.MatchFreeAgent(ctx, entry.ID)
+	assert.NoError(t, err)
+	assert.Empty(t, openings)
+}
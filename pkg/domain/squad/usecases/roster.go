@@ -0,0 +1,192 @@
+package squad_usecases
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	iam_out "github.com/psavelis/team-pro/replay-api/pkg/domain/iam/ports/out"
+	"github.com/psavelis/team-pro/replay-api/pkg/domain/squad"
+	squad_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/squad/entities"
+	squad_in "github.com/psavelis/team-pro/replay-api/pkg/domain/squad/ports/in"
+	squad_out "github.com/psavelis/team-pro/replay-api/pkg/domain/squad/ports/out"
+	squad_value_objects "github.com/psavelis/team-pro/replay-api/pkg/domain/squad/value-objects"
+)
+
+type SetMemberRoleUseCase struct {
+	SquadReader   squad_out.SquadReader
+	SquadWriter   squad_out.SquadWriter
+	HistoryWriter squad_out.SquadHistoryWriter
+}
+
+func NewSetMemberRoleUseCase(squadReader squad_out.SquadReader, squadWriter squad_out.SquadWriter, historyWriter squad_out.SquadHistoryWriter) squad_in.SetMemberRoleCommandHandler {
+	return &SetMemberRoleUseCase{
+		SquadReader:   squadReader,
+		SquadWriter:   squadWriter,
+		HistoryWriter: historyWriter,
+	}
+}
+
+func (usecase *SetMemberRoleUseCase) Exec(ctx context.Context, cmd squad_in.SetMemberRoleCommand) (*squad_entities.Squad, error) {
+	if !cmd.Role.Known() {
+		return nil, squad.NewInvalidMemberRoleError(string(cmd.Role))
+	}
+
+	s, err := getSquad(ctx, usecase.SquadReader, cmd.SquadID)
+	if err != nil {
+		return nil, err
+	}
+
+	callerID := common.GetResourceOwner(ctx).UserID
+	if callerID != s.OwnerID() {
+		return nil, squad.NewSquadForbiddenError("only the squad owner manages member roles")
+	}
+
+	if !s.IsMember(cmd.UserID) {
+		return nil, squad.NewMemberNotFoundError(s.ID, cmd.UserID)
+	}
+
+	current, _ := s.Member(cmd.UserID)
+	if current.Role == cmd.Role {
+		return s, nil
+	}
+
+	now := time.Now().UTC()
+
+	// a single captain per squad: the current one steps down to player first
+	if cmd.Role == squad_value_objects.MemberRoleCaptain {
+		captainID, ok := s.Captain()
+		if ok && captainID != cmd.UserID {
+			err = usecase.SquadWriter.SetMemberRole(ctx, s.ID, captainID, squad_value_objects.MemberRolePlayer)
+			if err != nil {
+				slog.ErrorContext(ctx, "unable to demote squad captain", "squadID", s.ID, "userID", captainID, "err", err)
+				return nil, err
+			}
+
+			recordHistory(ctx, usecase.HistoryWriter, squad_entities.NewSquadHistory(*s, captainID, callerID, squad_entities.SquadMemberDemoted, now))
+		}
+	}
+
+	err = usecase.SquadWriter.SetMemberRole(ctx, s.ID, cmd.UserID, cmd.Role)
+	if err != nil {
+		slog.ErrorContext(ctx, "unable to set squad member role", "squadID", s.ID, "userID", cmd.UserID, "role", cmd.Role, "err", err)
+		return nil, err
+	}
+
+	action := squad_entities.SquadMemberDemoted
+	if cmd.Role.Rank() > current.Role.Rank() {
+		action = squad_entities.SquadMemberPromoted
+	}
+
+	recordHistory(ctx, usecase.HistoryWriter, squad_entities.NewSquadHistory(*s, cmd.UserID, callerID, action, now))
+
+	return getSquad(ctx, usecase.SquadReader, s.ID)
+}
+
+type TransferSquadOwnershipUseCase struct {
+	SquadReader   squad_out.SquadReader
+	SquadWriter   squad_out.SquadWriter
+	UserReader    iam_out.UserReader
+	HistoryWriter squad_out.SquadHistoryWriter
+}
+
+func NewTransferSquadOwnershipUseCase(squadReader squad_out.SquadReader, squadWriter squad_out.SquadWriter, userReader iam_out.UserReader, historyWriter squad_out.SquadHistoryWriter) squad_in.TransferSquadOwnershipCommandHandler {
+	return &TransferSquadOwnershipUseCase{
+		SquadReader:   squadReader,
+		SquadWriter:   squadWriter,
+		UserReader:    userReader,
+		HistoryWriter: historyWriter,
+	}
+}
+
+func (usecase *TransferSquadOwnershipUseCase) Exec(ctx context.Context, cmd squad_in.TransferSquadOwnershipCommand) (*squad_entities.Squad, error) {
+	s, err := getSquad(ctx, usecase.SquadReader, cmd.SquadID)
+	if err != nil {
+		return nil, err
+	}
+
+	callerID := common.GetResourceOwner(ctx).UserID
+	if callerID != s.OwnerID() {
+		return nil, squad.NewSquadForbiddenError("only the squad owner transfers the squad")
+	}
+
+	if cmd.UserID == callerID {
+		return nil, squad.NewMembershipStateError("user already owns the squad")
+	}
+
+	if _, ok := s.Member(cmd.UserID); !ok {
+		return nil, squad.NewMemberNotFoundError(s.ID, cmd.UserID)
+	}
+
+	// the previous owner stays in the roster, as a player unless it already had a profile
+	previousOwner, ok := s.Member(callerID)
+	if !ok {
+		userName, err := getUserName(ctx, usecase.UserReader, callerID)
+		if err != nil {
+			return nil, err
+		}
+
+		if userName != nil {
+			previousOwner.Name = *userName
+		}
+	}
+
+	err = usecase.SquadWriter.TransferOwnership(ctx, s.ID, callerID, cmd.UserID, previousOwner)
+	if err != nil {
+		slog.ErrorContext(ctx, "unable to transfer squad ownership", "squadID", s.ID, "fromUserID", callerID, "toUserID", cmd.UserID, "err", err)
+		return nil, err
+	}
+
+	recordHistory(ctx, usecase.HistoryWriter, squad_entities.NewSquadHistory(*s, cmd.UserID, callerID, squad_entities.SquadOwnershipTransfered, time.Now().UTC()))
+
+	return getSquad(ctx, usecase.SquadReader, s.ID)
+}
+
+type RemoveSquadMemberUseCase struct {
+	SquadReader   squad_out.SquadReader
+	SquadWriter   squad_out.SquadWriter
+	HistoryWriter squad_out.SquadHistoryWriter
+}
+
+func NewRemoveSquadMemberUseCase(squadReader squad_out.SquadReader, squadWriter squad_out.SquadWriter, historyWriter squad_out.SquadHistoryWriter) squad_in.RemoveSquadMemberCommandHandler {
+	return &RemoveSquadMemberUseCase{
+		SquadReader:   squadReader,
+		SquadWriter:   squadWriter,
+		HistoryWriter: historyWriter,
+	}
+}
+
+func (usecase *RemoveSquadMemberUseCase) Exec(ctx context.Context, cmd squad_in.RemoveSquadMemberCommand) (*squad_entities.Squad, error) {
+	s, err := getSquad(ctx, usecase.SquadReader, cmd.SquadID)
+	if err != nil {
+		return nil, err
+	}
+
+	callerID := common.GetResourceOwner(ctx).UserID
+
+	action := squad_entities.SquadMemberRemoved
+	if cmd.UserID == callerID {
+		action = squad_entities.SquadMemberLeft
+	} else if callerID != s.OwnerID() {
+		return nil, squad.NewSquadForbiddenError("only the squad owner removes members")
+	}
+
+	if cmd.UserID == s.OwnerID() {
+		return nil, squad.NewMembershipStateError("the squad owner transfers the squad before leaving")
+	}
+
+	if _, ok := s.Member(cmd.UserID); !ok {
+		return nil, squad.NewMemberNotFoundError(s.ID, cmd.UserID)
+	}
+
+	err = usecase.SquadWriter.RemoveMember(ctx, s.ID, cmd.UserID)
+	if err != nil {
+		slog.ErrorContext(ctx, "unable to remove squad member", "squadID", s.ID, "userID", cmd.UserID, "err", err)
+		return nil, err
+	}
+
+	recordHistory(ctx, usecase.HistoryWriter, squad_entities.NewSquadHistory(*s, cmd.UserID, callerID, action, time.Now().UTC()))
+
+	return getSquad(ctx, usecase.SquadReader, s.ID)
+}
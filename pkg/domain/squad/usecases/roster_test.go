@@ -0,0 +1,114 @@
+package squad_usecases_test
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	iam_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/iam/entities"
+	"github.com/psavelis/team-pro/replay-api/pkg/domain/squad"
+	squad_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/squad/entities"
+	squad_in "github.com/psavelis/team-pro/replay-api/pkg/domain/squad/ports/in"
+	squad_usecases "github.com/psavelis/team-pro/replay-api/pkg/domain/squad/usecases"
+	squad_value_objects "github.com/psavelis/team-pro/replay-api/pkg/domain/squad/value-objects"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRoster_RolesAndRemoval(t *testing.T) {
+	ownerID, aliceID, bobID := uuid.New(), uuid.New(), uuid.New()
+
+	s := squad_entities.NewSquad(uuid.New(), common.CS2_GAME_ID, "Night Owls", "NO", "", map[string]squad_value_objects.Profile{
+		squad_entities.MemberKey(aliceID): {Name: "alice", Role: squad_value_objects.MemberRoleCaptain},
+		squad_entities.MemberKey(bobID):   {Name: "bob"},
+	}, common.ResourceOwner{TenantID: common.TeamPROTenantID, ClientID: common.TeamPROAppClientID, UserID: ownerID})
+
+	squads := &mockSquadStore{squads: []squad_entities.Squad{s}}
+	history := &mockHistoryStore{}
+
+	setRole := squad_usecases.NewSetMemberRoleUseCase(squads, squads, history)
+	remove := squad_usecases.NewRemoveSquadMemberUseCase(squads, squads, history)
+
+	_, err := setRole.Exec(userContext(aliceID), squad_in.SetMemberRoleCommand{SquadID: s.ID, UserID: bobID, Role: squad_value_objects.MemberRoleCaptain})
+	assert.IsType(t, &squad.SquadForbiddenError{}, err)
+
+	_, err = setRole.Exec(userContext(ownerID), squad_in.SetMemberRoleCommand{SquadID: s.ID, UserID: bobID, Role: "igl"})
+	assert.IsType(t, &squad.InvalidMemberRoleError{}, err)
+
+	_, err = setRole.Exec(userContext(ownerID), squad_in.SetMemberRoleCommand{SquadID: s.ID, UserID: uuid.New(), Role: squad_value_objects.MemberRoleCoach})
+	assert.IsType(t, &squad.MemberNotFoundError{}, err)
+
+	// a single captain: alice steps down when bob is promoted
+	updated, err := setRole.Exec(userContext(ownerID), squad_in.SetMemberRoleCommand{SquadID: s.ID, UserID: bobID, Role: squad_value_objects.MemberRoleCaptain})
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	alice, _ := updated.Member(aliceID)
+	bob, _ := updated.Member(bobID)
+	assert.Equal(t, squad_value_objects.MemberRolePlayer, alice.Role)
+	assert.Equal(t, squad_value_objects.MemberRoleCaptain, bob.Role)
+
+	_, err = setRole.Exec(userContext(ownerID), squad_in.SetMemberRoleCommand{SquadID: s.ID, UserID: aliceID, Role: squad_value_objects.MemberRoleSubstitute})
+	assert.NoError(t, err)
+
+	// members leave, only the owner removes others, the owner transfers the squad first
+	_, err = remove.Exec(userContext(aliceID), squad_in.RemoveSquadMemberCommand{SquadID: s.ID, UserID: bobID})
+	assert.IsType(t, &squad.SquadForbiddenError{}, err)
+
+	_, err = remove.Exec(userContext(ownerID), squad_in.RemoveSquadMemberCommand{SquadID: s.ID, UserID: ownerID})
+	assert.IsType(t, &squad.MembershipStateError{}, err)
+
+	_, err = remove.Exec(userContext(aliceID), squad_in.RemoveSquadMemberCommand{SquadID: s.ID, UserID: aliceID})
+	assert.NoError(t, err)
+
+	updated, err = remove.Exec(userContext(ownerID), squad_in.RemoveSquadMemberCommand{SquadID: s.ID, UserID: bobID})
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	assert.Empty(t, updated.Profiles)
+
+	_, err = remove.Exec(userContext(ownerID), squad_in.RemoveSquadMemberCommand{SquadID: s.ID, UserID: bobID})
+	assert.IsType(t, &squad.MemberNotFoundError{}, err)
+
+	assert.Equal(t, []squad_entities.SquadHistoryAction{
+		squad_entities.SquadMemberDemoted,
+		squad_entities.SquadMemberPromoted,
+		squad_entities.SquadMemberDemoted,
+		squad_entities.SquadMemberLeft,
+		squad_entities.SquadMemberRemoved,
+	}, history.actions())
+}
+
+func TestRoster_TransferOwnership(t *testing.T) {
+	ownerID, aliceID := uuid.New(), uuid.New()
+
+	s := squad_entities.NewSquad(uuid.New(), common.CS2_GAME_ID, "Night Owls", "NO", "", map[string]squad_value_objects.Profile{
+		squad_entities.MemberKey(aliceID): {Name: "alice"},
+	}, common.ResourceOwner{TenantID: common.TeamPROTenantID, ClientID: common.TeamPROAppClientID, UserID: ownerID})
+
+	squads := &mockSquadStore{squads: []squad_entities.Squad{s}}
+	users := &mockUserStore{users: []iam_entities.User{{ID: ownerID, Name: "owner"}, {ID: aliceID, Name: "alice"}}}
+	history := &mockHistoryStore{}
+
+	transfer := squad_usecases.NewTransferSquadOwnershipUseCase(squads, squads, users, history)
+
+	_, err := transfer.Exec(userContext(aliceID), squad_in.TransferSquadOwnershipCommand{SquadID: s.ID, UserID: aliceID})
+	assert.IsType(t, &squad.SquadForbiddenError{}, err)
+
+	_, err = transfer.Exec(userContext(ownerID), squad_in.TransferSquadOwnershipCommand{SquadID: s.ID, UserID: uuid.New()})
+	assert.IsType(t, &squad.MemberNotFoundError{}, err)
+
+	updated, err := transfer.Exec(userContext(ownerID), squad_in.TransferSquadOwnershipCommand{SquadID: s.ID, UserID: aliceID})
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	// the previous owner stays in the roster
+	assert.Equal(t, aliceID, updated.OwnerID())
+	previousOwner, ok := updated.Member(ownerID)
+	assert.True(t, ok)
+	assert.Equal(t, "owner", previousOwner.Name)
+
+	assert.Equal(t, []squad_entities.SquadHistoryAction{squad_entities.SquadOwnershipTransfered}, history.actions())
+}
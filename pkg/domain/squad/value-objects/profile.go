@@ -1,7 +1,36 @@
 package squad_value_objects
 
+// MemberRole is the role of a member in the roster of the squad, members without one are regular players.
+type MemberRole string
+
+const (
+	MemberRolePlayer     MemberRole = ""
+	MemberRoleCaptain    MemberRole = "captain" // a single captain per squad
+	MemberRoleCoach      MemberRole = "coach"
+	MemberRoleSubstitute MemberRole = "substitute"
+)
+
+// MemberRoles lists the known roles, from the lowest rank (promotions move up the list).
+var MemberRoles = []MemberRole{MemberRoleSubstitute, MemberRolePlayer, MemberRoleCoach, MemberRoleCaptain}
+
+func (r MemberRole) Known() bool {
+	return r.Rank() >= 0
+}
+
+// Rank is the position of the role in MemberRoles, -1 when unknown.
+func (r MemberRole) Rank() int {
+	for i, role := range MemberRoles {
+		if role == r {
+			return i
+		}
+	}
+
+	return -1
+}
+
 type Profile struct {
 	Name    string                 `json:"name" bson:"name"`
+	Role    MemberRole             `json:"role,omitempty" bson:"role,omitempty"`
 	Details map[string]interface{} `json:"details" bson:"details"`
 }
 
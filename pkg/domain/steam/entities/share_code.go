@@ -0,0 +1,93 @@
+package steam_entities
+
+import (
+	"encoding/binary"
+	"errors"
+	"math/big"
+	"strings"
+	"time"
+)
+
+const (
+	ShareCodePrefix = "CSGO-"
+
+	// share codes are the match, outcome (reservation) and token encoded in base 57, without the ambiguous characters
+	shareCodeDictionary = "ABCDEFGHJKLMNOPQRSTUVWXYZabcdefhijkmnopqrstuvwxyz23456789"
+	shareCodeLength     = 25
+)
+
+var ErrInvalidShareCode = errors.New("invalid match share code")
+
+// ShareCode identifies a matchmaking match on the Game Coordinator (ie: CSGO-GADqf-jjyJ8-cSP2r-smZRo-TO2xK), as shared from the
+// CS2 match history.
+type ShareCode struct {
+	MatchID   uint64 `json:"match_id,string"`
+	OutcomeID uint64 `json:"outcome_id,string"`
+	Token     uint16 `json:"token"`
+}
+
+// DecodeShareCode parses a share code, with or without its CSGO- prefix.
+func DecodeShareCode(code string) (ShareCode, error) {
+	code = strings.ReplaceAll(strings.TrimPrefix(strings.TrimSpace(code), ShareCodePrefix), "-", "")
+	if len(code) != shareCodeLength {
+		return ShareCode{}, ErrInvalidShareCode
+	}
+
+	n := new(big.Int)
+	base := big.NewInt(int64(len(shareCodeDictionary)))
+
+	// the least significant digit comes first
+	for i := len(code) - 1; i >= 0; i-- {
+		digit := strings.IndexByte(shareCodeDictionary, code[i])
+		if digit < 0 {
+			return ShareCode{}, ErrInvalidShareCode
+		}
+
+		n.Mul(n, base).Add(n, big.NewInt(int64(digit)))
+	}
+
+	if n.BitLen() > 18*8 {
+		return ShareCode{}, ErrInvalidShareCode
+	}
+
+	raw := n.FillBytes(make([]byte, 18))
+
+	return ShareCode{
+		MatchID:   binary.LittleEndian.Uint64(raw[0:8]),
+		OutcomeID: binary.LittleEndian.Uint64(raw[8:16]),
+		Token:     binary.LittleEndian.Uint16(raw[16:18]),
+	}, nil
+}
+
+// String encodes the share code, with its CSGO- prefix.
+func (c ShareCode) String() string {
+	raw := make([]byte, 18)
+	binary.LittleEndian.PutUint64(raw[0:8], c.MatchID)
+	binary.LittleEndian.PutUint64(raw[8:16], c.OutcomeID)
+	binary.LittleEndian.PutUint16(raw[16:18], c.Token)
+
+	n := new(big.Int).SetBytes(raw)
+	base := big.NewInt(int64(len(shareCodeDictionary)))
+	digit := new(big.Int)
+
+	var code strings.Builder
+	code.WriteString(ShareCodePrefix)
+
+	for i := 0; i < shareCodeLength; i++ {
+		if i > 0 && i%5 == 0 {
+			code.WriteByte('-')
+		}
+
+		n.DivMod(n, base, digit)
+		code.WriteByte(shareCodeDictionary[digit.Int64()])
+	}
+
+	return code.String()
+}
+
+// SteamMatch is a match resolved by the Game Coordinator, with the demo recorded by Valve (available for about a month).
+type SteamMatch struct {
+	ShareCode ShareCode `json:"share_code"`
+	DemoURL   string    `json:"demo_url"`
+	MatchTime time.Time `json:"match_time"`
+}
@@ -0,0 +1,45 @@
+package steam_entities_test
+
+import (
+	"testing"
+
+	steam_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/steam/entities"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDecodeShareCode(t *testing.T) {
+	tests := []struct {
+		name string
+		code string
+		want steam_entities.ShareCode
+		err  error
+	}{
+		{
+			name: "share code",
+			code: "CSGO-GADqf-jjyJ8-cSP2r-smZRo-TO2xK",
+			want: steam_entities.ShareCode{MatchID: 3230642215713767580, OutcomeID: 3230647599455273103, Token: 55788},
+		},
+		{
+			name: "without prefix",
+			code: " GADqf-jjyJ8-cSP2r-smZRo-TO2xK ",
+			want: steam_entities.ShareCode{MatchID: 3230642215713767580, OutcomeID: 3230647599455273103, Token: 55788},
+		},
+		{name: "too short", code: "CSGO-GADqf-jjyJ8-cSP2r-smZRo", err: steam_entities.ErrInvalidShareCode},
+		{name: "ambiguous character", code: "CSGO-GADqf-jjyJ8-cSP2r-smZRo-TO2x0", err: steam_entities.ErrInvalidShareCode},
+		{name: "overflow", code: "CSGO-99999-99999-99999-99999-99999", err: steam_entities.ErrInvalidShareCode},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			code, err := steam_entities.DecodeShareCode(tt.code)
+			if tt.err != nil {
+				assert.ErrorIs(t, err, tt.err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, code)
+			assert.Equal(t, "CSGO-GADqf-jjyJ8-cSP2r-smZRo-TO2xK", code.String())
+		})
+	}
+}
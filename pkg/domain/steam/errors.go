@@ -142,3 +142,38 @@ func NewVHashRequiredError() *VHashRequiredError {
 		Message: "vHash is required",
 	}
 }
+
+type InvalidShareCodeError struct {
+	// Error message
+	Message string
+}
+
+// Error returns the error message
+func (e *InvalidShareCodeError) Error() string {
+	return e.Message
+}
+
+// NewInvalidShareCodeError creates a new InvalidShareCodeError
+func NewInvalidShareCodeError(shareCode string) *InvalidShareCodeError {
+	return &InvalidShareCodeError{
+		Message: fmt.Sprintf("Invalid match share code: %s", shareCode),
+	}
+}
+
+// Steam Match Not Found Error (unknown to the Game Coordinator, or its demo is no longer available)
+type SteamMatchNotFoundError struct {
+	// Error message
+	Message string
+}
+
+// Error returns the error message
+func (e *SteamMatchNotFoundError) Error() string {
+	return e.Message
+}
+
+// NewSteamMatchNotFoundError creates a new SteamMatchNotFoundError
+func NewSteamMatchNotFoundError(message string) *SteamMatchNotFoundError {
+	return &SteamMatchNotFoundError{
+		Message: message,
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 
 	iam_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/iam/entities"
+	replay_entity "github.com/psavelis/team-pro/replay-api/pkg/domain/replay/entities"
 	steam_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/steam/entities"
 )
 
@@ -11,3 +12,9 @@ type OnboardSteamUserCommand interface {
 	Exec(ctx context.Context, steamUser *steam_entities.SteamUser) (*steam_entities.SteamUser, *iam_entities.RIDToken, error)
 	Validate(ctx context.Context, steamUser *steam_entities.SteamUser) error
 }
+
+// ImportSteamMatchCommand imports a CS2 matchmaking match from its share code: the demo is downloaded from Valve and processed as an
+// uploaded replay file.
+type ImportSteamMatchCommand interface {
+	Exec(ctx context.Context, shareCode string) (*replay_entity.Match, error)
+}
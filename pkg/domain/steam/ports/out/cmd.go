@@ -2,6 +2,7 @@ package steam_out
 
 import (
 	"context"
+	"io"
 
 	steam_entity "github.com/psavelis/team-pro/replay-api/pkg/domain/steam/entities"
 )
@@ -13,3 +14,11 @@ type SteamUserWriter interface {
 type VHashWriter interface {
 	CreateVHash(ctx context.Context, steamID string) string
 }
+
+// SteamMatchFetcher resolves matchmaking matches through the Steam Game Coordinator and downloads their demo.
+type SteamMatchFetcher interface {
+	// Resolve returns the match of the share code, a SteamMatchNotFoundError when the Game Coordinator doesn't know it.
+	Resolve(ctx context.Context, shareCode steam_entity.ShareCode) (*steam_entity.SteamMatch, error)
+	// Download streams the (decompressed) demo of the match, a SteamMatchNotFoundError when it's no longer available.
+	Download(ctx context.Context, match steam_entity.SteamMatch) (io.ReadCloser, error)
+}
@@ -0,0 +1,51 @@
+package use_cases
+
+import (
+	"context"
+	"log/slog"
+
+	replay_entity "github.com/psavelis/team-pro/replay-api/pkg/domain/replay/entities"
+	replay_in "github.com/psavelis/team-pro/replay-api/pkg/domain/replay/ports/in"
+	"github.com/psavelis/team-pro/replay-api/pkg/domain/steam"
+	steam_entity "github.com/psavelis/team-pro/replay-api/pkg/domain/steam/entities"
+	steam_in "github.com/psavelis/team-pro/replay-api/pkg/domain/steam/ports/in"
+	steam_out "github.com/psavelis/team-pro/replay-api/pkg/domain/steam/ports/out"
+)
+
+type ImportSteamMatchUseCase struct {
+	MatchFetcher               steam_out.SteamMatchFetcher
+	UploadAndProcessReplayFile replay_in.UploadAndProcessReplayFileCommand
+}
+
+func NewImportSteamMatchUseCase(matchFetcher steam_out.SteamMatchFetcher, uploadAndProcessReplayFile replay_in.UploadAndProcessReplayFileCommand) steam_in.ImportSteamMatchCommand {
+	return &ImportSteamMatchUseCase{
+		MatchFetcher:               matchFetcher,
+		UploadAndProcessReplayFile: uploadAndProcessReplayFile,
+	}
+}
+
+func (usecase *ImportSteamMatchUseCase) Exec(ctx context.Context, shareCode string) (*replay_entity.Match, error) {
+	code, err := steam_entity.DecodeShareCode(shareCode)
+	if err != nil {
+		slog.ErrorContext(ctx, "invalid match share code", "shareCode", shareCode, "err", err)
+		return nil, steam.NewInvalidShareCodeError(shareCode)
+	}
+
+	match, err := usecase.MatchFetcher.Resolve(ctx, code)
+	if err != nil {
+		slog.ErrorContext(ctx, "unable to resolve match share code", "shareCode", code.String(), "err", err)
+		return nil, err
+	}
+
+	demo, err := usecase.MatchFetcher.Download(ctx, *match)
+	if err != nil {
+		slog.ErrorContext(ctx, "unable to download match demo", "shareCode", code.String(), "demoURL", match.DemoURL, "err", err)
+		return nil, err
+	}
+
+	defer demo.Close()
+
+	slog.InfoContext(ctx, "importing steam match", "shareCode", code.String(), "matchID", code.MatchID, "demoURL", match.DemoURL)
+
+	return usecase.UploadAndProcessReplayFile.Exec(ctx, demo)
+}
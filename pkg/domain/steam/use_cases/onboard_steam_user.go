@@ -69,8 +69,7 @@ func (usecase *OnboardSteamUserUseCase) Exec(ctx context.Context, steamUser *ste
 
 		steamUser = &steamUserResult[0]
 
-		ctx = context.WithValue(ctx, common.UserIDKey, steamUser.ResourceOwner.UserID)
-		ctx = context.WithValue(ctx, common.GroupIDKey, steamUser.ResourceOwner.GroupID)
+		ctx = common.WithResourceOwner(ctx, common.ResourceOwner{GroupID: steamUser.ResourceOwner.GroupID, UserID: steamUser.ResourceOwner.UserID})
 	}
 
 	profile, ridToken, err := usecase.OnboardOpenIDUser.Exec(ctx, iam_in.OnboardOpenIDUserCommand{
@@ -90,8 +89,7 @@ func (usecase *OnboardSteamUserUseCase) Exec(ctx context.Context, steamUser *ste
 		return nil, nil, steam.NewSteamUserCreationError(fmt.Sprintf("error creating rid token: %v", steamUser.Steam.ID))
 	}
 
-	ctx = context.WithValue(ctx, common.UserIDKey, profile.ResourceOwner.UserID)
-	ctx = context.WithValue(ctx, common.GroupIDKey, profile.ResourceOwner.GroupID)
+	ctx = common.WithResourceOwner(ctx, common.ResourceOwner{GroupID: profile.ResourceOwner.GroupID, UserID: profile.ResourceOwner.UserID})
 
 	steamUser.ResourceOwner = common.GetResourceOwner(ctx)
 
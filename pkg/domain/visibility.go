@@ -0,0 +1,80 @@
+package common
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Visibility sets who sees an entity besides its owner. Entities created with none are left as before (not listed publicly).
+type Visibility string
+
+const (
+	VisibilityPublic  Visibility = "public"
+	VisibilitySquad   Visibility = "squad" // the group of the owner (ie: its squad)
+	VisibilityPrivate Visibility = "private"
+)
+
+func (v Visibility) Known() bool {
+	switch v {
+	case VisibilityPublic, VisibilitySquad, VisibilityPrivate:
+		return true
+	}
+
+	return false
+}
+
+// VisibilityPolicy is the default visibility of the entities created in a tenant, or in a group of it (GroupID, uuid.Nil for the
+// whole tenant). The policy of the group of the owner comes first.
+type VisibilityPolicy struct {
+	ID            uuid.UUID     `json:"id" bson:"_id"`
+	GroupID       uuid.UUID     `json:"group_id" bson:"group_id"`
+	Visibility    Visibility    `json:"visibility" bson:"visibility"`
+	UpdatedBy     uuid.UUID     `json:"updated_by" bson:"updated_by"`
+	ResourceOwner ResourceOwner `json:"resource_owner" bson:"resource_owner"`
+	CreatedAt     time.Time     `json:"created_at" bson:"created_at"`
+	UpdatedAt     time.Time     `json:"updated_at" bson:"updated_at"`
+}
+
+func (p VisibilityPolicy) GetID() uuid.UUID {
+	return p.ID
+}
+
+// ApplicableVisibilityPolicy returns the policy of the group of the owner, or the one of its tenant, nil when there's none.
+func ApplicableVisibilityPolicy(policies []VisibilityPolicy, owner ResourceOwner) *VisibilityPolicy {
+	var tenantPolicy *VisibilityPolicy
+
+	for i := range policies {
+		policy := &policies[i]
+		if policy.ResourceOwner.TenantID != owner.TenantID {
+			continue
+		}
+
+		if policy.GroupID != uuid.Nil && policy.GroupID == owner.GroupID {
+			return policy
+		}
+
+		if policy.GroupID == uuid.Nil {
+			tenantPolicy = policy
+		}
+	}
+
+	return tenantPolicy
+}
+
+// VisibilityOverride audits an entity created with a visibility other than the default of the policy applying to its owner.
+type VisibilityOverride struct {
+	ID            uuid.UUID     `json:"id" bson:"_id"`
+	ResourceType  ResourceType  `json:"resource_type" bson:"resource_type"`
+	ResourceID    uuid.UUID     `json:"resource_id" bson:"resource_id"`
+	PolicyID      uuid.UUID     `json:"policy_id" bson:"policy_id"`
+	Default       Visibility    `json:"default" bson:"default"`
+	Requested     Visibility    `json:"requested" bson:"requested"`
+	RequestedBy   uuid.UUID     `json:"requested_by" bson:"requested_by"`
+	ResourceOwner ResourceOwner `json:"resource_owner" bson:"resource_owner"`
+	CreatedAt     time.Time     `json:"created_at" bson:"created_at"`
+}
+
+func (o VisibilityOverride) GetID() uuid.UUID {
+	return o.ID
+}
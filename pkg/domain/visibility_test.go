@@ -0,0 +1,68 @@
+package common_test
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestApplicableVisibilityPolicy(t *testing.T) {
+	owner := common.ResourceOwner{TenantID: uuid.New(), GroupID: uuid.New(), UserID: uuid.New()}
+
+	tenantPolicy := common.VisibilityPolicy{ID: uuid.New(), Visibility: common.VisibilityPublic, ResourceOwner: common.ResourceOwner{TenantID: owner.TenantID}}
+	groupPolicy := common.VisibilityPolicy{ID: uuid.New(), GroupID: owner.GroupID, Visibility: common.VisibilitySquad, ResourceOwner: common.ResourceOwner{TenantID: owner.TenantID}}
+	otherTenantPolicy := common.VisibilityPolicy{ID: uuid.New(), Visibility: common.VisibilityPrivate, ResourceOwner: common.ResourceOwner{TenantID: uuid.New()}}
+
+	assert.Nil(t, common.ApplicableVisibilityPolicy(nil, owner))
+	assert.Nil(t, common.ApplicableVisibilityPolicy([]common.VisibilityPolicy{otherTenantPolicy}, owner))
+
+	policy := common.ApplicableVisibilityPolicy([]common.VisibilityPolicy{tenantPolicy}, owner)
+	assert.Equal(t, tenantPolicy.ID, policy.ID)
+
+	// the policy of the group comes first, whatever the order
+	policy = common.ApplicableVisibilityPolicy([]common.VisibilityPolicy{groupPolicy, tenantPolicy}, owner)
+	assert.Equal(t, groupPolicy.ID, policy.ID)
+
+	policy = common.ApplicableVisibilityPolicy([]common.VisibilityPolicy{tenantPolicy, groupPolicy}, owner)
+	assert.Equal(t, groupPolicy.ID, policy.ID)
+}
+
+func TestNewEntityWithVisibility(t *testing.T) {
+	owner := common.ResourceOwner{TenantID: uuid.New(), GroupID: uuid.New(), UserID: uuid.New()}
+	policies := []common.VisibilityPolicy{
+		{ID: uuid.New(), Visibility: common.VisibilitySquad, ResourceOwner: common.ResourceOwner{TenantID: owner.TenantID}},
+	}
+
+	// without a policy the requested visibility (if any) is kept
+	entity, override := common.NewEntityWithVisibility(common.ResourceTypeReplayFile, owner, nil, "")
+	assert.Equal(t, common.Visibility(""), entity.Visibility)
+	assert.Nil(t, override)
+
+	entity, override = common.NewEntityWithVisibility(common.ResourceTypeReplayFile, owner, nil, common.VisibilityPublic)
+	assert.Equal(t, common.VisibilityPublic, entity.Visibility)
+	assert.Nil(t, override)
+
+	// the default of the policy is inherited
+	entity, override = common.NewEntityWithVisibility(common.ResourceTypeReplayFile, owner, policies, "")
+	assert.Equal(t, common.VisibilitySquad, entity.Visibility)
+	assert.Equal(t, owner, entity.ResourceOwner)
+	assert.Nil(t, override)
+
+	entity, override = common.NewEntityWithVisibility(common.ResourceTypeReplayFile, owner, policies, common.VisibilitySquad)
+	assert.Equal(t, common.VisibilitySquad, entity.Visibility)
+	assert.Nil(t, override)
+
+	// requesting other than the default is audited
+	entity, override = common.NewEntityWithVisibility(common.ResourceTypeReplayFile, owner, policies, common.VisibilityPublic)
+	assert.Equal(t, common.VisibilityPublic, entity.Visibility)
+	if assert.NotNil(t, override) {
+		assert.Equal(t, common.ResourceTypeReplayFile, override.ResourceType)
+		assert.Equal(t, entity.ID, override.ResourceID)
+		assert.Equal(t, policies[0].ID, override.PolicyID)
+		assert.Equal(t, common.VisibilitySquad, override.Default)
+		assert.Equal(t, common.VisibilityPublic, override.Requested)
+		assert.Equal(t, owner.UserID, override.RequestedBy)
+	}
+}
@@ -0,0 +1,121 @@
+package alerts
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"time"
+
+	fx_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/fx/entities"
+	quality_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/quality/entities"
+	"github.com/psavelis/team-pro/replay-api/pkg/infra/scheduler"
+)
+
+// max findings included in a single alert payload; the full list is available through the findings API.
+const maxFindingsPerAlert = 20
+
+type WebhookAlertNotifier struct {
+	URL    string
+	Client *http.Client
+}
+
+// NewWebhookAlertNotifier posts alerts to the given URL. When url is empty alerts are only logged.
+func NewWebhookAlertNotifier(url string) *WebhookAlertNotifier {
+	return &WebhookAlertNotifier{
+		URL:    url,
+		Client: &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+type alertPayload struct {
+	Text     string                     `json:"text"`
+	Check    string                     `json:"check"`
+	Total    int                        `json:"total"`
+	Findings []quality_entities.Finding `json:"findings"`
+}
+
+func (n *WebhookAlertNotifier) Notify(ctx context.Context, checkName string, findings []quality_entities.Finding) error {
+	text := fmt.Sprintf("data quality check `%s` reported %d finding(s)", checkName, len(findings))
+
+	slog.WarnContext(ctx, text, "check", checkName, "findings", len(findings))
+
+	if n.URL == "" {
+		return nil
+	}
+
+	sample := findings
+	if len(sample) > maxFindingsPerAlert {
+		sample = sample[:maxFindingsPerAlert]
+	}
+
+	return n.post(ctx, alertPayload{Text: text, Check: checkName, Total: len(findings), Findings: sample})
+}
+
+type rateDeviationPayload struct {
+	Text string `json:"text"`
+	fx_entities.RateDeviationAlert
+}
+
+func (n *WebhookAlertNotifier) NotifyRateDeviation(ctx context.Context, alert fx_entities.RateDeviationAlert) error {
+	text := fmt.Sprintf("exchange rate `%s` from %s deviates %.2f%% from %s, rates are refused until sources agree", alert.Rate.Pair(), alert.Rate.Source, alert.Deviation*100, alert.Reference.Source)
+
+	slog.WarnContext(ctx, text, "pair", alert.Rate.Pair(), "rate", alert.Rate.Rate, "reference", alert.Reference.Rate)
+
+	if n.URL == "" {
+		return nil
+	}
+
+	return n.post(ctx, rateDeviationPayload{Text: text, RateDeviationAlert: alert})
+}
+
+type missedSchedulePayload struct {
+	Text string             `json:"text"`
+	Job  scheduler.JobState `json:"job"`
+}
+
+func (n *WebhookAlertNotifier) NotifyMissedSchedule(ctx context.Context, job scheduler.JobState, now time.Time) error {
+	last := "never ran"
+	if job.LastRun != nil {
+		last = fmt.Sprintf("last run started %s ago", now.Sub(job.LastRun.StartedAt).Round(time.Second))
+	}
+
+	text := fmt.Sprintf("job `%s` missed its schedule (every %s), %s", job.Name, job.Interval(), last)
+
+	slog.WarnContext(ctx, text, "job", job.Name)
+
+	if n.URL == "" {
+		return nil
+	}
+
+	return n.post(ctx, missedSchedulePayload{Text: text, Job: job})
+}
+
+func (n *WebhookAlertNotifier) post(ctx context.Context, payload any) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, n.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	res, err := n.Client.Do(req)
+	if err != nil {
+		return err
+	}
+
+	defer res.Body.Close()
+
+	if res.StatusCode >= 300 {
+		return fmt.Errorf("alert webhook responded with status %d", res.StatusCode)
+	}
+
+	return nil
+}
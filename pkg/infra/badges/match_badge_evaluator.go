@@ -0,0 +1,93 @@
+package badges
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/google/uuid"
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	badge_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/badge/entities"
+	badge_in "github.com/psavelis/team-pro/replay-api/pkg/domain/badge/ports/in"
+	matchmaking_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/matchmaking/entities"
+	rating_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/rating/entities"
+	rating_out "github.com/psavelis/team-pro/replay-api/pkg/domain/rating/ports/out"
+)
+
+// MatchBadgeEvaluator evaluates the match_completed badges for the rating worker: the facts of each player are the result of their team
+// and their rating, once rated for the match.
+type MatchBadgeEvaluator struct {
+	RatingReader    rating_out.RatingReader
+	EvaluateCommand badge_in.EvaluateBadgesCommand
+}
+
+func NewMatchBadgeEvaluator(ratingReader rating_out.RatingReader, evaluateCommand badge_in.EvaluateBadgesCommand) *MatchBadgeEvaluator {
+	return &MatchBadgeEvaluator{
+		RatingReader:    ratingReader,
+		EvaluateCommand: evaluateCommand,
+	}
+}
+
+func (e *MatchBadgeEvaluator) EvaluateMatchBadges(ctx context.Context, event matchmaking_entities.MatchCompleted) error {
+	ids := make([]interface{}, 0)
+	for _, team := range event.Teams {
+		for _, p := range team.Players {
+			ids = append(ids, rating_entities.RatingID(rating_entities.RatingSubjectPlayer, p.PlayerID, event.GameID))
+		}
+	}
+
+	if len(ids) == 0 {
+		return nil
+	}
+
+	ratings, err := e.RatingReader.Search(ctx, common.NewSearchByValues(ctx, []common.SearchableValue{
+		{Field: "ID", Values: ids, Operator: common.InOperator},
+	}, common.NewSearchResultOptions(0, uint(len(ids))), common.ClientApplicationAudienceIDKey))
+
+	if err != nil {
+		slog.ErrorContext(ctx, "unable to read ratings for match badges", "matchID", event.MatchID, "err", err)
+		return err
+	}
+
+	bySubject := make(map[uuid.UUID]rating_entities.Rating, len(ratings))
+	for _, r := range ratings {
+		bySubject[r.SubjectID] = r
+	}
+
+	matchID := event.MatchID
+
+	evaluation := badge_entities.BadgeEvaluation{
+		Trigger: badge_entities.BadgeTriggerMatchCompleted,
+		GameID:  event.GameID,
+		MatchID: &matchID,
+		Players: make([]badge_entities.PlayerFacts, 0, len(ids)),
+	}
+
+	for i, team := range event.Teams {
+		won := 1.0
+		for j, other := range event.Teams {
+			if i != j && other.Score >= team.Score {
+				won = 0
+			}
+		}
+
+		for _, p := range team.Players {
+			facts := map[string]float64{
+				badge_entities.FactMatchWon:   won,
+				badge_entities.FactMatchScore: float64(team.Score),
+			}
+
+			// unrated players (ie: the rating of the match failed) are evaluated on their result only
+			if r, ok := bySubject[p.PlayerID]; ok {
+				facts[badge_entities.FactRating] = r.Rating
+				facts[badge_entities.FactRatingMatchesPlayed] = float64(r.MatchesPlayed)
+				facts[badge_entities.FactRatingSeasonMatchesPlayed] = float64(r.SeasonMatchesPlayed)
+			}
+
+			evaluation.Players = append(evaluation.Players, badge_entities.PlayerFacts{PlayerID: p.PlayerID, Facts: facts})
+		}
+	}
+
+	_, err = e.EvaluateCommand.Exec(ctx, evaluation)
+
+	return err
+}
@@ -0,0 +1,36 @@
+package badges
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	badge_in "github.com/psavelis/team-pro/replay-api/pkg/domain/badge/ports/in"
+)
+
+// PlayerBadgeNamesReader reads the latest badges of the replay players for their player cards.
+type PlayerBadgeNamesReader struct {
+	BadgesQuery badge_in.PlayerBadgesQuery
+}
+
+func NewPlayerBadgeNamesReader(badgesQuery badge_in.PlayerBadgesQuery) *PlayerBadgeNamesReader {
+	return &PlayerBadgeNamesReader{BadgesQuery: badgesQuery}
+}
+
+func (r *PlayerBadgeNamesReader) GetLatestBadgeNames(ctx context.Context, playerID uuid.UUID, gameID common.GameIDKey, limit int) ([]string, error) {
+	badges, err := r.BadgesQuery.Exec(ctx, badge_in.PlayerBadgesQueryParams{PlayerID: playerID, GameID: gameID})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(badges) > limit {
+		badges = badges[:limit]
+	}
+
+	names := make([]string, 0, len(badges))
+	for _, badge := range badges {
+		names = append(names, badge.Name)
+	}
+
+	return names, nil
+}
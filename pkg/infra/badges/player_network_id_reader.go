@@ -0,0 +1,37 @@
+package badges
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/google/uuid"
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	replay_out "github.com/psavelis/team-pro/replay-api/pkg/domain/replay/ports/out"
+)
+
+// PlayerNetworkIDReader reads the network ID of the replay players for the player badges.
+type PlayerNetworkIDReader struct {
+	PlayerReader replay_out.PlayerMetadataReader
+}
+
+func NewPlayerNetworkIDReader(playerReader replay_out.PlayerMetadataReader) *PlayerNetworkIDReader {
+	return &PlayerNetworkIDReader{PlayerReader: playerReader}
+}
+
+func (r *PlayerNetworkIDReader) GetNetworkPlayerID(ctx context.Context, playerID uuid.UUID) (string, error) {
+	// stored as PlayerIDType (not encoded as a uuid.UUID)
+	players, err := r.PlayerReader.Search(ctx, common.NewSearchByValues(ctx, []common.SearchableValue{
+		{Field: "ID", Values: []interface{}{common.PlayerIDType(playerID)}},
+	}, common.NewSearchResultOptions(0, 1), common.ClientApplicationAudienceIDKey))
+
+	if err != nil {
+		slog.ErrorContext(ctx, "unable to search replay player", "playerID", playerID, "err", err)
+		return "", err
+	}
+
+	if len(players) == 0 {
+		return "", nil
+	}
+
+	return players[0].NetworkUserID, nil
+}
@@ -0,0 +1,98 @@
+package badges
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/google/uuid"
+	badge_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/badge/entities"
+	badge_in "github.com/psavelis/team-pro/replay-api/pkg/domain/badge/ports/in"
+	replay_entity "github.com/psavelis/team-pro/replay-api/pkg/domain/replay/entities"
+	replay_in "github.com/psavelis/team-pro/replay-api/pkg/domain/replay/ports/in"
+)
+
+// ReplayBadgeEvaluator evaluates the replay_processed badges for the replay processing: the facts of each player are their stats in the
+// replay (see replay_entity.MatchSummary) and their stats over every replay processed so far (see replay_in.PlayerStatsQuery).
+type ReplayBadgeEvaluator struct {
+	StatsQuery      replay_in.PlayerStatsQuery
+	EvaluateCommand badge_in.EvaluateBadgesCommand
+}
+
+func NewReplayBadgeEvaluator(statsQuery replay_in.PlayerStatsQuery, evaluateCommand badge_in.EvaluateBadgesCommand) *ReplayBadgeEvaluator {
+	return &ReplayBadgeEvaluator{
+		StatsQuery:      statsQuery,
+		EvaluateCommand: evaluateCommand,
+	}
+}
+
+func (e *ReplayBadgeEvaluator) EvaluateReplayBadges(ctx context.Context, summary *replay_entity.MatchSummary, players []*replay_entity.Player) error {
+	byNetworkID := make(map[string]*replay_entity.Player, len(players))
+	for _, p := range players {
+		if p != nil && p.NetworkUserID != "" {
+			byNetworkID[p.NetworkUserID] = p
+		}
+	}
+
+	matchID := summary.MatchID
+
+	evaluation := badge_entities.BadgeEvaluation{
+		Trigger: badge_entities.BadgeTriggerReplayProcessed,
+		GameID:  summary.GameID,
+		MatchID: &matchID,
+		Players: make([]badge_entities.PlayerFacts, 0, len(summary.Players)),
+	}
+
+	for _, stats := range summary.Players {
+		player, ok := byNetworkID[stats.NetworkPlayerID]
+		if !ok {
+			continue
+		}
+
+		playerID := uuid.UUID(player.ID)
+
+		career, err := e.StatsQuery.Exec(ctx, replay_in.PlayerStatsQueryParams{PlayerID: playerID})
+		if err != nil {
+			slog.ErrorContext(ctx, "unable to get player stats for replay badges", "playerID", playerID, "err", err)
+			return err
+		}
+
+		evaluation.Players = append(evaluation.Players, badge_entities.PlayerFacts{
+			PlayerID:        playerID,
+			NetworkPlayerID: stats.NetworkPlayerID,
+			Facts:           replayFacts(stats, career),
+		})
+	}
+
+	_, err := e.EvaluateCommand.Exec(ctx, evaluation)
+
+	return err
+}
+
+func replayFacts(stats replay_entity.PlayerMatchStats, career *replay_entity.PlayerStats) map[string]float64 {
+	mvp := 0.0
+	if stats.MVP {
+		mvp = 1
+	}
+
+	return map[string]float64{
+		badge_entities.FactMatchKills:     float64(stats.Kills),
+		badge_entities.FactMatchDeaths:    float64(stats.Deaths),
+		badge_entities.FactMatchAssists:   float64(stats.Assists),
+		badge_entities.FactMatchHeadshots: float64(stats.Headshots),
+		badge_entities.FactMatchDamage:    float64(stats.Damage),
+		badge_entities.FactMatchADR:       stats.ADR,
+		badge_entities.FactMatchRating:    stats.Rating,
+		badge_entities.FactMatchMVP:       mvp,
+		badge_entities.FactMatchAces:      float64(stats.MultiKills[4]),
+		badge_entities.FactMatchRounds:    float64(stats.Rounds),
+
+		badge_entities.FactCareerMatches:     float64(career.Matches),
+		badge_entities.FactCareerKills:       float64(career.Kills),
+		badge_entities.FactCareerDeaths:      float64(career.Deaths),
+		badge_entities.FactCareerAssists:     float64(career.Assists),
+		badge_entities.FactCareerHeadshots:   float64(career.Headshots),
+		badge_entities.FactCareerDamage:      float64(career.Damage),
+		badge_entities.FactCareerClutchesWon: float64(career.ClutchesWon),
+		badge_entities.FactCareerMVPs:        float64(career.MVPs),
+	}
+}
@@ -0,0 +1,94 @@
+package s3
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/credentials/stscreds"
+	"github.com/aws/aws-sdk-go/aws/endpoints"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/s3/s3manager"
+
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	export_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/export/entities"
+)
+
+const (
+	// session name of the roles assumed to deliver exports (shown in the CloudTrail of the tenant)
+	ExportRoleSessionName = "replay-api-export"
+
+	// shortest duration accepted by STS, deliveries are a single upload
+	ExportRoleDuration = 15 * time.Minute
+)
+
+// ExportDelivery writes exports to the buckets of tenants. It assumes the role of each destination (with its external ID) using the
+// credentials of the api, so tenants grant access to a bucket without sharing keys.
+type ExportDelivery struct {
+	Session *session.Session
+}
+
+func NewExportDelivery(config common.S3Config) (*ExportDelivery, error) {
+	awsConfig := aws.NewConfig().
+		WithRegion(endpoints.UsEast1RegionID).
+		WithS3ForcePathStyle(config.ForcePathStyle)
+
+	if config.Region != "" {
+		awsConfig.WithRegion(config.Region)
+	}
+
+	if config.S3Endpoint != "" {
+		awsConfig.WithEndpoint(config.S3Endpoint)
+	}
+
+	if config.AccessKeyID != "" {
+		awsConfig.WithCredentials(credentials.NewStaticCredentials(config.AccessKeyID, config.SecretAccessKey, ""))
+	}
+
+	s, err := session.NewSession(awsConfig)
+	if err != nil {
+		slog.Error("unable to create export delivery session", "endpoint", config.S3Endpoint, "err", err)
+		return nil, err
+	}
+
+	return &ExportDelivery{
+		Session: s,
+	}, nil
+}
+
+// Deliver uploads the content (in parts when larger than PartSize), encrypted at rest with the S3 managed key.
+func (delivery *ExportDelivery) Deliver(ctx context.Context, destination export_entities.ExportDestination, key string, content []byte) error {
+	roleCredentials := stscreds.NewCredentials(delivery.Session, destination.RoleARN, func(p *stscreds.AssumeRoleProvider) {
+		p.RoleSessionName = ExportRoleSessionName
+		p.Duration = ExportRoleDuration
+		p.ExternalID = aws.String(destination.ExternalID)
+	})
+
+	client := s3.New(delivery.Session, aws.NewConfig().WithCredentials(roleCredentials).WithRegion(destination.Region))
+
+	uploader := s3manager.NewUploaderWithClient(client, func(u *s3manager.Uploader) {
+		u.PartSize = PartSize
+		u.Concurrency = Concurrency
+	})
+
+	_, err := uploader.UploadWithContext(ctx, &s3manager.UploadInput{
+		Bucket:               aws.String(destination.Bucket),
+		Key:                  aws.String(key),
+		Body:                 bytes.NewReader(content),
+		ContentType:          aws.String("application/gzip"),
+		ServerSideEncryption: aws.String(s3.ServerSideEncryptionAes256),
+	})
+
+	if err != nil {
+		slog.ErrorContext(ctx, "error delivering export to s3", "bucket", destination.Bucket, "key", key, "role", destination.RoleARN, "err", err)
+		return err
+	}
+
+	slog.InfoContext(ctx, "ExportDelivery.Deliver: successfully delivered export", "bucket", destination.Bucket, "key", key, "bytes", len(content))
+
+	return nil
+}
@@ -0,0 +1,74 @@
+package s3_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	export_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/export/entities"
+	"github.com/psavelis/team-pro/replay-api/pkg/infra/blob/s3"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeSTS answers AssumeRole with temporary credentials, keeping the forms received. Other requests go to the fake S3.
+type fakeSTS struct {
+	s3    *fakeS3
+	forms []url.Values
+}
+
+func (f *fakeSTS) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost || r.URL.Path != "/" {
+		f.s3.ServeHTTP(w, r)
+		return
+	}
+
+	r.ParseForm()
+	f.forms = append(f.forms, r.PostForm)
+
+	fmt.Fprintf(w, `<AssumeRoleResponse><AssumeRoleResult><Credentials><AccessKeyId>ASIAEXPORT</AccessKeyId><SecretAccessKey>secret</SecretAccessKey><SessionToken>token</SessionToken><Expiration>%s</Expiration></Credentials></AssumeRoleResult></AssumeRoleResponse>`, time.Now().Add(time.Hour).UTC().Format(time.RFC3339))
+}
+
+func TestExportDelivery_Deliver(t *testing.T) {
+	fake := &fakeSTS{s3: newFakeS3()}
+	server := httptest.NewServer(fake)
+	t.Cleanup(server.Close)
+
+	delivery, err := s3.NewExportDelivery(common.S3Config{S3Endpoint: server.URL, AccessKeyID: "access", SecretAccessKey: "secret", ForcePathStyle: true})
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	destination := export_entities.ExportDestination{
+		Bucket:     "tenant-exports",
+		Region:     "eu-west-1",
+		RoleARN:    "arn:aws:iam::123456789012:role/replay-api-export",
+		ExternalID: "7f1c0a52-1e1b-4d51-9d3c-0d8b3f2b0e6a",
+	}
+
+	err = delivery.Deliver(context.Background(), destination, "nightly/matches/date=2026-10-16/run.ndjson.gz", []byte("content"))
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	if assert.Len(t, fake.forms, 1) {
+		assert.Equal(t, "AssumeRole", fake.forms[0].Get("Action"))
+		assert.Equal(t, destination.RoleARN, fake.forms[0].Get("RoleArn"))
+		assert.Equal(t, destination.ExternalID, fake.forms[0].Get("ExternalId"))
+		assert.Equal(t, s3.ExportRoleSessionName, fake.forms[0].Get("RoleSessionName"))
+	}
+
+	key := "/tenant-exports/nightly/matches/date=2026-10-16/run.ndjson.gz"
+
+	assert.Equal(t, []byte("content"), fake.s3.objects[key])
+
+	headers := fake.s3.headers[key]
+	assert.True(t, strings.Contains(headers.Get("Authorization"), "Credential=ASIAEXPORT/"), "signed with the assumed role: %s", headers.Get("Authorization"))
+	assert.Equal(t, "token", headers.Get("X-Amz-Security-Token"))
+	assert.Equal(t, "AES256", headers.Get("X-Amz-Server-Side-Encryption"))
+}
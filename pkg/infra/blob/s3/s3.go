@@ -0,0 +1,262 @@
+package s3
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/endpoints"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/s3/s3iface"
+	"github.com/aws/aws-sdk-go/service/s3/s3manager"
+
+	"github.com/google/uuid"
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	"github.com/psavelis/team-pro/replay-api/pkg/domain/replay"
+)
+
+const (
+	BackendName = "s3"
+
+	// replay files are stored as <KeyPrefix><replay_file_id>.dem
+	KeyPrefix = "replay_files/"
+
+	// files larger than a part are uploaded in concurrent parts (multipart upload), downloads are ranged by the same size
+	PartSize    = 16 * 1024 * 1024
+	Concurrency = 4
+
+	// longest validity accepted by S3 for SigV4 presigned URLs
+	MaxPresignTTL = 7 * 24 * time.Hour
+
+	// downloads are spooled here: the parser needs to seek the demo
+	DefaultTempDir = "/app/replay_files"
+)
+
+// S3Adapter stores replay file content on AWS S3 or any S3 compatible store (ie: MinIO). Objects are private: clients download through
+// presigned URLs, or streamed (ranged) by the API.
+type S3Adapter struct {
+	Bucket     string
+	Encryption string
+	KMSKeyID   string
+	TempDir    string
+	Client     s3iface.S3API
+	Uploader   *s3manager.Uploader
+	Downloader *s3manager.Downloader
+}
+
+func NewS3Adapter(config common.S3Config) (*S3Adapter, error) {
+	if config.Bucket == "" {
+		return nil, errors.New("s3 replay storage requires S3_BUCKET_NAME")
+	}
+
+	awsConfig := aws.NewConfig().
+		WithRegion(endpoints.UsEast1RegionID).
+		WithS3ForcePathStyle(config.ForcePathStyle)
+
+	if config.Region != "" {
+		awsConfig.WithRegion(config.Region)
+	}
+
+	if config.S3Endpoint != "" {
+		awsConfig.WithEndpoint(config.S3Endpoint)
+	}
+
+	if config.AccessKeyID != "" {
+		awsConfig.WithCredentials(credentials.NewStaticCredentials(config.AccessKeyID, config.SecretAccessKey, ""))
+	}
+
+	s, err := session.NewSession(awsConfig)
+	if err != nil {
+		slog.Error("unable to create s3 session", "endpoint", config.S3Endpoint, "err", err)
+		return nil, err
+	}
+
+	client := s3.New(s)
+
+	return &S3Adapter{
+		Bucket:     config.Bucket,
+		Encryption: config.Encryption,
+		KMSKeyID:   config.KMSKeyID,
+		TempDir:    DefaultTempDir,
+		Client:     client,
+		Uploader: s3manager.NewUploaderWithClient(client, func(u *s3manager.Uploader) {
+			u.PartSize = PartSize
+			u.Concurrency = Concurrency
+		}),
+		Downloader: s3manager.NewDownloaderWithClient(client, func(d *s3manager.Downloader) {
+			d.PartSize = PartSize
+			d.Concurrency = Concurrency
+		}),
+	}, nil
+}
+
+func objectKey(replayFileID uuid.UUID) string {
+	return KeyPrefix + replayFileID.String() + ".dem"
+}
+
+// Put uploads the content (in parts when larger than PartSize, aborting the multipart upload on failure) and returns its s3:// URI.
+func (adapter *S3Adapter) Put(ctx context.Context, replayFileID uuid.UUID, reader io.ReadSeeker) (string, error) {
+	_, err := reader.Seek(0, io.SeekStart)
+	if err != nil {
+		slog.ErrorContext(ctx, "error seeking to start of replay file", "replayFileID", replayFileID, "err", err)
+		return "", err
+	}
+
+	key := objectKey(replayFileID)
+
+	input := &s3manager.UploadInput{
+		Bucket:      aws.String(adapter.Bucket),
+		Key:         aws.String(key),
+		Body:        reader,
+		ContentType: aws.String("application/octet-stream"),
+	}
+
+	if adapter.Encryption != "" {
+		input.ServerSideEncryption = aws.String(adapter.Encryption)
+
+		if adapter.Encryption == s3.ServerSideEncryptionAwsKms && adapter.KMSKeyID != "" {
+			input.SSEKMSKeyId = aws.String(adapter.KMSKeyID)
+		}
+	}
+
+	res, err := adapter.Uploader.UploadWithContext(ctx, input)
+	if err != nil {
+		slog.ErrorContext(ctx, "error uploading replay file to s3", "replayFileID", replayFileID, "bucket", adapter.Bucket, "key", key, "err", err)
+		return "", err
+	}
+
+	slog.InfoContext(ctx, "S3Adapter.Put: successfully uploaded replay file", "replayFileID", replayFileID, "location", res.Location, "multipart", res.UploadID != "")
+
+	return "s3://" + adapter.Bucket + "/" + key, nil
+}
+
+// GetByID downloads the content (ranged, concurrently) to a temporary file, removed when closed.
+func (adapter *S3Adapter) GetByID(ctx context.Context, replayFileID uuid.UUID) (io.ReadSeekCloser, error) {
+	file, err := os.CreateTemp(adapter.TempDir, replayFileID.String()+"-*.dem")
+	if err != nil {
+		slog.ErrorContext(ctx, "error creating temporary replay file", "replayFileID", replayFileID, "dir", adapter.TempDir, "err", err)
+		return nil, err
+	}
+
+	key := objectKey(replayFileID)
+
+	length, err := adapter.Downloader.DownloadWithContext(ctx, file, &s3.GetObjectInput{
+		Bucket: aws.String(adapter.Bucket),
+		Key:    aws.String(key),
+	})
+
+	if err == nil {
+		_, err = file.Seek(0, io.SeekStart)
+	}
+
+	if err != nil {
+		file.Close()
+		os.Remove(file.Name())
+
+		var aerr awserr.Error
+		if errors.As(err, &aerr) && aerr.Code() == s3.ErrCodeNoSuchKey {
+			err = fmt.Errorf("replay file %s content not found: %w", replayFileID, err)
+		}
+
+		slog.ErrorContext(ctx, "error downloading replay file from s3", "replayFileID", replayFileID, "bucket", adapter.Bucket, "key", key, "err", err)
+
+		return nil, err
+	}
+
+	slog.InfoContext(ctx, "S3Adapter.GetByID: successfully downloaded replay file", "replayFileID", replayFileID, "length", length)
+
+	return &tempFile{file}, nil
+}
+
+// Ping checks the bucket exists and is reachable with the configured credentials (HEAD bucket).
+func (adapter *S3Adapter) Ping(ctx context.Context) error {
+	_, err := adapter.Client.HeadBucketWithContext(ctx, &s3.HeadBucketInput{
+		Bucket: aws.String(adapter.Bucket),
+	})
+
+	return err
+}
+
+// Size returns the length of the content, from its metadata (HEAD).
+func (adapter *S3Adapter) Size(ctx context.Context, replayFileID uuid.UUID) (int64, error) {
+	res, err := adapter.Client.HeadObjectWithContext(ctx, &s3.HeadObjectInput{
+		Bucket: aws.String(adapter.Bucket),
+		Key:    aws.String(objectKey(replayFileID)),
+	})
+
+	if err != nil {
+		// HEAD responses have no body: a missing key is a plain 404
+		var aerr awserr.Error
+		if errors.As(err, &aerr) && (aerr.Code() == "NotFound" || aerr.Code() == s3.ErrCodeNoSuchKey) {
+			return 0, replay.NewReplayFileNotFoundError(replayFileID)
+		}
+
+		slog.ErrorContext(ctx, "error reading replay file metadata from s3", "replayFileID", replayFileID, "err", err)
+		return 0, err
+	}
+
+	return aws.Int64Value(res.ContentLength), nil
+}
+
+// Stream GETs length bytes of the content from offset (a single ranged request, read as the client reads it).
+func (adapter *S3Adapter) Stream(ctx context.Context, replayFileID uuid.UUID, offset int64, length int64) (io.ReadCloser, error) {
+	res, err := adapter.Client.GetObjectWithContext(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(adapter.Bucket),
+		Key:    aws.String(objectKey(replayFileID)),
+		Range:  aws.String(fmt.Sprintf("bytes=%d-%d", offset, offset+length-1)),
+	})
+
+	if err != nil {
+		var aerr awserr.Error
+		if errors.As(err, &aerr) && aerr.Code() == s3.ErrCodeNoSuchKey {
+			return nil, replay.NewReplayFileNotFoundError(replayFileID)
+		}
+
+		slog.ErrorContext(ctx, "error streaming replay file from s3", "replayFileID", replayFileID, "offset", offset, "length", length, "err", err)
+		return nil, err
+	}
+
+	return res.Body, nil
+}
+
+// DownloadURL presigns a GET of the content, valid for ttl (capped at MaxPresignTTL).
+func (adapter *S3Adapter) DownloadURL(ctx context.Context, replayFileID uuid.UUID, ttl time.Duration) (string, error) {
+	if ttl > MaxPresignTTL {
+		ttl = MaxPresignTTL
+	}
+
+	req, _ := adapter.Client.GetObjectRequest(&s3.GetObjectInput{
+		Bucket:                     aws.String(adapter.Bucket),
+		Key:                        aws.String(objectKey(replayFileID)),
+		ResponseContentDisposition: aws.String(fmt.Sprintf(`attachment; filename="%s.dem"`, replayFileID)),
+	})
+
+	req.SetContext(ctx)
+
+	url, err := req.Presign(ttl)
+	if err != nil {
+		slog.ErrorContext(ctx, "error presigning replay file download", "replayFileID", replayFileID, "err", err)
+		return "", err
+	}
+
+	return url, nil
+}
+
+type tempFile struct {
+	*os.File
+}
+
+func (f *tempFile) Close() error {
+	err := f.File.Close()
+	os.Remove(f.File.Name())
+
+	return err
+}
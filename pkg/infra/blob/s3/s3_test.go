@@ -0,0 +1,265 @@
+package s3_test
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	"github.com/psavelis/team-pro/replay-api/pkg/infra/blob/s3"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeS3 implements the object, multipart upload and ranged GET calls made by the adapter (path style).
+type fakeS3 struct {
+	mu       sync.Mutex
+	objects  map[string][]byte
+	uploads  map[string]map[int][]byte
+	requests []string
+	headers  map[string]http.Header
+}
+
+func newFakeS3() *fakeS3 {
+	return &fakeS3{
+		objects: make(map[string][]byte),
+		uploads: make(map[string]map[int][]byte),
+		headers: make(map[string]http.Header),
+	}
+}
+
+func (f *fakeS3) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	key := r.URL.Path
+	query := r.URL.Query()
+
+	switch {
+	case r.Method == http.MethodPost && query.Has("uploads"):
+		f.requests = append(f.requests, "CreateMultipartUpload")
+		f.headers[key] = r.Header.Clone()
+
+		uploadID := uuid.NewString()
+		f.uploads[uploadID] = make(map[int][]byte)
+
+		fmt.Fprintf(w, `<InitiateMultipartUploadResult><Bucket>replays</Bucket><Key>%s</Key><UploadId>%s</UploadId></InitiateMultipartUploadResult>`, key, uploadID)
+	case r.Method == http.MethodPut && query.Has("partNumber"):
+		f.requests = append(f.requests, "UploadPart")
+
+		part, _ := strconv.Atoi(query.Get("partNumber"))
+		body, _ := io.ReadAll(r.Body)
+		f.uploads[query.Get("uploadId")][part] = body
+
+		w.Header().Set("ETag", fmt.Sprintf(`"etag-%d"`, part))
+	case r.Method == http.MethodPost && query.Has("uploadId"):
+		f.requests = append(f.requests, "CompleteMultipartUpload")
+
+		parts := f.uploads[query.Get("uploadId")]
+
+		numbers := make([]int, 0, len(parts))
+		for n := range parts {
+			numbers = append(numbers, n)
+		}
+
+		sort.Ints(numbers)
+
+		var content []byte
+		for _, n := range numbers {
+			content = append(content, parts[n]...)
+		}
+
+		f.objects[key] = content
+
+		fmt.Fprintf(w, `<CompleteMultipartUploadResult><Bucket>replays</Bucket><Key>%s</Key><ETag>"etag"</ETag></CompleteMultipartUploadResult>`, key)
+	case r.Method == http.MethodPut:
+		f.requests = append(f.requests, "PutObject")
+		f.headers[key] = r.Header.Clone()
+
+		body, _ := io.ReadAll(r.Body)
+		f.objects[key] = body
+
+		w.Header().Set("ETag", `"etag"`)
+	case r.Method == http.MethodGet || r.Method == http.MethodHead:
+		if r.Method == http.MethodHead {
+			f.requests = append(f.requests, "HeadObject")
+		} else {
+			f.requests = append(f.requests, "GetObject")
+			f.headers[key] = r.Header.Clone()
+		}
+
+		content, ok := f.objects[key]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`<Error><Code>NoSuchKey</Code><Message>The specified key does not exist.</Message></Error>`))
+			return
+		}
+
+		http.ServeContent(w, r, key, time.Time{}, bytes.NewReader(content))
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func newAdapter(t *testing.T, config common.S3Config) (*s3.S3Adapter, *fakeS3) {
+	fake := newFakeS3()
+	server := httptest.NewServer(fake)
+	t.Cleanup(server.Close)
+
+	config.S3Endpoint = server.URL
+	config.Bucket = "replays"
+	config.AccessKeyID = "access"
+	config.SecretAccessKey = "secret"
+	config.ForcePathStyle = true
+
+	adapter, err := s3.NewS3Adapter(config)
+	assert.NoError(t, err)
+
+	adapter.TempDir = t.TempDir()
+
+	return adapter, fake
+}
+
+func TestNewS3Adapter_RequiresBucket(t *testing.T) {
+	_, err := s3.NewS3Adapter(common.S3Config{S3Endpoint: "http://minio:9000"})
+	assert.Error(t, err)
+}
+
+func TestS3Adapter_PutAndGet(t *testing.T) {
+	tests := []struct {
+		name     string
+		size     int
+		requests []string
+	}{
+		{"single request", 1024, []string{"PutObject"}},
+		{"multipart", 2*s3.PartSize + 1024, []string{"CreateMultipartUpload", "UploadPart", "UploadPart", "UploadPart", "CompleteMultipartUpload"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			adapter, fake := newAdapter(t, common.S3Config{})
+
+			content := make([]byte, tt.size)
+			for i := range content {
+				content[i] = byte(i % 251)
+			}
+
+			replayFileID := uuid.New()
+
+			uri, err := adapter.Put(context.Background(), replayFileID, bytes.NewReader(content))
+			assert.NoError(t, err)
+			assert.Equal(t, "s3://replays/replay_files/"+replayFileID.String()+".dem", uri)
+			assert.ElementsMatch(t, tt.requests, fake.requests)
+
+			file, err := adapter.GetByID(context.Background(), replayFileID)
+			if !assert.NoError(t, err) {
+				return
+			}
+
+			downloaded, err := io.ReadAll(file)
+			assert.NoError(t, err)
+			assert.True(t, bytes.Equal(content, downloaded))
+
+			// seekable for the parser
+			_, err = file.Seek(0, io.SeekStart)
+			assert.NoError(t, err)
+
+			// the temporary copy is removed on close
+			assert.NoError(t, file.Close())
+
+			entries, _ := os.ReadDir(adapter.TempDir)
+			assert.Empty(t, entries)
+		})
+	}
+}
+
+func TestS3Adapter_Put_Encryption(t *testing.T) {
+	adapter, fake := newAdapter(t, common.S3Config{Encryption: "aws:kms", KMSKeyID: "key-1"})
+
+	replayFileID := uuid.New()
+
+	_, err := adapter.Put(context.Background(), replayFileID, bytes.NewReader([]byte("demo")))
+	assert.NoError(t, err)
+
+	headers := fake.headers["/replays/replay_files/"+replayFileID.String()+".dem"]
+	assert.Equal(t, "aws:kms", headers.Get("X-Amz-Server-Side-Encryption"))
+	assert.Equal(t, "key-1", headers.Get("X-Amz-Server-Side-Encryption-Aws-Kms-Key-Id"))
+}
+
+func TestS3Adapter_GetByID_NotFound(t *testing.T) {
+	adapter, _ := newAdapter(t, common.S3Config{})
+
+	_, err := adapter.GetByID(context.Background(), uuid.New())
+	assert.ErrorContains(t, err, "content not found")
+
+	entries, _ := os.ReadDir(adapter.TempDir)
+	assert.Empty(t, entries)
+}
+
+func TestS3Adapter_SizeAndStream(t *testing.T) {
+	adapter, fake := newAdapter(t, common.S3Config{})
+
+	replayFileID := uuid.New()
+
+	_, err := adapter.Put(context.Background(), replayFileID, bytes.NewReader([]byte("0123456789")))
+	assert.NoError(t, err)
+
+	size, err := adapter.Size(context.Background(), replayFileID)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(10), size)
+
+	stream, err := adapter.Stream(context.Background(), replayFileID, 3, 4)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	defer stream.Close()
+
+	content, err := io.ReadAll(stream)
+	assert.NoError(t, err)
+	assert.Equal(t, "3456", string(content))
+	assert.Equal(t, "bytes=3-6", fake.headers["/replays/replay_files/"+replayFileID.String()+".dem"].Get("Range"))
+
+	_, err = adapter.Size(context.Background(), uuid.New())
+	assert.ErrorContains(t, err, "not found")
+}
+
+func TestS3Adapter_DownloadURL(t *testing.T) {
+	adapter, _ := newAdapter(t, common.S3Config{})
+
+	replayFileID := uuid.New()
+
+	tests := []struct {
+		name            string
+		ttl             time.Duration
+		expectedExpires string
+	}{
+		{"requested ttl", 15 * time.Minute, "900"},
+		{"capped ttl", 30 * 24 * time.Hour, "604800"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			raw, err := adapter.DownloadURL(context.Background(), replayFileID, tt.ttl)
+			assert.NoError(t, err)
+
+			u, err := url.Parse(raw)
+			assert.NoError(t, err)
+			assert.Equal(t, "/replays/replay_files/"+replayFileID.String()+".dem", u.Path)
+			assert.Equal(t, tt.expectedExpires, u.Query().Get("X-Amz-Expires"))
+			assert.NotEmpty(t, u.Query().Get("X-Amz-Signature"))
+			assert.True(t, strings.HasPrefix(u.Query().Get("response-content-disposition"), "attachment;"))
+		})
+	}
+}
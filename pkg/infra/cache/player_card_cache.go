@@ -0,0 +1,78 @@
+package cache
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	replay_in "github.com/psavelis/team-pro/replay-api/pkg/domain/replay/ports/in"
+)
+
+// maxPlayerCardEntries bounds the memory of the cache (cards are tens of KiB each)
+const maxPlayerCardEntries = 1000
+
+// PlayerCardCache keeps the rendered player cards for TTL: a link shared in a busy channel is fetched by every client unfurling it,
+// and rendering a card reads the player, their stats, matches and badges. Failures aren't cached. Each instance of the API has its own cache.
+type PlayerCardCache struct {
+	replay_in.PlayerCardQuery
+	TTL time.Duration
+
+	mu      sync.Mutex
+	entries map[string]cachedPlayerCard
+}
+
+type cachedPlayerCard struct {
+	png       []byte
+	expiresAt time.Time
+}
+
+func NewPlayerCardCache(query replay_in.PlayerCardQuery, ttl time.Duration) *PlayerCardCache {
+	return &PlayerCardCache{
+		PlayerCardQuery: query,
+		TTL:             ttl,
+		entries:         make(map[string]cachedPlayerCard),
+	}
+}
+
+func (c *PlayerCardCache) Exec(ctx context.Context, playerID uuid.UUID) ([]byte, error) {
+	// players are read by the client application in context
+	owner := common.GetResourceOwner(ctx)
+	key := fmt.Sprint(owner.TenantID, owner.ClientID, playerID)
+
+	now := time.Now()
+
+	c.mu.Lock()
+	cached, ok := c.entries[key]
+	c.mu.Unlock()
+
+	if ok && now.Before(cached.expiresAt) {
+		return cached.png, nil
+	}
+
+	png, err := c.PlayerCardQuery.Exec(ctx, playerID)
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if len(c.entries) >= maxPlayerCardEntries {
+		for k, cached := range c.entries {
+			if !now.Before(cached.expiresAt) {
+				delete(c.entries, k)
+			}
+		}
+
+		if len(c.entries) >= maxPlayerCardEntries {
+			c.entries = make(map[string]cachedPlayerCard)
+		}
+	}
+
+	c.entries[key] = cachedPlayerCard{png: png, expiresAt: now.Add(c.TTL)}
+
+	return png, nil
+}
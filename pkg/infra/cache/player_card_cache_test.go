@@ -0,0 +1,62 @@
+package cache_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	"github.com/psavelis/team-pro/replay-api/pkg/infra/cache"
+	"github.com/stretchr/testify/assert"
+)
+
+type countingCardQuery struct {
+	renders int
+	err     error
+}
+
+func (q *countingCardQuery) Exec(ctx context.Context, playerID uuid.UUID) ([]byte, error) {
+	q.renders++
+	if q.err != nil {
+		return nil, q.err
+	}
+
+	return []byte(playerID.String()), nil
+}
+
+func TestPlayerCardCache(t *testing.T) {
+	query := &countingCardQuery{}
+	c := cache.NewPlayerCardCache(query, time.Hour)
+
+	ctx := tenantContext(common.TeamPROTenantID)
+	playerID := uuid.New()
+
+	first, _ := c.Exec(ctx, playerID)
+	second, _ := c.Exec(ctx, playerID)
+	assert.Equal(t, 1, query.renders)
+	assert.Equal(t, first, second)
+
+	// another player, or another tenant, is rendered again
+	c.Exec(ctx, uuid.New())
+	c.Exec(tenantContext(uuid.New()), playerID)
+	assert.Equal(t, 3, query.renders)
+
+	// failures aren't cached
+	query.err = errors.New("timeout")
+	otherID := uuid.New()
+	for i := 0; i < 2; i++ {
+		_, err := c.Exec(ctx, otherID)
+		assert.Error(t, err)
+	}
+
+	assert.Equal(t, 5, query.renders)
+
+	// expired
+	c.TTL = 0
+	query.err = nil
+	c.Exec(ctx, otherID)
+	c.Exec(ctx, otherID)
+	assert.Equal(t, 7, query.renders)
+}
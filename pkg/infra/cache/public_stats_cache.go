@@ -0,0 +1,152 @@
+package cache
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	public_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/public/entities"
+	public_out "github.com/psavelis/team-pro/replay-api/pkg/domain/public/ports/out"
+)
+
+// maxPublicStatsEntries bounds the memory of the cache (ie: a crawler paging through every match)
+const maxPublicStatsEntries = 10000
+
+// PublicStatsCache keeps the stats read for the public API for TTL: third-party sites poll the same pages over and over, and the stats
+// of a public match don't change once its replay is processed. Failures aren't cached. Each instance of the API has its own cache.
+type PublicStatsCache struct {
+	public_out.PublicStatsReader
+	TTL time.Duration
+
+	mu      sync.Mutex
+	entries map[string]cachedStats
+}
+
+type cachedStats struct {
+	value     interface{}
+	expiresAt time.Time
+}
+
+func NewPublicStatsCache(reader public_out.PublicStatsReader, ttl time.Duration) *PublicStatsCache {
+	return &PublicStatsCache{
+		PublicStatsReader: reader,
+		TTL:               ttl,
+		entries:           make(map[string]cachedStats),
+	}
+}
+
+func (c *PublicStatsCache) GetPublicMatches(ctx context.Context, gameID common.GameIDKey, before *time.Time, limit int) ([]public_entities.PublicMatch, error) {
+	page := "latest"
+	if before != nil {
+		page = before.UTC().Format(time.RFC3339Nano)
+	}
+
+	key := c.key(ctx, "matches", gameID, page, limit)
+
+	if cached, ok := c.get(key); ok {
+		return append([]public_entities.PublicMatch(nil), cached.([]public_entities.PublicMatch)...), nil
+	}
+
+	matches, err := c.PublicStatsReader.GetPublicMatches(ctx, gameID, before, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	c.set(key, append([]public_entities.PublicMatch(nil), matches...))
+
+	return matches, nil
+}
+
+func (c *PublicStatsCache) GetPublicMatch(ctx context.Context, gameID common.GameIDKey, matchID uuid.UUID) (*public_entities.PublicMatch, error) {
+	key := c.key(ctx, "match", gameID, matchID)
+
+	if cached, ok := c.get(key); ok {
+		match, _ := cached.(*public_entities.PublicMatch)
+		if match == nil {
+			return nil, nil
+		}
+
+		m := *match
+		return &m, nil
+	}
+
+	match, err := c.PublicStatsReader.GetPublicMatch(ctx, gameID, matchID)
+	if err != nil {
+		return nil, err
+	}
+
+	// matches not (yet) public are cached too, sparing the database from the lookups of unknown IDs
+	var cached *public_entities.PublicMatch
+	if match != nil {
+		m := *match
+		cached = &m
+	}
+
+	c.set(key, cached)
+
+	return match, nil
+}
+
+func (c *PublicStatsCache) GetLeaderboardEntries(ctx context.Context, gameID common.GameIDKey, since time.Time, seasonID *uuid.UUID, minMatches int, limit int) ([]public_entities.LeaderboardEntry, error) {
+	season := ""
+	if seasonID != nil {
+		season = seasonID.String()
+	}
+
+	key := c.key(ctx, "leaderboard", gameID, since.UTC().Format(time.RFC3339), season, minMatches, limit)
+
+	// entries are copied both ways: they are ranked in place
+	if cached, ok := c.get(key); ok {
+		return append([]public_entities.LeaderboardEntry(nil), cached.([]public_entities.LeaderboardEntry)...), nil
+	}
+
+	entries, err := c.PublicStatsReader.GetLeaderboardEntries(ctx, gameID, since, seasonID, minMatches, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	c.set(key, append([]public_entities.LeaderboardEntry(nil), entries...))
+
+	return entries, nil
+}
+
+// key scopes the cached stats by the tenant in context.
+func (c *PublicStatsCache) key(ctx context.Context, values ...interface{}) string {
+	return fmt.Sprint(append([]interface{}{common.GetResourceOwner(ctx).TenantID}, values...)...)
+}
+
+func (c *PublicStatsCache) get(key string) (interface{}, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	cached, ok := c.entries[key]
+	if !ok || !time.Now().Before(cached.expiresAt) {
+		return nil, false
+	}
+
+	return cached.value, true
+}
+
+func (c *PublicStatsCache) set(key string, value interface{}) {
+	now := time.Now()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if len(c.entries) >= maxPublicStatsEntries {
+		for k, cached := range c.entries {
+			if !now.Before(cached.expiresAt) {
+				delete(c.entries, k)
+			}
+		}
+
+		if len(c.entries) >= maxPublicStatsEntries {
+			c.entries = make(map[string]cachedStats)
+		}
+	}
+
+	c.entries[key] = cachedStats{value: value, expiresAt: now.Add(c.TTL)}
+}
@@ -0,0 +1,92 @@
+package cache_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	public_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/public/entities"
+	"github.com/psavelis/team-pro/replay-api/pkg/infra/cache"
+	"github.com/stretchr/testify/assert"
+)
+
+type countingStatsReader struct {
+	reads int
+}
+
+func (r *countingStatsReader) GetPublicMatches(ctx context.Context, gameID common.GameIDKey, before *time.Time, limit int) ([]public_entities.PublicMatch, error) {
+	r.reads++
+	return []public_entities.PublicMatch{{ID: uuid.New(), GameID: gameID}}, nil
+}
+
+func (r *countingStatsReader) GetPublicMatch(ctx context.Context, gameID common.GameIDKey, matchID uuid.UUID) (*public_entities.PublicMatch, error) {
+	r.reads++
+	return nil, nil
+}
+
+func (r *countingStatsReader) GetLeaderboardEntries(ctx context.Context, gameID common.GameIDKey, since time.Time, seasonID *uuid.UUID, minMatches int, limit int) ([]public_entities.LeaderboardEntry, error) {
+	r.reads++
+	return []public_entities.LeaderboardEntry{{NetworkPlayerID: "1", Rating: 1.234}}, nil
+}
+
+func tenantContext(tenantID uuid.UUID) context.Context {
+	return common.WithResourceOwner(context.Background(), common.ResourceOwner{TenantID: tenantID, ClientID: common.TeamPROAppClientID})
+}
+
+func TestPublicStatsCache(t *testing.T) {
+	reader := &countingStatsReader{}
+	c := cache.NewPublicStatsCache(reader, time.Hour)
+
+	ctx := tenantContext(common.TeamPROTenantID)
+
+	first, _ := c.GetPublicMatches(ctx, common.CS2_GAME_ID, nil, 20)
+	second, _ := c.GetPublicMatches(ctx, common.CS2_GAME_ID, nil, 20)
+	assert.Equal(t, 1, reader.reads)
+	assert.Equal(t, first, second)
+
+	// another page, or another tenant, is read again
+	before := time.Now()
+	c.GetPublicMatches(ctx, common.CS2_GAME_ID, &before, 20)
+	c.GetPublicMatches(tenantContext(uuid.New()), common.CS2_GAME_ID, nil, 20)
+	assert.Equal(t, 3, reader.reads)
+
+	// unknown matches are cached as well
+	matchID := uuid.New()
+	for i := 0; i < 2; i++ {
+		match, err := c.GetPublicMatch(ctx, common.CS2_GAME_ID, matchID)
+		assert.NoError(t, err)
+		assert.Nil(t, match)
+	}
+
+	assert.Equal(t, 4, reader.reads)
+
+	// the cached entries aren't changed by the ranking of a previous read
+	since := time.Now().Truncate(24 * time.Hour)
+	entries, _ := c.GetLeaderboardEntries(ctx, common.CS2_GAME_ID, since, nil, 5, 100)
+	entries[0].Rating = 1.23
+
+	entries, _ = c.GetLeaderboardEntries(ctx, common.CS2_GAME_ID, since, nil, 5, 100)
+	assert.Equal(t, 1.234, entries[0].Rating)
+	assert.Equal(t, 5, reader.reads)
+
+	// the leaderboard of a season is another read
+	seasonID := uuid.New()
+	c.GetLeaderboardEntries(ctx, common.CS2_GAME_ID, since, &seasonID, 5, 100)
+	c.GetLeaderboardEntries(ctx, common.CS2_GAME_ID, since, &seasonID, 5, 100)
+	assert.Equal(t, 6, reader.reads)
+}
+
+func TestPublicStatsCache_Expires(t *testing.T) {
+	reader := &countingStatsReader{}
+	c := cache.NewPublicStatsCache(reader, time.Millisecond)
+
+	ctx := tenantContext(common.TeamPROTenantID)
+
+	c.GetPublicMatches(ctx, common.CS2_GAME_ID, nil, 20)
+	time.Sleep(5 * time.Millisecond)
+	c.GetPublicMatches(ctx, common.CS2_GAME_ID, nil, 20)
+
+	assert.Equal(t, 2, reader.reads)
+}
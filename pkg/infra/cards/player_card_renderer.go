@@ -0,0 +1,245 @@
+package cards
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	_ "image/jpeg" // steam avatars
+	"image/png"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	replay_entity "github.com/psavelis/team-pro/replay-api/pkg/domain/replay/entities"
+)
+
+const (
+	// Open Graph recommended size (1.91:1), also the size of the large summary cards of Twitter
+	PlayerCardWidth  = 1200
+	PlayerCardHeight = 630
+
+	avatarSize         = 220
+	maxAvatarSize      = 2 << 20 // bytes
+	maxAvatarDimension = 1024    // pixels (full size Steam avatars are 184x184)
+)
+
+// AvatarHosts are the CDNs of the platforms the avatars of the players come from. Avatars elsewhere aren't fetched (the initials of
+// the player are drawn instead), so an avatar URI can't make the API reach other hosts.
+var AvatarHosts = []string{
+	"avatars.steamstatic.com",
+	"avatars.akamai.steamstatic.com",
+	"avatars.cloudflare.steamstatic.com",
+	"steamcdn-a.akamaihd.net",
+	"distribution.faceit-cdn.net",
+	"assets.faceit-cdn.net",
+}
+
+var (
+	backgroundColor = color.RGBA{0x11, 0x13, 0x18, 0xff}
+	panelColor      = color.RGBA{0x1c, 0x1f, 0x26, 0xff}
+	accentColor     = color.RGBA{0xf5, 0xa6, 0x23, 0xff}
+	textColor       = color.RGBA{0xff, 0xff, 0xff, 0xff}
+	mutedColor      = color.RGBA{0x8b, 0x91, 0x9e, 0xff}
+	winColor        = color.RGBA{0x2e, 0x9e, 0x5b, 0xff}
+	lossColor       = color.RGBA{0xc9, 0x42, 0x3a, 0xff}
+	drawColor       = color.RGBA{0x6b, 0x72, 0x80, 0xff}
+)
+
+// PlayerCardRenderer draws the cards of the players with the standard library only (a bitmap font, no antialiasing).
+type PlayerCardRenderer struct {
+	AvatarClient *http.Client
+	AvatarHosts  []string
+}
+
+func NewPlayerCardRenderer() *PlayerCardRenderer {
+	return &PlayerCardRenderer{
+		AvatarClient: &http.Client{Timeout: 3 * time.Second},
+		AvatarHosts:  AvatarHosts,
+	}
+}
+
+func (r *PlayerCardRenderer) Render(ctx context.Context, card replay_entity.PlayerCard) ([]byte, error) {
+	img := image.NewRGBA(image.Rect(0, 0, PlayerCardWidth, PlayerCardHeight))
+	fillRect(img, img.Bounds(), backgroundColor)
+	fillRect(img, image.Rect(0, 0, 12, PlayerCardHeight), accentColor)
+
+	r.drawAvatar(ctx, img, card, image.Rect(60, 60, 60+avatarSize, 60+avatarSize))
+
+	// who
+	name := card.Name
+	if name == "" {
+		name = "Unknown player"
+	}
+
+	drawText(img, 320, 70, truncateText(name, 7, PlayerCardWidth-380), 7, textColor)
+
+	if card.ClanName != "" {
+		drawText(img, 320, 140, truncateText(card.ClanName, 4, PlayerCardWidth-380), 4, mutedColor)
+	}
+
+	if card.Rank != nil && card.Rank.Label != "" {
+		drawText(img, 320, 190, truncateText(card.Rank.Label, 4, PlayerCardWidth-380), 4, accentColor)
+	}
+
+	// overall stats
+	stats := []struct {
+		label string
+		value string
+	}{
+		{"Rating", fmt.Sprintf("%.2f", card.Stats.Rating)},
+		{"K/D", fmt.Sprintf("%.2f", card.Stats.KD)},
+		{"ADR", fmt.Sprintf("%.0f", card.Stats.ADR)},
+		{"HS", fmt.Sprintf("%.0f%%", card.Stats.HSPercentage)},
+		{"Matches", fmt.Sprint(card.Stats.Matches)},
+	}
+
+	for i, stat := range stats {
+		box := image.Rect(60+i*220, 310, 60+i*220+200, 430)
+		fillRect(img, box, panelColor)
+		drawText(img, box.Min.X+20, box.Min.Y+20, stat.label, 3, mutedColor)
+		drawText(img, box.Min.X+20, box.Min.Y+55, truncateText(stat.value, 6, box.Dx()-40), 6, textColor)
+	}
+
+	// recent form (most recent first)
+	drawText(img, 60, 470, "Recent form", 3, mutedColor)
+
+	if len(card.RecentForm) == 0 {
+		drawText(img, 60, 520, "No matches", 3, mutedColor)
+	}
+
+	for i, outcome := range card.RecentForm {
+		box := image.Rect(60+i*66, 500, 60+i*66+56, 556)
+		fillRect(img, box, formColor(outcome))
+		drawText(img, box.Min.X+16, box.Min.Y+11, replay_entity.FormLetter(outcome), 5, textColor)
+	}
+
+	// latest badges
+	drawText(img, 480, 470, "Badges", 3, mutedColor)
+
+	if len(card.Badges) == 0 {
+		drawText(img, 480, 520, "No badges yet", 3, mutedColor)
+	}
+
+	x := 480
+	for _, badge := range card.Badges {
+		label := truncateText(badge, 3, 16*(glyphWidth+glyphSpacing)*3)
+		width := textWidth(label, 3) + 32
+
+		if x+width > PlayerCardWidth-60 {
+			break
+		}
+
+		fillRect(img, image.Rect(x, 500, x+width, 556), panelColor)
+		drawText(img, x+16, 518, label, 3, accentColor)
+
+		x += width + 12
+	}
+
+	var buf bytes.Buffer
+	err := png.Encode(&buf, img)
+	if err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// drawAvatar draws the avatar of the player scaled to the rect, or their initial on a color of their own when it can't be fetched.
+func (r *PlayerCardRenderer) drawAvatar(ctx context.Context, img *image.RGBA, card replay_entity.PlayerCard, rect image.Rectangle) {
+	avatar := r.fetchAvatar(ctx, card.AvatarURI)
+
+	if avatar == nil {
+		fillRect(img, rect, color.RGBA{0x30 + card.PlayerID[0]%0x60, 0x30 + card.PlayerID[1]%0x60, 0x30 + card.PlayerID[2]%0x60, 0xff})
+
+		initial := "?"
+		if name := []rune(strings.TrimSpace(card.Name)); len(name) > 0 {
+			initial = string(name[0])
+		}
+
+		scale := 20
+		drawText(img, rect.Min.X+(rect.Dx()-glyphWidth*scale)/2, rect.Min.Y+(rect.Dy()-glyphHeight*scale)/2, initial, scale, textColor)
+
+		return
+	}
+
+	// nearest neighbor
+	src := avatar.Bounds()
+	for y := 0; y < rect.Dy(); y++ {
+		for x := 0; x < rect.Dx(); x++ {
+			img.Set(rect.Min.X+x, rect.Min.Y+y, avatar.At(src.Min.X+x*src.Dx()/rect.Dx(), src.Min.Y+y*src.Dy()/rect.Dy()))
+		}
+	}
+}
+
+// fetchAvatar downloads the avatar from one of the AvatarHosts, nil when there's none or it can't be fetched (the card is still rendered).
+func (r *PlayerCardRenderer) fetchAvatar(ctx context.Context, uri string) image.Image {
+	if uri == "" {
+		return nil
+	}
+
+	u, err := url.Parse(uri)
+	if err != nil || u.Scheme != "https" || !slices.Contains(r.AvatarHosts, u.Hostname()) {
+		return nil
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil
+	}
+
+	res, err := r.AvatarClient.Do(req)
+	if err != nil {
+		slog.WarnContext(ctx, "unable to fetch avatar for player card", "uri", uri, "err", err)
+		return nil
+	}
+
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		slog.WarnContext(ctx, "unable to fetch avatar for player card", "uri", uri, "status", res.StatusCode)
+		return nil
+	}
+
+	body, err := io.ReadAll(io.LimitReader(res.Body, maxAvatarSize))
+	if err != nil {
+		slog.WarnContext(ctx, "unable to fetch avatar for player card", "uri", uri, "err", err)
+		return nil
+	}
+
+	// the dimensions are checked before decoding: a small file may decode into a huge image
+	config, _, err := image.DecodeConfig(bytes.NewReader(body))
+	if err != nil || config.Width > maxAvatarDimension || config.Height > maxAvatarDimension {
+		slog.WarnContext(ctx, "unable to decode avatar for player card", "uri", uri, "width", config.Width, "height", config.Height, "err", err)
+		return nil
+	}
+
+	avatar, _, err := image.Decode(bytes.NewReader(body))
+	if err != nil || avatar.Bounds().Empty() {
+		slog.WarnContext(ctx, "unable to decode avatar for player card", "uri", uri, "err", err)
+		return nil
+	}
+
+	return avatar
+}
+
+func formColor(outcome replay_entity.MatchOutcome) color.RGBA {
+	switch outcome {
+	case replay_entity.MatchOutcomeWin:
+		return winColor
+	case replay_entity.MatchOutcomeLoss:
+		return lossColor
+	default:
+		return drawColor
+	}
+}
+
+func fillRect(img *image.RGBA, rect image.Rectangle, c color.RGBA) {
+	draw.Draw(img, rect, &image.Uniform{C: c}, image.Point{}, draw.Src)
+}
@@ -0,0 +1,111 @@
+package cards_test
+
+import (
+	"bytes"
+	"context"
+	"image"
+	"image/color"
+	"image/png"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/google/uuid"
+	replay_entity "github.com/psavelis/team-pro/replay-api/pkg/domain/replay/entities"
+	"github.com/psavelis/team-pro/replay-api/pkg/infra/cards"
+	"github.com/stretchr/testify/assert"
+)
+
+func avatarServer(t *testing.T, width int, height int) (*httptest.Server, *int) {
+	avatar := image.NewRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			avatar.Set(x, y, color.RGBA{0xff, 0, 0, 0xff})
+		}
+	}
+
+	var body bytes.Buffer
+	assert.NoError(t, png.Encode(&body, avatar))
+
+	requests := 0
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("Content-Type", "image/png")
+		w.Write(body.Bytes())
+	}))
+
+	return server, &requests
+}
+
+func renderCard(t *testing.T, renderer *cards.PlayerCardRenderer, card replay_entity.PlayerCard) image.Image {
+	b, err := renderer.Render(context.Background(), card)
+	assert.NoError(t, err)
+
+	img, err := png.Decode(bytes.NewReader(b))
+	assert.NoError(t, err)
+
+	return img
+}
+
+func TestPlayerCardRenderer(t *testing.T) {
+	card := replay_entity.PlayerCard{
+		PlayerID:   uuid.New(),
+		Name:       "a very long player name that won't fit in the card",
+		ClanName:   "NAVI",
+		Rank:       &replay_entity.RankBadge{Label: "Level 10"},
+		Stats:      replay_entity.PlayerStatsTotals{Matches: 1234, KD: 1.31, ADR: 87.4, HSPercentage: 48.2, Rating: 1.18},
+		RecentForm: []replay_entity.MatchOutcome{replay_entity.MatchOutcomeWin, replay_entity.MatchOutcomeLoss, replay_entity.MatchOutcomeDraw},
+		Badges:     []string{"Ace", "Headhunter", "Clutch Minister", "Ñandú"},
+	}
+
+	server, requests := avatarServer(t, 184, 184)
+	defer server.Close()
+
+	u, _ := url.Parse(server.URL)
+
+	renderer := cards.NewPlayerCardRenderer()
+	renderer.AvatarClient = server.Client()
+
+	// Open Graph sized
+	img := renderCard(t, renderer, card)
+	assert.Equal(t, image.Rect(0, 0, cards.PlayerCardWidth, cards.PlayerCardHeight), img.Bounds())
+
+	// avatars are only fetched from the avatar hosts
+	card.AvatarURI = server.URL + "/avatar.png"
+	img = renderCard(t, renderer, card)
+	assert.Equal(t, 0, *requests)
+
+	r, g, b, _ := img.At(170, 170).RGBA()
+	assert.NotEqual(t, [3]uint32{0xffff, 0, 0}, [3]uint32{r, g, b})
+
+	renderer.AvatarHosts = []string{u.Hostname()}
+	img = renderCard(t, renderer, card)
+	assert.Equal(t, 1, *requests)
+
+	r, g, b, _ = img.At(170, 170).RGBA()
+	assert.Equal(t, [3]uint32{0xffff, 0, 0}, [3]uint32{r, g, b})
+
+	// and never over plain http
+	card.AvatarURI = "http://" + u.Host + "/avatar.png"
+	renderCard(t, renderer, card)
+	assert.Equal(t, 1, *requests)
+}
+
+func TestPlayerCardRendererOversizedAvatar(t *testing.T) {
+	server, requests := avatarServer(t, 4000, 10)
+	defer server.Close()
+
+	u, _ := url.Parse(server.URL)
+
+	renderer := cards.NewPlayerCardRenderer()
+	renderer.AvatarClient = server.Client()
+	renderer.AvatarHosts = []string{u.Hostname()}
+
+	// the initial is drawn instead
+	img := renderCard(t, renderer, replay_entity.PlayerCard{PlayerID: uuid.New(), Name: "s1mple", AvatarURI: server.URL + "/avatar.png"})
+	assert.Equal(t, 1, *requests)
+
+	r, g, b, _ := img.At(170, 170).RGBA()
+	assert.NotEqual(t, [3]uint32{0xffff, 0, 0}, [3]uint32{r, g, b})
+}
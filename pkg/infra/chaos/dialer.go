@@ -0,0 +1,92 @@
+package chaos
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net"
+	"sync/atomic"
+)
+
+// Dialer opens connections to an adapter's server (ie: the mongo driver or the rabbitmq client) and injects faults in them:
+// latency on dials and writes, failed dials and writes (the request never reaches the server) and dropped responses (the request
+// is delivered, the connection is closed before the response is read).
+type Dialer struct {
+	Target   string
+	Injector *Injector
+	Dialer   net.Dialer
+}
+
+func NewDialer(target string, injector *Injector) *Dialer {
+	return &Dialer{
+		Target:   target,
+		Injector: injector,
+	}
+}
+
+func (d *Dialer) DialContext(ctx context.Context, network, address string) (net.Conn, error) {
+	err := d.Injector.delay(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if d.Injector.roll(d.Injector.ErrorRate) {
+		slog.WarnContext(ctx, "chaos: failing dial", "target", d.Target, "address", address)
+		return nil, fmt.Errorf("%w: %s dial %s", ErrInjectedFault, d.Target, address)
+	}
+
+	conn, err := d.Dialer.DialContext(ctx, network, address)
+	if err != nil {
+		return nil, err
+	}
+
+	return &faultyConn{Conn: conn, dialer: d}, nil
+}
+
+// Dial is the context-less form expected by amqp.Config.
+func (d *Dialer) Dial(network, address string) (net.Conn, error) {
+	return d.DialContext(context.Background(), network, address)
+}
+
+type faultyConn struct {
+	net.Conn
+	dialer  *Dialer
+	dropped atomic.Bool
+}
+
+func (c *faultyConn) Write(b []byte) (int, error) {
+	injector := c.dialer.Injector
+
+	err := injector.delay(context.Background())
+	if err != nil {
+		return 0, err
+	}
+
+	if injector.roll(injector.ErrorRate) {
+		slog.Warn("chaos: failing write", "target", c.dialer.Target, "address", c.RemoteAddr().String(), "bytes", len(b))
+		c.Conn.Close()
+
+		return 0, fmt.Errorf("%w: %s write", ErrInjectedFault, c.dialer.Target)
+	}
+
+	n, err := c.Conn.Write(b)
+	if err != nil {
+		return n, err
+	}
+
+	if injector.roll(injector.PartialFailureRate) {
+		slog.Warn("chaos: dropping response", "target", c.dialer.Target, "address", c.RemoteAddr().String(), "bytes", len(b))
+		c.dropped.Store(true)
+		c.Conn.Close()
+	}
+
+	return n, nil
+}
+
+func (c *faultyConn) Read(b []byte) (int, error) {
+	if c.dropped.Load() {
+		return 0, fmt.Errorf("%w: %s response dropped", ErrInjectedFault, c.dialer.Target)
+	}
+
+	return c.Conn.Read(b)
+}
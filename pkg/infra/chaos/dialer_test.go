@@ -0,0 +1,169 @@
+package chaos_test
+
+import (
+	"context"
+	"io"
+	"net"
+	"testing"
+	"time"
+
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	"github.com/psavelis/team-pro/replay-api/pkg/infra/chaos"
+	"github.com/stretchr/testify/assert"
+)
+
+// echoServer answers every write with the same bytes and reports what it received.
+func echoServer(t *testing.T) (string, chan []byte) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to listen: %v", err)
+	}
+
+	t.Cleanup(func() { listener.Close() })
+
+	received := make(chan []byte, 10)
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+
+			go func() {
+				defer conn.Close()
+
+				buf := make([]byte, 1024)
+				for {
+					n, err := conn.Read(buf)
+					if err != nil {
+						return
+					}
+
+					received <- append([]byte(nil), buf[:n]...)
+					conn.Write(buf[:n])
+				}
+			}()
+		}
+	}()
+
+	return listener.Addr().String(), received
+}
+
+func TestDialer(t *testing.T) {
+	tests := []struct {
+		name             string
+		config           common.ChaosConfig
+		expectedDialErr  bool
+		expectedWriteErr bool
+		expectedReceived bool
+		expectedReadErr  bool
+	}{
+		{"No Faults", common.ChaosConfig{}, false, false, true, false},
+		{"Failed Dial", common.ChaosConfig{ErrorRate: 1}, true, false, false, false},
+		{"Dropped Response", common.ChaosConfig{PartialFailureRate: 1}, false, false, true, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			address, received := echoServer(t)
+
+			dialer := chaos.NewDialer(chaos.TargetMongoDB, chaos.NewInjector(tt.config))
+
+			conn, err := dialer.DialContext(context.Background(), "tcp", address)
+			if tt.expectedDialErr {
+				assert.ErrorIs(t, err, chaos.ErrInjectedFault)
+				return
+			}
+
+			if !assert.NoError(t, err) {
+				return
+			}
+
+			defer conn.Close()
+
+			_, err = conn.Write([]byte("ping"))
+			assert.NoError(t, err)
+
+			select {
+			case b := <-received:
+				assert.Equal(t, "ping", string(b))
+			case <-time.After(time.Second):
+				t.Fatal("request not delivered")
+			}
+
+			buf := make([]byte, 4)
+			_, err = io.ReadFull(conn, buf)
+
+			if tt.expectedReadErr {
+				assert.ErrorIs(t, err, chaos.ErrInjectedFault)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, "ping", string(buf))
+			}
+		})
+	}
+}
+
+func TestDialer_FailedWrite(t *testing.T) {
+	address, received := echoServer(t)
+
+	injector := chaos.NewInjector(common.ChaosConfig{})
+	dialer := chaos.NewDialer(chaos.TargetRabbitMQ, injector)
+
+	conn, err := dialer.Dial("tcp", address)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	defer conn.Close()
+
+	// dialed without faults, then every write fails
+	injector.ErrorRate = 1
+
+	_, err = conn.Write([]byte("ping"))
+	assert.ErrorIs(t, err, chaos.ErrInjectedFault)
+
+	select {
+	case <-received:
+		t.Fatal("failed write delivered")
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func TestDialer_Latency(t *testing.T) {
+	address, _ := echoServer(t)
+
+	dialer := chaos.NewDialer(chaos.TargetMongoDB, chaos.NewInjector(common.ChaosConfig{Latency: 30 * time.Millisecond}))
+
+	start := time.Now()
+
+	conn, err := dialer.DialContext(context.Background(), "tcp", address)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	defer conn.Close()
+
+	_, err = conn.Write([]byte("ping"))
+	assert.NoError(t, err)
+
+	// dial + write
+	assert.GreaterOrEqual(t, time.Since(start), 60*time.Millisecond)
+
+	// a cancelled dial doesn't wait for the latency
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err = dialer.DialContext(ctx, "tcp", address)
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func TestChaosConfig_Targeted(t *testing.T) {
+	config := common.ChaosConfig{Targets: "mongodb, rabbitmq"}
+
+	assert.True(t, config.Targeted(chaos.TargetMongoDB))
+	assert.True(t, config.Targeted(chaos.TargetRabbitMQ))
+	assert.False(t, config.Targeted("s3"))
+	assert.False(t, common.ChaosConfig{}.Targeted(chaos.TargetMongoDB))
+}
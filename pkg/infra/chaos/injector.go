@@ -0,0 +1,69 @@
+package chaos
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"sync"
+	"time"
+
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+)
+
+const (
+	TargetMongoDB  = "mongodb"
+	TargetRabbitMQ = "rabbitmq"
+)
+
+var ErrInjectedFault = errors.New("chaos: injected fault")
+
+// Injector decides which operations are delayed or failed, from the configured rates.
+type Injector struct {
+	Latency            time.Duration
+	ErrorRate          float64
+	PartialFailureRate float64
+
+	mu   sync.Mutex
+	rand *rand.Rand
+}
+
+func NewInjector(config common.ChaosConfig) *Injector {
+	seed := config.Seed
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+
+	return &Injector{
+		Latency:            config.Latency,
+		ErrorRate:          config.ErrorRate,
+		PartialFailureRate: config.PartialFailureRate,
+		rand:               rand.New(rand.NewSource(seed)),
+	}
+}
+
+func (i *Injector) delay(ctx context.Context) error {
+	if i.Latency <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(i.Latency)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+func (i *Injector) roll(rate float64) bool {
+	if rate <= 0 {
+		return false
+	}
+
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	return i.rand.Float64() < rate
+}
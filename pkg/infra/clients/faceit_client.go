@@ -0,0 +1,267 @@
+package clients
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	"github.com/psavelis/team-pro/replay-api/pkg/domain/faceit"
+	faceit_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/faceit/entities"
+	replay_entity "github.com/psavelis/team-pro/replay-api/pkg/domain/replay/entities"
+)
+
+const (
+	FaceitDataAPIURL      = "https://open.faceit.com/data/v4"
+	FaceitDownloadsAPIURL = "https://open.faceit.com/download/v2"
+)
+
+var ErrFaceitDisabled = errors.New("faceit imports are disabled (FACEIT_API_KEY not set)")
+
+// FaceitClient reads matches through the FACEIT Data API and downloads their demos through the Downloads API (demo_url resources
+// are only reachable through a signed download URL).
+type FaceitClient struct {
+	APIKey          string
+	DataAPIURL      string
+	DownloadsAPIURL string
+	Client          *http.Client
+	DemoClient      *http.Client
+}
+
+func NewFaceitClient(config common.FaceitConfig) *FaceitClient {
+	return &FaceitClient{
+		APIKey:          config.APIKey,
+		DataAPIURL:      FaceitDataAPIURL,
+		DownloadsAPIURL: FaceitDownloadsAPIURL,
+		Client:          &http.Client{Timeout: 10 * time.Second},
+		// demos are streamed as they're processed: only the response headers are bounded
+		DemoClient: &http.Client{Transport: &http.Transport{ResponseHeaderTimeout: 30 * time.Second}},
+	}
+}
+
+type faceitRosterPlayer struct {
+	PlayerID     string `json:"player_id"`
+	Nickname     string `json:"nickname"`
+	GamePlayerID string `json:"game_player_id"`
+}
+
+type faceitMatchResponse struct {
+	MatchID    string   `json:"match_id"`
+	Game       string   `json:"game"`
+	Status     string   `json:"status"`
+	FaceitURL  string   `json:"faceit_url"`
+	DemoURL    []string `json:"demo_url"`
+	StartedAt  int64    `json:"started_at"`
+	FinishedAt int64    `json:"finished_at"`
+	Teams      map[string]struct {
+		Roster []faceitRosterPlayer `json:"roster"`
+	} `json:"teams"`
+}
+
+func (c *FaceitClient) GetMatch(ctx context.Context, matchID string) (*faceit_entities.FaceitMatch, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.DataAPIURL+"/matches/"+url.PathEscape(matchID), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var res faceitMatchResponse
+	err = c.do(req, &res)
+	if err != nil {
+		if errors.Is(err, errFaceitNotFound) {
+			return nil, faceit.NewFaceitMatchNotFoundError(fmt.Sprintf("FACEIT match %s not found", matchID))
+		}
+
+		slog.ErrorContext(ctx, "unable to get faceit match", "faceitMatchID", matchID, "err", err)
+		return nil, err
+	}
+
+	match := &faceit_entities.FaceitMatch{
+		ID:       res.MatchID,
+		Game:     res.Game,
+		Status:   res.Status,
+		URL:      strings.ReplaceAll(res.FaceitURL, "{lang}", "en"),
+		DemoURLs: res.DemoURL,
+		Players:  make([]faceit_entities.FaceitPlayer, 0, 10),
+	}
+
+	if res.StartedAt > 0 {
+		startedAt := time.Unix(res.StartedAt, 0).UTC()
+		match.StartedAt = &startedAt
+	}
+
+	if res.FinishedAt > 0 {
+		finishedAt := time.Unix(res.FinishedAt, 0).UTC()
+		match.FinishedAt = &finishedAt
+	}
+
+	for _, faction := range []string{"faction1", "faction2"} {
+		for _, p := range res.Teams[faction].Roster {
+			match.Players = append(match.Players, faceit_entities.FaceitPlayer{
+				ID:           p.PlayerID,
+				Nickname:     p.Nickname,
+				GamePlayerID: p.GamePlayerID,
+				Faction:      faction,
+			})
+		}
+	}
+
+	return match, nil
+}
+
+// DownloadDemo signs the demo resource URL and streams the demo, decompressing the .gz demos.
+func (c *FaceitClient) DownloadDemo(ctx context.Context, demoURL string) (io.ReadCloser, error) {
+	body, _ := json.Marshal(map[string]string{"resource_url": demoURL})
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.DownloadsAPIURL+"/demos/download-url", bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	var signed struct {
+		Payload struct {
+			DownloadURL string `json:"download_url"`
+		} `json:"payload"`
+	}
+
+	err = c.do(req, &signed)
+	if err != nil {
+		if errors.Is(err, errFaceitNotFound) {
+			return nil, faceit.NewFaceitMatchNotFoundError("FACEIT demo is no longer available")
+		}
+
+		slog.ErrorContext(ctx, "unable to sign faceit demo download", "demoURL", demoURL, "err", err)
+		return nil, err
+	}
+
+	downloadURL, err := url.Parse(signed.Payload.DownloadURL)
+	if err != nil || (downloadURL.Scheme != "http" && downloadURL.Scheme != "https") {
+		return nil, fmt.Errorf("invalid faceit demo download url '%s'", signed.Payload.DownloadURL)
+	}
+
+	req, err = http.NewRequestWithContext(ctx, http.MethodGet, downloadURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := c.DemoClient.Do(req)
+	if err != nil {
+		slog.ErrorContext(ctx, "unable to download faceit demo", "demoURL", demoURL, "err", err)
+		return nil, err
+	}
+
+	if res.StatusCode == http.StatusNotFound {
+		res.Body.Close()
+		return nil, faceit.NewFaceitMatchNotFoundError("FACEIT demo is no longer available")
+	}
+
+	if res.StatusCode != http.StatusOK {
+		res.Body.Close()
+		return nil, fmt.Errorf("faceit demo download answered %d", res.StatusCode)
+	}
+
+	if !strings.HasSuffix(downloadURL.Path, ".gz") {
+		return &demoReader{Reader: io.LimitReader(res.Body, MaxDemoSize), body: res.Body}, nil
+	}
+
+	demo, err := gzip.NewReader(res.Body)
+	if err != nil {
+		res.Body.Close()
+		return nil, fmt.Errorf("invalid faceit demo: %w", err)
+	}
+
+	return &demoReader{Reader: io.LimitReader(demo, MaxDemoSize), body: res.Body}, nil
+}
+
+func (c *FaceitClient) Source() replay_entity.ExternalStatsSource {
+	return replay_entity.FaceitStatsSource
+}
+
+// FetchStats reads the CS2 elo and skill level of the FACEIT account of the SteamID (nil when they have none).
+func (c *FaceitClient) FetchStats(ctx context.Context, accounts replay_entity.ExternalAccounts) (*replay_entity.ExternalStats, error) {
+	if accounts.SteamID == "" {
+		return nil, nil
+	}
+
+	query := url.Values{"game": {"cs2"}, "game_player_id": {accounts.SteamID}}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.DataAPIURL+"/players?"+query.Encode(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var res struct {
+		PlayerID string `json:"player_id"`
+		Games    map[string]struct {
+			FaceitElo  int `json:"faceit_elo"`
+			SkillLevel int `json:"skill_level"`
+		} `json:"games"`
+	}
+
+	err = c.do(req, &res)
+	if err != nil {
+		if errors.Is(err, errFaceitNotFound) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	cs2, ok := res.Games["cs2"]
+	if !ok {
+		return nil, nil
+	}
+
+	return &replay_entity.ExternalStats{
+		Source:     replay_entity.FaceitStatsSource,
+		AccountID:  res.PlayerID,
+		Elo:        cs2.FaceitElo,
+		SkillLevel: cs2.SkillLevel,
+	}, nil
+}
+
+var errFaceitNotFound = errors.New("not found")
+
+func (c *FaceitClient) do(req *http.Request, v interface{}) error {
+	req.Header.Set("Authorization", "Bearer "+c.APIKey)
+	req.Header.Set("Accept", "application/json")
+
+	res, err := c.Client.Do(req)
+	if err != nil {
+		return err
+	}
+
+	defer res.Body.Close()
+
+	if res.StatusCode == http.StatusNotFound {
+		return errFaceitNotFound
+	}
+
+	if res.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(res.Body, 1024))
+		return fmt.Errorf("faceit api answered %d: %s", res.StatusCode, body)
+	}
+
+	return json.NewDecoder(res.Body).Decode(v)
+}
+
+// DisabledFaceitClient is used when no FACEIT API key is configured.
+type DisabledFaceitClient struct{}
+
+func (c *DisabledFaceitClient) GetMatch(ctx context.Context, matchID string) (*faceit_entities.FaceitMatch, error) {
+	return nil, ErrFaceitDisabled
+}
+
+func (c *DisabledFaceitClient) DownloadDemo(ctx context.Context, demoURL string) (io.ReadCloser, error) {
+	return nil, ErrFaceitDisabled
+}
@@ -0,0 +1,139 @@
+package clients_test
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	"github.com/psavelis/team-pro/replay-api/pkg/domain/faceit"
+	replay_entity "github.com/psavelis/team-pro/replay-api/pkg/domain/replay/entities"
+	"github.com/psavelis/team-pro/replay-api/pkg/infra/clients"
+	"github.com/stretchr/testify/assert"
+)
+
+const faceitMatchID = "1-0d3b7f0e-8f4c-4a8e-9f6a-2f0a4f6e8a1b"
+
+func TestFaceitClient(t *testing.T) {
+	var demo bytes.Buffer
+	gz := gzip.NewWriter(&demo)
+	gz.Write([]byte("PBDEMS2\x00demo"))
+	gz.Close()
+
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/demos/"+faceitMatchID+".dem.gz" && r.Header.Get("Authorization") != "Bearer key" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		switch r.URL.Path {
+		case "/data/v4/matches/" + faceitMatchID:
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"match_id":    faceitMatchID,
+				"game":        "cs2",
+				"status":      "FINISHED",
+				"faceit_url":  "https://www.faceit.com/{lang}/cs2/room/" + faceitMatchID,
+				"demo_url":    []string{"https://demos.faceit-cdn.net/cs2/" + faceitMatchID + "-1-1.dem.gz"},
+				"started_at":  1700000000,
+				"finished_at": 1700003000,
+				"teams": map[string]interface{}{
+					"faction1": map[string]interface{}{"roster": []map[string]string{{"player_id": "p1", "nickname": "one", "game_player_id": "76561198000000001"}}},
+					"faction2": map[string]interface{}{"roster": []map[string]string{{"player_id": "p2", "nickname": "two", "game_player_id": "76561198000000002"}}},
+				},
+			})
+		case "/download/v2/demos/download-url":
+			var req map[string]string
+			json.NewDecoder(r.Body).Decode(&req)
+
+			if req["resource_url"] != "https://demos.faceit-cdn.net/cs2/"+faceitMatchID+"-1-1.dem.gz" {
+				http.NotFound(w, r)
+				return
+			}
+
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"payload": map[string]string{"download_url": server.URL + "/demos/" + faceitMatchID + ".dem.gz"},
+			})
+		case "/demos/" + faceitMatchID + ".dem.gz":
+			w.Write(demo.Bytes())
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	client := clients.NewFaceitClient(common.FaceitConfig{APIKey: "key"})
+	client.DataAPIURL = server.URL + "/data/v4"
+	client.DownloadsAPIURL = server.URL + "/download/v2"
+
+	match, err := client.GetMatch(context.Background(), faceitMatchID)
+	if assert.NoError(t, err) {
+		assert.True(t, match.IsFinished())
+		assert.Equal(t, "https://www.faceit.com/en/cs2/room/"+faceitMatchID, match.URL)
+		assert.Equal(t, int64(1700003000), match.FinishedAt.Unix())
+		if assert.Len(t, match.Players, 2) {
+			assert.Equal(t, "faction1", match.Players[0].Faction)
+			assert.Equal(t, "76561198000000002", match.Players[1].GamePlayerID)
+		}
+	}
+
+	content, err := client.DownloadDemo(context.Background(), match.DemoURLs[0])
+	if assert.NoError(t, err) {
+		raw, err := io.ReadAll(content)
+		assert.NoError(t, err)
+		assert.Equal(t, "PBDEMS2\x00demo", string(raw))
+		assert.NoError(t, content.Close())
+	}
+
+	var notFoundErr *faceit.FaceitMatchNotFoundError
+
+	_, err = client.GetMatch(context.Background(), "1-00000000-0000-0000-0000-000000000000")
+	assert.ErrorAs(t, err, &notFoundErr, "unknown match")
+
+	_, err = client.DownloadDemo(context.Background(), "https://demos.faceit-cdn.net/cs2/expired.dem.gz")
+	assert.ErrorAs(t, err, &notFoundErr, "demo no longer available")
+
+	client.APIKey = "revoked"
+	_, err = client.GetMatch(context.Background(), faceitMatchID)
+	assert.Error(t, err)
+	assert.NotContains(t, err.Error(), "not found")
+}
+
+func TestFaceitClient_FetchStats(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/data/v4/players" || r.URL.Query().Get("game") != "cs2" || r.URL.Query().Get("game_player_id") != "76561198000000001" {
+			http.NotFound(w, r)
+			return
+		}
+
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"player_id": "p1",
+			"games":     map[string]interface{}{"cs2": map[string]int{"faceit_elo": 2150, "skill_level": 10}},
+		})
+	}))
+	defer server.Close()
+
+	client := clients.NewFaceitClient(common.FaceitConfig{APIKey: "key"})
+	client.DataAPIURL = server.URL + "/data/v4"
+
+	stats, err := client.FetchStats(context.Background(), replay_entity.ExternalAccounts{SteamID: "76561198000000001"})
+	if assert.NoError(t, err) && assert.NotNil(t, stats) {
+		assert.Equal(t, "p1", stats.AccountID)
+		assert.Equal(t, 2150, stats.Elo)
+		assert.Equal(t, 10, stats.SkillLevel)
+	}
+
+	// not on FACEIT
+	stats, err = client.FetchStats(context.Background(), replay_entity.ExternalAccounts{SteamID: "76561198000000002"})
+	assert.NoError(t, err)
+	assert.Nil(t, stats)
+
+	stats, err = client.FetchStats(context.Background(), replay_entity.ExternalAccounts{RiotPUUID: "puuid"})
+	assert.NoError(t, err)
+	assert.Nil(t, stats)
+}
@@ -0,0 +1,145 @@
+package clients
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	replay_entity "github.com/psavelis/team-pro/replay-api/pkg/domain/replay/entities"
+)
+
+const (
+	RiotAccountAPIURL = "https://americas.api.riotgames.com" // account-v1 answers for every region from any regional route
+	RiotShardAPIURL   = "https://%s.api.riotgames.com"       // val-match-v1 is only answered by the active shard of the player (ie: "na", "eu")
+)
+
+var errRiotNotFound = errors.New("not found")
+
+// RiotStatsClient reads the Valorant competitive rank of a Riot account through the Riot Games API. Ranks aren't exposed by the
+// API: the rank is the competitive tier the player had in their last competitive match.
+type RiotStatsClient struct {
+	APIKey        string
+	AccountAPIURL string
+	ShardAPIURL   string
+	Client        *http.Client
+}
+
+func NewRiotStatsClient(config common.RiotConfig) *RiotStatsClient {
+	return &RiotStatsClient{
+		APIKey:        config.APIKey,
+		AccountAPIURL: RiotAccountAPIURL,
+		ShardAPIURL:   RiotShardAPIURL,
+		Client:        &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+func (c *RiotStatsClient) Source() replay_entity.ExternalStatsSource {
+	return replay_entity.RiotStatsSource
+}
+
+// FetchStats reads the Valorant rank of the PUUID (nil when they never played Valorant, or no competitive match recently).
+func (c *RiotStatsClient) FetchStats(ctx context.Context, accounts replay_entity.ExternalAccounts) (*replay_entity.ExternalStats, error) {
+	if accounts.RiotPUUID == "" {
+		return nil, nil
+	}
+
+	puuid := url.PathEscape(accounts.RiotPUUID)
+
+	var shard struct {
+		ActiveShard string `json:"activeShard"`
+	}
+
+	err := c.get(ctx, c.AccountAPIURL+"/riot/account/v1/active-shards/by-game/val/by-puuid/"+puuid, &shard)
+	if err != nil || shard.ActiveShard == "" {
+		return nil, ignoreRiotNotFound(err)
+	}
+
+	shardAPIURL := fmt.Sprintf(c.ShardAPIURL, url.PathEscape(shard.ActiveShard))
+
+	var matchlist struct {
+		History []struct {
+			MatchID string `json:"matchId"`
+			QueueID string `json:"queueId"`
+		} `json:"history"`
+	}
+
+	err = c.get(ctx, shardAPIURL+"/val/match/v1/matchlists/by-puuid/"+puuid, &matchlist)
+	if err != nil {
+		return nil, ignoreRiotNotFound(err)
+	}
+
+	// most recent first
+	for _, m := range matchlist.History {
+		if m.QueueID != "competitive" {
+			continue
+		}
+
+		var match struct {
+			Players []struct {
+				PUUID           string `json:"puuid"`
+				CompetitiveTier int    `json:"competitiveTier"`
+			} `json:"players"`
+		}
+
+		err = c.get(ctx, shardAPIURL+"/val/match/v1/matches/"+url.PathEscape(m.MatchID), &match)
+		if err != nil {
+			return nil, ignoreRiotNotFound(err)
+		}
+
+		for _, p := range match.Players {
+			if p.PUUID == accounts.RiotPUUID {
+				return &replay_entity.ExternalStats{
+					Source:    replay_entity.RiotStatsSource,
+					AccountID: accounts.RiotPUUID,
+					RankTier:  p.CompetitiveTier,
+				}, nil
+			}
+		}
+
+		return nil, nil
+	}
+
+	return nil, nil
+}
+
+func (c *RiotStatsClient) get(ctx context.Context, resourceURL string, v interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, resourceURL, nil)
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("X-Riot-Token", c.APIKey)
+	req.Header.Set("Accept", "application/json")
+
+	res, err := c.Client.Do(req)
+	if err != nil {
+		return err
+	}
+
+	defer res.Body.Close()
+
+	if res.StatusCode == http.StatusNotFound {
+		return errRiotNotFound
+	}
+
+	if res.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(res.Body, 1024))
+		return fmt.Errorf("riot api answered %d: %s", res.StatusCode, body)
+	}
+
+	return json.NewDecoder(res.Body).Decode(v)
+}
+
+func ignoreRiotNotFound(err error) error {
+	if errors.Is(err, errRiotNotFound) {
+		return nil
+	}
+
+	return err
+}
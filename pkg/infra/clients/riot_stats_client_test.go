@@ -0,0 +1,61 @@
+package clients_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	replay_entity "github.com/psavelis/team-pro/replay-api/pkg/domain/replay/entities"
+	"github.com/psavelis/team-pro/replay-api/pkg/infra/clients"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRiotStatsClient(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Riot-Token") != "key" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+
+		switch r.URL.Path {
+		case "/riot/account/v1/active-shards/by-game/val/by-puuid/puuid-1":
+			json.NewEncoder(w).Encode(map[string]string{"puuid": "puuid-1", "game": "val", "activeShard": "eu"})
+		case "/eu/val/match/v1/matchlists/by-puuid/puuid-1":
+			json.NewEncoder(w).Encode(map[string]interface{}{"history": []map[string]string{
+				{"matchId": "m3", "queueId": "unrated"},
+				{"matchId": "m2", "queueId": "competitive"},
+				{"matchId": "m1", "queueId": "competitive"},
+			}})
+		case "/eu/val/match/v1/matches/m2":
+			json.NewEncoder(w).Encode(map[string]interface{}{"players": []map[string]interface{}{
+				{"puuid": "puuid-2", "competitiveTier": 12},
+				{"puuid": "puuid-1", "competitiveTier": 19},
+			}})
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	client := clients.NewRiotStatsClient(common.RiotConfig{APIKey: "key"})
+	client.AccountAPIURL = server.URL
+	client.ShardAPIURL = server.URL + "/%s"
+
+	stats, err := client.FetchStats(context.Background(), replay_entity.ExternalAccounts{RiotPUUID: "puuid-1"})
+	if assert.NoError(t, err) && assert.NotNil(t, stats) {
+		assert.Equal(t, 19, stats.RankTier)
+		assert.Equal(t, "Diamond 2", stats.RankBadge().Label)
+	}
+
+	// never played Valorant
+	stats, err = client.FetchStats(context.Background(), replay_entity.ExternalAccounts{RiotPUUID: "puuid-3"})
+	assert.NoError(t, err)
+	assert.Nil(t, stats)
+
+	client.APIKey = "revoked"
+	_, err = client.FetchStats(context.Background(), replay_entity.ExternalAccounts{RiotPUUID: "puuid-1"})
+	assert.Error(t, err)
+}
@@ -0,0 +1,172 @@
+package clients
+
+import (
+	"compress/bzip2"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	"github.com/psavelis/team-pro/replay-api/pkg/domain/steam"
+	steam_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/steam/entities"
+)
+
+const (
+	// demos are served by Valve replay servers (ie: http://replay183.valve.net/730/003652155434364371097_1094356929.dem.bz2)
+	ValveDemoHost = "valve.net"
+
+	// decompressed demos larger than this are refused (a competitive match demo is ~100-400MB)
+	MaxDemoSize = 1 << 30
+)
+
+var ErrSteamGCDisabled = errors.New("match share code imports are disabled (STEAM_GC_GATEWAY_URL not set)")
+
+// SteamGCMatchFetcher resolves share codes through the Game Coordinator gateway (GET /matches/{match_id}?outcome_id=&token=, answering
+// the demo URL and the time of the match) and downloads the demos from the Valve replay servers.
+type SteamGCMatchFetcher struct {
+	GatewayURL   string
+	GatewayToken string
+	DemoHosts    []string
+	Client       *http.Client
+	DemoClient   *http.Client
+}
+
+func NewSteamGCMatchFetcher(config common.SteamGCConfig) *SteamGCMatchFetcher {
+	return &SteamGCMatchFetcher{
+		GatewayURL:   strings.TrimSuffix(config.GatewayURL, "/"),
+		GatewayToken: config.GatewayToken,
+		DemoHosts:    []string{ValveDemoHost},
+		// the gateway waits for the Game Coordinator, which can be slow to answer
+		Client: &http.Client{Timeout: 30 * time.Second},
+		// demos are streamed as they're processed: only the response headers are bounded
+		DemoClient: &http.Client{Transport: &http.Transport{ResponseHeaderTimeout: 30 * time.Second}},
+	}
+}
+
+type steamGCMatchResponse struct {
+	DemoURL   string `json:"demo_url"`
+	MatchTime int64  `json:"match_time"`
+}
+
+func (f *SteamGCMatchFetcher) Resolve(ctx context.Context, shareCode steam_entities.ShareCode) (*steam_entities.SteamMatch, error) {
+	query := url.Values{}
+	query.Set("outcome_id", strconv.FormatUint(shareCode.OutcomeID, 10))
+	query.Set("token", strconv.FormatUint(uint64(shareCode.Token), 10))
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, f.GatewayURL+"/matches/"+strconv.FormatUint(shareCode.MatchID, 10)+"?"+query.Encode(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if f.GatewayToken != "" {
+		req.Header.Set("Authorization", "Bearer "+f.GatewayToken)
+	}
+
+	res, err := f.Client.Do(req)
+	if err != nil {
+		slog.ErrorContext(ctx, "unable to reach steam gc gateway", "matchID", shareCode.MatchID, "err", err)
+		return nil, err
+	}
+
+	defer res.Body.Close()
+
+	if res.StatusCode == http.StatusNotFound {
+		return nil, steam.NewSteamMatchNotFoundError(fmt.Sprintf("match %s not found", shareCode))
+	}
+
+	if res.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(res.Body, 1024))
+		return nil, fmt.Errorf("steam gc gateway answered %d: %s", res.StatusCode, body)
+	}
+
+	var match steamGCMatchResponse
+	err = json.NewDecoder(res.Body).Decode(&match)
+	if err != nil {
+		return nil, fmt.Errorf("invalid steam gc gateway response: %w", err)
+	}
+
+	// the Game Coordinator only knows the demos recorded in the last weeks
+	if match.DemoURL == "" {
+		return nil, steam.NewSteamMatchNotFoundError(fmt.Sprintf("match %s has no demo available", shareCode))
+	}
+
+	return &steam_entities.SteamMatch{
+		ShareCode: shareCode,
+		DemoURL:   match.DemoURL,
+		MatchTime: time.Unix(match.MatchTime, 0).UTC(),
+	}, nil
+}
+
+// Download streams the demo, decompressing the .bz2 demos. Only the Valve replay servers (DemoHosts) are reached.
+func (f *SteamGCMatchFetcher) Download(ctx context.Context, match steam_entities.SteamMatch) (io.ReadCloser, error) {
+	demoURL, err := url.Parse(match.DemoURL)
+	if err != nil || (demoURL.Scheme != "http" && demoURL.Scheme != "https") || !f.isDemoHost(demoURL.Hostname()) {
+		return nil, fmt.Errorf("refused demo url '%s': not a replay server", match.DemoURL)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, demoURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := f.DemoClient.Do(req)
+	if err != nil {
+		slog.ErrorContext(ctx, "unable to download demo", "demoURL", match.DemoURL, "err", err)
+		return nil, err
+	}
+
+	if res.StatusCode == http.StatusNotFound {
+		res.Body.Close()
+		return nil, steam.NewSteamMatchNotFoundError(fmt.Sprintf("demo of match %s is no longer available", match.ShareCode))
+	}
+
+	if res.StatusCode != http.StatusOK {
+		res.Body.Close()
+		return nil, fmt.Errorf("replay server answered %d for %s", res.StatusCode, match.DemoURL)
+	}
+
+	var demo io.Reader = res.Body
+	if strings.HasSuffix(demoURL.Path, ".bz2") {
+		demo = bzip2.NewReader(res.Body)
+	}
+
+	return &demoReader{Reader: io.LimitReader(demo, MaxDemoSize), body: res.Body}, nil
+}
+
+func (f *SteamGCMatchFetcher) isDemoHost(host string) bool {
+	for _, h := range f.DemoHosts {
+		if host == h || strings.HasSuffix(host, "."+h) {
+			return true
+		}
+	}
+
+	return false
+}
+
+type demoReader struct {
+	io.Reader
+	body io.Closer
+}
+
+func (r *demoReader) Close() error {
+	return r.body.Close()
+}
+
+// DisabledSteamMatchFetcher is used when no Game Coordinator gateway is configured.
+type DisabledSteamMatchFetcher struct{}
+
+func (f *DisabledSteamMatchFetcher) Resolve(ctx context.Context, shareCode steam_entities.ShareCode) (*steam_entities.SteamMatch, error) {
+	return nil, ErrSteamGCDisabled
+}
+
+func (f *DisabledSteamMatchFetcher) Download(ctx context.Context, match steam_entities.SteamMatch) (io.ReadCloser, error) {
+	return nil, ErrSteamGCDisabled
+}
@@ -0,0 +1,84 @@
+package clients_test
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	"github.com/psavelis/team-pro/replay-api/pkg/domain/steam"
+	steam_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/steam/entities"
+	"github.com/psavelis/team-pro/replay-api/pkg/infra/clients"
+	"github.com/stretchr/testify/assert"
+)
+
+// "PBDEMS2\x00demo" compressed with bzip2
+const demoBz2 = "QlpoOTFBWSZTWRHFryUAAANPAEAAEAAWAkgABgKgACIAZAQNA0GAmr4nN7xdyRThQkBHFryU"
+
+func TestSteamGCMatchFetcher(t *testing.T) {
+	shareCode, _ := steam_entities.DecodeShareCode("CSGO-GADqf-jjyJ8-cSP2r-smZRo-TO2xK")
+
+	demos := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/730/3230642215713767580_3230647599455273103.dem.bz2" {
+			http.NotFound(w, r)
+			return
+		}
+
+		raw, _ := base64.StdEncoding.DecodeString(demoBz2)
+		w.Write(raw)
+	}))
+	defer demos.Close()
+
+	var authorization string
+
+	gateway := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authorization = r.Header.Get("Authorization")
+
+		if r.URL.Path != "/matches/3230642215713767580" || r.URL.Query().Get("outcome_id") != "3230647599455273103" || r.URL.Query().Get("token") != "55788" {
+			http.NotFound(w, r)
+			return
+		}
+
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"demo_url":   demos.URL + "/730/3230642215713767580_3230647599455273103.dem.bz2",
+			"match_time": 1700000000,
+		})
+	}))
+	defer gateway.Close()
+
+	fetcher := clients.NewSteamGCMatchFetcher(common.SteamGCConfig{GatewayURL: gateway.URL + "/", GatewayToken: "secret"})
+	fetcher.DemoHosts = []string{"127.0.0.1"}
+
+	match, err := fetcher.Resolve(context.Background(), shareCode)
+	assert.NoError(t, err)
+	assert.Equal(t, "Bearer secret", authorization)
+	assert.Equal(t, int64(1700000000), match.MatchTime.Unix())
+
+	demo, err := fetcher.Download(context.Background(), *match)
+	if assert.NoError(t, err) {
+		content, err := io.ReadAll(demo)
+		assert.NoError(t, err)
+		assert.Equal(t, "PBDEMS2\x00demo", string(content))
+		assert.NoError(t, demo.Close())
+	}
+
+	var notFoundErr *steam.SteamMatchNotFoundError
+
+	_, err = fetcher.Resolve(context.Background(), steam_entities.ShareCode{MatchID: 1})
+	assert.ErrorAs(t, err, &notFoundErr, "unknown match")
+
+	expired := *match
+	expired.DemoURL = demos.URL + "/730/expired.dem.bz2"
+	_, err = fetcher.Download(context.Background(), expired)
+	assert.ErrorAs(t, err, &notFoundErr, "demo no longer available")
+
+	fetcher.DemoHosts = []string{clients.ValveDemoHost}
+	_, err = fetcher.Download(context.Background(), *match)
+	assert.Error(t, err, "demo hosted elsewhere than the replay servers")
+	assert.False(t, errors.As(err, &notFoundErr))
+}
@@ -0,0 +1,95 @@
+package clients
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	replay_entity "github.com/psavelis/team-pro/replay-api/pkg/domain/replay/entities"
+)
+
+const (
+	SteamWebAPIURL = "https://api.steampowered.com"
+	CS2SteamAppID  = 730
+)
+
+// SteamStatsClient reads the CS2 playtime of a Steam account through the Steam Web API (only answered for public game details).
+type SteamStatsClient struct {
+	APIKey string
+	APIURL string
+	Client *http.Client
+}
+
+func NewSteamStatsClient(config common.SteamConfig) *SteamStatsClient {
+	return &SteamStatsClient{
+		APIKey: config.SteamKey,
+		APIURL: SteamWebAPIURL,
+		Client: &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+func (c *SteamStatsClient) Source() replay_entity.ExternalStatsSource {
+	return replay_entity.SteamStatsSource
+}
+
+// FetchStats reads the hours played of CS2 by the SteamID (nil when their game details are private, or they don't own CS2).
+func (c *SteamStatsClient) FetchStats(ctx context.Context, accounts replay_entity.ExternalAccounts) (*replay_entity.ExternalStats, error) {
+	if accounts.SteamID == "" {
+		return nil, nil
+	}
+
+	query := url.Values{
+		"key":                       {c.APIKey},
+		"steamid":                   {accounts.SteamID},
+		"include_played_free_games": {"1"},
+		"appids_filter[0]":          {fmt.Sprint(CS2SteamAppID)},
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.APIURL+"/IPlayerService/GetOwnedGames/v1/?"+query.Encode(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := c.Client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(res.Body, 1024))
+		return nil, fmt.Errorf("steam web api answered %d: %s", res.StatusCode, body)
+	}
+
+	var owned struct {
+		Response struct {
+			Games []struct {
+				AppID           int `json:"appid"`
+				PlaytimeForever int `json:"playtime_forever"` // minutes
+			} `json:"games"`
+		} `json:"response"`
+	}
+
+	err = json.NewDecoder(res.Body).Decode(&owned)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, game := range owned.Response.Games {
+		if game.AppID == CS2SteamAppID {
+			return &replay_entity.ExternalStats{
+				Source:      replay_entity.SteamStatsSource,
+				AccountID:   accounts.SteamID,
+				HoursPlayed: float64(game.PlaytimeForever) / 60,
+			}, nil
+		}
+	}
+
+	return nil, nil
+}
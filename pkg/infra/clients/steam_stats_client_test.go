@@ -0,0 +1,50 @@
+package clients_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	replay_entity "github.com/psavelis/team-pro/replay-api/pkg/domain/replay/entities"
+	"github.com/psavelis/team-pro/replay-api/pkg/infra/clients"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSteamStatsClient(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/IPlayerService/GetOwnedGames/v1/" || r.URL.Query().Get("key") != "key" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+
+		switch r.URL.Query().Get("steamid") {
+		case "76561198000000001":
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"response": map[string]interface{}{"game_count": 1, "games": []map[string]int{{"appid": 730, "playtime_forever": 91230}}},
+			})
+		default:
+			// private game details
+			json.NewEncoder(w).Encode(map[string]interface{}{"response": map[string]interface{}{}})
+		}
+	}))
+	defer server.Close()
+
+	client := clients.NewSteamStatsClient(common.SteamConfig{SteamKey: "key"})
+	client.APIURL = server.URL
+
+	stats, err := client.FetchStats(context.Background(), replay_entity.ExternalAccounts{SteamID: "76561198000000001"})
+	if assert.NoError(t, err) && assert.NotNil(t, stats) {
+		assert.Equal(t, 1520.5, stats.HoursPlayed)
+	}
+
+	stats, err = client.FetchStats(context.Background(), replay_entity.ExternalAccounts{SteamID: "76561198000000002"})
+	assert.NoError(t, err)
+	assert.Nil(t, stats)
+
+	client.APIKey = "revoked"
+	_, err = client.FetchStats(context.Background(), replay_entity.ExternalAccounts{SteamID: "76561198000000001"})
+	assert.Error(t, err)
+}
@@ -0,0 +1,122 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// MaxAggregateGroups caps the groups returned by an aggregation (the ones with the lowest keys).
+const MaxAggregateGroups = 1000
+
+// aggregateDoc is a $group output: the group by values under _id, and the metrics.
+type aggregateDoc struct {
+	Key     map[string]interface{} `bson:"_id"`
+	Metrics map[string]interface{} `bson:",inline"`
+}
+
+// GetAggregatePipeline builds a $match (same filters and tenancy as GetPipeline) followed by a $group of the matching documents by
+// the groupBy fields (a single group when none), computing the metrics of each group.
+func (r *MongoDBRepository[T]) GetAggregatePipeline(queryCtx context.Context, s common.Search, groupBy []string, metrics []common.AggregateMetric) ([]bson.M, error) {
+	err := common.ValidateAggregate(groupBy, metrics, r.queryableFields)
+	if err != nil {
+		return nil, err
+	}
+
+	var pipe []bson.M
+
+	pipe, err = r.addMatch(queryCtx, pipe, s)
+	if err != nil {
+		slog.ErrorContext(queryCtx, "GetAggregatePipeline: unable to build $match stage", "error", err)
+		return nil, err
+	}
+
+	// output keys can't contain dots, so group by fields and metrics are indexed and mapped back to their names on decode
+	var key interface{}
+	if len(groupBy) > 0 {
+		fields := bson.D{}
+		for i, field := range groupBy {
+			bsonFieldName, err := r.GetBSONFieldNameFromSearchableValue(common.SearchableValue{Field: field})
+			if err != nil {
+				return nil, fmt.Errorf("unable to resolve group by field %s: %w", field, err)
+			}
+
+			fields = append(fields, bson.E{Key: aggregateKey("g", i), Value: "$" + bsonFieldName})
+		}
+
+		key = fields
+	}
+
+	group := bson.M{"_id": key}
+	for i, metric := range metrics {
+		if metric.Operator == common.CountAggregateOperator {
+			group[aggregateKey("m", i)] = bson.M{"$sum": 1}
+			continue
+		}
+
+		bsonFieldName, err := r.GetBSONFieldNameFromSearchableValue(common.SearchableValue{Field: metric.Field})
+		if err != nil {
+			return nil, fmt.Errorf("unable to resolve metric field %s: %w", metric.Field, err)
+		}
+
+		group[aggregateKey("m", i)] = bson.M{"$" + string(metric.Operator): "$" + bsonFieldName}
+	}
+
+	pipe = append(pipe,
+		bson.M{"$group": group},
+		bson.M{"$sort": bson.D{{Key: "_id", Value: 1}}},
+		bson.M{"$limit": MaxAggregateGroups},
+	)
+
+	return pipe, nil
+}
+
+func (r *MongoDBRepository[T]) Aggregate(queryCtx context.Context, s common.Search, groupBy []string, metrics []common.AggregateMetric) ([]common.AggregateGroup, error) {
+	pipe, err := r.GetAggregatePipeline(queryCtx, s, groupBy, metrics)
+	if err != nil {
+		return nil, err
+	}
+
+	collection := r.mongoClient.Database(r.dbName).Collection(r.collectionName)
+
+	cursor, err := collection.Aggregate(queryCtx, pipe)
+	if err != nil {
+		slog.ErrorContext(queryCtx, "unable to open aggregate cursor", "err", err, "collection", r.collectionName)
+		return nil, err
+	}
+
+	defer cursor.Close(queryCtx)
+
+	var docs []aggregateDoc
+	if err := cursor.All(queryCtx, &docs); err != nil {
+		slog.ErrorContext(queryCtx, "unable to decode aggregate groups", "err", err, "collection", r.collectionName)
+		return nil, err
+	}
+
+	groups := make([]common.AggregateGroup, 0, len(docs))
+	for _, doc := range docs {
+		group := common.AggregateGroup{
+			Key:     make(map[string]interface{}, len(groupBy)),
+			Metrics: make(map[string]interface{}, len(metrics)),
+		}
+
+		for i, field := range groupBy {
+			group.Key[field] = normalizeFacetValue(doc.Key[aggregateKey("g", i)])
+		}
+
+		for i, metric := range metrics {
+			group.Metrics[metric.Name] = doc.Metrics[aggregateKey("m", i)]
+		}
+
+		groups = append(groups, group)
+	}
+
+	return groups, nil
+}
+
+func aggregateKey(prefix string, i int) string {
+	return fmt.Sprintf("%s%d", prefix, i)
+}
@@ -0,0 +1,80 @@
+package db_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	replay_entity "github.com/psavelis/team-pro/replay-api/pkg/domain/replay/entities"
+	db "github.com/psavelis/team-pro/replay-api/pkg/infra/db/mongodb"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+func TestMongoDBRepository_GetAggregatePipeline(t *testing.T) {
+	// no round-trip: the driver connects lazily
+	client, err := mongo.Connect(context.Background(), options.Client().ApplyURI("mongodb://127.0.0.1:37019/replay"))
+	if err != nil {
+		t.Fatalf("unable to create mongo client: %v", err)
+	}
+
+	defer client.Disconnect(context.Background())
+
+	r := db.NewReplayFileMetadataRepository(client, dbName, replay_entity.ReplayFile{}, "replay_file_metadata_aggregate_test")
+
+	tenantID := uuid.New()
+	clientID := uuid.New()
+
+	ctx := common.WithResourceOwner(context.Background(), common.ResourceOwner{TenantID: tenantID, ClientID: clientID})
+
+	s := common.NewSearchByValues(ctx, []common.SearchableValue{{Field: "GameID", Values: []interface{}{"cs2"}}}, common.SearchResultOptions{}, common.ClientApplicationAudienceIDKey)
+
+	metrics := []common.AggregateMetric{
+		{Name: "replays", Operator: common.CountAggregateOperator},
+		{Name: "size", Operator: common.AvgAggregateOperator, Field: "Size"},
+	}
+
+	t.Run("Groups The Matches By Field", func(t *testing.T) {
+		pipe, err := r.GetAggregatePipeline(ctx, s, []string{"NetworkID", "Header.Filestamp"}, metrics)
+		if !assert.NoError(t, err) || !assert.Len(t, pipe, 4) {
+			return
+		}
+
+		assert.Equal(t, bson.M{"$match": bson.M{
+			"game_id":                  bson.M{"$in": []interface{}{"cs2"}},
+			"resource_owner.tenant_id": tenantID,
+			"resource_owner.client_id": clientID,
+		}}, pipe[0])
+
+		assert.Equal(t, bson.M{"$group": bson.M{
+			"_id": bson.D{{Key: "g0", Value: "$network_id"}, {Key: "g1", Value: "$header.filestamp"}},
+			"m0":  bson.M{"$sum": 1},
+			"m1":  bson.M{"$avg": "$size"},
+		}}, pipe[1])
+
+		assert.Equal(t, bson.M{"$limit": db.MaxAggregateGroups}, pipe[3])
+	})
+
+	t.Run("Single Group Without Group By", func(t *testing.T) {
+		pipe, err := r.GetAggregatePipeline(ctx, s, nil, metrics[:1])
+		if assert.NoError(t, err) {
+			assert.Equal(t, bson.M{"$group": bson.M{"_id": nil, "m0": bson.M{"$sum": 1}}}, pipe[1])
+		}
+	})
+
+	t.Run("Fields Not Queryable", func(t *testing.T) {
+		_, err := r.GetAggregatePipeline(ctx, s, []string{"network_id"}, metrics)
+		assert.ErrorContains(t, err, "grouping by field 'network_id' is not permitted")
+
+		_, err = r.GetAggregatePipeline(ctx, s, nil, []common.AggregateMetric{{Name: "x", Operator: common.SumAggregateOperator, Field: "Unknown"}})
+		assert.ErrorContains(t, err, "metric 'x' on field 'Unknown' is not permitted")
+	})
+
+	t.Run("Tenancy Is Enforced", func(t *testing.T) {
+		_, err := r.GetAggregatePipeline(ctx, common.NewSearchByValues(ctx, nil, common.SearchResultOptions{}, common.TenantAudienceIDKey), nil, metrics)
+		assert.Error(t, err)
+	})
+}
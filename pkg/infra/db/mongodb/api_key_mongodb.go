@@ -0,0 +1,89 @@
+package db
+
+import (
+	"context"
+	"log/slog"
+	"reflect"
+
+	"go.mongodb.org/mongo-driver/mongo"
+
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	iam_entity "github.com/psavelis/team-pro/replay-api/pkg/domain/iam/entities"
+)
+
+type APIKeyRepository struct {
+	MongoDBRepository[iam_entity.APIKey]
+}
+
+func NewAPIKeyRepository(client *mongo.Client, dbName string, entityType iam_entity.APIKey, collectionName string) *APIKeyRepository {
+	repo := MongoDBRepository[iam_entity.APIKey]{
+		mongoClient:       client,
+		dbName:            dbName,
+		mappingCache:      make(map[string]CacheItem),
+		entityModel:       reflect.TypeOf(entityType),
+		bsonFieldMappings: make(map[string]string),
+		collectionName:    collectionName,
+		entityName:        reflect.TypeOf(entityType).Name(),
+		queryableFields:   make(map[string]bool),
+	}
+
+	repo.InitQueryableFields(map[string]bool{
+		"ID":            true,
+		"Name":          true,
+		"Permissions":   true,
+		"IssuedBy":      true,
+		"ExpiresAt":     true,
+		"RevokedAt":     true,
+		"ResourceOwner": true,
+		"CreatedAt":     true,
+		"UpdatedAt":     true,
+	}, map[string]string{
+		"ID":                     "_id",
+		"Name":                   "name",
+		"KeyHash":                "key_hash",
+		"Permissions":            "permissions",
+		"IssuedBy":               "issued_by",
+		"ExpiresAt":              "expires_at",
+		"LastUsedAt":             "last_used_at",
+		"RotatedAt":              "rotated_at",
+		"RevokedAt":              "revoked_at",
+		"ResourceOwner":          "resource_owner",
+		"CreatedAt":              "created_at",
+		"UpdatedAt":              "updated_at",
+		"ResourceOwner.TenantID": "resource_owner.tenant_id",
+		"ResourceOwner.UserID":   "resource_owner.user_id",
+		"ResourceOwner.GroupID":  "resource_owner.group_id",
+		"ResourceOwner.ClientID": "resource_owner.client_id",
+	})
+
+	return &APIKeyRepository{
+		repo,
+	}
+}
+
+func (r *APIKeyRepository) Search(ctx context.Context, s common.Search) ([]iam_entity.APIKey, error) {
+	cursor, err := r.Query(ctx, s)
+	if cursor != nil {
+		defer cursor.Close(ctx)
+	}
+
+	if err != nil {
+		slog.ErrorContext(ctx, "error querying api keys", "err", err)
+		return nil, err
+	}
+
+	keys := make([]iam_entity.APIKey, 0)
+	for cursor.Next(ctx) {
+		var key iam_entity.APIKey
+		err := cursor.Decode(&key)
+
+		if err != nil {
+			slog.ErrorContext(ctx, "error decoding api key", "err", err)
+			return nil, err
+		}
+
+		keys = append(keys, key)
+	}
+
+	return keys, nil
+}
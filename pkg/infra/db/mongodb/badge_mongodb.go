@@ -8,15 +8,15 @@ import (
 	"go.mongodb.org/mongo-driver/mongo"
 
 	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
-	replay_entity "github.com/psavelis/team-pro/replay-api/pkg/domain/replay/entities"
+	badge_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/badge/entities"
 )
 
 type BadgeRepository struct {
-	MongoDBRepository[replay_entity.Badge]
+	MongoDBRepository[badge_entities.Badge]
 }
 
-func NewBadgeRepository(client *mongo.Client, dbName string, entityType replay_entity.Badge, collectionName string) *BadgeRepository {
-	repo := MongoDBRepository[replay_entity.Badge]{
+func NewBadgeRepository(client *mongo.Client, dbName string, entityType badge_entities.Badge, collectionName string) *BadgeRepository {
+	repo := MongoDBRepository[badge_entities.Badge]{
 		mongoClient:       client,
 		dbName:            dbName,
 		mappingCache:      make(map[string]CacheItem),
@@ -30,30 +30,33 @@ func NewBadgeRepository(client *mongo.Client, dbName string, entityType replay_e
 	repo.InitQueryableFields(map[string]bool{
 		"ID":            true,
 		"GameID":        true,
-		"MatchID":       true,
-		"PlayerID":      true,
+		"Key":           true,
 		"Name":          true,
-		"Events":        true,
 		"Description":   true,
 		"ImageURL":      true,
+		"Trigger":       true,
+		"Rule":          true,
+		"Enabled":       true,
 		"ResourceOwner": true,
 		"CreatedAt":     true,
 		"UpdatedAt":     true,
 	}, map[string]string{
 		"ID":                     "_id",
 		"GameID":                 "game_id",
-		"MatchID":                "match_id",
-		"PlayerID":               "player_id",
+		"Key":                    "key",
 		"Name":                   "name",
-		"Events":                 "events",
 		"Description":            "description",
-		"ResourceOwner":          "resource_owner", // TODO: principalmente resource ownership, que é padronizado.
+		"ImageURL":               "image_url",
+		"Trigger":                "trigger",
+		"Rule":                   "rule",
+		"Enabled":                "enabled",
+		"ResourceOwner":          "resource_owner",
+		"CreatedAt":              "created_at",
+		"UpdatedAt":              "updated_at",
 		"ResourceOwner.TenantID": "resource_owner.tenant_id",
 		"ResourceOwner.UserID":   "resource_owner.user_id",
 		"ResourceOwner.GroupID":  "resource_owner.group_id",
 		"ResourceOwner.ClientID": "resource_owner.client_id",
-		"CreatedAt":              "create_at",
-		"UpdatedAt":              "updated_at",
 	})
 
 	return &BadgeRepository{
@@ -61,48 +64,29 @@ func NewBadgeRepository(client *mongo.Client, dbName string, entityType replay_e
 	}
 }
 
-func (r *BadgeRepository) Search(ctx context.Context, s common.Search) ([]replay_entity.Badge, error) {
+func (r *BadgeRepository) Search(ctx context.Context, s common.Search) ([]badge_entities.Badge, error) {
 	cursor, err := r.Query(ctx, s)
 	if cursor != nil {
 		defer cursor.Close(ctx)
 	}
 
 	if err != nil {
-		slog.ErrorContext(ctx, "error querying Badge entity", "err", err)
+		slog.ErrorContext(ctx, "error querying badges", "err", err)
 		return nil, err
 	}
 
-	Badges := make([]replay_entity.Badge, 0)
-
+	badges := make([]badge_entities.Badge, 0)
 	for cursor.Next(ctx) {
-		var p replay_entity.Badge
-		err := cursor.Decode(&p)
+		var badge badge_entities.Badge
+		err := cursor.Decode(&badge)
 
 		if err != nil {
-			slog.ErrorContext(ctx, "error decoding Badge entity", "err", err)
+			slog.ErrorContext(ctx, "error decoding badge", "err", err)
 			return nil, err
 		}
 
-		Badges = append(Badges, p)
-	}
-
-	return Badges, nil
-}
-
-func (r *BadgeRepository) CreateMany(createCtx context.Context, events []replay_entity.Badge) error {
-	collection := r.mongoClient.Database(r.dbName).Collection(r.collectionName)
-
-	toInsert := make([]interface{}, len(events))
-
-	for i := range events {
-		toInsert[i] = events[i]
-	}
-
-	_, err := collection.InsertMany(createCtx, toInsert)
-	if err != nil {
-		slog.ErrorContext(createCtx, err.Error())
-		return err
+		badges = append(badges, badge)
 	}
 
-	return nil
+	return badges, nil
 }
@@ -0,0 +1,92 @@
+package db
+
+import (
+	"context"
+	"log/slog"
+	"reflect"
+
+	"go.mongodb.org/mongo-driver/mongo"
+
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	consent_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/consent/entities"
+)
+
+type ConsentRecordRepository struct {
+	MongoDBRepository[consent_entities.ConsentRecord]
+}
+
+func NewConsentRecordRepository(client *mongo.Client, dbName string, entityType consent_entities.ConsentRecord, collectionName string) *ConsentRecordRepository {
+	repo := MongoDBRepository[consent_entities.ConsentRecord]{
+		mongoClient:       client,
+		dbName:            dbName,
+		mappingCache:      make(map[string]CacheItem),
+		entityModel:       reflect.TypeOf(entityType),
+		bsonFieldMappings: make(map[string]string),
+		collectionName:    collectionName,
+		entityName:        reflect.TypeOf(entityType).Name(),
+		queryableFields:   make(map[string]bool),
+	}
+
+	repo.InitQueryableFields(map[string]bool{
+		"ID":            true,
+		"UserID":        true,
+		"PolicyID":      true,
+		"Kind":          true,
+		"Version":       true,
+		"ContentHash":   true,
+		"AcceptedAt":    true,
+		"IPAddress":     true,
+		"UserAgent":     true,
+		"ResourceOwner": true,
+		"CreatedAt":     true,
+		"UpdatedAt":     true,
+	}, map[string]string{
+		"ID":                     "_id",
+		"UserID":                 "user_id",
+		"PolicyID":               "policy_id",
+		"Kind":                   "kind",
+		"Version":                "version",
+		"ContentHash":            "content_hash",
+		"AcceptedAt":             "accepted_at",
+		"IPAddress":              "ip_address",
+		"UserAgent":              "user_agent",
+		"ResourceOwner":          "resource_owner",
+		"CreatedAt":              "created_at",
+		"UpdatedAt":              "updated_at",
+		"ResourceOwner.TenantID": "resource_owner.tenant_id",
+		"ResourceOwner.UserID":   "resource_owner.user_id",
+		"ResourceOwner.GroupID":  "resource_owner.group_id",
+		"ResourceOwner.ClientID": "resource_owner.client_id",
+	})
+
+	return &ConsentRecordRepository{
+		repo,
+	}
+}
+
+func (r *ConsentRecordRepository) Search(ctx context.Context, s common.Search) ([]consent_entities.ConsentRecord, error) {
+	cursor, err := r.Query(ctx, s)
+	if cursor != nil {
+		defer cursor.Close(ctx)
+	}
+
+	if err != nil {
+		slog.ErrorContext(ctx, "error querying consent records", "err", err)
+		return nil, err
+	}
+
+	records := make([]consent_entities.ConsentRecord, 0)
+	for cursor.Next(ctx) {
+		var record consent_entities.ConsentRecord
+		err := cursor.Decode(&record)
+
+		if err != nil {
+			slog.ErrorContext(ctx, "error decoding consent record", "err", err)
+			return nil, err
+		}
+
+		records = append(records, record)
+	}
+
+	return records, nil
+}
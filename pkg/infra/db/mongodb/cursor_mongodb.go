@@ -0,0 +1,144 @@
+package db
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"strings"
+
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/bsontype"
+)
+
+// searchCursor is the position of the last result of a page: its values of every sort key (the entity ID last). Encoded as base64 bson,
+// opaque to clients.
+type searchCursor struct {
+	Sort   []string        `bson:"s"` // field:direction of each sort key, a cursor only resumes the search it was built for
+	Values []bson.RawValue `bson:"v"`
+}
+
+// sortFields returns the $sort keys of the search: its sort options (resolved to bson names when mapped) and, when paging by cursor,
+// _id to break ties so every document has a single position.
+func (r *MongoDBRepository[T]) sortFields(s common.Search) bson.D {
+	fields := bson.D{}
+	hasID := false
+
+	for _, sortOption := range s.SortOptions {
+		field := sortOption.Field
+		if bsonFieldName, ok := r.bsonFieldMappings[field]; ok {
+			field = bsonFieldName
+		} else if bsonFieldName, ok := r.customFieldBSONName(field); ok {
+			field = bsonFieldName
+		}
+
+		hasID = hasID || field == "_id"
+		fields = append(fields, bson.E{Key: field, Value: sortOption.Direction})
+	}
+
+	if s.ResultOptions.Cursor != nil && !hasID {
+		fields = append(fields, bson.E{Key: "_id", Value: common.AscendingIDKey})
+	}
+
+	return fields
+}
+
+func sortSignature(fields bson.D) []string {
+	signature := make([]string, 0, len(fields))
+	for _, f := range fields {
+		signature = append(signature, fmt.Sprintf("%s:%v", f.Key, f.Value))
+	}
+
+	return signature
+}
+
+func encodeSearchCursor(c searchCursor) (string, error) {
+	b, err := bson.Marshal(c)
+	if err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+func decodeSearchCursor(token string) (*searchCursor, error) {
+	b, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, fmt.Errorf("malformed cursor")
+	}
+
+	var c searchCursor
+	err = bson.Unmarshal(b, &c)
+	if err != nil || len(c.Sort) == 0 || len(c.Sort) != len(c.Values) {
+		return nil, fmt.Errorf("malformed cursor")
+	}
+
+	return &c, nil
+}
+
+// addCursor filters out the documents up to the cursor position (keyset): the ones sorted after it have a greater (or lower, when
+// descending) value in a sort key, and the same values in the keys before it.
+func (r *MongoDBRepository[T]) addCursor(pipe []bson.M, s common.Search) ([]bson.M, error) {
+	if s.ResultOptions.Cursor == nil || *s.ResultOptions.Cursor == "" {
+		return pipe, nil
+	}
+
+	c, err := decodeSearchCursor(*s.ResultOptions.Cursor)
+	if err != nil {
+		return pipe, err
+	}
+
+	fields := r.sortFields(s)
+	if strings.Join(sortSignature(fields), ",") != strings.Join(c.Sort, ",") {
+		return pipe, fmt.Errorf("cursor doesn't match the sort of the search")
+	}
+
+	after := bson.A{}
+	for i, f := range fields {
+		filter := bson.M{}
+		for j := 0; j < i; j++ {
+			filter[fields[j].Key] = c.Values[j]
+		}
+
+		op := "$gt"
+		if f.Value == common.DescendingIDKey {
+			op = "$lt"
+		}
+
+		filter[f.Key] = bson.M{op: c.Values[i]}
+		after = append(after, filter)
+	}
+
+	if len(after) == 1 {
+		return append(pipe, bson.M{"$match": after[0]}), nil
+	}
+
+	return append(pipe, bson.M{"$match": bson.M{"$or": after}}), nil
+}
+
+// NextCursor reads the sort keys of last as stored (its bson encoding), so they compare to the documents of the collection. Sort keys
+// must be non-null and present in the results (not omitted).
+func (r *MongoDBRepository[T]) NextCursor(ctx context.Context, s common.Search, last interface{}) (string, error) {
+	raw, err := bson.MarshalWithRegistry(MongoRegistry, last)
+	if err != nil {
+		return "", fmt.Errorf("unable to encode the last result of %s: %w", r.entityName, err)
+	}
+
+	if s.ResultOptions.Cursor == nil {
+		s.ResultOptions.Cursor = new(string)
+	}
+
+	fields := r.sortFields(s)
+
+	c := searchCursor{Sort: sortSignature(fields), Values: make([]bson.RawValue, 0, len(fields))}
+	for _, f := range fields {
+		v, err := bson.Raw(raw).LookupErr(strings.Split(f.Key, ".")...)
+		if err != nil || v.Type == bsontype.Null {
+			return "", fmt.Errorf("sort key %s missing in the last result of %s", f.Key, r.entityName)
+		}
+
+		c.Values = append(c.Values, v)
+	}
+
+	return encodeSearchCursor(c)
+}
@@ -0,0 +1,101 @@
+package db_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	replay_entity "github.com/psavelis/team-pro/replay-api/pkg/domain/replay/entities"
+	db "github.com/psavelis/team-pro/replay-api/pkg/infra/db/mongodb"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+func TestMongoDBRepository_CursorPagination(t *testing.T) {
+	// no round-trip: the driver connects lazily
+	client, err := mongo.Connect(context.Background(), options.Client().ApplyURI("mongodb://127.0.0.1:37019/replay"))
+	if err != nil {
+		t.Fatalf("unable to create mongo client: %v", err)
+	}
+
+	defer client.Disconnect(context.Background())
+
+	r := db.NewReplayFileMetadataRepository(client, dbName, replay_entity.ReplayFile{}, "replay_file_metadata_cursor_test")
+
+	ctx := common.WithResourceOwner(context.Background(), common.ResourceOwner{TenantID: uuid.New(), ClientID: uuid.New()})
+
+	newSearch := func(cursor string, sort ...common.SearchSortOption) common.Search {
+		s := common.NewSearchByValues(ctx, []common.SearchableValue{{Field: "GameID", Values: []interface{}{"cs2"}}}, common.SearchResultOptions{Limit: 10, Cursor: &cursor}, common.ClientApplicationAudienceIDKey)
+		s.SortOptions = sort
+		return s
+	}
+
+	last := replay_entity.ReplayFile{ID: uuid.New(), CreatedAt: time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC), GameID: common.CS2_GAME_ID}
+	byCreatedAt := common.SearchSortOption{Field: "CreatedAt", Direction: common.DescendingIDKey}
+
+	t.Run("First Page Sorts By ID", func(t *testing.T) {
+		pipe, err := r.GetPipeline(ctx, newSearch("", byCreatedAt))
+		assert.NoError(t, err)
+
+		assert.Contains(t, pipe, bson.M{"$sort": bson.D{{Key: "created_at", Value: common.DescendingIDKey}, {Key: "_id", Value: common.AscendingIDKey}}})
+		assert.Len(t, pipe, 4, "match, sort, skip and limit")
+	})
+
+	t.Run("Next Page Resumes After The Last Result", func(t *testing.T) {
+		cursor, err := r.NextCursor(ctx, newSearch("", byCreatedAt), last)
+		if !assert.NoError(t, err) || !assert.NotEmpty(t, cursor) {
+			return
+		}
+
+		pipe, err := r.GetPipeline(ctx, newSearch(cursor, byCreatedAt))
+		if !assert.NoError(t, err) {
+			return
+		}
+
+		// the keyset $match follows the filters
+		_, filter, err := bson.MarshalValueWithRegistry(db.MongoRegistry, pipe[1]["$match"])
+		if !assert.NoError(t, err) {
+			return
+		}
+
+		var keyset struct {
+			Or []bson.M `bson:"$or"`
+		}
+
+		assert.NoError(t, bson.UnmarshalWithRegistry(db.MongoRegistry, filter, &keyset))
+		if assert.Len(t, keyset.Or, 2) {
+			createdAt := bson.M{"$lt": primitive.NewDateTimeFromTime(last.CreatedAt)}
+			assert.Equal(t, bson.M{"created_at": createdAt}, keyset.Or[0])
+			assert.Equal(t, primitive.NewDateTimeFromTime(last.CreatedAt), keyset.Or[1]["created_at"])
+			assert.Contains(t, keyset.Or[1], "_id")
+		}
+	})
+
+	t.Run("Cursor Of Another Sort Is Rejected", func(t *testing.T) {
+		cursor, err := r.NextCursor(ctx, newSearch(""), last)
+		assert.NoError(t, err)
+
+		_, err = r.GetPipeline(ctx, newSearch(cursor, byCreatedAt))
+		assert.ErrorContains(t, err, "doesn't match the sort")
+	})
+
+	t.Run("Invalid Options", func(t *testing.T) {
+		cursor := "not-a-cursor"
+		_, err := r.Compile(ctx, nil, common.SearchResultOptions{Limit: 10, Cursor: &cursor})
+		assert.ErrorContains(t, err, "malformed cursor")
+
+		first := ""
+		_, err = r.Compile(ctx, nil, common.SearchResultOptions{Skip: 10, Limit: 10, Cursor: &first})
+		assert.ErrorContains(t, err, "skip can't be combined")
+	})
+
+	t.Run("Null Sort Key", func(t *testing.T) {
+		_, err := r.NextCursor(ctx, newSearch("", common.SearchSortOption{Field: "Header", Direction: common.AscendingIDKey}), last)
+		assert.ErrorContains(t, err, "sort key header missing")
+	})
+}
@@ -0,0 +1,102 @@
+package db
+
+import (
+	"context"
+	"log/slog"
+	"reflect"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	customfield_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/customfield/entities"
+)
+
+type CustomFieldDefinitionRepository struct {
+	MongoDBRepository[customfield_entities.CustomFieldDefinition]
+}
+
+func NewCustomFieldDefinitionRepository(client *mongo.Client, dbName string, entityType customfield_entities.CustomFieldDefinition, collectionName string) *CustomFieldDefinitionRepository {
+	repo := MongoDBRepository[customfield_entities.CustomFieldDefinition]{
+		mongoClient:       client,
+		dbName:            dbName,
+		mappingCache:      make(map[string]CacheItem),
+		entityModel:       reflect.TypeOf(entityType),
+		bsonFieldMappings: make(map[string]string),
+		collectionName:    collectionName,
+		entityName:        reflect.TypeOf(entityType).Name(),
+		queryableFields:   make(map[string]bool),
+		collection:        client.Database(dbName).Collection(collectionName),
+	}
+
+	repo.InitQueryableFields(map[string]bool{
+		"ID":            true,
+		"EntityType":    true,
+		"Key":           true,
+		"Label":         true,
+		"Type":          true,
+		"Required":      true,
+		"ResourceOwner": true,
+		"CreatedAt":     true,
+		"UpdatedAt":     true,
+	}, map[string]string{
+		"ID":                     "_id",
+		"EntityType":             "entity_type",
+		"Key":                    "key",
+		"Label":                  "label",
+		"Type":                   "type",
+		"Options":                "options",
+		"MaxLength":              "max_length",
+		"Required":               "required",
+		"ResourceOwner":          "resource_owner",
+		"CreatedAt":              "created_at",
+		"UpdatedAt":              "updated_at",
+		"ResourceOwner.TenantID": "resource_owner.tenant_id",
+		"ResourceOwner.UserID":   "resource_owner.user_id",
+		"ResourceOwner.GroupID":  "resource_owner.group_id",
+		"ResourceOwner.ClientID": "resource_owner.client_id",
+	})
+
+	return &CustomFieldDefinitionRepository{
+		repo,
+	}
+}
+
+func (r *CustomFieldDefinitionRepository) Search(ctx context.Context, s common.Search) ([]customfield_entities.CustomFieldDefinition, error) {
+	cursor, err := r.Query(ctx, s)
+	if cursor != nil {
+		defer cursor.Close(ctx)
+	}
+
+	if err != nil {
+		slog.ErrorContext(ctx, "error querying custom field definitions", "err", err)
+		return nil, err
+	}
+
+	definitions := make([]customfield_entities.CustomFieldDefinition, 0)
+	for cursor.Next(ctx) {
+		var d customfield_entities.CustomFieldDefinition
+		err := cursor.Decode(&d)
+
+		if err != nil {
+			slog.ErrorContext(ctx, "error decoding custom field definition", "err", err)
+			return nil, err
+		}
+
+		definitions = append(definitions, d)
+	}
+
+	return definitions, nil
+}
+
+// Save replaces the definition of the key (the ID is stable per key).
+func (r *CustomFieldDefinitionRepository) Save(ctx context.Context, definition *customfield_entities.CustomFieldDefinition) (*customfield_entities.CustomFieldDefinition, error) {
+	_, err := r.collection.ReplaceOne(ctx, bson.M{"_id": definition.ID}, definition, options.Replace().SetUpsert(true))
+	if err != nil {
+		slog.ErrorContext(ctx, "unable to save custom field definition", "err", err, "entityType", definition.EntityType, "key", definition.Key)
+		return nil, err
+	}
+
+	return definition, nil
+}
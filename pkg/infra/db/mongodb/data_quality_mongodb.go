@@ -0,0 +1,204 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"reflect"
+	"time"
+
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	quality_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/quality/entities"
+	quality_out "github.com/psavelis/team-pro/replay-api/pkg/domain/quality/ports/out"
+)
+
+// grace period for entities still being written by an in-flight replay processing
+const DataQualityGracePeriod = time.Hour
+
+type FindingRepository struct {
+	MongoDBRepository[quality_entities.Finding]
+}
+
+func NewFindingRepository(client *mongo.Client, dbName string, entityType quality_entities.Finding, collectionName string) *FindingRepository {
+	repo := MongoDBRepository[quality_entities.Finding]{
+		mongoClient:       client,
+		dbName:            dbName,
+		mappingCache:      make(map[string]CacheItem),
+		entityModel:       reflect.TypeOf(entityType),
+		bsonFieldMappings: make(map[string]string),
+		collectionName:    collectionName,
+		entityName:        reflect.TypeOf(entityType).Name(),
+		queryableFields:   make(map[string]bool),
+	}
+
+	repo.InitQueryableFields(map[string]bool{
+		"ID":            true,
+		"CheckName":     true,
+		"Severity":      true,
+		"ResourceType":  true,
+		"ResourceID":    true,
+		"Description":   true,
+		"ResourceOwner": true,
+		"CreatedAt":     true,
+		"UpdatedAt":     true,
+	}, map[string]string{
+		"ID":                     "_id",
+		"CheckName":              "check_name",
+		"Severity":               "severity",
+		"ResourceType":           "resource_type",
+		"ResourceID":             "resource_id",
+		"Description":            "description",
+		"ResourceOwner":          "resource_owner",
+		"CreatedAt":              "created_at",
+		"UpdatedAt":              "updated_at",
+		"ResourceOwner.TenantID": "resource_owner.tenant_id",
+		"ResourceOwner.UserID":   "resource_owner.user_id",
+		"ResourceOwner.GroupID":  "resource_owner.group_id",
+		"ResourceOwner.ClientID": "resource_owner.client_id",
+	})
+
+	return &FindingRepository{
+		repo,
+	}
+}
+
+func (r *FindingRepository) ReplaceByCheck(ctx context.Context, checkName string, findings []quality_entities.Finding) error {
+	tenantID := common.GetResourceOwner(ctx).TenantID
+
+	_, err := r.collection.DeleteMany(ctx, bson.M{"resource_owner.tenant_id": tenantID, "check_name": checkName})
+	if err != nil {
+		slog.ErrorContext(ctx, "unable to delete previous findings", "check", checkName, "err", err)
+		return err
+	}
+
+	if len(findings) == 0 {
+		return nil
+	}
+
+	toInsert := make([]interface{}, len(findings))
+	for i := range findings {
+		toInsert[i] = findings[i]
+	}
+
+	_, err = r.collection.InsertMany(ctx, toInsert)
+	if err != nil {
+		slog.ErrorContext(ctx, "unable to insert findings", "check", checkName, "err", err)
+		return err
+	}
+
+	return nil
+}
+
+// aggregationCheck runs an aggregation whose output documents (only `_id` is required) are the inconsistent entities.
+type aggregationCheck struct {
+	name         string
+	severity     quality_entities.FindingSeverity
+	resourceType common.ResourceType
+	description  string
+	collection   *mongo.Collection
+	pipeline     func(tenantID uuid.UUID) []bson.M
+}
+
+func (c *aggregationCheck) Name() string {
+	return c.name
+}
+
+func (c *aggregationCheck) Run(ctx context.Context) ([]quality_entities.Finding, error) {
+	reso := common.GetResourceOwner(ctx)
+
+	cursor, err := c.collection.Aggregate(ctx, c.pipeline(reso.TenantID))
+	if cursor != nil {
+		defer cursor.Close(ctx)
+	}
+
+	if err != nil {
+		slog.ErrorContext(ctx, "unable to run data quality check", "check", c.name, "err", err)
+		return nil, err
+	}
+
+	findings := make([]quality_entities.Finding, 0)
+
+	for cursor.Next(ctx) {
+		var doc struct {
+			ID uuid.UUID `bson:"_id"`
+		}
+
+		err := cursor.Decode(&doc)
+		if err != nil {
+			slog.ErrorContext(ctx, "error decoding data quality check result", "check", c.name, "err", err)
+			return nil, err
+		}
+
+		findings = append(findings, quality_entities.NewFinding(c.name, c.severity, c.resourceType, doc.ID, fmt.Sprintf(c.description, doc.ID), reso))
+	}
+
+	return findings, nil
+}
+
+// NewProfilesWithoutUserCheck detects profiles whose owner user no longer exists.
+func NewProfilesWithoutUserCheck(client *mongo.Client, dbName string) quality_out.DataQualityCheck {
+	return &aggregationCheck{
+		name:         "profiles_without_user",
+		severity:     quality_entities.FindingSeverityCritical,
+		resourceType: common.ResourceTypeProfile,
+		description:  "profile %s references a missing user",
+		collection:   client.Database(dbName).Collection("profiles"),
+		pipeline: func(tenantID uuid.UUID) []bson.M {
+			return []bson.M{
+				{"$match": bson.M{"resource_owner.tenant_id": tenantID}},
+				{"$lookup": bson.M{"from": "users", "localField": "resource_owner.user_id", "foreignField": "_id", "as": "user"}},
+				{"$match": bson.M{"user": bson.M{"$size": 0}}},
+				{"$project": bson.M{"_id": 1}},
+			}
+		},
+	}
+}
+
+// NewMatchesWithoutEventsCheck detects processed matches that have no game events.
+func NewMatchesWithoutEventsCheck(client *mongo.Client, dbName string) quality_out.DataQualityCheck {
+	return &aggregationCheck{
+		name:         "matches_without_events",
+		severity:     quality_entities.FindingSeverityWarning,
+		resourceType: common.ResourceTypeMatch,
+		description:  "match %s has no game events",
+		collection:   client.Database(dbName).Collection("match_metadata"),
+		pipeline: func(tenantID uuid.UUID) []bson.M {
+			return []bson.M{
+				{"$match": bson.M{"resource_owner.tenant_id": tenantID, "created_at": bson.M{"$lt": time.Now().Add(-DataQualityGracePeriod)}}},
+				{"$lookup": bson.M{
+					"from":     "game_events",
+					"let":      bson.M{"match_id": "$_id"},
+					"pipeline": bson.A{bson.M{"$match": bson.M{"$expr": bson.M{"$eq": bson.A{"$match_id", "$$match_id"}}}}, bson.M{"$limit": 1}},
+					"as":       "events",
+				}},
+				{"$match": bson.M{"events": bson.M{"$size": 0}}},
+				{"$project": bson.M{"_id": 1}},
+			}
+		},
+	}
+}
+
+// NewStuckReplayFilesCheck detects replay files that never left the Pending/Processing state.
+func NewStuckReplayFilesCheck(client *mongo.Client, dbName string) quality_out.DataQualityCheck {
+	return &aggregationCheck{
+		name:         "replay_files_stuck_processing",
+		severity:     quality_entities.FindingSeverityWarning,
+		resourceType: common.ResourceTypeReplayFile,
+		description:  "replay file %s is still pending or processing",
+		collection:   client.Database(dbName).Collection("replay_file_metadata"),
+		pipeline: func(tenantID uuid.UUID) []bson.M {
+			return []bson.M{
+				{"$match": bson.M{
+					"resource_owner.tenant_id": tenantID,
+					"status":                   bson.M{"$in": bson.A{"Pending", "Processing"}},
+					"updated_at":               bson.M{"$lt": time.Now().Add(-DataQualityGracePeriod)},
+				}},
+				{"$project": bson.M{"_id": 1}},
+			}
+		},
+	}
+}
@@ -0,0 +1,84 @@
+package db
+
+import (
+	"context"
+	"log/slog"
+	"reflect"
+
+	"go.mongodb.org/mongo-driver/mongo"
+
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	notification_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/notification/entities"
+)
+
+type DeviceTokenRepository struct {
+	MongoDBRepository[notification_entities.DeviceToken]
+}
+
+func NewDeviceTokenRepository(client *mongo.Client, dbName string, entityType notification_entities.DeviceToken, collectionName string) *DeviceTokenRepository {
+	repo := MongoDBRepository[notification_entities.DeviceToken]{
+		mongoClient:       client,
+		dbName:            dbName,
+		mappingCache:      make(map[string]CacheItem),
+		entityModel:       reflect.TypeOf(entityType),
+		bsonFieldMappings: make(map[string]string),
+		collectionName:    collectionName,
+		entityName:        reflect.TypeOf(entityType).Name(),
+		queryableFields:   make(map[string]bool),
+	}
+
+	repo.InitQueryableFields(map[string]bool{
+		"ID":            true,
+		"UserID":        true,
+		"Platform":      true,
+		"Token":         true,
+		"DisabledAt":    true,
+		"ResourceOwner": true,
+		"CreatedAt":     true,
+		"UpdatedAt":     true,
+	}, map[string]string{
+		"ID":                     "_id",
+		"UserID":                 "user_id",
+		"Platform":               "platform",
+		"Token":                  "token",
+		"DisabledAt":             "disabled_at",
+		"ResourceOwner":          "resource_owner",
+		"CreatedAt":              "created_at",
+		"UpdatedAt":              "updated_at",
+		"ResourceOwner.TenantID": "resource_owner.tenant_id",
+		"ResourceOwner.UserID":   "resource_owner.user_id",
+		"ResourceOwner.GroupID":  "resource_owner.group_id",
+		"ResourceOwner.ClientID": "resource_owner.client_id",
+	})
+
+	return &DeviceTokenRepository{
+		repo,
+	}
+}
+
+func (r *DeviceTokenRepository) Search(ctx context.Context, s common.Search) ([]notification_entities.DeviceToken, error) {
+	cursor, err := r.Query(ctx, s)
+	if cursor != nil {
+		defer cursor.Close(ctx)
+	}
+
+	if err != nil {
+		slog.ErrorContext(ctx, "error querying device tokens", "err", err)
+		return nil, err
+	}
+
+	tokens := make([]notification_entities.DeviceToken, 0)
+	for cursor.Next(ctx) {
+		var token notification_entities.DeviceToken
+		err := cursor.Decode(&token)
+
+		if err != nil {
+			slog.ErrorContext(ctx, "error decoding device token", "err", err)
+			return nil, err
+		}
+
+		tokens = append(tokens, token)
+	}
+
+	return tokens, nil
+}
@@ -0,0 +1,55 @@
+package db
+
+import (
+	"reflect"
+
+	discord_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/discord/entities"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type DiscordUserRepository struct {
+	MongoDBRepository[discord_entities.DiscordUser]
+}
+
+func NewDiscordUserMongoDBRepository(client *mongo.Client, dbName string, entityType discord_entities.DiscordUser, collectionName string) *DiscordUserRepository {
+	repo := MongoDBRepository[discord_entities.DiscordUser]{
+		mongoClient:       client,
+		dbName:            dbName,
+		mappingCache:      make(map[string]CacheItem),
+		entityModel:       reflect.TypeOf(entityType),
+		bsonFieldMappings: make(map[string]string),
+		collectionName:    collectionName,
+		entityName:        reflect.TypeOf(entityType).Name(),
+		queryableFields:   make(map[string]bool),
+	}
+
+	repo.InitQueryableFields(map[string]bool{
+		"ID":            true,
+		"VHash":         true,
+		"DiscordID":     true,
+		"Username":      true,
+		"GlobalName":    true,
+		"Email":         true,
+		"Verified":      true,
+		"ResourceOwner": true,
+		"LinkedAt":      true,
+		"CreatedAt":     true,
+		"UpdatedAt":     true,
+	}, map[string]string{
+		"ID":            "_id",
+		"VHash":         "v_hash",
+		"DiscordID":     "discord_id",
+		"Username":      "username",
+		"GlobalName":    "global_name",
+		"Email":         "email",
+		"Verified":      "verified",
+		"ResourceOwner": "resource_owner",
+		"LinkedAt":      "linked_at",
+		"CreatedAt":     "created_at",
+		"UpdatedAt":     "updated_at",
+	})
+
+	return &DiscordUserRepository{
+		repo,
+	}
+}
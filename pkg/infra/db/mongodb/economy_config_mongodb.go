@@ -0,0 +1,94 @@
+package db
+
+import (
+	"context"
+	"log/slog"
+	"reflect"
+
+	"go.mongodb.org/mongo-driver/mongo"
+
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	matchmaking_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/matchmaking/entities"
+)
+
+type EconomyConfigRepository struct {
+	MongoDBRepository[matchmaking_entities.EconomyConfig]
+}
+
+func NewEconomyConfigRepository(client *mongo.Client, dbName string, entityType matchmaking_entities.EconomyConfig, collectionName string) *EconomyConfigRepository {
+	repo := MongoDBRepository[matchmaking_entities.EconomyConfig]{
+		mongoClient:       client,
+		dbName:            dbName,
+		mappingCache:      make(map[string]CacheItem),
+		entityModel:       reflect.TypeOf(entityType),
+		bsonFieldMappings: make(map[string]string),
+		collectionName:    collectionName,
+		entityName:        reflect.TypeOf(entityType).Name(),
+		queryableFields:   make(map[string]bool),
+	}
+
+	repo.InitQueryableFields(map[string]bool{
+		"ID":                     true,
+		"GameID":                 true,
+		"Mode":                   true,
+		"Version":                true,
+		"EffectiveFrom":          true,
+		"Currency":               true,
+		"EntryFee":               true,
+		"PlatformContribution":   true,
+		"RakeBasisPoints":        true,
+		"WinnerSplitBasisPoints": true,
+		"ResourceOwner":          true,
+		"CreatedAt":              true,
+		"UpdatedAt":              true,
+	}, map[string]string{
+		"ID":                     "_id",
+		"GameID":                 "game_id",
+		"Mode":                   "mode",
+		"Version":                "version",
+		"EffectiveFrom":          "effective_from",
+		"Currency":               "currency",
+		"EntryFee":               "entry_fee",
+		"PlatformContribution":   "platform_contribution",
+		"RakeBasisPoints":        "rake_basis_points",
+		"WinnerSplitBasisPoints": "winner_split_basis_points",
+		"ResourceOwner":          "resource_owner",
+		"CreatedAt":              "created_at",
+		"UpdatedAt":              "updated_at",
+		"ResourceOwner.TenantID": "resource_owner.tenant_id",
+		"ResourceOwner.UserID":   "resource_owner.user_id",
+		"ResourceOwner.GroupID":  "resource_owner.group_id",
+		"ResourceOwner.ClientID": "resource_owner.client_id",
+	})
+
+	return &EconomyConfigRepository{
+		repo,
+	}
+}
+
+func (r *EconomyConfigRepository) Search(ctx context.Context, s common.Search) ([]matchmaking_entities.EconomyConfig, error) {
+	cursor, err := r.Query(ctx, s)
+	if cursor != nil {
+		defer cursor.Close(ctx)
+	}
+
+	if err != nil {
+		slog.ErrorContext(ctx, "error querying economy configs", "err", err)
+		return nil, err
+	}
+
+	configs := make([]matchmaking_entities.EconomyConfig, 0)
+	for cursor.Next(ctx) {
+		var config matchmaking_entities.EconomyConfig
+		err := cursor.Decode(&config)
+
+		if err != nil {
+			slog.ErrorContext(ctx, "error decoding economy config", "err", err)
+			return nil, err
+		}
+
+		configs = append(configs, config)
+	}
+
+	return configs, nil
+}
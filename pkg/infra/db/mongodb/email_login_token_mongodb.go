@@ -0,0 +1,123 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"reflect"
+	"time"
+
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	email_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/email/entities"
+)
+
+type EmailLoginTokenRepository struct {
+	MongoDBRepository[email_entities.EmailLoginToken]
+}
+
+func NewEmailLoginTokenRepository(client *mongo.Client, dbName string, entityType email_entities.EmailLoginToken, collectionName string) *EmailLoginTokenRepository {
+	repo := MongoDBRepository[email_entities.EmailLoginToken]{
+		mongoClient:       client,
+		dbName:            dbName,
+		mappingCache:      make(map[string]CacheItem),
+		entityModel:       reflect.TypeOf(entityType),
+		bsonFieldMappings: make(map[string]string),
+		collectionName:    collectionName,
+		entityName:        reflect.TypeOf(entityType).Name(),
+		queryableFields:   make(map[string]bool),
+	}
+
+	repo.InitQueryableFields(map[string]bool{
+		"ID":            true,
+		"Email":         true,
+		"TokenHash":     true,
+		"RequestIP":     true,
+		"ExpiresAt":     true,
+		"ConsumedAt":    true,
+		"ResourceOwner": true,
+		"CreatedAt":     true,
+		"UpdatedAt":     true,
+	}, map[string]string{
+		"ID":                     "_id",
+		"Email":                  "email",
+		"TokenHash":              "token_hash",
+		"RequestIP":              "request_ip",
+		"ExpiresAt":              "expires_at",
+		"ConsumedAt":             "consumed_at",
+		"ResourceOwner":          "resource_owner",
+		"CreatedAt":              "created_at",
+		"UpdatedAt":              "updated_at",
+		"ResourceOwner.TenantID": "resource_owner.tenant_id",
+		"ResourceOwner.UserID":   "resource_owner.user_id",
+		"ResourceOwner.GroupID":  "resource_owner.group_id",
+		"ResourceOwner.ClientID": "resource_owner.client_id",
+	})
+
+	return &EmailLoginTokenRepository{
+		repo,
+	}
+}
+
+func (r *EmailLoginTokenRepository) Search(ctx context.Context, s common.Search) ([]email_entities.EmailLoginToken, error) {
+	cursor, err := r.Query(ctx, s)
+	if cursor != nil {
+		defer cursor.Close(ctx)
+	}
+
+	if err != nil {
+		slog.ErrorContext(ctx, "error querying email login tokens", "err", err)
+		return nil, err
+	}
+
+	tokens := make([]email_entities.EmailLoginToken, 0)
+	for cursor.Next(ctx) {
+		var token email_entities.EmailLoginToken
+		err := cursor.Decode(&token)
+
+		if err != nil {
+			slog.ErrorContext(ctx, "error decoding email login token", "err", err)
+			return nil, err
+		}
+
+		tokens = append(tokens, token)
+	}
+
+	return tokens, nil
+}
+
+// Consume is a single conditional update: of concurrent verifications of a token, only one finds it unconsumed. It's scoped by the
+// client application, as are the searches of the tokens.
+func (r *EmailLoginTokenRepository) Consume(ctx context.Context, tokenHash string, now time.Time) (*email_entities.EmailLoginToken, error) {
+	scope, _ := common.GetRequestScope(ctx)
+	if scope.ClientID == uuid.Nil {
+		return nil, fmt.Errorf("TENANCY.ApplicationLevel: valid client_id is required to consume email login token")
+	}
+
+	filter := bson.M{
+		"token_hash":               tokenHash,
+		"consumed_at":              nil,
+		"expires_at":               bson.M{"$gt": now},
+		"resource_owner.client_id": scope.ClientID,
+	}
+
+	update := bson.M{"$set": bson.M{"consumed_at": now, "updated_at": now}}
+
+	var token email_entities.EmailLoginToken
+	err := r.collection.FindOneAndUpdate(ctx, filter, update, options.FindOneAndUpdate().SetReturnDocument(options.After)).Decode(&token)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, nil
+	}
+
+	if err != nil {
+		slog.ErrorContext(ctx, "error consuming email login token", "err", err)
+		return nil, err
+	}
+
+	return &token, nil
+}
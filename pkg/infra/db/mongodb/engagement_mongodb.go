@@ -0,0 +1,162 @@
+package db
+
+import (
+	"context"
+	"log/slog"
+	"reflect"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	analytics_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/analytics/entities"
+)
+
+type EngagementSnapshotRepository struct {
+	MongoDBRepository[analytics_entities.EngagementSnapshot]
+}
+
+func NewEngagementSnapshotRepository(client *mongo.Client, dbName string, entityType analytics_entities.EngagementSnapshot, collectionName string) *EngagementSnapshotRepository {
+	repo := MongoDBRepository[analytics_entities.EngagementSnapshot]{
+		mongoClient:       client,
+		dbName:            dbName,
+		mappingCache:      make(map[string]CacheItem),
+		entityModel:       reflect.TypeOf(entityType),
+		bsonFieldMappings: make(map[string]string),
+		collectionName:    collectionName,
+		entityName:        reflect.TypeOf(entityType).Name(),
+		queryableFields:   make(map[string]bool),
+	}
+
+	repo.InitQueryableFields(map[string]bool{
+		"ID":                true,
+		"Day":               true,
+		"DailyActiveUsers":  true,
+		"WeeklyActiveUsers": true,
+		"UploadRetention":   true,
+		"ResourceOwner":     true,
+		"CreatedAt":         true,
+		"UpdatedAt":         true,
+	}, map[string]string{
+		"ID":                     "_id",
+		"Day":                    "day",
+		"DailyActiveUsers":       "dau",
+		"WeeklyActiveUsers":      "wau",
+		"UploadRetention":        "upload_retention",
+		"ResourceOwner":          "resource_owner",
+		"CreatedAt":              "created_at",
+		"UpdatedAt":              "updated_at",
+		"ResourceOwner.TenantID": "resource_owner.tenant_id",
+		"ResourceOwner.UserID":   "resource_owner.user_id",
+		"ResourceOwner.GroupID":  "resource_owner.group_id",
+		"ResourceOwner.ClientID": "resource_owner.client_id",
+	})
+
+	return &EngagementSnapshotRepository{
+		repo,
+	}
+}
+
+func (r *EngagementSnapshotRepository) Save(ctx context.Context, snapshot *analytics_entities.EngagementSnapshot) (*analytics_entities.EngagementSnapshot, error) {
+	filter := bson.M{
+		"resource_owner.tenant_id": snapshot.ResourceOwner.TenantID,
+		"day":                      snapshot.Day,
+	}
+
+	update := bson.M{
+		"$set": bson.M{
+			"dau":              snapshot.DailyActiveUsers,
+			"wau":              snapshot.WeeklyActiveUsers,
+			"upload_retention": snapshot.UploadRetention,
+			"resource_owner":   snapshot.ResourceOwner,
+			"updated_at":       snapshot.UpdatedAt,
+		},
+		"$setOnInsert": bson.M{
+			"_id":        snapshot.ID,
+			"created_at": snapshot.CreatedAt,
+		},
+	}
+
+	opts := options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After)
+
+	var saved analytics_entities.EngagementSnapshot
+	err := r.collection.FindOneAndUpdate(ctx, filter, update, opts).Decode(&saved)
+	if err != nil {
+		slog.ErrorContext(ctx, "unable to save engagement snapshot", "err", err, "day", snapshot.Day)
+		return nil, err
+	}
+
+	return &saved, nil
+}
+
+// UploadActivityRepository aggregates user activity straight from the replay file metadata collection.
+type UploadActivityRepository struct {
+	collection *mongo.Collection
+}
+
+func NewUploadActivityRepository(client *mongo.Client, dbName string, replayFileCollectionName string) *UploadActivityRepository {
+	return &UploadActivityRepository{
+		collection: client.Database(dbName).Collection(replayFileCollectionName),
+	}
+}
+
+func (r *UploadActivityRepository) GetUploadActivity(ctx context.Context, since time.Time) ([]analytics_entities.UserUploadActivity, error) {
+	tenantID := common.GetResourceOwner(ctx).TenantID
+
+	uploadDay := bson.M{
+		"$dateFromParts": bson.M{
+			"year":  bson.M{"$year": "$created_at"},
+			"month": bson.M{"$month": "$created_at"},
+			"day":   bson.M{"$dayOfMonth": "$created_at"},
+		},
+	}
+
+	pipe := []bson.M{
+		{"$match": bson.M{"resource_owner.tenant_id": tenantID}},
+		{"$group": bson.M{
+			"_id":          "$resource_owner.user_id",
+			"first_upload": bson.M{"$min": "$created_at"},
+			"last_upload":  bson.M{"$max": "$created_at"},
+			"upload_days":  bson.M{"$addToSet": uploadDay},
+		}},
+		{"$match": bson.M{"last_upload": bson.M{"$gte": since}}},
+		{"$project": bson.M{
+			"first_upload": 1,
+			"upload_days": bson.M{
+				"$filter": bson.M{
+					"input": "$upload_days",
+					"as":    "d",
+					"cond":  bson.M{"$gte": bson.A{"$$d", since}},
+				},
+			},
+		}},
+	}
+
+	cursor, err := r.collection.Aggregate(ctx, pipe)
+	if cursor != nil {
+		defer cursor.Close(ctx)
+	}
+
+	if err != nil {
+		slog.ErrorContext(ctx, "unable to aggregate upload activity", "err", err, "since", since)
+		return nil, err
+	}
+
+	activity := make([]analytics_entities.UserUploadActivity, 0)
+
+	for cursor.Next(ctx) {
+		var a analytics_entities.UserUploadActivity
+		err := cursor.Decode(&a)
+
+		if err != nil {
+			slog.ErrorContext(ctx, "error decoding upload activity", "err", err)
+			return nil, err
+		}
+
+		activity = append(activity, a)
+	}
+
+	return activity, nil
+}
@@ -0,0 +1,186 @@
+package db
+
+import (
+	"context"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+)
+
+// EntityMetadataRegistry keeps the search metadata of every repository initialized through InitQueryableFields. Fields are only listed
+// as queryable when every query service registered for the entity accepts them too (the repositories do not know the DENY fields).
+type EntityMetadataRegistry struct {
+	mu       sync.RWMutex
+	entities map[string]common.EntityMetadata
+	services map[string][]map[string]bool
+}
+
+var DefaultEntityMetadataRegistry = NewEntityMetadataRegistry()
+
+func NewEntityMetadataRegistry() *EntityMetadataRegistry {
+	return &EntityMetadataRegistry{
+		entities: make(map[string]common.EntityMetadata),
+		services: make(map[string][]map[string]bool),
+	}
+}
+
+func (reg *EntityMetadataRegistry) Register(metadata common.EntityMetadata) {
+	reg.mu.Lock()
+	defer reg.mu.Unlock()
+
+	reg.entities[metadata.Collection] = metadata
+}
+
+func (reg *EntityMetadataRegistry) RegisterQueryService(service common.QueryableEntity) {
+	reg.mu.Lock()
+	defer reg.mu.Unlock()
+
+	name := service.GetEntityName()
+	reg.services[name] = append(reg.services[name], service.GetQueryableFields())
+}
+
+func (reg *EntityMetadataRegistry) ListEntityMetadata(ctx context.Context) []common.EntityMetadata {
+	reg.mu.RLock()
+	defer reg.mu.RUnlock()
+
+	list := make([]common.EntityMetadata, 0, len(reg.entities))
+	for _, m := range reg.entities {
+		list = append(list, reg.restrictQueryable(m))
+	}
+
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].Name < list[j].Name
+	})
+
+	return list
+}
+
+// restrictQueryable returns a copy of the metadata where a field is only queryable when all the query services of the entity accept it.
+func (reg *EntityMetadataRegistry) restrictQueryable(metadata common.EntityMetadata) common.EntityMetadata {
+	services := reg.services[metadata.Name]
+	if len(services) == 0 {
+		return metadata
+	}
+
+	fields := make([]common.FieldMetadata, len(metadata.Fields))
+	for i, field := range metadata.Fields {
+		for _, queryableFields := range services {
+			field.Queryable = field.Queryable && queryableFields[field.Name]
+		}
+
+		fields[i] = field
+	}
+
+	metadata.Fields = fields
+
+	return metadata
+}
+
+func (r *MongoDBRepository[T]) GetEntityMetadata() common.EntityMetadata {
+	model := r.entityModel
+	for model != nil && model.Kind() == reflect.Ptr {
+		model = model.Elem()
+	}
+
+	names := make(map[string]bool)
+	for name := range r.bsonFieldMappings {
+		names[name] = true
+	}
+
+	for name := range r.queryableFields {
+		names[name] = true
+	}
+
+	fields := make([]common.FieldMetadata, 0, len(names))
+	for name := range names {
+		bsonField, ok := r.bsonFieldMappings[name]
+		if !ok && r.entityModel.Kind() == reflect.Struct && !strings.HasSuffix(name, ".*") {
+			bsonField, _ = r.GetBSONFieldName(name)
+		}
+
+		fields = append(fields, common.FieldMetadata{
+			Name:      name,
+			BSONField: bsonField,
+			Kind:      fieldKind(model, name),
+			Queryable: r.queryableFields[name],
+		})
+	}
+
+	sort.Slice(fields, func(i, j int) bool {
+		return fields[i].Name < fields[j].Name
+	})
+
+	entityName := r.entityName
+	if model != nil {
+		entityName = model.Name()
+	}
+
+	return common.EntityMetadata{
+		Name:       entityName,
+		Collection: r.collectionName,
+		Fields:     fields,
+		Operators:  common.SearchOperators,
+	}
+}
+
+var (
+	timeType     = reflect.TypeOf(time.Time{})
+	durationType = reflect.TypeOf(time.Duration(0))
+	uuidType     = reflect.TypeOf(uuid.UUID{})
+)
+
+func fieldKind(model reflect.Type, fieldName string) string {
+	current := model
+
+	for _, part := range strings.Split(fieldName, ".") {
+		for current != nil && current.Kind() == reflect.Ptr {
+			current = current.Elem()
+		}
+
+		if current == nil || current.Kind() != reflect.Struct {
+			return "unknown"
+		}
+
+		field, ok := current.FieldByName(part)
+		if !ok {
+			return "unknown"
+		}
+
+		current = field.Type
+	}
+
+	for current.Kind() == reflect.Ptr {
+		current = current.Elem()
+	}
+
+	switch {
+	case current == timeType:
+		return "time"
+	case current == durationType:
+		return "duration"
+	case current == uuidType || current.ConvertibleTo(uuidType) && current.Kind() == reflect.Array:
+		return "uuid"
+	}
+
+	switch current.Kind() {
+	case reflect.String:
+		return "string"
+	case reflect.Bool:
+		return "bool"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return "number"
+	case reflect.Slice, reflect.Array:
+		return "array"
+	case reflect.Struct, reflect.Map, reflect.Interface:
+		return "object"
+	default:
+		return "unknown"
+	}
+}
@@ -0,0 +1,97 @@
+package db_test
+
+import (
+	"context"
+	"testing"
+
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	iam_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/iam/entities"
+	replay_entity "github.com/psavelis/team-pro/replay-api/pkg/domain/replay/entities"
+	db "github.com/psavelis/team-pro/replay-api/pkg/infra/db/mongodb"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+func TestMongoDBRepository_GetEntityMetadata(t *testing.T) {
+	// no round-trip: the driver connects lazily
+	client, err := mongo.Connect(context.Background(), options.Client().ApplyURI("mongodb://127.0.0.1:37019/replay"))
+	if err != nil {
+		t.Fatalf("unable to create mongo client: %v", err)
+	}
+
+	defer client.Disconnect(context.Background())
+
+	r := db.NewReplayFileMetadataRepository(client, dbName, replay_entity.ReplayFile{}, "replay_file_metadata_meta_test")
+
+	metadata := r.GetEntityMetadata()
+
+	assert.Equal(t, "ReplayFile", metadata.Name)
+	assert.Equal(t, "replay_file_metadata_meta_test", metadata.Collection)
+	assert.Equal(t, common.SearchOperators, metadata.Operators)
+
+	fields := make(map[string]common.FieldMetadata)
+	for _, f := range metadata.Fields {
+		fields[f.Name] = f
+	}
+
+	assert.Equal(t, common.FieldMetadata{Name: "ID", BSONField: "_id", Kind: "uuid", Queryable: true}, fields["ID"])
+	assert.Equal(t, common.FieldMetadata{Name: "Size", BSONField: "size", Kind: "number", Queryable: true}, fields["Size"])
+	assert.Equal(t, common.FieldMetadata{Name: "CreatedAt", BSONField: "created_at", Kind: "time", Queryable: true}, fields["CreatedAt"])
+	assert.Equal(t, common.FieldMetadata{Name: "Header", BSONField: "header", Kind: "object", Queryable: true}, fields["Header"])
+	assert.Equal(t, common.FieldMetadata{Name: "ResourceOwner.TenantID", BSONField: "resource_owner.tenant_id", Kind: "uuid", Queryable: false}, fields["ResourceOwner.TenantID"])
+
+	// pointer entity models (ie: &iam_entities.User{}) are described by their element type
+	u := db.NewUserRepository(client, dbName, &iam_entities.User{}, "users_meta_test")
+	assert.Equal(t, "User", u.GetEntityMetadata().Name)
+
+	registered := false
+	for _, m := range db.DefaultEntityMetadataRegistry.ListEntityMetadata(context.Background()) {
+		if m.Collection == "replay_file_metadata_meta_test" {
+			registered = true
+		}
+	}
+
+	assert.True(t, registered, "expected InitQueryableFields to register the entity metadata")
+}
+
+func TestEntityMetadataRegistry_RestrictsQueryableToQueryServices(t *testing.T) {
+	reg := db.NewEntityMetadataRegistry()
+
+	reg.Register(common.EntityMetadata{Name: "ReplayFile", Collection: "replay_file_metadata", Fields: []common.FieldMetadata{
+		{Name: "ID", BSONField: "_id", Kind: "uuid", Queryable: true},
+		{Name: "Size", BSONField: "size", Kind: "number", Queryable: true},
+		{Name: "ResourceOwner", BSONField: "resource_owner", Kind: "object", Queryable: true},
+		{Name: "InternalURI", BSONField: "internal_uri", Kind: "string", Queryable: false},
+	}})
+
+	reg.Register(common.EntityMetadata{Name: "Game", Collection: "games", Fields: []common.FieldMetadata{
+		{Name: "ID", BSONField: "_id", Kind: "uuid", Queryable: true},
+	}})
+
+	reg.RegisterQueryService(&common.BaseQueryService[replay_entity.ReplayFile]{QueryableFields: map[string]bool{
+		"ID":            true,
+		"Size":          true,
+		"ResourceOwner": common.DENY,
+		"InternalURI":   true,
+	}})
+
+	reg.RegisterQueryService(&common.BaseQueryService[*replay_entity.ReplayFile]{QueryableFields: map[string]bool{
+		"ID":            true,
+		"ResourceOwner": true,
+		"InternalURI":   true,
+	}})
+
+	queryable := make(map[string]map[string]bool)
+	for _, m := range reg.ListEntityMetadata(context.Background()) {
+		queryable[m.Name] = make(map[string]bool)
+		for _, f := range m.Fields {
+			queryable[m.Name][f.Name] = f.Queryable
+		}
+	}
+
+	assert.Equal(t, map[string]bool{"ID": true, "Size": false, "ResourceOwner": false, "InternalURI": false}, queryable["ReplayFile"])
+
+	// entities without query services keep the repository metadata
+	assert.Equal(t, map[string]bool{"ID": true}, queryable["Game"])
+}
@@ -29,29 +29,37 @@ func NewEventsRepository(client *mongo.Client, dbName string, entityType *replay
 	}
 
 	repo.InitQueryableFields(map[string]bool{
-		"ID":              true,
-		"GameID":          true,
-		"MatchID":         true,
-		"Type":            true,
-		"Time":            true,
-		"EventData":       true,
-		"PlayerStats":     true,
-		"NetworkPlayerID": true,
-		"PlayerName":      true,
-		"ResourceOwner":   true,
-		"CreatedAt":       true,
+		"ID":                true,
+		"GameID":            true,
+		"MatchID":           true,
+		"Type":              true,
+		"Time":              true,
+		"Taxonomy":          true,
+		"Taxonomy.Type":     true,
+		"Taxonomy.Category": true,
+		"Taxonomy.Version":  true,
+		"EventData":         true,
+		"PlayerStats":       true,
+		"NetworkPlayerID":   true,
+		"PlayerName":        true,
+		"ResourceOwner":     true,
+		"CreatedAt":         true,
 	}, map[string]string{
-		"ID":              "_id",
-		"GameID":          "game_id",
-		"MatchID":         "match_id",
-		"Type":            "type",
-		"Time":            "event_time",
-		"EventData":       "event_data",
-		"PlayerStats":     "player_stats",
-		"NetworkPlayerID": "network_player_id",
-		"PlayerName":      "player_name",
-		"ResourceOwner":   "resource_owner",
-		"CreatedAt":       "created_at",
+		"ID":                "_id",
+		"GameID":            "game_id",
+		"MatchID":           "match_id",
+		"Type":              "type",
+		"Time":              "event_time",
+		"Taxonomy":          "taxonomy",
+		"Taxonomy.Type":     "taxonomy.type",
+		"Taxonomy.Category": "taxonomy.category",
+		"Taxonomy.Version":  "taxonomy.version",
+		"EventData":         "event_data",
+		"PlayerStats":       "player_stats",
+		"NetworkPlayerID":   "network_player_id",
+		"PlayerName":        "player_name",
+		"ResourceOwner":     "resource_owner",
+		"CreatedAt":         "created_at",
 	})
 
 	return &EventsRepository{
@@ -0,0 +1,127 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"reflect"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	export_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/export/entities"
+)
+
+type ExportConfigRepository struct {
+	MongoDBRepository[export_entities.ExportConfig]
+}
+
+func NewExportConfigRepository(client *mongo.Client, dbName string, entityType export_entities.ExportConfig, collectionName string) *ExportConfigRepository {
+	repo := MongoDBRepository[export_entities.ExportConfig]{
+		mongoClient:       client,
+		dbName:            dbName,
+		mappingCache:      make(map[string]CacheItem),
+		entityModel:       reflect.TypeOf(entityType),
+		bsonFieldMappings: make(map[string]string),
+		collectionName:    collectionName,
+		entityName:        reflect.TypeOf(entityType).Name(),
+		queryableFields:   make(map[string]bool),
+	}
+
+	repo.InitQueryableFields(map[string]bool{
+		"ID":            true,
+		"Name":          true,
+		"Datasets":      true,
+		"Format":        true,
+		"HourUTC":       true,
+		"Enabled":       true,
+		"NextRunAt":     true,
+		"LastRunAt":     true,
+		"ResourceOwner": true,
+		"CreatedAt":     true,
+		"UpdatedAt":     true,
+	}, map[string]string{
+		"ID":                     "_id",
+		"Name":                   "name",
+		"Datasets":               "datasets",
+		"Format":                 "format",
+		"Destination":            "destination",
+		"HourUTC":                "hour_utc",
+		"Enabled":                "enabled",
+		"NextRunAt":              "next_run_at",
+		"LastRunAt":              "last_run_at",
+		"ExportedUntil":          "exported_until",
+		"ClaimedUntil":           "claimed_until",
+		"ResourceOwner":          "resource_owner",
+		"CreatedAt":              "created_at",
+		"UpdatedAt":              "updated_at",
+		"ResourceOwner.TenantID": "resource_owner.tenant_id",
+		"ResourceOwner.UserID":   "resource_owner.user_id",
+		"ResourceOwner.GroupID":  "resource_owner.group_id",
+		"ResourceOwner.ClientID": "resource_owner.client_id",
+	})
+
+	return &ExportConfigRepository{
+		repo,
+	}
+}
+
+func (r *ExportConfigRepository) Search(ctx context.Context, s common.Search) ([]export_entities.ExportConfig, error) {
+	cursor, err := r.Query(ctx, s)
+	if cursor != nil {
+		defer cursor.Close(ctx)
+	}
+
+	if err != nil {
+		slog.ErrorContext(ctx, "error querying export configs", "err", err)
+		return nil, err
+	}
+
+	configs := make([]export_entities.ExportConfig, 0)
+	for cursor.Next(ctx) {
+		var config export_entities.ExportConfig
+		err := cursor.Decode(&config)
+
+		if err != nil {
+			slog.ErrorContext(ctx, "error decoding export config", "err", err)
+			return nil, err
+		}
+
+		configs = append(configs, config)
+	}
+
+	return configs, nil
+}
+
+// ClaimDue isn't scoped by tenant: the scheduler runs the exports of every tenant (each on behalf of its owner). The most overdue one is
+// claimed first.
+func (r *ExportConfigRepository) ClaimDue(ctx context.Context, now time.Time, lease time.Duration) (*export_entities.ExportConfig, error) {
+	filter := bson.M{
+		"enabled":     true,
+		"next_run_at": bson.M{"$lte": now},
+		"$or": []bson.M{
+			{"claimed_until": nil},
+			{"claimed_until": bson.M{"$lte": now}},
+		},
+	}
+
+	update := bson.M{"$set": bson.M{"claimed_until": now.Add(lease)}}
+
+	opts := options.FindOneAndUpdate().SetSort(bson.D{{Key: "next_run_at", Value: 1}}).SetReturnDocument(options.After)
+
+	var config export_entities.ExportConfig
+	err := r.collection.FindOneAndUpdate(ctx, filter, update, opts).Decode(&config)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, nil
+	}
+
+	if err != nil {
+		slog.ErrorContext(ctx, "error claiming due export config", "err", err)
+		return nil, err
+	}
+
+	return &config, nil
+}
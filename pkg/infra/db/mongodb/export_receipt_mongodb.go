@@ -0,0 +1,101 @@
+package db
+
+import (
+	"context"
+	"log/slog"
+	"reflect"
+
+	"go.mongodb.org/mongo-driver/mongo"
+
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	export_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/export/entities"
+)
+
+type ExportReceiptRepository struct {
+	MongoDBRepository[export_entities.ExportReceipt]
+}
+
+func NewExportReceiptRepository(client *mongo.Client, dbName string, entityType export_entities.ExportReceipt, collectionName string) *ExportReceiptRepository {
+	repo := MongoDBRepository[export_entities.ExportReceipt]{
+		mongoClient:       client,
+		dbName:            dbName,
+		mappingCache:      make(map[string]CacheItem),
+		entityModel:       reflect.TypeOf(entityType),
+		bsonFieldMappings: make(map[string]string),
+		collectionName:    collectionName,
+		entityName:        reflect.TypeOf(entityType).Name(),
+		queryableFields:   make(map[string]bool),
+	}
+
+	repo.InitQueryableFields(map[string]bool{
+		"ID":             true,
+		"ExportConfigID": true,
+		"RunID":          true,
+		"Dataset":        true,
+		"Format":         true,
+		"Status":         true,
+		"Bucket":         true,
+		"Key":            true,
+		"Records":        true,
+		"Bytes":          true,
+		"SHA256":         true,
+		"WindowFrom":     true,
+		"WindowTo":       true,
+		"ResourceOwner":  true,
+		"CreatedAt":      true,
+		"UpdatedAt":      true,
+	}, map[string]string{
+		"ID":                     "_id",
+		"ExportConfigID":         "export_config_id",
+		"RunID":                  "run_id",
+		"Dataset":                "dataset",
+		"Format":                 "format",
+		"Status":                 "status",
+		"Bucket":                 "bucket",
+		"Key":                    "key",
+		"Records":                "records",
+		"Bytes":                  "bytes",
+		"SHA256":                 "sha256",
+		"WindowFrom":             "window_from",
+		"WindowTo":               "window_to",
+		"Error":                  "error",
+		"ResourceOwner":          "resource_owner",
+		"CreatedAt":              "created_at",
+		"UpdatedAt":              "updated_at",
+		"ResourceOwner.TenantID": "resource_owner.tenant_id",
+		"ResourceOwner.UserID":   "resource_owner.user_id",
+		"ResourceOwner.GroupID":  "resource_owner.group_id",
+		"ResourceOwner.ClientID": "resource_owner.client_id",
+	})
+
+	return &ExportReceiptRepository{
+		repo,
+	}
+}
+
+func (r *ExportReceiptRepository) Search(ctx context.Context, s common.Search) ([]export_entities.ExportReceipt, error) {
+	cursor, err := r.Query(ctx, s)
+	if cursor != nil {
+		defer cursor.Close(ctx)
+	}
+
+	if err != nil {
+		slog.ErrorContext(ctx, "error querying export receipts", "err", err)
+		return nil, err
+	}
+
+	receipts := make([]export_entities.ExportReceipt, 0)
+	for cursor.Next(ctx) {
+		var receipt export_entities.ExportReceipt
+		err := cursor.Decode(&receipt)
+
+		if err != nil {
+			slog.ErrorContext(ctx, "error decoding export receipt", "err", err)
+			return nil, err
+		}
+
+		receipts = append(receipts, receipt)
+	}
+
+	return receipts, nil
+}
@@ -0,0 +1,179 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	MaxFacetBuckets      = 50
+	FacetCacheTTL        = 30 * time.Second
+	FacetCacheMaxEntries = 1000
+)
+
+// DefaultFacetCache is shared by every repository; keys include the collection and the full $match (tenancy included).
+var DefaultFacetCache = NewFacetCache(FacetCacheTTL, FacetCacheMaxEntries)
+
+type facetCacheEntry struct {
+	facets    common.Facets
+	expiresAt time.Time
+}
+
+// FacetCache is a small in-memory TTL cache: facet counts are expensive ($group over every match) and tolerate being slightly stale.
+type FacetCache struct {
+	mu         sync.Mutex
+	ttl        time.Duration
+	maxEntries int
+	entries    map[string]facetCacheEntry
+}
+
+func NewFacetCache(ttl time.Duration, maxEntries int) *FacetCache {
+	return &FacetCache{
+		ttl:        ttl,
+		maxEntries: maxEntries,
+		entries:    make(map[string]facetCacheEntry),
+	}
+}
+
+func (c *FacetCache) Get(key string) (common.Facets, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+
+	if time.Now().After(entry.expiresAt) {
+		delete(c.entries, key)
+		return nil, false
+	}
+
+	return entry.facets, true
+}
+
+func (c *FacetCache) Set(key string, facets common.Facets) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := time.Now()
+
+	if len(c.entries) >= c.maxEntries {
+		for k, entry := range c.entries {
+			if now.After(entry.expiresAt) {
+				delete(c.entries, k)
+			}
+		}
+	}
+
+	if len(c.entries) >= c.maxEntries {
+		c.entries = make(map[string]facetCacheEntry)
+	}
+
+	c.entries[key] = facetCacheEntry{facets: facets, expiresAt: now.Add(c.ttl)}
+}
+
+// GetFacetPipeline builds a $match (same filters and tenancy as GetPipeline) followed by one $facet branch per requested field.
+func (r *MongoDBRepository[T]) GetFacetPipeline(queryCtx context.Context, s common.Search) ([]bson.M, error) {
+	var pipe []bson.M
+
+	pipe, err := r.addMatch(queryCtx, pipe, s)
+	if err != nil {
+		slog.ErrorContext(queryCtx, "GetFacetPipeline: unable to build $match stage", "error", err)
+		return nil, err
+	}
+
+	facets := bson.M{}
+	for i, field := range s.ResultOptions.Facets {
+		bsonFieldName, err := r.GetBSONFieldNameFromSearchableValue(common.SearchableValue{Field: field})
+		if err != nil {
+			return nil, fmt.Errorf("unable to resolve facet field %s: %w", field, err)
+		}
+
+		// output keys can't contain dots, so branches are indexed and mapped back to the field names on decode
+		facets[facetKey(i)] = bson.A{
+			bson.M{"$group": bson.M{"_id": "$" + bsonFieldName, "count": bson.M{"$sum": 1}}},
+			bson.M{"$sort": bson.D{{Key: "count", Value: -1}, {Key: "_id", Value: 1}}},
+			bson.M{"$limit": MaxFacetBuckets},
+		}
+	}
+
+	pipe = append(pipe, bson.M{"$facet": facets})
+
+	return pipe, nil
+}
+
+func (r *MongoDBRepository[T]) Facets(queryCtx context.Context, s common.Search) (common.Facets, error) {
+	if len(s.ResultOptions.Facets) == 0 {
+		return common.Facets{}, nil
+	}
+
+	pipe, err := r.GetFacetPipeline(queryCtx, s)
+	if err != nil {
+		return nil, err
+	}
+
+	cacheKey := fmt.Sprintf("%s|%v", r.collectionName, pipe)
+	if cached, ok := DefaultFacetCache.Get(cacheKey); ok {
+		return cached, nil
+	}
+
+	collection := r.mongoClient.Database(r.dbName).Collection(r.collectionName)
+
+	cursor, err := collection.Aggregate(queryCtx, pipe)
+	if err != nil {
+		slog.ErrorContext(queryCtx, "unable to open facet cursor", "err", err, "collection", r.collectionName)
+		return nil, err
+	}
+
+	defer cursor.Close(queryCtx)
+
+	var docs []map[string][]common.FacetBucket
+	if err := cursor.All(queryCtx, &docs); err != nil {
+		slog.ErrorContext(queryCtx, "unable to decode facets", "err", err, "collection", r.collectionName)
+		return nil, err
+	}
+
+	facets := make(common.Facets, len(s.ResultOptions.Facets))
+	for i, field := range s.ResultOptions.Facets {
+		buckets := []common.FacetBucket{}
+		if len(docs) > 0 {
+			for _, b := range docs[0][facetKey(i)] {
+				buckets = append(buckets, common.FacetBucket{Value: normalizeFacetValue(b.Value), Count: b.Count})
+			}
+		}
+
+		facets[field] = buckets
+	}
+
+	DefaultFacetCache.Set(cacheKey, facets)
+
+	return facets, nil
+}
+
+func facetKey(i int) string {
+	return fmt.Sprintf("f%d", i)
+}
+
+// normalizeFacetValue decodes uuid binaries (decoded as primitive.Binary into interface{}) so they render as strings.
+func normalizeFacetValue(v interface{}) interface{} {
+	b, ok := v.(primitive.Binary)
+	if !ok || b.Subtype != uuidSubtype || len(b.Data) != 16 {
+		return v
+	}
+
+	id, err := uuid.FromBytes(b.Data)
+	if err != nil {
+		return v
+	}
+
+	return id
+}
@@ -0,0 +1,90 @@
+package db_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	replay_entity "github.com/psavelis/team-pro/replay-api/pkg/domain/replay/entities"
+	db "github.com/psavelis/team-pro/replay-api/pkg/infra/db/mongodb"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+func TestMongoDBRepository_GetFacetPipeline(t *testing.T) {
+	// no round-trip: the driver connects lazily
+	client, err := mongo.Connect(context.Background(), options.Client().ApplyURI("mongodb://127.0.0.1:37019/replay"))
+	if err != nil {
+		t.Fatalf("unable to create mongo client: %v", err)
+	}
+
+	defer client.Disconnect(context.Background())
+
+	r := db.NewReplayFileMetadataRepository(client, dbName, replay_entity.ReplayFile{}, "replay_file_metadata_facet_test")
+
+	tenantID := uuid.New()
+	clientID := uuid.New()
+
+	ctx := common.WithResourceOwner(context.Background(), common.ResourceOwner{TenantID: tenantID, ClientID: clientID})
+
+	s := common.NewSearchByValues(ctx, []common.SearchableValue{{Field: "GameID", Values: []interface{}{"cs2"}}}, common.SearchResultOptions{Limit: 10, Facets: []string{"NetworkID", "Header.Filestamp"}}, common.ClientApplicationAudienceIDKey)
+
+	pipe, err := r.GetFacetPipeline(ctx, s)
+
+	assert.NoError(t, err)
+	assert.Len(t, pipe, 2)
+
+	assert.Equal(t, bson.M{"$match": bson.M{
+		"game_id":                  bson.M{"$in": []interface{}{"cs2"}},
+		"resource_owner.tenant_id": tenantID,
+		"resource_owner.client_id": clientID,
+	}}, pipe[0])
+
+	facetStage := pipe[1]["$facet"].(bson.M)
+	assert.Len(t, facetStage, 2)
+
+	group := func(field string) bson.A {
+		return bson.A{
+			bson.M{"$group": bson.M{"_id": field, "count": bson.M{"$sum": 1}}},
+			bson.M{"$sort": bson.D{{Key: "count", Value: -1}, {Key: "_id", Value: 1}}},
+			bson.M{"$limit": db.MaxFacetBuckets},
+		}
+	}
+
+	assert.Equal(t, group("$network_id"), facetStage["f0"])
+	assert.Equal(t, group("$header.filestamp"), facetStage["f1"])
+}
+
+func TestFacetCache(t *testing.T) {
+	facets := common.Facets{"NetworkID": {{Value: "steam", Count: 2}}}
+
+	c := db.NewFacetCache(20*time.Millisecond, 2)
+
+	_, ok := c.Get("a")
+	assert.False(t, ok)
+
+	c.Set("a", facets)
+
+	cached, ok := c.Get("a")
+	assert.True(t, ok)
+	assert.Equal(t, facets, cached)
+
+	// full: every entry is dropped before inserting the new one
+	c.Set("b", facets)
+	c.Set("c", facets)
+
+	_, ok = c.Get("a")
+	assert.False(t, ok)
+
+	_, ok = c.Get("c")
+	assert.True(t, ok)
+
+	time.Sleep(30 * time.Millisecond)
+
+	_, ok = c.Get("c")
+	assert.False(t, ok)
+}
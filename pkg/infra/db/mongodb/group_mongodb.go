@@ -29,17 +29,23 @@ func NewGroupRepository(client *mongo.Client, dbName string, entityType *iam_ent
 		"Name":          true,
 		"Type":          true,
 		"ParentGroupID": true,
+		"Ancestors":     true,
 		"ResourceOwner": true,
 		"CreatedAt":     true,
 		"UpdatedAt":     true,
 	}, map[string]string{
-		"ID":            "_id",
-		"Name":          "name",
-		"Type":          "type",
-		"ParentGroupID": "parent_group_id",
-		"ResourceOwner": "resource_owner",
-		"CreatedAt":     "created_at",
-		"UpdatedAt":     "updated_at",
+		"ID":                     "_id",
+		"Name":                   "name",
+		"Type":                   "type",
+		"ParentGroupID":          "parent_group_id",
+		"Ancestors":              "ancestors",
+		"ResourceOwner":          "resource_owner",
+		"CreatedAt":              "created_at",
+		"UpdatedAt":              "updated_at",
+		"ResourceOwner.TenantID": "resource_owner.tenant_id",
+		"ResourceOwner.UserID":   "resource_owner.user_id",
+		"ResourceOwner.GroupID":  "resource_owner.group_id",
+		"ResourceOwner.ClientID": "resource_owner.client_id",
 	})
 
 	return &GroupRepository{
@@ -0,0 +1,140 @@
+package db
+
+import (
+	"context"
+	"log/slog"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// IndexMigration lists the indexes of a collection. Creating an index that already exists (same keys and options) is a no-op.
+type IndexMigration struct {
+	Collection string
+	Indexes    []mongo.IndexModel
+}
+
+// DefaultIndexMigrations lists the indexes of the lookups and aggregations of this service (job runs create their own on register).
+var DefaultIndexMigrations = []IndexMigration{
+	{Collection: "profiles", Indexes: []mongo.IndexModel{
+		{Keys: bson.D{{Key: "rid_source", Value: 1}, {Key: "source_key", Value: 1}}},
+		{Keys: bson.D{{Key: "resource_owner.user_id", Value: 1}}},
+	}},
+	{Collection: "roles", Indexes: []mongo.IndexModel{
+		{Keys: bson.D{{Key: "resource_owner.tenant_id", Value: 1}, {Key: "name", Value: 1}}},
+	}},
+	{Collection: "role_assignments", Indexes: []mongo.IndexModel{
+		{Keys: bson.D{{Key: "resource_owner.tenant_id", Value: 1}, {Key: "user_id", Value: 1}}},
+	}},
+	{Collection: "groups", Indexes: []mongo.IndexModel{
+		{Keys: bson.D{{Key: "resource_owner.tenant_id", Value: 1}, {Key: "ancestors", Value: 1}}},
+	}},
+	{Collection: "memberships", Indexes: []mongo.IndexModel{
+		{Keys: bson.D{{Key: "resource_owner.tenant_id", Value: 1}, {Key: "user_id", Value: 1}, {Key: "group_id", Value: 1}}},
+		{Keys: bson.D{{Key: "group_id", Value: 1}}},
+	}},
+	{Collection: "email_login_tokens", Indexes: []mongo.IndexModel{
+		{Keys: bson.D{{Key: "email", Value: 1}, {Key: "created_at", Value: -1}}},
+	}},
+	{Collection: "match_metadata", Indexes: []mongo.IndexModel{
+		{Keys: bson.D{{Key: "replay_file_id", Value: 1}}},
+		{Keys: bson.D{{Key: "game_id", Value: 1}, {Key: "visibility", Value: 1}, {Key: "created_at", Value: -1}}},
+		{Keys: bson.D{{Key: "resource_owner.group_id", Value: 1}, {Key: "created_at", Value: -1}}},
+	}},
+	{Collection: "match_summaries", Indexes: []mongo.IndexModel{
+		{Keys: bson.D{{Key: "match_id", Value: 1}}},
+		{Keys: bson.D{{Key: "game_id", Value: 1}, {Key: "created_at", Value: -1}}},
+		{Keys: bson.D{{Key: "season_id", Value: 1}, {Key: "game_id", Value: 1}}},
+	}},
+	{Collection: "game_events", Indexes: []mongo.IndexModel{
+		{Keys: bson.D{{Key: "match_id", Value: 1}}},
+	}},
+	{Collection: "player_metadata", Indexes: []mongo.IndexModel{
+		{Keys: bson.D{{Key: "network_id", Value: 1}, {Key: "stats_synced_at", Value: 1}}},
+	}},
+	{Collection: "player_profile_history", Indexes: []mongo.IndexModel{
+		{Keys: bson.D{{Key: "player_id", Value: 1}, {Key: "created_at", Value: -1}}},
+	}},
+	{Collection: "player_match_history", Indexes: []mongo.IndexModel{
+		{Keys: bson.D{{Key: "player_id", Value: 1}, {Key: "played_at", Value: -1}}},
+		{Keys: bson.D{{Key: "match_id", Value: 1}}},
+	}},
+	{Collection: "ratings", Indexes: []mongo.IndexModel{
+		{Keys: bson.D{{Key: "subject", Value: 1}, {Key: "game_id", Value: 1}, {Key: "subject_id", Value: 1}}},
+		{Keys: bson.D{{Key: "season_id", Value: 1}, {Key: "subject", Value: 1}}},
+	}},
+	{Collection: "rating_history", Indexes: []mongo.IndexModel{
+		{Keys: bson.D{{Key: "subject", Value: 1}, {Key: "subject_id", Value: 1}, {Key: "played_at", Value: -1}}},
+		{Keys: bson.D{{Key: "match_id", Value: 1}}},
+	}},
+	{Collection: "seasons", Indexes: []mongo.IndexModel{
+		{Keys: bson.D{{Key: "status", Value: 1}, {Key: "game_id", Value: 1}, {Key: "starts_at", Value: 1}}},
+	}},
+	{Collection: "season_rewards", Indexes: []mongo.IndexModel{
+		{Keys: bson.D{{Key: "season_id", Value: 1}, {Key: "rating", Value: -1}}},
+		{Keys: bson.D{{Key: "player_id", Value: 1}}},
+	}},
+	{Collection: "badges", Indexes: []mongo.IndexModel{
+		{Keys: bson.D{{Key: "game_id", Value: 1}, {Key: "trigger", Value: 1}, {Key: "enabled", Value: 1}}},
+		{Keys: bson.D{{Key: "game_id", Value: 1}, {Key: "key", Value: 1}}},
+	}},
+	{Collection: "player_badges", Indexes: []mongo.IndexModel{
+		{Keys: bson.D{{Key: "player_id", Value: 1}, {Key: "awarded_at", Value: -1}}},
+		{Keys: bson.D{{Key: "network_player_id", Value: 1}, {Key: "awarded_at", Value: -1}}},
+	}},
+	{Collection: "squad_join_requests", Indexes: []mongo.IndexModel{
+		{Keys: bson.D{{Key: "squad_id", Value: 1}, {Key: "user_id", Value: 1}, {Key: "status", Value: 1}}},
+	}},
+	{Collection: "squad_history", Indexes: []mongo.IndexModel{
+		{Keys: bson.D{{Key: "squad_id", Value: 1}, {Key: "created_at", Value: -1}}},
+	}},
+	{Collection: "free_agents", Indexes: []mongo.IndexModel{
+		{Keys: bson.D{{Key: "game_id", Value: 1}, {Key: "status", Value: 1}, {Key: "mmr", Value: 1}}},
+		{Keys: bson.D{{Key: "resource_owner.user_id", Value: 1}, {Key: "game_id", Value: 1}, {Key: "status", Value: 1}}},
+	}},
+	{Collection: "squad_openings", Indexes: []mongo.IndexModel{
+		{Keys: bson.D{{Key: "game_id", Value: 1}, {Key: "status", Value: 1}, {Key: "min_mmr", Value: 1}}},
+		{Keys: bson.D{{Key: "squad_id", Value: 1}, {Key: "status", Value: 1}}},
+	}},
+	{Collection: "visibility_policies", Indexes: []mongo.IndexModel{
+		{Keys: bson.D{{Key: "resource_owner.tenant_id", Value: 1}, {Key: "group_id", Value: 1}}},
+	}},
+	{Collection: "visibility_overrides", Indexes: []mongo.IndexModel{
+		{Keys: bson.D{{Key: "resource_owner.tenant_id", Value: 1}, {Key: "created_at", Value: -1}}},
+	}},
+	{Collection: "weekly_recaps", Indexes: []mongo.IndexModel{
+		{Keys: bson.D{{Key: "subject_id", Value: 1}, {Key: "week_start", Value: -1}}},
+	}},
+	{Collection: "notifications", Indexes: []mongo.IndexModel{
+		{Keys: bson.D{{Key: "resource_owner.user_id", Value: 1}, {Key: "read_at", Value: 1}, {Key: "created_at", Value: -1}}},
+	}},
+	{Collection: "notification_preferences", Indexes: []mongo.IndexModel{
+		{Keys: bson.D{{Key: "resource_owner.tenant_id", Value: 1}, {Key: "user_id", Value: 1}}},
+	}},
+	{Collection: "custom_field_definitions", Indexes: []mongo.IndexModel{
+		{Keys: bson.D{{Key: "resource_owner.tenant_id", Value: 1}, {Key: "resource_owner.client_id", Value: 1}, {Key: "entity_type", Value: 1}}},
+	}},
+}
+
+// IndexMigrationResult has the names of the indexes of a collection (created or already there).
+type IndexMigrationResult struct {
+	Collection string
+	Indexes    []string
+}
+
+// RunIndexMigrations creates the indexes of each migration, stopping at the first collection that fails.
+func RunIndexMigrations(ctx context.Context, client *mongo.Client, dbName string, migrations []IndexMigration) ([]IndexMigrationResult, error) {
+	results := make([]IndexMigrationResult, 0, len(migrations))
+
+	for _, migration := range migrations {
+		names, err := client.Database(dbName).Collection(migration.Collection).Indexes().CreateMany(ctx, migration.Indexes)
+		if err != nil {
+			slog.ErrorContext(ctx, "error creating indexes", "collection", migration.Collection, "err", err)
+			return results, err
+		}
+
+		results = append(results, IndexMigrationResult{Collection: migration.Collection, Indexes: names})
+	}
+
+	return results, nil
+}
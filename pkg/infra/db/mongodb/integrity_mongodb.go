@@ -0,0 +1,219 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+)
+
+const IntegrityTombstonesCollection = "integrity_tombstones"
+
+// ReferenceRule describes a cross-collection reference: documents in Collection point (through LocalField) to ForeignField in ForeignCollection.
+type ReferenceRule struct {
+	Name              string
+	Collection        string
+	LocalField        string
+	ForeignCollection string
+	ForeignField      string
+}
+
+// DefaultReferenceRules lists the references between the collections managed by this service.
+var DefaultReferenceRules = []ReferenceRule{
+	{Name: "profile_user", Collection: "profiles", LocalField: "resource_owner.user_id", ForeignCollection: "users", ForeignField: "_id"},
+	{Name: "profile_group", Collection: "profiles", LocalField: "resource_owner.group_id", ForeignCollection: "groups", ForeignField: "_id"},
+	{Name: "squad_group", Collection: "squads", LocalField: "group_id", ForeignCollection: "groups", ForeignField: "_id"},
+	{Name: "player_user", Collection: "player_metadata", LocalField: "user_id", ForeignCollection: "users", ForeignField: "_id"},
+	{Name: "match_replay_file", Collection: "match_metadata", LocalField: "replay_file_id", ForeignCollection: "replay_file_metadata", ForeignField: "_id"},
+	{Name: "game_event_match", Collection: "game_events", LocalField: "match_id", ForeignCollection: "match_metadata", ForeignField: "_id"},
+}
+
+func GetReferenceRule(name string) (ReferenceRule, error) {
+	for _, rule := range DefaultReferenceRules {
+		if rule.Name == name {
+			return rule, nil
+		}
+	}
+
+	return ReferenceRule{}, fmt.Errorf("unknown reference rule %s", name)
+}
+
+// DanglingReference is a document whose reference (LocalValue) does not exist in the foreign collection.
+type DanglingReference struct {
+	Rule       string      `json:"rule" bson:"-"`
+	DocumentID interface{} `json:"document_id" bson:"_id"`
+	LocalValue interface{} `json:"local_value" bson:"local_value"`
+	TenantID   uuid.UUID   `json:"tenant_id" bson:"tenant_id"`
+	ClientID   uuid.UUID   `json:"client_id" bson:"client_id"`
+}
+
+type IntegrityTombstone struct {
+	ID         uuid.UUID   `json:"id" bson:"_id"`
+	Rule       string      `json:"rule" bson:"rule"`
+	Collection string      `json:"collection" bson:"collection"`
+	DocumentID interface{} `json:"document_id" bson:"document_id"`
+	Document   bson.M      `json:"document" bson:"document"`
+	CreatedAt  time.Time   `json:"created_at" bson:"created_at"`
+}
+
+// IntegrityRepository scans and repairs references across collections. It is meant for admin tooling only and is not tenant-scoped
+// unless a tenantID is given.
+type IntegrityRepository struct {
+	db *mongo.Database
+}
+
+func NewIntegrityRepository(client *mongo.Client, dbName string) *IntegrityRepository {
+	return &IntegrityRepository{
+		db: client.Database(dbName),
+	}
+}
+
+func (r *IntegrityRepository) FindDangling(ctx context.Context, rule ReferenceRule, tenantID uuid.UUID, limit int64) ([]DanglingReference, error) {
+	match := bson.M{rule.LocalField: bson.M{"$exists": true, "$ne": nil}}
+	if tenantID != uuid.Nil {
+		match["resource_owner.tenant_id"] = tenantID
+	}
+
+	pipe := []bson.M{
+		{"$match": match},
+		{"$lookup": bson.M{"from": rule.ForeignCollection, "localField": rule.LocalField, "foreignField": rule.ForeignField, "as": "ref"}},
+		{"$match": bson.M{"ref": bson.M{"$size": 0}}},
+		{"$project": bson.M{"_id": 1, "local_value": "$" + rule.LocalField, "tenant_id": "$resource_owner.tenant_id", "client_id": "$resource_owner.client_id"}},
+	}
+
+	if limit > 0 {
+		pipe = append(pipe, bson.M{"$limit": limit})
+	}
+
+	cursor, err := r.db.Collection(rule.Collection).Aggregate(ctx, pipe)
+	if cursor != nil {
+		defer cursor.Close(ctx)
+	}
+
+	if err != nil {
+		slog.ErrorContext(ctx, "unable to scan references", "rule", rule.Name, "err", err)
+		return nil, err
+	}
+
+	dangling := make([]DanglingReference, 0)
+
+	for cursor.Next(ctx) {
+		var d DanglingReference
+		err := cursor.Decode(&d)
+		if err != nil {
+			slog.ErrorContext(ctx, "error decoding dangling reference", "rule", rule.Name, "err", err)
+			return nil, err
+		}
+
+		d.Rule = rule.Name
+		dangling = append(dangling, d)
+	}
+
+	return dangling, nil
+}
+
+// GetRelinkTarget returns the resource owner of the relink target, nil when it doesn't exist in the foreign collection.
+func (r *IntegrityRepository) GetRelinkTarget(ctx context.Context, rule ReferenceRule, target uuid.UUID) (*common.ResourceOwner, error) {
+	var doc struct {
+		ResourceOwner common.ResourceOwner `bson:"resource_owner"`
+	}
+
+	err := r.db.Collection(rule.ForeignCollection).FindOne(ctx, bson.M{rule.ForeignField: target}).Decode(&doc)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, nil
+	}
+
+	if err != nil {
+		slog.ErrorContext(ctx, "unable to find relink target", "rule", rule.Name, "target", target, "err", err)
+		return nil, err
+	}
+
+	return &doc.ResourceOwner, nil
+}
+
+// Relink points the reference of the given documents to target, which must exist in the foreign collection. Only the documents of the
+// same tenant and client as the target are relinked.
+func (r *IntegrityRepository) Relink(ctx context.Context, rule ReferenceRule, documentIDs []interface{}, target uuid.UUID) (int64, error) {
+	owner, err := r.GetRelinkTarget(ctx, rule, target)
+	if err != nil {
+		return 0, err
+	}
+
+	if owner == nil {
+		return 0, fmt.Errorf("relink target %s not found in %s.%s", target, rule.ForeignCollection, rule.ForeignField)
+	}
+
+	filter := bson.M{
+		"_id":                      bson.M{"$in": documentIDs},
+		"resource_owner.tenant_id": owner.TenantID,
+		"resource_owner.client_id": owner.ClientID,
+	}
+
+	res, err := r.db.Collection(rule.Collection).UpdateMany(ctx, filter, bson.M{"$set": bson.M{rule.LocalField: target}})
+	if err != nil {
+		return 0, err
+	}
+
+	return res.ModifiedCount, nil
+}
+
+// Tombstone moves the given documents into the tombstones collection, so they can be restored later.
+func (r *IntegrityRepository) Tombstone(ctx context.Context, rule ReferenceRule, documentIDs []interface{}) (int64, error) {
+	cursor, err := r.db.Collection(rule.Collection).Find(ctx, bson.M{"_id": bson.M{"$in": documentIDs}})
+	if cursor != nil {
+		defer cursor.Close(ctx)
+	}
+
+	if err != nil {
+		return 0, err
+	}
+
+	tombstones := make([]interface{}, 0, len(documentIDs))
+	ids := make([]interface{}, 0, len(documentIDs))
+
+	for cursor.Next(ctx) {
+		var doc bson.M
+		err := cursor.Decode(&doc)
+		if err != nil {
+			return 0, err
+		}
+
+		tombstones = append(tombstones, IntegrityTombstone{
+			ID:         uuid.New(),
+			Rule:       rule.Name,
+			Collection: rule.Collection,
+			DocumentID: doc["_id"],
+			Document:   doc,
+			CreatedAt:  time.Now(),
+		})
+
+		ids = append(ids, doc["_id"])
+	}
+
+	if len(tombstones) == 0 {
+		return 0, nil
+	}
+
+	_, err = r.db.Collection(IntegrityTombstonesCollection).InsertMany(ctx, tombstones)
+	if err != nil {
+		return 0, err
+	}
+
+	return r.Delete(ctx, rule, ids)
+}
+
+func (r *IntegrityRepository) Delete(ctx context.Context, rule ReferenceRule, documentIDs []interface{}) (int64, error) {
+	res, err := r.db.Collection(rule.Collection).DeleteMany(ctx, bson.M{"_id": bson.M{"$in": documentIDs}})
+	if err != nil {
+		return 0, err
+	}
+
+	return res.DeletedCount, nil
+}
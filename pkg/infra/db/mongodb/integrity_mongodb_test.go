@@ -0,0 +1,154 @@
+package db_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	db "github.com/psavelis/team-pro/replay-api/pkg/infra/db/mongodb"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func TestIntegrityRepository_ScanAndRepair(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	client, err := getClient()
+	assert.NoError(t, err, "Failed to connect to MongoDB")
+
+	dbName := "replay"
+	ctx := context.TODO()
+
+	suffix := uuid.New().String()
+	rule := db.ReferenceRule{
+		Name:              "test_squad_group",
+		Collection:        "integrity_squads_" + suffix,
+		LocalField:        "group_id",
+		ForeignCollection: "integrity_groups_" + suffix,
+		ForeignField:      "_id",
+	}
+
+	squads := client.Database(dbName).Collection(rule.Collection)
+	groups := client.Database(dbName).Collection(rule.ForeignCollection)
+
+	defer squads.Drop(ctx)
+	defer groups.Drop(ctx)
+
+	owner := common.ResourceOwner{TenantID: uuid.New(), ClientID: uuid.New()}
+	otherTenant := common.ResourceOwner{TenantID: uuid.New(), ClientID: owner.ClientID}
+	otherClient := common.ResourceOwner{TenantID: owner.TenantID, ClientID: uuid.New()}
+
+	groupID := uuid.New()
+	_, err = groups.InsertOne(ctx, bson.M{"_id": groupID, "resource_owner": owner})
+	assert.NoError(t, err)
+
+	linked := uuid.New()
+	orphan := uuid.New()
+	orphanOtherTenant := uuid.New()
+	orphanOtherClient := uuid.New()
+
+	_, err = squads.InsertMany(ctx, []interface{}{
+		bson.M{"_id": linked, "group_id": groupID, "resource_owner": owner},
+		bson.M{"_id": orphan, "group_id": uuid.New(), "resource_owner": owner},
+		bson.M{"_id": orphanOtherTenant, "group_id": uuid.New(), "resource_owner": otherTenant},
+		bson.M{"_id": orphanOtherClient, "group_id": uuid.New(), "resource_owner": otherClient},
+	})
+	assert.NoError(t, err)
+
+	repo := db.NewIntegrityRepository(client, dbName)
+
+	t.Run("FindDangling", func(t *testing.T) {
+		dangling, err := repo.FindDangling(ctx, rule, uuid.Nil, 0)
+		assert.NoError(t, err)
+		assert.Len(t, dangling, 3)
+
+		dangling, err = repo.FindDangling(ctx, rule, owner.TenantID, 0)
+		assert.NoError(t, err)
+		assert.Len(t, dangling, 2)
+
+		for _, d := range dangling {
+			assert.Equal(t, rule.Name, d.Rule)
+			assert.Equal(t, owner.TenantID, d.TenantID)
+		}
+	})
+
+	t.Run("GetRelinkTarget", func(t *testing.T) {
+		target, err := repo.GetRelinkTarget(ctx, rule, groupID)
+		assert.NoError(t, err)
+		assert.Equal(t, &owner, target)
+
+		target, err = repo.GetRelinkTarget(ctx, rule, uuid.New())
+		assert.NoError(t, err)
+		assert.Nil(t, target)
+	})
+
+	t.Run("Relink Only Same Tenant And Client", func(t *testing.T) {
+		modified, err := repo.Relink(ctx, rule, []interface{}{orphan, orphanOtherTenant, orphanOtherClient}, groupID)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), modified)
+
+		dangling, err := repo.FindDangling(ctx, rule, uuid.Nil, 0)
+		assert.NoError(t, err)
+		assert.ElementsMatch(t, []uuid.UUID{orphanOtherTenant, orphanOtherClient}, documentIDs(t, dangling))
+	})
+
+	t.Run("Relink Missing Target", func(t *testing.T) {
+		_, err := repo.Relink(ctx, rule, []interface{}{orphanOtherTenant}, uuid.New())
+		assert.Error(t, err)
+	})
+
+	t.Run("Tombstone", func(t *testing.T) {
+		removed, err := repo.Tombstone(ctx, rule, []interface{}{orphanOtherTenant})
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), removed)
+
+		count, err := squads.CountDocuments(ctx, bson.M{"_id": orphanOtherTenant})
+		assert.NoError(t, err)
+		assert.Equal(t, int64(0), count)
+
+		tombstones := client.Database(dbName).Collection(db.IntegrityTombstonesCollection)
+		defer tombstones.DeleteMany(ctx, bson.M{"rule": rule.Name})
+
+		var tombstone db.IntegrityTombstone
+		err = tombstones.FindOne(ctx, bson.M{"rule": rule.Name, "document_id": orphanOtherTenant}).Decode(&tombstone)
+		assert.NoError(t, err)
+		assert.Equal(t, rule.Collection, tombstone.Collection)
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		removed, err := repo.Delete(ctx, rule, []interface{}{orphanOtherClient})
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), removed)
+
+		dangling, err := repo.FindDangling(ctx, rule, uuid.Nil, 0)
+		assert.NoError(t, err)
+		assert.Empty(t, dangling)
+	})
+}
+
+func documentIDs(t *testing.T, dangling []db.DanglingReference) []uuid.UUID {
+	ids := make([]uuid.UUID, 0, len(dangling))
+	for _, d := range dangling {
+		var id uuid.UUID
+		switch v := d.DocumentID.(type) {
+		case uuid.UUID:
+			id = v
+		default:
+			raw, err := bson.Marshal(bson.M{"v": v})
+			assert.NoError(t, err)
+
+			var out struct {
+				V uuid.UUID `bson:"v"`
+			}
+			assert.NoError(t, bson.Unmarshal(raw, &out))
+			id = out.V
+		}
+
+		ids = append(ids, id)
+	}
+
+	return ids
+}
@@ -0,0 +1,188 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"sync"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	"github.com/psavelis/team-pro/replay-api/pkg/infra/scheduler"
+)
+
+const (
+	JobStatesCollection = "job_states"
+	JobRunsCollection   = "job_runs"
+
+	// runs are expired by a TTL index (the jobs running every few seconds add up fast)
+	JobRunRetention = 7 * 24 * time.Hour
+)
+
+// JobStoreRepository keeps a document per job (its schedule, controls and last run) and the history of the runs, expired after
+// JobRunRetention.
+type JobStoreRepository struct {
+	states  *mongo.Collection
+	runs    *mongo.Collection
+	indexes sync.Once
+}
+
+func NewJobStoreRepository(client *mongo.Client, dbName string) *JobStoreRepository {
+	db := client.Database(dbName)
+
+	return &JobStoreRepository{
+		states: db.Collection(JobStatesCollection),
+		runs:   db.Collection(JobRunsCollection),
+	}
+}
+
+// Register also creates the indexes of the runs (once per process: only the schedulers register jobs).
+func (r *JobStoreRepository) Register(ctx context.Context, name string, interval time.Duration) error {
+	r.indexes.Do(func() {
+		_, err := r.runs.Indexes().CreateMany(ctx, []mongo.IndexModel{
+			{Keys: bson.D{{Key: "job", Value: 1}, {Key: "started_at", Value: -1}}},
+			{Keys: bson.D{{Key: "finished_at", Value: 1}}, Options: options.Index().SetExpireAfterSeconds(int32(JobRunRetention.Seconds()))},
+		})
+
+		if err != nil {
+			slog.WarnContext(ctx, "error creating job runs indexes", "err", err)
+		}
+	})
+
+	_, err := r.states.UpdateOne(ctx, bson.M{"_id": name}, bson.M{
+		"$set":         bson.M{"interval_seconds": int64(interval.Seconds())},
+		"$setOnInsert": bson.M{"paused": false, "registered_at": time.Now().UTC()},
+	}, options.Update().SetUpsert(true))
+
+	if err != nil {
+		slog.ErrorContext(ctx, "error registering job", "job", name, "err", err)
+		return err
+	}
+
+	return nil
+}
+
+func (r *JobStoreRepository) RecordRun(ctx context.Context, run scheduler.JobRun) error {
+	_, err := r.runs.InsertOne(ctx, run)
+	if err != nil {
+		slog.ErrorContext(ctx, "error inserting job run", "job", run.Job, "runID", run.ID, "err", err)
+		return err
+	}
+
+	// a slow run doesn't overwrite the one started after it (ie: on another replica)
+	filter := bson.M{"_id": run.Job, "$or": bson.A{
+		bson.M{"last_run": bson.M{"$exists": false}},
+		bson.M{"last_run.started_at": bson.M{"$lte": run.StartedAt}},
+	}}
+
+	_, err = r.states.UpdateOne(ctx, filter, bson.M{
+		"$set":   bson.M{"last_run": run},
+		"$unset": bson.M{"missed_at": ""},
+	})
+
+	if err != nil {
+		slog.ErrorContext(ctx, "error updating job last run", "job", run.Job, "runID", run.ID, "err", err)
+		return err
+	}
+
+	return nil
+}
+
+func (r *JobStoreRepository) Jobs(ctx context.Context) ([]scheduler.JobState, error) {
+	cursor, err := r.states.Find(ctx, bson.M{}, options.Find().SetSort(bson.D{{Key: "_id", Value: 1}}))
+	if err != nil {
+		slog.ErrorContext(ctx, "error finding jobs", "err", err)
+		return nil, err
+	}
+
+	jobs := make([]scheduler.JobState, 0)
+
+	err = cursor.All(ctx, &jobs)
+	if err != nil {
+		slog.ErrorContext(ctx, "error decoding jobs", "err", err)
+		return nil, err
+	}
+
+	return jobs, nil
+}
+
+// Runs returns the last runs of the job, the most recent first.
+func (r *JobStoreRepository) Runs(ctx context.Context, name string, limit int) ([]scheduler.JobRun, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "started_at", Value: -1}}).SetLimit(int64(limit))
+
+	cursor, err := r.runs.Find(ctx, bson.M{"job": name}, opts)
+	if err != nil {
+		slog.ErrorContext(ctx, "error finding job runs", "job", name, "err", err)
+		return nil, err
+	}
+
+	runs := make([]scheduler.JobRun, 0)
+
+	err = cursor.All(ctx, &runs)
+	if err != nil {
+		slog.ErrorContext(ctx, "error decoding job runs", "job", name, "err", err)
+		return nil, err
+	}
+
+	return runs, nil
+}
+
+func (r *JobStoreRepository) SetPaused(ctx context.Context, name string, paused bool) (*scheduler.JobState, error) {
+	return r.update(ctx, name, bson.M{"$set": bson.M{"paused": paused}})
+}
+
+func (r *JobStoreRepository) RequestTrigger(ctx context.Context, name string) (*scheduler.JobState, error) {
+	scope, _ := common.GetRequestScope(ctx)
+
+	return r.update(ctx, name, bson.M{"$set": bson.M{"trigger_requested_at": time.Now().UTC(), "trigger_request_id": scope.RequestID}})
+}
+
+func (r *JobStoreRepository) ClaimTrigger(ctx context.Context, name string) (string, bool, error) {
+	var state scheduler.JobState
+
+	// the state before the claim holds the request ID of the trigger
+	err := r.states.FindOneAndUpdate(ctx, bson.M{"_id": name, "trigger_requested_at": bson.M{"$ne": nil}}, bson.M{
+		"$unset": bson.M{"trigger_requested_at": "", "trigger_request_id": ""},
+	}).Decode(&state)
+
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return "", false, nil
+	}
+
+	if err != nil {
+		slog.ErrorContext(ctx, "error claiming job trigger", "job", name, "err", err)
+		return "", false, err
+	}
+
+	return state.TriggerRequestID, true, nil
+}
+
+func (r *JobStoreRepository) MarkMissed(ctx context.Context, name string, at time.Time) (bool, error) {
+	res, err := r.states.UpdateOne(ctx, bson.M{"_id": name, "missed_at": nil}, bson.M{"$set": bson.M{"missed_at": at}})
+	if err != nil {
+		slog.ErrorContext(ctx, "error marking job missed schedule", "job", name, "err", err)
+		return false, err
+	}
+
+	return res.ModifiedCount > 0, nil
+}
+
+func (r *JobStoreRepository) update(ctx context.Context, name string, update bson.M) (*scheduler.JobState, error) {
+	var state scheduler.JobState
+
+	err := r.states.FindOneAndUpdate(ctx, bson.M{"_id": name}, update, options.FindOneAndUpdate().SetReturnDocument(options.After)).Decode(&state)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, scheduler.ErrJobNotFound
+	}
+
+	if err != nil {
+		slog.ErrorContext(ctx, "error updating job", "job", name, "err", err)
+		return nil, err
+	}
+
+	return &state, nil
+}
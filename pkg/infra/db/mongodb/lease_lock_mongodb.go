@@ -0,0 +1,81 @@
+package db
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const LeaseLocksCollection = "lease_locks"
+
+type LeaseLock struct {
+	Name        string    `json:"name" bson:"_id"`
+	Holder      string    `json:"holder" bson:"holder"`
+	ExpiresAt   time.Time `json:"expires_at" bson:"expires_at"`
+	HeartbeatAt time.Time `json:"heartbeat_at" bson:"heartbeat_at"`
+	AcquiredAt  time.Time `json:"acquired_at" bson:"acquired_at"`
+}
+
+// LeaseLockRepository implements distributed locks as one document per lock name. A lease is taken by upserting the document when it's
+// expired (or already held by the caller); a concurrent taker loses on the unique _id and gets false.
+type LeaseLockRepository struct {
+	collection *mongo.Collection
+}
+
+func NewLeaseLockRepository(client *mongo.Client, dbName string) *LeaseLockRepository {
+	return &LeaseLockRepository{
+		collection: client.Database(dbName).Collection(LeaseLocksCollection),
+	}
+}
+
+func (r *LeaseLockRepository) Acquire(ctx context.Context, name string, holder string, ttl time.Duration) (bool, error) {
+	now := time.Now().UTC()
+
+	filter := bson.M{
+		"_id": name,
+		"$or": []bson.M{
+			{"holder": holder},
+			{"expires_at": bson.M{"$lt": now}},
+		},
+	}
+
+	// acquired_at only changes hands with the lease
+	pipe := mongo.Pipeline{
+		{{Key: "$set", Value: bson.M{
+			"acquired_at": bson.M{"$cond": bson.A{bson.M{"$eq": bson.A{"$holder", holder}}, "$acquired_at", now}},
+		}}},
+		{{Key: "$set", Value: bson.M{
+			"holder":       holder,
+			"expires_at":   now.Add(ttl),
+			"heartbeat_at": now,
+		}}},
+	}
+
+	var lease LeaseLock
+	err := r.collection.FindOneAndUpdate(ctx, filter, pipe, options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After)).Decode(&lease)
+	if mongo.IsDuplicateKeyError(err) {
+		return false, nil
+	}
+
+	if err != nil {
+		slog.ErrorContext(ctx, "error acquiring lease lock", "name", name, "holder", holder, "err", err)
+		return false, err
+	}
+
+	return lease.Holder == holder, nil
+}
+
+// Release expires the lease right away (when still held by the holder) so a standby doesn't wait for the TTL.
+func (r *LeaseLockRepository) Release(ctx context.Context, name string, holder string) error {
+	_, err := r.collection.UpdateOne(ctx, bson.M{"_id": name, "holder": holder}, bson.M{"$set": bson.M{"expires_at": time.Now().UTC()}})
+	if err != nil {
+		slog.ErrorContext(ctx, "error releasing lease lock", "name", name, "holder", holder, "err", err)
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,116 @@
+package db
+
+import (
+	"context"
+	"log/slog"
+	"reflect"
+
+	"go.mongodb.org/mongo-driver/mongo"
+
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	matchmaking_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/matchmaking/entities"
+)
+
+type LobbyRepository struct {
+	MongoDBRepository[matchmaking_entities.Lobby]
+}
+
+func NewLobbyRepository(client *mongo.Client, dbName string, entityType matchmaking_entities.Lobby, collectionName string) *LobbyRepository {
+	repo := MongoDBRepository[matchmaking_entities.Lobby]{
+		mongoClient:       client,
+		dbName:            dbName,
+		mappingCache:      make(map[string]CacheItem),
+		entityModel:       reflect.TypeOf(entityType),
+		bsonFieldMappings: make(map[string]string),
+		collectionName:    collectionName,
+		entityName:        reflect.TypeOf(entityType).Name(),
+		queryableFields:   make(map[string]bool),
+	}
+
+	repo.InitQueryableFields(map[string]bool{
+		"ID":                  true,
+		"PoolID":              true,
+		"GameID":              true,
+		"RegionID":            true,
+		"LeaderUserID":        true,
+		"Players":             true,
+		"Players.UserID":      true,
+		"Players.PlayerID":    true,
+		"Mode":                true,
+		"Status":              true,
+		"CancelReason":        true,
+		"ReadyCheck":          true,
+		"ReadyCheck.Deadline": true,
+		"Draft":               true,
+		"Draft.TurnDeadline":  true,
+		"MatchID":             true,
+		"Voice":               true,
+		"Backfills":           true,
+		"Backfills.Status":    true,
+		"Economy":             true,
+		"Economy.ConfigID":    true,
+		"ResourceOwner":       true,
+		"CreatedAt":           true,
+		"UpdatedAt":           true,
+	}, map[string]string{
+		"ID":                     "_id",
+		"PoolID":                 "pool_id",
+		"GameID":                 "game_id",
+		"RegionID":               "region_id",
+		"LeaderUserID":           "leader_user_id",
+		"Players":                "players",
+		"Players.UserID":         "players.user_id",
+		"Players.PlayerID":       "players.player_id",
+		"Mode":                   "mode",
+		"Status":                 "status",
+		"CancelReason":           "cancel_reason",
+		"ReadyCheck":             "ready_check",
+		"ReadyCheck.Deadline":    "ready_check.deadline",
+		"Draft":                  "draft",
+		"Draft.TurnDeadline":     "draft.turn_deadline",
+		"MatchID":                "match_id",
+		"Voice":                  "voice",
+		"Backfills":              "backfills",
+		"Backfills.Status":       "backfills.status",
+		"Economy":                "economy",
+		"Economy.ConfigID":       "economy.config_id",
+		"ResourceOwner":          "resource_owner",
+		"CreatedAt":              "created_at",
+		"UpdatedAt":              "updated_at",
+		"ResourceOwner.TenantID": "resource_owner.tenant_id",
+		"ResourceOwner.UserID":   "resource_owner.user_id",
+		"ResourceOwner.GroupID":  "resource_owner.group_id",
+		"ResourceOwner.ClientID": "resource_owner.client_id",
+	})
+
+	return &LobbyRepository{
+		repo,
+	}
+}
+
+func (r *LobbyRepository) Search(ctx context.Context, s common.Search) ([]matchmaking_entities.Lobby, error) {
+	cursor, err := r.Query(ctx, s)
+	if cursor != nil {
+		defer cursor.Close(ctx)
+	}
+
+	if err != nil {
+		slog.ErrorContext(ctx, "error querying lobbies", "err", err)
+		return nil, err
+	}
+
+	lobbies := make([]matchmaking_entities.Lobby, 0)
+	for cursor.Next(ctx) {
+		var lobby matchmaking_entities.Lobby
+		err := cursor.Decode(&lobby)
+
+		if err != nil {
+			slog.ErrorContext(ctx, "error decoding lobby", "err", err)
+			return nil, err
+		}
+
+		lobbies = append(lobbies, lobby)
+	}
+
+	return lobbies, nil
+}
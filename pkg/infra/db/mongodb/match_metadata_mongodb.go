@@ -4,9 +4,13 @@ import (
 	"context"
 	"log/slog"
 	"reflect"
+	"time"
 
+	"github.com/google/uuid"
 	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	"github.com/psavelis/team-pro/replay-api/pkg/domain/matchmaking"
 	replay_entity "github.com/psavelis/team-pro/replay-api/pkg/domain/replay/entities"
+	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo"
 )
 
@@ -37,6 +41,13 @@ func NewMatchMetadataRepository(client *mongo.Client, dbName string, entityType
 		"Scoreboard":                     true,
 		"Events":                         true,
 		"ShareTokens.*":                  true,
+		"LobbyID":                        true,
+		"Draft":                          true,
+		"SeasonID":                       true,
+		"External":                       true,
+		"External.NetworkID":             true,
+		"External.MatchID":               true,
+		"External.Players.NetworkUserID": true,
 		"Scoreboard.MVP":                 true,
 		"Scoreboard.Teams":               true,
 		"Scoreboard.Teams.MVP":           true,
@@ -44,6 +55,11 @@ func NewMatchMetadataRepository(client *mongo.Client, dbName string, entityType
 		"Scoreboard.Teams.Players.Stats": true,
 		"Scoreboard.Teams.Rounds":        true,
 		"Scoreboard.Teams.Rounds.Stats":  true,
+		"Tags":                           true,
+		"Tags.Platform":                  true,
+		"Tags.ServerRegion":              true,
+		"Tags.ServerLocation":            true,
+		"Tags.Tickrate":                  true,
 	}, map[string]string{
 		"ID":                             "_id",
 		"ReplayFileID":                   "replay_file_id",
@@ -55,6 +71,13 @@ func NewMatchMetadataRepository(client *mongo.Client, dbName string, entityType
 		"Scoreboard":                     "scoreboard",
 		"Events":                         "game_events",
 		"ShareTokens":                    "share_tokens",
+		"LobbyID":                        "lobby_id",
+		"Draft":                          "draft",
+		"SeasonID":                       "season_id",
+		"External":                       "external",
+		"External.NetworkID":             "external.network_id",
+		"External.MatchID":               "external.match_id",
+		"External.Players.NetworkUserID": "external.players.network_user_id",
 		"Scoreboard.MVP":                 "scoreboard.match_mvp",
 		"Scoreboard.Teams":               "scoreboard.team_scoreboards",
 		"Scoreboard.Teams.MVP":           "scoreboard.team_mvp",
@@ -62,6 +85,11 @@ func NewMatchMetadataRepository(client *mongo.Client, dbName string, entityType
 		"Scoreboard.Teams.Players.Stats": "scoreboard.team_scoreboards.player_stats",
 		"Scoreboard.Teams.Rounds":        "scoreboard.team_scoreboards.rounds",
 		"Scoreboard.Teams.Rounds.Stats":  "scoreboard.team_scoreboards.round_stats",
+		"Tags":                           "tags",
+		"Tags.Platform":                  "tags.platform",
+		"Tags.ServerRegion":              "tags.server_region",
+		"Tags.ServerLocation":            "tags.server_location",
+		"Tags.Tickrate":                  "tags.tickrate",
 	})
 
 	return &MatchMetadataRepository{
@@ -122,3 +150,78 @@ func (r *MatchMetadataRepository) Create(createCtx context.Context, events ...re
 
 	return nil
 }
+
+// AttachLobby links the match (uploaded by the user in context) to the lobby that played it, along with its captain draft (if any). A match
+// is only linked once: returns a MatchLinkedError when it's already linked, and false when the match isn't found.
+func (r *MatchMetadataRepository) AttachLobby(ctx context.Context, matchID uuid.UUID, lobbyID uuid.UUID, draft *replay_entity.MatchDraft, queueSessions []replay_entity.MatchQueueSession) (bool, error) {
+	resourceOwner := common.GetResourceOwner(ctx)
+
+	filter := bson.M{
+		"_id":                      matchID,
+		"resource_owner.tenant_id": resourceOwner.TenantID,
+		"resource_owner.client_id": resourceOwner.ClientID,
+		"resource_owner.user_id":   resourceOwner.UserID,
+	}
+
+	// updated_at: the match history of its players is projected again
+	set := bson.M{"lobby_id": lobbyID, "queue_sessions": queueSessions, "updated_at": time.Now().UTC()}
+	if draft != nil {
+		set["draft"] = draft
+	}
+
+	res, err := r.collection.UpdateOne(ctx, bson.M{"$and": []bson.M{filter, {"lobby_id": nil}}}, bson.M{"$set": set})
+	if err != nil {
+		slog.ErrorContext(ctx, "unable to attach lobby to match", "matchID", matchID, "lobbyID", lobbyID, "err", err)
+		return false, err
+	}
+
+	if res.MatchedCount > 0 {
+		return true, nil
+	}
+
+	linked, err := r.collection.CountDocuments(ctx, filter)
+	if err != nil {
+		slog.ErrorContext(ctx, "unable to find match to attach lobby", "matchID", matchID, "lobbyID", lobbyID, "err", err)
+		return false, err
+	}
+
+	if linked > 0 {
+		return false, matchmaking.NewMatchLinkedError(matchID)
+	}
+
+	return false, nil
+}
+
+// AttachSettlement records the settlement of the lobby on the match (of the tenant in context) linked to it. Returns false when the match
+// isn't found.
+func (r *MatchMetadataRepository) AttachSettlement(ctx context.Context, matchID uuid.UUID, lobbyID uuid.UUID, settlement replay_entity.MatchSettlement) (bool, error) {
+	filter := bson.M{
+		"_id":                      matchID,
+		"lobby_id":                 lobbyID,
+		"resource_owner.tenant_id": common.GetResourceOwner(ctx).TenantID,
+	}
+
+	res, err := r.collection.UpdateOne(ctx, filter, bson.M{"$set": bson.M{"settlement": settlement, "updated_at": time.Now().UTC()}})
+	if err != nil {
+		slog.ErrorContext(ctx, "unable to attach lobby settlement to match", "matchID", matchID, "lobbyID", lobbyID, "err", err)
+		return false, err
+	}
+
+	return res.MatchedCount > 0, nil
+}
+
+// AttachExternal links the match (of the tenant in context) to the match it was imported from (ie: on FACEIT). Returns false when the match isn't found.
+func (r *MatchMetadataRepository) AttachExternal(ctx context.Context, matchID uuid.UUID, external replay_entity.ExternalMatch) (bool, error) {
+	filter := bson.M{
+		"_id":                      matchID,
+		"resource_owner.tenant_id": common.GetResourceOwner(ctx).TenantID,
+	}
+
+	res, err := r.collection.UpdateOne(ctx, filter, bson.M{"$set": bson.M{"external": external}})
+	if err != nil {
+		slog.ErrorContext(ctx, "unable to attach external match to match", "matchID", matchID, "externalMatchID", external.MatchID, "err", err)
+		return false, err
+	}
+
+	return res.MatchedCount > 0, nil
+}
@@ -31,10 +31,7 @@ func TestMatchMetadataRepository_Search(t *testing.T) {
 
 	setContextWithValues := func(tenantID, clientID, groupID, userID uuid.UUID) context.Context {
 		newCtx := context.TODO()
-		newCtx = context.WithValue(newCtx, common.TenantIDKey, tenantID)
-		newCtx = context.WithValue(newCtx, common.ClientIDKey, clientID)
-		newCtx = context.WithValue(newCtx, common.GroupIDKey, groupID)
-		newCtx = context.WithValue(newCtx, common.UserIDKey, userID)
+		newCtx = common.WithResourceOwner(newCtx, common.ResourceOwner{TenantID: tenantID, ClientID: clientID, GroupID: groupID, UserID: userID})
 		return newCtx
 	}
 
@@ -0,0 +1,101 @@
+package db
+
+import (
+	"context"
+	"log/slog"
+	"reflect"
+
+	"go.mongodb.org/mongo-driver/mongo"
+
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	replay_entity "github.com/psavelis/team-pro/replay-api/pkg/domain/replay/entities"
+)
+
+type MatchSummaryRepository struct {
+	MongoDBRepository[replay_entity.MatchSummary]
+}
+
+func NewMatchSummaryRepository(client *mongo.Client, dbName string, entityType replay_entity.MatchSummary, collectionName string) *MatchSummaryRepository {
+	repo := MongoDBRepository[replay_entity.MatchSummary]{
+		mongoClient:       client,
+		dbName:            dbName,
+		mappingCache:      make(map[string]CacheItem),
+		entityModel:       reflect.TypeOf(entityType),
+		bsonFieldMappings: make(map[string]string),
+		collectionName:    collectionName,
+		entityName:        reflect.TypeOf(entityType).Name(),
+		queryableFields:   make(map[string]bool),
+	}
+
+	repo.InitQueryableFields(map[string]bool{
+		"ID":                  true,
+		"GameID":              true,
+		"MatchID":             true,
+		"ReplayFileID":        true,
+		"MapName":             true,
+		"MVP":                 true,
+		"SeasonID":            true,
+		"ResourceOwner":       true,
+		"CreatedAt":           true,
+		"UpdatedAt":           true,
+		"Tags":                true,
+		"Tags.Platform":       true,
+		"Tags.ServerRegion":   true,
+		"Tags.ServerLocation": true,
+		"Tags.Tickrate":       true,
+	}, map[string]string{
+		"ID":                     "_id",
+		"GameID":                 "game_id",
+		"MatchID":                "match_id",
+		"ReplayFileID":           "replay_file_id",
+		"MapName":                "map_name",
+		"Rounds":                 "rounds",
+		"MVP":                    "mvp",
+		"MVP.NetworkPlayerID":    "mvp.network_player_id",
+		"Players":                "players",
+		"SeasonID":               "season_id",
+		"ResourceOwner":          "resource_owner",
+		"CreatedAt":              "created_at",
+		"UpdatedAt":              "updated_at",
+		"ResourceOwner.TenantID": "resource_owner.tenant_id",
+		"ResourceOwner.UserID":   "resource_owner.user_id",
+		"ResourceOwner.GroupID":  "resource_owner.group_id",
+		"ResourceOwner.ClientID": "resource_owner.client_id",
+		"Tags":                   "tags",
+		"Tags.Platform":          "tags.platform",
+		"Tags.ServerRegion":      "tags.server_region",
+		"Tags.ServerLocation":    "tags.server_location",
+		"Tags.Tickrate":          "tags.tickrate",
+	})
+
+	return &MatchSummaryRepository{
+		repo,
+	}
+}
+
+func (r *MatchSummaryRepository) Search(ctx context.Context, s common.Search) ([]replay_entity.MatchSummary, error) {
+	cursor, err := r.Query(ctx, s)
+	if cursor != nil {
+		defer cursor.Close(ctx)
+	}
+
+	if err != nil {
+		slog.ErrorContext(ctx, "error querying match summary", "err", err)
+		return nil, err
+	}
+
+	summaries := make([]replay_entity.MatchSummary, 0)
+	for cursor.Next(ctx) {
+		var summary replay_entity.MatchSummary
+		err := cursor.Decode(&summary)
+
+		if err != nil {
+			slog.ErrorContext(ctx, "error decoding match summary", "err", err)
+			return nil, err
+		}
+
+		summaries = append(summaries, summary)
+	}
+
+	return summaries, nil
+}
@@ -0,0 +1,94 @@
+package db
+
+import (
+	"context"
+	"log/slog"
+	"reflect"
+
+	"go.mongodb.org/mongo-driver/mongo"
+
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	matchmaking_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/matchmaking/entities"
+)
+
+type MatchmakingPoolRepository struct {
+	MongoDBRepository[matchmaking_entities.MatchmakingPool]
+}
+
+func NewMatchmakingPoolRepository(client *mongo.Client, dbName string, entityType matchmaking_entities.MatchmakingPool, collectionName string) *MatchmakingPoolRepository {
+	repo := MongoDBRepository[matchmaking_entities.MatchmakingPool]{
+		mongoClient:       client,
+		dbName:            dbName,
+		mappingCache:      make(map[string]CacheItem),
+		entityModel:       reflect.TypeOf(entityType),
+		bsonFieldMappings: make(map[string]string),
+		collectionName:    collectionName,
+		entityName:        reflect.TypeOf(entityType).Name(),
+		queryableFields:   make(map[string]bool),
+	}
+
+	repo.InitQueryableFields(map[string]bool{
+		"ID":               true,
+		"Name":             true,
+		"GameID":           true,
+		"RegionID":         true,
+		"Mode":             true,
+		"TeamSize":         true,
+		"Enabled":          true,
+		"Schedule":         true,
+		"Strategy":         true,
+		"ShadowStrategies": true,
+		"ResourceOwner":    true,
+		"CreatedAt":        true,
+		"UpdatedAt":        true,
+	}, map[string]string{
+		"ID":                     "_id",
+		"Name":                   "name",
+		"GameID":                 "game_id",
+		"RegionID":               "region_id",
+		"Mode":                   "mode",
+		"TeamSize":               "team_size",
+		"Enabled":                "enabled",
+		"Schedule":               "schedule",
+		"Strategy":               "strategy",
+		"ShadowStrategies":       "shadow_strategies",
+		"ResourceOwner":          "resource_owner",
+		"CreatedAt":              "created_at",
+		"UpdatedAt":              "updated_at",
+		"ResourceOwner.TenantID": "resource_owner.tenant_id",
+		"ResourceOwner.UserID":   "resource_owner.user_id",
+		"ResourceOwner.GroupID":  "resource_owner.group_id",
+		"ResourceOwner.ClientID": "resource_owner.client_id",
+	})
+
+	return &MatchmakingPoolRepository{
+		repo,
+	}
+}
+
+func (r *MatchmakingPoolRepository) Search(ctx context.Context, s common.Search) ([]matchmaking_entities.MatchmakingPool, error) {
+	cursor, err := r.Query(ctx, s)
+	if cursor != nil {
+		defer cursor.Close(ctx)
+	}
+
+	if err != nil {
+		slog.ErrorContext(ctx, "error querying matchmaking pools", "err", err)
+		return nil, err
+	}
+
+	pools := make([]matchmaking_entities.MatchmakingPool, 0)
+	for cursor.Next(ctx) {
+		var pool matchmaking_entities.MatchmakingPool
+		err := cursor.Decode(&pool)
+
+		if err != nil {
+			slog.ErrorContext(ctx, "error decoding matchmaking pool", "err", err)
+			return nil, err
+		}
+
+		pools = append(pools, pool)
+	}
+
+	return pools, nil
+}
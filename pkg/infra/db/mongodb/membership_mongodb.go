@@ -0,0 +1,81 @@
+package db
+
+import (
+	"context"
+	"log/slog"
+	"reflect"
+
+	"go.mongodb.org/mongo-driver/mongo"
+
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	iam_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/iam/entities"
+)
+
+type MembershipRepository struct {
+	MongoDBRepository[iam_entities.Membership]
+}
+
+func NewMembershipRepository(client *mongo.Client, dbName string, entityType iam_entities.Membership, collectionName string) *MembershipRepository {
+	repo := MongoDBRepository[iam_entities.Membership]{
+		mongoClient:       client,
+		dbName:            dbName,
+		mappingCache:      make(map[string]CacheItem),
+		entityModel:       reflect.TypeOf(entityType),
+		bsonFieldMappings: make(map[string]string),
+		collectionName:    collectionName,
+		entityName:        reflect.TypeOf(entityType).Name(),
+		queryableFields:   make(map[string]bool),
+	}
+
+	repo.InitQueryableFields(map[string]bool{
+		"ID":            true,
+		"GroupID":       true,
+		"UserID":        true,
+		"Type":          true,
+		"ResourceOwner": true,
+		"CreatedAt":     true,
+		"UpdatedAt":     true,
+	}, map[string]string{
+		"ID":                     "_id",
+		"GroupID":                "group_id",
+		"UserID":                 "user_id",
+		"Type":                   "type",
+		"ResourceOwner":          "resource_owner",
+		"CreatedAt":              "created_at",
+		"UpdatedAt":              "updated_at",
+		"ResourceOwner.TenantID": "resource_owner.tenant_id",
+		"ResourceOwner.UserID":   "resource_owner.user_id",
+		"ResourceOwner.GroupID":  "resource_owner.group_id",
+		"ResourceOwner.ClientID": "resource_owner.client_id",
+	})
+
+	return &MembershipRepository{
+		repo,
+	}
+}
+
+func (r *MembershipRepository) Search(ctx context.Context, s common.Search) ([]iam_entities.Membership, error) {
+	cursor, err := r.Query(ctx, s)
+	if cursor != nil {
+		defer cursor.Close(ctx)
+	}
+
+	if err != nil {
+		slog.ErrorContext(ctx, "error querying memberships", "err", err)
+		return nil, err
+	}
+
+	memberships := make([]iam_entities.Membership, 0)
+	for cursor.Next(ctx) {
+		var membership iam_entities.Membership
+		err := cursor.Decode(&membership)
+		if err != nil {
+			slog.ErrorContext(ctx, "error decoding membership", "err", err)
+			return nil, err
+		}
+
+		memberships = append(memberships, membership)
+	}
+
+	return memberships, nil
+}
@@ -0,0 +1,188 @@
+package db_test
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	replay_entity "github.com/psavelis/team-pro/replay-api/pkg/domain/replay/entities"
+	db "github.com/psavelis/team-pro/replay-api/pkg/infra/db/mongodb"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// allocations per call allowed on the hot path of every search request. Raise them only along with the benchmark results
+// (make bench-query-builder) that justify it.
+const (
+	ensureTenancyAllocBudget   = 4  // the tenancy keys boxed in the filter and logged
+	simplePipelineAllocBudget  = 26 // the bson.M of each stage and filter, handed over to the driver
+	groupedPipelineAllocBudget = 78
+)
+
+type queryBuilderFixture struct {
+	repo   *db.ReplayFileMetadataRepository
+	ctx    context.Context
+	owner  common.ResourceOwner
+	shapes []queryBuilderShape
+}
+
+type queryBuilderShape struct {
+	name         string
+	aggregations []common.SearchAggregation
+}
+
+// newQueryBuilderFixture returns a repository (no round-trip: the driver connects lazily) and the search shapes sent by the api,
+// logging at the production level to io.Discard.
+func newQueryBuilderFixture(tb testing.TB) *queryBuilderFixture {
+	logger := slog.Default()
+	slog.SetDefault(slog.New(slog.NewJSONHandler(io.Discard, nil)))
+	tb.Cleanup(func() { slog.SetDefault(logger) })
+
+	client, err := mongo.Connect(context.Background(), options.Client().ApplyURI("mongodb://127.0.0.1:37019/replay"))
+	if err != nil {
+		tb.Fatalf("unable to create mongo client: %v", err)
+	}
+
+	tb.Cleanup(func() { client.Disconnect(context.Background()) })
+
+	owner := common.ResourceOwner{TenantID: uuid.New(), ClientID: uuid.New(), GroupID: uuid.New(), UserID: uuid.New()}
+
+	since := time.Now().Add(-30 * 24 * time.Hour)
+
+	gameID := func(v interface{}) common.SearchParameter {
+		return common.SearchParameter{ValueParams: []common.SearchableValue{{Field: "GameID", Values: []interface{}{v}}}}
+	}
+
+	networkID := func(v interface{}) common.SearchParameter {
+		return common.SearchParameter{ValueParams: []common.SearchableValue{{Field: "NetworkID", Values: []interface{}{v}}}}
+	}
+
+	return &queryBuilderFixture{
+		repo:  db.NewReplayFileMetadataRepository(client, dbName, replay_entity.ReplayFile{}, "replay_file_metadata_bench"),
+		ctx:   common.WithResourceOwner(context.Background(), owner),
+		owner: owner,
+		shapes: []queryBuilderShape{
+			{"Simple", []common.SearchAggregation{{Params: []common.SearchParameter{gameID("cs2")}}}},
+			{"Grouped", []common.SearchAggregation{
+				{Params: []common.SearchParameter{gameID("cs2"), {DateParams: []common.SearchableDateRange{{Field: "CreatedAt", Min: &since}}}}},
+				{
+					AggregationClause: common.OrAggregationClause,
+					Params: []common.SearchParameter{
+						networkID("steam"),
+						{Negate: true, ValueParams: []common.SearchableValue{{Field: "Status", Values: []interface{}{"Failed"}, Operator: common.EqualsOperator}}},
+						{AggregationParams: []common.SearchAggregation{{Params: []common.SearchParameter{networkID("faceit"), gameID("vlrnt")}}}},
+					},
+				},
+			}},
+		},
+	}
+}
+
+func (f *queryBuilderFixture) search(aggregations []common.SearchAggregation, audience common.IntendedAudienceKey) common.Search {
+	return common.Search{
+		SearchParams:  aggregations,
+		ResultOptions: common.SearchResultOptions{Limit: 20},
+		SortOptions:   []common.SearchSortOption{{Field: "CreatedAt", Direction: common.DescendingIDKey}},
+		VisibilityOptions: common.SearchVisibilityOptions{
+			RequestSource:    f.owner,
+			IntendedAudience: audience,
+		},
+	}
+}
+
+func BenchmarkMongoDBRepository_EnsureTenancy(b *testing.B) {
+	f := newQueryBuilderFixture(b)
+
+	for _, audience := range []common.IntendedAudienceKey{common.ClientApplicationAudienceIDKey, common.GroupAudienceIDKey, common.UserAudienceIDKey} {
+		s := f.search(nil, audience)
+
+		b.Run(string(audience), func(b *testing.B) {
+			b.ReportAllocs()
+
+			agg := bson.M{}
+			for i := 0; i < b.N; i++ {
+				_, err := f.repo.EnsureTenancy(f.ctx, agg, s)
+				if err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkMongoDBRepository_GetPipeline(b *testing.B) {
+	f := newQueryBuilderFixture(b)
+
+	for _, shape := range f.shapes {
+		s := f.search(shape.aggregations, common.UserAudienceIDKey)
+
+		b.Run(shape.name, func(b *testing.B) {
+			b.ReportAllocs()
+
+			for i := 0; i < b.N; i++ {
+				_, err := f.repo.GetPipeline(f.ctx, s)
+				if err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkMongoDBRepository_GetBSONFieldName(b *testing.B) {
+	f := newQueryBuilderFixture(b)
+
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		_, err := f.repo.GetBSONFieldName("ResourceOwner.TenantID")
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func TestMongoDBRepository_QueryBuilderAllocBudget(t *testing.T) {
+	f := newQueryBuilderFixture(t)
+
+	tenancySearch := f.search(nil, common.UserAudienceIDKey)
+	agg := bson.M{}
+
+	budgets := []struct {
+		name   string
+		budget float64
+		fn     func()
+	}{
+		{"EnsureTenancy", ensureTenancyAllocBudget, func() { f.repo.EnsureTenancy(f.ctx, agg, tenancySearch) }},
+	}
+
+	for _, shape := range f.shapes {
+		s := f.search(shape.aggregations, common.UserAudienceIDKey)
+		budget := float64(simplePipelineAllocBudget)
+		if shape.name == "Grouped" {
+			budget = groupedPipelineAllocBudget
+		}
+
+		budgets = append(budgets, struct {
+			name   string
+			budget float64
+			fn     func()
+		}{"GetPipeline/" + shape.name, budget, func() { f.repo.GetPipeline(f.ctx, s) }})
+	}
+
+	for _, b := range budgets {
+		t.Run(b.name, func(t *testing.T) {
+			allocs := testing.AllocsPerRun(100, b.fn)
+			t.Logf("%.0f allocs/op (budget %.0f)", allocs, b.budget)
+
+			if allocs > b.budget {
+				t.Errorf("%s allocates %.0f times per call, over its budget of %.0f", b.name, allocs, b.budget)
+			}
+		})
+	}
+}
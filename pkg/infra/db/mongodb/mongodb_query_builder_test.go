@@ -0,0 +1,397 @@
+package db_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	replay_entity "github.com/psavelis/team-pro/replay-api/pkg/domain/replay/entities"
+	squad_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/squad/entities"
+	db "github.com/psavelis/team-pro/replay-api/pkg/infra/db/mongodb"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+func TestMongoDBRepository_GetPipeline_BooleanGroups(t *testing.T) {
+	// no round-trip: the driver connects lazily
+	client, err := mongo.Connect(context.Background(), options.Client().ApplyURI("mongodb://127.0.0.1:37019/replay"))
+	if err != nil {
+		t.Fatalf("unable to create mongo client: %v", err)
+	}
+
+	defer client.Disconnect(context.Background())
+
+	r := db.NewReplayFileMetadataRepository(client, dbName, replay_entity.ReplayFile{}, "replay_file_metadata_query_builder_test")
+
+	tenantID := uuid.New()
+	clientID := uuid.New()
+
+	ctx := common.WithResourceOwner(context.Background(), common.ResourceOwner{TenantID: tenantID, ClientID: clientID})
+
+	gameID := func(v interface{}) common.SearchParameter {
+		return common.SearchParameter{ValueParams: []common.SearchableValue{{Field: "GameID", Values: []interface{}{v}}}}
+	}
+
+	networkID := func(v interface{}) common.SearchParameter {
+		return common.SearchParameter{ValueParams: []common.SearchableValue{{Field: "NetworkID", Values: []interface{}{v}}}}
+	}
+
+	tenancy := func(filter bson.M) bson.M {
+		filter["resource_owner.tenant_id"] = tenantID
+		filter["resource_owner.client_id"] = clientID
+		return filter
+	}
+
+	tests := []struct {
+		name          string
+		aggregations  []common.SearchAggregation
+		expectedMatch bson.M
+	}{
+		{
+			name:          "No Params",
+			aggregations:  []common.SearchAggregation{},
+			expectedMatch: tenancy(bson.M{}),
+		},
+		{
+			name: "Single Value Is Not Wrapped",
+			aggregations: []common.SearchAggregation{
+				{Params: []common.SearchParameter{gameID("cs2")}},
+			},
+			expectedMatch: tenancy(bson.M{"game_id": bson.M{"$in": []interface{}{"cs2"}}}),
+		},
+		{
+			name: "Default Clause Is AND",
+			aggregations: []common.SearchAggregation{
+				{Params: []common.SearchParameter{gameID("cs2"), networkID("steam")}},
+			},
+			expectedMatch: tenancy(bson.M{"$and": bson.A{
+				bson.M{"game_id": bson.M{"$in": []interface{}{"cs2"}}},
+				bson.M{"network_id": bson.M{"$in": []interface{}{"steam"}}},
+			}}),
+		},
+		{
+			name: "OR Group Keeps Tenancy At Top Level",
+			aggregations: []common.SearchAggregation{
+				{
+					AggregationClause: common.OrAggregationClause,
+					Params:            []common.SearchParameter{gameID("cs2"), networkID("steam")},
+				},
+			},
+			expectedMatch: tenancy(bson.M{"$or": bson.A{
+				bson.M{"game_id": bson.M{"$in": []interface{}{"cs2"}}},
+				bson.M{"network_id": bson.M{"$in": []interface{}{"steam"}}},
+			}}),
+		},
+		{
+			name: "Multiple Top Level Aggregations Are ANDed",
+			aggregations: []common.SearchAggregation{
+				{
+					AggregationClause: common.OrAggregationClause,
+					Params:            []common.SearchParameter{gameID("cs2"), gameID("vlrnt")},
+				},
+				{
+					AggregationClause: common.OrAggregationClause,
+					Params:            []common.SearchParameter{networkID("steam"), networkID("faceit")},
+				},
+			},
+			expectedMatch: tenancy(bson.M{"$and": bson.A{
+				bson.M{"$or": bson.A{
+					bson.M{"game_id": bson.M{"$in": []interface{}{"cs2"}}},
+					bson.M{"game_id": bson.M{"$in": []interface{}{"vlrnt"}}},
+				}},
+				bson.M{"$or": bson.A{
+					bson.M{"network_id": bson.M{"$in": []interface{}{"steam"}}},
+					bson.M{"network_id": bson.M{"$in": []interface{}{"faceit"}}},
+				}},
+			}}),
+		},
+		{
+			name: "Parameter Inherits Clause Of Enclosing Aggregation",
+			aggregations: []common.SearchAggregation{
+				{
+					AggregationClause: common.OrAggregationClause,
+					Params: []common.SearchParameter{
+						{ValueParams: []common.SearchableValue{
+							{Field: "GameID", Values: []interface{}{"cs2"}},
+							{Field: "NetworkID", Values: []interface{}{"steam"}},
+						}},
+					},
+				},
+			},
+			expectedMatch: tenancy(bson.M{"$or": bson.A{
+				bson.M{"game_id": bson.M{"$in": []interface{}{"cs2"}}},
+				bson.M{"network_id": bson.M{"$in": []interface{}{"steam"}}},
+			}}),
+		},
+		{
+			name: "Nested AND Inside OR",
+			aggregations: []common.SearchAggregation{
+				{
+					AggregationClause: common.OrAggregationClause,
+					Params: []common.SearchParameter{
+						gameID("vlrnt"),
+						{
+							AggregationParams: []common.SearchAggregation{
+								{
+									AggregationClause: common.AndAggregationClause,
+									Params:            []common.SearchParameter{gameID("cs2"), networkID("steam")},
+								},
+							},
+						},
+					},
+				},
+			},
+			expectedMatch: tenancy(bson.M{"$or": bson.A{
+				bson.M{"game_id": bson.M{"$in": []interface{}{"vlrnt"}}},
+				bson.M{"$and": bson.A{
+					bson.M{"game_id": bson.M{"$in": []interface{}{"cs2"}}},
+					bson.M{"network_id": bson.M{"$in": []interface{}{"steam"}}},
+				}},
+			}}),
+		},
+		{
+			name: "NOT Parameter",
+			aggregations: []common.SearchAggregation{
+				{
+					Params: []common.SearchParameter{
+						gameID("cs2"),
+						{
+							Negate:      true,
+							ValueParams: []common.SearchableValue{{Field: "NetworkID", Values: []interface{}{"steam"}}},
+						},
+					},
+				},
+			},
+			expectedMatch: tenancy(bson.M{"$and": bson.A{
+				bson.M{"game_id": bson.M{"$in": []interface{}{"cs2"}}},
+				bson.M{"$nor": bson.A{bson.M{"network_id": bson.M{"$in": []interface{}{"steam"}}}}},
+			}}),
+		},
+		{
+			name: "NOT OR Group",
+			aggregations: []common.SearchAggregation{
+				{
+					AggregationClause: common.OrAggregationClause,
+					Negate:            true,
+					Params:            []common.SearchParameter{gameID("cs2"), networkID("steam")},
+				},
+			},
+			expectedMatch: tenancy(bson.M{"$nor": bson.A{
+				bson.M{"$or": bson.A{
+					bson.M{"game_id": bson.M{"$in": []interface{}{"cs2"}}},
+					bson.M{"network_id": bson.M{"$in": []interface{}{"steam"}}},
+				}},
+			}}),
+		},
+		{
+			name: "Empty Groups Are Dropped",
+			aggregations: []common.SearchAggregation{
+				{AggregationClause: common.OrAggregationClause, Negate: true},
+				{Params: []common.SearchParameter{gameID("cs2"), {AggregationParams: []common.SearchAggregation{{}}}}},
+			},
+			expectedMatch: tenancy(bson.M{"game_id": bson.M{"$in": []interface{}{"cs2"}}}),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := common.NewSearchByAggregation(ctx, tt.aggregations, common.SearchResultOptions{Limit: 10}, common.ClientApplicationAudienceIDKey)
+
+			pipe, err := r.GetPipeline(ctx, s)
+
+			assert.NoError(t, err)
+			assert.NotEmpty(t, pipe)
+			assert.Equal(t, bson.M{"$match": tt.expectedMatch}, pipe[0])
+		})
+	}
+}
+
+func TestMongoDBRepository_GetPipeline_Operators(t *testing.T) {
+	// no round-trip: the driver connects lazily
+	client, err := mongo.Connect(context.Background(), options.Client().ApplyURI("mongodb://127.0.0.1:37019/replay"))
+	if err != nil {
+		t.Fatalf("unable to create mongo client: %v", err)
+	}
+
+	defer client.Disconnect(context.Background())
+
+	r := db.NewReplayFileMetadataRepository(client, dbName, replay_entity.ReplayFile{}, "replay_file_metadata_query_builder_test")
+
+	ctx := common.WithResourceOwner(context.Background(), common.ResourceOwner{TenantID: uuid.New(), ClientID: uuid.New()})
+
+	tests := []struct {
+		name           string
+		value          common.SearchableValue
+		expectedFilter bson.M
+		expectedErr    bool
+	}{
+		{
+			name:           "Exists Defaults To True",
+			value:          common.SearchableValue{Field: "Error", Operator: common.ExistsOperator},
+			expectedFilter: bson.M{"error": bson.M{"$exists": true}},
+		},
+		{
+			name:           "Exists False",
+			value:          common.SearchableValue{Field: "Error", Operator: common.ExistsOperator, Values: []interface{}{false}},
+			expectedFilter: bson.M{"error": bson.M{"$exists": false}},
+		},
+		{
+			name:           "Between",
+			value:          common.SearchableValue{Field: "Size", Operator: common.BetweenOperator, Values: []interface{}{10, 20}},
+			expectedFilter: bson.M{"size": bson.M{"$gte": 10, "$lte": 20}},
+		},
+		{
+			name:           "StartsWith Is Anchored And Escaped",
+			value:          common.SearchableValue{Field: "InternalURI", Operator: common.StartsWithOperator, Values: []interface{}{"https://cdn.(x)"}},
+			expectedFilter: bson.M{"uri": bson.M{"$regex": `^https://cdn\.\(x\)`}},
+		},
+		{
+			name:           "Contains Is Escaped",
+			value:          common.SearchableValue{Field: "InternalURI", Operator: common.ContainsOperator, Values: []interface{}{".*dem"}},
+			expectedFilter: bson.M{"uri": bson.M{"$regex": `\.\*dem`, "$options": "i"}},
+		},
+		{
+			name:        "Short Unanchored Pattern Is Rejected",
+			value:       common.SearchableValue{Field: "InternalURI", Operator: common.ContainsOperator, Values: []interface{}{"a"}},
+			expectedErr: true,
+		},
+		{
+			name:        "Malformed Range Is Rejected",
+			value:       common.SearchableValue{Field: "Size", Operator: common.BetweenOperator, Values: []interface{}{10}},
+			expectedErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := common.NewSearchByValues(ctx, []common.SearchableValue{tt.value}, common.SearchResultOptions{Limit: 10}, common.ClientApplicationAudienceIDKey)
+
+			if tt.expectedErr {
+				_, err := r.GetPipeline(ctx, s)
+				assert.ErrorIs(t, err, common.ErrInvalidSearch)
+				return
+			}
+
+			pipe, err := r.GetPipeline(ctx, s)
+
+			assert.NoError(t, err)
+
+			match := pipe[0]["$match"].(bson.M)
+			for k, v := range tt.expectedFilter {
+				assert.Equal(t, v, match[k])
+			}
+		})
+	}
+}
+
+func TestMongoDBRepository_GetPipeline_Sort(t *testing.T) {
+	// no round-trip: the driver connects lazily
+	client, err := mongo.Connect(context.Background(), options.Client().ApplyURI("mongodb://127.0.0.1:37019/replay"))
+	if err != nil {
+		t.Fatalf("unable to create mongo client: %v", err)
+	}
+
+	defer client.Disconnect(context.Background())
+
+	r := db.NewReplayFileMetadataRepository(client, dbName, replay_entity.ReplayFile{}, "replay_file_metadata_query_builder_test")
+
+	ctx := common.WithResourceOwner(context.Background(), common.ResourceOwner{TenantID: uuid.New(), ClientID: uuid.New()})
+
+	t.Run("Sorts By Mapped Fields In Order", func(t *testing.T) {
+		s := common.NewSearchByValues(ctx, nil, common.SearchResultOptions{Limit: 10, Sort: []common.SearchSortOption{
+			{Field: "CreatedAt", Direction: common.DescendingIDKey},
+			{Field: "Size", Direction: common.AscendingIDKey},
+		}}, common.ClientApplicationAudienceIDKey)
+
+		pipe, err := r.GetPipeline(ctx, s)
+		assert.NoError(t, err)
+		assert.Contains(t, pipe, bson.M{"$sort": bson.D{{Key: "created_at", Value: common.DescendingIDKey}, {Key: "size", Value: common.AscendingIDKey}}})
+	})
+
+	t.Run("Compile Rejects Fields Not Queryable", func(t *testing.T) {
+		_, err := r.Compile(ctx, nil, common.SearchResultOptions{Limit: 10, Sort: []common.SearchSortOption{{Field: "created_at", Direction: common.AscendingIDKey}}})
+		assert.ErrorContains(t, err, "sorting on field 'created_at' is not permitted")
+	})
+
+	t.Run("Internal Searches Are Checked Too", func(t *testing.T) {
+		s := common.NewSearchByValues(ctx, nil, common.SearchResultOptions{Limit: 10}, common.ClientApplicationAudienceIDKey)
+		s.SortOptions = []common.SearchSortOption{{Field: "Unknown", Direction: common.AscendingIDKey}}
+
+		_, err := r.GetPipeline(ctx, s)
+		assert.ErrorContains(t, err, "sorting on field 'Unknown' is not permitted")
+	})
+}
+
+func TestMongoDBRepository_GetPipeline_CustomFields(t *testing.T) {
+	// no round-trip: the driver connects lazily
+	client, err := mongo.Connect(context.Background(), options.Client().ApplyURI("mongodb://127.0.0.1:37019/replay"))
+	if err != nil {
+		t.Fatalf("unable to create mongo client: %v", err)
+	}
+
+	defer client.Disconnect(context.Background())
+
+	r := db.NewSquadRepository(client, dbName, squad_entities.Squad{}, "squads_query_builder_test")
+
+	ctx := common.WithResourceOwner(context.Background(), common.ResourceOwner{TenantID: uuid.New(), ClientID: uuid.New()})
+
+	s := common.NewSearchByValues(ctx, []common.SearchableValue{
+		{Field: "CustomFields.team_code", Values: []interface{}{"A-1"}},
+	}, common.SearchResultOptions{Limit: 10, Sort: []common.SearchSortOption{
+		{Field: "CustomFields.sponsor_since", Direction: common.DescendingIDKey},
+	}}, common.ClientApplicationAudienceIDKey)
+
+	pipe, err := r.GetPipeline(ctx, s)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	assert.Equal(t, bson.M{"$in": []interface{}{"A-1"}}, pipe[0]["$match"].(bson.M)["custom_fields.team_code"])
+	assert.Contains(t, pipe, bson.M{"$sort": bson.D{{Key: "custom_fields.sponsor_since", Value: common.DescendingIDKey}}})
+
+	// keys are field names, operators can't be injected through them
+	s = common.NewSearchByValues(ctx, []common.SearchableValue{
+		{Field: "CustomFields.$where", Values: []interface{}{"1"}},
+	}, common.SearchResultOptions{Limit: 10}, common.ClientApplicationAudienceIDKey)
+
+	_, err = r.GetPipeline(ctx, s)
+	assert.ErrorIs(t, err, common.ErrInvalidSearch)
+}
+
+func TestMongoDBRepository_GetPipeline_ReplayTags(t *testing.T) {
+	client, err := mongo.Connect(context.Background(), options.Client().ApplyURI("mongodb://127.0.0.1:37019/replay"))
+	if err != nil {
+		t.Fatalf("unable to create mongo client: %v", err)
+	}
+
+	defer client.Disconnect(context.Background())
+
+	r := db.NewPlayerMatchHistoryRepository(client, dbName, replay_entity.PlayerMatchHistory{}, "player_match_history_query_builder_test")
+
+	ctx := common.WithResourceOwner(context.Background(), common.ResourceOwner{TenantID: uuid.New(), ClientID: uuid.New()})
+
+	tags := common.ReplayTagsFilter{Platform: common.ReplayPlatformValveMM, ServerRegion: common.SouthAmerica_RegionIDKey}
+
+	s := common.NewSearchByValues(ctx, tags.SearchableValues("Tags"), common.SearchResultOptions{Limit: 10, Sort: []common.SearchSortOption{
+		{Field: "Tags.Tickrate", Direction: common.DescendingIDKey},
+	}}, common.ClientApplicationAudienceIDKey)
+
+	pipe, err := r.GetPipeline(ctx, s)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	assert.Equal(t, bson.A{
+		bson.M{"tags.platform": bson.M{"$in": []interface{}{common.ReplayPlatformValveMM}}},
+		bson.M{"tags.server_region": bson.M{"$in": []interface{}{common.SouthAmerica_RegionIDKey}}},
+	}, pipe[0]["$match"].(bson.M)["$and"])
+	assert.Contains(t, pipe, bson.M{"$sort": bson.D{{Key: "tags.tickrate", Value: common.DescendingIDKey}}})
+
+	// the options of the bson tags aren't part of the field names
+	name, err := r.GetBSONFieldName("Tags.Tickrate")
+	assert.NoError(t, err)
+	assert.Equal(t, "tags.tickrate", name)
+}
@@ -5,8 +5,10 @@ import (
 	"fmt"
 	"log/slog"
 	"reflect"
+	"regexp"
 	"runtime"
 	"strings"
+	"sync"
 
 	"github.com/google/uuid"
 	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
@@ -44,7 +46,31 @@ func (r *MongoDBRepository[T]) InitQueryableFields(queryableFields map[string]bo
 		r.bsonFieldMappings[k] = v
 	}
 
+	r.precompileFieldNames()
+
 	r.collection = r.mongoClient.Database(r.dbName).Collection(r.collectionName)
+
+	DefaultEntityMetadataRegistry.Register(r.GetEntityMetadata())
+}
+
+// precompileFieldNames resolves the bson name of every queryable and mapped field once, so the mapping cache is only read while
+// serving requests (concurrently).
+func (r *MongoDBRepository[T]) precompileFieldNames() {
+	cache := make(CacheItem, len(r.queryableFields)+len(r.bsonFieldMappings))
+
+	for fieldName := range r.queryableFields {
+		if bsonFieldName, err := r.resolveBSONFieldName(fieldName); err == nil {
+			cache[fieldName] = bsonFieldName
+		}
+	}
+
+	for fieldName := range r.bsonFieldMappings {
+		if bsonFieldName, err := r.resolveBSONFieldName(fieldName); err == nil {
+			cache[fieldName] = bsonFieldName
+		}
+	}
+
+	r.mappingCache[r.entityName] = cache
 }
 
 func (r *MongoDBRepository[T]) GetBSONFieldName(fieldName string) (string, error) {
@@ -52,17 +78,32 @@ func (r *MongoDBRepository[T]) GetBSONFieldName(fieldName string) (string, error
 		return cachedBSONName, nil
 	}
 
+	// not precompiled: resolved on every call rather than written to the shared cache
+	return r.resolveBSONFieldName(fieldName)
+}
+
+func (r *MongoDBRepository[T]) resolveBSONFieldName(fieldName string) (string, error) {
 	fieldParts := strings.Split(fieldName, ".")
 	currentType := r.entityModel
 	bsonFieldName := ""
 
 	for i, part := range fieldParts {
+		// some repositories are built from a pointer to the entity (ie: *iam_entities.User)
+		for currentType.Kind() == reflect.Pointer {
+			currentType = currentType.Elem()
+		}
+
+		if currentType.Kind() != reflect.Struct {
+			return "", fmt.Errorf("field %s (of %s) not found", part, currentType.Name())
+		}
+
 		field, ok := currentType.FieldByName(part)
 		if !ok {
 			return "", fmt.Errorf("field %s (of %s) not found", part, currentType.Name())
 		}
 
-		bsonTag := field.Tag.Get("bson")
+		// without its options (ie: "tags,omitempty")
+		bsonTag, _, _ := strings.Cut(field.Tag.Get("bson"), ",")
 		if bsonTag == "" {
 			return "", fmt.Errorf("field %s (of %s) does not have bson-tag", part, currentType.Name())
 		}
@@ -76,15 +117,16 @@ func (r *MongoDBRepository[T]) GetBSONFieldName(fieldName string) (string, error
 			return bsonFieldName, nil
 		}
 
-		if field.Type.Kind() == reflect.Struct && i < len(fieldParts)-1 {
-			currentType = field.Type
+		// optional (pointer) structs as well
+		fieldType := field.Type
+		for fieldType.Kind() == reflect.Pointer {
+			fieldType = fieldType.Elem()
 		}
-	}
 
-	if _, exists := r.mappingCache[r.entityName]; !exists {
-		r.mappingCache[r.entityName] = make(CacheItem)
+		if fieldType.Kind() == reflect.Struct && i < len(fieldParts)-1 {
+			currentType = fieldType
+		}
 	}
-	r.mappingCache[r.entityName][fieldName] = bsonFieldName
 
 	return bsonFieldName, nil
 }
@@ -100,6 +142,16 @@ func (repo *MongoDBRepository[T]) Compile(ctx context.Context, searchParams []co
 		return nil, fmt.Errorf("error validating result options: %v", err)
 	}
 
+	err = common.ValidateFacetFields(resultOptions.Facets, repo.queryableFields)
+	if err != nil {
+		return nil, fmt.Errorf("error validating facets: %v", err)
+	}
+
+	err = common.ValidateSortOptions(resultOptions.Sort, repo.queryableFields)
+	if err != nil {
+		return nil, fmt.Errorf("error validating sort: %v", err)
+	}
+
 	s := common.NewSearchByAggregation(ctx, searchParams, resultOptions, common.UserAudienceIDKey)
 
 	return &s, nil
@@ -122,22 +174,26 @@ func (repo *MongoDBRepository[T]) ValidateBSONSetup(resultOptions common.SearchR
 		}
 	}
 
-	return nil
+	err := common.ValidateCursorOptions(resultOptions)
+	if err != nil {
+		return err
+	}
+
+	if resultOptions.Cursor != nil && *resultOptions.Cursor != "" {
+		_, err = decodeSearchCursor(*resultOptions.Cursor)
+	}
+
+	return err
 }
 
 func (r *MongoDBRepository[T]) Query(queryCtx context.Context, s common.Search) (*mongo.Cursor, error) {
-	collection := r.mongoClient.Database(r.dbName).Collection(r.collectionName)
-
 	pipe, err := r.GetPipeline(queryCtx, s)
-
-	slog.InfoContext(queryCtx, "Query: built pipeline", "pipeline", pipe)
-
 	if err != nil {
 		slog.ErrorContext(queryCtx, "unable to create query pipeline", "err", err)
 		return nil, err
 	}
 
-	cursor, err := collection.Aggregate(queryCtx, pipe)
+	cursor, err := r.collection.Aggregate(queryCtx, pipe)
 	if err != nil {
 		slog.ErrorContext(queryCtx, "unable to open query cursor", "err", err)
 		return nil, err
@@ -149,13 +205,26 @@ func (r *MongoDBRepository[T]) Query(queryCtx context.Context, s common.Search)
 func (r *MongoDBRepository[T]) GetPipeline(queryCtx context.Context, s common.Search) ([]bson.M, error) {
 	var pipe []bson.M
 
-	pipe, err := r.addMatch(queryCtx, pipe, s)
+	// searches built internally skip Compile, their sort is checked here
+	err := common.ValidateSortOptions(s.SortOptions, r.queryableFields)
+	if err != nil {
+		slog.ErrorContext(queryCtx, "GetPipeline: invalid sort", "error", err)
+		return nil, err
+	}
+
+	pipe, err = r.addMatch(queryCtx, pipe, s)
 
 	if err != nil {
 		slog.ErrorContext(queryCtx, "GetPipeline: unable to build $match stage", "error", err)
 		return nil, err
 	}
 
+	pipe, err = r.addCursor(pipe, s)
+	if err != nil {
+		slog.ErrorContext(queryCtx, "GetPipeline: unable to resume search from cursor", "error", err)
+		return nil, err
+	}
+
 	pipe = r.addProjection(pipe, s)
 	pipe = r.addSort(pipe, s)
 	pipe = r.addSkip(pipe, s)
@@ -167,12 +236,15 @@ func (r *MongoDBRepository[T]) GetPipeline(queryCtx context.Context, s common.Se
 		return nil, err
 	}
 
-	var pipeString string
-	for _, stage := range pipe {
-		pipeString += fmt.Sprintf("%v\n", stage)
-	}
+	// formatting the pipeline costs more than building it, only done when debugging
+	if slog.Default().Enabled(queryCtx, slog.LevelDebug) {
+		var pipeString strings.Builder
+		for _, stage := range pipe {
+			fmt.Fprintf(&pipeString, "%v\n", stage)
+		}
 
-	slog.InfoContext(queryCtx, "GetPipeline: built pipeline", "pipeline", pipeString)
+		slog.DebugContext(queryCtx, "GetPipeline: built pipeline", "pipeline", pipeString.String())
+	}
 
 	return pipe, nil
 }
@@ -200,24 +272,40 @@ func (r *MongoDBRepository[T]) addSkip(pipe []bson.M, s common.Search) []bson.M
 }
 
 func (r *MongoDBRepository[T]) addSort(pipe []bson.M, s common.Search) []bson.M {
-	sortFields := []bson.D{}
-	for _, sortOption := range s.SortOptions {
-		sortFields = append(sortFields, bson.D{{Key: sortOption.Field, Value: sortOption.Direction}})
-	}
-	sortStage := bson.M{"$sort": sortFields}
+	sortFields := r.sortFields(s)
 
-	if (sortFields != nil) && (len(sortFields) > 0) {
-		pipe = append(pipe, sortStage)
+	if len(sortFields) > 0 {
+		pipe = append(pipe, bson.M{"$sort": sortFields})
 	}
 	return pipe
 }
 
 func (r *MongoDBRepository[T]) addMatch(queryCtx context.Context, pipe []bson.M, s common.Search) ([]bson.M, error) {
-	aggregate := bson.M{}
+	if r.queryableFields == nil {
+		panic(fmt.Errorf("queryableFields not initialized in MongoDBRepository of %s", r.entityName))
+	}
+
+	// top level aggregations are combined with AND
+	filters := acquireFilters()
+	defer releaseFilters(filters)
+
 	for _, aggregator := range s.SearchParams {
-		r.setMatchValues(queryCtx, aggregator.Params, &aggregate, aggregator.AggregationClause)
+		filter, err := r.buildAggregationFilter(queryCtx, aggregator, common.AndAggregationClause, 0)
+		if err != nil {
+			return nil, err
+		}
+
+		if filter != nil {
+			*filters = append(*filters, filter)
+		}
+	}
+
+	aggregate := combineFilters(*filters, common.AndAggregationClause)
+	if aggregate == nil {
+		aggregate = bson.M{}
 	}
 
+	// tenancy keys are siblings of the user filter, so they are ANDed with it (even when it is an $or/$nor group)
 	aggregate, err := r.EnsureTenancy(queryCtx, aggregate, s)
 
 	if err != nil {
@@ -229,101 +317,175 @@ func (r *MongoDBRepository[T]) addMatch(queryCtx context.Context, pipe []bson.M,
 	return pipe, nil
 }
 
-func (r *MongoDBRepository[T]) setMatchValues(queryCtx context.Context, params []common.SearchParameter, aggregate *bson.M, clause common.SearchAggregationClause) {
-	if r.queryableFields == nil {
-		panic(fmt.Errorf("queryableFields not initialized in MongoDBRepository of %s", r.entityName))
+// buildAggregationFilter translates a SearchAggregation (and its nested groups) into a mongo filter. Returns nil when the group is empty,
+// and an error wrapping common.ErrInvalidSearch when a value can't be searched (searches built internally skip Compile).
+func (r *MongoDBRepository[T]) buildAggregationFilter(queryCtx context.Context, aggregation common.SearchAggregation, inheritedClause common.SearchAggregationClause, depth int) (bson.M, error) {
+	clause := aggregation.AggregationClause
+	if clause == "" {
+		clause = inheritedClause
 	}
 
-	clauses := bson.A{}
-	for _, p := range params {
-		// Handle ValueParams
-		for _, v := range p.ValueParams {
-			bsonFieldName, err := r.GetBSONFieldNameFromSearchableValue(v)
-			if err != nil {
-				panic(err) // Retain panic for irrecoverable errors
-			}
+	filters := acquireFilters()
+	defer releaseFilters(filters)
 
-			// Check if the prefix is allowed
-			if strings.HasSuffix(v.Field, ".*") {
-				prefix := strings.TrimSuffix(v.Field, ".*")
-				if !isPrefixAllowed(prefix, r.queryableFields) {
-					panic(fmt.Errorf("filtering on fields matching '%s.*' is not permitted", prefix))
-				}
-			}
+	for _, p := range aggregation.Params {
+		filter, err := r.buildParameterFilter(queryCtx, p, clause, depth)
+		if err != nil {
+			return nil, err
+		}
 
-			filter := buildFilterForOperator(v.Operator, v.Values)
-			if filter == nil {
-				continue // Skip this value if not supported
-			}
+		if filter != nil {
+			*filters = append(*filters, filter)
+		}
+	}
 
-			// Build filter based on operator (default to $in if not specified)
-			if strings.HasSuffix(v.Field, ".*") && strings.Contains(bsonFieldName, ".") {
-				// Nested field with wildcard: use $elemMatch
-				clauses = append(clauses, bson.M{bsonFieldName: bson.M{"$elemMatch": filter}})
-			} else {
-				clauses = append(clauses, bson.M{bsonFieldName: filter})
-			}
+	return negateFilter(combineFilters(*filters, clause), aggregation.Negate), nil
+}
 
-			slog.InfoContext(queryCtx, "query: %v, value: %v", bsonFieldName, v.Values)
+func (r *MongoDBRepository[T]) buildParameterFilter(queryCtx context.Context, p common.SearchParameter, inheritedClause common.SearchAggregationClause, depth int) (bson.M, error) {
+	clause := p.AggregationClause
+	if clause == "" {
+		clause = inheritedClause
+	}
+
+	clauses := acquireFilters()
+	defer releaseFilters(clauses)
+
+	// Handle ValueParams
+	for _, v := range p.ValueParams {
+		bsonFieldName, err := r.GetBSONFieldNameFromSearchableValue(v)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", common.ErrInvalidSearch, err)
 		}
 
-		// Handle DateParams
-		for _, d := range p.DateParams {
-			bsonFieldName, err := r.GetBSONFieldName(d.Field)
-			if err != nil {
-				panic(err) // Retain panic for irrecoverable reflection errors
+		// Check if the prefix is allowed
+		if strings.HasSuffix(v.Field, ".*") {
+			prefix := strings.TrimSuffix(v.Field, ".*")
+			if !isPrefixAllowed(prefix, r.queryableFields) {
+				return nil, fmt.Errorf("%w: filtering on fields matching '%s.*' is not permitted", common.ErrInvalidSearch, prefix)
 			}
+		}
 
-			dateFilter := bson.M{}
-			if d.Min != nil {
-				dateFilter["$gte"] = *d.Min
-			}
-			if d.Max != nil {
-				dateFilter["$lte"] = *d.Max
-			}
-			clauses = append(clauses, bson.M{bsonFieldName: dateFilter})
+		// searches built internally skip Compile, so unanchored regex and malformed ranges are rejected here as well
+		if err := common.ValidateOperatorValues(v); err != nil {
+			return nil, fmt.Errorf("%w: %v", common.ErrInvalidSearch, err)
 		}
 
-		// Handle DurationParams (similar to DateParams)
-		for _, dur := range p.DurationParams {
-			bsonFieldName, err := r.GetBSONFieldName(dur.Field)
-			if err != nil {
-				panic(err) // Retain panic for irrecoverable reflection errors
-			}
+		filter := buildFilterForOperator(v.Operator, v.Values)
+		if filter == nil {
+			continue // Skip this value if not supported
+		}
 
-			durationFilter := bson.M{}
-			if dur.Min != nil {
-				durationFilter["$gte"] = *dur.Min
-			}
-			if dur.Max != nil {
-				durationFilter["$lte"] = *dur.Max
-			}
-			clauses = append(clauses, bson.M{bsonFieldName: durationFilter})
+		// Build filter based on operator (default to $in if not specified)
+		if strings.HasSuffix(v.Field, ".*") && strings.Contains(bsonFieldName, ".") {
+			// Nested field with wildcard: use $elemMatch
+			*clauses = append(*clauses, bson.M{bsonFieldName: bson.M{"$elemMatch": filter}})
+		} else {
+			*clauses = append(*clauses, bson.M{bsonFieldName: filter})
+		}
+
+		slog.DebugContext(queryCtx, "buildParameterFilter: value filter", "field", bsonFieldName, "values", v.Values)
+	}
+
+	// Handle DateParams
+	for _, d := range p.DateParams {
+		bsonFieldName, err := r.GetBSONFieldName(d.Field)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", common.ErrInvalidSearch, err)
 		}
 
-		if p.AggregationParams == nil {
-			continue
+		dateFilter := bson.M{}
+		if d.Min != nil {
+			dateFilter["$gte"] = *d.Min
+		}
+		if d.Max != nil {
+			dateFilter["$lte"] = *d.Max
 		}
+		*clauses = append(*clauses, bson.M{bsonFieldName: dateFilter})
+	}
 
-		for i, v := range p.AggregationParams {
-			if i+1 >= MAX_RECURSIVE_DEPTH {
-				slog.WarnContext(queryCtx, "setMatchValue MaxRecursiveDepth exceeded", "depth", i, "MAX_RECURSIVE_DEPTH", MAX_RECURSIVE_DEPTH, "params", p.AggregationParams)
-				break
-			}
+	// Handle DurationParams (similar to DateParams)
+	for _, dur := range p.DurationParams {
+		bsonFieldName, err := r.GetBSONFieldName(dur.Field)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", common.ErrInvalidSearch, err)
+		}
 
-			innerAggregate := bson.M{}
-			r.setMatchValues(queryCtx, v.Params, &innerAggregate, clause)
-			clauses = append(clauses, innerAggregate)
+		durationFilter := bson.M{}
+		if dur.Min != nil {
+			durationFilter["$gte"] = *dur.Min
 		}
+		if dur.Max != nil {
+			durationFilter["$lte"] = *dur.Max
+		}
+		*clauses = append(*clauses, bson.M{bsonFieldName: durationFilter})
 	}
 
-	if len(clauses) > 0 {
-		if clause == common.OrAggregationClause {
-			(*aggregate)["$or"] = clauses
-		} else {
-			(*aggregate)["$and"] = clauses
+	// Handle nested groups
+	for _, v := range p.AggregationParams {
+		if depth+1 >= MAX_RECURSIVE_DEPTH {
+			slog.WarnContext(queryCtx, "buildParameterFilter MaxRecursiveDepth exceeded", "depth", depth+1, "MAX_RECURSIVE_DEPTH", MAX_RECURSIVE_DEPTH, "params", p.AggregationParams)
+			break
+		}
+
+		filter, err := r.buildAggregationFilter(queryCtx, v, clause, depth+1)
+		if err != nil {
+			return nil, err
+		}
+
+		if filter != nil {
+			*clauses = append(*clauses, filter)
 		}
 	}
+
+	return negateFilter(combineFilters(*clauses, clause), p.Negate), nil
+}
+
+// filterBuffers holds the scratch slices collecting the filters of a group while it's built, most groups hold a single filter
+// and don't need one of their own.
+var filterBuffers = sync.Pool{
+	New: func() interface{} {
+		filters := make(bson.A, 0, 8)
+		return &filters
+	},
+}
+
+func acquireFilters() *bson.A {
+	return filterBuffers.Get().(*bson.A)
+}
+
+func releaseFilters(filters *bson.A) {
+	clear(*filters)
+	*filters = (*filters)[:0]
+	filterBuffers.Put(filters)
+}
+
+// combineFilters never retains filters (a pooled scratch slice), groups of 2+ filters get their own copy.
+func combineFilters(filters bson.A, clause common.SearchAggregationClause) bson.M {
+	if len(filters) == 0 {
+		return nil
+	}
+
+	if len(filters) == 1 {
+		return filters[0].(bson.M)
+	}
+
+	combined := make(bson.A, len(filters))
+	copy(combined, filters)
+
+	if clause == common.OrAggregationClause {
+		return bson.M{"$or": combined}
+	}
+
+	return bson.M{"$and": combined}
+}
+
+func negateFilter(filter bson.M, negate bool) bson.M {
+	if filter == nil || !negate {
+		return filter
+	}
+
+	return bson.M{"$nor": bson.A{filter}}
 }
 
 // Helper function to build the filter based on the operator
@@ -342,11 +504,25 @@ func buildFilterForOperator(operator common.SearchOperator, values []interface{}
 	case common.LessThanOrEqualOperator:
 		return bson.M{"$lte": values[0]}
 	case common.ContainsOperator:
-		return bson.M{"$regex": values[0], "$options": "i"}
+		return bson.M{"$regex": regexp.QuoteMeta(fmt.Sprintf("%v", values[0])), "$options": "i"}
 	case common.StartsWithOperator:
-		return bson.M{"$regex": "^" + fmt.Sprintf("%v", values[0]), "$options": "i"}
+		// anchored and case-sensitive so mongo can bound the index scan to the prefix
+		return bson.M{"$regex": "^" + regexp.QuoteMeta(fmt.Sprintf("%v", values[0]))}
 	case common.EndsWithOperator:
-		return bson.M{"$regex": fmt.Sprintf("%v", values[0]) + "$", "$options": "i"}
+		return bson.M{"$regex": regexp.QuoteMeta(fmt.Sprintf("%v", values[0])) + "$", "$options": "i"}
+	case common.ExistsOperator:
+		exists := true
+		if len(values) > 0 {
+			if b, ok := values[0].(bool); ok {
+				exists = b
+			}
+		}
+		return bson.M{"$exists": exists}
+	case common.BetweenOperator:
+		if len(values) < 2 {
+			return nil
+		}
+		return bson.M{"$gte": values[0], "$lte": values[1]}
 	case common.InOperator:
 		return bson.M{"$in": values}
 	case common.NotInOperator:
@@ -377,6 +553,10 @@ func (r *MongoDBRepository[T]) GetBSONFieldNameFromSearchableValue(v common.Sear
 		return bsonFieldName, nil
 	}
 
+	if bsonFieldName, ok := r.customFieldBSONName(v.Field); ok {
+		return bsonFieldName, nil
+	}
+
 	slog.Warn("GetBSONFieldNameFromSearchableValue: field not found in mapping", "field", v.Field, "v", v)
 
 	if v.Field == "" {
@@ -386,6 +566,21 @@ func (r *MongoDBRepository[T]) GetBSONFieldNameFromSearchableValue(v common.Sear
 	return "", fmt.Errorf("field %s not found or not queryable in %s", v.Field, r.entityName)
 }
 
+// customFieldBSONName resolves a custom field searched by its key ("CustomFields.<key>"), on the entities with custom fields.
+func (r *MongoDBRepository[T]) customFieldBSONName(field string) (string, bool) {
+	key, ok := common.CustomFieldKey(field)
+	if !ok {
+		return "", false
+	}
+
+	bsonFieldName, ok := r.bsonFieldMappings[common.CustomFieldsField]
+	if !ok {
+		return "", false
+	}
+
+	return bsonFieldName + "." + key, true
+}
+
 func (r *MongoDBRepository[T]) Create(ctx context.Context, entity *T) (*T, error) {
 	_, err := r.collection.InsertOne(context.TODO(), entity)
 	if err != nil {
@@ -435,8 +630,10 @@ func (r *MongoDBRepository[T]) addProjection(pipe []bson.M, s common.Search) []b
 }
 
 func (r *MongoDBRepository[T]) EnsureTenancy(queryCtx context.Context, agg bson.M, s common.Search) (bson.M, error) {
-	tenantID, ok := queryCtx.Value(common.TenantIDKey).(uuid.UUID)
-	if !ok || tenantID == uuid.Nil {
+	scope, _ := common.GetRequestScope(queryCtx)
+
+	tenantID := scope.TenantID
+	if tenantID == uuid.Nil {
 		return agg, fmt.Errorf("TENANCY.RequestSource: valid tenant_id is required in queryCtx: %#v", queryCtx)
 	}
 
@@ -447,17 +644,17 @@ func (r *MongoDBRepository[T]) EnsureTenancy(queryCtx context.Context, agg bson.
 	}
 
 	agg["resource_owner.tenant_id"] = tenantID
-	slog.InfoContext(queryCtx, "TENANCY.RequestSource: tenant_id", "tenant_id", tenantID)
+	slog.DebugContext(queryCtx, "TENANCY.RequestSource: tenant_id", "tenant_id", tenantID)
 
 	switch s.VisibilityOptions.IntendedAudience {
 	case common.ClientApplicationAudienceIDKey:
-		return ensureClientID(queryCtx, agg, s)
+		return ensureClientID(queryCtx, scope, agg, s)
 
 	case common.GroupAudienceIDKey:
-		return ensureGroupID(queryCtx, agg, s)
+		return ensureGroupID(queryCtx, scope, agg, s)
 
 	case common.UserAudienceIDKey:
-		return ensureUserID(queryCtx, agg, s)
+		return ensureUserID(queryCtx, scope, agg, s)
 
 	case common.TenantAudienceIDKey:
 		slog.WarnContext(queryCtx, "TENANCY.Admin: tenant audience is not allowed", "intendedAudience", s.VisibilityOptions.IntendedAudience)
@@ -469,9 +666,9 @@ func (r *MongoDBRepository[T]) EnsureTenancy(queryCtx context.Context, agg bson.
 	}
 }
 
-func ensureClientID(ctx context.Context, agg bson.M, s common.Search) (bson.M, error) {
-	clientID, ok := ctx.Value(common.ClientIDKey).(uuid.UUID)
-	if !ok || clientID == uuid.Nil {
+func ensureClientID(ctx context.Context, scope common.RequestScope, agg bson.M, s common.Search) (bson.M, error) {
+	clientID := scope.ClientID
+	if clientID == uuid.Nil {
 		return agg, fmt.Errorf("TENANCY.ApplicationLevel: valid client_id is required in queryCtx: %#v", ctx)
 	}
 
@@ -485,14 +682,14 @@ func ensureClientID(ctx context.Context, agg bson.M, s common.Search) (bson.M, e
 
 	agg["resource_owner.client_id"] = clientID
 
-	slog.InfoContext(ctx, "TENANCY.ApplicationLevel: client_id", "client_id", clientID)
+	slog.DebugContext(ctx, "TENANCY.ApplicationLevel: client_id", "client_id", clientID)
 
 	return agg, nil
 }
 
-func ensureGroupID(ctx context.Context, agg bson.M, s common.Search) (bson.M, error) {
-	groupID, ok := ctx.Value(common.GroupIDKey).(uuid.UUID)
-	if !ok || groupID == uuid.Nil {
+func ensureGroupID(ctx context.Context, scope common.RequestScope, agg bson.M, s common.Search) (bson.M, error) {
+	groupID := scope.GroupID
+	if groupID == uuid.Nil {
 		return agg, fmt.Errorf("TENANCY.GroupLevel: valid group_id is required in queryCtx: %#v", ctx)
 	}
 
@@ -505,14 +702,14 @@ func ensureGroupID(ctx context.Context, agg bson.M, s common.Search) (bson.M, er
 	}
 
 	agg["resource_owner.group_id"] = groupID
-	slog.InfoContext(ctx, "TENANCY.GroupLevel: group_id", "group_id", groupID)
+	slog.DebugContext(ctx, "TENANCY.GroupLevel: group_id", "group_id", groupID)
 
 	return agg, nil
 }
 
-func ensureUserID(ctx context.Context, agg bson.M, s common.Search) (bson.M, error) {
-	userID, ok := ctx.Value(common.UserIDKey).(uuid.UUID)
-	if !ok || userID == uuid.Nil {
+func ensureUserID(ctx context.Context, scope common.RequestScope, agg bson.M, s common.Search) (bson.M, error) {
+	userID := scope.UserID
+	if userID == uuid.Nil {
 		return agg, fmt.Errorf("TENANCY.EndUser: valid user_id is required in queryCtx: %#v", ctx)
 	}
 
@@ -526,7 +723,7 @@ func ensureUserID(ctx context.Context, agg bson.M, s common.Search) (bson.M, err
 
 	agg["resource_owner.user_id"] = userID
 
-	slog.InfoContext(ctx, "TENANCY.EndUser: user_id", "user_id", userID)
+	slog.DebugContext(ctx, "TENANCY.EndUser: user_id", "user_id", userID)
 
 	return agg, nil
 }
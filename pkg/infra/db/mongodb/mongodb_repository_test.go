@@ -76,9 +76,7 @@ func Test_Mongo_QueryBuilder(t *testing.T) {
 		t.Fatalf("expected bsonFieldName, got %s", fieldName)
 	}
 
-	ctx := context.WithValue(context.Background(), common.TenantIDKey, common.TeamPROTenantID)
-	ctx = context.WithValue(ctx, common.ClientIDKey, common.TeamPROAppClientID)
-	ctx = context.WithValue(ctx, common.UserIDKey, uuid.New())
+	ctx := common.WithResourceOwner(context.Background(), common.ResourceOwner{TenantID: common.TeamPROTenantID, ClientID: common.TeamPROAppClientID, UserID: uuid.New()})
 
 	s := common.NewSearchByID(ctx, uuid.New(), common.ClientApplicationAudienceIDKey)
 
@@ -134,10 +132,7 @@ func TestMongoDBRepository_Query(t *testing.T) {
 	repo := db.NewReplayFileMetadataRepository(client, dbName, replay_entity.ReplayFile{}, collectionName)
 
 	setContextWithValues := func(ctx context.Context, tenantID, clientID, groupID, userID uuid.UUID) context.Context {
-		ctx = context.WithValue(ctx, common.TenantIDKey, tenantID)
-		ctx = context.WithValue(ctx, common.ClientIDKey, clientID)
-		ctx = context.WithValue(ctx, common.UserIDKey, userID)
-		ctx = context.WithValue(ctx, common.GroupIDKey, groupID)
+		ctx = common.WithResourceOwner(ctx, common.ResourceOwner{TenantID: tenantID, ClientID: clientID, UserID: userID, GroupID: groupID})
 		return ctx
 	}
 
@@ -220,7 +215,7 @@ func TestMongoDBRepository_Query(t *testing.T) {
 		expectedResults   []replay_entity.ReplayFile
 		expectedError     error
 		mockData          []replay_entity.ReplayFile
-		contextValues     map[interface{}]uuid.UUID
+		resourceOwner     common.ResourceOwner
 		maxRecursiveDepth int
 	}{
 		{
@@ -233,7 +228,7 @@ func TestMongoDBRepository_Query(t *testing.T) {
 			),
 			expectedResults: sampleData[:2],
 			mockData:        sampleData,
-			contextValues:   map[interface{}]uuid.UUID{common.TenantIDKey: tenantID, common.ClientIDKey: clientID, common.UserIDKey: userID},
+			resourceOwner:   common.ResourceOwner{TenantID: tenantID, ClientID: clientID, UserID: userID},
 		},
 		{
 			name: "Valid Query - Header Wildcard (User Level)",
@@ -245,7 +240,7 @@ func TestMongoDBRepository_Query(t *testing.T) {
 			),
 			expectedResults: sampleData[0:1],
 			mockData:        sampleData,
-			contextValues:   map[interface{}]uuid.UUID{common.TenantIDKey: tenantID, common.ClientIDKey: clientID, common.UserIDKey: userID},
+			resourceOwner:   common.ResourceOwner{TenantID: tenantID, ClientID: clientID, UserID: userID},
 		},
 		{
 			name: "Valid Query - Header Wildcard (Client Level)",
@@ -257,7 +252,7 @@ func TestMongoDBRepository_Query(t *testing.T) {
 			),
 			expectedResults: sampleData[0:1],
 			mockData:        sampleData,
-			contextValues:   map[interface{}]uuid.UUID{common.TenantIDKey: tenantID, common.ClientIDKey: clientID},
+			resourceOwner:   common.ResourceOwner{TenantID: tenantID, ClientID: clientID},
 		},
 		{
 			name: "Valid Query - Date Range",
@@ -276,7 +271,7 @@ func TestMongoDBRepository_Query(t *testing.T) {
 			),
 			expectedResults: sampleData[2:3],
 			mockData:        sampleData,
-			contextValues:   map[interface{}]uuid.UUID{common.TenantIDKey: tenantID, common.ClientIDKey: clientID, common.UserIDKey: userID},
+			resourceOwner:   common.ResourceOwner{TenantID: tenantID, ClientID: clientID, UserID: userID},
 		},
 		// 1. Basic Valid Query - Filter by GameID
 		{
@@ -289,11 +284,7 @@ func TestMongoDBRepository_Query(t *testing.T) {
 			),
 			expectedResults: sampleData[:2], // Both CS2 games
 			mockData:        sampleData,
-			contextValues: map[interface{}]uuid.UUID{
-				common.TenantIDKey: tenantID,
-				common.ClientIDKey: clientID,
-				common.UserIDKey:   userID,
-			},
+			resourceOwner:   common.ResourceOwner{TenantID: tenantID, ClientID: clientID, UserID: userID},
 		},
 
 		// 2. Nested Field Query - Header.Filestamp
@@ -307,7 +298,7 @@ func TestMongoDBRepository_Query(t *testing.T) {
 			),
 			expectedResults: sampleData[:1], // First game only
 			mockData:        sampleData,
-			contextValues:   map[interface{}]uuid.UUID{common.TenantIDKey: tenantID, common.ClientIDKey: clientID, common.UserIDKey: userID},
+			resourceOwner:   common.ResourceOwner{TenantID: tenantID, ClientID: clientID, UserID: userID},
 		},
 
 		// 3. Multiple Values - Filtering by NetworkID
@@ -321,7 +312,7 @@ func TestMongoDBRepository_Query(t *testing.T) {
 			),
 			expectedResults: sampleData, // All games match
 			mockData:        sampleData,
-			contextValues:   map[interface{}]uuid.UUID{common.TenantIDKey: tenantID, common.ClientIDKey: clientID, common.UserIDKey: userID},
+			resourceOwner:   common.ResourceOwner{TenantID: tenantID, ClientID: clientID, UserID: userID},
 		},
 
 		// 5. String Field - Filtering by Error (Contains) - NOT TESTED due to no sampleData containing Error value
@@ -337,7 +328,7 @@ func TestMongoDBRepository_Query(t *testing.T) {
 			),
 			expectedResults: sampleData[0:0],
 			mockData:        sampleData,
-			contextValues:   map[interface{}]uuid.UUID{common.TenantIDKey: tenantID, common.ClientIDKey: clientID, common.UserIDKey: userID},
+			resourceOwner:   common.ResourceOwner{TenantID: tenantID, ClientID: clientID, UserID: userID},
 		},
 
 		// 6. Date Range Query - CreatedAt
@@ -353,7 +344,7 @@ func TestMongoDBRepository_Query(t *testing.T) {
 			),
 			expectedResults: sampleData[2:],
 			mockData:        sampleData,
-			contextValues:   map[interface{}]uuid.UUID{common.TenantIDKey: tenantID, common.ClientIDKey: clientID},
+			resourceOwner:   common.ResourceOwner{TenantID: tenantID, ClientID: clientID},
 		},
 		// 7. Boolean Field Query - Filtering by Status
 		{
@@ -366,7 +357,7 @@ func TestMongoDBRepository_Query(t *testing.T) {
 			),
 			expectedResults: sampleData[0:0],
 			mockData:        sampleData,
-			contextValues:   map[interface{}]uuid.UUID{common.TenantIDKey: tenantID, common.ClientIDKey: clientID},
+			resourceOwner:   common.ResourceOwner{TenantID: tenantID, ClientID: clientID},
 		},
 
 		{
@@ -379,7 +370,7 @@ func TestMongoDBRepository_Query(t *testing.T) {
 			),
 			expectedResults: sampleData[0:0],
 			mockData:        sampleData,
-			contextValues:   map[interface{}]uuid.UUID{common.TenantIDKey: tenantID, common.ClientIDKey: clientID, common.UserIDKey: userID},
+			resourceOwner:   common.ResourceOwner{TenantID: tenantID, ClientID: clientID, UserID: userID},
 		},
 		{
 			name: "Numeric Filter - Size (Greater Than)",
@@ -393,7 +384,7 @@ func TestMongoDBRepository_Query(t *testing.T) {
 			),
 			expectedResults: sampleData[0:1],
 			mockData:        sampleData,
-			contextValues:   map[interface{}]uuid.UUID{common.TenantIDKey: tenantID, common.ClientIDKey: clientID},
+			resourceOwner:   common.ResourceOwner{TenantID: tenantID, ClientID: clientID},
 		},
 		{
 			name: "Numeric Filter - Size (Less Than)",
@@ -407,7 +398,7 @@ func TestMongoDBRepository_Query(t *testing.T) {
 			),
 			expectedResults: sampleData[1:],
 			mockData:        sampleData,
-			contextValues:   map[interface{}]uuid.UUID{common.TenantIDKey: tenantID, common.ClientIDKey: clientID},
+			resourceOwner:   common.ResourceOwner{TenantID: tenantID, ClientID: clientID},
 		},
 		{
 			name: "Numeric Filter - Size (Equals)",
@@ -421,7 +412,7 @@ func TestMongoDBRepository_Query(t *testing.T) {
 			),
 			expectedResults: sampleData[1:],
 			mockData:        sampleData,
-			contextValues:   map[interface{}]uuid.UUID{common.TenantIDKey: tenantID, common.ClientIDKey: clientID},
+			resourceOwner:   common.ResourceOwner{TenantID: tenantID, ClientID: clientID},
 		},
 
 		// 5. String Field - Filtering (All variations)
@@ -437,37 +428,36 @@ func TestMongoDBRepository_Query(t *testing.T) {
 			),
 			expectedResults: sampleData[0:1],
 			mockData:        sampleData,
-			contextValues:   map[interface{}]uuid.UUID{common.TenantIDKey: tenantID, common.ClientIDKey: clientID},
-		},
-
-		// {
-		// 	name: "Tenancy with OR Aggregation",
-		// 	search: common.NewSearchByValues(
-		// 		setContextWithValues(context.Background(), tenantID, clientID, uuid.Nil, uuid.Nil),
-		// 		[]common.SearchableValue{
-		// 			[]common.SearchableValue{
-		// 				{Field: "GameID", Values: []interface{}{common.CS2_GAME_ID}},
-		// 				{Field: "NetworkID", Values: []interface{}{common.FaceItNetworkIDKey}},
-		// 			},
-		// 		},
-		// 		common.SearchResultOptions{Limit: 10},
-		// 		common.ClientApplicationAudienceIDKey,
-		// 	),
-		// 	expectedResults: []replay_entity.ReplayFile{sampleData[0], sampleData[1]}, // Only User 1's games
-		// 	mockData:        sampleData,
-		// 	contextValues: map[interface{}]uuid.UUID{
-		// 		common.TenantIDKey: tenantID,
-		// 		common.ClientIDKey: clientID,
-		// 		common.UserIDKey:   userID,
-		// 	},
-		// },
+			resourceOwner:   common.ResourceOwner{TenantID: tenantID, ClientID: clientID},
+		},
+
+		{
+			name: "Tenancy with OR Aggregation",
+			search: common.NewSearchByAggregation(
+				setContextWithValues(context.Background(), tenantID, clientID, uuid.Nil, uuid.Nil),
+				[]common.SearchAggregation{
+					{
+						AggregationClause: common.OrAggregationClause,
+						Params: []common.SearchParameter{
+							{ValueParams: []common.SearchableValue{{Field: "GameID", Values: []interface{}{common.VLRNT_GAME_ID}}}},
+							{ValueParams: []common.SearchableValue{{Field: "NetworkID", Values: []interface{}{common.SteamNetworkIDKey}}}},
+						},
+					},
+				},
+				common.SearchResultOptions{Limit: 10},
+				common.ClientApplicationAudienceIDKey,
+			),
+			expectedResults: []replay_entity.ReplayFile{sampleData[0], sampleData[2]}, // Steam games or Valorant games
+			mockData:        sampleData,
+			resourceOwner:   common.ResourceOwner{TenantID: tenantID, ClientID: clientID},
+		},
 	}
 
 	collection := client.Database(dbName).Collection(collectionName)
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			ctx := setContextWithValues(context.Background(), tt.contextValues[common.TenantIDKey], tt.contextValues[common.ClientIDKey], tt.contextValues[common.GroupIDKey], tt.contextValues[common.UserIDKey])
+			ctx := common.WithResourceOwner(context.Background(), tt.resourceOwner)
 
 			data := make([]interface{}, len(tt.mockData))
 
@@ -713,14 +703,6 @@ func TestMongoDBRepository_EnsureTenancy(t *testing.T) {
 	collectionName := "replay_files"
 	repo := db.NewReplayFileMetadataRepository(client, dbName, replay_entity.ReplayFile{}, collectionName)
 
-	setContextWithValues := func(ctx context.Context, tenantID, clientID, groupID, userID uuid.UUID) context.Context {
-		ctx = context.WithValue(ctx, common.TenantIDKey, tenantID)
-		ctx = context.WithValue(ctx, common.ClientIDKey, clientID)
-		ctx = context.WithValue(ctx, common.UserIDKey, userID)
-		ctx = context.WithValue(ctx, common.GroupIDKey, groupID)
-		return ctx
-	}
-
 	tenantID := uuid.New()
 	clientID := uuid.New()
 	userID := uuid.New()
@@ -733,7 +715,7 @@ func TestMongoDBRepository_EnsureTenancy(t *testing.T) {
 		expectedAgg       bson.M
 		expectedError     error
 		expectedErrorPart string
-		contextValues     map[interface{}]uuid.UUID
+		resourceOwner     common.ResourceOwner
 		maxRecursiveDepth int
 	}{
 		{
@@ -742,7 +724,7 @@ func TestMongoDBRepository_EnsureTenancy(t *testing.T) {
 			search:        common.Search{VisibilityOptions: common.SearchVisibilityOptions{IntendedAudience: common.ClientApplicationAudienceIDKey, RequestSource: common.ResourceOwner{TenantID: tenantID, ClientID: clientID}}},
 			expectedAgg:   bson.M{"resource_owner.tenant_id": tenantID, "resource_owner.client_id": clientID},
 			expectedError: nil,
-			contextValues: map[interface{}]uuid.UUID{common.TenantIDKey: tenantID, common.ClientIDKey: clientID},
+			resourceOwner: common.ResourceOwner{TenantID: tenantID, ClientID: clientID},
 		},
 		{
 			name:          "Success - GroupAudienceIDKey",
@@ -750,7 +732,7 @@ func TestMongoDBRepository_EnsureTenancy(t *testing.T) {
 			search:        common.Search{VisibilityOptions: common.SearchVisibilityOptions{IntendedAudience: common.GroupAudienceIDKey, RequestSource: common.ResourceOwner{TenantID: tenantID, GroupID: groupID}}},
 			expectedAgg:   bson.M{"resource_owner.tenant_id": tenantID, "resource_owner.group_id": groupID},
 			expectedError: nil,
-			contextValues: map[interface{}]uuid.UUID{common.TenantIDKey: tenantID, common.GroupIDKey: groupID},
+			resourceOwner: common.ResourceOwner{TenantID: tenantID, GroupID: groupID},
 		},
 		{
 			name:          "Success - UserAudienceIDKey",
@@ -758,7 +740,7 @@ func TestMongoDBRepository_EnsureTenancy(t *testing.T) {
 			search:        common.Search{VisibilityOptions: common.SearchVisibilityOptions{IntendedAudience: common.UserAudienceIDKey, RequestSource: common.ResourceOwner{TenantID: tenantID, UserID: userID}}},
 			expectedAgg:   bson.M{"resource_owner.tenant_id": tenantID, "resource_owner.user_id": userID},
 			expectedError: nil,
-			contextValues: map[interface{}]uuid.UUID{common.TenantIDKey: tenantID, common.UserIDKey: userID},
+			resourceOwner: common.ResourceOwner{TenantID: tenantID, UserID: userID},
 		},
 		{
 			name:              "Error - Empty TenantID in Search",
@@ -766,7 +748,7 @@ func TestMongoDBRepository_EnsureTenancy(t *testing.T) {
 			search:            common.Search{VisibilityOptions: common.SearchVisibilityOptions{RequestSource: common.ResourceOwner{}}},
 			expectedAgg:       bson.M{},
 			expectedErrorPart: "TENANCY.RequestSource: valid tenant_id is required in queryCtx",
-			contextValues:     map[interface{}]uuid.UUID{},
+			resourceOwner:     common.ResourceOwner{},
 		},
 		{
 			name:              "Error - Empty ClientID in Search",
@@ -774,7 +756,7 @@ func TestMongoDBRepository_EnsureTenancy(t *testing.T) {
 			search:            common.Search{VisibilityOptions: common.SearchVisibilityOptions{IntendedAudience: common.ClientApplicationAudienceIDKey, RequestSource: common.ResourceOwner{TenantID: tenantID}}},
 			expectedAgg:       bson.M{},
 			expectedErrorPart: "TENANCY.ApplicationLevel: valid client_id is required in queryCtx",
-			contextValues:     map[interface{}]uuid.UUID{common.TenantIDKey: tenantID},
+			resourceOwner:     common.ResourceOwner{TenantID: tenantID},
 		},
 		{
 			name:              "Error - Empty GroupID in Search",
@@ -782,7 +764,7 @@ func TestMongoDBRepository_EnsureTenancy(t *testing.T) {
 			search:            common.Search{VisibilityOptions: common.SearchVisibilityOptions{IntendedAudience: common.GroupAudienceIDKey, RequestSource: common.ResourceOwner{TenantID: tenantID}}},
 			expectedAgg:       bson.M{},
 			expectedErrorPart: "TENANCY.GroupLevel: valid group_id is required in queryCtx",
-			contextValues:     map[interface{}]uuid.UUID{common.TenantIDKey: tenantID},
+			resourceOwner:     common.ResourceOwner{TenantID: tenantID},
 		},
 		{
 			name:              "Error - Empty UserID in Search",
@@ -790,7 +772,7 @@ func TestMongoDBRepository_EnsureTenancy(t *testing.T) {
 			search:            common.Search{VisibilityOptions: common.SearchVisibilityOptions{IntendedAudience: common.UserAudienceIDKey, RequestSource: common.ResourceOwner{TenantID: tenantID}}},
 			expectedAgg:       bson.M{},
 			expectedErrorPart: "TENANCY.EndUser: valid user_id is required in queryCtx",
-			contextValues:     map[interface{}]uuid.UUID{common.TenantIDKey: tenantID},
+			resourceOwner:     common.ResourceOwner{TenantID: tenantID},
 		},
 		{
 			name:              "Error - No Audience Provided",
@@ -798,7 +780,7 @@ func TestMongoDBRepository_EnsureTenancy(t *testing.T) {
 			search:            common.Search{VisibilityOptions: common.SearchVisibilityOptions{RequestSource: common.ResourceOwner{TenantID: tenantID, ClientID: clientID}}},
 			expectedAgg:       bson.M{},
 			expectedErrorPart: "TENANCY.Unknown: intended audience",
-			contextValues:     map[interface{}]uuid.UUID{common.TenantIDKey: tenantID, common.ClientIDKey: clientID},
+			resourceOwner:     common.ResourceOwner{TenantID: tenantID, ClientID: clientID},
 		},
 	}
 	// {
@@ -807,7 +789,7 @@ func TestMongoDBRepository_EnsureTenancy(t *testing.T) {
 	// 	search:        common.Search{VisibilityOptions: common.SearchVisibilityOptions{IntendedAudience: common.GroupAudienceIDKey, RequestSource: common.ResourceOwner{TenantID: uuid.New(), GroupID: uuid.New()}}},
 	// 	expectedAgg:   bson.M{"resource_owner.tenant_id": uuid.UUID{}, "resource_owner.group_id": uuid.UUID{}},
 	// 	expectedError: nil,
-	// 	contextValues: map[interface{}]uuid.UUID{common.TenantIDKey: uuid.Nil, common.GroupIDKey: uuid.Nil},
+	// 	resourceOwner: common.ResourceOwner{TenantID: uuid.Nil, GroupID: uuid.Nil},
 	// },
 	// {
 	// 	name:          "Success - UserAudienceIDKey",
@@ -815,7 +797,7 @@ func TestMongoDBRepository_EnsureTenancy(t *testing.T) {
 	// 	search:        common.Search{VisibilityOptions: common.SearchVisibilityOptions{IntendedAudience: common.UserAudienceIDKey, RequestSource: common.ResourceOwner{TenantID: uuid.New(), UserID: uuid.New()}}},
 	// 	expectedAgg:   bson.M{"resource_owner.tenant_id": uuid.Nil, "resource_owner.user_id": uuid.Nil},
 	// 	expectedError: nil,
-	// 	contextValues: map[interface{}]uuid.UUID{common.TenantIDKey: uuid.Nil, common.UserIDKey: uuid.Nil},
+	// 	resourceOwner: common.ResourceOwner{TenantID: uuid.Nil, UserID: uuid.Nil},
 	// },
 	// // Error cases
 	// {
@@ -824,14 +806,14 @@ func TestMongoDBRepository_EnsureTenancy(t *testing.T) {
 	// 	search:            common.Search{VisibilityOptions: common.SearchVisibilityOptions{RequestSource: common.ResourceOwner{}}},
 	// 	expectedAgg:       bson.M{},
 	// 	expectedError:     fmt.Errorf("tenant_id not found in search context"),
-	// 	contextValues:     map[interface{}]uuid.UUID{},
+	// 	resourceOwner:     common.ResourceOwner{},
 	// 	maxRecursiveDepth: 1,
 	// },
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
 			// set context
-			ctx := setContextWithValues(context.Background(), tc.contextValues[common.TenantIDKey], tc.contextValues[common.ClientIDKey], tc.contextValues[common.GroupIDKey], tc.contextValues[common.UserIDKey])
+			ctx := common.WithResourceOwner(context.Background(), tc.resourceOwner)
 			result, err := repo.EnsureTenancy(ctx, tc.agg, tc.search)
 
 			if tc.expectedError != nil {
@@ -0,0 +1,92 @@
+package db
+
+import (
+	"context"
+	"log/slog"
+	"reflect"
+
+	"go.mongodb.org/mongo-driver/mongo"
+
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	notification_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/notification/entities"
+)
+
+type NotificationRepository struct {
+	MongoDBRepository[notification_entities.Notification]
+}
+
+func NewNotificationRepository(client *mongo.Client, dbName string, entityType notification_entities.Notification, collectionName string) *NotificationRepository {
+	repo := MongoDBRepository[notification_entities.Notification]{
+		mongoClient:       client,
+		dbName:            dbName,
+		mappingCache:      make(map[string]CacheItem),
+		entityModel:       reflect.TypeOf(entityType),
+		bsonFieldMappings: make(map[string]string),
+		collectionName:    collectionName,
+		entityName:        reflect.TypeOf(entityType).Name(),
+		queryableFields:   make(map[string]bool),
+	}
+
+	repo.InitQueryableFields(map[string]bool{
+		"ID":            true,
+		"UserID":        true,
+		"Type":          true,
+		"Title":         true,
+		"Body":          true,
+		"Data":          true,
+		"Reference":     true,
+		"Deliveries":    true,
+		"ReadAt":        true,
+		"ResourceOwner": true,
+		"CreatedAt":     true,
+		"UpdatedAt":     true,
+	}, map[string]string{
+		"ID":                     "_id",
+		"UserID":                 "user_id",
+		"Type":                   "type",
+		"Title":                  "title",
+		"Body":                   "body",
+		"Data":                   "data",
+		"Reference":              "reference",
+		"Deliveries":             "deliveries",
+		"ReadAt":                 "read_at",
+		"ResourceOwner":          "resource_owner",
+		"CreatedAt":              "created_at",
+		"UpdatedAt":              "updated_at",
+		"ResourceOwner.TenantID": "resource_owner.tenant_id",
+		"ResourceOwner.UserID":   "resource_owner.user_id",
+		"ResourceOwner.GroupID":  "resource_owner.group_id",
+		"ResourceOwner.ClientID": "resource_owner.client_id",
+	})
+
+	return &NotificationRepository{
+		repo,
+	}
+}
+
+func (r *NotificationRepository) Search(ctx context.Context, s common.Search) ([]notification_entities.Notification, error) {
+	cursor, err := r.Query(ctx, s)
+	if cursor != nil {
+		defer cursor.Close(ctx)
+	}
+
+	if err != nil {
+		slog.ErrorContext(ctx, "error querying notifications", "err", err)
+		return nil, err
+	}
+
+	notifications := make([]notification_entities.Notification, 0)
+	for cursor.Next(ctx) {
+		var notification notification_entities.Notification
+		err := cursor.Decode(&notification)
+
+		if err != nil {
+			slog.ErrorContext(ctx, "error decoding notification", "err", err)
+			return nil, err
+		}
+
+		notifications = append(notifications, notification)
+	}
+
+	return notifications, nil
+}
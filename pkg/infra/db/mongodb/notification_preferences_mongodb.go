@@ -0,0 +1,98 @@
+package db
+
+import (
+	"context"
+	"log/slog"
+	"reflect"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	notification_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/notification/entities"
+)
+
+type NotificationPreferencesRepository struct {
+	MongoDBRepository[notification_entities.NotificationPreferences]
+}
+
+func NewNotificationPreferencesRepository(client *mongo.Client, dbName string, entityType notification_entities.NotificationPreferences, collectionName string) *NotificationPreferencesRepository {
+	repo := MongoDBRepository[notification_entities.NotificationPreferences]{
+		mongoClient:       client,
+		dbName:            dbName,
+		mappingCache:      make(map[string]CacheItem),
+		entityModel:       reflect.TypeOf(entityType),
+		bsonFieldMappings: make(map[string]string),
+		collectionName:    collectionName,
+		entityName:        reflect.TypeOf(entityType).Name(),
+		queryableFields:   make(map[string]bool),
+		collection:        client.Database(dbName).Collection(collectionName),
+	}
+
+	repo.InitQueryableFields(map[string]bool{
+		"ID":                true,
+		"UserID":            true,
+		"Channels":          true,
+		"Email":             true,
+		"DiscordWebhookURL": true,
+		"ResourceOwner":     true,
+		"CreatedAt":         true,
+		"UpdatedAt":         true,
+	}, map[string]string{
+		"ID":                     "_id",
+		"UserID":                 "user_id",
+		"Channels":               "channels",
+		"Email":                  "email",
+		"DiscordWebhookURL":      "discord_webhook_url",
+		"ResourceOwner":          "resource_owner",
+		"CreatedAt":              "created_at",
+		"UpdatedAt":              "updated_at",
+		"ResourceOwner.TenantID": "resource_owner.tenant_id",
+		"ResourceOwner.UserID":   "resource_owner.user_id",
+		"ResourceOwner.GroupID":  "resource_owner.group_id",
+		"ResourceOwner.ClientID": "resource_owner.client_id",
+	})
+
+	return &NotificationPreferencesRepository{
+		repo,
+	}
+}
+
+func (r *NotificationPreferencesRepository) Search(ctx context.Context, s common.Search) ([]notification_entities.NotificationPreferences, error) {
+	cursor, err := r.Query(ctx, s)
+	if cursor != nil {
+		defer cursor.Close(ctx)
+	}
+
+	if err != nil {
+		slog.ErrorContext(ctx, "error querying notification preferences", "err", err)
+		return nil, err
+	}
+
+	preferences := make([]notification_entities.NotificationPreferences, 0)
+	for cursor.Next(ctx) {
+		var p notification_entities.NotificationPreferences
+		err := cursor.Decode(&p)
+
+		if err != nil {
+			slog.ErrorContext(ctx, "error decoding notification preferences", "err", err)
+			return nil, err
+		}
+
+		preferences = append(preferences, p)
+	}
+
+	return preferences, nil
+}
+
+// Save replaces the preferences of the user (the ID is stable per user).
+func (r *NotificationPreferencesRepository) Save(ctx context.Context, preferences *notification_entities.NotificationPreferences) (*notification_entities.NotificationPreferences, error) {
+	_, err := r.collection.ReplaceOne(ctx, bson.M{"_id": preferences.ID}, preferences, options.Replace().SetUpsert(true))
+	if err != nil {
+		slog.ErrorContext(ctx, "unable to save notification preferences", "err", err, "userID", preferences.UserID)
+		return nil, err
+	}
+
+	return preferences, nil
+}
@@ -0,0 +1,122 @@
+package db
+
+import (
+	"context"
+	"log/slog"
+	"reflect"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	badge_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/badge/entities"
+)
+
+type PlayerBadgeRepository struct {
+	MongoDBRepository[badge_entities.PlayerBadge]
+}
+
+func NewPlayerBadgeRepository(client *mongo.Client, dbName string, entityType badge_entities.PlayerBadge, collectionName string) *PlayerBadgeRepository {
+	repo := MongoDBRepository[badge_entities.PlayerBadge]{
+		mongoClient:       client,
+		dbName:            dbName,
+		mappingCache:      make(map[string]CacheItem),
+		entityModel:       reflect.TypeOf(entityType),
+		bsonFieldMappings: make(map[string]string),
+		collectionName:    collectionName,
+		entityName:        reflect.TypeOf(entityType).Name(),
+		queryableFields:   make(map[string]bool),
+		collection:        client.Database(dbName).Collection(collectionName),
+	}
+
+	repo.InitQueryableFields(map[string]bool{
+		"ID":              true,
+		"BadgeID":         true,
+		"GameID":          true,
+		"PlayerID":        true,
+		"NetworkPlayerID": true,
+		"Key":             true,
+		"Name":            true,
+		"Description":     true,
+		"ImageURL":        true,
+		"MatchID":         true,
+		"ResourceOwner":   true,
+		"AwardedAt":       true,
+	}, map[string]string{
+		"ID":                     "_id",
+		"BadgeID":                "badge_id",
+		"GameID":                 "game_id",
+		"PlayerID":               "player_id",
+		"NetworkPlayerID":        "network_player_id",
+		"Key":                    "key",
+		"Name":                   "name",
+		"Description":            "description",
+		"ImageURL":               "image_url",
+		"MatchID":                "match_id",
+		"ResourceOwner":          "resource_owner",
+		"AwardedAt":              "awarded_at",
+		"ResourceOwner.TenantID": "resource_owner.tenant_id",
+		"ResourceOwner.UserID":   "resource_owner.user_id",
+		"ResourceOwner.GroupID":  "resource_owner.group_id",
+		"ResourceOwner.ClientID": "resource_owner.client_id",
+	})
+
+	return &PlayerBadgeRepository{
+		repo,
+	}
+}
+
+func (r *PlayerBadgeRepository) Search(ctx context.Context, s common.Search) ([]badge_entities.PlayerBadge, error) {
+	cursor, err := r.Query(ctx, s)
+	if cursor != nil {
+		defer cursor.Close(ctx)
+	}
+
+	if err != nil {
+		slog.ErrorContext(ctx, "error querying player badges", "err", err)
+		return nil, err
+	}
+
+	badges := make([]badge_entities.PlayerBadge, 0)
+	for cursor.Next(ctx) {
+		var badge badge_entities.PlayerBadge
+		err := cursor.Decode(&badge)
+
+		if err != nil {
+			slog.ErrorContext(ctx, "error decoding player badge", "err", err)
+			return nil, err
+		}
+
+		badges = append(badges, badge)
+	}
+
+	return badges, nil
+}
+
+// Award inserts the badges missing ($setOnInsert), the badges a player already has keep the match and date that first earned them.
+func (r *PlayerBadgeRepository) Award(ctx context.Context, badges []badge_entities.PlayerBadge) ([]badge_entities.PlayerBadge, error) {
+	if len(badges) == 0 {
+		return []badge_entities.PlayerBadge{}, nil
+	}
+
+	models := make([]mongo.WriteModel, 0, len(badges))
+	for _, badge := range badges {
+		models = append(models, mongo.NewUpdateOneModel().SetFilter(bson.M{"_id": badge.ID}).SetUpdate(bson.M{"$setOnInsert": badge}).SetUpsert(true))
+	}
+
+	result, err := r.collection.BulkWrite(ctx, models, options.BulkWrite().SetOrdered(false))
+	if err != nil {
+		slog.ErrorContext(ctx, "unable to award player badges", "err", err, "badges", len(badges))
+		return nil, err
+	}
+
+	awarded := make([]badge_entities.PlayerBadge, 0, len(result.UpsertedIDs))
+	for i := range badges {
+		if _, ok := result.UpsertedIDs[int64(i)]; ok {
+			awarded = append(awarded, badges[i])
+		}
+	}
+
+	return awarded, nil
+}
@@ -0,0 +1,163 @@
+package db
+
+import (
+	"context"
+	"log/slog"
+	"reflect"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	replay_entity "github.com/psavelis/team-pro/replay-api/pkg/domain/replay/entities"
+)
+
+type PlayerMatchHistoryRepository struct {
+	MongoDBRepository[replay_entity.PlayerMatchHistory]
+}
+
+func NewPlayerMatchHistoryRepository(client *mongo.Client, dbName string, entityType replay_entity.PlayerMatchHistory, collectionName string) *PlayerMatchHistoryRepository {
+	repo := MongoDBRepository[replay_entity.PlayerMatchHistory]{
+		mongoClient:       client,
+		dbName:            dbName,
+		mappingCache:      make(map[string]CacheItem),
+		entityModel:       reflect.TypeOf(entityType),
+		bsonFieldMappings: make(map[string]string),
+		collectionName:    collectionName,
+		entityName:        reflect.TypeOf(entityType).Name(),
+		queryableFields:   make(map[string]bool),
+	}
+
+	repo.InitQueryableFields(map[string]bool{
+		"ID":                  true,
+		"PlayerID":            true,
+		"MatchID":             true,
+		"GameID":              true,
+		"QueueSessionID":      true,
+		"LobbyID":             true,
+		"TeamID":              true,
+		"TeamName":            true,
+		"Outcome":             true,
+		"TeamScore":           true,
+		"OpponentScore":       true,
+		"Payout":              true,
+		"PlayedAt":            true,
+		"ResourceOwner":       true,
+		"CreatedAt":           true,
+		"UpdatedAt":           true,
+		"Tags":                true,
+		"Tags.Platform":       true,
+		"Tags.ServerRegion":   true,
+		"Tags.ServerLocation": true,
+		"Tags.Tickrate":       true,
+	}, map[string]string{
+		"ID":                     "_id",
+		"PlayerID":               "player_id",
+		"MatchID":                "match_id",
+		"GameID":                 "game_id",
+		"QueueSessionID":         "queue_session_id",
+		"LobbyID":                "lobby_id",
+		"TeamID":                 "team_id",
+		"TeamName":               "team_name",
+		"Outcome":                "outcome",
+		"TeamScore":              "team_score",
+		"OpponentScore":          "opponent_score",
+		"Payout":                 "payout",
+		"PlayedAt":               "played_at",
+		"ResourceOwner":          "resource_owner",
+		"CreatedAt":              "created_at",
+		"UpdatedAt":              "updated_at",
+		"ResourceOwner.TenantID": "resource_owner.tenant_id",
+		"ResourceOwner.UserID":   "resource_owner.user_id",
+		"ResourceOwner.GroupID":  "resource_owner.group_id",
+		"ResourceOwner.ClientID": "resource_owner.client_id",
+		"Tags":                   "tags",
+		"Tags.Platform":          "tags.platform",
+		"Tags.ServerRegion":      "tags.server_region",
+		"Tags.ServerLocation":    "tags.server_location",
+		"Tags.Tickrate":          "tags.tickrate",
+	})
+
+	return &PlayerMatchHistoryRepository{
+		repo,
+	}
+}
+
+func (r *PlayerMatchHistoryRepository) Search(ctx context.Context, s common.Search) ([]replay_entity.PlayerMatchHistory, error) {
+	cursor, err := r.Query(ctx, s)
+	if cursor != nil {
+		defer cursor.Close(ctx)
+	}
+
+	if err != nil {
+		slog.ErrorContext(ctx, "error querying player match history", "err", err)
+		return nil, err
+	}
+
+	entries := make([]replay_entity.PlayerMatchHistory, 0)
+	for cursor.Next(ctx) {
+		var entry replay_entity.PlayerMatchHistory
+		err := cursor.Decode(&entry)
+
+		if err != nil {
+			slog.ErrorContext(ctx, "error decoding player match history", "err", err)
+			return nil, err
+		}
+
+		entries = append(entries, entry)
+	}
+
+	return entries, nil
+}
+
+// Upsert writes the entries in a single unordered bulk. Matchmaking fields (queue session, lobby and payout) are only overwritten when set, so re-projecting a match doesn't erase them.
+func (r *PlayerMatchHistoryRepository) Upsert(ctx context.Context, entries []replay_entity.PlayerMatchHistory) error {
+	if len(entries) == 0 {
+		return nil
+	}
+
+	models := make([]mongo.WriteModel, 0, len(entries))
+	for _, entry := range entries {
+		set := bson.M{
+			"player_id":      entry.PlayerID,
+			"match_id":       entry.MatchID,
+			"game_id":        entry.GameID,
+			"team_id":        entry.TeamID,
+			"team_name":      entry.TeamName,
+			"outcome":        entry.Outcome,
+			"team_score":     entry.TeamScore,
+			"opponent_score": entry.OpponentScore,
+			"played_at":      entry.PlayedAt,
+			"resource_owner": entry.ResourceOwner,
+			"updated_at":     entry.UpdatedAt,
+		}
+
+		if entry.QueueSessionID != nil {
+			set["queue_session_id"] = entry.QueueSessionID
+		}
+
+		if entry.LobbyID != nil {
+			set["lobby_id"] = entry.LobbyID
+		}
+
+		if entry.Payout != nil {
+			set["payout"] = entry.Payout
+		}
+
+		update := bson.M{
+			"$set":         set,
+			"$setOnInsert": bson.M{"created_at": entry.CreatedAt},
+		}
+
+		models = append(models, mongo.NewUpdateOneModel().SetFilter(bson.M{"_id": entry.ID}).SetUpdate(update).SetUpsert(true))
+	}
+
+	_, err := r.collection.BulkWrite(ctx, models, options.BulkWrite().SetOrdered(false))
+	if err != nil {
+		slog.ErrorContext(ctx, "unable to upsert player match history", "err", err, "entries", len(entries))
+		return err
+	}
+
+	return nil
+}
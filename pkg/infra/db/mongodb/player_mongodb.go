@@ -4,10 +4,15 @@ import (
 	"context"
 	"log/slog"
 	"reflect"
+	"time"
 
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo"
 
 	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	"github.com/psavelis/team-pro/replay-api/pkg/domain/customfield"
+	customfield_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/customfield/entities"
 	replay_entity "github.com/psavelis/team-pro/replay-api/pkg/domain/replay/entities"
 )
 
@@ -38,6 +43,8 @@ func NewPlayerRepository(client *mongo.Client, dbName string, entityType replay_
 		"ResourceOwner":      true,
 		"CreatedAt":          true,
 		"UpdatedAt":          true,
+		"StatsSyncedAt":      true,
+		"CustomFields":       true,
 	}, map[string]string{
 		"ID":                 "_id", // TODO: review ; (opcional: aqui a exceção se tornou a regra. deixar default o que está na annotation da prop.) talvez seja melhor refletir os tipos nas anotacoes json/bson
 		"GameID":             "game_id",
@@ -52,6 +59,8 @@ func NewPlayerRepository(client *mongo.Client, dbName string, entityType replay_
 		"ClientID":           "resource_owner.client_id",
 		"CreatedAt":          "create_at",
 		"UpdatedAt":          "updated_at",
+		"StatsSyncedAt":      "stats_synced_at",
+		"CustomFields":       "custom_fields",
 	})
 
 	return &PlayerRepository{
@@ -112,3 +121,39 @@ func (r *PlayerRepository) Create(createCtx context.Context, events ...replay_en
 
 	return nil
 }
+
+func (r *PlayerRepository) UpdateExternalStats(ctx context.Context, player *replay_entity.Player) error {
+	_, err := r.collection.UpdateOne(ctx, bson.M{"_id": player.ID}, bson.M{"$set": bson.M{
+		"external_stats":  player.ExternalStats,
+		"rank_badges":     player.RankBadges,
+		"stats_synced_at": player.StatsSyncedAt,
+	}})
+
+	if err != nil {
+		slog.ErrorContext(ctx, "error updating player external stats", "err", err, "playerID", player.ID)
+		return err
+	}
+
+	return nil
+}
+
+// SetCustomFields replaces the custom fields of the player of the tenant in context.
+func (r *PlayerRepository) SetCustomFields(ctx context.Context, id uuid.UUID, fields common.CustomFields) error {
+	filter := bson.M{"_id": common.PlayerIDType(id), "resource_owner.tenant_id": common.GetResourceOwner(ctx).TenantID}
+
+	res, err := r.collection.UpdateOne(ctx, filter, bson.M{"$set": bson.M{
+		"custom_fields": fields,
+		"updated_at":    time.Now().UTC(),
+	}})
+
+	if err != nil {
+		slog.ErrorContext(ctx, "error setting player custom fields", "err", err, "playerID", id)
+		return err
+	}
+
+	if res.MatchedCount == 0 {
+		return customfield.NewCustomFieldsEntityNotFoundError(customfield_entities.CustomFieldEntityPlayer, id)
+	}
+
+	return nil
+}
@@ -0,0 +1,79 @@
+package db
+
+import (
+	"context"
+	"log/slog"
+	"reflect"
+
+	"go.mongodb.org/mongo-driver/mongo"
+
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	replay_entity "github.com/psavelis/team-pro/replay-api/pkg/domain/replay/entities"
+)
+
+type PlayerProfileHistoryRepository struct {
+	MongoDBRepository[replay_entity.PlayerProfileHistory]
+}
+
+func NewPlayerProfileHistoryRepository(client *mongo.Client, dbName string, entityType replay_entity.PlayerProfileHistory, collectionName string) *PlayerProfileHistoryRepository {
+	repo := MongoDBRepository[replay_entity.PlayerProfileHistory]{
+		mongoClient:       client,
+		dbName:            dbName,
+		mappingCache:      make(map[string]CacheItem),
+		entityModel:       reflect.TypeOf(entityType),
+		bsonFieldMappings: make(map[string]string),
+		collectionName:    collectionName,
+		entityName:        reflect.TypeOf(entityType).Name(),
+		queryableFields:   make(map[string]bool),
+		collection:        client.Database(dbName).Collection(collectionName),
+	}
+
+	repo.InitQueryableFields(map[string]bool{
+		"ID":            true,
+		"PlayerID":      true,
+		"ResourceOwner": true,
+		"CreatedAt":     true,
+	}, map[string]string{
+		"ID":                     "_id",
+		"PlayerID":               "player_id",
+		"Stats":                  "stats",
+		"RankBadges":             "rank_badges",
+		"ResourceOwner":          "resource_owner",
+		"CreatedAt":              "created_at",
+		"ResourceOwner.TenantID": "resource_owner.tenant_id",
+		"ResourceOwner.UserID":   "resource_owner.user_id",
+		"ResourceOwner.GroupID":  "resource_owner.group_id",
+		"ResourceOwner.ClientID": "resource_owner.client_id",
+	})
+
+	return &PlayerProfileHistoryRepository{
+		repo,
+	}
+}
+
+func (r *PlayerProfileHistoryRepository) Search(ctx context.Context, s common.Search) ([]replay_entity.PlayerProfileHistory, error) {
+	cursor, err := r.Query(ctx, s)
+	if cursor != nil {
+		defer cursor.Close(ctx)
+	}
+
+	if err != nil {
+		slog.ErrorContext(ctx, "error querying player profile history", "err", err)
+		return nil, err
+	}
+
+	snapshots := make([]replay_entity.PlayerProfileHistory, 0)
+	for cursor.Next(ctx) {
+		var snapshot replay_entity.PlayerProfileHistory
+		err := cursor.Decode(&snapshot)
+
+		if err != nil {
+			slog.ErrorContext(ctx, "error decoding player profile history", "err", err)
+			return nil, err
+		}
+
+		snapshots = append(snapshots, snapshot)
+	}
+
+	return snapshots, nil
+}
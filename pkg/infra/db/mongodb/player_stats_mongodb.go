@@ -0,0 +1,166 @@
+package db
+
+import (
+	"context"
+	"log/slog"
+	"reflect"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	replay_entity "github.com/psavelis/team-pro/replay-api/pkg/domain/replay/entities"
+)
+
+type PlayerStatsRepository struct {
+	MongoDBRepository[replay_entity.PlayerStatsBucket]
+}
+
+func NewPlayerStatsRepository(client *mongo.Client, dbName string, entityType replay_entity.PlayerStatsBucket, collectionName string) *PlayerStatsRepository {
+	repo := MongoDBRepository[replay_entity.PlayerStatsBucket]{
+		mongoClient:       client,
+		dbName:            dbName,
+		mappingCache:      make(map[string]CacheItem),
+		entityModel:       reflect.TypeOf(entityType),
+		bsonFieldMappings: make(map[string]string),
+		collectionName:    collectionName,
+		entityName:        reflect.TypeOf(entityType).Name(),
+		queryableFields:   make(map[string]bool),
+	}
+
+	repo.InitQueryableFields(map[string]bool{
+		"ID":                  true,
+		"GameID":              true,
+		"NetworkPlayerID":     true,
+		"MapName":             true,
+		"Day":                 true,
+		"Matches":             true,
+		"Rounds":              true,
+		"Kills":               true,
+		"Deaths":              true,
+		"Assists":             true,
+		"Headshots":           true,
+		"Damage":              true,
+		"ClutchesPlayed":      true,
+		"ClutchesWon":         true,
+		"MVPs":                true,
+		"RatingPoints":        true,
+		"ResourceOwner":       true,
+		"CreatedAt":           true,
+		"UpdatedAt":           true,
+		"Tags":                true,
+		"Tags.Platform":       true,
+		"Tags.ServerRegion":   true,
+		"Tags.ServerLocation": true,
+		"Tags.Tickrate":       true,
+	}, map[string]string{
+		"ID":                     "_id",
+		"GameID":                 "game_id",
+		"NetworkPlayerID":        "network_player_id",
+		"MapName":                "map_name",
+		"Day":                    "day",
+		"Matches":                "matches",
+		"Rounds":                 "rounds",
+		"Kills":                  "kills",
+		"Deaths":                 "deaths",
+		"Assists":                "assists",
+		"Headshots":              "headshots",
+		"Damage":                 "damage",
+		"ClutchesPlayed":         "clutches_played",
+		"ClutchesWon":            "clutches_won",
+		"MVPs":                   "mvps",
+		"RatingPoints":           "rating_points",
+		"ResourceOwner":          "resource_owner",
+		"CreatedAt":              "created_at",
+		"UpdatedAt":              "updated_at",
+		"ResourceOwner.TenantID": "resource_owner.tenant_id",
+		"ResourceOwner.UserID":   "resource_owner.user_id",
+		"ResourceOwner.GroupID":  "resource_owner.group_id",
+		"ResourceOwner.ClientID": "resource_owner.client_id",
+		"Tags":                   "tags",
+		"Tags.Platform":          "tags.platform",
+		"Tags.ServerRegion":      "tags.server_region",
+		"Tags.ServerLocation":    "tags.server_location",
+		"Tags.Tickrate":          "tags.tickrate",
+	})
+
+	return &PlayerStatsRepository{
+		repo,
+	}
+}
+
+func (r *PlayerStatsRepository) Search(ctx context.Context, s common.Search) ([]replay_entity.PlayerStatsBucket, error) {
+	cursor, err := r.Query(ctx, s)
+	if cursor != nil {
+		defer cursor.Close(ctx)
+	}
+
+	if err != nil {
+		slog.ErrorContext(ctx, "error querying player stats", "err", err)
+		return nil, err
+	}
+
+	buckets := make([]replay_entity.PlayerStatsBucket, 0)
+	for cursor.Next(ctx) {
+		var bucket replay_entity.PlayerStatsBucket
+		err := cursor.Decode(&bucket)
+
+		if err != nil {
+			slog.ErrorContext(ctx, "error decoding player stats", "err", err)
+			return nil, err
+		}
+
+		buckets = append(buckets, bucket)
+	}
+
+	return buckets, nil
+}
+
+// Increment upserts the buckets in a single unordered bulk, adding their counters ($inc) to the stored ones.
+func (r *PlayerStatsRepository) Increment(ctx context.Context, buckets []replay_entity.PlayerStatsBucket) error {
+	if len(buckets) == 0 {
+		return nil
+	}
+
+	models := make([]mongo.WriteModel, 0, len(buckets))
+	for _, bucket := range buckets {
+		update := bson.M{
+			"$inc": bson.M{
+				"matches":         bucket.Matches,
+				"rounds":          bucket.Rounds,
+				"kills":           bucket.Kills,
+				"deaths":          bucket.Deaths,
+				"assists":         bucket.Assists,
+				"headshots":       bucket.Headshots,
+				"damage":          bucket.Damage,
+				"clutches_played": bucket.ClutchesPlayed,
+				"clutches_won":    bucket.ClutchesWon,
+				"mvps":            bucket.MVPs,
+				"rating_points":   bucket.RatingPoints,
+			},
+			"$set": bson.M{
+				"updated_at": bucket.UpdatedAt,
+			},
+			"$setOnInsert": bson.M{
+				"game_id":           bucket.GameID,
+				"network_player_id": bucket.NetworkPlayerID,
+				"map_name":          bucket.MapName,
+				"day":               bucket.Day,
+				"tags":              bucket.Tags,
+				"resource_owner":    bucket.ResourceOwner,
+				"created_at":        bucket.CreatedAt,
+			},
+		}
+
+		models = append(models, mongo.NewUpdateOneModel().SetFilter(bson.M{"_id": bucket.ID}).SetUpdate(update).SetUpsert(true))
+	}
+
+	_, err := r.collection.BulkWrite(ctx, models, options.BulkWrite().SetOrdered(false))
+	if err != nil {
+		slog.ErrorContext(ctx, "unable to increment player stats", "err", err, "buckets", len(buckets))
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,86 @@
+package db
+
+import (
+	"context"
+	"log/slog"
+	"reflect"
+
+	"go.mongodb.org/mongo-driver/mongo"
+
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	consent_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/consent/entities"
+)
+
+type PolicyVersionRepository struct {
+	MongoDBRepository[consent_entities.PolicyVersion]
+}
+
+func NewPolicyVersionRepository(client *mongo.Client, dbName string, entityType consent_entities.PolicyVersion, collectionName string) *PolicyVersionRepository {
+	repo := MongoDBRepository[consent_entities.PolicyVersion]{
+		mongoClient:       client,
+		dbName:            dbName,
+		mappingCache:      make(map[string]CacheItem),
+		entityModel:       reflect.TypeOf(entityType),
+		bsonFieldMappings: make(map[string]string),
+		collectionName:    collectionName,
+		entityName:        reflect.TypeOf(entityType).Name(),
+		queryableFields:   make(map[string]bool),
+	}
+
+	repo.InitQueryableFields(map[string]bool{
+		"ID":            true,
+		"Kind":          true,
+		"Version":       true,
+		"URL":           true,
+		"ContentHash":   true,
+		"EffectiveFrom": true,
+		"ResourceOwner": true,
+		"CreatedAt":     true,
+		"UpdatedAt":     true,
+	}, map[string]string{
+		"ID":                     "_id",
+		"Kind":                   "kind",
+		"Version":                "version",
+		"URL":                    "url",
+		"ContentHash":            "content_hash",
+		"EffectiveFrom":          "effective_from",
+		"ResourceOwner":          "resource_owner",
+		"CreatedAt":              "created_at",
+		"UpdatedAt":              "updated_at",
+		"ResourceOwner.TenantID": "resource_owner.tenant_id",
+		"ResourceOwner.UserID":   "resource_owner.user_id",
+		"ResourceOwner.GroupID":  "resource_owner.group_id",
+		"ResourceOwner.ClientID": "resource_owner.client_id",
+	})
+
+	return &PolicyVersionRepository{
+		repo,
+	}
+}
+
+func (r *PolicyVersionRepository) Search(ctx context.Context, s common.Search) ([]consent_entities.PolicyVersion, error) {
+	cursor, err := r.Query(ctx, s)
+	if cursor != nil {
+		defer cursor.Close(ctx)
+	}
+
+	if err != nil {
+		slog.ErrorContext(ctx, "error querying policy versions", "err", err)
+		return nil, err
+	}
+
+	policies := make([]consent_entities.PolicyVersion, 0)
+	for cursor.Next(ctx) {
+		var policy consent_entities.PolicyVersion
+		err := cursor.Decode(&policy)
+
+		if err != nil {
+			slog.ErrorContext(ctx, "error decoding policy version", "err", err)
+			return nil, err
+		}
+
+		policies = append(policies, policy)
+	}
+
+	return policies, nil
+}
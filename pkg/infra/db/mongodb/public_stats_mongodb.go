@@ -0,0 +1,216 @@
+package db
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	public_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/public/entities"
+	replay_entity "github.com/psavelis/team-pro/replay-api/pkg/domain/replay/entities"
+)
+
+// PublicStatsRepository reads the public matches straight from the match metadata, joined with the summaries of their replays. Matches
+// are public to the whole tenant, so only the tenant in context is filtered.
+type PublicStatsRepository struct {
+	matches   *mongo.Collection
+	summaries *mongo.Collection
+}
+
+func NewPublicStatsRepository(client *mongo.Client, dbName string, matchCollectionName, summaryCollectionName string) *PublicStatsRepository {
+	return &PublicStatsRepository{
+		matches:   client.Database(dbName).Collection(matchCollectionName),
+		summaries: client.Database(dbName).Collection(summaryCollectionName),
+	}
+}
+
+func (r *PublicStatsRepository) GetPublicMatches(ctx context.Context, gameID common.GameIDKey, before *time.Time, limit int) ([]public_entities.PublicMatch, error) {
+	filter := bson.M{
+		"resource_owner.tenant_id": common.GetResourceOwner(ctx).TenantID,
+		"game_id":                  gameID,
+		"visibility":               replay_entity.MatchVisibilityPublic,
+	}
+
+	if before != nil {
+		filter["created_at"] = bson.M{"$lt": *before}
+	}
+
+	pipe := append([]bson.M{
+		{"$match": filter},
+		{"$sort": bson.M{"created_at": -1}},
+		{"$limit": limit},
+	}, r.withSummary(false)...)
+
+	return r.aggregateMatches(ctx, pipe)
+}
+
+func (r *PublicStatsRepository) GetPublicMatch(ctx context.Context, gameID common.GameIDKey, matchID uuid.UUID) (*public_entities.PublicMatch, error) {
+	pipe := append([]bson.M{
+		{"$match": bson.M{
+			"_id":                      matchID,
+			"resource_owner.tenant_id": common.GetResourceOwner(ctx).TenantID,
+			"game_id":                  gameID,
+			"visibility":               replay_entity.MatchVisibilityPublic,
+		}},
+		{"$limit": 1},
+	}, r.withSummary(true)...)
+
+	matches, err := r.aggregateMatches(ctx, pipe)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(matches) == 0 {
+		return nil, nil
+	}
+
+	return &matches[0], nil
+}
+
+// withSummary projects the matches as public matches, with the map, rounds and MVP of the summary of their replay (and its players).
+func (r *PublicStatsRepository) withSummary(players bool) []bson.M {
+	fields := bson.M{"_id": 0, "map_name": 1, "rounds": 1, "mvp": 1}
+	if players {
+		fields["players"] = 1
+	}
+
+	summary := func(field string) bson.M {
+		return bson.M{"$arrayElemAt": bson.A{"$summary." + field, 0}}
+	}
+
+	projection := bson.M{
+		"_id":       1,
+		"game_id":   1,
+		"played_at": "$created_at",
+		"teams": bson.M{"$map": bson.M{
+			"input": bson.M{"$ifNull": bson.A{"$scoreboard.team_scoreboards", bson.A{}}},
+			"as":    "t",
+			"in":    bson.M{"name": "$$t.team.name", "score": "$$t.team_score"},
+		}},
+		"map_name": summary("map_name"),
+		"rounds":   summary("rounds"),
+		"mvp":      summary("mvp"),
+	}
+
+	if players {
+		projection["players"] = summary("players")
+	}
+
+	return []bson.M{
+		{"$lookup": bson.M{
+			"from": r.summaries.Name(),
+			"let":  bson.M{"match_id": "$_id"},
+			"pipeline": bson.A{
+				bson.M{"$match": bson.M{"$expr": bson.M{"$eq": bson.A{"$match_id", "$$match_id"}}}},
+				bson.M{"$sort": bson.M{"created_at": -1}},
+				bson.M{"$limit": 1},
+				bson.M{"$project": fields},
+			},
+			"as": "summary",
+		}},
+		{"$project": projection},
+	}
+}
+
+func (r *PublicStatsRepository) aggregateMatches(ctx context.Context, pipe []bson.M) ([]public_entities.PublicMatch, error) {
+	cursor, err := r.matches.Aggregate(ctx, pipe)
+	if cursor != nil {
+		defer cursor.Close(ctx)
+	}
+
+	if err != nil {
+		slog.ErrorContext(ctx, "unable to aggregate public matches", "err", err)
+		return nil, err
+	}
+
+	matches := make([]public_entities.PublicMatch, 0)
+
+	for cursor.Next(ctx) {
+		var m public_entities.PublicMatch
+		err := cursor.Decode(&m)
+
+		if err != nil {
+			slog.ErrorContext(ctx, "error decoding public match", "err", err)
+			return nil, err
+		}
+
+		matches = append(matches, m)
+	}
+
+	return matches, nil
+}
+
+func (r *PublicStatsRepository) GetLeaderboardEntries(ctx context.Context, gameID common.GameIDKey, since time.Time, seasonID *uuid.UUID, minMatches int, limit int) ([]public_entities.LeaderboardEntry, error) {
+	filter := bson.M{
+		"resource_owner.tenant_id": common.GetResourceOwner(ctx).TenantID,
+		"game_id":                  gameID,
+		"created_at":               bson.M{"$gte": since},
+	}
+
+	if seasonID != nil {
+		filter["season_id"] = *seasonID
+	}
+
+	pipe := []bson.M{
+		{"$match": filter},
+		{"$lookup": bson.M{
+			"from": r.matches.Name(),
+			"let":  bson.M{"match_id": "$match_id"},
+			"pipeline": bson.A{
+				bson.M{"$match": bson.M{"$expr": bson.M{"$eq": bson.A{"$_id", "$$match_id"}}}},
+				bson.M{"$project": bson.M{"_id": 0, "visibility": 1}},
+			},
+			"as": "match",
+		}},
+		{"$match": bson.M{"match.visibility": replay_entity.MatchVisibilityPublic}},
+		// the latest name of the players is kept
+		{"$sort": bson.M{"created_at": 1}},
+		{"$unwind": "$players"},
+		{"$group": bson.M{
+			"_id":       "$players.network_player_id",
+			"name":      bson.M{"$last": "$players.name"},
+			"matches":   bson.M{"$sum": 1},
+			"rounds":    bson.M{"$sum": "$players.rounds"},
+			"kills":     bson.M{"$sum": "$players.kills"},
+			"deaths":    bson.M{"$sum": "$players.deaths"},
+			"assists":   bson.M{"$sum": "$players.assists"},
+			"headshots": bson.M{"$sum": "$players.headshots"},
+			"damage":    bson.M{"$sum": "$players.damage"},
+			"mvps":      bson.M{"$sum": bson.M{"$cond": bson.A{"$players.mvp", 1, 0}}},
+			"rating":    bson.M{"$avg": "$players.rating"},
+		}},
+		{"$match": bson.M{"matches": bson.M{"$gte": minMatches}}},
+		{"$sort": bson.D{{Key: "rating", Value: -1}, {Key: "matches", Value: -1}, {Key: "kills", Value: -1}}},
+		{"$limit": limit},
+	}
+
+	cursor, err := r.summaries.Aggregate(ctx, pipe)
+	if cursor != nil {
+		defer cursor.Close(ctx)
+	}
+
+	if err != nil {
+		slog.ErrorContext(ctx, "unable to aggregate leaderboard", "err", err, "gameID", gameID, "since", since, "seasonID", seasonID)
+		return nil, err
+	}
+
+	entries := make([]public_entities.LeaderboardEntry, 0)
+
+	for cursor.Next(ctx) {
+		var e public_entities.LeaderboardEntry
+		err := cursor.Decode(&e)
+
+		if err != nil {
+			slog.ErrorContext(ctx, "error decoding leaderboard entry", "err", err)
+			return nil, err
+		}
+
+		entries = append(entries, e)
+	}
+
+	return entries, nil
+}
@@ -0,0 +1,97 @@
+package db
+
+import (
+	"context"
+	"log/slog"
+	"reflect"
+
+	"go.mongodb.org/mongo-driver/mongo"
+
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	notification_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/notification/entities"
+)
+
+type PushReceiptRepository struct {
+	MongoDBRepository[notification_entities.PushReceipt]
+}
+
+func NewPushReceiptRepository(client *mongo.Client, dbName string, entityType notification_entities.PushReceipt, collectionName string) *PushReceiptRepository {
+	repo := MongoDBRepository[notification_entities.PushReceipt]{
+		mongoClient:       client,
+		dbName:            dbName,
+		mappingCache:      make(map[string]CacheItem),
+		entityModel:       reflect.TypeOf(entityType),
+		bsonFieldMappings: make(map[string]string),
+		collectionName:    collectionName,
+		entityName:        reflect.TypeOf(entityType).Name(),
+		queryableFields:   make(map[string]bool),
+	}
+
+	repo.InitQueryableFields(map[string]bool{
+		"ID":                true,
+		"Type":              true,
+		"Reference":         true,
+		"DeviceTokenID":     true,
+		"UserID":            true,
+		"Platform":          true,
+		"Provider":          true,
+		"CollapseKey":       true,
+		"Status":            true,
+		"ProviderMessageID": true,
+		"TTLSeconds":        true,
+		"ExpiresAt":         true,
+		"ResourceOwner":     true,
+		"CreatedAt":         true,
+	}, map[string]string{
+		"ID":                     "_id",
+		"Type":                   "type",
+		"Reference":              "reference",
+		"DeviceTokenID":          "device_token_id",
+		"UserID":                 "user_id",
+		"Platform":               "platform",
+		"Provider":               "provider",
+		"CollapseKey":            "collapse_key",
+		"Status":                 "status",
+		"ProviderMessageID":      "provider_message_id",
+		"Error":                  "error",
+		"TTLSeconds":             "ttl_seconds",
+		"ExpiresAt":              "expires_at",
+		"ResourceOwner":          "resource_owner",
+		"CreatedAt":              "created_at",
+		"ResourceOwner.TenantID": "resource_owner.tenant_id",
+		"ResourceOwner.UserID":   "resource_owner.user_id",
+		"ResourceOwner.GroupID":  "resource_owner.group_id",
+		"ResourceOwner.ClientID": "resource_owner.client_id",
+	})
+
+	return &PushReceiptRepository{
+		repo,
+	}
+}
+
+func (r *PushReceiptRepository) Search(ctx context.Context, s common.Search) ([]notification_entities.PushReceipt, error) {
+	cursor, err := r.Query(ctx, s)
+	if cursor != nil {
+		defer cursor.Close(ctx)
+	}
+
+	if err != nil {
+		slog.ErrorContext(ctx, "error querying push receipts", "err", err)
+		return nil, err
+	}
+
+	receipts := make([]notification_entities.PushReceipt, 0)
+	for cursor.Next(ctx) {
+		var receipt notification_entities.PushReceipt
+		err := cursor.Decode(&receipt)
+
+		if err != nil {
+			slog.ErrorContext(ctx, "error decoding push receipt", "err", err)
+			return nil, err
+		}
+
+		receipts = append(receipts, receipt)
+	}
+
+	return receipts, nil
+}
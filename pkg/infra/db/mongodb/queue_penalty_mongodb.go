@@ -0,0 +1,84 @@
+package db
+
+import (
+	"context"
+	"log/slog"
+	"reflect"
+
+	"go.mongodb.org/mongo-driver/mongo"
+
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	matchmaking_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/matchmaking/entities"
+)
+
+type QueuePenaltyRepository struct {
+	MongoDBRepository[matchmaking_entities.QueuePenalty]
+}
+
+func NewQueuePenaltyRepository(client *mongo.Client, dbName string, entityType matchmaking_entities.QueuePenalty, collectionName string) *QueuePenaltyRepository {
+	repo := MongoDBRepository[matchmaking_entities.QueuePenalty]{
+		mongoClient:       client,
+		dbName:            dbName,
+		mappingCache:      make(map[string]CacheItem),
+		entityModel:       reflect.TypeOf(entityType),
+		bsonFieldMappings: make(map[string]string),
+		collectionName:    collectionName,
+		entityName:        reflect.TypeOf(entityType).Name(),
+		queryableFields:   make(map[string]bool),
+	}
+
+	repo.InitQueryableFields(map[string]bool{
+		"ID":             true,
+		"UserID":         true,
+		"Declines":       true,
+		"LastDeclinedAt": true,
+		"CooldownUntil":  true,
+		"ResourceOwner":  true,
+		"CreatedAt":      true,
+		"UpdatedAt":      true,
+	}, map[string]string{
+		"ID":                     "_id",
+		"UserID":                 "user_id",
+		"Declines":               "declines",
+		"LastDeclinedAt":         "last_declined_at",
+		"CooldownUntil":          "cooldown_until",
+		"ResourceOwner":          "resource_owner",
+		"CreatedAt":              "created_at",
+		"UpdatedAt":              "updated_at",
+		"ResourceOwner.TenantID": "resource_owner.tenant_id",
+		"ResourceOwner.UserID":   "resource_owner.user_id",
+		"ResourceOwner.GroupID":  "resource_owner.group_id",
+		"ResourceOwner.ClientID": "resource_owner.client_id",
+	})
+
+	return &QueuePenaltyRepository{
+		repo,
+	}
+}
+
+func (r *QueuePenaltyRepository) Search(ctx context.Context, s common.Search) ([]matchmaking_entities.QueuePenalty, error) {
+	cursor, err := r.Query(ctx, s)
+	if cursor != nil {
+		defer cursor.Close(ctx)
+	}
+
+	if err != nil {
+		slog.ErrorContext(ctx, "error querying queue penalties", "err", err)
+		return nil, err
+	}
+
+	penalties := make([]matchmaking_entities.QueuePenalty, 0)
+	for cursor.Next(ctx) {
+		var penalty matchmaking_entities.QueuePenalty
+		err := cursor.Decode(&penalty)
+
+		if err != nil {
+			slog.ErrorContext(ctx, "error decoding queue penalty", "err", err)
+			return nil, err
+		}
+
+		penalties = append(penalties, penalty)
+	}
+
+	return penalties, nil
+}
@@ -0,0 +1,98 @@
+package db
+
+import (
+	"context"
+	"log/slog"
+	"reflect"
+
+	"go.mongodb.org/mongo-driver/mongo"
+
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	matchmaking_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/matchmaking/entities"
+)
+
+type QueueTicketRepository struct {
+	MongoDBRepository[matchmaking_entities.QueueTicket]
+}
+
+func NewQueueTicketRepository(client *mongo.Client, dbName string, entityType matchmaking_entities.QueueTicket, collectionName string) *QueueTicketRepository {
+	repo := MongoDBRepository[matchmaking_entities.QueueTicket]{
+		mongoClient:       client,
+		dbName:            dbName,
+		mappingCache:      make(map[string]CacheItem),
+		entityModel:       reflect.TypeOf(entityType),
+		bsonFieldMappings: make(map[string]string),
+		collectionName:    collectionName,
+		entityName:        reflect.TypeOf(entityType).Name(),
+		queryableFields:   make(map[string]bool),
+	}
+
+	repo.InitQueryableFields(map[string]bool{
+		"ID":             true,
+		"PoolID":         true,
+		"UserID":         true,
+		"PlayerID":       true,
+		"Rating":         true,
+		"Roles":          true,
+		"Status":         true,
+		"AcceptBackfill": true,
+		"LobbyID":        true,
+		"BackfillSlotID": true,
+		"EnqueuedAt":     true,
+		"MatchedAt":      true,
+		"ResourceOwner":  true,
+		"CreatedAt":      true,
+		"UpdatedAt":      true,
+	}, map[string]string{
+		"ID":                     "_id",
+		"PoolID":                 "pool_id",
+		"UserID":                 "user_id",
+		"PlayerID":               "player_id",
+		"Rating":                 "rating",
+		"Roles":                  "roles",
+		"Status":                 "status",
+		"AcceptBackfill":         "accept_backfill",
+		"LobbyID":                "lobby_id",
+		"BackfillSlotID":         "backfill_slot_id",
+		"EnqueuedAt":             "enqueued_at",
+		"MatchedAt":              "matched_at",
+		"ResourceOwner":          "resource_owner",
+		"CreatedAt":              "created_at",
+		"UpdatedAt":              "updated_at",
+		"ResourceOwner.TenantID": "resource_owner.tenant_id",
+		"ResourceOwner.UserID":   "resource_owner.user_id",
+		"ResourceOwner.GroupID":  "resource_owner.group_id",
+		"ResourceOwner.ClientID": "resource_owner.client_id",
+	})
+
+	return &QueueTicketRepository{
+		repo,
+	}
+}
+
+func (r *QueueTicketRepository) Search(ctx context.Context, s common.Search) ([]matchmaking_entities.QueueTicket, error) {
+	cursor, err := r.Query(ctx, s)
+	if cursor != nil {
+		defer cursor.Close(ctx)
+	}
+
+	if err != nil {
+		slog.ErrorContext(ctx, "error querying queue tickets", "err", err)
+		return nil, err
+	}
+
+	tickets := make([]matchmaking_entities.QueueTicket, 0)
+	for cursor.Next(ctx) {
+		var ticket matchmaking_entities.QueueTicket
+		err := cursor.Decode(&ticket)
+
+		if err != nil {
+			slog.ErrorContext(ctx, "error decoding queue ticket", "err", err)
+			return nil, err
+		}
+
+		tickets = append(tickets, ticket)
+	}
+
+	return tickets, nil
+}
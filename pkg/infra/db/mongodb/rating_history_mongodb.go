@@ -0,0 +1,121 @@
+package db
+
+import (
+	"context"
+	"log/slog"
+	"reflect"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	rating_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/rating/entities"
+)
+
+type RatingHistoryRepository struct {
+	MongoDBRepository[rating_entities.RatingHistory]
+}
+
+func NewRatingHistoryRepository(client *mongo.Client, dbName string, entityType rating_entities.RatingHistory, collectionName string) *RatingHistoryRepository {
+	repo := MongoDBRepository[rating_entities.RatingHistory]{
+		mongoClient:       client,
+		dbName:            dbName,
+		mappingCache:      make(map[string]CacheItem),
+		entityModel:       reflect.TypeOf(entityType),
+		bsonFieldMappings: make(map[string]string),
+		collectionName:    collectionName,
+		entityName:        reflect.TypeOf(entityType).Name(),
+		queryableFields:   make(map[string]bool),
+		collection:        client.Database(dbName).Collection(collectionName),
+	}
+
+	repo.InitQueryableFields(map[string]bool{
+		"ID":            true,
+		"Subject":       true,
+		"SubjectID":     true,
+		"GameID":        true,
+		"MatchID":       true,
+		"LobbyID":       true,
+		"Score":         true,
+		"RatingBefore":  true,
+		"Rating":        true,
+		"Deviation":     true,
+		"Volatility":    true,
+		"SeasonID":      true,
+		"ResourceOwner": true,
+		"PlayedAt":      true,
+		"CreatedAt":     true,
+	}, map[string]string{
+		"ID":                     "_id",
+		"Subject":                "subject",
+		"SubjectID":              "subject_id",
+		"GameID":                 "game_id",
+		"MatchID":                "match_id",
+		"LobbyID":                "lobby_id",
+		"Score":                  "score",
+		"RatingBefore":           "rating_before",
+		"Rating":                 "rating",
+		"Deviation":              "deviation",
+		"Volatility":             "volatility",
+		"SeasonID":               "season_id",
+		"ResourceOwner":          "resource_owner",
+		"PlayedAt":               "played_at",
+		"CreatedAt":              "created_at",
+		"ResourceOwner.TenantID": "resource_owner.tenant_id",
+		"ResourceOwner.UserID":   "resource_owner.user_id",
+		"ResourceOwner.GroupID":  "resource_owner.group_id",
+		"ResourceOwner.ClientID": "resource_owner.client_id",
+	})
+
+	return &RatingHistoryRepository{
+		repo,
+	}
+}
+
+func (r *RatingHistoryRepository) Search(ctx context.Context, s common.Search) ([]rating_entities.RatingHistory, error) {
+	cursor, err := r.Query(ctx, s)
+	if cursor != nil {
+		defer cursor.Close(ctx)
+	}
+
+	if err != nil {
+		slog.ErrorContext(ctx, "error querying rating history", "err", err)
+		return nil, err
+	}
+
+	entries := make([]rating_entities.RatingHistory, 0)
+	for cursor.Next(ctx) {
+		var entry rating_entities.RatingHistory
+		err := cursor.Decode(&entry)
+
+		if err != nil {
+			slog.ErrorContext(ctx, "error decoding rating history", "err", err)
+			return nil, err
+		}
+
+		entries = append(entries, entry)
+	}
+
+	return entries, nil
+}
+
+// Upsert writes the entries in a single unordered bulk, replacing the entries of a match rated again.
+func (r *RatingHistoryRepository) Upsert(ctx context.Context, entries []rating_entities.RatingHistory) error {
+	if len(entries) == 0 {
+		return nil
+	}
+
+	models := make([]mongo.WriteModel, 0, len(entries))
+	for _, entry := range entries {
+		models = append(models, mongo.NewReplaceOneModel().SetFilter(bson.M{"_id": entry.ID}).SetReplacement(entry).SetUpsert(true))
+	}
+
+	_, err := r.collection.BulkWrite(ctx, models, options.BulkWrite().SetOrdered(false))
+	if err != nil {
+		slog.ErrorContext(ctx, "unable to upsert rating history", "err", err, "entries", len(entries))
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,168 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"reflect"
+
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	"github.com/psavelis/team-pro/replay-api/pkg/domain/rating"
+	rating_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/rating/entities"
+)
+
+type RatingRepository struct {
+	MongoDBRepository[rating_entities.Rating]
+}
+
+func NewRatingRepository(client *mongo.Client, dbName string, entityType rating_entities.Rating, collectionName string) *RatingRepository {
+	repo := MongoDBRepository[rating_entities.Rating]{
+		mongoClient:       client,
+		dbName:            dbName,
+		mappingCache:      make(map[string]CacheItem),
+		entityModel:       reflect.TypeOf(entityType),
+		bsonFieldMappings: make(map[string]string),
+		collectionName:    collectionName,
+		entityName:        reflect.TypeOf(entityType).Name(),
+		queryableFields:   make(map[string]bool),
+		collection:        client.Database(dbName).Collection(collectionName),
+	}
+
+	repo.InitQueryableFields(map[string]bool{
+		"ID":                  true,
+		"Subject":             true,
+		"SubjectID":           true,
+		"GameID":              true,
+		"Rating":              true,
+		"Deviation":           true,
+		"Volatility":          true,
+		"MatchesPlayed":       true,
+		"SeasonID":            true,
+		"SeasonMatchesPlayed": true,
+		"Version":             true,
+		"LastMatchID":         true,
+		"ResourceOwner":       true,
+		"CreatedAt":           true,
+		"UpdatedAt":           true,
+	}, map[string]string{
+		"ID":                     "_id",
+		"Subject":                "subject",
+		"SubjectID":              "subject_id",
+		"GameID":                 "game_id",
+		"Rating":                 "rating",
+		"Deviation":              "deviation",
+		"Volatility":             "volatility",
+		"MatchesPlayed":          "matches_played",
+		"SeasonID":               "season_id",
+		"SeasonMatchesPlayed":    "season_matches_played",
+		"Version":                "version",
+		"LastMatchID":            "last_match_id",
+		"ResourceOwner":          "resource_owner",
+		"CreatedAt":              "created_at",
+		"UpdatedAt":              "updated_at",
+		"ResourceOwner.TenantID": "resource_owner.tenant_id",
+		"ResourceOwner.UserID":   "resource_owner.user_id",
+		"ResourceOwner.GroupID":  "resource_owner.group_id",
+		"ResourceOwner.ClientID": "resource_owner.client_id",
+	})
+
+	return &RatingRepository{
+		repo,
+	}
+}
+
+func (r *RatingRepository) Search(ctx context.Context, s common.Search) ([]rating_entities.Rating, error) {
+	cursor, err := r.Query(ctx, s)
+	if cursor != nil {
+		defer cursor.Close(ctx)
+	}
+
+	if err != nil {
+		slog.ErrorContext(ctx, "error querying ratings", "err", err)
+		return nil, err
+	}
+
+	ratings := make([]rating_entities.Rating, 0)
+	for cursor.Next(ctx) {
+		var rating rating_entities.Rating
+		err := cursor.Decode(&rating)
+
+		if err != nil {
+			slog.ErrorContext(ctx, "error decoding rating", "err", err)
+			return nil, err
+		}
+
+		ratings = append(ratings, rating)
+	}
+
+	return ratings, nil
+}
+
+// Upsert writes the ratings in a single unordered bulk, keeping the creation time of the existing ones. Each rating only replaces the
+// version it was rated from: when it changed meanwhile the filter misses, and the upsert fails on the duplicate _id.
+func (r *RatingRepository) Upsert(ctx context.Context, ratings []rating_entities.Rating) error {
+	if len(ratings) == 0 {
+		return nil
+	}
+
+	models := make([]mongo.WriteModel, 0, len(ratings))
+	for _, rating := range ratings {
+		update := bson.M{
+			"$set": bson.M{
+				"subject":               rating.Subject,
+				"subject_id":            rating.SubjectID,
+				"game_id":               rating.GameID,
+				"rating":                rating.Rating,
+				"deviation":             rating.Deviation,
+				"volatility":            rating.Volatility,
+				"matches_played":        rating.MatchesPlayed,
+				"season_id":             rating.SeasonID,
+				"season_matches_played": rating.SeasonMatchesPlayed,
+				"version":               rating.Version,
+				"last_match_id":         rating.LastMatchID,
+				"resource_owner":        rating.ResourceOwner,
+				"updated_at":            rating.UpdatedAt,
+			},
+			"$setOnInsert": bson.M{"created_at": rating.CreatedAt},
+		}
+
+		filter := bson.M{"_id": rating.ID, "version": rating.Version - 1}
+		if rating.Version <= 1 {
+			// ratings written before versioning have no version
+			filter["version"] = bson.M{"$in": bson.A{0, nil}}
+		}
+
+		models = append(models, mongo.NewUpdateOneModel().SetFilter(filter).SetUpdate(update).SetUpsert(true))
+	}
+
+	_, err := r.collection.BulkWrite(ctx, models, options.BulkWrite().SetOrdered(false))
+
+	var bulkErr mongo.BulkWriteException
+	if errors.As(err, &bulkErr) && bulkErr.WriteConcernError == nil && len(bulkErr.WriteErrors) > 0 {
+		conflicts := make([]uuid.UUID, 0, len(bulkErr.WriteErrors))
+		for _, writeErr := range bulkErr.WriteErrors {
+			if !mongo.IsDuplicateKeyError(writeErr) {
+				conflicts = nil
+				break
+			}
+
+			conflicts = append(conflicts, ratings[writeErr.Index].ID)
+		}
+
+		if conflicts != nil {
+			return rating.NewRatingConflictError(conflicts)
+		}
+	}
+
+	if err != nil {
+		slog.ErrorContext(ctx, "unable to upsert ratings", "err", err, "ratings", len(ratings))
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,169 @@
+package db
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	recap_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/recap/entities"
+	squad_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/squad/entities"
+)
+
+// RecapActivityRepository aggregates the weekly activity of the users straight from the match history of their players, joined with
+// the match summaries (ratings) and the replay highlights of the matches.
+type RecapActivityRepository struct {
+	history    *mongo.Collection
+	squads     *mongo.Collection
+	players    string
+	summaries  string
+	highlights string
+}
+
+func NewRecapActivityRepository(client *mongo.Client, dbName string, historyCollectionName, playerCollectionName, summaryCollectionName, highlightsCollectionName, squadCollectionName string) *RecapActivityRepository {
+	return &RecapActivityRepository{
+		history:    client.Database(dbName).Collection(historyCollectionName),
+		squads:     client.Database(dbName).Collection(squadCollectionName),
+		players:    playerCollectionName,
+		summaries:  summaryCollectionName,
+		highlights: highlightsCollectionName,
+	}
+}
+
+func (r *RecapActivityRepository) GetPlayerActivity(ctx context.Context, from time.Time, to time.Time) ([]recap_entities.PlayerActivity, error) {
+	tenantID := common.GetResourceOwner(ctx).TenantID
+
+	// the stats of the player in the match (matched by their network user)
+	ofPlayer := func(input string) bson.M {
+		return bson.M{"$filter": bson.M{
+			"input": input,
+			"as":    "p",
+			"cond":  bson.M{"$eq": bson.A{"$$p.network_player_id", "$$network_user_id"}},
+		}}
+	}
+
+	pipe := []bson.M{
+		{"$match": bson.M{
+			"resource_owner.tenant_id": tenantID,
+			"played_at":                bson.M{"$gte": from, "$lt": to},
+		}},
+		{"$lookup": bson.M{"from": r.players, "localField": "player_id", "foreignField": "_id", "as": "player"}},
+		{"$unwind": "$player"},
+		{"$match": bson.M{"player.user_id": bson.M{"$ne": nil}}},
+		{"$lookup": bson.M{
+			"from": r.summaries,
+			"let":  bson.M{"match_id": "$match_id", "network_user_id": "$player.network_user_id"},
+			"pipeline": bson.A{
+				bson.M{"$match": bson.M{"$expr": bson.M{"$eq": bson.A{"$match_id", "$$match_id"}}}},
+				bson.M{"$project": bson.M{"_id": 0, "players": ofPlayer("$players")}},
+			},
+			"as": "summaries",
+		}},
+		{"$lookup": bson.M{
+			"from": r.highlights,
+			"let":  bson.M{"match_id": "$match_id", "network_user_id": "$player.network_user_id"},
+			"pipeline": bson.A{
+				bson.M{"$match": bson.M{"$expr": bson.M{"$eq": bson.A{"$match_id", "$$match_id"}}}},
+				bson.M{"$project": bson.M{"_id": 0, "highlights": ofPlayer("$highlights")}},
+			},
+			"as": "highlights",
+		}},
+		{"$group": bson.M{
+			"_id": "$player.user_id",
+			"matches": bson.M{"$push": bson.M{
+				"match_id": "$match_id",
+				"outcome":  "$outcome",
+				"rating":   bson.M{"$arrayElemAt": bson.A{bson.M{"$arrayElemAt": bson.A{"$summaries.players.rating", 0}}, 0}},
+				"payout":   "$payout",
+				"highlights": bson.M{"$reduce": bson.M{
+					"input":        "$highlights.highlights",
+					"initialValue": bson.A{},
+					"in":           bson.M{"$concatArrays": bson.A{"$$value", "$$this"}},
+				}},
+				"played_at": "$played_at",
+			}},
+			"resource_owner": bson.M{"$first": "$player.resource_owner"},
+		}},
+	}
+
+	cursor, err := r.history.Aggregate(ctx, pipe)
+	if cursor != nil {
+		defer cursor.Close(ctx)
+	}
+
+	if err != nil {
+		slog.ErrorContext(ctx, "unable to aggregate player activity", "err", err, "from", from, "to", to)
+		return nil, err
+	}
+
+	activity := make([]recap_entities.PlayerActivity, 0)
+
+	for cursor.Next(ctx) {
+		var a recap_entities.PlayerActivity
+		err := cursor.Decode(&a)
+
+		if err != nil {
+			slog.ErrorContext(ctx, "error decoding player activity", "err", err)
+			return nil, err
+		}
+
+		activity = append(activity, a)
+	}
+
+	return activity, nil
+}
+
+// GetSquadRosters reads the members of the squads from the keys of their profiles (the IDs of the users of the members).
+func (r *RecapActivityRepository) GetSquadRosters(ctx context.Context) ([]recap_entities.SquadRoster, error) {
+	tenantID := common.GetResourceOwner(ctx).TenantID
+
+	opts := options.Find().SetProjection(bson.M{"name": 1, "profiles": 1, "resource_owner": 1})
+
+	cursor, err := r.squads.Find(ctx, bson.M{"resource_owner.tenant_id": tenantID}, opts)
+	if cursor != nil {
+		defer cursor.Close(ctx)
+	}
+
+	if err != nil {
+		slog.ErrorContext(ctx, "unable to find squads", "err", err)
+		return nil, err
+	}
+
+	rosters := make([]recap_entities.SquadRoster, 0)
+
+	for cursor.Next(ctx) {
+		var squad squad_entities.Squad
+		err := cursor.Decode(&squad)
+
+		if err != nil {
+			slog.ErrorContext(ctx, "error decoding squad", "err", err)
+			return nil, err
+		}
+
+		roster := recap_entities.SquadRoster{
+			SquadID:       squad.ID,
+			Name:          squad.Name,
+			UserIDs:       make([]uuid.UUID, 0, len(squad.Profiles)),
+			ResourceOwner: squad.ResourceOwner,
+		}
+
+		for key := range squad.Profiles {
+			userID, err := uuid.Parse(key)
+			if err != nil {
+				slog.WarnContext(ctx, "squad profile is not keyed by user, skipping", "squadID", squad.ID, "key", key)
+				continue
+			}
+
+			roster.UserIDs = append(roster.UserIDs, userID)
+		}
+
+		rosters = append(rosters, roster)
+	}
+
+	return rosters, nil
+}
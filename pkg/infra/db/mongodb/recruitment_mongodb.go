@@ -0,0 +1,180 @@
+package db
+
+import (
+	"context"
+	"log/slog"
+	"reflect"
+
+	"go.mongodb.org/mongo-driver/mongo"
+
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	squad_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/squad/entities"
+)
+
+type FreeAgentRepository struct {
+	MongoDBRepository[squad_entities.FreeAgent]
+}
+
+func NewFreeAgentRepository(client *mongo.Client, dbName string, entityType squad_entities.FreeAgent, collectionName string) *FreeAgentRepository {
+	repo := MongoDBRepository[squad_entities.FreeAgent]{
+		mongoClient:       client,
+		dbName:            dbName,
+		mappingCache:      make(map[string]CacheItem),
+		entityModel:       reflect.TypeOf(entityType),
+		bsonFieldMappings: make(map[string]string),
+		collectionName:    collectionName,
+		entityName:        reflect.TypeOf(entityType).Name(),
+		queryableFields:   make(map[string]bool),
+	}
+
+	repo.InitQueryableFields(map[string]bool{
+		"ID":            true,
+		"UserID":        true,
+		"PlayerID":      true,
+		"UserName":      true,
+		"GameID":        true,
+		"Roles":         true,
+		"Regions":       true,
+		"MMR":           true,
+		"Availability":  true,
+		"Message":       true,
+		"Status":        true,
+		"ResourceOwner": true,
+		"CreatedAt":     true,
+		"UpdatedAt":     true,
+	}, map[string]string{
+		"ID":                     "_id",
+		"UserID":                 "user_id",
+		"PlayerID":               "player_id",
+		"UserName":               "user_name",
+		"GameID":                 "game_id",
+		"Roles":                  "roles",
+		"Regions":                "regions",
+		"MMR":                    "mmr",
+		"Availability":           "availability",
+		"Message":                "message",
+		"Status":                 "status",
+		"ResourceOwner":          "resource_owner",
+		"CreatedAt":              "created_at",
+		"UpdatedAt":              "updated_at",
+		"ResourceOwner.TenantID": "resource_owner.tenant_id",
+		"ResourceOwner.UserID":   "resource_owner.user_id",
+		"ResourceOwner.GroupID":  "resource_owner.group_id",
+		"ResourceOwner.ClientID": "resource_owner.client_id",
+	})
+
+	return &FreeAgentRepository{
+		repo,
+	}
+}
+
+func (r *FreeAgentRepository) Search(ctx context.Context, s common.Search) ([]squad_entities.FreeAgent, error) {
+	cursor, err := r.Query(ctx, s)
+	if cursor != nil {
+		defer cursor.Close(ctx)
+	}
+
+	if err != nil {
+		slog.ErrorContext(ctx, "error querying free agents", "err", err)
+		return nil, err
+	}
+
+	results := make([]squad_entities.FreeAgent, 0)
+	for cursor.Next(ctx) {
+		var result squad_entities.FreeAgent
+		err := cursor.Decode(&result)
+
+		if err != nil {
+			slog.ErrorContext(ctx, "error decoding free agent", "err", err)
+			return nil, err
+		}
+
+		results = append(results, result)
+	}
+
+	return results, nil
+}
+
+type SquadOpeningRepository struct {
+	MongoDBRepository[squad_entities.SquadOpening]
+}
+
+func NewSquadOpeningRepository(client *mongo.Client, dbName string, entityType squad_entities.SquadOpening, collectionName string) *SquadOpeningRepository {
+	repo := MongoDBRepository[squad_entities.SquadOpening]{
+		mongoClient:       client,
+		dbName:            dbName,
+		mappingCache:      make(map[string]CacheItem),
+		entityModel:       reflect.TypeOf(entityType),
+		bsonFieldMappings: make(map[string]string),
+		collectionName:    collectionName,
+		entityName:        reflect.TypeOf(entityType).Name(),
+		queryableFields:   make(map[string]bool),
+	}
+
+	repo.InitQueryableFields(map[string]bool{
+		"ID":            true,
+		"SquadID":       true,
+		"SquadName":     true,
+		"GameID":        true,
+		"Roles":         true,
+		"Regions":       true,
+		"MinMMR":        true,
+		"MaxMMR":        true,
+		"Message":       true,
+		"Status":        true,
+		"PostedBy":      true,
+		"ResourceOwner": true,
+		"CreatedAt":     true,
+		"UpdatedAt":     true,
+	}, map[string]string{
+		"ID":                     "_id",
+		"SquadID":                "squad_id",
+		"SquadName":              "squad_name",
+		"GameID":                 "game_id",
+		"Roles":                  "roles",
+		"Regions":                "regions",
+		"MinMMR":                 "min_mmr",
+		"MaxMMR":                 "max_mmr",
+		"Message":                "message",
+		"Status":                 "status",
+		"PostedBy":               "posted_by",
+		"ResourceOwner":          "resource_owner",
+		"CreatedAt":              "created_at",
+		"UpdatedAt":              "updated_at",
+		"ResourceOwner.TenantID": "resource_owner.tenant_id",
+		"ResourceOwner.UserID":   "resource_owner.user_id",
+		"ResourceOwner.GroupID":  "resource_owner.group_id",
+		"ResourceOwner.ClientID": "resource_owner.client_id",
+	})
+
+	return &SquadOpeningRepository{
+		repo,
+	}
+}
+
+func (r *SquadOpeningRepository) Search(ctx context.Context, s common.Search) ([]squad_entities.SquadOpening, error) {
+	cursor, err := r.Query(ctx, s)
+	if cursor != nil {
+		defer cursor.Close(ctx)
+	}
+
+	if err != nil {
+		slog.ErrorContext(ctx, "error querying squad openings", "err", err)
+		return nil, err
+	}
+
+	results := make([]squad_entities.SquadOpening, 0)
+	for cursor.Next(ctx) {
+		var result squad_entities.SquadOpening
+		err := cursor.Decode(&result)
+
+		if err != nil {
+			slog.ErrorContext(ctx, "error decoding squad opening", "err", err)
+			return nil, err
+		}
+
+		results = append(results, result)
+	}
+
+	return results, nil
+}
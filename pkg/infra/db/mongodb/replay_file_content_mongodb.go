@@ -2,17 +2,23 @@ package db
 
 import (
 	"context"
+	"errors"
 	"io"
 	"log/slog"
 	"os"
 
+	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/gridfs"
 	"go.mongodb.org/mongo-driver/mongo/options"
 
 	"github.com/google/uuid"
+	"github.com/psavelis/team-pro/replay-api/pkg/domain/replay"
 )
 
+// default replay storage backend (GridFS)
+const ReplayFileContentBackendName = "mongodb"
+
 type ReplayFileContentRepository struct {
 	client *mongo.Client
 	bucket *gridfs.Bucket
@@ -86,3 +92,118 @@ func (r *ReplayFileContentRepository) Put(ctx context.Context, replayFileID uuid
 	return fileName, nil
 
 }
+
+// gridfsFile is the files collection document of a GridFS file.
+type gridfsFile struct {
+	ID        interface{} `bson:"_id"`
+	Length    int64       `bson:"length"`
+	ChunkSize int32       `bson:"chunkSize"`
+}
+
+// file returns the latest revision of the content (the one downloaded by name).
+func (r *ReplayFileContentRepository) file(ctx context.Context, replayFileID uuid.UUID) (*gridfsFile, error) {
+	var file gridfsFile
+
+	opts := options.FindOne().SetSort(bson.D{{Key: "uploadDate", Value: -1}})
+
+	err := r.bucket.GetFilesCollection().FindOne(ctx, bson.M{"filename": replayFileID.String() + ".dem"}, opts).Decode(&file)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, replay.NewReplayFileNotFoundError(replayFileID)
+		}
+
+		slog.ErrorContext(ctx, "error finding replay file content", "replayFileID", replayFileID, "err", err)
+		return nil, err
+	}
+
+	return &file, nil
+}
+
+func (r *ReplayFileContentRepository) Size(ctx context.Context, replayFileID uuid.UUID) (int64, error) {
+	file, err := r.file(ctx, replayFileID)
+	if err != nil {
+		return 0, err
+	}
+
+	return file.Length, nil
+}
+
+// Stream reads length bytes of the content from offset, starting at the chunk holding offset (the chunks before it aren't read).
+func (r *ReplayFileContentRepository) Stream(ctx context.Context, replayFileID uuid.UUID, offset int64, length int64) (io.ReadCloser, error) {
+	file, err := r.file(ctx, replayFileID)
+	if err != nil {
+		return nil, err
+	}
+
+	first := offset / int64(file.ChunkSize)
+
+	opts := options.Find().SetSort(bson.D{{Key: "n", Value: 1}})
+
+	cursor, err := r.bucket.GetChunksCollection().Find(ctx, bson.M{"files_id": file.ID, "n": bson.M{"$gte": first}}, opts)
+	if err != nil {
+		slog.ErrorContext(ctx, "error finding replay file content chunks", "replayFileID", replayFileID, "err", err)
+		return nil, err
+	}
+
+	return &chunkReader{
+		ctx:       ctx,
+		cursor:    cursor,
+		skip:      int(offset - first*int64(file.ChunkSize)),
+		remaining: length,
+	}, nil
+}
+
+// chunkReader reads the data of the chunks returned by the cursor, skipping the bytes before the offset in the first one.
+type chunkReader struct {
+	ctx       context.Context
+	cursor    *mongo.Cursor
+	skip      int
+	remaining int64
+	data      []byte
+}
+
+func (c *chunkReader) Read(p []byte) (int, error) {
+	if c.remaining <= 0 {
+		return 0, io.EOF
+	}
+
+	if len(c.data) == 0 {
+		if !c.cursor.Next(c.ctx) {
+			if c.cursor.Err() != nil {
+				return 0, c.cursor.Err()
+			}
+
+			return 0, io.ErrUnexpectedEOF
+		}
+
+		var chunk struct {
+			Data []byte `bson:"data"`
+		}
+
+		err := c.cursor.Decode(&chunk)
+		if err != nil {
+			return 0, err
+		}
+
+		if c.skip > len(chunk.Data) {
+			return 0, io.ErrUnexpectedEOF
+		}
+
+		c.data = chunk.Data[c.skip:]
+		c.skip = 0
+	}
+
+	if int64(len(p)) > c.remaining {
+		p = p[:c.remaining]
+	}
+
+	n := copy(p, c.data)
+	c.data = c.data[n:]
+	c.remaining -= int64(n)
+
+	return n, nil
+}
+
+func (c *chunkReader) Close() error {
+	return c.cursor.Close(context.Background())
+}
@@ -24,18 +24,23 @@ func NewReplayFileMetadataRepository(client *mongo.Client, dbName string, entity
 	}
 
 	repo.InitQueryableFields(map[string]bool{
-		"ID":               true,
-		"GameID":           true,
-		"NetworkID":        true,
-		"Size":             true,
-		"InternalURI":      true,
-		"Status":           true,
-		"Error":            true,
-		"Header":           true,
-		"Header.Filestamp": true,
-		"ResourceOwner":    true,
-		"CreatedAt":        true,
-		"UpdatedAt":        true,
+		"ID":                  true,
+		"GameID":              true,
+		"NetworkID":           true,
+		"Size":                true,
+		"InternalURI":         true,
+		"Status":              true,
+		"Error":               true,
+		"Header":              true,
+		"Header.Filestamp":    true,
+		"ResourceOwner":       true,
+		"CreatedAt":           true,
+		"UpdatedAt":           true,
+		"Tags":                true,
+		"Tags.Platform":       true,
+		"Tags.ServerRegion":   true,
+		"Tags.ServerLocation": true,
+		"Tags.Tickrate":       true,
 	}, map[string]string{
 		"ID":                     "_id",
 		"GameID":                 "game_id",
@@ -53,6 +58,11 @@ func NewReplayFileMetadataRepository(client *mongo.Client, dbName string, entity
 		"ResourceOwner.UserID":   "resource_owner.user_id",
 		"ResourceOwner.GroupID":  "resource_owner.group_id",
 		"ResourceOwner.ClientID": "resource_owner.client_id",
+		"Tags":                   "tags",
+		"Tags.Platform":          "tags.platform",
+		"Tags.ServerRegion":      "tags.server_region",
+		"Tags.ServerLocation":    "tags.server_location",
+		"Tags.Tickrate":          "tags.tickrate",
 	})
 
 	return &ReplayFileMetadataRepository{
@@ -0,0 +1,83 @@
+package db
+
+import (
+	"context"
+	"log/slog"
+	"reflect"
+
+	"go.mongodb.org/mongo-driver/mongo"
+
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	replay_entity "github.com/psavelis/team-pro/replay-api/pkg/domain/replay/entities"
+)
+
+type ReplayHighlightsRepository struct {
+	MongoDBRepository[replay_entity.ReplayHighlights]
+}
+
+func NewReplayHighlightsRepository(client *mongo.Client, dbName string, entityType replay_entity.ReplayHighlights, collectionName string) *ReplayHighlightsRepository {
+	repo := MongoDBRepository[replay_entity.ReplayHighlights]{
+		mongoClient:       client,
+		dbName:            dbName,
+		mappingCache:      make(map[string]CacheItem),
+		entityModel:       reflect.TypeOf(entityType),
+		bsonFieldMappings: make(map[string]string),
+		collectionName:    collectionName,
+		entityName:        reflect.TypeOf(entityType).Name(),
+		queryableFields:   make(map[string]bool),
+	}
+
+	repo.InitQueryableFields(map[string]bool{
+		"ID":            true,
+		"GameID":        true,
+		"MatchID":       true,
+		"ReplayFileID":  true,
+		"ResourceOwner": true,
+		"CreatedAt":     true,
+		"UpdatedAt":     true,
+	}, map[string]string{
+		"ID":                     "_id",
+		"GameID":                 "game_id",
+		"MatchID":                "match_id",
+		"ReplayFileID":           "replay_file_id",
+		"Highlights":             "highlights",
+		"ResourceOwner":          "resource_owner",
+		"CreatedAt":              "created_at",
+		"UpdatedAt":              "updated_at",
+		"ResourceOwner.TenantID": "resource_owner.tenant_id",
+		"ResourceOwner.UserID":   "resource_owner.user_id",
+		"ResourceOwner.GroupID":  "resource_owner.group_id",
+		"ResourceOwner.ClientID": "resource_owner.client_id",
+	})
+
+	return &ReplayHighlightsRepository{
+		repo,
+	}
+}
+
+func (r *ReplayHighlightsRepository) Search(ctx context.Context, s common.Search) ([]replay_entity.ReplayHighlights, error) {
+	cursor, err := r.Query(ctx, s)
+	if cursor != nil {
+		defer cursor.Close(ctx)
+	}
+
+	if err != nil {
+		slog.ErrorContext(ctx, "error querying replay highlights", "err", err)
+		return nil, err
+	}
+
+	highlights := make([]replay_entity.ReplayHighlights, 0)
+	for cursor.Next(ctx) {
+		var h replay_entity.ReplayHighlights
+		err := cursor.Decode(&h)
+
+		if err != nil {
+			slog.ErrorContext(ctx, "error decoding replay highlights", "err", err)
+			return nil, err
+		}
+
+		highlights = append(highlights, h)
+	}
+
+	return highlights, nil
+}
@@ -0,0 +1,85 @@
+package db
+
+import (
+	"context"
+	"log/slog"
+	"reflect"
+
+	"go.mongodb.org/mongo-driver/mongo"
+
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	replay_entity "github.com/psavelis/team-pro/replay-api/pkg/domain/replay/entities"
+)
+
+type ReplayPositionsRepository struct {
+	MongoDBRepository[replay_entity.ReplayPositions]
+}
+
+func NewReplayPositionsRepository(client *mongo.Client, dbName string, entityType replay_entity.ReplayPositions, collectionName string) *ReplayPositionsRepository {
+	repo := MongoDBRepository[replay_entity.ReplayPositions]{
+		mongoClient:       client,
+		dbName:            dbName,
+		mappingCache:      make(map[string]CacheItem),
+		entityModel:       reflect.TypeOf(entityType),
+		bsonFieldMappings: make(map[string]string),
+		collectionName:    collectionName,
+		entityName:        reflect.TypeOf(entityType).Name(),
+		queryableFields:   make(map[string]bool),
+	}
+
+	repo.InitQueryableFields(map[string]bool{
+		"ID":            true,
+		"GameID":        true,
+		"MatchID":       true,
+		"ReplayFileID":  true,
+		"MapName":       true,
+		"RoundNumber":   true,
+		"ResourceOwner": true,
+		"CreatedAt":     true,
+	}, map[string]string{
+		"ID":                     "_id",
+		"GameID":                 "game_id",
+		"MatchID":                "match_id",
+		"ReplayFileID":           "replay_file_id",
+		"MapName":                "map_name",
+		"RoundNumber":            "round_number",
+		"Samples":                "samples",
+		"ResourceOwner":          "resource_owner",
+		"CreatedAt":              "created_at",
+		"ResourceOwner.TenantID": "resource_owner.tenant_id",
+		"ResourceOwner.UserID":   "resource_owner.user_id",
+		"ResourceOwner.GroupID":  "resource_owner.group_id",
+		"ResourceOwner.ClientID": "resource_owner.client_id",
+	})
+
+	return &ReplayPositionsRepository{
+		repo,
+	}
+}
+
+func (r *ReplayPositionsRepository) Search(ctx context.Context, s common.Search) ([]replay_entity.ReplayPositions, error) {
+	cursor, err := r.Query(ctx, s)
+	if cursor != nil {
+		defer cursor.Close(ctx)
+	}
+
+	if err != nil {
+		slog.ErrorContext(ctx, "error querying replay positions", "err", err)
+		return nil, err
+	}
+
+	positions := make([]replay_entity.ReplayPositions, 0)
+	for cursor.Next(ctx) {
+		var round replay_entity.ReplayPositions
+		err := cursor.Decode(&round)
+
+		if err != nil {
+			slog.ErrorContext(ctx, "error decoding replay positions", "err", err)
+			return nil, err
+		}
+
+		positions = append(positions, round)
+	}
+
+	return positions, nil
+}
@@ -1,8 +1,13 @@
 package db
 
 import (
+	"context"
+	"log/slog"
 	"reflect"
+	"time"
 
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo"
 
 	iam_entity "github.com/psavelis/team-pro/replay-api/pkg/domain/iam/entities"
@@ -29,6 +34,8 @@ func NewRIDTokenRepository(client *mongo.Client, dbName string, entityType iam_e
 		"ID":                     true,
 		"Key":                    true,
 		"Source":                 true,
+		"SessionID":              true,
+		"RefreshKeyHash":         true,
 		"ResourceOwner":          true,
 		"ExpiresAt":              true,
 		"CreatedAt":              true,
@@ -41,6 +48,11 @@ func NewRIDTokenRepository(client *mongo.Client, dbName string, entityType iam_e
 		"ID":                     "_id",
 		"Key":                    "key",
 		"Source":                 "source",
+		"SessionID":              "session_id",
+		"RefreshKeyHash":         "refresh_key_hash",
+		"RefreshExpiresAt":       "refresh_expires_at",
+		"RotatedAt":              "rotated_at",
+		"RevokedAt":              "revoked_at",
 		"ResourceOwner":          "resource_owner",
 		"ExpiresAt":              "expires_at",
 		"CreatedAt":              "created_at",
@@ -55,3 +67,25 @@ func NewRIDTokenRepository(client *mongo.Client, dbName string, entityType iam_e
 		repo,
 	}
 }
+
+// Rotate marks the refresh key used (and the token expired) only when it wasn't already, so a refresh key is rotated once.
+func (r *RIDTokenRepository) Rotate(ctx context.Context, id uuid.UUID, at time.Time) (bool, error) {
+	res, err := r.collection.UpdateOne(ctx, bson.M{"_id": id, "rotated_at": nil}, bson.M{"$set": bson.M{"rotated_at": at, "expires_at": at, "updated_at": at}})
+	if err != nil {
+		slog.ErrorContext(ctx, "error rotating rid token", "id", id, "err", err)
+		return false, err
+	}
+
+	return res.ModifiedCount > 0, nil
+}
+
+// RevokeSession expires the (not yet revoked) tokens of the session, so neither their RIDs nor refresh keys can be used.
+func (r *RIDTokenRepository) RevokeSession(ctx context.Context, sessionID uuid.UUID, at time.Time) error {
+	_, err := r.collection.UpdateMany(ctx, bson.M{"session_id": sessionID, "revoked_at": nil}, bson.M{"$set": bson.M{"revoked_at": at, "expires_at": at, "updated_at": at}})
+	if err != nil {
+		slog.ErrorContext(ctx, "error revoking rid tokens of session", "sessionID", sessionID, "err", err)
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,87 @@
+package db
+
+import (
+	"context"
+	"log/slog"
+	"reflect"
+
+	"go.mongodb.org/mongo-driver/mongo"
+
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	iam_entity "github.com/psavelis/team-pro/replay-api/pkg/domain/iam/entities"
+)
+
+type RIDSessionRepository struct {
+	MongoDBRepository[iam_entity.RIDSession]
+}
+
+func NewRIDSessionRepository(client *mongo.Client, dbName string, entityType iam_entity.RIDSession, collectionName string) *RIDSessionRepository {
+	repo := MongoDBRepository[iam_entity.RIDSession]{
+		mongoClient:       client,
+		dbName:            dbName,
+		mappingCache:      make(map[string]CacheItem),
+		entityModel:       reflect.TypeOf(entityType),
+		bsonFieldMappings: make(map[string]string),
+		collectionName:    collectionName,
+		entityName:        reflect.TypeOf(entityType).Name(),
+		queryableFields:   make(map[string]bool),
+	}
+
+	repo.InitQueryableFields(map[string]bool{
+		"ID":            true,
+		"Source":        true,
+		"LastUsedAt":    true,
+		"ExpiresAt":     true,
+		"RevokedAt":     true,
+		"ResourceOwner": true,
+		"CreatedAt":     true,
+		"UpdatedAt":     true,
+	}, map[string]string{
+		"ID":                     "_id",
+		"Source":                 "rid_source",
+		"UserAgent":              "user_agent",
+		"IPAddress":              "ip_address",
+		"LastUsedAt":             "last_used_at",
+		"ExpiresAt":              "expires_at",
+		"RevokedAt":              "revoked_at",
+		"RevokedReason":          "revoked_reason",
+		"ResourceOwner":          "resource_owner",
+		"CreatedAt":              "created_at",
+		"UpdatedAt":              "updated_at",
+		"ResourceOwner.TenantID": "resource_owner.tenant_id",
+		"ResourceOwner.UserID":   "resource_owner.user_id",
+		"ResourceOwner.GroupID":  "resource_owner.group_id",
+		"ResourceOwner.ClientID": "resource_owner.client_id",
+	})
+
+	return &RIDSessionRepository{
+		repo,
+	}
+}
+
+func (r *RIDSessionRepository) Search(ctx context.Context, s common.Search) ([]iam_entity.RIDSession, error) {
+	cursor, err := r.Query(ctx, s)
+	if cursor != nil {
+		defer cursor.Close(ctx)
+	}
+
+	if err != nil {
+		slog.ErrorContext(ctx, "error querying rid sessions", "err", err)
+		return nil, err
+	}
+
+	sessions := make([]iam_entity.RIDSession, 0)
+	for cursor.Next(ctx) {
+		var session iam_entity.RIDSession
+		err := cursor.Decode(&session)
+
+		if err != nil {
+			slog.ErrorContext(ctx, "error decoding rid session", "err", err)
+			return nil, err
+		}
+
+		sessions = append(sessions, session)
+	}
+
+	return sessions, nil
+}
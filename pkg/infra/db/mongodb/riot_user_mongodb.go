@@ -0,0 +1,51 @@
+package db
+
+import (
+	"reflect"
+
+	riot_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/riot/entities"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type RiotUserRepository struct {
+	MongoDBRepository[riot_entities.RiotUser]
+}
+
+func NewRiotUserMongoDBRepository(client *mongo.Client, dbName string, entityType riot_entities.RiotUser, collectionName string) *RiotUserRepository {
+	repo := MongoDBRepository[riot_entities.RiotUser]{
+		mongoClient:       client,
+		dbName:            dbName,
+		mappingCache:      make(map[string]CacheItem),
+		entityModel:       reflect.TypeOf(entityType),
+		bsonFieldMappings: make(map[string]string),
+		collectionName:    collectionName,
+		entityName:        reflect.TypeOf(entityType).Name(),
+		queryableFields:   make(map[string]bool),
+	}
+
+	repo.InitQueryableFields(map[string]bool{
+		"ID":            true,
+		"VHash":         true,
+		"PUUID":         true,
+		"GameName":      true,
+		"TagLine":       true,
+		"ActiveShard":   true,
+		"ResourceOwner": true,
+		"CreatedAt":     true,
+		"UpdatedAt":     true,
+	}, map[string]string{
+		"ID":            "id",
+		"VHash":         "v_hash",
+		"PUUID":         "puuid",
+		"GameName":      "game_name",
+		"TagLine":       "tag_line",
+		"ActiveShard":   "active_shard",
+		"ResourceOwner": "resource_owner",
+		"CreatedAt":     "created_at",
+		"UpdatedAt":     "updated_at",
+	})
+
+	return &RiotUserRepository{
+		repo,
+	}
+}
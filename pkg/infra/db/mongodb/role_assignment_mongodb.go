@@ -0,0 +1,83 @@
+package db
+
+import (
+	"context"
+	"log/slog"
+	"reflect"
+
+	"go.mongodb.org/mongo-driver/mongo"
+
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	iam_entity "github.com/psavelis/team-pro/replay-api/pkg/domain/iam/entities"
+)
+
+type RoleAssignmentRepository struct {
+	MongoDBRepository[iam_entity.RoleAssignment]
+}
+
+func NewRoleAssignmentRepository(client *mongo.Client, dbName string, entityType iam_entity.RoleAssignment, collectionName string) *RoleAssignmentRepository {
+	repo := MongoDBRepository[iam_entity.RoleAssignment]{
+		mongoClient:       client,
+		dbName:            dbName,
+		mappingCache:      make(map[string]CacheItem),
+		entityModel:       reflect.TypeOf(entityType),
+		bsonFieldMappings: make(map[string]string),
+		collectionName:    collectionName,
+		entityName:        reflect.TypeOf(entityType).Name(),
+		queryableFields:   make(map[string]bool),
+	}
+
+	repo.InitQueryableFields(map[string]bool{
+		"ID":            true,
+		"RoleID":        true,
+		"UserID":        true,
+		"RevokedAt":     true,
+		"ResourceOwner": true,
+		"CreatedAt":     true,
+		"UpdatedAt":     true,
+	}, map[string]string{
+		"ID":                     "_id",
+		"RoleID":                 "role_id",
+		"UserID":                 "user_id",
+		"AssignedBy":             "assigned_by",
+		"RevokedAt":              "revoked_at",
+		"ResourceOwner":          "resource_owner",
+		"CreatedAt":              "created_at",
+		"UpdatedAt":              "updated_at",
+		"ResourceOwner.TenantID": "resource_owner.tenant_id",
+		"ResourceOwner.UserID":   "resource_owner.user_id",
+		"ResourceOwner.GroupID":  "resource_owner.group_id",
+		"ResourceOwner.ClientID": "resource_owner.client_id",
+	})
+
+	return &RoleAssignmentRepository{
+		repo,
+	}
+}
+
+func (r *RoleAssignmentRepository) Search(ctx context.Context, s common.Search) ([]iam_entity.RoleAssignment, error) {
+	cursor, err := r.Query(ctx, s)
+	if cursor != nil {
+		defer cursor.Close(ctx)
+	}
+
+	if err != nil {
+		slog.ErrorContext(ctx, "error querying role assignments", "err", err)
+		return nil, err
+	}
+
+	assignments := make([]iam_entity.RoleAssignment, 0)
+	for cursor.Next(ctx) {
+		var assignment iam_entity.RoleAssignment
+		err := cursor.Decode(&assignment)
+
+		if err != nil {
+			slog.ErrorContext(ctx, "error decoding role assignment", "err", err)
+			return nil, err
+		}
+
+		assignments = append(assignments, assignment)
+	}
+
+	return assignments, nil
+}
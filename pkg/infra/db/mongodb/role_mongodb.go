@@ -0,0 +1,81 @@
+package db
+
+import (
+	"context"
+	"log/slog"
+	"reflect"
+
+	"go.mongodb.org/mongo-driver/mongo"
+
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	iam_entity "github.com/psavelis/team-pro/replay-api/pkg/domain/iam/entities"
+)
+
+type RoleRepository struct {
+	MongoDBRepository[iam_entity.Role]
+}
+
+func NewRoleRepository(client *mongo.Client, dbName string, entityType iam_entity.Role, collectionName string) *RoleRepository {
+	repo := MongoDBRepository[iam_entity.Role]{
+		mongoClient:       client,
+		dbName:            dbName,
+		mappingCache:      make(map[string]CacheItem),
+		entityModel:       reflect.TypeOf(entityType),
+		bsonFieldMappings: make(map[string]string),
+		collectionName:    collectionName,
+		entityName:        reflect.TypeOf(entityType).Name(),
+		queryableFields:   make(map[string]bool),
+	}
+
+	repo.InitQueryableFields(map[string]bool{
+		"ID":            true,
+		"Name":          true,
+		"Permissions":   true,
+		"ResourceOwner": true,
+		"CreatedAt":     true,
+		"UpdatedAt":     true,
+	}, map[string]string{
+		"ID":                     "_id",
+		"Name":                   "name",
+		"Description":            "description",
+		"Permissions":            "permissions",
+		"ResourceOwner":          "resource_owner",
+		"CreatedAt":              "created_at",
+		"UpdatedAt":              "updated_at",
+		"ResourceOwner.TenantID": "resource_owner.tenant_id",
+		"ResourceOwner.UserID":   "resource_owner.user_id",
+		"ResourceOwner.GroupID":  "resource_owner.group_id",
+		"ResourceOwner.ClientID": "resource_owner.client_id",
+	})
+
+	return &RoleRepository{
+		repo,
+	}
+}
+
+func (r *RoleRepository) Search(ctx context.Context, s common.Search) ([]iam_entity.Role, error) {
+	cursor, err := r.Query(ctx, s)
+	if cursor != nil {
+		defer cursor.Close(ctx)
+	}
+
+	if err != nil {
+		slog.ErrorContext(ctx, "error querying roles", "err", err)
+		return nil, err
+	}
+
+	roles := make([]iam_entity.Role, 0)
+	for cursor.Next(ctx) {
+		var role iam_entity.Role
+		err := cursor.Decode(&role)
+
+		if err != nil {
+			slog.ErrorContext(ctx, "error decoding role", "err", err)
+			return nil, err
+		}
+
+		roles = append(roles, role)
+	}
+
+	return roles, nil
+}
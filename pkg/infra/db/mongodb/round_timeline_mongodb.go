@@ -0,0 +1,83 @@
+package db
+
+import (
+	"context"
+	"log/slog"
+	"reflect"
+
+	"go.mongodb.org/mongo-driver/mongo"
+
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	replay_entity "github.com/psavelis/team-pro/replay-api/pkg/domain/replay/entities"
+)
+
+type RoundTimelineRepository struct {
+	MongoDBRepository[replay_entity.RoundTimeline]
+}
+
+func NewRoundTimelineRepository(client *mongo.Client, dbName string, entityType replay_entity.RoundTimeline, collectionName string) *RoundTimelineRepository {
+	repo := MongoDBRepository[replay_entity.RoundTimeline]{
+		mongoClient:       client,
+		dbName:            dbName,
+		mappingCache:      make(map[string]CacheItem),
+		entityModel:       reflect.TypeOf(entityType),
+		bsonFieldMappings: make(map[string]string),
+		collectionName:    collectionName,
+		entityName:        reflect.TypeOf(entityType).Name(),
+		queryableFields:   make(map[string]bool),
+	}
+
+	repo.InitQueryableFields(map[string]bool{
+		"ID":            true,
+		"GameID":        true,
+		"MatchID":       true,
+		"ReplayFileID":  true,
+		"ResourceOwner": true,
+		"CreatedAt":     true,
+		"UpdatedAt":     true,
+	}, map[string]string{
+		"ID":                     "_id",
+		"GameID":                 "game_id",
+		"MatchID":                "match_id",
+		"ReplayFileID":           "replay_file_id",
+		"Rounds":                 "rounds",
+		"ResourceOwner":          "resource_owner",
+		"CreatedAt":              "created_at",
+		"UpdatedAt":              "updated_at",
+		"ResourceOwner.TenantID": "resource_owner.tenant_id",
+		"ResourceOwner.UserID":   "resource_owner.user_id",
+		"ResourceOwner.GroupID":  "resource_owner.group_id",
+		"ResourceOwner.ClientID": "resource_owner.client_id",
+	})
+
+	return &RoundTimelineRepository{
+		repo,
+	}
+}
+
+func (r *RoundTimelineRepository) Search(ctx context.Context, s common.Search) ([]replay_entity.RoundTimeline, error) {
+	cursor, err := r.Query(ctx, s)
+	if cursor != nil {
+		defer cursor.Close(ctx)
+	}
+
+	if err != nil {
+		slog.ErrorContext(ctx, "error querying round timelines", "err", err)
+		return nil, err
+	}
+
+	timelines := make([]replay_entity.RoundTimeline, 0)
+	for cursor.Next(ctx) {
+		var timeline replay_entity.RoundTimeline
+		err := cursor.Decode(&timeline)
+
+		if err != nil {
+			slog.ErrorContext(ctx, "error decoding round timeline", "err", err)
+			return nil, err
+		}
+
+		timelines = append(timelines, timeline)
+	}
+
+	return timelines, nil
+}
@@ -0,0 +1,94 @@
+package db
+
+import (
+	"context"
+	"log/slog"
+	"reflect"
+
+	"go.mongodb.org/mongo-driver/mongo"
+
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	season_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/season/entities"
+)
+
+type SeasonRepository struct {
+	MongoDBRepository[season_entities.Season]
+}
+
+func NewSeasonRepository(client *mongo.Client, dbName string, entityType season_entities.Season, collectionName string) *SeasonRepository {
+	repo := MongoDBRepository[season_entities.Season]{
+		mongoClient:       client,
+		dbName:            dbName,
+		mappingCache:      make(map[string]CacheItem),
+		entityModel:       reflect.TypeOf(entityType),
+		bsonFieldMappings: make(map[string]string),
+		collectionName:    collectionName,
+		entityName:        reflect.TypeOf(entityType).Name(),
+		queryableFields:   make(map[string]bool),
+	}
+
+	repo.InitQueryableFields(map[string]bool{
+		"ID":            true,
+		"GameID":        true,
+		"Name":          true,
+		"StartsAt":      true,
+		"EndsAt":        true,
+		"Status":        true,
+		"Reset":         true,
+		"RewardTiers":   true,
+		"ActivatedAt":   true,
+		"EndedAt":       true,
+		"ResourceOwner": true,
+		"CreatedAt":     true,
+		"UpdatedAt":     true,
+	}, map[string]string{
+		"ID":                     "_id",
+		"GameID":                 "game_id",
+		"Name":                   "name",
+		"StartsAt":               "starts_at",
+		"EndsAt":                 "ends_at",
+		"Status":                 "status",
+		"Reset":                  "reset",
+		"RewardTiers":            "reward_tiers",
+		"ActivatedAt":            "activated_at",
+		"EndedAt":                "ended_at",
+		"ResourceOwner":          "resource_owner",
+		"CreatedAt":              "created_at",
+		"UpdatedAt":              "updated_at",
+		"ResourceOwner.TenantID": "resource_owner.tenant_id",
+		"ResourceOwner.UserID":   "resource_owner.user_id",
+		"ResourceOwner.GroupID":  "resource_owner.group_id",
+		"ResourceOwner.ClientID": "resource_owner.client_id",
+	})
+
+	return &SeasonRepository{
+		repo,
+	}
+}
+
+func (r *SeasonRepository) Search(ctx context.Context, s common.Search) ([]season_entities.Season, error) {
+	cursor, err := r.Query(ctx, s)
+	if cursor != nil {
+		defer cursor.Close(ctx)
+	}
+
+	if err != nil {
+		slog.ErrorContext(ctx, "error querying seasons", "err", err)
+		return nil, err
+	}
+
+	seasons := make([]season_entities.Season, 0)
+	for cursor.Next(ctx) {
+		var season season_entities.Season
+		err := cursor.Decode(&season)
+
+		if err != nil {
+			slog.ErrorContext(ctx, "error decoding season", "err", err)
+			return nil, err
+		}
+
+		seasons = append(seasons, season)
+	}
+
+	return seasons, nil
+}
@@ -0,0 +1,109 @@
+package db
+
+import (
+	"context"
+	"log/slog"
+	"reflect"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	season_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/season/entities"
+)
+
+type SeasonRewardRepository struct {
+	MongoDBRepository[season_entities.SeasonReward]
+}
+
+func NewSeasonRewardRepository(client *mongo.Client, dbName string, entityType season_entities.SeasonReward, collectionName string) *SeasonRewardRepository {
+	repo := MongoDBRepository[season_entities.SeasonReward]{
+		mongoClient:       client,
+		dbName:            dbName,
+		mappingCache:      make(map[string]CacheItem),
+		entityModel:       reflect.TypeOf(entityType),
+		bsonFieldMappings: make(map[string]string),
+		collectionName:    collectionName,
+		entityName:        reflect.TypeOf(entityType).Name(),
+		queryableFields:   make(map[string]bool),
+		collection:        client.Database(dbName).Collection(collectionName),
+	}
+
+	repo.InitQueryableFields(map[string]bool{
+		"ID":            true,
+		"SeasonID":      true,
+		"GameID":        true,
+		"PlayerID":      true,
+		"Tier":          true,
+		"Rating":        true,
+		"MatchesPlayed": true,
+		"ResourceOwner": true,
+		"CreatedAt":     true,
+	}, map[string]string{
+		"ID":                     "_id",
+		"SeasonID":               "season_id",
+		"GameID":                 "game_id",
+		"PlayerID":               "player_id",
+		"Tier":                   "tier",
+		"Rating":                 "rating",
+		"MatchesPlayed":          "matches_played",
+		"ResourceOwner":          "resource_owner",
+		"CreatedAt":              "created_at",
+		"ResourceOwner.TenantID": "resource_owner.tenant_id",
+		"ResourceOwner.UserID":   "resource_owner.user_id",
+		"ResourceOwner.GroupID":  "resource_owner.group_id",
+		"ResourceOwner.ClientID": "resource_owner.client_id",
+	})
+
+	return &SeasonRewardRepository{
+		repo,
+	}
+}
+
+func (r *SeasonRewardRepository) Search(ctx context.Context, s common.Search) ([]season_entities.SeasonReward, error) {
+	cursor, err := r.Query(ctx, s)
+	if cursor != nil {
+		defer cursor.Close(ctx)
+	}
+
+	if err != nil {
+		slog.ErrorContext(ctx, "error querying season rewards", "err", err)
+		return nil, err
+	}
+
+	rewards := make([]season_entities.SeasonReward, 0)
+	for cursor.Next(ctx) {
+		var reward season_entities.SeasonReward
+		err := cursor.Decode(&reward)
+
+		if err != nil {
+			slog.ErrorContext(ctx, "error decoding season reward", "err", err)
+			return nil, err
+		}
+
+		rewards = append(rewards, reward)
+	}
+
+	return rewards, nil
+}
+
+// Upsert writes the rewards in a single unordered bulk, replacing the rewards of a season ended again.
+func (r *SeasonRewardRepository) Upsert(ctx context.Context, rewards []season_entities.SeasonReward) error {
+	if len(rewards) == 0 {
+		return nil
+	}
+
+	models := make([]mongo.WriteModel, 0, len(rewards))
+	for _, reward := range rewards {
+		models = append(models, mongo.NewReplaceOneModel().SetFilter(bson.M{"_id": reward.ID}).SetReplacement(reward).SetUpsert(true))
+	}
+
+	_, err := r.collection.BulkWrite(ctx, models, options.BulkWrite().SetOrdered(false))
+	if err != nil {
+		slog.ErrorContext(ctx, "unable to upsert season rewards", "err", err, "rewards", len(rewards))
+		return err
+	}
+
+	return nil
+}
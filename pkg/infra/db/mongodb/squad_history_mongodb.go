@@ -0,0 +1,82 @@
+package db
+
+import (
+	"context"
+	"log/slog"
+	"reflect"
+
+	"go.mongodb.org/mongo-driver/mongo"
+
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	squad_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/squad/entities"
+)
+
+type SquadHistoryRepository struct {
+	MongoDBRepository[squad_entities.SquadHistory]
+}
+
+func NewSquadHistoryRepository(client *mongo.Client, dbName string, entityType squad_entities.SquadHistory, collectionName string) *SquadHistoryRepository {
+	repo := MongoDBRepository[squad_entities.SquadHistory]{
+		mongoClient:       client,
+		dbName:            dbName,
+		mappingCache:      make(map[string]CacheItem),
+		entityModel:       reflect.TypeOf(entityType),
+		bsonFieldMappings: make(map[string]string),
+		collectionName:    collectionName,
+		entityName:        reflect.TypeOf(entityType).Name(),
+		queryableFields:   make(map[string]bool),
+	}
+
+	repo.InitQueryableFields(map[string]bool{
+		"ID":            true,
+		"SquadID":       true,
+		"UserID":        true,
+		"ActorID":       true,
+		"Action":        true,
+		"ResourceOwner": true,
+		"CreatedAt":     true,
+	}, map[string]string{
+		"ID":                     "_id",
+		"SquadID":                "squad_id",
+		"UserID":                 "user_id",
+		"ActorID":                "actor_id",
+		"Action":                 "action",
+		"ResourceOwner":          "resource_owner",
+		"CreatedAt":              "created_at",
+		"ResourceOwner.TenantID": "resource_owner.tenant_id",
+		"ResourceOwner.UserID":   "resource_owner.user_id",
+		"ResourceOwner.GroupID":  "resource_owner.group_id",
+		"ResourceOwner.ClientID": "resource_owner.client_id",
+	})
+
+	return &SquadHistoryRepository{
+		repo,
+	}
+}
+
+func (r *SquadHistoryRepository) Search(ctx context.Context, s common.Search) ([]squad_entities.SquadHistory, error) {
+	cursor, err := r.Query(ctx, s)
+	if cursor != nil {
+		defer cursor.Close(ctx)
+	}
+
+	if err != nil {
+		slog.ErrorContext(ctx, "error querying squad history", "err", err)
+		return nil, err
+	}
+
+	history := make([]squad_entities.SquadHistory, 0)
+	for cursor.Next(ctx) {
+		var entry squad_entities.SquadHistory
+		err := cursor.Decode(&entry)
+
+		if err != nil {
+			slog.ErrorContext(ctx, "error decoding squad history", "err", err)
+			return nil, err
+		}
+
+		history = append(history, entry)
+	}
+
+	return history, nil
+}
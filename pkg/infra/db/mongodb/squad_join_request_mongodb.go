@@ -0,0 +1,90 @@
+package db
+
+import (
+	"context"
+	"log/slog"
+	"reflect"
+
+	"go.mongodb.org/mongo-driver/mongo"
+
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	squad_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/squad/entities"
+)
+
+type JoinRequestRepository struct {
+	MongoDBRepository[squad_entities.JoinRequest]
+}
+
+func NewJoinRequestRepository(client *mongo.Client, dbName string, entityType squad_entities.JoinRequest, collectionName string) *JoinRequestRepository {
+	repo := MongoDBRepository[squad_entities.JoinRequest]{
+		mongoClient:       client,
+		dbName:            dbName,
+		mappingCache:      make(map[string]CacheItem),
+		entityModel:       reflect.TypeOf(entityType),
+		bsonFieldMappings: make(map[string]string),
+		collectionName:    collectionName,
+		entityName:        reflect.TypeOf(entityType).Name(),
+		queryableFields:   make(map[string]bool),
+	}
+
+	repo.InitQueryableFields(map[string]bool{
+		"ID":            true,
+		"SquadID":       true,
+		"UserID":        true,
+		"Kind":          true,
+		"Status":        true,
+		"RequestedBy":   true,
+		"ResourceOwner": true,
+		"CreatedAt":     true,
+		"UpdatedAt":     true,
+	}, map[string]string{
+		"ID":                     "_id",
+		"SquadID":                "squad_id",
+		"UserID":                 "user_id",
+		"UserName":               "user_name",
+		"Kind":                   "kind",
+		"Status":                 "status",
+		"Message":                "message",
+		"RequestedBy":            "requested_by",
+		"RespondedBy":            "responded_by",
+		"RespondedAt":            "responded_at",
+		"ResourceOwner":          "resource_owner",
+		"CreatedAt":              "created_at",
+		"UpdatedAt":              "updated_at",
+		"ResourceOwner.TenantID": "resource_owner.tenant_id",
+		"ResourceOwner.UserID":   "resource_owner.user_id",
+		"ResourceOwner.GroupID":  "resource_owner.group_id",
+		"ResourceOwner.ClientID": "resource_owner.client_id",
+	})
+
+	return &JoinRequestRepository{
+		repo,
+	}
+}
+
+func (r *JoinRequestRepository) Search(ctx context.Context, s common.Search) ([]squad_entities.JoinRequest, error) {
+	cursor, err := r.Query(ctx, s)
+	if cursor != nil {
+		defer cursor.Close(ctx)
+	}
+
+	if err != nil {
+		slog.ErrorContext(ctx, "error querying squad join requests", "err", err)
+		return nil, err
+	}
+
+	requests := make([]squad_entities.JoinRequest, 0)
+	for cursor.Next(ctx) {
+		var request squad_entities.JoinRequest
+		err := cursor.Decode(&request)
+
+		if err != nil {
+			slog.ErrorContext(ctx, "error decoding squad join request", "err", err)
+			return nil, err
+		}
+
+		requests = append(requests, request)
+	}
+
+	return requests, nil
+}
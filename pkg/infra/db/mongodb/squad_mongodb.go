@@ -4,11 +4,18 @@ import (
 	"context"
 	"log/slog"
 	"reflect"
+	"time"
 
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo"
 
 	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	"github.com/psavelis/team-pro/replay-api/pkg/domain/customfield"
+	customfield_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/customfield/entities"
+	"github.com/psavelis/team-pro/replay-api/pkg/domain/squad"
 	squad_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/squad/entities"
+	squad_value_objects "github.com/psavelis/team-pro/replay-api/pkg/domain/squad/value-objects"
 )
 
 type SquadRepository struct {
@@ -36,6 +43,7 @@ func NewSquadRepository(client *mongo.Client, dbName string, entityType squad_en
 		"ShortName":     true,
 		"Description":   true,
 		"Profiles":      true,
+		"CustomFields":  true,
 		"ResourceOwner": true,
 		"CreatedAt":     true,
 		"UpdatedAt":     true,
@@ -48,6 +56,7 @@ func NewSquadRepository(client *mongo.Client, dbName string, entityType squad_en
 		"Symbol":             "symbol",
 		"Description":        "description",
 		"Profiles":           "profiles",
+		"CustomFields":       "custom_fields",
 		"ResourceOwner":      "resource_owner",
 		"TenantID":           "resource_owner.tenant_id",
 		"UserID":             "resource_owner.user_id",
@@ -115,3 +124,138 @@ func (r *SquadRepository) Search(ctx context.Context, s common.Search) ([]squad_
 
 // 	return nil
 // }
+
+// AddMember sets the profile of the member (keyed by its user ID) in a single update, the other members are left untouched. Seeded
+// squads may have no profiles yet (null).
+func (r *SquadRepository) AddMember(ctx context.Context, squadID uuid.UUID, userID uuid.UUID, profile squad_value_objects.Profile) error {
+	filter := bson.M{"_id": squadID, "resource_owner.tenant_id": common.GetResourceOwner(ctx).TenantID}
+
+	pipe := mongo.Pipeline{
+		{{Key: "$set", Value: bson.M{
+			"profiles": bson.M{"$mergeObjects": bson.A{
+				bson.M{"$ifNull": bson.A{"$profiles", bson.M{}}},
+				bson.M{squad_entities.MemberKey(userID): bson.M{"$literal": profile}},
+			}},
+			"updated_at": time.Now().UTC(),
+		}}},
+	}
+
+	res, err := r.collection.UpdateOne(ctx, filter, pipe)
+	if err != nil {
+		slog.ErrorContext(ctx, "error adding squad member", "squadID", squadID, "userID", userID, "err", err)
+		return err
+	}
+
+	if res.MatchedCount == 0 {
+		return squad.NewSquadNotFoundError(squadID)
+	}
+
+	return nil
+}
+
+// SetMemberRole merges the role into the profile of the member (the owner may have no profile yet), the other members are left
+// untouched.
+func (r *SquadRepository) SetMemberRole(ctx context.Context, squadID uuid.UUID, userID uuid.UUID, role squad_value_objects.MemberRole) error {
+	filter := bson.M{"_id": squadID, "resource_owner.tenant_id": common.GetResourceOwner(ctx).TenantID}
+	key := squad_entities.MemberKey(userID)
+
+	pipe := mongo.Pipeline{
+		{{Key: "$set", Value: bson.M{
+			"profiles": bson.M{"$mergeObjects": bson.A{
+				bson.M{"$ifNull": bson.A{"$profiles", bson.M{}}},
+				bson.M{key: bson.M{"$mergeObjects": bson.A{
+					bson.M{"$ifNull": bson.A{"$profiles." + key, bson.M{}}},
+					bson.M{"role": bson.M{"$literal": role}},
+				}}},
+			}},
+			"updated_at": time.Now().UTC(),
+		}}},
+	}
+
+	res, err := r.collection.UpdateOne(ctx, filter, pipe)
+	if err != nil {
+		slog.ErrorContext(ctx, "error setting squad member role", "squadID", squadID, "userID", userID, "role", role, "err", err)
+		return err
+	}
+
+	if res.MatchedCount == 0 {
+		return squad.NewSquadNotFoundError(squadID)
+	}
+
+	return nil
+}
+
+// RemoveMember unsets the profile of the member in a single update.
+func (r *SquadRepository) RemoveMember(ctx context.Context, squadID uuid.UUID, userID uuid.UUID) error {
+	filter := bson.M{"_id": squadID, "resource_owner.tenant_id": common.GetResourceOwner(ctx).TenantID}
+
+	update := bson.M{
+		"$unset": bson.M{"profiles." + squad_entities.MemberKey(userID): ""},
+		"$set":   bson.M{"updated_at": time.Now().UTC()},
+	}
+
+	res, err := r.collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		slog.ErrorContext(ctx, "error removing squad member", "squadID", squadID, "userID", userID, "err", err)
+		return err
+	}
+
+	if res.MatchedCount == 0 {
+		return squad.NewSquadNotFoundError(squadID)
+	}
+
+	return nil
+}
+
+// TransferOwnership swaps the owner only while it's still the expected one, keeping the previous owner in the roster.
+func (r *SquadRepository) TransferOwnership(ctx context.Context, squadID uuid.UUID, fromUserID uuid.UUID, toUserID uuid.UUID, previousOwner squad_value_objects.Profile) error {
+	filter := bson.M{
+		"_id":                      squadID,
+		"resource_owner.tenant_id": common.GetResourceOwner(ctx).TenantID,
+		"resource_owner.user_id":   fromUserID,
+	}
+
+	pipe := mongo.Pipeline{
+		{{Key: "$set", Value: bson.M{
+			"resource_owner.user_id": bson.M{"$literal": toUserID},
+			"profiles": bson.M{"$mergeObjects": bson.A{
+				bson.M{"$ifNull": bson.A{"$profiles", bson.M{}}},
+				bson.M{squad_entities.MemberKey(fromUserID): bson.M{"$literal": previousOwner}},
+			}},
+			"updated_at": time.Now().UTC(),
+		}}},
+	}
+
+	res, err := r.collection.UpdateOne(ctx, filter, pipe)
+	if err != nil {
+		slog.ErrorContext(ctx, "error transferring squad ownership", "squadID", squadID, "fromUserID", fromUserID, "toUserID", toUserID, "err", err)
+		return err
+	}
+
+	if res.MatchedCount == 0 {
+		return squad.NewMembershipStateError("squad ownership changed meanwhile")
+	}
+
+	return nil
+}
+
+// SetCustomFields replaces the custom fields of the squad of the tenant in context.
+func (r *SquadRepository) SetCustomFields(ctx context.Context, squadID uuid.UUID, fields common.CustomFields) error {
+	filter := bson.M{"_id": squadID, "resource_owner.tenant_id": common.GetResourceOwner(ctx).TenantID}
+
+	res, err := r.collection.UpdateOne(ctx, filter, bson.M{"$set": bson.M{
+		"custom_fields": fields,
+		"updated_at":    time.Now().UTC(),
+	}})
+
+	if err != nil {
+		slog.ErrorContext(ctx, "error setting squad custom fields", "squadID", squadID, "err", err)
+		return err
+	}
+
+	if res.MatchedCount == 0 {
+		return customfield.NewCustomFieldsEntityNotFoundError(customfield_entities.CustomFieldEntitySquad, squadID)
+	}
+
+	return nil
+}
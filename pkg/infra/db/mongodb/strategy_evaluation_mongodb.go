@@ -0,0 +1,88 @@
+package db
+
+import (
+	"context"
+	"log/slog"
+	"reflect"
+
+	"go.mongodb.org/mongo-driver/mongo"
+
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	matchmaking_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/matchmaking/entities"
+)
+
+type StrategyEvaluationRepository struct {
+	MongoDBRepository[matchmaking_entities.StrategyEvaluation]
+}
+
+func NewStrategyEvaluationRepository(client *mongo.Client, dbName string, entityType matchmaking_entities.StrategyEvaluation, collectionName string) *StrategyEvaluationRepository {
+	repo := MongoDBRepository[matchmaking_entities.StrategyEvaluation]{
+		mongoClient:       client,
+		dbName:            dbName,
+		mappingCache:      make(map[string]CacheItem),
+		entityModel:       reflect.TypeOf(entityType),
+		bsonFieldMappings: make(map[string]string),
+		collectionName:    collectionName,
+		entityName:        reflect.TypeOf(entityType).Name(),
+		queryableFields:   make(map[string]bool),
+	}
+
+	repo.InitQueryableFields(map[string]bool{
+		"ID":            true,
+		"RunID":         true,
+		"PoolID":        true,
+		"Strategy":      true,
+		"Shadow":        true,
+		"MatchesFormed": true,
+		"EvaluatedAt":   true,
+		"ResourceOwner": true,
+		"CreatedAt":     true,
+		"UpdatedAt":     true,
+	}, map[string]string{
+		"ID":                     "_id",
+		"RunID":                  "run_id",
+		"PoolID":                 "pool_id",
+		"Strategy":               "strategy",
+		"Shadow":                 "shadow",
+		"MatchesFormed":          "matches_formed",
+		"EvaluatedAt":            "evaluated_at",
+		"ResourceOwner":          "resource_owner",
+		"CreatedAt":              "created_at",
+		"UpdatedAt":              "updated_at",
+		"ResourceOwner.TenantID": "resource_owner.tenant_id",
+		"ResourceOwner.UserID":   "resource_owner.user_id",
+		"ResourceOwner.GroupID":  "resource_owner.group_id",
+		"ResourceOwner.ClientID": "resource_owner.client_id",
+	})
+
+	return &StrategyEvaluationRepository{
+		repo,
+	}
+}
+
+func (r *StrategyEvaluationRepository) Search(ctx context.Context, s common.Search) ([]matchmaking_entities.StrategyEvaluation, error) {
+	cursor, err := r.Query(ctx, s)
+	if cursor != nil {
+		defer cursor.Close(ctx)
+	}
+
+	if err != nil {
+		slog.ErrorContext(ctx, "error querying strategy evaluations", "err", err)
+		return nil, err
+	}
+
+	evaluations := make([]matchmaking_entities.StrategyEvaluation, 0)
+	for cursor.Next(ctx) {
+		var evaluation matchmaking_entities.StrategyEvaluation
+		err := cursor.Decode(&evaluation)
+
+		if err != nil {
+			slog.ErrorContext(ctx, "error decoding strategy evaluation", "err", err)
+			return nil, err
+		}
+
+		evaluations = append(evaluations, evaluation)
+	}
+
+	return evaluations, nil
+}
@@ -0,0 +1,98 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// TenantCanaryMarker prefixes the name of the canaries, followed by the tenant (ie: for a leak found in a response body).
+const TenantCanaryMarker = "tenant-isolation-canary-"
+
+// TenantCanaries are the documents seeded by the isolation checks for a throwaway tenant: a document per tenant scoped collection,
+// sharing the same ID (a get by ID of any entity reaches it), only ever visible to its own tenant.
+type TenantCanaries struct {
+	ID            uuid.UUID
+	Marker        string
+	ResourceOwner common.ResourceOwner
+	Collections   []string
+	Skipped       []string // collections with unique indexes the canary conflicts with (ie: on fields it leaves empty)
+}
+
+// TenantScopedCollections lists the collections of the registered entities owned by a tenant (with a ResourceOwner.TenantID), only the
+// repositories already initialized are registered.
+func TenantScopedCollections(ctx context.Context, reg *EntityMetadataRegistry) []string {
+	collections := make([]string, 0)
+
+	for _, metadata := range reg.ListEntityMetadata(ctx) {
+		for _, field := range metadata.Fields {
+			if field.Name == "ResourceOwner.TenantID" {
+				collections = append(collections, metadata.Collection)
+				break
+			}
+		}
+	}
+
+	return collections
+}
+
+// SeedTenantCanaries inserts a canary of the resource owner in each collection. The canaries have the common fields of the entities
+// (the others are left empty), listed first by the searches sorted by the most recent.
+func SeedTenantCanaries(ctx context.Context, client *mongo.Client, dbName string, collections []string, owner common.ResourceOwner, now time.Time) (*TenantCanaries, error) {
+	canaries := &TenantCanaries{
+		ID:            uuid.New(),
+		Marker:        fmt.Sprintf("%s%s", TenantCanaryMarker, owner.TenantID),
+		ResourceOwner: owner,
+		Collections:   make([]string, 0, len(collections)),
+		Skipped:       make([]string, 0),
+	}
+
+	for _, collection := range collections {
+		_, err := client.Database(dbName).Collection(collection).InsertOne(ctx, bson.M{
+			"_id":            canaries.ID,
+			"name":           canaries.Marker,
+			"game_id":        common.CS2_GAME_ID,
+			"resource_owner": owner,
+			"created_at":     now,
+			"updated_at":     now,
+		})
+
+		if mongo.IsDuplicateKeyError(err) {
+			slog.WarnContext(ctx, "tenant canary conflicts with a unique index", "collection", collection, "tenantID", owner.TenantID, "err", err)
+			canaries.Skipped = append(canaries.Skipped, collection)
+			continue
+		}
+
+		if err != nil {
+			slog.ErrorContext(ctx, "error seeding tenant canary", "collection", collection, "tenantID", owner.TenantID, "err", err)
+			return canaries, err
+		}
+
+		canaries.Collections = append(canaries.Collections, collection)
+	}
+
+	return canaries, nil
+}
+
+// DeleteTenantDocuments removes every document of the tenant from the collections, only meant for the throwaway tenants of the checks.
+func DeleteTenantDocuments(ctx context.Context, client *mongo.Client, dbName string, collections []string, tenantID uuid.UUID) (int64, error) {
+	var deleted int64
+
+	for _, collection := range collections {
+		res, err := client.Database(dbName).Collection(collection).DeleteMany(ctx, bson.M{"resource_owner.tenant_id": tenantID})
+		if err != nil {
+			slog.ErrorContext(ctx, "error deleting tenant documents", "collection", collection, "tenantID", tenantID, "err", err)
+			return deleted, err
+		}
+
+		deleted += res.DeletedCount
+	}
+
+	return deleted, nil
+}
@@ -0,0 +1,26 @@
+package db_test
+
+import (
+	"context"
+	"testing"
+
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	db "github.com/psavelis/team-pro/replay-api/pkg/infra/db/mongodb"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTenantScopedCollections(t *testing.T) {
+	reg := db.NewEntityMetadataRegistry()
+
+	reg.Register(common.EntityMetadata{Name: "Squad", Collection: "squads", Fields: []common.FieldMetadata{
+		{Name: "ID", BSONField: "_id", Kind: "uuid"},
+		{Name: "ResourceOwner.TenantID", BSONField: "resource_owner.tenant_id", Kind: "uuid"},
+	}})
+
+	// shared across tenants (ie: reference data)
+	reg.Register(common.EntityMetadata{Name: "Game", Collection: "games", Fields: []common.FieldMetadata{
+		{Name: "ID", BSONField: "_id", Kind: "string"},
+	}})
+
+	assert.Equal(t, []string{"squads"}, db.TenantScopedCollections(context.Background(), reg))
+}
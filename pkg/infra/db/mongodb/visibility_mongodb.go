@@ -0,0 +1,155 @@
+package db
+
+import (
+	"context"
+	"log/slog"
+	"reflect"
+
+	"go.mongodb.org/mongo-driver/mongo"
+
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+)
+
+type VisibilityPolicyRepository struct {
+	MongoDBRepository[common.VisibilityPolicy]
+}
+
+func NewVisibilityPolicyRepository(client *mongo.Client, dbName string, entityType common.VisibilityPolicy, collectionName string) *VisibilityPolicyRepository {
+	repo := MongoDBRepository[common.VisibilityPolicy]{
+		mongoClient:       client,
+		dbName:            dbName,
+		mappingCache:      make(map[string]CacheItem),
+		entityModel:       reflect.TypeOf(entityType),
+		bsonFieldMappings: make(map[string]string),
+		collectionName:    collectionName,
+		entityName:        reflect.TypeOf(entityType).Name(),
+		queryableFields:   make(map[string]bool),
+	}
+
+	repo.InitQueryableFields(map[string]bool{
+		"ID":            true,
+		"GroupID":       true,
+		"Visibility":    true,
+		"UpdatedBy":     true,
+		"ResourceOwner": true,
+		"CreatedAt":     true,
+		"UpdatedAt":     true,
+	}, map[string]string{
+		"ID":                     "_id",
+		"GroupID":                "group_id",
+		"Visibility":             "visibility",
+		"UpdatedBy":              "updated_by",
+		"ResourceOwner":          "resource_owner",
+		"CreatedAt":              "created_at",
+		"UpdatedAt":              "updated_at",
+		"ResourceOwner.TenantID": "resource_owner.tenant_id",
+		"ResourceOwner.UserID":   "resource_owner.user_id",
+		"ResourceOwner.GroupID":  "resource_owner.group_id",
+		"ResourceOwner.ClientID": "resource_owner.client_id",
+	})
+
+	return &VisibilityPolicyRepository{
+		repo,
+	}
+}
+
+func (r *VisibilityPolicyRepository) Search(ctx context.Context, s common.Search) ([]common.VisibilityPolicy, error) {
+	cursor, err := r.Query(ctx, s)
+	if cursor != nil {
+		defer cursor.Close(ctx)
+	}
+
+	if err != nil {
+		slog.ErrorContext(ctx, "error querying visibility policies", "err", err)
+		return nil, err
+	}
+
+	policies := make([]common.VisibilityPolicy, 0)
+	for cursor.Next(ctx) {
+		var policy common.VisibilityPolicy
+		err := cursor.Decode(&policy)
+
+		if err != nil {
+			slog.ErrorContext(ctx, "error decoding visibility policy", "err", err)
+			return nil, err
+		}
+
+		policies = append(policies, policy)
+	}
+
+	return policies, nil
+}
+
+type VisibilityOverrideRepository struct {
+	MongoDBRepository[common.VisibilityOverride]
+}
+
+func NewVisibilityOverrideRepository(client *mongo.Client, dbName string, entityType common.VisibilityOverride, collectionName string) *VisibilityOverrideRepository {
+	repo := MongoDBRepository[common.VisibilityOverride]{
+		mongoClient:       client,
+		dbName:            dbName,
+		mappingCache:      make(map[string]CacheItem),
+		entityModel:       reflect.TypeOf(entityType),
+		bsonFieldMappings: make(map[string]string),
+		collectionName:    collectionName,
+		entityName:        reflect.TypeOf(entityType).Name(),
+		queryableFields:   make(map[string]bool),
+	}
+
+	repo.InitQueryableFields(map[string]bool{
+		"ID":            true,
+		"ResourceType":  true,
+		"ResourceID":    true,
+		"PolicyID":      true,
+		"Default":       true,
+		"Requested":     true,
+		"RequestedBy":   true,
+		"ResourceOwner": true,
+		"CreatedAt":     true,
+	}, map[string]string{
+		"ID":                     "_id",
+		"ResourceType":           "resource_type",
+		"ResourceID":             "resource_id",
+		"PolicyID":               "policy_id",
+		"Default":                "default",
+		"Requested":              "requested",
+		"RequestedBy":            "requested_by",
+		"ResourceOwner":          "resource_owner",
+		"CreatedAt":              "created_at",
+		"ResourceOwner.TenantID": "resource_owner.tenant_id",
+		"ResourceOwner.UserID":   "resource_owner.user_id",
+		"ResourceOwner.GroupID":  "resource_owner.group_id",
+		"ResourceOwner.ClientID": "resource_owner.client_id",
+	})
+
+	return &VisibilityOverrideRepository{
+		repo,
+	}
+}
+
+func (r *VisibilityOverrideRepository) Search(ctx context.Context, s common.Search) ([]common.VisibilityOverride, error) {
+	cursor, err := r.Query(ctx, s)
+	if cursor != nil {
+		defer cursor.Close(ctx)
+	}
+
+	if err != nil {
+		slog.ErrorContext(ctx, "error querying visibility overrides", "err", err)
+		return nil, err
+	}
+
+	overrides := make([]common.VisibilityOverride, 0)
+	for cursor.Next(ctx) {
+		var override common.VisibilityOverride
+		err := cursor.Decode(&override)
+
+		if err != nil {
+			slog.ErrorContext(ctx, "error decoding visibility override", "err", err)
+			return nil, err
+		}
+
+		overrides = append(overrides, override)
+	}
+
+	return overrides, nil
+}
@@ -0,0 +1,95 @@
+package db
+
+import (
+	"context"
+	"log/slog"
+	"reflect"
+
+	"go.mongodb.org/mongo-driver/mongo"
+
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	recap_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/recap/entities"
+)
+
+type WeeklyRecapRepository struct {
+	MongoDBRepository[recap_entities.WeeklyRecap]
+}
+
+func NewWeeklyRecapRepository(client *mongo.Client, dbName string, entityType recap_entities.WeeklyRecap, collectionName string) *WeeklyRecapRepository {
+	repo := MongoDBRepository[recap_entities.WeeklyRecap]{
+		mongoClient:       client,
+		dbName:            dbName,
+		mappingCache:      make(map[string]CacheItem),
+		entityModel:       reflect.TypeOf(entityType),
+		bsonFieldMappings: make(map[string]string),
+		collectionName:    collectionName,
+		entityName:        reflect.TypeOf(entityType).Name(),
+		queryableFields:   make(map[string]bool),
+	}
+
+	repo.InitQueryableFields(map[string]bool{
+		"ID":            true,
+		"Subject":       true,
+		"SubjectID":     true,
+		"WeekStart":     true,
+		"MatchesPlayed": true,
+		"Rating":        true,
+		"UserIDs":       true,
+		"ResourceOwner": true,
+		"CreatedAt":     true,
+		"UpdatedAt":     true,
+	}, map[string]string{
+		"ID":                     "_id",
+		"Subject":                "subject",
+		"SubjectID":              "subject_id",
+		"Name":                   "name",
+		"WeekStart":              "week_start",
+		"MatchesPlayed":          "matches_played",
+		"Wins":                   "wins",
+		"Losses":                 "losses",
+		"Draws":                  "draws",
+		"Rating":                 "rating",
+		"RatingDelta":            "rating_delta",
+		"BestHighlight":          "best_highlight",
+		"Earnings":               "earnings",
+		"UserIDs":                "user_ids",
+		"ResourceOwner":          "resource_owner",
+		"CreatedAt":              "created_at",
+		"UpdatedAt":              "updated_at",
+		"ResourceOwner.TenantID": "resource_owner.tenant_id",
+		"ResourceOwner.UserID":   "resource_owner.user_id",
+		"ResourceOwner.GroupID":  "resource_owner.group_id",
+		"ResourceOwner.ClientID": "resource_owner.client_id",
+	})
+
+	return &WeeklyRecapRepository{
+		repo,
+	}
+}
+
+func (r *WeeklyRecapRepository) Search(ctx context.Context, s common.Search) ([]recap_entities.WeeklyRecap, error) {
+	cursor, err := r.Query(ctx, s)
+	if cursor != nil {
+		defer cursor.Close(ctx)
+	}
+
+	if err != nil {
+		slog.ErrorContext(ctx, "error querying weekly recaps", "err", err)
+		return nil, err
+	}
+
+	recaps := make([]recap_entities.WeeklyRecap, 0)
+	for cursor.Next(ctx) {
+		var recap recap_entities.WeeklyRecap
+		err := cursor.Decode(&recap)
+
+		if err != nil {
+			slog.ErrorContext(ctx, "error decoding weekly recap", "err", err)
+			return nil, err
+		}
+
+		recaps = append(recaps, recap)
+	}
+
+	return recaps, nil
+}
@@ -0,0 +1,92 @@
+package discord
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	notification_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/notification/entities"
+)
+
+// WebhookSender posts notifications to the Discord webhook of the preferences of the users, as an embed. Mentions are never parsed
+// (a squad named @everyone pings nobody), and redirects aren't followed.
+type WebhookSender struct {
+	Client *http.Client
+}
+
+func NewWebhookSender() *WebhookSender {
+	return &WebhookSender{
+		Client: &http.Client{
+			Timeout: 10 * time.Second,
+			CheckRedirect: func(req *http.Request, via []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+	}
+}
+
+type webhookPayload struct {
+	Embeds          []webhookEmbed         `json:"embeds"`
+	AllowedMentions webhookAllowedMentions `json:"allowed_mentions"`
+}
+
+type webhookEmbed struct {
+	Title       string `json:"title"`
+	Description string `json:"description"`
+	Timestamp   string `json:"timestamp"`
+}
+
+type webhookAllowedMentions struct {
+	Parse []string `json:"parse"`
+}
+
+func (s *WebhookSender) Send(ctx context.Context, preferences notification_entities.NotificationPreferences, notification notification_entities.Notification) error {
+	// validated on update, checked again in case the hosts allowed changed since
+	if !notification_entities.IsDiscordWebhookURL(preferences.DiscordWebhookURL) {
+		return fmt.Errorf("not a discord webhook")
+	}
+
+	body, err := json.Marshal(webhookPayload{
+		Embeds: []webhookEmbed{{
+			Title:       notification.Title,
+			Description: notification.Body,
+			Timestamp:   notification.CreatedAt.UTC().Format(time.RFC3339),
+		}},
+		AllowedMentions: webhookAllowedMentions{Parse: []string{}},
+	})
+
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, preferences.DiscordWebhookURL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	res, err := s.Client.Do(req)
+	if err != nil {
+		// without the url: the token of the webhook is in its path
+		var urlErr *url.Error
+		if errors.As(err, &urlErr) {
+			return fmt.Errorf("discord webhook request failed: %w", urlErr.Err)
+		}
+
+		return err
+	}
+
+	defer res.Body.Close()
+
+	if res.StatusCode >= 300 {
+		return fmt.Errorf("discord webhook responded with status %d", res.StatusCode)
+	}
+
+	return nil
+}
@@ -0,0 +1,69 @@
+package discord_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	notification_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/notification/entities"
+	"github.com/psavelis/team-pro/replay-api/pkg/infra/discord"
+	"github.com/stretchr/testify/assert"
+)
+
+type roundTripFunc func(req *http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func TestWebhookSender(t *testing.T) {
+	var posted map[string]interface{}
+	requests := 0
+
+	sender := discord.NewWebhookSender()
+	sender.Client.Transport = roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		requests++
+
+		if req.URL.Path == "/api/webhooks/down/token" {
+			return nil, errors.New("connection refused")
+		}
+
+		json.NewDecoder(req.Body).Decode(&posted)
+		return &http.Response{StatusCode: http.StatusNoContent, Body: io.NopCloser(strings.NewReader(""))}, nil
+	})
+
+	notification := notification_entities.Notification{
+		Title:     "New squad application",
+		Body:      "@everyone applied to join Alpha.",
+		CreatedAt: time.Date(2026, 10, 16, 20, 0, 0, 0, time.UTC),
+	}
+
+	preferences := notification_entities.NotificationPreferences{DiscordWebhookURL: "https://discord.com/api/webhooks/123/token"}
+
+	err := sender.Send(context.Background(), preferences, notification)
+	assert.NoError(t, err)
+
+	embed := posted["embeds"].([]interface{})[0].(map[string]interface{})
+	assert.Equal(t, "@everyone applied to join Alpha.", embed["description"])
+	assert.Equal(t, "2026-10-16T20:00:00Z", embed["timestamp"])
+
+	// nobody is pinged
+	assert.Equal(t, map[string]interface{}{"parse": []interface{}{}}, posted["allowed_mentions"])
+
+	// the token of the webhook isn't in the errors
+	preferences.DiscordWebhookURL = "https://discord.com/api/webhooks/down/token"
+	err = sender.Send(context.Background(), preferences, notification)
+	if assert.Error(t, err) {
+		assert.NotContains(t, err.Error(), "token")
+	}
+
+	// only discord webhooks are posted to
+	preferences.DiscordWebhookURL = "https://example.com/api/webhooks/123/token"
+	assert.Error(t, sender.Send(context.Background(), preferences, notification))
+	assert.Equal(t, 2, requests)
+}
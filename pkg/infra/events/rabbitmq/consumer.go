@@ -0,0 +1,237 @@
+package rabbitmq
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/psavelis/team-pro/replay-api/pkg/domain/events"
+	replay_entity "github.com/psavelis/team-pro/replay-api/pkg/domain/replay/entities"
+	"github.com/streadway/amqp"
+)
+
+const reconnectDelay = 5 * time.Second
+
+// ReplayUploadedHandler processes a replay.uploaded event, an error fails the attempt.
+type ReplayUploadedHandler func(ctx context.Context, event replay_entity.ReplayFileUploaded) error
+
+// Publisher is the part of *amqp.Channel used to reroute failed deliveries.
+type Publisher interface {
+	Publish(exchange, key string, mandatory, immediate bool, msg amqp.Publishing) error
+}
+
+// ReplayUploadedConsumer runs the handler over the replay.uploaded queue, at most Concurrency deliveries at once. Failed deliveries are
+// retried after ReplayRetryDelay, up to MaxAttempts, then dead-lettered.
+type ReplayUploadedConsumer struct {
+	URL         string
+	Concurrency int
+	MaxAttempts int
+	Handler     ReplayUploadedHandler
+	Dial        DialFunc
+}
+
+func NewReplayUploadedConsumer(url string, concurrency int, maxAttempts int, handler ReplayUploadedHandler) *ReplayUploadedConsumer {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	return &ReplayUploadedConsumer{
+		URL:         url,
+		Concurrency: concurrency,
+		MaxAttempts: maxAttempts,
+		Handler:     handler,
+	}
+}
+
+// Run consumes until ctx is done, reconnecting when the broker connection drops. Deliveries in progress are completed on shutdown.
+func (c *ReplayUploadedConsumer) Run(ctx context.Context) {
+	runConnected(ctx, "replay consumer", c.consume)
+}
+
+// runConnected runs fn until ctx is done, reconnecting after reconnectDelay when it returns (ie: the connection dropped).
+func runConnected(ctx context.Context, name string, fn func(ctx context.Context) error) {
+	for {
+		err := fn(ctx)
+		if ctx.Err() != nil {
+			return
+		}
+
+		slog.ErrorContext(ctx, name+" disconnected, reconnecting", "err", err, "delay", reconnectDelay.String())
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(reconnectDelay):
+		}
+	}
+}
+
+func (c *ReplayUploadedConsumer) consume(ctx context.Context) error {
+	conn, err := dial(c.URL, c.Dial)
+	if err != nil {
+		return err
+	}
+
+	defer conn.Close()
+
+	ch, err := conn.Channel()
+	if err != nil {
+		return err
+	}
+
+	err = DeclareReplayTopology(ch)
+	if err != nil {
+		return err
+	}
+
+	// the broker hands each worker at most Concurrency unacked deliveries
+	err = ch.Qos(c.Concurrency, 0, false)
+	if err != nil {
+		return err
+	}
+
+	// retries and dead letters are published on their own channel, the consumer channel only acks
+	pub, err := conn.Channel()
+	if err != nil {
+		return err
+	}
+
+	consumerTag := fmt.Sprintf("replay-worker-%d", time.Now().UnixNano())
+
+	deliveries, err := ch.Consume(ReplayUploadedQueue, consumerTag, false, false, false, false, nil)
+	if err != nil {
+		return err
+	}
+
+	closed := conn.NotifyClose(make(chan *amqp.Error, 1))
+
+	slog.InfoContext(ctx, "replay consumer started", "queue", ReplayUploadedQueue, "concurrency", c.Concurrency)
+
+	// in-flight deliveries aren't cancelled on shutdown
+	handleCtx := context.WithoutCancel(ctx)
+
+	var wg sync.WaitGroup
+	for i := 0; i < c.Concurrency; i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for d := range deliveries {
+				c.Handle(handleCtx, pub, d)
+			}
+		}()
+	}
+
+	select {
+	case <-ctx.Done():
+		// stops new deliveries, the workers drain what they hold
+		ch.Cancel(consumerTag, false)
+		wg.Wait()
+
+		slog.InfoContext(handleCtx, "replay consumer stopped")
+
+		return nil
+	case amqpErr := <-closed:
+		wg.Wait()
+
+		if amqpErr == nil {
+			return errors.New("rabbitmq connection closed")
+		}
+
+		return amqpErr
+	}
+}
+
+// Handle processes a single delivery: acked when handled, republished to the retry queue (attempt + 1) on failure, or to the
+// dead-letter queue once out of attempts (or when the message can't be decoded).
+func (c *ReplayUploadedConsumer) Handle(ctx context.Context, pub Publisher, d amqp.Delivery) {
+	attempt := deliveryAttempt(d)
+
+	var event replay_entity.ReplayFileUploaded
+
+	err := json.Unmarshal(d.Body, &event)
+	if err != nil {
+		slog.ErrorContext(ctx, "invalid replay.uploaded message", "messageID", d.MessageId, "err", err)
+		c.reroute(ctx, pub, d, ReplayUploadedDeadLetterQueue, attempt, err)
+		return
+	}
+
+	event.Attempt = attempt
+
+	err = c.Handler(deliveryCause(ctx, d), event)
+	if err == nil {
+		d.Ack(false)
+		return
+	}
+
+	if attempt >= c.MaxAttempts {
+		slog.ErrorContext(ctx, "replay file processing out of attempts, dead-lettering", "replayFileID", event.ReplayFileID, "attempt", attempt, "err", err)
+		c.reroute(ctx, pub, d, ReplayUploadedDeadLetterQueue, attempt, err)
+		return
+	}
+
+	slog.WarnContext(ctx, "replay file processing failed, retrying", "replayFileID", event.ReplayFileID, "attempt", attempt, "delay", ReplayRetryDelay.String(), "err", err)
+	c.reroute(ctx, pub, d, ReplayUploadedRetryQueue, attempt+1, err)
+}
+
+// reroute publishes a copy of the delivery to the queue before acking it. The delivery is requeued when the copy can't be published:
+// processing is idempotent, losing the message is not.
+func (c *ReplayUploadedConsumer) reroute(ctx context.Context, pub Publisher, d amqp.Delivery, queue string, attempt int, cause error) {
+	err := pub.Publish("", queue, false, false, amqp.Publishing{
+		Headers: amqp.Table{
+			AttemptHeader:   int32(attempt),
+			ErrorHeader:     cause.Error(),
+			CausationHeader: d.Headers[CausationHeader],
+		},
+		ContentType:   d.ContentType,
+		DeliveryMode:  amqp.Persistent,
+		CorrelationId: d.CorrelationId,
+		MessageId:     d.MessageId,
+		Type:          d.Type,
+		Timestamp:     d.Timestamp,
+		Body:          d.Body,
+	})
+
+	if err != nil {
+		slog.ErrorContext(ctx, "unable to reroute replay message, requeueing", "messageID", d.MessageId, "queue", queue, "err", err)
+		d.Nack(false, true)
+		return
+	}
+
+	d.Ack(false)
+}
+
+// deliveryCause sets the delivered event as the cause of the events published while handling it. Messages published before the
+// catalog (no correlation ID) start a new correlation.
+func deliveryCause(ctx context.Context, d amqp.Delivery) context.Context {
+	eventID, err := uuid.Parse(d.MessageId)
+	if err != nil {
+		eventID = uuid.New()
+	}
+
+	correlationID := d.CorrelationId
+	if correlationID == "" {
+		correlationID = eventID.String()
+	}
+
+	return events.WithCause(ctx, correlationID, eventID)
+}
+
+func deliveryAttempt(d amqp.Delivery) int {
+	switch v := d.Headers[AttemptHeader].(type) {
+	case int32:
+		return int(v)
+	case int64:
+		return int(v)
+	case int:
+		return v
+	default:
+		return 1
+	}
+}
@@ -0,0 +1,162 @@
+package rabbitmq_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/psavelis/team-pro/replay-api/pkg/domain/events"
+	replay_entity "github.com/psavelis/team-pro/replay-api/pkg/domain/replay/entities"
+	"github.com/psavelis/team-pro/replay-api/pkg/infra/events/rabbitmq"
+	"github.com/streadway/amqp"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeAcknowledger struct {
+	acks     int
+	nacks    int
+	requeued bool
+}
+
+func (f *fakeAcknowledger) Ack(tag uint64, multiple bool) error {
+	f.acks++
+	return nil
+}
+
+func (f *fakeAcknowledger) Nack(tag uint64, multiple bool, requeue bool) error {
+	f.nacks++
+	f.requeued = requeue
+	return nil
+}
+
+func (f *fakeAcknowledger) Reject(tag uint64, requeue bool) error {
+	return f.Nack(tag, false, requeue)
+}
+
+type published struct {
+	key string
+	msg amqp.Publishing
+}
+
+type fakePublisher struct {
+	err       error
+	published []published
+}
+
+func (f *fakePublisher) Publish(exchange, key string, mandatory, immediate bool, msg amqp.Publishing) error {
+	if f.err != nil {
+		return f.err
+	}
+
+	f.published = append(f.published, published{key: key, msg: msg})
+	return nil
+}
+
+func TestReplayUploadedConsumer_Handle(t *testing.T) {
+	event := replay_entity.ReplayFileUploaded{ReplayFileID: uuid.New()}
+	body, _ := json.Marshal(event)
+
+	tests := []struct {
+		name            string
+		body            []byte
+		attempt         interface{}
+		handlerErr      error
+		publishErr      error
+		expectedAttempt int
+		expectedQueue   string
+		expectedHeader  int32
+		expectedAcks    int
+		expectedNacks   int
+	}{
+		{"Handled", body, nil, nil, nil, 1, "", 0, 1, 0},
+		{"First Failure Retried", body, nil, errors.New("parse error"), nil, 1, rabbitmq.ReplayUploadedRetryQueue, 2, 1, 0},
+		{"Retry Failure Retried", body, int32(2), errors.New("parse error"), nil, 2, rabbitmq.ReplayUploadedRetryQueue, 3, 1, 0},
+		{"Out Of Attempts Dead Lettered", body, int32(3), errors.New("parse error"), nil, 3, rabbitmq.ReplayUploadedDeadLetterQueue, 3, 1, 0},
+		{"Invalid Message Dead Lettered", []byte("{"), nil, nil, nil, 0, rabbitmq.ReplayUploadedDeadLetterQueue, 1, 1, 0},
+		{"Reroute Failure Requeued", body, nil, errors.New("parse error"), errors.New("channel closed"), 1, "", 0, 0, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var handled []replay_entity.ReplayFileUploaded
+
+			consumer := rabbitmq.NewReplayUploadedConsumer("", 1, 3, func(ctx context.Context, e replay_entity.ReplayFileUploaded) error {
+				handled = append(handled, e)
+				return tt.handlerErr
+			})
+
+			ack := &fakeAcknowledger{}
+			pub := &fakePublisher{err: tt.publishErr}
+
+			d := amqp.Delivery{Acknowledger: ack, Body: tt.body, MessageId: event.ReplayFileID.String(), Headers: amqp.Table{}}
+			if tt.attempt != nil {
+				d.Headers[rabbitmq.AttemptHeader] = tt.attempt
+			}
+
+			consumer.Handle(context.Background(), pub, d)
+
+			if tt.expectedAttempt > 0 {
+				assert.Len(t, handled, 1)
+				assert.Equal(t, event.ReplayFileID, handled[0].ReplayFileID)
+				assert.Equal(t, tt.expectedAttempt, handled[0].Attempt)
+			} else {
+				assert.Empty(t, handled)
+			}
+
+			assert.Equal(t, tt.expectedAcks, ack.acks)
+			assert.Equal(t, tt.expectedNacks, ack.nacks)
+
+			if tt.expectedNacks > 0 {
+				assert.True(t, ack.requeued)
+			}
+
+			if tt.expectedQueue == "" {
+				assert.Empty(t, pub.published)
+				return
+			}
+
+			if assert.Len(t, pub.published, 1) {
+				assert.Equal(t, tt.expectedQueue, pub.published[0].key)
+				assert.Equal(t, tt.expectedHeader, pub.published[0].msg.Headers[rabbitmq.AttemptHeader])
+				assert.Equal(t, tt.body, pub.published[0].msg.Body)
+				assert.NotEmpty(t, pub.published[0].msg.Headers[rabbitmq.ErrorHeader])
+			}
+		})
+	}
+}
+
+func TestReplayUploadedConsumer_Handle_Cause(t *testing.T) {
+	body, _ := json.Marshal(replay_entity.ReplayFileUploaded{ReplayFileID: uuid.New()})
+	eventID := uuid.New()
+
+	var published events.Event
+
+	consumer := rabbitmq.NewReplayUploadedConsumer("", 1, 3, func(ctx context.Context, e replay_entity.ReplayFileUploaded) error {
+		var err error
+		published, err = events.NewReplayProcessingProgress(ctx, replay_entity.ReplayProcessingProgress{ReplayFileID: e.ReplayFileID})
+		if err != nil {
+			return err
+		}
+
+		return errors.New("parse error")
+	})
+
+	pub := &fakePublisher{}
+
+	d := amqp.Delivery{Acknowledger: &fakeAcknowledger{}, Body: body, MessageId: eventID.String(), CorrelationId: "req-1", Headers: amqp.Table{rabbitmq.CausationHeader: "req-1"}}
+
+	consumer.Handle(context.Background(), pub, d)
+
+	// the progress is caused by the delivered event, within the upload request
+	assert.Equal(t, "req-1", published.CorrelationID)
+	assert.Equal(t, eventID.String(), published.CausationID)
+
+	// the retried copy keeps its correlation
+	if assert.Len(t, pub.published, 1) {
+		assert.Equal(t, "req-1", pub.published[0].msg.CorrelationId)
+		assert.Equal(t, "req-1", pub.published[0].msg.Headers[rabbitmq.CausationHeader])
+		assert.Equal(t, eventID.String(), pub.published[0].msg.MessageId)
+	}
+}
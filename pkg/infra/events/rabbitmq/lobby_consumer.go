@@ -0,0 +1,130 @@
+package rabbitmq
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	matchmaking_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/matchmaking/entities"
+	"github.com/streadway/amqp"
+)
+
+// LobbyCreatedHandler handles a lobby.created event.
+type LobbyCreatedHandler func(ctx context.Context, event matchmaking_entities.LobbyCreated) error
+
+// LobbyCreatedConsumer runs the handler over the lobby.created queue of the notification worker, at most Concurrency deliveries at
+// once. Deliveries are handled once: failures are only logged, since a retried alert would be stale.
+type LobbyCreatedConsumer struct {
+	URL         string
+	Concurrency int
+	Handler     LobbyCreatedHandler
+	Dial        DialFunc
+}
+
+func NewLobbyCreatedConsumer(url string, concurrency int, handler LobbyCreatedHandler) *LobbyCreatedConsumer {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	return &LobbyCreatedConsumer{
+		URL:         url,
+		Concurrency: concurrency,
+		Handler:     handler,
+	}
+}
+
+// Run consumes until ctx is done, reconnecting when the broker connection drops. Deliveries in progress are completed on shutdown.
+func (c *LobbyCreatedConsumer) Run(ctx context.Context) {
+	runConnected(ctx, "lobby consumer", c.consume)
+}
+
+func (c *LobbyCreatedConsumer) consume(ctx context.Context) error {
+	conn, err := dial(c.URL, c.Dial)
+	if err != nil {
+		return err
+	}
+
+	defer conn.Close()
+
+	ch, err := conn.Channel()
+	if err != nil {
+		return err
+	}
+
+	err = DeclareNotificationTopology(ch)
+	if err != nil {
+		return err
+	}
+
+	err = ch.Qos(c.Concurrency, 0, false)
+	if err != nil {
+		return err
+	}
+
+	consumerTag := fmt.Sprintf("notification-worker-%d", time.Now().UnixNano())
+
+	deliveries, err := ch.Consume(NotificationLobbyCreatedQueue, consumerTag, false, false, false, false, nil)
+	if err != nil {
+		return err
+	}
+
+	closed := conn.NotifyClose(make(chan *amqp.Error, 1))
+
+	slog.InfoContext(ctx, "lobby consumer started", "queue", NotificationLobbyCreatedQueue, "concurrency", c.Concurrency)
+
+	// in-flight deliveries aren't cancelled on shutdown
+	handleCtx := context.WithoutCancel(ctx)
+
+	var wg sync.WaitGroup
+	for i := 0; i < c.Concurrency; i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for d := range deliveries {
+				c.Handle(handleCtx, d)
+			}
+		}()
+	}
+
+	select {
+	case <-ctx.Done():
+		ch.Cancel(consumerTag, false)
+		wg.Wait()
+
+		slog.InfoContext(handleCtx, "lobby consumer stopped")
+
+		return nil
+	case amqpErr := <-closed:
+		wg.Wait()
+
+		if amqpErr == nil {
+			return errors.New("rabbitmq connection closed")
+		}
+
+		return amqpErr
+	}
+}
+
+// Handle processes a single delivery, acked whether it was handled or not.
+func (c *LobbyCreatedConsumer) Handle(ctx context.Context, d amqp.Delivery) {
+	defer d.Ack(false)
+
+	var event matchmaking_entities.LobbyCreated
+
+	err := json.Unmarshal(d.Body, &event)
+	if err != nil {
+		slog.ErrorContext(ctx, "invalid lobby.created message", "messageID", d.MessageId, "err", err)
+		return
+	}
+
+	err = c.Handler(deliveryCause(ctx, d), event)
+	if err != nil {
+		slog.ErrorContext(ctx, "unable to handle lobby.created message", "lobbyID", event.LobbyID, "err", err)
+	}
+}
@@ -0,0 +1,134 @@
+package rabbitmq
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	matchmaking_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/matchmaking/entities"
+	"github.com/streadway/amqp"
+)
+
+// MatchCompletedHandler handles a match.completed event, an error fails the attempt.
+type MatchCompletedHandler func(ctx context.Context, event matchmaking_entities.MatchCompleted) error
+
+// MatchCompletedConsumer runs the handler over the match.completed queue of the rating worker, at most Concurrency deliveries at once.
+// A failed delivery is requeued once (ie: the database was unreachable), then dropped and logged.
+type MatchCompletedConsumer struct {
+	URL         string
+	Concurrency int
+	Handler     MatchCompletedHandler
+	Dial        DialFunc
+}
+
+func NewMatchCompletedConsumer(url string, concurrency int, handler MatchCompletedHandler) *MatchCompletedConsumer {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	return &MatchCompletedConsumer{
+		URL:         url,
+		Concurrency: concurrency,
+		Handler:     handler,
+	}
+}
+
+// Run consumes until ctx is done, reconnecting when the broker connection drops. Deliveries in progress are completed on shutdown.
+func (c *MatchCompletedConsumer) Run(ctx context.Context) {
+	runConnected(ctx, "match consumer", c.consume)
+}
+
+func (c *MatchCompletedConsumer) consume(ctx context.Context) error {
+	conn, err := dial(c.URL, c.Dial)
+	if err != nil {
+		return err
+	}
+
+	defer conn.Close()
+
+	ch, err := conn.Channel()
+	if err != nil {
+		return err
+	}
+
+	err = DeclareRatingTopology(ch)
+	if err != nil {
+		return err
+	}
+
+	err = ch.Qos(c.Concurrency, 0, false)
+	if err != nil {
+		return err
+	}
+
+	consumerTag := fmt.Sprintf("rating-worker-%d", time.Now().UnixNano())
+
+	deliveries, err := ch.Consume(RatingMatchCompletedQueue, consumerTag, false, false, false, false, nil)
+	if err != nil {
+		return err
+	}
+
+	closed := conn.NotifyClose(make(chan *amqp.Error, 1))
+
+	slog.InfoContext(ctx, "match consumer started", "queue", RatingMatchCompletedQueue, "concurrency", c.Concurrency)
+
+	// in-flight deliveries aren't cancelled on shutdown
+	handleCtx := context.WithoutCancel(ctx)
+
+	var wg sync.WaitGroup
+	for i := 0; i < c.Concurrency; i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for d := range deliveries {
+				c.Handle(handleCtx, d)
+			}
+		}()
+	}
+
+	select {
+	case <-ctx.Done():
+		ch.Cancel(consumerTag, false)
+		wg.Wait()
+
+		slog.InfoContext(handleCtx, "match consumer stopped")
+
+		return nil
+	case amqpErr := <-closed:
+		wg.Wait()
+
+		if amqpErr == nil {
+			return errors.New("rabbitmq connection closed")
+		}
+
+		return amqpErr
+	}
+}
+
+// Handle processes a single delivery: acked when handled (or invalid), requeued on its first failure.
+func (c *MatchCompletedConsumer) Handle(ctx context.Context, d amqp.Delivery) {
+	var event matchmaking_entities.MatchCompleted
+
+	err := json.Unmarshal(d.Body, &event)
+	if err != nil {
+		slog.ErrorContext(ctx, "invalid match.completed message", "messageID", d.MessageId, "err", err)
+		d.Ack(false)
+		return
+	}
+
+	err = c.Handler(deliveryCause(ctx, d), event)
+	if err == nil {
+		d.Ack(false)
+		return
+	}
+
+	slog.ErrorContext(ctx, "unable to handle match.completed message", "matchID", event.MatchID, "lobbyID", event.LobbyID, "redelivered", d.Redelivered, "err", err)
+
+	d.Nack(false, !d.Redelivered)
+}
@@ -0,0 +1,80 @@
+package rabbitmq
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/psavelis/team-pro/replay-api/pkg/domain/events"
+	matchmaking_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/matchmaking/entities"
+)
+
+// MatchmakingEventPublisher publishes matchmaking (lobby) events.
+type MatchmakingEventPublisher struct {
+	confirmPublisher
+}
+
+func NewMatchmakingEventPublisher(url string) *MatchmakingEventPublisher {
+	return &MatchmakingEventPublisher{
+		confirmPublisher{
+			URL:      url,
+			exchange: MatchmakingExchange,
+			declare:  DeclareMatchmakingTopology,
+		},
+	}
+}
+
+func (p *MatchmakingEventPublisher) PublishLobbyCreated(ctx context.Context, created matchmaking_entities.LobbyCreated) error {
+	event, err := events.NewLobbyCreated(ctx, created)
+	if err == nil {
+		err = p.publish(ctx, event)
+	}
+
+	if err != nil {
+		slog.ErrorContext(ctx, "unable to publish lobby event", "lobbyID", created.LobbyID, "err", err)
+		return err
+	}
+
+	return nil
+}
+
+func (p *MatchmakingEventPublisher) PublishLobbyCancelled(ctx context.Context, cancelled matchmaking_entities.LobbyCancelled) error {
+	event, err := events.NewLobbyCancelled(ctx, cancelled)
+	if err == nil {
+		err = p.publish(ctx, event)
+	}
+
+	if err != nil {
+		slog.ErrorContext(ctx, "unable to publish lobby event", "lobbyID", cancelled.LobbyID, "err", err)
+		return err
+	}
+
+	return nil
+}
+
+func (p *MatchmakingEventPublisher) PublishQueueTicketExpired(ctx context.Context, expired matchmaking_entities.QueueTicketExpired) error {
+	event, err := events.NewQueueTicketExpired(ctx, expired)
+	if err == nil {
+		err = p.publish(ctx, event)
+	}
+
+	if err != nil {
+		slog.ErrorContext(ctx, "unable to publish queue event", "ticketID", expired.TicketID, "err", err)
+		return err
+	}
+
+	return nil
+}
+
+func (p *MatchmakingEventPublisher) PublishMatchCompleted(ctx context.Context, completed matchmaking_entities.MatchCompleted) error {
+	event, err := events.NewMatchCompleted(ctx, completed)
+	if err == nil {
+		err = p.publish(ctx, event)
+	}
+
+	if err != nil {
+		slog.ErrorContext(ctx, "unable to publish match event", "lobbyID", completed.LobbyID, "matchID", completed.MatchID, "err", err)
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,110 @@
+package rabbitmq
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/psavelis/team-pro/replay-api/pkg/domain/events"
+	replay_entity "github.com/psavelis/team-pro/replay-api/pkg/domain/replay/entities"
+	"github.com/streadway/amqp"
+)
+
+// relayed progress older than this is stale (a newer update is on its way)
+const ReplayProgressTTL = 10 * time.Second
+
+// ReplayProgressHandler receives the replay processing progress relayed from the broker.
+type ReplayProgressHandler func(ctx context.Context, progress replay_entity.ReplayProcessingProgress) error
+
+// ReplayProgressRelay delivers the replay processing progress published by any process (ie: the replay workers) to the handler.
+// Each relay consumes its own exclusive queue, so every api replica receives every update.
+type ReplayProgressRelay struct {
+	URL     string
+	Handler ReplayProgressHandler
+	Dial    DialFunc
+}
+
+func NewReplayProgressRelay(url string, handler ReplayProgressHandler) *ReplayProgressRelay {
+	return &ReplayProgressRelay{
+		URL:     url,
+		Handler: handler,
+	}
+}
+
+// Run relays until ctx is done, reconnecting when the broker connection drops. Progress published while disconnected is lost.
+func (r *ReplayProgressRelay) Run(ctx context.Context) {
+	runConnected(ctx, "replay progress relay", r.relay)
+}
+
+func (r *ReplayProgressRelay) relay(ctx context.Context) error {
+	conn, err := dial(r.URL, r.Dial)
+	if err != nil {
+		return err
+	}
+
+	defer conn.Close()
+
+	ch, err := conn.Channel()
+	if err != nil {
+		return err
+	}
+
+	err = DeclareReplayTopology(ch)
+	if err != nil {
+		return err
+	}
+
+	q, err := ch.QueueDeclare("", false, true, true, false, amqp.Table{
+		"x-message-ttl": int32(ReplayProgressTTL / time.Millisecond),
+	})
+
+	if err != nil {
+		return err
+	}
+
+	err = ch.QueueBind(q.Name, string(events.ReplayProcessingProgress), ReplayExchange, false, nil)
+	if err != nil {
+		return err
+	}
+
+	deliveries, err := ch.Consume(q.Name, "", true, true, false, false, nil)
+	if err != nil {
+		return err
+	}
+
+	closed := conn.NotifyClose(make(chan *amqp.Error, 1))
+
+	slog.InfoContext(ctx, "replay progress relay started", "queue", q.Name)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case amqpErr := <-closed:
+			if amqpErr == nil {
+				return errors.New("rabbitmq connection closed")
+			}
+
+			return amqpErr
+		case d, ok := <-deliveries:
+			if !ok {
+				return errors.New("rabbitmq progress deliveries closed")
+			}
+
+			var progress replay_entity.ReplayProcessingProgress
+
+			err = json.Unmarshal(d.Body, &progress)
+			if err != nil {
+				slog.ErrorContext(ctx, "invalid replay progress message", "messageID", d.MessageId, "err", err)
+				continue
+			}
+
+			err = r.Handler(deliveryCause(ctx, d), progress)
+			if err != nil {
+				slog.WarnContext(ctx, "unable to relay replay progress", "replayFileID", progress.ReplayFileID, "err", err)
+			}
+		}
+	}
+}
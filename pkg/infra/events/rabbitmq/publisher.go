@@ -0,0 +1,188 @@
+package rabbitmq
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"sync"
+
+	"github.com/psavelis/team-pro/replay-api/pkg/domain/events"
+	replay_entity "github.com/psavelis/team-pro/replay-api/pkg/domain/replay/entities"
+	"github.com/streadway/amqp"
+)
+
+// confirmPublisher publishes on an exchange with publisher confirms. The connection is opened on the first publish and reopened after
+// the broker closes it.
+type confirmPublisher struct {
+	URL  string
+	Dial DialFunc
+
+	exchange string
+	declare  func(ch *amqp.Channel) error
+
+	mu       sync.Mutex
+	conn     *amqp.Connection
+	ch       *amqp.Channel
+	confirms chan amqp.Confirmation
+}
+
+// ReplayEventPublisher publishes replay events.
+type ReplayEventPublisher struct {
+	confirmPublisher
+}
+
+func NewReplayEventPublisher(url string) *ReplayEventPublisher {
+	return &ReplayEventPublisher{
+		confirmPublisher{
+			URL:      url,
+			exchange: ReplayExchange,
+			declare:  DeclareReplayTopology,
+		},
+	}
+}
+
+func (p *ReplayEventPublisher) PublishUploaded(ctx context.Context, uploaded replay_entity.ReplayFileUploaded) error {
+	event, err := events.NewReplayFileUploaded(ctx, uploaded)
+	if err == nil {
+		err = p.publish(ctx, event)
+	}
+
+	if err != nil {
+		slog.ErrorContext(ctx, "unable to publish replay event", "replayFileID", uploaded.ReplayFileID, "err", err)
+		return err
+	}
+
+	return nil
+}
+
+// PublishProgress relays replay processing progress to the api replicas (transient: progress is only relevant while followed).
+func (p *ReplayEventPublisher) PublishProgress(ctx context.Context, progress replay_entity.ReplayProcessingProgress) error {
+	event, err := events.NewReplayProcessingProgress(ctx, progress)
+	if err != nil {
+		return err
+	}
+
+	return p.publish(ctx, event)
+}
+
+// publish sends the event to the exchange of its topic, routed by its type, and waits for the broker to confirm it.
+func (p *confirmPublisher) publish(ctx context.Context, event events.Event) error {
+	if string(event.Topic) != p.exchange {
+		return fmt.Errorf("%s event (topic %s) can't be published on the %s exchange", event.Type, event.Topic, p.exchange)
+	}
+
+	body, err := json.Marshal(event.Payload)
+	if err != nil {
+		return err
+	}
+
+	deliveryMode := amqp.Transient
+	if event.Durable {
+		deliveryMode = amqp.Persistent
+	}
+
+	routingKey := string(event.Type)
+	msg := amqp.Publishing{
+		Headers: amqp.Table{
+			CausationHeader: event.CausationID,
+		},
+		ContentType:   "application/json",
+		DeliveryMode:  deliveryMode,
+		CorrelationId: event.CorrelationID,
+		MessageId:     event.ID.String(),
+		Type:          routingKey,
+		Timestamp:     event.OccurredAt,
+		Body:          body,
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	err = p.connect()
+	if err != nil {
+		slog.ErrorContext(ctx, "unable to connect to rabbitmq", "err", err)
+		return err
+	}
+
+	err = p.ch.Publish(p.exchange, routingKey, false, false, msg)
+	if err != nil {
+		p.close()
+		return err
+	}
+
+	select {
+	case confirm, ok := <-p.confirms:
+		if !ok {
+			p.close()
+			return fmt.Errorf("rabbitmq channel closed before confirming %s message %s", routingKey, msg.MessageId)
+		}
+
+		if !confirm.Ack {
+			return fmt.Errorf("rabbitmq rejected %s message %s", routingKey, msg.MessageId)
+		}
+
+		return nil
+	case <-ctx.Done():
+		// the confirm may still arrive: the channel is dropped so it isn't read as the confirm of the next publish
+		p.close()
+		return ctx.Err()
+	}
+}
+
+func (p *confirmPublisher) connect() error {
+	if p.conn != nil && !p.conn.IsClosed() && p.ch != nil {
+		return nil
+	}
+
+	p.close()
+
+	conn, err := dial(p.URL, p.Dial)
+	if err != nil {
+		return err
+	}
+
+	ch, err := conn.Channel()
+	if err != nil {
+		conn.Close()
+		return err
+	}
+
+	err = p.declare(ch)
+	if err == nil {
+		err = ch.Confirm(false)
+	}
+
+	if err != nil {
+		ch.Close()
+		conn.Close()
+		return err
+	}
+
+	p.conn = conn
+	p.ch = ch
+	p.confirms = ch.NotifyPublish(make(chan amqp.Confirmation, 1))
+
+	return nil
+}
+
+func (p *confirmPublisher) close() {
+	if p.ch != nil {
+		p.ch.Close()
+	}
+
+	if p.conn != nil {
+		p.conn.Close()
+	}
+
+	p.conn = nil
+	p.ch = nil
+	p.confirms = nil
+}
+
+func (p *confirmPublisher) Close() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.close()
+}
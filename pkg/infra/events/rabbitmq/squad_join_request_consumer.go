@@ -0,0 +1,134 @@
+package rabbitmq
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	squad_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/squad/entities"
+	"github.com/streadway/amqp"
+)
+
+// SquadJoinRequestHandler handles a squad join request event (sent, accepted or declined), an error fails the attempt.
+type SquadJoinRequestHandler func(ctx context.Context, event squad_entities.JoinRequestUpdated) error
+
+// SquadJoinRequestConsumer runs the handler over the join request queue of the notification worker, at most Concurrency deliveries at
+// once. A failed delivery is requeued once (ie: the database was unreachable), then dropped and logged.
+type SquadJoinRequestConsumer struct {
+	URL         string
+	Concurrency int
+	Handler     SquadJoinRequestHandler
+	Dial        DialFunc
+}
+
+func NewSquadJoinRequestConsumer(url string, concurrency int, handler SquadJoinRequestHandler) *SquadJoinRequestConsumer {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	return &SquadJoinRequestConsumer{
+		URL:         url,
+		Concurrency: concurrency,
+		Handler:     handler,
+	}
+}
+
+// Run consumes until ctx is done, reconnecting when the broker connection drops. Deliveries in progress are completed on shutdown.
+func (c *SquadJoinRequestConsumer) Run(ctx context.Context) {
+	runConnected(ctx, "squad consumer", c.consume)
+}
+
+func (c *SquadJoinRequestConsumer) consume(ctx context.Context) error {
+	conn, err := dial(c.URL, c.Dial)
+	if err != nil {
+		return err
+	}
+
+	defer conn.Close()
+
+	ch, err := conn.Channel()
+	if err != nil {
+		return err
+	}
+
+	err = DeclareSquadNotificationTopology(ch)
+	if err != nil {
+		return err
+	}
+
+	err = ch.Qos(c.Concurrency, 0, false)
+	if err != nil {
+		return err
+	}
+
+	consumerTag := fmt.Sprintf("notification-worker-squad-%d", time.Now().UnixNano())
+
+	deliveries, err := ch.Consume(NotificationSquadJoinRequestQueue, consumerTag, false, false, false, false, nil)
+	if err != nil {
+		return err
+	}
+
+	closed := conn.NotifyClose(make(chan *amqp.Error, 1))
+
+	slog.InfoContext(ctx, "squad consumer started", "queue", NotificationSquadJoinRequestQueue, "concurrency", c.Concurrency)
+
+	// in-flight deliveries aren't cancelled on shutdown
+	handleCtx := context.WithoutCancel(ctx)
+
+	var wg sync.WaitGroup
+	for i := 0; i < c.Concurrency; i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for d := range deliveries {
+				c.Handle(handleCtx, d)
+			}
+		}()
+	}
+
+	select {
+	case <-ctx.Done():
+		ch.Cancel(consumerTag, false)
+		wg.Wait()
+
+		slog.InfoContext(handleCtx, "squad consumer stopped")
+
+		return nil
+	case amqpErr := <-closed:
+		wg.Wait()
+
+		if amqpErr == nil {
+			return errors.New("rabbitmq connection closed")
+		}
+
+		return amqpErr
+	}
+}
+
+// Handle processes a single delivery: acked when handled (or invalid), requeued on its first failure.
+func (c *SquadJoinRequestConsumer) Handle(ctx context.Context, d amqp.Delivery) {
+	var event squad_entities.JoinRequestUpdated
+
+	err := json.Unmarshal(d.Body, &event)
+	if err != nil {
+		slog.ErrorContext(ctx, "invalid squad join request message", "messageID", d.MessageId, "err", err)
+		d.Ack(false)
+		return
+	}
+
+	err = c.Handler(deliveryCause(ctx, d), event)
+	if err == nil {
+		d.Ack(false)
+		return
+	}
+
+	slog.ErrorContext(ctx, "unable to handle squad join request message", "joinRequestID", event.JoinRequestID, "status", event.Status, "redelivered", d.Redelivered, "err", err)
+
+	d.Nack(false, !d.Redelivered)
+}
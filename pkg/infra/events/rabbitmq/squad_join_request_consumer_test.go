@@ -0,0 +1,62 @@
+package rabbitmq_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	squad_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/squad/entities"
+	"github.com/psavelis/team-pro/replay-api/pkg/infra/events/rabbitmq"
+	"github.com/streadway/amqp"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSquadJoinRequestConsumer_Handle(t *testing.T) {
+	event := squad_entities.JoinRequestUpdated{JoinRequestID: uuid.New(), SquadID: uuid.New(), Kind: squad_entities.JoinRequestKindInvite, Status: squad_entities.JoinRequestStatusPending}
+	body, _ := json.Marshal(event)
+
+	tests := []struct {
+		name             string
+		body             []byte
+		redelivered      bool
+		handlerErr       error
+		expectedAcks     int
+		expectedNacks    int
+		expectedRequeued bool
+		expectedHandled  bool
+	}{
+		{name: "Notified", body: body, expectedAcks: 1, expectedHandled: true},
+		{name: "Invalid Message", body: []byte("{"), expectedAcks: 1},
+		{name: "First Failure Is Requeued", body: body, handlerErr: errors.New("mongo unreachable"), expectedNacks: 1, expectedRequeued: true, expectedHandled: true},
+		{name: "Redelivered Failure Is Dropped", body: body, redelivered: true, handlerErr: errors.New("mongo unreachable"), expectedNacks: 1, expectedHandled: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var handled *squad_entities.JoinRequestUpdated
+
+			consumer := rabbitmq.NewSquadJoinRequestConsumer("", 1, func(ctx context.Context, e squad_entities.JoinRequestUpdated) error {
+				handled = &e
+				return tt.handlerErr
+			})
+
+			ack := &fakeAcknowledger{}
+			consumer.Handle(context.Background(), amqp.Delivery{Acknowledger: ack, Body: tt.body, Redelivered: tt.redelivered, CorrelationId: "req-1"})
+
+			assert.Equal(t, tt.expectedAcks, ack.acks)
+			assert.Equal(t, tt.expectedNacks, ack.nacks)
+			assert.Equal(t, tt.expectedRequeued, ack.requeued)
+
+			if !tt.expectedHandled {
+				assert.Nil(t, handled)
+				return
+			}
+
+			if assert.NotNil(t, handled) {
+				assert.Equal(t, event.JoinRequestID, handled.JoinRequestID)
+			}
+		})
+	}
+}
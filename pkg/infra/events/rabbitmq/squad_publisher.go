@@ -0,0 +1,38 @@
+package rabbitmq
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/psavelis/team-pro/replay-api/pkg/domain/events"
+	squad_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/squad/entities"
+)
+
+// SquadEventPublisher publishes squad (join request) events.
+type SquadEventPublisher struct {
+	confirmPublisher
+}
+
+func NewSquadEventPublisher(url string) *SquadEventPublisher {
+	return &SquadEventPublisher{
+		confirmPublisher{
+			URL:      url,
+			exchange: SquadExchange,
+			declare:  DeclareSquadTopology,
+		},
+	}
+}
+
+func (p *SquadEventPublisher) PublishJoinRequestUpdated(ctx context.Context, updated squad_entities.JoinRequestUpdated) error {
+	event, err := events.NewSquadJoinRequestUpdated(ctx, updated)
+	if err == nil {
+		err = p.publish(ctx, event)
+	}
+
+	if err != nil {
+		slog.ErrorContext(ctx, "unable to publish squad event", "joinRequestID", updated.JoinRequestID, "err", err)
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,167 @@
+package rabbitmq
+
+import (
+	"net"
+	"time"
+
+	"github.com/psavelis/team-pro/replay-api/pkg/domain/events"
+	"github.com/streadway/amqp"
+)
+
+const (
+	ReplayExchange = string(events.ReplayTopic)
+
+	// lobby events are only published: each consumer (ie: game servers, notifications) binds its own queue
+	MatchmakingExchange = string(events.MatchmakingTopic)
+
+	// squad events are only published: each consumer (ie: notifications) binds its own queue
+	SquadExchange = string(events.SquadTopic)
+
+	// replay.uploaded messages wait in the retry queue for ReplayRetryDelay and are dead-lettered back to the exchange; messages out
+	// of attempts are parked in the dead-letter queue for inspection (and manual shoveling once fixed)
+	ReplayUploadedQueue           = string(events.ReplayFileUploaded)
+	ReplayUploadedRetryQueue      = ReplayUploadedQueue + ".retry"
+	ReplayUploadedDeadLetterQueue = ReplayUploadedQueue + ".dlq"
+
+	ReplayRetryDelay = 30 * time.Second
+
+	// lobby.created messages of the notification worker: match found alerts are dropped by the broker once stale (ie: while no
+	// worker is running), the players would only get them after the accept window
+	NotificationLobbyCreatedQueue      = "notifications." + string(events.LobbyCreated)
+	NotificationLobbyCreatedMessageTTL = time.Minute
+
+	// squad join request messages of the notification worker (all the steps of the requests): kept until notified, the inbox of the
+	// users is only filled by the worker
+	NotificationSquadJoinRequestQueue = "notifications.squad.join_requests"
+
+	// match.completed messages of the rating worker: kept until rated, a rating missed would skew the ratings of the players
+	RatingMatchCompletedQueue = "ratings." + string(events.MatchCompleted)
+
+	AttemptHeader   = "x-attempt"
+	ErrorHeader     = "x-error"
+	CausationHeader = "x-causation-id"
+)
+
+// DialFunc opens the broker connection (ie: a fault injecting dialer in tests), amqp's default dialer is used when nil.
+type DialFunc func(network, address string) (net.Conn, error)
+
+func dial(url string, dialFunc DialFunc) (*amqp.Connection, error) {
+	if dialFunc == nil {
+		return amqp.Dial(url)
+	}
+
+	// amqp.Dial defaults
+	return amqp.DialConfig(url, amqp.Config{
+		Heartbeat: 10 * time.Second,
+		Locale:    "en_US",
+		Dial:      dialFunc,
+	})
+}
+
+// Ping opens (and closes) a broker connection, ie: to verify connectivity on setup.
+func Ping(url string) error {
+	conn, err := dial(url, nil)
+	if err != nil {
+		return err
+	}
+
+	return conn.Close()
+}
+
+// DeclareReplayTopology declares (idempotently) the exchange and queues used by the replay publisher and workers.
+func DeclareReplayTopology(ch *amqp.Channel) error {
+	err := ch.ExchangeDeclare(ReplayExchange, amqp.ExchangeTopic, true, false, false, false, nil)
+	if err != nil {
+		return err
+	}
+
+	_, err = ch.QueueDeclare(ReplayUploadedQueue, true, false, false, false, nil)
+	if err != nil {
+		return err
+	}
+
+	err = ch.QueueBind(ReplayUploadedQueue, string(events.ReplayFileUploaded), ReplayExchange, false, nil)
+	if err != nil {
+		return err
+	}
+
+	_, err = ch.QueueDeclare(ReplayUploadedRetryQueue, true, false, false, false, amqp.Table{
+		"x-message-ttl":             int32(ReplayRetryDelay / time.Millisecond),
+		"x-dead-letter-exchange":    ReplayExchange,
+		"x-dead-letter-routing-key": string(events.ReplayFileUploaded),
+	})
+
+	if err != nil {
+		return err
+	}
+
+	_, err = ch.QueueDeclare(ReplayUploadedDeadLetterQueue, true, false, false, false, nil)
+
+	return err
+}
+
+// DeclareMatchmakingTopology declares (idempotently) the exchange of the matchmaking events.
+func DeclareMatchmakingTopology(ch *amqp.Channel) error {
+	return ch.ExchangeDeclare(MatchmakingExchange, amqp.ExchangeTopic, true, false, false, false, nil)
+}
+
+// DeclareSquadTopology declares (idempotently) the exchange of the squad events.
+func DeclareSquadTopology(ch *amqp.Channel) error {
+	return ch.ExchangeDeclare(SquadExchange, amqp.ExchangeTopic, true, false, false, false, nil)
+}
+
+// DeclareNotificationTopology declares (idempotently) the queue of the notification worker, bound to the lobby events it alerts on.
+func DeclareNotificationTopology(ch *amqp.Channel) error {
+	err := DeclareMatchmakingTopology(ch)
+	if err != nil {
+		return err
+	}
+
+	_, err = ch.QueueDeclare(NotificationLobbyCreatedQueue, true, false, false, false, amqp.Table{
+		"x-message-ttl": int32(NotificationLobbyCreatedMessageTTL / time.Millisecond),
+	})
+
+	if err != nil {
+		return err
+	}
+
+	return ch.QueueBind(NotificationLobbyCreatedQueue, string(events.LobbyCreated), MatchmakingExchange, false, nil)
+}
+
+// DeclareSquadNotificationTopology declares (idempotently) the join request queue of the notification worker, bound to each step of
+// the requests.
+func DeclareSquadNotificationTopology(ch *amqp.Channel) error {
+	err := DeclareSquadTopology(ch)
+	if err != nil {
+		return err
+	}
+
+	_, err = ch.QueueDeclare(NotificationSquadJoinRequestQueue, true, false, false, false, nil)
+	if err != nil {
+		return err
+	}
+
+	for _, t := range []events.Type{events.SquadInviteSent, events.SquadApplicationSubmitted, events.SquadJoinRequestAccepted, events.SquadJoinRequestDeclined} {
+		err = ch.QueueBind(NotificationSquadJoinRequestQueue, string(t), SquadExchange, false, nil)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// DeclareRatingTopology declares (idempotently) the queue of the rating worker, bound to the completed matches.
+func DeclareRatingTopology(ch *amqp.Channel) error {
+	err := DeclareMatchmakingTopology(ch)
+	if err != nil {
+		return err
+	}
+
+	_, err = ch.QueueDeclare(RatingMatchCompletedQueue, true, false, false, false, nil)
+	if err != nil {
+		return err
+	}
+
+	return ch.QueueBind(RatingMatchCompletedQueue, string(events.MatchCompleted), MatchmakingExchange, false, nil)
+}
@@ -0,0 +1,58 @@
+package fxrates
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	fx_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/fx/entities"
+	fx_out "github.com/psavelis/team-pro/replay-api/pkg/domain/fx/ports/out"
+)
+
+// CachedProvider keeps the rates fetched from a provider for TTL (sources are rate limited, and the ECB only publishes
+// once a day). Failures aren't cached. Staleness is still measured from the ObservedAt of the cached rate.
+type CachedProvider struct {
+	fx_out.ExchangeRateProvider
+	TTL time.Duration
+
+	mu    sync.Mutex
+	rates map[string]cachedRate
+}
+
+type cachedRate struct {
+	rate      fx_entities.ExchangeRate
+	expiresAt time.Time
+}
+
+func NewCachedProvider(provider fx_out.ExchangeRateProvider, ttl time.Duration) *CachedProvider {
+	return &CachedProvider{
+		ExchangeRateProvider: provider,
+		TTL:                  ttl,
+		rates:                make(map[string]cachedRate),
+	}
+}
+
+func (p *CachedProvider) GetRate(ctx context.Context, base string, quote string) (*fx_entities.ExchangeRate, error) {
+	key := base + "/" + quote
+	now := time.Now()
+
+	p.mu.Lock()
+	cached, ok := p.rates[key]
+	p.mu.Unlock()
+
+	if ok && now.Before(cached.expiresAt) {
+		rate := cached.rate
+		return &rate, nil
+	}
+
+	rate, err := p.ExchangeRateProvider.GetRate(ctx, base, quote)
+	if err != nil {
+		return nil, err
+	}
+
+	p.mu.Lock()
+	p.rates[key] = cachedRate{rate: *rate, expiresAt: now.Add(p.TTL)}
+	p.mu.Unlock()
+
+	return rate, nil
+}
@@ -0,0 +1,132 @@
+package fxrates
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/psavelis/team-pro/replay-api/pkg/domain/fx"
+	fx_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/fx/entities"
+)
+
+const (
+	CoingeckoProviderName = "coingecko"
+	CoingeckoURL          = "https://api.coingecko.com/api/v3"
+
+	// coingecko refreshes prices every minute or so, an older price means the asset isn't trading (or isn't tracked anymore)
+	coingeckoMaxAge = 10 * time.Minute
+)
+
+// coingeckoCoinIDs maps the crypto asset symbols to their coingecko coin IDs.
+var coingeckoCoinIDs = map[string]string{
+	"BTC":  "bitcoin",
+	"ETH":  "ethereum",
+	"SOL":  "solana",
+	"USDC": "usd-coin",
+	"USDT": "tether",
+}
+
+// CoingeckoProvider quotes crypto assets against fiat currencies (or other crypto assets) from the coingecko simple price API.
+// Pairs quoted the other way around (ie: USD/BTC) are inverted.
+type CoingeckoProvider struct {
+	URL    string
+	APIKey string // demo plan key, the keyless public API is rate limited harder
+	Client *http.Client
+}
+
+func NewCoingeckoProvider(url string, apiKey string) *CoingeckoProvider {
+	return &CoingeckoProvider{
+		URL:    strings.TrimSuffix(url, "/"),
+		APIKey: apiKey,
+		Client: &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+func (p *CoingeckoProvider) Name() string {
+	return CoingeckoProviderName
+}
+
+func (p *CoingeckoProvider) MaxAge() time.Duration {
+	return coingeckoMaxAge
+}
+
+func (p *CoingeckoProvider) GetRate(ctx context.Context, base string, quote string) (*fx_entities.ExchangeRate, error) {
+	if coinID, ok := coingeckoCoinIDs[base]; ok {
+		return p.price(ctx, coinID, base, quote)
+	}
+
+	if coinID, ok := coingeckoCoinIDs[quote]; ok {
+		rate, err := p.price(ctx, coinID, quote, base)
+		if err != nil {
+			var unsupportedErr *fx.UnsupportedPairError
+			if errors.As(err, &unsupportedErr) {
+				return nil, fx.NewUnsupportedPairError(base, quote)
+			}
+
+			return nil, err
+		}
+
+		inverse := rate.Inverse()
+
+		return &inverse, nil
+	}
+
+	return nil, fx.NewUnsupportedPairError(base, quote)
+}
+
+// price returns the price of the coin (symbol) in the vs currency.
+func (p *CoingeckoProvider) price(ctx context.Context, coinID string, symbol string, vsCurrency string) (*fx_entities.ExchangeRate, error) {
+	vs := strings.ToLower(vsCurrency)
+
+	query := url.Values{}
+	query.Set("ids", coinID)
+	query.Set("vs_currencies", vs)
+	query.Set("include_last_updated_at", "true")
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.URL+"/simple/price?"+query.Encode(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Accept", "application/json")
+
+	if p.APIKey != "" {
+		req.Header.Set("x-cg-demo-api-key", p.APIKey)
+	}
+
+	res, err := p.Client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("coingecko simple price responded with status %d", res.StatusCode)
+	}
+
+	// ie: {"bitcoin":{"usd":67000.5,"last_updated_at":1760000000}}
+	var prices map[string]map[string]float64
+	err = json.NewDecoder(res.Body).Decode(&prices)
+	if err != nil {
+		return nil, fmt.Errorf("unable to decode coingecko simple price: %w", err)
+	}
+
+	price := prices[coinID][vs]
+	if price <= 0 {
+		return nil, fx.NewUnsupportedPairError(symbol, vsCurrency)
+	}
+
+	return &fx_entities.ExchangeRate{
+		Base:       symbol,
+		Quote:      vsCurrency,
+		Rate:       price,
+		Source:     CoingeckoProviderName,
+		ObservedAt: time.Unix(int64(prices[coinID]["last_updated_at"]), 0).UTC(),
+	}, nil
+}
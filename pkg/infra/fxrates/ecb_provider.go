@@ -0,0 +1,125 @@
+package fxrates
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/psavelis/team-pro/replay-api/pkg/domain/fx"
+	fx_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/fx/entities"
+)
+
+const (
+	ECBProviderName = "ecb"
+	ECBDailyURL     = "https://www.ecb.europa.eu/stats/eurofxref/eurofxref-daily.xml"
+
+	// rates are published once per TARGET business day: the rates of the day before a long weekend (ie: Easter) last until
+	// the next publication
+	ecbMaxAge = 6 * 24 * time.Hour
+)
+
+// ECBProvider quotes the euro foreign exchange reference rates of the European Central Bank, crossed through the euro for
+// pairs without it (ie: USD/BRL).
+type ECBProvider struct {
+	URL    string
+	Client *http.Client
+}
+
+func NewECBProvider(url string) *ECBProvider {
+	return &ECBProvider{
+		URL:    url,
+		Client: &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+type ecbEnvelope struct {
+	Cube struct {
+		Days []struct {
+			Time  string `xml:"time,attr"`
+			Rates []struct {
+				Currency string  `xml:"currency,attr"`
+				Rate     float64 `xml:"rate,attr"`
+			} `xml:"Cube"`
+		} `xml:"Cube"`
+	} `xml:"Cube"`
+}
+
+func (p *ECBProvider) Name() string {
+	return ECBProviderName
+}
+
+func (p *ECBProvider) MaxAge() time.Duration {
+	return ecbMaxAge
+}
+
+func (p *ECBProvider) GetRate(ctx context.Context, base string, quote string) (*fx_entities.ExchangeRate, error) {
+	day, euroRates, err := p.fetch(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	baseRate, ok := euroRates[base]
+	if !ok {
+		return nil, fx.NewUnsupportedPairError(base, quote)
+	}
+
+	quoteRate, ok := euroRates[quote]
+	if !ok {
+		return nil, fx.NewUnsupportedPairError(base, quote)
+	}
+
+	return &fx_entities.ExchangeRate{
+		Base:       base,
+		Quote:      quote,
+		Rate:       quoteRate / baseRate,
+		Source:     ECBProviderName,
+		ObservedAt: day,
+	}, nil
+}
+
+// fetch returns the reference day and the rates of one euro in every published currency (including the euro itself).
+func (p *ECBProvider) fetch(ctx context.Context) (time.Time, map[string]float64, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.URL, nil)
+	if err != nil {
+		return time.Time{}, nil, err
+	}
+
+	res, err := p.Client.Do(req)
+	if err != nil {
+		return time.Time{}, nil, err
+	}
+
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return time.Time{}, nil, fmt.Errorf("ecb reference rates responded with status %d", res.StatusCode)
+	}
+
+	var envelope ecbEnvelope
+	err = xml.NewDecoder(res.Body).Decode(&envelope)
+	if err != nil {
+		return time.Time{}, nil, fmt.Errorf("unable to decode ecb reference rates: %w", err)
+	}
+
+	if len(envelope.Cube.Days) == 0 {
+		return time.Time{}, nil, fmt.Errorf("ecb reference rates are empty")
+	}
+
+	latest := envelope.Cube.Days[0]
+
+	day, err := time.Parse(time.DateOnly, latest.Time)
+	if err != nil {
+		return time.Time{}, nil, fmt.Errorf("invalid ecb reference day '%s': %w", latest.Time, err)
+	}
+
+	rates := map[string]float64{"EUR": 1}
+	for _, r := range latest.Rates {
+		if r.Rate > 0 {
+			rates[r.Currency] = r.Rate
+		}
+	}
+
+	return day, rates, nil
+}
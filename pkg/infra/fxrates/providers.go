@@ -0,0 +1,36 @@
+package fxrates
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	fx_out "github.com/psavelis/team-pro/replay-api/pkg/domain/fx/ports/out"
+)
+
+const (
+	coingeckoCacheTTL = time.Minute
+	ecbCacheTTL       = time.Hour
+)
+
+// NewExchangeRateProviders returns the configured providers (cached), by priority. Exchange rates are unavailable when none
+// is configured.
+func NewExchangeRateProviders(config common.FXConfig) ([]fx_out.ExchangeRateProvider, error) {
+	providers := make([]fx_out.ExchangeRateProvider, 0)
+
+	for _, name := range strings.Split(config.Providers, ",") {
+		switch strings.TrimSpace(name) {
+		case "":
+			continue
+		case CoingeckoProviderName:
+			providers = append(providers, NewCachedProvider(NewCoingeckoProvider(CoingeckoURL, config.CoingeckoAPIKey), coingeckoCacheTTL))
+		case ECBProviderName:
+			providers = append(providers, NewCachedProvider(NewECBProvider(ECBDailyURL), ecbCacheTTL))
+		default:
+			return nil, fmt.Errorf("unsupported exchange rate provider '%s'", name)
+		}
+	}
+
+	return providers, nil
+}
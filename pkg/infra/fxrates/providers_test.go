@@ -0,0 +1,153 @@
+package fxrates_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	"github.com/psavelis/team-pro/replay-api/pkg/domain/fx"
+	"github.com/psavelis/team-pro/replay-api/pkg/infra/fxrates"
+	"github.com/stretchr/testify/assert"
+)
+
+const ecbDaily = `<?xml version="1.0" encoding="UTF-8"?>
+<gesmes:Envelope xmlns:gesmes="http://www.gesmes.org/xml/2002-08-01" xmlns="http://www.ecb.int/vocabulary/2002-08-01/eurofxref">
+	<gesmes:subject>Reference rates</gesmes:subject>
+	<Cube>
+		<Cube time='2026-10-15'>
+			<Cube currency='USD' rate='1.0800'/>
+			<Cube currency='BRL' rate='5.4000'/>
+		</Cube>
+	</Cube>
+</gesmes:Envelope>`
+
+func TestECBProvider_GetRate(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(ecbDaily))
+	}))
+	defer server.Close()
+
+	p := fxrates.NewECBProvider(server.URL)
+
+	tests := []struct {
+		name     string
+		base     string
+		quote    string
+		wantRate float64
+	}{
+		{name: "euro based", base: "EUR", quote: "USD", wantRate: 1.08},
+		{name: "euro quoted", base: "USD", quote: "EUR", wantRate: 1 / 1.08},
+		{name: "cross rate", base: "USD", quote: "BRL", wantRate: 5},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rate, err := p.GetRate(context.Background(), tt.base, tt.quote)
+			if assert.NoError(t, err) {
+				assert.InDelta(t, tt.wantRate, rate.Rate, 1e-9)
+				assert.Equal(t, fxrates.ECBProviderName, rate.Source)
+				assert.Equal(t, time.Date(2026, 10, 15, 0, 0, 0, 0, time.UTC), rate.ObservedAt)
+			}
+		})
+	}
+
+	_, err := p.GetRate(context.Background(), "BTC", "USD")
+
+	var unsupportedErr *fx.UnsupportedPairError
+	assert.ErrorAs(t, err, &unsupportedErr)
+}
+
+func TestCoingeckoProvider_GetRate(t *testing.T) {
+	updatedAt := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/simple/price", r.URL.Path)
+		assert.Equal(t, "demo-key", r.Header.Get("x-cg-demo-api-key"))
+
+		if r.URL.Query().Get("ids") != "bitcoin" || r.URL.Query().Get("vs_currencies") != "usd" {
+			w.Write([]byte(`{}`))
+			return
+		}
+
+		fmt.Fprintf(w, `{"bitcoin":{"usd":64000,"last_updated_at":%d}}`, updatedAt.Unix())
+	}))
+	defer server.Close()
+
+	p := fxrates.NewCoingeckoProvider(server.URL, "demo-key")
+
+	rate, err := p.GetRate(context.Background(), "BTC", "USD")
+	if assert.NoError(t, err) {
+		assert.Equal(t, 64000.0, rate.Rate)
+		assert.Equal(t, updatedAt, rate.ObservedAt)
+	}
+
+	rate, err = p.GetRate(context.Background(), "USD", "BTC")
+	if assert.NoError(t, err) {
+		assert.Equal(t, "USD/BTC", rate.Pair())
+		assert.InDelta(t, 1/64000.0, rate.Rate, 1e-12)
+	}
+
+	for _, pair := range [][2]string{{"BTC", "BRL"}, {"EUR", "USD"}} {
+		_, err = p.GetRate(context.Background(), pair[0], pair[1])
+
+		var unsupportedErr *fx.UnsupportedPairError
+		assert.ErrorAs(t, err, &unsupportedErr, pair)
+	}
+}
+
+func TestCachedProvider_GetRate(t *testing.T) {
+	calls := 0
+	failing := false
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+
+		if failing {
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+
+		w.Write([]byte(ecbDaily))
+	}))
+	defer server.Close()
+
+	p := fxrates.NewCachedProvider(fxrates.NewECBProvider(server.URL), time.Hour)
+
+	for i := 0; i < 3; i++ {
+		rate, err := p.GetRate(context.Background(), "EUR", "USD")
+		assert.NoError(t, err)
+		assert.Equal(t, 1.08, rate.Rate)
+	}
+
+	assert.Equal(t, 1, calls)
+	assert.Equal(t, fxrates.ECBProviderName, p.Name())
+
+	// failures aren't cached
+	failing = true
+
+	for i := 0; i < 2; i++ {
+		_, err := p.GetRate(context.Background(), "EUR", "BRL")
+		assert.Error(t, err)
+	}
+
+	assert.Equal(t, 3, calls)
+}
+
+func TestNewExchangeRateProviders(t *testing.T) {
+	providers, err := fxrates.NewExchangeRateProviders(common.FXConfig{Providers: "coingecko, ecb"})
+	if assert.NoError(t, err) && assert.Len(t, providers, 2) {
+		assert.Equal(t, fxrates.CoingeckoProviderName, providers[0].Name())
+		assert.Equal(t, fxrates.ECBProviderName, providers[1].Name())
+	}
+
+	providers, err = fxrates.NewExchangeRateProviders(common.FXConfig{})
+	assert.NoError(t, err)
+	assert.Empty(t, providers)
+
+	_, err = fxrates.NewExchangeRateProviders(common.FXConfig{Providers: "ecb,fixer"})
+	assert.ErrorContains(t, err, "fixer")
+}
@@ -0,0 +1,573 @@
+package graphql
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+)
+
+type Request struct {
+	Query         string         `json:"query"`
+	OperationName string         `json:"operationName,omitempty"`
+	Variables     map[string]any `json:"variables,omitempty"`
+}
+
+// Error is a GraphQL error. Fields resolved in batch share their error, so Path has the response keys of the field, but not the
+// indices of the lists it was resolved under.
+type Error struct {
+	Message string `json:"message"`
+	Path    []any  `json:"path,omitempty"`
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+type Response struct {
+	Data   any      `json:"data,omitempty"`
+	Errors []*Error `json:"errors,omitempty"`
+}
+
+// resultObject keeps the fields in the order they were selected, as required in the response.
+type resultObject struct {
+	keys   []string
+	values map[string]any
+}
+
+func newResultObject() *resultObject {
+	return &resultObject{values: make(map[string]any)}
+}
+
+func (o *resultObject) set(key string, value any) {
+	if _, ok := o.values[key]; !ok {
+		o.keys = append(o.keys, key)
+	}
+
+	o.values[key] = value
+}
+
+func (o *resultObject) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+
+	buf.WriteByte('{')
+
+	for i, key := range o.keys {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+
+		k, err := json.Marshal(key)
+		if err != nil {
+			return nil, err
+		}
+
+		v, err := json.Marshal(o.values[key])
+		if err != nil {
+			return nil, err
+		}
+
+		buf.Write(k)
+		buf.WriteByte(':')
+		buf.Write(v)
+	}
+
+	buf.WriteByte('}')
+
+	return buf.Bytes(), nil
+}
+
+type execution struct {
+	schema    *Schema
+	doc       *Document
+	operation *Operation
+	variables map[string]any
+	errors    []*Error
+}
+
+// collectedField is the set of fields selected under the same response key (ie: the same field selected by two fragments).
+type collectedField struct {
+	Key    string
+	Fields []*Field
+}
+
+func (f collectedField) selectionSet() []Selection {
+	var selections []Selection
+	for _, field := range f.Fields {
+		selections = append(selections, field.SelectionSet...)
+	}
+
+	return selections
+}
+
+// Execute runs a query against the schema. Fields are resolved breadth-first: each field of a selection set is resolved once for
+// all the values of its level (see BatchResolveFunc). Mutations, subscriptions and introspection aren't supported.
+func (s *Schema) Execute(ctx context.Context, req Request) *Response {
+	doc, err := Parse(req.Query)
+	if err != nil {
+		return &Response{Errors: []*Error{{Message: fmt.Sprintf("syntax error: %v", err)}}}
+	}
+
+	op, err := selectOperation(doc, req.OperationName)
+	if err != nil {
+		return &Response{Errors: []*Error{{Message: err.Error()}}}
+	}
+
+	if op.Type != "query" {
+		return &Response{Errors: []*Error{{Message: fmt.Sprintf("%s operations are not supported", op.Type)}}}
+	}
+
+	e := &execution{schema: s, doc: doc, operation: op}
+
+	e.variables, err = e.coerceVariables(req.Variables)
+	if err != nil {
+		return &Response{Errors: []*Error{{Message: err.Error()}}}
+	}
+
+	err = e.validate(s.Query, op.SelectionSet, 1, nil)
+	if err != nil {
+		return &Response{Errors: []*Error{err.(*Error)}}
+	}
+
+	data := e.executeSelectionSet(ctx, s.Query, []any{nil}, op.SelectionSet, nil)
+
+	return &Response{Data: data[0], Errors: e.errors}
+}
+
+func selectOperation(doc *Document, name string) (*Operation, error) {
+	if name == "" {
+		if len(doc.Operations) > 1 {
+			return nil, fmt.Errorf("operationName is required when the document has more than one operation")
+		}
+
+		return doc.Operations[0], nil
+	}
+
+	for _, op := range doc.Operations {
+		if op.Name == name {
+			return op, nil
+		}
+	}
+
+	return nil, fmt.Errorf("unknown operation named %q", name)
+}
+
+// coerceVariables returns the values of the variables declared by the operation: the request ones, or their defaults. The types of
+// the declarations aren't checked, values are coerced by the arguments they're used in.
+func (e *execution) coerceVariables(values map[string]any) (map[string]any, error) {
+	variables := make(map[string]any, len(e.operation.Variables))
+	declared := make(map[string]bool, len(e.operation.Variables))
+
+	for _, definition := range e.operation.Variables {
+		if declared[definition.Name] {
+			return nil, fmt.Errorf("there can be only one variable named $%s", definition.Name)
+		}
+
+		declared[definition.Name] = true
+
+		if v, ok := values[definition.Name]; ok {
+			variables[definition.Name] = v
+			continue
+		}
+
+		if definition.DefaultValue != nil {
+			v, err := e.valueFromAST(definition.DefaultValue)
+			if err != nil {
+				return nil, err
+			}
+
+			variables[definition.Name] = v
+		}
+	}
+
+	return variables, nil
+}
+
+func (e *execution) valueFromAST(value Value) (any, error) {
+	switch v := value.(type) {
+	case Variable:
+		for _, definition := range e.operation.Variables {
+			if definition.Name == v.Name {
+				return e.variables[v.Name], nil
+			}
+		}
+
+		return nil, fmt.Errorf("variable $%s is not defined", v.Name)
+	case IntValue:
+		return v.Value, nil
+	case FloatValue:
+		return v.Value, nil
+	case StringValue:
+		return v.Value, nil
+	case BooleanValue:
+		return v.Value, nil
+	case EnumValue:
+		return v.Value, nil
+	case ListValue:
+		list := make([]any, len(v.Values))
+		for i, item := range v.Values {
+			var err error
+			list[i], err = e.valueFromAST(item)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		return list, nil
+	case ObjectValue:
+		object := make(map[string]any, len(v.Fields))
+		for name, field := range v.Fields {
+			var err error
+			object[name], err = e.valueFromAST(field)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		return object, nil
+	default:
+		return nil, nil
+	}
+}
+
+// coerceArguments returns the arguments of the field; null arguments are left out.
+func (e *execution) coerceArguments(definition *FieldDefinition, field *Field) (map[string]any, error) {
+	args := make(map[string]any, len(field.Arguments))
+
+	for name, value := range field.Arguments {
+		scalar, ok := definition.Args[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown argument %q on field %q", name, field.Name)
+		}
+
+		v, err := e.valueFromAST(value)
+		if err != nil {
+			return nil, err
+		}
+
+		if v == nil {
+			continue
+		}
+
+		args[name], err = scalar.ParseValue(v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid argument %q on field %q: %v", name, field.Name, err)
+		}
+	}
+
+	return args, nil
+}
+
+// shouldInclude applies the @skip and @include directives.
+func (e *execution) shouldInclude(directives []*Directive) (bool, error) {
+	for _, directive := range directives {
+		if directive.Name != "skip" && directive.Name != "include" {
+			return false, fmt.Errorf("unknown directive @%s", directive.Name)
+		}
+
+		value, ok := directive.Arguments["if"]
+		if !ok {
+			return false, fmt.Errorf("directive @%s requires the \"if\" argument", directive.Name)
+		}
+
+		v, err := e.valueFromAST(value)
+		if err != nil {
+			return false, err
+		}
+
+		condition, ok := v.(bool)
+		if !ok {
+			return false, fmt.Errorf("directive @%s \"if\" argument must be a Boolean", directive.Name)
+		}
+
+		if condition == (directive.Name == "skip") {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+
+// collectFields flattens the fragments of a selection set, grouping the fields by response key.
+func (e *execution) collectFields(object *Object, selections []Selection, collected []collectedField, visitedFragments map[string]bool) ([]collectedField, error) {
+	for _, selection := range selections {
+		switch s := selection.(type) {
+		case *Field:
+			include, err := e.shouldInclude(s.Directives)
+			if err != nil {
+				return nil, err
+			}
+
+			if !include {
+				continue
+			}
+
+			merged := false
+			for i := range collected {
+				if collected[i].Key == s.ResponseKey() {
+					if collected[i].Fields[0].Name != s.Name {
+						return nil, fmt.Errorf("fields %q and %q conflict because they are both selected as %q", collected[i].Fields[0].Name, s.Name, s.ResponseKey())
+					}
+
+					collected[i].Fields = append(collected[i].Fields, s)
+					merged = true
+					break
+				}
+			}
+
+			if !merged {
+				collected = append(collected, collectedField{Key: s.ResponseKey(), Fields: []*Field{s}})
+			}
+		case *FragmentSpread:
+			include, err := e.shouldInclude(s.Directives)
+			if err != nil {
+				return nil, err
+			}
+
+			if !include || visitedFragments[s.Name] {
+				continue
+			}
+
+			visitedFragments[s.Name] = true
+
+			fragment, ok := e.doc.Fragments[s.Name]
+			if !ok {
+				return nil, fmt.Errorf("unknown fragment %q", s.Name)
+			}
+
+			if fragment.TypeCondition != object.Name {
+				return nil, fmt.Errorf("fragment %q on %q cannot be spread on %q", s.Name, fragment.TypeCondition, object.Name)
+			}
+
+			collected, err = e.collectFields(object, fragment.SelectionSet, collected, visitedFragments)
+			if err != nil {
+				return nil, err
+			}
+		case *InlineFragment:
+			include, err := e.shouldInclude(s.Directives)
+			if err != nil {
+				return nil, err
+			}
+
+			if !include {
+				continue
+			}
+
+			if s.TypeCondition != "" && s.TypeCondition != object.Name {
+				return nil, fmt.Errorf("inline fragment on %q cannot be spread on %q", s.TypeCondition, object.Name)
+			}
+
+			collected, err = e.collectFields(object, s.SelectionSet, collected, visitedFragments)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return collected, nil
+}
+
+// validate checks the selections against the schema before anything is resolved.
+func (e *execution) validate(object *Object, selections []Selection, depth int, path []any) error {
+	if depth > e.schema.MaxDepth {
+		return &Error{Message: fmt.Sprintf("query exceeds the maximum depth of %d", e.schema.MaxDepth), Path: path}
+	}
+
+	fields, err := e.collectFields(object, selections, nil, make(map[string]bool))
+	if err != nil {
+		return &Error{Message: err.Error(), Path: path}
+	}
+
+	for _, f := range fields {
+		fieldPath := append(append([]any{}, path...), f.Key)
+		name := f.Fields[0].Name
+
+		if name == "__typename" {
+			if len(f.selectionSet()) > 0 {
+				return &Error{Message: "field \"__typename\" must not have a selection", Path: fieldPath}
+			}
+
+			continue
+		}
+
+		definition, ok := object.Fields[name]
+		if !ok {
+			return &Error{Message: fmt.Sprintf("cannot query field %q on type %q", name, object.Name), Path: fieldPath}
+		}
+
+		for _, field := range f.Fields {
+			_, err := e.coerceArguments(definition, field)
+			if err != nil {
+				return &Error{Message: err.Error(), Path: fieldPath}
+			}
+		}
+
+		switch t := namedType(definition.Type).(type) {
+		case *Object:
+			for _, field := range f.Fields {
+				if len(field.SelectionSet) == 0 {
+					return &Error{Message: fmt.Sprintf("field %q of type %q must have a selection of subfields", name, definition.Type), Path: fieldPath}
+				}
+			}
+
+			err := e.validate(t, f.selectionSet(), depth+1, fieldPath)
+			if err != nil {
+				return err
+			}
+		default:
+			if len(f.selectionSet()) > 0 {
+				return &Error{Message: fmt.Sprintf("field %q must not have a selection since type %q has no subfields", name, definition.Type), Path: fieldPath}
+			}
+		}
+	}
+
+	return nil
+}
+
+func (e *execution) addError(err error, path []any) {
+	e.errors = append(e.errors, &Error{Message: err.Error(), Path: path})
+}
+
+// executeSelectionSet resolves the selections for every source, field by field. A failed field is null for every source.
+func (e *execution) executeSelectionSet(ctx context.Context, object *Object, sources []any, selections []Selection, path []any) []*resultObject {
+	results := make([]*resultObject, len(sources))
+	for i := range results {
+		results[i] = newResultObject()
+	}
+
+	// validated already
+	fields, _ := e.collectFields(object, selections, nil, make(map[string]bool))
+
+	for _, f := range fields {
+		fieldPath := append(append([]any{}, path...), f.Key)
+		name := f.Fields[0].Name
+
+		if name == "__typename" {
+			for _, result := range results {
+				result.set(f.Key, object.Name)
+			}
+
+			continue
+		}
+
+		definition := object.Fields[name]
+		args, _ := e.coerceArguments(definition, f.Fields[0])
+
+		values, err := definition.Resolve(ctx, sources, args)
+		if err == nil && len(values) != len(sources) {
+			err = fmt.Errorf("resolver of %s.%s returned %d values for %d sources", object.Name, name, len(values), len(sources))
+		}
+
+		if err != nil {
+			e.addError(err, fieldPath)
+
+			for _, result := range results {
+				result.set(f.Key, nil)
+			}
+
+			continue
+		}
+
+		completed := e.completeValues(ctx, definition.Type, values, f.selectionSet(), fieldPath)
+		for i, result := range results {
+			result.set(f.Key, completed[i])
+		}
+	}
+
+	return results
+}
+
+// completeValues serializes scalars and resolves the selections of objects; lists are flattened so their items are completed at
+// once.
+func (e *execution) completeValues(ctx context.Context, t Type, values []any, selections []Selection, path []any) []any {
+	completed := make([]any, len(values))
+
+	switch t := t.(type) {
+	case *Scalar:
+		for i, v := range values {
+			if isNil(v) {
+				continue
+			}
+
+			serialized, err := t.Serialize(v)
+			if err != nil {
+				e.addError(err, path)
+				continue
+			}
+
+			completed[i] = serialized
+		}
+	case *Object:
+		var sources []any
+		var indices []int
+
+		for i, v := range values {
+			if isNil(v) {
+				continue
+			}
+
+			sources = append(sources, v)
+			indices = append(indices, i)
+		}
+
+		if len(sources) == 0 {
+			return completed
+		}
+
+		for i, result := range e.executeSelectionSet(ctx, t, sources, selections, path) {
+			completed[indices[i]] = result
+		}
+	case *List:
+		var items []any
+		bounds := make([][2]int, len(values))
+
+		for i, v := range values {
+			bounds[i] = [2]int{-1, -1}
+
+			if isNil(v) {
+				continue
+			}
+
+			rv := reflect.ValueOf(v)
+			if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+				e.addError(fmt.Errorf("expected a list for %s, got %T", t, v), path)
+				continue
+			}
+
+			bounds[i][0] = len(items)
+			for j := 0; j < rv.Len(); j++ {
+				items = append(items, rv.Index(j).Interface())
+			}
+			bounds[i][1] = len(items)
+		}
+
+		completedItems := e.completeValues(ctx, t.Of, items, selections, path)
+
+		for i, b := range bounds {
+			if b[0] < 0 {
+				continue
+			}
+
+			list := make([]any, b[1]-b[0])
+			copy(list, completedItems[b[0]:b[1]])
+			completed[i] = list
+		}
+	}
+
+	return completed
+}
+
+func isNil(v any) bool {
+	if v == nil {
+		return true
+	}
+
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Pointer, reflect.Map, reflect.Slice, reflect.Interface:
+		return rv.IsNil()
+	}
+
+	return false
+}
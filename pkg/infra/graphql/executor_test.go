@@ -0,0 +1,224 @@
+package graphql_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/psavelis/team-pro/replay-api/pkg/infra/graphql"
+	"github.com/stretchr/testify/assert"
+)
+
+type author struct {
+	ID   string
+	Name string
+}
+
+type book struct {
+	Title    string
+	AuthorID string
+}
+
+// newLibrarySchema returns a schema of books and their authors, counting the calls of the author resolver.
+func newLibrarySchema(t *testing.T, authorCalls *int) *graphql.Schema {
+	authors := map[string]author{"1": {ID: "1", Name: "Ursula"}, "2": {ID: "2", Name: "Italo"}}
+	books := []book{{Title: "Earthsea", AuthorID: "1"}, {Title: "Invisible Cities", AuthorID: "2"}, {Title: "The Dispossessed", AuthorID: "1"}}
+
+	authorType := &graphql.Object{Name: "Author"}
+	bookType := &graphql.Object{Name: "Book"}
+
+	authorType.Fields = map[string]*graphql.FieldDefinition{
+		"id":   {Type: graphql.ID, Resolve: graphql.ResolveEach(func(source any, _ map[string]any) (any, error) { return source.(author).ID, nil })},
+		"name": {Type: graphql.String, Resolve: graphql.ResolveEach(func(source any, _ map[string]any) (any, error) { return source.(author).Name, nil })},
+		"books": {Type: graphql.NewList(bookType), Resolve: graphql.ResolveEach(func(source any, _ map[string]any) (any, error) {
+			var written []book
+			for _, b := range books {
+				if b.AuthorID == source.(author).ID {
+					written = append(written, b)
+				}
+			}
+
+			return written, nil
+		})},
+	}
+
+	bookType.Fields = map[string]*graphql.FieldDefinition{
+		"title": {Type: graphql.String, Resolve: graphql.ResolveEach(func(source any, _ map[string]any) (any, error) { return source.(book).Title, nil })},
+		"author": {Type: authorType, Resolve: func(ctx context.Context, sources []any, args map[string]any) ([]any, error) {
+			*authorCalls++
+
+			values := make([]any, len(sources))
+			for i, source := range sources {
+				values[i] = authors[source.(book).AuthorID]
+			}
+
+			return values, nil
+		}},
+		"isbn": {Type: graphql.String, Resolve: graphql.ResolveEach(func(source any, _ map[string]any) (any, error) {
+			return nil, errors.New("isbn lookup unavailable")
+		})},
+	}
+
+	query := &graphql.Object{
+		Name: "Query",
+		Fields: map[string]*graphql.FieldDefinition{
+			"books": {
+				Type: graphql.NewList(bookType),
+				Args: map[string]*graphql.Scalar{"limit": graphql.Int},
+				Resolve: func(ctx context.Context, sources []any, args map[string]any) ([]any, error) {
+					limit, ok := args["limit"].(int)
+					if !ok || limit > len(books) {
+						limit = len(books)
+					}
+
+					return []any{books[:limit]}, nil
+				},
+			},
+		},
+	}
+
+	schema, err := graphql.NewSchema(query)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return schema
+}
+
+func execute(t *testing.T, schema *graphql.Schema, req graphql.Request) (string, *graphql.Response) {
+	res := schema.Execute(context.Background(), req)
+
+	b, err := json.Marshal(res)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return string(b), res
+}
+
+func TestSchema_Execute(t *testing.T) {
+	authorCalls := 0
+	schema := newLibrarySchema(t, &authorCalls)
+
+	body, res := execute(t, schema, graphql.Request{
+		Query: `
+			# aliases, fragments and directives
+			query Library($limit: Int = 3, $withBooks: Boolean!) {
+				books(limit: $limit) {
+					...bookTitle
+					writer: author {
+						name
+						books @include(if: $withBooks) { title }
+						__typename
+					}
+					author @skip(if: true) { id }
+				}
+			}
+
+			fragment bookTitle on Book { title }
+		`,
+		Variables: map[string]any{"limit": float64(2), "withBooks": false},
+	})
+
+	assert.Empty(t, res.Errors)
+	assert.JSONEq(t, `{"data":{"books":[
+		{"title":"Earthsea","writer":{"name":"Ursula","__typename":"Author"}},
+		{"title":"Invisible Cities","writer":{"name":"Italo","__typename":"Author"}}
+	]}}`, body)
+
+	// the author of every book resolved at once
+	assert.Equal(t, 1, authorCalls)
+}
+
+func TestSchema_Execute_KeepsSelectionOrder(t *testing.T) {
+	schema := newLibrarySchema(t, new(int))
+
+	body, _ := execute(t, schema, graphql.Request{Query: `{ books(limit: 1) { author { name id } title } }`})
+
+	assert.Equal(t, `{"data":{"books":[{"author":{"name":"Ursula","id":"1"},"title":"Earthsea"}]}}`, body)
+}
+
+func TestSchema_Execute_FieldErrors(t *testing.T) {
+	schema := newLibrarySchema(t, new(int))
+
+	body, res := execute(t, schema, graphql.Request{Query: `{ books(limit: 1) { title isbn } }`})
+
+	assert.JSONEq(t, `{
+		"data":{"books":[{"title":"Earthsea","isbn":null}]},
+		"errors":[{"message":"isbn lookup unavailable","path":["books","isbn"]}]
+	}`, body)
+	assert.NotNil(t, res.Data)
+}
+
+func TestSchema_Execute_RequestErrors(t *testing.T) {
+	schema := newLibrarySchema(t, new(int))
+	schema.MaxDepth = 3
+
+	tests := []struct {
+		name    string
+		req     graphql.Request
+		wantErr string
+	}{
+		{name: "syntax", req: graphql.Request{Query: `{ books { title }`}, wantErr: "syntax error"},
+		{name: "unknown field", req: graphql.Request{Query: `{ books { price } }`}, wantErr: `cannot query field "price" on type "Book"`},
+		{name: "introspection", req: graphql.Request{Query: `{ __schema { types { name } } }`}, wantErr: `cannot query field "__schema"`},
+		{name: "mutation", req: graphql.Request{Query: `mutation { books { title } }`}, wantErr: "mutation operations are not supported"},
+		{name: "missing subfields", req: graphql.Request{Query: `{ books }`}, wantErr: "must have a selection of subfields"},
+		{name: "scalar subfields", req: graphql.Request{Query: `{ books { title { length } } }`}, wantErr: "must not have a selection"},
+		{name: "unknown argument", req: graphql.Request{Query: `{ books(first: 1) { title } }`}, wantErr: `unknown argument "first"`},
+		{name: "invalid argument", req: graphql.Request{Query: `{ books(limit: "ten") { title } }`}, wantErr: `invalid argument "limit"`},
+		{name: "undefined variable", req: graphql.Request{Query: `{ books(limit: $n) { title } }`}, wantErr: "variable $n is not defined"},
+		{name: "unknown fragment", req: graphql.Request{Query: `{ books { ...missing } }`}, wantErr: `unknown fragment "missing"`},
+		{name: "fragment type", req: graphql.Request{Query: `{ books { ...a } } fragment a on Author { name }`}, wantErr: `cannot be spread on "Book"`},
+		{name: "depth", req: graphql.Request{Query: `{ books { author { books { author { name } } } } }`}, wantErr: "maximum depth of 3"},
+		{name: "operation name", req: graphql.Request{Query: `query A { books { title } } query B { books { title } }`}, wantErr: "operationName is required"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, res := execute(t, schema, tt.req)
+
+			assert.Nil(t, res.Data)
+			if assert.Len(t, res.Errors, 1) {
+				assert.Contains(t, res.Errors[0].Message, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSchema_Execute_FragmentCycle(t *testing.T) {
+	schema := newLibrarySchema(t, new(int))
+
+	body, _ := execute(t, schema, graphql.Request{Query: `{ books(limit: 1) { ...a } } fragment a on Book { title ...b } fragment b on Book { ...a }`})
+
+	assert.Equal(t, `{"data":{"books":[{"title":"Earthsea"}]}}`, body)
+}
+
+func TestParse(t *testing.T) {
+	doc, err := graphql.Parse(`query Q($id: [ID!]! = ["a"]) { a: node(id: $id, n: -1.5e3, s: "x\"A", e: ENUM, o: {k: [1, null]}) { ... on Node @skip(if: false) { id } } }`)
+	if !assert.NoError(t, err) || !assert.Len(t, doc.Operations, 1) {
+		return
+	}
+
+	op := doc.Operations[0]
+	assert.Equal(t, "Q", op.Name)
+	assert.Equal(t, "[ID!]!", op.Variables[0].Type)
+
+	field := op.SelectionSet[0].(*graphql.Field)
+	assert.Equal(t, "a", field.ResponseKey())
+	assert.Equal(t, graphql.Variable{Name: "id"}, field.Arguments["id"])
+	assert.Equal(t, graphql.FloatValue{Value: -1500}, field.Arguments["n"])
+	assert.Equal(t, graphql.StringValue{Value: `x"A`}, field.Arguments["s"])
+	assert.Equal(t, graphql.EnumValue{Value: "ENUM"}, field.Arguments["e"])
+	assert.Equal(t, graphql.ObjectValue{Fields: map[string]graphql.Value{"k": graphql.ListValue{Values: []graphql.Value{graphql.IntValue{Value: 1}, graphql.NullValue{}}}}}, field.Arguments["o"])
+
+	fragment := field.SelectionSet[0].(*graphql.InlineFragment)
+	assert.Equal(t, "Node", fragment.TypeCondition)
+	assert.Equal(t, "skip", fragment.Directives[0].Name)
+
+	for _, invalid := range []string{``, `{}`, `{ a(x: $) }`, `{ a`, `{ a(x: 1.) }`, `{ a(x: "b) }`, `fragment on on A { a }`} {
+		_, err := graphql.Parse(invalid)
+		assert.Error(t, err, invalid)
+	}
+}
@@ -0,0 +1,214 @@
+package graphql
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenPunctuator
+	tokenName
+	tokenInt
+	tokenFloat
+	tokenString
+)
+
+type token struct {
+	Kind  tokenKind
+	Value string
+	Pos   int
+}
+
+func (t token) String() string {
+	if t.Kind == tokenEOF {
+		return "<EOF>"
+	}
+
+	return fmt.Sprintf("%q", t.Value)
+}
+
+// tokenize splits a GraphQL document in tokens. Commas, whitespace and comments are ignored (as in the spec), block strings aren't
+// supported.
+func tokenize(source string) ([]token, error) {
+	var tokens []token
+
+	for i := 0; i < len(source); {
+		c := source[i]
+
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r' || c == ',':
+			i++
+		case c == '#':
+			for i < len(source) && source[i] != '\n' && source[i] != '\r' {
+				i++
+			}
+		case c == '.':
+			if !strings.HasPrefix(source[i:], "...") {
+				return nil, fmt.Errorf("unexpected character %q at %d", c, i)
+			}
+
+			tokens = append(tokens, token{Kind: tokenPunctuator, Value: "...", Pos: i})
+			i += 3
+		case strings.IndexByte("!$&()[]{}:=@|", c) >= 0:
+			tokens = append(tokens, token{Kind: tokenPunctuator, Value: string(c), Pos: i})
+			i++
+		case isNameStart(c):
+			start := i
+			for i < len(source) && (isNameStart(source[i]) || isDigit(source[i])) {
+				i++
+			}
+
+			tokens = append(tokens, token{Kind: tokenName, Value: source[start:i], Pos: start})
+		case c == '-' || isDigit(c):
+			t, err := readNumber(source, i)
+			if err != nil {
+				return nil, err
+			}
+
+			tokens = append(tokens, t)
+			i += len(t.Value)
+		case c == '"':
+			t, n, err := readString(source, i)
+			if err != nil {
+				return nil, err
+			}
+
+			tokens = append(tokens, t)
+			i += n
+		default:
+			return nil, fmt.Errorf("unexpected character %q at %d", c, i)
+		}
+	}
+
+	return append(tokens, token{Kind: tokenEOF, Pos: len(source)}), nil
+}
+
+func readNumber(source string, start int) (token, error) {
+	i := start
+	kind := tokenInt
+
+	if source[i] == '-' {
+		i++
+	}
+
+	digits := i
+	for i < len(source) && isDigit(source[i]) {
+		i++
+	}
+
+	if i == digits {
+		return token{}, fmt.Errorf("invalid number at %d", start)
+	}
+
+	if i < len(source) && source[i] == '.' {
+		kind = tokenFloat
+		i++
+
+		fraction := i
+		for i < len(source) && isDigit(source[i]) {
+			i++
+		}
+
+		if i == fraction {
+			return token{}, fmt.Errorf("invalid number at %d", start)
+		}
+	}
+
+	if i < len(source) && (source[i] == 'e' || source[i] == 'E') {
+		kind = tokenFloat
+		i++
+
+		if i < len(source) && (source[i] == '+' || source[i] == '-') {
+			i++
+		}
+
+		exponent := i
+		for i < len(source) && isDigit(source[i]) {
+			i++
+		}
+
+		if i == exponent {
+			return token{}, fmt.Errorf("invalid number at %d", start)
+		}
+	}
+
+	if i < len(source) && (isNameStart(source[i]) || source[i] == '.') {
+		return token{}, fmt.Errorf("invalid number at %d", start)
+	}
+
+	return token{Kind: kind, Value: source[start:i], Pos: start}, nil
+}
+
+// readString reads the quoted string at start, returning the token with the unescaped value and the number of bytes read.
+func readString(source string, start int) (token, int, error) {
+	if strings.HasPrefix(source[start:], `"""`) {
+		return token{}, 0, fmt.Errorf("block strings are not supported (at %d)", start)
+	}
+
+	var b strings.Builder
+
+	for i := start + 1; i < len(source); {
+		c := source[i]
+
+		switch {
+		case c == '"':
+			return token{Kind: tokenString, Value: b.String(), Pos: start}, i + 1 - start, nil
+		case c == '\n' || c == '\r':
+			return token{}, 0, fmt.Errorf("unterminated string at %d", start)
+		case c == '\\':
+			if i+1 >= len(source) {
+				return token{}, 0, fmt.Errorf("unterminated string at %d", start)
+			}
+
+			switch source[i+1] {
+			case '"', '\\', '/':
+				b.WriteByte(source[i+1])
+			case 'b':
+				b.WriteByte('\b')
+			case 'f':
+				b.WriteByte('\f')
+			case 'n':
+				b.WriteByte('\n')
+			case 'r':
+				b.WriteByte('\r')
+			case 't':
+				b.WriteByte('\t')
+			case 'u':
+				if i+6 > len(source) {
+					return token{}, 0, fmt.Errorf("invalid unicode escape at %d", i)
+				}
+
+				var r rune
+				_, err := fmt.Sscanf(source[i+2:i+6], "%04x", &r)
+				if err != nil {
+					return token{}, 0, fmt.Errorf("invalid unicode escape at %d", i)
+				}
+
+				b.WriteRune(r)
+				i += 4
+			default:
+				return token{}, 0, fmt.Errorf("invalid escape sequence at %d", i)
+			}
+
+			i += 2
+		default:
+			r, size := utf8.DecodeRuneInString(source[i:])
+			b.WriteRune(r)
+			i += size
+		}
+	}
+
+	return token{}, 0, fmt.Errorf("unterminated string at %d", start)
+}
+
+func isNameStart(c byte) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
@@ -0,0 +1,579 @@
+package graphql
+
+import (
+	"fmt"
+	"strconv"
+)
+
+type Document struct {
+	Operations []*Operation
+	Fragments  map[string]*Fragment
+}
+
+type Operation struct {
+	Type         string // query, mutation or subscription
+	Name         string
+	Variables    []*VariableDefinition
+	SelectionSet []Selection
+}
+
+type VariableDefinition struct {
+	Name         string
+	Type         string // as declared (ie: "[ID!]!"), variables are coerced by the arguments they're used in
+	DefaultValue Value
+}
+
+type Fragment struct {
+	Name          string
+	TypeCondition string
+	Directives    []*Directive
+	SelectionSet  []Selection
+}
+
+// Selection is a *Field, a *FragmentSpread or an *InlineFragment.
+type Selection interface {
+	selection()
+}
+
+type Field struct {
+	Alias        string
+	Name         string
+	Arguments    map[string]Value
+	Directives   []*Directive
+	SelectionSet []Selection
+}
+
+type FragmentSpread struct {
+	Name       string
+	Directives []*Directive
+}
+
+type InlineFragment struct {
+	TypeCondition string
+	Directives    []*Directive
+	SelectionSet  []Selection
+}
+
+func (*Field) selection()          {}
+func (*FragmentSpread) selection() {}
+func (*InlineFragment) selection() {}
+
+// ResponseKey is the key of the field in the response: its alias, when aliased.
+func (f *Field) ResponseKey() string {
+	if f.Alias != "" {
+		return f.Alias
+	}
+
+	return f.Name
+}
+
+type Directive struct {
+	Name      string
+	Arguments map[string]Value
+}
+
+// Value is an input value literal: Variable, IntValue, FloatValue, StringValue, BooleanValue, NullValue, EnumValue, ListValue or
+// ObjectValue.
+type Value interface {
+	value()
+}
+
+type (
+	Variable     struct{ Name string }
+	IntValue     struct{ Value int64 }
+	FloatValue   struct{ Value float64 }
+	StringValue  struct{ Value string }
+	BooleanValue struct{ Value bool }
+	NullValue    struct{}
+	EnumValue    struct{ Value string }
+	ListValue    struct{ Values []Value }
+	ObjectValue  struct{ Fields map[string]Value }
+)
+
+func (Variable) value()     {}
+func (IntValue) value()     {}
+func (FloatValue) value()   {}
+func (StringValue) value()  {}
+func (BooleanValue) value() {}
+func (NullValue) value()    {}
+func (EnumValue) value()    {}
+func (ListValue) value()    {}
+func (ObjectValue) value()  {}
+
+type parser struct {
+	tokens []token
+	pos    int
+}
+
+// Parse parses an executable GraphQL document (operations and fragments; type system definitions aren't accepted).
+func Parse(source string) (*Document, error) {
+	tokens, err := tokenize(source)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &parser{tokens: tokens}
+	doc := &Document{Fragments: make(map[string]*Fragment)}
+
+	for p.peek().Kind != tokenEOF {
+		t := p.peek()
+
+		switch {
+		case t.Kind == tokenPunctuator && t.Value == "{":
+			selectionSet, err := p.parseSelectionSet()
+			if err != nil {
+				return nil, err
+			}
+
+			doc.Operations = append(doc.Operations, &Operation{Type: "query", SelectionSet: selectionSet})
+		case t.Kind == tokenName && (t.Value == "query" || t.Value == "mutation" || t.Value == "subscription"):
+			op, err := p.parseOperation()
+			if err != nil {
+				return nil, err
+			}
+
+			doc.Operations = append(doc.Operations, op)
+		case t.Kind == tokenName && t.Value == "fragment":
+			fragment, err := p.parseFragment()
+			if err != nil {
+				return nil, err
+			}
+
+			if _, ok := doc.Fragments[fragment.Name]; ok {
+				return nil, fmt.Errorf("there can be only one fragment named %q", fragment.Name)
+			}
+
+			doc.Fragments[fragment.Name] = fragment
+		default:
+			return nil, p.unexpected(t)
+		}
+	}
+
+	if len(doc.Operations) == 0 {
+		return nil, fmt.Errorf("document has no operations")
+	}
+
+	return doc, nil
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() token {
+	t := p.tokens[p.pos]
+	if t.Kind != tokenEOF {
+		p.pos++
+	}
+
+	return t
+}
+
+func (p *parser) peekPunctuator(value string) bool {
+	t := p.peek()
+	return t.Kind == tokenPunctuator && t.Value == value
+}
+
+// skipPunctuator consumes the punctuator when it's the next token.
+func (p *parser) skipPunctuator(value string) bool {
+	if p.peekPunctuator(value) {
+		p.pos++
+		return true
+	}
+
+	return false
+}
+
+func (p *parser) expectPunctuator(value string) error {
+	if !p.skipPunctuator(value) {
+		return fmt.Errorf("expected %q, found %v at %d", value, p.peek(), p.peek().Pos)
+	}
+
+	return nil
+}
+
+func (p *parser) expectName() (string, error) {
+	t := p.next()
+	if t.Kind != tokenName {
+		return "", p.unexpected(t)
+	}
+
+	return t.Value, nil
+}
+
+func (p *parser) unexpected(t token) error {
+	return fmt.Errorf("unexpected %v at %d", t, t.Pos)
+}
+
+func (p *parser) parseOperation() (*Operation, error) {
+	op := &Operation{Type: p.next().Value}
+
+	if p.peek().Kind == tokenName {
+		op.Name = p.next().Value
+	}
+
+	if p.skipPunctuator("(") {
+		for !p.skipPunctuator(")") {
+			definition, err := p.parseVariableDefinition()
+			if err != nil {
+				return nil, err
+			}
+
+			op.Variables = append(op.Variables, definition)
+		}
+	}
+
+	// operation directives are parsed, but ignored
+	_, err := p.parseDirectives()
+	if err != nil {
+		return nil, err
+	}
+
+	op.SelectionSet, err = p.parseSelectionSet()
+	if err != nil {
+		return nil, err
+	}
+
+	return op, nil
+}
+
+func (p *parser) parseVariableDefinition() (*VariableDefinition, error) {
+	err := p.expectPunctuator("$")
+	if err != nil {
+		return nil, err
+	}
+
+	definition := &VariableDefinition{}
+
+	definition.Name, err = p.expectName()
+	if err != nil {
+		return nil, err
+	}
+
+	err = p.expectPunctuator(":")
+	if err != nil {
+		return nil, err
+	}
+
+	definition.Type, err = p.parseTypeReference()
+	if err != nil {
+		return nil, err
+	}
+
+	if p.skipPunctuator("=") {
+		definition.DefaultValue, err = p.parseValue(true)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return definition, nil
+}
+
+func (p *parser) parseTypeReference() (string, error) {
+	var typeRef string
+
+	if p.skipPunctuator("[") {
+		inner, err := p.parseTypeReference()
+		if err != nil {
+			return "", err
+		}
+
+		err = p.expectPunctuator("]")
+		if err != nil {
+			return "", err
+		}
+
+		typeRef = "[" + inner + "]"
+	} else {
+		name, err := p.expectName()
+		if err != nil {
+			return "", err
+		}
+
+		typeRef = name
+	}
+
+	if p.skipPunctuator("!") {
+		typeRef += "!"
+	}
+
+	return typeRef, nil
+}
+
+func (p *parser) parseFragment() (*Fragment, error) {
+	p.next() // fragment
+
+	name, err := p.expectName()
+	if err != nil {
+		return nil, err
+	}
+
+	if name == "on" {
+		return nil, fmt.Errorf("fragment cannot be named \"on\"")
+	}
+
+	if t := p.next(); t.Kind != tokenName || t.Value != "on" {
+		return nil, p.unexpected(t)
+	}
+
+	fragment := &Fragment{Name: name}
+
+	fragment.TypeCondition, err = p.expectName()
+	if err != nil {
+		return nil, err
+	}
+
+	fragment.Directives, err = p.parseDirectives()
+	if err != nil {
+		return nil, err
+	}
+
+	fragment.SelectionSet, err = p.parseSelectionSet()
+	if err != nil {
+		return nil, err
+	}
+
+	return fragment, nil
+}
+
+func (p *parser) parseSelectionSet() ([]Selection, error) {
+	err := p.expectPunctuator("{")
+	if err != nil {
+		return nil, err
+	}
+
+	var selections []Selection
+
+	for !p.skipPunctuator("}") {
+		var selection Selection
+
+		if p.skipPunctuator("...") {
+			selection, err = p.parseFragmentSelection()
+		} else {
+			selection, err = p.parseField()
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		selections = append(selections, selection)
+	}
+
+	if len(selections) == 0 {
+		return nil, fmt.Errorf("empty selection set at %d", p.tokens[p.pos-1].Pos)
+	}
+
+	return selections, nil
+}
+
+func (p *parser) parseFragmentSelection() (Selection, error) {
+	t := p.peek()
+
+	if t.Kind == tokenName && t.Value != "on" {
+		p.next()
+
+		directives, err := p.parseDirectives()
+		if err != nil {
+			return nil, err
+		}
+
+		return &FragmentSpread{Name: t.Value, Directives: directives}, nil
+	}
+
+	fragment := &InlineFragment{}
+
+	var err error
+	if t.Kind == tokenName {
+		p.next() // on
+
+		fragment.TypeCondition, err = p.expectName()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	fragment.Directives, err = p.parseDirectives()
+	if err != nil {
+		return nil, err
+	}
+
+	fragment.SelectionSet, err = p.parseSelectionSet()
+	if err != nil {
+		return nil, err
+	}
+
+	return fragment, nil
+}
+
+func (p *parser) parseField() (*Field, error) {
+	name, err := p.expectName()
+	if err != nil {
+		return nil, err
+	}
+
+	field := &Field{Name: name}
+
+	if p.skipPunctuator(":") {
+		field.Alias = name
+
+		field.Name, err = p.expectName()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	field.Arguments, err = p.parseArguments(false)
+	if err != nil {
+		return nil, err
+	}
+
+	field.Directives, err = p.parseDirectives()
+	if err != nil {
+		return nil, err
+	}
+
+	if p.peekPunctuator("{") {
+		field.SelectionSet, err = p.parseSelectionSet()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return field, nil
+}
+
+func (p *parser) parseArguments(constant bool) (map[string]Value, error) {
+	args := make(map[string]Value)
+
+	if !p.skipPunctuator("(") {
+		return args, nil
+	}
+
+	for !p.skipPunctuator(")") {
+		name, err := p.expectName()
+		if err != nil {
+			return nil, err
+		}
+
+		if _, ok := args[name]; ok {
+			return nil, fmt.Errorf("there can be only one argument named %q", name)
+		}
+
+		err = p.expectPunctuator(":")
+		if err != nil {
+			return nil, err
+		}
+
+		args[name], err = p.parseValue(constant)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return args, nil
+}
+
+func (p *parser) parseDirectives() ([]*Directive, error) {
+	var directives []*Directive
+
+	for p.skipPunctuator("@") {
+		name, err := p.expectName()
+		if err != nil {
+			return nil, err
+		}
+
+		args, err := p.parseArguments(false)
+		if err != nil {
+			return nil, err
+		}
+
+		directives = append(directives, &Directive{Name: name, Arguments: args})
+	}
+
+	return directives, nil
+}
+
+// parseValue parses an input value; constant values (ie: variable defaults) can't reference variables.
+func (p *parser) parseValue(constant bool) (Value, error) {
+	t := p.next()
+
+	switch t.Kind {
+	case tokenPunctuator:
+		switch t.Value {
+		case "$":
+			if constant {
+				return nil, p.unexpected(t)
+			}
+
+			name, err := p.expectName()
+			if err != nil {
+				return nil, err
+			}
+
+			return Variable{Name: name}, nil
+		case "[":
+			list := ListValue{Values: []Value{}}
+
+			for !p.skipPunctuator("]") {
+				v, err := p.parseValue(constant)
+				if err != nil {
+					return nil, err
+				}
+
+				list.Values = append(list.Values, v)
+			}
+
+			return list, nil
+		case "{":
+			object := ObjectValue{Fields: make(map[string]Value)}
+
+			for !p.skipPunctuator("}") {
+				name, err := p.expectName()
+				if err != nil {
+					return nil, err
+				}
+
+				err = p.expectPunctuator(":")
+				if err != nil {
+					return nil, err
+				}
+
+				object.Fields[name], err = p.parseValue(constant)
+				if err != nil {
+					return nil, err
+				}
+			}
+
+			return object, nil
+		}
+	case tokenInt:
+		n, err := strconv.ParseInt(t.Value, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid int %s at %d", t.Value, t.Pos)
+		}
+
+		return IntValue{Value: n}, nil
+	case tokenFloat:
+		f, err := strconv.ParseFloat(t.Value, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid float %s at %d", t.Value, t.Pos)
+		}
+
+		return FloatValue{Value: f}, nil
+	case tokenString:
+		return StringValue{Value: t.Value}, nil
+	case tokenName:
+		switch t.Value {
+		case "true", "false":
+			return BooleanValue{Value: t.Value == "true"}, nil
+		case "null":
+			return NullValue{}, nil
+		default:
+			return EnumValue{Value: t.Value}, nil
+		}
+	}
+
+	return nil, p.unexpected(t)
+}
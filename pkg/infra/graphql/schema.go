@@ -0,0 +1,242 @@
+package graphql
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+)
+
+// Type is a *Scalar, an *Object or a *List.
+type Type interface {
+	String() string
+}
+
+type Scalar struct {
+	Name string
+	// Serialize converts a resolved value to its JSON representation.
+	Serialize func(value any) (any, error)
+	// ParseValue coerces an argument (literal or JSON variable) to the Go value handed to the resolvers.
+	ParseValue func(value any) (any, error)
+}
+
+func (s *Scalar) String() string {
+	return s.Name
+}
+
+type Object struct {
+	Name   string
+	Fields map[string]*FieldDefinition
+}
+
+func (o *Object) String() string {
+	return o.Name
+}
+
+type List struct {
+	Of Type
+}
+
+func (l *List) String() string {
+	return "[" + l.Of.String() + "]"
+}
+
+func NewList(of Type) *List {
+	return &List{Of: of}
+}
+
+// BatchResolveFunc resolves a field for every source (parent value) of the same level at once, returning a value per source (in
+// the same order). Resolvers backed by the database batch their lookups here: a field selected under a list is resolved once, not
+// once per item.
+type BatchResolveFunc func(ctx context.Context, sources []any, args map[string]any) ([]any, error)
+
+type FieldDefinition struct {
+	Type    Type
+	Args    map[string]*Scalar
+	Resolve BatchResolveFunc
+}
+
+// ResolveEach adapts a resolver of a single source to a BatchResolveFunc, for fields read from the source itself (no I/O).
+func ResolveEach(resolve func(source any, args map[string]any) (any, error)) BatchResolveFunc {
+	return func(ctx context.Context, sources []any, args map[string]any) ([]any, error) {
+		values := make([]any, len(sources))
+
+		for i, source := range sources {
+			v, err := resolve(source, args)
+			if err != nil {
+				return nil, err
+			}
+
+			values[i] = v
+		}
+
+		return values, nil
+	}
+}
+
+type Schema struct {
+	Query *Object
+	// MaxDepth limits the nesting of selection sets in a query (0 means DefaultMaxDepth).
+	MaxDepth int
+}
+
+const DefaultMaxDepth = 10
+
+func NewSchema(query *Object) (*Schema, error) {
+	visited := make(map[*Object]bool)
+
+	err := validateObject(query, visited)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Schema{Query: query, MaxDepth: DefaultMaxDepth}, nil
+}
+
+func validateObject(o *Object, visited map[*Object]bool) error {
+	if visited[o] {
+		return nil
+	}
+
+	visited[o] = true
+
+	for name, field := range o.Fields {
+		if field.Type == nil || field.Resolve == nil {
+			return fmt.Errorf("field %s.%s must have a type and a resolver", o.Name, name)
+		}
+
+		if obj, ok := namedType(field.Type).(*Object); ok {
+			err := validateObject(obj, visited)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// namedType unwraps the lists of t.
+func namedType(t Type) Type {
+	for {
+		l, ok := t.(*List)
+		if !ok {
+			return t
+		}
+
+		t = l.Of
+	}
+}
+
+var String = &Scalar{
+	Name: "String",
+	Serialize: func(value any) (any, error) {
+		switch v := value.(type) {
+		case string:
+			return v, nil
+		case time.Time:
+			return v.UTC().Format(time.RFC3339), nil
+		case fmt.Stringer:
+			return v.String(), nil
+		default:
+			return fmt.Sprint(v), nil
+		}
+	},
+	ParseValue: func(value any) (any, error) {
+		s, ok := value.(string)
+		if !ok {
+			return nil, fmt.Errorf("String cannot represent %v", value)
+		}
+
+		return s, nil
+	},
+}
+
+// ID is serialized as a string (ie: uuids).
+var ID = &Scalar{
+	Name:       "ID",
+	Serialize:  String.Serialize,
+	ParseValue: String.ParseValue,
+}
+
+var Int = &Scalar{
+	Name: "Int",
+	Serialize: func(value any) (any, error) {
+		switch v := value.(type) {
+		case int:
+			return v, nil
+		case int32:
+			return int(v), nil
+		case int64:
+			return int(v), nil
+		case uint:
+			return int(v), nil
+		default:
+			return nil, fmt.Errorf("Int cannot represent %v", value)
+		}
+	},
+	ParseValue: func(value any) (any, error) {
+		switch v := value.(type) {
+		case int64:
+			if v > math.MaxInt32 || v < math.MinInt32 {
+				return nil, fmt.Errorf("Int cannot represent %v", value)
+			}
+
+			return int(v), nil
+		case float64: // JSON variables
+			if v != math.Trunc(v) || v > math.MaxInt32 || v < math.MinInt32 {
+				return nil, fmt.Errorf("Int cannot represent %v", value)
+			}
+
+			return int(v), nil
+		default:
+			return nil, fmt.Errorf("Int cannot represent %v", value)
+		}
+	},
+}
+
+var Float = &Scalar{
+	Name: "Float",
+	Serialize: func(value any) (any, error) {
+		switch v := value.(type) {
+		case float64:
+			return v, nil
+		case float32:
+			return float64(v), nil
+		case int:
+			return float64(v), nil
+		default:
+			return nil, fmt.Errorf("Float cannot represent %v", value)
+		}
+	},
+	ParseValue: func(value any) (any, error) {
+		switch v := value.(type) {
+		case float64:
+			return v, nil
+		case int64:
+			return float64(v), nil
+		default:
+			return nil, fmt.Errorf("Float cannot represent %v", value)
+		}
+	},
+}
+
+var Boolean = &Scalar{
+	Name: "Boolean",
+	Serialize: func(value any) (any, error) {
+		b, ok := value.(bool)
+		if !ok {
+			return nil, fmt.Errorf("Boolean cannot represent %v", value)
+		}
+
+		return b, nil
+	},
+	ParseValue: func(value any) (any, error) {
+		b, ok := value.(bool)
+		if !ok {
+			return nil, fmt.Errorf("Boolean cannot represent %v", value)
+		}
+
+		return b, nil
+	},
+}
@@ -2,6 +2,7 @@ package ioc
 
 import (
 	"context"
+	"fmt"
 	"log/slog"
 	"os"
 
@@ -16,10 +17,51 @@ import (
 	db "github.com/psavelis/team-pro/replay-api/pkg/infra/db/mongodb"
 
 	// messageBroker (kafka/rabbit)
+	"github.com/psavelis/team-pro/replay-api/pkg/infra/events/rabbitmq"
+	"github.com/psavelis/team-pro/replay-api/pkg/infra/websocket"
 
 	// encryption
 	encryption "github.com/psavelis/team-pro/replay-api/pkg/infra/crypto"
 
+	// fault injection (dev/test)
+	"github.com/psavelis/team-pro/replay-api/pkg/infra/chaos"
+	"github.com/psavelis/team-pro/replay-api/pkg/infra/fxrates"
+
+	// alerting
+	"github.com/psavelis/team-pro/replay-api/pkg/infra/alerts"
+
+	// magic links, notification emails
+	"github.com/psavelis/team-pro/replay-api/pkg/infra/mail"
+
+	// notification discord webhooks
+	"github.com/psavelis/team-pro/replay-api/pkg/infra/discord"
+
+	// push notifications (fcm, apns)
+	"github.com/psavelis/team-pro/replay-api/pkg/infra/push"
+
+	// blob storage
+	"github.com/psavelis/team-pro/replay-api/pkg/infra/blob/s3"
+
+	// steam game coordinator (match share codes), faceit
+	"github.com/psavelis/team-pro/replay-api/pkg/infra/clients"
+
+	// matchmaking (ratings, matcher leader election, voice)
+	"github.com/psavelis/team-pro/replay-api/pkg/infra/ratings"
+	"github.com/psavelis/team-pro/replay-api/pkg/infra/scheduler"
+	"github.com/psavelis/team-pro/replay-api/pkg/infra/voice"
+
+	// seasons (active season of the matches and ratings)
+	"github.com/psavelis/team-pro/replay-api/pkg/infra/seasons"
+
+	// badges (facts of the replays and matches)
+	"github.com/psavelis/team-pro/replay-api/pkg/infra/badges"
+
+	// player cards (rendering)
+	"github.com/psavelis/team-pro/replay-api/pkg/infra/cards"
+
+	// public api (stats cache) and player cards
+	"github.com/psavelis/team-pro/replay-api/pkg/infra/cache"
+
 	// container
 	container "github.com/golobby/container/v3"
 
@@ -27,18 +69,68 @@ import (
 
 	// ports
 	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	analytics_in "github.com/psavelis/team-pro/replay-api/pkg/domain/analytics/ports/in"
+	analytics_out "github.com/psavelis/team-pro/replay-api/pkg/domain/analytics/ports/out"
+	analytics_services "github.com/psavelis/team-pro/replay-api/pkg/domain/analytics/services"
+	badge_in "github.com/psavelis/team-pro/replay-api/pkg/domain/badge/ports/in"
+	badge_out "github.com/psavelis/team-pro/replay-api/pkg/domain/badge/ports/out"
+	badge_services "github.com/psavelis/team-pro/replay-api/pkg/domain/badge/services"
+	consent_in "github.com/psavelis/team-pro/replay-api/pkg/domain/consent/ports/in"
+	consent_out "github.com/psavelis/team-pro/replay-api/pkg/domain/consent/ports/out"
+	customfield_in "github.com/psavelis/team-pro/replay-api/pkg/domain/customfield/ports/in"
+	customfield_out "github.com/psavelis/team-pro/replay-api/pkg/domain/customfield/ports/out"
+	customfield_services "github.com/psavelis/team-pro/replay-api/pkg/domain/customfield/services"
+	discord_in "github.com/psavelis/team-pro/replay-api/pkg/domain/discord/ports/in"
+	discord_out "github.com/psavelis/team-pro/replay-api/pkg/domain/discord/ports/out"
+	discord_use_cases "github.com/psavelis/team-pro/replay-api/pkg/domain/discord/use_cases"
+	email_in "github.com/psavelis/team-pro/replay-api/pkg/domain/email/ports/in"
+	email_out "github.com/psavelis/team-pro/replay-api/pkg/domain/email/ports/out"
+	export_in "github.com/psavelis/team-pro/replay-api/pkg/domain/export/ports/in"
+	export_out "github.com/psavelis/team-pro/replay-api/pkg/domain/export/ports/out"
+	export_services "github.com/psavelis/team-pro/replay-api/pkg/domain/export/services"
+	fx_in "github.com/psavelis/team-pro/replay-api/pkg/domain/fx/ports/in"
+	fx_out "github.com/psavelis/team-pro/replay-api/pkg/domain/fx/ports/out"
+	fx_use_cases "github.com/psavelis/team-pro/replay-api/pkg/domain/fx/use_cases"
 	google_in "github.com/psavelis/team-pro/replay-api/pkg/domain/google/ports/in"
 	google_out "github.com/psavelis/team-pro/replay-api/pkg/domain/google/ports/out"
 	google_use_cases "github.com/psavelis/team-pro/replay-api/pkg/domain/google/use_cases"
+	matchmaking_in "github.com/psavelis/team-pro/replay-api/pkg/domain/matchmaking/ports/in"
+	matchmaking_out "github.com/psavelis/team-pro/replay-api/pkg/domain/matchmaking/ports/out"
+	matchmaking_services "github.com/psavelis/team-pro/replay-api/pkg/domain/matchmaking/services"
+	matchmaking_use_cases "github.com/psavelis/team-pro/replay-api/pkg/domain/matchmaking/use_cases"
+	notification_in "github.com/psavelis/team-pro/replay-api/pkg/domain/notification/ports/in"
+	notification_out "github.com/psavelis/team-pro/replay-api/pkg/domain/notification/ports/out"
+	notification_services "github.com/psavelis/team-pro/replay-api/pkg/domain/notification/services"
+	public_in "github.com/psavelis/team-pro/replay-api/pkg/domain/public/ports/in"
+	public_out "github.com/psavelis/team-pro/replay-api/pkg/domain/public/ports/out"
+	public_use_cases "github.com/psavelis/team-pro/replay-api/pkg/domain/public/use_cases"
+	quality_in "github.com/psavelis/team-pro/replay-api/pkg/domain/quality/ports/in"
+	quality_out "github.com/psavelis/team-pro/replay-api/pkg/domain/quality/ports/out"
+	quality_services "github.com/psavelis/team-pro/replay-api/pkg/domain/quality/services"
+	rating_in "github.com/psavelis/team-pro/replay-api/pkg/domain/rating/ports/in"
+	rating_out "github.com/psavelis/team-pro/replay-api/pkg/domain/rating/ports/out"
+	rating_services "github.com/psavelis/team-pro/replay-api/pkg/domain/rating/services"
+	recap_in "github.com/psavelis/team-pro/replay-api/pkg/domain/recap/ports/in"
+	recap_out "github.com/psavelis/team-pro/replay-api/pkg/domain/recap/ports/out"
 	metadata "github.com/psavelis/team-pro/replay-api/pkg/domain/replay/services/metadata"
+	riot_in "github.com/psavelis/team-pro/replay-api/pkg/domain/riot/ports/in"
+	riot_out "github.com/psavelis/team-pro/replay-api/pkg/domain/riot/ports/out"
+	riot_use_cases "github.com/psavelis/team-pro/replay-api/pkg/domain/riot/use_cases"
+	season_in "github.com/psavelis/team-pro/replay-api/pkg/domain/season/ports/in"
+	season_out "github.com/psavelis/team-pro/replay-api/pkg/domain/season/ports/out"
+	season_services "github.com/psavelis/team-pro/replay-api/pkg/domain/season/services"
+	season_use_cases "github.com/psavelis/team-pro/replay-api/pkg/domain/season/use_cases"
 	squad_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/squad/entities"
 	squad_in "github.com/psavelis/team-pro/replay-api/pkg/domain/squad/ports/in"
 	squad_out "github.com/psavelis/team-pro/replay-api/pkg/domain/squad/ports/out"
 	squad_services "github.com/psavelis/team-pro/replay-api/pkg/domain/squad/services"
+	squad_usecases "github.com/psavelis/team-pro/replay-api/pkg/domain/squad/usecases"
 
 	replay_in "github.com/psavelis/team-pro/replay-api/pkg/domain/replay/ports/in"
 	replay_out "github.com/psavelis/team-pro/replay-api/pkg/domain/replay/ports/out"
 
+	faceit_in "github.com/psavelis/team-pro/replay-api/pkg/domain/faceit/ports/in"
+	faceit_out "github.com/psavelis/team-pro/replay-api/pkg/domain/faceit/ports/out"
 	steam_in "github.com/psavelis/team-pro/replay-api/pkg/domain/steam/ports/in"
 	steam_out "github.com/psavelis/team-pro/replay-api/pkg/domain/steam/ports/out"
 	steam_query_services "github.com/psavelis/team-pro/replay-api/pkg/domain/steam/services"
@@ -48,16 +140,41 @@ import (
 	iam_query_services "github.com/psavelis/team-pro/replay-api/pkg/domain/iam/services"
 
 	// domain
+	analytics_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/analytics/entities"
+	badge_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/badge/entities"
+	consent_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/consent/entities"
+	customfield_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/customfield/entities"
+	discord_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/discord/entities"
+	email_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/email/entities"
+	export_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/export/entities"
 	google_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/google/entities"
 	iam_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/iam/entities"
+	matchmaking_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/matchmaking/entities"
+	notification_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/notification/entities"
+	quality_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/quality/entities"
+	rating_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/rating/entities"
+	recap_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/recap/entities"
 	replay_entity "github.com/psavelis/team-pro/replay-api/pkg/domain/replay/entities"
+	riot_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/riot/entities"
+	season_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/season/entities"
 	steam_entity "github.com/psavelis/team-pro/replay-api/pkg/domain/steam/entities"
 
 	// app
 	cs_app "github.com/psavelis/team-pro/replay-api/pkg/app/cs"
 
 	// usecases
+	analytics_use_cases "github.com/psavelis/team-pro/replay-api/pkg/domain/analytics/use_cases"
+	badge_use_cases "github.com/psavelis/team-pro/replay-api/pkg/domain/badge/use_cases"
+	consent_use_cases "github.com/psavelis/team-pro/replay-api/pkg/domain/consent/use_cases"
+	customfield_use_cases "github.com/psavelis/team-pro/replay-api/pkg/domain/customfield/use_cases"
+	email_use_cases "github.com/psavelis/team-pro/replay-api/pkg/domain/email/use_cases"
+	export_use_cases "github.com/psavelis/team-pro/replay-api/pkg/domain/export/use_cases"
+	faceit_use_cases "github.com/psavelis/team-pro/replay-api/pkg/domain/faceit/use_cases"
 	iam_use_cases "github.com/psavelis/team-pro/replay-api/pkg/domain/iam/use_cases"
+	notification_use_cases "github.com/psavelis/team-pro/replay-api/pkg/domain/notification/use_cases"
+	quality_use_cases "github.com/psavelis/team-pro/replay-api/pkg/domain/quality/use_cases"
+	rating_use_cases "github.com/psavelis/team-pro/replay-api/pkg/domain/rating/use_cases"
+	recap_use_cases "github.com/psavelis/team-pro/replay-api/pkg/domain/recap/use_cases"
 	replay_use_cases "github.com/psavelis/team-pro/replay-api/pkg/domain/replay/use_cases"
 	steam_use_cases "github.com/psavelis/team-pro/replay-api/pkg/domain/steam/use_cases"
 )
@@ -154,7 +271,14 @@ func (b *ContainerBuilder) WithInboundPorts() *ContainerBuilder {
 			return nil, err
 		}
 
-		return iam_use_cases.NewCreateRIDTokenUseCase(rIDWriter, rIDReader), nil
+		var sessionWriter iam_out.RIDSessionWriter
+		err = c.Resolve(&sessionWriter)
+		if err != nil {
+			slog.Error("Failed to resolve RIDSessionWriter for CreateRIDTokenCommand.", "err", err)
+			return nil, err
+		}
+
+		return iam_use_cases.NewCreateRIDTokenUseCase(rIDWriter, rIDReader, sessionWriter), nil
 	})
 
 	if err != nil {
@@ -235,7 +359,21 @@ func (b *ContainerBuilder) WithInboundPorts() *ContainerBuilder {
 			return nil, err
 		}
 
-		return replay_use_cases.NewUploadReplayFileUseCase(ReplayFileMetadataWriter, replayDataWriter), nil
+		var policyReader iam_out.VisibilityPolicyReader
+		err = c.Resolve(&policyReader)
+		if err != nil {
+			slog.Error("Failed to resolve iam_out.VisibilityPolicyReader for replay_in.UploadReplayFileCommand.", "err", err)
+			return nil, err
+		}
+
+		var overrideWriter iam_out.VisibilityOverrideWriter
+		err = c.Resolve(&overrideWriter)
+		if err != nil {
+			slog.Error("Failed to resolve iam_out.VisibilityOverrideWriter for replay_in.UploadReplayFileCommand.", "err", err)
+			return nil, err
+		}
+
+		return replay_use_cases.NewUploadReplayFileUseCase(ReplayFileMetadataWriter, replayDataWriter, policyReader, overrideWriter), nil
 	})
 
 	if err != nil {
@@ -243,6 +381,61 @@ func (b *ContainerBuilder) WithInboundPorts() *ContainerBuilder {
 		panic(err)
 	}
 
+	// badges are evaluated by the replay processing (below)
+	err = c.Singleton(func() (badge_in.EvaluateBadgesCommand, error) {
+		var badgeReader badge_out.BadgeReader
+		err := c.Resolve(&badgeReader)
+		if err != nil {
+			slog.Error("Failed to resolve badge_out.BadgeReader for badge_in.EvaluateBadgesCommand.", "err", err)
+			return nil, err
+		}
+
+		var playerBadgeWriter badge_out.PlayerBadgeWriter
+		err = c.Resolve(&playerBadgeWriter)
+		if err != nil {
+			slog.Error("Failed to resolve badge_out.PlayerBadgeWriter for badge_in.EvaluateBadgesCommand.", "err", err)
+			return nil, err
+		}
+
+		return badge_use_cases.NewEvaluateBadgesUseCase(badgeReader, playerBadgeWriter), nil
+	})
+
+	if err != nil {
+		slog.Error("Failed to load badge_in.EvaluateBadgesCommand.")
+		panic(err)
+	}
+
+	err = c.Singleton(func() (replay_out.ReplayBadgeEvaluator, error) {
+		var playerReader replay_out.PlayerMetadataReader
+		err := c.Resolve(&playerReader)
+		if err != nil {
+			slog.Error("Failed to resolve replay_out.PlayerMetadataReader for replay_out.ReplayBadgeEvaluator.", "err", err)
+			return nil, err
+		}
+
+		var statsReader replay_out.PlayerStatsReader
+		err = c.Resolve(&statsReader)
+		if err != nil {
+			slog.Error("Failed to resolve replay_out.PlayerStatsReader for replay_out.ReplayBadgeEvaluator.", "err", err)
+			return nil, err
+		}
+
+		var evaluateCommand badge_in.EvaluateBadgesCommand
+		err = c.Resolve(&evaluateCommand)
+		if err != nil {
+			slog.Error("Failed to resolve badge_in.EvaluateBadgesCommand for replay_out.ReplayBadgeEvaluator.", "err", err)
+			return nil, err
+		}
+
+		// replay_in.PlayerStatsQuery is registered after the replay processing
+		return badges.NewReplayBadgeEvaluator(replay_use_cases.NewGetPlayerStatsUseCase(playerReader, statsReader), evaluateCommand), nil
+	})
+
+	if err != nil {
+		slog.Error("Failed to load replay_out.ReplayBadgeEvaluator.")
+		panic(err)
+	}
+
 	err = c.Singleton(func() (replay_in.ProcessReplayFileCommand, error) {
 		var replayFileMetadataReader replay_out.ReplayFileMetadataReader
 		err = c.Resolve(&replayFileMetadataReader)
@@ -300,7 +493,63 @@ func (b *ContainerBuilder) WithInboundPorts() *ContainerBuilder {
 			return nil, err
 		}
 
-		return replay_use_cases.NewProcessReplayFileUseCase(replayFileMetadataReader, replayFileDataReader, ReplayFileMetadataWriter, replayDataWriter, replayCommand, eventWriter, playerMetadataWriter, matchMetadataWriter), nil
+		var roundTimelineWriter replay_out.RoundTimelineWriter
+		err = c.Resolve(&roundTimelineWriter)
+		if err != nil {
+			slog.Error("Failed to resolve RoundTimelineWriter for ProcessReplayFileCommand.", "err", err)
+			return nil, err
+		}
+
+		var playerStatsWriter replay_out.PlayerStatsWriter
+		err = c.Resolve(&playerStatsWriter)
+		if err != nil {
+			slog.Error("Failed to resolve PlayerStatsWriter for ProcessReplayFileCommand.", "err", err)
+			return nil, err
+		}
+
+		var positionsWriter replay_out.ReplayPositionsWriter
+		err = c.Resolve(&positionsWriter)
+		if err != nil {
+			slog.Error("Failed to resolve ReplayPositionsWriter for ProcessReplayFileCommand.", "err", err)
+			return nil, err
+		}
+
+		var highlightsWriter replay_out.ReplayHighlightsWriter
+		err = c.Resolve(&highlightsWriter)
+		if err != nil {
+			slog.Error("Failed to resolve ReplayHighlightsWriter for ProcessReplayFileCommand.", "err", err)
+			return nil, err
+		}
+
+		var summaryWriter replay_out.MatchSummaryWriter
+		err = c.Resolve(&summaryWriter)
+		if err != nil {
+			slog.Error("Failed to resolve MatchSummaryWriter for ProcessReplayFileCommand.", "err", err)
+			return nil, err
+		}
+
+		var progressPublisher replay_out.ReplayProcessingProgressPublisher
+		err = c.Resolve(&progressPublisher)
+		if err != nil {
+			slog.Error("Failed to resolve ReplayProcessingProgressPublisher for ProcessReplayFileCommand.", "err", err)
+			return nil, err
+		}
+
+		var seasonReader replay_out.ActiveSeasonReader
+		err = c.Resolve(&seasonReader)
+		if err != nil {
+			slog.Error("Failed to resolve ActiveSeasonReader for ProcessReplayFileCommand.", "err", err)
+			return nil, err
+		}
+
+		var badgeEvaluator replay_out.ReplayBadgeEvaluator
+		err = c.Resolve(&badgeEvaluator)
+		if err != nil {
+			slog.Error("Failed to resolve ReplayBadgeEvaluator for ProcessReplayFileCommand.", "err", err)
+			return nil, err
+		}
+
+		return replay_use_cases.NewProcessReplayFileUseCase(replayFileMetadataReader, replayFileDataReader, ReplayFileMetadataWriter, replayDataWriter, replayCommand, eventWriter, playerMetadataWriter, matchMetadataWriter, roundTimelineWriter, playerStatsWriter, positionsWriter, highlightsWriter, summaryWriter, progressPublisher, seasonReader, badgeEvaluator), nil
 	})
 
 	if err != nil {
@@ -308,6 +557,52 @@ func (b *ContainerBuilder) WithInboundPorts() *ContainerBuilder {
 		panic(err)
 	}
 
+	err = c.Singleton(func() (replay_in.ReplayProcessingProgressSubscriber, error) {
+		var replayFileMetadataReader replay_out.ReplayFileMetadataReader
+		err = c.Resolve(&replayFileMetadataReader)
+		if err != nil {
+			slog.Error("Failed to resolve ReplayFileMetadataReader for ReplayProcessingProgressSubscriber.", "err", err)
+			return nil, err
+		}
+
+		var progressStream replay_out.ReplayProcessingProgressStream
+		err = c.Resolve(&progressStream)
+		if err != nil {
+			slog.Error("Failed to resolve ReplayProcessingProgressStream for ReplayProcessingProgressSubscriber.", "err", err)
+			return nil, err
+		}
+
+		return replay_use_cases.NewSubscribeReplayProcessingProgressUseCase(replayFileMetadataReader, progressStream), nil
+	})
+
+	if err != nil {
+		slog.Error("Failed to load replay_in.ReplayProcessingProgressSubscriber.")
+		panic(err)
+	}
+
+	err = c.Singleton(func() (replay_in.ReplayFileContentQuery, error) {
+		var replayFileMetadataReader replay_out.ReplayFileMetadataReader
+		err = c.Resolve(&replayFileMetadataReader)
+		if err != nil {
+			slog.Error("Failed to resolve ReplayFileMetadataReader for ReplayFileContentQuery.", "err", err)
+			return nil, err
+		}
+
+		var contentStreamer replay_out.ReplayFileContentStreamer
+		err = c.Resolve(&contentStreamer)
+		if err != nil {
+			slog.Error("Failed to resolve ReplayFileContentStreamer for ReplayFileContentQuery.", "err", err)
+			return nil, err
+		}
+
+		return replay_use_cases.NewOpenReplayFileContentUseCase(replayFileMetadataReader, contentStreamer), nil
+	})
+
+	if err != nil {
+		slog.Error("Failed to load replay_in.ReplayFileContentQuery.", "err", err)
+		panic(err)
+	}
+
 	err = c.Singleton(func() (replay_in.UpdateReplayFileHeaderCommand, error) {
 		var eventReader replay_out.GameEventReader
 		err = c.Resolve(&eventReader)
@@ -368,6 +663,135 @@ func (b *ContainerBuilder) WithInboundPorts() *ContainerBuilder {
 		panic(err)
 	}
 
+	// lazy: only resolved with a broker (RABBITMQ_URL)
+	err = c.SingletonLazy(func() (replay_in.SubmitReplayFileCommand, error) {
+		var uploadReplayFileCommand replay_in.UploadReplayFileCommand
+		err = c.Resolve(&uploadReplayFileCommand)
+		if err != nil {
+			slog.Error("Failed to resolve UploadReplayFileCommand for SubmitReplayFileCommand.", "err", err)
+			return nil, err
+		}
+
+		var replayFileMetadataWriter replay_out.ReplayFileMetadataWriter
+		err = c.Resolve(&replayFileMetadataWriter)
+		if err != nil {
+			slog.Error("Failed to resolve ReplayFileMetadataWriter for SubmitReplayFileCommand.", "err", err)
+			return nil, err
+		}
+
+		var eventPublisher replay_out.ReplayFileEventPublisher
+		err = c.Resolve(&eventPublisher)
+		if err != nil {
+			slog.Error("Failed to resolve ReplayFileEventPublisher for SubmitReplayFileCommand.", "err", err)
+			return nil, err
+		}
+
+		return replay_use_cases.NewSubmitReplayFileUseCase(uploadReplayFileCommand, replayFileMetadataWriter, eventPublisher), nil
+	})
+
+	if err != nil {
+		slog.Error("Failed to register replay_in.SubmitReplayFileCommand.")
+		panic(err)
+	}
+
+	// lazy: only resolved with a broker (RABBITMQ_URL)
+	err = c.SingletonLazy(func() (replay_in.ReprocessReplayFileCommand, error) {
+		var replayFileMetadataReader replay_out.ReplayFileMetadataReader
+		err := c.Resolve(&replayFileMetadataReader)
+		if err != nil {
+			slog.Error("Failed to resolve ReplayFileMetadataReader for ReprocessReplayFileCommand.", "err", err)
+			return nil, err
+		}
+
+		var replayFileMetadataWriter replay_out.ReplayFileMetadataWriter
+		err = c.Resolve(&replayFileMetadataWriter)
+		if err != nil {
+			slog.Error("Failed to resolve ReplayFileMetadataWriter for ReprocessReplayFileCommand.", "err", err)
+			return nil, err
+		}
+
+		var eventPublisher replay_out.ReplayFileEventPublisher
+		err = c.Resolve(&eventPublisher)
+		if err != nil {
+			slog.Error("Failed to resolve ReplayFileEventPublisher for ReprocessReplayFileCommand.", "err", err)
+			return nil, err
+		}
+
+		return replay_use_cases.NewReprocessReplayFileUseCase(replayFileMetadataReader, replayFileMetadataWriter, eventPublisher), nil
+	})
+
+	if err != nil {
+		slog.Error("Failed to register replay_in.ReprocessReplayFileCommand.")
+		panic(err)
+	}
+
+	err = c.Singleton(func() (replay_in.ProcessUploadedReplayFileCommand, error) {
+		var replayFileMetadataReader replay_out.ReplayFileMetadataReader
+		err = c.Resolve(&replayFileMetadataReader)
+		if err != nil {
+			slog.Error("Failed to resolve ReplayFileMetadataReader for ProcessUploadedReplayFileCommand.", "err", err)
+			return nil, err
+		}
+
+		var replayFileMetadataWriter replay_out.ReplayFileMetadataWriter
+		err = c.Resolve(&replayFileMetadataWriter)
+		if err != nil {
+			slog.Error("Failed to resolve ReplayFileMetadataWriter for ProcessUploadedReplayFileCommand.", "err", err)
+			return nil, err
+		}
+
+		var processReplayFileCommand replay_in.ProcessReplayFileCommand
+		err = c.Resolve(&processReplayFileCommand)
+		if err != nil {
+			slog.Error("Failed to resolve ProcessReplayFileCommand for ProcessUploadedReplayFileCommand.", "err", err)
+			return nil, err
+		}
+
+		var updateReplayFileHeaderCommand replay_in.UpdateReplayFileHeaderCommand
+		err = c.Resolve(&updateReplayFileHeaderCommand)
+		if err != nil {
+			slog.Error("Failed to resolve UpdateReplayFileHeaderCommand for ProcessUploadedReplayFileCommand.", "err", err)
+			return nil, err
+		}
+
+		return replay_use_cases.NewProcessUploadedReplayFileUseCase(replayFileMetadataReader, replayFileMetadataWriter, processReplayFileCommand, updateReplayFileHeaderCommand), nil
+	})
+
+	if err != nil {
+		slog.Error("Failed to register replay_in.ProcessUploadedReplayFileCommand.")
+		panic(err)
+	}
+
+	err = c.Singleton(func() (replay_in.RecordReplayParseCrashCommand, error) {
+		var replayFileMetadataReader replay_out.ReplayFileMetadataReader
+		err := c.Resolve(&replayFileMetadataReader)
+		if err != nil {
+			slog.Error("Failed to resolve ReplayFileMetadataReader for RecordReplayParseCrashCommand.", "err", err)
+			return nil, err
+		}
+
+		var replayFileMetadataWriter replay_out.ReplayFileMetadataWriter
+		err = c.Resolve(&replayFileMetadataWriter)
+		if err != nil {
+			slog.Error("Failed to resolve ReplayFileMetadataWriter for RecordReplayParseCrashCommand.", "err", err)
+			return nil, err
+		}
+
+		var progressPublisher replay_out.ReplayProcessingProgressPublisher
+		err = c.Resolve(&progressPublisher)
+		if err != nil {
+			slog.Error("Failed to resolve ReplayProcessingProgressPublisher for RecordReplayParseCrashCommand.", "err", err)
+			return nil, err
+		}
+
+		return replay_use_cases.NewRecordReplayParseCrashUseCase(replayFileMetadataReader, replayFileMetadataWriter, progressPublisher), nil
+	})
+
+	if err != nil {
+		slog.Error("Failed to register replay_in.RecordReplayParseCrashCommand.")
+		panic(err)
+	}
+
 	err = c.Singleton(func() (replay_in.ReplayFileReader, error) {
 		var replayFileMetadataReader replay_out.ReplayFileMetadataReader
 		err := c.Resolve(&replayFileMetadataReader)
@@ -456,6 +880,66 @@ func (b *ContainerBuilder) WithInboundPorts() *ContainerBuilder {
 		panic(err)
 	}
 
+	err = c.Singleton(func() (steam_in.ImportSteamMatchCommand, error) {
+		var matchFetcher steam_out.SteamMatchFetcher
+		err := c.Resolve(&matchFetcher)
+		if err != nil {
+			slog.Error("Failed to resolve steam_out.SteamMatchFetcher for steam_in.ImportSteamMatchCommand.", "err", err)
+			return nil, err
+		}
+
+		var uploadAndProcessReplayFileCommand replay_in.UploadAndProcessReplayFileCommand
+		err = c.Resolve(&uploadAndProcessReplayFileCommand)
+		if err != nil {
+			slog.Error("Failed to resolve replay_in.UploadAndProcessReplayFileCommand for steam_in.ImportSteamMatchCommand.", "err", err)
+			return nil, err
+		}
+
+		return steam_use_cases.NewImportSteamMatchUseCase(matchFetcher, uploadAndProcessReplayFileCommand), nil
+	})
+
+	if err != nil {
+		slog.Error("Failed to load steam_in.ImportSteamMatchCommand.", "err", err)
+		panic(err)
+	}
+
+	err = c.Singleton(func() (faceit_in.ImportFaceitMatchCommand, error) {
+		var matchFetcher faceit_out.FaceitMatchFetcher
+		err := c.Resolve(&matchFetcher)
+		if err != nil {
+			slog.Error("Failed to resolve faceit_out.FaceitMatchFetcher for faceit_in.ImportFaceitMatchCommand.", "err", err)
+			return nil, err
+		}
+
+		var externalMatchWriter faceit_out.ExternalMatchWriter
+		err = c.Resolve(&externalMatchWriter)
+		if err != nil {
+			slog.Error("Failed to resolve faceit_out.ExternalMatchWriter for faceit_in.ImportFaceitMatchCommand.", "err", err)
+			return nil, err
+		}
+
+		var matchReader replay_out.MatchMetadataReader
+		err = c.Resolve(&matchReader)
+		if err != nil {
+			slog.Error("Failed to resolve replay_out.MatchMetadataReader for faceit_in.ImportFaceitMatchCommand.", "err", err)
+			return nil, err
+		}
+
+		var uploadAndProcessReplayFileCommand replay_in.UploadAndProcessReplayFileCommand
+		err = c.Resolve(&uploadAndProcessReplayFileCommand)
+		if err != nil {
+			slog.Error("Failed to resolve replay_in.UploadAndProcessReplayFileCommand for faceit_in.ImportFaceitMatchCommand.", "err", err)
+			return nil, err
+		}
+
+		return faceit_use_cases.NewImportFaceitMatchUseCase(matchFetcher, externalMatchWriter, matchReader, uploadAndProcessReplayFileCommand), nil
+	})
+
+	if err != nil {
+		slog.Error("Failed to load faceit_in.ImportFaceitMatchCommand.", "err", err)
+		panic(err)
+	}
+
 	err = c.Singleton(func() (google_in.OnboardGoogleUserCommand, error) {
 		var googleUserWriter google_out.GoogleUserWriter
 		err := c.Resolve(&googleUserWriter)
@@ -493,179 +977,6599 @@ func (b *ContainerBuilder) WithInboundPorts() *ContainerBuilder {
 		panic(err)
 	}
 
-	err = c.Singleton(func() (iam_in.VerifyRIDKeyCommand, error) {
-		var rIDWriter iam_out.RIDTokenWriter
-		err := c.Resolve(&rIDWriter)
+	err = c.Singleton(func() (riot_in.OnboardRiotUserCommand, error) {
+		var riotUserWriter riot_out.RiotUserWriter
+		err := c.Resolve(&riotUserWriter)
 		if err != nil {
-			slog.Error("Failed to resolve RIDWriter for OnboardRIDCommand.", "err", err)
+			slog.Error("Failed to resolve RiotUserWriter for OnboardRiotUserCommand.", "err", err)
+			return nil, err
+		}
+
+		var riotUserReader riot_out.RiotUserReader
+		err = c.Resolve(&riotUserReader)
+		if err != nil {
+			slog.Error("Failed to resolve RiotUserReader for OnboardRiotUserCommand.", "err", err)
+			return nil, err
+		}
+
+		var vHashWriter riot_out.VHashWriter
+		err = c.Resolve(&vHashWriter)
+		if err != nil {
+			slog.Error("Failed to resolve VHashWriter for OnboardRiotUserCommand.", "err", err)
+			return nil, err
+		}
+
+		var onboardOpenIDUser iam_in.OnboardOpenIDUserCommandHandler
+		err = c.Resolve(&onboardOpenIDUser)
+		if err != nil {
+			slog.Error("Failed to resolve OnboardOpenIDUserCommandHandler for OnboardRiotUserCommand.", "err", err)
+			return nil, err
+		}
+
+		return riot_use_cases.NewOnboardRiotUserUseCase(riotUserWriter, riotUserReader, vHashWriter, onboardOpenIDUser), nil
+	})
+
+	if err != nil {
+		slog.Error("Failed to load OnboardRiotUserCommand.", "err", err)
+		panic(err)
+	}
+
+	err = c.Singleton(func() (discord_in.OnboardDiscordUserCommand, error) {
+		var discordUserWriter discord_out.DiscordUserWriter
+		err := c.Resolve(&discordUserWriter)
+		if err != nil {
+			slog.Error("Failed to resolve DiscordUserWriter for OnboardDiscordUserCommand.", "err", err)
+			return nil, err
+		}
+
+		var discordUserReader discord_out.DiscordUserReader
+		err = c.Resolve(&discordUserReader)
+		if err != nil {
+			slog.Error("Failed to resolve DiscordUserReader for OnboardDiscordUserCommand.", "err", err)
+			return nil, err
+		}
+
+		var vHashWriter discord_out.VHashWriter
+		err = c.Resolve(&vHashWriter)
+		if err != nil {
+			slog.Error("Failed to resolve VHashWriter for OnboardDiscordUserCommand.", "err", err)
+			return nil, err
+		}
+
+		var onboardOpenIDUser iam_in.OnboardOpenIDUserCommandHandler
+		err = c.Resolve(&onboardOpenIDUser)
+		if err != nil {
+			slog.Error("Failed to resolve OnboardOpenIDUserCommandHandler for OnboardDiscordUserCommand.", "err", err)
+			return nil, err
+		}
+
+		return discord_use_cases.NewOnboardDiscordUserUseCase(discordUserWriter, discordUserReader, vHashWriter, onboardOpenIDUser), nil
+	})
+
+	if err != nil {
+		slog.Error("Failed to load OnboardDiscordUserCommand.", "err", err)
+		panic(err)
+	}
+
+	err = c.Singleton(func() (discord_in.LinkDiscordAccountCommand, error) {
+		var discordUserWriter discord_out.DiscordUserWriter
+		err := c.Resolve(&discordUserWriter)
+		if err != nil {
+			slog.Error("Failed to resolve DiscordUserWriter for LinkDiscordAccountCommand.", "err", err)
+			return nil, err
+		}
+
+		var discordUserReader discord_out.DiscordUserReader
+		err = c.Resolve(&discordUserReader)
+		if err != nil {
+			slog.Error("Failed to resolve DiscordUserReader for LinkDiscordAccountCommand.", "err", err)
+			return nil, err
+		}
+
+		var vHashWriter discord_out.VHashWriter
+		err = c.Resolve(&vHashWriter)
+		if err != nil {
+			slog.Error("Failed to resolve VHashWriter for LinkDiscordAccountCommand.", "err", err)
+			return nil, err
+		}
+
+		var profileWriter iam_out.ProfileWriter
+		err = c.Resolve(&profileWriter)
+		if err != nil {
+			slog.Error("Failed to resolve ProfileWriter for LinkDiscordAccountCommand.", "err", err)
+			return nil, err
+		}
+
+		return discord_use_cases.NewLinkDiscordAccountUseCase(discordUserWriter, discordUserReader, vHashWriter, profileWriter), nil
+	})
+
+	if err != nil {
+		slog.Error("Failed to load LinkDiscordAccountCommand.", "err", err)
+		panic(err)
+	}
+
+	err = c.Singleton(func() (iam_in.VerifyRIDKeyCommand, error) {
+		var rIDWriter iam_out.RIDTokenWriter
+		err := c.Resolve(&rIDWriter)
+		if err != nil {
+			slog.Error("Failed to resolve RIDWriter for OnboardRIDCommand.", "err", err)
+			return nil, err
+		}
+
+		var rIDReader iam_out.RIDTokenReader
+		err = c.Resolve(&rIDReader)
+		if err != nil {
+			slog.Error("Failed to resolve RIDReader for OnboardRIDCommand.", "err", err)
+			return nil, err
+		}
+
+		return iam_use_cases.NewVerifyRIDUseCase(rIDWriter, rIDReader), nil
+	})
+
+	if err != nil {
+		slog.Error("Failed to load iam_in.CreateRIDTokenCommand.")
+		panic(err)
+	}
+
+	err = c.Singleton(func() (iam_in.RefreshRIDTokenCommand, error) {
+		var rIDWriter iam_out.RIDTokenWriter
+		err := c.Resolve(&rIDWriter)
+		if err != nil {
+			slog.Error("Failed to resolve RIDWriter for RefreshRIDTokenCommand.", "err", err)
 			return nil, err
 		}
 
 		var rIDReader iam_out.RIDTokenReader
 		err = c.Resolve(&rIDReader)
 		if err != nil {
-			slog.Error("Failed to resolve RIDReader for OnboardRIDCommand.", "err", err)
+			slog.Error("Failed to resolve RIDReader for RefreshRIDTokenCommand.", "err", err)
+			return nil, err
+		}
+
+		var sessionWriter iam_out.RIDSessionWriter
+		err = c.Resolve(&sessionWriter)
+		if err != nil {
+			slog.Error("Failed to resolve RIDSessionWriter for RefreshRIDTokenCommand.", "err", err)
+			return nil, err
+		}
+
+		var sessionReader iam_out.RIDSessionReader
+		err = c.Resolve(&sessionReader)
+		if err != nil {
+			slog.Error("Failed to resolve RIDSessionReader for RefreshRIDTokenCommand.", "err", err)
+			return nil, err
+		}
+
+		return iam_use_cases.NewRefreshRIDTokenUseCase(rIDWriter, rIDReader, sessionWriter, sessionReader), nil
+	})
+
+	if err != nil {
+		slog.Error("Failed to load iam_in.RefreshRIDTokenCommand.", "err", err)
+		panic(err)
+	}
+
+	err = c.Singleton(func() (iam_in.ListRIDSessionsQuery, error) {
+		var sessionReader iam_out.RIDSessionReader
+		err := c.Resolve(&sessionReader)
+		if err != nil {
+			slog.Error("Failed to resolve RIDSessionReader for ListRIDSessionsQuery.", "err", err)
+			return nil, err
+		}
+
+		return iam_use_cases.NewListRIDSessionsUseCase(sessionReader), nil
+	})
+
+	if err != nil {
+		slog.Error("Failed to load iam_in.ListRIDSessionsQuery.", "err", err)
+		panic(err)
+	}
+
+	err = c.Singleton(func() (iam_in.RevokeRIDSessionCommand, error) {
+		var rIDWriter iam_out.RIDTokenWriter
+		err := c.Resolve(&rIDWriter)
+		if err != nil {
+			slog.Error("Failed to resolve RIDWriter for RevokeRIDSessionCommand.", "err", err)
+			return nil, err
+		}
+
+		var sessionWriter iam_out.RIDSessionWriter
+		err = c.Resolve(&sessionWriter)
+		if err != nil {
+			slog.Error("Failed to resolve RIDSessionWriter for RevokeRIDSessionCommand.", "err", err)
+			return nil, err
+		}
+
+		var sessionReader iam_out.RIDSessionReader
+		err = c.Resolve(&sessionReader)
+		if err != nil {
+			slog.Error("Failed to resolve RIDSessionReader for RevokeRIDSessionCommand.", "err", err)
+			return nil, err
+		}
+
+		return iam_use_cases.NewRevokeRIDSessionUseCase(rIDWriter, sessionWriter, sessionReader), nil
+	})
+
+	if err != nil {
+		slog.Error("Failed to load iam_in.RevokeRIDSessionCommand.", "err", err)
+		panic(err)
+	}
+
+	err = c.Singleton(func() (iam_in.CreateRoleCommandHandler, error) {
+		var roleWriter iam_out.RoleWriter
+		err := c.Resolve(&roleWriter)
+		if err != nil {
+			slog.Error("Failed to resolve RoleWriter for CreateRoleCommandHandler.", "err", err)
+			return nil, err
+		}
+
+		return iam_use_cases.NewCreateRoleUseCase(roleWriter), nil
+	})
+
+	if err != nil {
+		slog.Error("Failed to load iam_in.CreateRoleCommandHandler.", "err", err)
+		panic(err)
+	}
+
+	err = c.Singleton(func() (iam_in.AssignRoleCommandHandler, error) {
+		var roleReader iam_out.RoleReader
+		err := c.Resolve(&roleReader)
+		if err != nil {
+			slog.Error("Failed to resolve RoleReader for AssignRoleCommandHandler.", "err", err)
+			return nil, err
+		}
+
+		var assignmentWriter iam_out.RoleAssignmentWriter
+		err = c.Resolve(&assignmentWriter)
+		if err != nil {
+			slog.Error("Failed to resolve RoleAssignmentWriter for AssignRoleCommandHandler.", "err", err)
+			return nil, err
+		}
+
+		var assignmentReader iam_out.RoleAssignmentReader
+		err = c.Resolve(&assignmentReader)
+		if err != nil {
+			slog.Error("Failed to resolve RoleAssignmentReader for AssignRoleCommandHandler.", "err", err)
+			return nil, err
+		}
+
+		return iam_use_cases.NewAssignRoleUseCase(roleReader, assignmentWriter, assignmentReader), nil
+	})
+
+	if err != nil {
+		slog.Error("Failed to load iam_in.AssignRoleCommandHandler.", "err", err)
+		panic(err)
+	}
+
+	err = c.Singleton(func() (iam_in.UnassignRoleCommandHandler, error) {
+		var assignmentWriter iam_out.RoleAssignmentWriter
+		err := c.Resolve(&assignmentWriter)
+		if err != nil {
+			slog.Error("Failed to resolve RoleAssignmentWriter for UnassignRoleCommandHandler.", "err", err)
+			return nil, err
+		}
+
+		var assignmentReader iam_out.RoleAssignmentReader
+		err = c.Resolve(&assignmentReader)
+		if err != nil {
+			slog.Error("Failed to resolve RoleAssignmentReader for UnassignRoleCommandHandler.", "err", err)
+			return nil, err
+		}
+
+		return iam_use_cases.NewUnassignRoleUseCase(assignmentWriter, assignmentReader), nil
+	})
+
+	if err != nil {
+		slog.Error("Failed to load iam_in.UnassignRoleCommandHandler.", "err", err)
+		panic(err)
+	}
+
+	err = c.Singleton(func() (iam_in.AuthorizeQuery, error) {
+		var roleReader iam_out.RoleReader
+		err := c.Resolve(&roleReader)
+		if err != nil {
+			slog.Error("Failed to resolve RoleReader for AuthorizeQuery.", "err", err)
+			return nil, err
+		}
+
+		var assignmentReader iam_out.RoleAssignmentReader
+		err = c.Resolve(&assignmentReader)
+		if err != nil {
+			slog.Error("Failed to resolve RoleAssignmentReader for AuthorizeQuery.", "err", err)
+			return nil, err
+		}
+
+		var config common.Config
+		err = c.Resolve(&config)
+		if err != nil {
+			slog.Error("Failed to resolve config for AuthorizeQuery.", "err", err)
+			return nil, err
+		}
+
+		return iam_use_cases.NewAuthorizeUseCase(roleReader, assignmentReader, config.Auth.SuperAdmins()), nil
+	})
+
+	if err != nil {
+		slog.Error("Failed to load iam_in.AuthorizeQuery.", "err", err)
+		panic(err)
+	}
+
+	err = c.Singleton(func() (iam_in.IssueAPIKeyCommandHandler, error) {
+		var keyWriter iam_out.APIKeyWriter
+		err := c.Resolve(&keyWriter)
+		if err != nil {
+			slog.Error("Failed to resolve APIKeyWriter for IssueAPIKeyCommandHandler.", "err", err)
+			return nil, err
+		}
+
+		var authorize iam_in.AuthorizeQuery
+		err = c.Resolve(&authorize)
+		if err != nil {
+			slog.Error("Failed to resolve AuthorizeQuery for IssueAPIKeyCommandHandler.", "err", err)
+			return nil, err
+		}
+
+		return iam_use_cases.NewIssueAPIKeyUseCase(keyWriter, authorize), nil
+	})
+
+	if err != nil {
+		slog.Error("Failed to load iam_in.IssueAPIKeyCommandHandler.", "err", err)
+		panic(err)
+	}
+
+	err = c.Singleton(func() (iam_in.RotateAPIKeyCommandHandler, error) {
+		var keyWriter iam_out.APIKeyWriter
+		err := c.Resolve(&keyWriter)
+		if err != nil {
+			slog.Error("Failed to resolve APIKeyWriter for RotateAPIKeyCommandHandler.", "err", err)
+			return nil, err
+		}
+
+		var keyReader iam_out.APIKeyReader
+		err = c.Resolve(&keyReader)
+		if err != nil {
+			slog.Error("Failed to resolve APIKeyReader for RotateAPIKeyCommandHandler.", "err", err)
+			return nil, err
+		}
+
+		return iam_use_cases.NewRotateAPIKeyUseCase(keyWriter, keyReader), nil
+	})
+
+	if err != nil {
+		slog.Error("Failed to load iam_in.RotateAPIKeyCommandHandler.", "err", err)
+		panic(err)
+	}
+
+	err = c.Singleton(func() (iam_in.RevokeAPIKeyCommandHandler, error) {
+		var keyWriter iam_out.APIKeyWriter
+		err := c.Resolve(&keyWriter)
+		if err != nil {
+			slog.Error("Failed to resolve APIKeyWriter for RevokeAPIKeyCommandHandler.", "err", err)
+			return nil, err
+		}
+
+		var keyReader iam_out.APIKeyReader
+		err = c.Resolve(&keyReader)
+		if err != nil {
+			slog.Error("Failed to resolve APIKeyReader for RevokeAPIKeyCommandHandler.", "err", err)
+			return nil, err
+		}
+
+		return iam_use_cases.NewRevokeAPIKeyUseCase(keyWriter, keyReader), nil
+	})
+
+	if err != nil {
+		slog.Error("Failed to load iam_in.RevokeAPIKeyCommandHandler.", "err", err)
+		panic(err)
+	}
+
+	err = c.Singleton(func() (iam_in.AuthenticateAPIKeyCommand, error) {
+		var keyWriter iam_out.APIKeyWriter
+		err := c.Resolve(&keyWriter)
+		if err != nil {
+			slog.Error("Failed to resolve APIKeyWriter for AuthenticateAPIKeyCommand.", "err", err)
+			return nil, err
+		}
+
+		var keyReader iam_out.APIKeyReader
+		err = c.Resolve(&keyReader)
+		if err != nil {
+			slog.Error("Failed to resolve APIKeyReader for AuthenticateAPIKeyCommand.", "err", err)
+			return nil, err
+		}
+
+		return iam_use_cases.NewAuthenticateAPIKeyUseCase(keyWriter, keyReader), nil
+	})
+
+	if err != nil {
+		slog.Error("Failed to load iam_in.AuthenticateAPIKeyCommand.", "err", err)
+		panic(err)
+	}
+
+	err = c.Singleton(func() (iam_in.ProfileReader, error) {
+		var profileReader iam_out.ProfileReader
+		err := c.Resolve(&profileReader)
+
+		if err != nil {
+			slog.Error("Failed to resolve iam_out.ProfileReader for iam_in.ProfileReader.", "err", err)
+			return nil, err
+		}
+
+		return iam_query_services.NewwProfileQueryService(profileReader), nil
+	})
+
+	if err != nil {
+		slog.Error("Failed to load iam_in.ProfileReader.")
+		panic(err)
+	}
+
+	err = c.Singleton(func() (analytics_in.ComputeEngagementSnapshotCommand, error) {
+		var activityReader analytics_out.UploadActivityReader
+		err := c.Resolve(&activityReader)
+		if err != nil {
+			slog.Error("Failed to resolve analytics_out.UploadActivityReader for ComputeEngagementSnapshotCommand.", "err", err)
+			return nil, err
+		}
+
+		var snapshotWriter analytics_out.EngagementSnapshotWriter
+		err = c.Resolve(&snapshotWriter)
+		if err != nil {
+			slog.Error("Failed to resolve analytics_out.EngagementSnapshotWriter for ComputeEngagementSnapshotCommand.", "err", err)
+			return nil, err
+		}
+
+		return analytics_use_cases.NewComputeEngagementSnapshotUseCase(activityReader, snapshotWriter), nil
+	})
+
+	if err != nil {
+		slog.Error("Failed to load analytics_in.ComputeEngagementSnapshotCommand.")
+		panic(err)
+	}
+
+	err = c.Singleton(func() (analytics_in.EngagementReader, error) {
+		var snapshotReader analytics_out.EngagementSnapshotReader
+		err := c.Resolve(&snapshotReader)
+		if err != nil {
+			slog.Error("Failed to resolve analytics_out.EngagementSnapshotReader for analytics_in.EngagementReader.", "err", err)
+			return nil, err
+		}
+
+		return analytics_services.NewEngagementQueryService(snapshotReader), nil
+	})
+
+	if err != nil {
+		slog.Error("Failed to load analytics_in.EngagementReader.")
+		panic(err)
+	}
+
+	err = c.Singleton(func() (quality_in.RunDataQualityChecksCommand, error) {
+		var checks []quality_out.DataQualityCheck
+		err := c.Resolve(&checks)
+		if err != nil {
+			slog.Error("Failed to resolve []quality_out.DataQualityCheck for RunDataQualityChecksCommand.", "err", err)
+			return nil, err
+		}
+
+		var findingWriter quality_out.FindingWriter
+		err = c.Resolve(&findingWriter)
+		if err != nil {
+			slog.Error("Failed to resolve quality_out.FindingWriter for RunDataQualityChecksCommand.", "err", err)
+			return nil, err
+		}
+
+		var alertNotifier quality_out.AlertNotifier
+		err = c.Resolve(&alertNotifier)
+		if err != nil {
+			slog.Error("Failed to resolve quality_out.AlertNotifier for RunDataQualityChecksCommand.", "err", err)
+			return nil, err
+		}
+
+		return quality_use_cases.NewRunDataQualityChecksUseCase(checks, findingWriter, alertNotifier), nil
+	})
+
+	if err != nil {
+		slog.Error("Failed to load quality_in.RunDataQualityChecksCommand.")
+		panic(err)
+	}
+
+	err = c.Singleton(func() (quality_in.FindingReader, error) {
+		var findingReader quality_out.FindingReader
+		err := c.Resolve(&findingReader)
+		if err != nil {
+			slog.Error("Failed to resolve quality_out.FindingReader for quality_in.FindingReader.", "err", err)
+			return nil, err
+		}
+
+		return quality_services.NewFindingQueryService(findingReader), nil
+	})
+
+	if err != nil {
+		slog.Error("Failed to load quality_in.FindingReader.")
+		panic(err)
+	}
+
+	err = c.Singleton(func() (fx_in.GetExchangeRateQuery, error) {
+		var providers []fx_out.ExchangeRateProvider
+		err := c.Resolve(&providers)
+		if err != nil {
+			slog.Error("Failed to resolve []fx_out.ExchangeRateProvider for GetExchangeRateQuery.", "err", err)
+			return nil, err
+		}
+
+		var alertNotifier fx_out.RateAlertNotifier
+		err = c.Resolve(&alertNotifier)
+		if err != nil {
+			slog.Error("Failed to resolve fx_out.RateAlertNotifier for GetExchangeRateQuery.", "err", err)
+			return nil, err
+		}
+
+		var config common.Config
+		err = c.Resolve(&config)
+		if err != nil {
+			slog.Error("Failed to resolve config for GetExchangeRateQuery.", "err", err)
+			return nil, err
+		}
+
+		return fx_use_cases.NewGetExchangeRateUseCase(providers, alertNotifier, config.FX.MaxDeviation), nil
+	})
+
+	if err != nil {
+		slog.Error("Failed to load fx_in.GetExchangeRateQuery.")
+		panic(err)
+	}
+
+	err = c.Singleton(func() (replay_in.ProjectPlayerMatchHistoryCommand, error) {
+		var matchReader replay_out.MatchMetadataReader
+		err := c.Resolve(&matchReader)
+		if err != nil {
+			slog.Error("Failed to resolve replay_out.MatchMetadataReader for ProjectPlayerMatchHistoryCommand.", "err", err)
+			return nil, err
+		}
+
+		var historyWriter replay_out.PlayerMatchHistoryWriter
+		err = c.Resolve(&historyWriter)
+		if err != nil {
+			slog.Error("Failed to resolve replay_out.PlayerMatchHistoryWriter for ProjectPlayerMatchHistoryCommand.", "err", err)
+			return nil, err
+		}
+
+		return replay_use_cases.NewProjectPlayerMatchHistoryUseCase(matchReader, historyWriter), nil
+	})
+
+	if err != nil {
+		slog.Error("Failed to load replay_in.ProjectPlayerMatchHistoryCommand.")
+		panic(err)
+	}
+
+	err = c.Singleton(func() (replay_in.SyncPlayerProfilesCommand, error) {
+		var playerReader replay_out.PlayerMetadataReader
+		err := c.Resolve(&playerReader)
+		if err != nil {
+			slog.Error("Failed to resolve replay_out.PlayerMetadataReader for SyncPlayerProfilesCommand.", "err", err)
+			return nil, err
+		}
+
+		var playerWriter replay_out.PlayerMetadataWriter
+		err = c.Resolve(&playerWriter)
+		if err != nil {
+			slog.Error("Failed to resolve replay_out.PlayerMetadataWriter for SyncPlayerProfilesCommand.", "err", err)
+			return nil, err
+		}
+
+		var historyWriter replay_out.PlayerProfileHistoryWriter
+		err = c.Resolve(&historyWriter)
+		if err != nil {
+			slog.Error("Failed to resolve replay_out.PlayerProfileHistoryWriter for SyncPlayerProfilesCommand.", "err", err)
+			return nil, err
+		}
+
+		var fetchers []replay_out.ExternalStatsFetcher
+		err = c.Resolve(&fetchers)
+		if err != nil {
+			slog.Error("Failed to resolve []replay_out.ExternalStatsFetcher for SyncPlayerProfilesCommand.", "err", err)
+			return nil, err
+		}
+
+		return replay_use_cases.NewSyncPlayerProfilesUseCase(playerReader, playerWriter, historyWriter, fetchers), nil
+	})
+
+	if err != nil {
+		slog.Error("Failed to load replay_in.SyncPlayerProfilesCommand.")
+		panic(err)
+	}
+
+	err = c.Singleton(func() (replay_in.PlayerMatchHistoryReader, error) {
+		var historyReader replay_out.PlayerMatchHistoryReader
+		err := c.Resolve(&historyReader)
+		if err != nil {
+			slog.Error("Failed to resolve replay_out.PlayerMatchHistoryReader for replay_in.PlayerMatchHistoryReader.", "err", err)
+			return nil, err
+		}
+
+		return metadata.NewPlayerMatchHistoryQueryService(historyReader), nil
+	})
+
+	if err != nil {
+		slog.Error("Failed to load replay_in.PlayerMatchHistoryReader.")
+		panic(err)
+	}
+
+	err = c.Singleton(func() (replay_in.RoundTimelineReader, error) {
+		var timelineReader replay_out.RoundTimelineReader
+		err := c.Resolve(&timelineReader)
+		if err != nil {
+			slog.Error("Failed to resolve replay_out.RoundTimelineReader for replay_in.RoundTimelineReader.", "err", err)
+			return nil, err
+		}
+
+		return metadata.NewRoundTimelineQueryService(timelineReader), nil
+	})
+
+	if err != nil {
+		slog.Error("Failed to load replay_in.RoundTimelineReader.")
+		panic(err)
+	}
+
+	err = c.Singleton(func() (replay_in.ReplayHighlightsReader, error) {
+		var highlightsReader replay_out.ReplayHighlightsReader
+		err := c.Resolve(&highlightsReader)
+		if err != nil {
+			slog.Error("Failed to resolve replay_out.ReplayHighlightsReader for replay_in.ReplayHighlightsReader.", "err", err)
+			return nil, err
+		}
+
+		return metadata.NewReplayHighlightsQueryService(highlightsReader), nil
+	})
+
+	if err != nil {
+		slog.Error("Failed to load replay_in.ReplayHighlightsReader.")
+		panic(err)
+	}
+
+	err = c.Singleton(func() (replay_in.MatchSummaryReader, error) {
+		var summaryReader replay_out.MatchSummaryReader
+		err := c.Resolve(&summaryReader)
+		if err != nil {
+			slog.Error("Failed to resolve replay_out.MatchSummaryReader for replay_in.MatchSummaryReader.", "err", err)
+			return nil, err
+		}
+
+		return metadata.NewMatchSummaryQueryService(summaryReader), nil
+	})
+
+	if err != nil {
+		slog.Error("Failed to load replay_in.MatchSummaryReader.")
+		panic(err)
+	}
+
+	err = c.Singleton(func() (replay_in.PlayerStatsQuery, error) {
+		var playerReader replay_out.PlayerMetadataReader
+		err := c.Resolve(&playerReader)
+		if err != nil {
+			slog.Error("Failed to resolve replay_out.PlayerMetadataReader for replay_in.PlayerStatsQuery.", "err", err)
+			return nil, err
+		}
+
+		var statsReader replay_out.PlayerStatsReader
+		err = c.Resolve(&statsReader)
+		if err != nil {
+			slog.Error("Failed to resolve replay_out.PlayerStatsReader for replay_in.PlayerStatsQuery.", "err", err)
+			return nil, err
+		}
+
+		return replay_use_cases.NewGetPlayerStatsUseCase(playerReader, statsReader), nil
+	})
+
+	if err != nil {
+		slog.Error("Failed to load replay_in.PlayerStatsQuery.")
+		panic(err)
+	}
+
+	err = c.Singleton(func() (replay_in.ReplayHeatmapQuery, error) {
+		var positionsReader replay_out.ReplayPositionsReader
+		err := c.Resolve(&positionsReader)
+		if err != nil {
+			slog.Error("Failed to resolve replay_out.ReplayPositionsReader for replay_in.ReplayHeatmapQuery.", "err", err)
+			return nil, err
+		}
+
+		return replay_use_cases.NewGetReplayHeatmapUseCase(positionsReader), nil
+	})
+
+	if err != nil {
+		slog.Error("Failed to load replay_in.ReplayHeatmapQuery.")
+		panic(err)
+	}
+
+	err = c.Singleton(func() (replay_in.ReplayEconomyQuery, error) {
+		var timelineReader replay_out.RoundTimelineReader
+		err := c.Resolve(&timelineReader)
+		if err != nil {
+			slog.Error("Failed to resolve replay_out.RoundTimelineReader for replay_in.ReplayEconomyQuery.", "err", err)
+			return nil, err
+		}
+
+		return replay_use_cases.NewGetReplayEconomyUseCase(timelineReader), nil
+	})
+
+	if err != nil {
+		slog.Error("Failed to load replay_in.ReplayEconomyQuery.")
+		panic(err)
+	}
+
+	err = c.Singleton(func() (matchmaking_in.SyncLobbyVoiceChannelsCommand, error) {
+		var lobbyReader matchmaking_out.LobbyReader
+		err := c.Resolve(&lobbyReader)
+		if err != nil {
+			slog.Error("Failed to resolve matchmaking_out.LobbyReader for SyncLobbyVoiceChannelsCommand.", "err", err)
+			return nil, err
+		}
+
+		var lobbyWriter matchmaking_out.LobbyWriter
+		err = c.Resolve(&lobbyWriter)
+		if err != nil {
+			slog.Error("Failed to resolve matchmaking_out.LobbyWriter for SyncLobbyVoiceChannelsCommand.", "err", err)
+			return nil, err
+		}
+
+		var voiceProvider matchmaking_out.VoiceChannelProvider
+		err = c.Resolve(&voiceProvider)
+		if err != nil {
+			slog.Error("Failed to resolve matchmaking_out.VoiceChannelProvider for SyncLobbyVoiceChannelsCommand.", "err", err)
+			return nil, err
+		}
+
+		return matchmaking_use_cases.NewSyncLobbyVoiceChannelsUseCase(lobbyReader, lobbyWriter, voiceProvider), nil
+	})
+
+	if err != nil {
+		slog.Error("Failed to load matchmaking_in.SyncLobbyVoiceChannelsCommand.")
+		panic(err)
+	}
+
+	err = c.Singleton(func() (matchmaking_in.JoinLobbyVoiceCommand, error) {
+		var lobbyReader matchmaking_out.LobbyReader
+		err := c.Resolve(&lobbyReader)
+		if err != nil {
+			slog.Error("Failed to resolve matchmaking_out.LobbyReader for JoinLobbyVoiceCommand.", "err", err)
+			return nil, err
+		}
+
+		var voiceProvider matchmaking_out.VoiceChannelProvider
+		err = c.Resolve(&voiceProvider)
+		if err != nil {
+			slog.Error("Failed to resolve matchmaking_out.VoiceChannelProvider for JoinLobbyVoiceCommand.", "err", err)
+			return nil, err
+		}
+
+		return matchmaking_use_cases.NewJoinLobbyVoiceUseCase(lobbyReader, voiceProvider), nil
+	})
+
+	if err != nil {
+		slog.Error("Failed to load matchmaking_in.JoinLobbyVoiceCommand.")
+		panic(err)
+	}
+
+	err = c.Singleton(func() (matchmaking_in.ModerateLobbyVoiceCommand, error) {
+		var lobbyReader matchmaking_out.LobbyReader
+		err := c.Resolve(&lobbyReader)
+		if err != nil {
+			slog.Error("Failed to resolve matchmaking_out.LobbyReader for ModerateLobbyVoiceCommand.", "err", err)
+			return nil, err
+		}
+
+		var lobbyWriter matchmaking_out.LobbyWriter
+		err = c.Resolve(&lobbyWriter)
+		if err != nil {
+			slog.Error("Failed to resolve matchmaking_out.LobbyWriter for ModerateLobbyVoiceCommand.", "err", err)
+			return nil, err
+		}
+
+		var voiceProvider matchmaking_out.VoiceChannelProvider
+		err = c.Resolve(&voiceProvider)
+		if err != nil {
+			slog.Error("Failed to resolve matchmaking_out.VoiceChannelProvider for ModerateLobbyVoiceCommand.", "err", err)
+			return nil, err
+		}
+
+		return matchmaking_use_cases.NewModerateLobbyVoiceUseCase(lobbyReader, lobbyWriter, voiceProvider), nil
+	})
+
+	if err != nil {
+		slog.Error("Failed to load matchmaking_in.ModerateLobbyVoiceCommand.")
+		panic(err)
+	}
+
+	err = c.Singleton(func() (matchmaking_in.GetLobbyQuery, error) {
+		var lobbyReader matchmaking_out.LobbyReader
+		err := c.Resolve(&lobbyReader)
+		if err != nil {
+			slog.Error("Failed to resolve matchmaking_out.LobbyReader for GetLobbyQuery.", "err", err)
+			return nil, err
+		}
+
+		return matchmaking_use_cases.NewGetLobbyUseCase(lobbyReader), nil
+	})
+
+	if err != nil {
+		slog.Error("Failed to load matchmaking_in.GetLobbyQuery.")
+		panic(err)
+	}
+
+	err = c.Singleton(func() (matchmaking_in.StartCaptainDraftCommand, error) {
+		var lobbyReader matchmaking_out.LobbyReader
+		err := c.Resolve(&lobbyReader)
+		if err != nil {
+			slog.Error("Failed to resolve matchmaking_out.LobbyReader for StartCaptainDraftCommand.", "err", err)
+			return nil, err
+		}
+
+		var lobbyWriter matchmaking_out.LobbyWriter
+		err = c.Resolve(&lobbyWriter)
+		if err != nil {
+			slog.Error("Failed to resolve matchmaking_out.LobbyWriter for StartCaptainDraftCommand.", "err", err)
+			return nil, err
+		}
+
+		return matchmaking_use_cases.NewStartCaptainDraftUseCase(lobbyReader, lobbyWriter), nil
+	})
+
+	if err != nil {
+		slog.Error("Failed to load matchmaking_in.StartCaptainDraftCommand.")
+		panic(err)
+	}
+
+	err = c.Singleton(func() (matchmaking_in.PickDraftPlayerCommand, error) {
+		var lobbyReader matchmaking_out.LobbyReader
+		err := c.Resolve(&lobbyReader)
+		if err != nil {
+			slog.Error("Failed to resolve matchmaking_out.LobbyReader for PickDraftPlayerCommand.", "err", err)
+			return nil, err
+		}
+
+		var lobbyWriter matchmaking_out.LobbyWriter
+		err = c.Resolve(&lobbyWriter)
+		if err != nil {
+			slog.Error("Failed to resolve matchmaking_out.LobbyWriter for PickDraftPlayerCommand.", "err", err)
+			return nil, err
+		}
+
+		return matchmaking_use_cases.NewPickDraftPlayerUseCase(lobbyReader, lobbyWriter), nil
+	})
+
+	if err != nil {
+		slog.Error("Failed to load matchmaking_in.PickDraftPlayerCommand.")
+		panic(err)
+	}
+
+	err = c.Singleton(func() (matchmaking_in.ExpireDraftPicksCommand, error) {
+		var lobbyReader matchmaking_out.LobbyReader
+		err := c.Resolve(&lobbyReader)
+		if err != nil {
+			slog.Error("Failed to resolve matchmaking_out.LobbyReader for ExpireDraftPicksCommand.", "err", err)
+			return nil, err
+		}
+
+		var lobbyWriter matchmaking_out.LobbyWriter
+		err = c.Resolve(&lobbyWriter)
+		if err != nil {
+			slog.Error("Failed to resolve matchmaking_out.LobbyWriter for ExpireDraftPicksCommand.", "err", err)
+			return nil, err
+		}
+
+		return matchmaking_use_cases.NewExpireDraftPicksUseCase(lobbyReader, lobbyWriter), nil
+	})
+
+	if err != nil {
+		slog.Error("Failed to load matchmaking_in.ExpireDraftPicksCommand.")
+		panic(err)
+	}
+
+	err = c.Singleton(func() (matchmaking_in.RespondReadyCheckCommand, error) {
+		var lobbyReader matchmaking_out.LobbyReader
+		err := c.Resolve(&lobbyReader)
+		if err != nil {
+			slog.Error("Failed to resolve matchmaking_out.LobbyReader for RespondReadyCheckCommand.", "err", err)
+			return nil, err
+		}
+
+		var lobbyWriter matchmaking_out.LobbyWriter
+		err = c.Resolve(&lobbyWriter)
+		if err != nil {
+			slog.Error("Failed to resolve matchmaking_out.LobbyWriter for RespondReadyCheckCommand.", "err", err)
+			return nil, err
+		}
+
+		var ticketReader matchmaking_out.QueueTicketReader
+		err = c.Resolve(&ticketReader)
+		if err != nil {
+			slog.Error("Failed to resolve matchmaking_out.QueueTicketReader for RespondReadyCheckCommand.", "err", err)
+			return nil, err
+		}
+
+		var ticketWriter matchmaking_out.QueueTicketWriter
+		err = c.Resolve(&ticketWriter)
+		if err != nil {
+			slog.Error("Failed to resolve matchmaking_out.QueueTicketWriter for RespondReadyCheckCommand.", "err", err)
+			return nil, err
+		}
+
+		var penaltyReader matchmaking_out.QueuePenaltyReader
+		err = c.Resolve(&penaltyReader)
+		if err != nil {
+			slog.Error("Failed to resolve matchmaking_out.QueuePenaltyReader for RespondReadyCheckCommand.", "err", err)
+			return nil, err
+		}
+
+		var penaltyWriter matchmaking_out.QueuePenaltyWriter
+		err = c.Resolve(&penaltyWriter)
+		if err != nil {
+			slog.Error("Failed to resolve matchmaking_out.QueuePenaltyWriter for RespondReadyCheckCommand.", "err", err)
+			return nil, err
+		}
+
+		return matchmaking_use_cases.NewRespondReadyCheckUseCase(lobbyReader, lobbyWriter, ticketReader, ticketWriter, penaltyReader, penaltyWriter), nil
+	})
+
+	if err != nil {
+		slog.Error("Failed to load matchmaking_in.RespondReadyCheckCommand.")
+		panic(err)
+	}
+
+	err = c.Singleton(func() (matchmaking_in.ExpireReadyChecksCommand, error) {
+		var lobbyReader matchmaking_out.LobbyReader
+		err := c.Resolve(&lobbyReader)
+		if err != nil {
+			slog.Error("Failed to resolve matchmaking_out.LobbyReader for ExpireReadyChecksCommand.", "err", err)
+			return nil, err
+		}
+
+		var lobbyWriter matchmaking_out.LobbyWriter
+		err = c.Resolve(&lobbyWriter)
+		if err != nil {
+			slog.Error("Failed to resolve matchmaking_out.LobbyWriter for ExpireReadyChecksCommand.", "err", err)
+			return nil, err
+		}
+
+		var ticketReader matchmaking_out.QueueTicketReader
+		err = c.Resolve(&ticketReader)
+		if err != nil {
+			slog.Error("Failed to resolve matchmaking_out.QueueTicketReader for ExpireReadyChecksCommand.", "err", err)
+			return nil, err
+		}
+
+		var ticketWriter matchmaking_out.QueueTicketWriter
+		err = c.Resolve(&ticketWriter)
+		if err != nil {
+			slog.Error("Failed to resolve matchmaking_out.QueueTicketWriter for ExpireReadyChecksCommand.", "err", err)
+			return nil, err
+		}
+
+		var penaltyReader matchmaking_out.QueuePenaltyReader
+		err = c.Resolve(&penaltyReader)
+		if err != nil {
+			slog.Error("Failed to resolve matchmaking_out.QueuePenaltyReader for ExpireReadyChecksCommand.", "err", err)
+			return nil, err
+		}
+
+		var penaltyWriter matchmaking_out.QueuePenaltyWriter
+		err = c.Resolve(&penaltyWriter)
+		if err != nil {
+			slog.Error("Failed to resolve matchmaking_out.QueuePenaltyWriter for ExpireReadyChecksCommand.", "err", err)
+			return nil, err
+		}
+
+		return matchmaking_use_cases.NewExpireReadyChecksUseCase(lobbyReader, lobbyWriter, ticketReader, ticketWriter, penaltyReader, penaltyWriter), nil
+	})
+
+	if err != nil {
+		slog.Error("Failed to load matchmaking_in.ExpireReadyChecksCommand.")
+		panic(err)
+	}
+
+	err = c.Singleton(func() (matchmaking_in.ExpireStaleLobbiesCommand, error) {
+		var poolReader matchmaking_out.MatchmakingPoolReader
+		err := c.Resolve(&poolReader)
+		if err != nil {
+			slog.Error("Failed to resolve matchmaking_out.MatchmakingPoolReader for ExpireStaleLobbiesCommand.", "err", err)
+			return nil, err
+		}
+
+		var lobbyReader matchmaking_out.LobbyReader
+		err = c.Resolve(&lobbyReader)
+		if err != nil {
+			slog.Error("Failed to resolve matchmaking_out.LobbyReader for ExpireStaleLobbiesCommand.", "err", err)
+			return nil, err
+		}
+
+		var lobbyWriter matchmaking_out.LobbyWriter
+		err = c.Resolve(&lobbyWriter)
+		if err != nil {
+			slog.Error("Failed to resolve matchmaking_out.LobbyWriter for ExpireStaleLobbiesCommand.", "err", err)
+			return nil, err
+		}
+
+		var ticketReader matchmaking_out.QueueTicketReader
+		err = c.Resolve(&ticketReader)
+		if err != nil {
+			slog.Error("Failed to resolve matchmaking_out.QueueTicketReader for ExpireStaleLobbiesCommand.", "err", err)
+			return nil, err
+		}
+
+		var ticketWriter matchmaking_out.QueueTicketWriter
+		err = c.Resolve(&ticketWriter)
+		if err != nil {
+			slog.Error("Failed to resolve matchmaking_out.QueueTicketWriter for ExpireStaleLobbiesCommand.", "err", err)
+			return nil, err
+		}
+
+		var config common.Config
+		err = c.Resolve(&config)
+		if err != nil {
+			slog.Error("Failed to resolve config for ExpireStaleLobbiesCommand.", "err", err)
+			return nil, err
+		}
+
+		// lobby.cancelled and queue.ticket_expired are only published with a broker (RABBITMQ_URL)
+		var eventPublisher matchmaking_out.LobbyEventPublisher
+		if config.RabbitMQ.URL != "" {
+			var publisher *rabbitmq.MatchmakingEventPublisher
+			err = c.Resolve(&publisher)
+			if err != nil {
+				slog.Error("Failed to resolve rabbitmq.MatchmakingEventPublisher for ExpireStaleLobbiesCommand.", "err", err)
+				return nil, err
+			}
+
+			eventPublisher = publisher
+		}
+
+		return matchmaking_use_cases.NewExpireStaleLobbiesUseCase(poolReader, lobbyReader, lobbyWriter, ticketReader, ticketWriter, eventPublisher), nil
+	})
+
+	if err != nil {
+		slog.Error("Failed to load matchmaking_in.ExpireStaleLobbiesCommand.")
+		panic(err)
+	}
+
+	err = c.Singleton(func() (matchmaking_in.LinkLobbyMatchCommand, error) {
+		var lobbyReader matchmaking_out.LobbyReader
+		err := c.Resolve(&lobbyReader)
+		if err != nil {
+			slog.Error("Failed to resolve matchmaking_out.LobbyReader for LinkLobbyMatchCommand.", "err", err)
+			return nil, err
+		}
+
+		var lobbyWriter matchmaking_out.LobbyWriter
+		err = c.Resolve(&lobbyWriter)
+		if err != nil {
+			slog.Error("Failed to resolve matchmaking_out.LobbyWriter for LinkLobbyMatchCommand.", "err", err)
+			return nil, err
+		}
+
+		var lobbyMatchWriter matchmaking_out.LobbyMatchWriter
+		err = c.Resolve(&lobbyMatchWriter)
+		if err != nil {
+			slog.Error("Failed to resolve matchmaking_out.LobbyMatchWriter for LinkLobbyMatchCommand.", "err", err)
+			return nil, err
+		}
+
+		return matchmaking_use_cases.NewLinkLobbyMatchUseCase(lobbyReader, lobbyWriter, lobbyMatchWriter), nil
+	})
+
+	if err != nil {
+		slog.Error("Failed to load matchmaking_in.LinkLobbyMatchCommand.")
+		panic(err)
+	}
+
+	err = c.Singleton(func() (matchmaking_in.ReportLobbyResultCommand, error) {
+		var lobbyReader matchmaking_out.LobbyReader
+		err := c.Resolve(&lobbyReader)
+		if err != nil {
+			slog.Error("Failed to resolve matchmaking_out.LobbyReader for ReportLobbyResultCommand.", "err", err)
+			return nil, err
+		}
+
+		var lobbyWriter matchmaking_out.LobbyWriter
+		err = c.Resolve(&lobbyWriter)
+		if err != nil {
+			slog.Error("Failed to resolve matchmaking_out.LobbyWriter for ReportLobbyResultCommand.", "err", err)
+			return nil, err
+		}
+
+		var config common.Config
+		err = c.Resolve(&config)
+		if err != nil {
+			slog.Error("Failed to resolve config for ReportLobbyResultCommand.", "err", err)
+			return nil, err
+		}
+
+		var lobbyMatchWriter matchmaking_out.LobbyMatchWriter
+		err = c.Resolve(&lobbyMatchWriter)
+		if err != nil {
+			slog.Error("Failed to resolve matchmaking_out.LobbyMatchWriter for ReportLobbyResultCommand.", "err", err)
+			return nil, err
+		}
+
+		// match.completed is only published with a broker (RABBITMQ_URL), the players aren't rated without it
+		var eventPublisher matchmaking_out.LobbyEventPublisher
+		if config.RabbitMQ.URL != "" {
+			var publisher *rabbitmq.MatchmakingEventPublisher
+			err = c.Resolve(&publisher)
+			if err != nil {
+				slog.Error("Failed to resolve rabbitmq.MatchmakingEventPublisher for ReportLobbyResultCommand.", "err", err)
+				return nil, err
+			}
+
+			eventPublisher = publisher
+		}
+
+		return matchmaking_use_cases.NewReportLobbyResultUseCase(lobbyReader, lobbyWriter, lobbyMatchWriter, eventPublisher), nil
+	})
+
+	if err != nil {
+		slog.Error("Failed to load matchmaking_in.ReportLobbyResultCommand.")
+		panic(err)
+	}
+
+	err = c.Singleton(func() (matchmaking_in.AbandonLobbyCommand, error) {
+		var lobbyReader matchmaking_out.LobbyReader
+		err := c.Resolve(&lobbyReader)
+		if err != nil {
+			slog.Error("Failed to resolve matchmaking_out.LobbyReader for AbandonLobbyCommand.", "err", err)
+			return nil, err
+		}
+
+		var lobbyWriter matchmaking_out.LobbyWriter
+		err = c.Resolve(&lobbyWriter)
+		if err != nil {
+			slog.Error("Failed to resolve matchmaking_out.LobbyWriter for AbandonLobbyCommand.", "err", err)
+			return nil, err
+		}
+
+		return matchmaking_use_cases.NewAbandonLobbyUseCase(lobbyReader, lobbyWriter), nil
+	})
+
+	if err != nil {
+		slog.Error("Failed to load matchmaking_in.AbandonLobbyCommand.")
+		panic(err)
+	}
+
+	err = c.Singleton(func() (matchmaking_in.CreateMatchmakingPoolCommand, error) {
+		var poolWriter matchmaking_out.MatchmakingPoolWriter
+		err := c.Resolve(&poolWriter)
+		if err != nil {
+			slog.Error("Failed to resolve matchmaking_out.MatchmakingPoolWriter for CreateMatchmakingPoolCommand.", "err", err)
+			return nil, err
+		}
+
+		return matchmaking_use_cases.NewCreateMatchmakingPoolUseCase(poolWriter), nil
+	})
+
+	if err != nil {
+		slog.Error("Failed to load matchmaking_in.CreateMatchmakingPoolCommand.")
+		panic(err)
+	}
+
+	err = c.Singleton(func() (matchmaking_in.CreateEconomyConfigCommand, error) {
+		var economyReader matchmaking_out.EconomyConfigReader
+		err := c.Resolve(&economyReader)
+		if err != nil {
+			slog.Error("Failed to resolve matchmaking_out.EconomyConfigReader for CreateEconomyConfigCommand.", "err", err)
+			return nil, err
+		}
+
+		var economyWriter matchmaking_out.EconomyConfigWriter
+		err = c.Resolve(&economyWriter)
+		if err != nil {
+			slog.Error("Failed to resolve matchmaking_out.EconomyConfigWriter for CreateEconomyConfigCommand.", "err", err)
+			return nil, err
+		}
+
+		return matchmaking_use_cases.NewCreateEconomyConfigUseCase(economyReader, economyWriter), nil
+	})
+
+	if err != nil {
+		slog.Error("Failed to load matchmaking_in.CreateEconomyConfigCommand.")
+		panic(err)
+	}
+
+	err = c.Singleton(func() (matchmaking_in.UpdatePoolStrategyCommand, error) {
+		var poolReader matchmaking_out.MatchmakingPoolReader
+		err := c.Resolve(&poolReader)
+		if err != nil {
+			slog.Error("Failed to resolve matchmaking_out.MatchmakingPoolReader for UpdatePoolStrategyCommand.", "err", err)
+			return nil, err
+		}
+
+		var poolWriter matchmaking_out.MatchmakingPoolWriter
+		err = c.Resolve(&poolWriter)
+		if err != nil {
+			slog.Error("Failed to resolve matchmaking_out.MatchmakingPoolWriter for UpdatePoolStrategyCommand.", "err", err)
+			return nil, err
+		}
+
+		return matchmaking_use_cases.NewUpdatePoolStrategyUseCase(poolReader, poolWriter), nil
+	})
+
+	if err != nil {
+		slog.Error("Failed to load matchmaking_in.UpdatePoolStrategyCommand.")
+		panic(err)
+	}
+
+	err = c.Singleton(func() (matchmaking_in.UpdatePoolScheduleCommand, error) {
+		var poolReader matchmaking_out.MatchmakingPoolReader
+		err := c.Resolve(&poolReader)
+		if err != nil {
+			slog.Error("Failed to resolve matchmaking_out.MatchmakingPoolReader for UpdatePoolScheduleCommand.", "err", err)
+			return nil, err
+		}
+
+		var poolWriter matchmaking_out.MatchmakingPoolWriter
+		err = c.Resolve(&poolWriter)
+		if err != nil {
+			slog.Error("Failed to resolve matchmaking_out.MatchmakingPoolWriter for UpdatePoolScheduleCommand.", "err", err)
+			return nil, err
+		}
+
+		return matchmaking_use_cases.NewUpdatePoolScheduleUseCase(poolReader, poolWriter), nil
+	})
+
+	if err != nil {
+		slog.Error("Failed to load matchmaking_in.UpdatePoolScheduleCommand.")
+		panic(err)
+	}
+
+	err = c.Singleton(func() (matchmaking_in.DrainQueueCommand, error) {
+		var poolReader matchmaking_out.MatchmakingPoolReader
+		err := c.Resolve(&poolReader)
+		if err != nil {
+			slog.Error("Failed to resolve matchmaking_out.MatchmakingPoolReader for DrainQueueCommand.", "err", err)
+			return nil, err
+		}
+
+		var ticketReader matchmaking_out.QueueTicketReader
+		err = c.Resolve(&ticketReader)
+		if err != nil {
+			slog.Error("Failed to resolve matchmaking_out.QueueTicketReader for DrainQueueCommand.", "err", err)
+			return nil, err
+		}
+
+		var ticketWriter matchmaking_out.QueueTicketWriter
+		err = c.Resolve(&ticketWriter)
+		if err != nil {
+			slog.Error("Failed to resolve matchmaking_out.QueueTicketWriter for DrainQueueCommand.", "err", err)
+			return nil, err
+		}
+
+		return matchmaking_use_cases.NewDrainQueueUseCase(poolReader, ticketReader, ticketWriter), nil
+	})
+
+	if err != nil {
+		slog.Error("Failed to load matchmaking_in.DrainQueueCommand.")
+		panic(err)
+	}
+
+	err = c.Singleton(func() (matchmaking_in.EnqueuePlayerCommandHandler, error) {
+		var poolReader matchmaking_out.MatchmakingPoolReader
+		err := c.Resolve(&poolReader)
+		if err != nil {
+			slog.Error("Failed to resolve matchmaking_out.MatchmakingPoolReader for EnqueuePlayerCommandHandler.", "err", err)
+			return nil, err
+		}
+
+		var ticketReader matchmaking_out.QueueTicketReader
+		err = c.Resolve(&ticketReader)
+		if err != nil {
+			slog.Error("Failed to resolve matchmaking_out.QueueTicketReader for EnqueuePlayerCommandHandler.", "err", err)
+			return nil, err
+		}
+
+		var ticketWriter matchmaking_out.QueueTicketWriter
+		err = c.Resolve(&ticketWriter)
+		if err != nil {
+			slog.Error("Failed to resolve matchmaking_out.QueueTicketWriter for EnqueuePlayerCommandHandler.", "err", err)
+			return nil, err
+		}
+
+		var ratingReader matchmaking_out.PlayerRatingReader
+		err = c.Resolve(&ratingReader)
+		if err != nil {
+			slog.Error("Failed to resolve matchmaking_out.PlayerRatingReader for EnqueuePlayerCommandHandler.", "err", err)
+			return nil, err
+		}
+
+		var penaltyReader matchmaking_out.QueuePenaltyReader
+		err = c.Resolve(&penaltyReader)
+		if err != nil {
+			slog.Error("Failed to resolve matchmaking_out.QueuePenaltyReader for EnqueuePlayerCommandHandler.", "err", err)
+			return nil, err
+		}
+
+		return matchmaking_use_cases.NewEnqueuePlayerUseCase(poolReader, ticketReader, ticketWriter, ratingReader, penaltyReader), nil
+	})
+
+	if err != nil {
+		slog.Error("Failed to load matchmaking_in.EnqueuePlayerCommandHandler.")
+		panic(err)
+	}
+
+	err = c.Singleton(func() (matchmaking_in.LeaveQueueCommand, error) {
+		var ticketReader matchmaking_out.QueueTicketReader
+		err := c.Resolve(&ticketReader)
+		if err != nil {
+			slog.Error("Failed to resolve matchmaking_out.QueueTicketReader for LeaveQueueCommand.", "err", err)
+			return nil, err
+		}
+
+		var ticketWriter matchmaking_out.QueueTicketWriter
+		err = c.Resolve(&ticketWriter)
+		if err != nil {
+			slog.Error("Failed to resolve matchmaking_out.QueueTicketWriter for LeaveQueueCommand.", "err", err)
+			return nil, err
+		}
+
+		return matchmaking_use_cases.NewLeaveQueueUseCase(ticketReader, ticketWriter), nil
+	})
+
+	if err != nil {
+		slog.Error("Failed to load matchmaking_in.LeaveQueueCommand.")
+		panic(err)
+	}
+
+	err = c.Singleton(func() (matchmaking_in.GetQueueStatusQuery, error) {
+		var ticketReader matchmaking_out.QueueTicketReader
+		err := c.Resolve(&ticketReader)
+		if err != nil {
+			slog.Error("Failed to resolve matchmaking_out.QueueTicketReader for GetQueueStatusQuery.", "err", err)
+			return nil, err
+		}
+
+		return matchmaking_use_cases.NewGetQueueStatusUseCase(ticketReader), nil
+	})
+
+	if err != nil {
+		slog.Error("Failed to load matchmaking_in.GetQueueStatusQuery.")
+		panic(err)
+	}
+
+	err = c.Singleton(func() (matchmaking_in.GetMatchmakingSessionQuery, error) {
+		var ticketReader matchmaking_out.QueueTicketReader
+		err := c.Resolve(&ticketReader)
+		if err != nil {
+			slog.Error("Failed to resolve matchmaking_out.QueueTicketReader for GetMatchmakingSessionQuery.", "err", err)
+			return nil, err
+		}
+
+		var lobbyReader matchmaking_out.LobbyReader
+		err = c.Resolve(&lobbyReader)
+		if err != nil {
+			slog.Error("Failed to resolve matchmaking_out.LobbyReader for GetMatchmakingSessionQuery.", "err", err)
+			return nil, err
+		}
+
+		var getQueueStatusQuery matchmaking_in.GetQueueStatusQuery
+		err = c.Resolve(&getQueueStatusQuery)
+		if err != nil {
+			slog.Error("Failed to resolve matchmaking_in.GetQueueStatusQuery for GetMatchmakingSessionQuery.", "err", err)
+			return nil, err
+		}
+
+		return matchmaking_use_cases.NewGetMatchmakingSessionUseCase(ticketReader, lobbyReader, getQueueStatusQuery), nil
+	})
+
+	if err != nil {
+		slog.Error("Failed to load matchmaking_in.GetMatchmakingSessionQuery.")
+		panic(err)
+	}
+
+	err = c.Singleton(func() (matchmaking_in.RunMatchmakingCommand, error) {
+		var poolReader matchmaking_out.MatchmakingPoolReader
+		err := c.Resolve(&poolReader)
+		if err != nil {
+			slog.Error("Failed to resolve matchmaking_out.MatchmakingPoolReader for RunMatchmakingCommand.", "err", err)
+			return nil, err
+		}
+
+		var ticketReader matchmaking_out.QueueTicketReader
+		err = c.Resolve(&ticketReader)
+		if err != nil {
+			slog.Error("Failed to resolve matchmaking_out.QueueTicketReader for RunMatchmakingCommand.", "err", err)
+			return nil, err
+		}
+
+		var ticketWriter matchmaking_out.QueueTicketWriter
+		err = c.Resolve(&ticketWriter)
+		if err != nil {
+			slog.Error("Failed to resolve matchmaking_out.QueueTicketWriter for RunMatchmakingCommand.", "err", err)
+			return nil, err
+		}
+
+		var lobbyReader matchmaking_out.LobbyReader
+		err = c.Resolve(&lobbyReader)
+		if err != nil {
+			slog.Error("Failed to resolve matchmaking_out.LobbyReader for RunMatchmakingCommand.", "err", err)
+			return nil, err
+		}
+
+		var lobbyWriter matchmaking_out.LobbyWriter
+		err = c.Resolve(&lobbyWriter)
+		if err != nil {
+			slog.Error("Failed to resolve matchmaking_out.LobbyWriter for RunMatchmakingCommand.", "err", err)
+			return nil, err
+		}
+
+		var evaluationWriter matchmaking_out.StrategyEvaluationWriter
+		err = c.Resolve(&evaluationWriter)
+		if err != nil {
+			slog.Error("Failed to resolve matchmaking_out.StrategyEvaluationWriter for RunMatchmakingCommand.", "err", err)
+			return nil, err
+		}
+
+		var economyReader matchmaking_out.EconomyConfigReader
+		err = c.Resolve(&economyReader)
+		if err != nil {
+			slog.Error("Failed to resolve matchmaking_out.EconomyConfigReader for RunMatchmakingCommand.", "err", err)
+			return nil, err
+		}
+
+		var config common.Config
+		err = c.Resolve(&config)
+		if err != nil {
+			slog.Error("Failed to resolve config for RunMatchmakingCommand.", "err", err)
+			return nil, err
+		}
+
+		// lobby.created is only published with a broker (RABBITMQ_URL)
+		var eventPublisher matchmaking_out.LobbyEventPublisher
+		if config.RabbitMQ.URL != "" {
+			var publisher *rabbitmq.MatchmakingEventPublisher
+			err = c.Resolve(&publisher)
+			if err != nil {
+				slog.Error("Failed to resolve rabbitmq.MatchmakingEventPublisher for RunMatchmakingCommand.", "err", err)
+				return nil, err
+			}
+
+			eventPublisher = publisher
+		}
+
+		return matchmaking_use_cases.NewRunMatchmakingUseCase(poolReader, ticketReader, ticketWriter, lobbyReader, lobbyWriter, evaluationWriter, economyReader, eventPublisher), nil
+	})
+
+	if err != nil {
+		slog.Error("Failed to load matchmaking_in.RunMatchmakingCommand.")
+		panic(err)
+	}
+
+	err = c.Singleton(func() (matchmaking_in.RecoverMatchmakingQueuesCommand, error) {
+		var poolReader matchmaking_out.MatchmakingPoolReader
+		err := c.Resolve(&poolReader)
+		if err != nil {
+			slog.Error("Failed to resolve matchmaking_out.MatchmakingPoolReader for RecoverMatchmakingQueuesCommand.", "err", err)
+			return nil, err
+		}
+
+		var ticketReader matchmaking_out.QueueTicketReader
+		err = c.Resolve(&ticketReader)
+		if err != nil {
+			slog.Error("Failed to resolve matchmaking_out.QueueTicketReader for RecoverMatchmakingQueuesCommand.", "err", err)
+			return nil, err
+		}
+
+		var ticketWriter matchmaking_out.QueueTicketWriter
+		err = c.Resolve(&ticketWriter)
+		if err != nil {
+			slog.Error("Failed to resolve matchmaking_out.QueueTicketWriter for RecoverMatchmakingQueuesCommand.", "err", err)
+			return nil, err
+		}
+
+		var lobbyReader matchmaking_out.LobbyReader
+		err = c.Resolve(&lobbyReader)
+		if err != nil {
+			slog.Error("Failed to resolve matchmaking_out.LobbyReader for RecoverMatchmakingQueuesCommand.", "err", err)
+			return nil, err
+		}
+
+		return matchmaking_use_cases.NewRecoverMatchmakingQueuesUseCase(poolReader, ticketReader, ticketWriter, lobbyReader), nil
+	})
+
+	if err != nil {
+		slog.Error("Failed to load matchmaking_in.RecoverMatchmakingQueuesCommand.")
+		panic(err)
+	}
+
+	err = c.Singleton(func() (matchmaking_in.MatchmakingPoolReader, error) {
+		var poolReader matchmaking_out.MatchmakingPoolReader
+		err := c.Resolve(&poolReader)
+		if err != nil {
+			slog.Error("Failed to resolve matchmaking_out.MatchmakingPoolReader for matchmaking_in.MatchmakingPoolReader.", "err", err)
+			return nil, err
+		}
+
+		return matchmaking_services.NewMatchmakingPoolQueryService(poolReader), nil
+	})
+
+	if err != nil {
+		slog.Error("Failed to load matchmaking_in.MatchmakingPoolReader.")
+		panic(err)
+	}
+
+	err = c.Singleton(func() (matchmaking_in.StrategyEvaluationReader, error) {
+		var evaluationReader matchmaking_out.StrategyEvaluationReader
+		err := c.Resolve(&evaluationReader)
+		if err != nil {
+			slog.Error("Failed to resolve matchmaking_out.StrategyEvaluationReader for matchmaking_in.StrategyEvaluationReader.", "err", err)
+			return nil, err
+		}
+
+		return matchmaking_services.NewStrategyEvaluationQueryService(evaluationReader), nil
+	})
+
+	if err != nil {
+		slog.Error("Failed to load matchmaking_in.StrategyEvaluationReader.")
+		panic(err)
+	}
+
+	err = c.Singleton(func() (consent_in.PublishPolicyVersionCommand, error) {
+		var policyReader consent_out.PolicyVersionReader
+		err := c.Resolve(&policyReader)
+		if err != nil {
+			slog.Error("Failed to resolve consent_out.PolicyVersionReader for PublishPolicyVersionCommand.", "err", err)
+			return nil, err
+		}
+
+		var policyWriter consent_out.PolicyVersionWriter
+		err = c.Resolve(&policyWriter)
+		if err != nil {
+			slog.Error("Failed to resolve consent_out.PolicyVersionWriter for PublishPolicyVersionCommand.", "err", err)
+			return nil, err
+		}
+
+		return consent_use_cases.NewPublishPolicyVersionUseCase(policyReader, policyWriter), nil
+	})
+
+	if err != nil {
+		slog.Error("Failed to load consent_in.PublishPolicyVersionCommand.")
+		panic(err)
+	}
+
+	err = c.Singleton(func() (consent_in.AcceptPolicyCommandHandler, error) {
+		var policyReader consent_out.PolicyVersionReader
+		err := c.Resolve(&policyReader)
+		if err != nil {
+			slog.Error("Failed to resolve consent_out.PolicyVersionReader for AcceptPolicyCommandHandler.", "err", err)
+			return nil, err
+		}
+
+		var consentWriter consent_out.ConsentRecordWriter
+		err = c.Resolve(&consentWriter)
+		if err != nil {
+			slog.Error("Failed to resolve consent_out.ConsentRecordWriter for AcceptPolicyCommandHandler.", "err", err)
+			return nil, err
+		}
+
+		return consent_use_cases.NewAcceptPolicyUseCase(policyReader, consentWriter), nil
+	})
+
+	if err != nil {
+		slog.Error("Failed to load consent_in.AcceptPolicyCommandHandler.")
+		panic(err)
+	}
+
+	err = c.Singleton(func() (consent_in.GetConsentStatusQuery, error) {
+		var policyReader consent_out.PolicyVersionReader
+		err := c.Resolve(&policyReader)
+		if err != nil {
+			slog.Error("Failed to resolve consent_out.PolicyVersionReader for GetConsentStatusQuery.", "err", err)
+			return nil, err
+		}
+
+		var consentReader consent_out.ConsentRecordReader
+		err = c.Resolve(&consentReader)
+		if err != nil {
+			slog.Error("Failed to resolve consent_out.ConsentRecordReader for GetConsentStatusQuery.", "err", err)
+			return nil, err
+		}
+
+		return consent_use_cases.NewGetConsentStatusUseCase(policyReader, consentReader), nil
+	})
+
+	if err != nil {
+		slog.Error("Failed to load consent_in.GetConsentStatusQuery.")
+		panic(err)
+	}
+
+	err = c.Singleton(func() (email_in.RequestMagicLinkCommandHandler, error) {
+		var tokenReader email_out.EmailLoginTokenReader
+		err := c.Resolve(&tokenReader)
+		if err != nil {
+			slog.Error("Failed to resolve email_out.EmailLoginTokenReader for RequestMagicLinkCommandHandler.", "err", err)
+			return nil, err
+		}
+
+		var tokenWriter email_out.EmailLoginTokenWriter
+		err = c.Resolve(&tokenWriter)
+		if err != nil {
+			slog.Error("Failed to resolve email_out.EmailLoginTokenWriter for RequestMagicLinkCommandHandler.", "err", err)
+			return nil, err
+		}
+
+		var sender email_out.MagicLinkSender
+		err = c.Resolve(&sender)
+		if err != nil {
+			slog.Error("Failed to resolve email_out.MagicLinkSender for RequestMagicLinkCommandHandler.", "err", err)
+			return nil, err
+		}
+
+		var config common.Config
+		err = c.Resolve(&config)
+		if err != nil {
+			slog.Error("Failed to resolve config for RequestMagicLinkCommandHandler.", "err", err)
+			return nil, err
+		}
+
+		return email_use_cases.NewRequestMagicLinkUseCase(tokenReader, tokenWriter, sender, config.Email.BlockedDomains), nil
+	})
+
+	if err != nil {
+		slog.Error("Failed to load email_in.RequestMagicLinkCommandHandler.")
+		panic(err)
+	}
+
+	err = c.Singleton(func() (email_in.VerifyMagicLinkCommandHandler, error) {
+		var tokenWriter email_out.EmailLoginTokenWriter
+		err := c.Resolve(&tokenWriter)
+		if err != nil {
+			slog.Error("Failed to resolve email_out.EmailLoginTokenWriter for VerifyMagicLinkCommandHandler.", "err", err)
+			return nil, err
+		}
+
+		var onboardOpenIDUser iam_in.OnboardOpenIDUserCommandHandler
+		err = c.Resolve(&onboardOpenIDUser)
+		if err != nil {
+			slog.Error("Failed to resolve OnboardOpenIDUserCommandHandler for VerifyMagicLinkCommandHandler.", "err", err)
+			return nil, err
+		}
+
+		return email_use_cases.NewVerifyMagicLinkUseCase(tokenWriter, onboardOpenIDUser), nil
+	})
+
+	if err != nil {
+		slog.Error("Failed to load email_in.VerifyMagicLinkCommandHandler.")
+		panic(err)
+	}
+
+	err = c.Singleton(func() (export_in.CreateExportConfigCommand, error) {
+		var configWriter export_out.ExportConfigWriter
+		err := c.Resolve(&configWriter)
+		if err != nil {
+			slog.Error("Failed to resolve export_out.ExportConfigWriter for CreateExportConfigCommand.", "err", err)
+			return nil, err
+		}
+
+		return export_use_cases.NewCreateExportConfigUseCase(configWriter), nil
+	})
+
+	if err != nil {
+		slog.Error("Failed to load export_in.CreateExportConfigCommand.")
+		panic(err)
+	}
+
+	err = c.Singleton(func() (export_in.RunScheduledExportsCommand, error) {
+		var configWriter export_out.ExportConfigWriter
+		err := c.Resolve(&configWriter)
+		if err != nil {
+			slog.Error("Failed to resolve export_out.ExportConfigWriter for RunScheduledExportsCommand.", "err", err)
+			return nil, err
+		}
+
+		var receiptWriter export_out.ExportReceiptWriter
+		err = c.Resolve(&receiptWriter)
+		if err != nil {
+			slog.Error("Failed to resolve export_out.ExportReceiptWriter for RunScheduledExportsCommand.", "err", err)
+			return nil, err
+		}
+
+		var delivery export_out.ExportDelivery
+		err = c.Resolve(&delivery)
+		if err != nil {
+			slog.Error("Failed to resolve export_out.ExportDelivery for RunScheduledExportsCommand.", "err", err)
+			return nil, err
+		}
+
+		var matchReader replay_out.MatchMetadataReader
+		err = c.Resolve(&matchReader)
+		if err != nil {
+			slog.Error("Failed to resolve replay_out.MatchMetadataReader for RunScheduledExportsCommand.", "err", err)
+			return nil, err
+		}
+
+		var statsReader replay_out.PlayerStatsReader
+		err = c.Resolve(&statsReader)
+		if err != nil {
+			slog.Error("Failed to resolve replay_out.PlayerStatsReader for RunScheduledExportsCommand.", "err", err)
+			return nil, err
+		}
+
+		var timelineReader replay_out.RoundTimelineReader
+		err = c.Resolve(&timelineReader)
+		if err != nil {
+			slog.Error("Failed to resolve replay_out.RoundTimelineReader for RunScheduledExportsCommand.", "err", err)
+			return nil, err
+		}
+
+		datasets := map[export_entities.ExportDataset]export_out.ExportDatasetReader{
+			export_entities.ExportDatasetMatches:        export_services.NewMatchesExportDataset(matchReader),
+			export_entities.ExportDatasetPlayerStats:    export_services.NewPlayerStatsExportDataset(statsReader),
+			export_entities.ExportDatasetRoundTimelines: export_services.NewRoundTimelinesExportDataset(timelineReader),
+		}
+
+		return export_use_cases.NewRunScheduledExportsUseCase(configWriter, receiptWriter, delivery, datasets), nil
+	})
+
+	if err != nil {
+		slog.Error("Failed to load export_in.RunScheduledExportsCommand.")
+		panic(err)
+	}
+
+	err = c.Singleton(func() (export_in.ExportConfigReader, error) {
+		var configReader export_out.ExportConfigReader
+		err := c.Resolve(&configReader)
+		if err != nil {
+			slog.Error("Failed to resolve export_out.ExportConfigReader for export_in.ExportConfigReader.", "err", err)
+			return nil, err
+		}
+
+		return export_services.NewExportConfigQueryService(configReader), nil
+	})
+
+	if err != nil {
+		slog.Error("Failed to load export_in.ExportConfigReader.")
+		panic(err)
+	}
+
+	err = c.Singleton(func() (export_in.ExportReceiptReader, error) {
+		var receiptReader export_out.ExportReceiptReader
+		err := c.Resolve(&receiptReader)
+		if err != nil {
+			slog.Error("Failed to resolve export_out.ExportReceiptReader for export_in.ExportReceiptReader.", "err", err)
+			return nil, err
+		}
+
+		return export_services.NewExportReceiptQueryService(receiptReader), nil
+	})
+
+	if err != nil {
+		slog.Error("Failed to load export_in.ExportReceiptReader.")
+		panic(err)
+	}
+
+	err = c.Singleton(func() (notification_in.RegisterDeviceTokenCommandHandler, error) {
+		var tokenReader notification_out.DeviceTokenReader
+		err := c.Resolve(&tokenReader)
+		if err != nil {
+			slog.Error("Failed to resolve notification_out.DeviceTokenReader for RegisterDeviceTokenCommandHandler.", "err", err)
+			return nil, err
+		}
+
+		var tokenWriter notification_out.DeviceTokenWriter
+		err = c.Resolve(&tokenWriter)
+		if err != nil {
+			slog.Error("Failed to resolve notification_out.DeviceTokenWriter for RegisterDeviceTokenCommandHandler.", "err", err)
+			return nil, err
+		}
+
+		return notification_use_cases.NewRegisterDeviceTokenUseCase(tokenReader, tokenWriter), nil
+	})
+
+	if err != nil {
+		slog.Error("Failed to load notification_in.RegisterDeviceTokenCommandHandler.")
+		panic(err)
+	}
+
+	err = c.Singleton(func() (notification_in.UnregisterDeviceTokenCommandHandler, error) {
+		var tokenReader notification_out.DeviceTokenReader
+		err := c.Resolve(&tokenReader)
+		if err != nil {
+			slog.Error("Failed to resolve notification_out.DeviceTokenReader for UnregisterDeviceTokenCommandHandler.", "err", err)
+			return nil, err
+		}
+
+		var tokenWriter notification_out.DeviceTokenWriter
+		err = c.Resolve(&tokenWriter)
+		if err != nil {
+			slog.Error("Failed to resolve notification_out.DeviceTokenWriter for UnregisterDeviceTokenCommandHandler.", "err", err)
+			return nil, err
+		}
+
+		return notification_use_cases.NewUnregisterDeviceTokenUseCase(tokenReader, tokenWriter), nil
+	})
+
+	if err != nil {
+		slog.Error("Failed to load notification_in.UnregisterDeviceTokenCommandHandler.")
+		panic(err)
+	}
+
+	err = c.Singleton(func() (notification_in.NotifyMatchFoundCommandHandler, error) {
+		var tokenReader notification_out.DeviceTokenReader
+		err := c.Resolve(&tokenReader)
+		if err != nil {
+			slog.Error("Failed to resolve notification_out.DeviceTokenReader for NotifyMatchFoundCommandHandler.", "err", err)
+			return nil, err
+		}
+
+		var tokenWriter notification_out.DeviceTokenWriter
+		err = c.Resolve(&tokenWriter)
+		if err != nil {
+			slog.Error("Failed to resolve notification_out.DeviceTokenWriter for NotifyMatchFoundCommandHandler.", "err", err)
+			return nil, err
+		}
+
+		var receiptWriter notification_out.PushReceiptWriter
+		err = c.Resolve(&receiptWriter)
+		if err != nil {
+			slog.Error("Failed to resolve notification_out.PushReceiptWriter for NotifyMatchFoundCommandHandler.", "err", err)
+			return nil, err
+		}
+
+		var sender notification_out.PushSender
+		err = c.Resolve(&sender)
+		if err != nil {
+			slog.Error("Failed to resolve notification_out.PushSender for NotifyMatchFoundCommandHandler.", "err", err)
+			return nil, err
+		}
+
+		return notification_use_cases.NewNotifyMatchFoundUseCase(tokenReader, tokenWriter, receiptWriter, sender), nil
+	})
+
+	if err != nil {
+		slog.Error("Failed to load notification_in.NotifyMatchFoundCommandHandler.")
+		panic(err)
+	}
+
+	err = c.Singleton(func() (notification_in.PushReceiptReader, error) {
+		var receiptReader notification_out.PushReceiptReader
+		err := c.Resolve(&receiptReader)
+		if err != nil {
+			slog.Error("Failed to resolve notification_out.PushReceiptReader for notification_in.PushReceiptReader.", "err", err)
+			return nil, err
+		}
+
+		return notification_services.NewPushReceiptQueryService(receiptReader), nil
+	})
+
+	if err != nil {
+		slog.Error("Failed to load notification_in.PushReceiptReader.")
+		panic(err)
+	}
+
+	err = c.Singleton(func() (notification_in.NotifyWeeklyRecapCommandHandler, error) {
+		var tokenReader notification_out.DeviceTokenReader
+		err := c.Resolve(&tokenReader)
+		if err != nil {
+			slog.Error("Failed to resolve notification_out.DeviceTokenReader for NotifyWeeklyRecapCommandHandler.", "err", err)
+			return nil, err
+		}
+
+		var tokenWriter notification_out.DeviceTokenWriter
+		err = c.Resolve(&tokenWriter)
+		if err != nil {
+			slog.Error("Failed to resolve notification_out.DeviceTokenWriter for NotifyWeeklyRecapCommandHandler.", "err", err)
+			return nil, err
+		}
+
+		var receiptWriter notification_out.PushReceiptWriter
+		err = c.Resolve(&receiptWriter)
+		if err != nil {
+			slog.Error("Failed to resolve notification_out.PushReceiptWriter for NotifyWeeklyRecapCommandHandler.", "err", err)
+			return nil, err
+		}
+
+		var sender notification_out.PushSender
+		err = c.Resolve(&sender)
+		if err != nil {
+			slog.Error("Failed to resolve notification_out.PushSender for NotifyWeeklyRecapCommandHandler.", "err", err)
+			return nil, err
+		}
+
+		return notification_use_cases.NewNotifyWeeklyRecapUseCase(tokenReader, tokenWriter, receiptWriter, sender), nil
+	})
+
+	if err != nil {
+		slog.Error("Failed to load notification_in.NotifyWeeklyRecapCommandHandler.")
+		panic(err)
+	}
+
+	err = c.Singleton(func() (notification_in.DispatchNotificationCommandHandler, error) {
+		var notificationReader notification_out.NotificationReader
+		err := c.Resolve(&notificationReader)
+		if err != nil {
+			slog.Error("Failed to resolve notification_out.NotificationReader for DispatchNotificationCommandHandler.", "err", err)
+			return nil, err
+		}
+
+		var notificationWriter notification_out.NotificationWriter
+		err = c.Resolve(&notificationWriter)
+		if err != nil {
+			slog.Error("Failed to resolve notification_out.NotificationWriter for DispatchNotificationCommandHandler.", "err", err)
+			return nil, err
+		}
+
+		var preferencesReader notification_out.NotificationPreferencesReader
+		err = c.Resolve(&preferencesReader)
+		if err != nil {
+			slog.Error("Failed to resolve notification_out.NotificationPreferencesReader for DispatchNotificationCommandHandler.", "err", err)
+			return nil, err
+		}
+
+		var tokenReader notification_out.DeviceTokenReader
+		err = c.Resolve(&tokenReader)
+		if err != nil {
+			slog.Error("Failed to resolve notification_out.DeviceTokenReader for DispatchNotificationCommandHandler.", "err", err)
+			return nil, err
+		}
+
+		var tokenWriter notification_out.DeviceTokenWriter
+		err = c.Resolve(&tokenWriter)
+		if err != nil {
+			slog.Error("Failed to resolve notification_out.DeviceTokenWriter for DispatchNotificationCommandHandler.", "err", err)
+			return nil, err
+		}
+
+		var receiptWriter notification_out.PushReceiptWriter
+		err = c.Resolve(&receiptWriter)
+		if err != nil {
+			slog.Error("Failed to resolve notification_out.PushReceiptWriter for DispatchNotificationCommandHandler.", "err", err)
+			return nil, err
+		}
+
+		var sender notification_out.PushSender
+		err = c.Resolve(&sender)
+		if err != nil {
+			slog.Error("Failed to resolve notification_out.PushSender for DispatchNotificationCommandHandler.", "err", err)
+			return nil, err
+		}
+
+		var config common.Config
+		err = c.Resolve(&config)
+		if err != nil {
+			slog.Error("Failed to resolve config for DispatchNotificationCommandHandler.", "err", err)
+			return nil, err
+		}
+
+		// emails go through the relay of the magic links
+		channels := map[notification_entities.NotificationChannel]notification_out.NotificationChannelSender{
+			notification_entities.NotificationChannelEmail:   mail.NewSMTPNotificationSender(config.Email),
+			notification_entities.NotificationChannelDiscord: discord.NewWebhookSender(),
+		}
+
+		return notification_use_cases.NewDispatchNotificationUseCase(notificationReader, notificationWriter, preferencesReader, tokenReader, tokenWriter, receiptWriter, sender, channels), nil
+	})
+
+	if err != nil {
+		slog.Error("Failed to load notification_in.DispatchNotificationCommandHandler.")
+		panic(err)
+	}
+
+	err = c.Singleton(func() (notification_in.NotificationReader, error) {
+		var notificationReader notification_out.NotificationReader
+		err := c.Resolve(&notificationReader)
+		if err != nil {
+			slog.Error("Failed to resolve notification_out.NotificationReader for notification_in.NotificationReader.", "err", err)
+			return nil, err
+		}
+
+		return notification_services.NewNotificationQueryService(notificationReader), nil
+	})
+
+	if err != nil {
+		slog.Error("Failed to load notification_in.NotificationReader.")
+		panic(err)
+	}
+
+	err = c.Singleton(func() (notification_in.MarkNotificationReadCommandHandler, error) {
+		var notificationReader notification_out.NotificationReader
+		err := c.Resolve(&notificationReader)
+		if err != nil {
+			slog.Error("Failed to resolve notification_out.NotificationReader for MarkNotificationReadCommandHandler.", "err", err)
+			return nil, err
+		}
+
+		var notificationWriter notification_out.NotificationWriter
+		err = c.Resolve(&notificationWriter)
+		if err != nil {
+			slog.Error("Failed to resolve notification_out.NotificationWriter for MarkNotificationReadCommandHandler.", "err", err)
+			return nil, err
+		}
+
+		return notification_use_cases.NewMarkNotificationReadUseCase(notificationReader, notificationWriter), nil
+	})
+
+	if err != nil {
+		slog.Error("Failed to load notification_in.MarkNotificationReadCommandHandler.")
+		panic(err)
+	}
+
+	err = c.Singleton(func() (notification_in.NotificationPreferencesQuery, error) {
+		var preferencesReader notification_out.NotificationPreferencesReader
+		err := c.Resolve(&preferencesReader)
+		if err != nil {
+			slog.Error("Failed to resolve notification_out.NotificationPreferencesReader for NotificationPreferencesQuery.", "err", err)
+			return nil, err
+		}
+
+		return notification_use_cases.NewGetNotificationPreferencesUseCase(preferencesReader), nil
+	})
+
+	if err != nil {
+		slog.Error("Failed to load notification_in.NotificationPreferencesQuery.")
+		panic(err)
+	}
+
+	err = c.Singleton(func() (notification_in.UpdateNotificationPreferencesCommandHandler, error) {
+		var preferencesReader notification_out.NotificationPreferencesReader
+		err := c.Resolve(&preferencesReader)
+		if err != nil {
+			slog.Error("Failed to resolve notification_out.NotificationPreferencesReader for UpdateNotificationPreferencesCommandHandler.", "err", err)
+			return nil, err
+		}
+
+		var preferencesWriter notification_out.NotificationPreferencesWriter
+		err = c.Resolve(&preferencesWriter)
+		if err != nil {
+			slog.Error("Failed to resolve notification_out.NotificationPreferencesWriter for UpdateNotificationPreferencesCommandHandler.", "err", err)
+			return nil, err
+		}
+
+		return notification_use_cases.NewUpdateNotificationPreferencesUseCase(preferencesReader, preferencesWriter), nil
+	})
+
+	if err != nil {
+		slog.Error("Failed to load notification_in.UpdateNotificationPreferencesCommandHandler.")
+		panic(err)
+	}
+
+	// custom fields (defined by the client applications, set on their squads and players)
+	err = c.Singleton(func() (customfield_in.DefineCustomFieldCommand, error) {
+		var definitionReader customfield_out.CustomFieldDefinitionReader
+		err := c.Resolve(&definitionReader)
+		if err != nil {
+			slog.Error("Failed to resolve customfield_out.CustomFieldDefinitionReader for customfield_in.DefineCustomFieldCommand.", "err", err)
+			return nil, err
+		}
+
+		var definitionWriter customfield_out.CustomFieldDefinitionWriter
+		err = c.Resolve(&definitionWriter)
+		if err != nil {
+			slog.Error("Failed to resolve customfield_out.CustomFieldDefinitionWriter for customfield_in.DefineCustomFieldCommand.", "err", err)
+			return nil, err
+		}
+
+		return customfield_use_cases.NewDefineCustomFieldUseCase(definitionReader, definitionWriter), nil
+	})
+
+	if err != nil {
+		slog.Error("Failed to load customfield_in.DefineCustomFieldCommand.")
+		panic(err)
+	}
+
+	err = c.Singleton(func() (customfield_in.SetCustomFieldsCommandHandler, error) {
+		var definitionReader customfield_out.CustomFieldDefinitionReader
+		err := c.Resolve(&definitionReader)
+		if err != nil {
+			slog.Error("Failed to resolve customfield_out.CustomFieldDefinitionReader for customfield_in.SetCustomFieldsCommandHandler.", "err", err)
+			return nil, err
+		}
+
+		var squadRepository *db.SquadRepository
+		err = c.Resolve(&squadRepository)
+		if err != nil {
+			slog.Error("Failed to resolve SquadRepository for customfield_in.SetCustomFieldsCommandHandler.", "err", err)
+			return nil, err
+		}
+
+		var playerRepository *db.PlayerRepository
+		err = c.Resolve(&playerRepository)
+		if err != nil {
+			slog.Error("Failed to resolve PlayerRepository for customfield_in.SetCustomFieldsCommandHandler.", "err", err)
+			return nil, err
+		}
+
+		return customfield_use_cases.NewSetCustomFieldsUseCase(definitionReader, map[customfield_entities.CustomFieldEntityType]customfield_out.CustomFieldsWriter{
+			customfield_entities.CustomFieldEntitySquad:  squadRepository,
+			customfield_entities.CustomFieldEntityPlayer: playerRepository,
+		}), nil
+	})
+
+	if err != nil {
+		slog.Error("Failed to load customfield_in.SetCustomFieldsCommandHandler.")
+		panic(err)
+	}
+
+	err = c.Singleton(func() (customfield_in.CustomFieldDefinitionReader, error) {
+		var definitionReader customfield_out.CustomFieldDefinitionReader
+		err := c.Resolve(&definitionReader)
+		if err != nil {
+			slog.Error("Failed to resolve customfield_out.CustomFieldDefinitionReader for customfield_in.CustomFieldDefinitionReader.", "err", err)
+			return nil, err
+		}
+
+		return customfield_services.NewCustomFieldDefinitionQueryService(definitionReader), nil
+	})
+
+	if err != nil {
+		slog.Error("Failed to load customfield_in.CustomFieldDefinitionReader.")
+		panic(err)
+	}
+
+	// weekly recaps
+	err = c.Singleton(func() (recap_in.GenerateWeeklyRecapsCommand, error) {
+		var activityReader recap_out.RecapActivityReader
+		err := c.Resolve(&activityReader)
+		if err != nil {
+			slog.Error("Failed to resolve recap_out.RecapActivityReader for GenerateWeeklyRecapsCommand.", "err", err)
+			return nil, err
+		}
+
+		var recapWriter recap_out.WeeklyRecapWriter
+		err = c.Resolve(&recapWriter)
+		if err != nil {
+			slog.Error("Failed to resolve recap_out.WeeklyRecapWriter for GenerateWeeklyRecapsCommand.", "err", err)
+			return nil, err
+		}
+
+		var recapReader recap_out.WeeklyRecapReader
+		err = c.Resolve(&recapReader)
+		if err != nil {
+			slog.Error("Failed to resolve recap_out.WeeklyRecapReader for GenerateWeeklyRecapsCommand.", "err", err)
+			return nil, err
+		}
+
+		return recap_use_cases.NewGenerateWeeklyRecapsUseCase(activityReader, recapWriter, recapReader), nil
+	})
+
+	if err != nil {
+		slog.Error("Failed to load recap_in.GenerateWeeklyRecapsCommand.")
+		panic(err)
+	}
+
+	err = c.Singleton(func() (recap_in.LatestPlayerRecapQuery, error) {
+		var recapReader recap_out.WeeklyRecapReader
+		err := c.Resolve(&recapReader)
+		if err != nil {
+			slog.Error("Failed to resolve recap_out.WeeklyRecapReader for LatestPlayerRecapQuery.", "err", err)
+			return nil, err
+		}
+
+		return recap_use_cases.NewGetLatestPlayerRecapUseCase(recapReader), nil
+	})
+
+	if err != nil {
+		slog.Error("Failed to load recap_in.LatestPlayerRecapQuery.")
+		panic(err)
+	}
+
+	err = c.Singleton(func() (rating_in.RateMatchCommand, error) {
+		var ratingReader rating_out.RatingReader
+		err := c.Resolve(&ratingReader)
+		if err != nil {
+			slog.Error("Failed to resolve rating_out.RatingReader for RateMatchCommand.", "err", err)
+			return nil, err
+		}
+
+		var ratingWriter rating_out.RatingWriter
+		err = c.Resolve(&ratingWriter)
+		if err != nil {
+			slog.Error("Failed to resolve rating_out.RatingWriter for RateMatchCommand.", "err", err)
+			return nil, err
+		}
+
+		var historyReader rating_out.RatingHistoryReader
+		err = c.Resolve(&historyReader)
+		if err != nil {
+			slog.Error("Failed to resolve rating_out.RatingHistoryReader for RateMatchCommand.", "err", err)
+			return nil, err
+		}
+
+		var historyWriter rating_out.RatingHistoryWriter
+		err = c.Resolve(&historyWriter)
+		if err != nil {
+			slog.Error("Failed to resolve rating_out.RatingHistoryWriter for RateMatchCommand.", "err", err)
+			return nil, err
+		}
+
+		var squadReader squad_out.SquadReader
+		err = c.Resolve(&squadReader)
+		if err != nil {
+			slog.Error("Failed to resolve squad_out.SquadReader for RateMatchCommand.", "err", err)
+			return nil, err
+		}
+
+		var seasonReader rating_out.ActiveSeasonReader
+		err = c.Resolve(&seasonReader)
+		if err != nil {
+			slog.Error("Failed to resolve rating_out.ActiveSeasonReader for RateMatchCommand.", "err", err)
+			return nil, err
+		}
+
+		return rating_use_cases.NewRatingService(ratingReader, ratingWriter, historyReader, historyWriter, squadReader, seasonReader), nil
+	})
+
+	if err != nil {
+		slog.Error("Failed to load rating_in.RateMatchCommand.")
+		panic(err)
+	}
+
+	err = c.Singleton(func() (rating_in.RatingHistoryReader, error) {
+		var historyReader rating_out.RatingHistoryReader
+		err := c.Resolve(&historyReader)
+		if err != nil {
+			slog.Error("Failed to resolve rating_out.RatingHistoryReader for rating_in.RatingHistoryReader.", "err", err)
+			return nil, err
+		}
+
+		return rating_services.NewRatingHistoryQueryService(historyReader), nil
+	})
+
+	if err != nil {
+		slog.Error("Failed to load rating_in.RatingHistoryReader.")
+		panic(err)
+	}
+
+	err = c.Singleton(func() (season_in.ScheduleSeasonCommand, error) {
+		var seasonReader season_out.SeasonReader
+		err := c.Resolve(&seasonReader)
+		if err != nil {
+			slog.Error("Failed to resolve season_out.SeasonReader for ScheduleSeasonCommand.", "err", err)
+			return nil, err
+		}
+
+		var seasonWriter season_out.SeasonWriter
+		err = c.Resolve(&seasonWriter)
+		if err != nil {
+			slog.Error("Failed to resolve season_out.SeasonWriter for ScheduleSeasonCommand.", "err", err)
+			return nil, err
+		}
+
+		return season_use_cases.NewScheduleSeasonUseCase(seasonReader, seasonWriter), nil
+	})
+
+	if err != nil {
+		slog.Error("Failed to load season_in.ScheduleSeasonCommand.")
+		panic(err)
+	}
+
+	err = c.Singleton(func() (season_in.TransitionSeasonsCommand, error) {
+		var seasonReader season_out.SeasonReader
+		err := c.Resolve(&seasonReader)
+		if err != nil {
+			slog.Error("Failed to resolve season_out.SeasonReader for TransitionSeasonsCommand.", "err", err)
+			return nil, err
+		}
+
+		var seasonWriter season_out.SeasonWriter
+		err = c.Resolve(&seasonWriter)
+		if err != nil {
+			slog.Error("Failed to resolve season_out.SeasonWriter for TransitionSeasonsCommand.", "err", err)
+			return nil, err
+		}
+
+		var rewardWriter season_out.SeasonRewardWriter
+		err = c.Resolve(&rewardWriter)
+		if err != nil {
+			slog.Error("Failed to resolve season_out.SeasonRewardWriter for TransitionSeasonsCommand.", "err", err)
+			return nil, err
+		}
+
+		var ratingReader rating_out.RatingReader
+		err = c.Resolve(&ratingReader)
+		if err != nil {
+			slog.Error("Failed to resolve rating_out.RatingReader for TransitionSeasonsCommand.", "err", err)
+			return nil, err
+		}
+
+		return season_use_cases.NewTransitionSeasonsUseCase(seasonReader, seasonWriter, rewardWriter, ratingReader), nil
+	})
+
+	if err != nil {
+		slog.Error("Failed to load season_in.TransitionSeasonsCommand.")
+		panic(err)
+	}
+
+	err = c.Singleton(func() (season_in.SeasonReader, error) {
+		var seasonReader season_out.SeasonReader
+		err := c.Resolve(&seasonReader)
+		if err != nil {
+			slog.Error("Failed to resolve season_out.SeasonReader for season_in.SeasonReader.", "err", err)
+			return nil, err
+		}
+
+		return season_services.NewSeasonQueryService(seasonReader), nil
+	})
+
+	if err != nil {
+		slog.Error("Failed to load season_in.SeasonReader.")
+		panic(err)
+	}
+
+	err = c.Singleton(func() (season_in.SeasonRewardReader, error) {
+		var rewardReader season_out.SeasonRewardReader
+		err := c.Resolve(&rewardReader)
+		if err != nil {
+			slog.Error("Failed to resolve season_out.SeasonRewardReader for season_in.SeasonRewardReader.", "err", err)
+			return nil, err
+		}
+
+		return season_services.NewSeasonRewardQueryService(rewardReader), nil
+	})
+
+	if err != nil {
+		slog.Error("Failed to load season_in.SeasonRewardReader.")
+		panic(err)
+	}
+
+	err = c.Singleton(func() (badge_in.CreateBadgeCommand, error) {
+		var badgeReader badge_out.BadgeReader
+		err := c.Resolve(&badgeReader)
+		if err != nil {
+			slog.Error("Failed to resolve badge_out.BadgeReader for badge_in.CreateBadgeCommand.", "err", err)
+			return nil, err
+		}
+
+		var badgeWriter badge_out.BadgeWriter
+		err = c.Resolve(&badgeWriter)
+		if err != nil {
+			slog.Error("Failed to resolve badge_out.BadgeWriter for badge_in.CreateBadgeCommand.", "err", err)
+			return nil, err
+		}
+
+		return badge_use_cases.NewCreateBadgeUseCase(badgeReader, badgeWriter), nil
+	})
+
+	if err != nil {
+		slog.Error("Failed to load badge_in.CreateBadgeCommand.")
+		panic(err)
+	}
+
+	err = c.Singleton(func() (badge_in.BadgeReader, error) {
+		var badgeReader badge_out.BadgeReader
+		err := c.Resolve(&badgeReader)
+		if err != nil {
+			slog.Error("Failed to resolve badge_out.BadgeReader for badge_in.BadgeReader.", "err", err)
+			return nil, err
+		}
+
+		return badge_services.NewBadgeQueryService(badgeReader), nil
+	})
+
+	if err != nil {
+		slog.Error("Failed to load badge_in.BadgeReader.")
+		panic(err)
+	}
+
+	err = c.Singleton(func() (badge_in.PlayerBadgeReader, error) {
+		var playerBadgeReader badge_out.PlayerBadgeReader
+		err := c.Resolve(&playerBadgeReader)
+		if err != nil {
+			slog.Error("Failed to resolve badge_out.PlayerBadgeReader for badge_in.PlayerBadgeReader.", "err", err)
+			return nil, err
+		}
+
+		return badge_services.NewPlayerBadgeQueryService(playerBadgeReader), nil
+	})
+
+	if err != nil {
+		slog.Error("Failed to load badge_in.PlayerBadgeReader.")
+		panic(err)
+	}
+
+	err = c.Singleton(func() (badge_in.PlayerBadgesQuery, error) {
+		var playerBadgeReader badge_out.PlayerBadgeReader
+		err := c.Resolve(&playerBadgeReader)
+		if err != nil {
+			slog.Error("Failed to resolve badge_out.PlayerBadgeReader for badge_in.PlayerBadgesQuery.", "err", err)
+			return nil, err
+		}
+
+		var networkIDReader badge_out.PlayerNetworkIDReader
+		err = c.Resolve(&networkIDReader)
+		if err != nil {
+			slog.Error("Failed to resolve badge_out.PlayerNetworkIDReader for badge_in.PlayerBadgesQuery.", "err", err)
+			return nil, err
+		}
+
+		return badge_use_cases.NewGetPlayerBadgesUseCase(playerBadgeReader, networkIDReader), nil
+	})
+
+	if err != nil {
+		slog.Error("Failed to load badge_in.PlayerBadgesQuery.")
+		panic(err)
+	}
+
+	// evaluated by the rating worker, once the players of a match are rated
+	err = c.Singleton(func() (*badges.MatchBadgeEvaluator, error) {
+		var ratingReader rating_out.RatingReader
+		err := c.Resolve(&ratingReader)
+		if err != nil {
+			slog.Error("Failed to resolve rating_out.RatingReader for badges.MatchBadgeEvaluator.", "err", err)
+			return nil, err
+		}
+
+		var evaluateCommand badge_in.EvaluateBadgesCommand
+		err = c.Resolve(&evaluateCommand)
+		if err != nil {
+			slog.Error("Failed to resolve badge_in.EvaluateBadgesCommand for badges.MatchBadgeEvaluator.", "err", err)
+			return nil, err
+		}
+
+		return badges.NewMatchBadgeEvaluator(ratingReader, evaluateCommand), nil
+	})
+
+	if err != nil {
+		slog.Error("Failed to load badges.MatchBadgeEvaluator.")
+		panic(err)
+	}
+
+	// the preview images of the shared profile links
+	err = c.Singleton(func() (replay_in.PlayerCardQuery, error) {
+		var playerReader replay_out.PlayerMetadataReader
+		err := c.Resolve(&playerReader)
+		if err != nil {
+			slog.Error("Failed to resolve replay_out.PlayerMetadataReader for replay_in.PlayerCardQuery.", "err", err)
+			return nil, err
+		}
+
+		var statsQuery replay_in.PlayerStatsQuery
+		err = c.Resolve(&statsQuery)
+		if err != nil {
+			slog.Error("Failed to resolve replay_in.PlayerStatsQuery for replay_in.PlayerCardQuery.", "err", err)
+			return nil, err
+		}
+
+		var historyReader replay_out.PlayerMatchHistoryReader
+		err = c.Resolve(&historyReader)
+		if err != nil {
+			slog.Error("Failed to resolve replay_out.PlayerMatchHistoryReader for replay_in.PlayerCardQuery.", "err", err)
+			return nil, err
+		}
+
+		var badgesQuery badge_in.PlayerBadgesQuery
+		err = c.Resolve(&badgesQuery)
+		if err != nil {
+			slog.Error("Failed to resolve badge_in.PlayerBadgesQuery for replay_in.PlayerCardQuery.", "err", err)
+			return nil, err
+		}
+
+		var config common.Config
+		err = c.Resolve(&config)
+		if err != nil {
+			slog.Error("Failed to resolve config for replay_in.PlayerCardQuery.", "err", err)
+			return nil, err
+		}
+
+		query := replay_use_cases.NewGetPlayerCardUseCase(playerReader, statsQuery, historyReader, badges.NewPlayerBadgeNamesReader(badgesQuery), cards.NewPlayerCardRenderer())
+
+		return cache.NewPlayerCardCache(query, config.PlayerCard.CacheDuration()), nil
+	})
+
+	if err != nil {
+		slog.Error("Failed to load replay_in.PlayerCardQuery.")
+		panic(err)
+	}
+
+	err = c.Singleton(func() (public_in.PublicMatchesQuery, error) {
+		var statsReader public_out.PublicStatsReader
+		err := c.Resolve(&statsReader)
+		if err != nil {
+			slog.Error("Failed to resolve public_out.PublicStatsReader for PublicMatchesQuery.", "err", err)
+			return nil, err
+		}
+
+		return public_use_cases.NewListPublicMatchesUseCase(statsReader), nil
+	})
+
+	if err != nil {
+		slog.Error("Failed to load public_in.PublicMatchesQuery.", "err", err)
+		panic(err)
+	}
+
+	err = c.Singleton(func() (public_in.PublicMatchQuery, error) {
+		var statsReader public_out.PublicStatsReader
+		err := c.Resolve(&statsReader)
+		if err != nil {
+			slog.Error("Failed to resolve public_out.PublicStatsReader for PublicMatchQuery.", "err", err)
+			return nil, err
+		}
+
+		return public_use_cases.NewGetPublicMatchUseCase(statsReader), nil
+	})
+
+	if err != nil {
+		slog.Error("Failed to load public_in.PublicMatchQuery.", "err", err)
+		panic(err)
+	}
+
+	err = c.Singleton(func() (public_in.LeaderboardQuery, error) {
+		var statsReader public_out.PublicStatsReader
+		err := c.Resolve(&statsReader)
+		if err != nil {
+			slog.Error("Failed to resolve public_out.PublicStatsReader for LeaderboardQuery.", "err", err)
+			return nil, err
+		}
+
+		return public_use_cases.NewGetLeaderboardUseCase(statsReader), nil
+	})
+
+	if err != nil {
+		slog.Error("Failed to load public_in.LeaderboardQuery.", "err", err)
+		panic(err)
+	}
+
+	err = c.Singleton(func() (squad_in.InviteToSquadCommandHandler, error) {
+		var squadReader squad_out.SquadReader
+		err := c.Resolve(&squadReader)
+		if err != nil {
+			slog.Error("Failed to resolve squad_out.SquadReader for InviteToSquadCommandHandler.", "err", err)
+			return nil, err
+		}
+
+		var userReader iam_out.UserReader
+		err = c.Resolve(&userReader)
+		if err != nil {
+			slog.Error("Failed to resolve iam_out.UserReader for InviteToSquadCommandHandler.", "err", err)
+			return nil, err
+		}
+
+		var joinRequestReader squad_out.JoinRequestReader
+		err = c.Resolve(&joinRequestReader)
+		if err != nil {
+			slog.Error("Failed to resolve squad_out.JoinRequestReader for InviteToSquadCommandHandler.", "err", err)
+			return nil, err
+		}
+
+		var joinRequestWriter squad_out.JoinRequestWriter
+		err = c.Resolve(&joinRequestWriter)
+		if err != nil {
+			slog.Error("Failed to resolve squad_out.JoinRequestWriter for InviteToSquadCommandHandler.", "err", err)
+			return nil, err
+		}
+
+		var historyWriter squad_out.SquadHistoryWriter
+		err = c.Resolve(&historyWriter)
+		if err != nil {
+			slog.Error("Failed to resolve squad_out.SquadHistoryWriter for InviteToSquadCommandHandler.", "err", err)
+			return nil, err
+		}
+
+		var config common.Config
+		err = c.Resolve(&config)
+		if err != nil {
+			slog.Error("Failed to resolve config for InviteToSquadCommandHandler.", "err", err)
+			return nil, err
+		}
+
+		// join request events are only published with a broker (RABBITMQ_URL)
+		var eventPublisher squad_out.SquadEventPublisher
+		if config.RabbitMQ.URL != "" {
+			var publisher *rabbitmq.SquadEventPublisher
+			err = c.Resolve(&publisher)
+			if err != nil {
+				slog.Error("Failed to resolve rabbitmq.SquadEventPublisher for InviteToSquadCommandHandler.", "err", err)
+				return nil, err
+			}
+
+			eventPublisher = publisher
+		}
+
+		return squad_usecases.NewInviteToSquadUseCase(squadReader, userReader, joinRequestReader, joinRequestWriter, historyWriter, eventPublisher), nil
+	})
+
+	if err != nil {
+		slog.Error("Failed to load squad_in.InviteToSquadCommandHandler.", "err", err)
+		panic(err)
+	}
+
+	err = c.Singleton(func() (squad_in.ApplyToSquadCommandHandler, error) {
+		var squadReader squad_out.SquadReader
+		err := c.Resolve(&squadReader)
+		if err != nil {
+			slog.Error("Failed to resolve squad_out.SquadReader for ApplyToSquadCommandHandler.", "err", err)
+			return nil, err
+		}
+
+		var userReader iam_out.UserReader
+		err = c.Resolve(&userReader)
+		if err != nil {
+			slog.Error("Failed to resolve iam_out.UserReader for ApplyToSquadCommandHandler.", "err", err)
+			return nil, err
+		}
+
+		var joinRequestReader squad_out.JoinRequestReader
+		err = c.Resolve(&joinRequestReader)
+		if err != nil {
+			slog.Error("Failed to resolve squad_out.JoinRequestReader for ApplyToSquadCommandHandler.", "err", err)
+			return nil, err
+		}
+
+		var joinRequestWriter squad_out.JoinRequestWriter
+		err = c.Resolve(&joinRequestWriter)
+		if err != nil {
+			slog.Error("Failed to resolve squad_out.JoinRequestWriter for ApplyToSquadCommandHandler.", "err", err)
+			return nil, err
+		}
+
+		var historyWriter squad_out.SquadHistoryWriter
+		err = c.Resolve(&historyWriter)
+		if err != nil {
+			slog.Error("Failed to resolve squad_out.SquadHistoryWriter for ApplyToSquadCommandHandler.", "err", err)
+			return nil, err
+		}
+
+		var config common.Config
+		err = c.Resolve(&config)
+		if err != nil {
+			slog.Error("Failed to resolve config for ApplyToSquadCommandHandler.", "err", err)
+			return nil, err
+		}
+
+		// join request events are only published with a broker (RABBITMQ_URL)
+		var eventPublisher squad_out.SquadEventPublisher
+		if config.RabbitMQ.URL != "" {
+			var publisher *rabbitmq.SquadEventPublisher
+			err = c.Resolve(&publisher)
+			if err != nil {
+				slog.Error("Failed to resolve rabbitmq.SquadEventPublisher for ApplyToSquadCommandHandler.", "err", err)
+				return nil, err
+			}
+
+			eventPublisher = publisher
+		}
+
+		return squad_usecases.NewApplyToSquadUseCase(squadReader, userReader, joinRequestReader, joinRequestWriter, historyWriter, eventPublisher), nil
+	})
+
+	if err != nil {
+		slog.Error("Failed to load squad_in.ApplyToSquadCommandHandler.", "err", err)
+		panic(err)
+	}
+
+	err = c.Singleton(func() (squad_in.RespondToJoinRequestCommandHandler, error) {
+		var squadReader squad_out.SquadReader
+		err := c.Resolve(&squadReader)
+		if err != nil {
+			slog.Error("Failed to resolve squad_out.SquadReader for RespondToJoinRequestCommandHandler.", "err", err)
+			return nil, err
+		}
+
+		var squadWriter squad_out.SquadWriter
+		err = c.Resolve(&squadWriter)
+		if err != nil {
+			slog.Error("Failed to resolve squad_out.SquadWriter for RespondToJoinRequestCommandHandler.", "err", err)
+			return nil, err
+		}
+
+		var joinRequestReader squad_out.JoinRequestReader
+		err = c.Resolve(&joinRequestReader)
+		if err != nil {
+			slog.Error("Failed to resolve squad_out.JoinRequestReader for RespondToJoinRequestCommandHandler.", "err", err)
+			return nil, err
+		}
+
+		var joinRequestWriter squad_out.JoinRequestWriter
+		err = c.Resolve(&joinRequestWriter)
+		if err != nil {
+			slog.Error("Failed to resolve squad_out.JoinRequestWriter for RespondToJoinRequestCommandHandler.", "err", err)
+			return nil, err
+		}
+
+		var historyWriter squad_out.SquadHistoryWriter
+		err = c.Resolve(&historyWriter)
+		if err != nil {
+			slog.Error("Failed to resolve squad_out.SquadHistoryWriter for RespondToJoinRequestCommandHandler.", "err", err)
+			return nil, err
+		}
+
+		var config common.Config
+		err = c.Resolve(&config)
+		if err != nil {
+			slog.Error("Failed to resolve config for RespondToJoinRequestCommandHandler.", "err", err)
+			return nil, err
+		}
+
+		// join request events are only published with a broker (RABBITMQ_URL)
+		var eventPublisher squad_out.SquadEventPublisher
+		if config.RabbitMQ.URL != "" {
+			var publisher *rabbitmq.SquadEventPublisher
+			err = c.Resolve(&publisher)
+			if err != nil {
+				slog.Error("Failed to resolve rabbitmq.SquadEventPublisher for RespondToJoinRequestCommandHandler.", "err", err)
+				return nil, err
+			}
+
+			eventPublisher = publisher
+		}
+
+		return squad_usecases.NewRespondToJoinRequestUseCase(squadReader, squadWriter, joinRequestReader, joinRequestWriter, historyWriter, eventPublisher), nil
+	})
+
+	if err != nil {
+		slog.Error("Failed to load squad_in.RespondToJoinRequestCommandHandler.", "err", err)
+		panic(err)
+	}
+
+	err = c.Singleton(func() (squad_in.SetMemberRoleCommandHandler, error) {
+		var squadReader squad_out.SquadReader
+		err := c.Resolve(&squadReader)
+		if err != nil {
+			slog.Error("Failed to resolve squad_out.SquadReader for SetMemberRoleCommandHandler.", "err", err)
+			return nil, err
+		}
+
+		var squadWriter squad_out.SquadWriter
+		err = c.Resolve(&squadWriter)
+		if err != nil {
+			slog.Error("Failed to resolve squad_out.SquadWriter for SetMemberRoleCommandHandler.", "err", err)
+			return nil, err
+		}
+
+		var historyWriter squad_out.SquadHistoryWriter
+		err = c.Resolve(&historyWriter)
+		if err != nil {
+			slog.Error("Failed to resolve squad_out.SquadHistoryWriter for SetMemberRoleCommandHandler.", "err", err)
+			return nil, err
+		}
+
+		return squad_usecases.NewSetMemberRoleUseCase(squadReader, squadWriter, historyWriter), nil
+	})
+
+	if err != nil {
+		slog.Error("Failed to load squad_in.SetMemberRoleCommandHandler.", "err", err)
+		panic(err)
+	}
+
+	err = c.Singleton(func() (squad_in.TransferSquadOwnershipCommandHandler, error) {
+		var squadReader squad_out.SquadReader
+		err := c.Resolve(&squadReader)
+		if err != nil {
+			slog.Error("Failed to resolve squad_out.SquadReader for TransferSquadOwnershipCommandHandler.", "err", err)
+			return nil, err
+		}
+
+		var squadWriter squad_out.SquadWriter
+		err = c.Resolve(&squadWriter)
+		if err != nil {
+			slog.Error("Failed to resolve squad_out.SquadWriter for TransferSquadOwnershipCommandHandler.", "err", err)
+			return nil, err
+		}
+
+		var userReader iam_out.UserReader
+		err = c.Resolve(&userReader)
+		if err != nil {
+			slog.Error("Failed to resolve iam_out.UserReader for TransferSquadOwnershipCommandHandler.", "err", err)
+			return nil, err
+		}
+
+		var historyWriter squad_out.SquadHistoryWriter
+		err = c.Resolve(&historyWriter)
+		if err != nil {
+			slog.Error("Failed to resolve squad_out.SquadHistoryWriter for TransferSquadOwnershipCommandHandler.", "err", err)
+			return nil, err
+		}
+
+		return squad_usecases.NewTransferSquadOwnershipUseCase(squadReader, squadWriter, userReader, historyWriter), nil
+	})
+
+	if err != nil {
+		slog.Error("Failed to load squad_in.TransferSquadOwnershipCommandHandler.", "err", err)
+		panic(err)
+	}
+
+	err = c.Singleton(func() (squad_in.RemoveSquadMemberCommandHandler, error) {
+		var squadReader squad_out.SquadReader
+		err := c.Resolve(&squadReader)
+		if err != nil {
+			slog.Error("Failed to resolve squad_out.SquadReader for RemoveSquadMemberCommandHandler.", "err", err)
+			return nil, err
+		}
+
+		var squadWriter squad_out.SquadWriter
+		err = c.Resolve(&squadWriter)
+		if err != nil {
+			slog.Error("Failed to resolve squad_out.SquadWriter for RemoveSquadMemberCommandHandler.", "err", err)
+			return nil, err
+		}
+
+		var historyWriter squad_out.SquadHistoryWriter
+		err = c.Resolve(&historyWriter)
+		if err != nil {
+			slog.Error("Failed to resolve squad_out.SquadHistoryWriter for RemoveSquadMemberCommandHandler.", "err", err)
+			return nil, err
+		}
+
+		return squad_usecases.NewRemoveSquadMemberUseCase(squadReader, squadWriter, historyWriter), nil
+	})
+
+	if err != nil {
+		slog.Error("Failed to load squad_in.RemoveSquadMemberCommandHandler.", "err", err)
+		panic(err)
+	}
+
+	err = c.Singleton(func() (iam_in.SetVisibilityPolicyCommandHandler, error) {
+		var policyReader iam_out.VisibilityPolicyReader
+		err := c.Resolve(&policyReader)
+		if err != nil {
+			slog.Error("Failed to resolve iam_out.VisibilityPolicyReader for SetVisibilityPolicyCommandHandler.", "err", err)
+			return nil, err
+		}
+
+		var policyWriter iam_out.VisibilityPolicyWriter
+		err = c.Resolve(&policyWriter)
+		if err != nil {
+			slog.Error("Failed to resolve iam_out.VisibilityPolicyWriter for SetVisibilityPolicyCommandHandler.", "err", err)
+			return nil, err
+		}
+
+		return iam_use_cases.NewSetVisibilityPolicyUseCase(policyReader, policyWriter), nil
+	})
+
+	if err != nil {
+		slog.Error("Failed to load iam_in.SetVisibilityPolicyCommandHandler.", "err", err)
+		panic(err)
+	}
+
+	err = c.Singleton(func() (iam_in.VisibilityPolicyReader, error) {
+		var policyReader iam_out.VisibilityPolicyReader
+		err := c.Resolve(&policyReader)
+		if err != nil {
+			slog.Error("Failed to resolve iam_out.VisibilityPolicyReader for iam_in.VisibilityPolicyReader.", "err", err)
+			return nil, err
+		}
+
+		return iam_query_services.NewVisibilityPolicyQueryService(policyReader), nil
+	})
+
+	if err != nil {
+		slog.Error("Failed to load iam_in.VisibilityPolicyReader.", "err", err)
+		panic(err)
+	}
+
+	err = c.Singleton(func() (iam_in.VisibilityOverrideReader, error) {
+		var overrideReader iam_out.VisibilityOverrideReader
+		err := c.Resolve(&overrideReader)
+		if err != nil {
+			slog.Error("Failed to resolve iam_out.VisibilityOverrideReader for iam_in.VisibilityOverrideReader.", "err", err)
+			return nil, err
+		}
+
+		return iam_query_services.NewVisibilityOverrideQueryService(overrideReader), nil
+	})
+
+	if err != nil {
+		slog.Error("Failed to load iam_in.VisibilityOverrideReader.", "err", err)
+		panic(err)
+	}
+
+	err = c.Singleton(func() (iam_in.CreateGroupCommandHandler, error) {
+		var groupReader iam_out.GroupReader
+		err := c.Resolve(&groupReader)
+		if err != nil {
+			slog.Error("Failed to resolve iam_out.GroupReader for CreateGroupCommandHandler.", "err", err)
+			return nil, err
+		}
+
+		var groupWriter iam_out.GroupWriter
+		err = c.Resolve(&groupWriter)
+		if err != nil {
+			slog.Error("Failed to resolve iam_out.GroupWriter for CreateGroupCommandHandler.", "err", err)
+			return nil, err
+		}
+
+		var membershipReader iam_out.MembershipReader
+		err = c.Resolve(&membershipReader)
+		if err != nil {
+			slog.Error("Failed to resolve iam_out.MembershipReader for CreateGroupCommandHandler.", "err", err)
+			return nil, err
+		}
+
+		var membershipWriter iam_out.MembershipWriter
+		err = c.Resolve(&membershipWriter)
+		if err != nil {
+			slog.Error("Failed to resolve iam_out.MembershipWriter for CreateGroupCommandHandler.", "err", err)
+			return nil, err
+		}
+
+		return iam_use_cases.NewCreateGroupUseCase(groupReader, groupWriter, membershipReader, membershipWriter), nil
+	})
+
+	if err != nil {
+		slog.Error("Failed to load iam_in.CreateGroupCommandHandler.", "err", err)
+		panic(err)
+	}
+
+	err = c.Singleton(func() (iam_in.SetGroupParentCommandHandler, error) {
+		var groupReader iam_out.GroupReader
+		err := c.Resolve(&groupReader)
+		if err != nil {
+			slog.Error("Failed to resolve iam_out.GroupReader for SetGroupParentCommandHandler.", "err", err)
+			return nil, err
+		}
+
+		var groupWriter iam_out.GroupWriter
+		err = c.Resolve(&groupWriter)
+		if err != nil {
+			slog.Error("Failed to resolve iam_out.GroupWriter for SetGroupParentCommandHandler.", "err", err)
+			return nil, err
+		}
+
+		var membershipReader iam_out.MembershipReader
+		err = c.Resolve(&membershipReader)
+		if err != nil {
+			slog.Error("Failed to resolve iam_out.MembershipReader for SetGroupParentCommandHandler.", "err", err)
+			return nil, err
+		}
+
+		return iam_use_cases.NewSetGroupParentUseCase(groupReader, groupWriter, membershipReader), nil
+	})
+
+	if err != nil {
+		slog.Error("Failed to load iam_in.SetGroupParentCommandHandler.", "err", err)
+		panic(err)
+	}
+
+	err = c.Singleton(func() (iam_in.SetGroupMemberCommandHandler, error) {
+		var groupReader iam_out.GroupReader
+		err := c.Resolve(&groupReader)
+		if err != nil {
+			slog.Error("Failed to resolve iam_out.GroupReader for SetGroupMemberCommandHandler.", "err", err)
+			return nil, err
+		}
+
+		var membershipReader iam_out.MembershipReader
+		err = c.Resolve(&membershipReader)
+		if err != nil {
+			slog.Error("Failed to resolve iam_out.MembershipReader for SetGroupMemberCommandHandler.", "err", err)
+			return nil, err
+		}
+
+		var membershipWriter iam_out.MembershipWriter
+		err = c.Resolve(&membershipWriter)
+		if err != nil {
+			slog.Error("Failed to resolve iam_out.MembershipWriter for SetGroupMemberCommandHandler.", "err", err)
+			return nil, err
+		}
+
+		return iam_use_cases.NewSetGroupMemberUseCase(groupReader, membershipReader, membershipWriter), nil
+	})
+
+	if err != nil {
+		slog.Error("Failed to load iam_in.SetGroupMemberCommandHandler.", "err", err)
+		panic(err)
+	}
+
+	err = c.Singleton(func() (iam_in.GroupHierarchyReader, error) {
+		var groupReader iam_out.GroupReader
+		err := c.Resolve(&groupReader)
+		if err != nil {
+			slog.Error("Failed to resolve iam_out.GroupReader for iam_in.GroupHierarchyReader.", "err", err)
+			return nil, err
+		}
+
+		var membershipReader iam_out.MembershipReader
+		err = c.Resolve(&membershipReader)
+		if err != nil {
+			slog.Error("Failed to resolve iam_out.MembershipReader for iam_in.GroupHierarchyReader.", "err", err)
+			return nil, err
+		}
+
+		return iam_use_cases.NewGroupHierarchyUseCase(groupReader, membershipReader), nil
+	})
+
+	if err != nil {
+		slog.Error("Failed to load iam_in.GroupHierarchyReader.", "err", err)
+		panic(err)
+	}
+
+	err = c.Singleton(func() (replay_in.GroupMatchesQuery, error) {
+		var groupHierarchy iam_in.GroupHierarchyReader
+		err := c.Resolve(&groupHierarchy)
+		if err != nil {
+			slog.Error("Failed to resolve iam_in.GroupHierarchyReader for replay_in.GroupMatchesQuery.", "err", err)
+			return nil, err
+		}
+
+		var matchReader replay_out.MatchMetadataReader
+		err = c.Resolve(&matchReader)
+		if err != nil {
+			slog.Error("Failed to resolve replay_out.MatchMetadataReader for replay_in.GroupMatchesQuery.", "err", err)
+			return nil, err
+		}
+
+		return replay_use_cases.NewGetGroupMatchesUseCase(groupHierarchy, matchReader), nil
+	})
+
+	if err != nil {
+		slog.Error("Failed to load replay_in.GroupMatchesQuery.", "err", err)
+		panic(err)
+	}
+
+	err = c.Singleton(func() (squad_in.ListFreeAgentCommandHandler, error) {
+		var freeAgentReader squad_out.FreeAgentReader
+		err := c.Resolve(&freeAgentReader)
+		if err != nil {
+			slog.Error("Failed to resolve squad_out.FreeAgentReader for ListFreeAgentCommandHandler.", "err", err)
+			return nil, err
+		}
+
+		var freeAgentWriter squad_out.FreeAgentWriter
+		err = c.Resolve(&freeAgentWriter)
+		if err != nil {
+			slog.Error("Failed to resolve squad_out.FreeAgentWriter for ListFreeAgentCommandHandler.", "err", err)
+			return nil, err
+		}
+
+		var userReader iam_out.UserReader
+		err = c.Resolve(&userReader)
+		if err != nil {
+			slog.Error("Failed to resolve iam_out.UserReader for ListFreeAgentCommandHandler.", "err", err)
+			return nil, err
+		}
+
+		var ratingReader matchmaking_out.PlayerRatingReader
+		err = c.Resolve(&ratingReader)
+		if err != nil {
+			slog.Error("Failed to resolve matchmaking_out.PlayerRatingReader for ListFreeAgentCommandHandler.", "err", err)
+			return nil, err
+		}
+
+		return squad_usecases.NewListFreeAgentUseCase(freeAgentReader, freeAgentWriter, userReader, ratingReader), nil
+	})
+
+	if err != nil {
+		slog.Error("Failed to load squad_in.ListFreeAgentCommandHandler.", "err", err)
+		panic(err)
+	}
+
+	err = c.Singleton(func() (squad_in.UnlistFreeAgentCommandHandler, error) {
+		var freeAgentReader squad_out.FreeAgentReader
+		err := c.Resolve(&freeAgentReader)
+		if err != nil {
+			slog.Error("Failed to resolve squad_out.FreeAgentReader for UnlistFreeAgentCommandHandler.", "err", err)
+			return nil, err
+		}
+
+		var freeAgentWriter squad_out.FreeAgentWriter
+		err = c.Resolve(&freeAgentWriter)
+		if err != nil {
+			slog.Error("Failed to resolve squad_out.FreeAgentWriter for UnlistFreeAgentCommandHandler.", "err", err)
+			return nil, err
+		}
+
+		return squad_usecases.NewUnlistFreeAgentUseCase(freeAgentReader, freeAgentWriter), nil
+	})
+
+	if err != nil {
+		slog.Error("Failed to load squad_in.UnlistFreeAgentCommandHandler.", "err", err)
+		panic(err)
+	}
+
+	err = c.Singleton(func() (squad_in.PostSquadOpeningCommandHandler, error) {
+		var squadReader squad_out.SquadReader
+		err := c.Resolve(&squadReader)
+		if err != nil {
+			slog.Error("Failed to resolve squad_out.SquadReader for PostSquadOpeningCommandHandler.", "err", err)
+			return nil, err
+		}
+
+		var openingReader squad_out.SquadOpeningReader
+		err = c.Resolve(&openingReader)
+		if err != nil {
+			slog.Error("Failed to resolve squad_out.SquadOpeningReader for PostSquadOpeningCommandHandler.", "err", err)
+			return nil, err
+		}
+
+		var openingWriter squad_out.SquadOpeningWriter
+		err = c.Resolve(&openingWriter)
+		if err != nil {
+			slog.Error("Failed to resolve squad_out.SquadOpeningWriter for PostSquadOpeningCommandHandler.", "err", err)
+			return nil, err
+		}
+
+		return squad_usecases.NewPostSquadOpeningUseCase(squadReader, openingReader, openingWriter), nil
+	})
+
+	if err != nil {
+		slog.Error("Failed to load squad_in.PostSquadOpeningCommandHandler.", "err", err)
+		panic(err)
+	}
+
+	err = c.Singleton(func() (squad_in.CloseSquadOpeningCommandHandler, error) {
+		var squadReader squad_out.SquadReader
+		err := c.Resolve(&squadReader)
+		if err != nil {
+			slog.Error("Failed to resolve squad_out.SquadReader for CloseSquadOpeningCommandHandler.", "err", err)
+			return nil, err
+		}
+
+		var openingReader squad_out.SquadOpeningReader
+		err = c.Resolve(&openingReader)
+		if err != nil {
+			slog.Error("Failed to resolve squad_out.SquadOpeningReader for CloseSquadOpeningCommandHandler.", "err", err)
+			return nil, err
+		}
+
+		var openingWriter squad_out.SquadOpeningWriter
+		err = c.Resolve(&openingWriter)
+		if err != nil {
+			slog.Error("Failed to resolve squad_out.SquadOpeningWriter for CloseSquadOpeningCommandHandler.", "err", err)
+			return nil, err
+		}
+
+		return squad_usecases.NewCloseSquadOpeningUseCase(squadReader, openingReader, openingWriter), nil
+	})
+
+	if err != nil {
+		slog.Error("Failed to load squad_in.CloseSquadOpeningCommandHandler.", "err", err)
+		panic(err)
+	}
+
+	err = c.Singleton(func() (squad_in.RecruitmentSearch, error) {
+		var freeAgentReader squad_out.FreeAgentReader
+		err := c.Resolve(&freeAgentReader)
+		if err != nil {
+			slog.Error("Failed to resolve squad_out.FreeAgentReader for RecruitmentSearch.", "err", err)
+			return nil, err
+		}
+
+		var openingReader squad_out.SquadOpeningReader
+		err = c.Resolve(&openingReader)
+		if err != nil {
+			slog.Error("Failed to resolve squad_out.SquadOpeningReader for RecruitmentSearch.", "err", err)
+			return nil, err
+		}
+
+		return squad_usecases.NewRecruitmentSearchUseCase(freeAgentReader, openingReader), nil
+	})
+
+	if err != nil {
+		slog.Error("Failed to load squad_in.RecruitmentSearch.", "err", err)
+		panic(err)
+	}
+
+	err = c.Singleton(func() (squad_in.SquadSearchableReader, error) {
+		var squadReader squad_out.SquadReader
+		err := c.Resolve(&squadReader)
+		if err != nil {
+			slog.Error("Failed to resolve squad_out.SquadReader for SquadSearchableReader.", "err", err)
+			return nil, err
+		}
+
+		return squad_services.NewSquadQueryService(squadReader), nil
+	})
+
+	if err != nil {
+		slog.Error("Failed to load squad_in.SquadSearchableReader.", "err", err)
+		panic(err)
+	}
+
+	// the entity metadata only advertises the filters accepted by the query services (all resolved above)
+	err = c.Singleton(func() common.EntityMetadataReader {
+		registerQueryService[replay_in.EventReader](c, db.DefaultEntityMetadataRegistry)
+		registerQueryService[replay_in.ReplayFileReader](c, db.DefaultEntityMetadataRegistry)
+		registerQueryService[replay_in.MatchReader](c, db.DefaultEntityMetadataRegistry)
+		registerQueryService[steam_in.SteamUserReader](c, db.DefaultEntityMetadataRegistry)
+		registerQueryService[iam_in.ProfileReader](c, db.DefaultEntityMetadataRegistry)
+		registerQueryService[analytics_in.EngagementReader](c, db.DefaultEntityMetadataRegistry)
+		registerQueryService[quality_in.FindingReader](c, db.DefaultEntityMetadataRegistry)
+		registerQueryService[replay_in.PlayerMatchHistoryReader](c, db.DefaultEntityMetadataRegistry)
+		registerQueryService[replay_in.RoundTimelineReader](c, db.DefaultEntityMetadataRegistry)
+		registerQueryService[replay_in.ReplayHighlightsReader](c, db.DefaultEntityMetadataRegistry)
+		registerQueryService[replay_in.MatchSummaryReader](c, db.DefaultEntityMetadataRegistry)
+		registerQueryService[matchmaking_in.MatchmakingPoolReader](c, db.DefaultEntityMetadataRegistry)
+		registerQueryService[matchmaking_in.StrategyEvaluationReader](c, db.DefaultEntityMetadataRegistry)
+		registerQueryService[export_in.ExportConfigReader](c, db.DefaultEntityMetadataRegistry)
+		registerQueryService[export_in.ExportReceiptReader](c, db.DefaultEntityMetadataRegistry)
+		registerQueryService[notification_in.PushReceiptReader](c, db.DefaultEntityMetadataRegistry)
+		registerQueryService[notification_in.NotificationReader](c, db.DefaultEntityMetadataRegistry)
+		registerQueryService[customfield_in.CustomFieldDefinitionReader](c, db.DefaultEntityMetadataRegistry)
+		registerQueryService[rating_in.RatingHistoryReader](c, db.DefaultEntityMetadataRegistry)
+		registerQueryService[season_in.SeasonReader](c, db.DefaultEntityMetadataRegistry)
+		registerQueryService[season_in.SeasonRewardReader](c, db.DefaultEntityMetadataRegistry)
+		registerQueryService[badge_in.BadgeReader](c, db.DefaultEntityMetadataRegistry)
+		registerQueryService[badge_in.PlayerBadgeReader](c, db.DefaultEntityMetadataRegistry)
+		registerQueryService[iam_in.VisibilityPolicyReader](c, db.DefaultEntityMetadataRegistry)
+		registerQueryService[iam_in.VisibilityOverrideReader](c, db.DefaultEntityMetadataRegistry)
+		registerQueryService[squad_in.SquadSearchableReader](c, db.DefaultEntityMetadataRegistry)
+
+		return db.DefaultEntityMetadataRegistry
+	})
+
+	if err != nil {
+		slog.Error("Failed to load common.EntityMetadataReader.", "err", err)
+		panic(err)
+	}
+
+	return b
+}
+
+// registerQueryService restricts the entity metadata to the queryable fields of the query service bound to S.
+func registerQueryService[S any](c container.Container, registry *db.EntityMetadataRegistry) {
+	var service S
+	err := c.Resolve(&service)
+	if err != nil {
+		slog.Warn("Cannot resolve query service for the entity metadata.", "service", fmt.Sprintf("%T", &service), "err", err)
+		return
+	}
+
+	if queryable, ok := any(service).(common.QueryableEntity); ok {
+		registry.RegisterQueryService(queryable)
+	}
+}
+
+func (b *ContainerBuilder) WithKafkaConsumer() *ContainerBuilder {
+	// c := b.Container
+
+	// err := c.Singleton(func() (out.KafkaConsumer, error) {
+	// 	consumer, err := kafka.NewConsumer(&kafka.ConfigMap{
+	// 		"bootstrap.servers":        "localhost:9092",
+	// 		"acks":                     1,
+	// 		"retries":                  0,
+	// 		"retry.backoff.ms":         100,
+	// 		"socket.timeout.ms":        6000,
+	// 		"reconnect.backoff.max.ms": 3000,
+	// 	})
+	// 	if err != nil {
+	// 		slog.Error(err.Error())
+	// 		panic(err)
+	// 	}
+
+	// 	var config common.Config
+
+	// 	err := c.Resolve(&config)
+	// 	if err != nil {
+	// 		return nil, err
+	// 	}
+
+	// 	return kafka.NewKafkaConsumer(config.Kafka), nil
+	// })
+
+	// if err != nil {
+	// 	slog.Error("Failed to load KafkaConsumer.")
+	// 	panic(err)
+	// }
+
+	return b
+}
+
+func InjectMongoDB(c container.Container) error {
+	// shared by every targeted adapter (a single random source, reproducible with CHAOS_SEED)
+	err := c.Singleton(func() (*chaos.Injector, error) {
+		var config common.Config
+
+		err := c.Resolve(&config)
+		if err != nil {
+			slog.Error("Failed to resolve config for chaos.Injector.", "err", err)
+			return nil, err
+		}
+
+		if config.Chaos.Targets != "" {
+			slog.Warn("Fault injection enabled.", "targets", config.Chaos.Targets, "latency", config.Chaos.Latency.String(), "error_rate", config.Chaos.ErrorRate, "partial_failure_rate", config.Chaos.PartialFailureRate)
+		}
+
+		return chaos.NewInjector(config.Chaos), nil
+	})
+
+	if err != nil {
+		slog.Error("Failed to load chaos.Injector.")
+		return err
+	}
+
+	err = c.Singleton(func() (*mongo.Client, error) {
+		var config common.Config
+
+		err := c.Resolve(&config)
+		if err != nil {
+			slog.Error("Failed to resolve config for mongo.Client.", "err", err)
+			return nil, err
+		}
+
+		mongoOptions := options.Client().ApplyURI(config.MongoDB.URI).SetRegistry(db.MongoRegistry).SetMaxPoolSize(100)
+
+		if config.Chaos.Targeted(chaos.TargetMongoDB) {
+			var injector *chaos.Injector
+			err = c.Resolve(&injector)
+			if err != nil {
+				slog.Error("Failed to resolve chaos.Injector for mongo.Client.", "err", err)
+				return nil, err
+			}
+
+			mongoOptions.SetDialer(chaos.NewDialer(chaos.TargetMongoDB, injector))
+		}
+
+		client, err := mongo.Connect(context.TODO(), mongoOptions)
+
+		if err != nil {
+			slog.Error("Failed to connect to MongoDB.", "err", err)
+			return nil, err
+		}
+
+		return client, nil
+	})
+
+	if err != nil {
+		slog.Error("Failed to load mongo.Client.")
+		return err
+	}
+
+	// events repo
+	err = c.Singleton(func() (*db.EventsRepository, error) {
+		var config common.Config
+
+		err := c.Resolve(&config)
+		if err != nil {
+			slog.Error("Failed to resolve config for db.EventsRepository.", "err", err)
+			return nil, err
+		}
+
+		var client *mongo.Client
+		err = c.Resolve(&client)
+		if err != nil {
+			slog.Error("Failed to resolve mongo.Client for db.EventsRepository as generic MongoDBRepository.", "err", err)
+			return &db.EventsRepository{}, err
+		}
+
+		repo := db.NewEventsRepository(client, config.MongoDB.DBName, &replay_entity.GameEvent{}, "game_events")
+
+		return repo, nil
+	})
+
+	if err != nil {
+		slog.Error("Failed to load NamedSingleton EventsRepository as generic MongoDBRepository.", "err", err)
+		panic(err)
+	}
+
+	err = c.Singleton(func() (replay_out.EventsByGameReader, error) {
+		var repo *db.EventsRepository
+		err = c.Resolve(&repo)
+		if err != nil {
+			slog.Error("Failed to resolve EventsRepository for replay_out.EventsByGameReader.", "err", err)
+			return nil, err
+		}
+
+		return repo, nil
+	})
+
+	if err != nil {
+		slog.Error("Failed to load replay_out.EventsByGameReader.", "err", err)
+		panic(err)
+	}
+
+	err = c.Singleton(func() (replay_out.GameEventReader, error) {
+		var repo *db.EventsRepository
+		err = c.Resolve(&repo)
+		if err != nil {
+			slog.Error("Failed to resolve EventsRepository for replay_out.GameEventReader.", "err", err)
+			return nil, err
+		}
+
+		return repo, nil
+	})
+
+	if err != nil {
+		slog.Error("Failed to load replay_out.GameEventReader.", "err", err)
+		panic(err)
+	}
+
+	err = c.Singleton(func() (replay_out.GameEventWriter, error) {
+		var repo *db.EventsRepository
+		err = c.Resolve(&repo)
+		if err != nil {
+			slog.Error("Failed to resolve EventsRepository for replay_out.GameEventWriter.", "err", err)
+			return nil, err
+		}
+
+		return repo, nil
+	})
+
+	if err != nil {
+		slog.Error("Failed to resolve replay_out.GameEventWriter.", "err", err)
+		panic(err)
+	}
+
+	// replay
+
+	err = c.Singleton(func() (*db.ReplayFileMetadataRepository, error) {
+		var client *mongo.Client
+		err := c.Resolve(&client)
+		if err != nil {
+			slog.Error("Failed to resolve mongo.Client for NamedSingleton ReplayFileMetadataRepository as generic MongoDBRepository.", "err", err)
+			return &db.ReplayFileMetadataRepository{}, err
+		}
+
+		var config common.Config
+
+		err = c.Resolve(&config)
+		if err != nil {
+			slog.Error("Failed to resolve config for db.ReplayFileMetadataRepository.", "err", err)
+			return nil, err
+		}
+
+		repo := db.NewReplayFileMetadataRepository(client, config.MongoDB.DBName, replay_entity.ReplayFile{}, "replay_file_metadata")
+
+		return repo, nil
+	})
+
+	if err != nil {
+		slog.Error("Failed to load NamedSingleton ReplayFileMetadataRepository as generic MongoDBRepository.", "err", err)
+		panic(err)
+	}
+
+	err = c.Singleton(func() (replay_out.ReplayFileMetadataReader, error) {
+		var repo *db.ReplayFileMetadataRepository
+		err = c.Resolve(&repo)
+		if err != nil {
+			slog.Error("Failed to resolve ReplayFileMetadataRepository for replay_out.ReplayFileMetadataReader.", "err", err)
+			return nil, err
+		}
+
+		return repo, nil
+	})
+
+	if err != nil {
+		slog.Error("Failed to load replay_out.ReplayFileMetadataReader.", "err", err)
+		panic(err)
+	}
+
+	err = c.Singleton(func() (replay_out.ReplayFileMetadataWriter, error) {
+		var repo *db.ReplayFileMetadataRepository
+		err = c.Resolve(&repo)
+		if err != nil {
+			slog.Error("Failed to resolve SteamUserRepository for replay_out.ReplayFileMetadataWriter.", "err", err)
+			return nil, err
+		}
+
+		return repo, nil
+	})
+
+	if err != nil {
+		slog.Error("Failed to load replay_out.ReplayFileMetadataWriter.", "err", err)
+		panic(err)
+	}
+
+	// MATCH METADATA
+	err = c.Singleton(func() (*db.MatchMetadataRepository, error) {
+		var client *mongo.Client
+		err := c.Resolve(&client)
+		if err != nil {
+			slog.Error("Failed to resolve mongo.Client for NamedSingleton MatchMetadataRepository as generic MongoDBRepository.", "err", err)
+			return &db.MatchMetadataRepository{}, err
+		}
+
+		var config common.Config
+
+		err = c.Resolve(&config)
+		if err != nil {
+			slog.Error("Failed to resolve config for db.MatchMetadataRepository.", "err", err)
+			return nil, err
+		}
+
+		repo := db.NewMatchMetadataRepository(client, config.MongoDB.DBName, replay_entity.Match{}, "match_metadata")
+
+		return repo, nil
+	})
+
+	if err != nil {
+		slog.Error("Failed to load NamedSingleton ReplayFileMetadataRepository as generic MongoDBRepository.", "err", err)
+		panic(err)
+	}
+
+	err = c.Singleton(func() (replay_out.MatchMetadataReader, error) {
+		var repo *db.MatchMetadataRepository
+		err = c.Resolve(&repo)
+		if err != nil {
+			slog.Error("Failed to resolve MatchMetadataRepository for replay_out.MatchMetadataReader.", "err", err)
+			return nil, err
+		}
+
+		return repo, nil
+	})
+
+	if err != nil {
+		slog.Error("Failed to load replay_out.MatchMetadataReader.", "err", err)
+		panic(err)
+	}
+
+	// Player Metadata Repository
+	err = c.Singleton(func() (*db.PlayerRepository, error) {
+		var client *mongo.Client
+		err := c.Resolve(&client)
+		if err != nil {
+			slog.Error("Failed to resolve mongo.Client for PlayerRepository as generic MongoDBRepository.", "err", err)
+			return &db.PlayerRepository{}, err
+		}
+
+		var config common.Config
+
+		err = c.Resolve(&config)
+		if err != nil {
+			slog.Error("Failed to resolve config for db.PlayerRepository.", "err", err)
+			return nil, err
+		}
+
+		repo := db.NewPlayerRepository(client, config.MongoDB.DBName, replay_entity.Player{}, "player_metadata")
+
+		return repo, nil
+	})
+
+	if err != nil {
+		slog.Error("Failed to load PlayerRepository as generic MongoDBRepository.", "err", err)
+		panic(err)
+	}
+
+	err = c.Singleton(func() (replay_out.PlayerMetadataReader, error) {
+		var repo *db.PlayerRepository
+		err = c.Resolve(&repo)
+		if err != nil {
+			slog.Error("Failed to resolve PlayerRepository for replay_out.PlayerMetadataReader.", "err", err)
+			return nil, err
+		}
+
+		return repo, nil
+	})
+
+	if err != nil {
+		slog.Error("Failed to load replay_out.PlayerMetadataReader.", "err", err)
+		panic(err)
+	}
+
+	err = c.Singleton(func() (replay_out.PlayerMetadataWriter, error) {
+		var repo *db.PlayerRepository
+		err = c.Resolve(&repo)
+		if err != nil {
+			slog.Error("Failed to resolve PlayerRepository for replay_out.PlayerMetadataWriter.", "err", err)
+			return nil, err
+		}
+
+		return repo, nil
+	})
+
+	if err != nil {
+		slog.Error("Failed to load replay_out.PlayerMetadataWriter.", "err", err)
+		panic(err)
+	}
+
+	err = c.Singleton(func() (*db.PlayerProfileHistoryRepository, error) {
+		var client *mongo.Client
+		err := c.Resolve(&client)
+		if err != nil {
+			slog.Error("Failed to resolve mongo.Client for PlayerProfileHistoryRepository.", "err", err)
+			return nil, err
+		}
+
+		var config common.Config
+		err = c.Resolve(&config)
+		if err != nil {
+			slog.Error("Failed to resolve config for db.PlayerProfileHistoryRepository.", "err", err)
+			return nil, err
+		}
+
+		return db.NewPlayerProfileHistoryRepository(client, config.MongoDB.DBName, replay_entity.PlayerProfileHistory{}, "player_profile_history"), nil
+	})
+
+	if err != nil {
+		slog.Error("Failed to load PlayerProfileHistoryRepository.", "err", err)
+		panic(err)
+	}
+
+	err = c.Singleton(func() (replay_out.PlayerProfileHistoryWriter, error) {
+		var repo *db.PlayerProfileHistoryRepository
+		err := c.Resolve(&repo)
+		if err != nil {
+			slog.Error("Failed to resolve PlayerProfileHistoryRepository for replay_out.PlayerProfileHistoryWriter.", "err", err)
+			return nil, err
+		}
+
+		return repo, nil
+	})
+
+	if err != nil {
+		slog.Error("Failed to load replay_out.PlayerProfileHistoryWriter.", "err", err)
+		panic(err)
+	}
+
+	err = c.Singleton(func() (replay_out.MatchMetadataWriter, error) {
+		var repo *db.MatchMetadataRepository
+		err = c.Resolve(&repo)
+		if err != nil {
+			slog.Error("Failed to resolve PlayerRepository for replay_out.MatchMetadataWriter.", "err", err)
+			return nil, err
+		}
+
+		return repo, nil
+	})
+
+	if err != nil {
+		slog.Error("Failed to load replay_out.MatchMetadataWriter.", "err", err)
+		panic(err)
+	}
+
+	err = c.Singleton(func() (replay_in.PlayerReader, error) {
+		var repo *db.PlayerRepository
+		err = c.Resolve(&repo)
+		if err != nil {
+			slog.Error("Failed to resolve PlayerRepository for replay_in.PlayerReader.", "err", err)
+			return nil, err
+		}
+
+		return repo, nil
+	})
+
+	if err != nil {
+		slog.Error("Failed to load replay_in.PlayerReader.", "err", err)
+		panic(err)
+	}
+
+	// lazy: only resolved (and configured) when replay content is stored on s3
+	err = c.SingletonLazy(func() (*s3.S3Adapter, error) {
+		var config common.Config
+
+		err := c.Resolve(&config)
+		if err != nil {
+			slog.Error("Failed to resolve config for s3.S3Adapter.", "err", err)
+			return nil, err
+		}
+
+		return s3.NewS3Adapter(config.S3)
+	})
+
+	if err != nil {
+		slog.Error("Failed to load S3Adapter.", "err", err)
+		panic(err)
+	}
+
+	err = c.Singleton(func() (replay_out.ReplayFileContentWriter, error) {
+		var config common.Config
+
+		err := c.Resolve(&config)
+		if err != nil {
+			slog.Error("Failed to resolve config for replay_out.ReplayFileContentWriter.", "err", err)
+			return nil, err
+		}
+
+		switch config.ReplayStorage.Backend {
+		case s3.BackendName:
+			var adapter *s3.S3Adapter
+
+			err = c.Resolve(&adapter)
+			if err != nil {
+				slog.Error("Failed to resolve s3.S3Adapter for ReplayFileContentWriter.", "err", err)
+				return nil, err
+			}
+
+			return adapter, nil
+		case "", db.ReplayFileContentBackendName:
+			var client *mongo.Client
+
+			err = c.Resolve(&client)
+			if err != nil {
+				slog.Error("Failed to resolve mongo.Client for ReplayFileContentWriter.", "err", err)
+				return nil, err
+			}
+
+			return db.NewReplayFileContentRepository(client), nil
+		default:
+			return nil, fmt.Errorf("unsupported replay storage backend '%s'", config.ReplayStorage.Backend)
+		}
+	})
+
+	if err != nil {
+		slog.Error("Failed to load replay_out.ReplayFileContentWriter.", "err", err)
+		panic(err)
+	}
+
+	err = c.Singleton(func() (replay_out.ReplayFileContentReader, error) {
+		var config common.Config
+
+		err := c.Resolve(&config)
+		if err != nil {
+			slog.Error("Failed to resolve config for ReplayFileContentReader.", "err", err)
+			return nil, err
+		}
+
+		switch config.ReplayStorage.Backend {
+		case s3.BackendName:
+			var adapter *s3.S3Adapter
+
+			err = c.Resolve(&adapter)
+			if err != nil {
+				slog.Error("Failed to resolve s3.S3Adapter for ReplayFileContentReader.", "err", err)
+				return nil, err
+			}
+
+			return adapter, nil
+		case "", db.ReplayFileContentBackendName:
+			var client *mongo.Client
+
+			err = c.Resolve(&client)
+			if err != nil {
+				slog.Error("Failed to resolve mongo.Client for ReplayFileContentReader.", "err", err)
+				return nil, err
+			}
+
+			return db.NewReplayFileContentRepository(client), nil
+		default:
+			return nil, fmt.Errorf("unsupported replay storage backend '%s'", config.ReplayStorage.Backend)
+		}
+	})
+
+	if err != nil {
+		slog.Error("Failed to load replay_out.ReplayFileContentReader.", "err", err)
+		panic(err)
+	}
+
+	// ranged reads of the content, for the demo downloads
+	err = c.Singleton(func() (replay_out.ReplayFileContentStreamer, error) {
+		var config common.Config
+
+		err := c.Resolve(&config)
+		if err != nil {
+			slog.Error("Failed to resolve config for ReplayFileContentStreamer.", "err", err)
+			return nil, err
+		}
+
+		switch config.ReplayStorage.Backend {
+		case s3.BackendName:
+			var adapter *s3.S3Adapter
+
+			err = c.Resolve(&adapter)
+			if err != nil {
+				slog.Error("Failed to resolve s3.S3Adapter for ReplayFileContentStreamer.", "err", err)
+				return nil, err
+			}
+
+			return adapter, nil
+		case "", db.ReplayFileContentBackendName:
+			var client *mongo.Client
+
+			err = c.Resolve(&client)
+			if err != nil {
+				slog.Error("Failed to resolve mongo.Client for ReplayFileContentStreamer.", "err", err)
+				return nil, err
+			}
+
+			return db.NewReplayFileContentRepository(client), nil
+		default:
+			return nil, fmt.Errorf("unsupported replay storage backend '%s'", config.ReplayStorage.Backend)
+		}
+	})
+
+	if err != nil {
+		slog.Error("Failed to load replay_out.ReplayFileContentStreamer.", "err", err)
+		panic(err)
+	}
+
+	// lazy: GridFS content is only served through the API
+	err = c.SingletonLazy(func() (replay_out.ReplayFileContentURLSigner, error) {
+		var config common.Config
+
+		err := c.Resolve(&config)
+		if err != nil {
+			slog.Error("Failed to resolve config for ReplayFileContentURLSigner.", "err", err)
+			return nil, err
+		}
+
+		if config.ReplayStorage.Backend != s3.BackendName {
+			return nil, fmt.Errorf("presigned replay downloads require the '%s' replay storage backend", s3.BackendName)
+		}
+
+		var adapter *s3.S3Adapter
+
+		err = c.Resolve(&adapter)
+		if err != nil {
+			slog.Error("Failed to resolve s3.S3Adapter for ReplayFileContentURLSigner.", "err", err)
+			return nil, err
+		}
+
+		return adapter, nil
+	})
+
+	if err != nil {
+		slog.Error("Failed to load replay_out.ReplayFileContentURLSigner.", "err", err)
+		panic(err)
+	}
+
+	// lazy: only used with a broker (RABBITMQ_URL)
+	err = c.SingletonLazy(func() (*rabbitmq.ReplayEventPublisher, error) {
+		var config common.Config
+
+		err := c.Resolve(&config)
+		if err != nil {
+			slog.Error("Failed to resolve config for rabbitmq.ReplayEventPublisher.", "err", err)
+			return nil, err
+		}
+
+		if config.RabbitMQ.URL == "" {
+			return nil, fmt.Errorf("replay file events require RABBITMQ_URL")
+		}
+
+		publisher := rabbitmq.NewReplayEventPublisher(config.RabbitMQ.URL)
+
+		if config.Chaos.Targeted(chaos.TargetRabbitMQ) {
+			var injector *chaos.Injector
+			err = c.Resolve(&injector)
+			if err != nil {
+				slog.Error("Failed to resolve chaos.Injector for rabbitmq.ReplayEventPublisher.", "err", err)
+				return nil, err
+			}
+
+			publisher.Dial = chaos.NewDialer(chaos.TargetRabbitMQ, injector).Dial
+		}
+
+		return publisher, nil
+	})
+
+	if err != nil {
+		slog.Error("Failed to load rabbitmq.ReplayEventPublisher.", "err", err)
+		panic(err)
+	}
+
+	// lazy: only used with a broker (RABBITMQ_URL)
+	err = c.SingletonLazy(func() (*rabbitmq.MatchmakingEventPublisher, error) {
+		var config common.Config
+
+		err := c.Resolve(&config)
+		if err != nil {
+			slog.Error("Failed to resolve config for rabbitmq.MatchmakingEventPublisher.", "err", err)
+			return nil, err
+		}
+
+		if config.RabbitMQ.URL == "" {
+			return nil, fmt.Errorf("matchmaking events require RABBITMQ_URL")
+		}
+
+		publisher := rabbitmq.NewMatchmakingEventPublisher(config.RabbitMQ.URL)
+
+		if config.Chaos.Targeted(chaos.TargetRabbitMQ) {
+			var injector *chaos.Injector
+			err = c.Resolve(&injector)
+			if err != nil {
+				slog.Error("Failed to resolve chaos.Injector for rabbitmq.MatchmakingEventPublisher.", "err", err)
+				return nil, err
+			}
+
+			publisher.Dial = chaos.NewDialer(chaos.TargetRabbitMQ, injector).Dial
+		}
+
+		return publisher, nil
+	})
+
+	if err != nil {
+		slog.Error("Failed to load rabbitmq.MatchmakingEventPublisher.", "err", err)
+		panic(err)
+	}
+
+	// lazy: only used with a broker (RABBITMQ_URL)
+	err = c.SingletonLazy(func() (*rabbitmq.SquadEventPublisher, error) {
+		var config common.Config
+
+		err := c.Resolve(&config)
+		if err != nil {
+			slog.Error("Failed to resolve config for rabbitmq.SquadEventPublisher.", "err", err)
+			return nil, err
+		}
+
+		if config.RabbitMQ.URL == "" {
+			return nil, fmt.Errorf("squad events require RABBITMQ_URL")
+		}
+
+		publisher := rabbitmq.NewSquadEventPublisher(config.RabbitMQ.URL)
+
+		if config.Chaos.Targeted(chaos.TargetRabbitMQ) {
+			var injector *chaos.Injector
+			err = c.Resolve(&injector)
+			if err != nil {
+				slog.Error("Failed to resolve chaos.Injector for rabbitmq.SquadEventPublisher.", "err", err)
+				return nil, err
+			}
+
+			publisher.Dial = chaos.NewDialer(chaos.TargetRabbitMQ, injector).Dial
+		}
+
+		return publisher, nil
+	})
+
+	if err != nil {
+		slog.Error("Failed to load rabbitmq.SquadEventPublisher.", "err", err)
+		panic(err)
+	}
+
+	// lazy: only the async upload path publishes
+	err = c.SingletonLazy(func() (replay_out.ReplayFileEventPublisher, error) {
+		var publisher *rabbitmq.ReplayEventPublisher
+
+		err := c.Resolve(&publisher)
+		if err != nil {
+			slog.Error("Failed to resolve rabbitmq.ReplayEventPublisher for ReplayFileEventPublisher.", "err", err)
+			return nil, err
+		}
+
+		return publisher, nil
+	})
+
+	if err != nil {
+		slog.Error("Failed to load replay_out.ReplayFileEventPublisher.", "err", err)
+		panic(err)
+	}
+
+	// payload sizes of the messages written to the websocket clients, per channel (to compare the framings and compression)
+	err = c.Singleton(func() *websocket.Upgrader {
+		return websocket.NewUpgrader(websocket.NewPayloadStats())
+	})
+
+	if err != nil {
+		slog.Error("Failed to load websocket.Upgrader.", "err", err)
+		panic(err)
+	}
+
+	// progress of the replay files processed by this process is followed through its hub, and relayed to it from the broker (when
+	// processed by the replay workers)
+	err = c.Singleton(func() *websocket.ReplayProgressHub {
+		return websocket.NewReplayProgressHub()
+	})
+
+	if err != nil {
+		slog.Error("Failed to load websocket.ReplayProgressHub.", "err", err)
+		panic(err)
+	}
+
+	err = c.Singleton(func() (replay_out.ReplayProcessingProgressStream, error) {
+		var hub *websocket.ReplayProgressHub
+
+		err := c.Resolve(&hub)
+		if err != nil {
+			slog.Error("Failed to resolve websocket.ReplayProgressHub for ReplayProcessingProgressStream.", "err", err)
+			return nil, err
+		}
+
+		return hub, nil
+	})
+
+	if err != nil {
+		slog.Error("Failed to load replay_out.ReplayProcessingProgressStream.", "err", err)
+		panic(err)
+	}
+
+	err = c.Singleton(func() (replay_out.ReplayProcessingProgressPublisher, error) {
+		var config common.Config
+
+		err := c.Resolve(&config)
+		if err != nil {
+			slog.Error("Failed to resolve config for ReplayProcessingProgressPublisher.", "err", err)
+			return nil, err
+		}
+
+		// through the broker every api replica relays the progress to its clients
+		if config.RabbitMQ.URL != "" {
+			var publisher *rabbitmq.ReplayEventPublisher
+
+			err = c.Resolve(&publisher)
+			if err != nil {
+				slog.Error("Failed to resolve rabbitmq.ReplayEventPublisher for ReplayProcessingProgressPublisher.", "err", err)
+				return nil, err
+			}
+
+			return publisher, nil
+		}
+
+		var hub *websocket.ReplayProgressHub
+
+		err = c.Resolve(&hub)
+		if err != nil {
+			slog.Error("Failed to resolve websocket.ReplayProgressHub for ReplayProcessingProgressPublisher.", "err", err)
+			return nil, err
+		}
+
+		return hub, nil
+	})
+
+	if err != nil {
+		slog.Error("Failed to load replay_out.ReplayProcessingProgressPublisher.", "err", err)
+		panic(err)
+	}
+
+	err = c.Singleton(func() replay_out.ReplayParser {
+		return cs_app.NewCS2ReplayAdapter()
+	})
+
+	if err != nil {
+		slog.Error("Failed to load CS2ReplayAdapter.", "err", err)
+		panic(err)
+	}
+
+	// steam repo
+	err = c.Singleton(func() (*db.SteamUserRepository, error) {
+		var client *mongo.Client
+		err := c.Resolve(&client)
+		if err != nil {
+			slog.Error("Failed to resolve mongo.Client for NamedSingleton SteamUserRepository as generic MongoDBRepository.", "err", err)
+			return nil, err
+		}
+
+		var config common.Config
+
+		err = c.Resolve(&config)
+		if err != nil {
+			slog.Error("Failed to resolve config for db.SteamUserRepository.", "err", err)
+			return nil, err
+		}
+
+		repo := db.NewSteamUserMongoDBRepository(client, config.MongoDB.DBName, steam_entity.SteamUser{}, "steam_users")
+
+		return repo, nil
+	})
+
+	if err != nil {
+		slog.Error("Failed to load NamedSingleton SteamUserRepository as generic MongoDBRepository.", "err", err)
+		panic(err)
+	}
+
+	err = c.Singleton(func() (steam_out.SteamUserWriter, error) {
+		var repo *db.SteamUserRepository
+		err = c.Resolve(&repo)
+		if err != nil {
+			slog.Error("Failed to resolve SteamUserRepository for steam_out.SteamUserWriter.", "err", err)
+			return nil, err
+		}
+
+		return repo, nil
+	})
+
+	if err != nil {
+		slog.Error("Failed to load SteamUserWriter.", "err", err)
+		panic(err)
+	}
+
+	err = c.Singleton(func() (steam_out.SteamUserReader, error) {
+		var repo *db.SteamUserRepository
+		err = c.Resolve(&repo)
+		if err != nil {
+			slog.Error("Failed to resolve SteamUserRepository for steam_out.SteamUserReader.", "err", err)
+			return nil, err
+		}
+
+		return repo, nil
+	})
+
+	if err != nil {
+		slog.Error("Failed to load SteamUserReader.", "err", err)
+		panic(err)
+	}
+
+	err = c.Singleton(func() (steam_out.VHashWriter, error) {
+		var config common.Config
+
+		err := c.Resolve(&config)
+		if err != nil {
+			slog.Error("Failed to resolve config for steam_out.VHashWriter.", "err", err)
+			return nil, err
+		}
+
+		return encryption.NewSHA256VHasherAdapter(config.Auth.SteamConfig.VHashSource), nil
+	})
+
+	if err != nil {
+		slog.Error("Failed to load VHashWriter.", "err", err)
+		panic(err)
+	}
+
+	// share code imports are disabled (not an error) without a Game Coordinator gateway
+	err = c.Singleton(func() (steam_out.SteamMatchFetcher, error) {
+		var config common.Config
+
+		err := c.Resolve(&config)
+		if err != nil {
+			slog.Error("Failed to resolve config for steam_out.SteamMatchFetcher.", "err", err)
+			return nil, err
+		}
+
+		if config.SteamGC.GatewayURL == "" {
+			return &clients.DisabledSteamMatchFetcher{}, nil
+		}
+
+		return clients.NewSteamGCMatchFetcher(config.SteamGC), nil
+	})
+
+	if err != nil {
+		slog.Error("Failed to load steam_out.SteamMatchFetcher.", "err", err)
+		panic(err)
+	}
+
+	// end-steam
+
+	// faceit imports are disabled (not an error) without an API key
+	err = c.Singleton(func() (faceit_out.FaceitMatchFetcher, error) {
+		var config common.Config
+
+		err := c.Resolve(&config)
+		if err != nil {
+			slog.Error("Failed to resolve config for faceit_out.FaceitMatchFetcher.", "err", err)
+			return nil, err
+		}
+
+		if config.Faceit.APIKey == "" {
+			return &clients.DisabledFaceitClient{}, nil
+		}
+
+		return clients.NewFaceitClient(config.Faceit), nil
+	})
+
+	if err != nil {
+		slog.Error("Failed to load faceit_out.FaceitMatchFetcher.", "err", err)
+		panic(err)
+	}
+
+	// player stats are only synced from the platforms an API key is configured for
+	err = c.Singleton(func() ([]replay_out.ExternalStatsFetcher, error) {
+		var config common.Config
+
+		err := c.Resolve(&config)
+		if err != nil {
+			slog.Error("Failed to resolve config for []replay_out.ExternalStatsFetcher.", "err", err)
+			return nil, err
+		}
+
+		fetchers := make([]replay_out.ExternalStatsFetcher, 0, 3)
+
+		if config.Auth.SteamConfig.SteamKey != "" {
+			fetchers = append(fetchers, clients.NewSteamStatsClient(config.Auth.SteamConfig))
+		}
+
+		if config.Faceit.APIKey != "" {
+			fetchers = append(fetchers, clients.NewFaceitClient(config.Faceit))
+		}
+
+		if config.Riot.APIKey != "" {
+			fetchers = append(fetchers, clients.NewRiotStatsClient(config.Riot))
+		}
+
+		return fetchers, nil
+	})
+
+	if err != nil {
+		slog.Error("Failed to load []replay_out.ExternalStatsFetcher.", "err", err)
+		panic(err)
+	}
+
+	// GOOGLE repo
+	err = c.Singleton(func() (*db.GoogleUserRepository, error) {
+		var client *mongo.Client
+		err := c.Resolve(&client)
+		if err != nil {
+			slog.Error("Failed to resolve mongo.Client for NamedSingleton GoogleUserRepository as generic MongoDBRepository.", "err", err)
+			return nil, err
+		}
+
+		var config common.Config
+
+		err = c.Resolve(&config)
+		if err != nil {
+			slog.Error("Failed to resolve config for db.GoogleUserRepository.", "err", err)
+			return nil, err
+		}
+
+		repo := db.NewGoogleUserMongoDBRepository(client, config.MongoDB.DBName, google_entities.GoogleUser{}, "google_users")
+
+		return repo, nil
+	})
+
+	if err != nil {
+		slog.Error("Failed to load NamedSingleton GoogleUserRepository as generic MongoDBRepository.", "err", err)
+		panic(err)
+	}
+
+	err = c.Singleton(func() (google_out.GoogleUserWriter, error) {
+		var repo *db.GoogleUserRepository
+		err = c.Resolve(&repo)
+		if err != nil {
+			slog.Error("Failed to resolve GoogleUserRepository for google_out.GoogleUserWriter.", "err", err)
+			return nil, err
+		}
+
+		return repo, nil
+	})
+
+	if err != nil {
+		slog.Error("Failed to load GoogleUserWriter.", "err", err)
+		panic(err)
+	}
+
+	err = c.Singleton(func() (google_out.GoogleUserReader, error) {
+		var repo *db.GoogleUserRepository
+		err = c.Resolve(&repo)
+		if err != nil {
+			slog.Error("Failed to resolve GoogleUserRepository for google_out.GoogleUserReader.", "err", err)
+			return nil, err
+		}
+
+		return repo, nil
+	})
+
+	if err != nil {
+		slog.Error("Failed to load GoogleUserReader.", "err", err)
+		panic(err)
+	}
+
+	err = c.Singleton(func() (google_out.VHashWriter, error) {
+		var config common.Config
+
+		err := c.Resolve(&config)
+		if err != nil {
+			slog.Error("Failed to resolve config for google_out.VHashWriter.", "err", err)
+			return nil, err
+		}
+
+		return encryption.NewSHA256VHasherAdapter(config.Auth.SteamConfig.VHashSource), nil
+	})
+
+	if err != nil {
+		slog.Error("Failed to load VHashWriter.", "err", err)
+		panic(err)
+	}
+
+	// end-google
+
+	// RIOT repo
+	err = c.Singleton(func() (*db.RiotUserRepository, error) {
+		var client *mongo.Client
+		err := c.Resolve(&client)
+		if err != nil {
+			slog.Error("Failed to resolve mongo.Client for NamedSingleton RiotUserRepository as generic MongoDBRepository.", "err", err)
+			return nil, err
+		}
+
+		var config common.Config
+
+		err = c.Resolve(&config)
+		if err != nil {
+			slog.Error("Failed to resolve config for db.RiotUserRepository.", "err", err)
+			return nil, err
+		}
+
+		repo := db.NewRiotUserMongoDBRepository(client, config.MongoDB.DBName, riot_entities.RiotUser{}, "riot_users")
+
+		return repo, nil
+	})
+
+	if err != nil {
+		slog.Error("Failed to load NamedSingleton RiotUserRepository as generic MongoDBRepository.", "err", err)
+		panic(err)
+	}
+
+	err = c.Singleton(func() (riot_out.RiotUserWriter, error) {
+		var repo *db.RiotUserRepository
+		err = c.Resolve(&repo)
+		if err != nil {
+			slog.Error("Failed to resolve RiotUserRepository for riot_out.RiotUserWriter.", "err", err)
+			return nil, err
+		}
+
+		return repo, nil
+	})
+
+	if err != nil {
+		slog.Error("Failed to load RiotUserWriter.", "err", err)
+		panic(err)
+	}
+
+	err = c.Singleton(func() (riot_out.RiotUserReader, error) {
+		var repo *db.RiotUserRepository
+		err = c.Resolve(&repo)
+		if err != nil {
+			slog.Error("Failed to resolve RiotUserRepository for riot_out.RiotUserReader.", "err", err)
+			return nil, err
+		}
+
+		return repo, nil
+	})
+
+	if err != nil {
+		slog.Error("Failed to load RiotUserReader.", "err", err)
+		panic(err)
+	}
+
+	err = c.Singleton(func() (riot_out.VHashWriter, error) {
+		var config common.Config
+
+		err := c.Resolve(&config)
+		if err != nil {
+			slog.Error("Failed to resolve config for riot_out.VHashWriter.", "err", err)
+			return nil, err
+		}
+
+		return encryption.NewSHA256VHasherAdapter(config.Auth.SteamConfig.VHashSource), nil
+	})
+
+	if err != nil {
+		slog.Error("Failed to load VHashWriter.", "err", err)
+		panic(err)
+	}
+
+	// end-riot
+
+	// DISCORD repo
+	err = c.Singleton(func() (*db.DiscordUserRepository, error) {
+		var client *mongo.Client
+		err := c.Resolve(&client)
+		if err != nil {
+			slog.Error("Failed to resolve mongo.Client for NamedSingleton DiscordUserRepository as generic MongoDBRepository.", "err", err)
+			return nil, err
+		}
+
+		var config common.Config
+
+		err = c.Resolve(&config)
+		if err != nil {
+			slog.Error("Failed to resolve config for db.DiscordUserRepository.", "err", err)
+			return nil, err
+		}
+
+		repo := db.NewDiscordUserMongoDBRepository(client, config.MongoDB.DBName, discord_entities.DiscordUser{}, "discord_users")
+
+		return repo, nil
+	})
+
+	if err != nil {
+		slog.Error("Failed to load NamedSingleton DiscordUserRepository as generic MongoDBRepository.", "err", err)
+		panic(err)
+	}
+
+	err = c.Singleton(func() (discord_out.DiscordUserWriter, error) {
+		var repo *db.DiscordUserRepository
+		err = c.Resolve(&repo)
+		if err != nil {
+			slog.Error("Failed to resolve DiscordUserRepository for discord_out.DiscordUserWriter.", "err", err)
+			return nil, err
+		}
+
+		return repo, nil
+	})
+
+	if err != nil {
+		slog.Error("Failed to load DiscordUserWriter.", "err", err)
+		panic(err)
+	}
+
+	err = c.Singleton(func() (discord_out.DiscordUserReader, error) {
+		var repo *db.DiscordUserRepository
+		err = c.Resolve(&repo)
+		if err != nil {
+			slog.Error("Failed to resolve DiscordUserRepository for discord_out.DiscordUserReader.", "err", err)
+			return nil, err
+		}
+
+		return repo, nil
+	})
+
+	if err != nil {
+		slog.Error("Failed to load DiscordUserReader.", "err", err)
+		panic(err)
+	}
+
+	err = c.Singleton(func() (discord_out.VHashWriter, error) {
+		var config common.Config
+
+		err := c.Resolve(&config)
+		if err != nil {
+			slog.Error("Failed to resolve config for discord_out.VHashWriter.", "err", err)
+			return nil, err
+		}
+
+		return encryption.NewSHA256VHasherAdapter(config.Auth.SteamConfig.VHashSource), nil
+	})
+
+	if err != nil {
+		slog.Error("Failed to load VHashWriter.", "err", err)
+		panic(err)
+	}
+
+	// end-discord
+
+	// rid
+	err = c.Singleton(func() (*db.RIDTokenRepository, error) {
+		var client *mongo.Client
+		err := c.Resolve(&client)
+		if err != nil {
+			slog.Error("Failed to resolve mongo.Client for NamedSingleton RIDTokenRepository as generic MongoDBRepository.", "err", err)
+			return &db.RIDTokenRepository{}, err
+		}
+
+		var config common.Config
+
+		err = c.Resolve(&config)
+		if err != nil {
+			slog.Error("Failed to resolve config for db.SteamUserRepository.", "err", err)
+			return nil, err
+		}
+
+		repo := db.NewRIDTokenRepository(client, config.MongoDB.DBName, iam_entities.RIDToken{}, "rid")
+
+		return repo, nil
+	})
+
+	if err != nil {
+		slog.Error("Failed to load NamedSingleton RIDTokenRepository as generic MongoDBRepository.", "err", err)
+		panic(err)
+	}
+
+	err = c.Singleton(func() (iam_out.RIDTokenWriter, error) {
+		var repo *db.RIDTokenRepository
+		err = c.Resolve(&repo)
+		if err != nil {
+			slog.Error("Failed to resolve RIDTokenRepository for iam_out.RIDTokenWriter.", "err", err)
+			return nil, err
+		}
+
+		return repo, nil
+	})
+
+	if err != nil {
+		slog.Error("Failed to load iam_out.RIDTokenWriter.", "err", err)
+		panic(err)
+	}
+
+	err = c.Singleton(func() (iam_out.RIDTokenReader, error) {
+		var repo *db.RIDTokenRepository
+		err = c.Resolve(&repo)
+		if err != nil {
+			slog.Error("Failed to resolve RIDTokenRepository for iam_out.RIDTokenReader.", "err", err)
+			return nil, err
+		}
+
+		return repo, nil
+	})
+
+	if err != nil {
+		slog.Error("Failed to load iam_out.RIDTokenReader.", "err", err)
+		panic(err)
+	}
+
+	err = c.Singleton(func() (*db.RIDSessionRepository, error) {
+		var client *mongo.Client
+		err := c.Resolve(&client)
+		if err != nil {
+			slog.Error("Failed to resolve mongo.Client for RIDSessionRepository.", "err", err)
+			return nil, err
+		}
+
+		var config common.Config
+		err = c.Resolve(&config)
+		if err != nil {
+			slog.Error("Failed to resolve config for db.RIDSessionRepository.", "err", err)
+			return nil, err
+		}
+
+		return db.NewRIDSessionRepository(client, config.MongoDB.DBName, iam_entities.RIDSession{}, "rid_sessions"), nil
+	})
+
+	if err != nil {
+		slog.Error("Failed to load RIDSessionRepository.", "err", err)
+		panic(err)
+	}
+
+	err = c.Singleton(func() (iam_out.RIDSessionWriter, error) {
+		var repo *db.RIDSessionRepository
+		err = c.Resolve(&repo)
+		if err != nil {
+			slog.Error("Failed to resolve RIDSessionRepository for iam_out.RIDSessionWriter.", "err", err)
+			return nil, err
+		}
+
+		return repo, nil
+	})
+
+	if err != nil {
+		slog.Error("Failed to load iam_out.RIDSessionWriter.", "err", err)
+		panic(err)
+	}
+
+	err = c.Singleton(func() (iam_out.RIDSessionReader, error) {
+		var repo *db.RIDSessionRepository
+		err = c.Resolve(&repo)
+		if err != nil {
+			slog.Error("Failed to resolve RIDSessionRepository for iam_out.RIDSessionReader.", "err", err)
+			return nil, err
+		}
+
+		return repo, nil
+	})
+
+	if err != nil {
+		slog.Error("Failed to load iam_out.RIDSessionReader.", "err", err)
+		panic(err)
+	}
+
+	// API Keys
+	err = c.Singleton(func() (*db.APIKeyRepository, error) {
+		var client *mongo.Client
+		err := c.Resolve(&client)
+		if err != nil {
+			slog.Error("Failed to resolve mongo.Client for APIKeyRepository.", "err", err)
+			return nil, err
+		}
+
+		var config common.Config
+		err = c.Resolve(&config)
+		if err != nil {
+			slog.Error("Failed to resolve config for db.APIKeyRepository.", "err", err)
+			return nil, err
+		}
+
+		return db.NewAPIKeyRepository(client, config.MongoDB.DBName, iam_entities.APIKey{}, "api_keys"), nil
+	})
+
+	if err != nil {
+		slog.Error("Failed to load APIKeyRepository.", "err", err)
+		panic(err)
+	}
+
+	err = c.Singleton(func() (iam_out.APIKeyWriter, error) {
+		var repo *db.APIKeyRepository
+		err = c.Resolve(&repo)
+		if err != nil {
+			slog.Error("Failed to resolve APIKeyRepository for iam_out.APIKeyWriter.", "err", err)
+			return nil, err
+		}
+
+		return repo, nil
+	})
+
+	if err != nil {
+		slog.Error("Failed to load iam_out.APIKeyWriter.", "err", err)
+		panic(err)
+	}
+
+	err = c.Singleton(func() (iam_out.APIKeyReader, error) {
+		var repo *db.APIKeyRepository
+		err = c.Resolve(&repo)
+		if err != nil {
+			slog.Error("Failed to resolve APIKeyRepository for iam_out.APIKeyReader.", "err", err)
+			return nil, err
+		}
+
+		return repo, nil
+	})
+
+	if err != nil {
+		slog.Error("Failed to load iam_out.APIKeyReader.", "err", err)
+		panic(err)
+	}
+
+	// Visibility Policies
+	err = c.Singleton(func() (*db.VisibilityPolicyRepository, error) {
+		var client *mongo.Client
+		err := c.Resolve(&client)
+		if err != nil {
+			slog.Error("Failed to resolve mongo.Client for VisibilityPolicyRepository.", "err", err)
+			return nil, err
+		}
+
+		var config common.Config
+		err = c.Resolve(&config)
+		if err != nil {
+			slog.Error("Failed to resolve config for db.VisibilityPolicyRepository.", "err", err)
+			return nil, err
+		}
+
+		return db.NewVisibilityPolicyRepository(client, config.MongoDB.DBName, common.VisibilityPolicy{}, "visibility_policies"), nil
+	})
+
+	if err != nil {
+		slog.Error("Failed to load VisibilityPolicyRepository.", "err", err)
+		panic(err)
+	}
+
+	err = c.Singleton(func() (iam_out.VisibilityPolicyWriter, error) {
+		var repo *db.VisibilityPolicyRepository
+		err = c.Resolve(&repo)
+		if err != nil {
+			slog.Error("Failed to resolve VisibilityPolicyRepository for iam_out.VisibilityPolicyWriter.", "err", err)
+			return nil, err
+		}
+
+		return repo, nil
+	})
+
+	if err != nil {
+		slog.Error("Failed to load iam_out.VisibilityPolicyWriter.", "err", err)
+		panic(err)
+	}
+
+	err = c.Singleton(func() (iam_out.VisibilityPolicyReader, error) {
+		var repo *db.VisibilityPolicyRepository
+		err = c.Resolve(&repo)
+		if err != nil {
+			slog.Error("Failed to resolve VisibilityPolicyRepository for iam_out.VisibilityPolicyReader.", "err", err)
+			return nil, err
+		}
+
+		return repo, nil
+	})
+
+	if err != nil {
+		slog.Error("Failed to load iam_out.VisibilityPolicyReader.", "err", err)
+		panic(err)
+	}
+
+	// Visibility Overrides
+	err = c.Singleton(func() (*db.VisibilityOverrideRepository, error) {
+		var client *mongo.Client
+		err := c.Resolve(&client)
+		if err != nil {
+			slog.Error("Failed to resolve mongo.Client for VisibilityOverrideRepository.", "err", err)
+			return nil, err
+		}
+
+		var config common.Config
+		err = c.Resolve(&config)
+		if err != nil {
+			slog.Error("Failed to resolve config for db.VisibilityOverrideRepository.", "err", err)
+			return nil, err
+		}
+
+		return db.NewVisibilityOverrideRepository(client, config.MongoDB.DBName, common.VisibilityOverride{}, "visibility_overrides"), nil
+	})
+
+	if err != nil {
+		slog.Error("Failed to load VisibilityOverrideRepository.", "err", err)
+		panic(err)
+	}
+
+	err = c.Singleton(func() (iam_out.VisibilityOverrideWriter, error) {
+		var repo *db.VisibilityOverrideRepository
+		err = c.Resolve(&repo)
+		if err != nil {
+			slog.Error("Failed to resolve VisibilityOverrideRepository for iam_out.VisibilityOverrideWriter.", "err", err)
+			return nil, err
+		}
+
+		return repo, nil
+	})
+
+	if err != nil {
+		slog.Error("Failed to load iam_out.VisibilityOverrideWriter.", "err", err)
+		panic(err)
+	}
+
+	err = c.Singleton(func() (iam_out.VisibilityOverrideReader, error) {
+		var repo *db.VisibilityOverrideRepository
+		err = c.Resolve(&repo)
+		if err != nil {
+			slog.Error("Failed to resolve VisibilityOverrideRepository for iam_out.VisibilityOverrideReader.", "err", err)
+			return nil, err
+		}
+
+		return repo, nil
+	})
+
+	if err != nil {
+		slog.Error("Failed to load iam_out.VisibilityOverrideReader.", "err", err)
+		panic(err)
+	}
+
+	// Roles
+	err = c.Singleton(func() (*db.RoleRepository, error) {
+		var client *mongo.Client
+		err := c.Resolve(&client)
+		if err != nil {
+			slog.Error("Failed to resolve mongo.Client for RoleRepository.", "err", err)
+			return nil, err
+		}
+
+		var config common.Config
+		err = c.Resolve(&config)
+		if err != nil {
+			slog.Error("Failed to resolve config for db.RoleRepository.", "err", err)
+			return nil, err
+		}
+
+		return db.NewRoleRepository(client, config.MongoDB.DBName, iam_entities.Role{}, "roles"), nil
+	})
+
+	if err != nil {
+		slog.Error("Failed to load RoleRepository.", "err", err)
+		panic(err)
+	}
+
+	err = c.Singleton(func() (iam_out.RoleWriter, error) {
+		var repo *db.RoleRepository
+		err = c.Resolve(&repo)
+		if err != nil {
+			slog.Error("Failed to resolve RoleRepository for iam_out.RoleWriter.", "err", err)
+			return nil, err
+		}
+
+		return repo, nil
+	})
+
+	if err != nil {
+		slog.Error("Failed to load iam_out.RoleWriter.", "err", err)
+		panic(err)
+	}
+
+	err = c.Singleton(func() (iam_out.RoleReader, error) {
+		var repo *db.RoleRepository
+		err = c.Resolve(&repo)
+		if err != nil {
+			slog.Error("Failed to resolve RoleRepository for iam_out.RoleReader.", "err", err)
+			return nil, err
+		}
+
+		return repo, nil
+	})
+
+	if err != nil {
+		slog.Error("Failed to load iam_out.RoleReader.", "err", err)
+		panic(err)
+	}
+
+	err = c.Singleton(func() (*db.RoleAssignmentRepository, error) {
+		var client *mongo.Client
+		err := c.Resolve(&client)
+		if err != nil {
+			slog.Error("Failed to resolve mongo.Client for RoleAssignmentRepository.", "err", err)
+			return nil, err
+		}
+
+		var config common.Config
+		err = c.Resolve(&config)
+		if err != nil {
+			slog.Error("Failed to resolve config for db.RoleAssignmentRepository.", "err", err)
+			return nil, err
+		}
+
+		return db.NewRoleAssignmentRepository(client, config.MongoDB.DBName, iam_entities.RoleAssignment{}, "role_assignments"), nil
+	})
+
+	if err != nil {
+		slog.Error("Failed to load RoleAssignmentRepository.", "err", err)
+		panic(err)
+	}
+
+	err = c.Singleton(func() (iam_out.RoleAssignmentWriter, error) {
+		var repo *db.RoleAssignmentRepository
+		err = c.Resolve(&repo)
+		if err != nil {
+			slog.Error("Failed to resolve RoleAssignmentRepository for iam_out.RoleAssignmentWriter.", "err", err)
+			return nil, err
+		}
+
+		return repo, nil
+	})
+
+	if err != nil {
+		slog.Error("Failed to load iam_out.RoleAssignmentWriter.", "err", err)
+		panic(err)
+	}
+
+	err = c.Singleton(func() (iam_out.RoleAssignmentReader, error) {
+		var repo *db.RoleAssignmentRepository
+		err = c.Resolve(&repo)
+		if err != nil {
+			slog.Error("Failed to resolve RoleAssignmentRepository for iam_out.RoleAssignmentReader.", "err", err)
+			return nil, err
+		}
+
+		return repo, nil
+	})
+
+	if err != nil {
+		slog.Error("Failed to load iam_out.RoleAssignmentReader.", "err", err)
+		panic(err)
+	}
+
+	// Squad
+	err = c.Singleton(func() (*db.SquadRepository, error) {
+		var client *mongo.Client
+		err := c.Resolve(&client)
+		if err != nil {
+			slog.Error("Failed to resolve mongo.Client for NamedSingleton SquadRepository as generic MongoDBRepository.", "err", err)
+			return &db.SquadRepository{}, err
+		}
+
+		var config common.Config
+
+		err = c.Resolve(&config)
+		if err != nil {
+			slog.Error("Failed to resolve config for db.SquadRepository.", "err", err)
+			return nil, err
+		}
+
+		repo := db.NewSquadRepository(client, config.MongoDB.DBName, squad_entities.Squad{}, "squads")
+
+		return repo, nil
+	})
+
+	if err != nil {
+		slog.Error("Failed to load NamedSingleton SquadRepository as generic MongoDBRepository.", "err", err)
+		panic(err)
+	}
+
+	err = c.Singleton(func() (squad_out.SquadReader, error) {
+		var repo *db.SquadRepository
+		err = c.Resolve(&repo)
+		if err != nil {
+			slog.Error("Failed to resolve SquadRepository for squad_out.SquadReader.", "err", err)
+			return nil, err
+		}
+
+		return repo, nil
+	})
+
+	if err != nil {
+		slog.Error("Failed to load squad_out.SquadReader.", "err", err)
+		panic(err)
+	}
+
+	err = c.Singleton(func() (squad_out.SquadWriter, error) {
+		var repo *db.SquadRepository
+		err = c.Resolve(&repo)
+		if err != nil {
+			slog.Error("Failed to resolve SquadRepository for squad_out.SquadWriter.", "err", err)
+			return nil, err
+		}
+
+		return repo, nil
+	})
+
+	if err != nil {
+		slog.Error("Failed to load squad_out.SquadWriter.", "err", err)
+		panic(err)
+	}
+
+	// Squad join requests (invites and applications)
+	err = c.Singleton(func() (*db.JoinRequestRepository, error) {
+		var client *mongo.Client
+		err := c.Resolve(&client)
+		if err != nil {
+			slog.Error("Failed to resolve mongo.Client for JoinRequestRepository.", "err", err)
+			return nil, err
+		}
+
+		var config common.Config
+		err = c.Resolve(&config)
+		if err != nil {
+			slog.Error("Failed to resolve config for db.JoinRequestRepository.", "err", err)
+			return nil, err
+		}
+
+		return db.NewJoinRequestRepository(client, config.MongoDB.DBName, squad_entities.JoinRequest{}, "squad_join_requests"), nil
+	})
+
+	if err != nil {
+		slog.Error("Failed to load JoinRequestRepository.", "err", err)
+		panic(err)
+	}
+
+	err = c.Singleton(func() (squad_out.JoinRequestReader, error) {
+		var repo *db.JoinRequestRepository
+		err = c.Resolve(&repo)
+		if err != nil {
+			slog.Error("Failed to resolve JoinRequestRepository for squad_out.JoinRequestReader.", "err", err)
+			return nil, err
+		}
+
+		return repo, nil
+	})
+
+	if err != nil {
+		slog.Error("Failed to load squad_out.JoinRequestReader.", "err", err)
+		panic(err)
+	}
+
+	err = c.Singleton(func() (squad_out.JoinRequestWriter, error) {
+		var repo *db.JoinRequestRepository
+		err = c.Resolve(&repo)
+		if err != nil {
+			slog.Error("Failed to resolve JoinRequestRepository for squad_out.JoinRequestWriter.", "err", err)
+			return nil, err
+		}
+
+		return repo, nil
+	})
+
+	if err != nil {
+		slog.Error("Failed to load squad_out.JoinRequestWriter.", "err", err)
+		panic(err)
+	}
+
+	// Squad history
+	err = c.Singleton(func() (*db.SquadHistoryRepository, error) {
+		var client *mongo.Client
+		err := c.Resolve(&client)
+		if err != nil {
+			slog.Error("Failed to resolve mongo.Client for SquadHistoryRepository.", "err", err)
+			return nil, err
+		}
+
+		var config common.Config
+		err = c.Resolve(&config)
+		if err != nil {
+			slog.Error("Failed to resolve config for db.SquadHistoryRepository.", "err", err)
+			return nil, err
+		}
+
+		return db.NewSquadHistoryRepository(client, config.MongoDB.DBName, squad_entities.SquadHistory{}, "squad_history"), nil
+	})
+
+	if err != nil {
+		slog.Error("Failed to load SquadHistoryRepository.", "err", err)
+		panic(err)
+	}
+
+	err = c.Singleton(func() (squad_out.SquadHistoryWriter, error) {
+		var repo *db.SquadHistoryRepository
+		err = c.Resolve(&repo)
+		if err != nil {
+			slog.Error("Failed to resolve SquadHistoryRepository for squad_out.SquadHistoryWriter.", "err", err)
+			return nil, err
+		}
+
+		return repo, nil
+	})
+
+	if err != nil {
+		slog.Error("Failed to load squad_out.SquadHistoryWriter.", "err", err)
+		panic(err)
+	}
+
+	// Free agents (LFT)
+	err = c.Singleton(func() (*db.FreeAgentRepository, error) {
+		var client *mongo.Client
+		err := c.Resolve(&client)
+		if err != nil {
+			slog.Error("Failed to resolve mongo.Client for FreeAgentRepository.", "err", err)
+			return nil, err
+		}
+
+		var config common.Config
+		err = c.Resolve(&config)
+		if err != nil {
+			slog.Error("Failed to resolve config for db.FreeAgentRepository.", "err", err)
+			return nil, err
+		}
+
+		return db.NewFreeAgentRepository(client, config.MongoDB.DBName, squad_entities.FreeAgent{}, "free_agents"), nil
+	})
+
+	if err != nil {
+		slog.Error("Failed to load FreeAgentRepository.", "err", err)
+		panic(err)
+	}
+
+	err = c.Singleton(func() (squad_out.FreeAgentWriter, error) {
+		var repo *db.FreeAgentRepository
+		err = c.Resolve(&repo)
+		if err != nil {
+			slog.Error("Failed to resolve FreeAgentRepository for squad_out.FreeAgentWriter.", "err", err)
+			return nil, err
+		}
+
+		return repo, nil
+	})
+
+	if err != nil {
+		slog.Error("Failed to load squad_out.FreeAgentWriter.", "err", err)
+		panic(err)
+	}
+
+	err = c.Singleton(func() (squad_out.FreeAgentReader, error) {
+		var repo *db.FreeAgentRepository
+		err = c.Resolve(&repo)
+		if err != nil {
+			slog.Error("Failed to resolve FreeAgentRepository for squad_out.FreeAgentReader.", "err", err)
+			return nil, err
+		}
+
+		return repo, nil
+	})
+
+	if err != nil {
+		slog.Error("Failed to load squad_out.FreeAgentReader.", "err", err)
+		panic(err)
+	}
+
+	// Squad openings
+	err = c.Singleton(func() (*db.SquadOpeningRepository, error) {
+		var client *mongo.Client
+		err := c.Resolve(&client)
+		if err != nil {
+			slog.Error("Failed to resolve mongo.Client for SquadOpeningRepository.", "err", err)
+			return nil, err
+		}
+
+		var config common.Config
+		err = c.Resolve(&config)
+		if err != nil {
+			slog.Error("Failed to resolve config for db.SquadOpeningRepository.", "err", err)
+			return nil, err
+		}
+
+		return db.NewSquadOpeningRepository(client, config.MongoDB.DBName, squad_entities.SquadOpening{}, "squad_openings"), nil
+	})
+
+	if err != nil {
+		slog.Error("Failed to load SquadOpeningRepository.", "err", err)
+		panic(err)
+	}
+
+	err = c.Singleton(func() (squad_out.SquadOpeningWriter, error) {
+		var repo *db.SquadOpeningRepository
+		err = c.Resolve(&repo)
+		if err != nil {
+			slog.Error("Failed to resolve SquadOpeningRepository for squad_out.SquadOpeningWriter.", "err", err)
+			return nil, err
+		}
+
+		return repo, nil
+	})
+
+	if err != nil {
+		slog.Error("Failed to load squad_out.SquadOpeningWriter.", "err", err)
+		panic(err)
+	}
+
+	err = c.Singleton(func() (squad_out.SquadOpeningReader, error) {
+		var repo *db.SquadOpeningRepository
+		err = c.Resolve(&repo)
+		if err != nil {
+			slog.Error("Failed to resolve SquadOpeningRepository for squad_out.SquadOpeningReader.", "err", err)
+			return nil, err
+		}
+
+		return repo, nil
+	})
+
+	if err != nil {
+		slog.Error("Failed to load squad_out.SquadOpeningReader.", "err", err)
+		panic(err)
+	}
+
+	// -----
+
+	// User
+	err = c.Singleton(func() (*db.UserRepository, error) {
+		var client *mongo.Client
+		err := c.Resolve(&client)
+		if err != nil {
+			slog.Error("Failed to resolve mongo.Client for NamedSingleton UserRepository as generic MongoDBRepository.", "err", err)
+			return &db.UserRepository{}, err
+		}
+
+		var config common.Config
+
+		err = c.Resolve(&config)
+		if err != nil {
+			slog.Error("Failed to resolve config for db.UserRepository.", "err", err)
+			return nil, err
+		}
+
+		repo := db.NewUserRepository(client, config.MongoDB.DBName, &iam_entities.User{}, "users")
+
+		return repo, nil
+	})
+
+	if err != nil {
+		slog.Error("Failed to load NamedSingleton UserRepository as generic MongoDBRepository.", "err", err)
+		panic(err)
+	}
+
+	err = c.Singleton(func() (iam_out.UserReader, error) {
+		var repo *db.UserRepository
+		err = c.Resolve(&repo)
+		if err != nil {
+			slog.Error("Failed to resolve UserRepository for iam_out.UserReader.", "err", err)
+			return nil, err
+		}
+
+		return repo, nil
+	})
+
+	if err != nil {
+		slog.Error("Failed to load iam_out.UserReader.", "err", err)
+		panic(err)
+	}
+
+	err = c.Singleton(func() (iam_out.UserWriter, error) {
+		var repo *db.UserRepository
+		err = c.Resolve(&repo)
+		if err != nil {
+			slog.Error("Failed to resolve UserRepository for iam_out.UserWriter.", "err", err)
+			return nil, err
+		}
+
+		return repo, nil
+	})
+
+	if err != nil {
+		slog.Error("Failed to load iam_out.UserWriter.", "err", err)
+		panic(err)
+	}
+
+	// -----
+
+	// Group
+	err = c.Singleton(func() (*db.GroupRepository, error) {
+		var client *mongo.Client
+		err := c.Resolve(&client)
+		if err != nil {
+			slog.Error("Failed to resolve mongo.Client for NamedSingleton GroupRepository as generic MongoDBRepository.", "err", err)
+			return &db.GroupRepository{}, err
+		}
+
+		var config common.Config
+
+		err = c.Resolve(&config)
+		if err != nil {
+			slog.Error("Failed to resolve config for db.GroupRepository.", "err", err)
+			return nil, err
+		}
+
+		repo := db.NewGroupRepository(client, config.MongoDB.DBName, &iam_entities.Group{}, "groups")
+
+		return repo, nil
+	})
+
+	if err != nil {
+		slog.Error("Failed to load NamedSingleton GroupRepository as generic MongoDBRepository.", "err", err)
+		panic(err)
+	}
+
+	err = c.Singleton(func() (iam_out.GroupReader, error) {
+		var repo *db.GroupRepository
+		err = c.Resolve(&repo)
+		if err != nil {
+			slog.Error("Failed to resolve GroupRepository for iam_out.GroupReader.", "err", err)
+			return nil, err
+		}
+
+		return repo, nil
+	})
+
+	if err != nil {
+		slog.Error("Failed to load iam_out.GroupReader.", "err", err)
+		panic(err)
+	}
+
+	err = c.Singleton(func() (iam_out.GroupWriter, error) {
+		var repo *db.GroupRepository
+		err = c.Resolve(&repo)
+		if err != nil {
+			slog.Error("Failed to resolve GroupRepository for iam_out.GroupWriter.", "err", err)
+			return nil, err
+		}
+
+		return repo, nil
+	})
+
+	if err != nil {
+		slog.Error("Failed to load iam_out.GroupWriter.", "err", err)
+		panic(err)
+	}
+
+	// -----
+
+	// Membership
+	err = c.Singleton(func() (*db.MembershipRepository, error) {
+		var client *mongo.Client
+		err := c.Resolve(&client)
+		if err != nil {
+			slog.Error("Failed to resolve mongo.Client for db.MembershipRepository.", "err", err)
+			return nil, err
+		}
+
+		var config common.Config
+
+		err = c.Resolve(&config)
+		if err != nil {
+			slog.Error("Failed to resolve config for db.MembershipRepository.", "err", err)
+			return nil, err
+		}
+
+		return db.NewMembershipRepository(client, config.MongoDB.DBName, iam_entities.Membership{}, "memberships"), nil
+	})
+
+	if err != nil {
+		slog.Error("Failed to load db.MembershipRepository.", "err", err)
+		panic(err)
+	}
+
+	err = c.Singleton(func() (iam_out.MembershipReader, error) {
+		var repo *db.MembershipRepository
+		err = c.Resolve(&repo)
+		if err != nil {
+			slog.Error("Failed to resolve db.MembershipRepository for iam_out.MembershipReader.", "err", err)
+			return nil, err
+		}
+
+		return repo, nil
+	})
+
+	if err != nil {
+		slog.Error("Failed to load iam_out.MembershipReader.", "err", err)
+		panic(err)
+	}
+
+	err = c.Singleton(func() (iam_out.MembershipWriter, error) {
+		var repo *db.MembershipRepository
+		err = c.Resolve(&repo)
+		if err != nil {
+			slog.Error("Failed to resolve db.MembershipRepository for iam_out.MembershipWriter.", "err", err)
+			return nil, err
+		}
+
+		return repo, nil
+	})
+
+	if err != nil {
+		slog.Error("Failed to load iam_out.MembershipWriter.", "err", err)
+		panic(err)
+	}
+
+	// -----
+
+	// Profile
+	err = c.Singleton(func() (*db.ProfileRepository, error) {
+		var client *mongo.Client
+		err := c.Resolve(&client)
+		if err != nil {
+			slog.Error("Failed to resolve mongo.Client for NamedSingleton ProfileRepository as generic MongoDBRepository.", "err", err)
+			return &db.ProfileRepository{}, err
+		}
+
+		var config common.Config
+
+		err = c.Resolve(&config)
+		if err != nil {
+			slog.Error("Failed to resolve config for db.ProfileRepository.", "err", err)
+			return nil, err
+		}
+
+		repo := db.NewProfileRepository(client, config.MongoDB.DBName, &iam_entities.Profile{}, "profiles")
+
+		return repo, nil
+	})
+
+	if err != nil {
+		slog.Error("Failed to load NamedSingleton ProfileRepository as generic MongoDBRepository.", "err", err)
+		panic(err)
+	}
+
+	err = c.Singleton(func() (iam_out.ProfileReader, error) {
+		var repo *db.ProfileRepository
+		err = c.Resolve(&repo)
+		if err != nil {
+			slog.Error("Failed to resolve ProfileRepository for iam_out.ProfileReader.", "err", err)
+			return nil, err
+		}
+
+		return repo, nil
+	})
+
+	if err != nil {
+		slog.Error("Failed to load iam_out.ProfileReader.", "err", err)
+		panic(err)
+	}
+
+	err = c.Singleton(func() (iam_out.ProfileWriter, error) {
+		var repo *db.ProfileRepository
+		err = c.Resolve(&repo)
+		if err != nil {
+			slog.Error("Failed to resolve ProfileRepository for iam_out.ProfileWriter.", "err", err)
+			return nil, err
+		}
+
+		return repo, nil
+	})
+
+	if err != nil {
+		slog.Error("Failed to load iam_out.ProfileWriter.", "err", err)
+		panic(err)
+	}
+
+	// analytics
+	err = c.Singleton(func() (*db.EngagementSnapshotRepository, error) {
+		var client *mongo.Client
+		err := c.Resolve(&client)
+		if err != nil {
+			slog.Error("Failed to resolve mongo.Client for EngagementSnapshotRepository.", "err", err)
+			return nil, err
+		}
+
+		var config common.Config
+		err = c.Resolve(&config)
+		if err != nil {
+			slog.Error("Failed to resolve config for db.EngagementSnapshotRepository.", "err", err)
+			return nil, err
+		}
+
+		return db.NewEngagementSnapshotRepository(client, config.MongoDB.DBName, analytics_entities.EngagementSnapshot{}, "engagement_snapshots"), nil
+	})
+
+	if err != nil {
+		slog.Error("Failed to load EngagementSnapshotRepository.", "err", err)
+		panic(err)
+	}
+
+	err = c.Singleton(func() (analytics_out.EngagementSnapshotReader, error) {
+		var repo *db.EngagementSnapshotRepository
+		err = c.Resolve(&repo)
+		if err != nil {
+			slog.Error("Failed to resolve EngagementSnapshotRepository for analytics_out.EngagementSnapshotReader.", "err", err)
+			return nil, err
+		}
+
+		return repo, nil
+	})
+
+	if err != nil {
+		slog.Error("Failed to load analytics_out.EngagementSnapshotReader.", "err", err)
+		panic(err)
+	}
+
+	err = c.Singleton(func() (analytics_out.EngagementSnapshotWriter, error) {
+		var repo *db.EngagementSnapshotRepository
+		err = c.Resolve(&repo)
+		if err != nil {
+			slog.Error("Failed to resolve EngagementSnapshotRepository for analytics_out.EngagementSnapshotWriter.", "err", err)
+			return nil, err
+		}
+
+		return repo, nil
+	})
+
+	if err != nil {
+		slog.Error("Failed to load analytics_out.EngagementSnapshotWriter.", "err", err)
+		panic(err)
+	}
+
+	err = c.Singleton(func() (analytics_out.UploadActivityReader, error) {
+		var client *mongo.Client
+		err := c.Resolve(&client)
+		if err != nil {
+			slog.Error("Failed to resolve mongo.Client for analytics_out.UploadActivityReader.", "err", err)
+			return nil, err
+		}
+
+		var config common.Config
+		err = c.Resolve(&config)
+		if err != nil {
+			slog.Error("Failed to resolve config for analytics_out.UploadActivityReader.", "err", err)
+			return nil, err
+		}
+
+		return db.NewUploadActivityRepository(client, config.MongoDB.DBName, "replay_file_metadata"), nil
+	})
+
+	if err != nil {
+		slog.Error("Failed to load analytics_out.UploadActivityReader.", "err", err)
+		panic(err)
+	}
+
+	// data quality
+	err = c.Singleton(func() (*db.FindingRepository, error) {
+		var client *mongo.Client
+		err := c.Resolve(&client)
+		if err != nil {
+			slog.Error("Failed to resolve mongo.Client for FindingRepository.", "err", err)
+			return nil, err
+		}
+
+		var config common.Config
+		err = c.Resolve(&config)
+		if err != nil {
+			slog.Error("Failed to resolve config for db.FindingRepository.", "err", err)
+			return nil, err
+		}
+
+		return db.NewFindingRepository(client, config.MongoDB.DBName, quality_entities.Finding{}, "data_quality_findings"), nil
+	})
+
+	if err != nil {
+		slog.Error("Failed to load FindingRepository.", "err", err)
+		panic(err)
+	}
+
+	err = c.Singleton(func() (quality_out.FindingReader, error) {
+		var repo *db.FindingRepository
+		err = c.Resolve(&repo)
+		if err != nil {
+			slog.Error("Failed to resolve FindingRepository for quality_out.FindingReader.", "err", err)
+			return nil, err
+		}
+
+		return repo, nil
+	})
+
+	if err != nil {
+		slog.Error("Failed to load quality_out.FindingReader.", "err", err)
+		panic(err)
+	}
+
+	err = c.Singleton(func() (quality_out.FindingWriter, error) {
+		var repo *db.FindingRepository
+		err = c.Resolve(&repo)
+		if err != nil {
+			slog.Error("Failed to resolve FindingRepository for quality_out.FindingWriter.", "err", err)
+			return nil, err
+		}
+
+		return repo, nil
+	})
+
+	if err != nil {
+		slog.Error("Failed to load quality_out.FindingWriter.", "err", err)
+		panic(err)
+	}
+
+	err = c.Singleton(func() ([]quality_out.DataQualityCheck, error) {
+		var client *mongo.Client
+		err := c.Resolve(&client)
+		if err != nil {
+			slog.Error("Failed to resolve mongo.Client for []quality_out.DataQualityCheck.", "err", err)
+			return nil, err
+		}
+
+		var config common.Config
+		err = c.Resolve(&config)
+		if err != nil {
+			slog.Error("Failed to resolve config for []quality_out.DataQualityCheck.", "err", err)
+			return nil, err
+		}
+
+		return []quality_out.DataQualityCheck{
+			db.NewProfilesWithoutUserCheck(client, config.MongoDB.DBName),
+			db.NewMatchesWithoutEventsCheck(client, config.MongoDB.DBName),
+			db.NewStuckReplayFilesCheck(client, config.MongoDB.DBName),
+		}, nil
+	})
+
+	if err != nil {
+		slog.Error("Failed to load []quality_out.DataQualityCheck.", "err", err)
+		panic(err)
+	}
+
+	err = c.Singleton(func() (quality_out.AlertNotifier, error) {
+		var config common.Config
+		err := c.Resolve(&config)
+		if err != nil {
+			slog.Error("Failed to resolve config for quality_out.AlertNotifier.", "err", err)
+			return nil, err
+		}
+
+		return alerts.NewWebhookAlertNotifier(config.Alerts.WebhookURL), nil
+	})
+
+	if err != nil {
+		slog.Error("Failed to load quality_out.AlertNotifier.", "err", err)
+		panic(err)
+	}
+
+	// fx: exchange rates
+	err = c.Singleton(func() ([]fx_out.ExchangeRateProvider, error) {
+		var config common.Config
+		err := c.Resolve(&config)
+		if err != nil {
+			slog.Error("Failed to resolve config for []fx_out.ExchangeRateProvider.", "err", err)
+			return nil, err
+		}
+
+		return fxrates.NewExchangeRateProviders(config.FX)
+	})
+
+	if err != nil {
+		slog.Error("Failed to load []fx_out.ExchangeRateProvider.", "err", err)
+		panic(err)
+	}
+
+	err = c.Singleton(func() (fx_out.RateAlertNotifier, error) {
+		var config common.Config
+		err := c.Resolve(&config)
+		if err != nil {
+			slog.Error("Failed to resolve config for fx_out.RateAlertNotifier.", "err", err)
+			return nil, err
+		}
+
+		return alerts.NewWebhookAlertNotifier(config.Alerts.WebhookURL), nil
+	})
+
+	if err != nil {
+		slog.Error("Failed to load fx_out.RateAlertNotifier.", "err", err)
+		panic(err)
+	}
+
+	err = c.Singleton(func() (scheduler.MissedScheduleNotifier, error) {
+		var config common.Config
+		err := c.Resolve(&config)
+		if err != nil {
+			slog.Error("Failed to resolve config for scheduler.MissedScheduleNotifier.", "err", err)
+			return nil, err
+		}
+
+		return alerts.NewWebhookAlertNotifier(config.Alerts.WebhookURL), nil
+	})
+
+	if err != nil {
+		slog.Error("Failed to load scheduler.MissedScheduleNotifier.", "err", err)
+		panic(err)
+	}
+
+	// replay: player match history (read model)
+	err = c.Singleton(func() (*db.PlayerMatchHistoryRepository, error) {
+		var client *mongo.Client
+		err := c.Resolve(&client)
+		if err != nil {
+			slog.Error("Failed to resolve mongo.Client for PlayerMatchHistoryRepository.", "err", err)
+			return nil, err
+		}
+
+		var config common.Config
+		err = c.Resolve(&config)
+		if err != nil {
+			slog.Error("Failed to resolve config for db.PlayerMatchHistoryRepository.", "err", err)
+			return nil, err
+		}
+
+		return db.NewPlayerMatchHistoryRepository(client, config.MongoDB.DBName, replay_entity.PlayerMatchHistory{}, "player_match_history"), nil
+	})
+
+	if err != nil {
+		slog.Error("Failed to load PlayerMatchHistoryRepository.", "err", err)
+		panic(err)
+	}
+
+	err = c.Singleton(func() (replay_out.PlayerMatchHistoryReader, error) {
+		var repo *db.PlayerMatchHistoryRepository
+		err = c.Resolve(&repo)
+		if err != nil {
+			slog.Error("Failed to resolve PlayerMatchHistoryRepository for replay_out.PlayerMatchHistoryReader.", "err", err)
+			return nil, err
+		}
+
+		return repo, nil
+	})
+
+	if err != nil {
+		slog.Error("Failed to load replay_out.PlayerMatchHistoryReader.", "err", err)
+		panic(err)
+	}
+
+	err = c.Singleton(func() (replay_out.PlayerMatchHistoryWriter, error) {
+		var repo *db.PlayerMatchHistoryRepository
+		err = c.Resolve(&repo)
+		if err != nil {
+			slog.Error("Failed to resolve PlayerMatchHistoryRepository for replay_out.PlayerMatchHistoryWriter.", "err", err)
+			return nil, err
+		}
+
+		return repo, nil
+	})
+
+	if err != nil {
+		slog.Error("Failed to load replay_out.PlayerMatchHistoryWriter.", "err", err)
+		panic(err)
+	}
+
+	// rating: Glicko-2 ratings of players and squads, and their history
+	err = c.Singleton(func() (*db.RatingRepository, error) {
+		var client *mongo.Client
+		err := c.Resolve(&client)
+		if err != nil {
+			slog.Error("Failed to resolve mongo.Client for RatingRepository.", "err", err)
+			return nil, err
+		}
+
+		var config common.Config
+		err = c.Resolve(&config)
+		if err != nil {
+			slog.Error("Failed to resolve config for db.RatingRepository.", "err", err)
+			return nil, err
+		}
+
+		return db.NewRatingRepository(client, config.MongoDB.DBName, rating_entities.Rating{}, "ratings"), nil
+	})
+
+	if err != nil {
+		slog.Error("Failed to load RatingRepository.", "err", err)
+		panic(err)
+	}
+
+	err = c.Singleton(func() (rating_out.RatingReader, error) {
+		var repo *db.RatingRepository
+		err = c.Resolve(&repo)
+		if err != nil {
+			slog.Error("Failed to resolve RatingRepository for rating_out.RatingReader.", "err", err)
+			return nil, err
+		}
+
+		return repo, nil
+	})
+
+	if err != nil {
+		slog.Error("Failed to load rating_out.RatingReader.", "err", err)
+		panic(err)
+	}
+
+	err = c.Singleton(func() (rating_out.RatingWriter, error) {
+		var repo *db.RatingRepository
+		err = c.Resolve(&repo)
+		if err != nil {
+			slog.Error("Failed to resolve RatingRepository for rating_out.RatingWriter.", "err", err)
+			return nil, err
+		}
+
+		return repo, nil
+	})
+
+	if err != nil {
+		slog.Error("Failed to load rating_out.RatingWriter.", "err", err)
+		panic(err)
+	}
+
+	err = c.Singleton(func() (*db.RatingHistoryRepository, error) {
+		var client *mongo.Client
+		err := c.Resolve(&client)
+		if err != nil {
+			slog.Error("Failed to resolve mongo.Client for RatingHistoryRepository.", "err", err)
+			return nil, err
+		}
+
+		var config common.Config
+		err = c.Resolve(&config)
+		if err != nil {
+			slog.Error("Failed to resolve config for db.RatingHistoryRepository.", "err", err)
+			return nil, err
+		}
+
+		return db.NewRatingHistoryRepository(client, config.MongoDB.DBName, rating_entities.RatingHistory{}, "rating_history"), nil
+	})
+
+	if err != nil {
+		slog.Error("Failed to load RatingHistoryRepository.", "err", err)
+		panic(err)
+	}
+
+	err = c.Singleton(func() (rating_out.RatingHistoryReader, error) {
+		var repo *db.RatingHistoryRepository
+		err = c.Resolve(&repo)
+		if err != nil {
+			slog.Error("Failed to resolve RatingHistoryRepository for rating_out.RatingHistoryReader.", "err", err)
+			return nil, err
+		}
+
+		return repo, nil
+	})
+
+	if err != nil {
+		slog.Error("Failed to load rating_out.RatingHistoryReader.", "err", err)
+		panic(err)
+	}
+
+	err = c.Singleton(func() (rating_out.RatingHistoryWriter, error) {
+		var repo *db.RatingHistoryRepository
+		err = c.Resolve(&repo)
+		if err != nil {
+			slog.Error("Failed to resolve RatingHistoryRepository for rating_out.RatingHistoryWriter.", "err", err)
+			return nil, err
+		}
+
+		return repo, nil
+	})
+
+	if err != nil {
+		slog.Error("Failed to load rating_out.RatingHistoryWriter.", "err", err)
+		panic(err)
+	}
+
+	// badges
+	err = c.Singleton(func() (*db.BadgeRepository, error) {
+		var client *mongo.Client
+		err := c.Resolve(&client)
+		if err != nil {
+			slog.Error("Failed to resolve mongo.Client for BadgeRepository.", "err", err)
+			return nil, err
+		}
+
+		var config common.Config
+		err = c.Resolve(&config)
+		if err != nil {
+			slog.Error("Failed to resolve config for db.BadgeRepository.", "err", err)
+			return nil, err
+		}
+
+		return db.NewBadgeRepository(client, config.MongoDB.DBName, badge_entities.Badge{}, "badges"), nil
+	})
+
+	if err != nil {
+		slog.Error("Failed to load BadgeRepository.", "err", err)
+		panic(err)
+	}
+
+	err = c.Singleton(func() (badge_out.BadgeReader, error) {
+		var repo *db.BadgeRepository
+		err = c.Resolve(&repo)
+		if err != nil {
+			slog.Error("Failed to resolve BadgeRepository for badge_out.BadgeReader.", "err", err)
+			return nil, err
+		}
+
+		return repo, nil
+	})
+
+	if err != nil {
+		slog.Error("Failed to load badge_out.BadgeReader.", "err", err)
+		panic(err)
+	}
+
+	err = c.Singleton(func() (badge_out.BadgeWriter, error) {
+		var repo *db.BadgeRepository
+		err = c.Resolve(&repo)
+		if err != nil {
+			slog.Error("Failed to resolve BadgeRepository for badge_out.BadgeWriter.", "err", err)
+			return nil, err
+		}
+
+		return repo, nil
+	})
+
+	if err != nil {
+		slog.Error("Failed to load badge_out.BadgeWriter.", "err", err)
+		panic(err)
+	}
+
+	err = c.Singleton(func() (*db.PlayerBadgeRepository, error) {
+		var client *mongo.Client
+		err := c.Resolve(&client)
+		if err != nil {
+			slog.Error("Failed to resolve mongo.Client for PlayerBadgeRepository.", "err", err)
+			return nil, err
+		}
+
+		var config common.Config
+		err = c.Resolve(&config)
+		if err != nil {
+			slog.Error("Failed to resolve config for db.PlayerBadgeRepository.", "err", err)
+			return nil, err
+		}
+
+		return db.NewPlayerBadgeRepository(client, config.MongoDB.DBName, badge_entities.PlayerBadge{}, "player_badges"), nil
+	})
+
+	if err != nil {
+		slog.Error("Failed to load PlayerBadgeRepository.", "err", err)
+		panic(err)
+	}
+
+	err = c.Singleton(func() (badge_out.PlayerBadgeReader, error) {
+		var repo *db.PlayerBadgeRepository
+		err = c.Resolve(&repo)
+		if err != nil {
+			slog.Error("Failed to resolve PlayerBadgeRepository for badge_out.PlayerBadgeReader.", "err", err)
+			return nil, err
+		}
+
+		return repo, nil
+	})
+
+	if err != nil {
+		slog.Error("Failed to load badge_out.PlayerBadgeReader.", "err", err)
+		panic(err)
+	}
+
+	err = c.Singleton(func() (badge_out.PlayerBadgeWriter, error) {
+		var repo *db.PlayerBadgeRepository
+		err = c.Resolve(&repo)
+		if err != nil {
+			slog.Error("Failed to resolve PlayerBadgeRepository for badge_out.PlayerBadgeWriter.", "err", err)
+			return nil, err
+		}
+
+		return repo, nil
+	})
+
+	if err != nil {
+		slog.Error("Failed to load badge_out.PlayerBadgeWriter.", "err", err)
+		panic(err)
+	}
+
+	err = c.Singleton(func() (badge_out.PlayerNetworkIDReader, error) {
+		var playerReader replay_out.PlayerMetadataReader
+		err := c.Resolve(&playerReader)
+		if err != nil {
+			slog.Error("Failed to resolve replay_out.PlayerMetadataReader for badge_out.PlayerNetworkIDReader.", "err", err)
+			return nil, err
+		}
+
+		return badges.NewPlayerNetworkIDReader(playerReader), nil
+	})
+
+	if err != nil {
+		slog.Error("Failed to load badge_out.PlayerNetworkIDReader.", "err", err)
+		panic(err)
+	}
+
+	// seasons
+	err = c.Singleton(func() (*db.SeasonRepository, error) {
+		var client *mongo.Client
+		err := c.Resolve(&client)
+		if err != nil {
+			slog.Error("Failed to resolve mongo.Client for SeasonRepository.", "err", err)
+			return nil, err
+		}
+
+		var config common.Config
+		err = c.Resolve(&config)
+		if err != nil {
+			slog.Error("Failed to resolve config for db.SeasonRepository.", "err", err)
+			return nil, err
+		}
+
+		return db.NewSeasonRepository(client, config.MongoDB.DBName, season_entities.Season{}, "seasons"), nil
+	})
+
+	if err != nil {
+		slog.Error("Failed to load SeasonRepository.", "err", err)
+		panic(err)
+	}
+
+	err = c.Singleton(func() (season_out.SeasonReader, error) {
+		var repo *db.SeasonRepository
+		err = c.Resolve(&repo)
+		if err != nil {
+			slog.Error("Failed to resolve SeasonRepository for season_out.SeasonReader.", "err", err)
+			return nil, err
+		}
+
+		return repo, nil
+	})
+
+	if err != nil {
+		slog.Error("Failed to load season_out.SeasonReader.", "err", err)
+		panic(err)
+	}
+
+	err = c.Singleton(func() (season_out.SeasonWriter, error) {
+		var repo *db.SeasonRepository
+		err = c.Resolve(&repo)
+		if err != nil {
+			slog.Error("Failed to resolve SeasonRepository for season_out.SeasonWriter.", "err", err)
+			return nil, err
+		}
+
+		return repo, nil
+	})
+
+	if err != nil {
+		slog.Error("Failed to load season_out.SeasonWriter.", "err", err)
+		panic(err)
+	}
+
+	err = c.Singleton(func() (*db.SeasonRewardRepository, error) {
+		var client *mongo.Client
+		err := c.Resolve(&client)
+		if err != nil {
+			slog.Error("Failed to resolve mongo.Client for SeasonRewardRepository.", "err", err)
+			return nil, err
+		}
+
+		var config common.Config
+		err = c.Resolve(&config)
+		if err != nil {
+			slog.Error("Failed to resolve config for db.SeasonRewardRepository.", "err", err)
+			return nil, err
+		}
+
+		return db.NewSeasonRewardRepository(client, config.MongoDB.DBName, season_entities.SeasonReward{}, "season_rewards"), nil
+	})
+
+	if err != nil {
+		slog.Error("Failed to load SeasonRewardRepository.", "err", err)
+		panic(err)
+	}
+
+	err = c.Singleton(func() (season_out.SeasonRewardReader, error) {
+		var repo *db.SeasonRewardRepository
+		err = c.Resolve(&repo)
+		if err != nil {
+			slog.Error("Failed to resolve SeasonRewardRepository for season_out.SeasonRewardReader.", "err", err)
+			return nil, err
+		}
+
+		return repo, nil
+	})
+
+	if err != nil {
+		slog.Error("Failed to load season_out.SeasonRewardReader.", "err", err)
+		panic(err)
+	}
+
+	err = c.Singleton(func() (season_out.SeasonRewardWriter, error) {
+		var repo *db.SeasonRewardRepository
+		err = c.Resolve(&repo)
+		if err != nil {
+			slog.Error("Failed to resolve SeasonRewardRepository for season_out.SeasonRewardWriter.", "err", err)
+			return nil, err
+		}
+
+		return repo, nil
+	})
+
+	if err != nil {
+		slog.Error("Failed to load season_out.SeasonRewardWriter.", "err", err)
+		panic(err)
+	}
+
+	err = c.Singleton(func() (replay_out.ActiveSeasonReader, error) {
+		var seasonReader season_out.SeasonReader
+		err := c.Resolve(&seasonReader)
+		if err != nil {
+			slog.Error("Failed to resolve season_out.SeasonReader for replay_out.ActiveSeasonReader.", "err", err)
+			return nil, err
+		}
+
+		return seasons.NewActiveSeasonReader(seasonReader), nil
+	})
+
+	if err != nil {
+		slog.Error("Failed to load replay_out.ActiveSeasonReader.", "err", err)
+		panic(err)
+	}
+
+	err = c.Singleton(func() (rating_out.ActiveSeasonReader, error) {
+		var seasonReader season_out.SeasonReader
+		err := c.Resolve(&seasonReader)
+		if err != nil {
+			slog.Error("Failed to resolve season_out.SeasonReader for rating_out.ActiveSeasonReader.", "err", err)
+			return nil, err
+		}
+
+		return seasons.NewActiveSeasonReader(seasonReader), nil
+	})
+
+	if err != nil {
+		slog.Error("Failed to load rating_out.ActiveSeasonReader.", "err", err)
+		panic(err)
+	}
+
+	// replay: round timelines
+	err = c.Singleton(func() (*db.RoundTimelineRepository, error) {
+		var client *mongo.Client
+		err := c.Resolve(&client)
+		if err != nil {
+			slog.Error("Failed to resolve mongo.Client for RoundTimelineRepository.", "err", err)
+			return nil, err
+		}
+
+		var config common.Config
+		err = c.Resolve(&config)
+		if err != nil {
+			slog.Error("Failed to resolve config for db.RoundTimelineRepository.", "err", err)
+			return nil, err
+		}
+
+		return db.NewRoundTimelineRepository(client, config.MongoDB.DBName, replay_entity.RoundTimeline{}, "round_timelines"), nil
+	})
+
+	if err != nil {
+		slog.Error("Failed to load RoundTimelineRepository.", "err", err)
+		panic(err)
+	}
+
+	err = c.Singleton(func() (replay_out.RoundTimelineReader, error) {
+		var repo *db.RoundTimelineRepository
+		err = c.Resolve(&repo)
+		if err != nil {
+			slog.Error("Failed to resolve RoundTimelineRepository for replay_out.RoundTimelineReader.", "err", err)
+			return nil, err
+		}
+
+		return repo, nil
+	})
+
+	if err != nil {
+		slog.Error("Failed to load replay_out.RoundTimelineReader.", "err", err)
+		panic(err)
+	}
+
+	err = c.Singleton(func() (replay_out.RoundTimelineWriter, error) {
+		var repo *db.RoundTimelineRepository
+		err = c.Resolve(&repo)
+		if err != nil {
+			slog.Error("Failed to resolve RoundTimelineRepository for replay_out.RoundTimelineWriter.", "err", err)
+			return nil, err
+		}
+
+		return repo, nil
+	})
+
+	if err != nil {
+		slog.Error("Failed to load replay_out.RoundTimelineWriter.", "err", err)
+		panic(err)
+	}
+
+	// replay: player stats
+	err = c.Singleton(func() (*db.PlayerStatsRepository, error) {
+		var client *mongo.Client
+		err := c.Resolve(&client)
+		if err != nil {
+			slog.Error("Failed to resolve mongo.Client for PlayerStatsRepository.", "err", err)
+			return nil, err
+		}
+
+		var config common.Config
+		err = c.Resolve(&config)
+		if err != nil {
+			slog.Error("Failed to resolve config for db.PlayerStatsRepository.", "err", err)
+			return nil, err
+		}
+
+		return db.NewPlayerStatsRepository(client, config.MongoDB.DBName, replay_entity.PlayerStatsBucket{}, "player_stats"), nil
+	})
+
+	if err != nil {
+		slog.Error("Failed to load PlayerStatsRepository.", "err", err)
+		panic(err)
+	}
+
+	err = c.Singleton(func() (replay_out.PlayerStatsReader, error) {
+		var repo *db.PlayerStatsRepository
+		err = c.Resolve(&repo)
+		if err != nil {
+			slog.Error("Failed to resolve PlayerStatsRepository for replay_out.PlayerStatsReader.", "err", err)
+			return nil, err
+		}
+
+		return repo, nil
+	})
+
+	if err != nil {
+		slog.Error("Failed to load replay_out.PlayerStatsReader.", "err", err)
+		panic(err)
+	}
+
+	err = c.Singleton(func() (replay_out.PlayerStatsWriter, error) {
+		var repo *db.PlayerStatsRepository
+		err = c.Resolve(&repo)
+		if err != nil {
+			slog.Error("Failed to resolve PlayerStatsRepository for replay_out.PlayerStatsWriter.", "err", err)
+			return nil, err
+		}
+
+		return repo, nil
+	})
+
+	if err != nil {
+		slog.Error("Failed to load replay_out.PlayerStatsWriter.", "err", err)
+		panic(err)
+	}
+
+	// replay: positions (heatmaps)
+	err = c.Singleton(func() (*db.ReplayPositionsRepository, error) {
+		var client *mongo.Client
+		err := c.Resolve(&client)
+		if err != nil {
+			slog.Error("Failed to resolve mongo.Client for ReplayPositionsRepository.", "err", err)
+			return nil, err
+		}
+
+		var config common.Config
+		err = c.Resolve(&config)
+		if err != nil {
+			slog.Error("Failed to resolve config for db.ReplayPositionsRepository.", "err", err)
+			return nil, err
+		}
+
+		return db.NewReplayPositionsRepository(client, config.MongoDB.DBName, replay_entity.ReplayPositions{}, "replay_positions"), nil
+	})
+
+	if err != nil {
+		slog.Error("Failed to load ReplayPositionsRepository.", "err", err)
+		panic(err)
+	}
+
+	err = c.Singleton(func() (replay_out.ReplayPositionsReader, error) {
+		var repo *db.ReplayPositionsRepository
+		err = c.Resolve(&repo)
+		if err != nil {
+			slog.Error("Failed to resolve ReplayPositionsRepository for replay_out.ReplayPositionsReader.", "err", err)
+			return nil, err
+		}
+
+		return repo, nil
+	})
+
+	if err != nil {
+		slog.Error("Failed to load replay_out.ReplayPositionsReader.", "err", err)
+		panic(err)
+	}
+
+	err = c.Singleton(func() (replay_out.ReplayPositionsWriter, error) {
+		var repo *db.ReplayPositionsRepository
+		err = c.Resolve(&repo)
+		if err != nil {
+			slog.Error("Failed to resolve ReplayPositionsRepository for replay_out.ReplayPositionsWriter.", "err", err)
+			return nil, err
+		}
+
+		return repo, nil
+	})
+
+	if err != nil {
+		slog.Error("Failed to load replay_out.ReplayPositionsWriter.", "err", err)
+		panic(err)
+	}
+
+	// replay: highlights
+	err = c.Singleton(func() (*db.ReplayHighlightsRepository, error) {
+		var client *mongo.Client
+		err := c.Resolve(&client)
+		if err != nil {
+			slog.Error("Failed to resolve mongo.Client for ReplayHighlightsRepository.", "err", err)
+			return nil, err
+		}
+
+		var config common.Config
+		err = c.Resolve(&config)
+		if err != nil {
+			slog.Error("Failed to resolve config for db.ReplayHighlightsRepository.", "err", err)
+			return nil, err
+		}
+
+		return db.NewReplayHighlightsRepository(client, config.MongoDB.DBName, replay_entity.ReplayHighlights{}, "replay_highlights"), nil
+	})
+
+	if err != nil {
+		slog.Error("Failed to load ReplayHighlightsRepository.", "err", err)
+		panic(err)
+	}
+
+	err = c.Singleton(func() (replay_out.ReplayHighlightsReader, error) {
+		var repo *db.ReplayHighlightsRepository
+		err = c.Resolve(&repo)
+		if err != nil {
+			slog.Error("Failed to resolve ReplayHighlightsRepository for replay_out.ReplayHighlightsReader.", "err", err)
+			return nil, err
+		}
+
+		return repo, nil
+	})
+
+	if err != nil {
+		slog.Error("Failed to load replay_out.ReplayHighlightsReader.", "err", err)
+		panic(err)
+	}
+
+	err = c.Singleton(func() (replay_out.ReplayHighlightsWriter, error) {
+		var repo *db.ReplayHighlightsRepository
+		err = c.Resolve(&repo)
+		if err != nil {
+			slog.Error("Failed to resolve ReplayHighlightsRepository for replay_out.ReplayHighlightsWriter.", "err", err)
+			return nil, err
+		}
+
+		return repo, nil
+	})
+
+	if err != nil {
+		slog.Error("Failed to load replay_out.ReplayHighlightsWriter.", "err", err)
+		panic(err)
+	}
+
+	// replay: match summaries
+	err = c.Singleton(func() (*db.MatchSummaryRepository, error) {
+		var client *mongo.Client
+		err := c.Resolve(&client)
+		if err != nil {
+			slog.Error("Failed to resolve mongo.Client for MatchSummaryRepository.", "err", err)
+			return nil, err
+		}
+
+		var config common.Config
+		err = c.Resolve(&config)
+		if err != nil {
+			slog.Error("Failed to resolve config for db.MatchSummaryRepository.", "err", err)
+			return nil, err
+		}
+
+		return db.NewMatchSummaryRepository(client, config.MongoDB.DBName, replay_entity.MatchSummary{}, "match_summaries"), nil
+	})
+
+	if err != nil {
+		slog.Error("Failed to load MatchSummaryRepository.", "err", err)
+		panic(err)
+	}
+
+	err = c.Singleton(func() (replay_out.MatchSummaryReader, error) {
+		var repo *db.MatchSummaryRepository
+		err = c.Resolve(&repo)
+		if err != nil {
+			slog.Error("Failed to resolve MatchSummaryRepository for replay_out.MatchSummaryReader.", "err", err)
+			return nil, err
+		}
+
+		return repo, nil
+	})
+
+	if err != nil {
+		slog.Error("Failed to load replay_out.MatchSummaryReader.", "err", err)
+		panic(err)
+	}
+
+	err = c.Singleton(func() (replay_out.MatchSummaryWriter, error) {
+		var repo *db.MatchSummaryRepository
+		err = c.Resolve(&repo)
+		if err != nil {
+			slog.Error("Failed to resolve MatchSummaryRepository for replay_out.MatchSummaryWriter.", "err", err)
+			return nil, err
+		}
+
+		return repo, nil
+	})
+
+	if err != nil {
+		slog.Error("Failed to load replay_out.MatchSummaryWriter.", "err", err)
+		panic(err)
+	}
+
+	// matchmaking: lobbies
+	err = c.Singleton(func() (*db.LobbyRepository, error) {
+		var client *mongo.Client
+		err := c.Resolve(&client)
+		if err != nil {
+			slog.Error("Failed to resolve mongo.Client for LobbyRepository.", "err", err)
+			return nil, err
+		}
+
+		var config common.Config
+		err = c.Resolve(&config)
+		if err != nil {
+			slog.Error("Failed to resolve config for db.LobbyRepository.", "err", err)
+			return nil, err
+		}
+
+		return db.NewLobbyRepository(client, config.MongoDB.DBName, matchmaking_entities.Lobby{}, "lobbies"), nil
+	})
+
+	if err != nil {
+		slog.Error("Failed to load LobbyRepository.", "err", err)
+		panic(err)
+	}
+
+	err = c.Singleton(func() (matchmaking_out.LobbyReader, error) {
+		var repo *db.LobbyRepository
+		err = c.Resolve(&repo)
+		if err != nil {
+			slog.Error("Failed to resolve LobbyRepository for matchmaking_out.LobbyReader.", "err", err)
+			return nil, err
+		}
+
+		return repo, nil
+	})
+
+	if err != nil {
+		slog.Error("Failed to load matchmaking_out.LobbyReader.", "err", err)
+		panic(err)
+	}
+
+	err = c.Singleton(func() (matchmaking_out.LobbyWriter, error) {
+		var repo *db.LobbyRepository
+		err = c.Resolve(&repo)
+		if err != nil {
+			slog.Error("Failed to resolve LobbyRepository for matchmaking_out.LobbyWriter.", "err", err)
+			return nil, err
+		}
+
+		return repo, nil
+	})
+
+	if err != nil {
+		slog.Error("Failed to load matchmaking_out.LobbyWriter.", "err", err)
+		panic(err)
+	}
+
+	err = c.Singleton(func() (matchmaking_out.VoiceChannelProvider, error) {
+		var config common.Config
+		err := c.Resolve(&config)
+		if err != nil {
+			slog.Error("Failed to resolve config for matchmaking_out.VoiceChannelProvider.", "err", err)
+			return nil, err
+		}
+
+		return voice.NewVoiceChannelProvider(config.Voice)
+	})
+
+	if err != nil {
+		slog.Error("Failed to load matchmaking_out.VoiceChannelProvider.", "err", err)
+		panic(err)
+	}
+
+	err = c.Singleton(func() (matchmaking_out.LobbyMatchWriter, error) {
+		var repo *db.MatchMetadataRepository
+		err = c.Resolve(&repo)
+		if err != nil {
+			slog.Error("Failed to resolve MatchMetadataRepository for matchmaking_out.LobbyMatchWriter.", "err", err)
+			return nil, err
+		}
+
+		return repo, nil
+	})
+
+	if err != nil {
+		slog.Error("Failed to load matchmaking_out.LobbyMatchWriter.", "err", err)
+		panic(err)
+	}
+
+	err = c.Singleton(func() (faceit_out.ExternalMatchWriter, error) {
+		var repo *db.MatchMetadataRepository
+		err = c.Resolve(&repo)
+		if err != nil {
+			slog.Error("Failed to resolve MatchMetadataRepository for faceit_out.ExternalMatchWriter.", "err", err)
+			return nil, err
+		}
+
+		return repo, nil
+	})
+
+	if err != nil {
+		slog.Error("Failed to load faceit_out.ExternalMatchWriter.", "err", err)
+		panic(err)
+	}
+
+	// matchmaking: pools, queue and strategy evaluations
+	err = c.Singleton(func() (*db.MatchmakingPoolRepository, error) {
+		var client *mongo.Client
+		err := c.Resolve(&client)
+		if err != nil {
+			slog.Error("Failed to resolve mongo.Client for MatchmakingPoolRepository.", "err", err)
+			return nil, err
+		}
+
+		var config common.Config
+		err = c.Resolve(&config)
+		if err != nil {
+			slog.Error("Failed to resolve config for db.MatchmakingPoolRepository.", "err", err)
+			return nil, err
+		}
+
+		return db.NewMatchmakingPoolRepository(client, config.MongoDB.DBName, matchmaking_entities.MatchmakingPool{}, "matchmaking_pools"), nil
+	})
+
+	if err != nil {
+		slog.Error("Failed to load MatchmakingPoolRepository.", "err", err)
+		panic(err)
+	}
+
+	err = c.Singleton(func() (matchmaking_out.MatchmakingPoolReader, error) {
+		var repo *db.MatchmakingPoolRepository
+		err = c.Resolve(&repo)
+		if err != nil {
+			slog.Error("Failed to resolve MatchmakingPoolRepository for matchmaking_out.MatchmakingPoolReader.", "err", err)
+			return nil, err
+		}
+
+		return repo, nil
+	})
+
+	if err != nil {
+		slog.Error("Failed to load matchmaking_out.MatchmakingPoolReader.", "err", err)
+		panic(err)
+	}
+
+	err = c.Singleton(func() (matchmaking_out.MatchmakingPoolWriter, error) {
+		var repo *db.MatchmakingPoolRepository
+		err = c.Resolve(&repo)
+		if err != nil {
+			slog.Error("Failed to resolve MatchmakingPoolRepository for matchmaking_out.MatchmakingPoolWriter.", "err", err)
+			return nil, err
+		}
+
+		return repo, nil
+	})
+
+	if err != nil {
+		slog.Error("Failed to load matchmaking_out.MatchmakingPoolWriter.", "err", err)
+		panic(err)
+	}
+
+	err = c.Singleton(func() (*db.QueueTicketRepository, error) {
+		var client *mongo.Client
+		err := c.Resolve(&client)
+		if err != nil {
+			slog.Error("Failed to resolve mongo.Client for QueueTicketRepository.", "err", err)
+			return nil, err
+		}
+
+		var config common.Config
+		err = c.Resolve(&config)
+		if err != nil {
+			slog.Error("Failed to resolve config for db.QueueTicketRepository.", "err", err)
 			return nil, err
 		}
 
-		return iam_use_cases.NewVerifyRIDUseCase(rIDWriter, rIDReader), nil
+		return db.NewQueueTicketRepository(client, config.MongoDB.DBName, matchmaking_entities.QueueTicket{}, "queue_tickets"), nil
 	})
 
 	if err != nil {
-		slog.Error("Failed to load iam_in.CreateRIDTokenCommand.")
+		slog.Error("Failed to load QueueTicketRepository.", "err", err)
 		panic(err)
 	}
 
-	err = c.Singleton(func() (iam_in.ProfileReader, error) {
-		var profileReader iam_out.ProfileReader
-		err := c.Resolve(&profileReader)
-
+	err = c.Singleton(func() (matchmaking_out.QueueTicketReader, error) {
+		var repo *db.QueueTicketRepository
+		err = c.Resolve(&repo)
 		if err != nil {
-			slog.Error("Failed to resolve iam_out.ProfileReader for iam_in.ProfileReader.", "err", err)
+			slog.Error("Failed to resolve QueueTicketRepository for matchmaking_out.QueueTicketReader.", "err", err)
 			return nil, err
 		}
 
-		return iam_query_services.NewwProfileQueryService(profileReader), nil
+		return repo, nil
 	})
 
-	return b
-}
-
-func (b *ContainerBuilder) WithSquadAPI() *ContainerBuilder {
-	c := b.Container
+	if err != nil {
+		slog.Error("Failed to load matchmaking_out.QueueTicketReader.", "err", err)
+		panic(err)
+	}
 
-	// InboundPorts
-	err := c.Singleton(func() (squad_in.SquadSearchableReader, error) {
-		var squadReader squad_out.SquadReader
-		err := c.Resolve(&squadReader)
+	err = c.Singleton(func() (matchmaking_out.QueueTicketWriter, error) {
+		var repo *db.QueueTicketRepository
+		err = c.Resolve(&repo)
 		if err != nil {
-			slog.Error("Failed to resolve SquadSearchableReader for SquadQueryService.", "err", err)
+			slog.Error("Failed to resolve QueueTicketRepository for matchmaking_out.QueueTicketWriter.", "err", err)
 			return nil, err
 		}
 
-		return squad_services.NewSquadQueryService(squadReader), nil
+		return repo, nil
 	})
 
 	if err != nil {
-		slog.Error("Failed to load SquadSearchableReader.")
+		slog.Error("Failed to load matchmaking_out.QueueTicketWriter.", "err", err)
 		panic(err)
 	}
 
-	// // OutboundPorts
-	err = c.Singleton(func() (squad_out.SquadReader, error) {
-		var squadReader squad_out.SquadReader
-		err = c.Resolve(&squadReader)
+	err = c.Singleton(func() (*db.QueuePenaltyRepository, error) {
+		var client *mongo.Client
+		err := c.Resolve(&client)
 		if err != nil {
-			slog.Error("Failed to resolve SquadReader for SquadQueryService.", "err", err)
+			slog.Error("Failed to resolve mongo.Client for QueuePenaltyRepository.", "err", err)
 			return nil, err
 		}
 
-		return squad_services.NewSquadQueryService(squadReader), nil
+		var config common.Config
+		err = c.Resolve(&config)
+		if err != nil {
+			slog.Error("Failed to resolve config for db.QueuePenaltyRepository.", "err", err)
+			return nil, err
+		}
+
+		return db.NewQueuePenaltyRepository(client, config.MongoDB.DBName, matchmaking_entities.QueuePenalty{}, "queue_penalties"), nil
 	})
 
 	if err != nil {
-		slog.Error("Failed to load SquadReader.")
+		slog.Error("Failed to load QueuePenaltyRepository.", "err", err)
 		panic(err)
 	}
 
-	return b
-
-}
-
-func (b *ContainerBuilder) WithKafkaConsumer() *ContainerBuilder {
-	// c := b.Container
+	err = c.Singleton(func() (matchmaking_out.QueuePenaltyReader, error) {
+		var repo *db.QueuePenaltyRepository
+		err = c.Resolve(&repo)
+		if err != nil {
+			slog.Error("Failed to resolve QueuePenaltyRepository for matchmaking_out.QueuePenaltyReader.", "err", err)
+			return nil, err
+		}
 
-	// err := c.Singleton(func() (out.KafkaConsumer, error) {
-	// 	consumer, err := kafka.NewConsumer(&kafka.ConfigMap{
-	// 		"bootstrap.servers":        "localhost:9092",
-	// 		"acks":                     1,
-	// 		"retries":                  0,
-	// 		"retry.backoff.ms":         100,
-	// 		"socket.timeout.ms":        6000,
-	// 		"reconnect.backoff.max.ms": 3000,
-	// 	})
-	// 	if err != nil {
-	// 		slog.Error(err.Error())
-	// 		panic(err)
-	// 	}
+		return repo, nil
+	})
 
-	// 	var config common.Config
+	if err != nil {
+		slog.Error("Failed to load matchmaking_out.QueuePenaltyReader.", "err", err)
+		panic(err)
+	}
 
-	// 	err := c.Resolve(&config)
-	// 	if err != nil {
-	// 		return nil, err
-	// 	}
+	err = c.Singleton(func() (matchmaking_out.QueuePenaltyWriter, error) {
+		var repo *db.QueuePenaltyRepository
+		err = c.Resolve(&repo)
+		if err != nil {
+			slog.Error("Failed to resolve QueuePenaltyRepository for matchmaking_out.QueuePenaltyWriter.", "err", err)
+			return nil, err
+		}
 
-	// 	return kafka.NewKafkaConsumer(config.Kafka), nil
-	// })
+		return repo, nil
+	})
 
-	// if err != nil {
-	// 	slog.Error("Failed to load KafkaConsumer.")
-	// 	panic(err)
-	// }
+	if err != nil {
+		slog.Error("Failed to load matchmaking_out.QueuePenaltyWriter.", "err", err)
+		panic(err)
+	}
 
-	return b
-}
+	err = c.Singleton(func() (*db.EconomyConfigRepository, error) {
+		var client *mongo.Client
+		err := c.Resolve(&client)
+		if err != nil {
+			slog.Error("Failed to resolve mongo.Client for EconomyConfigRepository.", "err", err)
+			return nil, err
+		}
 
-func InjectMongoDB(c container.Container) error {
-	err := c.Singleton(func() (*mongo.Client, error) {
 		var config common.Config
-
-		err := c.Resolve(&config)
+		err = c.Resolve(&config)
 		if err != nil {
-			slog.Error("Failed to resolve config for mongo.Client.", "err", err)
+			slog.Error("Failed to resolve config for db.EconomyConfigRepository.", "err", err)
 			return nil, err
 		}
 
-		mongoOptions := options.Client().ApplyURI(config.MongoDB.URI).SetRegistry(db.MongoRegistry).SetMaxPoolSize(100)
+		return db.NewEconomyConfigRepository(client, config.MongoDB.DBName, matchmaking_entities.EconomyConfig{}, "economy_configs"), nil
+	})
 
-		client, err := mongo.Connect(context.TODO(), mongoOptions)
+	if err != nil {
+		slog.Error("Failed to load EconomyConfigRepository.", "err", err)
+		panic(err)
+	}
 
+	err = c.Singleton(func() (matchmaking_out.EconomyConfigReader, error) {
+		var repo *db.EconomyConfigRepository
+		err = c.Resolve(&repo)
 		if err != nil {
-			slog.Error("Failed to connect to MongoDB.", "err", err)
+			slog.Error("Failed to resolve EconomyConfigRepository for matchmaking_out.EconomyConfigReader.", "err", err)
 			return nil, err
 		}
 
-		return client, nil
+		return repo, nil
 	})
 
 	if err != nil {
-		slog.Error("Failed to load mongo.Client.")
-		return err
+		slog.Error("Failed to load matchmaking_out.EconomyConfigReader.", "err", err)
+		panic(err)
 	}
 
-	// events repo
-	err = c.Singleton(func() (*db.EventsRepository, error) {
-		var config common.Config
-
-		err := c.Resolve(&config)
+	err = c.Singleton(func() (matchmaking_out.EconomyConfigWriter, error) {
+		var repo *db.EconomyConfigRepository
+		err = c.Resolve(&repo)
 		if err != nil {
-			slog.Error("Failed to resolve config for db.EventsRepository.", "err", err)
+			slog.Error("Failed to resolve EconomyConfigRepository for matchmaking_out.EconomyConfigWriter.", "err", err)
 			return nil, err
 		}
 
+		return repo, nil
+	})
+
+	if err != nil {
+		slog.Error("Failed to load matchmaking_out.EconomyConfigWriter.", "err", err)
+		panic(err)
+	}
+
+	err = c.Singleton(func() (*db.StrategyEvaluationRepository, error) {
 		var client *mongo.Client
-		err = c.Resolve(&client)
+		err := c.Resolve(&client)
 		if err != nil {
-			slog.Error("Failed to resolve mongo.Client for db.EventsRepository as generic MongoDBRepository.", "err", err)
-			return &db.EventsRepository{}, err
+			slog.Error("Failed to resolve mongo.Client for StrategyEvaluationRepository.", "err", err)
+			return nil, err
 		}
 
-		repo := db.NewEventsRepository(client, config.MongoDB.DBName, &replay_entity.GameEvent{}, "game_events")
+		var config common.Config
+		err = c.Resolve(&config)
+		if err != nil {
+			slog.Error("Failed to resolve config for db.StrategyEvaluationRepository.", "err", err)
+			return nil, err
+		}
 
-		return repo, nil
+		return db.NewStrategyEvaluationRepository(client, config.MongoDB.DBName, matchmaking_entities.StrategyEvaluation{}, "matchmaking_strategy_evaluations"), nil
 	})
 
 	if err != nil {
-		slog.Error("Failed to load NamedSingleton EventsRepository as generic MongoDBRepository.", "err", err)
+		slog.Error("Failed to load StrategyEvaluationRepository.", "err", err)
 		panic(err)
 	}
 
-	err = c.Singleton(func() (replay_out.EventsByGameReader, error) {
-		var repo *db.EventsRepository
+	err = c.Singleton(func() (matchmaking_out.StrategyEvaluationReader, error) {
+		var repo *db.StrategyEvaluationRepository
 		err = c.Resolve(&repo)
 		if err != nil {
-			slog.Error("Failed to resolve EventsRepository for replay_out.EventsByGameReader.", "err", err)
+			slog.Error("Failed to resolve StrategyEvaluationRepository for matchmaking_out.StrategyEvaluationReader.", "err", err)
 			return nil, err
 		}
 
@@ -673,15 +7577,15 @@ func InjectMongoDB(c container.Container) error {
 	})
 
 	if err != nil {
-		slog.Error("Failed to load replay_out.EventsByGameReader.", "err", err)
+		slog.Error("Failed to load matchmaking_out.StrategyEvaluationReader.", "err", err)
 		panic(err)
 	}
 
-	err = c.Singleton(func() (replay_out.GameEventReader, error) {
-		var repo *db.EventsRepository
+	err = c.Singleton(func() (matchmaking_out.StrategyEvaluationWriter, error) {
+		var repo *db.StrategyEvaluationRepository
 		err = c.Resolve(&repo)
 		if err != nil {
-			slog.Error("Failed to resolve EventsRepository for replay_out.GameEventReader.", "err", err)
+			slog.Error("Failed to resolve StrategyEvaluationRepository for matchmaking_out.StrategyEvaluationWriter.", "err", err)
 			return nil, err
 		}
 
@@ -689,118 +7593,123 @@ func InjectMongoDB(c container.Container) error {
 	})
 
 	if err != nil {
-		slog.Error("Failed to load replay_out.GameEventReader.", "err", err)
+		slog.Error("Failed to load matchmaking_out.StrategyEvaluationWriter.", "err", err)
 		panic(err)
 	}
 
-	err = c.Singleton(func() (replay_out.GameEventWriter, error) {
-		var repo *db.EventsRepository
-		err = c.Resolve(&repo)
+	err = c.Singleton(func() (matchmaking_out.PlayerRatingReader, error) {
+		var ratingReader rating_out.RatingReader
+		err := c.Resolve(&ratingReader)
 		if err != nil {
-			slog.Error("Failed to resolve EventsRepository for replay_out.GameEventWriter.", "err", err)
+			slog.Error("Failed to resolve rating_out.RatingReader for matchmaking_out.PlayerRatingReader.", "err", err)
 			return nil, err
 		}
 
-		return repo, nil
+		var seasonReader rating_out.ActiveSeasonReader
+		err = c.Resolve(&seasonReader)
+		if err != nil {
+			slog.Error("Failed to resolve rating_out.ActiveSeasonReader for matchmaking_out.PlayerRatingReader.", "err", err)
+			return nil, err
+		}
+
+		return ratings.NewStoredRatingReader(ratingReader, seasonReader), nil
 	})
 
 	if err != nil {
-		slog.Error("Failed to resolve replay_out.GameEventWriter.", "err", err)
+		slog.Error("Failed to load matchmaking_out.PlayerRatingReader.", "err", err)
 		panic(err)
 	}
 
-	// replay
-
-	err = c.Singleton(func() (*db.ReplayFileMetadataRepository, error) {
+	err = c.Singleton(func() (scheduler.LeaseLock, error) {
 		var client *mongo.Client
 		err := c.Resolve(&client)
 		if err != nil {
-			slog.Error("Failed to resolve mongo.Client for NamedSingleton ReplayFileMetadataRepository as generic MongoDBRepository.", "err", err)
-			return &db.ReplayFileMetadataRepository{}, err
+			slog.Error("Failed to resolve mongo.Client for scheduler.LeaseLock.", "err", err)
+			return nil, err
 		}
 
 		var config common.Config
-
 		err = c.Resolve(&config)
 		if err != nil {
-			slog.Error("Failed to resolve config for db.ReplayFileMetadataRepository.", "err", err)
+			slog.Error("Failed to resolve config for scheduler.LeaseLock.", "err", err)
 			return nil, err
 		}
 
-		repo := db.NewReplayFileMetadataRepository(client, config.MongoDB.DBName, replay_entity.ReplayFile{}, "replay_file_metadata")
-
-		return repo, nil
+		return db.NewLeaseLockRepository(client, config.MongoDB.DBName), nil
 	})
 
 	if err != nil {
-		slog.Error("Failed to load NamedSingleton ReplayFileMetadataRepository as generic MongoDBRepository.", "err", err)
+		slog.Error("Failed to load scheduler.LeaseLock.", "err", err)
 		panic(err)
 	}
 
-	err = c.Singleton(func() (replay_out.ReplayFileMetadataReader, error) {
-		var repo *db.ReplayFileMetadataRepository
-		err = c.Resolve(&repo)
+	err = c.Singleton(func() (scheduler.JobStore, error) {
+		var client *mongo.Client
+		err := c.Resolve(&client)
 		if err != nil {
-			slog.Error("Failed to resolve ReplayFileMetadataRepository for replay_out.ReplayFileMetadataReader.", "err", err)
+			slog.Error("Failed to resolve mongo.Client for scheduler.JobStore.", "err", err)
 			return nil, err
 		}
 
-		return repo, nil
-	})
-
-	if err != nil {
-		slog.Error("Failed to load replay_out.ReplayFileMetadataReader.", "err", err)
-		panic(err)
-	}
-
-	err = c.Singleton(func() (replay_out.ReplayFileMetadataWriter, error) {
-		var repo *db.ReplayFileMetadataRepository
-		err = c.Resolve(&repo)
+		var config common.Config
+		err = c.Resolve(&config)
 		if err != nil {
-			slog.Error("Failed to resolve SteamUserRepository for replay_out.ReplayFileMetadataWriter.", "err", err)
+			slog.Error("Failed to resolve config for scheduler.JobStore.", "err", err)
 			return nil, err
 		}
 
-		return repo, nil
+		return db.NewJobStoreRepository(client, config.MongoDB.DBName), nil
 	})
 
 	if err != nil {
-		slog.Error("Failed to load replay_out.ReplayFileMetadataWriter.", "err", err)
+		slog.Error("Failed to load scheduler.JobStore.", "err", err)
 		panic(err)
 	}
 
-	// MATCH METADATA
-	err = c.Singleton(func() (*db.MatchMetadataRepository, error) {
+	err = c.Singleton(func() (*db.PolicyVersionRepository, error) {
 		var client *mongo.Client
 		err := c.Resolve(&client)
 		if err != nil {
-			slog.Error("Failed to resolve mongo.Client for NamedSingleton MatchMetadataRepository as generic MongoDBRepository.", "err", err)
-			return &db.MatchMetadataRepository{}, err
+			slog.Error("Failed to resolve mongo.Client for PolicyVersionRepository.", "err", err)
+			return nil, err
 		}
 
 		var config common.Config
-
 		err = c.Resolve(&config)
 		if err != nil {
-			slog.Error("Failed to resolve config for db.MatchMetadataRepository.", "err", err)
+			slog.Error("Failed to resolve config for db.PolicyVersionRepository.", "err", err)
 			return nil, err
 		}
 
-		repo := db.NewMatchMetadataRepository(client, config.MongoDB.DBName, replay_entity.Match{}, "match_metadata")
+		return db.NewPolicyVersionRepository(client, config.MongoDB.DBName, consent_entities.PolicyVersion{}, "policy_versions"), nil
+	})
+
+	if err != nil {
+		slog.Error("Failed to load PolicyVersionRepository.", "err", err)
+		panic(err)
+	}
+
+	err = c.Singleton(func() (consent_out.PolicyVersionReader, error) {
+		var repo *db.PolicyVersionRepository
+		err = c.Resolve(&repo)
+		if err != nil {
+			slog.Error("Failed to resolve PolicyVersionRepository for consent_out.PolicyVersionReader.", "err", err)
+			return nil, err
+		}
 
 		return repo, nil
 	})
 
 	if err != nil {
-		slog.Error("Failed to load NamedSingleton ReplayFileMetadataRepository as generic MongoDBRepository.", "err", err)
+		slog.Error("Failed to load consent_out.PolicyVersionReader.", "err", err)
 		panic(err)
 	}
 
-	err = c.Singleton(func() (replay_out.MatchMetadataReader, error) {
-		var repo *db.MatchMetadataRepository
+	err = c.Singleton(func() (consent_out.PolicyVersionWriter, error) {
+		var repo *db.PolicyVersionRepository
 		err = c.Resolve(&repo)
 		if err != nil {
-			slog.Error("Failed to resolve MatchMetadataRepository for replay_out.MatchMetadataReader.", "err", err)
+			slog.Error("Failed to resolve PolicyVersionRepository for consent_out.PolicyVersionWriter.", "err", err)
 			return nil, err
 		}
 
@@ -808,42 +7717,54 @@ func InjectMongoDB(c container.Container) error {
 	})
 
 	if err != nil {
-		slog.Error("Failed to load replay_out.MatchMetadataReader.", "err", err)
+		slog.Error("Failed to load consent_out.PolicyVersionWriter.", "err", err)
 		panic(err)
 	}
 
-	// Player Metadata Repository
-	err = c.Singleton(func() (*db.PlayerRepository, error) {
+	err = c.Singleton(func() (*db.ConsentRecordRepository, error) {
 		var client *mongo.Client
 		err := c.Resolve(&client)
 		if err != nil {
-			slog.Error("Failed to resolve mongo.Client for PlayerRepository as generic MongoDBRepository.", "err", err)
-			return &db.PlayerRepository{}, err
+			slog.Error("Failed to resolve mongo.Client for ConsentRecordRepository.", "err", err)
+			return nil, err
 		}
 
 		var config common.Config
-
 		err = c.Resolve(&config)
 		if err != nil {
-			slog.Error("Failed to resolve config for db.PlayerRepository.", "err", err)
+			slog.Error("Failed to resolve config for db.ConsentRecordRepository.", "err", err)
 			return nil, err
 		}
 
-		repo := db.NewPlayerRepository(client, config.MongoDB.DBName, replay_entity.Player{}, "player_metadata")
+		return db.NewConsentRecordRepository(client, config.MongoDB.DBName, consent_entities.ConsentRecord{}, "consent_records"), nil
+	})
+
+	if err != nil {
+		slog.Error("Failed to load ConsentRecordRepository.", "err", err)
+		panic(err)
+	}
+
+	err = c.Singleton(func() (consent_out.ConsentRecordReader, error) {
+		var repo *db.ConsentRecordRepository
+		err = c.Resolve(&repo)
+		if err != nil {
+			slog.Error("Failed to resolve ConsentRecordRepository for consent_out.ConsentRecordReader.", "err", err)
+			return nil, err
+		}
 
 		return repo, nil
 	})
 
 	if err != nil {
-		slog.Error("Failed to load PlayerRepository as generic MongoDBRepository.", "err", err)
+		slog.Error("Failed to load consent_out.ConsentRecordReader.", "err", err)
 		panic(err)
 	}
 
-	err = c.Singleton(func() (replay_out.PlayerMetadataReader, error) {
-		var repo *db.PlayerRepository
+	err = c.Singleton(func() (consent_out.ConsentRecordWriter, error) {
+		var repo *db.ConsentRecordRepository
 		err = c.Resolve(&repo)
 		if err != nil {
-			slog.Error("Failed to resolve PlayerRepository for replay_out.PlayerMetadataReader.", "err", err)
+			slog.Error("Failed to resolve ConsentRecordRepository for consent_out.ConsentRecordWriter.", "err", err)
 			return nil, err
 		}
 
@@ -851,15 +7772,38 @@ func InjectMongoDB(c container.Container) error {
 	})
 
 	if err != nil {
-		slog.Error("Failed to load replay_out.PlayerMetadataReader.", "err", err)
+		slog.Error("Failed to load consent_out.ConsentRecordWriter.", "err", err)
+		panic(err)
+	}
+
+	err = c.Singleton(func() (*db.EmailLoginTokenRepository, error) {
+		var client *mongo.Client
+		err := c.Resolve(&client)
+		if err != nil {
+			slog.Error("Failed to resolve mongo.Client for EmailLoginTokenRepository.", "err", err)
+			return nil, err
+		}
+
+		var config common.Config
+		err = c.Resolve(&config)
+		if err != nil {
+			slog.Error("Failed to resolve config for db.EmailLoginTokenRepository.", "err", err)
+			return nil, err
+		}
+
+		return db.NewEmailLoginTokenRepository(client, config.MongoDB.DBName, email_entities.EmailLoginToken{}, "email_login_tokens"), nil
+	})
+
+	if err != nil {
+		slog.Error("Failed to load EmailLoginTokenRepository.", "err", err)
 		panic(err)
 	}
 
-	err = c.Singleton(func() (replay_out.PlayerMetadataWriter, error) {
-		var repo *db.PlayerRepository
+	err = c.Singleton(func() (email_out.EmailLoginTokenReader, error) {
+		var repo *db.EmailLoginTokenRepository
 		err = c.Resolve(&repo)
 		if err != nil {
-			slog.Error("Failed to resolve PlayerRepository for replay_out.PlayerMetadataWriter.", "err", err)
+			slog.Error("Failed to resolve EmailLoginTokenRepository for email_out.EmailLoginTokenReader.", "err", err)
 			return nil, err
 		}
 
@@ -867,15 +7811,15 @@ func InjectMongoDB(c container.Container) error {
 	})
 
 	if err != nil {
-		slog.Error("Failed to load replay_out.PlayerMetadataWriter.", "err", err)
+		slog.Error("Failed to load email_out.EmailLoginTokenReader.", "err", err)
 		panic(err)
 	}
 
-	err = c.Singleton(func() (replay_out.MatchMetadataWriter, error) {
-		var repo *db.MatchMetadataRepository
+	err = c.Singleton(func() (email_out.EmailLoginTokenWriter, error) {
+		var repo *db.EmailLoginTokenRepository
 		err = c.Resolve(&repo)
 		if err != nil {
-			slog.Error("Failed to resolve PlayerRepository for replay_out.MatchMetadataWriter.", "err", err)
+			slog.Error("Failed to resolve EmailLoginTokenRepository for email_out.EmailLoginTokenWriter.", "err", err)
 			return nil, err
 		}
 
@@ -883,138 +7827,110 @@ func InjectMongoDB(c container.Container) error {
 	})
 
 	if err != nil {
-		slog.Error("Failed to load replay_out.MatchMetadataWriter.", "err", err)
+		slog.Error("Failed to load email_out.EmailLoginTokenWriter.", "err", err)
 		panic(err)
 	}
 
-	err = c.Singleton(func() (replay_in.PlayerReader, error) {
-		var repo *db.PlayerRepository
-		err = c.Resolve(&repo)
+	err = c.Singleton(func() (email_out.MagicLinkSender, error) {
+		var config common.Config
+		err := c.Resolve(&config)
 		if err != nil {
-			slog.Error("Failed to resolve PlayerRepository for replay_in.PlayerReader.", "err", err)
+			slog.Error("Failed to resolve config for email_out.MagicLinkSender.", "err", err)
 			return nil, err
 		}
 
-		return repo, nil
+		return mail.NewSMTPMagicLinkSender(config.Email), nil
 	})
 
 	if err != nil {
-		slog.Error("Failed to load replay_in.PlayerReader.", "err", err)
+		slog.Error("Failed to load email_out.MagicLinkSender.", "err", err)
 		panic(err)
 	}
 
-	// err = c.Singleton(func() (replay_out.BadgeReader, error) {
-	// 	var repo *db.BadgeRepository
-	// 	err = c.Resolve(&repo)
-	// 	if err != nil {
-	// 		slog.Error("Failed to resolve BadgeRepository for replay_out.BadgeReader.", "err", err)
-	// 		return nil, err
-	// 	}
-
-	// 	return repo, nil
-	// })
-
-	// if err != nil {
-	// 	slog.Error("Failed to load replay_out.BadgeReader.", "err", err)
-	// 	panic(err)
-	// }
-
-	err = c.Singleton(func() (replay_out.ReplayFileContentWriter, error) {
+	// exports
+	err = c.Singleton(func() (*db.ExportConfigRepository, error) {
 		var client *mongo.Client
-
 		err := c.Resolve(&client)
 		if err != nil {
-			slog.Error("Failed to resolve mongo.Client for ReplayFileContentWriter.", "err", err)
+			slog.Error("Failed to resolve mongo.Client for ExportConfigRepository.", "err", err)
 			return nil, err
 		}
 
 		var config common.Config
-
 		err = c.Resolve(&config)
 		if err != nil {
-			slog.Error("Failed to resolve config for replay_out.ReplayFileContentWriter.", "err", err)
+			slog.Error("Failed to resolve config for db.ExportConfigRepository.", "err", err)
 			return nil, err
 		}
 
-		// return s3.NewS3Adapter(config.S3), nil
-		// return local_files.NewLocalFileAdapter(), nil
-		return db.NewReplayFileContentRepository(client), nil
+		return db.NewExportConfigRepository(client, config.MongoDB.DBName, export_entities.ExportConfig{}, "export_configs"), nil
 	})
 
 	if err != nil {
-		slog.Error("Failed to load S3Adapter.", "err", err)
+		slog.Error("Failed to load ExportConfigRepository.", "err", err)
 		panic(err)
 	}
 
-	err = c.Singleton(func() (replay_out.ReplayFileContentReader, error) {
-		var config common.Config
-
-		err := c.Resolve(&config)
-		if err != nil {
-			slog.Error("Failed to resolve config for ReplayFileContentReader.", "err", err)
-			return nil, err
-		}
-
-		// return blob.NewS3Adapter(config.S3), nil
-		// return local_files.NewLocalFileAdapter(), nil
-
-		var client *mongo.Client
-
-		err = c.Resolve(&client)
+	err = c.Singleton(func() (export_out.ExportConfigReader, error) {
+		var repo *db.ExportConfigRepository
+		err = c.Resolve(&repo)
 		if err != nil {
-			slog.Error("Failed to resolve mongo.Client for ReplayFileContentReader.", "err", err)
+			slog.Error("Failed to resolve ExportConfigRepository for export_out.ExportConfigReader.", "err", err)
 			return nil, err
 		}
 
-		return db.NewReplayFileContentRepository(client), nil
+		return repo, nil
 	})
 
 	if err != nil {
-		slog.Error("Failed to load S3Adapter.")
+		slog.Error("Failed to load export_out.ExportConfigReader.", "err", err)
 		panic(err)
 	}
 
-	err = c.Singleton(func() replay_out.ReplayParser {
-		return cs_app.NewCS2ReplayAdapter()
+	err = c.Singleton(func() (export_out.ExportConfigWriter, error) {
+		var repo *db.ExportConfigRepository
+		err = c.Resolve(&repo)
+		if err != nil {
+			slog.Error("Failed to resolve ExportConfigRepository for export_out.ExportConfigWriter.", "err", err)
+			return nil, err
+		}
+
+		return repo, nil
 	})
 
 	if err != nil {
-		slog.Error("Failed to load CS2ReplayAdapter.", "err", err)
+		slog.Error("Failed to load export_out.ExportConfigWriter.", "err", err)
 		panic(err)
 	}
 
-	// steam repo
-	err = c.Singleton(func() (*db.SteamUserRepository, error) {
+	err = c.Singleton(func() (*db.ExportReceiptRepository, error) {
 		var client *mongo.Client
 		err := c.Resolve(&client)
 		if err != nil {
-			slog.Error("Failed to resolve mongo.Client for NamedSingleton SteamUserRepository as generic MongoDBRepository.", "err", err)
+			slog.Error("Failed to resolve mongo.Client for ExportReceiptRepository.", "err", err)
 			return nil, err
 		}
 
 		var config common.Config
-
 		err = c.Resolve(&config)
 		if err != nil {
-			slog.Error("Failed to resolve config for db.SteamUserRepository.", "err", err)
+			slog.Error("Failed to resolve config for db.ExportReceiptRepository.", "err", err)
 			return nil, err
 		}
 
-		repo := db.NewSteamUserMongoDBRepository(client, config.MongoDB.DBName, steam_entity.SteamUser{}, "steam_users")
-
-		return repo, nil
+		return db.NewExportReceiptRepository(client, config.MongoDB.DBName, export_entities.ExportReceipt{}, "export_receipts"), nil
 	})
 
 	if err != nil {
-		slog.Error("Failed to load NamedSingleton SteamUserRepository as generic MongoDBRepository.", "err", err)
+		slog.Error("Failed to load ExportReceiptRepository.", "err", err)
 		panic(err)
 	}
 
-	err = c.Singleton(func() (steam_out.SteamUserWriter, error) {
-		var repo *db.SteamUserRepository
+	err = c.Singleton(func() (export_out.ExportReceiptReader, error) {
+		var repo *db.ExportReceiptRepository
 		err = c.Resolve(&repo)
 		if err != nil {
-			slog.Error("Failed to resolve SteamUserRepository for steam_out.SteamUserWriter.", "err", err)
+			slog.Error("Failed to resolve ExportReceiptRepository for export_out.ExportReceiptReader.", "err", err)
 			return nil, err
 		}
 
@@ -1022,15 +7938,15 @@ func InjectMongoDB(c container.Container) error {
 	})
 
 	if err != nil {
-		slog.Error("Failed to load SteamUserWriter.", "err", err)
+		slog.Error("Failed to load export_out.ExportReceiptReader.", "err", err)
 		panic(err)
 	}
 
-	err = c.Singleton(func() (steam_out.SteamUserReader, error) {
-		var repo *db.SteamUserRepository
+	err = c.Singleton(func() (export_out.ExportReceiptWriter, error) {
+		var repo *db.ExportReceiptRepository
 		err = c.Resolve(&repo)
 		if err != nil {
-			slog.Error("Failed to resolve SteamUserRepository for steam_out.SteamUserReader.", "err", err)
+			slog.Error("Failed to resolve ExportReceiptRepository for export_out.ExportReceiptWriter.", "err", err)
 			return nil, err
 		}
 
@@ -1038,61 +7954,56 @@ func InjectMongoDB(c container.Container) error {
 	})
 
 	if err != nil {
-		slog.Error("Failed to load SteamUserReader.", "err", err)
+		slog.Error("Failed to load export_out.ExportReceiptWriter.", "err", err)
 		panic(err)
 	}
 
-	err = c.Singleton(func() (steam_out.VHashWriter, error) {
+	// lazy: only created by the scheduler (deliveries assume the role of each destination with the credentials of the api)
+	err = c.SingletonLazy(func() (export_out.ExportDelivery, error) {
 		var config common.Config
-
 		err := c.Resolve(&config)
 		if err != nil {
-			slog.Error("Failed to resolve config for steam_out.VHashWriter.", "err", err)
+			slog.Error("Failed to resolve config for export_out.ExportDelivery.", "err", err)
 			return nil, err
 		}
 
-		return encryption.NewSHA256VHasherAdapter(config.Auth.SteamConfig.VHashSource), nil
+		return s3.NewExportDelivery(config.S3)
 	})
 
 	if err != nil {
-		slog.Error("Failed to load VHashWriter.", "err", err)
+		slog.Error("Failed to load export_out.ExportDelivery.", "err", err)
 		panic(err)
 	}
 
-	// end-steam
-
-	// GOOGLE repo
-	err = c.Singleton(func() (*db.GoogleUserRepository, error) {
+	// notifications
+	err = c.Singleton(func() (*db.DeviceTokenRepository, error) {
 		var client *mongo.Client
 		err := c.Resolve(&client)
 		if err != nil {
-			slog.Error("Failed to resolve mongo.Client for NamedSingleton GoogleUserRepository as generic MongoDBRepository.", "err", err)
+			slog.Error("Failed to resolve mongo.Client for DeviceTokenRepository.", "err", err)
 			return nil, err
 		}
 
 		var config common.Config
-
 		err = c.Resolve(&config)
 		if err != nil {
-			slog.Error("Failed to resolve config for db.GoogleUserRepository.", "err", err)
+			slog.Error("Failed to resolve config for db.DeviceTokenRepository.", "err", err)
 			return nil, err
 		}
 
-		repo := db.NewGoogleUserMongoDBRepository(client, config.MongoDB.DBName, google_entities.GoogleUser{}, "google_users")
-
-		return repo, nil
+		return db.NewDeviceTokenRepository(client, config.MongoDB.DBName, notification_entities.DeviceToken{}, "device_tokens"), nil
 	})
 
 	if err != nil {
-		slog.Error("Failed to load NamedSingleton GoogleUserRepository as generic MongoDBRepository.", "err", err)
+		slog.Error("Failed to load DeviceTokenRepository.", "err", err)
 		panic(err)
 	}
 
-	err = c.Singleton(func() (google_out.GoogleUserWriter, error) {
-		var repo *db.GoogleUserRepository
+	err = c.Singleton(func() (notification_out.DeviceTokenReader, error) {
+		var repo *db.DeviceTokenRepository
 		err = c.Resolve(&repo)
 		if err != nil {
-			slog.Error("Failed to resolve GoogleUserRepository for google_out.GoogleUserWriter.", "err", err)
+			slog.Error("Failed to resolve DeviceTokenRepository for notification_out.DeviceTokenReader.", "err", err)
 			return nil, err
 		}
 
@@ -1100,15 +8011,15 @@ func InjectMongoDB(c container.Container) error {
 	})
 
 	if err != nil {
-		slog.Error("Failed to load GoogleUserWriter.", "err", err)
+		slog.Error("Failed to load notification_out.DeviceTokenReader.", "err", err)
 		panic(err)
 	}
 
-	err = c.Singleton(func() (google_out.GoogleUserReader, error) {
-		var repo *db.GoogleUserRepository
+	err = c.Singleton(func() (notification_out.DeviceTokenWriter, error) {
+		var repo *db.DeviceTokenRepository
 		err = c.Resolve(&repo)
 		if err != nil {
-			slog.Error("Failed to resolve GoogleUserRepository for google_out.GoogleUserReader.", "err", err)
+			slog.Error("Failed to resolve DeviceTokenRepository for notification_out.DeviceTokenWriter.", "err", err)
 			return nil, err
 		}
 
@@ -1116,61 +8027,93 @@ func InjectMongoDB(c container.Container) error {
 	})
 
 	if err != nil {
-		slog.Error("Failed to load GoogleUserReader.", "err", err)
+		slog.Error("Failed to load notification_out.DeviceTokenWriter.", "err", err)
 		panic(err)
 	}
 
-	err = c.Singleton(func() (google_out.VHashWriter, error) {
+	err = c.Singleton(func() (*db.PushReceiptRepository, error) {
+		var client *mongo.Client
+		err := c.Resolve(&client)
+		if err != nil {
+			slog.Error("Failed to resolve mongo.Client for PushReceiptRepository.", "err", err)
+			return nil, err
+		}
+
 		var config common.Config
+		err = c.Resolve(&config)
+		if err != nil {
+			slog.Error("Failed to resolve config for db.PushReceiptRepository.", "err", err)
+			return nil, err
+		}
 
-		err := c.Resolve(&config)
+		return db.NewPushReceiptRepository(client, config.MongoDB.DBName, notification_entities.PushReceipt{}, "push_receipts"), nil
+	})
+
+	if err != nil {
+		slog.Error("Failed to load PushReceiptRepository.", "err", err)
+		panic(err)
+	}
+
+	err = c.Singleton(func() (notification_out.PushReceiptReader, error) {
+		var repo *db.PushReceiptRepository
+		err = c.Resolve(&repo)
 		if err != nil {
-			slog.Error("Failed to resolve config for google_out.VHashWriter.", "err", err)
+			slog.Error("Failed to resolve PushReceiptRepository for notification_out.PushReceiptReader.", "err", err)
 			return nil, err
 		}
 
-		return encryption.NewSHA256VHasherAdapter(config.Auth.SteamConfig.VHashSource), nil
+		return repo, nil
 	})
 
 	if err != nil {
-		slog.Error("Failed to load VHashWriter.", "err", err)
+		slog.Error("Failed to load notification_out.PushReceiptReader.", "err", err)
 		panic(err)
 	}
 
-	// end-google
+	err = c.Singleton(func() (notification_out.PushReceiptWriter, error) {
+		var repo *db.PushReceiptRepository
+		err = c.Resolve(&repo)
+		if err != nil {
+			slog.Error("Failed to resolve PushReceiptRepository for notification_out.PushReceiptWriter.", "err", err)
+			return nil, err
+		}
 
-	// rid
-	err = c.Singleton(func() (*db.RIDTokenRepository, error) {
+		return repo, nil
+	})
+
+	if err != nil {
+		slog.Error("Failed to load notification_out.PushReceiptWriter.", "err", err)
+		panic(err)
+	}
+
+	err = c.Singleton(func() (*db.NotificationRepository, error) {
 		var client *mongo.Client
 		err := c.Resolve(&client)
 		if err != nil {
-			slog.Error("Failed to resolve mongo.Client for NamedSingleton RIDTokenRepository as generic MongoDBRepository.", "err", err)
-			return &db.RIDTokenRepository{}, err
+			slog.Error("Failed to resolve mongo.Client for NotificationRepository.", "err", err)
+			return nil, err
 		}
 
 		var config common.Config
-
 		err = c.Resolve(&config)
 		if err != nil {
-			slog.Error("Failed to resolve config for db.SteamUserRepository.", "err", err)
+			slog.Error("Failed to resolve config for db.NotificationRepository.", "err", err)
 			return nil, err
 		}
 
-		repo := db.NewRIDTokenRepository(client, config.MongoDB.DBName, iam_entities.RIDToken{}, "rid")
-
-		return repo, nil
+		return db.NewNotificationRepository(client, config.MongoDB.DBName, notification_entities.Notification{}, "notifications"), nil
 	})
 
 	if err != nil {
-		slog.Error("Failed to load NamedSingleton RIDTokenRepository as generic MongoDBRepository.", "err", err)
+		slog.Error("Failed to load NotificationRepository.", "err", err)
 		panic(err)
 	}
 
-	err = c.Singleton(func() (iam_out.RIDTokenWriter, error) {
-		var repo *db.RIDTokenRepository
+	err = c.Singleton(func() (notification_out.NotificationReader, error) {
+		var repo *db.NotificationRepository
 		err = c.Resolve(&repo)
 		if err != nil {
-			slog.Error("Failed to resolve RIDTokenRepository for iam_out.RIDTokenWriter.", "err", err)
+			slog.Error("Failed to resolve NotificationRepository for notification_out.NotificationReader.", "err", err)
 			return nil, err
 		}
 
@@ -1178,15 +8121,15 @@ func InjectMongoDB(c container.Container) error {
 	})
 
 	if err != nil {
-		slog.Error("Failed to load iam_out.RIDTokenWriter.", "err", err)
+		slog.Error("Failed to load notification_out.NotificationReader.", "err", err)
 		panic(err)
 	}
 
-	err = c.Singleton(func() (iam_out.RIDTokenReader, error) {
-		var repo *db.RIDTokenRepository
+	err = c.Singleton(func() (notification_out.NotificationWriter, error) {
+		var repo *db.NotificationRepository
 		err = c.Resolve(&repo)
 		if err != nil {
-			slog.Error("Failed to resolve RIDTokenRepository for iam_out.RIDTokenReader.", "err", err)
+			slog.Error("Failed to resolve NotificationRepository for notification_out.NotificationWriter.", "err", err)
 			return nil, err
 		}
 
@@ -1194,42 +8137,38 @@ func InjectMongoDB(c container.Container) error {
 	})
 
 	if err != nil {
-		slog.Error("Failed to load iam_out.RIDTokenReader.", "err", err)
+		slog.Error("Failed to load notification_out.NotificationWriter.", "err", err)
 		panic(err)
 	}
 
-	// Squad
-	err = c.Singleton(func() (*db.SquadRepository, error) {
+	err = c.Singleton(func() (*db.NotificationPreferencesRepository, error) {
 		var client *mongo.Client
 		err := c.Resolve(&client)
 		if err != nil {
-			slog.Error("Failed to resolve mongo.Client for NamedSingleton SquadRepository as generic MongoDBRepository.", "err", err)
-			return &db.SquadRepository{}, err
+			slog.Error("Failed to resolve mongo.Client for NotificationPreferencesRepository.", "err", err)
+			return nil, err
 		}
 
 		var config common.Config
-
 		err = c.Resolve(&config)
 		if err != nil {
-			slog.Error("Failed to resolve config for db.SquadRepository.", "err", err)
+			slog.Error("Failed to resolve config for db.NotificationPreferencesRepository.", "err", err)
 			return nil, err
 		}
 
-		repo := db.NewSquadRepository(client, config.MongoDB.DBName, squad_entities.Squad{}, "squads")
-
-		return repo, nil
+		return db.NewNotificationPreferencesRepository(client, config.MongoDB.DBName, notification_entities.NotificationPreferences{}, "notification_preferences"), nil
 	})
 
 	if err != nil {
-		slog.Error("Failed to load NamedSingleton SquadRepository as generic MongoDBRepository.", "err", err)
+		slog.Error("Failed to load NotificationPreferencesRepository.", "err", err)
 		panic(err)
 	}
 
-	err = c.Singleton(func() (squad_out.SquadReader, error) {
-		var repo *db.SquadRepository
+	err = c.Singleton(func() (notification_out.NotificationPreferencesReader, error) {
+		var repo *db.NotificationPreferencesRepository
 		err = c.Resolve(&repo)
 		if err != nil {
-			slog.Error("Failed to resolve SquadRepository for squad_out.SquadReader.", "err", err)
+			slog.Error("Failed to resolve NotificationPreferencesRepository for notification_out.NotificationPreferencesReader.", "err", err)
 			return nil, err
 		}
 
@@ -1237,15 +8176,15 @@ func InjectMongoDB(c container.Container) error {
 	})
 
 	if err != nil {
-		slog.Error("Failed to load squad_out.SquadReader.", "err", err)
+		slog.Error("Failed to load notification_out.NotificationPreferencesReader.", "err", err)
 		panic(err)
 	}
 
-	err = c.Singleton(func() (squad_out.SquadWriter, error) {
-		var repo *db.SquadRepository
+	err = c.Singleton(func() (notification_out.NotificationPreferencesWriter, error) {
+		var repo *db.NotificationPreferencesRepository
 		err = c.Resolve(&repo)
 		if err != nil {
-			slog.Error("Failed to resolve SquadRepository for squad_out.SquadWriter.", "err", err)
+			slog.Error("Failed to resolve NotificationPreferencesRepository for notification_out.NotificationPreferencesWriter.", "err", err)
 			return nil, err
 		}
 
@@ -1253,44 +8192,38 @@ func InjectMongoDB(c container.Container) error {
 	})
 
 	if err != nil {
-		slog.Error("Failed to load squad_out.SquadWriter.", "err", err)
+		slog.Error("Failed to load notification_out.NotificationPreferencesWriter.", "err", err)
 		panic(err)
 	}
 
-	// -----
-
-	// User
-	err = c.Singleton(func() (*db.UserRepository, error) {
+	err = c.Singleton(func() (*db.CustomFieldDefinitionRepository, error) {
 		var client *mongo.Client
 		err := c.Resolve(&client)
 		if err != nil {
-			slog.Error("Failed to resolve mongo.Client for NamedSingleton UserRepository as generic MongoDBRepository.", "err", err)
-			return &db.UserRepository{}, err
+			slog.Error("Failed to resolve mongo.Client for CustomFieldDefinitionRepository.", "err", err)
+			return nil, err
 		}
 
 		var config common.Config
-
 		err = c.Resolve(&config)
 		if err != nil {
-			slog.Error("Failed to resolve config for db.UserRepository.", "err", err)
+			slog.Error("Failed to resolve config for db.CustomFieldDefinitionRepository.", "err", err)
 			return nil, err
 		}
 
-		repo := db.NewUserRepository(client, config.MongoDB.DBName, &iam_entities.User{}, "users")
-
-		return repo, nil
+		return db.NewCustomFieldDefinitionRepository(client, config.MongoDB.DBName, customfield_entities.CustomFieldDefinition{}, "custom_field_definitions"), nil
 	})
 
 	if err != nil {
-		slog.Error("Failed to load NamedSingleton UserRepository as generic MongoDBRepository.", "err", err)
+		slog.Error("Failed to load CustomFieldDefinitionRepository.", "err", err)
 		panic(err)
 	}
 
-	err = c.Singleton(func() (iam_out.UserReader, error) {
-		var repo *db.UserRepository
+	err = c.Singleton(func() (customfield_out.CustomFieldDefinitionReader, error) {
+		var repo *db.CustomFieldDefinitionRepository
 		err = c.Resolve(&repo)
 		if err != nil {
-			slog.Error("Failed to resolve UserRepository for iam_out.UserReader.", "err", err)
+			slog.Error("Failed to resolve CustomFieldDefinitionRepository for customfield_out.CustomFieldDefinitionReader.", "err", err)
 			return nil, err
 		}
 
@@ -1298,15 +8231,15 @@ func InjectMongoDB(c container.Container) error {
 	})
 
 	if err != nil {
-		slog.Error("Failed to load iam_out.UserReader.", "err", err)
+		slog.Error("Failed to load customfield_out.CustomFieldDefinitionReader.", "err", err)
 		panic(err)
 	}
 
-	err = c.Singleton(func() (iam_out.UserWriter, error) {
-		var repo *db.UserRepository
+	err = c.Singleton(func() (customfield_out.CustomFieldDefinitionWriter, error) {
+		var repo *db.CustomFieldDefinitionRepository
 		err = c.Resolve(&repo)
 		if err != nil {
-			slog.Error("Failed to resolve UserRepository for iam_out.UserWriter.", "err", err)
+			slog.Error("Failed to resolve CustomFieldDefinitionRepository for customfield_out.CustomFieldDefinitionWriter.", "err", err)
 			return nil, err
 		}
 
@@ -1314,44 +8247,56 @@ func InjectMongoDB(c container.Container) error {
 	})
 
 	if err != nil {
-		slog.Error("Failed to load iam_out.UserWriter.", "err", err)
+		slog.Error("Failed to load customfield_out.CustomFieldDefinitionWriter.", "err", err)
 		panic(err)
 	}
 
-	// -----
+	// lazy: only created by the notification worker and the scheduler (weekly recaps)
+	err = c.SingletonLazy(func() (notification_out.PushSender, error) {
+		var config common.Config
+		err := c.Resolve(&config)
+		if err != nil {
+			slog.Error("Failed to resolve config for notification_out.PushSender.", "err", err)
+			return nil, err
+		}
 
-	// Group
-	err = c.Singleton(func() (*db.GroupRepository, error) {
+		return push.NewSender(config.Push)
+	})
+
+	if err != nil {
+		slog.Error("Failed to load notification_out.PushSender.", "err", err)
+		panic(err)
+	}
+
+	// weekly recaps
+	err = c.Singleton(func() (*db.WeeklyRecapRepository, error) {
 		var client *mongo.Client
 		err := c.Resolve(&client)
 		if err != nil {
-			slog.Error("Failed to resolve mongo.Client for NamedSingleton GroupRepository as generic MongoDBRepository.", "err", err)
-			return &db.GroupRepository{}, err
+			slog.Error("Failed to resolve mongo.Client for WeeklyRecapRepository.", "err", err)
+			return nil, err
 		}
 
 		var config common.Config
-
 		err = c.Resolve(&config)
 		if err != nil {
-			slog.Error("Failed to resolve config for db.GroupRepository.", "err", err)
+			slog.Error("Failed to resolve config for db.WeeklyRecapRepository.", "err", err)
 			return nil, err
 		}
 
-		repo := db.NewGroupRepository(client, config.MongoDB.DBName, &iam_entities.Group{}, "groups")
-
-		return repo, nil
+		return db.NewWeeklyRecapRepository(client, config.MongoDB.DBName, recap_entities.WeeklyRecap{}, "weekly_recaps"), nil
 	})
 
 	if err != nil {
-		slog.Error("Failed to load NamedSingleton GroupRepository as generic MongoDBRepository.", "err", err)
+		slog.Error("Failed to load WeeklyRecapRepository.", "err", err)
 		panic(err)
 	}
 
-	err = c.Singleton(func() (iam_out.GroupReader, error) {
-		var repo *db.GroupRepository
+	err = c.Singleton(func() (recap_out.WeeklyRecapReader, error) {
+		var repo *db.WeeklyRecapRepository
 		err = c.Resolve(&repo)
 		if err != nil {
-			slog.Error("Failed to resolve GroupRepository for iam_out.GroupReader.", "err", err)
+			slog.Error("Failed to resolve WeeklyRecapRepository for recap_out.WeeklyRecapReader.", "err", err)
 			return nil, err
 		}
 
@@ -1359,15 +8304,15 @@ func InjectMongoDB(c container.Container) error {
 	})
 
 	if err != nil {
-		slog.Error("Failed to load iam_out.GroupReader.", "err", err)
+		slog.Error("Failed to load recap_out.WeeklyRecapReader.", "err", err)
 		panic(err)
 	}
 
-	err = c.Singleton(func() (iam_out.GroupWriter, error) {
-		var repo *db.GroupRepository
+	err = c.Singleton(func() (recap_out.WeeklyRecapWriter, error) {
+		var repo *db.WeeklyRecapRepository
 		err = c.Resolve(&repo)
 		if err != nil {
-			slog.Error("Failed to resolve GroupRepository for iam_out.GroupWriter.", "err", err)
+			slog.Error("Failed to resolve WeeklyRecapRepository for recap_out.WeeklyRecapWriter.", "err", err)
 			return nil, err
 		}
 
@@ -1375,68 +8320,54 @@ func InjectMongoDB(c container.Container) error {
 	})
 
 	if err != nil {
-		slog.Error("Failed to load iam_out.GroupWriter.", "err", err)
+		slog.Error("Failed to load recap_out.WeeklyRecapWriter.", "err", err)
 		panic(err)
 	}
 
-	// -----
-
-	// Profile
-	err = c.Singleton(func() (*db.ProfileRepository, error) {
+	err = c.Singleton(func() (recap_out.RecapActivityReader, error) {
 		var client *mongo.Client
 		err := c.Resolve(&client)
 		if err != nil {
-			slog.Error("Failed to resolve mongo.Client for NamedSingleton ProfileRepository as generic MongoDBRepository.", "err", err)
-			return &db.ProfileRepository{}, err
+			slog.Error("Failed to resolve mongo.Client for recap_out.RecapActivityReader.", "err", err)
+			return nil, err
 		}
 
 		var config common.Config
-
 		err = c.Resolve(&config)
 		if err != nil {
-			slog.Error("Failed to resolve config for db.ProfileRepository.", "err", err)
+			slog.Error("Failed to resolve config for recap_out.RecapActivityReader.", "err", err)
 			return nil, err
 		}
 
-		repo := db.NewProfileRepository(client, config.MongoDB.DBName, &iam_entities.Profile{}, "profiles")
-
-		return repo, nil
+		return db.NewRecapActivityRepository(client, config.MongoDB.DBName, "player_match_history", "player_metadata", "match_summaries", "replay_highlights", "squads"), nil
 	})
 
 	if err != nil {
-		slog.Error("Failed to load NamedSingleton ProfileRepository as generic MongoDBRepository.", "err", err)
+		slog.Error("Failed to load recap_out.RecapActivityReader.", "err", err)
 		panic(err)
 	}
 
-	err = c.Singleton(func() (iam_out.ProfileReader, error) {
-		var repo *db.ProfileRepository
-		err = c.Resolve(&repo)
+	// cached: the public API serves the same pages over and over to third-party sites
+	err = c.Singleton(func() (public_out.PublicStatsReader, error) {
+		var client *mongo.Client
+		err := c.Resolve(&client)
 		if err != nil {
-			slog.Error("Failed to resolve ProfileRepository for iam_out.ProfileReader.", "err", err)
+			slog.Error("Failed to resolve mongo.Client for public_out.PublicStatsReader.", "err", err)
 			return nil, err
 		}
 
-		return repo, nil
-	})
-
-	if err != nil {
-		slog.Error("Failed to load iam_out.ProfileReader.", "err", err)
-		panic(err)
-	}
-
-	err = c.Singleton(func() (iam_out.ProfileWriter, error) {
-		var repo *db.ProfileRepository
-		err = c.Resolve(&repo)
+		var config common.Config
+		err = c.Resolve(&config)
 		if err != nil {
-			slog.Error("Failed to resolve ProfileRepository for iam_out.ProfileWriter.", "err", err)
+			slog.Error("Failed to resolve config for public_out.PublicStatsReader.", "err", err)
 			return nil, err
 		}
 
-		return repo, nil
+		return cache.NewPublicStatsCache(db.NewPublicStatsRepository(client, config.MongoDB.DBName, "match_metadata", "match_summaries"), config.PublicAPI.CacheDuration()), nil
 	})
 
 	if err != nil {
-		slog.Error("Failed to load iam_out.ProfileWriter.", "err", err)
+		slog.Error("Failed to load public_out.PublicStatsReader.", "err", err)
 		panic(err)
 	}
 
@@ -44,10 +44,7 @@ func TestResolveOnboardSteamUserCommand(t *testing.T) {
 	groupID := uuid.New()
 	userID := uuid.New()
 
-	ctx := context.WithValue(context.Background(), common.TenantIDKey, common.TeamPROTenantID)
-	ctx = context.WithValue(ctx, common.ClientIDKey, common.TeamPROAppClientID)
-	ctx = context.WithValue(ctx, common.GroupIDKey, groupID)
-	ctx = context.WithValue(ctx, common.UserIDKey, userID)
+	ctx := common.WithResourceOwner(context.Background(), common.ResourceOwner{TenantID: common.TeamPROTenantID, ClientID: common.TeamPROAppClientID, GroupID: groupID, UserID: userID})
 
 	steamUser := &steam_entity.SteamUser{ID: userID,
 		VHash: "4ef1c47e874ec4425c5786cddadd9adfc908a530ada95a602742f49c32430185",
@@ -111,10 +108,7 @@ func TestResolverSteamUserReader(t *testing.T) {
 		t.Fatalf("failed to resolve SteamUserWriter: %v", err)
 	}
 
-	ctx := context.WithValue(context.Background(), common.TenantIDKey, common.TeamPROTenantID)
-	ctx = context.WithValue(ctx, common.ClientIDKey, common.TeamPROAppClientID)
-	ctx = context.WithValue(ctx, common.GroupIDKey, uuid.New())
-	ctx = context.WithValue(ctx, common.UserIDKey, uuid.New())
+	ctx := common.WithResourceOwner(context.Background(), common.ResourceOwner{TenantID: common.TeamPROTenantID, ClientID: common.TeamPROAppClientID, GroupID: uuid.New(), UserID: uuid.New()})
 
 	steamCommunityDetails := steam_entity.Steam{
 		ID: "1",
@@ -165,9 +159,7 @@ func TestResolverSteamUserWriter(t *testing.T) {
 		t.Fatalf("failed to resolve SteamUserWriter: %v", err)
 	}
 
-	ctx := context.WithValue(context.Background(), common.TenantIDKey, common.TeamPROTenantID)
-	ctx = context.WithValue(ctx, common.ClientIDKey, common.TeamPROAppClientID)
-	ctx = context.WithValue(ctx, common.UserIDKey, uuid.New())
+	ctx := common.WithResourceOwner(context.Background(), common.ResourceOwner{TenantID: common.TeamPROTenantID, ClientID: common.TeamPROAppClientID, UserID: uuid.New()})
 
 	user := &steam_entity.SteamUser{
 		ID:    common.GetResourceOwner(ctx).UserID,
@@ -1,7 +1,10 @@
 package ioc
 
 import (
+	"fmt"
 	"os"
+	"strconv"
+	"time"
 
 	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
 )
@@ -21,6 +24,7 @@ func EnvironmentConfig() (common.Config, error) {
 			GitHubConfig: common.GitHubConfig{
 				GitHubKey: os.Getenv("GITHUB_KEY"),
 			},
+			SuperAdminUserIDs: os.Getenv("RBAC_SUPERADMIN_USER_IDS"),
 		},
 		MongoDB: common.MongoDBConfig{
 			URI:         os.Getenv("MONGO_URI"),
@@ -28,7 +32,165 @@ func EnvironmentConfig() (common.Config, error) {
 			Certificate: os.Getenv("MONGO_CERT"),
 			DBName:      os.Getenv("MONGO_DB_NAME"),
 		},
+		ReplayStorage: common.ReplayStorageConfig{
+			Backend: os.Getenv("REPLAY_STORAGE_BACKEND"),
+		},
+		S3: common.S3Config{
+			S3Endpoint:      os.Getenv("S3_ENDPOINT_URL"),
+			Region:          os.Getenv("S3_BUCKET_REGION"),
+			Bucket:          os.Getenv("S3_BUCKET_NAME"),
+			AccessKeyID:     os.Getenv("S3_ACCESS_KEY_ID"),
+			SecretAccessKey: os.Getenv("S3_SECRET_ACCESS_KEY"),
+			ForcePathStyle:  os.Getenv("S3_FORCE_PATH_STYLE") == "true",
+			Encryption:      os.Getenv("S3_BUCKET_ENCRYPTION"),
+			KMSKeyID:        os.Getenv("S3_BUCKET_KMS_KEY_ID"),
+		},
+		RabbitMQ: common.RabbitMQConfig{
+			URL: os.Getenv("RABBITMQ_URL"),
+		},
+		Alerts: common.AlertsConfig{
+			WebhookURL: os.Getenv("ALERTS_WEBHOOK_URL"),
+		},
+		Chaos: common.ChaosConfig{
+			Targets: os.Getenv("CHAOS_TARGETS"),
+		},
+		FX: common.FXConfig{
+			Providers:       os.Getenv("FX_PROVIDERS"),
+			CoingeckoAPIKey: os.Getenv("COINGECKO_API_KEY"),
+		},
+		Email: common.EmailConfig{
+			SMTPHost:       os.Getenv("SMTP_HOST"),
+			SMTPPort:       os.Getenv("SMTP_PORT"),
+			SMTPUsername:   os.Getenv("SMTP_USERNAME"),
+			SMTPPassword:   os.Getenv("SMTP_PASSWORD"),
+			From:           os.Getenv("EMAIL_FROM"),
+			MagicLinkURL:   os.Getenv("MAGIC_LINK_URL"),
+			BlockedDomains: os.Getenv("EMAIL_BLOCKED_DOMAINS"),
+		},
+		Push: common.PushConfig{
+			FCMProjectID:   os.Getenv("FCM_PROJECT_ID"),
+			FCMCredentials: os.Getenv("FCM_CREDENTIALS"),
+			APNsKeyID:      os.Getenv("APNS_KEY_ID"),
+			APNsTeamID:     os.Getenv("APNS_TEAM_ID"),
+			APNsPrivateKey: os.Getenv("APNS_PRIVATE_KEY"),
+			APNsBundleID:   os.Getenv("APNS_BUNDLE_ID"),
+			APNsSandbox:    os.Getenv("APNS_SANDBOX") == "true",
+		},
+		SteamGC: common.SteamGCConfig{
+			GatewayURL:   os.Getenv("STEAM_GC_GATEWAY_URL"),
+			GatewayToken: os.Getenv("STEAM_GC_GATEWAY_TOKEN"),
+		},
+		Faceit: common.FaceitConfig{
+			APIKey: os.Getenv("FACEIT_API_KEY"),
+		},
+		Riot: common.RiotConfig{
+			APIKey: os.Getenv("RIOT_API_KEY"),
+		},
+		PublicAPI: common.PublicAPIConfig{
+			AttributionText: os.Getenv("PUBLIC_API_ATTRIBUTION_TEXT"),
+			AttributionURL:  os.Getenv("PUBLIC_API_ATTRIBUTION_URL"),
+		},
+		Voice: common.VoiceConfig{
+			Provider:         os.Getenv("VOICE_PROVIDER"),
+			LiveKitURL:       os.Getenv("LIVEKIT_URL"),
+			LiveKitAPIKey:    os.Getenv("LIVEKIT_API_KEY"),
+			LiveKitAPISecret: os.Getenv("LIVEKIT_API_SECRET"),
+		},
+	}
+
+	if concurrency := os.Getenv("REPLAY_WORKER_CONCURRENCY"); concurrency != "" {
+		n, err := strconv.Atoi(concurrency)
+		if err != nil {
+			return config, fmt.Errorf("invalid REPLAY_WORKER_CONCURRENCY '%s': %w", concurrency, err)
+		}
+
+		config.RabbitMQ.ReplayWorkerConcurrency = n
+	}
+
+	config.ReplayParser.Sandbox = os.Getenv("REPLAY_PARSER_SANDBOX") == "true"
+
+	if memory := os.Getenv("REPLAY_PARSER_MEMORY_LIMIT_MB"); memory != "" {
+		n, err := strconv.Atoi(memory)
+		if err != nil || n <= 0 {
+			return config, fmt.Errorf("invalid REPLAY_PARSER_MEMORY_LIMIT_MB '%s': expected a positive number of MiB", memory)
+		}
+
+		config.ReplayParser.MemoryLimitMB = n
+	}
+
+	for name, limit := range map[string]*time.Duration{
+		"REPLAY_PARSER_CPU_LIMIT": &config.ReplayParser.CPULimit,
+		"REPLAY_PARSER_TIMEOUT":   &config.ReplayParser.Timeout,
+		"PUBLIC_API_CACHE_TTL":    &config.PublicAPI.CacheTTL,
+		"PLAYER_CARD_CACHE_TTL":   &config.PlayerCard.CacheTTL,
+	} {
+		value := os.Getenv(name)
+		if value == "" {
+			continue
+		}
+
+		d, err := time.ParseDuration(value)
+		if err != nil || d <= 0 {
+			return config, fmt.Errorf("invalid %s '%s': expected a positive duration", name, value)
+		}
+
+		*limit = d
+	}
+
+	if deviation := os.Getenv("FX_MAX_DEVIATION"); deviation != "" {
+		d, err := strconv.ParseFloat(deviation, 64)
+		if err != nil || d <= 0 || d > 1 {
+			return config, fmt.Errorf("invalid FX_MAX_DEVIATION '%s': expected a rate between 0 and 1", deviation)
+		}
+
+		config.FX.MaxDeviation = d
+	}
+
+	if config.Chaos.Targets != "" {
+		err := chaosConfig(&config.Chaos)
+		if err != nil {
+			return config, err
+		}
 	}
 
 	return config, nil
 }
+
+func chaosConfig(config *common.ChaosConfig) error {
+	if os.Getenv("DEV_ENV") == "" {
+		return fmt.Errorf("CHAOS_TARGETS '%s' refused: fault injection is only allowed in dev/test environments (DEV_ENV)", config.Targets)
+	}
+
+	var err error
+
+	if latency := os.Getenv("CHAOS_LATENCY"); latency != "" {
+		config.Latency, err = time.ParseDuration(latency)
+		if err != nil {
+			return fmt.Errorf("invalid CHAOS_LATENCY '%s': %w", latency, err)
+		}
+	}
+
+	for name, rate := range map[string]*float64{
+		"CHAOS_ERROR_RATE":           &config.ErrorRate,
+		"CHAOS_PARTIAL_FAILURE_RATE": &config.PartialFailureRate,
+	} {
+		value := os.Getenv(name)
+		if value == "" {
+			continue
+		}
+
+		*rate, err = strconv.ParseFloat(value, 64)
+		if err != nil || *rate < 0 || *rate > 1 {
+			return fmt.Errorf("invalid %s '%s': expected a rate between 0 and 1", name, value)
+		}
+	}
+
+	if seed := os.Getenv("CHAOS_SEED"); seed != "" {
+		config.Seed, err = strconv.ParseInt(seed, 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid CHAOS_SEED '%s': %w", seed, err)
+		}
+	}
+
+	return nil
+}
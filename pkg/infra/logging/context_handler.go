@@ -0,0 +1,38 @@
+package logging
+
+import (
+	"context"
+	"log/slog"
+
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+)
+
+// RequestIDAttrKey is the attribute of the correlation ID in the logs: the ID of the API request, shared by the events it published
+// (handled by the workers), or the ID of the job run (the ID of the request that triggered it when run manually).
+const RequestIDAttrKey = "requestID"
+
+// ContextHandler adds the correlation ID of the request scope in context to the records of the wrapped handler, so the logs of the
+// rest-api, the workers and the jobs can be joined. Records logged without a context (or out of a request) are left as they are.
+type ContextHandler struct {
+	slog.Handler
+}
+
+func NewContextHandler(handler slog.Handler) *ContextHandler {
+	return &ContextHandler{Handler: handler}
+}
+
+func (h *ContextHandler) Handle(ctx context.Context, record slog.Record) error {
+	if scope, ok := common.GetRequestScope(ctx); ok && scope.RequestID != "" {
+		record.AddAttrs(slog.String(RequestIDAttrKey, scope.RequestID))
+	}
+
+	return h.Handler.Handle(ctx, record)
+}
+
+func (h *ContextHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &ContextHandler{Handler: h.Handler.WithAttrs(attrs)}
+}
+
+func (h *ContextHandler) WithGroup(name string) slog.Handler {
+	return &ContextHandler{Handler: h.Handler.WithGroup(name)}
+}
@@ -0,0 +1,38 @@
+package logging_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"testing"
+
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	"github.com/psavelis/team-pro/replay-api/pkg/infra/logging"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestContextHandler(t *testing.T) {
+	var out bytes.Buffer
+	logger := slog.New(logging.NewContextHandler(slog.NewJSONHandler(&out, nil))).With("job", "exports.nightly")
+
+	ctx := common.WithRequestID(context.Background(), "4f1c2a57-0d0e-4a43-9f0b-3f9e1f0b7a10")
+
+	logger.InfoContext(ctx, "export delivered")
+	logger.Info("no context")
+
+	lines := bytes.Split(bytes.TrimSpace(out.Bytes()), []byte("\n"))
+	if !assert.Len(t, lines, 2) {
+		return
+	}
+
+	var record map[string]interface{}
+
+	assert.NoError(t, json.Unmarshal(lines[0], &record))
+	assert.Equal(t, "4f1c2a57-0d0e-4a43-9f0b-3f9e1f0b7a10", record[logging.RequestIDAttrKey])
+	assert.Equal(t, "exports.nightly", record["job"])
+
+	record = nil
+	assert.NoError(t, json.Unmarshal(lines[1], &record))
+	assert.NotContains(t, record, logging.RequestIDAttrKey)
+}
@@ -0,0 +1,85 @@
+package mail
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"net/url"
+	"time"
+
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+)
+
+const defaultSMTPPort = "587"
+
+type SMTPMagicLinkSender struct {
+	Config common.EmailConfig
+}
+
+// NewSMTPMagicLinkSender sends magic links through the SMTP relay configured. When no relay is configured links are dropped (logged
+// without their token).
+func NewSMTPMagicLinkSender(config common.EmailConfig) *SMTPMagicLinkSender {
+	if config.SMTPPort == "" {
+		config.SMTPPort = defaultSMTPPort
+	}
+
+	return &SMTPMagicLinkSender{
+		Config: config,
+	}
+}
+
+func (s *SMTPMagicLinkSender) SendMagicLink(ctx context.Context, email string, token string, expiresAt time.Time) error {
+	if s.Config.SMTPHost == "" {
+		slog.WarnContext(ctx, "magic link not sent: no smtp relay configured", "expiresAt", expiresAt)
+		return nil
+	}
+
+	link, err := s.link(token)
+	if err != nil {
+		return err
+	}
+
+	from, err := mail.ParseAddress(s.Config.From)
+	if err != nil {
+		return fmt.Errorf("invalid sender address '%s': %w", s.Config.From, err)
+	}
+
+	var msg bytes.Buffer
+	fmt.Fprintf(&msg, "From: %s\r\n", from.String())
+	fmt.Fprintf(&msg, "To: %s\r\n", email)
+	fmt.Fprintf(&msg, "Subject: Your sign in link\r\n")
+	fmt.Fprintf(&msg, "MIME-Version: 1.0\r\n")
+	fmt.Fprintf(&msg, "Content-Type: text/plain; charset=UTF-8\r\n\r\n")
+	fmt.Fprintf(&msg, "Use the link below to sign in. It expires at %s and can only be used once.\r\n\r\n", expiresAt.UTC().Format(time.RFC1123))
+	fmt.Fprintf(&msg, "%s\r\n\r\n", link)
+	fmt.Fprintf(&msg, "If you didn't request it, you can ignore this email.\r\n")
+
+	var auth smtp.Auth
+	if s.Config.SMTPUsername != "" {
+		auth = smtp.PlainAuth("", s.Config.SMTPUsername, s.Config.SMTPPassword, s.Config.SMTPHost)
+	}
+
+	err = smtp.SendMail(net.JoinHostPort(s.Config.SMTPHost, s.Config.SMTPPort), auth, from.Address, []string{email}, msg.Bytes())
+	if err != nil {
+		return fmt.Errorf("unable to send magic link through %s: %w", s.Config.SMTPHost, err)
+	}
+
+	return nil
+}
+
+func (s *SMTPMagicLinkSender) link(token string) (string, error) {
+	u, err := url.Parse(s.Config.MagicLinkURL)
+	if err != nil || u.Scheme == "" || u.Host == "" {
+		return "", fmt.Errorf("invalid magic link url '%s'", s.Config.MagicLinkURL)
+	}
+
+	q := u.Query()
+	q.Set("token", token)
+	u.RawQuery = q.Encode()
+
+	return u.String(), nil
+}
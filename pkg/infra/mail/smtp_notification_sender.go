@@ -0,0 +1,73 @@
+package mail
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log/slog"
+	"mime"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"strings"
+
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	notification_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/notification/entities"
+)
+
+type SMTPNotificationSender struct {
+	Config common.EmailConfig
+}
+
+// NewSMTPNotificationSender sends notifications to the email of the preferences of the users, through the SMTP relay of the magic links.
+// When no relay is configured notifications are dropped (logged).
+func NewSMTPNotificationSender(config common.EmailConfig) *SMTPNotificationSender {
+	if config.SMTPPort == "" {
+		config.SMTPPort = defaultSMTPPort
+	}
+
+	return &SMTPNotificationSender{
+		Config: config,
+	}
+}
+
+func (s *SMTPNotificationSender) Send(ctx context.Context, preferences notification_entities.NotificationPreferences, notification notification_entities.Notification) error {
+	if s.Config.SMTPHost == "" {
+		slog.WarnContext(ctx, "notification email not sent: no smtp relay configured", "notificationID", notification.ID)
+		return nil
+	}
+
+	from, err := mail.ParseAddress(s.Config.From)
+	if err != nil {
+		return fmt.Errorf("invalid sender address '%s': %w", s.Config.From, err)
+	}
+
+	to, err := mail.ParseAddress(preferences.Email)
+	if err != nil {
+		return fmt.Errorf("invalid recipient address: %w", err)
+	}
+
+	// titles have the names of squads and players in them: no header is ever broken out of
+	subject := mime.QEncoding.Encode("UTF-8", strings.Join(strings.Fields(notification.Title), " "))
+
+	var msg bytes.Buffer
+	fmt.Fprintf(&msg, "From: %s\r\n", from.String())
+	fmt.Fprintf(&msg, "To: %s\r\n", to.String())
+	fmt.Fprintf(&msg, "Subject: %s\r\n", subject)
+	fmt.Fprintf(&msg, "MIME-Version: 1.0\r\n")
+	fmt.Fprintf(&msg, "Content-Type: text/plain; charset=UTF-8\r\n\r\n")
+	fmt.Fprintf(&msg, "%s\r\n\r\n", notification.Body)
+	fmt.Fprintf(&msg, "You can choose how you are notified in the notification preferences of your account.\r\n")
+
+	var auth smtp.Auth
+	if s.Config.SMTPUsername != "" {
+		auth = smtp.PlainAuth("", s.Config.SMTPUsername, s.Config.SMTPPassword, s.Config.SMTPHost)
+	}
+
+	err = smtp.SendMail(net.JoinHostPort(s.Config.SMTPHost, s.Config.SMTPPort), auth, from.Address, []string{to.Address}, msg.Bytes())
+	if err != nil {
+		return fmt.Errorf("unable to send notification through %s: %w", s.Config.SMTPHost, err)
+	}
+
+	return nil
+}
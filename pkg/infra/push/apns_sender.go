@@ -0,0 +1,206 @@
+package push
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/psavelis/team-pro/replay-api/pkg/domain/notification"
+	notification_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/notification/entities"
+)
+
+const (
+	apnsProductionURL = "https://api.push.apple.com"
+	apnsSandboxURL    = "https://api.sandbox.push.apple.com"
+
+	// APNs refuses provider tokens older than an hour, and throttles the ones refreshed more often than every 20 minutes
+	apnsTokenTTL = 45 * time.Minute
+)
+
+// APNsSender sends notifications to iOS devices through the APNs HTTP/2 API, authenticated with provider tokens (ES256 JWTs signed
+// with the key of the team, reused until apnsTokenTTL).
+type APNsSender struct {
+	BaseURL  string
+	KeyID    string
+	TeamID   string
+	BundleID string
+	Client   *http.Client
+
+	key *ecdsa.PrivateKey
+
+	mu       sync.Mutex
+	token    string
+	issuedAt time.Time
+}
+
+func NewAPNsSender(keyID string, teamID string, privateKey string, bundleID string, sandbox bool) (*APNsSender, error) {
+	key, err := parseECPrivateKey(privateKey)
+	if err != nil {
+		return nil, fmt.Errorf("invalid APNS_PRIVATE_KEY: %w", err)
+	}
+
+	baseURL := apnsProductionURL
+	if sandbox {
+		baseURL = apnsSandboxURL
+	}
+
+	return &APNsSender{
+		BaseURL:  baseURL,
+		KeyID:    keyID,
+		TeamID:   teamID,
+		BundleID: bundleID,
+		// the default transport negotiates HTTP/2, required by APNs
+		Client: &http.Client{Timeout: 10 * time.Second},
+		key:    key,
+	}, nil
+}
+
+type apnsAlert struct {
+	Title string `json:"title"`
+	Body  string `json:"body"`
+}
+
+type apnsErrorResponse struct {
+	Reason string `json:"reason"`
+}
+
+func (s *APNsSender) Send(ctx context.Context, device notification_entities.DeviceToken, push notification_entities.PushNotification) (string, error) {
+	token, err := s.providerToken(time.Now())
+	if err != nil {
+		return "", err
+	}
+
+	// the data of the notification is sent as custom keys, next to the aps dictionary
+	body := map[string]interface{}{
+		"aps": map[string]interface{}{
+			"alert":              apnsAlert{Title: push.Title, Body: push.Body},
+			"sound":              "default",
+			"interruption-level": "time-sensitive",
+		},
+	}
+
+	for k, v := range push.Data {
+		body[k] = v
+	}
+
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return "", err
+	}
+
+	endpoint := fmt.Sprintf("%s/3/device/%s", strings.TrimSuffix(s.BaseURL, "/"), device.Token)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(payload))
+	if err != nil {
+		return "", err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "bearer "+token)
+	req.Header.Set("apns-topic", s.BundleID)
+	req.Header.Set("apns-push-type", "alert")
+	req.Header.Set("apns-priority", "10")
+	req.Header.Set("apns-expiration", strconv.FormatInt(push.ExpiresAt.Unix(), 10))
+
+	if push.CollapseKey != "" {
+		req.Header.Set("apns-collapse-id", push.CollapseKey)
+	}
+
+	res, err := s.Client.Do(req)
+	if err != nil {
+		return "", err
+	}
+
+	defer res.Body.Close()
+
+	if res.StatusCode == http.StatusOK {
+		return res.Header.Get("apns-id"), nil
+	}
+
+	resBody, _ := io.ReadAll(io.LimitReader(res.Body, 1024))
+
+	var apnsErr apnsErrorResponse
+	_ = json.Unmarshal(resBody, &apnsErr)
+
+	// 410: the token is no longer active for the topic (ie: the app was uninstalled)
+	if res.StatusCode == http.StatusGone || apnsErr.Reason == "BadDeviceToken" || apnsErr.Reason == "DeviceTokenNotForTopic" {
+		return "", notification.NewDeviceTokenRejectedError(apnsErr.Reason)
+	}
+
+	return "", fmt.Errorf("apns request failed with status %d: %s", res.StatusCode, string(resBody))
+}
+
+// providerToken returns the ES256 JWT of the team, issuing a new one once apnsTokenTTL old.
+func (s *APNsSender) providerToken(now time.Time) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.token != "" && now.Sub(s.issuedAt) < apnsTokenTTL {
+		return s.token, nil
+	}
+
+	header, err := json.Marshal(map[string]string{"alg": "ES256", "kid": s.KeyID})
+	if err != nil {
+		return "", err
+	}
+
+	claims, err := json.Marshal(map[string]interface{}{
+		"iss": s.TeamID,
+		"iat": now.Unix(),
+	})
+
+	if err != nil {
+		return "", err
+	}
+
+	unsigned := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(claims)
+
+	digest := sha256.Sum256([]byte(unsigned))
+
+	r, sig, err := ecdsa.Sign(rand.Reader, s.key, digest[:])
+	if err != nil {
+		return "", err
+	}
+
+	// JWS signatures are the fixed size big endian r and s (not ASN.1)
+	signature := make([]byte, 64)
+	r.FillBytes(signature[:32])
+	sig.FillBytes(signature[32:])
+
+	s.token = unsigned + "." + base64.RawURLEncoding.EncodeToString(signature)
+	s.issuedAt = now
+
+	return s.token, nil
+}
+
+func parseECPrivateKey(key string) (*ecdsa.PrivateKey, error) {
+	block, _ := pem.Decode([]byte(key))
+	if block == nil {
+		return nil, fmt.Errorf("no PEM block found")
+	}
+
+	parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return x509.ParseECPrivateKey(block.Bytes)
+	}
+
+	ecKey, ok := parsed.(*ecdsa.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("not an EC key")
+	}
+
+	return ecKey, nil
+}
@@ -0,0 +1,300 @@
+package push
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/psavelis/team-pro/replay-api/pkg/domain/notification"
+	notification_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/notification/entities"
+)
+
+const (
+	fcmBaseURL         = "https://fcm.googleapis.com"
+	fcmScope           = "https://www.googleapis.com/auth/firebase.messaging"
+	fcmDefaultTokenURI = "https://oauth2.googleapis.com/token"
+
+	// access tokens are requested again a minute before they expire
+	fcmTokenLeeway = time.Minute
+)
+
+// fcmServiceAccount is the part of the service account key file used to request access tokens.
+type fcmServiceAccount struct {
+	ClientEmail string `json:"client_email"`
+	PrivateKey  string `json:"private_key"`
+	TokenURI    string `json:"token_uri"`
+}
+
+// FCMSender sends notifications to android devices and browsers (web push) through the FCM HTTP v1 API, authenticated with OAuth2 access tokens of a service
+// account (a JWT signed with its key is exchanged for a token, reused until it expires).
+type FCMSender struct {
+	ProjectID string
+	BaseURL   string
+	Client    *http.Client
+
+	account fcmServiceAccount
+	key     *rsa.PrivateKey
+
+	mu          sync.Mutex
+	accessToken string
+	expiresAt   time.Time
+}
+
+func NewFCMSender(projectID string, credentials string) (*FCMSender, error) {
+	var account fcmServiceAccount
+	err := json.Unmarshal([]byte(credentials), &account)
+	if err != nil {
+		return nil, fmt.Errorf("invalid FCM_CREDENTIALS: %w", err)
+	}
+
+	key, err := parseRSAPrivateKey(account.PrivateKey)
+	if err != nil {
+		return nil, fmt.Errorf("invalid FCM_CREDENTIALS private key: %w", err)
+	}
+
+	if account.TokenURI == "" {
+		account.TokenURI = fcmDefaultTokenURI
+	}
+
+	return &FCMSender{
+		ProjectID: projectID,
+		BaseURL:   fcmBaseURL,
+		Client:    &http.Client{Timeout: 10 * time.Second},
+		account:   account,
+		key:       key,
+	}, nil
+}
+
+type fcmMessage struct {
+	Token        string            `json:"token"`
+	Notification fcmNotification   `json:"notification"`
+	Data         map[string]string `json:"data,omitempty"`
+	Android      *fcmAndroidConfig `json:"android,omitempty"`
+	Webpush      *fcmWebpushConfig `json:"webpush,omitempty"`
+}
+
+type fcmNotification struct {
+	Title string `json:"title"`
+	Body  string `json:"body"`
+}
+
+type fcmAndroidConfig struct {
+	CollapseKey string `json:"collapse_key,omitempty"`
+	Priority    string `json:"priority"`
+	TTL         string `json:"ttl"`
+}
+
+// fcmWebpushConfig sets the Web Push protocol headers of the browsers (the TTL, in seconds, and the urgency).
+type fcmWebpushConfig struct {
+	Headers map[string]string `json:"headers"`
+}
+
+type fcmErrorResponse struct {
+	Error struct {
+		Status  string `json:"status"`
+		Message string `json:"message"`
+		Details []struct {
+			ErrorCode string `json:"errorCode"`
+		} `json:"details"`
+	} `json:"error"`
+}
+
+func (s *FCMSender) Send(ctx context.Context, device notification_entities.DeviceToken, push notification_entities.PushNotification) (string, error) {
+	accessToken, err := s.token(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	ttl := int(push.TTL(time.Now()).Seconds())
+
+	message := fcmMessage{
+		Token:        device.Token,
+		Notification: fcmNotification{Title: push.Title, Body: push.Body},
+		Data:         push.Data,
+	}
+
+	if device.Platform == notification_entities.DevicePlatformWeb {
+		message.Webpush = &fcmWebpushConfig{Headers: map[string]string{"TTL": strconv.Itoa(ttl), "Urgency": "high"}}
+	} else {
+		message.Android = &fcmAndroidConfig{
+			CollapseKey: push.CollapseKey,
+			Priority:    "high",
+			TTL:         fmt.Sprintf("%ds", ttl),
+		}
+	}
+
+	payload, err := json.Marshal(map[string]interface{}{
+		"message": message,
+	})
+
+	if err != nil {
+		return "", err
+	}
+
+	endpoint := fmt.Sprintf("%s/v1/projects/%s/messages:send", strings.TrimSuffix(s.BaseURL, "/"), url.PathEscape(s.ProjectID))
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(payload))
+	if err != nil {
+		return "", err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+accessToken)
+
+	res, err := s.Client.Do(req)
+	if err != nil {
+		return "", err
+	}
+
+	defer res.Body.Close()
+
+	body, _ := io.ReadAll(io.LimitReader(res.Body, 4096))
+
+	if res.StatusCode >= 300 {
+		var fcmErr fcmErrorResponse
+		_ = json.Unmarshal(body, &fcmErr)
+
+		// the token was unregistered (the app was uninstalled) or isn't a token of the project
+		for _, detail := range fcmErr.Error.Details {
+			if detail.ErrorCode == "UNREGISTERED" || detail.ErrorCode == "SENDER_ID_MISMATCH" {
+				return "", notification.NewDeviceTokenRejectedError(detail.ErrorCode)
+			}
+		}
+
+		if res.StatusCode == http.StatusNotFound {
+			return "", notification.NewDeviceTokenRejectedError(fcmErr.Error.Status)
+		}
+
+		return "", fmt.Errorf("fcm request failed with status %d: %s", res.StatusCode, string(body))
+	}
+
+	var sent struct {
+		Name string `json:"name"` // projects/{project}/messages/{message_id}
+	}
+
+	err = json.Unmarshal(body, &sent)
+	if err != nil {
+		return "", fmt.Errorf("invalid fcm response: %w", err)
+	}
+
+	return sent.Name[strings.LastIndex(sent.Name, "/")+1:], nil
+}
+
+// token returns the access token of the service account, requesting a new one when it's about to expire.
+func (s *FCMSender) token(ctx context.Context) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	if s.accessToken != "" && now.Add(fcmTokenLeeway).Before(s.expiresAt) {
+		return s.accessToken, nil
+	}
+
+	assertion, err := s.assertion(now)
+	if err != nil {
+		return "", err
+	}
+
+	form := url.Values{}
+	form.Set("grant_type", "urn:ietf:params:oauth:grant-type:jwt-bearer")
+	form.Set("assertion", assertion)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.account.TokenURI, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", err
+	}
+
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	res, err := s.Client.Do(req)
+	if err != nil {
+		return "", err
+	}
+
+	defer res.Body.Close()
+
+	body, _ := io.ReadAll(io.LimitReader(res.Body, 4096))
+	if res.StatusCode >= 300 {
+		return "", fmt.Errorf("fcm access token request failed with status %d: %s", res.StatusCode, string(body))
+	}
+
+	var token struct {
+		AccessToken string `json:"access_token"`
+		ExpiresIn   int    `json:"expires_in"`
+	}
+
+	err = json.Unmarshal(body, &token)
+	if err != nil || token.AccessToken == "" {
+		return "", fmt.Errorf("invalid fcm access token response: %s", string(body))
+	}
+
+	s.accessToken = token.AccessToken
+	s.expiresAt = now.Add(time.Duration(token.ExpiresIn) * time.Second)
+
+	return s.accessToken, nil
+}
+
+// assertion is the RS256 JWT of the service account requesting the messaging scope.
+func (s *FCMSender) assertion(now time.Time) (string, error) {
+	header, err := json.Marshal(map[string]string{"alg": "RS256", "typ": "JWT"})
+	if err != nil {
+		return "", err
+	}
+
+	claims, err := json.Marshal(map[string]interface{}{
+		"iss":   s.account.ClientEmail,
+		"scope": fcmScope,
+		"aud":   s.account.TokenURI,
+		"iat":   now.Unix(),
+		"exp":   now.Add(time.Hour).Unix(),
+	})
+
+	if err != nil {
+		return "", err
+	}
+
+	unsigned := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(claims)
+
+	digest := sha256.Sum256([]byte(unsigned))
+
+	signature, err := rsa.SignPKCS1v15(rand.Reader, s.key, crypto.SHA256, digest[:])
+	if err != nil {
+		return "", err
+	}
+
+	return unsigned + "." + base64.RawURLEncoding.EncodeToString(signature), nil
+}
+
+func parseRSAPrivateKey(key string) (*rsa.PrivateKey, error) {
+	block, _ := pem.Decode([]byte(key))
+	if block == nil {
+		return nil, fmt.Errorf("no PEM block found")
+	}
+
+	parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return x509.ParsePKCS1PrivateKey(block.Bytes)
+	}
+
+	rsaKey, ok := parsed.(*rsa.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("not an RSA key")
+	}
+
+	return rsaKey, nil
+}
@@ -0,0 +1,61 @@
+package push
+
+import (
+	"context"
+	"fmt"
+
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	notification_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/notification/entities"
+	notification_out "github.com/psavelis/team-pro/replay-api/pkg/domain/notification/ports/out"
+)
+
+// Sender routes each notification to the push service of the device platform. Notifications to the platforms of push services not
+// configured fail (recorded as failed receipts).
+type Sender struct {
+	Providers map[notification_entities.PushProvider]notification_out.PushSender
+}
+
+// NewSender configures the push services set in config: FCM with FCM_PROJECT_ID and FCM_CREDENTIALS, APNs with APNS_KEY_ID,
+// APNS_TEAM_ID, APNS_PRIVATE_KEY and APNS_BUNDLE_ID.
+func NewSender(config common.PushConfig) (*Sender, error) {
+	providers := make(map[notification_entities.PushProvider]notification_out.PushSender)
+
+	if config.FCMProjectID != "" || config.FCMCredentials != "" {
+		if config.FCMProjectID == "" || config.FCMCredentials == "" {
+			return nil, fmt.Errorf("fcm requires FCM_PROJECT_ID and FCM_CREDENTIALS")
+		}
+
+		fcm, err := NewFCMSender(config.FCMProjectID, config.FCMCredentials)
+		if err != nil {
+			return nil, err
+		}
+
+		providers[notification_entities.PushProviderFCM] = fcm
+	}
+
+	if config.APNsKeyID != "" || config.APNsPrivateKey != "" {
+		if config.APNsKeyID == "" || config.APNsTeamID == "" || config.APNsPrivateKey == "" || config.APNsBundleID == "" {
+			return nil, fmt.Errorf("apns requires APNS_KEY_ID, APNS_TEAM_ID, APNS_PRIVATE_KEY and APNS_BUNDLE_ID")
+		}
+
+		apns, err := NewAPNsSender(config.APNsKeyID, config.APNsTeamID, config.APNsPrivateKey, config.APNsBundleID, config.APNsSandbox)
+		if err != nil {
+			return nil, err
+		}
+
+		providers[notification_entities.PushProviderAPNs] = apns
+	}
+
+	return &Sender{
+		Providers: providers,
+	}, nil
+}
+
+func (s *Sender) Send(ctx context.Context, device notification_entities.DeviceToken, push notification_entities.PushNotification) (string, error) {
+	provider, ok := s.Providers[device.Platform.Provider()]
+	if !ok {
+		return "", fmt.Errorf("push provider %s is not configured", device.Platform.Provider())
+	}
+
+	return provider.Send(ctx, device, push)
+}
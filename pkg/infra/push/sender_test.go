@@ -0,0 +1,206 @@
+package push_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	"github.com/psavelis/team-pro/replay-api/pkg/domain/notification"
+	notification_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/notification/entities"
+	"github.com/psavelis/team-pro/replay-api/pkg/infra/push"
+	"github.com/stretchr/testify/assert"
+)
+
+func matchFoundNotification() notification_entities.PushNotification {
+	deadline := time.Now().Add(20 * time.Second)
+
+	return notification_entities.NewMatchFoundNotification(notification_entities.MatchFound{
+		LobbyID:            uuid.New(),
+		GameID:             common.CS2_GAME_ID,
+		ReadyCheckDeadline: &deadline,
+		CreatedAt:          time.Now(),
+	})
+}
+
+func device(platform notification_entities.DevicePlatform, token string) notification_entities.DeviceToken {
+	return notification_entities.DeviceToken{ID: uuid.New(), UserID: uuid.New(), Platform: platform, Token: token}
+}
+
+func TestFCMSender_Send(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	tokenRequests := 0
+	var message map[string]interface{}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/token":
+			tokenRequests++
+
+			assert.NoError(t, r.ParseForm())
+			assert.Equal(t, "urn:ietf:params:oauth:grant-type:jwt-bearer", r.Form.Get("grant_type"))
+			assert.Len(t, strings.Split(r.Form.Get("assertion"), "."), 3)
+
+			w.Write([]byte(`{"access_token":"access-1","expires_in":3600}`))
+		case "/v1/projects/project-1/messages:send":
+			assert.Equal(t, "Bearer access-1", r.Header.Get("Authorization"))
+
+			body, _ := io.ReadAll(r.Body)
+			assert.NoError(t, json.Unmarshal(body, &message))
+
+			if strings.Contains(string(body), "stale-token") {
+				w.WriteHeader(http.StatusNotFound)
+				w.Write([]byte(`{"error":{"status":"NOT_FOUND","details":[{"errorCode":"UNREGISTERED"}]}}`))
+				return
+			}
+
+			w.Write([]byte(`{"name":"projects/project-1/messages/0:123"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+
+	defer server.Close()
+
+	credentials, _ := json.Marshal(map[string]string{
+		"client_email": "push@project-1.iam.gserviceaccount.com",
+		"private_key":  string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})),
+		"token_uri":    server.URL + "/token",
+	})
+
+	sender, err := push.NewFCMSender("project-1", string(credentials))
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	sender.BaseURL = server.URL
+
+	messageID, err := sender.Send(context.Background(), device(notification_entities.DevicePlatformAndroid, "fcm-token"), matchFoundNotification())
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	assert.Equal(t, "0:123", messageID)
+
+	android := message["message"].(map[string]interface{})["android"].(map[string]interface{})
+	assert.Equal(t, notification_entities.MatchFoundCollapseKey, android["collapse_key"])
+	assert.Equal(t, "high", android["priority"])
+	assert.Regexp(t, `^(19|20)s$`, android["ttl"])
+
+	// browsers are sent the web push headers instead
+	_, err = sender.Send(context.Background(), device(notification_entities.DevicePlatformWeb, "browser-token"), matchFoundNotification())
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	assert.NotContains(t, message["message"], "android")
+
+	webpush := message["message"].(map[string]interface{})["webpush"].(map[string]interface{})["headers"].(map[string]interface{})
+	assert.Equal(t, "high", webpush["Urgency"])
+	assert.Regexp(t, `^(19|20)$`, webpush["TTL"])
+
+	// rejected tokens are reported, the access token is reused
+	_, err = sender.Send(context.Background(), device(notification_entities.DevicePlatformAndroid, "stale-token"), matchFoundNotification())
+
+	var rejectedErr *notification.DeviceTokenRejectedError
+	assert.True(t, errors.As(err, &rejectedErr))
+	assert.Equal(t, 1, tokenRequests)
+}
+
+func TestAPNsSender_Send(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	notif := matchFoundNotification()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.True(t, strings.HasPrefix(r.Header.Get("Authorization"), "bearer "))
+		assert.Equal(t, "com.example.app", r.Header.Get("apns-topic"))
+		assert.Equal(t, "alert", r.Header.Get("apns-push-type"))
+		assert.Equal(t, notification_entities.MatchFoundCollapseKey, r.Header.Get("apns-collapse-id"))
+		assert.Equal(t, notif.ExpiresAt.Unix(), mustParseInt(t, r.Header.Get("apns-expiration")))
+
+		if r.URL.Path == "/3/device/stale-token" {
+			w.WriteHeader(http.StatusGone)
+			w.Write([]byte(`{"reason":"Unregistered"}`))
+			return
+		}
+
+		var body map[string]interface{}
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		assert.Contains(t, body, "aps")
+		assert.Equal(t, notif.Data["lobby_id"], body["lobby_id"])
+
+		w.Header().Set("apns-id", "apns-1")
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	defer server.Close()
+
+	sender, err := push.NewAPNsSender("KEY1", "TEAM1", string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})), "com.example.app", true)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	sender.BaseURL = server.URL
+
+	messageID, err := sender.Send(context.Background(), device(notification_entities.DevicePlatformIOS, "apns-token"), notif)
+	assert.NoError(t, err)
+	assert.Equal(t, "apns-1", messageID)
+
+	_, err = sender.Send(context.Background(), device(notification_entities.DevicePlatformIOS, "stale-token"), notif)
+
+	var rejectedErr *notification.DeviceTokenRejectedError
+	assert.True(t, errors.As(err, &rejectedErr))
+}
+
+func TestNewSender(t *testing.T) {
+	sender, err := push.NewSender(common.PushConfig{})
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	// no push service configured for the platform
+	_, err = sender.Send(context.Background(), device(notification_entities.DevicePlatformIOS, "apns-token"), matchFoundNotification())
+	assert.Error(t, err)
+
+	_, err = push.NewSender(common.PushConfig{APNsKeyID: "KEY1"})
+	assert.Error(t, err)
+}
+
+func mustParseInt(t *testing.T, s string) int64 {
+	n, err := strconv.ParseInt(s, 10, 64)
+	assert.NoError(t, err)
+
+	return n
+}
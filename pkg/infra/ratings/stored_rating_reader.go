@@ -0,0 +1,50 @@
+package ratings
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/google/uuid"
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	matchmaking_out "github.com/psavelis/team-pro/replay-api/pkg/domain/matchmaking/ports/out"
+	rating_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/rating/entities"
+	rating_out "github.com/psavelis/team-pro/replay-api/pkg/domain/rating/ports/out"
+)
+
+const DefaultRating = rating_entities.InitialRating
+
+// StoredRatingReader reads the Glicko-2 rating of the player in the game (updated after each of their completed matches), unrated
+// players get the DefaultRating. Players yet to play the active season are matched by their rating reset for it.
+type StoredRatingReader struct {
+	RatingReader rating_out.RatingReader
+	SeasonReader rating_out.ActiveSeasonReader
+}
+
+func NewStoredRatingReader(ratingReader rating_out.RatingReader, seasonReader rating_out.ActiveSeasonReader) matchmaking_out.PlayerRatingReader {
+	return &StoredRatingReader{RatingReader: ratingReader, SeasonReader: seasonReader}
+}
+
+func (r *StoredRatingReader) GetRating(ctx context.Context, gameID common.GameIDKey, playerID uuid.UUID) (int, error) {
+	id := rating_entities.RatingID(rating_entities.RatingSubjectPlayer, playerID, gameID)
+
+	ratings, err := r.RatingReader.Search(ctx, common.NewSearchByID(ctx, id, common.ClientApplicationAudienceIDKey))
+	if err != nil {
+		slog.ErrorContext(ctx, "unable to search player rating", "playerID", playerID, "gameID", gameID, "err", err)
+		return 0, err
+	}
+
+	if len(ratings) == 0 {
+		return DefaultRating, nil
+	}
+
+	season, err := r.SeasonReader.GetActiveSeason(ctx, gameID)
+	if err != nil {
+		return 0, err
+	}
+
+	if season != nil {
+		return season.ResetRating(ratings[0]).MMR(), nil
+	}
+
+	return ratings[0].MMR(), nil
+}
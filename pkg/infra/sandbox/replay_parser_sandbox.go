@@ -0,0 +1,240 @@
+package sandbox
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"os/exec"
+	"runtime/debug"
+	"strings"
+	"syscall"
+	"time"
+
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	"github.com/psavelis/team-pro/replay-api/pkg/domain/replay"
+	replay_entity "github.com/psavelis/team-pro/replay-api/pkg/domain/replay/entities"
+)
+
+const (
+	// the replay worker runs the parser process as `<replay-worker> parse`
+	ParseCommand = "parse"
+
+	// exit code of a parser process that failed to process the replay file (the failure is already recorded on it)
+	ExitCodeFailed = 1
+
+	DefaultMemoryLimitMB = 2048
+	DefaultCPULimit      = 10 * time.Minute
+	DefaultTimeout       = 15 * time.Minute
+
+	// bytes of the parser stderr kept as the detail of a crash (the panic and its stack, mostly)
+	crashDetailSize = 4096
+)
+
+// Limits of a parser process. Memory is enforced on the data segment (RLIMIT_DATA: the Go runtime reserves far more address space
+// than it uses), CPU time by RLIMIT_CPU (SIGXCPU, then SIGKILL a few seconds later) and the wall time by the replay worker.
+type Limits struct {
+	MemoryLimitMB int
+	CPULimit      time.Duration
+	Timeout       time.Duration
+}
+
+func NewLimits(config common.ReplayParserConfig) Limits {
+	limits := Limits{
+		MemoryLimitMB: config.MemoryLimitMB,
+		CPULimit:      config.CPULimit,
+		Timeout:       config.Timeout,
+	}
+
+	if limits.MemoryLimitMB <= 0 {
+		limits.MemoryLimitMB = DefaultMemoryLimitMB
+	}
+
+	if limits.CPULimit <= 0 {
+		limits.CPULimit = DefaultCPULimit
+	}
+
+	if limits.Timeout <= 0 {
+		limits.Timeout = DefaultTimeout
+	}
+
+	return limits
+}
+
+// Apply limits the current (parser) process. The GC is also told about the memory limit, so it collects harder before reaching it.
+func (l Limits) Apply() error {
+	memory := uint64(l.MemoryLimitMB) * 1024 * 1024
+
+	err := syscall.Setrlimit(syscall.RLIMIT_DATA, &syscall.Rlimit{Cur: memory, Max: memory})
+	if err != nil {
+		return fmt.Errorf("unable to limit the parser memory to %d MiB: %w", l.MemoryLimitMB, err)
+	}
+
+	cpu := uint64(l.CPULimit.Seconds())
+
+	err = syscall.Setrlimit(syscall.RLIMIT_CPU, &syscall.Rlimit{Cur: cpu, Max: cpu + 5})
+	if err != nil {
+		return fmt.Errorf("unable to limit the parser CPU time to %s: %w", l.CPULimit, err)
+	}
+
+	debug.SetMemoryLimit(int64(memory) / 10 * 9)
+
+	return nil
+}
+
+// ParseRequest is handed to the parser process on its stdin.
+type ParseRequest struct {
+	Event     replay_entity.ReplayFileUploaded `json:"event"`
+	Attempt   int                              `json:"attempt"`
+	RequestID string                           `json:"request_id"` // correlation ID of the delivery the worker is handling
+}
+
+// ReadParseRequest reads the replay file to process, in the parser process, returning the context correlated with the worker.
+func ReadParseRequest(ctx context.Context, r io.Reader) (context.Context, replay_entity.ReplayFileUploaded, error) {
+	var req ParseRequest
+
+	err := json.NewDecoder(r).Decode(&req)
+	if err != nil {
+		return ctx, replay_entity.ReplayFileUploaded{}, fmt.Errorf("invalid parse request: %w", err)
+	}
+
+	req.Event.Attempt = req.Attempt
+
+	if req.RequestID != "" {
+		ctx = common.WithRequestID(ctx, req.RequestID)
+	}
+
+	return ctx, req.Event, nil
+}
+
+// ReplayParserSandbox processes each replay file in a parser process (the worker executable, running ParseCommand), so a malformed
+// demo crashing the parser or exhausting its limits doesn't take the worker down: the crash is returned as a ReplayParserCrashError.
+// The parser logs are forwarded to the worker output.
+type ReplayParserSandbox struct {
+	Executable string
+	Args       []string
+	Env        []string
+	Limits     Limits
+	Stdout     io.Writer
+	Stderr     io.Writer
+}
+
+func NewReplayParserSandbox(config common.ReplayParserConfig) (*ReplayParserSandbox, error) {
+	executable, err := os.Executable()
+	if err != nil {
+		return nil, fmt.Errorf("unable to locate the replay worker executable: %w", err)
+	}
+
+	return &ReplayParserSandbox{
+		Executable: executable,
+		Args:       []string{ParseCommand},
+		Limits:     NewLimits(config),
+		Stdout:     os.Stdout,
+		Stderr:     os.Stderr,
+	}, nil
+}
+
+func (s *ReplayParserSandbox) Process(ctx context.Context, event replay_entity.ReplayFileUploaded) error {
+	scope, _ := common.GetRequestScope(ctx)
+
+	req, err := json.Marshal(ParseRequest{Event: event, Attempt: event.Attempt, RequestID: scope.RequestID})
+	if err != nil {
+		return err
+	}
+
+	runCtx, cancel := context.WithTimeout(ctx, s.Limits.Timeout)
+	defer cancel()
+
+	stderr := &tailWriter{max: crashDetailSize}
+
+	cmd := exec.CommandContext(runCtx, s.Executable, s.Args...)
+	cmd.Env = s.Env
+	cmd.Stdin = bytes.NewReader(req)
+	cmd.Stdout = s.Stdout
+	cmd.Stderr = io.MultiWriter(s.Stderr, stderr)
+	cmd.WaitDelay = 5 * time.Second
+
+	start := time.Now()
+
+	err = cmd.Run()
+	if err == nil {
+		return nil
+	}
+
+	// shutting down: not the replay file's fault
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	var exitErr *exec.ExitError
+	if !errors.As(err, &exitErr) {
+		slog.ErrorContext(ctx, "unable to start the replay parser process", "replayFileID", event.ReplayFileID, "executable", s.Executable, "err", err)
+		return err
+	}
+
+	if exitErr.ExitCode() == ExitCodeFailed {
+		return fmt.Errorf("replay parser failed on replay file %s: %w", event.ReplayFileID, err)
+	}
+
+	failure := s.failure(exitErr.ProcessState, runCtx.Err() != nil, stderr.String())
+	failure.Attempt = event.Attempt
+
+	slog.ErrorContext(ctx, "replay parser process crashed", "replayFileID", event.ReplayFileID, "attempt", event.Attempt, "reason", failure.Reason, "exitCode", failure.ExitCode, "signal", failure.Signal, "elapsed", time.Since(start).String())
+
+	return replay.NewReplayParserCrashError(event.ReplayFileID, failure)
+}
+
+// failure tells why the parser process didn't exit on its own.
+func (s *ReplayParserSandbox) failure(state *os.ProcessState, timedOut bool, detail string) replay_entity.ParseFailure {
+	failure := replay_entity.ParseFailure{
+		Reason:   replay_entity.ParseFailureCrashed,
+		ExitCode: state.ExitCode(),
+		Detail:   detail,
+		At:       time.Now().UTC(),
+	}
+
+	status, ok := state.Sys().(syscall.WaitStatus)
+	if ok && status.Signaled() {
+		failure.Signal = status.Signal().String()
+	}
+
+	cpu := state.UserTime() + state.SystemTime()
+
+	switch {
+	case timedOut:
+		failure.Reason = replay_entity.ParseFailureTimeout
+	case ok && status.Signaled() && (status.Signal() == syscall.SIGXCPU || cpu >= s.Limits.CPULimit):
+		failure.Reason = replay_entity.ParseFailureCPULimit
+	case ok && status.Signaled() && status.Signal() == syscall.SIGKILL:
+		// not killed by the worker (timeout) nor on its CPU time: the OOM killer
+		failure.Reason = replay_entity.ParseFailureOutOfMemory
+	case strings.Contains(detail, "out of memory"):
+		// the runtime aborts once the data segment limit is reached
+		failure.Reason = replay_entity.ParseFailureOutOfMemory
+	}
+
+	return failure
+}
+
+// tailWriter keeps the last max bytes written.
+type tailWriter struct {
+	max int
+	buf []byte
+}
+
+func (w *tailWriter) Write(p []byte) (int, error) {
+	w.buf = append(w.buf, p...)
+	if len(w.buf) > w.max {
+		w.buf = w.buf[len(w.buf)-w.max:]
+	}
+
+	return len(p), nil
+}
+
+func (w *tailWriter) String() string {
+	return strings.ToValidUTF8(string(w.buf), "")
+}
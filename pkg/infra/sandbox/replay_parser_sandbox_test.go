@@ -0,0 +1,103 @@
+package sandbox_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	"github.com/psavelis/team-pro/replay-api/pkg/domain/replay"
+	replay_entity "github.com/psavelis/team-pro/replay-api/pkg/domain/replay/entities"
+	"github.com/psavelis/team-pro/replay-api/pkg/infra/sandbox"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestParserProcess is the parser process run by the sandbox in the tests (the test binary itself), behaving as PARSER_BEHAVIOR says.
+func TestParserProcess(t *testing.T) {
+	behavior := os.Getenv("PARSER_BEHAVIOR")
+	if behavior == "" {
+		return
+	}
+
+	_, event, err := sandbox.ReadParseRequest(context.Background(), os.Stdin)
+	if err != nil || event.Attempt != 2 {
+		os.Exit(3)
+	}
+
+	switch behavior {
+	case "completed":
+		os.Exit(0)
+	case "failed":
+		os.Exit(sandbox.ExitCodeFailed)
+	case "panic":
+		panic(fmt.Sprintf("index out of range parsing %s", event.ReplayFileID))
+	case "out_of_memory":
+		fmt.Fprintln(os.Stderr, "fatal error: runtime: out of memory")
+		os.Exit(2)
+	case "hang":
+		time.Sleep(time.Minute)
+	}
+}
+
+func newSandbox(behavior string) *sandbox.ReplayParserSandbox {
+	return &sandbox.ReplayParserSandbox{
+		Executable: os.Args[0],
+		Args:       []string{"-test.run=^TestParserProcess$"},
+		Env:        append(os.Environ(), "PARSER_BEHAVIOR="+behavior),
+		Limits:     sandbox.Limits{MemoryLimitMB: 512, CPULimit: time.Minute, Timeout: 2 * time.Second},
+		Stdout:     io.Discard,
+		Stderr:     io.Discard,
+	}
+}
+
+func TestReplayParserSandbox_Process(t *testing.T) {
+	tests := []struct {
+		behavior       string
+		expectedReason replay_entity.ParseFailureReason // empty when the parser didn't crash
+		expectedDetail string
+	}{
+		{"failed", "", ""},
+		{"panic", replay_entity.ParseFailureCrashed, "index out of range parsing"},
+		{"out_of_memory", replay_entity.ParseFailureOutOfMemory, "runtime: out of memory"},
+		{"hang", replay_entity.ParseFailureTimeout, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.behavior, func(t *testing.T) {
+			event := replay_entity.ReplayFileUploaded{ReplayFileID: uuid.New(), Attempt: 2}
+
+			err := newSandbox(tt.behavior).Process(context.Background(), event)
+			assert.Error(t, err)
+
+			var crash *replay.ReplayParserCrashError
+			if tt.expectedReason == "" {
+				assert.False(t, errors.As(err, &crash))
+				return
+			}
+
+			assert.ErrorAs(t, err, &crash)
+			assert.Equal(t, tt.expectedReason, crash.Failure.Reason)
+			assert.Equal(t, 2, crash.Failure.Attempt)
+			assert.Contains(t, crash.Failure.Detail, tt.expectedDetail)
+		})
+	}
+}
+
+func TestReplayParserSandbox_ProcessCompleted(t *testing.T) {
+	err := newSandbox("completed").Process(context.Background(), replay_entity.ReplayFileUploaded{ReplayFileID: uuid.New(), Attempt: 2})
+
+	assert.NoError(t, err)
+}
+
+func TestNewLimits_Defaults(t *testing.T) {
+	limits := sandbox.NewLimits(common.ReplayParserConfig{})
+
+	assert.Equal(t, sandbox.DefaultMemoryLimitMB, limits.MemoryLimitMB)
+	assert.Equal(t, sandbox.DefaultCPULimit, limits.CPULimit)
+	assert.Equal(t, sandbox.DefaultTimeout, limits.Timeout)
+}
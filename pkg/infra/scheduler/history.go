@@ -0,0 +1,124 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// a job missed its schedule when no run started for twice its interval, plus the grace (restarts, slow runs)
+	MissedScheduleGrace = time.Minute
+
+	// manual triggers and pauses are picked up within a poll
+	ControlPollInterval = 5 * time.Second
+)
+
+var ErrJobNotFound = errors.New("job not found")
+
+type RunStatus string
+
+const (
+	RunSucceeded RunStatus = "succeeded"
+	RunFailed    RunStatus = "failed"
+)
+
+type RunTrigger string
+
+const (
+	TriggerSchedule RunTrigger = "schedule"
+	TriggerManual   RunTrigger = "manual"
+)
+
+// JobRun is the record of a run of a job: when it ran, on which replica, how many items it processed and why it failed.
+type JobRun struct {
+	ID      uuid.UUID  `json:"id" bson:"_id"`
+	Job     string     `json:"job" bson:"job"`
+	Holder  string     `json:"holder" bson:"holder"`
+	Trigger RunTrigger `json:"trigger" bson:"trigger"`
+	// logged with the records of the run and published with its events: the request triggering the run, or the ID of the run
+	CorrelationID string    `json:"correlation_id" bson:"correlation_id"`
+	Status        RunStatus `json:"status" bson:"status"`
+	Processed     int64     `json:"processed" bson:"processed"`
+	Error         string    `json:"error,omitempty" bson:"error,omitempty"`
+	StartedAt     time.Time `json:"started_at" bson:"started_at"`
+	FinishedAt    time.Time `json:"finished_at" bson:"finished_at"`
+}
+
+// JobState is the schedule of a job, its controls (pause, manual trigger) and a summary of its last run.
+type JobState struct {
+	Name               string     `json:"name" bson:"_id"`
+	IntervalSeconds    int64      `json:"interval_seconds" bson:"interval_seconds"`
+	Paused             bool       `json:"paused" bson:"paused"`
+	TriggerRequestedAt *time.Time `json:"trigger_requested_at,omitempty" bson:"trigger_requested_at,omitempty"`
+	TriggerRequestID   string     `json:"trigger_request_id,omitempty" bson:"trigger_request_id,omitempty"` // correlates the triggered run
+	LastRun            *JobRun    `json:"last_run,omitempty" bson:"last_run,omitempty"`
+	MissedAt           *time.Time `json:"missed_at,omitempty" bson:"missed_at,omitempty"` // set once the miss was alerted, cleared by the next run
+	RegisteredAt       time.Time  `json:"registered_at" bson:"registered_at"`
+}
+
+func (s JobState) Interval() time.Duration {
+	return time.Duration(s.IntervalSeconds) * time.Second
+}
+
+// Overdue reports whether no run started for twice the interval of the job (plus MissedScheduleGrace). Paused jobs are never overdue.
+func (s JobState) Overdue(now time.Time) bool {
+	if s.Paused {
+		return false
+	}
+
+	since := s.RegisteredAt
+	if s.LastRun != nil {
+		since = s.LastRun.StartedAt
+	}
+
+	return now.After(since.Add(2*s.Interval() + MissedScheduleGrace))
+}
+
+// JobStore persists the runs of the jobs and their controls, shared by the scheduler replicas and the admin API.
+type JobStore interface {
+	// Register creates the state of the job (unpaused) or updates its interval.
+	Register(ctx context.Context, name string, interval time.Duration) error
+	// RecordRun appends the run to the history of the job, and clears its trigger and missed schedule.
+	RecordRun(ctx context.Context, run JobRun) error
+	Jobs(ctx context.Context) ([]JobState, error)
+	Runs(ctx context.Context, name string, limit int) ([]JobRun, error)
+	SetPaused(ctx context.Context, name string, paused bool) (*JobState, error)
+	// RequestTrigger requests a run of the job, correlated by the request ID in context.
+	RequestTrigger(ctx context.Context, name string) (*JobState, error)
+	// ClaimTrigger clears a pending manual trigger, true for the replica that cleared it (the one running it), with the request ID of
+	// the trigger.
+	ClaimTrigger(ctx context.Context, name string) (string, bool, error)
+	// MarkMissed flags the job as having missed its schedule, true for the first replica flagging it (the one alerting).
+	MarkMissed(ctx context.Context, name string, at time.Time) (bool, error)
+}
+
+// MissedScheduleNotifier alerts the on-call when a job missed its schedule.
+type MissedScheduleNotifier interface {
+	NotifyMissedSchedule(ctx context.Context, job JobState, now time.Time) error
+}
+
+// runProgress is what a running job reports through its context.
+type runProgress struct {
+	processed atomic.Int64
+	skipped   atomic.Bool
+}
+
+type runProgressKey struct{}
+
+// Processed adds n to the items processed by the current run (a no-op outside a run).
+func Processed(ctx context.Context, n int) {
+	if progress, ok := ctx.Value(runProgressKey{}).(*runProgress); ok {
+		progress.processed.Add(int64(n))
+	}
+}
+
+// Skip marks the current run as skipped (ie: a standby replica of a leader guarded job): it's not recorded in the history.
+func Skip(ctx context.Context) {
+	if progress, ok := ctx.Value(runProgressKey{}).(*runProgress); ok {
+		progress.skipped.Store(true)
+	}
+}
@@ -0,0 +1,170 @@
+package scheduler
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+// LeaseLock is a distributed lock held for a TTL. Acquire both takes a free (or expired) lease and renews one already held by the holder.
+type LeaseLock interface {
+	Acquire(ctx context.Context, name string, holder string, ttl time.Duration) (bool, error)
+	Release(ctx context.Context, name string, holder string) error
+}
+
+// LeaderElection keeps a single replica (the leader) running the jobs it guards; the other replicas stand by and take over once the
+// leader stops renewing its lease (ie: the pod died). The lease is renewed on every heartbeat (TTL/3), each renewal bounded to TTL/3.
+// Leadership lapses with the lease: a leader that can't renew it stops running the guarded jobs before a standby can take it over.
+type LeaderElection struct {
+	Name   string
+	Holder string
+	TTL    time.Duration
+	Lock   LeaseLock
+
+	mu            sync.RWMutex
+	leader        bool
+	lastHeartbeat time.Time // of the last renewal, taken before acquiring (the lease lasts at least a TTL from it)
+}
+
+func NewLeaderElection(lock LeaseLock, name string, holder string, ttl time.Duration) *LeaderElection {
+	return &LeaderElection{
+		Name:   name,
+		Holder: holder,
+		TTL:    ttl,
+		Lock:   lock,
+	}
+}
+
+// IsLeader reports whether this replica holds the lease and finished its takeover. It's false once the lease is about to expire
+// (TTL/6 before it) without being renewed, even while the renewal is still in flight.
+func (e *LeaderElection) IsLeader() bool {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	return e.leader && time.Now().Before(e.lastHeartbeat.Add(e.TTL-e.TTL/6))
+}
+
+// elected reports whether this replica took over the lease, regardless of it having lapsed meanwhile (renewing it resumes leadership
+// without another takeover).
+func (e *LeaderElection) elected() bool {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	return e.leader
+}
+
+// Guard wraps a job so it only runs on the leader. The runs of the standby replicas are skipped (not recorded in the job history).
+func (e *LeaderElection) Guard(run JobFunc) JobFunc {
+	return func(ctx context.Context) error {
+		if !e.IsLeader() {
+			Skip(ctx)
+			return nil
+		}
+
+		return run(ctx)
+	}
+}
+
+// Run campaigns for the lease until ctx is done, releasing it on exit. onElected runs on every takeover before the guarded jobs are
+// resumed (ie: to recover the state left by the previous leader); a failing takeover is logged and leadership is kept anyway, a
+// stalled leader is worse than a partially recovered one.
+func (e *LeaderElection) Run(ctx context.Context, onElected JobFunc) {
+	ticker := time.NewTicker(e.TTL / 3)
+	defer ticker.Stop()
+
+	slog.InfoContext(ctx, "leader election: campaigning", "election", e.Name, "holder", e.Holder, "ttl", e.TTL.String())
+
+	e.heartbeat(ctx, onElected)
+
+	for {
+		select {
+		case <-ctx.Done():
+			e.resign()
+			return
+		case <-ticker.C:
+			e.heartbeat(ctx, onElected)
+		}
+	}
+}
+
+func (e *LeaderElection) heartbeat(ctx context.Context, onElected JobFunc) {
+	now := time.Now()
+
+	// a stalled lock can't hold the heartbeats back
+	acquireCtx, cancel := context.WithTimeout(ctx, e.TTL/3)
+	defer cancel()
+
+	acquired, err := e.Lock.Acquire(acquireCtx, e.Name, e.Holder, e.TTL)
+	if err != nil {
+		slog.ErrorContext(ctx, "leader election: unable to renew lease", "election", e.Name, "holder", e.Holder, "err", err)
+
+		// IsLeader is already false once the lease is about to expire, the lease may belong to a standby after a whole TTL
+		if e.elected() && now.Sub(e.lastHeartbeatAt()) >= e.TTL {
+			slog.WarnContext(ctx, "leader election: heartbeat lost, stepping down", "election", e.Name, "holder", e.Holder)
+			e.setLeader(false, time.Time{})
+		}
+
+		return
+	}
+
+	if !acquired {
+		if e.elected() {
+			slog.WarnContext(ctx, "leader election: lease taken by another replica, stepping down", "election", e.Name, "holder", e.Holder)
+			e.setLeader(false, time.Time{})
+		}
+
+		return
+	}
+
+	if e.elected() {
+		e.setLeader(true, now)
+		return
+	}
+
+	slog.InfoContext(ctx, "leader election: lease acquired, taking over", "election", e.Name, "holder", e.Holder)
+
+	if onElected != nil {
+		err := onElected(ctx)
+		if err != nil {
+			slog.ErrorContext(ctx, "leader election: takeover failed", "election", e.Name, "holder", e.Holder, "err", err)
+		}
+	}
+
+	e.setLeader(true, now)
+}
+
+func (e *LeaderElection) resign() {
+	if !e.elected() {
+		return
+	}
+
+	e.setLeader(false, time.Time{})
+
+	// the run context is already done
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	err := e.Lock.Release(ctx, e.Name, e.Holder)
+	if err != nil {
+		slog.ErrorContext(ctx, "leader election: unable to release lease", "election", e.Name, "holder", e.Holder, "err", err)
+		return
+	}
+
+	slog.InfoContext(ctx, "leader election: lease released", "election", e.Name, "holder", e.Holder)
+}
+
+func (e *LeaderElection) setLeader(leader bool, heartbeat time.Time) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.leader = leader
+	e.lastHeartbeat = heartbeat
+}
+
+func (e *LeaderElection) lastHeartbeatAt() time.Time {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	return e.lastHeartbeat
+}
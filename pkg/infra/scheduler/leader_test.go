@@ -0,0 +1,201 @@
+package scheduler_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/psavelis/team-pro/replay-api/pkg/infra/scheduler"
+	"github.com/stretchr/testify/assert"
+)
+
+const ttl = 60 * time.Millisecond
+
+type memoryLeaseLock struct {
+	mu        sync.Mutex
+	holder    string
+	expiresAt time.Time
+	down      map[string]bool // holders that can't reach the lock
+	stalled   map[string]bool // holders whose requests hang (until canceled)
+}
+
+func newMemoryLeaseLock() *memoryLeaseLock {
+	return &memoryLeaseLock{down: make(map[string]bool), stalled: make(map[string]bool)}
+}
+
+func (l *memoryLeaseLock) Acquire(ctx context.Context, name string, holder string, ttl time.Duration) (bool, error) {
+	l.mu.Lock()
+
+	if l.stalled[holder] {
+		l.mu.Unlock()
+		<-ctx.Done()
+		return false, ctx.Err()
+	}
+
+	defer l.mu.Unlock()
+
+	if l.down[holder] {
+		return false, errors.New("lock unreachable")
+	}
+
+	now := time.Now()
+	if l.holder != holder && now.Before(l.expiresAt) {
+		return false, nil
+	}
+
+	l.holder = holder
+	l.expiresAt = now.Add(ttl)
+
+	return true, nil
+}
+
+func (l *memoryLeaseLock) Release(ctx context.Context, name string, holder string) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.holder == holder {
+		l.expiresAt = time.Now()
+	}
+
+	return nil
+}
+
+func (l *memoryLeaseLock) setDown(holder string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.down[holder] = true
+}
+
+func (l *memoryLeaseLock) setStalled(holder string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.stalled[holder] = true
+}
+
+func startElection(ctx context.Context, lock scheduler.LeaseLock, holder string, takeovers *int32) *scheduler.LeaderElection {
+	election := scheduler.NewLeaderElection(lock, "matchmaking.matcher", holder, ttl)
+
+	go election.Run(ctx, func(ctx context.Context) error {
+		atomic.AddInt32(takeovers, 1)
+		return nil
+	})
+
+	return election
+}
+
+func TestLeaderElection_SingleLeader(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	lock := newMemoryLeaseLock()
+
+	var takeovers int32
+	a := startElection(ctx, lock, "a", &takeovers)
+	b := startElection(ctx, lock, "b", &takeovers)
+
+	assert.Eventually(t, func() bool { return a.IsLeader() || b.IsLeader() }, time.Second, 5*time.Millisecond)
+
+	// renewals keep the same leader
+	time.Sleep(3 * ttl)
+
+	assert.NotEqual(t, a.IsLeader(), b.IsLeader())
+	assert.Equal(t, int32(1), atomic.LoadInt32(&takeovers))
+}
+
+func TestLeaderElection_StandbyTakesOverWhenLeaderStops(t *testing.T) {
+	lock := newMemoryLeaseLock()
+
+	var takeovers int32
+
+	leaderCtx, stopLeader := context.WithCancel(context.Background())
+	leader := startElection(leaderCtx, lock, "leader", &takeovers)
+
+	assert.Eventually(t, leader.IsLeader, time.Second, 5*time.Millisecond)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	standby := startElection(ctx, lock, "standby", &takeovers)
+
+	time.Sleep(ttl)
+	assert.False(t, standby.IsLeader())
+
+	stopLeader()
+
+	assert.Eventually(t, standby.IsLeader, time.Second, 5*time.Millisecond)
+	assert.False(t, leader.IsLeader())
+	assert.Equal(t, int32(2), atomic.LoadInt32(&takeovers))
+}
+
+func TestLeaderElection_LeaderStepsDownWithoutHeartbeat(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	lock := newMemoryLeaseLock()
+
+	var takeovers int32
+	leader := startElection(ctx, lock, "leader", &takeovers)
+
+	assert.Eventually(t, leader.IsLeader, time.Second, 5*time.Millisecond)
+
+	standby := startElection(ctx, lock, "standby", &takeovers)
+
+	// the leader is partitioned from the lock: it must step down before (or as) the standby takes over
+	lock.setDown("leader")
+
+	assert.Eventually(t, func() bool { return !leader.IsLeader() }, time.Second, 5*time.Millisecond)
+	assert.Eventually(t, standby.IsLeader, time.Second, 5*time.Millisecond)
+}
+
+func TestLeaderElection_LeaderStepsDownWhenLockStalls(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	lock := newMemoryLeaseLock()
+
+	var takeovers int32
+	leader := startElection(ctx, lock, "leader", &takeovers)
+
+	assert.Eventually(t, leader.IsLeader, time.Second, 5*time.Millisecond)
+
+	standby := startElection(ctx, lock, "standby", &takeovers)
+
+	// the renewals of the leader hang: its leadership lapses with the lease, never overlapping the standby's
+	lock.setStalled("leader")
+
+	deadline := time.Now().Add(10 * ttl)
+	for time.Now().Before(deadline) && !standby.IsLeader() {
+		assert.False(t, leader.IsLeader() && standby.IsLeader(), "two leaders")
+		time.Sleep(time.Millisecond)
+	}
+
+	assert.True(t, standby.IsLeader())
+	assert.False(t, leader.IsLeader())
+}
+
+func TestLeaderElection_Guard(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	election := scheduler.NewLeaderElection(newMemoryLeaseLock(), "matchmaking.matcher", "a", ttl)
+
+	runs := 0
+	job := election.Guard(func(ctx context.Context) error {
+		runs++
+		return nil
+	})
+
+	assert.NoError(t, job(ctx))
+	assert.Equal(t, 0, runs)
+
+	go election.Run(ctx, nil)
+
+	assert.Eventually(t, election.IsLeader, time.Second, 5*time.Millisecond)
+	assert.NoError(t, job(ctx))
+	assert.Equal(t, 1, runs)
+}
@@ -0,0 +1,314 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+)
+
+type JobFunc func(ctx context.Context) error
+
+type Job struct {
+	Name     string
+	Interval time.Duration
+	Run      JobFunc
+}
+
+// Scheduler runs each registered job once on start and then on every tick of its interval.
+// Runs of the same job never overlap: a tick is skipped while the previous run is still in progress.
+//
+// With a Store, every run is recorded in the history of its job, and the controls of the jobs are polled (every ControlPollInterval):
+// the ticks of paused jobs are skipped, and manually triggered jobs run right away (paused or not) on the replica claiming the trigger.
+type Scheduler struct {
+	Store  JobStore
+	Holder string
+
+	jobs     []Job
+	mu       sync.RWMutex
+	paused   map[string]bool
+	triggers map[string]chan struct{}
+}
+
+func NewScheduler() *Scheduler {
+	return &Scheduler{
+		jobs:     make([]Job, 0),
+		paused:   make(map[string]bool),
+		triggers: make(map[string]chan struct{}),
+	}
+}
+
+// WithStore records the runs of the jobs in the store, as the holder (ie: the replica).
+func (s *Scheduler) WithStore(store JobStore, holder string) *Scheduler {
+	s.Store = store
+	s.Holder = holder
+
+	return s
+}
+
+func (s *Scheduler) Every(interval time.Duration, name string, run JobFunc) *Scheduler {
+	s.jobs = append(s.jobs, Job{
+		Name:     name,
+		Interval: interval,
+		Run:      run,
+	})
+
+	s.triggers[name] = make(chan struct{}, 1)
+
+	return s
+}
+
+// Start blocks until ctx is done.
+func (s *Scheduler) Start(ctx context.Context) {
+	var wg sync.WaitGroup
+
+	if s.Store != nil {
+		for _, job := range s.jobs {
+			err := s.Store.Register(ctx, job.Name, job.Interval)
+			if err != nil {
+				slog.ErrorContext(ctx, "scheduler: unable to register job", "job", job.Name, "err", err)
+			}
+		}
+
+		s.pollControls(ctx)
+
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+			s.controlLoop(ctx)
+		}()
+	}
+
+	for _, job := range s.jobs {
+		wg.Add(1)
+
+		go func(job Job) {
+			defer wg.Done()
+			s.loop(ctx, job)
+		}(job)
+	}
+
+	wg.Wait()
+}
+
+func (s *Scheduler) loop(ctx context.Context, job Job) {
+	ticker := time.NewTicker(job.Interval)
+	defer ticker.Stop()
+
+	slog.InfoContext(ctx, "scheduler: job registered", "job", job.Name, "interval", job.Interval.String())
+
+	s.tick(ctx, job)
+
+	for {
+		select {
+		case <-ctx.Done():
+			slog.InfoContext(ctx, "scheduler: job stopped", "job", job.Name)
+			return
+		case <-ticker.C:
+			s.tick(ctx, job)
+		case <-s.triggers[job.Name]:
+			s.trigger(ctx, job)
+		}
+	}
+}
+
+func (s *Scheduler) tick(ctx context.Context, job Job) {
+	if s.isPaused(job.Name) {
+		slog.DebugContext(ctx, "scheduler: job paused, tick skipped", "job", job.Name)
+		return
+	}
+
+	s.run(ctx, job, TriggerSchedule, "")
+}
+
+func (s *Scheduler) trigger(ctx context.Context, job Job) {
+	requestID, claimed, err := s.Store.ClaimTrigger(ctx, job.Name)
+	if err != nil {
+		slog.ErrorContext(ctx, "scheduler: unable to claim job trigger", "job", job.Name, "err", err)
+		return
+	}
+
+	if !claimed {
+		return
+	}
+
+	slog.InfoContext(ctx, "scheduler: job triggered manually", "job", job.Name)
+
+	skipped := s.run(ctx, job, TriggerManual, requestID)
+	if !skipped {
+		return
+	}
+
+	// a standby replica claimed it: handed back to the leader
+	_, err = s.Store.RequestTrigger(common.WithRequestID(ctx, requestID), job.Name)
+	if err != nil {
+		slog.ErrorContext(ctx, "scheduler: unable to hand back job trigger", "job", job.Name, "err", err)
+	}
+}
+
+// run runs the job, returning whether the run was skipped (see Skip). Runs are correlated by the request ID of their trigger, or their
+// own ID (when scheduled, or triggered out of a request).
+func (s *Scheduler) run(ctx context.Context, job Job, trigger RunTrigger, requestID string) (skipped bool) {
+	runID := uuid.New()
+	if requestID == "" {
+		requestID = runID.String()
+	}
+
+	ctx = common.WithRequestID(ctx, requestID)
+
+	progress := &runProgress{}
+	ctx = context.WithValue(ctx, runProgressKey{}, progress)
+
+	start := time.Now()
+
+	var err error
+
+	defer func() {
+		if r := recover(); r != nil {
+			slog.ErrorContext(ctx, "scheduler: job panicked", "job", job.Name, "panic", r)
+			err = fmt.Errorf("panic: %v", r)
+		}
+
+		skipped = progress.skipped.Load()
+		if skipped || s.Store == nil {
+			return
+		}
+
+		s.record(ctx, JobRun{
+			ID:            runID,
+			Job:           job.Name,
+			Holder:        s.Holder,
+			Trigger:       trigger,
+			CorrelationID: requestID,
+			Processed:     progress.processed.Load(),
+			StartedAt:     start.UTC(),
+			FinishedAt:    time.Now().UTC(),
+		}, err)
+	}()
+
+	err = job.Run(ctx)
+	if err != nil {
+		slog.ErrorContext(ctx, "scheduler: job failed", "job", job.Name, "runID", runID, "err", err, "elapsed", time.Since(start).String())
+		return
+	}
+
+	slog.InfoContext(ctx, "scheduler: job completed", "job", job.Name, "elapsed", time.Since(start).String())
+
+	return
+}
+
+func (s *Scheduler) record(ctx context.Context, run JobRun, err error) {
+	run.Status = RunSucceeded
+	if err != nil {
+		run.Status = RunFailed
+		run.Error = err.Error()
+	}
+
+	// recorded even when the run was cancelled by the shutdown
+	recordCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), 5*time.Second)
+	defer cancel()
+
+	recordErr := s.Store.RecordRun(recordCtx, run)
+	if recordErr != nil {
+		slog.ErrorContext(ctx, "scheduler: unable to record job run", "job", run.Job, "runID", run.ID, "err", recordErr)
+	}
+}
+
+func (s *Scheduler) controlLoop(ctx context.Context) {
+	ticker := time.NewTicker(ControlPollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.pollControls(ctx)
+		}
+	}
+}
+
+// pollControls refreshes the paused jobs and signals the loops of the jobs with a pending manual trigger.
+func (s *Scheduler) pollControls(ctx context.Context) {
+	states, err := s.Store.Jobs(ctx)
+	if err != nil {
+		slog.ErrorContext(ctx, "scheduler: unable to poll job controls", "err", err)
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, state := range states {
+		triggers, ok := s.triggers[state.Name]
+		if !ok {
+			continue
+		}
+
+		if s.paused[state.Name] != state.Paused {
+			slog.InfoContext(ctx, "scheduler: job pause toggled", "job", state.Name, "paused", state.Paused)
+		}
+
+		s.paused[state.Name] = state.Paused
+
+		if state.TriggerRequestedAt != nil {
+			select {
+			case triggers <- struct{}{}:
+			default:
+			}
+		}
+	}
+}
+
+func (s *Scheduler) isPaused(name string) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.paused[name]
+}
+
+// Watchdog is a job alerting (once per miss) on the jobs of this scheduler that missed their schedule (see JobState.Overdue): the
+// replicas share the states of the jobs, only the first one flagging a miss alerts.
+func (s *Scheduler) Watchdog(notifier MissedScheduleNotifier) JobFunc {
+	return func(ctx context.Context) error {
+		if s.Store == nil {
+			return nil
+		}
+
+		states, err := s.Store.Jobs(ctx)
+		if err != nil {
+			return err
+		}
+
+		now := time.Now().UTC()
+
+		for _, state := range states {
+			if _, ok := s.triggers[state.Name]; !ok || state.MissedAt != nil || !state.Overdue(now) {
+				continue
+			}
+
+			flagged, err := s.Store.MarkMissed(ctx, state.Name, now)
+			if err != nil {
+				return err
+			}
+
+			if !flagged {
+				continue
+			}
+
+			Processed(ctx, 1)
+
+			err = notifier.NotifyMissedSchedule(ctx, state, now)
+			if err != nil {
+				slog.ErrorContext(ctx, "scheduler: unable to alert missed schedule", "job", state.Name, "err", err)
+			}
+		}
+
+		return nil
+	}
+}
@@ -0,0 +1,304 @@
+package scheduler_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	"github.com/psavelis/team-pro/replay-api/pkg/infra/scheduler"
+	"github.com/stretchr/testify/assert"
+)
+
+type memoryJobStore struct {
+	mu     sync.Mutex
+	states map[string]*scheduler.JobState
+	runs   []scheduler.JobRun
+}
+
+func newMemoryJobStore() *memoryJobStore {
+	return &memoryJobStore{states: make(map[string]*scheduler.JobState)}
+}
+
+func (s *memoryJobStore) Register(ctx context.Context, name string, interval time.Duration) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	state, ok := s.states[name]
+	if !ok {
+		state = &scheduler.JobState{Name: name, RegisteredAt: time.Now().UTC()}
+		s.states[name] = state
+	}
+
+	state.IntervalSeconds = int64(interval.Seconds())
+
+	return nil
+}
+
+func (s *memoryJobStore) RecordRun(ctx context.Context, run scheduler.JobRun) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.runs = append(s.runs, run)
+	s.states[run.Job].LastRun = &run
+	s.states[run.Job].MissedAt = nil
+
+	return nil
+}
+
+func (s *memoryJobStore) Jobs(ctx context.Context) ([]scheduler.JobState, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	jobs := make([]scheduler.JobState, 0, len(s.states))
+	for _, state := range s.states {
+		jobs = append(jobs, *state)
+	}
+
+	return jobs, nil
+}
+
+func (s *memoryJobStore) Runs(ctx context.Context, name string, limit int) ([]scheduler.JobRun, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	runs := make([]scheduler.JobRun, 0)
+	for i := len(s.runs) - 1; i >= 0 && len(runs) < limit; i-- {
+		if s.runs[i].Job == name {
+			runs = append(runs, s.runs[i])
+		}
+	}
+
+	return runs, nil
+}
+
+func (s *memoryJobStore) SetPaused(ctx context.Context, name string, paused bool) (*scheduler.JobState, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	state, ok := s.states[name]
+	if !ok {
+		return nil, scheduler.ErrJobNotFound
+	}
+
+	state.Paused = paused
+
+	return state, nil
+}
+
+func (s *memoryJobStore) RequestTrigger(ctx context.Context, name string) (*scheduler.JobState, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	state, ok := s.states[name]
+	if !ok {
+		return nil, scheduler.ErrJobNotFound
+	}
+
+	scope, _ := common.GetRequestScope(ctx)
+
+	now := time.Now().UTC()
+	state.TriggerRequestedAt = &now
+	state.TriggerRequestID = scope.RequestID
+
+	return state, nil
+}
+
+func (s *memoryJobStore) ClaimTrigger(ctx context.Context, name string) (string, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	state := s.states[name]
+	if state.TriggerRequestedAt == nil {
+		return "", false, nil
+	}
+
+	requestID := state.TriggerRequestID
+	state.TriggerRequestedAt = nil
+	state.TriggerRequestID = ""
+
+	return requestID, true, nil
+}
+
+func (s *memoryJobStore) MarkMissed(ctx context.Context, name string, at time.Time) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	state := s.states[name]
+	if state.MissedAt != nil {
+		return false, nil
+	}
+
+	state.MissedAt = &at
+
+	return true, nil
+}
+
+func (s *memoryJobStore) recorded(name string) []scheduler.JobRun {
+	runs, _ := s.Runs(context.Background(), name, 100)
+	return runs
+}
+
+type memoryNotifier struct {
+	mu     sync.Mutex
+	missed []string
+}
+
+func (n *memoryNotifier) NotifyMissedSchedule(ctx context.Context, job scheduler.JobState, now time.Time) error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	n.missed = append(n.missed, job.Name)
+
+	return nil
+}
+
+func TestScheduler_RecordsRuns(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	store := newMemoryJobStore()
+
+	s := scheduler.NewScheduler().WithStore(store, "replica-a")
+
+	s.Every(time.Hour, "exports.nightly", func(ctx context.Context) error {
+		scheduler.Processed(ctx, 3)
+		return nil
+	})
+
+	s.Every(time.Hour, "quality.checks", func(ctx context.Context) error {
+		scheduler.Processed(ctx, 1)
+		return errors.New("findings unavailable")
+	})
+
+	s.Every(time.Hour, "matchmaking.matcher", func(ctx context.Context) error {
+		scheduler.Skip(ctx)
+		return nil
+	})
+
+	done := make(chan struct{})
+	go func() {
+		s.Start(ctx)
+		close(done)
+	}()
+
+	assert.Eventually(t, func() bool {
+		return len(store.recorded("exports.nightly")) == 1 && len(store.recorded("quality.checks")) == 1
+	}, time.Second, 10*time.Millisecond)
+
+	cancel()
+	<-done
+
+	exports := store.recorded("exports.nightly")[0]
+	assert.Equal(t, scheduler.RunSucceeded, exports.Status)
+	assert.Equal(t, scheduler.TriggerSchedule, exports.Trigger)
+	assert.Equal(t, int64(3), exports.Processed)
+	assert.Equal(t, "replica-a", exports.Holder)
+	assert.False(t, exports.FinishedAt.Before(exports.StartedAt))
+	assert.Equal(t, exports.ID.String(), exports.CorrelationID)
+
+	checks := store.recorded("quality.checks")[0]
+	assert.Equal(t, scheduler.RunFailed, checks.Status)
+	assert.Equal(t, "findings unavailable", checks.Error)
+
+	// standby runs aren't history
+	assert.Empty(t, store.recorded("matchmaking.matcher"))
+}
+
+func TestScheduler_PausedJobRunsOnlyWhenTriggered(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	store := newMemoryJobStore()
+	store.Register(ctx, "replay.player_match_history", time.Hour)
+	store.SetPaused(ctx, "replay.player_match_history", true)
+	// triggered through the admin API
+	store.RequestTrigger(common.WithRequestID(ctx, "trigger-request"), "replay.player_match_history")
+
+	s := scheduler.NewScheduler().WithStore(store, "replica-a")
+
+	var mu sync.Mutex
+	runs := 0
+	requestID := ""
+
+	s.Every(time.Hour, "replay.player_match_history", func(ctx context.Context) error {
+		mu.Lock()
+		defer mu.Unlock()
+
+		runs++
+
+		scope, _ := common.GetRequestScope(ctx)
+		requestID = scope.RequestID
+
+		return nil
+	})
+
+	done := make(chan struct{})
+	go func() {
+		s.Start(ctx)
+		close(done)
+	}()
+
+	assert.Eventually(t, func() bool {
+		return len(store.recorded("replay.player_match_history")) == 1
+	}, time.Second, 10*time.Millisecond)
+
+	cancel()
+	<-done
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	// the first tick is skipped (paused), the trigger runs anyway
+	assert.Equal(t, 1, runs)
+	assert.Equal(t, scheduler.TriggerManual, store.recorded("replay.player_match_history")[0].Trigger)
+
+	// the run is correlated with the request triggering it
+	assert.Equal(t, "trigger-request", requestID)
+	assert.Equal(t, "trigger-request", store.recorded("replay.player_match_history")[0].CorrelationID)
+
+	state, _ := store.Jobs(context.Background())
+	assert.Nil(t, state[0].TriggerRequestedAt)
+}
+
+func TestScheduler_WatchdogAlertsOncePerMiss(t *testing.T) {
+	ctx := context.Background()
+
+	store := newMemoryJobStore()
+	notifier := &memoryNotifier{}
+
+	s := scheduler.NewScheduler().WithStore(store, "replica-a")
+	s.Every(time.Minute, "analytics.engagement", func(ctx context.Context) error { return nil })
+	s.Every(time.Minute, "quality.checks", func(ctx context.Context) error { return nil })
+
+	watchdog := s.Watchdog(notifier)
+
+	now := time.Now().UTC()
+
+	// engagement last ran an hour ago, quality checks a few seconds ago
+	store.Register(ctx, "analytics.engagement", time.Minute)
+	store.Register(ctx, "quality.checks", time.Minute)
+	store.RecordRun(ctx, scheduler.JobRun{Job: "analytics.engagement", StartedAt: now.Add(-time.Hour), FinishedAt: now.Add(-time.Hour)})
+	store.RecordRun(ctx, scheduler.JobRun{Job: "quality.checks", StartedAt: now.Add(-5 * time.Second), FinishedAt: now})
+
+	// not registered by this scheduler
+	store.Register(ctx, "exports.nightly", time.Minute)
+	store.states["exports.nightly"].RegisteredAt = now.Add(-time.Hour)
+
+	assert.NoError(t, watchdog(ctx))
+	assert.NoError(t, watchdog(ctx))
+	assert.Equal(t, []string{"analytics.engagement"}, notifier.missed)
+
+	// a new run clears the miss, the next one alerts again
+	store.RecordRun(ctx, scheduler.JobRun{Job: "analytics.engagement", StartedAt: now.Add(-time.Hour), FinishedAt: now})
+
+	assert.NoError(t, watchdog(ctx))
+	assert.Equal(t, []string{"analytics.engagement", "analytics.engagement"}, notifier.missed)
+
+	// paused jobs don't miss their schedule
+	store.SetPaused(ctx, "analytics.engagement", true)
+	store.RecordRun(ctx, scheduler.JobRun{Job: "analytics.engagement", StartedAt: now.Add(-time.Hour), FinishedAt: now})
+
+	assert.NoError(t, watchdog(ctx))
+	assert.Len(t, notifier.missed, 2)
+}
@@ -0,0 +1,38 @@
+package seasons
+
+import (
+	"context"
+	"log/slog"
+
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	season_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/season/entities"
+	season_out "github.com/psavelis/team-pro/replay-api/pkg/domain/season/ports/out"
+)
+
+// ActiveSeasonReader reads the active season of a game, as transitioned by the seasons job (see season_in.TransitionSeasonsCommand). It serves
+// the season to the replay processing (matches) and the rating worker (ratings).
+type ActiveSeasonReader struct {
+	SeasonReader season_out.SeasonReader
+}
+
+func NewActiveSeasonReader(seasonReader season_out.SeasonReader) *ActiveSeasonReader {
+	return &ActiveSeasonReader{SeasonReader: seasonReader}
+}
+
+func (r *ActiveSeasonReader) GetActiveSeason(ctx context.Context, gameID common.GameIDKey) (*season_entities.Season, error) {
+	seasons, err := r.SeasonReader.Search(ctx, common.NewSearchByValues(ctx, []common.SearchableValue{
+		{Field: "GameID", Values: []interface{}{gameID}},
+		{Field: "Status", Values: []interface{}{season_entities.SeasonStatusActive}},
+	}, common.NewSearchResultOptions(0, 1), common.ClientApplicationAudienceIDKey))
+
+	if err != nil {
+		slog.ErrorContext(ctx, "unable to search active season", "gameID", gameID, "err", err)
+		return nil, err
+	}
+
+	if len(seasons) == 0 {
+		return nil, nil
+	}
+
+	return &seasons[0], nil
+}
@@ -0,0 +1,246 @@
+package voice
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	matchmaking_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/matchmaking/entities"
+)
+
+const (
+	LiveKitProviderName = "livekit"
+
+	// how long a lobby room stays open without participants (LiveKit deletes it afterwards)
+	liveKitEmptyTimeout = 10 * time.Minute
+
+	// join tokens are short lived: clients request a new invite to reconnect
+	liveKitJoinTokenTTL = 15 * time.Minute
+
+	liveKitAPITokenTTL = time.Minute
+)
+
+// LiveKitProvider manages lobby rooms through the LiveKit server API (twirp over HTTP). Rooms are named after the lobby ID and participants are identified by user ID.
+type LiveKitProvider struct {
+	URL       string
+	APIKey    string
+	APISecret string
+	Client    *http.Client
+}
+
+func NewLiveKitProvider(url string, apiKey string, apiSecret string) *LiveKitProvider {
+	return &LiveKitProvider{
+		URL:       strings.TrimSuffix(url, "/"),
+		APIKey:    apiKey,
+		APISecret: apiSecret,
+		Client:    &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+type liveKitVideoGrant struct {
+	Room           string `json:"room,omitempty"`
+	RoomCreate     bool   `json:"roomCreate,omitempty"`
+	RoomAdmin      bool   `json:"roomAdmin,omitempty"`
+	RoomJoin       bool   `json:"roomJoin,omitempty"`
+	CanPublish     *bool  `json:"canPublish,omitempty"`
+	CanSubscribe   *bool  `json:"canSubscribe,omitempty"`
+	CanPublishData *bool  `json:"canPublishData,omitempty"`
+}
+
+type liveKitClaims struct {
+	Issuer    string            `json:"iss"`
+	Subject   string            `json:"sub,omitempty"`
+	NotBefore int64             `json:"nbf"`
+	ExpiresAt int64             `json:"exp"`
+	Video     liveKitVideoGrant `json:"video"`
+}
+
+type liveKitPermission struct {
+	CanSubscribe   bool `json:"can_subscribe"`
+	CanPublish     bool `json:"can_publish"`
+	CanPublishData bool `json:"can_publish_data"`
+}
+
+func (p *LiveKitProvider) Name() string {
+	return LiveKitProviderName
+}
+
+func (p *LiveKitProvider) CreateChannel(ctx context.Context, lobby matchmaking_entities.Lobby) (string, error) {
+	room := lobby.ID.String()
+
+	body := map[string]interface{}{
+		"name":             room,
+		"empty_timeout":    int(liveKitEmptyTimeout.Seconds()),
+		"max_participants": len(lobby.Players),
+	}
+
+	err := p.call(ctx, "CreateRoom", liveKitVideoGrant{RoomCreate: true}, body)
+	if err != nil {
+		return "", err
+	}
+
+	return room, nil
+}
+
+func (p *LiveKitProvider) Invite(ctx context.Context, channelID string, userID uuid.UUID, muted bool) (*matchmaking_entities.VoiceInvite, error) {
+	canPublish := !muted
+	canSubscribe := true
+
+	expiresAt := time.Now().Add(liveKitJoinTokenTTL)
+
+	token, err := p.sign(userID.String(), expiresAt, liveKitVideoGrant{
+		Room:           channelID,
+		RoomJoin:       true,
+		CanPublish:     &canPublish,
+		CanSubscribe:   &canSubscribe,
+		CanPublishData: &canSubscribe,
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &matchmaking_entities.VoiceInvite{
+		Provider:  LiveKitProviderName,
+		ChannelID: channelID,
+		URL:       p.URL,
+		Token:     token,
+		ExpiresAt: expiresAt,
+	}, nil
+}
+
+// SetMuted revokes (or restores) the publish permission of a participant. It is a no-op on LiveKit's side when the user isn't connected: the next invite carries the permission.
+func (p *LiveKitProvider) SetMuted(ctx context.Context, channelID string, userID uuid.UUID, muted bool) error {
+	body := map[string]interface{}{
+		"room":     channelID,
+		"identity": userID.String(),
+		"permission": liveKitPermission{
+			CanSubscribe:   true,
+			CanPublish:     !muted,
+			CanPublishData: true,
+		},
+	}
+
+	err := p.call(ctx, "UpdateParticipant", liveKitVideoGrant{Room: channelID, RoomAdmin: true}, body)
+	if isLiveKitNotFound(err) {
+		return nil
+	}
+
+	return err
+}
+
+func (p *LiveKitProvider) Kick(ctx context.Context, channelID string, userID uuid.UUID) error {
+	body := map[string]interface{}{
+		"room":     channelID,
+		"identity": userID.String(),
+	}
+
+	err := p.call(ctx, "RemoveParticipant", liveKitVideoGrant{Room: channelID, RoomAdmin: true}, body)
+	if isLiveKitNotFound(err) {
+		return nil
+	}
+
+	return err
+}
+
+func (p *LiveKitProvider) DeleteChannel(ctx context.Context, channelID string) error {
+	err := p.call(ctx, "DeleteRoom", liveKitVideoGrant{RoomCreate: true}, map[string]interface{}{"room": channelID})
+	if isLiveKitNotFound(err) {
+		return nil
+	}
+
+	return err
+}
+
+type liveKitError struct {
+	StatusCode int
+	Body       string
+}
+
+func (e *liveKitError) Error() string {
+	return fmt.Sprintf("livekit request failed with status %d: %s", e.StatusCode, e.Body)
+}
+
+func isLiveKitNotFound(err error) bool {
+	lkErr, ok := err.(*liveKitError)
+	return ok && lkErr.StatusCode == http.StatusNotFound
+}
+
+func (p *LiveKitProvider) call(ctx context.Context, method string, grant liveKitVideoGrant, body interface{}) error {
+	token, err := p.sign("", time.Now().Add(liveKitAPITokenTTL), grant)
+	if err != nil {
+		return err
+	}
+
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.apiURL(method), bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	res, err := p.Client.Do(req)
+	if err != nil {
+		return err
+	}
+
+	defer res.Body.Close()
+
+	if res.StatusCode >= 300 {
+		resBody, _ := io.ReadAll(io.LimitReader(res.Body, 1024))
+		return &liveKitError{StatusCode: res.StatusCode, Body: string(resBody)}
+	}
+
+	return nil
+}
+
+// apiURL maps the (ws/wss) LiveKit URL used by clients to the HTTP server API.
+func (p *LiveKitProvider) apiURL(method string) string {
+	base := p.URL
+	base = strings.Replace(base, "wss://", "https://", 1)
+	base = strings.Replace(base, "ws://", "http://", 1)
+
+	return fmt.Sprintf("%s/twirp/livekit.RoomService/%s", base, method)
+}
+
+// sign issues a LiveKit access token (HS256 JWT signed with the API secret).
+func (p *LiveKitProvider) sign(identity string, expiresAt time.Time, grant liveKitVideoGrant) (string, error) {
+	header, err := json.Marshal(map[string]string{"alg": "HS256", "typ": "JWT"})
+	if err != nil {
+		return "", err
+	}
+
+	claims, err := json.Marshal(liveKitClaims{
+		Issuer:    p.APIKey,
+		Subject:   identity,
+		NotBefore: time.Now().Add(-10 * time.Second).Unix(),
+		ExpiresAt: expiresAt.Unix(),
+		Video:     grant,
+	})
+
+	if err != nil {
+		return "", err
+	}
+
+	unsigned := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(claims)
+
+	mac := hmac.New(sha256.New, []byte(p.APISecret))
+	mac.Write([]byte(unsigned))
+
+	return unsigned + "." + base64.RawURLEncoding.EncodeToString(mac.Sum(nil)), nil
+}
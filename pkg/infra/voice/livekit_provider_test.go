@@ -0,0 +1,122 @@
+package voice_test
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/psavelis/team-pro/replay-api/pkg/infra/voice"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	apiKey    = "APIkey"
+	apiSecret = "secret"
+)
+
+// decodeToken verifies the HS256 signature and returns the claims.
+func decodeToken(t *testing.T, token string) map[string]interface{} {
+	parts := strings.Split(token, ".")
+	if !assert.Len(t, parts, 3) {
+		t.FailNow()
+	}
+
+	mac := hmac.New(sha256.New, []byte(apiSecret))
+	mac.Write([]byte(parts[0] + "." + parts[1]))
+	assert.Equal(t, base64.RawURLEncoding.EncodeToString(mac.Sum(nil)), parts[2])
+
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	assert.NoError(t, err)
+
+	var claims map[string]interface{}
+	assert.NoError(t, json.Unmarshal(payload, &claims))
+
+	return claims
+}
+
+func TestLiveKitProvider_Invite(t *testing.T) {
+	p := voice.NewLiveKitProvider("wss://voice.example.com/", apiKey, apiSecret)
+
+	userID := uuid.New()
+
+	tests := []struct {
+		name               string
+		muted              bool
+		expectedCanPublish bool
+	}{
+		{name: "Unmuted", muted: false, expectedCanPublish: true},
+		{name: "Muted", muted: true, expectedCanPublish: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			invite, err := p.Invite(context.Background(), "room-1", userID, tt.muted)
+
+			assert.NoError(t, err)
+			assert.Equal(t, "wss://voice.example.com", invite.URL)
+			assert.Equal(t, "room-1", invite.ChannelID)
+
+			claims := decodeToken(t, invite.Token)
+			assert.Equal(t, apiKey, claims["iss"])
+			assert.Equal(t, userID.String(), claims["sub"])
+			assert.Equal(t, float64(invite.ExpiresAt.Unix()), claims["exp"])
+
+			grant := claims["video"].(map[string]interface{})
+			assert.Equal(t, "room-1", grant["room"])
+			assert.Equal(t, true, grant["roomJoin"])
+			assert.Equal(t, tt.expectedCanPublish, grant["canPublish"])
+			assert.Nil(t, grant["roomAdmin"])
+		})
+	}
+}
+
+func TestLiveKitProvider_RoomService(t *testing.T) {
+	var paths []string
+	var bodies []map[string]interface{}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path)
+
+		claims := decodeToken(t, strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer "))
+		assert.Equal(t, apiKey, claims["iss"])
+
+		var body map[string]interface{}
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		bodies = append(bodies, body)
+
+		if strings.HasSuffix(r.URL.Path, "/RemoveParticipant") {
+			// participant not connected
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		w.Write([]byte("{}"))
+	}))
+
+	defer server.Close()
+
+	p := voice.NewLiveKitProvider(strings.Replace(server.URL, "http://", "ws://", 1), apiKey, apiSecret)
+
+	userID := uuid.New()
+
+	assert.NoError(t, p.SetMuted(context.Background(), "room-1", userID, true))
+	assert.NoError(t, p.Kick(context.Background(), "room-1", userID))
+	assert.NoError(t, p.DeleteChannel(context.Background(), "room-1"))
+
+	assert.Equal(t, []string{
+		"/twirp/livekit.RoomService/UpdateParticipant",
+		"/twirp/livekit.RoomService/RemoveParticipant",
+		"/twirp/livekit.RoomService/DeleteRoom",
+	}, paths)
+
+	assert.Equal(t, userID.String(), bodies[0]["identity"])
+	assert.Equal(t, false, bodies[0]["permission"].(map[string]interface{})["can_publish"])
+	assert.Equal(t, "room-1", bodies[2]["room"])
+}
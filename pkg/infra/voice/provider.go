@@ -0,0 +1,57 @@
+package voice
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	common "github.com/psavelis/team-pro/replay-api/pkg/domain"
+	matchmaking_entities "github.com/psavelis/team-pro/replay-api/pkg/domain/matchmaking/entities"
+	matchmaking_out "github.com/psavelis/team-pro/replay-api/pkg/domain/matchmaking/ports/out"
+)
+
+var ErrVoiceDisabled = errors.New("lobby voice channels are disabled (VOICE_PROVIDER not set)")
+
+// NewVoiceChannelProvider returns the provider selected by config. Voice is disabled (not an error) when no provider is configured.
+func NewVoiceChannelProvider(config common.VoiceConfig) (matchmaking_out.VoiceChannelProvider, error) {
+	switch config.Provider {
+	case "":
+		return &DisabledProvider{}, nil
+	case LiveKitProviderName:
+		if config.LiveKitURL == "" || config.LiveKitAPIKey == "" || config.LiveKitAPISecret == "" {
+			return nil, fmt.Errorf("voice provider '%s' requires LIVEKIT_URL, LIVEKIT_API_KEY and LIVEKIT_API_SECRET", config.Provider)
+		}
+
+		return NewLiveKitProvider(config.LiveKitURL, config.LiveKitAPIKey, config.LiveKitAPISecret), nil
+	default:
+		return nil, fmt.Errorf("unsupported voice provider '%s'", config.Provider)
+	}
+}
+
+// DisabledProvider is used when no voice provider is configured. Lobbies never get a channel, so only the sync job would reach it.
+type DisabledProvider struct{}
+
+func (p *DisabledProvider) Name() string {
+	return ""
+}
+
+func (p *DisabledProvider) CreateChannel(ctx context.Context, lobby matchmaking_entities.Lobby) (string, error) {
+	return "", ErrVoiceDisabled
+}
+
+func (p *DisabledProvider) Invite(ctx context.Context, channelID string, userID uuid.UUID, muted bool) (*matchmaking_entities.VoiceInvite, error) {
+	return nil, ErrVoiceDisabled
+}
+
+func (p *DisabledProvider) SetMuted(ctx context.Context, channelID string, userID uuid.UUID, muted bool) error {
+	return ErrVoiceDisabled
+}
+
+func (p *DisabledProvider) Kick(ctx context.Context, channelID string, userID uuid.UUID) error {
+	return ErrVoiceDisabled
+}
+
+func (p *DisabledProvider) DeleteChannel(ctx context.Context, channelID string) error {
+	return ErrVoiceDisabled
+}
@@ -0,0 +1,155 @@
+package websocket
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net"
+	"net/http"
+	"sync/atomic"
+	"time"
+
+	gorilla "github.com/gorilla/websocket"
+)
+
+const (
+	// JSONSubprotocol frames messages as JSON text messages (the default, when the client doesn't negotiate a subprotocol).
+	JSONSubprotocol = "json"
+	// MessagePackSubprotocol frames messages as MessagePack binary messages, for the clients offering it in Sec-WebSocket-Protocol.
+	MessagePackSubprotocol = "msgpack"
+
+	// messages smaller than CompressionThreshold aren't worth deflating (the deflate block overhead outweighs the savings)
+	CompressionThreshold = 512
+)
+
+// Upgrader negotiates the framing (MessagePack when offered by the client, JSON otherwise) and permessage-deflate (when offered by
+// the client) of the connections, counting the payloads written in Stats.
+type Upgrader struct {
+	Stats    *PayloadStats
+	upgrader gorilla.Upgrader
+}
+
+// NewUpgrader accepts every origin (the same origins as the REST API: Access-Control-Allow-Origin: *).
+func NewUpgrader(stats *PayloadStats) *Upgrader {
+	return &Upgrader{
+		Stats: stats,
+		upgrader: gorilla.Upgrader{
+			ReadBufferSize:    1024,
+			WriteBufferSize:   1024,
+			EnableCompression: true,
+			Subprotocols:      []string{MessagePackSubprotocol, JSONSubprotocol},
+			CheckOrigin:       func(r *http.Request) bool { return true },
+		},
+	}
+}
+
+// Conn writes the messages of a channel with the framing negotiated, deflating the ones above CompressionThreshold when the client
+// supports it.
+type Conn struct {
+	*gorilla.Conn
+	Channel string
+	Binary  bool
+
+	stats   *PayloadStats
+	counter *countingConn
+}
+
+// Upgrade upgrades the request to a websocket connection of the channel. On failure the request was already answered.
+func (u *Upgrader) Upgrade(w http.ResponseWriter, r *http.Request, channel string) (*Conn, error) {
+	hijacker, ok := w.(http.Hijacker)
+	if !ok {
+		return nil, errors.New("websocket: response does not implement http.Hijacker")
+	}
+
+	counter := &countingConn{}
+
+	conn, err := u.upgrader.Upgrade(&countingResponseWriter{ResponseWriter: w, hijacker: hijacker, counter: counter}, r, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// the handshake response isn't a payload
+	counter.written.Store(0)
+
+	return &Conn{
+		Conn:    conn,
+		Channel: channel,
+		Binary:  conn.Subprotocol() == MessagePackSubprotocol,
+		stats:   u.Stats,
+		counter: counter,
+	}, nil
+}
+
+// WriteMessage encodes v with the framing of the connection, within the write timeout.
+func (c *Conn) WriteMessage(v interface{}, timeout time.Duration) error {
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	payload, messageType := raw, gorilla.TextMessage
+	if c.Binary {
+		payload, err = JSONToMessagePack(raw)
+		if err != nil {
+			return err
+		}
+
+		messageType = gorilla.BinaryMessage
+	}
+
+	// a no-op when permessage-deflate wasn't negotiated
+	compressed := len(payload) >= CompressionThreshold
+	c.EnableWriteCompression(compressed)
+
+	c.SetWriteDeadline(time.Now().Add(timeout))
+
+	before := c.counter.written.Load()
+
+	err = c.Conn.WriteMessage(messageType, payload)
+	if err != nil {
+		return err
+	}
+
+	if c.stats != nil {
+		c.stats.Record(c.Channel, c.Binary, compressed && c.counter.compression, len(raw), len(payload), int(c.counter.written.Load()-before))
+	}
+
+	return nil
+}
+
+// countingResponseWriter hands the upgrader a connection counting the bytes written to the client.
+type countingResponseWriter struct {
+	http.ResponseWriter
+	hijacker http.Hijacker
+	counter  *countingConn
+}
+
+func (w *countingResponseWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	conn, rw, err := w.hijacker.Hijack()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	w.counter.Conn = conn
+
+	return w.counter, rw, nil
+}
+
+type countingConn struct {
+	net.Conn
+	written     atomic.Int64
+	compression bool
+}
+
+func (c *countingConn) Write(b []byte) (int, error) {
+	if !c.compression && c.written.Load() == 0 {
+		// the first write is the handshake response, telling whether permessage-deflate was negotiated
+		c.compression = bytes.Contains(b, []byte("permessage-deflate"))
+	}
+
+	n, err := c.Conn.Write(b)
+	c.written.Add(int64(n))
+
+	return n, err
+}
@@ -0,0 +1,107 @@
+package websocket_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	gorilla "github.com/gorilla/websocket"
+	"github.com/psavelis/team-pro/replay-api/pkg/infra/websocket"
+	"github.com/stretchr/testify/assert"
+)
+
+type scoreboard struct {
+	Round   int      `json:"round"`
+	Players []string `json:"players"`
+	Live    bool     `json:"live"`
+}
+
+func TestMarshalMessagePack(t *testing.T) {
+	packed, err := websocket.MarshalMessagePack(map[string]interface{}{"b": []int{1, -1, 300}, "a": nil, "c": true, "d": 1.5, "e": "x"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []byte{
+		0x85,
+		0xa1, 'a', 0xc0,
+		0xa1, 'b', 0x93, 0x01, 0xff, 0xd1, 0x01, 0x2c,
+		0xa1, 'c', 0xc3,
+		0xa1, 'd', 0xcb, 0x3f, 0xf8, 0, 0, 0, 0, 0, 0,
+		0xa1, 'e', 0xa1, 'x',
+	}, packed)
+}
+
+func TestUpgrader_Upgrade(t *testing.T) {
+	upgrader := websocket.NewUpgrader(websocket.NewPayloadStats())
+
+	players := make([]string, 100)
+	for i := range players {
+		players[i] = strings.Repeat("player", 3)
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, "scoreboard")
+		if err != nil {
+			return
+		}
+
+		defer conn.Close()
+
+		conn.WriteMessage(scoreboard{Round: 1, Live: true}, time.Second)
+		conn.WriteMessage(scoreboard{Round: 2, Players: players}, time.Second)
+	}))
+
+	defer server.Close()
+
+	url := "ws" + strings.TrimPrefix(server.URL, "http")
+
+	// JSON, without compression
+	conn, _, err := gorilla.DefaultDialer.Dial(url, nil)
+	assert.NoError(t, err)
+
+	messageType, payload, err := conn.ReadMessage()
+	assert.NoError(t, err)
+	assert.Equal(t, gorilla.TextMessage, messageType)
+	assert.JSONEq(t, `{"round":1,"players":null,"live":true}`, string(payload))
+
+	_, _, err = conn.ReadMessage()
+	assert.NoError(t, err)
+	conn.Close()
+
+	// MessagePack, with compression
+	dialer := gorilla.Dialer{Subprotocols: []string{websocket.MessagePackSubprotocol}, EnableCompression: true}
+
+	conn, _, err = dialer.Dial(url, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, websocket.MessagePackSubprotocol, conn.Subprotocol())
+
+	messageType, payload, err = conn.ReadMessage()
+	assert.NoError(t, err)
+	assert.Equal(t, gorilla.BinaryMessage, messageType)
+
+	expected, _ := websocket.MarshalMessagePack(scoreboard{Round: 1, Live: true})
+	assert.Equal(t, expected, payload)
+
+	_, payload, err = conn.ReadMessage()
+	assert.NoError(t, err)
+
+	expected, _ = websocket.MarshalMessagePack(scoreboard{Round: 2, Players: players})
+	assert.Equal(t, expected, payload)
+	conn.Close()
+
+	// counted once written, the client may read them first
+	assert.Eventually(t, func() bool {
+		stats := upgrader.Stats.Snapshot()
+		return len(stats) == 1 && stats[0].Messages == 4
+	}, time.Second, 10*time.Millisecond)
+
+	stats := upgrader.Stats.Snapshot()
+	assert.Equal(t, "scoreboard", stats[0].Channel)
+	assert.Equal(t, uint64(2), stats[0].BinaryMessages)
+	// only the large message of the client supporting permessage-deflate
+	assert.Equal(t, uint64(1), stats[0].CompressedMessages)
+	assert.Less(t, stats[0].PayloadBytes, stats[0].JSONBytes)
+	assert.Less(t, stats[0].WireBytes, stats[0].PayloadBytes)
+	assert.Greater(t, stats[0].Savings, 0.0)
+}
@@ -0,0 +1,305 @@
+package websocket
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+const (
+	// updates queued per subscriber: a subscriber that falls further behind is handled by the SlowSubscriberPolicy of the hub, rather
+	// than blocking publishers
+	SubscriberBuffer = 16
+
+	// events retained per topic (and for how long after the last one) for the reconnecting subscribers to catch up
+	DefaultHistorySize = SubscriberBuffer
+	DefaultHistoryTTL  = 5 * time.Minute
+)
+
+// SlowSubscriberPolicy is how the hub handles a subscriber whose queue is full when a message is published.
+type SlowSubscriberPolicy string
+
+const (
+	// DropOldest discards the oldest queued message to make room: the subscriber skips stale messages (ie: progress, scoreboards).
+	DropOldest SlowSubscriberPolicy = "drop_oldest"
+	// Disconnect closes the subscription: the client reconnects and catches up from its last event id.
+	Disconnect SlowSubscriberPolicy = "disconnect"
+)
+
+type HubOptions struct {
+	QueueSize   int
+	Policy      SlowSubscriberPolicy
+	HistorySize int
+	HistoryTTL  time.Duration
+}
+
+var DefaultHubOptions = HubOptions{
+	QueueSize:   SubscriberBuffer,
+	Policy:      DropOldest,
+	HistorySize: DefaultHistorySize,
+	HistoryTTL:  DefaultHistoryTTL,
+}
+
+// Hub fans out the messages published on a topic to its subscribers (ie: the websocket connections following it). Each message is
+// given an event id, increasing per topic: the last messages of a topic are retained for a while, so subscribers resuming from their
+// last event id receive the ones they missed. Event ids are only known to this process (the subscribers resuming elsewhere don't
+// catch up).
+type Hub[T any] struct {
+	Options HubOptions
+
+	// StampEventID, when set, records the event id in the message (ie: for the clients to resume from it)
+	StampEventID func(msg T, eventID uint64) T
+
+	mu         sync.RWMutex
+	topics     map[string]*topic[T]
+	lastPruned time.Time
+}
+
+type topic[T any] struct {
+	lastEventID   uint64
+	lastPublished time.Time
+	history       []event[T]
+	subscribers   map[*subscriber[T]]struct{}
+}
+
+type event[T any] struct {
+	id  uint64
+	msg T
+}
+
+type subscriber[T any] struct {
+	ch           chan T
+	since        time.Time
+	dropped      uint64
+	disconnected bool
+	closed       bool
+}
+
+// SubscriberStats is a snapshot of the queue of a subscriber (ie: a websocket connection).
+type SubscriberStats struct {
+	Topic    string    `json:"topic"`
+	Since    time.Time `json:"since"`
+	Queued   int       `json:"queued"`  // messages published and not yet taken by the connection (its lag, in messages)
+	Dropped  uint64    `json:"dropped"` // messages discarded by the DropOldest policy
+	Capacity int       `json:"capacity"`
+}
+
+func NewHub[T any]() *Hub[T] {
+	return NewHubWithOptions[T](DefaultHubOptions)
+}
+
+func NewHubWithOptions[T any](options HubOptions) *Hub[T] {
+	if options.QueueSize <= 0 {
+		options.QueueSize = SubscriberBuffer
+	}
+
+	if options.Policy == "" {
+		options.Policy = DropOldest
+	}
+
+	return &Hub[T]{
+		Options: options,
+		topics:  make(map[string]*topic[T]),
+	}
+}
+
+// Subscribe returns the messages published on the topic until cancel is called (the channel is then closed).
+func (h *Hub[T]) Subscribe(topic string) (<-chan T, func()) {
+	return h.Resume(topic, 0)
+}
+
+// Resume subscribes to the topic, first delivering the retained messages published after lastEventID (none when 0). The channel is
+// closed when cancel is called, or when the subscriber is disconnected for being too slow (see Disconnect).
+func (h *Hub[T]) Resume(name string, lastEventID uint64) (<-chan T, func()) {
+	sub := &subscriber[T]{ch: make(chan T, h.Options.QueueSize), since: time.Now()}
+
+	h.mu.Lock()
+	h.prune()
+
+	t := h.topics[name]
+	if t == nil {
+		t = &topic[T]{subscribers: make(map[*subscriber[T]]struct{})}
+		h.topics[name] = t
+	}
+
+	t.subscribers[sub] = struct{}{}
+
+	if lastEventID > 0 {
+		for _, e := range t.history {
+			if e.id > lastEventID {
+				h.enqueue(sub, e.msg)
+			}
+		}
+
+		if sub.disconnected {
+			h.remove(name, t, sub)
+		}
+	}
+
+	h.mu.Unlock()
+
+	var once sync.Once
+
+	return sub.ch, func() {
+		once.Do(func() {
+			h.mu.Lock()
+			defer h.mu.Unlock()
+
+			h.remove(name, t, sub)
+		})
+	}
+}
+
+// Publish delivers the message to the topic subscribers, returning how many received it.
+func (h *Hub[T]) Publish(name string, msg T) int {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.prune()
+
+	t := h.topics[name]
+	if t == nil {
+		if h.Options.HistorySize <= 0 {
+			return 0
+		}
+
+		// retained for the subscribers resuming later
+		t = &topic[T]{subscribers: make(map[*subscriber[T]]struct{})}
+		h.topics[name] = t
+	}
+
+	t.lastEventID++
+	t.lastPublished = time.Now()
+
+	if h.StampEventID != nil {
+		msg = h.StampEventID(msg, t.lastEventID)
+	}
+
+	if h.Options.HistorySize > 0 {
+		t.history = append(t.history, event[T]{id: t.lastEventID, msg: msg})
+		if len(t.history) > h.Options.HistorySize {
+			t.history = t.history[len(t.history)-h.Options.HistorySize:]
+		}
+	}
+
+	delivered := 0
+
+	for sub := range t.subscribers {
+		if h.enqueue(sub, msg) {
+			delivered++
+		}
+	}
+
+	// disconnected subscribers are removed once the iteration is over
+	for sub := range t.subscribers {
+		if sub.disconnected {
+			h.remove(name, t, sub)
+		}
+	}
+
+	return delivered
+}
+
+// enqueue queues the message, applying the policy of the hub when the queue of the subscriber is full.
+func (h *Hub[T]) enqueue(sub *subscriber[T], msg T) bool {
+	if sub.disconnected {
+		return false
+	}
+
+	select {
+	case sub.ch <- msg:
+		return true
+	default:
+	}
+
+	if h.Options.Policy == Disconnect {
+		sub.disconnected = true
+		return false
+	}
+
+	// only the hub sends (under its lock): once the oldest is taken out, there's room for the message
+	select {
+	case <-sub.ch:
+		sub.dropped++
+	default:
+	}
+
+	select {
+	case sub.ch <- msg:
+		return true
+	default:
+		return false
+	}
+}
+
+func (h *Hub[T]) remove(name string, t *topic[T], sub *subscriber[T]) {
+	if sub.closed {
+		return
+	}
+
+	sub.closed = true
+	close(sub.ch)
+
+	delete(t.subscribers, sub)
+
+	if len(t.subscribers) == 0 && (len(t.history) == 0 || h.Options.HistoryTTL <= 0) && h.topics[name] == t {
+		delete(h.topics, name)
+	}
+}
+
+// prune removes the topics without subscribers whose last message is older than the history TTL (at most once a minute).
+func (h *Hub[T]) prune() {
+	now := time.Now()
+	if now.Sub(h.lastPruned) < time.Minute {
+		return
+	}
+
+	h.lastPruned = now
+
+	for name, t := range h.topics {
+		if len(t.subscribers) == 0 && now.Sub(t.lastPublished) > h.Options.HistoryTTL {
+			delete(h.topics, name)
+		}
+	}
+}
+
+func (h *Hub[T]) Subscribers(name string) int {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	if t := h.topics[name]; t != nil {
+		return len(t.subscribers)
+	}
+
+	return 0
+}
+
+// Stats returns the queue of every subscriber, the most lagging first.
+func (h *Hub[T]) Stats() []SubscriberStats {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	stats := make([]SubscriberStats, 0)
+
+	for name, t := range h.topics {
+		for sub := range t.subscribers {
+			stats = append(stats, SubscriberStats{
+				Topic:    name,
+				Since:    sub.since,
+				Queued:   len(sub.ch),
+				Dropped:  sub.dropped,
+				Capacity: cap(sub.ch),
+			})
+		}
+	}
+
+	sort.Slice(stats, func(i, j int) bool {
+		if stats[i].Queued != stats[j].Queued {
+			return stats[i].Queued > stats[j].Queued
+		}
+
+		return stats[i].Dropped > stats[j].Dropped
+	})
+
+	return stats
+}
@@ -0,0 +1,135 @@
+package websocket_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	replay_entity "github.com/psavelis/team-pro/replay-api/pkg/domain/replay/entities"
+	"github.com/psavelis/team-pro/replay-api/pkg/infra/websocket"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHub_Publish(t *testing.T) {
+	hub := websocket.NewHub[int]()
+
+	first, cancelFirst := hub.Subscribe("a")
+	second, cancelSecond := hub.Subscribe("a")
+	other, cancelOther := hub.Subscribe("b")
+
+	defer cancelOther()
+
+	assert.Equal(t, 2, hub.Subscribers("a"))
+	assert.Equal(t, 2, hub.Publish("a", 1))
+	assert.Equal(t, 1, <-first)
+	assert.Equal(t, 1, <-second)
+	assert.Empty(t, other)
+
+	// cancelled subscribers are closed and no longer receive
+	cancelFirst()
+	cancelFirst()
+
+	_, ok := <-first
+	assert.False(t, ok)
+	assert.Equal(t, 1, hub.Publish("a", 2))
+	assert.Equal(t, 2, <-second)
+
+	cancelSecond()
+	assert.Equal(t, 0, hub.Subscribers("a"))
+	assert.Equal(t, 0, hub.Publish("a", 3))
+}
+
+func TestHub_PublishSlowSubscriber(t *testing.T) {
+	hub := websocket.NewHub[int]()
+
+	updates, cancel := hub.Subscribe("a")
+	defer cancel()
+
+	// a full subscriber skips the oldest updates instead of blocking the publisher
+	for i := 0; i <= websocket.SubscriberBuffer; i++ {
+		assert.Equal(t, 1, hub.Publish("a", i))
+	}
+
+	assert.Equal(t, 1, <-updates)
+	assert.Len(t, updates, websocket.SubscriberBuffer-1)
+
+	stats := hub.Stats()
+	assert.Len(t, stats, 1)
+	assert.Equal(t, "a", stats[0].Topic)
+	assert.Equal(t, websocket.SubscriberBuffer-1, stats[0].Queued)
+	assert.Equal(t, uint64(1), stats[0].Dropped)
+	assert.Equal(t, websocket.SubscriberBuffer, stats[0].Capacity)
+}
+
+func TestHub_PublishSlowSubscriber_Disconnect(t *testing.T) {
+	hub := websocket.NewHubWithOptions[int](websocket.HubOptions{QueueSize: 2, Policy: websocket.Disconnect})
+
+	slow, cancelSlow := hub.Subscribe("a")
+	defer cancelSlow()
+
+	assert.Equal(t, 1, hub.Publish("a", 1))
+	assert.Equal(t, 1, hub.Publish("a", 2))
+
+	fast, cancelFast := hub.Subscribe("a")
+	defer cancelFast()
+
+	// the slow subscriber is disconnected, the others still receive
+	assert.Equal(t, 1, hub.Publish("a", 3))
+	assert.Equal(t, 1, hub.Subscribers("a"))
+	assert.Equal(t, 3, <-fast)
+
+	assert.Equal(t, 1, <-slow)
+	assert.Equal(t, 2, <-slow)
+
+	_, ok := <-slow
+	assert.False(t, ok)
+}
+
+func TestHub_Resume(t *testing.T) {
+	hub := websocket.NewHubWithOptions[int](websocket.HubOptions{HistorySize: 3, HistoryTTL: time.Minute})
+	hub.StampEventID = func(msg int, eventID uint64) int { return msg*100 + int(eventID) }
+
+	// retained without subscribers
+	for i := 1; i <= 4; i++ {
+		assert.Equal(t, 0, hub.Publish("a", i))
+	}
+
+	// only the retained events after the last one received (2) are delivered
+	updates, cancel := hub.Resume("a", 2)
+	defer cancel()
+
+	assert.Equal(t, 303, <-updates)
+	assert.Equal(t, 404, <-updates)
+	assert.Empty(t, updates)
+
+	assert.Equal(t, 1, hub.Publish("a", 5))
+	assert.Equal(t, 505, <-updates)
+
+	// new subscribers don't receive the history
+	other, cancelOther := hub.Subscribe("a")
+	defer cancelOther()
+
+	assert.Empty(t, other)
+}
+
+func TestReplayProgressHub(t *testing.T) {
+	hub := websocket.NewReplayProgressHub()
+
+	replayFileID := uuid.New()
+
+	updates, cancel := hub.Subscribe(context.Background(), replayFileID, 0)
+	defer cancel()
+
+	err := hub.PublishProgress(context.Background(), replay_entity.ReplayProcessingProgress{ReplayFileID: uuid.New(), Percent: 10})
+	assert.NoError(t, err)
+
+	err = hub.PublishProgress(context.Background(), replay_entity.ReplayProcessingProgress{ReplayFileID: replayFileID, Percent: 20})
+	assert.NoError(t, err)
+
+	progress := <-updates
+	assert.Equal(t, replayFileID, progress.ReplayFileID)
+	assert.Equal(t, 20.0, progress.Percent)
+	assert.Equal(t, uint64(1), progress.EventID)
+	assert.Empty(t, updates)
+}
@@ -0,0 +1,42 @@
+package websocket
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/psavelis/team-pro/replay-api/pkg/domain/events"
+	replay_entity "github.com/psavelis/team-pro/replay-api/pkg/domain/replay/entities"
+)
+
+// ReplayProgressHub delivers replay processing progress to the clients following the replay file, within this process. Progress
+// published by other processes (ie: replay workers) reaches it through the broker relay. Slow clients skip the oldest updates (only
+// the latest progress matters), and reconnecting clients resume from the event id of the last update received.
+type ReplayProgressHub struct {
+	Hub *Hub[replay_entity.ReplayProcessingProgress]
+}
+
+func NewReplayProgressHub() *ReplayProgressHub {
+	hub := NewHubWithOptions[replay_entity.ReplayProcessingProgress](DefaultHubOptions)
+	hub.StampEventID = func(progress replay_entity.ReplayProcessingProgress, eventID uint64) replay_entity.ReplayProcessingProgress {
+		progress.EventID = eventID
+		return progress
+	}
+
+	return &ReplayProgressHub{
+		Hub: hub,
+	}
+}
+
+func ReplayProgressTopic(replayFileID uuid.UUID) string {
+	return string(events.ReplayProcessingProgress) + "." + replayFileID.String()
+}
+
+func (h *ReplayProgressHub) PublishProgress(ctx context.Context, progress replay_entity.ReplayProcessingProgress) error {
+	h.Hub.Publish(ReplayProgressTopic(progress.ReplayFileID), progress)
+
+	return nil
+}
+
+func (h *ReplayProgressHub) Subscribe(ctx context.Context, replayFileID uuid.UUID, lastEventID uint64) (<-chan replay_entity.ReplayProcessingProgress, func()) {
+	return h.Hub.Resume(ReplayProgressTopic(replayFileID), lastEventID)
+}
@@ -0,0 +1,109 @@
+package websocket
+
+import (
+	"sort"
+	"sync"
+	"sync/atomic"
+)
+
+// PayloadStats counts the messages written per channel (ie: replay.progress) and their size at each step: encoded as JSON, framed
+// for the client (JSON or MessagePack) and on the wire (compressed, with the frame headers). The savings of the binary framing and
+// of the compression are the ratios between them.
+type PayloadStats struct {
+	mu       sync.RWMutex
+	channels map[string]*channelStats
+}
+
+type channelStats struct {
+	messages     atomic.Uint64
+	binary       atomic.Uint64
+	compressed   atomic.Uint64
+	jsonBytes    atomic.Uint64
+	payloadBytes atomic.Uint64
+	wireBytes    atomic.Uint64
+}
+
+// ChannelPayloadStats is a snapshot of the payload sizes of a channel, since the process started.
+type ChannelPayloadStats struct {
+	Channel            string  `json:"channel"`
+	Messages           uint64  `json:"messages"`
+	BinaryMessages     uint64  `json:"binary_messages"`     // framed as MessagePack
+	CompressedMessages uint64  `json:"compressed_messages"` // written with permessage-deflate
+	JSONBytes          uint64  `json:"json_bytes"`
+	PayloadBytes       uint64  `json:"payload_bytes"`
+	WireBytes          uint64  `json:"wire_bytes"`
+	Savings            float64 `json:"savings"` // share (0..1) of the JSON bytes not sent over the wire
+}
+
+func NewPayloadStats() *PayloadStats {
+	return &PayloadStats{
+		channels: make(map[string]*channelStats),
+	}
+}
+
+func (s *PayloadStats) channel(name string) *channelStats {
+	s.mu.RLock()
+	stats, ok := s.channels[name]
+	s.mu.RUnlock()
+
+	if ok {
+		return stats
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if stats, ok = s.channels[name]; !ok {
+		stats = &channelStats{}
+		s.channels[name] = stats
+	}
+
+	return stats
+}
+
+// Record counts a message written to a client of the channel.
+func (s *PayloadStats) Record(channel string, binary bool, compressed bool, jsonBytes int, payloadBytes int, wireBytes int) {
+	stats := s.channel(channel)
+
+	stats.messages.Add(1)
+	stats.jsonBytes.Add(uint64(jsonBytes))
+	stats.payloadBytes.Add(uint64(payloadBytes))
+	stats.wireBytes.Add(uint64(wireBytes))
+
+	if binary {
+		stats.binary.Add(1)
+	}
+
+	if compressed {
+		stats.compressed.Add(1)
+	}
+}
+
+// Snapshot returns the stats of every channel, by name.
+func (s *PayloadStats) Snapshot() []ChannelPayloadStats {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	snapshot := make([]ChannelPayloadStats, 0, len(s.channels))
+	for name, stats := range s.channels {
+		channel := ChannelPayloadStats{
+			Channel:            name,
+			Messages:           stats.messages.Load(),
+			BinaryMessages:     stats.binary.Load(),
+			CompressedMessages: stats.compressed.Load(),
+			JSONBytes:          stats.jsonBytes.Load(),
+			PayloadBytes:       stats.payloadBytes.Load(),
+			WireBytes:          stats.wireBytes.Load(),
+		}
+
+		if channel.JSONBytes > 0 {
+			channel.Savings = 1 - float64(channel.WireBytes)/float64(channel.JSONBytes)
+		}
+
+		snapshot = append(snapshot, channel)
+	}
+
+	sort.Slice(snapshot, func(i, j int) bool { return snapshot[i].Channel < snapshot[j].Channel })
+
+	return snapshot
+}